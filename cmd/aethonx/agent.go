@@ -0,0 +1,161 @@
+// cmd/aethonx/agent.go
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"aethonx/internal/adapters/agent"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/core/usecases"
+	"aethonx/internal/platform/config"
+	"aethonx/internal/platform/logx"
+
+	"github.com/spf13/pflag"
+)
+
+const agentUsage = `Usage: aethonx agent --cert <file> --key <file> [options]
+
+Run a remote worker for distributed scans. A coordinator (aethonx -t <domain>
+--agent https://<host>:<port>) assigns it sources and receives their artifacts,
+so those sources query the target from this host's IP and region.
+
+Options:
+  --listen <addr>          Listen address (default: :7443)
+  --cert <file>            Agent TLS certificate (PEM)
+  --key <file>             Agent TLS key (PEM)
+  --client-ca <file>       Require coordinator certificates signed by this CA (mutual TLS)
+  --config <file>          YAML config: sources enabled here are offered to coordinators
+  --id <name>              Agent name shown to coordinators (default: hostname)
+  --region <name>          Region/egress label shown to coordinators
+  --max-concurrent <n>     Sources run at once; the rest wait (default: 4)
+  -a, --active             Allow the active phase of hybrid sources (as aethonx -a)
+  --upstream-rate <u>      Shared budget per upstream across runs: <upstream>=<rps>[/<burst>]
+
+Set AETHONX_AGENT_TOKEN to require a bearer token (same value on the coordinator).
+The agent refuses to start without --client-ca or AETHONX_AGENT_TOKEN.
+`
+
+// runAgent implements "aethonx agent".
+func runAgent(args []string) int {
+	fs := pflag.NewFlagSet("agent", pflag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, agentUsage) }
+	listen := fs.String("listen", ":7443", "Listen address")
+	certFile := fs.String("cert", "", "Agent TLS certificate (PEM)")
+	keyFile := fs.String("key", "", "Agent TLS key (PEM)")
+	clientCA := fs.String("client-ca", "", "CA for coordinator certificates (mutual TLS)")
+	configPath := fs.String("config", "", "YAML config file (default: AETHONX_CONFIG)")
+	id := fs.String("id", "", "Agent name (default: hostname)")
+	region := fs.String("region", "", "Region/egress label")
+	maxConcurrent := fs.Int("max-concurrent", 4, "Sources run at once")
+	active := fs.BoolP("active", "a", false, "Allow the active phase of hybrid sources")
+	upstreamRates := fs.StringSlice("upstream-rate", nil, "Shared budget per upstream: <upstream>=<rps>[/<burst>]")
+	if err := fs.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Without client authentication the agent would run sources for anyone
+	// who can reach it
+	token := os.Getenv("AETHONX_AGENT_TOKEN")
+	if *clientCA == "" && token == "" {
+		fmt.Fprintln(os.Stderr, "Error: the agent requires --client-ca or AETHONX_AGENT_TOKEN")
+		return 2
+	}
+
+	tlsConfig, err := agent.ServerTLSConfig(*certFile, *keyFile, *clientCA)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	cfg, err := config.FromFile(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if len(*upstreamRates) > 0 {
+		cfg.Network.UpstreamRates = *upstreamRates
+	}
+	// Runs for concurrent coordinators share the per-upstream budgets
+	if err := configureUpstreamRates(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	for name, sourceConfig := range cfg.Source.Sources {
+		if sourceConfig.Custom == nil {
+			sourceConfig.Custom = make(map[string]interface{})
+		}
+		sourceConfig.Custom["active_mode"] = *active
+		cfg.Source.Sources[name] = sourceConfig
+	}
+
+	if *id == "" {
+		*id, _ = os.Hostname()
+	}
+
+	logger := logx.New()
+	sources, err := buildSourcesWithResilience(logger, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer func() {
+		for _, src := range sources {
+			src.Close()
+		}
+	}()
+
+	srv := agent.NewServer(agent.ServerOptions{
+		Addr:          *listen,
+		ID:            *id,
+		Region:        *region,
+		Version:       version,
+		Sources:       sources,
+		MaxConcurrent: *maxConcurrent,
+		Token:         token,
+		TLS:           tlsConfig,
+		Logger:        logger,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := srv.ListenAndServe(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// newAgentScheduler connects to the agents of a distributed scan (nil when
+// no agent is configured).
+func newAgentScheduler(cfg config.Config, logger logx.Logger) (*usecases.AgentScheduler, error) {
+	if len(cfg.Agents.URLs) == 0 {
+		return nil, nil
+	}
+
+	tlsConfig, err := agent.ClientTLSConfig(cfg.Agents.CAFile, cfg.Agents.CertFile, cfg.Agents.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	agents := make([]ports.Agent, 0, len(cfg.Agents.URLs))
+	for _, url := range cfg.Agents.URLs {
+		client, err := agent.NewClient(url, tlsConfig, cfg.Agents.Token)
+		if err != nil {
+			return nil, err
+		}
+		agents = append(agents, client)
+	}
+
+	logger.Info("distributed scan", "agents", len(agents))
+	return usecases.NewAgentScheduler(agents, logger), nil
+}
@@ -0,0 +1,116 @@
+// cmd/aethonx/artifacts.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"aethonx/internal/adapters/output"
+	"aethonx/internal/core/domain"
+
+	"github.com/spf13/pflag"
+)
+
+// runArtifacts implements "aethonx artifacts": filter, sort and format the
+// artifacts of a consolidated scan without external tools.
+func runArtifacts(args []string) int {
+	fs := pflag.NewFlagSet("artifacts", pflag.ContinueOnError)
+	scanPath := fs.String("scan", "", "Consolidated scan JSON or sharded scan directory (index.json) to read (required)")
+	types := fs.StringSlice("type", nil, "Only these artifact types (repeatable, e.g. subdomain,url)")
+	tags := fs.StringSlice("tag", nil, "Only artifacts with any of these tags (repeatable)")
+	sources := fs.StringSlice("source", nil, "Only artifacts found by any of these sources (repeatable)")
+	alive := fs.Bool("alive", false, "Only artifacts that answered a probe")
+	minConfidence := fs.Float64("min-confidence", 0, "Minimum confidence (0-1)")
+	match := fs.String("match", "", "Only artifacts whose value contains this substring (case-insensitive)")
+	sortBy := fs.String("sort", "value", "Sort key: value, type, confidence, sources, discovered (prefix - for descending)")
+	format := fs.String("format", "table", "Output format: "+strings.Join(output.ListingFormats, ", "))
+	limit := fs.Int("limit", 0, "Maximum artifacts to print (0 = all)")
+	redact := fs.String("redact", "full", "Redaction profile: full, client-safe")
+
+	if err := fs.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if *scanPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: --scan is required")
+		return 2
+	}
+
+	filter := domain.ArtifactFilter{
+		Tags:          *tags,
+		OnlyAlive:     *alive,
+		MinConfidence: *minConfidence,
+	}
+	for _, name := range *types {
+		t := domain.ArtifactType(strings.ToLower(strings.TrimSpace(name)))
+		if !t.IsValid() {
+			fmt.Fprintf(os.Stderr, "Error: unknown artifact type in --type: %q\n", name)
+			return 2
+		}
+		filter.Types = append(filter.Types, t)
+	}
+
+	sortKey, desc, err := domain.ParseArtifactSortKey(*sortBy)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	profile, err := domain.ParseRedactionProfile(*redact)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	// Of a sharded scan only the shards of the --type filter are read
+	result, err := output.ReadScanTypes(*scanPath, filter.Types)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	needle := strings.ToLower(*match)
+	selected := make([]*domain.Artifact, 0, len(result.Artifacts))
+	for _, a := range result.Artifacts {
+		if !filter.Match(a) || !foundByAny(a, *sources) {
+			continue
+		}
+		if needle != "" && !strings.Contains(strings.ToLower(a.Value), needle) {
+			continue
+		}
+		selected = append(selected, a)
+	}
+
+	domain.SortArtifacts(selected, sortKey, desc)
+	if *limit > 0 && len(selected) > *limit {
+		selected = selected[:*limit]
+	}
+
+	selected = domain.RedactArtifacts(selected, profile)
+
+	if err := output.WriteArtifactList(os.Stdout, selected, *format); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// foundByAny reports whether the artifact was discovered by any of sources
+// (an empty list matches everything).
+func foundByAny(a *domain.Artifact, sources []string) bool {
+	if len(sources) == 0 {
+		return true
+	}
+	for _, want := range sources {
+		for _, have := range a.Sources {
+			if strings.EqualFold(have, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
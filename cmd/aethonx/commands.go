@@ -0,0 +1,23 @@
+// cmd/aethonx/commands.go
+package main
+
+// subcommand is an entry point for "aethonx <name> [args]".
+// It receives the remaining arguments and returns the process exit code.
+type subcommand func(args []string) int
+
+// subcommands maps subcommand names to their handlers.
+// Anything not listed here falls through to the default scan command.
+var subcommands = map[string]subcommand{
+	"serve":     runServe,
+	"graph":     runGraph,
+	"artifacts": runArtifacts,
+	"workspace": runWorkspace,
+	"verify":    runVerify,
+	"config":    runConfig,
+	"sources":   runSources,
+	"doctor":    runDoctor,
+	"deps":      runDeps,
+	"agent":     runAgent,
+	"init":      runInit,
+	"replay":    runReplay,
+}
@@ -0,0 +1,152 @@
+// cmd/aethonx/config.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"aethonx/internal/platform/config"
+	"aethonx/internal/platform/registry"
+
+	"github.com/spf13/pflag"
+)
+
+const configUsage = `Usage: aethonx config <command> [options]
+
+Commands:
+  validate                 Check the configuration before scanning: source options
+                           against each source's schema, CLI binaries, API keys,
+                           output filters, redaction, signing and encryption
+
+Options:
+  --config <file>          YAML config file (default: AETHONX_CONFIG)
+
+Configuration is read like a scan would: defaults, config file, then AETHONX_* env.
+`
+
+// globalSource labels problems that are not tied to a source.
+const globalSource = "(global)"
+
+// runConfig implements "aethonx config validate".
+// Exit codes: 0 valid (warnings allowed), 1 errors found, 2 usage error.
+func runConfig(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(os.Stderr, configUsage)
+		return 2
+	}
+	if args[0] != "validate" {
+		fmt.Fprintf(os.Stderr, "Error: unknown config command %q\n\n%s", args[0], configUsage)
+		return 2
+	}
+
+	fs := pflag.NewFlagSet("config validate", pflag.ContinueOnError)
+	configPath := fs.String("config", "", "YAML config file (default: AETHONX_CONFIG)")
+	if err := fs.Parse(args[1:]); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	cfg, err := config.FromFile(*configPath)
+	if err != nil {
+		printProblems([]registry.ConfigProblem{{
+			Source:   globalSource,
+			Severity: registry.SeverityError,
+			Message:  err.Error(),
+			Hint:     "fix the config file; unknown fields are rejected",
+		}})
+		return 1
+	}
+
+	problems := validateGlobal(cfg)
+	problems = append(problems, registry.Global().ValidateConfigs(cfg.Source.Sources)...)
+
+	printProblems(problems)
+	for _, p := range problems {
+		if p.Severity == registry.SeverityError {
+			return 1
+		}
+	}
+	return 0
+}
+
+// validateGlobal runs the checks main performs before a scan (exit 2 there),
+// collecting every failure instead of stopping at the first.
+func validateGlobal(cfg config.Config) []registry.ConfigProblem {
+	var problems []registry.ConfigProblem
+	add := func(option string, err error, hint string) {
+		if err != nil {
+			problems = append(problems, registry.ConfigProblem{
+				Source:   globalSource,
+				Key:      option,
+				Severity: registry.SeverityError,
+				Message:  err.Error(),
+				Hint:     hint,
+			})
+		}
+	}
+
+	_, err := cfg.NormalizationPolicy()
+	add("normalization", err, "use strict or aggressive")
+	_, err = cfg.OutputFilter()
+	add("output filters", err, "check --only-types, --min-confidence and --tag")
+	_, err = loadOutputProtection(cfg)
+	add("output protection", err, "check --redact, --sign-key, --encrypt and --encrypt-to")
+	if cfg.Tagging.RulesFile != "" {
+		_, err = config.LoadTagRules(cfg.Tagging.RulesFile)
+		add("tag rules", err, "fix or unset AETHONX_TAG_RULES")
+	}
+
+	enabled := 0
+	for _, sc := range cfg.Source.Sources {
+		if sc.Enabled {
+			enabled++
+		}
+	}
+	if enabled == 0 {
+		problems = append(problems, registry.ConfigProblem{
+			Source:   globalSource,
+			Key:      "sources",
+			Severity: registry.SeverityWarning,
+			Message:  "no sources enabled",
+			Hint:     "enable at least one source (sources.<name>.enabled: true)",
+		})
+	}
+
+	return problems
+}
+
+func printProblems(problems []registry.ConfigProblem) {
+	if len(problems) == 0 {
+		fmt.Println("Configuration OK")
+		return
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Severity == registry.SeverityError && problems[j].Severity != registry.SeverityError
+	})
+
+	errs := 0
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SEVERITY\tSOURCE\tOPTION\tPROBLEM\tHINT")
+	for _, p := range problems {
+		if p.Severity == registry.SeverityError {
+			errs++
+		}
+		key := p.Key
+		if key == "" {
+			key = "-"
+		}
+		// YAML errors span several lines; keep one row per problem
+		message := strings.Join(strings.Fields(p.Message), " ")
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.Severity, p.Source, key, message, p.Hint)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d error(s), %d warning(s)\n", errs, len(problems)-errs)
+}
@@ -0,0 +1,239 @@
+// cmd/aethonx/deps.go
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"aethonx/internal/platform/config"
+	"aethonx/internal/platform/installer"
+	"aethonx/internal/platform/registry"
+
+	"github.com/spf13/pflag"
+)
+
+const depsUsage = `Usage: aethonx deps <command> [options]
+
+Commands:
+  check                    Show which source binaries are installed
+  install                  Install missing binaries (GitHub releases or pip)
+  update                   Install missing binaries and upgrade all to their latest release
+  bundle create|install    Offline installation (see aethonx deps bundle --help)
+
+Options:
+  --config <file>          YAML config file (default: AETHONX_CONFIG)
+  --all                    Include disabled sources
+  --dir <path>             Installation directory (default: $HOME/go/bin)
+  --force                  Reinstall even if already installed (install, update)
+  --upgrade                Upgrade binaries older than the sources require (install)
+  -q, --quiet              Minimal output
+
+The binaries come from the enabled sources' metadata (see aethonx sources),
+including the minimum versions their options need (e.g., httpx profile=headless).
+Downloads are verified against the release SHA256 checksums (and the checksum
+file's cosign signature when declared and cosign is installed); a mismatch
+aborts the installation. Versions and verification are recorded in
+~/.aethonx/tools.json and shown by check.
+`
+
+// runDeps implements "aethonx deps check|install|update|bundle".
+// Exit codes: 0 ok, 1 check found missing tools or installation failed, 2 usage error.
+func runDeps(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(os.Stderr, depsUsage)
+		return 2
+	}
+	switch command := args[0]; command {
+	case "check", "install", "update":
+		return runDepsTools(command, args[1:])
+	case "bundle":
+		return runDepsBundle(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown deps command %q\n\n%s", command, depsUsage)
+		return 2
+	}
+}
+
+// runDepsTools checks or installs the tools of the configured sources.
+// command is check, install, update or "bundle install" (install from the
+// bundle given as argument instead of downloading).
+func runDepsTools(command string, args []string) int {
+	fromBundle := command == "bundle install"
+
+	fs := pflag.NewFlagSet("deps "+command, pflag.ContinueOnError)
+	configPath := fs.String("config", "", "YAML config file (default: AETHONX_CONFIG)")
+	all := fs.Bool("all", false, "Include disabled sources")
+	installDir := fs.String("dir", "", "Installation directory (default: $HOME/go/bin)")
+	force := fs.Bool("force", false, "Reinstall even if already installed")
+	upgrade := fs.Bool("upgrade", false, "Upgrade binaries older than the sources require")
+	quiet := fs.BoolP("quiet", "q", false, "Minimal output")
+	var goModCache *string
+	if fromBundle {
+		goModCache = fs.String("gomodcache", "", "Where to copy bundled Go modules (default: go env GOMODCACHE)")
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	var bundle *installer.Bundle
+	if fromBundle {
+		if fs.NArg() != 1 {
+			fmt.Fprintf(os.Stderr, "Error: deps bundle install needs the bundle file\n\n%s", depsBundleUsage)
+			return 2
+		}
+		var err error
+		if bundle, err = installer.OpenBundle(fs.Arg(0)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer bundle.Close()
+
+		if bundle.Manifest.GoModules {
+			if err := installBundleGoModules(bundle, *goModCache, *quiet); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return 1
+			}
+		}
+	}
+
+	cfg, err := config.FromFile(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	tools := installer.ToolsFor(registry.Global().GetAllMetadata(), cfg.Source.Sources, *all)
+	if len(tools) == 0 {
+		if !*quiet {
+			fmt.Println("No enabled source needs an external binary")
+		}
+		return 0
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	orch := installer.NewOrchestrator(installer.Config{ExternalTools: tools, AddToPath: true}, *installDir)
+	orch.SetCheckUpdates(command == "update")
+	orch.SetUpgrade(*upgrade || command == "update")
+	recordPath, records := loadToolRecords()
+	orch.SetRecords(records)
+	if bundle != nil {
+		orch.SetBundle(bundle)
+	}
+	if err := orch.Initialize(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	presenter := installer.NewSimplePresenter(*quiet)
+	presenter.ShowHeader()
+	orch.SetProgressCallback(presenter.ShowProgress)
+
+	var results []installer.InstallationResult
+	var code int
+	if command == "check" {
+		results, code = checkDeps(ctx, orch, presenter)
+	} else {
+		results, code = installDeps(ctx, orch, presenter, *force)
+	}
+
+	// ~/.aethonx/tools.json lets scans check versions without running every
+	// binary, and check report how each binary was verified
+	if recordPath != "" {
+		records.Record(tools, results, time.Now())
+		if err := records.Save(recordPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+	return code
+}
+
+// loadToolRecords reads ~/.aethonx/tools.json. Failures only warn; path is
+// "" when the records cannot be located.
+func loadToolRecords() (string, installer.ToolRecords) {
+	path, err := installer.DefaultRecordPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return "", make(installer.ToolRecords)
+	}
+	records, err := installer.LoadRecords(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return path, make(installer.ToolRecords)
+	}
+	return path, records
+}
+
+// checkDeps reports the status of each tool; missing or outdated tools fail
+// the check.
+func checkDeps(ctx context.Context, orch *installer.Orchestrator, presenter *installer.SimplePresenter) ([]installer.InstallationResult, int) {
+	results, err := orch.Check(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: dependency check failed: %v\n", err)
+		return nil, 1
+	}
+
+	presenter.ShowCheckResults(results)
+
+	for _, r := range results {
+		if r.Status != installer.StatusAlreadyInstalled {
+			return results, 1
+		}
+	}
+	return results, 0
+}
+
+// installDeps installs missing tools (and upgrades outdated ones when the
+// orchestrator is configured to), then reports a summary. Tools left
+// outdated fail the command.
+func installDeps(ctx context.Context, orch *installer.Orchestrator, presenter *installer.SimplePresenter, force bool) ([]installer.InstallationResult, int) {
+	start := time.Now()
+
+	preResults, err := orch.Check(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: pre-installation check failed: %v\n", err)
+		return nil, 1
+	}
+	presenter.ShowPreCheck(preResults, force)
+
+	toInstall := 0
+	for _, r := range preResults {
+		if force || r.Status != installer.StatusAlreadyInstalled {
+			toInstall++
+		}
+	}
+	presenter.StartInstallation(toInstall)
+
+	results, err := orch.Install(ctx, force)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: installation failed: %v\n", err)
+		return nil, 1
+	}
+
+	fmt.Println()
+	for _, r := range results {
+		presenter.ShowResult(r)
+	}
+
+	pathWarning := ""
+	if inPath, _ := orch.CheckPath(); !inPath {
+		pathWarning = orch.GetPathWarning()
+	}
+	presenter.ShowSummary(results, time.Since(start), pathWarning)
+
+	for _, r := range results {
+		if r.Status == installer.StatusFailed || r.Status == installer.StatusOutdated {
+			return results, 1
+		}
+	}
+	return results, 0
+}
@@ -0,0 +1,173 @@
+// cmd/aethonx/deps_bundle.go
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"aethonx/internal/platform/config"
+	"aethonx/internal/platform/installer"
+	"aethonx/internal/platform/registry"
+
+	"github.com/spf13/pflag"
+)
+
+const depsBundleUsage = `Usage: aethonx deps bundle <command> [options]
+
+Install source binaries on machines that cannot reach GitHub or PyPI.
+
+Commands:
+  create                   Download and verify the binaries into a tarball (online machine)
+  install <file>           Install the binaries from a tarball (offline machine)
+
+Create options:
+  --config <file>          YAML config file (default: AETHONX_CONFIG)
+  --all                    Include disabled sources
+  --platform <os_arch>     Target platform, e.g. linux_amd64 (repeatable, default: this machine)
+  --go-modules             Include the Go modules to build aethonx (run from its source tree)
+  -o, --output <file>      Bundle file (default: aethonx-deps-<date>.tar.gz)
+  -q, --quiet              Minimal output
+
+Install options:
+  --config, --all, --dir, --force, --upgrade, -q    As in aethonx deps install
+  --gomodcache <dir>       Where to copy bundled Go modules (default: go env GOMODCACHE)
+
+Assets are verified against the release checksums when downloaded and against
+the bundle manifest when installed. pip packages (shodan) are downloaded for
+the Python version and platform of the machine that creates the bundle.
+`
+
+// runDepsBundle implements "aethonx deps bundle create|install".
+func runDepsBundle(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(os.Stderr, depsBundleUsage)
+		return 2
+	}
+	switch command := args[0]; command {
+	case "create":
+		return runBundleCreate(args[1:])
+	case "install":
+		return runDepsTools("bundle install", args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown deps bundle command %q\n\n%s", command, depsBundleUsage)
+		return 2
+	}
+}
+
+func runBundleCreate(args []string) int {
+	fs := pflag.NewFlagSet("deps bundle create", pflag.ContinueOnError)
+	configPath := fs.String("config", "", "YAML config file (default: AETHONX_CONFIG)")
+	all := fs.Bool("all", false, "Include disabled sources")
+	platforms := fs.StringSlice("platform", nil, "Target platform <os>_<arch> (repeatable, default: this machine)")
+	goModules := fs.Bool("go-modules", false, "Include the Go modules to build aethonx (run from its source tree)")
+	output := fs.StringP("output", "o", "aethonx-deps-"+time.Now().Format("20060102")+".tar.gz", "Bundle file")
+	quiet := fs.BoolP("quiet", "q", false, "Minimal output")
+	if err := fs.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	for _, p := range *platforms {
+		if parts := strings.Split(p, "_"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			fmt.Fprintf(os.Stderr, "Error: invalid --platform %q (use <os>_<arch>, e.g. linux_amd64)\n", p)
+			return 2
+		}
+	}
+
+	opts := installer.BundleOptions{Platforms: *platforms}
+	if *goModules {
+		if _, err := os.Stat("go.mod"); err != nil {
+			fmt.Fprintln(os.Stderr, "Error: --go-modules must run from the aethonx source tree (no go.mod here)")
+			return 2
+		}
+		opts.GoModDir = "."
+	}
+
+	cfg, err := config.FromFile(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	tools := installer.ToolsFor(registry.Global().GetAllMetadata(), cfg.Source.Sources, *all)
+	if len(tools) == 0 && !*goModules {
+		fmt.Fprintln(os.Stderr, "No enabled source needs an external binary (use --all to bundle every source)")
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	presenter := installer.NewSimplePresenter(*quiet)
+	opts.Progress = presenter.ShowProgress
+
+	manifest, err := installer.CreateBundle(ctx, tools, *output, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if *quiet {
+		fmt.Println(*output)
+		return 0
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TOOL\tVERSION\tPLATFORMS\tVERIFICATION")
+	for _, t := range manifest.Tools {
+		var tp []string
+		for _, f := range t.Files {
+			if f.Platform != "" {
+				tp = append(tp, f.Platform)
+			}
+		}
+		platformList := strings.Join(tp, ",")
+		if t.Package != "" {
+			platformList = "pip (this machine)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", t.Name, orDash(t.Version), platformList, t.Verification)
+	}
+	w.Flush()
+	if manifest.GoModules {
+		fmt.Println("\nGo modules: included")
+	}
+
+	size := int64(0)
+	if info, err := os.Stat(*output); err == nil {
+		size = info.Size()
+	}
+	fmt.Printf("\nBundle written to %s (%.1f MB)\n", *output, float64(size)/(1<<20))
+	fmt.Printf("On the offline machine run: aethonx deps bundle install %s\n", filepath.Base(*output))
+	return 0
+}
+
+// installBundleGoModules copies the bundled Go modules into the module cache.
+func installBundleGoModules(bundle *installer.Bundle, cacheDir string, quiet bool) error {
+	if cacheDir == "" {
+		out, err := exec.Command("go", "env", "GOMODCACHE").Output()
+		if err != nil {
+			return fmt.Errorf("cannot locate the Go module cache (set --gomodcache): %w", err)
+		}
+		cacheDir = strings.TrimSpace(string(out))
+	}
+
+	if err := bundle.InstallGoModules(cacheDir); err != nil {
+		return err
+	}
+	if !quiet {
+		fmt.Printf("Go modules copied to %s\n", cacheDir)
+		fmt.Printf("Build offline with: GOMODCACHE=%s GOPROXY=off GOFLAGS=-mod=mod make build\n\n", cacheDir)
+	}
+	return nil
+}
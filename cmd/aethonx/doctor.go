@@ -0,0 +1,204 @@
+// cmd/aethonx/doctor.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/core/usecases"
+	"aethonx/internal/platform/config"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+
+	"github.com/spf13/pflag"
+)
+
+// runDoctor implements "aethonx doctor": run each enabled source's
+// Initialize/Validate/HealthCheck, report binary versions and API quota, and
+// make a real call with the lightweight sources.
+// Exit codes: 0 all ready, 1 some source not ready, 2 usage error.
+func runDoctor(args []string) int {
+	fs := pflag.NewFlagSet("doctor", pflag.ContinueOnError)
+	configPath := fs.String("config", "", "YAML config file (default: AETHONX_CONFIG)")
+	only := fs.StringSlice("source", nil, "Only these sources, enabled or not (repeatable)")
+	live := fs.Bool("live", true, "Run passive keyless sources against --live-target")
+	liveTarget := fs.String("live-target", "example.com", "Known domain for the live call")
+	timeout := fs.Duration("timeout", 30*time.Second, "Timeout of each live call")
+	format := fs.String("format", "table", "Output format: table, json")
+
+	if err := fs.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if *format != "table" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use table or json)\n", *format)
+		return 2
+	}
+
+	var target *domain.Target
+	if *live {
+		target = domain.NewTarget(*liveTarget, domain.ScanModePassive)
+		if err := target.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --live-target: %v\n", err)
+			return 2
+		}
+	}
+
+	cfg, err := config.FromFile(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	names, err := doctorSources(cfg, *only)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if len(names) == 0 {
+		fmt.Fprintln(os.Stderr, "No sources enabled")
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	reg := registry.Global()
+	logger := logx.NewSilent()
+	results := make([]usecases.SourceHealth, 0, len(names))
+	for _, name := range names {
+		meta, _ := reg.GetMetadata(name)
+		sc := cfg.Source.Sources[name]
+
+		src, err := reg.BuildSource(name, sc, logger)
+		if err != nil {
+			results = append(results, usecases.SourceHealth{
+				Source:     name,
+				Initialize: usecases.CheckResult{Status: usecases.CheckFailed, Error: err.Error()},
+				Validate:   usecases.CheckResult{Status: usecases.CheckSkipped},
+				Health:     usecases.CheckResult{Status: usecases.CheckSkipped},
+				Live:       usecases.CheckResult{Status: usecases.CheckSkipped},
+			})
+			continue
+		}
+
+		opts := usecases.DiagnoseOptions{Config: sc, LiveTimeout: *timeout}
+		if liveEligible(meta) {
+			opts.LiveTarget = target
+		}
+		results = append(results, usecases.DiagnoseSource(ctx, src, opts))
+		src.Close()
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	} else {
+		printHealth(results)
+	}
+
+	for _, h := range results {
+		if !h.Ready() {
+			return 1
+		}
+	}
+	return 0
+}
+
+// doctorSources returns the sources to check: the requested ones, or every
+// enabled source in the configuration.
+func doctorSources(cfg config.Config, only []string) ([]string, error) {
+	reg := registry.Global()
+	if len(only) > 0 {
+		for _, name := range only {
+			if !reg.IsRegistered(name) {
+				return nil, fmt.Errorf("unknown source %q (see aethonx sources)", name)
+			}
+		}
+		names := append([]string(nil), only...)
+		sort.Strings(names)
+		return names, nil
+	}
+
+	var names []string
+	for name, sc := range cfg.Source.Sources {
+		if sc.Enabled && reg.IsRegistered(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// liveEligible reports whether a source is cheap enough for a live call:
+// passive, keyless and in-process (no CLI binary, no quota spent).
+func liveEligible(meta ports.SourceMetadata) bool {
+	return meta.Mode == domain.SourceModePassive &&
+		meta.Type != domain.SourceTypeCLI &&
+		!meta.RequiresAuth
+}
+
+func printHealth(results []usecases.SourceHealth) {
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SOURCE\tINIT\tVALIDATE\tHEALTH\tLIVE\tVERSION\tQUOTA\tSTATUS")
+	for _, h := range results {
+		status := "ready"
+		if !h.Ready() {
+			status = "NOT READY"
+		}
+		live := h.Live.Status
+		if h.Live.Status == usecases.CheckOK {
+			live = fmt.Sprintf("ok (%d, %s)", h.LiveArtifacts, h.Live.Duration.Round(time.Millisecond))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			h.Source, h.Initialize.Status, h.Validate.Status, h.Health.Status, live,
+			orDash(h.Version), orDash(h.Quota), status)
+	}
+	w.Flush()
+
+	var failures []string
+	for _, h := range results {
+		for _, c := range []struct {
+			name   string
+			result usecases.CheckResult
+		}{
+			{"initialize", h.Initialize},
+			{"validate", h.Validate},
+			{"health", h.Health},
+			{"live", h.Live},
+		} {
+			if c.result.Status == usecases.CheckFailed {
+				failures = append(failures, fmt.Sprintf("  %s %s: %s", h.Source, c.name, c.result.Error))
+			}
+		}
+	}
+	if len(failures) > 0 {
+		fmt.Println("\nFailures:")
+		for _, f := range failures {
+			fmt.Println(f)
+		}
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
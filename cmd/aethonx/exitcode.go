@@ -0,0 +1,170 @@
+// cmd/aethonx/exitcode.go
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"aethonx/internal/adapters/output"
+	"aethonx/internal/core/domain"
+)
+
+// Exit codes of a scan (see "EXIT CODES" in --help). Codes 3-6 are only
+// returned for the outcomes selected with --fail-on.
+const (
+	exitOK             = 0 // Clean scan, or no --fail-on outcome matched
+	exitError          = 1 // Scan or output failure
+	exitUsage          = 2 // Invalid configuration or setup failure
+	exitSourceFailures = 3 // Completed, but one or more sources failed
+	exitPartial        = 4 // Partial results: global timeout or interrupted
+	exitNoArtifacts    = 5 // Completed without artifacts
+	exitNewRisks       = 6 // New risky findings since the previous scan
+)
+
+// defaultNewRiskSeverity is the minimum severity for "new-risk" without
+// "=<severity>": missing security headers alone (low) do not fail a build.
+const defaultNewRiskSeverity = "medium"
+
+// failPolicy is the parsed --fail-on: which scan outcomes exit non-zero.
+type failPolicy struct {
+	sourceErrors bool
+	timeout      bool
+	empty        bool
+	newRisk      string // Minimum severity of a new risky finding ("" = disabled)
+}
+
+// parseFailOn parses the --fail-on conditions. "none" disables every
+// outcome (only scan and output failures exit non-zero).
+func parseFailOn(conditions []string) (failPolicy, error) {
+	var p failPolicy
+	for _, condition := range conditions {
+		name, arg, hasArg := strings.Cut(condition, "=")
+		if hasArg && name != "new-risk" {
+			return p, fmt.Errorf("--fail-on %s takes no value", name)
+		}
+		switch name {
+		case "none":
+			if len(conditions) > 1 {
+				return p, fmt.Errorf("--fail-on none cannot be combined with other conditions")
+			}
+		case "source-error":
+			p.sourceErrors = true
+		case "timeout":
+			p.timeout = true
+		case "empty":
+			p.empty = true
+		case "new-risk":
+			p.newRisk = defaultNewRiskSeverity
+			if hasArg {
+				if output.SeverityRank(arg) == 0 {
+					return p, fmt.Errorf("unknown severity in --fail-on new-risk=%s (valid: critical, high, medium, low)", arg)
+				}
+				p.newRisk = arg
+			}
+		default:
+			return p, fmt.Errorf("unknown --fail-on condition %q (valid: source-error, timeout, empty, new-risk[=<severity>], none)", condition)
+		}
+	}
+	return p, nil
+}
+
+// scanExitCode maps the scan outcome to an exit code. ctxErr is the root
+// context error after the scan (deadline or signal = partial results).
+// When several outcomes match, the most actionable wins: new risks, then
+// partial results, source failures and finally an empty result. The
+// returned reason is empty for exitOK.
+func scanExitCode(result *domain.ScanResult, runErr, ctxErr error, policy failPolicy) (int, string) {
+	partial := ctxErr != nil || errors.Is(runErr, context.DeadlineExceeded)
+
+	if runErr != nil || result == nil {
+		if policy.timeout && partial {
+			return exitPartial, "scan interrupted before completion"
+		}
+		return exitError, "scan failed"
+	}
+
+	if policy.newRisk != "" {
+		if n := countNewRisks(result, policy.newRisk); n > 0 {
+			return exitNewRisks, fmt.Sprintf("%d new findings with severity >= %s", n, policy.newRisk)
+		}
+	}
+	if policy.timeout && partial {
+		if errors.Is(ctxErr, context.DeadlineExceeded) {
+			return exitPartial, "global timeout reached, results are partial"
+		}
+		return exitPartial, "scan interrupted, results are partial"
+	}
+	if policy.sourceErrors {
+		if failed := failedSources(result); len(failed) > 0 {
+			return exitSourceFailures, "sources failed: " + strings.Join(failed, ", ")
+		}
+	}
+	if policy.empty && result.TotalArtifacts() == 0 {
+		return exitNoArtifacts, "no artifacts found"
+	}
+	return exitOK, ""
+}
+
+// scanStatus classifies the scan outcome for its manifest: failed when the
+// run errored, partial when it was interrupted or sources failed, complete
+// otherwise.
+func scanStatus(result *domain.ScanResult, runErr, ctxErr error) (string, string) {
+	interrupted := ctxErr != nil || errors.Is(runErr, context.DeadlineExceeded) || errors.Is(runErr, context.Canceled)
+	switch {
+	case interrupted:
+		return output.ManifestStatusPartial, "scan interrupted before completion"
+	case runErr != nil:
+		return output.ManifestStatusFailed, runErr.Error()
+	}
+	if failed := failedSources(result); len(failed) > 0 {
+		return output.ManifestStatusPartial, "sources failed: " + strings.Join(failed, ", ")
+	}
+	return output.ManifestStatusComplete, ""
+}
+
+// failedSources returns the sources with critical or fatal errors, in the
+// order they were recorded.
+func failedSources(result *domain.ScanResult) []string {
+	var failed []string
+	seen := make(map[string]bool)
+	for _, e := range result.Errors {
+		if e.Severity != domain.ErrorCritical && e.Severity != domain.ErrorFatal {
+			continue
+		}
+		if !seen[e.Source] {
+			seen[e.Source] = true
+			failed = append(failed, e.Source)
+		}
+	}
+	return failed
+}
+
+// countNewRisks counts the new and reappeared artifacts of the lifecycle
+// report whose risk severity (as ranked in the PDF report) is at least
+// minSeverity. Without a lifecycle report nothing is new.
+func countNewRisks(result *domain.ScanResult, minSeverity string) int {
+	if !result.Lifecycle.HasChanges() {
+		return 0
+	}
+
+	byKey := make(map[string]*domain.Artifact, len(result.Artifacts))
+	for _, a := range result.Artifacts {
+		if a != nil {
+			byKey[a.Key()] = a
+		}
+	}
+
+	threshold := output.SeverityRank(minSeverity)
+	count := 0
+	for _, list := range [][]domain.ArtifactLifecycle{result.Lifecycle.New, result.Lifecycle.Reappeared} {
+		for _, entry := range list {
+			a, ok := byKey[entry.Key]
+			if ok && output.SeverityRank(output.RiskSeverity(a)) >= threshold {
+				count++
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,127 @@
+// cmd/aethonx/graph.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"aethonx/internal/adapters/output"
+	"aethonx/internal/core/usecases"
+	"aethonx/internal/platform/logx"
+
+	"github.com/spf13/pflag"
+)
+
+// graphPathJSON is the --json representation of a matched path.
+type graphPathJSON struct {
+	Artifacts []graphNodeJSON `json:"artifacts"`
+	Relations []string        `json:"relations"`
+	Path      string          `json:"path"`
+}
+
+type graphNodeJSON struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// runGraph implements "aethonx graph": path queries over a consolidated scan.
+func runGraph(args []string) int {
+	fs := pflag.NewFlagSet("graph", pflag.ContinueOnError)
+	scanPath := fs.String("scan", "", "Consolidated scan JSON to query (required)")
+	query := fs.StringP("query", "q", "", `Path query, e.g. "domain:example.com -[resolves_to]-> ip"`)
+	from := fs.String("from", "", "Shortest path: start node selector (type[:value])")
+	to := fs.String("to", "", "Shortest path: end node selector (type[:value])")
+	limit := fs.Int("limit", 0, "Maximum paths to return (0 = all)")
+	asJSON := fs.Bool("json", false, "Print matches as JSON")
+
+	if err := fs.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	pathMode := *from != "" || *to != ""
+	switch {
+	case *scanPath == "":
+		fmt.Fprintln(os.Stderr, "Error: --scan is required")
+		return 2
+	case pathMode && (*from == "" || *to == ""):
+		fmt.Fprintln(os.Stderr, "Error: --from and --to must be used together")
+		return 2
+	case pathMode && *query != "":
+		fmt.Fprintln(os.Stderr, "Error: use either --query or --from/--to")
+		return 2
+	case !pathMode && *query == "":
+		fmt.Fprintln(os.Stderr, "Error: --query or --from/--to is required")
+		return 2
+	}
+
+	result, err := output.ReadJSON(*scanPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	graph := usecases.NewGraphService(result.Artifacts, logx.NewSilent())
+
+	var matches []usecases.GraphMatch
+	if pathMode {
+		fromNode, err := usecases.ParseQueryNode(*from)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --from: %v\n", err)
+			return 2
+		}
+		toNode, err := usecases.ParseQueryNode(*to)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --to: %v\n", err)
+			return 2
+		}
+		if match, ok := graph.ShortestPath(fromNode, toNode); ok {
+			matches = append(matches, match)
+		}
+	} else {
+		q, err := usecases.ParseGraphQuery(*query)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		matches = graph.Query(q, *limit)
+	}
+
+	if *asJSON {
+		return printGraphJSON(matches)
+	}
+
+	for _, m := range matches {
+		fmt.Println(m.String())
+	}
+	fmt.Fprintf(os.Stderr, "%d match(es)\n", len(matches))
+	return 0
+}
+
+func printGraphJSON(matches []usecases.GraphMatch) int {
+	out := make([]graphPathJSON, 0, len(matches))
+	for _, m := range matches {
+		p := graphPathJSON{Path: m.String()}
+		for _, a := range m.Artifacts {
+			p.Artifacts = append(p.Artifacts, graphNodeJSON{ID: a.ID, Type: string(a.Type), Value: a.Value})
+		}
+		for _, e := range m.Edges {
+			p.Relations = append(p.Relations, e.String())
+		}
+		out = append(out, p)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false) // keep "<-[rel]-" readable
+	if err := enc.Encode(out); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,122 @@
+// cmd/aethonx/imports.go
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"aethonx/internal/adapters/output"
+	"aethonx/internal/platform/config"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/sources/nmapimport"
+	"aethonx/internal/sources/previousscan"
+	"aethonx/internal/sources/screenshotimport"
+)
+
+func init() {
+	// previousscan reads saved scans through the output adapter
+	previousscan.SetLoader(output.ReadScanTypes)
+}
+
+// importSniffBytes is how much of a file is read to detect its format.
+const importSniffBytes = 4096
+
+// importFormats maps each --import file format to the source that reads it.
+// detect receives the first bytes of the file.
+var importFormats = []struct {
+	name   string
+	source string
+	detect func(head []byte) bool
+}{
+	{name: "nmap XML", source: "nmapimport", detect: nmapimport.IsNmapXML},
+	{name: "AethonX scan JSON", source: "previousscan", detect: previousscan.IsScanJSON},
+	{name: "Aquatone session", source: "screenshotimport", detect: screenshotimport.IsAquatoneSession},
+	{name: "EyeWitness Requests.csv", source: "screenshotimport", detect: screenshotimport.IsEyeWitnessCSV},
+}
+
+// routeImports enables the import source of each --import file and hands it
+// its files, plus the --previous-scan file to previousscan. An unreadable
+// file or unknown format fails before the scan.
+func routeImports(cfg config.Config, logger logx.Logger) error {
+	files := make(map[string][]string)
+	for _, path := range cfg.Core.Imports {
+		source, err := detectImport(path)
+		if err != nil {
+			return err
+		}
+		files[source] = append(files[source], path)
+	}
+
+	previous, err := resolvePreviousScan(cfg)
+	if err != nil {
+		return err
+	}
+	if previous != "" {
+		files["previousscan"] = append(files["previousscan"], previous)
+	} else if cfg.Core.PreviousScan != "" {
+		logger.Info("no previous complete scan of the target, nothing to re-verify", "dir", cfg.Output.Dir)
+	}
+
+	for source, paths := range files {
+		sourceConfig, ok := cfg.Source.Sources[source]
+		if !ok {
+			return fmt.Errorf("--import: source %s is not configured", source)
+		}
+		if sourceConfig.Custom == nil {
+			sourceConfig.Custom = make(map[string]interface{})
+		}
+		sourceConfig.Enabled = true
+		sourceConfig.Custom["files"] = paths
+		cfg.Source.Sources[source] = sourceConfig
+		logger.Info("importing files", "source", source, "files", len(paths))
+	}
+	return nil
+}
+
+// detectImport returns the import source for the format of path.
+func detectImport(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("--import: %w", err)
+	}
+	defer f.Close()
+
+	head := make([]byte, importSniffBytes)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", fmt.Errorf("--import %s: %w", path, err)
+	}
+	for _, format := range importFormats {
+		if format.detect(head[:n]) {
+			return format.source, nil
+		}
+	}
+
+	supported := make([]string, len(importFormats))
+	for i, format := range importFormats {
+		supported[i] = format.name
+	}
+	return "", fmt.Errorf("--import %s: unrecognized format (supported: %s)", path, strings.Join(supported, ", "))
+}
+
+// resolvePreviousScan returns the scan named by --previous-scan: a path, or
+// "latest" for the newest complete scan of the target in the output
+// directory ("" on the first scan).
+func resolvePreviousScan(cfg config.Config) (string, error) {
+	switch cfg.Core.PreviousScan {
+	case "":
+		return "", nil
+	case "latest":
+		path, err := output.LatestScan(cfg.Output.Dir, cfg.Core.Target)
+		if err != nil {
+			return "", fmt.Errorf("--previous-scan latest: %w", err)
+		}
+		return path, nil
+	}
+	if _, err := os.Stat(cfg.Core.PreviousScan); err != nil {
+		return "", fmt.Errorf("--previous-scan: %w", err)
+	}
+	return cfg.Core.PreviousScan, nil
+}
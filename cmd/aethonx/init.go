@@ -0,0 +1,307 @@
+// cmd/aethonx/init.go
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/config"
+	"aethonx/internal/platform/installer"
+	"aethonx/internal/platform/registry"
+	"aethonx/internal/platform/workspace"
+
+	"github.com/spf13/pflag"
+)
+
+const initUsage = `Usage: aethonx init [options]
+
+Asks which API keys are available, whether active scanning is permitted and
+which extra output formats to write, then writes a validated config file,
+optionally creates a workspace, and offers to install missing binaries.
+
+Options:
+  -o, --output <file>      Config file to write (default: aethonx.yaml, or
+                           <workspace>/aethonx.yaml with --workspace)
+  --workspace <name>       Create this workspace and point its config.env at the
+                           config file (asked when not given)
+  --dir <path>             Installation directory for binaries (default: $HOME/go/bin)
+  --force                  Overwrite an existing config file
+
+API keys are written to the config file (mode 0600); keep it out of version control.
+`
+
+// initConfigFile is the config file name written by "aethonx init".
+const initConfigFile = "aethonx.yaml"
+
+// runInit implements "aethonx init".
+// Exit codes: 0 written and valid, 1 failure or validation errors, 2 usage error.
+func runInit(args []string) int {
+	fs := pflag.NewFlagSet("init", pflag.ContinueOnError)
+	output := fs.StringP("output", "o", "", "Config file to write")
+	wsName := fs.String("workspace", "", "Workspace to create")
+	installDir := fs.String("dir", "", "Installation directory (default: $HOME/go/bin)")
+	force := fs.Bool("force", false, "Overwrite an existing config file")
+	fs.Usage = func() { fmt.Fprint(os.Stderr, initUsage) }
+	if err := fs.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	p := &prompter{in: bufio.NewReader(os.Stdin), out: os.Stdout}
+	metadata := registry.Global().GetAllMetadata()
+
+	if !fs.Changed("workspace") {
+		*wsName = p.ask("Workspace to create (empty for none)", "")
+	}
+	var ws *workspace.Workspace
+	if *wsName != "" {
+		base, err := workspace.BaseDir()
+		if err == nil {
+			ws, err = workspace.Create(base, *wsName)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
+	path := *output
+	if path == "" {
+		path = initConfigFile
+		if ws != nil {
+			path = filepath.Join(ws.Path, initConfigFile)
+		}
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if _, err := os.Stat(path); err == nil && !*force {
+		fmt.Fprintf(os.Stderr, "Error: %s already exists (use --force to overwrite)\n", path)
+		return 1
+	}
+
+	answers := config.SetupAnswers{Credentials: askCredentials(p, metadata)}
+	answers.Active = p.confirm("Is active scanning (HTTP probing, crawling, port scanning) permitted?", false)
+	answers.PDF, answers.Parquet = askOutputFormats(p)
+
+	data, err := config.SetupFile(metadata, answers)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write config file: %v\n", err)
+		return 1
+	}
+	fmt.Printf("\nWrote %s\n", path)
+
+	if ws != nil {
+		if err := appendWorkspaceEnv(ws, config.SetupEnv(answers, path)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Updated %s\n", filepath.Join(ws.Path, workspace.ConfigFile))
+	}
+
+	cfg, err := config.FromFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	initTools(ctx, p, metadata, cfg, *installDir)
+
+	fmt.Println()
+	problems := validateGlobal(cfg)
+	problems = append(problems, registry.Global().ValidateConfigs(cfg.Source.Sources)...)
+	printProblems(problems)
+
+	fmt.Println()
+	switch {
+	case ws != nil:
+		fmt.Printf("Next: aethonx --workspace %s -t <domain>\n", ws.Name)
+	default:
+		flags := ""
+		if answers.Active {
+			flags += " --active"
+		}
+		if answers.PDF {
+			flags += " --pdf"
+		}
+		if answers.Parquet {
+			flags += " --parquet"
+		}
+		fmt.Printf("Next: aethonx --config %s%s -t <domain>\n", path, flags)
+	}
+
+	for _, problem := range problems {
+		if problem.Severity == registry.SeverityError {
+			return 1
+		}
+	}
+	return 0
+}
+
+// askCredentials asks for the API keys of every source that takes one.
+func askCredentials(p *prompter, metadata map[string]ports.SourceMetadata) map[string]map[string]string {
+	fields := config.CredentialFields(metadata)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	credentials := make(map[string]map[string]string)
+	if len(names) > 0 {
+		fmt.Fprintln(p.out, "\nAPI keys (empty to skip; sources that require one stay disabled):")
+	}
+	for _, name := range names {
+		for _, f := range fields[name] {
+			value := p.ask(fmt.Sprintf("  %s %s (%s)", name, f.Name, f.Description), "")
+			if value == "" {
+				continue
+			}
+			if credentials[name] == nil {
+				credentials[name] = make(map[string]string)
+			}
+			credentials[name][f.Name] = value
+		}
+	}
+	return credentials
+}
+
+// askOutputFormats asks for the outputs written besides the consolidated JSON.
+func askOutputFormats(p *prompter) (pdf, parquet bool) {
+	for {
+		answer := p.ask("Extra output formats besides JSON (pdf, parquet; comma-separated, empty for none)", "")
+		pdf, parquet = false, false
+		unknown := ""
+		for _, format := range strings.Split(answer, ",") {
+			switch format = strings.ToLower(strings.TrimSpace(format)); format {
+			case "":
+			case "pdf":
+				pdf = true
+			case "parquet":
+				parquet = true
+			default:
+				unknown = format
+			}
+		}
+		if unknown == "" {
+			return pdf, parquet
+		}
+		fmt.Fprintf(p.out, "  unknown format %q\n", unknown)
+	}
+}
+
+// appendWorkspaceEnv appends the init settings to the workspace config.env;
+// later lines override earlier ones, so existing settings are kept but lose.
+func appendWorkspaceEnv(ws *workspace.Workspace, env string) error {
+	f, err := os.OpenFile(filepath.Join(ws.Path, workspace.ConfigFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to update workspace config: %w", err)
+	}
+	if _, err := io.WriteString(f, "\n"+env); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to update workspace config: %w", err)
+	}
+	return f.Close()
+}
+
+// initTools checks the binaries of the configured sources and offers to
+// install the missing ones. Failures only warn: validation reports what is
+// still missing.
+func initTools(ctx context.Context, p *prompter, metadata map[string]ports.SourceMetadata, cfg config.Config, installDir string) {
+	tools := installer.ToolsFor(metadata, cfg.Source.Sources, false)
+	if len(tools) == 0 {
+		return
+	}
+
+	orch := installer.NewOrchestrator(installer.Config{ExternalTools: tools, AddToPath: true}, installDir)
+	recordPath, records := loadToolRecords()
+	orch.SetRecords(records)
+	if err := orch.Initialize(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+
+	presenter := installer.NewSimplePresenter(false)
+	fmt.Println()
+	results, code := checkDeps(ctx, orch, presenter)
+	if code == 0 || !p.confirm("Install the missing binaries now?", true) {
+		return
+	}
+
+	presenter.ShowHeader()
+	orch.SetProgressCallback(presenter.ShowProgress)
+	if installed, _ := installDeps(ctx, orch, presenter, false); installed != nil {
+		results = installed
+	}
+	if recordPath != "" {
+		records.Record(tools, results, time.Now())
+		if err := records.Save(recordPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+}
+
+// prompter asks questions on out and reads the answers from in. At end of
+// input every question takes its default.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// ask prints question and returns the trimmed answer, or def if empty.
+func (p *prompter) ask(question, def string) string {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+	line, err := p.in.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return def
+	}
+	if errors.Is(err, io.EOF) && line == "" {
+		fmt.Fprintln(p.out)
+	}
+	if line = strings.TrimSpace(line); line == "" {
+		return def
+	}
+	return line
+}
+
+// confirm asks a yes/no question.
+func (p *prompter) confirm(question string, def bool) bool {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	for {
+		switch strings.ToLower(p.ask(question+" ("+hint+")", "")) {
+		case "":
+			return def
+		case "y", "yes":
+			return true
+		case "n", "no":
+			return false
+		}
+	}
+}
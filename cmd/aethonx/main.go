@@ -3,32 +3,64 @@ package main
 
 import (
 	"context"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	"aethonx/internal/adapters/integrations"
 	"aethonx/internal/adapters/output"
 	"aethonx/internal/core/domain"
 	"aethonx/internal/core/ports"
 	"aethonx/internal/core/usecases"
+	"aethonx/internal/platform/browser"
+	"aethonx/internal/platform/budget"
+	"aethonx/internal/platform/compress"
 	"aethonx/internal/platform/config"
+	"aethonx/internal/platform/installer"
 	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/oob"
+	"aethonx/internal/platform/politeness"
+	"aethonx/internal/platform/rate"
 	"aethonx/internal/platform/registry"
 	"aethonx/internal/platform/resilience"
 	"aethonx/internal/platform/ui"
 
 	// Import sources for auto-registration via init()
 	_ "aethonx/internal/sources/amass"
+	_ "aethonx/internal/sources/asnexpand"
+	_ "aethonx/internal/sources/axfr"
 	_ "aethonx/internal/sources/crtsh"
+	_ "aethonx/internal/sources/disclosure"
+	_ "aethonx/internal/sources/dns"
 	_ "aethonx/internal/sources/httpx"
+	_ "aethonx/internal/sources/katana"
+	_ "aethonx/internal/sources/masscan"
+	_ "aethonx/internal/sources/nmapimport"
+	_ "aethonx/internal/sources/pdns"
+	_ "aethonx/internal/sources/previousscan"
 	_ "aethonx/internal/sources/rdap"
+	_ "aethonx/internal/sources/reversewhois"
+	_ "aethonx/internal/sources/robots"
+	_ "aethonx/internal/sources/screenshotimport"
 	_ "aethonx/internal/sources/shodan"
 	_ "aethonx/internal/sources/subfinder"
+	_ "aethonx/internal/sources/tlsgrade"
 	_ "aethonx/internal/sources/waybackurls"
 )
 
+// exportTimeout bounds the import of findings into all the configured
+// vulnerability-management platforms.
+const exportTimeout = 5 * time.Minute
+
 var (
 	// Rellenables con -ldflags en build
 	version = "dev"
@@ -37,11 +69,18 @@ var (
 )
 
 func main() {
+	// Subcommands (aethonx <command> ...) are dispatched before scan config loading
+	if len(os.Args) > 1 {
+		if cmd, ok := subcommands[os.Args[1]]; ok {
+			os.Exit(cmd(os.Args[2:]))
+		}
+	}
+
 	// 1. Load centralized config (handles help/version internally)
 	cfg, err := config.Load(version, commit, date)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: configuration load failed: %v\n", err)
-		os.Exit(2)
+		os.Exit(exitUsage)
 	}
 
 	// Validate target
@@ -49,21 +88,129 @@ func main() {
 		fmt.Fprintln(os.Stderr, "Error: target domain is required")
 		fmt.Fprintln(os.Stderr, "Usage: aethonx -t <domain>")
 		fmt.Fprintln(os.Stderr, "Try: aethonx -h for help")
-		os.Exit(2)
+		os.Exit(exitUsage)
+	}
+
+	// --fail-on decides the exit code; a typo must not surface after the scan
+	failOn, err := parseFailOn(cfg.Core.FailOn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	if failOn.newRisk != "" && !cfg.Lifecycle.Enabled {
+		fmt.Fprintln(os.Stderr, "Error: --fail-on new-risk requires --track-lifecycle")
+		os.Exit(exitUsage)
+	}
+
+	// Domain normalization policy must be set before any artifact is created
+	policy, err := cfg.NormalizationPolicy()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	domain.SetNormalizationPolicy(policy)
+	domain.SetProvenanceRecording(cfg.Output.IncludeProvenance)
+
+	// Validate output filters before spending time on the scan
+	outputFilter, err := cfg.OutputFilter()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	// Output names are fixed for the whole run; a bad template must fail now
+	if err := configureFilenames(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	// Shared per-upstream budgets must be set before any source is built
+	if err := configureUpstreamRates(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	// Traffic budgets must be set before any active source runs
+	if err := configureBudget(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	// Per-host politeness applies to every active probe of the scan
+	if err := configurePoliteness(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	// Screenshot and rendering sources share one headless browser
+	browser.Shared().Configure(cfg.BrowserPool())
+
+	// Active checks plant out-of-band callbacks through one interactsh session
+	oob.Shared().Configure(cfg.OOBClient())
+
+	// zstd needs its binary: fail before the scan, not when writing results
+	if _, err := cfg.Compression(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	// Malformed caps would only surface once the scan is already running
+	if _, err := cfg.ScanLimits(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	// An unknown phase in --skip-stages would silently skip nothing
+	if _, err := cfg.StagesToSkip(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	// A bad window or time zone must not surface once passive stages are done
+	if _, err := cfg.ActiveWindow(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	// "25%" needs the system RAM; fail before the scan if it cannot be resolved
+	if _, err := cfg.MemoryBudgetBytes(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	// A broken signing key or encryption setup must fail now, not after the scan
+	protection, err := loadOutputProtection(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	// Export targets are checked now so a typo does not surface after the scan
+	exporters, err := newExporters(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
 	}
 
 	// 2. Determine UI mode and create appropriate logger
-	// Pretty mode: Use silent logger (only errors)
-	// Raw mode: Use regular logger
-	usingVisualUI := cfg.Output.UIMode == "pretty" || cfg.Output.UIMode == ""
-
-	var logger logx.Logger
-	if usingVisualUI {
-		// Pretty mode: silent logger (only critical errors go to stderr)
-		logger = logx.NewSilent()
-	} else {
-		// Non-visual mode: regular logger respecting AETHONX_LOG_LEVEL
-		logger = logx.New()
+	// Pretty mode: silent logger (only errors) unless -v/-vv
+	// Raw mode: regular logger respecting AETHONX_LOG_LEVEL
+	// Quiet mode: no visual UI, errors only
+	usingVisualUI := !cfg.Output.Quiet && (cfg.Output.UIMode == "pretty" || cfg.Output.UIMode == "")
+
+	logger := newConsoleLogger(cfg, usingVisualUI)
+
+	// Optional file logging (keeps full diagnostics even with the pretty UI)
+	logger, logFile, err := attachLogFile(logger, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	if logFile != nil {
+		defer logFile.Close()
+	}
+
+	if !usingVisualUI || logFile != nil {
 		logger.Info("AethonX starting",
 			"version", version,
 			"commit", commit,
@@ -74,163 +221,422 @@ func main() {
 		)
 	}
 
+	// --plan: show the stages without building or running any source
+	if cfg.Core.Plan {
+		os.Exit(runPlan(cfg, logger))
+	}
+
 	// 3. Context and signals for clean shutdown
 	ctx, cancel := rootContextWithSignals(cfg.Core.TimeoutS)
 	defer cancel()
 
-	// 4. Build target domain
+	warnToolVersions(ctx, cfg, logger, usingVisualUI)
+
+	// 4. Create UI presenter based on configuration
+	presenter := newPresenter(cfg)
+
+	// 5. Execute scan workflow
+	start := time.Now()
+	result, runErr := runScan(ctx, cfg, logger, presenter)
+	elapsed := time.Since(start)
+	ctxErr := ctx.Err() // Before outputs: a timeout while writing is not a partial scan
+
+	// 6. Handle setup and execution errors
+	var setupErr *scanSetupError
+	if errors.As(runErr, &setupErr) {
+		logger.Err(setupErr.err, "phase", setupErr.phase)
+		os.Exit(exitUsage)
+	}
+
+	if runErr != nil {
+		logger.Err(runErr, "phase", "run", "elapsed_ms", elapsed.Milliseconds())
+		// Continue to emit partial results (useful in pipelines)
+	}
+
+	// Lifecycle tracking (monitor mode) before outputs so the report is exported
+	if result != nil && cfg.Lifecycle.Enabled {
+		trackLifecycle(ctx, cfg, result, runErr, logger)
+	}
+
+	// 7. Write outputs
+	if result != nil {
+		status, reason := scanStatus(result, runErr, ctxErr)
+		manifest := output.NewScanManifest(result, status, reason)
+		outErr := writeOutputs(cfg, result, outputFilter, protection, manifest)
+		if outErr != nil {
+			logger.Err(outErr, "phase", "output")
+			os.Exit(exitError)
+		}
+		exportFindings(exporters, result, status, protection, evidenceLinks(cfg, manifest), logger)
+	}
+
+	// 8. Summary (only in non-visual mode)
+	if result != nil && !usingVisualUI {
+		logger.Info("AethonX finished",
+			"elapsed_ms", elapsed.Milliseconds(),
+			"artifacts", result.TotalArtifacts(),
+			"warnings", len(result.Warnings),
+			"errors", len(result.Errors),
+		)
+	}
+
+	// 9. Exit code from the scan outcome (--fail-on)
+	code, reason := scanExitCode(result, runErr, ctxErr, failOn)
+	if code != exitOK {
+		if usingVisualUI {
+			fmt.Fprintf(os.Stderr, "Exit code %d: %s\n", code, reason)
+		} else {
+			logger.Warn("scan outcome", "exit_code", code, "reason", reason)
+		}
+	}
+	os.Exit(code)
+}
+
+// warnToolVersions warns when an enabled source's binary is older than its
+// configuration needs (e.g., httpx profile=headless). The scan still runs;
+// the affected options may fail or be ignored by the tool.
+func warnToolVersions(ctx context.Context, cfg config.Config, logger logx.Logger, usingVisualUI bool) {
+	records := make(installer.ToolRecords)
+	if path, err := installer.DefaultRecordPath(); err == nil {
+		if loaded, err := installer.LoadRecords(path); err == nil {
+			records = loaded
+		}
+	}
+
+	issues := installer.CheckVersions(ctx, registry.Global().GetAllMetadata(), cfg.Source.Sources, records)
+	for _, issue := range issues {
+		if usingVisualUI {
+			fmt.Fprintf(os.Stderr, "Warning: %s (run: aethonx deps install --upgrade)\n", issue)
+			continue
+		}
+		logger.Warn("outdated tool",
+			"source", issue.Source,
+			"binary", issue.Binary,
+			"installed", issue.Installed,
+			"required", issue.Required,
+			"reason", issue.Reason,
+		)
+	}
+}
+
+// scanSetupError marks failures that happen before the pipeline starts
+// (invalid target, source build). main maps them to exitUsage.
+type scanSetupError struct {
+	phase string
+	err   error
+}
+
+func (e *scanSetupError) Error() string {
+	return fmt.Sprintf("%s: %v", e.phase, e.err)
+}
+
+func (e *scanSetupError) Unwrap() error {
+	return e.err
+}
+
+// runScan builds target, sources and orchestrator from cfg and executes the pipeline.
+// Shared by the default scan command and "aethonx serve".
+func runScan(ctx context.Context, cfg config.Config, logger logx.Logger, presenter ui.Presenter) (*domain.ScanResult, error) {
+	// Build target domain
 	scanMode := domain.ScanModePassive
 	if cfg.Core.Active {
 		scanMode = domain.ScanModeActive
 	}
 
 	target := domain.NewTarget(cfg.Core.Target, scanMode)
+	for _, excluded := range cfg.Core.ExcludeDomains {
+		target.AddExclusion(excluded)
+	}
 
 	// Validate target
 	if err := target.Validate(); err != nil {
-		logger.Err(err, "phase", "validation")
-		os.Exit(2)
+		return nil, &scanSetupError{phase: "validation", err: err}
 	}
 
-	// Inject active mode into all source configs (for hybrid sources like amass)
-	for sourceName, sourceConfig := range cfg.Source.Sources {
-		if sourceConfig.Custom == nil {
-			sourceConfig.Custom = make(map[string]interface{})
-		}
-		sourceConfig.Custom["active_mode"] = cfg.Core.Active
-		cfg.Source.Sources[sourceName] = sourceConfig
+	if err := configureSources(cfg, logger); err != nil {
+		return nil, &scanSetupError{phase: "import", err: err}
 	}
 
-	// 5. Build sources from registry with resilience wrappers
+	// Build sources from registry with resilience wrappers
 	sources, err := buildSourcesWithResilience(logger, cfg)
 	if err != nil {
-		logger.Err(err, "phase", "source-build")
-		os.Exit(2)
+		return nil, &scanSetupError{phase: "source-build", err: err}
 	}
 
 	if len(sources) == 0 {
-		logger.Err(fmt.Errorf("no sources enabled"))
-		os.Exit(2)
+		return nil, &scanSetupError{phase: "source-build", err: fmt.Errorf("no sources enabled")}
 	}
 
 	// Ensure source cleanup on exit
 	defer func() {
 		for _, src := range sources {
 			if err := src.Close(); err != nil {
-				// Only log cleanup errors if not in visual mode
-				if !usingVisualUI {
-					logger.Warn("failed to close source",
-						"source", src.Name(),
-						"error", err.Error(),
-					)
-				}
+				logger.Warn("failed to close source",
+					"source", src.Name(),
+					"error", err.Error(),
+				)
 			}
 		}
+		// After the sources: none of them holds a page any more
+		browser.Shared().Close()
 	}()
 
-	if !usingVisualUI {
-		logger.Info("sources built", "count", len(sources))
-	}
+	logger.Info("sources built", "count", len(sources))
 
-	// 6. Create streaming writer
+	// Create streaming writer
 	scanID := fmt.Sprintf("scan-%d", time.Now().Unix())
 	streamingWriter := output.NewStreamingWriter(cfg.Output.Dir, scanID, cfg.Core.Target, logger)
+	codec, err := cfg.Compression()
+	if err != nil {
+		return nil, &scanSetupError{phase: "compression", err: err}
+	}
+	streamingWriter.SetCompression(codec)
 
-	if !usingVisualUI {
-		logger.Info("streaming configured",
-			"threshold", cfg.Streaming.ArtifactThreshold,
-			"output_dir", cfg.Output.Dir,
-		)
+	memoryBudget, err := cfg.MemoryBudgetBytes()
+	if err != nil {
+		return nil, &scanSetupError{phase: "memory-budget", err: err}
 	}
 
-	// 7. Get source metadata from registry
-	sourceMetadata := registry.Global().GetAllMetadata()
+	logger.Info("streaming configured",
+		"threshold", cfg.Streaming.ArtifactThreshold,
+		"memory_budget_bytes", memoryBudget,
+		"output_dir", cfg.Output.Dir,
+	)
 
-	// 8. Create UI presenter based on configuration
-	var presenter ui.Presenter
-	switch cfg.Output.UIMode {
-	case "raw":
-		// Raw mode: plain logs (text or JSON format)
-		logFormat := ui.LogFormatText
-		if cfg.Output.LogFormat == "json" {
-			logFormat = ui.LogFormatJSON
+	limits, err := cfg.ScanLimits()
+	if err != nil {
+		return nil, &scanSetupError{phase: "limits", err: err}
+	}
+
+	activeWindow, err := cfg.ActiveWindow()
+	if err != nil {
+		return nil, &scanSetupError{phase: "active-window", err: err}
+	}
+
+	// --capture-raw: archive raw source outputs for "aethonx replay"
+	captureDir := ""
+	if cfg.Output.CaptureRaw {
+		captureDir = output.CaptureDir(cfg.Output.Dir, cfg.Core.Target, scanID)
+		manifest := output.CaptureManifest{ScanID: scanID, Target: *target, Version: version, CreatedAt: time.Now()}
+		if err := output.WriteCaptureManifest(captureDir, manifest); err != nil {
+			return nil, &scanSetupError{phase: "capture", err: err}
 		}
-		presenter = ui.NewRawPresenter(logFormat)
-	case "pretty":
-		// Pretty mode: visual UI with custom renderer
-		presenter = ui.NewCustomPresenter()
-	default:
-		// Default to pretty mode
-		presenter = ui.NewCustomPresenter()
+		if cfg.Output.Encrypt != "" {
+			logger.Warn("raw captures are not encrypted", "dir", captureDir)
+		}
+		logger.Info("capturing raw source outputs", "dir", captureDir)
+	}
+
+	// Get source metadata from registry
+	sourceMetadata := registry.Global().GetAllMetadata()
+
+	// Compile user-defined tagging rules
+	tagger, err := usecases.NewTaggingService(cfg.Tagging.Rules, logger)
+	if err != nil {
+		return nil, &scanSetupError{phase: "tag-rules", err: err}
 	}
 
-	// 9. Create pipeline orchestrator (stage-based execution)
+	// Remote agents of a distributed scan (nil = everything runs locally)
+	scheduler, err := newAgentScheduler(cfg, logger)
+	if err != nil {
+		return nil, &scanSetupError{phase: "agents", err: err}
+	}
+
+	// Webhooks get the scan events; undelivered ones wait in the spool
+	observers, eventSpool, err := newEventObservers(ctx, cfg, logger)
+	if err != nil {
+		return nil, &scanSetupError{phase: "notify", err: err}
+	}
+	defer func() {
+		for _, observer := range observers {
+			observer.Close()
+		}
+	}()
+
+	// Create pipeline orchestrator (stage-based execution)
 	orch := usecases.NewPipelineOrchestrator(usecases.PipelineOrchestratorOptions{
-		Sources:         sources,
-		SourceMetadata:  sourceMetadata,
-		Logger:          logger,
-		Observers:       []ports.Notifier{}, // Future: webhooks, metrics, etc.
-		MaxWorkers:      max(1, cfg.Core.Workers),
-		StreamingWriter: streamingWriter,
+		Sources:             sources,
+		SourceMetadata:      sourceMetadata,
+		Logger:              logger,
+		Observers:           observers,
+		EventSpool:          eventSpool,
+		MaxWorkers:          max(1, cfg.Core.Workers),
+		ProviderConcurrency: cfg.Core.ProviderConcurrency,
+		SkipStages:          cfg.Core.SkipStages,
+		CriticalSources:     cfg.CriticalSources(),
+		CriticalStages:      cfg.Core.CriticalStages,
+		StreamingWriter:     streamingWriter,
 		StreamingConfig: usecases.StreamingConfig{
 			ArtifactThreshold: cfg.Streaming.ArtifactThreshold,
 			OutputDir:         cfg.Output.Dir,
+			MemoryBudgetBytes: memoryBudget,
 		},
 		Presenter: presenter,
+		Tagger:    tagger,
+		Noise:     newNoiseService(cfg, logger),
 		UIConfig: usecases.UIConfig{
 			Mode:        ui.UIMode(cfg.Output.UIMode),
 			ShowMetrics: cfg.Output.ShowMetrics,
 			ShowPhases:  cfg.Output.ShowPhases,
 			TimeoutS:    cfg.Core.TimeoutS,
 		},
+		MinRelationConfidence: cfg.Output.MinRelationConfidence,
+		Scheduler:             scheduler,
+		Limits:                usecases.ScanLimits(limits),
+		ActiveWindow:          activeWindow,
+		CaptureDir:            captureDir,
 	})
 
-	// 10. Execute scan workflow
-	start := time.Now()
 	result, runErr := orch.Run(ctx, *target)
-	elapsed := time.Since(start)
+
+	// Partials are plaintext: drop them once consolidated when outputs are encrypted
+	if cfg.Output.Encrypt != "" {
+		if _, err := streamingWriter.RemovePartials(); err != nil {
+			logger.Warn("failed to remove partial results", "error", err.Error())
+		}
+	}
 
 	// Add version metadata
 	if result != nil {
 		result.Metadata.Version = version
 		result.Metadata.Environment = map[string]string{
-			"commit": commit,
-			"date":   date,
+			"commit":  commit,
+			"date":    date,
+			"profile": cfg.Core.Profile,
 		}
+		if cfg.Core.Sample > 0 {
+			result.Metadata.Environment["sample"] = strconv.Itoa(cfg.Core.Sample)
+		}
+		recordBudgetUsage(result, logger)
+		recordOOBInteractions(result, logger)
 	}
 
-	// 11. Handle execution errors
-	if runErr != nil {
-		logger.Err(runErr, "phase", "run", "elapsed_ms", elapsed.Milliseconds())
-		// Continue to emit partial results (useful in pipelines)
+	return result, runErr
+}
+
+// newConsoleLogger builds the stderr logger from AETHONX_LOG_LEVEL and -q/-v/-vv.
+// Flags override the default level; per-component overrides from the env are kept.
+func newConsoleLogger(cfg config.Config, usingVisualUI bool) logx.Logger {
+	spec := logx.ParseLevelSpec(os.Getenv("AETHONX_LOG_LEVEL"))
+
+	switch {
+	case cfg.Output.Quiet:
+		spec.Default = logx.LevelError
+	case cfg.Output.Verbosity >= 2:
+		spec.Default = logx.LevelDebug
+	case cfg.Output.Verbosity == 1:
+		spec.Default = logx.LevelInfo
+	case usingVisualUI:
+		// Pretty mode: only critical errors go to stderr
+		spec.Default = logx.LevelError
 	}
 
-	// 12. Write outputs
-	if result != nil {
-		outErr := writeOutputs(cfg, result)
-		if outErr != nil {
-			logger.Err(outErr, "phase", "output")
-			os.Exit(1)
+	return logx.NewWithSpec(spec)
+}
+
+// attachLogFile tees logger into a rotating file when --log-file is set.
+// The returned closer is nil when file logging is disabled.
+func attachLogFile(logger logx.Logger, cfg config.Config) (logx.Logger, io.Closer, error) {
+	if cfg.Output.LogFile == "" {
+		return logger, nil, nil
+	}
+
+	file, err := logx.NewRotatingFile(cfg.Output.LogFile, logx.RotateOptions{
+		MaxSizeBytes: int64(cfg.Output.LogMaxSizeMB) * 1024 * 1024,
+		MaxAge:       cfg.Output.LogMaxAge,
+		MaxBackups:   cfg.Output.LogMaxBackups,
+	})
+	if err != nil {
+		return logger, nil, fmt.Errorf("log file: %w", err)
+	}
+
+	lvl := logx.ParseLevel(cfg.Output.LogFileLevel)
+	var fileLogger logx.Logger
+	if cfg.Output.LogFileFormat == "text" {
+		fileLogger = logx.NewText(file, lvl)
+	} else {
+		fileLogger = logx.NewJSON(file, lvl)
+	}
+
+	return logx.Tee(logger, fileLogger), file, nil
+}
+
+// newPresenter creates the UI presenter selected by --ui-mode, optionally
+// combined with JSON progress events on stderr (--progress-format=json).
+func newPresenter(cfg config.Config) ui.Presenter {
+	var presenter ui.Presenter
+	switch {
+	case cfg.Output.Quiet:
+		// Quiet mode: no progress rendering at all
+		presenter = ui.NewNopPresenter()
+	case cfg.Output.UIMode == "raw":
+		// Raw mode: plain logs (text or JSON format)
+		logFormat := ui.LogFormatText
+		if cfg.Output.LogFormat == "json" {
+			logFormat = ui.LogFormatJSON
+		}
+		presenter = ui.NewRawPresenter(logFormat)
+	case cfg.Output.UIMode == "pretty":
+		// Pretty mode: visual UI with custom renderer
+		presenter = ui.NewCustomPresenter()
+	default:
+		// Default to pretty mode
+		presenter = ui.NewCustomPresenter()
+	}
+
+	if ui.ProgressFormat(cfg.Output.ProgressFormat) == ui.ProgressFormatJSON {
+		return ui.NewMultiPresenter(presenter, ui.NewEventPresenter(os.Stderr))
+	}
+
+	return presenter
+}
+
+// configureSources applies the scan-wide settings to the source configs:
+// active mode, --no-pivot and --import. Shared by runScan and --plan.
+func configureSources(cfg config.Config, logger logx.Logger) error {
+	// Inject active mode into all source configs (for hybrid sources like amass)
+	for sourceName, sourceConfig := range cfg.Source.Sources {
+		if sourceConfig.Custom == nil {
+			sourceConfig.Custom = make(map[string]interface{})
 		}
+		sourceConfig.Custom["active_mode"] = cfg.Core.Active
+		cfg.Source.Sources[sourceName] = sourceConfig
 	}
 
-	// 12. Summary (only in non-visual mode)
-	if result != nil && !usingVisualUI {
-		logger.Info("AethonX finished",
-			"elapsed_ms", elapsed.Milliseconds(),
-			"artifacts", result.TotalArtifacts(),
-			"warnings", len(result.Warnings),
-			"errors", len(result.Errors),
-		)
+	// --no-pivot: never look beyond the target
+	if cfg.Core.NoPivot {
+		disablePivotSources(cfg, logger)
 	}
 
-	if runErr != nil {
-		os.Exit(1)
+	// --import: fuse other tools' output through their import sources
+	return routeImports(cfg, logger)
+}
+
+// disablePivotSources disables the enabled sources whose metadata marks them
+// as pivot sources (they discover assets outside the target).
+func disablePivotSources(cfg config.Config, logger logx.Logger) {
+	for name, sourceConfig := range cfg.Source.Sources {
+		meta, ok := registry.Global().GetMetadata(name)
+		if !ok || !meta.Pivot || !sourceConfig.Enabled {
+			continue
+		}
+		sourceConfig.Enabled = false
+		cfg.Source.Sources[name] = sourceConfig
+		logger.Info("pivot source disabled by --no-pivot", "source", name)
 	}
 }
 
 // buildSourcesWithResilience builds sources from registry with resilience wrappers.
 func buildSourcesWithResilience(logger logx.Logger, cfg config.Config) ([]ports.Source, error) {
+	// Negative results (empty crt.sh, RDAP 404) persist across runs so
+	// periodic scans don't query known dead ends again
+	negativePath := filepath.Join(cfg.Output.Dir, registry.NegativeCacheFilename)
+	if negative, err := registry.LoadNegativeCache(negativePath); err != nil {
+		logger.Warn("negative cache not loaded, using an in-memory one", "path", negativePath, "error", err.Error())
+	} else {
+		registry.Global().SetNegativeCache(negative)
+	}
+
 	// Build sources from registry
 	sources, err := registry.Global().Build(cfg.Source.Sources, logger)
 	if err != nil {
@@ -276,18 +682,215 @@ func buildSourcesWithResilience(logger logx.Logger, cfg config.Config) ([]ports.
 	return sources, nil
 }
 
+// configureUpstreamRates applies --upstream-rate to the process-wide budgets
+// that every source and concurrent scan of an upstream share.
+func configureUpstreamRates(cfg config.Config) error {
+	rates, err := cfg.UpstreamRates()
+	if err != nil {
+		return err
+	}
+	rate.Shared().ConfigureAll(rates)
+	return nil
+}
+
+// configureFilenames applies --filename-template to every output written.
+func configureFilenames(cfg config.Config) error {
+	tmpl, err := output.ParseFilenameTemplate(cfg.Output.FilenameTemplate)
+	if err != nil {
+		return err
+	}
+	output.SetFilenameTemplate(tmpl)
+	return nil
+}
+
+// configureBudget applies --budget to the process-wide traffic budgets
+// shared by the active sources.
+func configureBudget(cfg config.Config) error {
+	limits, err := cfg.Budgets()
+	if err != nil {
+		return err
+	}
+	budget.Shared().Configure(limits)
+	return nil
+}
+
+// configurePoliteness applies --host-concurrency and --host-delay to the
+// process-wide per-host limits of active probing.
+func configurePoliteness(cfg config.Config) error {
+	policy, err := cfg.Politeness()
+	if err != nil {
+		return err
+	}
+	politeness.Shared().Configure(policy)
+	return nil
+}
+
+// recordBudgetUsage stores the traffic budgets consumed so far in the result
+// metadata (budget_<kind> = "<used>[/<limit>]") when --budget is set.
+func recordBudgetUsage(result *domain.ScanResult, logger logx.Logger) {
+	if !budget.Shared().Limited() {
+		return
+	}
+	for _, usage := range budget.Shared().Usage() {
+		value := strconv.FormatInt(usage.Used, 10)
+		if usage.Limit > 0 {
+			value += "/" + strconv.FormatInt(usage.Limit, 10)
+		}
+		result.Metadata.Environment["budget_"+string(usage.Kind)] = value
+		logger.Info("traffic budget", "kind", string(usage.Kind), "used", usage.Used, "limit", usage.Limit)
+	}
+}
+
+// recordOOBInteractions waits --oob-wait for late callbacks, polls the
+// interactsh server a last time and tags the artifacts whose callbacks were
+// hit (oob-interaction, oob-<protocol>). The session is then deregistered.
+func recordOOBInteractions(result *domain.ScanResult, logger logx.Logger) {
+	client := oob.Shared()
+	if client.Callbacks() == 0 {
+		return
+	}
+	cfg := client.Config()
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Wait+2*cfg.Timeout)
+	defer cancel()
+	defer func() {
+		if err := client.Close(ctx); err != nil {
+			logger.Warn("failed to close out-of-band session", "error", err.Error())
+		}
+	}()
+
+	select {
+	case <-time.After(cfg.Wait):
+	case <-ctx.Done():
+	}
+	if _, err := client.Poll(ctx); err != nil {
+		logger.Warn("failed to poll out-of-band interactions", "error", err.Error())
+	}
+
+	interactions := client.Interactions()
+	byKey := make(map[string]*domain.Artifact, len(result.Artifacts))
+	for _, a := range result.Artifacts {
+		byKey[a.Key()] = a
+	}
+	for _, in := range interactions {
+		if a, ok := byKey[in.Origin.ArtifactKey]; ok {
+			a.AddTag("oob-interaction")
+			a.AddTag("oob-" + strings.ToLower(in.Protocol))
+		}
+		logger.Info("out-of-band interaction",
+			"artifact", in.Origin.ArtifactKey,
+			"source", in.Origin.Source,
+			"check", in.Origin.Check,
+			"protocol", in.Protocol,
+			"remote", in.RemoteAddress,
+		)
+	}
+	result.Metadata.Environment["oob_callbacks"] = strconv.Itoa(client.Callbacks())
+	result.Metadata.Environment["oob_interactions"] = strconv.Itoa(len(interactions))
+}
+
+// newNoiseService builds the third-party noise filter. Scope exclusions
+// (e.g. from the workspace scope file) are always enforced.
+func newNoiseService(cfg config.Config, logger logx.Logger) *usecases.NoiseService {
+	return usecases.NewNoiseService(usecases.NoiseOptions{
+		Suppress:    cfg.Noise.Suppress,
+		ExcludeApex: cfg.Noise.ExcludeApex,
+	}, logger)
+}
+
+// trackLifecycle updates first/last seen state for the target and attaches the
+// change report to result. Incomplete scans are skipped: artifacts from failed
+// sources would otherwise be marked stale.
+func trackLifecycle(ctx context.Context, cfg config.Config, result *domain.ScanResult, runErr error, logger logx.Logger) {
+	if runErr != nil || result.HasErrors() {
+		logger.Warn("lifecycle tracking skipped: scan incomplete", "errors", len(result.Errors))
+		return
+	}
+
+	svc := usecases.NewLifecycleService(output.NewFileLifecycleStore(cfg.Output.Dir), usecases.LifecycleOptions{
+		StaleAfter:    cfg.Lifecycle.StaleAfter,
+		RemoveAfter:   cfg.Lifecycle.RemoveAfter,
+		CertAlertDays: cfg.Lifecycle.CertAlertDays,
+		Logger:        logger,
+	})
+	if _, err := svc.Track(ctx, result); err != nil {
+		logger.Err(err, "phase", "lifecycle")
+	}
+}
+
 // writeOutputs decides and executes outputs based on config.
 // Keeping isolated from main makes it easier to add new formats.
-func writeOutputs(cfg config.Config, result *domain.ScanResult) error {
+// Every file written is recorded in manifest, which is written last so its
+// presence means the outputs are complete.
+func writeOutputs(cfg config.Config, result *domain.ScanResult, filter domain.ArtifactFilter, protection outputProtection, manifest *output.ScanManifest) error {
+	// Provenance is only exported on request
+	if !cfg.Output.IncludeProvenance {
+		result = result.WithoutProvenance()
+	}
+
+	codec, err := cfg.Compression()
+	if err != nil {
+		return err
+	}
+
 	// ALWAYS generate consolidated JSON (required for streaming)
 	// This file contains final result after deduplication and graph building
-	if err := output.OutputJSON(cfg.Output.Dir, result); err != nil {
-		return fmt.Errorf("json output: %w", err)
+	if err := writeConsolidatedJSON(cfg.Output.Dir, result, codec, cfg.Output.Shard, protection, manifest); err != nil {
+		return err
+	}
+
+	// Focused export: the consolidated record above keeps the full dataset
+	exported := result
+	if !filter.IsZero() {
+		exported = result.Filtered(filter)
+		filtered := exported.Redacted(protection.redaction["filtered"])
+		path, err := output.WriteFilteredJSON(cfg.Output.Dir, filtered, codec, protection.encryptor)
+		if err == nil {
+			err = manifest.AddOutput("filtered", path)
+		}
+		if err != nil {
+			return fmt.Errorf("filtered json output: %w", err)
+		}
+	}
+
+	// Client-ready PDF report of the exported dataset
+	if cfg.Output.PDFReport {
+		if err := writeSignedOutput(protection, manifest, "pdf", func() (string, error) {
+			return output.WritePDFReport(cfg.Output.Dir, exported.Redacted(protection.redaction["pdf"]), protection.encryptor)
+		}); err != nil {
+			return fmt.Errorf("pdf report: %w", err)
+		}
+	}
+
+	// Columnar export for data pipelines (DuckDB, Athena, Spark)
+	if cfg.Output.Parquet {
+		if err := writeSignedOutput(protection, manifest, "parquet", func() (string, error) {
+			return output.WriteParquet(cfg.Output.Dir, exported.Redacted(protection.redaction["parquet"]), protection.encryptor)
+		}); err != nil {
+			return fmt.Errorf("parquet output: %w", err)
+		}
+	}
+
+	// Hand-off to manual testing and screenshot triage tools
+	if cfg.Output.Burp || cfg.Output.ZAP || cfg.Output.Aquatone || cfg.Output.EyeWitness {
+		if err := writeHandoffOutputs(cfg, exported.Redacted(protection.redaction["proxy"]), protection, manifest); err != nil {
+			return err
+		}
+	}
+
+	if err := writeManifest(cfg.Output.Dir, result, protection, manifest); err != nil {
+		return err
+	}
+
+	// Piping: the same redacted result as the consolidated JSON, on one line
+	if cfg.Output.Stdout {
+		if err := output.OutputJSONStdout(result.Redacted(protection.redaction["json"]), false); err != nil {
+			return fmt.Errorf("stdout output: %w", err)
+		}
 	}
 
 	// Terminal-readable table only in pretty mode
-	if cfg.Output.UIMode == "pretty" || cfg.Output.UIMode == "" {
-		if err := output.OutputTable(result); err != nil {
+	if !cfg.Output.Quiet && (cfg.Output.UIMode == "pretty" || cfg.Output.UIMode == "") {
+		if err := output.OutputTable(exported.Redacted(protection.redaction["table"])); err != nil {
 			return fmt.Errorf("table output: %w", err)
 		}
 	}
@@ -295,6 +898,248 @@ func writeOutputs(cfg config.Config, result *domain.ScanResult) error {
 	return nil
 }
 
+// writeHandoffOutputs writes the Burp scope, ZAP context and Aquatone host
+// list requested in cfg, plus the alive URL list all of these tools import.
+func writeHandoffOutputs(cfg config.Config, result *domain.ScanResult, protection outputProtection, manifest *output.ScanManifest) error {
+	if cfg.Output.Burp {
+		if err := writeSignedOutput(protection, manifest, output.FormatBurp, func() (string, error) {
+			return output.WriteBurpScope(cfg.Output.Dir, result, protection.encryptor)
+		}); err != nil {
+			return fmt.Errorf("burp scope: %w", err)
+		}
+	}
+	if cfg.Output.ZAP {
+		if err := writeSignedOutput(protection, manifest, output.FormatZAP, func() (string, error) {
+			return output.WriteZAPContext(cfg.Output.Dir, result, protection.encryptor)
+		}); err != nil {
+			return fmt.Errorf("zap context: %w", err)
+		}
+	}
+	if cfg.Output.Aquatone {
+		if err := writeSignedOutput(protection, manifest, output.FormatHosts, func() (string, error) {
+			return output.WriteHostList(cfg.Output.Dir, result, protection.encryptor)
+		}); err != nil {
+			return fmt.Errorf("host list: %w", err)
+		}
+	}
+	if err := writeSignedOutput(protection, manifest, output.FormatURLs, func() (string, error) {
+		return output.WriteURLList(cfg.Output.Dir, result, protection.encryptor)
+	}); err != nil {
+		return fmt.Errorf("url list: %w", err)
+	}
+	return nil
+}
+
+// writeManifest writes the scan manifest (signed when a signing key is
+// configured) once every output is on disk.
+func writeManifest(dir string, result *domain.ScanResult, protection outputProtection, manifest *output.ScanManifest) error {
+	err := writeSignedOutput(protection, nil, "", func() (string, error) {
+		return output.WriteManifest(dir, result, manifest)
+	})
+	if err != nil {
+		return fmt.Errorf("manifest: %w", err)
+	}
+	return nil
+}
+
+// newExporters builds the vulnerability-management exporters configured in
+// cfg.Export (none when no platform URL is set).
+func newExporters(cfg config.Config) ([]integrations.Exporter, error) {
+	ex := cfg.Export
+	if ex.MinSeverity != "" && output.SeverityRank(ex.MinSeverity) == 0 {
+		return nil, fmt.Errorf("invalid --export-min-severity %q (use low, medium, high or critical)", ex.MinSeverity)
+	}
+
+	var exporters []integrations.Exporter
+	if ex.DefectDojoURL != "" {
+		dojo, err := integrations.NewDefectDojo(integrations.DefectDojoOptions{
+			URL:         ex.DefectDojoURL,
+			Token:       ex.DefectDojoToken,
+			Product:     ex.DefectDojoProduct,
+			ProductType: ex.DefectDojoProductType,
+			Engagement:  ex.DefectDojoEngagement,
+			MinSeverity: ex.MinSeverity,
+		})
+		if err != nil {
+			return nil, err
+		}
+		exporters = append(exporters, dojo)
+	}
+	if ex.FaradayURL != "" {
+		faraday, err := integrations.NewFaraday(integrations.FaradayOptions{
+			URL:         ex.FaradayURL,
+			Token:       ex.FaradayToken,
+			Workspace:   ex.FaradayWorkspace,
+			MinSeverity: ex.MinSeverity,
+		})
+		if err != nil {
+			return nil, err
+		}
+		exporters = append(exporters, faraday)
+	}
+	if ex.JiraURL != "" {
+		// Tickets are opened for new findings only, which needs the lifecycle
+		if !cfg.Lifecycle.Enabled {
+			return nil, fmt.Errorf("--jira-url requires --track-lifecycle")
+		}
+		if output.SeverityRank(ex.JiraMinSeverity) == 0 {
+			return nil, fmt.Errorf("invalid --jira-min-severity %q (use low, medium, high or critical)", ex.JiraMinSeverity)
+		}
+		jira, err := integrations.NewJira(integrations.JiraOptions{
+			URL:         ex.JiraURL,
+			User:        ex.JiraUser,
+			Token:       ex.JiraToken,
+			Project:     ex.JiraProject,
+			IssueType:   ex.JiraIssueType,
+			Labels:      ex.JiraLabels,
+			MinSeverity: ex.JiraMinSeverity,
+		})
+		if err != nil {
+			return nil, err
+		}
+		exporters = append(exporters, jira)
+	}
+	return exporters, nil
+}
+
+// evidenceLinks lists the report and consolidated JSON of the manifest as
+// evidence for tickets: URLs under --report-url, or local paths without it.
+func evidenceLinks(cfg config.Config, manifest *output.ScanManifest) []integrations.Link {
+	names := map[string]string{"pdf": "Report", "json": "Scan results (JSON)"}
+
+	var links []integrations.Link
+	for _, o := range manifest.Outputs {
+		name, ok := names[o.Kind]
+		if !ok {
+			continue
+		}
+		link := integrations.Link{Name: name, URL: o.File}
+		if cfg.Export.ReportURL != "" {
+			if rel, err := filepath.Rel(cfg.Output.Dir, o.File); err == nil {
+				segments := strings.Split(filepath.ToSlash(rel), "/")
+				for i, seg := range segments {
+					segments[i] = url.PathEscape(seg)
+				}
+				link.URL = cfg.Export.ReportURL + "/" + strings.Join(segments, "/")
+			}
+		}
+		links = append(links, link)
+	}
+	return links
+}
+
+// exportFindings imports the findings into each configured platform. Only
+// complete scans are exported: a reimport closes the findings that are not in
+// it, so a partial scan would close findings that still exist. Failures are
+// logged and do not change the exit code. Ticket exporters cite evidence.
+func exportFindings(exporters []integrations.Exporter, result *domain.ScanResult, status string, protection outputProtection, evidence []integrations.Link, logger logx.Logger) {
+	if len(exporters) == 0 {
+		return
+	}
+	if status != output.ManifestStatusComplete {
+		logger.Warn("findings export skipped: scan incomplete", "status", status)
+		return
+	}
+
+	// The scan context may have expired; the export gets its own deadline
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	redacted := result.Redacted(protection.redaction["export"])
+	for _, exporter := range exporters {
+		if jira, ok := exporter.(*integrations.Jira); ok {
+			jira.SetEvidence(evidence)
+		}
+		n, err := exporter.Export(ctx, redacted)
+		if err != nil {
+			logger.Err(err, "phase", "export", "platform", exporter.Name())
+			continue
+		}
+		logger.Info("findings exported", "platform", exporter.Name(), "findings", n)
+	}
+}
+
+// outputProtection holds the redaction profiles, optional signing key and
+// encryptor applied to written results. The zero value writes plaintext,
+// unredacted, unsigned files.
+type outputProtection struct {
+	redaction  map[string]domain.RedactionProfile // per output format (config.RedactionFormats)
+	signingKey ed25519.PrivateKey
+	encryptor  *output.Encryptor
+}
+
+// loadOutputProtection loads the redaction profiles, signing key and encryptor from config.
+func loadOutputProtection(cfg config.Config) (outputProtection, error) {
+	var p outputProtection
+	redaction, err := cfg.RedactionProfiles()
+	if err != nil {
+		return p, err
+	}
+	p.redaction = redaction
+	if cfg.Output.SigningKey != "" {
+		key, err := output.LoadSigningKey(cfg.Output.SigningKey)
+		if err != nil {
+			return p, err
+		}
+		p.signingKey = key
+	}
+	if cfg.Output.Encrypt != "" {
+		enc, err := output.NewEncryptor(cfg.Output.Encrypt, cfg.Output.EncryptTo)
+		if err != nil {
+			return p, err
+		}
+		p.encryptor = enc
+	}
+	return p, nil
+}
+
+// writeConsolidatedJSON writes the consolidated JSON (redacted, compressed and
+// encrypted if configured) and, when a signing key is configured, its detached
+// signature (<file>.sig). The signature covers the file as written, so
+// compressed or encrypted results are signed as written. With shard the result
+// is written as a directory sharded by artifact type and the signature covers
+// its index.json, which carries the SHA-256 of every shard. The files written
+// are recorded in manifest.
+func writeConsolidatedJSON(dir string, result *domain.ScanResult, codec compress.Codec, shard bool, protection outputProtection, manifest *output.ScanManifest) error {
+	err := writeSignedOutput(protection, manifest, "json", func() (string, error) {
+		redacted := result.Redacted(protection.redaction["json"])
+		if shard {
+			return output.WriteShardedJSON(dir, redacted, codec, protection.encryptor)
+		}
+		return output.WriteJSON(dir, redacted, codec, protection.encryptor)
+	})
+	if err != nil {
+		return fmt.Errorf("json output: %w", err)
+	}
+	return nil
+}
+
+// writeSignedOutput runs write and signs the file it produced when a signing
+// key is configured. The file (as kind) and its signature are recorded in
+// manifest when it is not nil.
+func writeSignedOutput(protection outputProtection, manifest *output.ScanManifest, kind string, write func() (string, error)) error {
+	path, err := write()
+	if err != nil {
+		return err
+	}
+	if manifest != nil {
+		if err := manifest.AddOutput(kind, path); err != nil {
+			return err
+		}
+	}
+	if protection.signingKey == nil {
+		return nil
+	}
+	sigPath, err := output.SignFile(path, protection.signingKey)
+	if err != nil {
+		return fmt.Errorf("signature: %w", err)
+	}
+	if manifest != nil {
+		return manifest.AddOutput("signature", sigPath)
+	}
+	return nil
+}
+
 // rootContextWithSignals creates a root context with optional timeout and signal cancellation.
 // Returns a context and cancel function that cleans up all resources (signals, goroutines).
 func rootContextWithSignals(timeoutSeconds int) (context.Context, context.CancelFunc) {
@@ -0,0 +1,45 @@
+// cmd/aethonx/notify.go
+package main
+
+import (
+	"context"
+
+	"aethonx/internal/adapters/integrations"
+	"aethonx/internal/adapters/output"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/core/usecases"
+	"aethonx/internal/platform/config"
+	"aethonx/internal/platform/logx"
+)
+
+// newEventObservers builds the --webhook observers and the --event-spool
+// spool, then replays to each webhook the events earlier scans could not
+// deliver. A failed replay leaves the rest spooled for the next scan.
+func newEventObservers(ctx context.Context, cfg config.Config, logger logx.Logger) ([]ports.Notifier, ports.EventSpool, error) {
+	observers := make([]ports.Notifier, 0, len(cfg.Notify.Webhooks))
+	for _, raw := range cfg.Notify.Webhooks {
+		webhook, err := integrations.NewWebhook(raw, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		observers = append(observers, webhook)
+	}
+
+	var spool ports.EventSpool
+	if cfg.Notify.SpoolDir == "" {
+		return observers, spool, nil
+	}
+	spool = output.NewFileEventSpool(cfg.Notify.SpoolDir)
+
+	for _, observer := range observers {
+		replayed, err := usecases.ReplaySpooledEvents(ctx, spool, observer)
+		if err != nil {
+			logger.Warn("spooled events not replayed", "observer", usecases.ObserverName(observer), "replayed", replayed, "error", err.Error())
+			continue
+		}
+		if replayed > 0 {
+			logger.Info("spooled events replayed", "observer", usecases.ObserverName(observer), "events", replayed)
+		}
+	}
+	return observers, spool, nil
+}
@@ -0,0 +1,130 @@
+// cmd/aethonx/plan.go
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/core/usecases"
+	"aethonx/internal/platform/config"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+)
+
+// plannedSource stands in for an enabled source in --plan: stages only need
+// its name, mode and type, so nothing is built and no upstream is touched.
+type plannedSource struct {
+	meta ports.SourceMetadata
+}
+
+func (s plannedSource) Name() string            { return s.meta.Name }
+func (s plannedSource) Mode() domain.SourceMode { return s.meta.Mode }
+func (s plannedSource) Type() domain.SourceType { return s.meta.Type }
+func (s plannedSource) Close() error            { return nil }
+func (s plannedSource) Run(context.Context, domain.Target) (*domain.ScanResult, error) {
+	return nil, errors.New("planned source cannot run")
+}
+
+// runPlan implements --plan: print the stages the scan would run, with their
+// workers, timeout and fail-fast policy, and the sources skipped by
+// --skip-stages.
+func runPlan(cfg config.Config, logger logx.Logger) int {
+	if err := configureSources(cfg, logger); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+	limits, err := cfg.ScanLimits()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	metadata := registry.Global().GetAllMetadata()
+	var sources []ports.Source
+	for name, sc := range cfg.Source.Sources {
+		if meta, ok := metadata[name]; ok && sc.Enabled {
+			meta.Name = name
+			sources = append(sources, plannedSource{meta: meta})
+		}
+	}
+
+	mode := domain.ScanModePassive
+	if cfg.Core.Active {
+		mode = domain.ScanModeActive
+	}
+
+	orch := usecases.NewPipelineOrchestrator(usecases.PipelineOrchestratorOptions{
+		Sources:        sources,
+		SourceMetadata: metadata,
+		Logger:         logger,
+		SkipStages:     cfg.Core.SkipStages,
+	})
+	plan, err := orch.Plan(mode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Scan plan for %s (%s mode)\n\n", cfg.Core.Target, mode)
+	if len(plan.Stages) == 0 {
+		fmt.Println("No stage would run: every enabled source is skipped or incompatible with the mode.")
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+		critical := cfg.CriticalSources()
+		fmt.Fprintln(w, "STAGE\tNAME\tWORKERS\tTIMEOUT\tCRITICAL\tSOURCES")
+		for _, stage := range plan.Stages {
+			workers := max(1, cfg.Core.Workers)
+			if n := limits.StageMaxWorkers[stage.ID]; n > 0 {
+				workers = n
+			}
+			fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\t%s\n",
+				stage.ID, stage.Name, workers, planTimeout(cfg, limits, stage.ID),
+				yesNo(slices.Contains(cfg.Core.CriticalStages, stage.ID)), stageSources(stage, critical))
+		}
+		w.Flush()
+	}
+
+	if len(plan.Skipped) > 0 {
+		fmt.Println("\nSkipped stages (--skip-stages):")
+		for _, phase := range ports.Phases {
+			if names, ok := plan.Skipped[phase]; ok {
+				fmt.Printf("  %s: %s\n", phase, strings.Join(names, ", "))
+			}
+		}
+	}
+	return 0
+}
+
+// planTimeout describes the deadline of a stage: its --stage-timeout or -T.
+func planTimeout(cfg config.Config, limits config.ScanLimits, stageID int) string {
+	if d := limits.StageMaxDuration[stageID]; d > 0 {
+		return d.String()
+	}
+	if cfg.Core.TimeoutS > 0 {
+		return (time.Duration(cfg.Core.TimeoutS) * time.Second).String()
+	}
+	return "none"
+}
+
+// stageSources lists the sources of a stage by name, marking the critical ones.
+func stageSources(stage usecases.Stage, critical []string) string {
+	names := make([]string, 0, len(stage.Sources))
+	for _, src := range stage.Sources {
+		name := src.Name()
+		if slices.Contains(critical, name) {
+			name += " (critical)"
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,180 @@
+// cmd/aethonx/replay.go
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"aethonx/internal/adapters/output"
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/core/usecases"
+	"aethonx/internal/platform/compress"
+	"aethonx/internal/platform/config"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+	"aethonx/internal/platform/ui"
+
+	"github.com/spf13/pflag"
+)
+
+const replayUsage = `Usage: aethonx replay <capture dir> [options]
+
+Reprocesses the raw source outputs archived by --capture-raw through the
+current parsers, deduplication and graph, without querying anything.
+The capture dir is <output>/<target>/raw/<scan id>.
+
+Options:
+  --config <file>    YAML config file with source options (default: AETHONX_CONFIG)
+  -o, --out <dir>    Output directory for the consolidated JSON
+                     (default: the output dir the capture belongs to)
+  -q, --quiet        Do not print the results table
+`
+
+// runReplay implements "aethonx replay".
+// Exit codes: 0 replayed, 1 failure, 2 usage error.
+func runReplay(args []string) int {
+	fs := pflag.NewFlagSet("replay", pflag.ContinueOnError)
+	configPath := fs.String("config", "", "YAML config file")
+	outDir := fs.StringP("out", "o", "", "Output directory")
+	quiet := fs.BoolP("quiet", "q", false, "Do not print the results table")
+	fs.Usage = func() { fmt.Fprint(os.Stderr, replayUsage) }
+	if err := fs.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	dir := fs.Arg(0)
+
+	manifest, names, err := output.ReadCapture(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	cfg, err := config.FromFile(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	domain.SetProvenanceRecording(cfg.Output.IncludeProvenance)
+	if *outDir == "" {
+		// <output>/<target>/raw/<scan id>
+		*outDir = filepath.Dir(filepath.Dir(filepath.Dir(filepath.Clean(dir))))
+	}
+
+	logger := logx.NewSilent()
+	target := manifest.Target
+	sources := buildReplaySources(cfg, logger, dir, names, target)
+	if len(sources) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: the capture holds no replayable source outputs")
+		return 1
+	}
+	defer func() {
+		for _, src := range sources {
+			src.Close()
+		}
+	}()
+
+	tagger, err := usecases.NewTaggingService(cfg.Tagging.Rules, logger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	orch := usecases.NewPipelineOrchestrator(usecases.PipelineOrchestratorOptions{
+		Sources:               sources,
+		SourceMetadata:        registry.Global().GetAllMetadata(),
+		Logger:                logger,
+		MaxWorkers:            max(1, cfg.Core.Workers),
+		ProviderConcurrency:   cfg.Core.ProviderConcurrency,
+		SkipStages:            cfg.Core.SkipStages,
+		CriticalSources:       cfg.CriticalSources(),
+		CriticalStages:        cfg.Core.CriticalStages,
+		Presenter:             ui.NewNopPresenter(),
+		Tagger:                tagger,
+		Noise:                 newNoiseService(cfg, logger),
+		MinRelationConfidence: cfg.Output.MinRelationConfidence,
+	})
+	result, err := orch.Run(ctx, target)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: replay failed: %v\n", err)
+		return 1
+	}
+
+	result.Metadata.Version = version
+	result.Metadata.Environment = map[string]string{
+		"commit":    commit,
+		"date":      date,
+		"replay_of": manifest.ScanID,
+	}
+
+	if !*quiet {
+		if err := output.OutputTable(result); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+	path, err := output.WriteJSON(*outDir, result, compress.None, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Replayed %s (%d sources, %d artifacts): %s\n",
+		manifest.ScanID, len(sources), len(result.Artifacts), path)
+	return 0
+}
+
+// buildReplaySources builds the captured sources with their configured
+// options and wraps them so the pipeline replays them instead of running
+// them. Sources that cannot replay their captures are skipped with a warning.
+func buildReplaySources(cfg config.Config, logger logx.Logger, dir string, names []string, target domain.Target) []ports.Source {
+	var sources []ports.Source
+	for _, name := range names {
+		sc := cfg.Source.Sources[name]
+		sc.Enabled = true
+		custom := make(map[string]interface{}, len(sc.Custom)+1)
+		for k, v := range sc.Custom {
+			custom[k] = v
+		}
+		custom["active_mode"] = target.Mode == domain.ScanModeActive
+		sc.Custom = custom
+
+		src, err := registry.Global().BuildSource(name, sc, logger)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", name, err)
+			continue
+		}
+		replayable, ok := src.(ports.ReplayableSource)
+		if !ok {
+			src.Close()
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s: source does not support replay\n", name)
+			continue
+		}
+		sources = append(sources, &replaySource{ReplayableSource: replayable, dir: filepath.Join(dir, name)})
+	}
+	return sources
+}
+
+// replaySource runs a source from its raw capture instead of querying.
+type replaySource struct {
+	ports.ReplayableSource
+	dir string
+}
+
+// Run replays the capture.
+func (r *replaySource) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	return r.Replay(ctx, target, r.dir)
+}
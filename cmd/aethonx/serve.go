@@ -0,0 +1,154 @@
+// cmd/aethonx/serve.go
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"aethonx/internal/adapters/output"
+	"aethonx/internal/adapters/web"
+	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/config"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/ui"
+
+	"github.com/spf13/pflag"
+)
+
+// runServe implements "aethonx serve": embedded web dashboard + REST API.
+func runServe(args []string) int {
+	cfg := config.FromEnv()
+
+	fs := pflag.NewFlagSet("serve", pflag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:8080", "Listen address for the dashboard (requests must use this host or localhost)")
+	fs.StringVarP(&cfg.Output.Dir, "out", "o", cfg.Output.Dir, "Output directory with scan results")
+	fs.IntVarP(&cfg.Core.Workers, "workers", "w", cfg.Core.Workers, "Concurrent workers for scans launched from the dashboard")
+	fs.IntVarP(&cfg.Core.TimeoutS, "timeout", "T", cfg.Core.TimeoutS, "Timeout in seconds for scans launched from the dashboard (0=none)")
+	readOnly := fs.Bool("read-only", false, "Disable launching scans from the dashboard")
+	fs.StringVar(&cfg.Output.LogFile, "log-file", cfg.Output.LogFile, "Also write logs to this file (rotated)")
+	fs.StringVar(&cfg.Core.Normalization, "normalization", cfg.Core.Normalization, "Domain normalization policy: strict, aggressive")
+	fs.BoolVar(&cfg.Output.IncludeProvenance, "include-provenance", cfg.Output.IncludeProvenance, "Include per-source provenance in JSON output")
+	fs.BoolVar(&cfg.Lifecycle.Enabled, "track-lifecycle", cfg.Lifecycle.Enabled, "Track first/last seen per artifact across dashboard scans")
+	fs.StringVar(&cfg.Tagging.RulesFile, "tag-rules", cfg.Tagging.RulesFile, "YAML file with artifact tagging rules")
+	fs.StringVar(&cfg.Output.SigningKey, "sign-key", cfg.Output.SigningKey, "Sign the consolidated JSON of dashboard scans with this ed25519 key")
+	fs.StringSliceVar(&cfg.Output.Redact, "redact", cfg.Output.Redact, "Redaction profile for dashboard scan JSON: full, client-safe")
+	fs.StringVar(&cfg.Output.Encrypt, "encrypt", cfg.Output.Encrypt, "Encrypt the JSON of dashboard scans: age, gpg")
+	fs.StringSliceVar(&cfg.Output.EncryptTo, "encrypt-to", cfg.Output.EncryptTo, "Encryption recipient: key or recipients file (repeatable)")
+	fs.StringVar(&cfg.Output.Compress, "compress", cfg.Output.Compress, "Compress the JSON of dashboard scans: gzip, zstd")
+	fs.StringSliceVar(&cfg.Network.UpstreamRates, "upstream-rate", cfg.Network.UpstreamRates, "Shared budget per upstream across dashboard scans: <upstream>=<rps>[/<burst>]")
+
+	if err := fs.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	policy, err := cfg.NormalizationPolicy()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	domain.SetNormalizationPolicy(policy)
+	domain.SetProvenanceRecording(cfg.Output.IncludeProvenance)
+
+	// Concurrent dashboard scans share the per-upstream budgets
+	if err := configureUpstreamRates(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	if cfg.Tagging.RulesFile != "" {
+		rules, err := config.LoadTagRules(cfg.Tagging.RulesFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		cfg.Tagging.Rules = rules
+	}
+
+	protection, err := loadOutputProtection(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if _, err := cfg.Compression(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	logger, logFile, err := attachLogFile(logx.New(), cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if logFile != nil {
+		defer logFile.Close()
+	}
+
+	var runner web.ScanRunner
+	if !*readOnly {
+		runner = newDashboardRunner(cfg, protection, logger)
+	}
+
+	server := web.NewServer(web.Options{
+		Addr:      *addr,
+		OutputDir: cfg.Output.Dir,
+		Runner:    runner,
+		Logger:    logger,
+	})
+
+	ctx, cancel := rootContextWithSignals(0)
+	defer cancel()
+
+	fmt.Fprintf(os.Stderr, "AethonX dashboard: http://%s\n", *addr)
+	if err := server.ListenAndServe(ctx); err != nil {
+		logger.Err(err, "phase", "serve")
+		return 1
+	}
+
+	return 0
+}
+
+// newDashboardRunner adapts runScan to web.ScanRunner using cfg as template.
+func newDashboardRunner(base config.Config, protection outputProtection, logger logx.Logger) web.ScanRunner {
+	return func(ctx context.Context, req web.ScanRequest, presenter ui.Presenter) error {
+		cfg := base
+		cfg.Core.Target = req.Target
+		cfg.Core.Active = req.Active
+
+		// Each scan mutates Custom maps (active_mode), so copy source configs
+		cfg.Source.Sources = config.CloneSources(base.Source.Sources)
+
+		if cfg.Core.TimeoutS > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, cfg.Timeout())
+			defer cancel()
+		}
+
+		result, runErr := runScan(ctx, cfg, logger.With("target", req.Target), presenter)
+		if result != nil && cfg.Lifecycle.Enabled {
+			trackLifecycle(ctx, cfg, result, runErr, logger)
+		}
+		if result != nil {
+			if !cfg.Output.IncludeProvenance {
+				result = result.WithoutProvenance()
+			}
+			codec, err := cfg.Compression()
+			if err != nil {
+				return err
+			}
+			status, reason := scanStatus(result, runErr, ctx.Err())
+			manifest := output.NewScanManifest(result, status, reason)
+			if err := writeConsolidatedJSON(cfg.Output.Dir, result, codec, cfg.Output.Shard, protection, manifest); err != nil {
+				return err
+			}
+			if err := writeManifest(cfg.Output.Dir, result, protection, manifest); err != nil {
+				return err
+			}
+		}
+		return runErr
+	}
+}
@@ -0,0 +1,149 @@
+// cmd/aethonx/sources.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/config"
+	"aethonx/internal/platform/registry"
+
+	"github.com/spf13/pflag"
+)
+
+// Install status values of sourceInfo.Status.
+const (
+	statusBuiltin   = "builtin"   // No external binary needed
+	statusInstalled = "installed" // Binary found in PATH
+	statusMissing   = "missing"   // Binary not found (run aethonx deps install)
+)
+
+// sourceInfo is one row of "aethonx sources".
+type sourceInfo struct {
+	Name         string                `json:"name"`
+	Description  string                `json:"description"`
+	Mode         domain.SourceMode     `json:"mode"`
+	Type         domain.SourceType     `json:"type"`
+	StageHint    int                   `json:"stage_hint"` // 0 = auto-detected from inputs
+	Phase        string                `json:"phase"`      // --skip-stages name
+	Inputs       []domain.ArtifactType `json:"inputs"`
+	Outputs      []domain.ArtifactType `json:"outputs"`
+	RequiresAuth bool                  `json:"requires_auth"`
+	Enabled      bool                  `json:"enabled"`
+	Binary       string                `json:"binary,omitempty"`
+	BinaryPath   string                `json:"binary_path,omitempty"`
+	Status       string                `json:"status"`
+}
+
+// runSources implements "aethonx sources": list registered sources with their
+// capabilities and whether their external binaries are installed.
+func runSources(args []string) int {
+	fs := pflag.NewFlagSet("sources", pflag.ContinueOnError)
+	format := fs.String("format", "table", "Output format: table, json")
+	configPath := fs.String("config", "", "YAML config file (default: AETHONX_CONFIG)")
+
+	if err := fs.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if *format != "table" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use table or json)\n", *format)
+		return 2
+	}
+
+	// Configuration decides enabled state and exec_path overrides
+	cfg, err := config.FromFile(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	infos := collectSources(registry.Global(), cfg)
+
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(infos); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tMODE\tTYPE\tSTAGE\tPHASE\tINPUTS\tOUTPUTS\tAUTH\tENABLED\tSTATUS")
+	for _, s := range infos {
+		stage := "auto"
+		if s.StageHint > 0 {
+			stage = fmt.Sprintf("%d", s.StageHint)
+		}
+		status := s.Status
+		if s.Status == statusMissing {
+			status = fmt.Sprintf("%s (%s)", s.Status, s.Binary)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.Name, s.Mode, s.Type, stage, s.Phase,
+			joinArtifactTypes(s.Inputs), joinArtifactTypes(s.Outputs),
+			yesNo(s.RequiresAuth), yesNo(s.Enabled), status)
+	}
+	w.Flush()
+	return 0
+}
+
+// collectSources builds the listing from registry metadata, sorted by name.
+func collectSources(r *registry.SourceRegistry, cfg config.Config) []sourceInfo {
+	all := r.GetAllMetadata()
+	infos := make([]sourceInfo, 0, len(all))
+	for name, meta := range all {
+		sc := cfg.Source.Sources[name]
+		info := sourceInfo{
+			Name:         name,
+			Description:  meta.Description,
+			Mode:         meta.Mode,
+			Type:         meta.Type,
+			StageHint:    meta.StageHint,
+			Phase:        meta.StagePhase(),
+			Inputs:       meta.InputArtifacts,
+			Outputs:      meta.OutputArtifacts,
+			RequiresAuth: meta.RequiresAuth,
+			Enabled:      sc.Enabled,
+			Status:       statusBuiltin,
+		}
+		info.Binary, info.BinaryPath = registry.BinaryStatus(name, meta, sc)
+		if info.Binary != "" {
+			info.Status = statusInstalled
+			if info.BinaryPath == "" {
+				info.Status = statusMissing
+			}
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+func joinArtifactTypes(types []domain.ArtifactType) string {
+	if len(types) == 0 {
+		return "-"
+	}
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ",")
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,119 @@
+// cmd/aethonx/verify.go
+package main
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"aethonx/internal/adapters/output"
+	"aethonx/internal/platform/compress"
+
+	"github.com/spf13/pflag"
+)
+
+// runVerify implements "aethonx verify": checks the detached ed25519
+// signature of a consolidated scan and prints its metadata.
+// Exit codes: 0 valid, 1 invalid/tampered/untrusted, 2 usage error.
+func runVerify(args []string) int {
+	fs := pflag.NewFlagSet("verify", pflag.ContinueOnError)
+	scanPath := fs.String("scan", "", "Signed scan JSON or report to verify (required)")
+	sigPath := fs.String("sig", "", "Signature file (default: <scan>.sig)")
+	pubKeyPath := fs.String("pubkey", "", "Trusted ed25519 public key (PEM or base64); without it only integrity is checked")
+
+	if err := fs.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if *scanPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: --scan is required")
+		return 2
+	}
+
+	var trusted ed25519.PublicKey
+	if *pubKeyPath != "" {
+		key, err := output.LoadPublicKey(*pubKeyPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		trusted = key
+	}
+
+	sig, err := output.VerifyFile(*scanPath, *sigPath, trusted)
+	if err != nil {
+		switch {
+		case errors.Is(err, output.ErrSignatureMismatch):
+			fmt.Fprintf(os.Stderr, "INVALID: %s was modified after signing or the signature is corrupt\n", *scanPath)
+		case errors.Is(err, output.ErrUntrustedKey):
+			fmt.Fprintf(os.Stderr, "UNTRUSTED: %v\n", err)
+		default:
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		return 1
+	}
+
+	fmt.Printf("Signature:   VALID (%s)\n", sig.Algorithm)
+	fmt.Printf("Signer:      %s\n", sig.Fingerprint)
+	fmt.Printf("Signed at:   %s\n", sig.SignedAt.Format(time.RFC3339))
+	fmt.Printf("SHA-256:     %s\n", sig.SHA256)
+
+	// Encrypted results are signed as ciphertext and reports are not scans:
+	// only JSON scans carry metadata to display
+	if !strings.HasSuffix(compress.TrimExtension(*scanPath), ".json") {
+		if isEncryptedOutput(*scanPath) {
+			fmt.Println("Scan:        encrypted (decrypt it to inspect metadata)")
+		}
+		warnUntrusted(trusted)
+		return 0
+	}
+
+	// A signed index.json covers its shards through their SHA-256, which
+	// ReadJSON checks while loading them
+	if output.IsSharded(*scanPath) {
+		if index, _, err := output.ReadShardIndex(*scanPath); err == nil && isEncryptedOutput(index.Scan.File) {
+			fmt.Println("Scan:        sharded, encrypted (decrypt the shards to inspect metadata)")
+			warnUntrusted(trusted)
+			return 0
+		}
+	}
+
+	result, err := output.ReadJSON(*scanPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: signature is valid but the scan cannot be parsed: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Scan ID:     %s\n", result.ID)
+	fmt.Printf("Target:      %s\n", result.Target.Root)
+	fmt.Printf("Started:     %s\n", result.Metadata.StartTime.Format(time.RFC3339))
+	fmt.Printf("Finished:    %s\n", result.Metadata.EndTime.Format(time.RFC3339))
+	fmt.Printf("Artifacts:   %d\n", len(result.Artifacts))
+	fmt.Printf("Sources:     %s\n", strings.Join(result.Metadata.SourcesUsed, ", "))
+	fmt.Printf("Version:     %s\n", result.Metadata.Version)
+
+	warnUntrusted(trusted)
+	return 0
+}
+
+func warnUntrusted(trusted ed25519.PublicKey) {
+	if trusted == nil {
+		fmt.Fprintln(os.Stderr, "Warning: no --pubkey given; the file is intact but the signer is not authenticated")
+	}
+}
+
+// isEncryptedOutput reports whether path was written with --encrypt.
+func isEncryptedOutput(path string) bool {
+	for _, method := range output.EncryptionMethods {
+		if strings.HasSuffix(path, "."+method) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+// cmd/aethonx/workspace.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"aethonx/internal/platform/workspace"
+
+	"github.com/spf13/pflag"
+)
+
+const workspaceUsage = `Usage: aethonx workspace <command> [options]
+
+Commands:
+  list                     List workspaces
+  create <name>            Create a workspace (config.env, scope.txt, scans/, cache/, wordlists/)
+  clean <name>             Delete old scans (--keep <n>) and optionally the cache (--cache)
+
+Workspaces live in ~/.aethonx/workspaces (override with AETHONX_WORKSPACES_DIR).
+Scan inside one with: aethonx --workspace <name> [-t <domain>]
+`
+
+// runWorkspace implements "aethonx workspace list|create|clean".
+func runWorkspace(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(os.Stderr, workspaceUsage)
+		return 2
+	}
+
+	base, err := workspace.BaseDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	switch args[0] {
+	case "list":
+		return workspaceList(base)
+	case "create":
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, "Usage: aethonx workspace create <name>")
+			return 2
+		}
+		ws, err := workspace.Create(base, args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Println(ws.Path)
+		return 0
+	case "clean":
+		return workspaceClean(base, args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown workspace command %q\n\n%s", args[0], workspaceUsage)
+		return 2
+	}
+}
+
+func workspaceList(base string) int {
+	infos, err := workspace.List(base)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if len(infos) == 0 {
+		fmt.Fprintf(os.Stderr, "No workspaces in %s (create one with: aethonx workspace create <name>)\n", base)
+		return 0
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSCANS\tSIZE\tMODIFIED\tPATH")
+	for _, info := range infos {
+		fmt.Fprintf(w, "%s\t%d\t%.1f MB\t%s\t%s\n",
+			info.Name,
+			info.Scans,
+			float64(info.SizeBytes)/(1024*1024),
+			info.Modified.Format("2006-01-02 15:04"),
+			info.Path,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func workspaceClean(base string, args []string) int {
+	fs := pflag.NewFlagSet("workspace clean", pflag.ContinueOnError)
+	keep := fs.Int("keep", 0, "Most recent scan files to keep")
+	cache := fs.Bool("cache", false, "Also empty the workspace cache")
+	if err := fs.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: aethonx workspace clean <name> [--keep <n>] [--cache]")
+		return 2
+	}
+
+	ws, err := workspace.Open(base, fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	removed, err := ws.Clean(workspace.CleanOptions{KeepScans: *keep, Cache: *cache})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "Removed %d file(s) from workspace %s\n", removed, ws.Name)
+	return 0
+}
@@ -0,0 +1,153 @@
+// internal/adapters/agent/client.go
+package agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+)
+
+// Client es un agente remoto visto desde el coordinador (ports.Agent).
+type Client struct {
+	endpoint string
+	token    string
+	http     *http.Client
+}
+
+var _ ports.Agent = (*Client)(nil)
+
+// NewClient crea el cliente de un agente en endpoint (https://host:port).
+func NewClient(endpoint string, tlsConfig *tls.Config, token string) (*Client, error) {
+	endpoint = strings.TrimRight(endpoint, "/")
+	if !strings.HasPrefix(endpoint, "https://") {
+		return nil, fmt.Errorf("agent endpoint %q must use https://", endpoint)
+	}
+
+	transport := &http.Transport{
+		TLSClientConfig:       tlsConfig,
+		ForceAttemptHTTP2:     true,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+		IdleConnTimeout:       90 * time.Second,
+	}
+	return &Client{
+		endpoint: endpoint,
+		token:    token,
+		// Sin timeout global: una source puede ejecutar minutos (manda ctx)
+		http: &http.Client{Transport: transport},
+	}, nil
+}
+
+// Endpoint retorna la URL del agente.
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
+// Info consulta capacidades y carga del agente.
+func (c *Client) Info(ctx context.Context) (ports.AgentInfo, error) {
+	var info ports.AgentInfo
+
+	resp, err := c.do(ctx, http.MethodGet, infoPath, nil)
+	if err != nil {
+		return info, err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return info, fmt.Errorf("%w: invalid info response: %v", ports.ErrAgentUnavailable, err)
+	}
+	return info, nil
+}
+
+// RunSource ejecuta la source en el agente leyendo el stream de artifacts.
+// Los fallos de transporte (incluido un stream truncado) retornan
+// ports.ErrAgentUnavailable; el error de la propia source se retorna tal cual.
+func (c *Client) RunSource(ctx context.Context, req ports.AgentRunRequest, onArtifact func(*domain.Artifact)) (*domain.ScanResult, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encode run request: %w", err)
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, runPath, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := domain.NewScanResult(req.Target)
+	received := 0
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var msg streamMessage
+		if err := dec.Decode(&msg); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("%w: stream interrupted after %d artifacts: %v",
+				ports.ErrAgentUnavailable, received, err)
+		}
+
+		switch {
+		case msg.Artifact != nil:
+			received++
+			result.AddArtifact(msg.Artifact)
+			if onArtifact != nil {
+				onArtifact(msg.Artifact)
+			}
+		case msg.Done != nil:
+			if msg.Done.Artifacts != received {
+				return nil, fmt.Errorf("%w: received %d of %d artifacts",
+					ports.ErrAgentUnavailable, received, msg.Done.Artifacts)
+			}
+			result.Warnings = append(result.Warnings, msg.Done.Warnings...)
+			result.Errors = append(result.Errors, msg.Done.Errors...)
+			if msg.Done.Error != "" {
+				return result, fmt.Errorf("%s", msg.Done.Error)
+			}
+			return result, nil
+		}
+		// Heartbeat: nada que hacer
+	}
+}
+
+// do envía la petición autenticada. Cualquier fallo o estado != 200 se
+// considera agente no disponible.
+func (c *Client) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ports.ErrAgentUnavailable, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: %v", ports.ErrAgentUnavailable, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s: %s", ports.ErrAgentUnavailable, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
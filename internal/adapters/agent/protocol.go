@@ -0,0 +1,39 @@
+// internal/adapters/agent/protocol.go
+// Package agent implementa los agentes remotos de escaneo distribuido: el
+// servidor que ejecuta sources en un host remoto y el cliente (ports.Agent)
+// con el que el coordinador le asigna sources.
+//
+// Transporte: HTTP/2 sobre TLS (mutuo si el agente exige certificado de
+// cliente) más un token bearer opcional.
+//
+//	GET  /v1/info  -> ports.AgentInfo
+//	POST /v1/run   ports.AgentRunRequest -> stream JSON de streamMessage
+//
+// El stream de /v1/run emite heartbeats mientras la source ejecuta, después
+// un mensaje por artifact (sin documento JSON gigante en memoria) y un mensaje
+// final "done" con warnings, errores y el recuento enviado.
+package agent
+
+import (
+	"aethonx/internal/core/domain"
+)
+
+const (
+	infoPath = "/v1/info"
+	runPath  = "/v1/run"
+)
+
+// streamMessage es un mensaje del stream de /v1/run (exactamente un campo).
+type streamMessage struct {
+	Heartbeat bool             `json:"heartbeat,omitempty"`
+	Artifact  *domain.Artifact `json:"artifact,omitempty"`
+	Done      *runSummary      `json:"done,omitempty"`
+}
+
+// runSummary cierra el stream con el resto del ScanResult de la source.
+type runSummary struct {
+	Artifacts int              `json:"artifacts"` // Artifacts enviados (detecta streams truncados)
+	Warnings  []domain.Warning `json:"warnings,omitempty"`
+	Errors    []domain.Error   `json:"errors,omitempty"`
+	Error     string           `json:"error,omitempty"` // Error de la source ("" = éxito)
+}
@@ -0,0 +1,286 @@
+// internal/adapters/agent/server.go
+package agent
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+)
+
+// heartbeatInterval mantiene vivo el stream mientras la source ejecuta.
+const heartbeatInterval = 15 * time.Second
+
+// ServerOptions configuración del agente.
+type ServerOptions struct {
+	Addr          string         // Dirección de escucha (e.g., ":7443")
+	ID            string         // Identificador del agente (default: Addr)
+	Region        string         // Región/egress informativo para el coordinador
+	Version       string         // Versión de AethonX del agente
+	Sources       []ports.Source // Sources construidas que el agente puede ejecutar
+	MaxConcurrent int            // Sources simultáneas (default: 4); el resto espera
+	Token         string         // Token bearer exigido al coordinador ("" = solo TLS)
+	TLS           *tls.Config    // Certificado del agente y, opcional, CA de clientes
+	Logger        logx.Logger
+}
+
+// Server ejecuta sources por encargo de un coordinador.
+type Server struct {
+	opts    ServerOptions
+	logger  logx.Logger
+	sources map[string]*hostedSource
+	slots   chan struct{}
+	srv     *http.Server
+
+	mu      sync.Mutex
+	running int // Ejecuciones en curso o esperando slot
+}
+
+// hostedSource serializa las ejecuciones de una misma instancia de source.
+type hostedSource struct {
+	mu     sync.Mutex
+	source ports.Source
+}
+
+// NewServer crea un agente sobre las sources dadas.
+func NewServer(opts ServerOptions) *Server {
+	if opts.MaxConcurrent <= 0 {
+		opts.MaxConcurrent = 4
+	}
+	if opts.ID == "" {
+		opts.ID = opts.Addr
+	}
+	if opts.Logger == nil {
+		opts.Logger = logx.New()
+	}
+
+	s := &Server{
+		opts:    opts,
+		logger:  opts.Logger.With("component", "agent"),
+		sources: make(map[string]*hostedSource, len(opts.Sources)),
+		slots:   make(chan struct{}, opts.MaxConcurrent),
+	}
+	for _, src := range opts.Sources {
+		s.sources[src.Name()] = &hostedSource{source: src}
+	}
+
+	s.srv = &http.Server{
+		Addr:              opts.Addr,
+		Handler:           s.Handler(),
+		TLSConfig:         opts.TLS,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s
+}
+
+// Handler construye el router HTTP (útil para tests con httptest).
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+infoPath, s.handleInfo)
+	mux.HandleFunc("POST "+runPath, s.handleRun)
+	return s.authenticate(mux)
+}
+
+// ListenAndServe sirve por TLS hasta que ctx se cancela.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	if s.opts.TLS == nil {
+		return fmt.Errorf("agent requires TLS")
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		s.logger.Info("agent listening",
+			"addr", s.opts.Addr,
+			"id", s.opts.ID,
+			"sources", len(s.sources),
+			"mutual_tls", s.opts.TLS.ClientAuth == tls.RequireAndVerifyClientCert,
+		)
+		errCh <- s.srv.ListenAndServeTLS("", "")
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return s.srv.Shutdown(shutdownCtx)
+	}
+}
+
+// Info retorna las capacidades y la carga actuales.
+func (s *Server) Info() ports.AgentInfo {
+	names := make([]string, 0, len(s.sources))
+	for name := range s.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	s.mu.Lock()
+	running := s.running
+	s.mu.Unlock()
+
+	return ports.AgentInfo{
+		ID:            s.opts.ID,
+		Region:        s.opts.Region,
+		Version:       s.opts.Version,
+		Sources:       names,
+		MaxConcurrent: s.opts.MaxConcurrent,
+		Running:       running,
+	}
+}
+
+// authenticate exige el token bearer cuando está configurado.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	if s.opts.Token == "" {
+		return next
+	}
+	want := []byte("Bearer " + s.opts.Token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1 {
+			s.logger.Warn("rejected request with invalid token", "remote", r.RemoteAddr, "path", r.URL.Path)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleInfo(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.Info())
+}
+
+func (s *Server) handleRun(w http.ResponseWriter, r *http.Request) {
+	var req ports.AgentRunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	hosted, ok := s.sources[req.Source]
+	if !ok {
+		http.Error(w, fmt.Sprintf("source %q not available on this agent", req.Source), http.StatusNotFound)
+		return
+	}
+	if err := req.Target.Validate(); err != nil {
+		http.Error(w, "invalid target: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	enc := json.NewEncoder(w)
+	send := func(msg streamMessage) error {
+		if err := enc.Encode(msg); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+
+	s.track(1)
+	defer s.track(-1)
+
+	start := time.Now()
+	log := s.logger.With("source", req.Source, "target", req.Target.Root, "remote", r.RemoteAddr)
+	log.Info("run assigned")
+
+	// La source ejecuta en segundo plano; mientras tanto, heartbeats
+	type outcome struct {
+		result *domain.ScanResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := s.run(r.Context(), hosted, req)
+		done <- outcome{result, err}
+	}()
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	var out outcome
+	for waiting := true; waiting; {
+		select {
+		case out = <-done:
+			waiting = false
+		case <-ticker.C:
+			if err := send(streamMessage{Heartbeat: true}); err != nil {
+				log.Warn("coordinator disconnected", "error", err.Error())
+				<-done // r.Context() ya está cancelado
+				return
+			}
+		}
+	}
+
+	summary := &runSummary{}
+	if out.err != nil {
+		summary.Error = out.err.Error()
+	}
+	if out.result != nil {
+		for _, a := range out.result.Artifacts {
+			if err := send(streamMessage{Artifact: a}); err != nil {
+				log.Warn("coordinator disconnected", "error", err.Error())
+				return
+			}
+			summary.Artifacts++
+		}
+		summary.Warnings = out.result.Warnings
+		summary.Errors = out.result.Errors
+	}
+	send(streamMessage{Done: summary})
+
+	log.Info("run finished",
+		"artifacts", summary.Artifacts,
+		"duration_ms", time.Since(start).Milliseconds(),
+		"error", summary.Error,
+	)
+}
+
+// run espera slot y ejecuta la source, con input si es InputConsumer.
+func (s *Server) run(ctx context.Context, hosted *hostedSource, req ports.AgentRunRequest) (*domain.ScanResult, error) {
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	hosted.mu.Lock()
+	defer hosted.mu.Unlock()
+
+	if consumer, ok := hosted.source.(ports.InputConsumer); ok {
+		input := req.Input
+		if input == nil {
+			input = domain.NewScanResult(req.Target)
+		}
+		return consumer.RunWithInput(ctx, req.Target, input)
+	}
+	return hosted.source.Run(ctx, req.Target)
+}
+
+func (s *Server) track(delta int) {
+	s.mu.Lock()
+	s.running += delta
+	s.mu.Unlock()
+}
@@ -0,0 +1,176 @@
+package agent
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+)
+
+// echoSource devuelve un subdominio por artifact de input (o uno fijo sin input).
+type echoSource struct {
+	name string
+	err  error
+}
+
+func (e *echoSource) Name() string            { return e.name }
+func (e *echoSource) Mode() domain.SourceMode { return domain.SourceModePassive }
+func (e *echoSource) Type() domain.SourceType { return domain.SourceTypeAPI }
+func (e *echoSource) Close() error            { return nil }
+
+func (e *echoSource) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	return e.RunWithInput(ctx, target, domain.NewScanResult(target))
+}
+
+func (e *echoSource) RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	result := domain.NewScanResult(target)
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "agent."+target.Root, e.name))
+	for _, a := range input.Artifacts {
+		result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeURL, "https://"+a.Value, e.name))
+	}
+	result.AddWarning(e.name, "partial results")
+	return result, nil
+}
+
+// startAgent sirve un agente por TLS (HTTP/2) y retorna un cliente conectado.
+func startAgent(t *testing.T, opts ServerOptions, token string) (*Server, *Client) {
+	t.Helper()
+	opts.Logger = logx.NewSilent()
+	srv := NewServer(opts)
+
+	ts := httptest.NewUnstartedServer(srv.Handler())
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	t.Cleanup(ts.Close)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ts.Certificate())
+	client, err := NewClient(ts.URL, &tls.Config{RootCAs: roots}, token)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return srv, client
+}
+
+func TestAgent_Info(t *testing.T) {
+	_, client := startAgent(t, ServerOptions{
+		ID:      "eu-1",
+		Region:  "eu-west",
+		Sources: []ports.Source{&echoSource{name: "subfinder"}, &echoSource{name: "crtsh"}},
+	}, "")
+
+	info, err := client.Info(context.Background())
+	if err != nil {
+		t.Fatalf("Info() error = %v", err)
+	}
+	if info.ID != "eu-1" || info.Region != "eu-west" || info.MaxConcurrent != 4 {
+		t.Errorf("Info() = %+v", info)
+	}
+	if strings.Join(info.Sources, ",") != "crtsh,subfinder" {
+		t.Errorf("Sources = %v, want sorted [crtsh subfinder]", info.Sources)
+	}
+}
+
+func TestAgent_RunSource(t *testing.T) {
+	_, client := startAgent(t, ServerOptions{
+		Sources: []ports.Source{&echoSource{name: "httpx"}, &echoSource{name: "broken", err: fmt.Errorf("binary not found")}},
+	}, "")
+
+	target := *domain.NewTarget("example.com", domain.ScanModeActive)
+
+	t.Run("streams artifacts", func(t *testing.T) {
+		input := domain.NewScanResult(target)
+		input.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "crtsh"))
+
+		streamed := 0
+		result, err := client.RunSource(context.Background(), ports.AgentRunRequest{
+			Source: "httpx",
+			Target: target,
+			Input:  input,
+		}, func(*domain.Artifact) { streamed++ })
+		if err != nil {
+			t.Fatalf("RunSource() error = %v", err)
+		}
+		if len(result.Artifacts) != 2 || streamed != 2 {
+			t.Fatalf("got %d artifacts (%d streamed), want 2", len(result.Artifacts), streamed)
+		}
+		if result.Artifacts[1].Value != "https://api.example.com" {
+			t.Errorf("input not forwarded to the source: %q", result.Artifacts[1].Value)
+		}
+		if len(result.Warnings) != 1 {
+			t.Errorf("warnings = %v, want 1", result.Warnings)
+		}
+	})
+
+	t.Run("source error", func(t *testing.T) {
+		_, err := client.RunSource(context.Background(), ports.AgentRunRequest{Source: "broken", Target: target}, nil)
+		if err == nil || !strings.Contains(err.Error(), "binary not found") {
+			t.Fatalf("expected source error, got %v", err)
+		}
+		if errors.Is(err, ports.ErrAgentUnavailable) {
+			t.Error("a source error must not mark the agent unavailable")
+		}
+	})
+
+	t.Run("unknown source", func(t *testing.T) {
+		_, err := client.RunSource(context.Background(), ports.AgentRunRequest{Source: "amass", Target: target}, nil)
+		if !errors.Is(err, ports.ErrAgentUnavailable) {
+			t.Errorf("expected ErrAgentUnavailable, got %v", err)
+		}
+	})
+}
+
+func TestAgent_Token(t *testing.T) {
+	opts := ServerOptions{Sources: []ports.Source{&echoSource{name: "crtsh"}}, Token: "s3cret"}
+
+	_, client := startAgent(t, opts, "wrong")
+	if _, err := client.Info(context.Background()); !errors.Is(err, ports.ErrAgentUnavailable) || !strings.Contains(err.Error(), "401") {
+		t.Errorf("wrong token: expected 401 ErrAgentUnavailable, got %v", err)
+	}
+
+	_, client = startAgent(t, opts, "s3cret")
+	if _, err := client.Info(context.Background()); err != nil {
+		t.Errorf("valid token: Info() error = %v", err)
+	}
+}
+
+func TestClient_TruncatedStream(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Un artifact y el agente muere antes de "done"
+		fmt.Fprintln(w, `{"artifact":{"type":"subdomain","value":"a.example.com"}}`)
+	}))
+	defer ts.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ts.Certificate())
+	client, err := NewClient(ts.URL, &tls.Config{RootCAs: roots}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.RunSource(context.Background(), ports.AgentRunRequest{
+		Source: "crtsh",
+		Target: *domain.NewTarget("example.com", domain.ScanModePassive),
+	}, nil)
+	if !errors.Is(err, ports.ErrAgentUnavailable) {
+		t.Errorf("expected ErrAgentUnavailable for a truncated stream, got %v", err)
+	}
+}
+
+func TestNewClient_RequiresHTTPS(t *testing.T) {
+	if _, err := NewClient("http://agent:7443", nil, ""); err == nil {
+		t.Error("expected an error for a plaintext endpoint")
+	}
+}
@@ -0,0 +1,194 @@
+// internal/adapters/integrations/defectdojo.go
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"aethonx/internal/core/domain"
+)
+
+const (
+	// defectDojoImportPath reimporta sobre el mismo test: los hallazgos que
+	// desaparecen se cierran y los que vuelven se reactivan
+	defectDojoImportPath = "/api/v2/reimport-scan/"
+
+	// defectDojoScanType es el parser genérico de DefectDojo (JSON)
+	defectDojoScanType = "Generic Findings Import"
+
+	// DefaultDefectDojoEngagement es el engagement si no se configura otro.
+	DefaultDefectDojoEngagement = "AethonX recon"
+)
+
+// DefectDojoOptions configura la importación en DefectDojo. Product y
+// Engagement identifican dónde caen los hallazgos; con auto_create_context se
+// crean si no existen (un producto nuevo necesita ProductType).
+type DefectDojoOptions struct {
+	URL         string
+	Token       string // API v2 key
+	Product     string
+	ProductType string
+	Engagement  string
+	MinSeverity string // Severidad mínima exportada ("" = todas)
+	HTTPClient  *http.Client
+}
+
+// DefectDojo importa los hallazgos de un escaneo con la API de import de
+// DefectDojo (Generic Findings Import), un test por target.
+type DefectDojo struct {
+	opts DefectDojoOptions
+	http *http.Client
+}
+
+var _ Exporter = (*DefectDojo)(nil)
+
+// NewDefectDojo valida las opciones y crea el exporter.
+func NewDefectDojo(opts DefectDojoOptions) (*DefectDojo, error) {
+	base, err := checkBaseURL("defectdojo", opts.URL)
+	if err != nil {
+		return nil, err
+	}
+	opts.URL = base
+	if opts.Token == "" {
+		return nil, fmt.Errorf("defectdojo requires an API token (AETHONX_DEFECTDOJO_TOKEN)")
+	}
+	if strings.TrimSpace(opts.Product) == "" {
+		return nil, fmt.Errorf("defectdojo requires a product")
+	}
+	if strings.TrimSpace(opts.Engagement) == "" {
+		opts.Engagement = DefaultDefectDojoEngagement
+	}
+
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: requestTimeout}
+	}
+	return &DefectDojo{opts: opts, http: client}, nil
+}
+
+// Name retorna el nombre de la plataforma.
+func (d *DefectDojo) Name() string { return "defectdojo" }
+
+// defectDojoFinding es un hallazgo del formato Generic Findings Import.
+type defectDojoFinding struct {
+	Title          string               `json:"title"`
+	Description    string               `json:"description"`
+	Severity       string               `json:"severity"` // Critical, High, Medium, Low, Info
+	Date           string               `json:"date"`
+	CVE            string               `json:"cve,omitempty"`
+	CVSSv3Score    float64              `json:"cvssv3_score,omitempty"`
+	References     string               `json:"references,omitempty"`
+	UniqueIDTool   string               `json:"unique_id_from_tool"`
+	VulnIDFromTool string               `json:"vuln_id_from_tool,omitempty"`
+	StaticFinding  bool                 `json:"static_finding"`
+	DynamicFinding bool                 `json:"dynamic_finding"`
+	Endpoints      []defectDojoEndpoint `json:"endpoints,omitempty"`
+}
+
+type defectDojoEndpoint struct {
+	Protocol string `json:"protocol,omitempty"`
+	Host     string `json:"host"`
+	Port     int    `json:"port,omitempty"`
+	Path     string `json:"path,omitempty"`
+}
+
+// Export reimporta los hallazgos en el test "AethonX <target>" del engagement.
+func (d *DefectDojo) Export(ctx context.Context, result *domain.ScanResult) (int, error) {
+	issues := collectIssues(result, d.opts.MinSeverity)
+	report, err := json.Marshal(map[string]interface{}{"findings": defectDojoFindings(result, issues)})
+	if err != nil {
+		return 0, fmt.Errorf("encode defectdojo findings: %w", err)
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	fields := map[string]string{
+		"scan_type":           defectDojoScanType,
+		"product_name":        d.opts.Product,
+		"engagement_name":     d.opts.Engagement,
+		"test_title":          toolName + " " + result.Target.Root,
+		"scan_date":           scanDate(result).Format("2006-01-02"),
+		"auto_create_context": "true",
+		"minimum_severity":    "Info",
+		"active":              "true",
+		"verified":            "false",
+	}
+	if d.opts.ProductType != "" {
+		fields["product_type_name"] = d.opts.ProductType
+	}
+	for k, v := range fields {
+		if err := form.WriteField(k, v); err != nil {
+			return 0, err
+		}
+	}
+	file, err := form.CreateFormFile("file", "aethonx_"+result.ID+".json")
+	if err != nil {
+		return 0, err
+	}
+	if _, err := file.Write(report); err != nil {
+		return 0, err
+	}
+	if err := form.Close(); err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.opts.URL+defectDojoImportPath, &body)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Token "+d.opts.Token)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	resp, err := d.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("defectdojo import: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return 0, apiError("defectdojo", resp)
+	}
+	return len(issues), nil
+}
+
+// defectDojoFindings traduce los issues al formato Generic Findings Import.
+func defectDojoFindings(result *domain.ScanResult, issues []issue) []defectDojoFinding {
+	date := scanDate(result).Format("2006-01-02")
+	findings := make([]defectDojoFinding, 0, len(issues))
+	for _, is := range issues {
+		f := defectDojoFinding{
+			Title:          is.Title,
+			Description:    is.Description,
+			Severity:       capitalize(is.Severity),
+			Date:           date,
+			CVE:            is.CVE,
+			CVSSv3Score:    is.CVSSScore,
+			References:     strings.Join(is.References, "\n"),
+			UniqueIDTool:   is.ID,
+			VulnIDFromTool: is.CVE,
+			DynamicFinding: true,
+		}
+		if is.Host != "" {
+			f.Endpoints = []defectDojoEndpoint{{
+				Protocol: is.Scheme,
+				Host:     is.Host,
+				Port:     is.Port,
+				Path:     is.Path,
+			}}
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// capitalize convierte "high" en "High" (severidades de DefectDojo).
+func capitalize(s string) string {
+	if s == "" {
+		return "Info"
+	}
+	return strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+}
@@ -0,0 +1,219 @@
+// internal/adapters/integrations/faraday.go
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"aethonx/internal/core/domain"
+)
+
+// FaradayOptions configura la importación en un workspace de Faraday.
+type FaradayOptions struct {
+	URL         string
+	Token       string // API token
+	Workspace   string // Workspace de Faraday (debe existir)
+	MinSeverity string // Severidad mínima exportada ("" = todas)
+	HTTPClient  *http.Client
+}
+
+// Faraday crea hosts, servicios y vulnerabilidades en un workspace de
+// Faraday con su API bulk_create.
+type Faraday struct {
+	opts FaradayOptions
+	http *http.Client
+}
+
+var _ Exporter = (*Faraday)(nil)
+
+// NewFaraday valida las opciones y crea el exporter.
+func NewFaraday(opts FaradayOptions) (*Faraday, error) {
+	base, err := checkBaseURL("faraday", opts.URL)
+	if err != nil {
+		return nil, err
+	}
+	opts.URL = base
+	if opts.Token == "" {
+		return nil, fmt.Errorf("faraday requires an API token (AETHONX_FARADAY_TOKEN)")
+	}
+	if strings.TrimSpace(opts.Workspace) == "" {
+		return nil, fmt.Errorf("faraday requires a workspace")
+	}
+
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: requestTimeout}
+	}
+	return &Faraday{opts: opts, http: client}, nil
+}
+
+// Name retorna el nombre de la plataforma.
+func (f *Faraday) Name() string { return "faraday" }
+
+type faradayBulk struct {
+	Hosts   []*faradayHost `json:"hosts"`
+	Command faradayCommand `json:"command"`
+}
+
+type faradayCommand struct {
+	Tool         string `json:"tool"`
+	Command      string `json:"command"`
+	Params       string `json:"params"`
+	User         string `json:"user"`
+	Hostname     string `json:"hostname"`
+	ImportSource string `json:"import_source"`
+	StartDate    string `json:"start_date"`
+	EndDate      string `json:"end_date"`
+}
+
+type faradayHost struct {
+	IP              string            `json:"ip"`
+	Description     string            `json:"description"`
+	Hostnames       []string          `json:"hostnames"`
+	Services        []*faradayService `json:"services"`
+	Vulnerabilities []faradayVuln     `json:"vulnerabilities"`
+}
+
+type faradayService struct {
+	Name            string        `json:"name"`
+	Port            int           `json:"port"`
+	Protocol        string        `json:"protocol"`
+	Status          string        `json:"status"`
+	Vulnerabilities []faradayVuln `json:"vulnerabilities"`
+}
+
+type faradayVuln struct {
+	Name       string       `json:"name"`
+	Desc       string       `json:"desc"`
+	Severity   string       `json:"severity"` // critical, high, medium, low, informational
+	Type       string       `json:"type"`     // Vulnerability, VulnerabilityWeb
+	Website    string       `json:"website,omitempty"`
+	Path       string       `json:"path,omitempty"`
+	ExternalID string       `json:"external_id"`
+	CVE        []string     `json:"cve,omitempty"`
+	Refs       []faradayRef `json:"refs,omitempty"`
+	Status     string       `json:"status"`
+}
+
+type faradayRef struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Export envía los hallazgos agrupados por host y servicio.
+func (f *Faraday) Export(ctx context.Context, result *domain.ScanResult) (int, error) {
+	issues := collectIssues(result, f.opts.MinSeverity)
+	body, err := json.Marshal(faradayPayload(result, issues))
+	if err != nil {
+		return 0, fmt.Errorf("encode faraday payload: %w", err)
+	}
+
+	endpoint := f.opts.URL + "/_api/v3/ws/" + url.PathEscape(f.opts.Workspace) + "/bulk_create"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Token "+f.opts.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("faraday import: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return 0, apiError("faraday", resp)
+	}
+	return len(issues), nil
+}
+
+// faradayPayload agrupa los issues por host; los que tienen puerto cuelgan
+// de su servicio como VulnerabilityWeb (si hay esquema) o Vulnerability.
+func faradayPayload(result *domain.ScanResult, issues []issue) faradayBulk {
+	hosts := make(map[string]*faradayHost)
+	services := make(map[string]*faradayService)
+	for _, is := range issues {
+		h, ok := hosts[is.Host]
+		if !ok {
+			h = &faradayHost{
+				IP:              is.Host,
+				Description:     "Discovered by " + toolName,
+				Hostnames:       []string{},
+				Services:        []*faradayService{},
+				Vulnerabilities: []faradayVuln{},
+			}
+			if is.Host != "" && net.ParseIP(is.Host) == nil {
+				h.Hostnames = append(h.Hostnames, is.Host)
+			}
+			hosts[is.Host] = h
+		}
+
+		v := faradayVuln{
+			Name:       is.Title,
+			Desc:       is.Description,
+			Severity:   is.Severity,
+			Type:       "Vulnerability",
+			ExternalID: is.ID,
+			Status:     "open",
+		}
+		if is.CVE != "" {
+			v.CVE = []string{is.CVE}
+		}
+		for _, ref := range is.References {
+			v.Refs = append(v.Refs, faradayRef{Name: ref, Type: "other"})
+		}
+
+		if is.Port == 0 {
+			h.Vulnerabilities = append(h.Vulnerabilities, v)
+			continue
+		}
+		if is.Scheme != "" {
+			v.Type, v.Website, v.Path = "VulnerabilityWeb", is.Host, is.Path
+		}
+		key := fmt.Sprintf("%s:%d", is.Host, is.Port)
+		svc, ok := services[key]
+		if !ok {
+			name := is.Scheme
+			if name == "" {
+				name = "unknown"
+			}
+			svc = &faradayService{Name: name, Port: is.Port, Protocol: "tcp", Status: "open"}
+			services[key] = svc
+			h.Services = append(h.Services, svc)
+		}
+		svc.Vulnerabilities = append(svc.Vulnerabilities, v)
+	}
+
+	bulk := faradayBulk{
+		Hosts: make([]*faradayHost, 0, len(hosts)),
+		Command: faradayCommand{
+			Tool:         toolName,
+			Command:      "aethonx",
+			Params:       "-t " + result.Target.Root,
+			ImportSource: "report",
+			StartDate:    scanDate(result).UTC().Format(time.RFC3339),
+			EndDate:      endDate(result).UTC().Format(time.RFC3339),
+		},
+	}
+	for _, h := range hosts {
+		bulk.Hosts = append(bulk.Hosts, h)
+	}
+	sort.Slice(bulk.Hosts, func(i, j int) bool { return bulk.Hosts[i].IP < bulk.Hosts[j].IP })
+	return bulk
+}
+
+// endDate es la fecha de fin del escaneo (ahora si no consta).
+func endDate(result *domain.ScanResult) time.Time {
+	if result.Metadata.EndTime.IsZero() {
+		return time.Now()
+	}
+	return result.Metadata.EndTime
+}
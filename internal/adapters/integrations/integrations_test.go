@@ -0,0 +1,192 @@
+// internal/adapters/integrations/integrations_test.go
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+)
+
+// newFindingsResult crea un escaneo con una vulnerabilidad colgada de una
+// URL (has_vuln), una URL sin cabeceras de seguridad y un subdominio sin
+// riesgo.
+func newFindingsResult() *domain.ScanResult {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+
+	page := metadata.NewServiceMetadata("https", 8443)
+	page.MissingSecurityHeaders = []string{"hsts"}
+	url := domain.NewArtifactWithMetadata(domain.ArtifactTypeURL, "https://app.example.com:8443/login", "httpx", page)
+
+	vuln := domain.NewVulnerabilityArtifact("CVE-2021-44228", "critical", "nuclei")
+	if m, ok := vuln.TypedMetadata.(*metadata.VulnerabilityMetadata); ok {
+		m.Description = "Log4Shell"
+		m.References = []string{"https://nvd.nist.gov/vuln/detail/CVE-2021-44228"}
+	}
+	url.AddRelation(vuln.ID, domain.RelationHasVuln, 1.0, "nuclei")
+
+	result.AddArtifact(url)
+	result.AddArtifact(vuln)
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "www.example.com", "crtsh"))
+	result.Finalize()
+	return result
+}
+
+func TestCollectIssues(t *testing.T) {
+	issues := collectIssues(newFindingsResult(), "")
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d", len(issues))
+	}
+
+	vuln := issues[0]
+	if vuln.Severity != "critical" || vuln.CVE != "CVE-2021-44228" {
+		t.Errorf("expected the critical CVE first, got %+v", vuln)
+	}
+	if vuln.Host != "app.example.com" || vuln.Port != 8443 || vuln.Scheme != "https" || vuln.Path != "/login" {
+		t.Errorf("vulnerability should point at the affected URL, got %s:%d %s %s", vuln.Host, vuln.Port, vuln.Scheme, vuln.Path)
+	}
+	if !strings.Contains(vuln.Description, "Log4Shell") {
+		t.Errorf("description should keep the vulnerability text: %q", vuln.Description)
+	}
+
+	if got := collectIssues(newFindingsResult(), "medium"); len(got) != 1 {
+		t.Errorf("expected only the critical issue with min severity medium, got %d", len(got))
+	}
+}
+
+func TestDefectDojo_Export(t *testing.T) {
+	var fields map[string]string
+	var report struct {
+		Findings []defectDojoFinding `json:"findings"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != defectDojoImportPath || r.Header.Get("Authorization") != "Token secret" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fields = make(map[string]string)
+		for k, v := range r.MultipartForm.Value {
+			fields[k] = v[0]
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		if err := json.NewDecoder(file).Decode(&report); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	dojo, err := NewDefectDojo(DefectDojoOptions{URL: srv.URL + "/", Token: "secret", Product: "Example"})
+	if err != nil {
+		t.Fatalf("NewDefectDojo() failed: %v", err)
+	}
+	n, err := dojo.Export(context.Background(), newFindingsResult())
+	if err != nil {
+		t.Fatalf("Export() failed: %v", err)
+	}
+	if n != 2 || len(report.Findings) != 2 {
+		t.Fatalf("expected 2 findings, exported %d, received %d", n, len(report.Findings))
+	}
+
+	if fields["product_name"] != "Example" || fields["engagement_name"] != DefaultDefectDojoEngagement ||
+		fields["scan_type"] != defectDojoScanType || fields["auto_create_context"] != "true" {
+		t.Errorf("unexpected import fields %v", fields)
+	}
+	if _, ok := fields["product_type_name"]; ok {
+		t.Error("product_type_name should only be sent when configured")
+	}
+
+	f := report.Findings[0]
+	if f.Severity != "Critical" || f.UniqueIDTool == "" || len(f.Endpoints) != 1 || f.Endpoints[0].Port != 8443 {
+		t.Errorf("unexpected finding %+v", f)
+	}
+}
+
+func TestDefectDojo_APIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"detail":"Invalid token."}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	dojo, err := NewDefectDojo(DefectDojoOptions{URL: srv.URL, Token: "bad", Product: "Example"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = dojo.Export(context.Background(), newFindingsResult())
+	if err == nil || !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "Invalid token") {
+		t.Errorf("expected the API error with its body, got %v", err)
+	}
+}
+
+func TestNewExporters_Validation(t *testing.T) {
+	if _, err := NewDefectDojo(DefectDojoOptions{URL: "ftp://dojo", Token: "t", Product: "p"}); err == nil {
+		t.Error("expected an error for a non-http url")
+	}
+	if _, err := NewDefectDojo(DefectDojoOptions{URL: "https://dojo", Product: "p"}); err == nil {
+		t.Error("expected an error without token")
+	}
+	if _, err := NewDefectDojo(DefectDojoOptions{URL: "https://dojo", Token: "t"}); err == nil {
+		t.Error("expected an error without product")
+	}
+	if _, err := NewFaraday(FaradayOptions{URL: "https://faraday", Token: "t"}); err == nil {
+		t.Error("expected an error without workspace")
+	}
+}
+
+func TestFaraday_Export(t *testing.T) {
+	var bulk faradayBulk
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("Authorization") != "Token secret" || json.Unmarshal(body, &bulk) != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	faraday, err := NewFaraday(FaradayOptions{URL: srv.URL, Token: "secret", Workspace: "acme recon"})
+	if err != nil {
+		t.Fatalf("NewFaraday() failed: %v", err)
+	}
+	if _, err := faraday.Export(context.Background(), newFindingsResult()); err != nil {
+		t.Fatalf("Export() failed: %v", err)
+	}
+
+	if path != "/_api/v3/ws/acme recon/bulk_create" {
+		t.Errorf("unexpected endpoint %s", path)
+	}
+	if len(bulk.Hosts) != 1 || bulk.Hosts[0].IP != "app.example.com" {
+		t.Fatalf("expected one host for app.example.com, got %+v", bulk.Hosts)
+	}
+	host := bulk.Hosts[0]
+	if len(host.Hostnames) != 1 || len(host.Services) != 1 || host.Services[0].Port != 8443 {
+		t.Fatalf("expected the https service on 8443, got %+v", host.Services)
+	}
+	vulns := host.Services[0].Vulnerabilities
+	if len(vulns) != 2 || vulns[0].Type != "VulnerabilityWeb" || vulns[0].Path != "/login" || len(vulns[0].CVE) != 1 {
+		t.Errorf("unexpected vulnerabilities %+v", vulns)
+	}
+	if bulk.Command.Tool != toolName {
+		t.Errorf("unexpected command %+v", bulk.Command)
+	}
+}
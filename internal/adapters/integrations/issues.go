@@ -0,0 +1,165 @@
+// internal/adapters/integrations/issues.go
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"aethonx/internal/adapters/output"
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+)
+
+// toolName identifica a AethonX como herramienta de origen en las plataformas.
+const toolName = "AethonX"
+
+// requestTimeout acota cada llamada a una plataforma externa.
+const requestTimeout = 60 * time.Second
+
+// Exporter publica los hallazgos de un escaneo en una plataforma externa de
+// gestión de vulnerabilidades o incidencias.
+type Exporter interface {
+	// Name identifica la plataforma en logs y errores ("defectdojo", ...)
+	Name() string
+
+	// Export envía los hallazgos de result y retorna cuántos se enviaron
+	Export(ctx context.Context, result *domain.ScanResult) (int, error)
+}
+
+// issue es un hallazgo listo para exportar: el riesgo del informe más el
+// host/puerto/ruta donde se encontró.
+type issue struct {
+	ID          string // ID del artifact: estable entre escaneos (deduplicación)
+	Title       string
+	Severity    string // critical, high, medium, low
+	Description string
+	Location    string // URL o host[:puerto] afectado
+	Host        string
+	Port        int
+	Scheme      string
+	Path        string
+	CVE         string
+	CVSSScore   float64
+	References  []string
+}
+
+// collectIssues convierte los hallazgos de result con severidad >=
+// minSeverity en issues.
+func collectIssues(result *domain.ScanResult, minSeverity string) []issue {
+	// Las vulnerabilidades cuelgan (has_vuln) del servicio/host afectado
+	affected := make(map[string]string)
+	for _, a := range result.Artifacts {
+		if a == nil {
+			continue
+		}
+		for _, rel := range a.Relations {
+			if rel.Type == domain.RelationHasVuln {
+				affected[rel.TargetID] = a.Value
+			}
+		}
+	}
+
+	findings := output.Findings(result, minSeverity)
+	issues := make([]issue, 0, len(findings))
+	for _, f := range findings {
+		a := f.Artifact
+		is := issue{
+			ID:       a.ID,
+			Severity: f.Severity,
+			Title:    fmt.Sprintf("%s: %s", f.Reason, a.Value),
+			Location: a.Value,
+		}
+
+		if vuln, ok := a.TypedMetadata.(*metadata.VulnerabilityMetadata); ok {
+			is.CVE = vuln.CVE
+			is.CVSSScore = vuln.CVSSScore
+			is.References = vuln.References
+			is.Description = vuln.Description
+			switch {
+			case vuln.MatchedAt != "":
+				is.Location = vuln.MatchedAt
+			case affected[a.ID] != "":
+				is.Location = affected[a.ID]
+			}
+			is.Title = fmt.Sprintf("%s on %s", f.Reason, is.Location)
+		}
+
+		is.Host, is.Port, is.Scheme, is.Path = splitLocation(is.Location)
+		is.Description = describe(a, f, is)
+		issues = append(issues, is)
+	}
+	return issues
+}
+
+// describe compone la descripción del hallazgo: motivo, ubicación, fuentes
+// y la descripción propia si la hay.
+func describe(a *domain.Artifact, f output.Finding, is issue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", f.Reason)
+	fmt.Fprintf(&b, "Type: %s\n", a.Type)
+	fmt.Fprintf(&b, "Location: %s\n", is.Location)
+	if len(a.Sources) > 0 {
+		fmt.Fprintf(&b, "Found by: %s\n", strings.Join(a.Sources, ", "))
+	}
+	if is.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", is.Description)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// splitLocation extrae host, puerto, esquema y ruta de una URL o host[:puerto].
+func splitLocation(location string) (host string, port int, scheme, path string) {
+	if strings.Contains(location, "://") {
+		if u, err := url.Parse(location); err == nil && u.Hostname() != "" {
+			port, _ = strconv.Atoi(u.Port())
+			if port == 0 {
+				switch u.Scheme {
+				case "https":
+					port = 443
+				case "http":
+					port = 80
+				}
+			}
+			return u.Hostname(), port, u.Scheme, u.EscapedPath()
+		}
+	}
+	if h, p, err := net.SplitHostPort(location); err == nil {
+		port, _ = strconv.Atoi(p)
+		return h, port, "", ""
+	}
+	return location, 0, "", ""
+}
+
+// checkBaseURL valida la URL base de una plataforma y la retorna sin "/" final.
+func checkBaseURL(platform, raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%s url must be http(s)://host[:port], got %q", platform, raw)
+	}
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
+// apiError convierte una respuesta no 2xx en un error con el inicio del cuerpo.
+func apiError(platform string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	msg := strings.TrimSpace(string(body))
+	if msg == "" {
+		msg = http.StatusText(resp.StatusCode)
+	}
+	return fmt.Errorf("%s api returned %d: %s", platform, resp.StatusCode, msg)
+}
+
+// scanDate es la fecha de inicio del escaneo (ahora si no consta).
+func scanDate(result *domain.ScanResult) time.Time {
+	if result.Metadata.StartTime.IsZero() {
+		return time.Now()
+	}
+	return result.Metadata.StartTime
+}
@@ -0,0 +1,286 @@
+// internal/adapters/integrations/jira.go
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"aethonx/internal/core/domain"
+)
+
+const (
+	// DefaultJiraIssueType es el tipo de issue si no se configura otro.
+	DefaultJiraIssueType = "Task"
+
+	// DefaultJiraMinSeverity es la severidad mínima para abrir un ticket.
+	DefaultJiraMinSeverity = "high"
+
+	// jiraDedupPrefix prefija la etiqueta que identifica el hallazgo en Jira
+	// (aethonx-<artifact ID>): un escaneo posterior la busca antes de crear
+	jiraDedupPrefix = "aethonx-"
+
+	// jiraSearchBatch acota las etiquetas por consulta JQL
+	jiraSearchBatch = 50
+)
+
+// JiraOptions configura la apertura de tickets en Jira. Con User se usa
+// autenticación básica (Jira Cloud: email + API token); sin él, Token es un
+// personal access token (Jira Server/Data Center).
+type JiraOptions struct {
+	URL         string
+	User        string
+	Token       string
+	Project     string   // Clave del proyecto (p. ej. "SEC")
+	IssueType   string   // Tipo de issue ("" = Task)
+	Labels      []string // Etiquetas añadidas a cada ticket
+	MinSeverity string   // Severidad mínima ("" = high)
+	HTTPClient  *http.Client
+}
+
+// Link es una evidencia enlazada desde el ticket (informe, JSON, ...).
+type Link struct {
+	Name string
+	URL  string
+}
+
+// Jira abre un ticket por cada hallazgo nuevo (o reaparecido) del escaneo
+// según el lifecycle del target. Cada ticket lleva la etiqueta
+// aethonx-<artifact ID>; si ya existe un ticket con ella, no se vuelve a crear.
+type Jira struct {
+	opts     JiraOptions
+	http     *http.Client
+	evidence []Link
+}
+
+var _ Exporter = (*Jira)(nil)
+
+// NewJira valida las opciones y crea el exporter.
+func NewJira(opts JiraOptions) (*Jira, error) {
+	base, err := checkBaseURL("jira", opts.URL)
+	if err != nil {
+		return nil, err
+	}
+	opts.URL = base
+	if opts.Token == "" {
+		return nil, fmt.Errorf("jira requires an API token (AETHONX_JIRA_TOKEN)")
+	}
+	opts.Project = strings.TrimSpace(opts.Project)
+	if opts.Project == "" {
+		return nil, fmt.Errorf("jira requires a project key")
+	}
+	if strings.TrimSpace(opts.IssueType) == "" {
+		opts.IssueType = DefaultJiraIssueType
+	}
+	if opts.MinSeverity == "" {
+		opts.MinSeverity = DefaultJiraMinSeverity
+	}
+	for _, label := range opts.Labels {
+		if strings.ContainsAny(label, " \t") {
+			return nil, fmt.Errorf("jira label %q must not contain spaces", label)
+		}
+	}
+
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: requestTimeout}
+	}
+	return &Jira{opts: opts, http: client}, nil
+}
+
+// Name retorna el nombre de la plataforma.
+func (j *Jira) Name() string { return "jira" }
+
+// SetEvidence fija los enlaces (informe, JSON...) que se citan en los tickets.
+// Se llama cuando las salidas ya están escritas.
+func (j *Jira) SetEvidence(links []Link) {
+	j.evidence = links
+}
+
+// Export abre un ticket por cada hallazgo nuevo sin ticket previo y retorna
+// cuántos se crearon. Sin lifecycle (escaneo no monitorizado) no hay nada nuevo.
+func (j *Jira) Export(ctx context.Context, result *domain.ScanResult) (int, error) {
+	issues := newIssues(result, collectIssues(result, j.opts.MinSeverity))
+	if len(issues) == 0 {
+		return 0, nil
+	}
+
+	labels := make([]string, len(issues))
+	for i, is := range issues {
+		labels[i] = jiraDedupPrefix + is.ID
+	}
+	existing, err := j.existingLabels(ctx, labels)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for i, is := range issues {
+		if existing[labels[i]] {
+			continue
+		}
+		if err := j.create(ctx, result, is, labels[i]); err != nil {
+			return created, err
+		}
+		created++
+	}
+	return created, nil
+}
+
+// newIssues se queda con los issues cuyo artifact es nuevo o ha reaparecido.
+// Se compara por ID: el valor puede venir enmascarado por la redacción.
+func newIssues(result *domain.ScanResult, issues []issue) []issue {
+	if !result.Lifecycle.HasChanges() {
+		return nil
+	}
+	fresh := make(map[string]bool)
+	for _, list := range [][]domain.ArtifactLifecycle{result.Lifecycle.New, result.Lifecycle.Reappeared} {
+		for _, entry := range list {
+			if id, err := domain.ArtifactID(entry.Type, entry.Value, domain.CurrentIDScheme); err == nil {
+				fresh[id] = true
+			}
+		}
+	}
+
+	var out []issue
+	for _, is := range issues {
+		if fresh[is.ID] {
+			out = append(out, is)
+		}
+	}
+	return out
+}
+
+// existingLabels busca en el proyecto los tickets que ya llevan alguna de las
+// etiquetas de deduplicación, en cualquier estado.
+func (j *Jira) existingLabels(ctx context.Context, labels []string) (map[string]bool, error) {
+	found := make(map[string]bool)
+	for start := 0; start < len(labels); start += jiraSearchBatch {
+		batch := labels[start:min(start+jiraSearchBatch, len(labels))]
+		quoted := make([]string, len(batch))
+		for i, l := range batch {
+			quoted[i] = jqlQuote(l)
+		}
+		query := map[string]interface{}{
+			"jql":        fmt.Sprintf("project = %s AND labels in (%s)", jqlQuote(j.opts.Project), strings.Join(quoted, ", ")),
+			"fields":     []string{"labels"},
+			"maxResults": len(batch),
+		}
+
+		var resp struct {
+			Issues []struct {
+				Fields struct {
+					Labels []string `json:"labels"`
+				} `json:"fields"`
+			} `json:"issues"`
+		}
+		if err := j.do(ctx, "/rest/api/2/search", query, &resp); err != nil {
+			return nil, fmt.Errorf("jira search: %w", err)
+		}
+		for _, is := range resp.Issues {
+			for _, l := range is.Fields.Labels {
+				found[l] = true
+			}
+		}
+	}
+	return found, nil
+}
+
+// create abre el ticket de un hallazgo.
+func (j *Jira) create(ctx context.Context, result *domain.ScanResult, is issue, dedup string) error {
+	labels := append([]string{"aethonx", dedup, "severity-" + is.Severity}, j.opts.Labels...)
+	body := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"project":     map[string]string{"key": j.opts.Project},
+			"issuetype":   map[string]string{"name": j.opts.IssueType},
+			"summary":     jiraSummary(result, is),
+			"description": j.description(result, is),
+			"labels":      labels,
+		},
+	}
+	var resp struct {
+		Key string `json:"key"`
+	}
+	if err := j.do(ctx, "/rest/api/2/issue", body, &resp); err != nil {
+		return fmt.Errorf("jira create issue for %s: %w", is.Location, err)
+	}
+	return nil
+}
+
+// jiraSummary compone el resumen del ticket (Jira admite 255 caracteres).
+func jiraSummary(result *domain.ScanResult, is issue) string {
+	summary := fmt.Sprintf("[%s] [%s] %s", toolName, strings.ToUpper(is.Severity), is.Title)
+	if !strings.Contains(summary, result.Target.Root) {
+		summary += " (" + result.Target.Root + ")"
+	}
+	if len(summary) > 255 {
+		summary = summary[:252] + "..."
+	}
+	return summary
+}
+
+// description compone la descripción en wiki markup: el hallazgo, las
+// referencias y los enlaces a la evidencia del escaneo.
+func (j *Jira) description(result *domain.ScanResult, is issue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "{noformat}\n%s\n{noformat}\n\n", is.Description)
+	fmt.Fprintf(&b, "*Target:* %s\n*Scan:* %s (%s)\n", result.Target.Root, result.ID, scanDate(result).UTC().Format("2006-01-02 15:04 MST"))
+	if is.CVE != "" {
+		fmt.Fprintf(&b, "*CVE:* %s\n", is.CVE)
+	}
+	if len(is.References) > 0 {
+		b.WriteString("\nh3. References\n")
+		for _, ref := range is.References {
+			fmt.Fprintf(&b, "* %s\n", ref)
+		}
+	}
+	if len(j.evidence) > 0 {
+		b.WriteString("\nh3. Evidence\n")
+		for _, l := range j.evidence {
+			if strings.Contains(l.URL, "://") {
+				fmt.Fprintf(&b, "* [%s|%s]\n", l.Name, l.URL)
+			} else {
+				fmt.Fprintf(&b, "* %s: {{%s}}\n", l.Name, l.URL)
+			}
+		}
+	}
+	fmt.Fprintf(&b, "\n_Opened by %s. Repeated scans keep this ticket (label %s%s)._", toolName, jiraDedupPrefix, is.ID)
+	return b.String()
+}
+
+// do envía body como JSON a path y decodifica la respuesta en out.
+func (j *Jira) do(ctx context.Context, path string, body, out interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, j.opts.URL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if j.opts.User != "" {
+		req.SetBasicAuth(j.opts.User, j.opts.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+j.opts.Token)
+	}
+
+	resp, err := j.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return apiError("jira", resp)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// jqlQuote entrecomilla un valor para JQL.
+func jqlQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,158 @@
+// internal/adapters/integrations/jira_test.go
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"aethonx/internal/core/domain"
+)
+
+// fakeJira simula la API de Jira: guarda los tickets creados y responde a
+// las búsquedas por etiqueta con ellos.
+type fakeJira struct {
+	mu      sync.Mutex
+	created []map[string]interface{}
+	auth    string
+}
+
+func (f *fakeJira) handler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.auth = r.Header.Get("Authorization")
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			jql, _ := body["jql"].(string)
+			var issues []map[string]interface{}
+			for _, c := range f.created {
+				fields := c["fields"].(map[string]interface{})
+				for _, l := range fields["labels"].([]interface{}) {
+					if strings.HasPrefix(l.(string), jiraDedupPrefix) && strings.Contains(jql, `"`+l.(string)+`"`) {
+						issues = append(issues, map[string]interface{}{"fields": map[string]interface{}{"labels": fields["labels"]}})
+					}
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"issues": issues})
+		case "/rest/api/2/issue":
+			f.created = append(f.created, body)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]string{"key": "SEC-1"})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// withNewArtifacts marca todos los artifacts de result como nuevos.
+func withNewArtifacts(result *domain.ScanResult) *domain.ScanResult {
+	result.Lifecycle = &domain.LifecycleReport{}
+	for _, a := range result.Artifacts {
+		result.Lifecycle.New = append(result.Lifecycle.New, domain.ArtifactLifecycle{Key: a.Key(), Type: a.Type, Value: a.Value})
+	}
+	return result
+}
+
+func TestJira_ExportDeduplicates(t *testing.T) {
+	fake := &fakeJira{}
+	srv := httptest.NewServer(fake.handler(t))
+	defer srv.Close()
+
+	jira, err := NewJira(JiraOptions{URL: srv.URL, User: "sec@example.com", Token: "secret", Project: "SEC", Labels: []string{"recon"}})
+	if err != nil {
+		t.Fatalf("NewJira() failed: %v", err)
+	}
+	jira.SetEvidence([]Link{{Name: "Report", URL: "https://reports.example.net/example.com/report.pdf"}})
+
+	n, err := jira.Export(context.Background(), withNewArtifacts(newFindingsResult()))
+	if err != nil {
+		t.Fatalf("Export() failed: %v", err)
+	}
+	// Solo la vulnerabilidad crítica supera el umbral por defecto (high)
+	if n != 1 || len(fake.created) != 1 {
+		t.Fatalf("expected 1 ticket, created %d", len(fake.created))
+	}
+	if !strings.HasPrefix(fake.auth, "Basic ") {
+		t.Errorf("expected basic auth with a user, got %q", fake.auth)
+	}
+
+	fields := fake.created[0]["fields"].(map[string]interface{})
+	if fields["project"].(map[string]interface{})["key"] != "SEC" || fields["issuetype"].(map[string]interface{})["name"] != DefaultJiraIssueType {
+		t.Errorf("unexpected project or issue type: %v", fields)
+	}
+	summary := fields["summary"].(string)
+	if !strings.Contains(summary, "CRITICAL") || !strings.Contains(summary, "CVE-2021-44228") {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	description := fields["description"].(string)
+	if !strings.Contains(description, "[Report|https://reports.example.net/example.com/report.pdf]") {
+		t.Errorf("description should link the evidence: %s", description)
+	}
+	labels := fields["labels"].([]interface{})
+	if len(labels) != 4 || labels[3] != "recon" {
+		t.Errorf("unexpected labels %v", labels)
+	}
+
+	// Un segundo escaneo con los mismos hallazgos no duplica el ticket
+	n, err = jira.Export(context.Background(), withNewArtifacts(newFindingsResult()))
+	if err != nil {
+		t.Fatalf("second Export() failed: %v", err)
+	}
+	if n != 0 || len(fake.created) != 1 {
+		t.Errorf("expected no new tickets on the repeated scan, created %d", n)
+	}
+}
+
+func TestJira_OnlyNewFindings(t *testing.T) {
+	fake := &fakeJira{}
+	srv := httptest.NewServer(fake.handler(t))
+	defer srv.Close()
+
+	jira, err := NewJira(JiraOptions{URL: srv.URL, Token: "pat", Project: "SEC", MinSeverity: "low"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Sin lifecycle no hay hallazgos nuevos
+	if n, err := jira.Export(context.Background(), newFindingsResult()); err != nil || n != 0 {
+		t.Fatalf("expected no tickets without lifecycle, got %d (%v)", n, err)
+	}
+
+	result := newFindingsResult()
+	for _, a := range result.Artifacts {
+		if a.Type == domain.ArtifactTypeURL {
+			result.Lifecycle = &domain.LifecycleReport{Reappeared: []domain.ArtifactLifecycle{{Key: a.Key(), Type: a.Type, Value: a.Value}}}
+		}
+	}
+	n, err := jira.Export(context.Background(), result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || fake.auth != "Bearer pat" {
+		t.Errorf("expected one ticket for the reappeared URL with a bearer PAT, got %d (%q)", n, fake.auth)
+	}
+}
+
+func TestNewJira_Validation(t *testing.T) {
+	if _, err := NewJira(JiraOptions{URL: "https://jira", Token: "t"}); err == nil {
+		t.Error("expected an error without project")
+	}
+	if _, err := NewJira(JiraOptions{URL: "https://jira", Project: "SEC"}); err == nil {
+		t.Error("expected an error without token")
+	}
+	if _, err := NewJira(JiraOptions{URL: "https://jira", Token: "t", Project: "SEC", Labels: []string{"two words"}}); err == nil {
+		t.Error("expected an error for a label with spaces")
+	}
+}
@@ -0,0 +1,93 @@
+// internal/adapters/integrations/webhook.go
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"aethonx/internal/core/ports"
+)
+
+// webhookTimeout tiempo máximo de cada POST; el bus de eventos aplica además
+// su propio timeout por entrega
+const webhookTimeout = 10 * time.Second
+
+// webhookEvent es el cuerpo JSON que recibe el webhook por cada evento.
+type webhookEvent struct {
+	Type      ports.EventType     `json:"type"`
+	Timestamp time.Time           `json:"timestamp"`
+	Source    string              `json:"source,omitempty"`
+	ScanID    string              `json:"scan_id,omitempty"`
+	Target    string              `json:"target,omitempty"`
+	Severity  ports.EventSeverity `json:"severity,omitempty"`
+	Data      interface{}         `json:"data,omitempty"`
+	Metadata  map[string]string   `json:"metadata,omitempty"`
+}
+
+// Webhook es un observer (ports.Notifier) que publica cada evento con un
+// POST JSON. Una respuesta no 2xx es un fallo de entrega: el bus de eventos
+// lo guarda en el spool y se reentrega al arrancar el siguiente escaneo.
+type Webhook struct {
+	url  string
+	name string
+	http *http.Client
+}
+
+var _ ports.Notifier = (*Webhook)(nil)
+
+// NewWebhook valida la URL y crea el notifier. client nil usa uno propio.
+func NewWebhook(rawURL string, client *http.Client) (*Webhook, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("webhook url must be http(s)://host[:port][/path], got %q", rawURL)
+	}
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	return &Webhook{url: u.String(), name: "webhook-" + u.Host + u.Path, http: client}, nil
+}
+
+// Name identifica al webhook en el spool y en las métricas del bus: estable
+// entre escaneos para que los eventos pendientes se reentreguen al mismo.
+func (w *Webhook) Name() string { return w.name }
+
+// Notify publica el evento.
+func (w *Webhook) Notify(ctx context.Context, event ports.Event) error {
+	body, err := json.Marshal(webhookEvent{
+		Type:      event.Type,
+		Timestamp: event.Timestamp,
+		Source:    event.Source,
+		ScanID:    event.ScanID,
+		Target:    event.Target,
+		Severity:  event.Severity,
+		Data:      event.Data,
+		Metadata:  event.Metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return apiError("webhook", resp)
+	}
+	return nil
+}
+
+// Close no retiene recursos.
+func (w *Webhook) Close() error { return nil }
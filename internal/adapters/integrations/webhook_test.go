@@ -0,0 +1,88 @@
+// internal/adapters/integrations/webhook_test.go
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"aethonx/internal/adapters/output"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/core/usecases"
+)
+
+// fakeWebhook guarda el tipo de cada evento recibido; mientras down es true
+// responde 503.
+type fakeWebhook struct {
+	mu       sync.Mutex
+	down     bool
+	received []string
+}
+
+func (f *fakeWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.down {
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+		return
+	}
+	var body map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.received = append(f.received, body["type"].(string))
+}
+
+func TestNewWebhook_InvalidURL(t *testing.T) {
+	for _, raw := range []string{"", "ftp://hooks.example.com", "hooks.example.com/x"} {
+		if _, err := NewWebhook(raw, nil); err == nil {
+			t.Errorf("NewWebhook(%q) should fail", raw)
+		}
+	}
+}
+
+// Un webhook caído no pierde eventos: el bus los guarda en el spool y la
+// reentrega del siguiente escaneo los envía en orden.
+func TestWebhook_SpoolAndReplay(t *testing.T) {
+	fake := &fakeWebhook{down: true}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	webhook, err := NewWebhook(srv.URL+"/hooks/aethonx", srv.Client())
+	if err != nil {
+		t.Fatalf("NewWebhook() failed: %v", err)
+	}
+	spool := output.NewFileEventSpool(t.TempDir())
+
+	bus := usecases.NewEventBus([]ports.Notifier{webhook}, usecases.EventBusOptions{Spool: spool})
+	bus.Publish(ports.NewEvent(ports.EventTypeScanStarted, "pipeline", nil))
+	bus.Publish(ports.NewEvent(ports.EventTypeScanCompleted, "pipeline", map[string]int{"artifacts": 3}))
+	stats := bus.Close()
+	if stats[0].Spooled != 2 {
+		t.Fatalf("spooled = %d, want 2 (stats %+v)", stats[0].Spooled, stats[0])
+	}
+
+	// Todavía caído: la reentrega falla y los eventos siguen pendientes
+	if _, err := usecases.ReplaySpooledEvents(context.Background(), spool, webhook); err == nil {
+		t.Fatal("replay against a down webhook should fail")
+	}
+
+	fake.mu.Lock()
+	fake.down = false
+	fake.mu.Unlock()
+
+	replayed, err := usecases.ReplaySpooledEvents(context.Background(), spool, webhook)
+	if err != nil || replayed != 2 {
+		t.Fatalf("replay = %d, %v; want 2 events", replayed, err)
+	}
+	if len(fake.received) != 2 || fake.received[0] != string(ports.EventTypeScanStarted) || fake.received[1] != string(ports.EventTypeScanCompleted) {
+		t.Errorf("received %v, want scan.started then scan.completed", fake.received)
+	}
+	if pending, _ := spool.Pending(webhook.Name()); len(pending) != 0 {
+		t.Errorf("%d events left in the spool", len(pending))
+	}
+}
@@ -0,0 +1,68 @@
+// internal/adapters/output/atomic.go
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// atomicFile es un fichero de salida que se escribe en un temporal del mismo
+// directorio y solo aparece en su ruta final al confirmarlo (rename). Un
+// crash a mitad deja como mucho un temporal oculto, nunca un JSON truncado
+// con el nombre de uno completo.
+type atomicFile struct {
+	*os.File
+	path string
+}
+
+// createAtomic abre el temporal de path.
+func createAtomic(path string) (*atomicFile, error) {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	return &atomicFile{File: f, path: path}, nil
+}
+
+// Commit vuelca el temporal a disco y lo renombra a su ruta final.
+func (f *atomicFile) Commit() error {
+	tmp := f.Name()
+	if err := f.Sync(); err != nil {
+		f.Abort()
+		return err
+	}
+	if err := f.File.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Chmod(tmp, 0o644); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// Abort descarta el temporal. Tras Commit no hace nada.
+func (f *atomicFile) Abort() {
+	if f.File.Close() == nil {
+		os.Remove(f.Name())
+	}
+}
+
+// writeFileAtomic escribe data en path a través de un temporal y un rename.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := createAtomic(path)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Abort()
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return f.Commit()
+}
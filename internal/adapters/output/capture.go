@@ -0,0 +1,72 @@
+// internal/adapters/output/capture.go
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"aethonx/internal/core/domain"
+)
+
+// CaptureManifestFile describe el escaneo de una captura cruda.
+const CaptureManifestFile = "capture.json"
+
+// CaptureManifest identifica el escaneo cuyas salidas crudas se archivaron
+// (--capture-raw). Cada subdirectorio de la captura es el de una source.
+type CaptureManifest struct {
+	ScanID    string        `json:"scan_id"`
+	Target    domain.Target `json:"target"`
+	Version   string        `json:"version,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// CaptureDir retorna el directorio de captura de un escaneo:
+// <dir>/<target>/raw/<scanID>.
+func CaptureDir(dir, target, scanID string) string {
+	return filepath.Join(dir, sanitizeDomainName(target), "raw", scanID)
+}
+
+// WriteCaptureManifest crea el directorio de captura con su manifiesto.
+func WriteCaptureManifest(dir string, manifest CaptureManifest) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create capture directory: %w", err)
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode capture manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, CaptureManifestFile), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write capture manifest: %w", err)
+	}
+	return nil
+}
+
+// ReadCapture lee el manifiesto de la captura en dir y retorna las sources
+// capturadas, ordenadas por nombre.
+func ReadCapture(dir string) (CaptureManifest, []string, error) {
+	var manifest CaptureManifest
+	data, err := os.ReadFile(filepath.Join(dir, CaptureManifestFile))
+	if err != nil {
+		return manifest, nil, fmt.Errorf("not a raw capture (%s missing): %w", CaptureManifestFile, err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, nil, fmt.Errorf("invalid capture manifest: %w", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return manifest, nil, fmt.Errorf("failed to list capture: %w", err)
+	}
+	var sources []string
+	for _, e := range entries {
+		if e.IsDir() {
+			sources = append(sources, e.Name())
+		}
+	}
+	sort.Strings(sources)
+	return manifest, sources, nil
+}
@@ -0,0 +1,46 @@
+// internal/adapters/output/capture_test.go
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+)
+
+func TestCapture_RoundTrip(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+	target.AddExclusion("legacy.example.com")
+	dir := CaptureDir(t.TempDir(), target.Root, "scan-1")
+
+	manifest := CaptureManifest{ScanID: "scan-1", Target: *target, CreatedAt: time.Now().UTC().Truncate(time.Second)}
+	if err := WriteCaptureManifest(dir, manifest); err != nil {
+		t.Fatalf("WriteCaptureManifest() failed: %v", err)
+	}
+	for _, source := range []string{"subfinder", "crtsh"} {
+		if err := os.MkdirAll(filepath.Join(dir, source), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, sources, err := ReadCapture(dir)
+	if err != nil {
+		t.Fatalf("ReadCapture() failed: %v", err)
+	}
+	if got.ScanID != "scan-1" || got.Target.Root != "example.com" || got.Target.Mode != domain.ScanModeActive {
+		t.Errorf("manifest = %+v", got)
+	}
+	if !reflect.DeepEqual(got.Target.Scope.ExcludeDomains, target.Scope.ExcludeDomains) {
+		t.Errorf("exclusions = %v, want %v", got.Target.Scope.ExcludeDomains, target.Scope.ExcludeDomains)
+	}
+	if !reflect.DeepEqual(sources, []string{"crtsh", "subfinder"}) {
+		t.Errorf("sources = %v", sources)
+	}
+
+	if _, _, err := ReadCapture(t.TempDir()); err == nil {
+		t.Error("expected error for a directory without manifest")
+	}
+}
@@ -0,0 +1,100 @@
+// internal/adapters/output/encrypt.go
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Métodos de cifrado soportados. Se delega en los binarios age/gpg para no
+// reimplementar sus formatos: el fichero resultante se descifra con las
+// herramientas estándar (age -d -i key.txt, gpg -d).
+const (
+	EncryptAge = "age"
+	EncryptGPG = "gpg"
+)
+
+// EncryptionMethods lista los métodos válidos para --encrypt.
+var EncryptionMethods = []string{EncryptAge, EncryptGPG}
+
+// Encryptor cifra las salidas para un conjunto de destinatarios. El texto
+// plano nunca llega a disco: se pasa al binario por stdin.
+type Encryptor struct {
+	method     string
+	bin        string
+	recipients []string
+}
+
+// NewEncryptor valida el método y los destinatarios y localiza el binario.
+// Un destinatario puede ser una clave (age1..., ssh-ed25519 ..., key ID/email
+// de gpg) o la ruta a un fichero de destinatarios/clave pública.
+func NewEncryptor(method string, recipients []string) (*Encryptor, error) {
+	method = strings.ToLower(strings.TrimSpace(method))
+	if method != EncryptAge && method != EncryptGPG {
+		return nil, fmt.Errorf("invalid encryption method %q (valid: %s)", method, strings.Join(EncryptionMethods, ", "))
+	}
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("%s encryption requires at least one recipient", method)
+	}
+
+	bin, err := exec.LookPath(method)
+	if err != nil {
+		return nil, fmt.Errorf("%s encryption requires the %s binary in PATH: %w", method, method, err)
+	}
+
+	return &Encryptor{method: method, bin: bin, recipients: recipients}, nil
+}
+
+// Method retorna el método de cifrado (age, gpg).
+func (e *Encryptor) Method() string { return e.method }
+
+// Extension retorna la extensión que se añade a los ficheros cifrados.
+func (e *Encryptor) Extension() string { return "." + e.method }
+
+// Encrypt cifra src en dst.
+func (e *Encryptor) Encrypt(dst io.Writer, src io.Reader) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(e.bin, e.args()...)
+	cmd.Stdin = src
+	cmd.Stdout = dst
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s encryption failed: %s", e.method, msg)
+		}
+		return fmt.Errorf("%s encryption failed: %w", e.method, err)
+	}
+	return nil
+}
+
+// args construye la línea de comandos del binario (lee stdin, escribe stdout).
+func (e *Encryptor) args() []string {
+	var args []string
+	if e.method == EncryptGPG {
+		// --trust-model always: los destinatarios los elige el usuario explícitamente
+		args = []string{"--batch", "--yes", "--trust-model", "always", "--encrypt", "--output", "-"}
+	}
+
+	for _, r := range e.recipients {
+		fileFlag, keyFlag := "-R", "-r"
+		if e.method == EncryptGPG {
+			fileFlag, keyFlag = "--recipient-file", "--recipient"
+		}
+		if isFile(r) {
+			args = append(args, fileFlag, r)
+		} else {
+			args = append(args, keyFlag, r)
+		}
+	}
+	return args
+}
+
+func isFile(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && !fi.IsDir()
+}
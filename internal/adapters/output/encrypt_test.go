@@ -0,0 +1,88 @@
+// internal/adapters/output/encrypt_test.go
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/compress"
+)
+
+// fakeTool instala en PATH un binario que invierte el texto (sustituto de age/gpg).
+func fakeTool(t *testing.T, name string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script fake tool not supported on windows")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\nexec rev\n"
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestNewEncryptor_Validation(t *testing.T) {
+	fakeTool(t, "age")
+
+	if _, err := NewEncryptor("rot13", []string{"x"}); err == nil {
+		t.Error("expected error for unknown method")
+	}
+	if _, err := NewEncryptor("age", nil); err == nil {
+		t.Error("expected error without recipients")
+	}
+	enc, err := NewEncryptor("AGE", []string{"age1example"})
+	if err != nil {
+		t.Fatalf("NewEncryptor() failed: %v", err)
+	}
+	if enc.Extension() != ".age" {
+		t.Errorf("Extension() = %q, want .age", enc.Extension())
+	}
+}
+
+func TestEncryptor_Args(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "recipients.txt")
+	os.WriteFile(keyFile, []byte("age1example\n"), 0o644)
+
+	age := &Encryptor{method: EncryptAge, recipients: []string{"age1example", keyFile}}
+	want := []string{"-r", "age1example", "-R", keyFile}
+	if got := age.args(); !reflect.DeepEqual(got, want) {
+		t.Errorf("age args = %v, want %v", got, want)
+	}
+
+	gpg := &Encryptor{method: EncryptGPG, recipients: []string{"ops@example.com"}}
+	got := strings.Join(gpg.args(), " ")
+	if !strings.Contains(got, "--encrypt") || !strings.HasSuffix(got, "--recipient ops@example.com") {
+		t.Errorf("unexpected gpg args: %s", got)
+	}
+}
+
+func TestWriteJSON_Encrypted(t *testing.T) {
+	fakeTool(t, "age")
+	enc, err := NewEncryptor("age", []string{"age1example"})
+	if err != nil {
+		t.Fatalf("NewEncryptor() failed: %v", err)
+	}
+
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeEmail, "admin@example.com", "rdap"))
+
+	path, err := WriteJSON(t.TempDir(), result, compress.None, enc)
+	if err != nil {
+		t.Fatalf("WriteJSON() failed: %v", err)
+	}
+	if !strings.HasSuffix(path, ".json.age") {
+		t.Errorf("encrypted output should end in .json.age, got %s", path)
+	}
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "admin@example.com") {
+		t.Error("encrypted output must not contain plaintext values")
+	}
+}
@@ -0,0 +1,138 @@
+// internal/adapters/output/event_spool.go
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"aethonx/internal/core/ports"
+)
+
+// FileEventSpool implementa ports.EventSpool con un fichero JSON Lines por
+// observer (<dir>/<observer>.jsonl). Al releer, Event.Data llega como el
+// JSON genérico decodificado (map[string]interface{}), no como el struct
+// original: basta para reenviarlo a un webhook.
+type FileEventSpool struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileEventSpool crea un spool sobre el directorio dir.
+func NewFileEventSpool(dir string) *FileEventSpool {
+	if dir == "" {
+		dir = "."
+	}
+	return &FileEventSpool{dir: dir}
+}
+
+func (s *FileEventSpool) path(observer string) string {
+	return filepath.Join(s.dir, sanitizeDomainName(observer)+".jsonl")
+}
+
+// Save añade el evento al final del fichero del observer.
+func (s *FileEventSpool) Save(observer string, event ports.Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create spool directory: %w", err)
+	}
+	f, err := os.OpenFile(s.path(observer), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open event spool: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write event spool: %w", err)
+	}
+	return f.Close()
+}
+
+// Pending lee los eventos pendientes del observer en orden.
+func (s *FileEventSpool) Pending(observer string) ([]ports.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.read(observer)
+}
+
+// Ack descarta los n primeros eventos y reescribe el fichero de forma
+// atómica (fichero temporal + rename). Sin pendientes, elimina el fichero.
+func (s *FileEventSpool) Ack(observer string, n int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events, err := s.read(observer)
+	if err != nil {
+		return err
+	}
+	if n > len(events) {
+		n = len(events)
+	}
+	remaining := events[n:]
+
+	path := s.path(observer)
+	if len(remaining) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove event spool: %w", err)
+		}
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, event := range remaining {
+		if err := enc.Encode(event); err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write event spool: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write event spool: %w", err)
+	}
+	return nil
+}
+
+// read decodifica el fichero del observer (sin fichero = sin pendientes).
+func (s *FileEventSpool) read(observer string) ([]ports.Event, error) {
+	f, err := os.Open(s.path(observer))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event spool: %w", err)
+	}
+	defer f.Close()
+
+	var events []ports.Event
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var event ports.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("failed to decode spooled event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read event spool: %w", err)
+	}
+	return events, nil
+}
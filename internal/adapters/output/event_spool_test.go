@@ -0,0 +1,58 @@
+// internal/adapters/output/event_spool_test.go
+package output
+
+import (
+	"os"
+	"testing"
+
+	"aethonx/internal/core/ports"
+)
+
+var _ ports.EventSpool = (*FileEventSpool)(nil)
+
+func TestFileEventSpool_SavePendingAck(t *testing.T) {
+	spool := NewFileEventSpool(t.TempDir())
+	const observer = "*webhook.Notifier"
+
+	pending, err := spool.Pending(observer)
+	if err != nil || len(pending) != 0 {
+		t.Fatalf("expected empty spool, got %d events (err=%v)", len(pending), err)
+	}
+
+	for _, eventType := range []ports.EventType{ports.EventTypeScanStarted, ports.EventTypeSourceCompleted, ports.EventTypeScanCompleted} {
+		event := ports.NewEvent(eventType, "orchestrator", map[string]string{"k": "v"})
+		event.ScanID = "scan-1"
+		if err := spool.Save(observer, event); err != nil {
+			t.Fatalf("Save() failed: %v", err)
+		}
+	}
+
+	pending, err = spool.Pending(observer)
+	if err != nil {
+		t.Fatalf("Pending() failed: %v", err)
+	}
+	if len(pending) != 3 {
+		t.Fatalf("expected 3 pending events, got %d", len(pending))
+	}
+	if pending[0].Type != ports.EventTypeScanStarted || pending[2].Type != ports.EventTypeScanCompleted {
+		t.Errorf("events out of order: %s ... %s", pending[0].Type, pending[2].Type)
+	}
+	if pending[1].ScanID != "scan-1" {
+		t.Errorf("expected scan_id to round-trip, got %q", pending[1].ScanID)
+	}
+
+	if err := spool.Ack(observer, 2); err != nil {
+		t.Fatalf("Ack() failed: %v", err)
+	}
+	pending, _ = spool.Pending(observer)
+	if len(pending) != 1 || pending[0].Type != ports.EventTypeScanCompleted {
+		t.Fatalf("expected only the last event after ack, got %+v", pending)
+	}
+
+	if err := spool.Ack(observer, 5); err != nil {
+		t.Fatalf("Ack() beyond pending failed: %v", err)
+	}
+	if _, err := os.Stat(spool.path(observer)); !os.IsNotExist(err) {
+		t.Errorf("expected spool file removed once drained, stat err=%v", err)
+	}
+}
@@ -0,0 +1,118 @@
+// internal/adapters/output/filename.go
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"aethonx/internal/core/domain"
+)
+
+// DefaultFilenameTemplate es el nombre histórico de las salidas:
+// aethonx_<target>_<timestamp>[_filtered|_report|...].<ext>.
+const DefaultFilenameTemplate = "aethonx_{target}_{date}"
+
+// Formatos de salida ({format} de la plantilla).
+const (
+	FormatJSON     = "json"
+	FormatFiltered = "filtered"
+	FormatPDF      = "pdf"
+	FormatParquet  = "parquet"
+	FormatManifest = "manifest"
+	FormatBurp     = "burp"
+	FormatZAP      = "zap"
+	FormatURLs     = "urls"
+	FormatHosts    = "hosts"
+)
+
+// formatSuffixes distinguen las salidas de un escaneo cuando la plantilla no
+// usa {format} (así el consolidado y el filtrado no se pisan).
+var formatSuffixes = map[string]string{
+	FormatJSON:     "",
+	FormatFiltered: "_filtered",
+	FormatPDF:      "_report",
+	FormatParquet:  "_artifacts",
+	FormatManifest: "_manifest",
+	FormatBurp:     "_burp_scope",
+	FormatZAP:      "_zap",
+	FormatURLs:     "_urls",
+	FormatHosts:    "_hosts",
+}
+
+// filenameVars son las variables que admite la plantilla.
+var filenameVars = []string{"target", "date", "scan_id", "format"}
+
+var filenameVarRe = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// FilenameTemplate es la plantilla del nombre (sin extensión) de las salidas
+// de un escaneo, p.ej. "{target}-{scan_id}-{format}". Las salidas se
+// escriben en el subdirectorio del target con la extensión de su formato.
+type FilenameTemplate string
+
+// ParseFilenameTemplate valida una plantilla ("" = DefaultFilenameTemplate):
+// solo variables conocidas y sin separadores de ruta.
+func ParseFilenameTemplate(s string) (FilenameTemplate, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return DefaultFilenameTemplate, nil
+	}
+	if strings.ContainsAny(s, `/\`) || s == "." || s == ".." {
+		return "", fmt.Errorf("filename template %q must be a file name, not a path", s)
+	}
+	for _, m := range filenameVarRe.FindAllStringSubmatch(s, -1) {
+		if !slices.Contains(filenameVars, m[1]) {
+			return "", fmt.Errorf("unknown variable {%s} in filename template (valid: {%s})", m[1], strings.Join(filenameVars, "}, {"))
+		}
+	}
+	if rest := filenameVarRe.ReplaceAllString(s, ""); strings.ContainsAny(rest, "{}") {
+		return "", fmt.Errorf("unbalanced braces in filename template %q", s)
+	}
+	return FilenameTemplate(s), nil
+}
+
+// filenameTemplate es la plantilla activa. Se fija una vez al arrancar.
+var filenameTemplate atomic.Value
+
+// SetFilenameTemplate fija la plantilla de nombres de las salidas.
+func SetFilenameTemplate(t FilenameTemplate) {
+	filenameTemplate.Store(t)
+}
+
+// CurrentFilenameTemplate retorna la plantilla activa.
+func CurrentFilenameTemplate() FilenameTemplate {
+	if t, ok := filenameTemplate.Load().(FilenameTemplate); ok && t != "" {
+		return t
+	}
+	return DefaultFilenameTemplate
+}
+
+// Render retorna el nombre (sin extensión) de la salida format de result.
+// Sin {format} en la plantilla se añade el sufijo del formato.
+func (t FilenameTemplate) Render(result *domain.ScanResult, format string, at time.Time) string {
+	name := filenameVarRe.ReplaceAllStringFunc(string(t), func(v string) string {
+		switch v {
+		case "{target}":
+			return fileSafe(result.Target.Root)
+		case "{date}":
+			return at.Format("20060102_150405")
+		case "{scan_id}":
+			return fileSafe(result.ID)
+		case "{format}":
+			return format
+		}
+		return v
+	})
+	if !strings.Contains(string(t), "{format}") {
+		name += formatSuffixes[format]
+	}
+	return name
+}
+
+// fileSafe sustituye los separadores de ruta de un valor de la plantilla.
+func fileSafe(s string) string {
+	return strings.NewReplacer("/", "_", `\`, "_", "..", "_").Replace(s)
+}
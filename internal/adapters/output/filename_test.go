@@ -0,0 +1,82 @@
+// internal/adapters/output/filename_test.go
+package output
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/compress"
+)
+
+func TestParseFilenameTemplate(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    FilenameTemplate
+		wantErr bool
+	}{
+		{"", DefaultFilenameTemplate, false},
+		{"  {target}-{scan_id}-{format}  ", "{target}-{scan_id}-{format}", false},
+		{"recon_{date}", "recon_{date}", false},
+		{"{target}/{date}", "", true},
+		{"..", "", true},
+		{"{host}_{date}", "", true},
+		{"{target}_{date", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFilenameTemplate(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFilenameTemplate(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseFilenameTemplate(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFilenameTemplate_Render(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+	result.ID = "scan-42"
+	at := time.Date(2025, 3, 1, 10, 20, 30, 0, time.UTC)
+
+	tests := []struct {
+		tmpl   FilenameTemplate
+		format string
+		want   string
+	}{
+		{DefaultFilenameTemplate, FormatJSON, "aethonx_example.com_20250301_102030"},
+		{DefaultFilenameTemplate, FormatFiltered, "aethonx_example.com_20250301_102030_filtered"},
+		{DefaultFilenameTemplate, FormatPDF, "aethonx_example.com_20250301_102030_report"},
+		{"{target}-{scan_id}-{format}", FormatParquet, "example.com-scan-42-parquet"},
+		{"{scan_id}", FormatManifest, "scan-42_manifest"},
+	}
+	for _, tt := range tests {
+		if got := tt.tmpl.Render(result, tt.format, at); got != tt.want {
+			t.Errorf("%q.Render(%s) = %q, want %q", tt.tmpl, tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestWriteJSON_FilenameTemplate(t *testing.T) {
+	SetFilenameTemplate("{target}-{scan_id}-{format}")
+	defer SetFilenameTemplate(DefaultFilenameTemplate)
+
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+	result.ID = "scan-42"
+
+	path, err := WriteJSON(t.TempDir(), result, compress.Gzip, nil)
+	if err != nil {
+		t.Fatalf("WriteJSON() failed: %v", err)
+	}
+	if got := filepath.Base(path); got != "example.com-scan-42-json.json.gz" {
+		t.Errorf("unexpected file name %s", got)
+	}
+	if !strings.HasSuffix(filepath.Dir(path), "example_com") {
+		t.Errorf("outputs must stay in the target directory, got %s", path)
+	}
+}
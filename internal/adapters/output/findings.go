@@ -0,0 +1,44 @@
+// internal/adapters/output/findings.go
+package output
+
+import (
+	"sort"
+
+	"aethonx/internal/core/domain"
+)
+
+// Finding es un artifact que el informe destacaría como riesgo, con su
+// severidad (critical, high, medium, low) y el motivo.
+type Finding struct {
+	Artifact *domain.Artifact
+	Severity string
+	Reason   string
+}
+
+// Findings retorna los riesgos de result con severidad >= minSeverity ("" =
+// todos), de mayor a menor severidad y por valor. Es la misma clasificación
+// que "Top risks" del informe, sin recortar.
+func Findings(result *domain.ScanResult, minSeverity string) []Finding {
+	threshold := SeverityRank(minSeverity)
+
+	var out []Finding
+	for _, a := range result.Artifacts {
+		if a == nil {
+			continue
+		}
+		severity, reason, ok := assessRisk(a)
+		if !ok || SeverityRank(severity) < threshold {
+			continue
+		}
+		out = append(out, Finding{Artifact: a, Severity: severity, Reason: reason})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		ri, rj := SeverityRank(out[i].Severity), SeverityRank(out[j].Severity)
+		if ri != rj {
+			return ri > rj
+		}
+		return out[i].Artifact.Value < out[j].Artifact.Value
+	})
+	return out
+}
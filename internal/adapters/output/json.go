@@ -2,14 +2,17 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/compress"
 )
 
 // sanitizeDomainName convierte un nombre de dominio en un nombre de carpeta válido.
@@ -29,39 +32,134 @@ func sanitizeDomainName(domain string) string {
 
 // OutputJSON exporta el resultado en formato JSON.
 func OutputJSON(dir string, result *domain.ScanResult) error {
+	_, err := writeResultJSON(dir, result, FormatJSON, compress.None, nil)
+	return err
+}
+
+// WriteJSON exporta el resultado consolidado y retorna la ruta del fichero
+// (necesaria para firmarlo a continuación). Con codec se comprime
+// (<nombre>.json.gz / .json.zst) y con enc != nil se escribe cifrado
+// (<nombre>.json.age / .json.zst.age): primero se comprime y luego se cifra.
+func WriteJSON(dir string, result *domain.ScanResult, codec compress.Codec, enc *Encryptor) (string, error) {
+	return writeResultJSON(dir, result, FormatJSON, codec, enc)
+}
+
+// OutputFilteredJSON exporta un resultado filtrado (ver domain.ArtifactFilter)
+// junto al consolidado, con sufijo "_filtered" para no sustituirlo.
+func OutputFilteredJSON(dir string, result *domain.ScanResult) error {
+	_, err := writeResultJSON(dir, result, FormatFiltered, compress.None, nil)
+	return err
+}
+
+// WriteFilteredJSON es OutputFilteredJSON con compresión y cifrado opcionales.
+func WriteFilteredJSON(dir string, result *domain.ScanResult, codec compress.Codec, enc *Encryptor) (string, error) {
+	return writeResultJSON(dir, result, FormatFiltered, codec, enc)
+}
+
+// writeResultJSON escribe la salida format como <nombre>.json[.gz|.zst] en el
+// subdirectorio del target y retorna su ruta.
+func writeResultJSON(dir string, result *domain.ScanResult, format string, codec compress.Codec, enc *Encryptor) (string, error) {
+	return writeResultFile(dir, result, format, ".json"+codec.Extension(), enc, func(w io.Writer) error {
+		zw, err := compress.NewWriter(w, codec)
+		if err != nil {
+			return err
+		}
+
+		// Codificar JSON con indentación
+		jsonEnc := json.NewEncoder(zw)
+		jsonEnc.SetIndent("", "  ")
+		if err := jsonEnc.Encode(result); err != nil {
+			zw.Close()
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("failed to compress JSON: %w", err)
+		}
+		return nil
+	})
+}
+
+// writeResultFile escribe la salida format (<nombre><ext>) en el
+// subdirectorio del target con el contenido generado por render y retorna su
+// ruta. Con enc != nil el contenido se cifra en memoria antes de llegar a disco.
+// La escritura es atómica (ver atomicFile).
+func writeResultFile(dir string, result *domain.ScanResult, format, ext string, enc *Encryptor, render func(io.Writer) error) (string, error) {
+	if enc != nil {
+		ext += enc.Extension()
+	}
+	filepath, err := resultPath(dir, result, format, ext)
+	if err != nil {
+		return "", err
+	}
+
+	// Generar el contenido en memoria (necesario si hay que cifrarlo)
+	var buf bytes.Buffer
+	if err := render(&buf); err != nil {
+		return "", err
+	}
+
+	// Temporal + rename: el fichero final solo aparece completo
+	f, err := createAtomic(filepath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	if enc != nil {
+		err = enc.Encrypt(f, &buf)
+	} else if _, err = buf.WriteTo(f); err != nil {
+		err = fmt.Errorf("failed to write output file: %w", err)
+	}
+	if err != nil {
+		f.Abort()
+		return "", err
+	}
+	if err := f.Commit(); err != nil {
+		return "", fmt.Errorf("failed to write output file: %w", err)
+	}
+	return filepath, nil
+}
+
+// resultPath crea el subdirectorio del target y retorna la ruta
+// <dir>/<target>/<nombre><ext> de la salida format, con el nombre según la
+// plantilla activa (ver SetFilenameTemplate).
+func resultPath(dir string, result *domain.ScanResult, format, ext string) (string, error) {
 	if dir == "" {
 		dir = "."
 	}
 
 	// Crear subdirectorio específico para el dominio
-	domainDir := sanitizeDomainName(result.Target.Root)
-	fullDir := filepath.Join(dir, domainDir)
-
-	// Crear directorio completo si no existe
+	fullDir := filepath.Join(dir, sanitizeDomainName(result.Target.Root))
 	if err := os.MkdirAll(fullDir, 0o755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+		return "", fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Generar nombre de archivo con timestamp
-	timestamp := time.Now().Format("20060102_150405")
-	filename := fmt.Sprintf("aethonx_%s_%s.json", result.Target.Root, timestamp)
-	filepath := filepath.Join(fullDir, filename)
+	name := CurrentFilenameTemplate().Render(result, format, time.Now())
+	return filepath.Join(fullDir, name+ext), nil
+}
+
+// ReadJSON carga un ScanResult consolidado desde un fichero JSON (comprimido
+// con gzip/zstd o no) o desde un consolidado por shards (su directorio o su
+// index.json) y migra sus IDs de artifacts al esquema actual.
+func ReadJSON(path string) (*domain.ScanResult, error) {
+	return ReadScanTypes(path, nil)
+}
 
-	// Crear archivo
-	f, err := os.Create(filepath)
+// readConsolidated carga un JSON consolidado de un solo fichero.
+func readConsolidated(path string) (*domain.ScanResult, error) {
+	data, err := compress.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
+		return nil, fmt.Errorf("failed to read scan: %w", err)
 	}
-	defer f.Close()
 
-	// Codificar JSON con indentación
-	enc := json.NewEncoder(f)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(result); err != nil {
-		return fmt.Errorf("failed to encode JSON: %w", err)
+	var result domain.ScanResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode scan %s: %w", path, err)
 	}
-
-	return nil
+	// Escaneos de versiones anteriores: llevar los IDs al esquema actual
+	if _, err := domain.MigrateArtifactIDs(&result); err != nil {
+		return nil, fmt.Errorf("failed to migrate scan %s: %w", path, err)
+	}
+	return &result, nil
 }
 
 // OutputJSONStdout exporta el resultado a stdout en formato JSON.
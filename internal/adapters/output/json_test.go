@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/compress"
 )
 
 func TestOutputJSON(t *testing.T) {
@@ -361,3 +362,86 @@ func TestOutputJSONStdout_EmptyResult(t *testing.T) {
 		t.Errorf("Artifacts: expected 0, got %d", len(decoded.Artifacts))
 	}
 }
+
+func TestOutputFilteredJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+	sub := domain.NewArtifact(domain.ArtifactTypeSubdomain, "test.example.com", "crtsh")
+	ip := domain.NewArtifact(domain.ArtifactTypeIP, "192.168.1.1", "dns")
+	ip.AddRelation(sub.ID, domain.RelationReverseResolves, 1.0, "dns")
+	result.AddArtifact(sub)
+	result.AddArtifact(ip)
+	result.Finalize()
+
+	filtered := result.Filtered(domain.ArtifactFilter{Types: []domain.ArtifactType{domain.ArtifactTypeIP}})
+	if err := OutputFilteredJSON(tmpDir, filtered); err != nil {
+		t.Fatalf("OutputFilteredJSON() failed: %v", err)
+	}
+
+	files, err := os.ReadDir(filepath.Join(tmpDir, "example_com"))
+	if err != nil {
+		t.Fatalf("failed to read domain subdirectory: %v", err)
+	}
+	if len(files) != 1 || !strings.HasSuffix(files[0].Name(), "_filtered.json") {
+		t.Fatalf("expected one *_filtered.json file, got %v", files)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "example_com", files[0].Name()))
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+
+	var decoded domain.ScanResult
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(decoded.Artifacts) != 1 || decoded.Artifacts[0].Type != domain.ArtifactTypeIP {
+		t.Errorf("expected only the IP artifact, got %d artifacts", len(decoded.Artifacts))
+	}
+
+	// El grafo exportado no apunta a artifacts filtrados
+	exported := make(map[string]bool)
+	for _, a := range decoded.Artifacts {
+		exported[a.ID] = true
+	}
+	for _, a := range decoded.Artifacts {
+		for _, rel := range a.Relations {
+			if !exported[rel.TargetID] {
+				t.Errorf("%s has a dangling %s relation to %s", a.Value, rel.Type, rel.TargetID)
+			}
+		}
+	}
+}
+
+func TestWriteJSON_Compressed(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "test.example.com", "crtsh"))
+	result.Finalize()
+
+	path, err := WriteJSON(t.TempDir(), result, compress.Gzip, nil)
+	if err != nil {
+		t.Fatalf("WriteJSON() failed: %v", err)
+	}
+	if !strings.HasSuffix(path, ".json.gz") {
+		t.Errorf("expected a .json.gz file, got %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	if json.Valid(data) {
+		t.Error("output should be compressed")
+	}
+
+	decoded, err := ReadJSON(path)
+	if err != nil {
+		t.Fatalf("ReadJSON() failed on compressed scan: %v", err)
+	}
+	if len(decoded.Artifacts) != 1 {
+		t.Errorf("expected 1 artifact, got %d", len(decoded.Artifacts))
+	}
+}
@@ -0,0 +1,77 @@
+// internal/adapters/output/lifecycle_store.go
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"aethonx/internal/core/domain"
+)
+
+// lifecycleFilename es el estado persistido por target. No lleva el prefijo
+// "aethonx_" para que el dashboard no lo liste como escaneo.
+const lifecycleFilename = "lifecycle.json"
+
+// FileLifecycleStore implementa ports.LifecycleStore con un fichero JSON por
+// target dentro del directorio de salida (<dir>/<target>/lifecycle.json).
+type FileLifecycleStore struct {
+	dir string
+}
+
+// NewFileLifecycleStore crea un store sobre el directorio de salida dir.
+func NewFileLifecycleStore(dir string) *FileLifecycleStore {
+	if dir == "" {
+		dir = "."
+	}
+	return &FileLifecycleStore{dir: dir}
+}
+
+func (s *FileLifecycleStore) path(target string) string {
+	return filepath.Join(s.dir, sanitizeDomainName(target), lifecycleFilename)
+}
+
+// LoadLifecycle lee el estado de target; si no existe retorna uno vacío.
+func (s *FileLifecycleStore) LoadLifecycle(ctx context.Context, target string) (*domain.LifecycleState, error) {
+	data, err := os.ReadFile(s.path(target))
+	if errors.Is(err, os.ErrNotExist) {
+		return domain.NewLifecycleState(target), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lifecycle state: %w", err)
+	}
+
+	state := domain.NewLifecycleState(target)
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to decode lifecycle state: %w", err)
+	}
+	if state.Artifacts == nil {
+		state.Artifacts = make(map[string]*domain.ArtifactLifecycle)
+	}
+	return state, nil
+}
+
+// SaveLifecycle escribe el estado de forma atómica (fichero temporal + rename).
+func (s *FileLifecycleStore) SaveLifecycle(ctx context.Context, state *domain.LifecycleState) error {
+	path := s.path(state.Target)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode lifecycle state: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write lifecycle state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write lifecycle state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// internal/adapters/output/lifecycle_store_test.go
+package output
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+)
+
+func TestFileLifecycleStore_RoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := NewFileLifecycleStore(tmpDir)
+	ctx := context.Background()
+
+	state, err := store.LoadLifecycle(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("LoadLifecycle() on empty dir failed: %v", err)
+	}
+	if len(state.Artifacts) != 0 {
+		t.Fatalf("expected empty state, got %d artifacts", len(state.Artifacts))
+	}
+
+	seen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	state.ScanCount = 1
+	state.Artifacts["subdomain:api.example.com"] = &domain.ArtifactLifecycle{
+		Key:       "subdomain:api.example.com",
+		Type:      domain.ArtifactTypeSubdomain,
+		Value:     "api.example.com",
+		FirstSeen: seen,
+		LastSeen:  seen,
+		Status:    domain.LifecycleNew,
+	}
+	if err := store.SaveLifecycle(ctx, state); err != nil {
+		t.Fatalf("SaveLifecycle() failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "example_com", "lifecycle.json")); err != nil {
+		t.Fatalf("expected lifecycle.json in target directory: %v", err)
+	}
+
+	loaded, err := store.LoadLifecycle(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("LoadLifecycle() failed: %v", err)
+	}
+	entry := loaded.Artifacts["subdomain:api.example.com"]
+	if entry == nil || !entry.FirstSeen.Equal(seen) || loaded.ScanCount != 1 {
+		t.Errorf("state not round-tripped: %+v", loaded)
+	}
+}
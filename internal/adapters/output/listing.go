@@ -0,0 +1,96 @@
+// internal/adapters/output/listing.go
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"aethonx/internal/core/domain"
+)
+
+// ListingFormats son los formatos soportados por WriteArtifactList.
+var ListingFormats = []string{"table", "csv", "json", "jsonl", "values", "parquet"}
+
+// listingHeader son las columnas de las salidas tabulares (table/csv).
+var listingHeader = []string{"type", "value", "sources", "confidence", "tags", "discovered_at"}
+
+// WriteArtifactList escribe una lista plana de artifacts en el formato indicado.
+// Es la salida de "aethonx artifacts"; no incluye cabecera de scan.
+func WriteArtifactList(w io.Writer, artifacts []*domain.Artifact, format string) error {
+	switch format {
+	case "table", "":
+		return writeListingTable(w, artifacts)
+	case "csv":
+		return writeListingCSV(w, artifacts)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if artifacts == nil {
+			artifacts = []*domain.Artifact{}
+		}
+		return enc.Encode(artifacts)
+	case "jsonl":
+		enc := json.NewEncoder(w)
+		for _, a := range artifacts {
+			if err := enc.Encode(a); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "values":
+		for _, a := range artifacts {
+			if _, err := fmt.Fprintln(w, a.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "parquet":
+		return RenderParquet(w, artifacts)
+	default:
+		return fmt.Errorf("unknown format %q (use %s)", format, strings.Join(ListingFormats, ", "))
+	}
+}
+
+func listingRow(a *domain.Artifact) []string {
+	discovered := ""
+	if !a.DiscoveredAt.IsZero() {
+		discovered = a.DiscoveredAt.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		string(a.Type),
+		a.Value,
+		strings.Join(a.Sources, ";"),
+		strconv.FormatFloat(a.Confidence, 'f', 2, 64),
+		strings.Join(a.Tags, ";"),
+		discovered,
+	}
+}
+
+func writeListingCSV(w io.Writer, artifacts []*domain.Artifact) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(listingHeader); err != nil {
+		return err
+	}
+	for _, a := range artifacts {
+		if err := cw.Write(listingRow(a)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeListingTable(w io.Writer, artifacts []*domain.Artifact) error {
+	tw := tabwriter.NewWriter(w, 2, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(listingHeader[:5], "\t")))
+	for _, a := range artifacts {
+		fmt.Fprintln(tw, strings.Join(listingRow(a)[:5], "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,62 @@
+// internal/adapters/output/listing_test.go
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"aethonx/internal/core/domain"
+)
+
+func listingFixture() []*domain.Artifact {
+	sub := domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "crtsh")
+	sub.AddSource("subfinder")
+	sub.AddTag("alive")
+	url := domain.NewArtifact(domain.ArtifactTypeURL, "https://example.com/a,b", "waybackurls")
+	return []*domain.Artifact{sub, url}
+}
+
+func TestWriteArtifactList_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteArtifactList(&buf, listingFixture(), "csv"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected header + 2 rows, got %d", len(records))
+	}
+	if records[1][2] != "crtsh;subfinder" || records[1][4] != "alive" {
+		t.Errorf("unexpected row: %v", records[1])
+	}
+	if records[2][1] != "https://example.com/a,b" {
+		t.Errorf("comma in value not preserved: %q", records[2][1])
+	}
+}
+
+func TestWriteArtifactList_Formats(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteArtifactList(&buf, listingFixture(), "values"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Fields(buf.String()); len(got) != 2 || got[0] != "api.example.com" {
+		t.Errorf("unexpected values output: %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteArtifactList(&buf, listingFixture(), "jsonl"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 2 {
+		t.Errorf("expected 2 JSON lines, got %d", lines)
+	}
+
+	if err := WriteArtifactList(&buf, nil, "xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
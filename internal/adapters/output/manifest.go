@@ -0,0 +1,190 @@
+// internal/adapters/output/manifest.go
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"aethonx/internal/core/domain"
+)
+
+// Estado de un escaneo en su manifiesto.
+const (
+	ManifestStatusComplete = "complete" // Terminó sin fallos de sources
+	ManifestStatusPartial  = "partial"  // Interrumpido o con sources fallidas
+	ManifestStatusFailed   = "failed"   // El escaneo falló; las salidas son lo que se llegó a obtener
+)
+
+// ManifestVersion es la versión del formato del manifiesto.
+const ManifestVersion = 1
+
+// ScanManifest es aethonx_<target>_<timestamp>_manifest.json: se escribe el
+// último, cuando todas las salidas del escaneo están en disco, y lista cada
+// una con su tamaño y SHA-256. Sin manifiesto las salidas no están completas.
+type ScanManifest struct {
+	Version    int              `json:"version"`
+	ScanID     string           `json:"scan_id"`
+	Target     string           `json:"target"`
+	Status     string           `json:"status"`
+	Reason     string           `json:"reason,omitempty"`
+	StartedAt  time.Time        `json:"started_at"`
+	FinishedAt time.Time        `json:"finished_at"`
+	Artifacts  int              `json:"artifacts"`
+	Warnings   int              `json:"warnings"`
+	Errors     int              `json:"errors"`
+	Outputs    []ManifestOutput `json:"outputs"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// ManifestOutput es una salida del escaneo. File es relativo al directorio
+// del manifiesto.
+type ManifestOutput struct {
+	Kind   string `json:"kind"` // json, filtered, pdf, parquet, signature, ...
+	File   string `json:"file"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// NewScanManifest crea el manifiesto de result con el estado dado.
+func NewScanManifest(result *domain.ScanResult, status, reason string) *ScanManifest {
+	return &ScanManifest{
+		Version:    ManifestVersion,
+		ScanID:     result.ID,
+		Target:     result.Target.Root,
+		Status:     status,
+		Reason:     reason,
+		StartedAt:  result.Metadata.StartTime,
+		FinishedAt: result.Metadata.EndTime,
+		Artifacts:  len(result.Artifacts),
+		Warnings:   len(result.Warnings),
+		Errors:     len(result.Errors),
+	}
+}
+
+// AddOutput añade el fichero path (ya escrito) con su tamaño y SHA-256.
+func (m *ScanManifest) AddOutput(kind, path string) error {
+	size, sum, err := hashFile(path)
+	if err != nil {
+		return err
+	}
+	m.Outputs = append(m.Outputs, ManifestOutput{
+		Kind:   kind,
+		File:   path,
+		Size:   size,
+		SHA256: sum,
+	})
+	return nil
+}
+
+// WriteManifest escribe el manifiesto en el subdirectorio del target y
+// retorna su ruta. Las rutas de las salidas se guardan relativas a él.
+func WriteManifest(dir string, result *domain.ScanResult, m *ScanManifest) (string, error) {
+	path, err := resultPath(dir, result, FormatManifest, ".json")
+	if err != nil {
+		return "", err
+	}
+
+	out := *m
+	out.CreatedAt = time.Now().UTC()
+	out.Outputs = make([]ManifestOutput, len(m.Outputs))
+	for i, o := range m.Outputs {
+		if rel, err := filepath.Rel(filepath.Dir(path), o.File); err == nil {
+			o.File = filepath.ToSlash(rel)
+		}
+		out.Outputs[i] = o
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := writeFileAtomic(path, append(data, '\n')); err != nil {
+		return "", fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return path, nil
+}
+
+// ReadManifest carga un manifiesto y comprueba que cada salida sigue en disco
+// con el tamaño y el SHA-256 registrados.
+func ReadManifest(path string) (*ScanManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var m ScanManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	if m.Version > ManifestVersion {
+		return nil, fmt.Errorf("manifest %s has version %d, newer than supported (%d)", path, m.Version, ManifestVersion)
+	}
+
+	for _, o := range m.Outputs {
+		file := filepath.Join(filepath.Dir(path), filepath.FromSlash(o.File))
+		size, sum, err := hashFile(file)
+		if err != nil {
+			return &m, err
+		}
+		if size != o.Size || sum != o.SHA256 {
+			return &m, fmt.Errorf("output %s does not match its manifest entry", o.File)
+		}
+	}
+	return &m, nil
+}
+
+// hashFile retorna el tamaño y el SHA-256 (hex) de path.
+func hashFile(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to read output: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to read output: %w", err)
+	}
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// LatestScan retorna el JSON consolidado del último escaneo completo de
+// target en dir, según los manifiestos de su subdirectorio ("" si no hay
+// ninguno). Los escaneos parciales o fallidos no cuentan.
+func LatestScan(dir, target string) (string, error) {
+	if dir == "" {
+		dir = "."
+	}
+	// Todo manifiesto lleva "manifest" en el nombre: {format} o el sufijo
+	candidates, err := filepath.Glob(filepath.Join(dir, sanitizeDomainName(target), "*manifest*.json"))
+	if err != nil {
+		return "", err
+	}
+
+	var latest string
+	var latestAt time.Time
+	for _, path := range candidates {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read manifest: %w", err)
+		}
+		var m ScanManifest
+		if err := json.Unmarshal(data, &m); err != nil || m.Status != ManifestStatusComplete || !m.FinishedAt.After(latestAt) {
+			continue
+		}
+		for _, o := range m.Outputs {
+			if o.Kind == FormatJSON {
+				latest = filepath.Join(filepath.Dir(path), filepath.FromSlash(o.File))
+				latestAt = m.FinishedAt
+				break
+			}
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,148 @@
+// internal/adapters/output/manifest_test.go
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/compress"
+)
+
+func TestWriteManifest(t *testing.T) {
+	dir := t.TempDir()
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "a.example.com", "crtsh"))
+	result.Finalize()
+
+	jsonPath, err := WriteJSON(dir, result, compress.None, nil)
+	if err != nil {
+		t.Fatalf("WriteJSON() failed: %v", err)
+	}
+	manifest := NewScanManifest(result, ManifestStatusComplete, "")
+	if err := manifest.AddOutput("json", jsonPath); err != nil {
+		t.Fatalf("AddOutput() failed: %v", err)
+	}
+
+	path, err := WriteManifest(dir, result, manifest)
+	if err != nil {
+		t.Fatalf("WriteManifest() failed: %v", err)
+	}
+	if !strings.HasSuffix(path, "_manifest.json") || filepath.Dir(path) != filepath.Dir(jsonPath) {
+		t.Errorf("manifest should sit next to the outputs, got %s", path)
+	}
+
+	read, err := ReadManifest(path)
+	if err != nil {
+		t.Fatalf("ReadManifest() failed: %v", err)
+	}
+	if read.ScanID != result.ID || read.Status != ManifestStatusComplete || read.Artifacts != 1 {
+		t.Errorf("unexpected manifest %+v", read)
+	}
+	if len(read.Outputs) != 1 || read.Outputs[0].File != filepath.Base(jsonPath) || read.Outputs[0].Size == 0 {
+		t.Fatalf("expected the json output with a relative path, got %+v", read.Outputs)
+	}
+
+	// Una salida modificada tras el escaneo ya no cuadra con el manifiesto
+	if err := os.WriteFile(jsonPath, []byte("{}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadManifest(path); err == nil {
+		t.Error("expected an error for an output that changed after the scan")
+	}
+}
+
+func TestLatestScan(t *testing.T) {
+	dir := t.TempDir()
+	if path, err := LatestScan(dir, "example.com"); err != nil || path != "" {
+		t.Fatalf("expected no scan before the first one, got %q (%v)", path, err)
+	}
+
+	// Plantilla con {format}: los nombres no siguen el formato histórico
+	SetFilenameTemplate(FilenameTemplate("{scan_id}-{format}"))
+	defer SetFilenameTemplate(DefaultFilenameTemplate)
+
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	var complete string
+	for i, status := range []string{ManifestStatusComplete, ManifestStatusPartial} {
+		result := domain.NewScanResult(*target)
+		result.ID = fmt.Sprintf("scan-%d", i)
+		result.Finalize()
+		result.Metadata.EndTime = result.Metadata.EndTime.Add(time.Duration(i) * time.Hour)
+
+		jsonPath, err := WriteJSON(dir, result, compress.None, nil)
+		if err != nil {
+			t.Fatalf("WriteJSON() failed: %v", err)
+		}
+		manifest := NewScanManifest(result, status, "")
+		if err := manifest.AddOutput(FormatJSON, jsonPath); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := WriteManifest(dir, result, manifest); err != nil {
+			t.Fatalf("WriteManifest() failed: %v", err)
+		}
+		if status == ManifestStatusComplete {
+			complete = jsonPath
+		}
+	}
+
+	// El parcial es más reciente pero no cuenta
+	path, err := LatestScan(dir, "example.com")
+	if err != nil {
+		t.Fatalf("LatestScan() failed: %v", err)
+	}
+	if path != complete {
+		t.Errorf("expected the complete scan %s, got %s", complete, path)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.json")
+
+	if err := os.WriteFile(path, []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(path, []byte("new")); err != nil {
+		t.Fatalf("writeFileAtomic() failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "new" {
+		t.Errorf("expected the new content, got %q (%v)", data, err)
+	}
+	if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0o644 {
+		t.Errorf("expected mode 0644, got %v (%v)", fi.Mode().Perm(), err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("temporary files left behind: %d entries", len(entries))
+	}
+}
+
+func TestAtomicFile_Abort(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "partial.json")
+
+	f, err := createAtomic(path)
+	if err != nil {
+		t.Fatalf("createAtomic() failed: %v", err)
+	}
+	if _, err := f.Write([]byte(`{"artifacts": [`)); err != nil {
+		t.Fatal(err)
+	}
+	f.Abort()
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("an aborted write must not create the final file")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("temporary file left behind: %d entries", len(entries))
+	}
+}
@@ -0,0 +1,383 @@
+// internal/adapters/output/parquet.go
+package output
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"aethonx/internal/core/domain"
+)
+
+// Constantes del formato Parquet usadas por el exportador.
+const (
+	parquetMagic = "PAR1"
+
+	parquetInt32     int32 = 1
+	parquetInt64     int32 = 2
+	parquetDouble    int32 = 5
+	parquetByteArray int32 = 6
+
+	parquetRequired int32 = 0
+	parquetOptional int32 = 1
+
+	parquetUTF8            int32 = 0
+	parquetTimestampMillis int32 = 9
+	parquetNoConverted     int32 = -1
+
+	parquetEncodingPlain int32 = 0
+	parquetEncodingRLE   int32 = 3
+	parquetCodecGzip     int32 = 2
+	parquetDataPage      int32 = 0
+
+	// parquetRowGroupRows filas por row group: acota la memoria del writer y
+	// permite a DuckDB/Athena leer en paralelo
+	parquetRowGroupRows = 50000
+)
+
+// parquetRow es un artifact con su metadata aplanada (ToMap) ya calculada.
+type parquetRow struct {
+	artifact *domain.Artifact
+	meta     map[string]string
+}
+
+// parquetColumn describe una columna y cómo extraer su valor de un artifact.
+type parquetColumn struct {
+	name      string
+	physical  int32
+	converted int32
+	optional  bool
+
+	// value escribe el valor en codificación PLAIN; false = null (solo optional)
+	value func(row parquetRow, buf *bytes.Buffer) bool
+}
+
+// parquetChunk es la metadata de una columna dentro de un row group.
+type parquetChunk struct {
+	offset       int64
+	uncompressed int64
+	compressed   int64
+	values       int64
+}
+
+type parquetRowGroup struct {
+	chunks []parquetChunk
+	rows   int64
+}
+
+// WriteParquet exporta los artifacts del scan en Apache Parquet
+// (<nombre>_artifacts.parquet) y retorna su ruta. Con enc != nil el fichero
+// se escribe cifrado.
+func WriteParquet(dir string, result *domain.ScanResult, enc *Encryptor) (string, error) {
+	return writeResultFile(dir, result, FormatParquet, ".parquet", enc, func(w io.Writer) error {
+		return RenderParquet(w, result.Artifacts)
+	})
+}
+
+// RenderParquet escribe los artifacts en formato Parquet: una fila por
+// artifact con columnas tipadas (confidence DOUBLE, discovered_at TIMESTAMP,
+// relations INT32) y una columna meta_<clave> por cada clave de metadata
+// presente en algún artifact (null en el resto). Sources y tags se unen con
+// ";" como en la salida CSV. Páginas PLAIN comprimidas con gzip.
+func RenderParquet(w io.Writer, artifacts []*domain.Artifact) error {
+	columns := parquetColumns(artifacts)
+	cw := &countingWriter{w: w}
+
+	if _, err := io.WriteString(cw, parquetMagic); err != nil {
+		return err
+	}
+
+	var rowGroups []parquetRowGroup
+	for start := 0; start < len(artifacts); start += parquetRowGroupRows {
+		end := min(start+parquetRowGroupRows, len(artifacts))
+
+		rows := make([]parquetRow, 0, end-start)
+		for _, a := range artifacts[start:end] {
+			row := parquetRow{artifact: a}
+			if a.TypedMetadata != nil {
+				row.meta = a.TypedMetadata.ToMap()
+			}
+			rows = append(rows, row)
+		}
+
+		rg, err := writeParquetRowGroup(cw, columns, rows)
+		if err != nil {
+			return err
+		}
+		rowGroups = append(rowGroups, rg)
+	}
+
+	footer := parquetFooter(columns, rowGroups, int64(len(artifacts)))
+	if _, err := cw.Write(footer); err != nil {
+		return err
+	}
+	if err := binary.Write(cw, binary.LittleEndian, uint32(len(footer))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(cw, parquetMagic)
+	return err
+}
+
+// parquetColumns construye el esquema: columnas fijas + meta_<clave>.
+func parquetColumns(artifacts []*domain.Artifact) []parquetColumn {
+	columns := []parquetColumn{
+		parquetString("id", false, func(a *domain.Artifact) string { return a.ID }),
+		parquetString("type", false, func(a *domain.Artifact) string { return string(a.Type) }),
+		parquetString("value", false, func(a *domain.Artifact) string { return a.Value }),
+		parquetString("raw_value", true, func(a *domain.Artifact) string { return a.RawValue }),
+		parquetString("unicode_value", true, func(a *domain.Artifact) string { return a.UnicodeValue }),
+		parquetString("sources", false, func(a *domain.Artifact) string { return strings.Join(a.Sources, ";") }),
+		parquetString("tags", true, func(a *domain.Artifact) string { return strings.Join(a.Tags, ";") }),
+		{
+			name: "confidence", physical: parquetDouble, converted: parquetNoConverted,
+			value: func(row parquetRow, buf *bytes.Buffer) bool {
+				binary.Write(buf, binary.LittleEndian, math.Float64bits(row.artifact.Confidence))
+				return true
+			},
+		},
+		{
+			name: "discovered_at", physical: parquetInt64, converted: parquetTimestampMillis, optional: true,
+			value: func(row parquetRow, buf *bytes.Buffer) bool {
+				if row.artifact.DiscoveredAt.IsZero() {
+					return false
+				}
+				binary.Write(buf, binary.LittleEndian, row.artifact.DiscoveredAt.UnixMilli())
+				return true
+			},
+		},
+		{
+			name: "relations", physical: parquetInt32, converted: parquetNoConverted,
+			value: func(row parquetRow, buf *bytes.Buffer) bool {
+				binary.Write(buf, binary.LittleEndian, int32(len(row.artifact.Relations)))
+				return true
+			},
+		},
+	}
+
+	// Claves de metadata presentes en algún artifact, en orden estable
+	keys := make(map[string]string) // nombre de columna -> clave
+	for _, a := range artifacts {
+		if a.TypedMetadata == nil {
+			continue
+		}
+		for key := range a.TypedMetadata.ToMap() {
+			name := "meta_" + parquetColumnName(key)
+			if _, exists := keys[name]; !exists {
+				keys[name] = key
+			}
+		}
+	}
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key := keys[name]
+		columns = append(columns, parquetColumn{
+			name: name, physical: parquetByteArray, converted: parquetUTF8, optional: true,
+			value: func(row parquetRow, buf *bytes.Buffer) bool {
+				v, ok := row.meta[key]
+				if !ok || v == "" {
+					return false
+				}
+				putParquetString(buf, v)
+				return true
+			},
+		})
+	}
+
+	return columns
+}
+
+// parquetString crea una columna UTF8; las optional son null si están vacías.
+func parquetString(name string, optional bool, get func(*domain.Artifact) string) parquetColumn {
+	return parquetColumn{
+		name: name, physical: parquetByteArray, converted: parquetUTF8, optional: optional,
+		value: func(row parquetRow, buf *bytes.Buffer) bool {
+			v := get(row.artifact)
+			if optional && v == "" {
+				return false
+			}
+			putParquetString(buf, v)
+			return true
+		},
+	}
+}
+
+// parquetColumnName normaliza una clave de metadata a [a-z0-9_].
+func parquetColumnName(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return '_'
+	}, key)
+}
+
+// writeParquetRowGroup escribe una página de datos por columna.
+func writeParquetRowGroup(w *countingWriter, columns []parquetColumn, rows []parquetRow) (parquetRowGroup, error) {
+	rg := parquetRowGroup{rows: int64(len(rows))}
+
+	for _, col := range columns {
+		var values bytes.Buffer
+		defined := make([]bool, len(rows))
+		for i, row := range rows {
+			defined[i] = col.value(row, &values)
+		}
+
+		// Página v1: [niveles de definición (solo optional)] + valores PLAIN
+		var page bytes.Buffer
+		if col.optional {
+			levels := parquetDefinitionLevels(defined)
+			binary.Write(&page, binary.LittleEndian, uint32(len(levels)))
+			page.Write(levels)
+		}
+		page.Write(values.Bytes())
+
+		var compressed bytes.Buffer
+		zw := gzip.NewWriter(&compressed)
+		zw.Write(page.Bytes())
+		if err := zw.Close(); err != nil {
+			return rg, fmt.Errorf("parquet: compress column %s: %w", col.name, err)
+		}
+
+		var header thriftWriter
+		header.begin()
+		header.i32(1, parquetDataPage)
+		header.i32(2, int32(page.Len()))
+		header.i32(3, int32(compressed.Len()))
+		header.structField(5)
+		header.i32(1, int32(len(rows)))
+		header.i32(2, parquetEncodingPlain)
+		header.i32(3, parquetEncodingRLE)
+		header.i32(4, parquetEncodingRLE)
+		header.end()
+		header.end()
+
+		chunk := parquetChunk{
+			offset:       w.n,
+			uncompressed: int64(header.buf.Len() + page.Len()),
+			compressed:   int64(header.buf.Len() + compressed.Len()),
+			values:       int64(len(rows)),
+		}
+		if _, err := w.Write(header.buf.Bytes()); err != nil {
+			return rg, err
+		}
+		if _, err := w.Write(compressed.Bytes()); err != nil {
+			return rg, err
+		}
+		rg.chunks = append(rg.chunks, chunk)
+	}
+
+	return rg, nil
+}
+
+// parquetDefinitionLevels codifica los niveles de definición (0 = null,
+// 1 = presente) en RLE/bit-packed hybrid con ancho de bit 1, solo runs RLE.
+func parquetDefinitionLevels(defined []bool) []byte {
+	var out bytes.Buffer
+	var varint [binary.MaxVarintLen64]byte
+	for i := 0; i < len(defined); {
+		j := i
+		for j < len(defined) && defined[j] == defined[i] {
+			j++
+		}
+		n := binary.PutUvarint(varint[:], uint64(j-i)<<1)
+		out.Write(varint[:n])
+		if defined[i] {
+			out.WriteByte(1)
+		} else {
+			out.WriteByte(0)
+		}
+		i = j
+	}
+	return out.Bytes()
+}
+
+// parquetFooter codifica FileMetaData (esquema + row groups).
+func parquetFooter(columns []parquetColumn, rowGroups []parquetRowGroup, numRows int64) []byte {
+	var t thriftWriter
+	t.begin()
+	t.i32(1, 1) // version
+
+	t.list(2, thriftStruct, len(columns)+1)
+	t.begin()
+	t.binary(4, "schema")
+	t.i32(5, int32(len(columns)))
+	t.end()
+	for _, col := range columns {
+		repetition := parquetRequired
+		if col.optional {
+			repetition = parquetOptional
+		}
+		t.begin()
+		t.i32(1, col.physical)
+		t.i32(3, repetition)
+		t.binary(4, col.name)
+		if col.converted != parquetNoConverted {
+			t.i32(6, col.converted)
+		}
+		t.end()
+	}
+
+	t.i64(3, numRows)
+
+	t.list(4, thriftStruct, len(rowGroups))
+	for _, rg := range rowGroups {
+		var totalBytes int64
+		t.begin()
+		t.list(1, thriftStruct, len(rg.chunks))
+		for i, chunk := range rg.chunks {
+			col := columns[i]
+			totalBytes += chunk.uncompressed
+
+			t.begin()
+			t.i64(2, chunk.offset)
+			t.structField(3)
+			t.i32(1, col.physical)
+			t.listI32(2, []int32{parquetEncodingPlain, parquetEncodingRLE})
+			t.listBinary(3, []string{col.name})
+			t.i32(4, parquetCodecGzip)
+			t.i64(5, chunk.values)
+			t.i64(6, chunk.uncompressed)
+			t.i64(7, chunk.compressed)
+			t.i64(9, chunk.offset)
+			t.end()
+			t.end()
+		}
+		t.i64(2, totalBytes)
+		t.i64(3, rg.rows)
+		t.end()
+	}
+
+	t.binary(6, "aethonx")
+	t.end()
+	return t.buf.Bytes()
+}
+
+func putParquetString(buf *bytes.Buffer, s string) {
+	binary.Write(buf, binary.LittleEndian, uint32(len(s)))
+	buf.WriteString(s)
+}
+
+// countingWriter cuenta los bytes escritos (offsets de las column chunks).
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,184 @@
+// internal/adapters/output/parquet_test.go
+package output
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+)
+
+func TestRenderParquet_RoundTrip(t *testing.T) {
+	ip := domain.NewArtifactWithMetadata(domain.ArtifactTypeIP, "203.0.113.7", "shodan",
+		&metadata.IPMetadata{Country: "Spain"})
+	ip.Confidence = 0.5
+	ip.DiscoveredAt = time.Time{}
+	sub := domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "crtsh")
+	sub.AddSource("amass")
+	sub.DiscoveredAt = time.UnixMilli(1700000000123)
+
+	var buf bytes.Buffer
+	if err := RenderParquet(&buf, []*domain.Artifact{ip, sub}); err != nil {
+		t.Fatalf("RenderParquet() failed: %v", err)
+	}
+	data := buf.Bytes()
+
+	if !bytes.HasPrefix(data, []byte("PAR1")) || !bytes.HasSuffix(data, []byte("PAR1")) {
+		t.Fatal("missing PAR1 magic")
+	}
+	footerLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footer := readThriftStruct(t, bytes.NewReader(data[len(data)-8-footerLen:len(data)-8]))
+
+	if rows := footer[3].(int64); rows != 2 {
+		t.Fatalf("num_rows = %d, want 2", rows)
+	}
+
+	// Esquema: raíz + columnas; localizar columnas por nombre
+	schema := footer[2].([]any)
+	columns := make(map[string]int)
+	for i, el := range schema[1:] {
+		columns[el.(map[int16]any)[4].(string)] = i
+	}
+	for _, name := range []string{"id", "type", "value", "sources", "confidence", "discovered_at", "meta_country"} {
+		if _, ok := columns[name]; !ok {
+			t.Fatalf("column %q missing from schema", name)
+		}
+	}
+
+	chunks := footer[4].([]any)[0].(map[int16]any)[1].([]any)
+	page := func(name string) []byte {
+		meta := chunks[columns[name]].(map[int16]any)[3].(map[int16]any)
+		offset := meta[9].(int64)
+		r := bytes.NewReader(data[offset:])
+		header := readThriftStruct(t, r)
+		compressed := make([]byte, header[3].(int64))
+		io.ReadFull(r, compressed)
+		zr, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			t.Fatalf("page of %s: %v", name, err)
+		}
+		plain, _ := io.ReadAll(zr)
+		return plain
+	}
+
+	values := readPlainStrings(page("value"))
+	if len(values) != 2 || values[0] != "203.0.113.7" || values[1] != "api.example.com" {
+		t.Errorf("value column = %v", values)
+	}
+	if sources := readPlainStrings(page("sources")); sources[1] != "crtsh;amass" {
+		t.Errorf("sources = %q, want crtsh;amass", sources[1])
+	}
+	if c := math.Float64frombits(binary.LittleEndian.Uint64(page("confidence"))); c != 0.5 {
+		t.Errorf("confidence = %v, want 0.5", c)
+	}
+
+	// Optional: niveles de definición (run de 1 null, run de 1 presente) + valor
+	discovered := page("discovered_at")
+	levels := int(binary.LittleEndian.Uint32(discovered))
+	if ms := int64(binary.LittleEndian.Uint64(discovered[4+levels:])); ms != 1700000000123 {
+		t.Errorf("discovered_at = %d, want 1700000000123", ms)
+	}
+	country := page("meta_country")
+	levels = int(binary.LittleEndian.Uint32(country))
+	if got := readPlainStrings(country[4+levels:]); len(got) != 1 || got[0] != "Spain" {
+		t.Errorf("meta_country = %v, want [Spain]", got)
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	tmpDir := t.TempDir()
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeURL, "https://example.com/a", "wayback"))
+	result.Finalize()
+
+	path, err := WriteParquet(tmpDir, result, nil)
+	if err != nil {
+		t.Fatalf("WriteParquet() failed: %v", err)
+	}
+	if filepath.Ext(path) != ".parquet" {
+		t.Errorf("unexpected file name %s", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read parquet: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("PAR1")) {
+		t.Error("missing PAR1 magic")
+	}
+}
+
+func readPlainStrings(b []byte) []string {
+	var out []string
+	for len(b) >= 4 {
+		n := binary.LittleEndian.Uint32(b)
+		out = append(out, string(b[4:4+n]))
+		b = b[4+n:]
+	}
+	return out
+}
+
+// readThriftStruct decodifica un struct Thrift compact (solo los tipos que
+// escribe thriftWriter) en un mapa field id -> valor.
+func readThriftStruct(t *testing.T, r *bytes.Reader) map[int16]any {
+	t.Helper()
+	fields := make(map[int16]any)
+	var last int16
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			t.Fatalf("thrift: %v", err)
+		}
+		if b == 0 {
+			return fields
+		}
+		typ := b & 0x0f
+		if delta := int16(b >> 4); delta != 0 {
+			last += delta
+		} else {
+			v, _ := binary.ReadUvarint(r)
+			last = int16(unzigzag(v))
+		}
+		fields[last] = readThriftValue(t, r, typ)
+	}
+}
+
+func readThriftValue(t *testing.T, r *bytes.Reader, typ byte) any {
+	switch typ {
+	case thriftI32, thriftI64:
+		v, _ := binary.ReadUvarint(r)
+		return unzigzag(v)
+	case thriftBinary:
+		n, _ := binary.ReadUvarint(r)
+		s := make([]byte, n)
+		io.ReadFull(r, s)
+		return string(s)
+	case thriftList:
+		h, _ := r.ReadByte()
+		size := uint64(h >> 4)
+		if size == 15 {
+			size, _ = binary.ReadUvarint(r)
+		}
+		items := make([]any, 0, size)
+		for i := uint64(0); i < size; i++ {
+			items = append(items, readThriftValue(t, r, h&0x0f))
+		}
+		return items
+	case thriftStruct:
+		return readThriftStruct(t, r)
+	}
+	t.Fatalf("thrift: unexpected type %d", typ)
+	return nil
+}
+
+func unzigzag(v uint64) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
@@ -0,0 +1,107 @@
+// internal/adapters/output/parquet_thrift.go
+package output
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Tipos del protocolo Thrift compact (cabeceras de campo y de lista).
+const (
+	thriftI32    byte = 5
+	thriftI64    byte = 6
+	thriftBinary byte = 8
+	thriftList   byte = 9
+	thriftStruct byte = 12
+)
+
+// thriftWriter codifica las estructuras de metadata de Parquet (PageHeader,
+// FileMetaData) con el protocolo Thrift compact. Solo cubre lo que necesita
+// el exportador: i32, i64, binary, listas y structs anidados.
+type thriftWriter struct {
+	buf    bytes.Buffer
+	lastID []int16 // último field id de cada struct abierto
+}
+
+// begin abre un struct (el de nivel superior o un elemento de lista).
+func (t *thriftWriter) begin() {
+	t.lastID = append(t.lastID, 0)
+}
+
+// end cierra el struct abierto con el marcador STOP.
+func (t *thriftWriter) end() {
+	t.buf.WriteByte(0)
+	t.lastID = t.lastID[:len(t.lastID)-1]
+}
+
+func (t *thriftWriter) field(id int16, typ byte) {
+	last := &t.lastID[len(t.lastID)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.varint(uint64(zigzag(int64(id))))
+	}
+	*last = id
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.varint(uint64(zigzag(int64(v))))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.varint(uint64(zigzag(v)))
+}
+
+func (t *thriftWriter) binary(id int16, s string) {
+	t.field(id, thriftBinary)
+	t.rawBinary(s)
+}
+
+// structField abre un struct como campo id; cerrar con end.
+func (t *thriftWriter) structField(id int16) {
+	t.field(id, thriftStruct)
+	t.begin()
+}
+
+// list escribe la cabecera de una lista de size elementos de tipo elem.
+func (t *thriftWriter) list(id int16, elem byte, size int) {
+	t.field(id, thriftList)
+	if size < 15 {
+		t.buf.WriteByte(byte(size)<<4 | elem)
+	} else {
+		t.buf.WriteByte(0xf0 | elem)
+		t.varint(uint64(size))
+	}
+}
+
+func (t *thriftWriter) listI32(id int16, vs []int32) {
+	t.list(id, thriftI32, len(vs))
+	for _, v := range vs {
+		t.varint(uint64(zigzag(int64(v))))
+	}
+}
+
+func (t *thriftWriter) listBinary(id int16, vs []string) {
+	t.list(id, thriftBinary, len(vs))
+	for _, v := range vs {
+		t.rawBinary(v)
+	}
+}
+
+func (t *thriftWriter) rawBinary(s string) {
+	t.varint(uint64(len(s)))
+	t.buf.WriteString(s)
+}
+
+func (t *thriftWriter) varint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	t.buf.Write(b[:n])
+}
+
+func zigzag(v int64) uint64 {
+	return uint64((v << 1) ^ (v >> 63))
+}
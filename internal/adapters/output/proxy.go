@@ -0,0 +1,203 @@
+// internal/adapters/output/proxy.go
+package output
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"aethonx/internal/core/domain"
+)
+
+// burpScope es el fichero de opciones de proyecto de Burp Suite con solo el
+// scope del target (Target > Scope > "Load options" o Project options).
+type burpScope struct {
+	Target struct {
+		Scope struct {
+			AdvancedMode bool            `json:"advanced_mode"`
+			Include      []burpScopeRule `json:"include"`
+			Exclude      []burpScopeRule `json:"exclude"`
+		} `json:"scope"`
+	} `json:"target"`
+}
+
+// burpScopeRule es una regla del scope avanzado de Burp: cada campo es una
+// expresión regular y "" equivale a cualquier valor.
+type burpScopeRule struct {
+	Enabled  bool   `json:"enabled"`
+	Protocol string `json:"protocol"`
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	File     string `json:"file"`
+}
+
+// zapContext es un fichero de contexto de OWASP ZAP (File > Import Context).
+type zapContext struct {
+	XMLName xml.Name `xml:"configuration"`
+	Context struct {
+		Name      string   `xml:"name"`
+		Desc      string   `xml:"desc"`
+		InScope   bool     `xml:"inscope"`
+		IncRegexs []string `xml:"incregexes"`
+		ExcRegexs []string `xml:"excregexes"`
+	} `xml:"context"`
+}
+
+// WriteBurpScope escribe el scope del target como opciones de proyecto de
+// Burp Suite (<file>_burp_scope.json) y retorna su ruta.
+func WriteBurpScope(dir string, result *domain.ScanResult, enc *Encryptor) (string, error) {
+	return writeResultFile(dir, result, FormatBurp, ".json", enc, func(w io.Writer) error {
+		return RenderBurpScope(w, result)
+	})
+}
+
+// RenderBurpScope escribe el scope de result en el formato de Burp: el root
+// (con sus subdominios si el scope los incluye), los hosts IP de las URLs
+// vivas con su puerto y, como exclusiones, los dominios excluidos.
+func RenderBurpScope(w io.Writer, result *domain.ScanResult) error {
+	var scope burpScope
+	scope.Target.Scope.AdvancedMode = true
+	scope.Target.Scope.Include = []burpScopeRule{{
+		Enabled:  true,
+		Protocol: "any",
+		Host:     "^" + domainPattern(result.Target.Root, result.Target.Scope.IncludeSubdomains) + "$",
+		File:     "^/.*",
+	}}
+	for _, u := range ipURLs(result) {
+		scope.Target.Scope.Include = append(scope.Target.Scope.Include, burpScopeRule{
+			Enabled:  true,
+			Protocol: u.Scheme,
+			Host:     "^" + regexp.QuoteMeta(u.Hostname()) + "$",
+			Port:     "^" + urlPort(u) + "$",
+			File:     "^/.*",
+		})
+	}
+	scope.Target.Scope.Exclude = []burpScopeRule{}
+	for _, excluded := range result.Target.Scope.ExcludeDomains {
+		scope.Target.Scope.Exclude = append(scope.Target.Scope.Exclude, burpScopeRule{
+			Enabled:  true,
+			Protocol: "any",
+			Host:     "^" + domainPattern(excluded, true) + "$",
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(scope)
+}
+
+// WriteZAPContext escribe el scope del target como contexto de OWASP ZAP
+// (<file>_zap.context) y retorna su ruta.
+func WriteZAPContext(dir string, result *domain.ScanResult, enc *Encryptor) (string, error) {
+	return writeResultFile(dir, result, FormatZAP, ".context", enc, func(w io.Writer) error {
+		return RenderZAPContext(w, result)
+	})
+}
+
+// RenderZAPContext escribe el scope de result como contexto de ZAP: las
+// mismas inclusiones y exclusiones que el scope de Burp, como expresiones
+// sobre la URL completa.
+func RenderZAPContext(w io.Writer, result *domain.ScanResult) error {
+	var ctx zapContext
+	ctx.Context.Name = result.Target.Root
+	ctx.Context.Desc = fmt.Sprintf("AethonX scan %s", result.ID)
+	ctx.Context.InScope = true
+	ctx.Context.IncRegexs = []string{
+		`https?://` + domainPattern(result.Target.Root, result.Target.Scope.IncludeSubdomains) + `(:[0-9]+)?(/.*)?`,
+	}
+	for _, u := range ipURLs(result) {
+		ctx.Context.IncRegexs = append(ctx.Context.IncRegexs,
+			regexp.QuoteMeta(u.Scheme+"://"+u.Host)+`(/.*)?`)
+	}
+	for _, excluded := range result.Target.Scope.ExcludeDomains {
+		ctx.Context.ExcRegexs = append(ctx.Context.ExcRegexs,
+			`https?://`+domainPattern(excluded, true)+`(:[0-9]+)?(/.*)?`)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(ctx); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteURLList escribe las URLs vivas, una por línea (<file>_urls.txt),
+// para importarlas en el site map de Burp o ZAP ("Import URLs").
+func WriteURLList(dir string, result *domain.ScanResult, enc *Encryptor) (string, error) {
+	return writeResultFile(dir, result, FormatURLs, ".txt", enc, func(w io.Writer) error {
+		for _, u := range AliveURLs(result) {
+			if _, err := fmt.Fprintln(w, u); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AliveURLs retorna las URLs que respondieron a un probe, ordenadas y sin
+// duplicados.
+func AliveURLs(result *domain.ScanResult) []string {
+	seen := make(map[string]bool)
+	var urls []string
+	for _, a := range result.Artifacts {
+		if a == nil || a.Type != domain.ArtifactTypeURL || !a.IsAlive() || seen[a.Value] {
+			continue
+		}
+		seen[a.Value] = true
+		urls = append(urls, a.Value)
+	}
+	sort.Strings(urls)
+	return urls
+}
+
+// ipURLs retorna las URLs vivas servidas en una IP (scheme y host:puerto
+// únicos): quedan fuera de la regla del dominio y necesitan la suya.
+func ipURLs(result *domain.ScanResult) []*url.URL {
+	seen := make(map[string]bool)
+	var out []*url.URL
+	for _, raw := range AliveURLs(result) {
+		u, err := url.Parse(raw)
+		if err != nil || net.ParseIP(u.Hostname()) == nil {
+			continue
+		}
+		key := u.Scheme + "://" + u.Host
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, u)
+	}
+	return out
+}
+
+// domainPattern es la expresión de un dominio y, si subdomains, de sus
+// subdominios.
+func domainPattern(name string, subdomains bool) string {
+	quoted := regexp.QuoteMeta(strings.ToLower(name))
+	if subdomains {
+		return `(.*\.)?` + quoted
+	}
+	return quoted
+}
+
+// urlPort retorna el puerto de u, o el del scheme si no lo indica.
+func urlPort(u *url.URL) string {
+	if p := u.Port(); p != "" {
+		return p
+	}
+	if u.Scheme == "https" {
+		return "443"
+	}
+	return "80"
+}
@@ -0,0 +1,116 @@
+// internal/adapters/output/proxy_test.go
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"regexp"
+	"strings"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/compress"
+)
+
+// newProxyResult crea un escaneo con URLs vivas (una en una IP), una URL
+// muerta y un dominio excluido del scope.
+func newProxyResult() *domain.ScanResult {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	target.Scope.ExcludeDomains = []string{"legacy.example.com"}
+	result := domain.NewScanResult(*target)
+	for _, u := range []string{"https://www.example.com/login", "http://192.0.2.10:8080/", "https://api.example.com/"} {
+		a := domain.NewArtifact(domain.ArtifactTypeURL, u, "httpx")
+		a.AddTag("alive")
+		result.AddArtifact(a)
+	}
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeURL, "https://dead.example.com/", "waybackurls"))
+	return result
+}
+
+func TestAliveURLs(t *testing.T) {
+	got := AliveURLs(newProxyResult())
+	want := []string{"http://192.0.2.10:8080", "https://api.example.com", "https://www.example.com/login"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("AliveURLs() = %v, want %v", got, want)
+	}
+}
+
+func TestRenderBurpScope(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderBurpScope(&buf, newProxyResult()); err != nil {
+		t.Fatalf("RenderBurpScope() failed: %v", err)
+	}
+	var scope burpScope
+	if err := json.Unmarshal(buf.Bytes(), &scope); err != nil {
+		t.Fatalf("invalid Burp JSON: %v", err)
+	}
+
+	include := scope.Target.Scope.Include
+	if !scope.Target.Scope.AdvancedMode || len(include) != 2 {
+		t.Fatalf("expected the domain rule and the IP rule, got %+v", include)
+	}
+	root := regexp.MustCompile(include[0].Host)
+	for host, want := range map[string]bool{"example.com": true, "www.example.com": true, "badexample.com": false, "example.com.evil.net": false} {
+		if root.MatchString(host) != want {
+			t.Errorf("root rule on %s: got %v, want %v", host, !want, want)
+		}
+	}
+	if include[1].Host != `^192\.0\.2\.10$` || include[1].Port != "^8080$" || include[1].Protocol != "http" {
+		t.Errorf("unexpected IP rule %+v", include[1])
+	}
+	if len(scope.Target.Scope.Exclude) != 1 || !regexp.MustCompile(scope.Target.Scope.Exclude[0].Host).MatchString("db.legacy.example.com") {
+		t.Errorf("expected the excluded domain, got %+v", scope.Target.Scope.Exclude)
+	}
+}
+
+func TestRenderZAPContext(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderZAPContext(&buf, newProxyResult()); err != nil {
+		t.Fatalf("RenderZAPContext() failed: %v", err)
+	}
+	var ctx zapContext
+	if err := xml.Unmarshal(buf.Bytes(), &ctx); err != nil {
+		t.Fatalf("invalid ZAP context: %v", err)
+	}
+	if ctx.Context.Name != "example.com" || !ctx.Context.InScope || len(ctx.Context.IncRegexs) != 2 || len(ctx.Context.ExcRegexs) != 1 {
+		t.Fatalf("unexpected context %+v", ctx.Context)
+	}
+	if !regexp.MustCompile("^" + ctx.Context.IncRegexs[0] + "$").MatchString("https://api.example.com:8443/v1/users") {
+		t.Error("include regex should match URLs of subdomains")
+	}
+	if !regexp.MustCompile("^" + ctx.Context.IncRegexs[1] + "$").MatchString("http://192.0.2.10:8080/admin") {
+		t.Error("include regex should match the IP service")
+	}
+}
+
+func TestWriteURLList(t *testing.T) {
+	path, err := WriteURLList(t.TempDir(), newProxyResult(), nil)
+	if err != nil {
+		t.Fatalf("WriteURLList() failed: %v", err)
+	}
+	if !strings.HasSuffix(path, "_urls.txt") {
+		t.Errorf("unexpected file name %s", path)
+	}
+	data, err := compress.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 3 {
+		t.Errorf("expected 3 alive URLs, got %q", data)
+	}
+}
+
+func TestAliveHosts(t *testing.T) {
+	result := newProxyResult()
+	sub := domain.NewArtifact(domain.ArtifactTypeSubdomain, "mail.example.com", "httpx")
+	sub.AddTag("alive")
+	result.AddArtifact(sub)
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "dead.example.com", "crtsh"))
+
+	got := AliveHosts(result)
+	want := []string{"192.0.2.10", "api.example.com", "mail.example.com", "www.example.com"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("AliveHosts() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,270 @@
+// internal/adapters/output/report.go
+package output
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+)
+
+// Límites del informe: el detalle completo siempre está en el JSON consolidado.
+const (
+	reportTopRisks      = 15  // hallazgos en "Top risks"
+	reportAppendixLimit = 300 // filas por tipo en los apéndices
+)
+
+// Niveles de severidad usados para ordenar los riesgos.
+var severityRank = map[string]int{"critical": 4, "high": 3, "medium": 2, "low": 1}
+
+// tlsGradeSeverity es la severidad de cada nota TLS de tlsgrade (A no es un
+// riesgo).
+var tlsGradeSeverity = map[string]string{"F": "high", "C": "medium", "B": "low"}
+
+// reportData es el contenido del informe, independiente del formato de salida.
+type reportData struct {
+	Result    *domain.ScanResult
+	ByType    []reportCount // artifacts por tipo, de mayor a menor
+	BySource  []reportCount // artifacts por fuente, de mayor a menor
+	Risks     []reportRisk
+	Expiring  []domain.CertExpiry  // Certificados caducados o que caducan en domain.CertExpiryWindow
+	Mail      []*domain.Artifact   // MX y TXT de correo (SPF, DMARC, verificaciones), MX primero
+	Providers []string             // Proveedores de correo detectados
+	Clusters  []domain.HostCluster // Hosts que sirven la misma página
+	Appendix  []reportSection
+	Redaction string // perfil de redacción aplicado ("" = sin redactar)
+}
+
+// reportCount es una barra de los gráficos del informe.
+type reportCount struct {
+	Label string
+	Count int
+}
+
+// reportRisk es un hallazgo destacado.
+type reportRisk struct {
+	Artifact *domain.Artifact
+	Severity string
+	Reason   string
+}
+
+// reportSection es una tabla del apéndice (un tipo de artifact).
+type reportSection struct {
+	Type      domain.ArtifactType
+	Artifacts []*domain.Artifact
+	Omitted   int // artifacts no listados por reportAppendixLimit
+}
+
+// buildReport prepara los datos del informe a partir del resultado.
+func buildReport(result *domain.ScanResult) reportData {
+	data := reportData{
+		Result:    result,
+		Redaction: result.Metadata.Environment["redaction"],
+	}
+
+	byType := make(map[string]int)
+	bySource := make(map[string]int)
+	grouped := make(map[domain.ArtifactType][]*domain.Artifact)
+	for _, a := range result.Artifacts {
+		if a == nil {
+			continue
+		}
+		byType[string(a.Type)]++
+		for _, src := range a.Sources {
+			bySource[src]++
+		}
+		grouped[a.Type] = append(grouped[a.Type], a)
+
+		if severity, reason, ok := assessRisk(a); ok {
+			data.Risks = append(data.Risks, reportRisk{Artifact: a, Severity: severity, Reason: reason})
+		}
+		if mail := a.GetMailInfraMetadata(); mail != nil && (mail.Kind != metadata.MailKindTXT || mail.Provider != "") {
+			data.Mail = append(data.Mail, a)
+			if mail.Provider != "" && !slices.Contains(data.Providers, mail.Provider) {
+				data.Providers = append(data.Providers, mail.Provider)
+			}
+		}
+	}
+	data.ByType = sortedCounts(byType)
+	data.BySource = sortedCounts(bySource)
+
+	sort.SliceStable(data.Risks, func(i, j int) bool {
+		ri, rj := data.Risks[i], data.Risks[j]
+		if severityRank[ri.Severity] != severityRank[rj.Severity] {
+			return severityRank[ri.Severity] > severityRank[rj.Severity]
+		}
+		if ri.Artifact.Confidence != rj.Artifact.Confidence {
+			return ri.Artifact.Confidence > rj.Artifact.Confidence
+		}
+		return ri.Artifact.Value < rj.Artifact.Value
+	})
+	if len(data.Risks) > reportTopRisks {
+		data.Risks = data.Risks[:reportTopRisks]
+	}
+
+	at := result.Metadata.EndTime
+	if at.IsZero() {
+		at = time.Now()
+	}
+	data.Expiring = domain.ExpiringCerts(domain.CertExpiries(result, at), at, domain.CertExpiryWindow)
+
+	data.Clusters = domain.ClusterHosts(result, domain.ClusterMinHosts)
+
+	sort.Strings(data.Providers)
+	sort.SliceStable(data.Mail, func(i, j int) bool {
+		mi, mj := data.Mail[i].GetMailInfraMetadata(), data.Mail[j].GetMailInfraMetadata()
+		if (mi.Kind == metadata.MailKindMX) != (mj.Kind == metadata.MailKindMX) {
+			return mi.Kind == metadata.MailKindMX
+		}
+		if mi.Priority != mj.Priority {
+			return mi.Priority < mj.Priority
+		}
+		return data.Mail[i].Value < data.Mail[j].Value
+	})
+
+	for _, c := range data.ByType {
+		artifacts := grouped[domain.ArtifactType(c.Label)]
+		domain.SortArtifacts(artifacts, domain.SortByValue, false)
+		section := reportSection{Type: domain.ArtifactType(c.Label), Artifacts: artifacts}
+		if len(artifacts) > reportAppendixLimit {
+			section.Artifacts = artifacts[:reportAppendixLimit]
+			section.Omitted = len(artifacts) - reportAppendixLimit
+		}
+		data.Appendix = append(data.Appendix, section)
+	}
+
+	return data
+}
+
+// assessRisk decide si un artifact es un riesgo destacable y con qué severidad.
+// La severidad del metadata (vulnerabilidades, secretos) prevalece sobre la
+// asignada por tipo.
+func assessRisk(a *domain.Artifact) (severity, reason string, ok bool) {
+	if a.Type == domain.ArtifactTypeURL {
+		return assessHeaderRisk(a)
+	}
+
+	switch a.Type {
+	case domain.ArtifactTypeWebshell:
+		severity, reason = "critical", "webshell exposed"
+	case domain.ArtifactTypeCredential:
+		severity, reason = "critical", "credential exposed"
+	case domain.ArtifactTypeSecret:
+		severity, reason = "high", "secret exposed"
+	case domain.ArtifactTypeVulnerability:
+		severity, reason = "medium", "vulnerability"
+	case domain.ArtifactTypeSensitiveFile:
+		severity, reason = "high", "sensitive file reachable"
+	case domain.ArtifactTypeBackupFile:
+		severity, reason = "medium", "backup file reachable"
+	case domain.ArtifactTypeStorageBucket:
+		severity, reason = "low", "storage bucket"
+	default:
+		return "", "", false
+	}
+
+	switch m := a.TypedMetadata.(type) {
+	case *metadata.VulnerabilityMetadata:
+		if severityRank[strings.ToLower(m.Severity)] > 0 {
+			severity = strings.ToLower(m.Severity)
+		}
+		reason = strings.TrimSpace(strings.Join(nonEmpty(m.CVE, m.Title), " "))
+	case *metadata.SecretMetadata:
+		if severityRank[strings.ToLower(m.Severity)] > 0 {
+			severity = strings.ToLower(m.Severity)
+		}
+		if m.Verified {
+			severity = "critical"
+		}
+		reason = "secret exposed (" + m.Kind + ")"
+	case *metadata.StorageBucketMetadata:
+		if m.PublicAccess {
+			severity, reason = "high", "publicly accessible bucket"
+		}
+	}
+
+	if reason == "" {
+		reason = string(a.Type)
+	}
+	return severity, reason, true
+}
+
+// RiskSeverity retorna la severidad con la que el informe destacaría a (la
+// clasificación de "Top risks") o "" si no es un riesgo.
+func RiskSeverity(a *domain.Artifact) string {
+	severity, _, _ := assessRisk(a)
+	return severity
+}
+
+// SeverityRank ordena las severidades de RiskSeverity (critical = 4 ...
+// low = 1); retorna 0 para una severidad desconocida.
+func SeverityRank(severity string) int {
+	return severityRank[strings.ToLower(severity)]
+}
+
+// assessHeaderRisk evalúa una URL sondeada: paneles de administración
+// expuestos (según la firma), cookies sin Secure/HttpOnly (medium),
+// cabeceras de seguridad ausentes (low) y la nota TLS (F high, C medium,
+// B low).
+func assessHeaderRisk(a *domain.Artifact) (severity, reason string, ok bool) {
+	m, isService := a.TypedMetadata.(*metadata.ServiceMetadata)
+	if !isService {
+		return "", "", false
+	}
+
+	var reasons []string
+	if m.ExposedPanel != "" {
+		severity = "medium"
+		reasons = append(reasons, "exposed "+strings.TrimSpace(m.ExposedPanel+" "+m.PanelVersion)+" panel")
+	}
+	if len(m.InsecureCookies) > 0 {
+		severity = "medium"
+		reasons = append(reasons, "cookies without Secure/HttpOnly ("+strings.Join(m.InsecureCookies, ", ")+")")
+	}
+	if len(m.MissingSecurityHeaders) > 0 {
+		if severity == "" {
+			severity = "low"
+		}
+		reasons = append(reasons, "missing security headers ("+strings.Join(m.MissingSecurityHeaders, ", ")+")")
+	}
+	if tlsSeverity, ok := tlsGradeSeverity[m.TLSGrade]; ok {
+		if severityRank[tlsSeverity] > severityRank[severity] {
+			severity = tlsSeverity
+		}
+		reasons = append(reasons, "weak TLS configuration (grade "+m.TLSGrade+")")
+	}
+	if len(reasons) == 0 {
+		return "", "", false
+	}
+	if severityRank[strings.ToLower(m.RiskLevel)] > severityRank[severity] {
+		severity = strings.ToLower(m.RiskLevel)
+	}
+	return severity, strings.Join(reasons, "; "), true
+}
+
+func sortedCounts(counts map[string]int) []reportCount {
+	out := make([]reportCount, 0, len(counts))
+	for label, n := range counts {
+		out = append(out, reportCount{Label: label, Count: n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Label < out[j].Label
+	})
+	return out
+}
+
+func nonEmpty(values ...string) []string {
+	var out []string
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
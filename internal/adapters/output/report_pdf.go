@@ -0,0 +1,487 @@
+// internal/adapters/output/report_pdf.go
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Paleta del informe (RGB).
+var (
+	pdfAccent   = [3]int{31, 78, 121}
+	pdfMuted    = [3]int{110, 110, 110}
+	pdfZebra    = [3]int{242, 245, 248}
+	pdfSeverity = map[string][3]int{
+		"critical": {176, 0, 32},
+		"high":     {214, 92, 0},
+		"medium":   {201, 153, 0},
+		"low":      {46, 125, 50},
+	}
+)
+
+const (
+	pdfMargin     = 15.0
+	pdfChartBars  = 12 // barras máximas por gráfico (el resto se agrupa en "other")
+	pdfValueWidth = 95.0
+)
+
+// WritePDFReport genera el informe PDF del scan (portada, gráficos, tendencias,
+// top risks y apéndices por tipo) junto al JSON consolidado y retorna su ruta.
+// Con enc != nil el PDF se escribe cifrado.
+func WritePDFReport(dir string, result *domain.ScanResult, enc *Encryptor) (string, error) {
+	return writeResultFile(dir, result, FormatPDF, ".pdf", enc, func(w io.Writer) error {
+		return RenderPDFReport(w, result)
+	})
+}
+
+// RenderPDFReport escribe el informe PDF en w.
+func RenderPDFReport(w io.Writer, result *domain.ScanResult) error {
+	data := buildReport(result)
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin+5)
+	pdf.SetTitle("AethonX report - "+result.Target.Root, true)
+	pdf.SetCreator("AethonX "+result.Metadata.Version, true)
+	if !result.Metadata.EndTime.IsZero() {
+		pdf.SetCreationDate(result.Metadata.EndTime)
+	}
+	pdf.AliasNbPages("")
+
+	r := &pdfReport{pdf: pdf, tr: pdf.UnicodeTranslatorFromDescriptor(""), data: data}
+	pdf.SetFooterFunc(r.footer)
+
+	r.cover()
+	r.summary()
+	r.trends()
+	r.risks()
+	r.expiring()
+	r.mail()
+	r.clusters()
+	r.appendix()
+
+	return pdf.Output(w)
+}
+
+// pdfReport agrupa el estado de renderizado del informe.
+type pdfReport struct {
+	pdf  *fpdf.Fpdf
+	tr   func(string) string // UTF-8 -> cp1252 (fuentes estándar)
+	data reportData
+}
+
+func (r *pdfReport) cover() {
+	res := r.data.Result
+	pdf := r.pdf
+	pdf.AddPage()
+
+	pdf.SetFillColor(pdfAccent[0], pdfAccent[1], pdfAccent[2])
+	pdf.Rect(0, 0, 210, 70, "F")
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetXY(pdfMargin, 25)
+	pdf.SetFont("Helvetica", "B", 26)
+	pdf.CellFormat(0, 12, "Reconnaissance Report", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 16)
+	pdf.CellFormat(0, 10, r.tr(res.Target.Root), "", 1, "L", false, 0, "")
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetY(90)
+	rows := [][2]string{
+		{"Scan ID", res.ID},
+		{"Target", res.Target.Root},
+		{"Mode", string(res.Target.Mode)},
+		{"Started", formatReportTime(res.Metadata.StartTime)},
+		{"Finished", formatReportTime(res.Metadata.EndTime)},
+		{"Duration", res.Metadata.DurationHuman},
+		{"Sources", strings.Join(res.Metadata.SourcesUsed, ", ")},
+		{"Artifacts", fmt.Sprintf("%d", len(res.Artifacts))},
+		{"Relations", fmt.Sprintf("%d", res.Metadata.TotalRelations)},
+		{"AethonX version", res.Metadata.Version},
+	}
+	if r.data.Redaction != "" {
+		rows = append(rows, [2]string{"Redaction", r.data.Redaction})
+	}
+	if len(res.Metadata.Truncations) > 0 {
+		notes := make([]string, 0, len(res.Metadata.Truncations))
+		for _, t := range res.Metadata.Truncations {
+			notes = append(notes, t.String())
+		}
+		rows = append(rows, [2]string{"Truncated", "incomplete results: " + strings.Join(notes, "; ")})
+	}
+	for _, row := range rows {
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(45, 8, row[0], "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 11)
+		pdf.MultiCell(0, 8, r.tr(row[1]), "", "L", false)
+	}
+
+	pdf.SetY(250)
+	pdf.SetFont("Helvetica", "I", 9)
+	r.muted()
+	pdf.MultiCell(0, 5, "Generated by AethonX. This report summarizes the consolidated scan; "+
+		"the JSON output contains the complete dataset and relation graph.", "", "L", false)
+	pdf.SetTextColor(0, 0, 0)
+}
+
+func (r *pdfReport) summary() {
+	r.pdf.AddPage()
+	r.heading("Summary")
+	r.barChart("Artifacts by type", r.data.ByType)
+	r.pdf.Ln(6)
+	r.barChart("Artifacts by source", r.data.BySource)
+}
+
+// trends dibuja la evolución de las métricas de superficie (modo monitor con
+// --track-lifecycle); requiere al menos dos escaneos.
+func (r *pdfReport) trends() {
+	points := r.data.Result.Trends
+	if len(points) < 2 {
+		return
+	}
+	pdf := r.pdf
+	pdf.AddPage()
+	r.heading("Attack surface trends")
+	pdf.SetFont("Helvetica", "", 10)
+	r.muted()
+	pdf.MultiCell(0, 5, fmt.Sprintf("%d scans from %s to %s", len(points),
+		formatReportTime(points[0].Time), formatReportTime(points[len(points)-1].Time)), "", "L", false)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(4)
+
+	for _, metric := range domain.TrendMetrics {
+		r.lineChart(trendTitles[metric], points, metric)
+		pdf.Ln(6)
+	}
+}
+
+// Títulos de las métricas de tendencia en el informe.
+var trendTitles = map[string]string{
+	"subdomains":     "Subdomains",
+	"alive_hosts":    "Alive hosts",
+	"open_ports":     "Open ports",
+	"certs_expiring": "Certificates expired or expiring within 30 days",
+}
+
+// lineChart dibuja la serie de una métrica con su valor actual y la
+// variación respecto al primer punto.
+func (r *pdfReport) lineChart(title string, points []domain.TrendPoint, metric string) {
+	pdf := r.pdf
+	const chartW, chartH = 180.0, 38.0
+
+	first, last := points[0].Value(metric), points[len(points)-1].Value(metric)
+	minV, maxV := first, first
+	for _, p := range points {
+		minV, maxV = min(minV, p.Value(metric)), max(maxV, p.Value(metric))
+	}
+
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(120, 7, title, "", 0, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 7, fmt.Sprintf("%d (%+d)", last, last-first), "", 1, "R", false, 0, "")
+
+	x0, y0 := pdfMargin, pdf.GetY()
+	pdf.SetDrawColor(pdfZebra[0]-30, pdfZebra[1]-30, pdfZebra[2]-30)
+	pdf.Rect(x0, y0, chartW, chartH, "D")
+
+	span := float64(maxV - minV)
+	if span == 0 {
+		span = 1
+	}
+	step := chartW / float64(len(points)-1)
+	pointAt := func(i int) (float64, float64) {
+		v := float64(points[i].Value(metric) - minV)
+		return x0 + step*float64(i), y0 + chartH - 2 - (chartH-4)*v/span
+	}
+
+	pdf.SetDrawColor(pdfAccent[0], pdfAccent[1], pdfAccent[2])
+	pdf.SetLineWidth(0.6)
+	for i := 1; i < len(points); i++ {
+		x1, y1 := pointAt(i - 1)
+		x2, y2 := pointAt(i)
+		pdf.Line(x1, y1, x2, y2)
+	}
+	pdf.SetLineWidth(0.2)
+	pdf.SetDrawColor(0, 0, 0)
+
+	pdf.SetFont("Helvetica", "", 8)
+	r.muted()
+	pdf.SetXY(x0, y0+chartH)
+	pdf.CellFormat(chartW/2, 5, fmt.Sprintf("min %d / max %d", minV, maxV), "", 0, "L", false, 0, "")
+	pdf.CellFormat(chartW/2, 5, points[len(points)-1].Time.UTC().Format("2006-01-02"), "", 1, "R", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+}
+
+func (r *pdfReport) risks() {
+	pdf := r.pdf
+	pdf.AddPage()
+	r.heading("Top risks")
+
+	if len(r.data.Risks) == 0 {
+		pdf.SetFont("Helvetica", "", 11)
+		pdf.MultiCell(0, 6, "No exposed secrets, credentials, vulnerabilities or sensitive files were found.", "", "L", false)
+		return
+	}
+
+	r.tableHeader([]string{"Severity", "Finding", "Artifact"}, []float64{25, 60, 95})
+	for i, risk := range r.data.Risks {
+		fill := i%2 == 1
+		r.zebra(fill)
+		color := pdfSeverity[risk.Severity]
+		pdf.SetTextColor(color[0], color[1], color[2])
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.CellFormat(25, 7, strings.ToUpper(risk.Severity), "", 0, "L", fill, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(60, 7, r.fit(risk.Reason, 60), "", 0, "L", fill, 0, "")
+		pdf.CellFormat(95, 7, r.fit(risk.Artifact.Value, 95), "", 1, "L", fill, 0, "")
+	}
+}
+
+// expiring lista los certificados caducados o que caducan en los próximos
+// 30 días, por host.
+func (r *pdfReport) expiring() {
+	if len(r.data.Expiring) == 0 {
+		return
+	}
+	pdf := r.pdf
+	pdf.Ln(8)
+	if pdf.GetY() > 230 {
+		pdf.AddPage()
+	}
+	r.heading("Certificates expiring within 30 days")
+
+	widths := []float64{70, 25, 30, 55}
+	r.tableHeader([]string{"Host", "Days left", "Expires", "Issuer"}, widths)
+	for i, cert := range r.data.Expiring {
+		fill := i%2 == 1
+		r.zebra(fill)
+		days := fmt.Sprintf("%d", cert.DaysRemaining)
+		color := pdfSeverity["medium"]
+		if cert.Expired() {
+			days, color = "expired", pdfSeverity["critical"]
+		}
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(widths[0], 7, r.fit(cert.Host, widths[0]), "", 0, "L", fill, 0, "")
+		pdf.SetTextColor(color[0], color[1], color[2])
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.CellFormat(widths[1], 7, days, "", 0, "L", fill, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(widths[2], 7, cert.ValidUntil.UTC().Format("2006-01-02"), "", 0, "L", fill, 0, "")
+		pdf.CellFormat(widths[3], 7, r.fit(cert.Issuer, widths[3]), "", 1, "L", fill, 0, "")
+	}
+}
+
+// mail resume la infraestructura de correo del dominio: proveedores
+// detectados, intercambiadores MX y registros SPF/DMARC.
+func (r *pdfReport) mail() {
+	if len(r.data.Mail) == 0 {
+		return
+	}
+	pdf := r.pdf
+	pdf.Ln(8)
+	if pdf.GetY() > 230 {
+		pdf.AddPage()
+	}
+	r.heading("Mail infrastructure")
+
+	if len(r.data.Providers) > 0 {
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(0, 7, "Providers: "+strings.Join(r.data.Providers, ", "), "", 1, "L", false, 0, "")
+		pdf.Ln(2)
+	}
+
+	widths := []float64{25, 110, 45}
+	r.tableHeader([]string{"Record", "Value", "Provider"}, widths)
+	for i, a := range r.data.Mail {
+		meta := a.GetMailInfraMetadata()
+		fill := i%2 == 1
+		r.zebra(fill)
+		kind, value := strings.ToUpper(meta.Kind), a.Value
+		if meta.Kind == metadata.MailKindMX {
+			value = fmt.Sprintf("%d %s", meta.Priority, a.Value)
+		}
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.CellFormat(widths[0], 7, kind, "", 0, "L", fill, 0, "")
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(widths[1], 7, r.fit(value, widths[1]), "", 0, "L", fill, 0, "")
+		pdf.CellFormat(widths[2], 7, r.fit(meta.Provider, widths[2]), "", 1, "L", fill, 0, "")
+	}
+}
+
+// clusters lista los grupos de hosts que sirven la misma página para que el
+// analista pueda descartar de un vistazo las páginas por defecto repetidas.
+func (r *pdfReport) clusters() {
+	if len(r.data.Clusters) == 0 {
+		return
+	}
+	pdf := r.pdf
+	pdf.Ln(8)
+	if pdf.GetY() > 230 {
+		pdf.AddPage()
+	}
+	r.heading("Host clusters")
+
+	widths := []float64{18, 72, 30, 60}
+	r.tableHeader([]string{"Hosts", "Title", "Favicon", "Technologies"}, widths)
+	for i, c := range r.data.Clusters {
+		fill := i%2 == 1
+		r.zebra(fill)
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.CellFormat(widths[0], 7, fmt.Sprintf("%d", len(c.Hosts)), "", 0, "L", fill, 0, "")
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(widths[1], 7, r.fit(c.Title, widths[1]), "", 0, "L", fill, 0, "")
+		pdf.CellFormat(widths[2], 7, r.fit(c.FaviconMMH3, widths[2]), "", 0, "L", fill, 0, "")
+		pdf.CellFormat(widths[3], 7, r.fit(strings.Join(c.Technologies, ", "), widths[3]), "", 1, "L", fill, 0, "")
+	}
+}
+
+func (r *pdfReport) appendix() {
+	pdf := r.pdf
+	for i, section := range r.data.Appendix {
+		if i == 0 {
+			pdf.AddPage()
+			r.heading("Appendix: artifacts by type")
+		}
+		if pdf.GetY() > 250 {
+			pdf.AddPage()
+		}
+
+		pdf.Ln(3)
+		pdf.SetFont("Helvetica", "B", 12)
+		pdf.CellFormat(0, 8, fmt.Sprintf("%s (%d)", section.Type, len(section.Artifacts)+section.Omitted), "", 1, "L", false, 0, "")
+
+		widths := []float64{pdfValueWidth, 45, 20, 20}
+		r.tableHeader([]string{"Value", "Sources", "Confidence", "Tags"}, widths)
+		for j, a := range section.Artifacts {
+			fill := j%2 == 1
+			r.zebra(fill)
+			pdf.SetFont("Helvetica", "", 8)
+			pdf.CellFormat(widths[0], 6, r.fit(a.Value, widths[0]), "", 0, "L", fill, 0, "")
+			pdf.CellFormat(widths[1], 6, r.fit(strings.Join(a.Sources, ", "), widths[1]), "", 0, "L", fill, 0, "")
+			pdf.CellFormat(widths[2], 6, fmt.Sprintf("%.2f", a.Confidence), "", 0, "R", fill, 0, "")
+			pdf.CellFormat(widths[3], 6, r.fit(strings.Join(a.Tags, ", "), widths[3]), "", 1, "L", fill, 0, "")
+		}
+		if section.Omitted > 0 {
+			pdf.SetFont("Helvetica", "I", 8)
+			r.muted()
+			pdf.CellFormat(0, 6, fmt.Sprintf("... %d more (see the JSON output)", section.Omitted), "", 1, "L", false, 0, "")
+			pdf.SetTextColor(0, 0, 0)
+		}
+	}
+}
+
+// barChart dibuja un gráfico de barras horizontales.
+func (r *pdfReport) barChart(title string, counts []reportCount) {
+	pdf := r.pdf
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(0, 8, title, "", 1, "L", false, 0, "")
+
+	if len(counts) == 0 {
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(0, 6, "No data", "", 1, "L", false, 0, "")
+		return
+	}
+
+	if len(counts) > pdfChartBars {
+		other := 0
+		for _, c := range counts[pdfChartBars-1:] {
+			other += c.Count
+		}
+		counts = append(append([]reportCount{}, counts[:pdfChartBars-1]...), reportCount{Label: "other", Count: other})
+	}
+
+	maxCount := 0
+	for _, c := range counts {
+		maxCount = max(maxCount, c.Count)
+	}
+
+	const labelW, barH, barMaxW = 45.0, 6.0, 115.0
+	pdf.SetFont("Helvetica", "", 9)
+	for _, c := range counts {
+		y := pdf.GetY()
+		pdf.CellFormat(labelW, barH, r.fit(c.Label, labelW), "", 0, "L", false, 0, "")
+
+		w := barMaxW * float64(c.Count) / float64(maxCount)
+		pdf.SetFillColor(pdfAccent[0], pdfAccent[1], pdfAccent[2])
+		pdf.Rect(pdfMargin+labelW, y+1, max(w, 0.5), barH-2, "F")
+
+		pdf.SetX(pdfMargin + labelW + w + 2)
+		pdf.CellFormat(20, barH, fmt.Sprintf("%d", c.Count), "", 1, "L", false, 0, "")
+	}
+}
+
+func (r *pdfReport) heading(text string) {
+	pdf := r.pdf
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.SetTextColor(pdfAccent[0], pdfAccent[1], pdfAccent[2])
+	pdf.CellFormat(0, 12, text, "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(2)
+}
+
+func (r *pdfReport) tableHeader(cols []string, widths []float64) {
+	pdf := r.pdf
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(pdfAccent[0], pdfAccent[1], pdfAccent[2])
+	pdf.SetTextColor(255, 255, 255)
+	for i, col := range cols {
+		ln := 0
+		if i == len(cols)-1 {
+			ln = 1
+		}
+		pdf.CellFormat(widths[i], 7, col, "", ln, "L", true, 0, "")
+	}
+	pdf.SetTextColor(0, 0, 0)
+}
+
+func (r *pdfReport) footer() {
+	pdf := r.pdf
+	if pdf.PageNo() == 1 {
+		return
+	}
+	pdf.SetY(-pdfMargin)
+	pdf.SetFont("Helvetica", "", 8)
+	r.muted()
+	pdf.CellFormat(0, 5, r.tr(r.data.Result.Target.Root), "", 0, "L", false, 0, "")
+	pdf.SetX(pdfMargin)
+	pdf.CellFormat(0, 5, fmt.Sprintf("Page %d/{nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+}
+
+func (r *pdfReport) zebra(fill bool) {
+	if fill {
+		r.pdf.SetFillColor(pdfZebra[0], pdfZebra[1], pdfZebra[2])
+	}
+}
+
+func (r *pdfReport) muted() {
+	r.pdf.SetTextColor(pdfMuted[0], pdfMuted[1], pdfMuted[2])
+}
+
+// fit traduce el texto y lo recorta con "..." para que quepa en width mm.
+func (r *pdfReport) fit(text string, width float64) string {
+	text = r.tr(text)
+	width -= 2 // margen interno de la celda
+	if r.pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	for len(text) > 0 && r.pdf.GetStringWidth(text+"...") > width {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}
+
+func formatReportTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format("2006-01-02 15:04:05 UTC")
+}
@@ -0,0 +1,194 @@
+// internal/adapters/output/report_test.go
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+)
+
+func reportFixture() *domain.ScanResult {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+	result.ID = "scan-report"
+	result.Metadata.SourcesUsed = []string{"crtsh", "httpx"}
+
+	for i := 0; i < 5; i++ {
+		result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, fmt.Sprintf("h%d.example.com", i), "crtsh"))
+	}
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeBackupFile, "https://example.com/site.zip", "httpx"))
+
+	vuln := domain.NewArtifact(domain.ArtifactTypeVulnerability, "CVE-2021-44228", "httpx")
+	vuln.TypedMetadata = &metadata.VulnerabilityMetadata{CVE: "CVE-2021-44228", Title: "Log4Shell", Severity: "critical"}
+	result.AddArtifact(vuln)
+
+	return result
+}
+
+func TestBuildReport(t *testing.T) {
+	data := buildReport(reportFixture())
+
+	if len(data.ByType) != 3 || data.ByType[0].Label != "subdomain" || data.ByType[0].Count != 5 {
+		t.Errorf("ByType should be sorted by count, got %+v", data.ByType)
+	}
+	if len(data.BySource) != 2 || data.BySource[0].Label != "crtsh" {
+		t.Errorf("unexpected BySource: %+v", data.BySource)
+	}
+
+	if len(data.Risks) != 2 {
+		t.Fatalf("expected 2 risks, got %d", len(data.Risks))
+	}
+	if data.Risks[0].Severity != "critical" || !strings.Contains(data.Risks[0].Reason, "Log4Shell") {
+		t.Errorf("vulnerability severity should come from metadata and rank first, got %+v", data.Risks[0])
+	}
+	if data.Risks[1].Severity != "medium" {
+		t.Errorf("backup file should be medium, got %s", data.Risks[1].Severity)
+	}
+
+	if len(data.Appendix) != 3 || data.Appendix[0].Artifacts[0].Value != "h0.example.com" {
+		t.Errorf("appendix should group by type sorted by value")
+	}
+}
+
+func TestAssessRisk_SecurityHeaders(t *testing.T) {
+	url := domain.NewArtifact(domain.ArtifactTypeURL, "https://example.com", "httpx")
+	url.TypedMetadata = &metadata.ServiceMetadata{MissingSecurityHeaders: []string{"hsts", "csp"}}
+
+	severity, reason, ok := assessRisk(url)
+	if !ok || severity != "low" || !strings.Contains(reason, "hsts, csp") {
+		t.Errorf("missing headers should be a low risk, got %s %q %v", severity, reason, ok)
+	}
+
+	url.TypedMetadata = &metadata.ServiceMetadata{MissingSecurityHeaders: []string{"csp"}, InsecureCookies: []string{"sid"}}
+	severity, reason, _ = assessRisk(url)
+	if severity != "medium" || !strings.Contains(reason, "sid") {
+		t.Errorf("insecure cookies should be a medium risk, got %s %q", severity, reason)
+	}
+
+	url.TypedMetadata = &metadata.ServiceMetadata{ExposedPanel: "Jenkins", PanelVersion: "2.401", RiskLevel: "high"}
+	severity, reason, _ = assessRisk(url)
+	if severity != "high" || reason != "exposed Jenkins 2.401 panel" {
+		t.Errorf("exposed panel should use its risk level, got %s %q", severity, reason)
+	}
+
+	url.TypedMetadata = &metadata.ServiceMetadata{MissingSecurityHeaders: []string{"csp"}, TLSGrade: "F"}
+	severity, reason, _ = assessRisk(url)
+	if severity != "high" || !strings.Contains(reason, "grade F") {
+		t.Errorf("failing TLS grade should be a high risk, got %s %q", severity, reason)
+	}
+
+	url.TypedMetadata = &metadata.ServiceMetadata{Port: 443, TLSGrade: "A"}
+	if _, _, ok := assessRisk(url); ok {
+		t.Error("URL without header findings should not be a risk")
+	}
+	if RiskSeverity(url) != "" {
+		t.Error("RiskSeverity should be empty for a non-risk")
+	}
+}
+
+func TestSeverityRank(t *testing.T) {
+	if SeverityRank("High") <= SeverityRank("medium") || SeverityRank("low") != 1 {
+		t.Errorf("unexpected ranks: high=%d medium=%d low=%d", SeverityRank("High"), SeverityRank("medium"), SeverityRank("low"))
+	}
+	if SeverityRank("urgent") != 0 {
+		t.Error("unknown severity should rank 0")
+	}
+}
+
+func TestBuildReport_Mail(t *testing.T) {
+	result := reportFixture()
+	result.AddArtifact(domain.NewTXTRecordArtifact("example.com", "v=spf1 include:_spf.google.com ~all", metadata.MailKindSPF, "dns"))
+	result.AddArtifact(domain.NewTXTRecordArtifact("example.com", "unrelated=token", metadata.MailKindTXT, "dns"))
+	backup := domain.NewMXRecordArtifact("example.com", "alt1.aspmx.l.google.com", 5, "dns")
+	backup.GetMailInfraMetadata().Provider = "Google Workspace"
+	primary := domain.NewMXRecordArtifact("example.com", "aspmx.l.google.com", 1, "dns")
+	primary.GetMailInfraMetadata().Provider = "Google Workspace"
+	result.AddArtifacts(backup, primary)
+
+	data := buildReport(result)
+	if len(data.Mail) != 3 {
+		t.Fatalf("expected 2 MX and the SPF record, got %d", len(data.Mail))
+	}
+	if data.Mail[0].Value != "aspmx.l.google.com" || data.Mail[2].Type != domain.ArtifactTypeTXTRecord {
+		t.Errorf("MX should come first by preference, got %s, %s, %s", data.Mail[0].Value, data.Mail[1].Value, data.Mail[2].Value)
+	}
+	if len(data.Providers) != 1 || data.Providers[0] != "Google Workspace" {
+		t.Errorf("unexpected providers: %v", data.Providers)
+	}
+
+	var buf bytes.Buffer
+	if err := RenderPDFReport(&buf, result); err != nil {
+		t.Fatalf("RenderPDFReport() failed: %v", err)
+	}
+}
+
+func TestBuildReport_Clusters(t *testing.T) {
+	result := reportFixture()
+	for i := 0; i < 3; i++ {
+		a := domain.NewArtifactWithMetadata(domain.ArtifactTypeURL, fmt.Sprintf("https://h%d.example.com", i), "httpx",
+			&metadata.ServiceMetadata{Name: "https", Port: 443, Title: "Welcome to nginx!", FaviconMMH3: "-1234"})
+		a.AddTag("alive")
+		result.AddArtifact(a)
+	}
+
+	data := buildReport(result)
+	if len(data.Clusters) != 1 || len(data.Clusters[0].Hosts) != 3 {
+		t.Fatalf("expected one cluster of 3 hosts, got %+v", data.Clusters)
+	}
+
+	var buf bytes.Buffer
+	if err := RenderPDFReport(&buf, result); err != nil {
+		t.Fatalf("RenderPDFReport() failed: %v", err)
+	}
+}
+
+func TestWritePDFReport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderPDFReport(&buf, reportFixture()); err != nil {
+		t.Fatalf("RenderPDFReport() failed: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Error("output is not a PDF")
+	}
+
+	path, err := WritePDFReport(t.TempDir(), reportFixture(), nil)
+	if err != nil {
+		t.Fatalf("WritePDFReport() failed: %v", err)
+	}
+	if !strings.HasSuffix(path, "_report.pdf") {
+		t.Errorf("unexpected report path %s", path)
+	}
+	if fi, err := os.Stat(path); err != nil || fi.Size() == 0 {
+		t.Errorf("report file missing or empty: %v", err)
+	}
+}
+
+func TestRenderPDFReport_Trends(t *testing.T) {
+	result := reportFixture()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		result.Trends = append(result.Trends, domain.TrendPoint{
+			ScanID:     fmt.Sprintf("scan-%d", i),
+			Time:       base.Add(time.Duration(i) * 24 * time.Hour),
+			Subdomains: 10 + i*3,
+			AliveHosts: 5 + i,
+		})
+	}
+
+	var withTrends, without bytes.Buffer
+	if err := RenderPDFReport(&withTrends, result); err != nil {
+		t.Fatalf("RenderPDFReport() failed: %v", err)
+	}
+	if err := RenderPDFReport(&without, reportFixture()); err != nil {
+		t.Fatalf("RenderPDFReport() failed: %v", err)
+	}
+	if withTrends.Len() <= without.Len() {
+		t.Error("expected the trends page to be rendered")
+	}
+}
@@ -0,0 +1,54 @@
+// internal/adapters/output/screenshots.go
+package output
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+
+	"aethonx/internal/core/domain"
+)
+
+// WriteHostList escribe los hosts vivos, uno por línea (<file>_hosts.txt),
+// en el formato de entrada de Aquatone (cat hosts.txt | aquatone).
+func WriteHostList(dir string, result *domain.ScanResult, enc *Encryptor) (string, error) {
+	return writeResultFile(dir, result, FormatHosts, ".txt", enc, func(w io.Writer) error {
+		for _, host := range AliveHosts(result) {
+			if _, err := fmt.Fprintln(w, host); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AliveHosts retorna los hosts que respondieron a un probe: dominios y
+// subdominios vivos y los hosts de las URLs vivas, ordenados y sin duplicados.
+func AliveHosts(result *domain.ScanResult) []string {
+	seen := make(map[string]bool)
+	var hosts []string
+	add := func(host string) {
+		if host != "" && !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+
+	for _, a := range result.Artifacts {
+		if a == nil || !a.IsAlive() {
+			continue
+		}
+		switch a.Type {
+		case domain.ArtifactTypeDomain, domain.ArtifactTypeSubdomain:
+			add(a.Value)
+		}
+	}
+	for _, raw := range AliveURLs(result) {
+		if u, err := url.Parse(raw); err == nil {
+			add(u.Hostname())
+		}
+	}
+	sort.Strings(hosts)
+	return hosts
+}
@@ -0,0 +1,243 @@
+// internal/adapters/output/sharded.go
+package output
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/compress"
+)
+
+// Disposición por shards del consolidado (--shard): un directorio
+// aethonx_<target>_<timestamp>/ con
+//
+//	index.json            manifiesto (recuentos, ficheros y SHA-256)
+//	scan.json             el ScanResult sin artifacts (metadata, warnings, ...)
+//	artifacts/<tipo>.json un array de artifacts por ArtifactType
+//
+// Los lectores solo abren los shards de los tipos que necesitan.
+const (
+	ShardIndexFile = "index.json"
+	shardScanFile  = "scan.json"
+	shardDir       = "artifacts"
+
+	// ShardIndexVersion es la versión del formato de index.json.
+	ShardIndexVersion = 1
+)
+
+// ShardIndex es el manifiesto de un consolidado por shards.
+type ShardIndex struct {
+	Version   int         `json:"version"`
+	ScanID    string      `json:"scan_id"`
+	Target    string      `json:"target"`
+	Artifacts int         `json:"artifacts"`
+	Scan      ShardFile   `json:"scan"`
+	Shards    []ShardFile `json:"shards"`
+}
+
+// ShardFile describe un fichero del consolidado. SHA256 es el del fichero
+// tal como está en disco (comprimido o cifrado si procede).
+type ShardFile struct {
+	Type   domain.ArtifactType `json:"type,omitempty"`
+	File   string              `json:"file"` // Relativo al directorio del índice
+	Count  int                 `json:"count,omitempty"`
+	SHA256 string              `json:"sha256"`
+}
+
+// WriteShardedJSON escribe el consolidado como un directorio de shards por
+// tipo de artifact y retorna la ruta de su index.json (la que se firma: el
+// índice lleva el hash de cada shard). codec y enc se aplican a cada shard y
+// a scan.json; el índice queda en claro y se escribe el último, así que un
+// directorio sin index.json es un consolidado incompleto.
+func WriteShardedJSON(dir string, result *domain.ScanResult, codec compress.Codec, enc *Encryptor) (string, error) {
+	root, err := resultPath(dir, result, FormatJSON, "")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Join(root, shardDir), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create shard directory: %w", err)
+	}
+
+	ext := ".json" + codec.Extension()
+	if enc != nil {
+		ext += enc.Extension()
+	}
+	write := func(rel string, v interface{}) (ShardFile, error) {
+		var buf bytes.Buffer
+		zw, err := compress.NewWriter(&buf, codec)
+		if err != nil {
+			return ShardFile{}, err
+		}
+		jsonEnc := json.NewEncoder(zw)
+		jsonEnc.SetIndent("", "  ")
+		if err := jsonEnc.Encode(v); err != nil {
+			zw.Close()
+			return ShardFile{}, fmt.Errorf("failed to encode %s: %w", rel, err)
+		}
+		if err := zw.Close(); err != nil {
+			return ShardFile{}, fmt.Errorf("failed to compress %s: %w", rel, err)
+		}
+
+		data := buf.Bytes()
+		if enc != nil {
+			var out bytes.Buffer
+			if err := enc.Encrypt(&out, &buf); err != nil {
+				return ShardFile{}, err
+			}
+			data = out.Bytes()
+		}
+		sum := sha256.Sum256(data)
+		if err := writeFileAtomic(filepath.Join(root, rel), data); err != nil {
+			return ShardFile{}, fmt.Errorf("failed to write %s: %w", rel, err)
+		}
+		return ShardFile{File: filepath.ToSlash(rel), SHA256: hex.EncodeToString(sum[:])}, nil
+	}
+
+	byType := make(map[domain.ArtifactType][]*domain.Artifact)
+	for _, a := range result.Artifacts {
+		if a != nil {
+			byType[a.Type] = append(byType[a.Type], a)
+		}
+	}
+	types := make([]domain.ArtifactType, 0, len(byType))
+	for t := range byType {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	index := ShardIndex{
+		Version: ShardIndexVersion,
+		ScanID:  result.ID,
+		Target:  result.Target.Root,
+	}
+	for _, t := range types {
+		shard, err := write(filepath.Join(shardDir, string(t)+ext), byType[t])
+		if err != nil {
+			return "", err
+		}
+		shard.Type, shard.Count = t, len(byType[t])
+		index.Shards = append(index.Shards, shard)
+		index.Artifacts += shard.Count
+	}
+
+	header := *result
+	header.Artifacts = nil
+	if index.Scan, err = write(shardScanFile+strings.TrimPrefix(ext, ".json"), &header); err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode shard index: %w", err)
+	}
+	indexPath := filepath.Join(root, ShardIndexFile)
+	if err := writeFileAtomic(indexPath, append(data, '\n')); err != nil {
+		return "", fmt.Errorf("failed to write shard index: %w", err)
+	}
+	return indexPath, nil
+}
+
+// IsSharded indica si path es un consolidado por shards (su directorio o su
+// index.json).
+func IsSharded(path string) bool {
+	if filepath.Base(path) == ShardIndexFile {
+		return true
+	}
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir() && isFile(filepath.Join(path, ShardIndexFile))
+}
+
+// ReadShardIndex carga el index.json de un consolidado por shards (path es
+// el directorio o el propio índice).
+func ReadShardIndex(path string) (*ShardIndex, string, error) {
+	if filepath.Base(path) != ShardIndexFile {
+		path = filepath.Join(path, ShardIndexFile)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read shard index: %w", err)
+	}
+	var index ShardIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, "", fmt.Errorf("failed to decode shard index %s: %w", path, err)
+	}
+	if index.Version > ShardIndexVersion {
+		return nil, "", fmt.Errorf("shard index %s has version %d, newer than supported (%d)", path, index.Version, ShardIndexVersion)
+	}
+	return &index, filepath.Dir(path), nil
+}
+
+// ReadScanTypes carga el escaneo de path con solo los artifacts de types
+// (todos si está vacío). De un consolidado por shards solo lee los shards de
+// esos tipos y comprueba su SHA-256 contra el índice; de un JSON consolidado
+// lo lee entero y filtra.
+func ReadScanTypes(path string, types []domain.ArtifactType) (*domain.ScanResult, error) {
+	if !IsSharded(path) {
+		result, err := readConsolidated(path)
+		if err != nil || len(types) == 0 {
+			return result, err
+		}
+		return result.Filtered(domain.ArtifactFilter{Types: types}), nil
+	}
+
+	index, root, err := ReadShardIndex(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result domain.ScanResult
+	if err := readShard(root, index.Scan, &result); err != nil {
+		return nil, err
+	}
+	for _, shard := range index.Shards {
+		if len(types) > 0 && !slices.Contains(types, shard.Type) {
+			continue
+		}
+		var artifacts []*domain.Artifact
+		if err := readShard(root, shard, &artifacts); err != nil {
+			return nil, err
+		}
+		result.Artifacts = append(result.Artifacts, artifacts...)
+	}
+
+	if _, err := domain.MigrateArtifactIDs(&result); err != nil {
+		return nil, fmt.Errorf("failed to migrate scan %s: %w", path, err)
+	}
+	return &result, nil
+}
+
+// readShard lee un fichero del consolidado, comprueba su hash y lo decodifica en v.
+func readShard(root string, shard ShardFile, v interface{}) error {
+	path := filepath.Join(root, filepath.FromSlash(shard.File))
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read shard: %w", err)
+	}
+	if sum := sha256.Sum256(raw); hex.EncodeToString(sum[:]) != shard.SHA256 {
+		return fmt.Errorf("shard %s does not match its SHA-256 in %s", shard.File, ShardIndexFile)
+	}
+
+	zr, err := compress.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("failed to decompress shard %s: %w", shard.File, err)
+	}
+	defer zr.Close()
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return fmt.Errorf("failed to decompress shard %s: %w", shard.File, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode shard %s: %w", shard.File, err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// internal/adapters/output/sharded_test.go
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/compress"
+)
+
+func newShardedResult() *domain.ScanResult {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "a.example.com", "crtsh"))
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "b.example.com", "crtsh"))
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeURL, "https://a.example.com/", "httpx"))
+	result.AddWarning("crtsh", "partial results")
+	result.Finalize()
+	return result
+}
+
+func TestWriteShardedJSON_RoundTrip(t *testing.T) {
+	result := newShardedResult()
+
+	indexPath, err := WriteShardedJSON(t.TempDir(), result, compress.Gzip, nil)
+	if err != nil {
+		t.Fatalf("WriteShardedJSON() failed: %v", err)
+	}
+	if filepath.Base(indexPath) != ShardIndexFile {
+		t.Errorf("expected the index path, got %s", indexPath)
+	}
+
+	index, root, err := ReadShardIndex(filepath.Dir(indexPath))
+	if err != nil {
+		t.Fatalf("ReadShardIndex() failed: %v", err)
+	}
+	if index.Artifacts != 3 || len(index.Shards) != 2 {
+		t.Fatalf("expected 3 artifacts in 2 shards, got %d in %d", index.Artifacts, len(index.Shards))
+	}
+	for _, shard := range index.Shards {
+		if !strings.HasSuffix(shard.File, ".json.gz") || shard.SHA256 == "" {
+			t.Errorf("unexpected shard entry %+v", shard)
+		}
+		if !isFile(filepath.Join(root, shard.File)) {
+			t.Errorf("shard %s missing on disk", shard.File)
+		}
+	}
+	if index.Shards[0].Type != domain.ArtifactTypeSubdomain || index.Shards[0].Count != 2 {
+		t.Errorf("expected 2 subdomains in the first shard, got %+v", index.Shards[0])
+	}
+
+	// ReadJSON acepta tanto el directorio como el índice
+	for _, path := range []string{root, indexPath} {
+		if !IsSharded(path) {
+			t.Errorf("IsSharded(%s) = false", path)
+		}
+		decoded, err := ReadJSON(path)
+		if err != nil {
+			t.Fatalf("ReadJSON(%s) failed: %v", path, err)
+		}
+		if decoded.ID != result.ID || len(decoded.Artifacts) != 3 || len(decoded.Warnings) != 1 {
+			t.Errorf("round trip mismatch: id=%s artifacts=%d warnings=%d",
+				decoded.ID, len(decoded.Artifacts), len(decoded.Warnings))
+		}
+	}
+}
+
+func TestReadScanTypes_OnlySelectedShards(t *testing.T) {
+	indexPath, err := WriteShardedJSON(t.TempDir(), newShardedResult(), compress.None, nil)
+	if err != nil {
+		t.Fatalf("WriteShardedJSON() failed: %v", err)
+	}
+
+	// Sin el shard de subdominios la lectura de URLs no debe tocarlo
+	if err := os.Remove(filepath.Join(filepath.Dir(indexPath), shardDir, "subdomain.json")); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := ReadScanTypes(indexPath, []domain.ArtifactType{domain.ArtifactTypeURL})
+	if err != nil {
+		t.Fatalf("ReadScanTypes() failed: %v", err)
+	}
+	if len(decoded.Artifacts) != 1 || decoded.Artifacts[0].Type != domain.ArtifactTypeURL {
+		t.Errorf("expected only the url artifact, got %d artifacts", len(decoded.Artifacts))
+	}
+
+	if _, err := ReadJSON(indexPath); err == nil {
+		t.Error("expected an error reading a missing shard")
+	}
+}
+
+func TestReadScanTypes_TamperedShard(t *testing.T) {
+	indexPath, err := WriteShardedJSON(t.TempDir(), newShardedResult(), compress.None, nil)
+	if err != nil {
+		t.Fatalf("WriteShardedJSON() failed: %v", err)
+	}
+
+	shard := filepath.Join(filepath.Dir(indexPath), shardDir, "url.json")
+	data, err := os.ReadFile(shard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(string(data), "a.example.com", "evil.example.com", 1)
+	if err := os.WriteFile(shard, []byte(tampered), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ReadScanTypes(indexPath, []domain.ArtifactType{domain.ArtifactTypeURL})
+	if err == nil || !strings.Contains(err.Error(), "SHA-256") {
+		t.Errorf("expected a hash mismatch error, got %v", err)
+	}
+}
+
+func TestIsSharded_ConsolidatedFile(t *testing.T) {
+	path, err := WriteJSON(t.TempDir(), newShardedResult(), compress.None, nil)
+	if err != nil {
+		t.Fatalf("WriteJSON() failed: %v", err)
+	}
+	if IsSharded(path) || IsSharded(filepath.Dir(path)) {
+		t.Error("a consolidated file is not a sharded scan")
+	}
+
+	decoded, err := ReadScanTypes(path, []domain.ArtifactType{domain.ArtifactTypeSubdomain})
+	if err != nil {
+		t.Fatalf("ReadScanTypes() failed: %v", err)
+	}
+	if len(decoded.Artifacts) != 2 {
+		t.Errorf("expected 2 subdomains, got %d", len(decoded.Artifacts))
+	}
+}
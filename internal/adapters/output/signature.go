@@ -0,0 +1,183 @@
+// internal/adapters/output/signature.go
+package output
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// SignatureExt es la extensión de la firma separada (<scan>.json.sig).
+const SignatureExt = ".sig"
+
+// SignatureAlgorithm es el único algoritmo soportado.
+const SignatureAlgorithm = "ed25519"
+
+// ErrSignatureMismatch indica que el fichero fue modificado tras firmarse
+// o que la firma no corresponde a la clave.
+var ErrSignatureMismatch = errors.New("signature verification failed")
+
+// ErrUntrustedKey indica que la firma es válida pero con una clave distinta
+// de la esperada.
+var ErrUntrustedKey = errors.New("signed with an untrusted key")
+
+// Signature es la firma separada de un resultado consolidado. Se firma el
+// contenido exacto del fichero, no una re-serialización.
+type Signature struct {
+	Algorithm   string    `json:"algorithm"`
+	PublicKey   string    `json:"public_key"`  // base64 de la clave pública
+	Fingerprint string    `json:"fingerprint"` // sha256 de la clave pública (hex, 16 chars)
+	SHA256      string    `json:"sha256"`      // digest del fichero firmado
+	Signature   string    `json:"signature"`   // base64
+	SignedAt    time.Time `json:"signed_at"`
+}
+
+// LoadSigningKey lee una clave privada ed25519: PEM PKCS#8 ("PRIVATE KEY",
+// p.ej. `openssl genpkey -algorithm ed25519`) o base64 de la semilla (32 bytes)
+// o de la clave completa (64 bytes).
+func LoadSigningKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+
+	if block, _ := pem.Decode(data); block != nil {
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing key %s: %w", path, err)
+		}
+		priv, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("signing key %s is not ed25519", path)
+		}
+		return priv, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key %s: expected PEM or base64", path)
+	}
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(raw), nil
+	default:
+		return nil, fmt.Errorf("invalid signing key %s: unexpected length %d", path, len(raw))
+	}
+}
+
+// LoadPublicKey lee una clave pública ed25519: PEM PKIX ("PUBLIC KEY") o base64.
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	if block, _ := pem.Decode(data); block != nil {
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key %s: %w", path, err)
+		}
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("public key %s is not ed25519", path)
+		}
+		return pub, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key %s: expected PEM or base64", path)
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// KeyFingerprint retorna un identificador corto de la clave pública.
+func KeyFingerprint(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:8])
+}
+
+// SignFile firma el fichero y escribe <path>.sig. Retorna la ruta de la firma.
+func SignFile(path string, key ed25519.PrivateKey) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file to sign: %w", err)
+	}
+
+	pub := key.Public().(ed25519.PublicKey)
+	digest := sha256.Sum256(data)
+	sig := Signature{
+		Algorithm:   SignatureAlgorithm,
+		PublicKey:   base64.StdEncoding.EncodeToString(pub),
+		Fingerprint: KeyFingerprint(pub),
+		SHA256:      hex.EncodeToString(digest[:]),
+		Signature:   base64.StdEncoding.EncodeToString(ed25519.Sign(key, data)),
+		SignedAt:    time.Now().UTC(),
+	}
+
+	encoded, err := json.MarshalIndent(sig, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	sigPath := path + SignatureExt
+	if err := writeFileAtomic(sigPath, append(encoded, '\n')); err != nil {
+		return "", fmt.Errorf("failed to write signature: %w", err)
+	}
+	return sigPath, nil
+}
+
+// VerifyFile comprueba la firma separada de un fichero. Si trusted no es nil,
+// la firma debe haberse hecho con esa clave (ErrUntrustedKey si no). Sin clave
+// de confianza solo se garantiza integridad respecto a la clave embebida.
+func VerifyFile(path, sigPath string, trusted ed25519.PublicKey) (*Signature, error) {
+	if sigPath == "" {
+		sigPath = path + SignatureExt
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	rawSig, err := os.ReadFile(sigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signature: %w", err)
+	}
+
+	var sig Signature
+	if err := json.Unmarshal(rawSig, &sig); err != nil {
+		return nil, fmt.Errorf("invalid signature file %s: %w", sigPath, err)
+	}
+	if sig.Algorithm != SignatureAlgorithm {
+		return &sig, fmt.Errorf("unsupported signature algorithm %q", sig.Algorithm)
+	}
+
+	pub, err := base64.StdEncoding.DecodeString(sig.PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return &sig, fmt.Errorf("invalid public key in %s", sigPath)
+	}
+	signature, err := base64.StdEncoding.DecodeString(sig.Signature)
+	if err != nil {
+		return &sig, fmt.Errorf("invalid signature encoding in %s", sigPath)
+	}
+
+	if !ed25519.Verify(pub, data, signature) {
+		return &sig, ErrSignatureMismatch
+	}
+	if trusted != nil && !trusted.Equal(ed25519.PublicKey(pub)) {
+		return &sig, fmt.Errorf("%w: %s (expected %s)", ErrUntrustedKey, KeyFingerprint(pub), KeyFingerprint(trusted))
+	}
+
+	return &sig, nil
+}
@@ -0,0 +1,84 @@
+// internal/adapters/output/signature_test.go
+package output
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSignAndVerifyFile(t *testing.T) {
+	dir := t.TempDir()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("keygen: %v", err)
+	}
+
+	scan := filepath.Join(dir, "aethonx_example.com.json")
+	if err := os.WriteFile(scan, []byte(`{"ID":"scan-1"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sigPath, err := SignFile(scan, priv)
+	if err != nil {
+		t.Fatalf("SignFile() failed: %v", err)
+	}
+
+	sig, err := VerifyFile(scan, "", pub)
+	if err != nil {
+		t.Fatalf("VerifyFile() failed: %v", err)
+	}
+	if sig.Fingerprint != KeyFingerprint(pub) {
+		t.Errorf("fingerprint mismatch: %s", sig.Fingerprint)
+	}
+
+	otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	if _, err := VerifyFile(scan, sigPath, otherPub); !errors.Is(err, ErrUntrustedKey) {
+		t.Errorf("expected ErrUntrustedKey, got %v", err)
+	}
+
+	// Editar el resultado tras firmarlo invalida la firma
+	if err := os.WriteFile(scan, []byte(`{"ID":"scan-2"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyFile(scan, sigPath, nil); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("expected ErrSignatureMismatch, got %v", err)
+	}
+}
+
+func TestLoadSigningKey_Formats(t *testing.T) {
+	dir := t.TempDir()
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemPath := filepath.Join(dir, "key.pem")
+	os.WriteFile(pemPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+
+	seedPath := filepath.Join(dir, "key.b64")
+	os.WriteFile(seedPath, []byte(base64.StdEncoding.EncodeToString(priv.Seed())+"\n"), 0o600)
+
+	for _, path := range []string{pemPath, seedPath} {
+		got, err := LoadSigningKey(path)
+		if err != nil {
+			t.Fatalf("LoadSigningKey(%s) failed: %v", path, err)
+		}
+		if !got.Equal(priv) {
+			t.Errorf("LoadSigningKey(%s) returned a different key", path)
+		}
+	}
+
+	badPath := filepath.Join(dir, "bad")
+	os.WriteFile(badPath, []byte("not a key"), 0o600)
+	if _, err := LoadSigningKey(badPath); err == nil {
+		t.Error("expected error for invalid key")
+	}
+}
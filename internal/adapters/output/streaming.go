@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/compress"
 	"aethonx/internal/platform/logx"
 )
 
@@ -36,6 +37,7 @@ type StreamingWriter struct {
 	scanID     string
 	targetRoot string
 	timestamp  string
+	codec      compress.Codec
 	logger     logx.Logger
 }
 
@@ -50,8 +52,14 @@ func NewStreamingWriter(baseDir, scanID, targetRoot string, logger logx.Logger)
 	}
 }
 
+// SetCompression comprime los parciales siguientes con codec (.json.gz, .json.zst).
+// MergeService los lee igual que los parciales sin comprimir.
+func (w *StreamingWriter) SetCompression(codec compress.Codec) {
+	w.codec = codec
+}
+
 // WritePartial escribe un resultado parcial de una source a disco.
-// Formato: aethonx_{target}_{timestamp}_partial_{source}.json
+// Formato: aethonx_{target}_{timestamp}_partial_{source}.json[.gz|.zst]
 func (w *StreamingWriter) WritePartial(sourceName string, result *domain.ScanResult) (string, error) {
 	// Crear subdirectorio específico para el dominio
 	domainDir := sanitizeDomainNameForStreaming(w.targetRoot)
@@ -66,12 +74,12 @@ func (w *StreamingWriter) WritePartial(sourceName string, result *domain.ScanRes
 	filename := w.GeneratePartialFilename(sourceName)
 	filepath := filepath.Join(fullDir, filename)
 
-	// Crear archivo
-	f, err := os.Create(filepath)
+	// Temporal + rename: la consolidación nunca lee un parcial a medias
+	f, err := createAtomic(filepath)
 	if err != nil {
 		return "", fmt.Errorf("failed to create partial file: %w", err)
 	}
-	defer f.Close()
+	defer f.Abort()
 
 	// Estructura de datos para archivo parcial
 	partialData := PartialScanResult{
@@ -85,12 +93,24 @@ func (w *StreamingWriter) WritePartial(sourceName string, result *domain.ScanRes
 		ArtifactCount: len(result.Artifacts),
 	}
 
+	zw, err := compress.NewWriter(f, w.codec)
+	if err != nil {
+		return "", fmt.Errorf("failed to compress partial file: %w", err)
+	}
+
 	// Codificar JSON con indentación
-	enc := json.NewEncoder(f)
+	enc := json.NewEncoder(zw)
 	enc.SetIndent("", "  ")
 	if err := enc.Encode(partialData); err != nil {
+		zw.Close()
 		return "", fmt.Errorf("failed to encode partial JSON: %w", err)
 	}
+	if err := zw.Close(); err != nil {
+		return "", fmt.Errorf("failed to compress partial file: %w", err)
+	}
+	if err := f.Commit(); err != nil {
+		return "", fmt.Errorf("failed to write partial file: %w", err)
+	}
 
 	w.logger.Debug("partial result written",
 		"source", sourceName,
@@ -103,16 +123,37 @@ func (w *StreamingWriter) WritePartial(sourceName string, result *domain.ScanRes
 
 // GeneratePartialFilename genera el nombre de archivo para un resultado parcial.
 func (w *StreamingWriter) GeneratePartialFilename(sourceName string) string {
-	return fmt.Sprintf("aethonx_%s_%s_partial_%s.json",
+	return fmt.Sprintf("aethonx_%s_%s_partial_%s.json%s",
 		w.targetRoot,
 		w.timestamp,
 		sourceName,
+		w.codec.Extension(),
 	)
 }
 
-// GetPattern retorna el patrón glob para encontrar archivos parciales de este scan.
+// GetPattern retorna el patrón glob para encontrar archivos parciales de este
+// scan, comprimidos o no.
 func (w *StreamingWriter) GetPattern() string {
-	return fmt.Sprintf("aethonx_%s_%s_partial_*.json", w.targetRoot, w.timestamp)
+	return fmt.Sprintf("aethonx_%s_%s_partial_*.json*", w.targetRoot, w.timestamp)
+}
+
+// RemovePartials elimina los archivos parciales de este scan una vez
+// consolidados (p.ej. cuando las salidas se cifran y no deben quedar en claro).
+func (w *StreamingWriter) RemovePartials() (int, error) {
+	dir := filepath.Join(w.baseDir, sanitizeDomainNameForStreaming(w.targetRoot))
+	files, err := filepath.Glob(filepath.Join(dir, w.GetPattern()))
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, file := range files {
+		if err := os.Remove(file); err != nil {
+			return removed, fmt.Errorf("failed to remove partial file: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
 }
 
 // GetFinalFilename retorna el nombre del archivo final consolidado.
@@ -4,9 +4,11 @@ package output
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/compress"
 	"aethonx/internal/platform/logx"
 	"aethonx/internal/testutil"
 )
@@ -101,3 +103,44 @@ func TestStreamingWriter_WritePartial_CreatesDirectory(t *testing.T) {
 	_, statErr := os.Stat(tmpDir)
 	testutil.AssertNoError(t, statErr, "directory should be created")
 }
+
+func TestStreamingWriter_RemovePartials(t *testing.T) {
+	tmpDir := t.TempDir()
+	writer := NewStreamingWriter(tmpDir, "test-scan-123", "example.com", logx.NewSilent())
+
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+	for _, source := range []string{"crtsh", "rdap"} {
+		_, err := writer.WritePartial(source, result)
+		testutil.AssertNoError(t, err, "WritePartial should succeed")
+	}
+
+	removed, err := writer.RemovePartials()
+	testutil.AssertNoError(t, err, "RemovePartials should succeed")
+	testutil.AssertEqual(t, removed, 2, "both partial files removed")
+
+	left, _ := filepath.Glob(filepath.Join(tmpDir, "example_com", writer.GetPattern()))
+	testutil.AssertEqual(t, len(left), 0, "no partial files left")
+}
+
+func TestStreamingWriter_CompressedPartial(t *testing.T) {
+	tmpDir := t.TempDir()
+	writer := NewStreamingWriter(tmpDir, "scan-123", "example.com", logx.NewSilent())
+	writer.SetCompression(compress.Gzip)
+
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeURL, "https://example.com/login", "waybackurls"))
+
+	path, err := writer.WritePartial("waybackurls", result)
+	testutil.AssertNoError(t, err, "WritePartial should succeed")
+	testutil.AssertTrue(t, strings.HasSuffix(path, "_partial_waybackurls.json.gz"), "partial has .json.gz extension")
+
+	matched, err := filepath.Match(writer.GetPattern(), filepath.Base(path))
+	testutil.AssertNoError(t, err, "valid pattern")
+	testutil.AssertTrue(t, matched, "pattern matches compressed partials")
+
+	data, err := compress.ReadFile(path)
+	testutil.AssertNoError(t, err, "partial decompresses")
+	testutil.AssertContains(t, string(data), "https://example.com/login", "partial content")
+}
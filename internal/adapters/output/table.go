@@ -3,7 +3,9 @@ package output
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
 
@@ -12,7 +14,12 @@ import (
 
 // OutputTable imprime una tabla legible en terminal.
 func OutputTable(result *domain.ScanResult) error {
-	w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+	return WriteTable(os.Stdout, result)
+}
+
+// WriteTable escribe la tabla legible en el writer indicado.
+func WriteTable(out io.Writer, result *domain.ScanResult) error {
+	w := tabwriter.NewWriter(out, 2, 4, 2, ' ', 0)
 
 	// Header con información del scan
 	fmt.Fprintf(w, "\n=== AethonX Scan Results ===\n")
@@ -47,33 +54,104 @@ func OutputTable(result *domain.ScanResult) error {
 
 	// Warnings
 	if len(result.Warnings) > 0 {
-		fmt.Fprintf(os.Stdout, "\n⚠️  Warnings (%d):\n", len(result.Warnings))
+		fmt.Fprintf(out, "\n⚠️  Warnings (%d):\n", len(result.Warnings))
 		for i, warning := range result.Warnings {
-			fmt.Fprintf(os.Stdout, "  %d. [%s] %s\n", i+1, warning.Source, warning.Message)
+			fmt.Fprintf(out, "  %d. [%s] %s\n", i+1, warning.Source, warning.Message)
 		}
 	}
 
 	// Errors
 	if len(result.Errors) > 0 {
-		fmt.Fprintf(os.Stdout, "\n❌ Errors (%d):\n", len(result.Errors))
+		fmt.Fprintf(out, "\n❌ Errors (%d):\n", len(result.Errors))
 		for i, err := range result.Errors {
 			fatal := ""
 			if err.Fatal {
 				fatal = " (FATAL)"
 			}
-			fmt.Fprintf(os.Stdout, "  %d. [%s] %s%s\n", i+1, err.Source, err.Message, fatal)
+			fmt.Fprintf(out, "  %d. [%s] %s%s\n", i+1, err.Source, err.Message, fatal)
 		}
 	}
 
 	// Stats summary
 	if len(result.Artifacts) > 0 {
-		fmt.Fprintln(os.Stdout, "\n📊 Statistics by Type:")
+		fmt.Fprintln(out, "\n📊 Statistics by Type:")
 		stats := result.Stats()
 		for artifactType, count := range stats {
-			fmt.Fprintf(os.Stdout, "  - %s: %d\n", artifactType, count)
+			fmt.Fprintf(out, "  - %s: %d\n", artifactType, count)
+		}
+	}
+
+	// Tags (incluye los de reglas de etiquetado del usuario)
+	if tags := tagCounts(result.Artifacts); len(tags) > 0 {
+		fmt.Fprintln(out, "\n🏷️  Tags:")
+		for _, tc := range tags {
+			fmt.Fprintf(out, "  - %s: %d\n", tc.tag, tc.count)
+		}
+	}
+
+	// Hosts que sirven la misma página (título, favicon y tecnologías)
+	if clusters := domain.ClusterHosts(result, domain.ClusterMinHosts); len(clusters) > 0 {
+		fmt.Fprintf(out, "\n🧩 Clusters (%d):\n", len(clusters))
+		for _, c := range clusters {
+			fmt.Fprintf(out, "  - %s\n", c.Summary())
 		}
 	}
 
-	fmt.Fprintln(os.Stdout)
+	if n := result.Metadata.NoiseSuppressed; n > 0 {
+		fmt.Fprintf(out, "\n🔇 Suppressed (third-party noise, scope exclusions): %d artifacts\n", n)
+	}
+
+	// Topes --max-artifacts/--max-duration: el resultado está incompleto
+	if truncations := result.Metadata.Truncations; len(truncations) > 0 {
+		fmt.Fprintf(out, "\n✂️  Truncated (%d):\n", len(truncations))
+		for _, t := range truncations {
+			fmt.Fprintf(out, "  - %s\n", t.String())
+		}
+	}
+
+	// --active-window: stages activos aplazados u omitidos fuera de la franja
+	if deferrals := result.Metadata.Deferrals; len(deferrals) > 0 {
+		fmt.Fprintf(out, "\n⏸️  Deferred (%d):\n", len(deferrals))
+		for _, d := range deferrals {
+			fmt.Fprintf(out, "  - %s\n", d.String())
+		}
+	}
+
+	if lc := result.Lifecycle; lc != nil {
+		fmt.Fprintln(out, "\n🕒 Lifecycle:")
+		fmt.Fprintf(out, "  - new: %d\n", len(lc.New))
+		fmt.Fprintf(out, "  - reappeared: %d\n", len(lc.Reappeared))
+		fmt.Fprintf(out, "  - stale: %d\n", len(lc.Stale))
+		fmt.Fprintf(out, "  - removed: %d\n", len(lc.Removed))
+	}
+
+	fmt.Fprintln(out)
 	return nil
 }
+
+type tagCount struct {
+	tag   string
+	count int
+}
+
+// tagCounts cuenta artifacts por tag, ordenados por frecuencia y nombre.
+func tagCounts(artifacts []*domain.Artifact) []tagCount {
+	counts := make(map[string]int)
+	for _, a := range artifacts {
+		for _, t := range a.Tags {
+			counts[t]++
+		}
+	}
+
+	out := make([]tagCount, 0, len(counts))
+	for tag, n := range counts {
+		out = append(out, tagCount{tag: tag, count: n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].count != out[j].count {
+			return out[i].count > out[j].count
+		}
+		return out[i].tag < out[j].tag
+	})
+	return out
+}
@@ -6,8 +6,10 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
 )
 
 func TestOutputTable(t *testing.T) {
@@ -401,3 +403,107 @@ func TestOutputTable_SourcesUsedDisplay(t *testing.T) {
 		t.Error("output should list rdap source")
 	}
 }
+
+func TestWriteTable_TagSummary(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+
+	a := domain.NewArtifact(domain.ArtifactTypeSubdomain, "db.internal.example.com", "crtsh")
+	a.AddTag("internal")
+	b := domain.NewArtifact(domain.ArtifactTypeSubdomain, "vpn.internal.example.com", "crtsh")
+	b.AddTag("internal")
+	b.AddTag("review")
+	result.AddArtifact(a)
+	result.AddArtifact(b)
+	result.Finalize()
+
+	var buf strings.Builder
+	if err := WriteTable(&buf, result); err != nil {
+		t.Fatalf("WriteTable() failed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "Tags:") {
+		t.Error("output should contain tags section")
+	}
+	if !strings.Contains(output, "- internal: 2") {
+		t.Error("output should count internal tag")
+	}
+	if !strings.Contains(output, "- review: 1") {
+		t.Error("output should count review tag")
+	}
+}
+
+func TestWriteTable_Clusters(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+	for _, host := range []string{"a", "b", "c"} {
+		a := domain.NewArtifactWithMetadata(domain.ArtifactTypeURL, "https://"+host+".example.com", "httpx",
+			&metadata.ServiceMetadata{Name: "https", Port: 443, Title: "IIS Windows Server"})
+		a.AddTag("alive")
+		result.AddArtifact(a)
+	}
+	result.Finalize()
+
+	var buf strings.Builder
+	if err := WriteTable(&buf, result); err != nil {
+		t.Fatalf("WriteTable() failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Clusters (1):\n  - 3 hosts serving \"IIS Windows Server\"") {
+		t.Errorf("output should summarize the cluster, got:\n%s", buf.String())
+	}
+}
+
+func TestWriteTable_Truncations(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+	result.Metadata.Truncations = []domain.Truncation{
+		{Source: "waybackurls", Scope: "source", Reason: domain.TruncatedMaxArtifacts, Limit: "50000", Artifacts: 50000},
+		{Scope: "scan", Reason: domain.TruncatedMaxDuration, Limit: "2h0m0s", Message: "stages skipped: Stage 2"},
+	}
+	result.Finalize()
+
+	var buf strings.Builder
+	if err := WriteTable(&buf, result); err != nil {
+		t.Fatalf("WriteTable() failed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "Truncated (2):") {
+		t.Error("output should contain truncation section")
+	}
+	if !strings.Contains(output, "waybackurls: source max_artifacts=50000 reached, 50000 artifacts kept") {
+		t.Errorf("output should describe the source truncation, got:\n%s", output)
+	}
+	if !strings.Contains(output, "scan: scan max_duration=2h0m0s reached (stages skipped: Stage 2)") {
+		t.Errorf("output should describe the scan truncation, got:\n%s", output)
+	}
+}
+
+func TestWriteTable_Deferrals(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModeHybrid)
+	result := domain.NewScanResult(*target)
+	deferredAt := time.Date(2024, 6, 1, 21, 30, 0, 0, time.UTC)
+	resumedAt := deferredAt.Add(30 * time.Minute)
+	result.Metadata.Deferrals = []domain.StageDeferral{
+		{Stage: "Stage 2", Window: "22:00-06:00 UTC", DeferredAt: deferredAt, OpensAt: resumedAt, ResumedAt: &resumedAt},
+		{Stage: "Stage 3", Window: "22:00-06:00 UTC", DeferredAt: deferredAt, OpensAt: resumedAt, Skipped: true, Reason: "scan ends before the window opens"},
+	}
+	result.Finalize()
+
+	var buf strings.Builder
+	if err := WriteTable(&buf, result); err != nil {
+		t.Fatalf("WriteTable() failed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "Deferred (2):") {
+		t.Error("output should contain deferral section")
+	}
+	if !strings.Contains(output, "Stage 2: deferred 30m0s until window 22:00-06:00 UTC opened") {
+		t.Errorf("output should describe the resumed stage, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Stage 3: skipped outside window 22:00-06:00 UTC (opens 2024-06-01T22:00:00Z)") {
+		t.Errorf("output should describe the skipped stage, got:\n%s", output)
+	}
+}
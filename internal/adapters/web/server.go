@@ -0,0 +1,554 @@
+// internal/adapters/web/server.go
+// Package web expone un dashboard embebido y una API REST mínima sobre los
+// resultados de escaneo guardados en el directorio de salida.
+package web
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"aethonx/internal/adapters/output"
+	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/compress"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/ui"
+)
+
+//go:embed static
+var staticFiles embed.FS
+
+// tokenHeader cabecera con el token de sesión que exige POST /api/scans.
+const tokenHeader = "X-AethonX-Token"
+
+// tokenPlaceholder se sustituye en index.html por el token de sesión.
+const tokenPlaceholder = "__AETHONX_TOKEN__"
+
+// ScanRequest parámetros para lanzar un escaneo desde la API.
+type ScanRequest struct {
+	Target string `json:"target"`
+	Active bool   `json:"active"`
+}
+
+// ScanRunner ejecuta un escaneo completo reportando progreso al presenter.
+// Lo inyecta cmd/aethonx para no acoplar el adapter con el wiring de sources.
+type ScanRunner func(ctx context.Context, req ScanRequest, presenter ui.Presenter) error
+
+// Options configuración del servidor web.
+type Options struct {
+	Addr      string      // Dirección de escucha (e.g., "127.0.0.1:8080")
+	OutputDir string      // Directorio con los JSON de escaneos
+	Runner    ScanRunner  // Opcional: habilita POST /api/scans
+	Logger    logx.Logger // Logger
+}
+
+// Server sirve el dashboard y la API REST.
+type Server struct {
+	opts    Options
+	tracker *Tracker
+	logger  logx.Logger
+	srv     *http.Server
+
+	// token de sesión embebido en el dashboard servido; solo quien lee la
+	// página (mismo origen) puede lanzar escaneos
+	token string
+
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+}
+
+// ScanFile describe un escaneo completado en disco.
+type ScanFile struct {
+	ID       string    `json:"id"`
+	Target   string    `json:"target"`
+	Path     string    `json:"path"`
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+}
+
+// GraphNode nodo del grafo de relaciones para el frontend.
+type GraphNode struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+}
+
+// GraphEdge arista del grafo de relaciones para el frontend.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+}
+
+// NewServer crea un nuevo servidor web.
+func NewServer(opts Options) *Server {
+	if opts.Addr == "" {
+		opts.Addr = "127.0.0.1:8080"
+	}
+	if opts.OutputDir == "" {
+		opts.OutputDir = "."
+	}
+
+	s := &Server{
+		opts:    opts,
+		tracker: NewTracker(),
+		logger:  opts.Logger.With("component", "web"),
+		cancels: make(map[string]context.CancelFunc),
+		token:   newSessionToken(),
+	}
+
+	s.srv = &http.Server{
+		Addr:              opts.Addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return s
+}
+
+// Tracker retorna el tracker de escaneos en curso.
+func (s *Server) Tracker() *Tracker {
+	return s.tracker
+}
+
+// Handler construye el router HTTP (útil para tests con httptest).
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	static, _ := fs.Sub(staticFiles, "static")
+	mux.Handle("GET /", http.FileServer(http.FS(static)))
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+
+	mux.HandleFunc("GET /api/scans", s.handleListScans)
+	mux.HandleFunc("POST /api/scans", s.handleStartScan)
+	mux.HandleFunc("GET /api/scans/{id}", s.handleGetScan)
+	mux.HandleFunc("GET /api/scans/{id}/graph", s.handleGraph)
+	mux.HandleFunc("GET /api/scans/{id}/download", s.handleDownload)
+	mux.HandleFunc("GET /api/targets/{target}/trends", s.handleTrends)
+
+	return s.checkHost(mux)
+}
+
+// newSessionToken genera un token aleatorio para la sesión del servidor.
+func newSessionToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("web: session token: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+// checkHost rechaza peticiones cuyo Host no es la dirección de escucha ni un
+// nombre de loopback. Frena el DNS rebinding: una web del atacante cuyo
+// dominio resuelve a 127.0.0.1 es same-origin consigo misma, pero su Host es
+// ese dominio.
+func (s *Server) checkHost(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.allowedHost(r.Host) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("host %q not allowed", r.Host))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allowedHost indica si host (cabecera Host, con o sin puerto) apunta a este
+// servidor: localhost, una IP de loopback, el host de escucha o, si se
+// escucha en todas las interfaces, cualquier IP literal (el rebinding
+// necesita un nombre de dominio).
+func (s *Server) allowedHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.Trim(host, "[]"))
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	if ip != nil && ip.IsLoopback() {
+		return true
+	}
+
+	listen, _, err := net.SplitHostPort(s.opts.Addr)
+	if err != nil {
+		listen = s.opts.Addr
+	}
+	listen = strings.ToLower(strings.Trim(listen, "[]"))
+	if listen == "" || listen == "0.0.0.0" || listen == "::" {
+		return ip != nil
+	}
+	return host == listen
+}
+
+// handleIndex sirve el dashboard con el token de sesión embebido.
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	page, err := staticFiles.ReadFile("static/index.html")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(bytes.ReplaceAll(page, []byte(tokenPlaceholder), []byte(s.token)))
+}
+
+// ListenAndServe arranca el servidor y bloquea hasta que ctx se cancele.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		s.logger.Info("dashboard listening", "addr", s.opts.Addr, "output_dir", s.opts.OutputDir)
+		errCh <- s.srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	// Cancelar escaneos en curso y cerrar con gracia
+	s.mu.Lock()
+	for _, cancel := range s.cancels {
+		cancel()
+	}
+	s.mu.Unlock()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.srv.Shutdown(shutdownCtx)
+}
+
+// handleListScans lista escaneos en curso (tracker) y completados (disco).
+func (s *Server) handleListScans(w http.ResponseWriter, r *http.Request) {
+	files, err := s.listScanFiles()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"running":   s.tracker.List(),
+		"completed": files,
+	})
+}
+
+// handleStartScan lanza un escaneo en background si hay runner configurado.
+func (s *Server) handleStartScan(w http.ResponseWriter, r *http.Request) {
+	if s.opts.Runner == nil {
+		writeError(w, http.StatusNotImplemented, fmt.Errorf("scan launching is disabled"))
+		return
+	}
+
+	// Lanzar un escaneo exige el token de sesión del dashboard y una petición
+	// same-origin con cuerpo JSON: un formulario o fetch de otra web no pasa
+	if err := s.checkScanRequest(r); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	var req ScanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	req.Target = strings.ToLower(strings.TrimSpace(req.Target))
+	target := domain.NewTarget(req.Target, domain.ScanModePassive)
+	if err := target.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	mode := string(domain.ScanModePassive)
+	if req.Active {
+		mode = string(domain.ScanModeActive)
+	}
+
+	id := fmt.Sprintf("scan-%d", time.Now().UnixNano())
+	presenter := s.tracker.Begin(id, target.Root, mode)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.mu.Lock()
+	s.cancels[id] = cancel
+	s.mu.Unlock()
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.cancels, id)
+			s.mu.Unlock()
+			cancel()
+		}()
+
+		err := s.opts.Runner(ctx, req, presenter)
+		if err != nil {
+			s.logger.Warn("dashboard scan failed", "scan_id", id, "error", err.Error())
+		}
+		s.tracker.End(id, err)
+	}()
+
+	writeJSON(w, http.StatusAccepted, map[string]string{"id": id})
+}
+
+// checkScanRequest rechaza peticiones sin el token de sesión (tokenHeader) y
+// las cross-site: Content-Type distinto de application/json (evita los POST
+// "simples" que no requieren preflight CORS) u Origin cuyo host no coincide
+// con Host.
+func (s *Server) checkScanRequest(r *http.Request) error {
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get(tokenHeader)), []byte(s.token)) != 1 {
+		return fmt.Errorf("missing or invalid session token")
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return fmt.Errorf("content type must be application/json")
+	}
+	if r.Header.Get("Sec-Fetch-Site") == "cross-site" {
+		return fmt.Errorf("cross-site request rejected")
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		u, err := url.Parse(origin)
+		if err != nil || !strings.EqualFold(u.Host, r.Host) {
+			return fmt.Errorf("cross-origin request rejected: %s", origin)
+		}
+	}
+	return nil
+}
+
+// handleGetScan retorna el estado en vivo o el resumen de un escaneo completado.
+func (s *Server) handleGetScan(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if status, ok := s.tracker.Get(id); ok {
+		writeJSON(w, http.StatusOK, status)
+		return
+	}
+
+	result, err := s.loadScan(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":       id,
+		"summary":  output.BuildGraphSummary(result),
+		"metadata": result.Metadata,
+		"warnings": result.Warnings,
+		"errors":   result.Errors,
+	})
+}
+
+// handleGraph retorna nodos y aristas del grafo de relaciones.
+func (s *Server) handleGraph(w http.ResponseWriter, r *http.Request) {
+	result, err := s.loadScan(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, BuildGraph(result))
+}
+
+// handleDownload sirve el resultado en el formato solicitado (json, table).
+func (s *Server) handleDownload(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	result, err := s.loadScan(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", id+".json"))
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(result)
+	case "table":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", id+".txt"))
+		_ = output.WriteTable(w, result)
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unsupported format: %s", format))
+	}
+}
+
+// handleTrends retorna la serie temporal del target registrada en modo monitor
+// (--track-lifecycle). Sin historial retorna una serie vacía.
+func (s *Server) handleTrends(w http.ResponseWriter, r *http.Request) {
+	target := domain.NewTarget(strings.ToLower(strings.TrimSpace(r.PathValue("target"))), domain.ScanModePassive)
+	if err := target.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	state, err := output.NewFileLifecycleStore(s.opts.OutputDir).LoadLifecycle(r.Context(), target.Root)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	points := state.Trends
+	if points == nil {
+		points = []domain.TrendPoint{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"target":  target.Root,
+		"metrics": domain.TrendMetrics,
+		"points":  points,
+	})
+}
+
+// BuildGraph convierte los artifacts y relaciones en nodos y aristas.
+// Las relaciones hacia artifacts inexistentes se descartan.
+func BuildGraph(result *domain.ScanResult) map[string]interface{} {
+	nodes := make([]GraphNode, 0, len(result.Artifacts))
+	known := make(map[string]bool, len(result.Artifacts))
+
+	for _, a := range result.Artifacts {
+		nodes = append(nodes, GraphNode{ID: a.ID, Label: a.Value, Type: string(a.Type)})
+		known[a.ID] = true
+	}
+
+	edges := make([]GraphEdge, 0)
+	for _, a := range result.Artifacts {
+		for _, rel := range a.Relations {
+			if !known[rel.TargetID] {
+				continue
+			}
+			edges = append(edges, GraphEdge{From: a.ID, To: rel.TargetID, Type: string(rel.Type)})
+		}
+	}
+
+	return map[string]interface{}{
+		"nodes": nodes,
+		"edges": edges,
+	}
+}
+
+// listScanFiles recorre el directorio de salida buscando JSON consolidados.
+func (s *Server) listScanFiles() ([]ScanFile, error) {
+	files := make([]ScanFile, 0)
+
+	err := filepath.WalkDir(s.opts.OutputDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() || !isScanFile(d.Name()) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		name := strings.TrimSuffix(compress.TrimExtension(d.Name()), ".json")
+		rel, _ := filepath.Rel(s.opts.OutputDir, path)
+		files = append(files, ScanFile{
+			ID:       name,
+			Target:   targetFromFilename(name),
+			Path:     rel,
+			Size:     info.Size(),
+			Modified: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scans: %w", err)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Modified.After(files[j].Modified)
+	})
+
+	return files, nil
+}
+
+// loadScan carga un ScanResult por ID (nombre de fichero sin extensión).
+func (s *Server) loadScan(id string) (*domain.ScanResult, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.Contains(id, "..") {
+		return nil, fmt.Errorf("invalid scan id: %q", id)
+	}
+
+	files, err := s.listScanFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range files {
+		if f.ID != id {
+			continue
+		}
+
+		data, err := compress.ReadFile(filepath.Join(s.opts.OutputDir, f.Path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read scan: %w", err)
+		}
+
+		var result domain.ScanResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode scan: %w", err)
+		}
+		if _, err := domain.MigrateArtifactIDs(&result); err != nil {
+			return nil, fmt.Errorf("failed to migrate scan: %w", err)
+		}
+		return &result, nil
+	}
+
+	return nil, fmt.Errorf("scan not found: %s", id)
+}
+
+// isScanFile indica si el fichero es un resultado consolidado (no parcial,
+// filtrado ni manifiesto), comprimido o no.
+func isScanFile(name string) bool {
+	name = compress.TrimExtension(name)
+	return strings.HasPrefix(name, "aethonx_") &&
+		strings.HasSuffix(name, ".json") &&
+		!strings.Contains(name, "_partial_") &&
+		!strings.HasSuffix(name, "_filtered.json") &&
+		!strings.HasSuffix(name, "_manifest.json")
+}
+
+// targetFromFilename extrae el target de "aethonx_<target>_<date>_<time>".
+func targetFromFilename(name string) string {
+	parts := strings.Split(strings.TrimPrefix(name, "aethonx_"), "_")
+	if len(parts) < 3 {
+		return ""
+	}
+	return strings.Join(parts[:len(parts)-2], "_")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,340 @@
+// internal/adapters/web/server_test.go
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"aethonx/internal/adapters/output"
+	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/ui"
+)
+
+// newRequest crea una petición dirigida a la dirección de escucha por defecto.
+func newRequest(method, target string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(method, target, body)
+	req.Host = "127.0.0.1:8080"
+	return req
+}
+
+// writeTestScan guarda un escaneo con una relación en dir y retorna su ID.
+func writeTestScan(t *testing.T, dir string) string {
+	t.Helper()
+
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+	sub := domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "crtsh")
+	ip := domain.NewArtifact(domain.ArtifactTypeIP, "192.0.2.10", "httpx")
+	sub.AddRelation(ip.ID, domain.RelationResolvesTo, 1.0, "httpx")
+	sub.AddRelation("missing-id", domain.RelationResolvesTo, 1.0, "httpx")
+	result.AddArtifacts(sub, ip)
+	result.Finalize()
+
+	if err := output.OutputJSON(dir, result); err != nil {
+		t.Fatalf("OutputJSON() failed: %v", err)
+	}
+
+	srv := NewServer(Options{OutputDir: dir, Logger: logx.NewSilent()})
+	files, err := srv.listScanFiles()
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected 1 scan file, got %d (err=%v)", len(files), err)
+	}
+	return files[0].ID
+}
+
+func TestServer_ListScans(t *testing.T) {
+	dir := t.TempDir()
+	id := writeTestScan(t, dir)
+
+	srv := NewServer(Options{OutputDir: dir, Logger: logx.NewSilent()})
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, newRequest(http.MethodGet, "/api/scans", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	var body struct {
+		Completed []ScanFile `json:"completed"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(body.Completed) != 1 || body.Completed[0].ID != id {
+		t.Fatalf("completed = %+v, want scan %s", body.Completed, id)
+	}
+	if body.Completed[0].Target != "example.com" {
+		t.Errorf("target = %q, want example.com", body.Completed[0].Target)
+	}
+}
+
+func TestServer_Graph(t *testing.T) {
+	dir := t.TempDir()
+	id := writeTestScan(t, dir)
+
+	srv := NewServer(Options{OutputDir: dir, Logger: logx.NewSilent()})
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, newRequest(http.MethodGet, "/api/scans/"+id+"/graph", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	var graph struct {
+		Nodes []GraphNode `json:"nodes"`
+		Edges []GraphEdge `json:"edges"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &graph); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(graph.Nodes) != 2 {
+		t.Errorf("nodes = %d, want 2", len(graph.Nodes))
+	}
+	// La relación hacia un artifact inexistente se descarta
+	if len(graph.Edges) != 1 {
+		t.Errorf("edges = %d, want 1", len(graph.Edges))
+	}
+}
+
+func TestServer_Download(t *testing.T) {
+	dir := t.TempDir()
+	id := writeTestScan(t, dir)
+	srv := NewServer(Options{OutputDir: dir, Logger: logx.NewSilent()})
+
+	tests := []struct {
+		format   string
+		wantCode int
+		contains string
+	}{
+		{"json", http.StatusOK, `"api.example.com"`},
+		{"table", http.StatusOK, "AethonX Scan Results"},
+		{"xml", http.StatusBadRequest, "unsupported format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := newRequest(http.MethodGet, "/api/scans/"+id+"/download?format="+tt.format, nil)
+			srv.Handler().ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if !strings.Contains(rec.Body.String(), tt.contains) {
+				t.Errorf("body does not contain %q", tt.contains)
+			}
+		})
+	}
+}
+
+func TestServer_GetScan_NotFound(t *testing.T) {
+	srv := NewServer(Options{OutputDir: t.TempDir(), Logger: logx.NewSilent()})
+
+	for _, id := range []string{"aethonx_nope_20240101_000000", "..%2Fetc"} {
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, newRequest(http.MethodGet, "/api/scans/"+id, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("id %q: status = %d, want 404", id, rec.Code)
+		}
+	}
+}
+
+func TestServer_StartScan(t *testing.T) {
+	done := make(chan struct{})
+	runner := func(ctx context.Context, req ScanRequest, presenter ui.Presenter) error {
+		defer close(done)
+		presenter.Start(ui.ScanInfo{Target: req.Target, TotalStages: 1})
+		presenter.StartStage(ui.StageInfo{Number: 1, TotalStages: 1, Name: "Stage 1", Sources: []string{"crtsh"}})
+		presenter.FinishSource("crtsh", ui.StatusSuccess, time.Second, 3, nil)
+		presenter.FinishStage(1, time.Second)
+		presenter.Finish(ui.ScanStats{UniqueArtifacts: 3, ArtifactsByType: map[string]int{"subdomain": 3}})
+		return nil
+	}
+
+	srv := NewServer(Options{OutputDir: t.TempDir(), Runner: runner, Logger: logx.NewSilent()})
+	rec := httptest.NewRecorder()
+	req := newRequest(http.MethodPost, "/api/scans", strings.NewReader(`{"target":"Example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Origin", "http://"+req.Host)
+	req.Header.Set(tokenHeader, srv.token)
+	srv.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want 202", rec.Code)
+	}
+
+	var resp map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	<-done
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		status, ok := srv.Tracker().Get(resp["id"])
+		if !ok {
+			t.Fatalf("scan %s not tracked", resp["id"])
+		}
+		if status.State == ScanStateCompleted {
+			if status.Target != "example.com" {
+				t.Errorf("target = %q, want example.com", status.Target)
+			}
+			if status.Sources["crtsh"].Artifacts != 3 || !status.Stages[0].Done {
+				t.Errorf("unexpected progress: %+v", status)
+			}
+			if status.ArtifactsByType["subdomain"] != 3 {
+				t.Errorf("artifacts_by_type = %v", status.ArtifactsByType)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("scan did not complete, state=%s", status.State)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestServer_StartScan_CrossSite(t *testing.T) {
+	ran := false
+	runner := func(ctx context.Context, req ScanRequest, presenter ui.Presenter) error {
+		ran = true
+		return nil
+	}
+	srv := NewServer(Options{OutputDir: t.TempDir(), Runner: runner, Logger: logx.NewSilent()})
+
+	tests := map[string]map[string]string{
+		"missing token":    {"Content-Type": "application/json", tokenHeader: ""},
+		"wrong token":      {"Content-Type": "application/json", tokenHeader: "guessed"},
+		"text/plain body":  {"Content-Type": "text/plain"},
+		"foreign origin":   {"Content-Type": "application/json", "Origin": "http://evil.example"},
+		"cross-site fetch": {"Content-Type": "application/json", "Sec-Fetch-Site": "cross-site"},
+		"rebound host":     {"Content-Type": "application/json", "Host": "evil.example:8080", "Origin": "http://evil.example:8080"},
+	}
+	for name, headers := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := newRequest(http.MethodPost, "/api/scans", strings.NewReader(`{"target":"example.com"}`))
+			req.Header.Set(tokenHeader, srv.token)
+			for k, v := range headers {
+				if k == "Host" {
+					req.Host = v
+					continue
+				}
+				req.Header.Set(k, v)
+			}
+			srv.Handler().ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want 403", rec.Code)
+			}
+		})
+	}
+	if ran {
+		t.Error("cross-site request must not launch a scan")
+	}
+}
+
+func TestServer_StartScan_Disabled(t *testing.T) {
+	srv := NewServer(Options{OutputDir: t.TempDir(), Logger: logx.NewSilent()})
+	rec := httptest.NewRecorder()
+	req := newRequest(http.MethodPost, "/api/scans", strings.NewReader(`{"target":"example.com"}`))
+	srv.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotImplemented {
+		t.Errorf("status = %d, want 501", rec.Code)
+	}
+}
+
+func TestServer_Dashboard(t *testing.T) {
+	srv := NewServer(Options{OutputDir: t.TempDir(), Logger: logx.NewSilent()})
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, newRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "AethonX Dashboard") {
+		t.Errorf("dashboard not served: status=%d", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "https://") {
+		t.Error("dashboard must not load assets from third-party hosts")
+	}
+	if !strings.Contains(rec.Body.String(), `content="`+srv.token+`"`) {
+		t.Error("dashboard must embed the session token")
+	}
+
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, newRequest(http.MethodGet, "/graph.js", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("embedded graph renderer not served: status=%d", rec.Code)
+	}
+}
+
+func TestServer_Trends(t *testing.T) {
+	dir := t.TempDir()
+	store := output.NewFileLifecycleStore(dir)
+	state := domain.NewLifecycleState("example.com")
+	state.AppendTrend(domain.TrendPoint{ScanID: "s1", Subdomains: 3}, 0)
+	state.AppendTrend(domain.TrendPoint{ScanID: "s2", Subdomains: 5}, 0)
+	if err := store.SaveLifecycle(context.Background(), state); err != nil {
+		t.Fatalf("SaveLifecycle() failed: %v", err)
+	}
+
+	srv := NewServer(Options{OutputDir: dir, Logger: logx.NewSilent()})
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, newRequest(http.MethodGet, "/api/targets/example.com/trends", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	var body struct {
+		Metrics []string            `json:"metrics"`
+		Points  []domain.TrendPoint `json:"points"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(body.Points) != 2 || body.Points[1].Subdomains != 5 {
+		t.Errorf("points = %+v, want 2 points", body.Points)
+	}
+	if len(body.Metrics) == 0 {
+		t.Error("expected metric names")
+	}
+
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, newRequest(http.MethodGet, "/api/targets/unknown.org/trends", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"points":[]`) {
+		t.Errorf("unknown target: status = %d body = %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestServer_RejectsForeignHost(t *testing.T) {
+	tests := []struct {
+		addr, host string
+		allowed    bool
+	}{
+		{"127.0.0.1:8080", "127.0.0.1:8080", true},
+		{"127.0.0.1:8080", "localhost:8080", true},
+		{"127.0.0.1:8080", "[::1]:8080", true},
+		{"127.0.0.1:8080", "rebind.evil.example:8080", false},
+		{"192.0.2.5:8080", "192.0.2.5:8080", true},
+		{"192.0.2.5:8080", "192.0.2.6:8080", false},
+		{"0.0.0.0:8080", "192.0.2.5:8080", true},
+		{"0.0.0.0:8080", "rebind.evil.example", false},
+	}
+	for _, tt := range tests {
+		srv := NewServer(Options{Addr: tt.addr, OutputDir: t.TempDir(), Logger: logx.NewSilent()})
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/scans", nil)
+		req.Host = tt.host
+		srv.Handler().ServeHTTP(rec, req)
+
+		if allowed := rec.Code == http.StatusOK; allowed != tt.allowed {
+			t.Errorf("addr=%s host=%s: status = %d, allowed want %v", tt.addr, tt.host, rec.Code, tt.allowed)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// AethonX dashboard: polls the REST API and renders scans, progress and graph.
+(function () {
+  "use strict";
+
+  const POLL_MS = 2000;
+  // Session token embedded by the server; required to start scans
+  const TOKEN = document.querySelector('meta[name="aethonx-token"]').content;
+  const DOWNLOAD_FORMATS = ["json", "table"];
+  const SVG_NS = "http://www.w3.org/2000/svg";
+  const TREND_LABELS = {
+    subdomains: "Subdomains",
+    alive_hosts: "Alive hosts",
+    open_ports: "Open ports",
+    certs_expiring: "Certs expiring (30d)",
+  };
+
+  function el(tag, attrs, children) {
+    const node = document.createElement(tag);
+    Object.entries(attrs || {}).forEach(([k, v]) => {
+      if (k === "text") node.textContent = v;
+      else if (k === "onclick") node.onclick = v;
+      else node.setAttribute(k, v);
+    });
+    (children || []).forEach((c) => node.appendChild(c));
+    return node;
+  }
+
+  function badges(counts) {
+    return Object.entries(counts || {})
+      .filter(([, n]) => n > 0)
+      .sort((a, b) => b[1] - a[1])
+      .map(([type, n]) => el("span", { class: "badge", text: type + ": " + n }));
+  }
+
+  // sparkline draws the series as an inline SVG polyline.
+  function sparkline(values) {
+    const w = 160, h = 36, pad = 3;
+    const min = Math.min(...values), max = Math.max(...values);
+    const span = max - min || 1;
+    const step = (w - 2 * pad) / Math.max(values.length - 1, 1);
+    const points = values
+      .map((v, i) => (pad + i * step).toFixed(1) + "," + (h - pad - ((v - min) / span) * (h - 2 * pad)).toFixed(1))
+      .join(" ");
+
+    const svg = document.createElementNS(SVG_NS, "svg");
+    svg.setAttribute("width", w);
+    svg.setAttribute("height", h);
+    svg.setAttribute("class", "sparkline");
+    const line = document.createElementNS(SVG_NS, "polyline");
+    line.setAttribute("points", points);
+    svg.appendChild(line);
+    return svg;
+  }
+
+  function renderTrends(data) {
+    const root = document.getElementById("detail-trends");
+    root.innerHTML = "";
+    const points = data.points || [];
+    if (points.length < 2) {
+      root.appendChild(el("p", { class: "empty", text: "No trend data (run monitor mode with --track-lifecycle)." }));
+      return;
+    }
+    (data.metrics || []).forEach((metric) => {
+      const values = points.map((p) => p[metric] || 0);
+      const last = values[values.length - 1];
+      const delta = last - values[0];
+      root.appendChild(
+        el("div", { class: "trend", title: points.length + " scans" }, [
+          el("div", { class: "trend-label", text: TREND_LABELS[metric] || metric }),
+          sparkline(values),
+          el("div", { class: "trend-value", text: last + " (" + (delta >= 0 ? "+" : "") + delta + ")" }),
+        ])
+      );
+    });
+  }
+
+  function renderRunning(scans) {
+    const root = document.getElementById("running");
+    root.innerHTML = "";
+    if (!scans.length) {
+      root.appendChild(el("p", { class: "empty", text: "No scans running." }));
+      return;
+    }
+    scans.forEach((scan) => {
+      const total = scan.total_stages || 1;
+      const done = (scan.stages || []).filter((s) => s.done).length;
+      const pct = Math.round((done / total) * 100);
+      const sources = Object.values(scan.sources || {}).map((s) =>
+        el("span", { class: "badge status-" + s.status, text: s.name + " (" + s.artifacts + ")" })
+      );
+      root.appendChild(
+        el("div", { class: "scan-card" }, [
+          el("strong", { text: scan.target + " " }),
+          el("span", { class: "status-" + scan.state, text: scan.state }),
+          el("div", { text: "Stage " + scan.current_stage + "/" + total }),
+          el("div", { class: "progress" }, [el("div", { style: "width:" + pct + "%" })]),
+          el("div", {}, sources),
+          el("div", {}, badges(scan.artifacts_by_type)),
+        ])
+      );
+    });
+  }
+
+  function renderCompleted(files) {
+    const body = document.querySelector("#completed tbody");
+    body.innerHTML = "";
+    files.forEach((f) => {
+      const links = DOWNLOAD_FORMATS.map((fmt) =>
+        el("a", { href: "api/scans/" + encodeURIComponent(f.id) + "/download?format=" + fmt, text: fmt })
+      );
+      body.appendChild(
+        el("tr", { class: "scan-row", onclick: () => showDetail(f.id, f.target) }, [
+          el("td", { text: f.target }),
+          el("td", { text: f.id }),
+          el("td", { text: new Date(f.modified).toLocaleString() }),
+          el("td", {}, links),
+        ])
+      );
+    });
+  }
+
+  async function showDetail(id, target) {
+    const [scan, graph, trends] = await Promise.all([
+      fetch("api/scans/" + encodeURIComponent(id)).then((r) => r.json()),
+      fetch("api/scans/" + encodeURIComponent(id) + "/graph").then((r) => r.json()),
+      fetch("api/targets/" + encodeURIComponent(target) + "/trends").then((r) => (r.ok ? r.json() : {})),
+    ]);
+
+    document.getElementById("detail").hidden = false;
+    document.getElementById("detail-title").textContent = id;
+    const counts = document.getElementById("detail-counts");
+    counts.innerHTML = "";
+    badges((scan.summary || {}).artifacts_by_type).forEach((b) => counts.appendChild(b));
+    renderTrends(trends);
+
+    AethonXGraph.render(document.getElementById("graph"), graph);
+  }
+
+  async function refresh() {
+    try {
+      const data = await fetch("api/scans").then((r) => r.json());
+      renderRunning(data.running || []);
+      renderCompleted(data.completed || []);
+    } catch (err) {
+      console.error("refresh failed", err);
+    }
+  }
+
+  document.getElementById("scan-form").addEventListener("submit", async (ev) => {
+    ev.preventDefault();
+    const target = document.getElementById("scan-target").value;
+    const active = document.getElementById("scan-active").checked;
+    const resp = await fetch("api/scans", {
+      method: "POST",
+      headers: {
+        "Content-Type": "application/json",
+        "X-AethonX-Token": TOKEN,
+      },
+      body: JSON.stringify({ target, active }),
+    });
+    if (!resp.ok) {
+      const body = await resp.json();
+      alert(body.error || "failed to start scan");
+    }
+    refresh();
+  });
+
+  refresh();
+  setInterval(refresh, POLL_MS);
+})();
@@ -0,0 +1,170 @@
+// AethonX dashboard: force-directed relationship graph drawn on a canvas.
+// Embedded with the rest of the dashboard so it works offline and loads
+// nothing from third-party hosts.
+(function () {
+  "use strict";
+
+  const PALETTE = ["#88c0d0", "#a3be8c", "#ebcb8b", "#d08770", "#b48ead", "#5e81ac", "#bf616a", "#8fbcbb"];
+  const TEXT = "#d8dee9";
+  const EDGE = "#4c566a";
+  const RADIUS = 6;
+  const ITERATIONS = 300;
+  const MAX_PAIR_STEPS = 3e7; // Repulsion is O(n²): fewer iterations on big graphs
+
+  // layout runs a fixed number of force-directed iterations: every pair of
+  // nodes repels, every edge pulls its ends together, gravity keeps the
+  // graph centred.
+  function layout(nodes, edges, width, height) {
+    const k = Math.sqrt((width * height) / Math.max(nodes.length, 1)) * 0.5;
+    nodes.forEach((n, i) => {
+      const angle = (2 * Math.PI * i) / nodes.length;
+      n.x = width / 2 + Math.cos(angle) * width * 0.3;
+      n.y = height / 2 + Math.sin(angle) * height * 0.3;
+    });
+
+    const iterations = Math.max(30, Math.min(ITERATIONS, Math.floor(MAX_PAIR_STEPS / (nodes.length * nodes.length + 1))));
+    for (let iter = 0; iter < iterations; iter++) {
+      const temp = (1 - iter / iterations) * k;
+      nodes.forEach((n) => { n.dx = 0; n.dy = 0; });
+
+      for (let i = 0; i < nodes.length; i++) {
+        for (let j = i + 1; j < nodes.length; j++) {
+          const a = nodes[i];
+          const b = nodes[j];
+          const dx = a.x - b.x || 0.01;
+          const dy = a.y - b.y || 0.01;
+          const dist = Math.max(Math.hypot(dx, dy), 0.01);
+          const force = (k * k) / dist;
+          a.dx += (dx / dist) * force;
+          a.dy += (dy / dist) * force;
+          b.dx -= (dx / dist) * force;
+          b.dy -= (dy / dist) * force;
+        }
+      }
+
+      edges.forEach((e) => {
+        const dx = e.from.x - e.to.x;
+        const dy = e.from.y - e.to.y;
+        const dist = Math.max(Math.hypot(dx, dy), 0.01);
+        const force = (dist * dist) / k;
+        e.from.dx -= (dx / dist) * force;
+        e.from.dy -= (dy / dist) * force;
+        e.to.dx += (dx / dist) * force;
+        e.to.dy += (dy / dist) * force;
+      });
+
+      nodes.forEach((n) => {
+        n.dx += (width / 2 - n.x) * 0.01 * k;
+        n.dy += (height / 2 - n.y) * 0.01 * k;
+        const len = Math.max(Math.hypot(n.dx, n.dy), 0.01);
+        n.x += (n.dx / len) * Math.min(len, temp);
+        n.y += (n.dy / len) * Math.min(len, temp);
+      });
+    }
+  }
+
+  // renderGraph draws graph ({nodes: [{id, label, type}], edges: [{from, to,
+  // type}]}) into container. Nodes can be dragged; the wheel zooms.
+  function renderGraph(container, graph) {
+    container.innerHTML = "";
+    const canvas = document.createElement("canvas");
+    const width = container.clientWidth || 800;
+    const height = container.clientHeight || 560;
+    const ratio = window.devicePixelRatio || 1;
+    canvas.width = width * ratio;
+    canvas.height = height * ratio;
+    canvas.style.width = width + "px";
+    canvas.style.height = height + "px";
+    container.appendChild(canvas);
+    const ctx = canvas.getContext("2d");
+
+    const colors = {};
+    const byID = {};
+    const nodes = (graph.nodes || []).map((n) => {
+      if (!(n.type in colors)) colors[n.type] = PALETTE[Object.keys(colors).length % PALETTE.length];
+      const node = { id: n.id, label: n.label, color: colors[n.type] };
+      byID[n.id] = node;
+      return node;
+    });
+    const edges = (graph.edges || [])
+      .filter((e) => byID[e.from] && byID[e.to])
+      .map((e) => ({ from: byID[e.from], to: byID[e.to], label: e.type }));
+    layout(nodes, edges, width, height);
+
+    let scale = 1;
+    let offsetX = 0;
+    let offsetY = 0;
+
+    function draw() {
+      ctx.setTransform(ratio * scale, 0, 0, ratio * scale, ratio * offsetX, ratio * offsetY);
+      ctx.clearRect(-offsetX / scale, -offsetY / scale, width / scale, height / scale);
+
+      ctx.strokeStyle = EDGE;
+      ctx.fillStyle = EDGE;
+      ctx.font = "9px sans-serif";
+      edges.forEach((e) => {
+        const angle = Math.atan2(e.to.y - e.from.y, e.to.x - e.from.x);
+        const tipX = e.to.x - Math.cos(angle) * RADIUS;
+        const tipY = e.to.y - Math.sin(angle) * RADIUS;
+        ctx.beginPath();
+        ctx.moveTo(e.from.x, e.from.y);
+        ctx.lineTo(tipX, tipY);
+        ctx.stroke();
+        ctx.beginPath();
+        ctx.moveTo(tipX, tipY);
+        ctx.lineTo(tipX - Math.cos(angle - 0.4) * 6, tipY - Math.sin(angle - 0.4) * 6);
+        ctx.lineTo(tipX - Math.cos(angle + 0.4) * 6, tipY - Math.sin(angle + 0.4) * 6);
+        ctx.fill();
+        ctx.fillStyle = TEXT;
+        ctx.fillText(e.label, (e.from.x + e.to.x) / 2, (e.from.y + e.to.y) / 2);
+        ctx.fillStyle = EDGE;
+      });
+
+      ctx.font = "11px sans-serif";
+      nodes.forEach((n) => {
+        ctx.beginPath();
+        ctx.arc(n.x, n.y, RADIUS, 0, 2 * Math.PI);
+        ctx.fillStyle = n.color;
+        ctx.fill();
+        ctx.fillStyle = TEXT;
+        ctx.fillText(n.label, n.x + RADIUS + 3, n.y + 4);
+      });
+    }
+
+    // toGraph converts a mouse event to graph coordinates
+    function toGraph(ev) {
+      const rect = canvas.getBoundingClientRect();
+      return { x: (ev.clientX - rect.left - offsetX) / scale, y: (ev.clientY - rect.top - offsetY) / scale };
+    }
+
+    let dragging = null;
+    canvas.addEventListener("mousedown", (ev) => {
+      const p = toGraph(ev);
+      dragging = nodes.find((n) => Math.hypot(n.x - p.x, n.y - p.y) <= RADIUS + 2) || null;
+    });
+    canvas.addEventListener("mousemove", (ev) => {
+      if (!dragging) return;
+      const p = toGraph(ev);
+      dragging.x = p.x;
+      dragging.y = p.y;
+      draw();
+    });
+    canvas.addEventListener("mouseup", () => { dragging = null; });
+    canvas.addEventListener("mouseleave", () => { dragging = null; });
+    canvas.addEventListener("wheel", (ev) => {
+      ev.preventDefault();
+      const rect = canvas.getBoundingClientRect();
+      const mx = ev.clientX - rect.left;
+      const my = ev.clientY - rect.top;
+      const factor = ev.deltaY < 0 ? 1.1 : 1 / 1.1;
+      offsetX = mx - (mx - offsetX) * factor;
+      offsetY = my - (my - offsetY) * factor;
+      scale *= factor;
+      draw();
+    });
+
+    draw();
+  }
+
+  window.AethonXGraph = { render: renderGraph };
+})();
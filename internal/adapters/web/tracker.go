@@ -0,0 +1,281 @@
+// internal/adapters/web/tracker.go
+package web
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"aethonx/internal/platform/ui"
+)
+
+// ScanState estados posibles de un escaneo lanzado desde el dashboard.
+type ScanState string
+
+const (
+	ScanStateRunning   ScanState = "running"
+	ScanStateCompleted ScanState = "completed"
+	ScanStateFailed    ScanState = "failed"
+)
+
+// SourceState estado de una source dentro de un escaneo en curso.
+type SourceState struct {
+	Name      string        `json:"name"`
+	Stage     int           `json:"stage"`
+	Status    string        `json:"status"`
+	Phase     string        `json:"phase,omitempty"`
+	Artifacts int           `json:"artifacts"`
+	Duration  time.Duration `json:"duration_ns"`
+}
+
+// StageState estado de un stage del pipeline.
+type StageState struct {
+	Number   int           `json:"number"`
+	Name     string        `json:"name"`
+	Sources  []string      `json:"sources"`
+	Done     bool          `json:"done"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// ScanStatus snapshot serializable del progreso de un escaneo.
+type ScanStatus struct {
+	ID              string                  `json:"id"`
+	Target          string                  `json:"target"`
+	Mode            string                  `json:"mode"`
+	State           ScanState               `json:"state"`
+	StartedAt       time.Time               `json:"started_at"`
+	FinishedAt      time.Time               `json:"finished_at,omitempty"`
+	TotalStages     int                     `json:"total_stages"`
+	CurrentStage    int                     `json:"current_stage"`
+	Stages          []StageState            `json:"stages"`
+	Sources         map[string]*SourceState `json:"sources"`
+	ArtifactsByType map[string]int          `json:"artifacts_by_type"`
+	TotalArtifacts  int                     `json:"total_artifacts"`
+	Error           string                  `json:"error,omitempty"`
+}
+
+// Tracker mantiene el estado en vivo de los escaneos ejecutados por el servidor.
+// Cada escaneo recibe un ui.Presenter propio que actualiza el Tracker.
+type Tracker struct {
+	mu    sync.RWMutex
+	scans map[string]*ScanStatus
+}
+
+// NewTracker crea un Tracker vacío.
+func NewTracker() *Tracker {
+	return &Tracker{
+		scans: make(map[string]*ScanStatus),
+	}
+}
+
+// Begin registra un nuevo escaneo y retorna el presenter que lo alimenta.
+func (t *Tracker) Begin(id, target, mode string) ui.Presenter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.scans[id] = &ScanStatus{
+		ID:              id,
+		Target:          target,
+		Mode:            mode,
+		State:           ScanStateRunning,
+		StartedAt:       time.Now(),
+		Stages:          []StageState{},
+		Sources:         make(map[string]*SourceState),
+		ArtifactsByType: make(map[string]int),
+	}
+
+	return &trackingPresenter{tracker: t, scanID: id}
+}
+
+// End marca el escaneo como terminado (con error opcional).
+func (t *Tracker) End(id string, err error) {
+	t.update(id, func(s *ScanStatus) {
+		s.FinishedAt = time.Now()
+		if err != nil {
+			s.State = ScanStateFailed
+			s.Error = err.Error()
+			return
+		}
+		s.State = ScanStateCompleted
+	})
+}
+
+// Get retorna una copia del estado de un escaneo.
+func (t *Tracker) Get(id string) (ScanStatus, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	s, ok := t.scans[id]
+	if !ok {
+		return ScanStatus{}, false
+	}
+	return s.clone(), true
+}
+
+// List retorna copias de todos los escaneos, más recientes primero.
+func (t *Tracker) List() []ScanStatus {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	list := make([]ScanStatus, 0, len(t.scans))
+	for _, s := range t.scans {
+		list = append(list, s.clone())
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].StartedAt.After(list[j].StartedAt)
+	})
+	return list
+}
+
+// update aplica fn sobre el estado del escaneo bajo lock.
+func (t *Tracker) update(id string, fn func(s *ScanStatus)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if s, ok := t.scans[id]; ok {
+		fn(s)
+	}
+}
+
+// clone copia profunda del snapshot (mapas y slices).
+func (s *ScanStatus) clone() ScanStatus {
+	c := *s
+	c.Stages = append([]StageState(nil), s.Stages...)
+	c.Sources = make(map[string]*SourceState, len(s.Sources))
+	for k, v := range s.Sources {
+		src := *v
+		c.Sources[k] = &src
+	}
+	c.ArtifactsByType = make(map[string]int, len(s.ArtifactsByType))
+	for k, v := range s.ArtifactsByType {
+		c.ArtifactsByType[k] = v
+	}
+	return c
+}
+
+// trackingPresenter implementa ui.Presenter volcando el progreso en el Tracker.
+type trackingPresenter struct {
+	tracker *Tracker
+	scanID  string
+}
+
+func (p *trackingPresenter) Start(info ui.ScanInfo) {
+	p.tracker.update(p.scanID, func(s *ScanStatus) {
+		s.TotalStages = info.TotalStages
+	})
+}
+
+func (p *trackingPresenter) StartStage(stage ui.StageInfo) {
+	p.tracker.update(p.scanID, func(s *ScanStatus) {
+		s.CurrentStage = stage.Number
+		s.TotalStages = stage.TotalStages
+		s.Stages = append(s.Stages, StageState{
+			Number:  stage.Number,
+			Name:    stage.Name,
+			Sources: append([]string(nil), stage.Sources...),
+		})
+		for _, name := range stage.Sources {
+			s.Sources[name] = &SourceState{Name: name, Stage: stage.Number, Status: "pending"}
+		}
+	})
+}
+
+func (p *trackingPresenter) FinishStage(stageNum int, duration time.Duration) {
+	p.tracker.update(p.scanID, func(s *ScanStatus) {
+		for i := range s.Stages {
+			if s.Stages[i].Number == stageNum {
+				s.Stages[i].Done = true
+				s.Stages[i].Duration = duration
+			}
+		}
+	})
+}
+
+func (p *trackingPresenter) StartSource(stageNum int, sourceName string) {
+	p.tracker.update(p.scanID, func(s *ScanStatus) {
+		src := p.source(s, sourceName)
+		src.Status = "running"
+	})
+}
+
+func (p *trackingPresenter) UpdateSourceStatus(sourceName string, status ui.Status, detail string) {
+	p.tracker.update(p.scanID, func(s *ScanStatus) {
+		src := p.source(s, sourceName)
+		src.Status = status.String()
+		if detail != "" {
+			src.Phase = detail
+		}
+	})
+}
+
+func (p *trackingPresenter) UpdateSource(sourceName string, metrics ui.ProgressMetrics) {
+	p.tracker.update(p.scanID, func(s *ScanStatus) {
+		src := p.source(s, sourceName)
+		src.Artifacts = metrics.Current
+		if metrics.Phase != "" {
+			src.Phase = metrics.Phase
+		}
+	})
+}
+
+func (p *trackingPresenter) UpdateSourcePhase(sourceName string, phase string) {
+	p.tracker.update(p.scanID, func(s *ScanStatus) {
+		p.source(s, sourceName).Phase = phase
+	})
+}
+
+func (p *trackingPresenter) FinishSource(sourceName string, status ui.Status, duration time.Duration, artifactCount int, summary *ui.SourceSummary) {
+	p.tracker.update(p.scanID, func(s *ScanStatus) {
+		src := p.source(s, sourceName)
+		src.Status = status.String()
+		src.Duration = duration
+		src.Artifacts = artifactCount
+	})
+}
+
+func (p *trackingPresenter) UpdateDiscoveries(d ui.DiscoveryStats) {
+	p.tracker.update(p.scanID, func(s *ScanStatus) {
+		s.ArtifactsByType["subdomain"] = d.Subdomains
+		s.ArtifactsByType["ip"] = d.IPs
+		s.ArtifactsByType["ipv6"] = d.IPv6
+		s.ArtifactsByType["url"] = d.URLs
+		s.ArtifactsByType["email"] = d.Emails
+		s.ArtifactsByType["port"] = d.Ports
+		s.TotalArtifacts = d.Unique
+	})
+}
+
+func (p *trackingPresenter) UpdateMemory(stats ui.MemoryStats) {}
+
+func (p *trackingPresenter) Info(msg string)    {}
+func (p *trackingPresenter) Warning(msg string) {}
+
+func (p *trackingPresenter) Error(msg string) {
+	p.tracker.update(p.scanID, func(s *ScanStatus) {
+		s.Error = msg
+	})
+}
+
+func (p *trackingPresenter) Finish(stats ui.ScanStats) {
+	p.tracker.update(p.scanID, func(s *ScanStatus) {
+		s.ArtifactsByType = make(map[string]int, len(stats.ArtifactsByType))
+		for k, v := range stats.ArtifactsByType {
+			s.ArtifactsByType[k] = v
+		}
+		s.TotalArtifacts = stats.UniqueArtifacts
+	})
+}
+
+func (p *trackingPresenter) Close() error {
+	return nil
+}
+
+// source retorna (creando si hace falta) el estado de una source.
+func (p *trackingPresenter) source(s *ScanStatus, name string) *SourceState {
+	src, ok := s.Sources[name]
+	if !ok {
+		src = &SourceState{Name: name, Stage: s.CurrentStage}
+		s.Sources[name] = src
+	}
+	return src
+}
@@ -2,8 +2,8 @@
 package domain
 
 import (
-	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -24,6 +24,14 @@ type Artifact struct {
 	// Value es el valor normalizado del artefacto
 	Value string
 
+	// RawValue es el valor original reportado por la fuente, solo si la
+	// normalización lo cambió (trazabilidad)
+	RawValue string
+
+	// UnicodeValue es la forma unicode de dominios internacionalizados (IDN);
+	// Value siempre guarda la forma punycode
+	UnicodeValue string
+
 	// Sources lista las fuentes que descubrieron este artefacto
 	Sources []string
 
@@ -42,6 +50,9 @@ type Artifact struct {
 
 	// Tags permite categorización adicional
 	Tags []string `json:"tags,omitempty"`
+
+	// Provenance registra el valor crudo, momento y consulta de cada fuente
+	Provenance []Provenance `json:"provenance,omitempty"`
 }
 
 // ArtifactRelation representa una relación dirigida entre dos artifacts.
@@ -88,20 +99,22 @@ const (
 const (
 	RelationRunsOn    RelationType = "runs_on"     // Service -> Port
 	RelationListensOn RelationType = "listens_on"  // IP -> Port
-	RelationServes    RelationType = "serves"      // Port -> Service
+	RelationServes    RelationType = "serves"      // Port -> Service/URL
 )
 
 // Relaciones DNS
 const (
 	RelationHasNameserver RelationType = "has_nameserver" // Domain -> Nameserver
 	RelationHasMX         RelationType = "has_mx"         // Domain -> MXRecord
+	RelationHasTXT        RelationType = "has_txt"        // Domain -> TXTRecord
 	RelationHasCNAME      RelationType = "has_cname"      // Domain -> Domain
 )
 
 // Relaciones de contacto
 const (
-	RelationHasContact RelationType = "has_contact"  // Domain -> Email
-	RelationManagedBy  RelationType = "managed_by"   // Domain -> WhoisContact
+	RelationHasContact     RelationType = "has_contact"     // Domain -> Email
+	RelationManagedBy      RelationType = "managed_by"      // Domain -> WhoisContact
+	RelationSameRegistrant RelationType = "same_registrant" // Domain -> Domain (reverse WHOIS)
 )
 
 // Relaciones de tecnología
@@ -122,6 +135,13 @@ func NewArtifact(artifactType ArtifactType, value, source string) *Artifact {
 	}
 	a.Normalize()
 	a.ID = a.GenerateID()
+	if recordProvenance.Load() {
+		a.AddProvenance(Provenance{
+			Source:   source,
+			RawValue: value,
+			SeenAt:   a.DiscoveredAt,
+		})
+	}
 	return a
 }
 
@@ -134,25 +154,52 @@ func NewArtifactWithMetadata(artifactType ArtifactType, value, source string, ty
 
 // Normalize normaliza el valor del artefacto según su tipo.
 func (a *Artifact) Normalize() {
+	raw := a.Value
 	a.Value = strings.TrimSpace(a.Value)
 
 	switch a.Type {
 	case ArtifactTypeDomain, ArtifactTypeSubdomain:
 		a.Value = normalizeDomain(a.Value)
+		if validator.IsIDN(a.Value) {
+			a.UnicodeValue = validator.ToUnicodeDomain(a.Value)
+		}
+	case ArtifactTypeMXRecord:
+		a.Value = normalizeDomain(a.Value)
 	case ArtifactTypeEmail:
 		a.Value = normalizeEmail(a.Value)
 	case ArtifactTypeIP, ArtifactTypeIPv6:
 		a.Value = normalizeIP(a.Value)
+		// El tipo sigue a la familia real de la dirección (ip = v4, ipv6 = v6)
+		if a.Value != "" {
+			a.Type = IPArtifactType(a.Value)
+		}
 	case ArtifactTypeURL:
 		a.Value = normalizeURL(a.Value)
+	case ArtifactTypePort:
+		a.Value = normalizePort(a.Value)
+	case ArtifactTypeASN:
+		a.Value = validator.NormalizeASN(a.Value)
+	case ArtifactTypeCIDR:
+		a.Value = validator.NormalizeCIDR(a.Value)
+	case ArtifactTypeVulnerability:
+		if validator.IsCVE(a.Value) {
+			a.Value = strings.ToUpper(a.Value)
+		}
+	case ArtifactTypeSecret:
+		a.Value = strings.ToLower(a.Value)
+	}
+
+	// Conservar el valor original la primera vez que la normalización lo altera
+	if a.RawValue == "" && raw != a.Value {
+		a.RawValue = raw
 	}
 }
 
-// GenerateID genera un ID único basado en el tipo y valor del artefacto.
+// GenerateID genera un ID único basado en el tipo y valor del artefacto,
+// con el esquema CurrentIDScheme.
 func (a *Artifact) GenerateID() string {
-	h := sha256.New()
-	h.Write([]byte(string(a.Type) + ":" + a.Value))
-	return fmt.Sprintf("%x", h.Sum(nil))[:16]
+	id, _ := ArtifactID(a.Type, a.Value, CurrentIDScheme)
+	return id
 }
 
 // Key retorna una clave única para el artefacto (type:value).
@@ -271,6 +318,14 @@ func (a *Artifact) Merge(other *Artifact) error {
 		return fmt.Errorf("cannot merge artifacts with different keys: %s != %s", a.Key(), other.Key())
 	}
 
+	// Conservar el primer valor original conocido
+	if a.RawValue == "" {
+		a.RawValue = other.RawValue
+	}
+	if a.UnicodeValue == "" {
+		a.UnicodeValue = other.UnicodeValue
+	}
+
 	// Combinar sources
 	for _, s := range other.Sources {
 		a.AddSource(s)
@@ -281,6 +336,11 @@ func (a *Artifact) Merge(other *Artifact) error {
 		a.AddTag(t)
 	}
 
+	// Combinar procedencia
+	for _, p := range other.Provenance {
+		a.AddProvenance(p)
+	}
+
 	// Combinar relaciones (evitar duplicados)
 	for _, rel := range other.Relations {
 		if !a.HasRelation(rel.TargetID, rel.Type) {
@@ -290,11 +350,15 @@ func (a *Artifact) Merge(other *Artifact) error {
 
 	// Merge TypedMetadata si existe
 	// Si el artifact actual no tiene metadata, tomar el del otro
-	if a.TypedMetadata == nil && other.TypedMetadata != nil {
+	if a.TypedMetadata == nil {
 		a.TypedMetadata = other.TypedMetadata
+	} else if other.TypedMetadata != nil {
+		// Si ambos tienen metadata, combinar campo a campo (fuentes complementarias).
+		// Con tipos distintos se mantiene el actual.
+		if err := a.TypedMetadata.Merge(other.TypedMetadata); err != nil && !errors.Is(err, metadata.ErrMetadataMismatch) {
+			return fmt.Errorf("merge metadata for %s: %w", a.Key(), err)
+		}
 	}
-	// Si ambos tienen metadata, mantener el actual (no sobreescribir)
-	// En el futuro podríamos implementar un Merge() más inteligente en cada tipo de metadata
 
 	// Usar la confianza máxima
 	if other.Confidence > a.Confidence {
@@ -329,8 +393,11 @@ func (a *Artifact) IsValid() bool {
 		if a.Value == "" {
 			return false
 		}
-		// Additional check: verify it's a valid IP using centralized validator
-		if !validator.IsIP(a.Value) {
+		// Additional check: verify it's a valid IP of the declared family
+		if a.Type == ArtifactTypeIP && !validator.IsIPv4(a.Value) {
+			return false
+		}
+		if a.Type == ArtifactTypeIPv6 && !validator.IsIPv6(a.Value) {
 			return false
 		}
 
@@ -344,13 +411,13 @@ func (a *Artifact) IsValid() bool {
 			return false
 		}
 
-	case ArtifactTypeDomain, ArtifactTypeSubdomain:
+	case ArtifactTypeDomain, ArtifactTypeSubdomain, ArtifactTypeMXRecord:
 		if !isValidDomain(a.Value) {
 			return false
 		}
 
 	case ArtifactTypePort:
-		if !isValidPort(a.Value) {
+		if !isValidPortValue(a.Value) {
 			return false
 		}
 
@@ -358,6 +425,31 @@ func (a *Artifact) IsValid() bool {
 		if !isValidCertSerial(a.Value) {
 			return false
 		}
+
+	case ArtifactTypeASN:
+		if !validator.IsASN(a.Value) {
+			return false
+		}
+
+	case ArtifactTypeCIDR:
+		if !validator.IsCIDR(a.Value) {
+			return false
+		}
+
+	case ArtifactTypeCloudResource:
+		if !isValidCloudResource(a.Value) {
+			return false
+		}
+
+	case ArtifactTypeSecret:
+		if !isValidSecretRef(a.Value) {
+			return false
+		}
+
+	case ArtifactTypeVulnerability:
+		if !isValidVulnID(a.Value) {
+			return false
+		}
 	}
 
 	return true
@@ -369,13 +461,53 @@ func (a *Artifact) String() string {
 		a.Type, a.Value, len(a.Sources), a.Confidence)
 }
 
+// Tamaños aproximados (bytes) de las partes fijas de un artifact en memoria:
+// struct, cabeceras de slices/strings y entradas de mapas.
+const (
+	artifactBaseSize   = 256
+	relationBaseSize   = 96
+	provenanceBaseSize = 72
+	stringHeaderSize   = 16
+	metadataEntrySize  = 48
+)
+
+// ApproxSize estima los bytes que ocupa el artifact en memoria. No es exacto
+// (ignora padding y overhead del allocator) pero es proporcional al contenido:
+// una URL de wayback con metadata pesa mucho más que un subdominio.
+func (a *Artifact) ApproxSize() int {
+	size := artifactBaseSize + len(a.ID) + len(a.Type) + len(a.Value) + len(a.RawValue) + len(a.UnicodeValue)
+
+	for _, s := range a.Sources {
+		size += stringHeaderSize + len(s)
+	}
+	for _, t := range a.Tags {
+		size += stringHeaderSize + len(t)
+	}
+	for _, r := range a.Relations {
+		size += relationBaseSize + len(r.Type) + len(r.TargetID) + len(r.Source)
+		for k, v := range r.Metadata {
+			size += metadataEntrySize + len(k) + len(v)
+		}
+	}
+	for _, p := range a.Provenance {
+		size += provenanceBaseSize + len(p.Source) + len(p.RawValue) + len(p.Query)
+	}
+	if a.TypedMetadata != nil {
+		for k, v := range a.TypedMetadata.ToMap() {
+			size += metadataEntrySize + len(k) + len(v)
+		}
+	}
+
+	return size
+}
+
 // Funciones de normalización privadas
 
 func normalizeDomain(v string) string {
 	// Handle wildcard prefix specific to certificates
 	v = strings.TrimPrefix(v, "*.")
-	// Delegate to centralized validator
-	return validator.NormalizeDomain(v)
+	// Delegate to centralized validator (www. is only stripped in aggressive mode)
+	return validator.NormalizeDomainWithPolicy(v, CurrentNormalizationPolicy())
 }
 
 func normalizeEmail(v string) string {
@@ -400,25 +532,45 @@ func isValidDomain(domain string) bool {
 	return validator.IsDomain(domain)
 }
 
-func isValidPort(portStr string) bool {
-	return validator.IsPort(portStr)
-}
-
 func isValidCertSerial(serial string) bool {
 	return validator.IsCertSerial(serial)
 }
 
+// isValidCloudResource valida "<provider>:<identifier>" (e.g., "aws:93.184.216.34").
+func isValidCloudResource(v string) bool {
+	provider, id, ok := strings.Cut(v, ":")
+	return ok && provider != "" && id != ""
+}
+
+// isValidSecretRef valida "<kind>:<sha256>": el secreto nunca va en claro en el valor.
+func isValidSecretRef(v string) bool {
+	kind, hash, ok := strings.Cut(v, ":")
+	return ok && kind != "" && len(hash) == 64 && validator.IsHash(hash)
+}
+
+// isValidVulnID valida IDs de vulnerabilidad: CVE bien formado u otro ID sin espacios
+// (GHSA-..., ID de plantilla).
+func isValidVulnID(v string) bool {
+	if strings.HasPrefix(strings.ToUpper(v), "CVE-") {
+		return validator.IsCVE(v)
+	}
+	return !strings.ContainsAny(v, " \t\n")
+}
+
 // artifactJSON es una estructura auxiliar para serialización custom.
 type artifactJSON struct {
 	ID            string                      `json:"id"`
 	Type          ArtifactType                `json:"type"`
 	Value         string                      `json:"value"`
+	RawValue      string                      `json:"raw_value,omitempty"`
+	UnicodeValue  string                      `json:"unicode_value,omitempty"`
 	Sources       []string                    `json:"sources"`
 	Metadata      *metadata.MetadataEnvelope  `json:"metadata,omitempty"`
 	Relations     []ArtifactRelation          `json:"relations,omitempty"`
 	Confidence    float64                     `json:"confidence"`
 	DiscoveredAt  time.Time                   `json:"discovered_at"`
 	Tags          []string                    `json:"tags,omitempty"`
+	Provenance    []Provenance                `json:"provenance,omitempty"`
 }
 
 // MarshalJSON implementa custom JSON marshaling para Artifact.
@@ -439,12 +591,15 @@ func (a *Artifact) MarshalJSON() ([]byte, error) {
 		ID:           a.ID,
 		Type:         a.Type,
 		Value:        a.Value,
+		RawValue:     a.RawValue,
+		UnicodeValue: a.UnicodeValue,
 		Sources:      a.Sources,
 		Metadata:     metaEnvelope,
 		Relations:    a.Relations,
 		Confidence:   a.Confidence,
 		DiscoveredAt: a.DiscoveredAt,
 		Tags:         a.Tags,
+		Provenance:   a.Provenance,
 	}
 
 	return json.Marshal(aux)
@@ -463,11 +618,14 @@ func (a *Artifact) UnmarshalJSON(data []byte) error {
 	a.ID = aux.ID
 	a.Type = aux.Type
 	a.Value = aux.Value
+	a.RawValue = aux.RawValue
+	a.UnicodeValue = aux.UnicodeValue
 	a.Sources = aux.Sources
 	a.Relations = aux.Relations
 	a.Confidence = aux.Confidence
 	a.DiscoveredAt = aux.DiscoveredAt
 	a.Tags = aux.Tags
+	a.Provenance = aux.Provenance
 
 	// Deserializar metadata tipado
 	if aux.Metadata != nil {
@@ -0,0 +1,189 @@
+// internal/core/domain/artifact_filter.go
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ArtifactFilter selecciona artifacts para exports focalizados.
+// Las condiciones se combinan con AND; dentro de Types y Tags basta con
+// que coincida uno de los valores (OR).
+type ArtifactFilter struct {
+	Types         []ArtifactType // Tipos permitidos (vacío = todos)
+	Tags          []string       // Tags requeridos, al menos uno (vacío = sin filtro)
+	OnlyAlive     bool           // Solo artifacts marcados como vivos (httpx/probes)
+	MinConfidence float64        // Confianza mínima [0.0-1.0] (0 = sin filtro)
+}
+
+// IsZero indica si el filtro no restringe nada.
+func (f ArtifactFilter) IsZero() bool {
+	return len(f.Types) == 0 && len(f.Tags) == 0 && !f.OnlyAlive && f.MinConfidence <= 0
+}
+
+// Match indica si el artifact cumple el filtro.
+func (f ArtifactFilter) Match(a *Artifact) bool {
+	if a == nil {
+		return false
+	}
+
+	if len(f.Types) > 0 && !containsType(f.Types, a.Type) {
+		return false
+	}
+
+	if f.MinConfidence > 0 && a.Confidence < f.MinConfidence {
+		return false
+	}
+
+	if len(f.Tags) > 0 && !hasAnyTag(a, f.Tags) {
+		return false
+	}
+
+	if f.OnlyAlive && !a.IsAlive() {
+		return false
+	}
+
+	return true
+}
+
+// String retorna una descripción compacta del filtro (e.g., "types=url,subdomain alive").
+func (f ArtifactFilter) String() string {
+	var parts []string
+	if len(f.Types) > 0 {
+		types := make([]string, len(f.Types))
+		for i, t := range f.Types {
+			types[i] = string(t)
+		}
+		parts = append(parts, "types="+strings.Join(types, ","))
+	}
+	if len(f.Tags) > 0 {
+		parts = append(parts, "tags="+strings.Join(f.Tags, ","))
+	}
+	if f.OnlyAlive {
+		parts = append(parts, "alive")
+	}
+	if f.MinConfidence > 0 {
+		parts = append(parts, fmt.Sprintf("min_confidence=%.2f", f.MinConfidence))
+	}
+	return strings.Join(parts, " ")
+}
+
+// IsAlive indica si el artifact respondió a un probe (tag "alive" o is_alive en metadata).
+func (a *Artifact) IsAlive() bool {
+	for _, t := range a.Tags {
+		if t == "alive" {
+			return true
+		}
+	}
+	if a.TypedMetadata != nil {
+		return a.TypedMetadata.ToMap()["is_alive"] == "true"
+	}
+	return false
+}
+
+// TagHistorical marca los artifacts reinyectados desde un escaneo anterior
+// (source previousscan) que este escaneo todavía no ha vuelto a observar.
+const TagHistorical = "historical"
+
+// TagRelatedOrg marca los artifacts de otra entidad del mismo registrante
+// hallados al pivotar (reversewhois): quedan fuera del target, así que no se
+// sondean activamente ni cuentan como ruido de terceros.
+const TagRelatedOrg = "related-org"
+
+// IsRelatedOrg indica si el artifact lleva el tag "related-org".
+func (a *Artifact) IsRelatedOrg() bool {
+	for _, t := range a.Tags {
+		if t == TagRelatedOrg {
+			return true
+		}
+	}
+	return false
+}
+
+// IsHistoricalOnly indica si el artifact solo viene de un escaneo anterior:
+// lleva el tag "historical" y ninguna otra source lo ha confirmado.
+func (a *Artifact) IsHistoricalOnly() bool {
+	if len(a.Sources) > 1 {
+		return false
+	}
+	for _, t := range a.Tags {
+		if t == TagHistorical {
+			return true
+		}
+	}
+	return false
+}
+
+// Filtered retorna una copia superficial del resultado con solo los artifacts
+// que cumplen el filtro. Las relaciones hacia artifacts descartados se quitan
+// (en una copia del artifact) para que el grafo exportado no tenga
+// referencias colgantes. El resultado original no se modifica.
+func (r *ScanResult) Filtered(f ArtifactFilter) *ScanResult {
+	out := *r
+
+	kept := make(map[string]bool, len(r.Artifacts))
+	out.Artifacts = make([]*Artifact, 0, len(r.Artifacts))
+	for _, a := range r.Artifacts {
+		if f.Match(a) {
+			out.Artifacts = append(out.Artifacts, a)
+			kept[a.ID] = true
+		}
+	}
+	for i, a := range out.Artifacts {
+		out.Artifacts[i] = a.withRelationsTo(kept)
+	}
+
+	out.Metadata.Environment = make(map[string]string, len(r.Metadata.Environment)+1)
+	for k, v := range r.Metadata.Environment {
+		out.Metadata.Environment[k] = v
+	}
+	if !f.IsZero() {
+		out.Metadata.Environment["output_filter"] = f.String()
+	}
+
+	return &out
+}
+
+// withRelationsTo retorna el artifact con solo las relaciones cuyo destino
+// está en ids; si hay que quitar alguna retorna una copia.
+func (a *Artifact) withRelationsTo(ids map[string]bool) *Artifact {
+	dangling := false
+	for _, rel := range a.Relations {
+		if !ids[rel.TargetID] {
+			dangling = true
+			break
+		}
+	}
+	if !dangling {
+		return a
+	}
+
+	pruned := *a
+	pruned.Relations = make([]ArtifactRelation, 0, len(a.Relations))
+	for _, rel := range a.Relations {
+		if ids[rel.TargetID] {
+			pruned.Relations = append(pruned.Relations, rel)
+		}
+	}
+	return &pruned
+}
+
+func containsType(types []ArtifactType, t ArtifactType) bool {
+	for _, candidate := range types {
+		if candidate == t {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyTag(a *Artifact, tags []string) bool {
+	for _, want := range tags {
+		for _, have := range a.Tags {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+// internal/core/domain/artifact_filter_test.go
+package domain
+
+import (
+	"testing"
+
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/testutil"
+)
+
+func TestArtifactFilter_Match(t *testing.T) {
+	sub := NewArtifact(ArtifactTypeSubdomain, "api.example.com", "crtsh")
+	sub.Confidence = 0.9
+	sub.AddTag("internal")
+
+	url := NewArtifact(ArtifactTypeURL, "https://example.com/login", "httpx")
+	url.Confidence = 0.5
+	url.AddTag("alive")
+
+	probed := NewArtifact(ArtifactTypeSubdomain, "www.example.com", "httpx")
+	probed.Confidence = 1.0
+	meta := metadata.NewDomainMetadata()
+	meta.IsAlive = true
+	probed.TypedMetadata = meta
+
+	tests := []struct {
+		name   string
+		filter ArtifactFilter
+		want   []bool // sub, url, probed
+	}{
+		{"zero filter", ArtifactFilter{}, []bool{true, true, true}},
+		{"types", ArtifactFilter{Types: []ArtifactType{ArtifactTypeURL}}, []bool{false, true, false}},
+		{"min confidence", ArtifactFilter{MinConfidence: 0.8}, []bool{true, false, true}},
+		{"tag", ArtifactFilter{Tags: []string{"internal", "review"}}, []bool{true, false, false}},
+		{"only alive", ArtifactFilter{OnlyAlive: true}, []bool{false, true, true}},
+		{"combined", ArtifactFilter{Types: []ArtifactType{ArtifactTypeSubdomain}, OnlyAlive: true}, []bool{false, false, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, a := range []*Artifact{sub, url, probed} {
+				testutil.AssertEqual(t, tt.filter.Match(a), tt.want[i], a.Value)
+			}
+		})
+	}
+}
+
+func TestScanResult_Filtered(t *testing.T) {
+	result := NewScanResult(fixtureTarget(ScanModePassive))
+	result.AddArtifact(NewArtifact(ArtifactTypeSubdomain, "api.example.com", "crtsh"))
+	result.AddArtifact(NewArtifact(ArtifactTypeIP, "10.0.0.1", "rdap"))
+
+	filtered := result.Filtered(ArtifactFilter{Types: []ArtifactType{ArtifactTypeIP}})
+
+	testutil.AssertEqual(t, len(filtered.Artifacts), 1, "filtered artifacts")
+	testutil.AssertEqual(t, len(result.Artifacts), 2, "original must keep all artifacts")
+	testutil.AssertEqual(t, filtered.Metadata.Environment["output_filter"], "types=ip", "filter recorded")
+	_, leaked := result.Metadata.Environment["output_filter"]
+	testutil.AssertFalse(t, leaked, "original metadata must not be modified")
+}
+
+func TestScanResult_Filtered_PrunesDanglingRelations(t *testing.T) {
+	result := NewScanResult(fixtureTarget(ScanModePassive))
+	sub := NewArtifact(ArtifactTypeSubdomain, "api.example.com", "crtsh")
+	ip := NewArtifact(ArtifactTypeIP, "10.0.0.1", "dns")
+	cert := NewArtifact(ArtifactTypeCertificate, "0abc", "crtsh")
+	sub.AddRelation(ip.ID, RelationResolvesTo, 1.0, "dns")
+	sub.AddRelation(cert.ID, RelationUsesCert, 1.0, "crtsh")
+	result.AddArtifact(sub)
+	result.AddArtifact(ip)
+	result.AddArtifact(cert)
+
+	filtered := result.Filtered(ArtifactFilter{Types: []ArtifactType{ArtifactTypeSubdomain, ArtifactTypeIP}})
+
+	ids := make(map[string]bool)
+	for _, a := range filtered.Artifacts {
+		ids[a.ID] = true
+	}
+	for _, a := range filtered.Artifacts {
+		for _, rel := range a.Relations {
+			testutil.AssertTrue(t, ids[rel.TargetID], a.Value+" must not point to a filtered-out artifact")
+		}
+	}
+	testutil.AssertEqual(t, len(filtered.Artifacts[0].Relations), 1, "relation to a kept artifact survives")
+	testutil.AssertEqual(t, len(sub.Relations), 2, "original artifact keeps its relations")
+}
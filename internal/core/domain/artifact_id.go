@@ -0,0 +1,83 @@
+// internal/core/domain/artifact_id.go
+package domain
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+// Esquemas de ID de artifacts. Todos derivan de SHA256(type:value); cambia la
+// longitud del prefijo hexadecimal. Un cambio de normalización o de longitud
+// exige un esquema nuevo para que los escaneos guardados puedan migrarse en
+// lugar de quedar con IDs incompatibles en diffs y persistencia.
+const (
+	// IDSchemeV1 prefijo de 16 hex (64 bits); escaneos sin id_scheme
+	IDSchemeV1 = 1
+
+	// IDSchemeV2 prefijo de 32 hex (128 bits)
+	IDSchemeV2 = 2
+
+	// CurrentIDScheme esquema usado por GenerateID
+	CurrentIDScheme = IDSchemeV2
+)
+
+// idSchemeLengths longitud en caracteres hex de cada esquema.
+var idSchemeLengths = map[int]int{
+	IDSchemeV1: 16,
+	IDSchemeV2: 32,
+}
+
+// ArtifactID calcula el ID de type:value con el esquema indicado.
+func ArtifactID(artifactType ArtifactType, value string, scheme int) (string, error) {
+	length, ok := idSchemeLengths[scheme]
+	if !ok {
+		return "", fmt.Errorf("unknown artifact id scheme %d", scheme)
+	}
+	sum := sha256.Sum256([]byte(string(artifactType) + ":" + value))
+	return fmt.Sprintf("%x", sum)[:length], nil
+}
+
+// MigrateArtifactIDs reescribe los IDs de un escaneo guardado al esquema
+// actual, junto con los TargetID de sus relaciones, y retorna el mapeo
+// ID antiguo -> ID nuevo. Un escaneo sin id_scheme se considera IDSchemeV1.
+// Los IDs se recalculan sobre Type/Value tal como se guardaron (sin volver a
+// normalizar). Las relaciones hacia artifacts ausentes del escaneo conservan
+// su TargetID: sin el valor no hay forma de recalcularlo.
+func MigrateArtifactIDs(result *ScanResult) (map[string]string, error) {
+	scheme := result.Metadata.IDScheme
+	if scheme == 0 {
+		scheme = IDSchemeV1
+	}
+	if scheme == CurrentIDScheme {
+		return nil, nil
+	}
+	if _, ok := idSchemeLengths[scheme]; !ok {
+		return nil, fmt.Errorf("scan %s uses unknown artifact id scheme %d (current: %d)", result.ID, scheme, CurrentIDScheme)
+	}
+
+	mapping := make(map[string]string, len(result.Artifacts))
+	for _, a := range result.Artifacts {
+		if a == nil {
+			continue
+		}
+		id := a.GenerateID()
+		if a.ID != "" && a.ID != id {
+			mapping[a.ID] = id
+		}
+		a.ID = id
+	}
+
+	for _, a := range result.Artifacts {
+		if a == nil {
+			continue
+		}
+		for i := range a.Relations {
+			if id, ok := mapping[a.Relations[i].TargetID]; ok {
+				a.Relations[i].TargetID = id
+			}
+		}
+	}
+
+	result.Metadata.IDScheme = CurrentIDScheme
+	return mapping, nil
+}
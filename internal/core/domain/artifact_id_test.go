@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"aethonx/internal/testutil"
+)
+
+func TestArtifactID_Schemes(t *testing.T) {
+	v1, err := ArtifactID(ArtifactTypeSubdomain, "api.example.com", IDSchemeV1)
+	testutil.AssertNoError(t, err, "v1")
+	v2, err := ArtifactID(ArtifactTypeSubdomain, "api.example.com", IDSchemeV2)
+	testutil.AssertNoError(t, err, "v2")
+
+	testutil.AssertEqual(t, len(v1), 16, "v1 length")
+	testutil.AssertEqual(t, len(v2), 32, "v2 length")
+	testutil.AssertTrue(t, strings.HasPrefix(v2, v1), "same hash, longer prefix")
+
+	_, err = ArtifactID(ArtifactTypeSubdomain, "api.example.com", 99)
+	testutil.AssertError(t, err, "unknown scheme")
+}
+
+func TestNewScanResult_IDScheme(t *testing.T) {
+	result := NewScanResult(*NewTarget("example.com", ScanModePassive))
+	testutil.AssertEqual(t, result.Metadata.IDScheme, CurrentIDScheme, "new scans use the current scheme")
+}
+
+// legacyScan simula un escaneo guardado antes del versionado (sin id_scheme).
+func legacyScan(t *testing.T) (*ScanResult, *Artifact, *Artifact) {
+	t.Helper()
+	result := NewScanResult(*NewTarget("example.com", ScanModePassive))
+	result.Metadata.IDScheme = 0
+
+	sub := NewArtifact(ArtifactTypeSubdomain, "api.example.com", "crtsh")
+	ip := NewArtifact(ArtifactTypeIP, "192.0.2.10", "dns")
+	for _, a := range []*Artifact{sub, ip} {
+		id, err := ArtifactID(a.Type, a.Value, IDSchemeV1)
+		testutil.AssertNoError(t, err, "v1 id")
+		a.ID = id
+	}
+	sub.AddRelation(ip.ID, RelationResolvesTo, ConfidenceHigh, "dns")
+	sub.AddRelation("0123456789abcdef", RelationSubdomainOf, ConfidenceHigh, "crtsh") // Raíz ausente
+	result.AddArtifact(sub)
+	result.AddArtifact(ip)
+	return result, sub, ip
+}
+
+func TestMigrateArtifactIDs_Legacy(t *testing.T) {
+	result, sub, ip := legacyScan(t)
+	oldIP := ip.ID
+
+	mapping, err := MigrateArtifactIDs(result)
+	testutil.AssertNoError(t, err, "migrate")
+	testutil.AssertEqual(t, len(mapping), 2, "both artifacts remapped")
+	testutil.AssertEqual(t, result.Metadata.IDScheme, CurrentIDScheme, "scheme updated")
+
+	testutil.AssertEqual(t, ip.ID, ip.GenerateID(), "ip on current scheme")
+	testutil.AssertEqual(t, mapping[oldIP], ip.ID, "mapping old -> new")
+	testutil.AssertTrue(t, sub.HasRelation(ip.ID, RelationResolvesTo), "relation rewritten")
+	testutil.AssertTrue(t, sub.HasRelation("0123456789abcdef", RelationSubdomainOf), "unknown target kept")
+
+	mapping, err = MigrateArtifactIDs(result)
+	testutil.AssertNoError(t, err, "second migration")
+	testutil.AssertEqual(t, len(mapping), 0, "already current: no-op")
+}
+
+func TestMigrateArtifactIDs_UnknownScheme(t *testing.T) {
+	result, _, _ := legacyScan(t)
+	result.Metadata.IDScheme = CurrentIDScheme + 1
+
+	_, err := MigrateArtifactIDs(result)
+	testutil.AssertError(t, err, "scan written by a newer version")
+}
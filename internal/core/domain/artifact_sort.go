@@ -0,0 +1,78 @@
+// internal/core/domain/artifact_sort.go
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ArtifactSortKey define el criterio de ordenación de artifacts.
+type ArtifactSortKey string
+
+const (
+	SortByValue      ArtifactSortKey = "value"
+	SortByType       ArtifactSortKey = "type"
+	SortByConfidence ArtifactSortKey = "confidence"
+	SortBySources    ArtifactSortKey = "sources"
+	SortByDiscovered ArtifactSortKey = "discovered"
+)
+
+// ParseArtifactSortKey valida un criterio de ordenación.
+// Un prefijo "-" invierte el orden (e.g., "-confidence").
+func ParseArtifactSortKey(s string) (key ArtifactSortKey, desc bool, err error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if strings.HasPrefix(s, "-") {
+		desc = true
+		s = s[1:]
+	}
+
+	switch key = ArtifactSortKey(s); key {
+	case SortByValue, SortByType, SortByConfidence, SortBySources, SortByDiscovered:
+		return key, desc, nil
+	case "":
+		return SortByValue, desc, nil
+	default:
+		return "", false, fmt.Errorf("unknown sort key %q (use value, type, confidence, sources, discovered)", s)
+	}
+}
+
+// SortArtifacts ordena in-place de forma estable; empates se resuelven por tipo y valor.
+func SortArtifacts(artifacts []*Artifact, key ArtifactSortKey, desc bool) {
+	compare := func(a, b *Artifact) int {
+		switch key {
+		case SortByValue:
+			return strings.Compare(a.Value, b.Value)
+		case SortByType:
+			return strings.Compare(string(a.Type), string(b.Type))
+		case SortByConfidence:
+			return compareFloat(a.Confidence, b.Confidence)
+		case SortBySources:
+			return len(a.Sources) - len(b.Sources)
+		case SortByDiscovered:
+			return a.DiscoveredAt.Compare(b.DiscoveredAt)
+		}
+		return 0
+	}
+
+	sort.SliceStable(artifacts, func(i, j int) bool {
+		a, b := artifacts[i], artifacts[j]
+		if c := compare(a, b); c != 0 {
+			return (c < 0) != desc
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Value < b.Value
+	})
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,33 @@
+// internal/core/domain/artifact_sort_test.go
+package domain
+
+import (
+	"testing"
+
+	"aethonx/internal/testutil"
+)
+
+func TestSortArtifacts(t *testing.T) {
+	low := NewArtifact(ArtifactTypeSubdomain, "b.example.com", "crtsh")
+	low.Confidence = 0.3
+	high := NewArtifact(ArtifactTypeSubdomain, "a.example.com", "crtsh")
+	high.Confidence = 0.9
+	url := NewArtifact(ArtifactTypeURL, "https://a.example.com/", "httpx")
+	url.Confidence = 0.6
+
+	artifacts := []*Artifact{low, url, high}
+
+	key, desc, err := ParseArtifactSortKey("-confidence")
+	testutil.AssertNoError(t, err, "sort key should parse")
+	SortArtifacts(artifacts, key, desc)
+	testutil.AssertEqual(t, artifacts[0].Value, "a.example.com", "highest confidence first")
+	testutil.AssertEqual(t, artifacts[2].Value, "b.example.com", "lowest confidence last")
+
+	key, desc, _ = ParseArtifactSortKey("value")
+	SortArtifacts(artifacts, key, desc)
+	testutil.AssertEqual(t, artifacts[0].Value, "a.example.com", "sorted by value")
+	testutil.AssertEqual(t, artifacts[2].Type, ArtifactTypeURL, "https:// sorts after hostnames")
+
+	_, _, err = ParseArtifactSortKey("size")
+	testutil.AssertError(t, err, "unknown key rejected")
+}
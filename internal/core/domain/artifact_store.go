@@ -0,0 +1,82 @@
+// internal/core/domain/artifact_store.go
+package domain
+
+import "sync"
+
+// ArtifactStore acumula artifacts, warnings y errores escritos desde varias
+// goroutines. ScanResult no es seguro para escritura concurrente: los
+// orchestrators y las sources con worker pools escriben en el store y
+// recuperan el ScanResult con Result cuando todas las goroutines terminaron.
+type ArtifactStore struct {
+	mu     sync.Mutex
+	result *ScanResult
+}
+
+// NewArtifactStore crea un store que escribe sobre result.
+func NewArtifactStore(result *ScanResult) *ArtifactStore {
+	return &ArtifactStore{result: result}
+}
+
+// AddArtifact añade un artifact válido.
+func (s *ArtifactStore) AddArtifact(artifact *Artifact) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.result.AddArtifact(artifact)
+}
+
+// AddArtifacts añade varios artifacts bajo un único lock.
+func (s *ArtifactStore) AddArtifacts(artifacts ...*Artifact) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.result.AddArtifacts(artifacts...)
+}
+
+// AddWarning añade una advertencia.
+func (s *ArtifactStore) AddWarning(source, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.result.AddWarning(source, message)
+}
+
+// AddErrorWithSeverity añade un error con severidad específica.
+func (s *ArtifactStore) AddErrorWithSeverity(source, message string, severity ErrorSeverity, retryable bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.result.AddErrorWithSeverity(source, message, severity, retryable)
+}
+
+// Merge copia artifacts, warnings y errores de other tal cual (la
+// validación y deduplicación quedan para DedupeService).
+func (s *ArtifactStore) Merge(other *ScanResult) {
+	if other == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.result.Artifacts = append(s.result.Artifacts, other.Artifacts...)
+	s.result.Warnings = append(s.result.Warnings, other.Warnings...)
+	s.result.Errors = append(s.result.Errors, other.Errors...)
+}
+
+// Update da acceso exclusivo al ScanResult para cambios compuestos
+// (metadata, contadores). fn no debe retener el puntero.
+func (s *ArtifactStore) Update(fn func(result *ScanResult)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.result)
+}
+
+// Len retorna el número de artifacts acumulados.
+func (s *ArtifactStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.result.Artifacts)
+}
+
+// Result retorna el ScanResult acumulado. Solo debe llamarse cuando ninguna
+// goroutine sigue escribiendo en el store.
+func (s *ArtifactStore) Result() *ScanResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.result
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"aethonx/internal/testutil"
+)
+
+func TestArtifactStore_ConcurrentWriters(t *testing.T) {
+	target := NewTarget("example.com", ScanModePassive)
+	store := NewArtifactStore(NewScanResult(*target))
+
+	const writers, perWriter = 8, 50
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			partial := NewScanResult(*target)
+			for i := 0; i < perWriter; i++ {
+				host := fmt.Sprintf("h%d-%d.example.com", w, i)
+				store.AddArtifact(NewArtifact(ArtifactTypeSubdomain, host, "crtsh"))
+				partial.AddArtifact(NewArtifact(ArtifactTypeSubdomain, "m"+host, "dns"))
+			}
+			partial.AddWarning("dns", "partial warning")
+			store.Merge(partial)
+			store.AddWarning("crtsh", fmt.Sprintf("writer %d done", w))
+			store.AddErrorWithSeverity("crtsh", "boom", ErrorWarning, true)
+			store.Update(func(r *ScanResult) {
+				r.Metadata.SourcesUsed = append(r.Metadata.SourcesUsed, fmt.Sprintf("w%d", w))
+			})
+		}(w)
+	}
+	wg.Wait()
+
+	result := store.Result()
+	testutil.AssertEqual(t, store.Len(), writers*perWriter*2, "artifacts")
+	testutil.AssertEqual(t, len(result.Warnings), writers*2, "warnings")
+	testutil.AssertEqual(t, len(result.Errors), writers, "errors")
+	testutil.AssertEqual(t, len(result.Metadata.SourcesUsed), writers, "metadata updates")
+}
+
+func TestArtifactStore_SkipsInvalid(t *testing.T) {
+	store := NewArtifactStore(NewScanResult(*NewTarget("example.com", ScanModePassive)))
+	store.AddArtifacts(nil, &Artifact{Type: ArtifactTypeSubdomain}, NewArtifact(ArtifactTypeSubdomain, "api.example.com", "crtsh"))
+	store.Merge(nil)
+
+	testutil.AssertEqual(t, store.Len(), 1, "only valid artifacts")
+}
@@ -2,9 +2,11 @@
 package domain
 
 import (
+	"encoding/json"
 	"testing"
 
 	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/platform/validator"
 	"aethonx/internal/testutil"
 )
 
@@ -46,10 +48,10 @@ func TestArtifact_Normalize(t *testing.T) {
 			expected: "example.com",
 		},
 		{
-			name:     "normalize subdomain - remove www",
+			name:     "normalize subdomain - keep www",
 			artType:  ArtifactTypeSubdomain,
 			input:    "www.example.com",
-			expected: "example.com",
+			expected: "www.example.com",
 		},
 		{
 			name:     "normalize email - lowercase",
@@ -83,6 +85,30 @@ func TestArtifact_Normalize(t *testing.T) {
 	}
 }
 
+func TestArtifact_NormalizeAggressivePolicy(t *testing.T) {
+	SetNormalizationPolicy(validator.NormalizationAggressive)
+	defer SetNormalizationPolicy(validator.NormalizationStrict)
+
+	a := NewArtifact(ArtifactTypeSubdomain, "WWW.Example.com", "crtsh")
+	testutil.AssertEqual(t, a.Value, "example.com", "www stripped in aggressive mode")
+	testutil.AssertEqual(t, a.RawValue, "WWW.Example.com", "raw value preserved")
+}
+
+func TestArtifact_RawValueOnlyWhenChanged(t *testing.T) {
+	a := NewArtifact(ArtifactTypeSubdomain, "api.example.com", "crtsh")
+	testutil.AssertEqual(t, a.RawValue, "", "no raw value when unchanged")
+
+	b := NewArtifact(ArtifactTypeSubdomain, "*.Example.com.", "crtsh")
+	testutil.AssertEqual(t, b.Value, "example.com", "normalized wildcard")
+	testutil.AssertEqual(t, b.RawValue, "*.Example.com.", "raw value preserved")
+
+	data, err := json.Marshal(b)
+	testutil.AssertNoError(t, err, "marshal")
+	var decoded Artifact
+	testutil.AssertNoError(t, json.Unmarshal(data, &decoded), "unmarshal")
+	testutil.AssertEqual(t, decoded.RawValue, "*.Example.com.", "raw value round-trip")
+}
+
 func TestArtifact_GenerateID(t *testing.T) {
 	a1 := NewArtifact(ArtifactTypeSubdomain, "test.example.com", "crtsh")
 	a2 := NewArtifact(ArtifactTypeSubdomain, "test.example.com", "rdap")
@@ -94,8 +120,8 @@ func TestArtifact_GenerateID(t *testing.T) {
 	// Diferente valor = diferente ID
 	testutil.AssertNotEqual(t, a1.ID, a3.ID, "different domain should have different ID")
 
-	// ID debe tener longitud 16 (truncated SHA256)
-	testutil.AssertEqual(t, len(a1.ID), 16, "ID length")
+	// ID debe tener la longitud del esquema actual (truncated SHA256)
+	testutil.AssertEqual(t, len(a1.ID), 32, "ID length")
 }
 
 func TestArtifact_Key(t *testing.T) {
@@ -177,6 +203,37 @@ func TestArtifact_Merge(t *testing.T) {
 	testutil.AssertEqual(t, a1.Confidence, 0.9, "confidence should be max")
 }
 
+func TestArtifact_MergeComplementaryMetadata(t *testing.T) {
+	rdapMeta := metadata.NewDomainMetadata()
+	rdapMeta.Registrar = "Example Registrar"
+	rdapMeta.Nameservers = []string{"ns1.example.com"}
+
+	httpxMeta := metadata.NewDomainMetadata()
+	httpxMeta.HTTPStatus = 200
+	httpxMeta.HTTPTitle = "Home"
+	httpxMeta.Nameservers = []string{"ns1.example.com", "ns2.example.com"}
+
+	a1 := NewArtifactWithMetadata(ArtifactTypeDomain, "example.com", "rdap", rdapMeta)
+	a2 := NewArtifactWithMetadata(ArtifactTypeDomain, "example.com", "httpx", httpxMeta)
+
+	testutil.AssertNoError(t, a1.Merge(a2), "merge should succeed")
+
+	merged := a1.GetDomainMetadata()
+	testutil.AssertNotNil(t, merged, "typed metadata should exist")
+	testutil.AssertEqual(t, merged.Registrar, "Example Registrar", "registrar from rdap")
+	testutil.AssertEqual(t, merged.HTTPStatus, 200, "http status from httpx")
+	testutil.AssertEqual(t, merged.HTTPTitle, "Home", "http title from httpx")
+	testutil.AssertLen(t, merged.Nameservers, 2, "nameservers union")
+}
+
+func TestArtifact_MergeMismatchedMetadataKeepsCurrent(t *testing.T) {
+	a1 := NewArtifactWithMetadata(ArtifactTypeDomain, "example.com", "rdap", metadata.NewDomainMetadata())
+	a2 := NewArtifactWithMetadata(ArtifactTypeDomain, "example.com", "other", metadata.NewIPMetadata())
+
+	testutil.AssertNoError(t, a1.Merge(a2), "type mismatch should not fail the merge")
+	testutil.AssertNotNil(t, a1.GetDomainMetadata(), "current metadata kept")
+}
+
 func TestArtifact_MergeIncompatible(t *testing.T) {
 	a1 := NewArtifact(ArtifactTypeSubdomain, "test.example.com", "crtsh")
 	a2 := NewArtifact(ArtifactTypeSubdomain, "different.example.com", "rdap")
@@ -455,43 +512,53 @@ func TestArtifact_PortValidation(t *testing.T) {
 		valid bool
 	}{
 		{
-			name:  "valid port - 80",
-			port:  "80",
+			name:  "valid port - ip:80",
+			port:  "93.184.216.34:80",
 			valid: true,
 		},
 		{
-			name:  "valid port - 443",
-			port:  "443",
+			name:  "valid port - host:443",
+			port:  "api.example.com:443",
 			valid: true,
 		},
 		{
-			name:  "valid port - 8080",
-			port:  "8080",
+			name:  "valid port - ipv6:8080",
+			port:  "[2001:db8::1]:8080",
 			valid: true,
 		},
 		{
 			name:  "valid port - max",
-			port:  "65535",
+			port:  "10.0.0.1:65535",
 			valid: true,
 		},
+		{
+			name:  "invalid port - bare number collides across hosts",
+			port:  "443",
+			valid: false,
+		},
+		{
+			name:  "invalid port - missing host",
+			port:  ":443",
+			valid: false,
+		},
 		{
 			name:  "invalid port - 0",
-			port:  "0",
+			port:  "10.0.0.1:0",
 			valid: false,
 		},
 		{
 			name:  "invalid port - negative",
-			port:  "-1",
+			port:  "10.0.0.1:-1",
 			valid: false,
 		},
 		{
 			name:  "invalid port - too large",
-			port:  "65536",
+			port:  "10.0.0.1:65536",
 			valid: false,
 		},
 		{
 			name:  "invalid port - not a number",
-			port:  "abc",
+			port:  "10.0.0.1:abc",
 			valid: false,
 		},
 	}
@@ -559,3 +626,35 @@ func TestArtifact_CertificateSerialValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestNewPortArtifact_HostScoped(t *testing.T) {
+	a := NewPortArtifact("93.184.216.34", 443, "https", "httpx")
+	b := NewPortArtifact("10.0.0.1", 443, "https", "httpx")
+	upper := NewPortArtifact("API.Example.com", 8443, "", "shodan")
+
+	testutil.AssertEqual(t, a.Value, "93.184.216.34:443", "port value encodes host")
+	testutil.AssertNotEqual(t, a.ID, b.ID, "same port on different hosts must not collide")
+	testutil.AssertEqual(t, upper.Value, "api.example.com:8443", "host normalized")
+	testutil.AssertTrue(t, a.IsValid(), "port artifact should be valid")
+
+	meta, ok := a.TypedMetadata.(*metadata.ServiceMetadata)
+	testutil.AssertTrue(t, ok, "port artifact carries ServiceMetadata")
+	testutil.AssertEqual(t, meta.ParentIP, "93.184.216.34", "parent IP set for IP hosts")
+	testutil.AssertEqual(t, meta.Port, 443, "port number in metadata")
+
+	host, port, err := SplitPortValue("[2001:db8::1]:8080")
+	testutil.AssertNoError(t, err, "split ipv6 port value")
+	testutil.AssertEqual(t, host, "2001:db8::1", "ipv6 host")
+	testutil.AssertEqual(t, port, 8080, "ipv6 port")
+}
+
+func TestArtifact_NormalizeIDN(t *testing.T) {
+	a := NewArtifact(ArtifactTypeSubdomain, "Shop.Bücher.example", "crtsh")
+	testutil.AssertEqual(t, a.Value, "shop.xn--bcher-kva.example", "value stored as punycode")
+	testutil.AssertEqual(t, a.UnicodeValue, "shop.bücher.example", "unicode form kept")
+	testutil.AssertTrue(t, a.IsValid(), "IDN artifact should be valid")
+
+	b := NewArtifact(ArtifactTypeSubdomain, "shop.xn--bcher-kva.example", "rdap")
+	testutil.AssertEqual(t, a.Key(), b.Key(), "unicode and punycode inputs dedupe")
+	testutil.AssertEqual(t, b.UnicodeValue, "shop.bücher.example", "unicode derived from punycode")
+}
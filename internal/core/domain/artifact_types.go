@@ -24,7 +24,7 @@ const (
 	// ArtifactTypeASN representa un Autonomous System Number
 	ArtifactTypeASN ArtifactType = "asn"
 
-	// ArtifactTypePort representa un puerto abierto
+	// ArtifactTypePort representa un puerto abierto en un host ("host:port")
 	ArtifactTypePort ArtifactType = "port"
 
 	// ArtifactTypeService representa un servicio de red en un puerto (Nmap/Masscan)
@@ -38,6 +38,9 @@ const (
 
 	// ArtifactTypeMXRecord representa un Mail Exchange record
 	ArtifactTypeMXRecord ArtifactType = "mx_record"
+
+	// ArtifactTypeTXTRecord representa un registro TXT (SPF, DMARC, verificaciones)
+	ArtifactTypeTXTRecord ArtifactType = "txt_record"
 )
 
 // Tipos de artefactos - Aplicaciones Web
@@ -114,6 +117,9 @@ const (
 	// ArtifactTypeCredential representa credenciales expuestas
 	ArtifactTypeCredential ArtifactType = "credential"
 
+	// ArtifactTypeSecret representa un secreto expuesto (API key, token); el valor es "<kind>:<sha256>"
+	ArtifactTypeSecret ArtifactType = "secret"
+
 	// ArtifactTypeSensitiveFile representa archivos sensibles
 	ArtifactTypeSensitiveFile ArtifactType = "sensitive_file"
 
@@ -150,12 +156,12 @@ func (t ArtifactType) IsValid() bool {
 	switch t {
 	case ArtifactTypeDomain, ArtifactTypeSubdomain, ArtifactTypeIP, ArtifactTypeIPv6,
 		ArtifactTypeCIDR, ArtifactTypeASN, ArtifactTypePort, ArtifactTypeService, ArtifactTypeDNSRecord,
-		ArtifactTypeNameserver, ArtifactTypeMXRecord, ArtifactTypeURL, ArtifactTypeEndpoint, ArtifactTypeAPI,
+		ArtifactTypeNameserver, ArtifactTypeMXRecord, ArtifactTypeTXTRecord, ArtifactTypeURL, ArtifactTypeEndpoint, ArtifactTypeAPI,
 		ArtifactTypeTechnology, ArtifactTypeHTTPHeader, ArtifactTypeCookie, ArtifactTypeForm,
 		ArtifactTypeParameter, ArtifactTypeJavaScript, ArtifactTypeRedirect, ArtifactTypeWAF,
 		ArtifactTypeCertificate, ArtifactTypeVulnerability, ArtifactTypeSecurityHeader, ArtifactTypeTLSConfig,
 		ArtifactTypeSSHKey, ArtifactTypeCloudResource, ArtifactTypeCDNEndpoint, ArtifactTypeContainer,
-		ArtifactTypeStorageBucket, ArtifactTypeCredential, ArtifactTypeSensitiveFile, ArtifactTypeBackupFile,
+		ArtifactTypeStorageBucket, ArtifactTypeCredential, ArtifactTypeSecret, ArtifactTypeSensitiveFile, ArtifactTypeBackupFile,
 		ArtifactTypeRepository, ArtifactTypeWebshell, ArtifactTypeMetadata, ArtifactTypeEmail, ArtifactTypePhone,
 		ArtifactTypeSocialMedia, ArtifactTypeWhoisContact:
 		return true
@@ -169,7 +175,7 @@ func (t ArtifactType) Category() string {
 	switch t {
 	case ArtifactTypeDomain, ArtifactTypeSubdomain, ArtifactTypeIP, ArtifactTypeIPv6,
 		ArtifactTypeCIDR, ArtifactTypeASN, ArtifactTypePort, ArtifactTypeService, ArtifactTypeDNSRecord,
-		ArtifactTypeNameserver, ArtifactTypeMXRecord:
+		ArtifactTypeNameserver, ArtifactTypeMXRecord, ArtifactTypeTXTRecord:
 		return "infrastructure"
 
 	case ArtifactTypeURL, ArtifactTypeEndpoint, ArtifactTypeAPI, ArtifactTypeTechnology, ArtifactTypeHTTPHeader,
@@ -184,7 +190,7 @@ func (t ArtifactType) Category() string {
 	case ArtifactTypeCloudResource, ArtifactTypeCDNEndpoint, ArtifactTypeContainer, ArtifactTypeStorageBucket:
 		return "cloud"
 
-	case ArtifactTypeCredential, ArtifactTypeSensitiveFile, ArtifactTypeBackupFile, ArtifactTypeRepository,
+	case ArtifactTypeCredential, ArtifactTypeSecret, ArtifactTypeSensitiveFile, ArtifactTypeBackupFile, ArtifactTypeRepository,
 		ArtifactTypeWebshell, ArtifactTypeMetadata:
 		return "data"
 
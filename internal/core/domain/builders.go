@@ -2,7 +2,15 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+
 	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/platform/validator"
 )
 
 // NewTechnologyArtifact crea un artifact de tecnología con metadata tipado.
@@ -42,17 +50,41 @@ func NewSubdomainArtifact(subdomain, source string) *Artifact {
 }
 
 // NewIPArtifact crea un artifact de IP con metadata tipado.
+// El tipo (ip/ipv6) y IPVersion se derivan de la familia de la dirección.
 func NewIPArtifact(ip, source string) *Artifact {
 	meta := metadata.NewIPMetadata()
+	meta.IPVersion = IPVersion(ip)
 
 	return NewArtifactWithMetadata(
-		ArtifactTypeIP,
+		IPArtifactType(ip),
 		ip,
 		source,
 		meta,
 	)
 }
 
+// IPArtifactType retorna ArtifactTypeIPv6 para direcciones v6 y ArtifactTypeIP
+// en cualquier otro caso.
+func IPArtifactType(ip string) ArtifactType {
+	if validator.IsIPv6(strings.TrimSpace(ip)) {
+		return ArtifactTypeIPv6
+	}
+	return ArtifactTypeIP
+}
+
+// IPVersion retorna "4" o "6" según la familia de ip ("" si no es una IP).
+func IPVersion(ip string) string {
+	ip = strings.TrimSpace(ip)
+	switch {
+	case validator.IsIPv4(ip):
+		return "4"
+	case validator.IsIPv6(ip):
+		return "6"
+	default:
+		return ""
+	}
+}
+
 // SetTechnologyMetadata establece metadata de tecnología en un artifact existente.
 func (a *Artifact) SetTechnologyMetadata(meta *metadata.TechnologyMetadata) {
 	a.TypedMetadata = meta
@@ -104,6 +136,119 @@ func NewServiceArtifact(name string, port int, source string) *Artifact {
 	)
 }
 
+// NewPortArtifact crea un artifact de puerto "host:port" con ServiceMetadata.
+// service es el nombre del servicio detectado ("http", "ssh"...), "" = desconocido.
+func NewPortArtifact(host string, port int, service, source string) *Artifact {
+	if service == "" {
+		service = "unknown"
+	}
+	meta := metadata.NewServiceMetadata(service, port)
+	if validator.IsIP(host) {
+		meta.ParentIP = validator.NormalizeIP(host)
+	}
+
+	return NewArtifactWithMetadata(
+		ArtifactTypePort,
+		PortValue(host, port),
+		source,
+		meta,
+	)
+}
+
+// NewASNArtifact crea un artifact de ASN ("AS<number>") con metadata tipado.
+func NewASNArtifact(number int, org, source string) *Artifact {
+	meta := metadata.NewASNMetadata(number)
+	meta.Org = org
+
+	return NewArtifactWithMetadata(
+		ArtifactTypeASN,
+		fmt.Sprintf("AS%d", number),
+		source,
+		meta,
+	)
+}
+
+// NewCIDRArtifact crea un artifact de rango de red con metadata tipado.
+func NewCIDRArtifact(cidr, source string) *Artifact {
+	a := NewArtifact(ArtifactTypeCIDR, cidr, source)
+	meta := metadata.NewCIDRMetadata(a.Value)
+	if _, ipnet, err := net.ParseCIDR(a.Value); err == nil {
+		meta.IPVersion = 4
+		if ipnet.IP.To4() == nil {
+			meta.IPVersion = 6
+		}
+		ones, bits := ipnet.Mask.Size()
+		meta.Size = new(big.Int).Lsh(big.NewInt(1), uint(bits-ones)).String()
+	}
+	a.TypedMetadata = meta
+	return a
+}
+
+// NewCloudResourceArtifact crea un artifact de recurso cloud ("<provider>:<id>").
+func NewCloudResourceArtifact(provider, resourceID, source string) *Artifact {
+	meta := metadata.NewCloudResourceMetadata(provider)
+	meta.ResourceID = resourceID
+
+	return NewArtifactWithMetadata(
+		ArtifactTypeCloudResource,
+		provider+":"+resourceID,
+		source,
+		meta,
+	)
+}
+
+// NewSecretArtifact crea un artifact de secreto expuesto. El valor del artifact
+// es "<kind>:<sha256>" y el metadata solo guarda un preview enmascarado.
+func NewSecretArtifact(kind, secret, location, source string) *Artifact {
+	sum := sha256.Sum256([]byte(secret))
+	fingerprint := hex.EncodeToString(sum[:])
+
+	meta := metadata.NewSecretMetadata(kind, fingerprint)
+	meta.Redacted = RedactSecret(secret)
+	meta.Location = location
+
+	return NewArtifactWithMetadata(
+		ArtifactTypeSecret,
+		kind+":"+fingerprint,
+		source,
+		meta,
+	)
+}
+
+// RedactSecret enmascara un secreto dejando visibles como mucho 4 caracteres por extremo.
+func RedactSecret(secret string) string {
+	visible := len(secret) / 4
+	if visible > 4 {
+		visible = 4
+	}
+	if visible == 0 {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:visible] + strings.Repeat("*", len(secret)-2*visible) + secret[len(secret)-visible:]
+}
+
+// NewVulnerabilityArtifact crea un artifact de vulnerabilidad con metadata tipado.
+// id es un CVE o, si no existe, el ID del check/plantilla.
+func NewVulnerabilityArtifact(id, severity, source string) *Artifact {
+	meta := metadata.NewVulnerabilityMetadata("")
+	if validator.IsCVE(id) {
+		meta.CVE = strings.ToUpper(id)
+	} else {
+		meta.TemplateID = id
+	}
+	if severity != "" {
+		meta.Severity = severity
+	}
+	meta.DiscoveryTool = source
+
+	return NewArtifactWithMetadata(
+		ArtifactTypeVulnerability,
+		id,
+		source,
+		meta,
+	)
+}
+
 // NewWAFArtifact crea un artifact de WAF con metadata tipado.
 func NewWAFArtifact(name, source string) *Artifact {
 	meta := metadata.NewWAFMetadata(name)
@@ -176,6 +321,38 @@ func NewWebshellArtifact(name, shellType, source string) *Artifact {
 	)
 }
 
+// NewMXRecordArtifact crea un artifact de intercambiador de correo de domain
+// con metadata tipado.
+func NewMXRecordArtifact(domain, host string, priority int, source string) *Artifact {
+	a := NewArtifact(ArtifactTypeMXRecord, host, source)
+	a.TypedMetadata = &metadata.MailInfraMetadata{
+		Kind:     metadata.MailKindMX,
+		Domain:   domain,
+		Host:     a.Value,
+		Priority: priority,
+	}
+	return a
+}
+
+// NewTXTRecordArtifact crea un artifact de registro TXT publicado en name.
+// kind es uno de metadata.MailKind* (SPF, DMARC, verificación u otro TXT).
+func NewTXTRecordArtifact(name, record, kind, source string) *Artifact {
+	return NewArtifactWithMetadata(
+		ArtifactTypeTXTRecord,
+		record,
+		source,
+		&metadata.MailInfraMetadata{Kind: kind, Domain: name, Record: record},
+	)
+}
+
+// GetMailInfraMetadata retorna el metadata de correo si existe.
+func (a *Artifact) GetMailInfraMetadata() *metadata.MailInfraMetadata {
+	if meta, ok := a.TypedMetadata.(*metadata.MailInfraMetadata); ok {
+		return meta
+	}
+	return nil
+}
+
 // GetServiceMetadata retorna el metadata de servicio si existe.
 func (a *Artifact) GetServiceMetadata() *metadata.ServiceMetadata {
 	if meta, ok := a.TypedMetadata.(*metadata.ServiceMetadata); ok {
@@ -0,0 +1,131 @@
+// internal/core/domain/cert_expiry.go
+package domain
+
+import (
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"aethonx/internal/core/domain/metadata"
+)
+
+// CertExpiry es el certificado vigente de un host y los días que le quedan.
+type CertExpiry struct {
+	Host          string    `json:"host"`
+	Serial        string    `json:"serial"`
+	SubjectCN     string    `json:"subject_cn,omitempty"`
+	Issuer        string    `json:"issuer,omitempty"`
+	ValidUntil    time.Time `json:"valid_until"`
+	DaysRemaining int       `json:"days_remaining"` // Negativo si ya caducó
+}
+
+// Expired indica si el certificado ya caducó.
+func (e CertExpiry) Expired() bool { return e.DaysRemaining < 0 }
+
+// CertExpiries agrega los certificados del resultado por host y calcula los
+// días hasta su caducidad respecto a at. El host es el del artifact que lo
+// usa (relación uses_cert) o, si ninguno lo usa, su CN. De cada host se
+// conserva el certificado que caduca más tarde (el vigente; crt.sh también
+// trae los históricos). Ordenados de menos a más días restantes.
+func CertExpiries(result *ScanResult, at time.Time) []CertExpiry {
+	certs := make(map[string]CertExpiry) // ID del certificado -> datos sin host
+	for _, a := range result.Artifacts {
+		if a == nil || a.Type != ArtifactTypeCertificate {
+			continue
+		}
+		cert, ok := a.TypedMetadata.(*metadata.CertificateMetadata)
+		if !ok {
+			continue
+		}
+		until, ok := certValidUntil(cert)
+		if !ok {
+			continue
+		}
+		certs[a.ID] = CertExpiry{
+			Host:          strings.ToLower(cert.SubjectCN),
+			Serial:        a.Value,
+			SubjectCN:     cert.SubjectCN,
+			Issuer:        firstNonEmpty(cert.IssuerO, cert.IssuerCN),
+			ValidUntil:    until,
+			DaysRemaining: int(math.Floor(until.Sub(at).Hours() / 24)),
+		}
+	}
+	if len(certs) == 0 {
+		return nil
+	}
+
+	byHost := make(map[string]CertExpiry)
+	keep := func(e CertExpiry) {
+		if e.Host == "" {
+			return
+		}
+		if prev, ok := byHost[e.Host]; !ok || e.ValidUntil.After(prev.ValidUntil) {
+			byHost[e.Host] = e
+		}
+	}
+	used := make(map[string]bool)
+	for _, a := range result.Artifacts {
+		if a == nil {
+			continue
+		}
+		for _, rel := range a.Relations {
+			cert, ok := certs[rel.TargetID]
+			if !ok || rel.Type != RelationUsesCert {
+				continue
+			}
+			used[rel.TargetID] = true
+			cert.Host = artifactHost(a)
+			keep(cert)
+		}
+	}
+	for id, cert := range certs {
+		if !used[id] {
+			keep(cert)
+		}
+	}
+
+	out := make([]CertExpiry, 0, len(byHost))
+	for _, e := range byHost {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].DaysRemaining != out[j].DaysRemaining {
+			return out[i].DaysRemaining < out[j].DaysRemaining
+		}
+		return out[i].Host < out[j].Host
+	})
+	return out
+}
+
+// ExpiringCerts filtra las caducidades que vencen antes de window (incluye
+// las ya caducadas).
+func ExpiringCerts(expiries []CertExpiry, at time.Time, window time.Duration) []CertExpiry {
+	var out []CertExpiry
+	for _, e := range expiries {
+		if e.ValidUntil.Before(at.Add(window)) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// artifactHost retorna el host de un artifact (el de la URL o el propio valor).
+func artifactHost(a *Artifact) string {
+	if a.Type == ArtifactTypeURL {
+		if u, err := url.Parse(a.Value); err == nil && u.Hostname() != "" {
+			return strings.ToLower(u.Hostname())
+		}
+	}
+	return strings.ToLower(a.Value)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+// internal/core/domain/cert_expiry_test.go
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/testutil"
+)
+
+func certArtifact(serial, cn string, until time.Time) *Artifact {
+	return NewArtifactWithMetadata(ArtifactTypeCertificate, serial, "crtsh", &metadata.CertificateMetadata{
+		SerialNumber: serial,
+		SubjectCN:    cn,
+		IssuerO:      "Let's Encrypt",
+		ValidUntil:   until.Format(time.RFC3339),
+	})
+}
+
+func TestCertExpiries(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	result := NewScanResult(Target{Root: "example.com"})
+
+	old := certArtifact("0a01", "api.example.com", now.Add(-90*24*time.Hour))
+	current := certArtifact("0a02", "api.example.com", now.Add(10*24*time.Hour+time.Hour))
+	www := certArtifact("0b01", "www.example.com", now.Add(200*24*time.Hour))
+	expired := certArtifact("0c01", "legacy.example.com", now.Add(-36*time.Hour))
+
+	api := NewArtifact(ArtifactTypeSubdomain, "api.example.com", "crtsh")
+	api.AddRelation(old.ID, RelationUsesCert, 1.0, "crtsh")
+	api.AddRelation(current.ID, RelationUsesCert, 1.0, "crtsh")
+	site := NewArtifact(ArtifactTypeURL, "https://www.example.com/login", "tlsgrade")
+	site.AddRelation(www.ID, RelationUsesCert, 1.0, "tlsgrade")
+	result.AddArtifacts(old, current, www, expired, api, site)
+
+	expiries := CertExpiries(result, now)
+	testutil.AssertEqual(t, len(expiries), 3, "one certificate per host")
+	testutil.AssertEqual(t, expiries[0].Host, "legacy.example.com", "unreferenced cert keyed by CN, expired first")
+	testutil.AssertEqual(t, expiries[0].DaysRemaining, -2, "days since expiry")
+	testutil.AssertTrue(t, expiries[0].Expired(), "expired")
+	testutil.AssertEqual(t, expiries[1].Host, "api.example.com", "host from relation")
+	testutil.AssertEqual(t, expiries[1].Serial, "0a02", "newest certificate of the host kept")
+	testutil.AssertEqual(t, expiries[1].DaysRemaining, 10, "days remaining")
+	testutil.AssertEqual(t, expiries[1].Issuer, "Let's Encrypt", "issuer")
+	testutil.AssertEqual(t, expiries[2].Host, "www.example.com", "URL hostname")
+
+	expiring := ExpiringCerts(expiries, now, CertExpiryWindow)
+	testutil.AssertEqual(t, len(expiring), 2, "expired and within 30 days")
+}
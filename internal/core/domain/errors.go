@@ -23,6 +23,7 @@ var (
 	ErrSourceInitFailed    = errors.New("source initialization failed")
 	ErrSourceExecutionFailed = errors.New("source execution failed")
 	ErrSourceTimeout       = errors.New("source execution timeout")
+	ErrCriticalSourceFailed = errors.New("critical source failed")
 
 	// Scan errors
 	ErrScanFailed        = errors.New("scan failed")
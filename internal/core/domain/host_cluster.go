@@ -0,0 +1,151 @@
+// internal/core/domain/host_cluster.go
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"aethonx/internal/core/domain/metadata"
+)
+
+// ClusterMinHosts es el mínimo de hosts distintos para que un grupo de
+// páginas iguales se considere un cluster.
+const ClusterMinHosts = 3
+
+// HostCluster agrupa los hosts vivos que sirven la misma página: mismo
+// título, mismo favicon y las mismas tecnologías (p.ej. la página por defecto
+// de IIS o el login de un appliance repetido en decenas de hosts).
+type HostCluster struct {
+	Title        string   `json:"title,omitempty"`
+	FaviconMMH3  string   `json:"favicon_mmh3,omitempty"`
+	Technologies []string `json:"technologies,omitempty"`
+	Hosts        []string `json:"hosts"`
+	URLs         int      `json:"urls"`
+}
+
+// Label describe la página del cluster: el título o, sin título, el favicon
+// y las tecnologías.
+func (c HostCluster) Label() string {
+	if c.Title != "" {
+		return fmt.Sprintf("%q", c.Title)
+	}
+	var parts []string
+	if c.FaviconMMH3 != "" {
+		parts = append(parts, "favicon "+c.FaviconMMH3)
+	}
+	if len(c.Technologies) > 0 {
+		parts = append(parts, strings.Join(c.Technologies, ", "))
+	}
+	return strings.Join(parts, " / ")
+}
+
+// Summary resume el cluster en una línea ("37 hosts serving "IIS Windows Server"").
+func (c HostCluster) Summary() string {
+	return fmt.Sprintf("%d hosts serving %s", len(c.Hosts), c.Label())
+}
+
+// ClusterHosts agrupa las URLs vivas del resultado por título, hash del
+// favicon y tecnologías detectadas, y retorna los grupos con al menos
+// minHosts hosts distintos, de mayor a menor. Las URLs sin ninguna de las
+// tres señales no se agrupan.
+func ClusterHosts(result *ScanResult, minHosts int) []HostCluster {
+	if minHosts < 2 {
+		minHosts = 2
+	}
+
+	// httpx relaciona la tecnología con la URL; se aceptan ambos sentidos
+	techByURL := make(map[string][]string) // ID de la URL -> tecnologías
+	techName := make(map[string]string)    // ID de la tecnología -> nombre
+	for _, a := range result.Artifacts {
+		if a == nil || a.Type != ArtifactTypeTechnology {
+			continue
+		}
+		techName[a.ID] = a.Value
+		for _, rel := range a.Relations {
+			if rel.Type == RelationUsesTech {
+				techByURL[rel.TargetID] = append(techByURL[rel.TargetID], a.Value)
+			}
+		}
+	}
+
+	type group struct {
+		cluster HostCluster
+		hosts   map[string]bool
+	}
+	groups := make(map[string]*group)
+	for _, a := range result.Artifacts {
+		if a == nil || a.Type != ArtifactTypeURL || !a.IsAlive() {
+			continue
+		}
+		meta, _ := a.TypedMetadata.(*metadata.ServiceMetadata)
+		var title, favicon string
+		if meta != nil {
+			title, favicon = strings.Join(strings.Fields(meta.Title), " "), meta.FaviconMMH3
+		}
+		techs := append([]string(nil), techByURL[a.ID]...)
+		for _, rel := range a.Relations {
+			if rel.Type == RelationUsesTech {
+				techs = append(techs, techName[rel.TargetID])
+			}
+		}
+		techs = uniqueSorted(techs)
+		if title == "" && favicon == "" && len(techs) == 0 {
+			continue
+		}
+
+		u, err := url.Parse(a.Value)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+
+		key := strings.ToLower(title) + "\x00" + favicon + "\x00" + strings.Join(techs, ",")
+		g, ok := groups[key]
+		if !ok {
+			g = &group{
+				cluster: HostCluster{Title: title, FaviconMMH3: favicon, Technologies: techs},
+				hosts:   make(map[string]bool),
+			}
+			groups[key] = g
+		}
+		g.cluster.URLs++
+		g.hosts[strings.ToLower(u.Hostname())] = true
+	}
+
+	var out []HostCluster
+	for _, g := range groups {
+		if len(g.hosts) < minHosts {
+			continue
+		}
+		for host := range g.hosts {
+			g.cluster.Hosts = append(g.cluster.Hosts, host)
+		}
+		sort.Strings(g.cluster.Hosts)
+		out = append(out, g.cluster)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if len(out[i].Hosts) != len(out[j].Hosts) {
+			return len(out[i].Hosts) > len(out[j].Hosts)
+		}
+		return out[i].Label() < out[j].Label()
+	})
+	return out
+}
+
+// uniqueSorted retorna values sin duplicados ni vacíos, ordenados.
+func uniqueSorted(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	seen := make(map[string]bool, len(values))
+	var out []string
+	for _, v := range values {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
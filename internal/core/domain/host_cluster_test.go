@@ -0,0 +1,59 @@
+// internal/core/domain/host_cluster_test.go
+package domain
+
+import (
+	"fmt"
+	"testing"
+
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/testutil"
+)
+
+func aliveURL(value, title, favicon string) *Artifact {
+	a := NewArtifactWithMetadata(ArtifactTypeURL, value, "httpx", &metadata.ServiceMetadata{
+		Name: "http", Port: 443, Title: title, FaviconMMH3: favicon,
+	})
+	a.AddTag("alive")
+	return a
+}
+
+func TestClusterHosts(t *testing.T) {
+	result := NewScanResult(Target{Root: "example.com"})
+
+	iis := NewArtifact(ArtifactTypeTechnology, "IIS", "httpx")
+	for i := 0; i < 4; i++ {
+		u := aliveURL(fmt.Sprintf("https://web%d.example.com", i), "IIS  Windows Server", "-1234")
+		iis.AddRelation(u.ID, RelationUsesTech, 0.9, "httpx")
+		result.AddArtifact(u)
+	}
+	// Misma página en http y https del mismo host: un solo host
+	dup := aliveURL("http://web0.example.com", "IIS Windows Server", "-1234")
+	iis.AddRelation(dup.ID, RelationUsesTech, 0.9, "httpx")
+	result.AddArtifacts(dup, iis)
+
+	// Solo favicon: agrupa sin título
+	for i := 0; i < 3; i++ {
+		result.AddArtifact(aliveURL(fmt.Sprintf("https://vpn%d.example.com", i), "", "81586312"))
+	}
+	// Por debajo del mínimo, sin señales o no vivas
+	result.AddArtifacts(
+		aliveURL("https://a.example.com", "Login", ""),
+		aliveURL("https://b.example.com", "Login", ""),
+		aliveURL("https://c.example.com", "", ""),
+		aliveURL("https://d.example.com", "", ""),
+		aliveURL("https://e.example.com", "", ""),
+		NewArtifactWithMetadata(ArtifactTypeURL, "https://f.example.com", "httpx", &metadata.ServiceMetadata{Name: "http", Port: 443, Title: "Login"}),
+	)
+
+	clusters := ClusterHosts(result, ClusterMinHosts)
+	testutil.AssertEqual(t, len(clusters), 2, "IIS and favicon clusters")
+
+	testutil.AssertEqual(t, len(clusters[0].Hosts), 4, "distinct hosts")
+	testutil.AssertEqual(t, clusters[0].URLs, 5, "URLs")
+	testutil.AssertEqual(t, clusters[0].Title, "IIS Windows Server", "whitespace collapsed")
+	testutil.AssertEqual(t, clusters[0].Technologies[0], "IIS", "tech stack")
+	testutil.AssertEqual(t, clusters[0].Summary(), `4 hosts serving "IIS Windows Server"`, "summary")
+
+	testutil.AssertEqual(t, clusters[1].Label(), "favicon 81586312", "label without title")
+	testutil.AssertEqual(t, len(ClusterHosts(result, 2)), 3, "lower minimum includes the Login pair")
+}
@@ -0,0 +1,78 @@
+// internal/core/domain/lifecycle.go
+package domain
+
+import "time"
+
+// LifecycleStatus describe el estado de un artifact a lo largo de varios escaneos.
+type LifecycleStatus string
+
+const (
+	LifecycleNew     LifecycleStatus = "new"     // Visto por primera vez en el último escaneo
+	LifecycleActive  LifecycleStatus = "active"  // Visto en el último escaneo
+	LifecycleStale   LifecycleStatus = "stale"   // Ausente en N escaneos consecutivos
+	LifecycleRemoved LifecycleStatus = "removed" // Ausente el tiempo suficiente para darlo por retirado
+)
+
+// ArtifactLifecycle registra first_seen/last_seen de un artifact entre escaneos.
+// DiscoveredAt solo refleja el escaneo actual; esto refleja la historia.
+type ArtifactLifecycle struct {
+	Key         string          `json:"key"`
+	Type        ArtifactType    `json:"type"`
+	Value       string          `json:"value"`
+	FirstSeen   time.Time       `json:"first_seen"`
+	LastSeen    time.Time       `json:"last_seen"`
+	MissedScans int             `json:"missed_scans"` // Escaneos consecutivos sin verlo
+	Status      LifecycleStatus `json:"status"`
+}
+
+// LifecycleState es el estado persistido de un target entre escaneos.
+type LifecycleState struct {
+	Target    string                        `json:"target"`
+	ScanCount int                           `json:"scan_count"`
+	LastScan  time.Time                     `json:"last_scan"`
+	Artifacts map[string]*ArtifactLifecycle `json:"artifacts"`
+	Trends    []TrendPoint                  `json:"trends,omitempty"` // métricas por escaneo, de más antiguo a más reciente
+
+	// Certificates son los avisos de caducidad ya enviados, por host
+	Certificates map[string]*CertWatch `json:"certificates,omitempty"`
+
+	// Pages es la última instantánea de cada URL sondeada, por URL
+	Pages map[string]*PageSnapshot `json:"pages,omitempty"`
+}
+
+// CertWatch es el último aviso de caducidad enviado para el certificado de
+// un host; se descarta cuando el host presenta otro certificado (renovado).
+type CertWatch struct {
+	Serial     string    `json:"serial"`
+	ValidUntil time.Time `json:"valid_until"`
+	Threshold  int       `json:"threshold"` // Umbral en días del último aviso (-1 = caducado)
+}
+
+// NewLifecycleState crea un estado vacío para target.
+func NewLifecycleState(target string) *LifecycleState {
+	return &LifecycleState{
+		Target:    target,
+		Artifacts: make(map[string]*ArtifactLifecycle),
+	}
+}
+
+// LifecycleReport resume los cambios de estado producidos por un escaneo.
+// Es la entrada para diffs y notificaciones en modo monitor.
+type LifecycleReport struct {
+	New        []ArtifactLifecycle `json:"new,omitempty"`
+	Reappeared []ArtifactLifecycle `json:"reappeared,omitempty"`
+	Stale      []ArtifactLifecycle `json:"stale,omitempty"`
+	Removed    []ArtifactLifecycle `json:"removed,omitempty"`
+
+	// CertAlerts son los certificados que han cruzado un umbral de caducidad
+	CertAlerts []CertExpiry `json:"cert_alerts,omitempty"`
+
+	// PageChanges son las URLs cuyo contenido, título, estado, tecnologías o
+	// cabeceras cambiaron respecto al último escaneo que las sondeó
+	PageChanges []PageChange `json:"page_changes,omitempty"`
+}
+
+// HasChanges indica si el escaneo produjo algún cambio de estado.
+func (r *LifecycleReport) HasChanges() bool {
+	return r != nil && len(r.New)+len(r.Reappeared)+len(r.Stale)+len(r.Removed) > 0
+}
@@ -139,6 +139,7 @@ func (a *APIMetadata) FromMap(m map[string]string) error {
 
 func (a *APIMetadata) IsValid() bool { return a.BaseURL != "" || a.APIType != "" }
 func (a *APIMetadata) Type() string  { return "api" }
+func (a *APIMetadata) Merge(other ArtifactMetadata) error { return mergeSame(a, other) }
 
 // NewAPIMetadata crea una instancia de APIMetadata con valores por defecto.
 func NewAPIMetadata(apiType, baseURL string) *APIMetadata {
@@ -0,0 +1,52 @@
+// internal/core/domain/metadata/asn.go
+package metadata
+
+// ASNMetadata contiene información sobre un Autonomous System.
+type ASNMetadata struct {
+	Number      int    // 15133
+	Name        string // "EDGECAST"
+	Org         string // "Edgecast Inc."
+	Country     string // "US"
+	Registry    string // "arin", "ripencc", "apnic", "lacnic", "afrinic"
+	Allocated   string // Fecha de asignación (YYYY-MM-DD)
+	PrefixCount int    // Número de prefijos anunciados
+	Source      string // "bgpview", "ipinfo", "shodan"
+}
+
+func (a *ASNMetadata) ToMap() map[string]string {
+	m := make(map[string]string)
+	if a.Number > 0 {
+		SetInt(m, "number", a.Number)
+	}
+	SetIfNotEmpty(m, "name", a.Name)
+	SetIfNotEmpty(m, "org", a.Org)
+	SetIfNotEmpty(m, "country", a.Country)
+	SetIfNotEmpty(m, "registry", a.Registry)
+	SetIfNotEmpty(m, "allocated", a.Allocated)
+	if a.PrefixCount > 0 {
+		SetInt(m, "prefix_count", a.PrefixCount)
+	}
+	SetIfNotEmpty(m, "source", a.Source)
+	return m
+}
+
+func (a *ASNMetadata) FromMap(m map[string]string) error {
+	a.Number = GetInt(m, "number", 0)
+	a.Name = GetString(m, "name", "")
+	a.Org = GetString(m, "org", "")
+	a.Country = GetString(m, "country", "")
+	a.Registry = GetString(m, "registry", "")
+	a.Allocated = GetString(m, "allocated", "")
+	a.PrefixCount = GetInt(m, "prefix_count", 0)
+	a.Source = GetString(m, "source", "")
+	return nil
+}
+
+func (a *ASNMetadata) IsValid() bool                      { return a.Number > 0 }
+func (a *ASNMetadata) Type() string                       { return "asn" }
+func (a *ASNMetadata) Merge(other ArtifactMetadata) error { return mergeSame(a, other) }
+
+// NewASNMetadata crea una instancia de ASNMetadata.
+func NewASNMetadata(number int) *ASNMetadata {
+	return &ASNMetadata{Number: number}
+}
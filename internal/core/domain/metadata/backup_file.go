@@ -130,6 +130,7 @@ func (b *BackupFileMetadata) FromMap(m map[string]string) error {
 
 func (b *BackupFileMetadata) IsValid() bool { return b.Filename != "" }
 func (b *BackupFileMetadata) Type() string  { return "backup_file" }
+func (b *BackupFileMetadata) Merge(other ArtifactMetadata) error { return mergeSame(b, other) }
 
 // NewBackupFileMetadata crea una instancia de BackupFileMetadata con valores por defecto.
 func NewBackupFileMetadata(filename string) *BackupFileMetadata {
@@ -52,6 +52,7 @@ type CertificateMetadata struct {
 	WeakKey       bool
 	Revoked       bool
 	RevocationReason string
+	ChainIssues   []string // "expired", "self-signed", "untrusted", "hostname-mismatch", ...
 }
 
 func (c *CertificateMetadata) ToMap() map[string]string {
@@ -86,6 +87,9 @@ func (c *CertificateMetadata) ToMap() map[string]string {
 	SetBool(m, "weak_signature", c.WeakSignature)
 	SetBool(m, "weak_key", c.WeakKey)
 	SetBool(m, "revoked", c.Revoked)
+	if len(c.ChainIssues) > 0 {
+		m["chain_issues"] = StringSliceToCSV(c.ChainIssues)
+	}
 	return m
 }
 
@@ -103,8 +107,10 @@ func (c *CertificateMetadata) FromMap(m map[string]string) error {
 	c.IsSelfSigned = GetBool(m, "is_self_signed", false)
 	c.SANDomains = CSVToStringSlice(GetString(m, "san_domains", ""))
 	c.WildcardCert = GetBool(m, "wildcard_cert", false)
+	c.ChainIssues = CSVToStringSlice(GetString(m, "chain_issues", ""))
 	return nil
 }
 
 func (c *CertificateMetadata) IsValid() bool { return c.SerialNumber != "" }
 func (c *CertificateMetadata) Type() string  { return "certificate" }
+func (c *CertificateMetadata) Merge(other ArtifactMetadata) error { return mergeSame(c, other) }
@@ -0,0 +1,53 @@
+// internal/core/domain/metadata/cidr.go
+package metadata
+
+// CIDRMetadata contiene información sobre un rango de red.
+type CIDRMetadata struct {
+	Network   string // "93.184.216.0/24"
+	IPVersion int    // 4 o 6
+	Size      string // Número de direcciones (string: IPv6 no cabe en int64)
+	ASN       string // "AS15133"
+	Org       string // Organización propietaria
+	NetName   string // Nombre del bloque (WHOIS/RDAP)
+	Country   string
+	Registry  string // "arin", "ripencc", ...
+	Source    string // "rdap", "bgpview", "asn_expansion"
+}
+
+func (c *CIDRMetadata) ToMap() map[string]string {
+	m := make(map[string]string)
+	SetIfNotEmpty(m, "network", c.Network)
+	if c.IPVersion > 0 {
+		SetInt(m, "ip_version", c.IPVersion)
+	}
+	SetIfNotEmpty(m, "size", c.Size)
+	SetIfNotEmpty(m, "asn", c.ASN)
+	SetIfNotEmpty(m, "org", c.Org)
+	SetIfNotEmpty(m, "net_name", c.NetName)
+	SetIfNotEmpty(m, "country", c.Country)
+	SetIfNotEmpty(m, "registry", c.Registry)
+	SetIfNotEmpty(m, "source", c.Source)
+	return m
+}
+
+func (c *CIDRMetadata) FromMap(m map[string]string) error {
+	c.Network = GetString(m, "network", "")
+	c.IPVersion = GetInt(m, "ip_version", 0)
+	c.Size = GetString(m, "size", "")
+	c.ASN = GetString(m, "asn", "")
+	c.Org = GetString(m, "org", "")
+	c.NetName = GetString(m, "net_name", "")
+	c.Country = GetString(m, "country", "")
+	c.Registry = GetString(m, "registry", "")
+	c.Source = GetString(m, "source", "")
+	return nil
+}
+
+func (c *CIDRMetadata) IsValid() bool                      { return c.Network != "" }
+func (c *CIDRMetadata) Type() string                       { return "cidr" }
+func (c *CIDRMetadata) Merge(other ArtifactMetadata) error { return mergeSame(c, other) }
+
+// NewCIDRMetadata crea una instancia de CIDRMetadata.
+func NewCIDRMetadata(network string) *CIDRMetadata {
+	return &CIDRMetadata{Network: network}
+}
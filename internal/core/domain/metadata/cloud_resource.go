@@ -0,0 +1,54 @@
+// internal/core/domain/metadata/cloud_resource.go
+package metadata
+
+// CloudResourceMetadata contiene información sobre un recurso cloud
+// (instancia, load balancer, función, distribución CDN...).
+type CloudResourceMetadata struct {
+	Provider     string   // aws, azure, gcp, digitalocean, etc.
+	Service      string   // ec2, elb, lambda, cloudfront, compute-engine, etc.
+	Region       string   // us-east-1, westeurope, etc.
+	ResourceID   string   // ARN, resource ID o hostname del recurso
+	AccountID    string   // Cuenta/proyecto/suscripción (si se conoce)
+	PublicIP     string   // IP pública asociada
+	PublicAccess bool     // Si el recurso es accesible públicamente
+	Tags         []string // Tags adicionales
+}
+
+func (c *CloudResourceMetadata) ToMap() map[string]string {
+	m := make(map[string]string)
+	SetIfNotEmpty(m, "provider", c.Provider)
+	SetIfNotEmpty(m, "service", c.Service)
+	SetIfNotEmpty(m, "region", c.Region)
+	SetIfNotEmpty(m, "resource_id", c.ResourceID)
+	SetIfNotEmpty(m, "account_id", c.AccountID)
+	SetIfNotEmpty(m, "public_ip", c.PublicIP)
+	SetBool(m, "public_access", c.PublicAccess)
+	if len(c.Tags) > 0 {
+		m["tags"] = StringSliceToCSV(c.Tags)
+	}
+	return m
+}
+
+func (c *CloudResourceMetadata) FromMap(m map[string]string) error {
+	c.Provider = GetString(m, "provider", "")
+	c.Service = GetString(m, "service", "")
+	c.Region = GetString(m, "region", "")
+	c.ResourceID = GetString(m, "resource_id", "")
+	c.AccountID = GetString(m, "account_id", "")
+	c.PublicIP = GetString(m, "public_ip", "")
+	c.PublicAccess = GetBool(m, "public_access", false)
+	c.Tags = CSVToStringSlice(GetString(m, "tags", ""))
+	return nil
+}
+
+func (c *CloudResourceMetadata) IsValid() bool                      { return c.Provider != "" }
+func (c *CloudResourceMetadata) Type() string                       { return "cloud_resource" }
+func (c *CloudResourceMetadata) Merge(other ArtifactMetadata) error { return mergeSame(c, other) }
+
+// NewCloudResourceMetadata crea una instancia de CloudResourceMetadata.
+func NewCloudResourceMetadata(provider string) *CloudResourceMetadata {
+	return &CloudResourceMetadata{
+		Provider: provider,
+		Tags:     []string{},
+	}
+}
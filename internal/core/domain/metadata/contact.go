@@ -77,6 +77,11 @@ func (c *ContactMetadata) Type() string {
 	return "contact"
 }
 
+// Merge combina campo a campo con otro ContactMetadata (ver MergeFields).
+func (c *ContactMetadata) Merge(other ArtifactMetadata) error {
+	return mergeSame(c, other)
+}
+
 // HasPrivateInfo verifica si contiene información privada no redactada
 func (c *ContactMetadata) HasPrivateInfo() bool {
 	return !c.Redacted && (c.Email != "" || c.Phone != "" || c.Name != "")
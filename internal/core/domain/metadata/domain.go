@@ -22,7 +22,18 @@ type DomainMetadata struct {
 
 	// Estado
 	Status   string // active, inactive, pending, etc.
-	DNSSEC   bool   // Si tiene DNSSEC habilitado
+	DNSSEC   bool   // Si la cadena DNSSEC del apex valida (DNSSECStatus "valid")
+
+	// DNSSECStatus es el resultado de validar la cadena del apex: valid,
+	// unsigned (sin DS ni DNSKEY), insecure (DNSKEY sin DS en el padre) o
+	// broken (DS sin clave que case, firmas inválidas o caducadas)
+	DNSSECStatus string
+
+	// Divulgación responsable (CAA y security.txt)
+	CAAIssuers         []string // CAs autorizadas (CAA issue/issuewild)
+	SecurityContacts   []string // Contact de security.txt (mailto:, https:, tel:)
+	SecurityPolicy     string   // Policy de security.txt
+	SecurityTxtExpires string   // Expires de security.txt
 
 	// Estado de actividad (probing)
 	IsAlive     bool   // Si el dominio responde a HTTP/HTTPS
@@ -86,6 +97,17 @@ func (d *DomainMetadata) ToMap() map[string]string {
 	// Estado
 	SetIfNotEmpty(m, "status", d.Status)
 	SetBool(m, "dnssec", d.DNSSEC)
+	SetIfNotEmpty(m, "dnssec_status", d.DNSSECStatus)
+
+	// Divulgación responsable
+	if len(d.CAAIssuers) > 0 {
+		m["caa_issuers"] = StringSliceToCSV(d.CAAIssuers)
+	}
+	if len(d.SecurityContacts) > 0 {
+		m["security_contacts"] = StringSliceToCSV(d.SecurityContacts)
+	}
+	SetIfNotEmpty(m, "security_policy", d.SecurityPolicy)
+	SetIfNotEmpty(m, "security_txt_expires", d.SecurityTxtExpires)
 
 	// Estado de actividad
 	SetBool(m, "is_alive", d.IsAlive)
@@ -147,6 +169,13 @@ func (d *DomainMetadata) FromMap(m map[string]string) error {
 	// Estado
 	d.Status = GetString(m, "status", "")
 	d.DNSSEC = GetBool(m, "dnssec", false)
+	d.DNSSECStatus = GetString(m, "dnssec_status", "")
+
+	// Divulgación responsable
+	d.CAAIssuers = CSVToStringSlice(GetString(m, "caa_issuers", ""))
+	d.SecurityContacts = CSVToStringSlice(GetString(m, "security_contacts", ""))
+	d.SecurityPolicy = GetString(m, "security_policy", "")
+	d.SecurityTxtExpires = GetString(m, "security_txt_expires", "")
 
 	// Estado de actividad
 	d.IsAlive = GetBool(m, "is_alive", false)
@@ -184,7 +213,8 @@ func (d *DomainMetadata) FromMap(m map[string]string) error {
 
 // IsValid verifica si el metadata tiene datos válidos mínimos.
 func (d *DomainMetadata) IsValid() bool {
-	return len(d.ResolvedIPs) > 0 || d.Registrar != "" || d.HTTPStatus > 0
+	return len(d.ResolvedIPs) > 0 || d.Registrar != "" || d.HTTPStatus > 0 || d.DNSSECStatus != "" ||
+		len(d.CAAIssuers) > 0 || len(d.SecurityContacts) > 0
 }
 
 // Type retorna el tipo de metadata.
@@ -192,6 +222,68 @@ func (d *DomainMetadata) Type() string {
 	return "domain"
 }
 
+// Merge combina campo a campo con otro DomainMetadata. El estado del probe
+// (alive/dead + datos HTTP) no se mezcla: se toma entero del probe más
+// reciente según LastProbed, para que un "alive" antiguo no pise un "dead"
+// posterior.
+func (d *DomainMetadata) Merge(other ArtifactMetadata) error {
+	o, ok := other.(*DomainMetadata)
+	if !ok {
+		return mergeSame(d, other)
+	}
+	if o == nil {
+		return nil
+	}
+
+	mine, theirs := d.probe(), o.probe()
+	rest := *o
+	rest.setProbe(domainProbe{})
+	if err := MergeFields(d, &rest); err != nil {
+		return err
+	}
+
+	if theirs.LastProbed > mine.LastProbed || mine == (domainProbe{}) {
+		d.setProbe(theirs)
+	}
+	return nil
+}
+
+// domainProbe agrupa los campos de un probe, que se sustituyen en bloque.
+type domainProbe struct {
+	IsAlive      bool
+	ProbeStatus  string
+	LastProbed   string
+	ProbeSource  string
+	HTTPStatus   int
+	HTTPRedirect string
+	HTTPTitle    string
+	HTTPServer   string
+}
+
+func (d *DomainMetadata) probe() domainProbe {
+	return domainProbe{
+		IsAlive:      d.IsAlive,
+		ProbeStatus:  d.ProbeStatus,
+		LastProbed:   d.LastProbed,
+		ProbeSource:  d.ProbeSource,
+		HTTPStatus:   d.HTTPStatus,
+		HTTPRedirect: d.HTTPRedirect,
+		HTTPTitle:    d.HTTPTitle,
+		HTTPServer:   d.HTTPServer,
+	}
+}
+
+func (d *DomainMetadata) setProbe(p domainProbe) {
+	d.IsAlive = p.IsAlive
+	d.ProbeStatus = p.ProbeStatus
+	d.LastProbed = p.LastProbed
+	d.ProbeSource = p.ProbeSource
+	d.HTTPStatus = p.HTTPStatus
+	d.HTTPRedirect = p.HTTPRedirect
+	d.HTTPTitle = p.HTTPTitle
+	d.HTTPServer = p.HTTPServer
+}
+
 // NewDomainMetadata crea un nuevo DomainMetadata vacío.
 func NewDomainMetadata() *DomainMetadata {
 	return &DomainMetadata{
@@ -160,6 +160,11 @@ func (i *IPMetadata) Type() string {
 	return "ip"
 }
 
+// Merge combina campo a campo con otro IPMetadata (ver MergeFields).
+func (i *IPMetadata) Merge(other ArtifactMetadata) error {
+	return mergeSame(i, other)
+}
+
 // NewIPMetadata crea un nuevo IPMetadata vacío.
 func NewIPMetadata() *IPMetadata {
 	return &IPMetadata{
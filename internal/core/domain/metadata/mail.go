@@ -0,0 +1,49 @@
+// internal/core/domain/metadata/mail.go
+package metadata
+
+// Tipos de registro de correo (MailInfraMetadata.Kind).
+const (
+	MailKindMX           = "mx"
+	MailKindSPF          = "spf"
+	MailKindDMARC        = "dmarc"
+	MailKindVerification = "verification" // Token de verificación de un proveedor
+	MailKindTXT          = "txt"          // Otro TXT del dominio
+)
+
+// MailInfraMetadata describe un registro MX o TXT de la infraestructura de
+// correo de un dominio.
+type MailInfraMetadata struct {
+	Kind     string // mx, spf, dmarc, verification, txt
+	Domain   string // Nombre consultado ("example.com", "_dmarc.example.com")
+	Host     string // Intercambiador de correo (solo MX)
+	Priority int    // Preferencia MX (menor = preferido)
+	Record   string // Texto del registro (solo TXT)
+	Provider string // "Google Workspace", "Microsoft 365", "Proton Mail", ...
+}
+
+func (m *MailInfraMetadata) ToMap() map[string]string {
+	out := make(map[string]string)
+	SetIfNotEmpty(out, "kind", m.Kind)
+	SetIfNotEmpty(out, "domain", m.Domain)
+	SetIfNotEmpty(out, "host", m.Host)
+	if m.Kind == MailKindMX {
+		SetInt(out, "priority", m.Priority)
+	}
+	SetIfNotEmpty(out, "record", m.Record)
+	SetIfNotEmpty(out, "provider", m.Provider)
+	return out
+}
+
+func (m *MailInfraMetadata) FromMap(in map[string]string) error {
+	m.Kind = GetString(in, "kind", "")
+	m.Domain = GetString(in, "domain", "")
+	m.Host = GetString(in, "host", "")
+	m.Priority = GetInt(in, "priority", 0)
+	m.Record = GetString(in, "record", "")
+	m.Provider = GetString(in, "provider", "")
+	return nil
+}
+
+func (m *MailInfraMetadata) IsValid() bool                      { return m.Kind != "" }
+func (m *MailInfraMetadata) Type() string                       { return "mail_infra" }
+func (m *MailInfraMetadata) Merge(other ArtifactMetadata) error { return mergeSame(m, other) }
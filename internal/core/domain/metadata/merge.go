@@ -0,0 +1,115 @@
+// internal/core/domain/metadata/merge.go
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrMetadataMismatch se retorna al intentar combinar metadata de tipos distintos.
+var ErrMetadataMismatch = errors.New("cannot merge metadata of different types")
+
+// mergeSame combina src en dst si ambos son del mismo tipo concreto.
+func mergeSame(dst, src ArtifactMetadata) error {
+	if src == nil || reflect.ValueOf(src).IsNil() {
+		return nil
+	}
+	if reflect.TypeOf(dst) != reflect.TypeOf(src) {
+		return fmt.Errorf("%w: %s <- %s", ErrMetadataMismatch, dst.Type(), src.Type())
+	}
+	return MergeFields(dst, src)
+}
+
+// MergeFields combina campo a campo src en dst (punteros al mismo tipo de struct).
+// Estrategia por defecto, pensada para fuentes complementarias:
+//   - strings y números: se rellena dst solo si está vacío/cero (gana el primero)
+//   - bools: OR lógico
+//   - slices: unión sin duplicados preservando el orden
+//   - maps: se añaden las claves ausentes en dst
+//   - structs anidados: recursivo
+func MergeFields(dst, src interface{}) error {
+	dv := reflect.ValueOf(dst)
+	sv := reflect.ValueOf(src)
+	if dv.Kind() != reflect.Ptr || sv.Kind() != reflect.Ptr || dv.Type() != sv.Type() {
+		return fmt.Errorf("%w: %T <- %T", ErrMetadataMismatch, dst, src)
+	}
+	if dv.IsNil() || sv.IsNil() {
+		return nil
+	}
+	mergeValue(dv.Elem(), sv.Elem())
+	return nil
+}
+
+func mergeValue(dst, src reflect.Value) {
+	switch dst.Kind() {
+	case reflect.Struct:
+		for i := 0; i < dst.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				mergeValue(dst.Field(i), src.Field(i))
+			}
+		}
+
+	case reflect.Bool:
+		if src.Bool() {
+			dst.SetBool(true)
+		}
+
+	case reflect.Slice:
+		mergeSlice(dst, src)
+
+	case reflect.Map:
+		if src.Len() == 0 {
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(dst.Type(), src.Len()))
+		}
+		iter := src.MapRange()
+		for iter.Next() {
+			if !dst.MapIndex(iter.Key()).IsValid() {
+				dst.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
+
+	case reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(src)
+		} else if !src.IsNil() {
+			mergeValue(dst.Elem(), src.Elem())
+		}
+
+	default:
+		// strings, ints, floats: rellenar huecos
+		if dst.IsZero() && !src.IsZero() {
+			dst.Set(src)
+		}
+	}
+}
+
+func mergeSlice(dst, src reflect.Value) {
+	if src.Len() == 0 {
+		return
+	}
+	if dst.Len() == 0 {
+		dst.Set(src)
+		return
+	}
+	if !dst.Type().Elem().Comparable() {
+		return
+	}
+
+	seen := make(map[interface{}]bool, dst.Len()+src.Len())
+	for i := 0; i < dst.Len(); i++ {
+		seen[dst.Index(i).Interface()] = true
+	}
+	out := dst
+	for i := 0; i < src.Len(); i++ {
+		v := src.Index(i)
+		if !seen[v.Interface()] {
+			seen[v.Interface()] = true
+			out = reflect.Append(out, v)
+		}
+	}
+	dst.Set(out)
+}
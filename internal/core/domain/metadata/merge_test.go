@@ -0,0 +1,81 @@
+// internal/core/domain/metadata/merge_test.go
+package metadata
+
+import (
+	"errors"
+	"testing"
+
+	"aethonx/internal/testutil"
+)
+
+func TestMergeFields_FillsGapsAndUnions(t *testing.T) {
+	dst := &IPMetadata{ASN: "AS13335", OpenPorts: []int{80}}
+	src := &IPMetadata{ASN: "AS15169", Country: "US", OpenPorts: []int{80, 443}, Blacklisted: true}
+
+	testutil.AssertNoError(t, MergeFields(dst, src), "merge fields")
+	testutil.AssertEqual(t, dst.ASN, "AS13335", "first value wins")
+	testutil.AssertEqual(t, dst.Country, "US", "empty field filled")
+	testutil.AssertTrue(t, dst.Blacklisted, "bools are OR'd")
+	testutil.AssertEqual(t, len(dst.OpenPorts), 2, "ports union")
+}
+
+func TestMerge_TypeMismatch(t *testing.T) {
+	err := NewDomainMetadata().Merge(NewIPMetadata())
+	testutil.AssertTrue(t, errors.Is(err, ErrMetadataMismatch), "mismatch error")
+}
+
+func TestDomainMetadata_MergeNewerProbeWins(t *testing.T) {
+	older := NewDomainMetadata()
+	older.IsAlive = true
+	older.ProbeStatus = "alive"
+	older.LastProbed = "2024-01-01T00:00:00Z"
+	older.HTTPStatus = 200
+
+	newer := NewDomainMetadata()
+	newer.IsAlive = false
+	newer.ProbeStatus = "dead"
+	newer.LastProbed = "2024-02-01T00:00:00Z"
+
+	testutil.AssertNoError(t, older.Merge(newer), "merge")
+	testutil.AssertFalse(t, older.IsAlive, "newer dead probe wins")
+	testutil.AssertEqual(t, older.ProbeStatus, "dead", "probe status")
+	testutil.AssertEqual(t, older.LastProbed, "2024-02-01T00:00:00Z", "last probed")
+}
+
+func TestDomainMetadata_MergeOlderProbeIgnored(t *testing.T) {
+	newer := NewDomainMetadata()
+	newer.ProbeStatus = "dead"
+	newer.LastProbed = "2024-02-01T00:00:00Z"
+
+	older := NewDomainMetadata()
+	older.IsAlive = true
+	older.ProbeStatus = "alive"
+	older.LastProbed = "2024-01-01T00:00:00Z"
+	older.HTTPStatus = 200
+	older.HTTPTitle = "Old login"
+	older.Registrar = "Example Registrar"
+
+	testutil.AssertNoError(t, newer.Merge(older), "merge")
+	testutil.AssertFalse(t, newer.IsAlive, "older alive probe does not OR in")
+	testutil.AssertEqual(t, newer.ProbeStatus, "dead", "probe status kept")
+	testutil.AssertEqual(t, newer.HTTPStatus, 0, "no stale HTTP status")
+	testutil.AssertEqual(t, newer.HTTPTitle, "", "no stale title")
+	testutil.AssertEqual(t, newer.Registrar, "Example Registrar", "non-probe fields still merged")
+
+	// Sin probe propio se adopta el del otro aunque no tenga timestamp
+	empty := NewDomainMetadata()
+	testutil.AssertNoError(t, empty.Merge(&DomainMetadata{HTTPStatus: 301, HTTPRedirect: "https://example.com/"}), "merge")
+	testutil.AssertEqual(t, empty.HTTPStatus, 301, "probe adopted")
+}
+
+func TestServiceMetadata_MergeKeepsMaxConfidence(t *testing.T) {
+	a := NewServiceMetadata("http", 80)
+	a.Confidence = 0.5
+	b := NewServiceMetadata("http", 80)
+	b.Confidence = 0.9
+	b.Product = "nginx"
+
+	testutil.AssertNoError(t, a.Merge(b), "merge")
+	testutil.AssertEqual(t, a.Confidence, 0.9, "max confidence")
+	testutil.AssertEqual(t, a.Product, "nginx", "product filled")
+}
@@ -20,6 +20,11 @@ type ArtifactMetadata interface {
 
 	// Type retorna el tipo de metadata (para debugging)
 	Type() string
+
+	// Merge combina en el receptor los datos de otro metadata del mismo tipo
+	// (p.ej. registrar de rdap + HTTP status de httpx sobre el mismo dominio).
+	// Retorna ErrMetadataMismatch si los tipos no coinciden.
+	Merge(other ArtifactMetadata) error
 }
 
 // Helper functions para conversión de tipos comunes
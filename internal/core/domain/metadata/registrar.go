@@ -86,6 +86,11 @@ func (r *RegistrarMetadata) Type() string {
 	return "registrar"
 }
 
+// Merge combina campo a campo con otro RegistrarMetadata (ver MergeFields).
+func (r *RegistrarMetadata) Merge(other ArtifactMetadata) error {
+	return mergeSame(r, other)
+}
+
 // IsExpired verifica si el dominio ha expirado
 func (r *RegistrarMetadata) IsExpired() bool {
 	if r.ExpiryDate == "" {
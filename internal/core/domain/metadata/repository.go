@@ -104,8 +104,9 @@ func (r *RepositoryMetadata) FromMap(m map[string]string) error {
 	return nil
 }
 
-func (r *RepositoryMetadata) IsValid() bool { return r.RepoType != "" }
-func (r *RepositoryMetadata) Type() string  { return "repository" }
+func (r *RepositoryMetadata) IsValid() bool                      { return r.RepoType != "" }
+func (r *RepositoryMetadata) Type() string                       { return "repository" }
+func (r *RepositoryMetadata) Merge(other ArtifactMetadata) error { return mergeSame(r, other) }
 
 // NewRepositoryMetadata crea una instancia de RepositoryMetadata con valores por defecto.
 func NewRepositoryMetadata(repoType string) *RepositoryMetadata {
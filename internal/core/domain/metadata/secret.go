@@ -0,0 +1,59 @@
+// internal/core/domain/metadata/secret.go
+package metadata
+
+// SecretMetadata describe un secreto expuesto (API key, token, clave privada...).
+// Nunca almacena el secreto en claro: solo huella y un preview enmascarado.
+type SecretMetadata struct {
+	Kind        string  // "aws_access_key", "github_token", "private_key", "jwt"
+	Fingerprint string  // SHA-256 (hex) del secreto
+	Redacted    string  // Preview enmascarado ("AKIA****WXYZ")
+	Location    string  // URL o fichero donde se encontró
+	Line        int     // Línea dentro del fichero (0 = desconocida)
+	Entropy     float64 // Entropía de Shannon del valor
+	Verified    bool    // Si se comprobó que el secreto es válido
+	Severity    string  // critical, high, medium, low
+	Detector    string  // Regla/detector que lo encontró
+}
+
+func (s *SecretMetadata) ToMap() map[string]string {
+	m := make(map[string]string)
+	SetIfNotEmpty(m, "kind", s.Kind)
+	SetIfNotEmpty(m, "fingerprint", s.Fingerprint)
+	SetIfNotEmpty(m, "redacted", s.Redacted)
+	SetIfNotEmpty(m, "location", s.Location)
+	if s.Line > 0 {
+		SetInt(m, "line", s.Line)
+	}
+	if s.Entropy > 0 {
+		SetFloat(m, "entropy", s.Entropy)
+	}
+	SetBool(m, "verified", s.Verified)
+	SetIfNotEmpty(m, "severity", s.Severity)
+	SetIfNotEmpty(m, "detector", s.Detector)
+	return m
+}
+
+func (s *SecretMetadata) FromMap(m map[string]string) error {
+	s.Kind = GetString(m, "kind", "")
+	s.Fingerprint = GetString(m, "fingerprint", "")
+	s.Redacted = GetString(m, "redacted", "")
+	s.Location = GetString(m, "location", "")
+	s.Line = GetInt(m, "line", 0)
+	s.Entropy = GetFloat(m, "entropy", 0.0)
+	s.Verified = GetBool(m, "verified", false)
+	s.Severity = GetString(m, "severity", "")
+	s.Detector = GetString(m, "detector", "")
+	return nil
+}
+
+func (s *SecretMetadata) IsValid() bool                      { return s.Kind != "" && s.Fingerprint != "" }
+func (s *SecretMetadata) Type() string                       { return "secret" }
+func (s *SecretMetadata) Merge(other ArtifactMetadata) error { return mergeSame(s, other) }
+
+// NewSecretMetadata crea una instancia de SecretMetadata.
+func NewSecretMetadata(kind, fingerprint string) *SecretMetadata {
+	return &SecretMetadata{
+		Kind:        kind,
+		Fingerprint: fingerprint,
+	}
+}
@@ -28,6 +28,12 @@ var TypeRegistry = map[string]func() ArtifactMetadata{
 	"webshell":      func() ArtifactMetadata { return &WebshellMetadata{} },
 	"registrar":     func() ArtifactMetadata { return &RegistrarMetadata{} },
 	"contact":       func() ArtifactMetadata { return &ContactMetadata{} },
+	"asn":           func() ArtifactMetadata { return &ASNMetadata{} },
+	"cidr":          func() ArtifactMetadata { return &CIDRMetadata{} },
+	"cloud_resource": func() ArtifactMetadata { return &CloudResourceMetadata{} },
+	"secret":        func() ArtifactMetadata { return &SecretMetadata{} },
+	"vulnerability": func() ArtifactMetadata { return &VulnerabilityMetadata{} },
+	"mail_infra":    func() ArtifactMetadata { return &MailInfraMetadata{} },
 }
 
 // MarshalMetadata serializa ArtifactMetadata a MetadataEnvelope.
@@ -106,6 +112,18 @@ func GetMetadataType(meta ArtifactMetadata) string {
 		return "registrar"
 	case *ContactMetadata:
 		return "contact"
+	case *ASNMetadata:
+		return "asn"
+	case *CIDRMetadata:
+		return "cidr"
+	case *CloudResourceMetadata:
+		return "cloud_resource"
+	case *SecretMetadata:
+		return "secret"
+	case *VulnerabilityMetadata:
+		return "vulnerability"
+	case *MailInfraMetadata:
+		return "mail_infra"
 	default:
 		return ""
 	}
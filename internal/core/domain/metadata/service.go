@@ -27,8 +27,11 @@ type ServiceMetadata struct {
 	CPE string // "cpe:/a:mysql:mysql:5.7.40"
 
 	// SSL/TLS (si el servicio usa SSL)
-	SSLEnabled bool
-	SSLCert    string // Subject del certificado
+	SSLEnabled  bool
+	SSLCert     string   // Subject del certificado
+	TLSGrade    string   // Nota de la configuración TLS: "A", "B", "C", "F"
+	TLSVersions []string // Versiones aceptadas ("TLS 1.2", "TLS 1.3")
+	WeakCiphers []string // Suites débiles aceptadas (RC4, 3DES, ...)
 
 	// Vulnerabilidades conocidas
 	HasVulns  bool
@@ -45,6 +48,31 @@ type ServiceMetadata struct {
 
 	// Relación con IP
 	ParentIP string // IP donde se encuentra el servicio
+
+	// Contenido de la respuesta HTTP (detección de cambios y agrupación de
+	// páginas idénticas, e.g. páginas de error compartidas)
+	StatusCode    int // Código de estado HTTP
+	ContentLength int
+	WordCount     int
+	LineCount     int
+	BodyMMH3      string // Hash MurmurHash3 del cuerpo
+	BodySHA256    string // Hash SHA-256 del cuerpo
+	Body          string // Cuerpo truncado (solo si se almacena)
+	Title         string // Título de la página
+	FaviconMMH3   string // Hash MurmurHash3 del favicon (estilo Shodan)
+	Screenshot    string // Ruta de la captura de la página (Aquatone, EyeWitness)
+
+	// Cabeceras de seguridad de la respuesta HTTP
+	HeaderCSP                 string
+	HeaderHSTS                string
+	HeaderXFrameOptions       string
+	HeaderXContentTypeOptions string
+	MissingSecurityHeaders    []string // "hsts", "csp", "x-frame-options", ...
+	InsecureCookies           []string // Cookies sin Secure/HttpOnly
+
+	// Panel de administración expuesto (solo identificación, sin probar credenciales)
+	ExposedPanel string // "Jenkins", "Grafana", "phpMyAdmin", ...
+	PanelVersion string
 }
 
 func (s *ServiceMetadata) ToMap() map[string]string {
@@ -63,6 +91,13 @@ func (s *ServiceMetadata) ToMap() map[string]string {
 	SetIfNotEmpty(m, "cpe", s.CPE)
 	SetBool(m, "ssl_enabled", s.SSLEnabled)
 	SetIfNotEmpty(m, "ssl_cert", s.SSLCert)
+	SetIfNotEmpty(m, "tls_grade", s.TLSGrade)
+	if len(s.TLSVersions) > 0 {
+		m["tls_versions"] = StringSliceToCSV(s.TLSVersions)
+	}
+	if len(s.WeakCiphers) > 0 {
+		m["weak_ciphers"] = StringSliceToCSV(s.WeakCiphers)
+	}
 	SetBool(m, "has_vulns", s.HasVulns)
 	if len(s.CVEList) > 0 {
 		m["cve_list"] = StringSliceToCSV(s.CVEList)
@@ -79,6 +114,36 @@ func (s *ServiceMetadata) ToMap() map[string]string {
 	}
 	SetIfNotEmpty(m, "scan_tool", s.ScanTool)
 	SetIfNotEmpty(m, "parent_ip", s.ParentIP)
+	if s.StatusCode > 0 {
+		SetInt(m, "status_code", s.StatusCode)
+	}
+	if s.ContentLength > 0 {
+		SetInt(m, "content_length", s.ContentLength)
+	}
+	if s.WordCount > 0 {
+		SetInt(m, "word_count", s.WordCount)
+	}
+	if s.LineCount > 0 {
+		SetInt(m, "line_count", s.LineCount)
+	}
+	SetIfNotEmpty(m, "body_mmh3", s.BodyMMH3)
+	SetIfNotEmpty(m, "body_sha256", s.BodySHA256)
+	SetIfNotEmpty(m, "body", s.Body)
+	SetIfNotEmpty(m, "title", s.Title)
+	SetIfNotEmpty(m, "favicon_mmh3", s.FaviconMMH3)
+	SetIfNotEmpty(m, "screenshot", s.Screenshot)
+	SetIfNotEmpty(m, "header_csp", s.HeaderCSP)
+	SetIfNotEmpty(m, "header_hsts", s.HeaderHSTS)
+	SetIfNotEmpty(m, "header_x_frame_options", s.HeaderXFrameOptions)
+	SetIfNotEmpty(m, "header_x_content_type_options", s.HeaderXContentTypeOptions)
+	if len(s.MissingSecurityHeaders) > 0 {
+		m["missing_security_headers"] = StringSliceToCSV(s.MissingSecurityHeaders)
+	}
+	if len(s.InsecureCookies) > 0 {
+		m["insecure_cookies"] = StringSliceToCSV(s.InsecureCookies)
+	}
+	SetIfNotEmpty(m, "exposed_panel", s.ExposedPanel)
+	SetIfNotEmpty(m, "panel_version", s.PanelVersion)
 	return m
 }
 
@@ -95,6 +160,9 @@ func (s *ServiceMetadata) FromMap(m map[string]string) error {
 	s.CPE = GetString(m, "cpe", "")
 	s.SSLEnabled = GetBool(m, "ssl_enabled", false)
 	s.SSLCert = GetString(m, "ssl_cert", "")
+	s.TLSGrade = GetString(m, "tls_grade", "")
+	s.TLSVersions = CSVToStringSlice(GetString(m, "tls_versions", ""))
+	s.WeakCiphers = CSVToStringSlice(GetString(m, "weak_ciphers", ""))
 	s.HasVulns = GetBool(m, "has_vulns", false)
 	s.CVEList = CSVToStringSlice(GetString(m, "cve_list", ""))
 	s.RiskLevel = GetString(m, "risk_level", "")
@@ -115,12 +183,48 @@ func (s *ServiceMetadata) FromMap(m map[string]string) error {
 	}
 	s.ScanTool = GetString(m, "scan_tool", "")
 	s.ParentIP = GetString(m, "parent_ip", "")
+	s.StatusCode = GetInt(m, "status_code", 0)
+	s.ContentLength = GetInt(m, "content_length", 0)
+	s.WordCount = GetInt(m, "word_count", 0)
+	s.LineCount = GetInt(m, "line_count", 0)
+	s.BodyMMH3 = GetString(m, "body_mmh3", "")
+	s.BodySHA256 = GetString(m, "body_sha256", "")
+	s.Body = GetString(m, "body", "")
+	s.Title = GetString(m, "title", "")
+	s.FaviconMMH3 = GetString(m, "favicon_mmh3", "")
+	s.Screenshot = GetString(m, "screenshot", "")
+	s.HeaderCSP = GetString(m, "header_csp", "")
+	s.HeaderHSTS = GetString(m, "header_hsts", "")
+	s.HeaderXFrameOptions = GetString(m, "header_x_frame_options", "")
+	s.HeaderXContentTypeOptions = GetString(m, "header_x_content_type_options", "")
+	s.MissingSecurityHeaders = CSVToStringSlice(GetString(m, "missing_security_headers", ""))
+	s.InsecureCookies = CSVToStringSlice(GetString(m, "insecure_cookies", ""))
+	s.ExposedPanel = GetString(m, "exposed_panel", "")
+	s.PanelVersion = GetString(m, "panel_version", "")
 	return nil
 }
 
 func (s *ServiceMetadata) IsValid() bool { return s.Name != "" && s.Port > 0 }
 func (s *ServiceMetadata) Type() string  { return "service" }
 
+// Merge combina campo a campo con otro ServiceMetadata; la confianza
+// resultante es la mayor de ambas detecciones.
+func (s *ServiceMetadata) Merge(other ArtifactMetadata) error {
+	o, ok := other.(*ServiceMetadata)
+	if !ok || o == nil {
+		return mergeSame(s, other)
+	}
+	conf := s.Confidence
+	if o.Confidence > conf {
+		conf = o.Confidence
+	}
+	if err := MergeFields(s, o); err != nil {
+		return err
+	}
+	s.Confidence = conf
+	return nil
+}
+
 // NewServiceMetadata crea una instancia de ServiceMetadata con valores por defecto.
 func NewServiceMetadata(name string, port int) *ServiceMetadata {
 	return &ServiceMetadata{
@@ -146,8 +146,9 @@ func (s *StorageBucketMetadata) FromMap(m map[string]string) error {
 	return nil
 }
 
-func (s *StorageBucketMetadata) IsValid() bool { return s.BucketName != "" }
-func (s *StorageBucketMetadata) Type() string  { return "storage_bucket" }
+func (s *StorageBucketMetadata) IsValid() bool                      { return s.BucketName != "" }
+func (s *StorageBucketMetadata) Type() string                       { return "storage_bucket" }
+func (s *StorageBucketMetadata) Merge(other ArtifactMetadata) error { return mergeSame(s, other) }
 
 // NewStorageBucketMetadata crea una instancia de StorageBucketMetadata con valores por defecto.
 func NewStorageBucketMetadata(provider, bucketName string) *StorageBucketMetadata {
@@ -203,6 +203,11 @@ func (t *TechnologyMetadata) Type() string {
 	return "technology"
 }
 
+// Merge combina campo a campo con otro TechnologyMetadata (ver MergeFields).
+func (t *TechnologyMetadata) Merge(other ArtifactMetadata) error {
+	return mergeSame(t, other)
+}
+
 // NewTechnologyMetadata crea un nuevo TechnologyMetadata con valores básicos.
 func NewTechnologyMetadata(name, version string) *TechnologyMetadata {
 	return &TechnologyMetadata{
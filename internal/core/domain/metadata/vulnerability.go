@@ -0,0 +1,68 @@
+// internal/core/domain/metadata/vulnerability.go
+package metadata
+
+// VulnerabilityMetadata contiene información detallada sobre una vulnerabilidad.
+type VulnerabilityMetadata struct {
+	CVE           string   // CVE identifier (e.g., "CVE-2021-44228"), vacío si no aplica
+	Title         string   // Título corto
+	Severity      string   // critical, high, medium, low, info, unknown
+	CVSSScore     float64  // CVSS score (0.0-10.0)
+	CVSSVector    string   // "CVSS:3.1/AV:N/AC:L/..."
+	Description   string   // Descripción de la vulnerabilidad
+	References    []string // URLs a advisories
+	AffectedPorts []int    // Puertos donde se detectó
+	MatchedAt     string   // URL/host:port donde se confirmó
+	TemplateID    string   // ID de plantilla/check (nuclei, etc.)
+	DiscoveryTool string   // Herramienta que la descubrió (e.g., "shodan")
+}
+
+func (v *VulnerabilityMetadata) ToMap() map[string]string {
+	m := make(map[string]string)
+	SetIfNotEmpty(m, "cve", v.CVE)
+	SetIfNotEmpty(m, "title", v.Title)
+	SetIfNotEmpty(m, "severity", v.Severity)
+	if v.CVSSScore > 0 {
+		SetFloat(m, "cvss_score", v.CVSSScore)
+	}
+	SetIfNotEmpty(m, "cvss_vector", v.CVSSVector)
+	SetIfNotEmpty(m, "description", v.Description)
+	if len(v.References) > 0 {
+		m["references"] = StringSliceToCSV(v.References)
+	}
+	if len(v.AffectedPorts) > 0 {
+		m["affected_ports"] = IntSliceToCSV(v.AffectedPorts)
+	}
+	SetIfNotEmpty(m, "matched_at", v.MatchedAt)
+	SetIfNotEmpty(m, "template_id", v.TemplateID)
+	SetIfNotEmpty(m, "discovery_tool", v.DiscoveryTool)
+	return m
+}
+
+func (v *VulnerabilityMetadata) FromMap(m map[string]string) error {
+	v.CVE = GetString(m, "cve", "")
+	v.Title = GetString(m, "title", "")
+	v.Severity = GetString(m, "severity", "")
+	v.CVSSScore = GetFloat(m, "cvss_score", 0.0)
+	v.CVSSVector = GetString(m, "cvss_vector", "")
+	v.Description = GetString(m, "description", "")
+	v.References = CSVToStringSlice(GetString(m, "references", ""))
+	v.AffectedPorts = CSVToIntSlice(GetString(m, "affected_ports", ""))
+	v.MatchedAt = GetString(m, "matched_at", "")
+	v.TemplateID = GetString(m, "template_id", "")
+	v.DiscoveryTool = GetString(m, "discovery_tool", "")
+	return nil
+}
+
+func (v *VulnerabilityMetadata) IsValid() bool                      { return v.CVE != "" || v.TemplateID != "" }
+func (v *VulnerabilityMetadata) Type() string                       { return "vulnerability" }
+func (v *VulnerabilityMetadata) Merge(other ArtifactMetadata) error { return mergeSame(v, other) }
+
+// NewVulnerabilityMetadata crea una instancia de VulnerabilityMetadata.
+func NewVulnerabilityMetadata(cve string) *VulnerabilityMetadata {
+	return &VulnerabilityMetadata{
+		CVE:           cve,
+		Severity:      "unknown",
+		References:    []string{},
+		AffectedPorts: []int{},
+	}
+}
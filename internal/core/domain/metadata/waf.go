@@ -113,6 +113,7 @@ func (w *WAFMetadata) FromMap(m map[string]string) error {
 
 func (w *WAFMetadata) IsValid() bool { return w.Name != "" }
 func (w *WAFMetadata) Type() string  { return "waf" }
+func (w *WAFMetadata) Merge(other ArtifactMetadata) error { return mergeSame(w, other) }
 
 // NewWAFMetadata crea una instancia de WAFMetadata con valores por defecto.
 func NewWAFMetadata(name string) *WAFMetadata {
@@ -148,8 +148,9 @@ func (w *WebshellMetadata) FromMap(m map[string]string) error {
 	return nil
 }
 
-func (w *WebshellMetadata) IsValid() bool { return w.Name != "" }
-func (w *WebshellMetadata) Type() string  { return "webshell" }
+func (w *WebshellMetadata) IsValid() bool                      { return w.Name != "" }
+func (w *WebshellMetadata) Type() string                       { return "webshell" }
+func (w *WebshellMetadata) Merge(other ArtifactMetadata) error { return mergeSame(w, other) }
 
 // NewWebshellMetadata crea una instancia de WebshellMetadata con valores por defecto.
 func NewWebshellMetadata(name, shellType string) *WebshellMetadata {
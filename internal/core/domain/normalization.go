@@ -0,0 +1,26 @@
+// internal/core/domain/normalization.go
+package domain
+
+import (
+	"sync/atomic"
+
+	"aethonx/internal/platform/validator"
+)
+
+// normalizationPolicy es la política activa para normalizar dominios.
+// Se fija una vez al arrancar (desde la configuración) antes de crear artifacts.
+var normalizationPolicy atomic.Value
+
+// SetNormalizationPolicy fija la política de normalización de dominios usada
+// por NewArtifact/Normalize (strict por defecto).
+func SetNormalizationPolicy(p validator.NormalizationPolicy) {
+	normalizationPolicy.Store(p)
+}
+
+// CurrentNormalizationPolicy retorna la política de normalización activa.
+func CurrentNormalizationPolicy() validator.NormalizationPolicy {
+	if p, ok := normalizationPolicy.Load().(validator.NormalizationPolicy); ok {
+		return p
+	}
+	return validator.NormalizationStrict
+}
@@ -0,0 +1,199 @@
+// internal/core/domain/page_change.go
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"aethonx/internal/core/domain/metadata"
+)
+
+// Campos de una página que pueden cambiar entre escaneos.
+const (
+	PageFieldContent = "content"
+	PageFieldTitle   = "title"
+	PageFieldStatus  = "status"
+	PageFieldTech    = "tech"
+	PageFieldHeaders = "headers"
+)
+
+// TagPageChanged marca las URLs cuya página cambió desde el último escaneo.
+const TagPageChanged = "page-changed"
+
+// loginTitle reconoce títulos de páginas de login.
+var loginTitle = regexp.MustCompile(`(?i)\b(log ?in|sign ?in|sso|single sign-on|iniciar sesi[oó]n|acceso)\b`)
+
+// PageSnapshot es lo que se observó de una URL en un escaneo (httpx).
+// Los campos vacíos no se conocen y no cuentan como cambio.
+type PageSnapshot struct {
+	Hash    string            `json:"hash,omitempty"` // SHA-256 (o MMH3) del cuerpo
+	Title   string            `json:"title,omitempty"`
+	Status  int               `json:"status,omitempty"`
+	Tech    []string          `json:"tech,omitempty"`    // Tecnologías, ordenadas
+	Headers map[string]string `json:"headers,omitempty"` // Server y cabeceras de seguridad
+}
+
+// PageChange es un cambio de una URL vigilada respecto al escaneo anterior.
+type PageChange struct {
+	URL     string       `json:"url"`
+	Host    string       `json:"host"`
+	Fields  []string     `json:"fields"` // PageField* que cambiaron
+	Before  PageSnapshot `json:"before"`
+	After   PageSnapshot `json:"after"`
+	Summary string       `json:"summary"`
+}
+
+// LoginAppeared indica si la URL pasó a servir una página de login.
+func (c PageChange) LoginAppeared() bool {
+	return loginTitle.MatchString(c.After.Title) && !loginTitle.MatchString(c.Before.Title)
+}
+
+// PageSnapshots extrae la instantánea de cada URL sondeada del resultado,
+// por valor de la URL. Las tecnologías son las relacionadas (uses_tech).
+// Las URLs solo reinyectadas de un escaneo anterior no se sondearon.
+func PageSnapshots(result *ScanResult) map[string]PageSnapshot {
+	tech := make(map[string][]string) // ID de la URL -> tecnologías
+	for _, a := range result.Artifacts {
+		if a == nil || a.Type != ArtifactTypeTechnology {
+			continue
+		}
+		for _, rel := range a.Relations {
+			if rel.Type == RelationUsesTech && !slices.Contains(tech[rel.TargetID], a.Value) {
+				tech[rel.TargetID] = append(tech[rel.TargetID], a.Value)
+			}
+		}
+	}
+
+	pages := make(map[string]PageSnapshot)
+	for _, a := range result.Artifacts {
+		if a == nil || a.Type != ArtifactTypeURL || a.IsHistoricalOnly() {
+			continue
+		}
+		svc, ok := a.TypedMetadata.(*metadata.ServiceMetadata)
+		if !ok || (svc.StatusCode == 0 && svc.Title == "" && svc.BodySHA256 == "" && svc.BodyMMH3 == "") {
+			continue
+		}
+		snap := PageSnapshot{
+			Hash:    firstNonEmpty(svc.BodySHA256, svc.BodyMMH3),
+			Title:   strings.TrimSpace(svc.Title),
+			Status:  svc.StatusCode,
+			Tech:    tech[a.ID],
+			Headers: pageHeaders(svc),
+		}
+		sort.Strings(snap.Tech)
+		pages[a.Value] = snap
+	}
+	return pages
+}
+
+// pageHeaders retorna las cabeceras vigiladas presentes en la respuesta.
+func pageHeaders(svc *metadata.ServiceMetadata) map[string]string {
+	headers := make(map[string]string)
+	for name, value := range map[string]string{
+		"server":                    svc.Banner,
+		"content-security-policy":   svc.HeaderCSP,
+		"strict-transport-security": svc.HeaderHSTS,
+		"x-frame-options":           svc.HeaderXFrameOptions,
+		"x-content-type-options":    svc.HeaderXContentTypeOptions,
+	} {
+		if value != "" {
+			headers[name] = value
+		}
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+	return headers
+}
+
+// Carry retorna next con los campos que next no conoce (hash, tecnologías,
+// cabeceras) tomados de s, para que un escaneo sin -hash o sin detección de
+// tecnologías no borre la referencia con la que comparar el siguiente.
+func (s PageSnapshot) Carry(next PageSnapshot) PageSnapshot {
+	if next.Hash == "" {
+		next.Hash = s.Hash
+	}
+	if len(next.Tech) == 0 {
+		next.Tech = s.Tech
+	}
+	if len(next.Headers) == 0 {
+		next.Headers = s.Headers
+	}
+	return next
+}
+
+// ComparePages retorna los campos que cambiaron de before a after. Solo se
+// compara lo que ambas instantáneas conocen: un escaneo sin hashes (httpx sin
+// -hash) o sin detección de tecnologías no cuenta como cambio. El título sí
+// se compara siempre: una página sin título que pasa a tenerlo es un cambio.
+func ComparePages(before, after PageSnapshot) []string {
+	var fields []string
+	if before.Hash != "" && after.Hash != "" && before.Hash != after.Hash {
+		fields = append(fields, PageFieldContent)
+	}
+	if before.Title != after.Title {
+		fields = append(fields, PageFieldTitle)
+	}
+	if before.Status != 0 && after.Status != 0 && before.Status != after.Status {
+		fields = append(fields, PageFieldStatus)
+	}
+	if len(before.Tech) > 0 && len(after.Tech) > 0 && strings.Join(before.Tech, ",") != strings.Join(after.Tech, ",") {
+		fields = append(fields, PageFieldTech)
+	}
+	if len(before.Headers) > 0 && len(after.Headers) > 0 && !maps.Equal(before.Headers, after.Headers) {
+		fields = append(fields, PageFieldHeaders)
+	}
+	return fields
+}
+
+// NewPageChange describe el cambio de url entre dos instantáneas.
+func NewPageChange(rawURL string, before, after PageSnapshot, fields []string) PageChange {
+	change := PageChange{
+		URL:    rawURL,
+		Host:   artifactHost(&Artifact{Type: ArtifactTypeURL, Value: rawURL}),
+		Fields: fields,
+		Before: before,
+		After:  after,
+	}
+
+	var parts []string
+	for _, field := range fields {
+		switch field {
+		case PageFieldStatus:
+			parts = append(parts, fmt.Sprintf("status %d -> %d", before.Status, after.Status))
+		case PageFieldTitle:
+			parts = append(parts, fmt.Sprintf("title %q -> %q", before.Title, after.Title))
+		case PageFieldContent:
+			parts = append(parts, "content changed")
+		case PageFieldTech:
+			parts = append(parts, "tech "+techDiff(before.Tech, after.Tech))
+		case PageFieldHeaders:
+			parts = append(parts, "headers changed")
+		}
+	}
+	change.Summary = fmt.Sprintf("%s changed: %s", rawURL, strings.Join(parts, "; "))
+	if change.LoginAppeared() {
+		change.Summary = fmt.Sprintf("login page appeared on %s (%s)", change.Host, rawURL)
+	}
+	return change
+}
+
+// techDiff formatea las tecnologías añadidas (+) y retiradas (-).
+func techDiff(before, after []string) string {
+	var diff []string
+	for _, t := range after {
+		if !slices.Contains(before, t) {
+			diff = append(diff, "+"+t)
+		}
+	}
+	for _, t := range before {
+		if !slices.Contains(after, t) {
+			diff = append(diff, "-"+t)
+		}
+	}
+	return strings.Join(diff, " ")
+}
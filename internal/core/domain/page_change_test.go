@@ -0,0 +1,58 @@
+// internal/core/domain/page_change_test.go
+package domain
+
+import (
+	"testing"
+
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/testutil"
+)
+
+func TestPageSnapshots(t *testing.T) {
+	result := NewScanResult(Target{Root: "example.com"})
+	page := NewArtifactWithMetadata(ArtifactTypeURL, "https://www.example.com", "httpx", &metadata.ServiceMetadata{
+		StatusCode: 200,
+		Title:      " Example ",
+		BodyMMH3:   "-1234",
+		Banner:     "nginx",
+		HeaderHSTS: "max-age=31536000",
+	})
+	unprobed := NewArtifact(ArtifactTypeURL, "https://www.example.com/robots.txt", "waybackurls")
+	historical := NewArtifactWithMetadata(ArtifactTypeURL, "https://old.example.com", "previousscan", &metadata.ServiceMetadata{StatusCode: 200})
+	historical.AddTag(TagHistorical)
+	for _, name := range []string{"React", "Nginx", "React"} {
+		tech := NewArtifact(ArtifactTypeTechnology, name, "httpx")
+		tech.AddRelation(page.ID, RelationUsesTech, 0.9, "httpx")
+		result.AddArtifact(tech)
+	}
+	result.AddArtifacts(page, unprobed, historical)
+
+	pages := PageSnapshots(result)
+	testutil.AssertEqual(t, len(pages), 1, "only probed URLs")
+	snap := pages["https://www.example.com"]
+	testutil.AssertEqual(t, snap.Hash, "-1234", "mmh3 when there is no sha256")
+	testutil.AssertEqual(t, snap.Title, "Example", "trimmed title")
+	testutil.AssertEqual(t, len(snap.Tech), 2, "tech deduplicated")
+	testutil.AssertEqual(t, snap.Tech[0], "Nginx", "tech sorted")
+	testutil.AssertEqual(t, len(snap.Headers), 2, "server and hsts")
+}
+
+func TestComparePages(t *testing.T) {
+	before := PageSnapshot{Hash: "aa", Title: "Home", Status: 200, Tech: []string{"Nginx"}, Headers: map[string]string{"server": "nginx"}}
+
+	testutil.AssertEqual(t, len(ComparePages(before, before)), 0, "same page")
+	testutil.AssertEqual(t, len(ComparePages(before, PageSnapshot{Title: "Home", Status: 200})), 0, "unknown fields not compared")
+
+	after := PageSnapshot{Hash: "bb", Title: "Home", Status: 200, Tech: []string{"Apache"}, Headers: map[string]string{"server": "Apache"}}
+	fields := ComparePages(before, after)
+	testutil.AssertEqual(t, len(fields), 3, "content, tech and headers")
+
+	change := NewPageChange("https://www.example.com/app", before, after, fields)
+	testutil.AssertEqual(t, change.Host, "www.example.com", "host")
+	testutil.AssertEqual(t, change.Summary, "https://www.example.com/app changed: content changed; tech +Apache -Nginx; headers changed", "summary")
+	testutil.AssertFalse(t, change.LoginAppeared(), "no login page")
+
+	carried := before.Carry(PageSnapshot{Title: "Home", Status: 301})
+	testutil.AssertEqual(t, carried.Hash, "aa", "hash carried")
+	testutil.AssertEqual(t, carried.Status, 301, "known fields replaced")
+}
@@ -0,0 +1,60 @@
+// internal/core/domain/port.go
+package domain
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"aethonx/internal/platform/validator"
+)
+
+// Los artifacts de tipo Port usan el valor canónico "host:port" (IPv6 entre
+// corchetes: "[2001:db8::1]:443"). Un número de puerto aislado colisionaría
+// entre hosts distintos al deduplicar por type:value.
+
+// PortValue construye el valor canónico de un artifact Port.
+func PortValue(host string, port int) string {
+	return normalizePort(net.JoinHostPort(host, strconv.Itoa(port)))
+}
+
+// SplitPortValue separa un valor "host:port" en sus componentes.
+func SplitPortValue(value string) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(value)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid port value %q: %w", value, err)
+	}
+	if host == "" {
+		return "", 0, fmt.Errorf("invalid port value %q: missing host", value)
+	}
+	if !validator.IsPort(portStr) {
+		return "", 0, fmt.Errorf("invalid port value %q: port out of range", value)
+	}
+	port, _ := strconv.Atoi(portStr)
+	return host, port, nil
+}
+
+// normalizePort normaliza el host de un valor "host:port" (IP canónica o
+// dominio en minúsculas). Los valores no parseables se dejan intactos.
+func normalizePort(v string) string {
+	host, portStr, err := net.SplitHostPort(v)
+	if err != nil || host == "" {
+		return v
+	}
+
+	if validator.IsIP(host) {
+		host = validator.NormalizeIP(host)
+	} else {
+		host = validator.NormalizeDomainWithPolicy(host, CurrentNormalizationPolicy())
+	}
+	if host == "" {
+		return v
+	}
+
+	return net.JoinHostPort(host, portStr)
+}
+
+func isValidPortValue(v string) bool {
+	_, _, err := SplitPortValue(v)
+	return err == nil
+}
@@ -0,0 +1,86 @@
+// internal/core/domain/provenance.go
+package domain
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// recordProvenance indica si NewArtifact registra procedencia. Está apagado
+// por defecto: en escaneos grandes (p. ej. wayback) cada registro cuesta
+// memoria y solo se exporta con --include-provenance.
+var recordProvenance atomic.Bool
+
+// SetProvenanceRecording activa o desactiva el registro de procedencia.
+// Se fija una vez al arrancar (desde la configuración) antes de crear artifacts.
+func SetProvenanceRecording(on bool) {
+	recordProvenance.Store(on)
+}
+
+// ProvenanceRecording indica si el registro de procedencia está activo.
+func ProvenanceRecording() bool {
+	return recordProvenance.Load()
+}
+
+// Provenance registra cómo una fuente observó un artifact: el valor crudo
+// que reportó, cuándo y con qué consulta/endpoint. Permite auditar por qué
+// existe un artifact.
+type Provenance struct {
+	// Source es la fuente que observó el artifact
+	Source string `json:"source"`
+
+	// RawValue es el valor tal como lo reportó la fuente (antes de normalizar)
+	RawValue string `json:"raw_value"`
+
+	// Query es la consulta, endpoint o comando usado (opcional)
+	Query string `json:"query,omitempty"`
+
+	// SeenAt es cuándo la fuente observó el valor
+	SeenAt time.Time `json:"seen_at"`
+}
+
+// AddProvenance añade un registro de procedencia sin duplicados (source+raw+query).
+func (a *Artifact) AddProvenance(p Provenance) {
+	for _, existing := range a.Provenance {
+		if existing.Source == p.Source && existing.RawValue == p.RawValue && existing.Query == p.Query {
+			return
+		}
+	}
+	a.Provenance = append(a.Provenance, p)
+}
+
+// SetProvenanceQuery asigna query a los registros de procedencia de source
+// que aún no la tienen (las fuentes conocen el endpoint tras crear el artifact).
+func (a *Artifact) SetProvenanceQuery(source, query string) {
+	for i := range a.Provenance {
+		if a.Provenance[i].Source == source && a.Provenance[i].Query == "" {
+			a.Provenance[i].Query = query
+		}
+	}
+}
+
+// SetProvenanceQuery asigna query a la procedencia de source en todos los artifacts.
+func (r *ScanResult) SetProvenanceQuery(source, query string) {
+	for _, a := range r.Artifacts {
+		a.SetProvenanceQuery(source, query)
+	}
+}
+
+// WithoutProvenance retorna una copia superficial del resultado cuyos artifacts
+// no incluyen procedencia (salida JSON por defecto, sin --include-provenance).
+func (r *ScanResult) WithoutProvenance() *ScanResult {
+	out := *r
+
+	out.Artifacts = make([]*Artifact, len(r.Artifacts))
+	for i, a := range r.Artifacts {
+		if len(a.Provenance) == 0 {
+			out.Artifacts[i] = a
+			continue
+		}
+		copied := *a
+		copied.Provenance = nil
+		out.Artifacts[i] = &copied
+	}
+
+	return &out
+}
@@ -0,0 +1,76 @@
+// internal/core/domain/provenance_test.go
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"aethonx/internal/testutil"
+)
+
+// withProvenanceRecording activa el registro de procedencia durante el test.
+func withProvenanceRecording(t *testing.T) {
+	t.Helper()
+	prev := ProvenanceRecording()
+	SetProvenanceRecording(true)
+	t.Cleanup(func() { SetProvenanceRecording(prev) })
+}
+
+func TestNewArtifact_NoProvenanceByDefault(t *testing.T) {
+	a := NewArtifact(ArtifactTypeSubdomain, "api.example.com", "crtsh")
+
+	testutil.AssertEqual(t, len(a.Provenance), 0, "provenance not recorded unless enabled")
+}
+
+func TestNewArtifact_RecordsProvenance(t *testing.T) {
+	withProvenanceRecording(t)
+	a := NewArtifact(ArtifactTypeSubdomain, "API.Example.com.", "crtsh")
+
+	testutil.AssertEqual(t, len(a.Provenance), 1, "provenance entries")
+	testutil.AssertEqual(t, a.Provenance[0].Source, "crtsh", "source")
+	testutil.AssertEqual(t, a.Provenance[0].RawValue, "API.Example.com.", "raw value as seen")
+	testutil.AssertFalse(t, a.Provenance[0].SeenAt.IsZero(), "seen_at set")
+}
+
+func TestArtifact_MergeCombinesProvenance(t *testing.T) {
+	withProvenanceRecording(t)
+	a := NewArtifact(ArtifactTypeSubdomain, "api.example.com", "crtsh")
+	b := NewArtifact(ArtifactTypeSubdomain, "API.example.com", "subfinder")
+
+	testutil.AssertNoError(t, a.Merge(b), "merge")
+	testutil.AssertEqual(t, len(a.Provenance), 2, "provenance from both sources")
+
+	testutil.AssertNoError(t, a.Merge(b), "merge again")
+	testutil.AssertEqual(t, len(a.Provenance), 2, "no duplicate provenance")
+}
+
+func TestScanResult_SetProvenanceQuery(t *testing.T) {
+	withProvenanceRecording(t)
+	result := NewScanResult(Target{Root: "example.com"})
+	result.AddArtifact(NewArtifact(ArtifactTypeSubdomain, "api.example.com", "crtsh"))
+
+	result.SetProvenanceQuery("crtsh", "https://crt.sh/?q=%25.example.com&output=json")
+	result.SetProvenanceQuery("crtsh", "ignored")
+	result.SetProvenanceQuery("rdap", "ignored")
+
+	testutil.AssertEqual(t, result.Artifacts[0].Provenance[0].Query,
+		"https://crt.sh/?q=%25.example.com&output=json", "query set once for matching source")
+}
+
+func TestScanResult_WithoutProvenance(t *testing.T) {
+	withProvenanceRecording(t)
+	result := NewScanResult(Target{Root: "example.com"})
+	result.AddArtifact(NewArtifact(ArtifactTypeSubdomain, "api.example.com", "crtsh"))
+
+	stripped := result.WithoutProvenance()
+	data, err := json.Marshal(stripped)
+	testutil.AssertNoError(t, err, "marshal stripped")
+	testutil.AssertFalse(t, strings.Contains(string(data), "provenance"), "provenance omitted")
+
+	testutil.AssertEqual(t, len(result.Artifacts[0].Provenance), 1, "original untouched")
+
+	data, err = json.Marshal(result)
+	testutil.AssertNoError(t, err, "marshal full")
+	testutil.AssertTrue(t, strings.Contains(string(data), `"provenance"`), "provenance serialized")
+}
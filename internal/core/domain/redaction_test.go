@@ -0,0 +1,81 @@
+// internal/core/domain/redaction_test.go
+package domain
+
+import (
+	"testing"
+
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/testutil"
+)
+
+func TestParseRedactionProfile(t *testing.T) {
+	p, err := ParseRedactionProfile("")
+	testutil.AssertNoError(t, err, "empty profile")
+	testutil.AssertEqual(t, p, RedactionFull, "empty means full")
+
+	p, err = ParseRedactionProfile("Client-Safe")
+	testutil.AssertNoError(t, err, "case-insensitive")
+	testutil.AssertEqual(t, p, RedactionClientSafe, "client-safe")
+
+	_, err = ParseRedactionProfile("public")
+	testutil.AssertError(t, err, "unknown profile")
+}
+
+func TestScanResult_Redacted(t *testing.T) {
+	withProvenanceRecording(t)
+	result := NewScanResult(Target{Root: "example.com"})
+
+	email := NewArtifact(ArtifactTypeEmail, "john.doe@example.com", "rdap")
+	contact := NewArtifact(ArtifactTypeWhoisContact, "John Doe", "rdap")
+	cm := metadata.NewContactMetadata("registrant")
+	cm.Name, cm.Email, cm.Phone, cm.Organization = "John Doe", "john.doe@example.com", "+34 600 123 456", "Example Corp"
+	contact.TypedMetadata = cm
+	secret := NewArtifact(ArtifactTypeSecret, "aws_access_key:2d711642b726b04401627ca9fbac32f5c8530fb1903cc4db02258717921a4881", "js")
+	host := NewArtifact(ArtifactTypeSubdomain, "api.example.com", "crtsh")
+	result.AddArtifacts(email, contact, secret, host)
+
+	redacted := result.Redacted(RedactionClientSafe)
+
+	testutil.AssertEqual(t, redacted.Artifacts[0].Value, "j***@example.com", "email masked")
+	testutil.AssertEqual(t, redacted.Artifacts[0].ID, email.ID, "ID preserved for relations")
+	testutil.AssertEqual(t, redacted.Artifacts[0].Provenance[0].RawValue, "j***@example.com", "provenance masked")
+	testutil.AssertEqual(t, redacted.Artifacts[1].Value, "J*** D***", "contact name masked")
+	testutil.AssertEqual(t, redacted.Artifacts[2].Value, "aws_access_key:2d71****", "secret masked, kind kept")
+	testutil.AssertTrue(t, redacted.Artifacts[3] == host, "non-sensitive artifacts shared")
+
+	rc := redacted.Artifacts[1].TypedMetadata.(*metadata.ContactMetadata)
+	testutil.AssertEqual(t, rc.Email, "j***@example.com", "contact email masked")
+	testutil.AssertEqual(t, rc.Phone, "****56", "contact phone masked")
+	testutil.AssertEqual(t, rc.Organization, "Example Corp", "organization kept")
+	testutil.AssertTrue(t, rc.Redacted, "contact marked redacted")
+	testutil.AssertEqual(t, redacted.Metadata.Environment["redaction"], "client-safe", "profile recorded")
+
+	// El original no se modifica
+	testutil.AssertEqual(t, email.Value, "john.doe@example.com", "original email untouched")
+	testutil.AssertEqual(t, cm.Name, "John Doe", "original metadata untouched")
+
+	testutil.AssertTrue(t, result.Redacted(RedactionFull) == result, "full profile is a no-op")
+}
+
+func TestScanResult_RedactedLifecycle(t *testing.T) {
+	result := NewScanResult(Target{Root: "example.com"})
+	result.Lifecycle = &LifecycleReport{
+		New: []ArtifactLifecycle{
+			{Key: "email:john.doe@example.com", Type: ArtifactTypeEmail, Value: "john.doe@example.com"},
+			{Key: "subdomain:api.example.com", Type: ArtifactTypeSubdomain, Value: "api.example.com"},
+		},
+		Reappeared: []ArtifactLifecycle{{Key: "whois_contact:John Doe", Type: ArtifactTypeWhoisContact, Value: "John Doe"}},
+		Removed:    []ArtifactLifecycle{{Type: ArtifactTypeSecret, Value: "aws_access_key:2d711642b726b04401627ca9fbac32f5"}},
+	}
+
+	redacted := result.Redacted(RedactionClientSafe).Lifecycle
+
+	testutil.AssertEqual(t, redacted.New[0].Value, "j***@example.com", "new email masked")
+	testutil.AssertEqual(t, redacted.New[0].Key, "email:j***@example.com", "key masked")
+	testutil.AssertEqual(t, redacted.New[1].Value, "api.example.com", "hosts kept")
+	testutil.AssertEqual(t, redacted.Reappeared[0].Value, "J*** D***", "reappeared contact masked")
+	testutil.AssertEqual(t, redacted.Removed[0].Value, "aws_access_key:2d71****", "removed secret masked")
+
+	// El original no se modifica
+	testutil.AssertEqual(t, result.Lifecycle.New[0].Value, "john.doe@example.com", "original untouched")
+}
@@ -28,6 +28,12 @@ type ScanResult struct {
 
 	// Errors errores ocurridos durante el escaneo
 	Errors []Error
+
+	// Lifecycle cambios first_seen/last_seen respecto a escaneos previos (opcional)
+	Lifecycle *LifecycleReport `json:"lifecycle,omitempty"`
+
+	// Trends series temporal de métricas del target hasta este escaneo (modo monitor)
+	Trends []TrendPoint `json:"trends,omitempty"`
 }
 
 // ScanMetadata contiene información sobre la ejecución del escaneo.
@@ -56,9 +62,27 @@ type ScanMetadata struct {
 	// RelationsByType cuenta de relaciones agrupadas por tipo
 	RelationsByType map[RelationType]int
 
+	// NoiseSuppressed artifacts de terceros descartados por la supresión de ruido
+	NoiseSuppressed int `json:"noise_suppressed,omitempty"`
+
+	// Truncations sources (o el escaneo) detenidas por --max-artifacts/--max-duration
+	Truncations []Truncation `json:"truncations,omitempty"`
+
+	// Deferrals stages activos aplazados u omitidos fuera de --active-window
+	Deferrals []StageDeferral `json:"deferrals,omitempty"`
+
+	// Cache aciertos y fallos de las sources con caché de resultados
+	Cache *SourceCacheStats `json:"cache,omitempty"`
+
+	// SkippedStages fases omitidas con --skip-stages (e.g. verification)
+	SkippedStages []string `json:"skipped_stages,omitempty"`
+
 	// Version versión de AethonX utilizada
 	Version string
 
+	// IDScheme esquema de los IDs de artifacts (ver CurrentIDScheme); 0 = IDSchemeV1
+	IDScheme int `json:"id_scheme,omitempty"`
+
 	// Environment información del entorno (opcional)
 	Environment map[string]string
 }
@@ -132,6 +156,7 @@ func NewScanResult(target Target) *ScanResult {
 		Artifacts:     []*Artifact{},
 		Metadata: ScanMetadata{
 			StartTime:   time.Now(),
+			IDScheme:    CurrentIDScheme,
 			Environment: make(map[string]string),
 		},
 		Warnings: []Warning{},
@@ -206,6 +231,17 @@ func (r *ScanResult) TotalArtifacts() int {
 	return len(r.Artifacts)
 }
 
+// ApproxSize estima los bytes que ocupan los artefactos en memoria (ver Artifact.ApproxSize).
+func (r *ScanResult) ApproxSize() int64 {
+	var size int64
+	for _, a := range r.Artifacts {
+		if a != nil {
+			size += int64(a.ApproxSize())
+		}
+	}
+	return size
+}
+
 // HasErrors indica si hubo errores durante el escaneo.
 func (r *ScanResult) HasErrors() bool {
 	return len(r.Errors) > 0
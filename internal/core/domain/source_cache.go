@@ -0,0 +1,43 @@
+// internal/core/domain/source_cache.go
+package domain
+
+import "sort"
+
+// EnvSourceCache es la clave de Metadata.Environment con la que una source
+// cacheada indica si su resultado salió de la caché.
+const EnvSourceCache = "source_cache"
+
+// Valores de EnvSourceCache.
+const (
+	SourceCacheHit  = "hit"
+	SourceCacheMiss = "miss"
+)
+
+// SourceCacheStats cuenta las sources cacheadas de un escaneo que reutilizaron
+// un resultado anterior (hit) o tuvieron que ejecutarse (miss).
+type SourceCacheStats struct {
+	Hits    int      `json:"hits"`
+	Misses  int      `json:"misses"`
+	Sources []string `json:"sources,omitempty"` // Sources servidas desde la caché
+}
+
+// Record cuenta el resultado de una source según su EnvSourceCache; las
+// sources sin caché no cuentan.
+func (s *SourceCacheStats) Record(source string, result *ScanResult) {
+	if result == nil {
+		return
+	}
+	switch result.Metadata.Environment[EnvSourceCache] {
+	case SourceCacheHit:
+		s.Hits++
+		s.Sources = append(s.Sources, source)
+		sort.Strings(s.Sources)
+	case SourceCacheMiss:
+		s.Misses++
+	}
+}
+
+// IsZero indica si ninguna source usó la caché.
+func (s SourceCacheStats) IsZero() bool {
+	return s.Hits == 0 && s.Misses == 0
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "testing"
+
+func TestSourceCacheStats_Record(t *testing.T) {
+	var stats SourceCacheStats
+	for source, status := range map[string]string{"shodan": SourceCacheHit, "crtsh": SourceCacheHit, "rdap": SourceCacheMiss, "dns": ""} {
+		result := NewScanResult(*NewTarget("example.com", ScanModePassive))
+		if status != "" {
+			result.Metadata.Environment[EnvSourceCache] = status
+		}
+		stats.Record(source, result)
+	}
+	stats.Record("nil", nil)
+
+	if stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("expected 2 hits and 1 miss, got %+v", stats)
+	}
+	if len(stats.Sources) != 2 || stats.Sources[0] != "crtsh" || stats.Sources[1] != "shodan" {
+		t.Errorf("expected sorted hit sources, got %v", stats.Sources)
+	}
+	if stats.IsZero() || !(SourceCacheStats{}).IsZero() {
+		t.Error("IsZero() mismatch")
+	}
+}
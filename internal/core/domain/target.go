@@ -64,7 +64,7 @@ func (t *Target) Validate() error {
 	}
 
 	// Normalizar usando validator centralizado
-	t.Root = validator.NormalizeDomain(t.Root)
+	t.Root = validator.NormalizeDomainWithPolicy(t.Root, CurrentNormalizationPolicy())
 
 	// Validar formato de dominio usando validator centralizado
 	if !validator.IsDomain(t.Root) {
@@ -84,9 +84,25 @@ func (t *Target) Validate() error {
 	return nil
 }
 
+// QueryName retorna Root en forma ASCII (punycode), la usada al consultar
+// fuentes externas (crt.sh, RDAP, httpx) con dominios internacionalizados.
+func (t Target) QueryName() string {
+	if validator.IsIDN(t.Root) {
+		if ascii, err := validator.ToASCIIDomain(t.Root); err == nil {
+			return ascii
+		}
+	}
+	return t.Root
+}
+
 // IsInScope verifica si un dominio está dentro del alcance del target.
 func (t *Target) IsInScope(domain string) bool {
 	domain = strings.ToLower(strings.TrimSpace(domain))
+	if validator.IsIDN(domain) {
+		if ascii, err := validator.ToASCIIDomain(domain); err == nil {
+			domain = ascii
+		}
+	}
 
 	// Verificar si está en la lista de exclusión
 	for _, excluded := range t.Scope.ExcludeDomains {
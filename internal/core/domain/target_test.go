@@ -226,3 +226,11 @@ func TestTarget_String(t *testing.T) {
 
 	testutil.AssertNotEqual(t, str, "", "string representation should not be empty")
 }
+
+func TestTarget_IDN(t *testing.T) {
+	target := NewTarget("Bücher.example", ScanModePassive)
+	testutil.AssertNoError(t, target.Validate(), "IDN target should validate")
+	testutil.AssertEqual(t, target.Root, "xn--bcher-kva.example", "root normalized to punycode")
+	testutil.AssertEqual(t, target.QueryName(), "xn--bcher-kva.example", "query name is punycode")
+	testutil.AssertTrue(t, target.IsInScope("shop.bücher.example"), "unicode subdomain in scope")
+}
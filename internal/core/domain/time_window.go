@@ -0,0 +1,137 @@
+// internal/core/domain/time_window.go
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeWindow franja horaria diaria (e.g. 22:00-06:00) en una zona horaria.
+// Si End es anterior a Start la franja cruza la medianoche.
+type TimeWindow struct {
+	Start    time.Duration // Desde la medianoche local
+	End      time.Duration // Desde la medianoche local
+	Location *time.Location
+}
+
+// ParseTimeWindow parsea "HH:MM-HH:MM" en la zona tz (IANA, e.g.
+// "Europe/Madrid"; "" = hora local).
+func ParseTimeWindow(spec, tz string) (TimeWindow, error) {
+	var w TimeWindow
+
+	from, to, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return w, fmt.Errorf("invalid time window %q: want HH:MM-HH:MM", spec)
+	}
+	start, err := parseClock(from)
+	if err != nil {
+		return w, fmt.Errorf("invalid time window %q: %w", spec, err)
+	}
+	end, err := parseClock(to)
+	if err != nil {
+		return w, fmt.Errorf("invalid time window %q: %w", spec, err)
+	}
+	if start == end {
+		return w, fmt.Errorf("invalid time window %q: start and end are equal", spec)
+	}
+
+	loc := time.Local
+	if tz = strings.TrimSpace(tz); tz != "" {
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return w, fmt.Errorf("invalid time zone %q: %w", tz, err)
+		}
+	}
+
+	return TimeWindow{Start: start, End: end, Location: loc}, nil
+}
+
+// parseClock parsea "HH:MM" como duración desde la medianoche.
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a HH:MM time", strings.TrimSpace(s))
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// location retorna la zona de la franja (hora local si no tiene).
+func (w TimeWindow) location() *time.Location {
+	if w.Location == nil {
+		return time.Local
+	}
+	return w.Location
+}
+
+// Contains indica si t cae dentro de la franja.
+func (w TimeWindow) Contains(t time.Time) bool {
+	local := t.In(w.location())
+	clock := time.Duration(local.Hour())*time.Hour +
+		time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second +
+		time.Duration(local.Nanosecond())
+
+	if w.Start < w.End {
+		return clock >= w.Start && clock < w.End
+	}
+	return clock >= w.Start || clock < w.End
+}
+
+// NextOpen retorna el próximo instante en que la franja está abierta a
+// partir de t (t mismo si ya lo está).
+func (w TimeWindow) NextOpen(t time.Time) time.Time {
+	if w.Contains(t) {
+		return t
+	}
+	local := t.In(w.location())
+	open := w.at(local, 0)
+	if !open.After(t) {
+		open = w.at(local, 1)
+	}
+	return open
+}
+
+// at retorna la apertura de la franja el día de local desplazado days días.
+// time.Date resuelve los cambios de horario de verano.
+func (w TimeWindow) at(local time.Time, days int) time.Time {
+	return time.Date(local.Year(), local.Month(), local.Day()+days,
+		int(w.Start/time.Hour), int(w.Start%time.Hour/time.Minute), 0, 0, local.Location())
+}
+
+// String retorna la franja como "22:00-06:00 Europe/Madrid".
+func (w TimeWindow) String() string {
+	return fmt.Sprintf("%s-%s %s", formatClock(w.Start), formatClock(w.End), w.location())
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// StageDeferral registra un stage activo que se encontró fuera de la franja
+// permitida (--active-window): se aplazó hasta su apertura o, si el escaneo
+// no podía esperar tanto, se omitió.
+type StageDeferral struct {
+	Stage      string     `json:"stage"`
+	Window     string     `json:"window"`
+	DeferredAt time.Time  `json:"deferred_at"`
+	OpensAt    time.Time  `json:"opens_at"`
+	ResumedAt  *time.Time `json:"resumed_at,omitempty"` // nil si el stage se omitió
+	Skipped    bool       `json:"skipped,omitempty"`
+	Reason     string     `json:"reason,omitempty"` // Por qué se omitió
+}
+
+// String describe el aplazamiento para logs, tablas e informes.
+func (d StageDeferral) String() string {
+	if d.Skipped {
+		s := fmt.Sprintf("%s: skipped outside window %s (opens %s)", d.Stage, d.Window, d.OpensAt.Format(time.RFC3339))
+		if d.Reason != "" {
+			s += " (" + d.Reason + ")"
+		}
+		return s
+	}
+	waited := time.Duration(0)
+	if d.ResumedAt != nil {
+		waited = d.ResumedAt.Sub(d.DeferredAt).Round(time.Second)
+	}
+	return fmt.Sprintf("%s: deferred %s until window %s opened", d.Stage, waited, d.Window)
+}
@@ -0,0 +1,43 @@
+// internal/core/domain/time_window_test.go
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"aethonx/internal/testutil"
+)
+
+func TestParseTimeWindow(t *testing.T) {
+	w, err := ParseTimeWindow("22:00-06:30", "UTC")
+	testutil.AssertNoError(t, err, "valid window")
+	testutil.AssertEqual(t, w.Start, 22*time.Hour, "start")
+	testutil.AssertEqual(t, w.End, 6*time.Hour+30*time.Minute, "end")
+	testutil.AssertEqual(t, w.String(), "22:00-06:30 UTC", "string")
+
+	for _, bad := range [][2]string{{"22:00", ""}, {"25:00-06:00", ""}, {"10:00-10:00", ""}, {"22:00-06:00", "Mars/Olympus"}} {
+		_, err := ParseTimeWindow(bad[0], bad[1])
+		testutil.AssertError(t, err, bad[0]+" "+bad[1])
+	}
+}
+
+func TestTimeWindow_ContainsAndNextOpen(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2024, 6, 1, h, m, 0, 0, time.UTC) }
+
+	night, _ := ParseTimeWindow("22:00-06:00", "UTC")
+	testutil.AssertTrue(t, night.Contains(at(23, 0)), "before midnight")
+	testutil.AssertTrue(t, night.Contains(at(5, 59)), "after midnight")
+	testutil.AssertFalse(t, night.Contains(at(6, 0)), "end is exclusive")
+	testutil.AssertFalse(t, night.Contains(at(12, 0)), "midday")
+	testutil.AssertEqual(t, night.NextOpen(at(12, 0)), at(22, 0), "opens tonight")
+	testutil.AssertEqual(t, night.NextOpen(at(23, 0)), at(23, 0), "already open")
+
+	office, _ := ParseTimeWindow("09:00-17:00", "UTC")
+	testutil.AssertEqual(t, office.NextOpen(at(18, 0)), at(9, 0).AddDate(0, 0, 1), "opens tomorrow")
+
+	// La franja se evalúa en la hora local del target
+	madrid, err := ParseTimeWindow("22:00-06:00", "Europe/Madrid")
+	testutil.AssertNoError(t, err, "tz")
+	testutil.AssertTrue(t, madrid.Contains(at(21, 0)), "21:00 UTC is 23:00 in Madrid (CEST)")
+	testutil.AssertTrue(t, madrid.NextOpen(at(12, 0)).Equal(at(20, 0)), "22:00 CEST is 20:00 UTC")
+}
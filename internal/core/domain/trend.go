@@ -0,0 +1,119 @@
+// internal/core/domain/trend.go
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"aethonx/internal/core/domain/metadata"
+)
+
+// CertExpiryWindow es el margen con el que un certificado cuenta como
+// "expira pronto" en las series temporales.
+const CertExpiryWindow = 30 * 24 * time.Hour
+
+// TrendPoint son las métricas de superficie de ataque de un escaneo.
+// La serie de puntos de un target (LifecycleState.Trends) permite ver su
+// evolución en modo monitor.
+type TrendPoint struct {
+	ScanID        string    `json:"scan_id"`
+	Time          time.Time `json:"time"`
+	Artifacts     int       `json:"artifacts"`
+	Subdomains    int       `json:"subdomains"`
+	AliveHosts    int       `json:"alive_hosts"`
+	OpenPorts     int       `json:"open_ports"`
+	CertsExpiring int       `json:"certs_expiring"` // caducados o dentro de CertExpiryWindow
+}
+
+// TrendMetrics son las métricas de TrendPoint, en el orden en que se muestran.
+var TrendMetrics = []string{"subdomains", "alive_hosts", "open_ports", "certs_expiring"}
+
+// NewTrendPoint calcula las métricas del resultado en el instante at (los
+// certificados se evalúan respecto a at).
+func NewTrendPoint(result *ScanResult, at time.Time) TrendPoint {
+	p := TrendPoint{ScanID: result.ID, Time: at, Artifacts: len(result.Artifacts)}
+	for _, a := range result.Artifacts {
+		if a == nil {
+			continue
+		}
+		switch a.Type {
+		case ArtifactTypeSubdomain:
+			p.Subdomains++
+		case ArtifactTypePort:
+			p.OpenPorts++
+		case ArtifactTypeCertificate:
+			if cert, ok := a.TypedMetadata.(*metadata.CertificateMetadata); ok && certExpiresBy(cert, at.Add(CertExpiryWindow)) {
+				p.CertsExpiring++
+			}
+		}
+		if isHostType(a.Type) && a.IsAlive() {
+			p.AliveHosts++
+		}
+	}
+	return p
+}
+
+// Value retorna la métrica por nombre (ver TrendMetrics).
+func (p TrendPoint) Value(metric string) int {
+	switch metric {
+	case "artifacts":
+		return p.Artifacts
+	case "subdomains":
+		return p.Subdomains
+	case "alive_hosts":
+		return p.AliveHosts
+	case "open_ports":
+		return p.OpenPorts
+	case "certs_expiring":
+		return p.CertsExpiring
+	default:
+		return 0
+	}
+}
+
+// AppendTrend añade el punto del escaneo a la serie del target (sustituyendo
+// uno previo del mismo scan) y conserva como máximo maxPoints (0 = sin
+// límite), descartando los más antiguos.
+func (s *LifecycleState) AppendTrend(p TrendPoint, maxPoints int) {
+	for i := range s.Trends {
+		if p.ScanID != "" && s.Trends[i].ScanID == p.ScanID {
+			s.Trends[i] = p
+			return
+		}
+	}
+	s.Trends = append(s.Trends, p)
+	if maxPoints > 0 && len(s.Trends) > maxPoints {
+		s.Trends = append([]TrendPoint(nil), s.Trends[len(s.Trends)-maxPoints:]...)
+	}
+}
+
+// RecentTrends retorna los n puntos más recientes (n <= 0 = todos).
+func (s *LifecycleState) RecentTrends(n int) []TrendPoint {
+	if n <= 0 || len(s.Trends) <= n {
+		return s.Trends
+	}
+	return s.Trends[len(s.Trends)-n:]
+}
+
+func isHostType(t ArtifactType) bool {
+	return t == ArtifactTypeDomain || t == ArtifactTypeSubdomain
+}
+
+// certExpiresBy indica si el certificado está caducado o caduca antes de deadline.
+func certExpiresBy(cert *metadata.CertificateMetadata, deadline time.Time) bool {
+	if cert.CertExpired {
+		return true
+	}
+	until, ok := certValidUntil(cert)
+	return ok && until.Before(deadline)
+}
+
+// certValidUntil interpreta ValidUntil en los formatos de las fuentes.
+func certValidUntil(cert *metadata.CertificateMetadata) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if until, err := time.Parse(layout, strings.TrimSpace(cert.ValidUntil)); err == nil {
+			return until, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,51 @@
+// internal/core/domain/trend_test.go
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/testutil"
+)
+
+func TestNewTrendPoint(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	result := NewScanResult(Target{Root: "example.com"})
+
+	alive := NewArtifact(ArtifactTypeSubdomain, "api.example.com", "httpx")
+	alive.AddTag("alive")
+	expiring := NewArtifact(ArtifactTypeCertificate, "2d711642b726b04401627ca9fbac32f5c8530fb1903cc4db02258717921a4881", "crtsh")
+	expiring.TypedMetadata = &metadata.CertificateMetadata{ValidUntil: now.Add(10 * 24 * time.Hour).Format(time.RFC3339)}
+	valid := NewArtifact(ArtifactTypeCertificate, "5c8530fb1903cc4db02258717921a48812d711642b726b04401627ca9fbac32f", "crtsh")
+	valid.TypedMetadata = &metadata.CertificateMetadata{ValidUntil: "2025-06-01"}
+	result.AddArtifacts(alive,
+		NewArtifact(ArtifactTypeSubdomain, "www.example.com", "crtsh"),
+		NewArtifact(ArtifactTypePort, "api.example.com:443", "naabu"),
+		expiring, valid)
+
+	p := NewTrendPoint(result, now)
+	testutil.AssertEqual(t, p.ScanID, result.ID, "scan id")
+	testutil.AssertEqual(t, p.Artifacts, len(result.Artifacts), "artifacts")
+	testutil.AssertEqual(t, p.Subdomains, 2, "subdomains")
+	testutil.AssertEqual(t, p.AliveHosts, 1, "alive hosts")
+	testutil.AssertEqual(t, p.OpenPorts, 1, "open ports")
+	testutil.AssertEqual(t, p.CertsExpiring, 1, "certs expiring within window")
+	testutil.AssertEqual(t, p.Value("subdomains"), 2, "value by metric")
+}
+
+func TestLifecycleState_AppendTrend(t *testing.T) {
+	state := NewLifecycleState("example.com")
+	for i, id := range []string{"s1", "s2", "s3", "s4"} {
+		state.AppendTrend(TrendPoint{ScanID: id, Subdomains: i}, 3)
+	}
+	testutil.AssertEqual(t, len(state.Trends), 3, "capped to max points")
+	testutil.AssertEqual(t, state.Trends[0].ScanID, "s2", "oldest dropped")
+
+	state.AppendTrend(TrendPoint{ScanID: "s4", Subdomains: 10}, 3)
+	testutil.AssertEqual(t, len(state.Trends), 3, "same scan replaced")
+	testutil.AssertEqual(t, state.Trends[2].Subdomains, 10, "replaced value")
+
+	testutil.AssertEqual(t, len(state.RecentTrends(2)), 2, "recent trends")
+	testutil.AssertEqual(t, state.RecentTrends(2)[1].ScanID, "s4", "most recent last")
+}
@@ -0,0 +1,46 @@
+// internal/core/domain/truncation.go
+package domain
+
+import "fmt"
+
+// TruncationReason identifica el tope que detuvo una source o el escaneo.
+type TruncationReason string
+
+const (
+	TruncatedMaxArtifacts TruncationReason = "max_artifacts" // --max-artifacts
+	TruncatedMaxDuration  TruncationReason = "max_duration"  // --max-duration
+	TruncatedSample       TruncationReason = "sample"        // --sample
+)
+
+// Truncation registra una source (o el escaneo completo si Source está
+// vacío) detenida al alcanzar un tope. Los artifacts obtenidos hasta ese
+// momento se conservan.
+type Truncation struct {
+	Source    string           `json:"source,omitempty"`
+	Scope     string           `json:"scope"` // "source", "stage" o "scan": de quién es el tope alcanzado
+	Reason    TruncationReason `json:"reason"`
+	Limit     string           `json:"limit"`               // Valor del tope (e.g. "50000", "30m0s")
+	Artifacts int              `json:"artifacts,omitempty"` // Artifacts conservados de la source
+	Message   string           `json:"message,omitempty"`
+}
+
+// String describe la truncación para logs, tablas e informes.
+func (t Truncation) String() string {
+	who := t.Source
+	if who == "" {
+		who = "scan"
+	}
+	s := fmt.Sprintf("%s: %s %s=%s reached", who, t.Scope, t.Reason, t.Limit)
+	if t.Source != "" {
+		s += fmt.Sprintf(", %d artifacts kept", t.Artifacts)
+	}
+	if t.Message != "" {
+		s += " (" + t.Message + ")"
+	}
+	return s
+}
+
+// IsTruncated indica si alguna source o el propio escaneo se detuvo por un tope.
+func (r *ScanResult) IsTruncated() bool {
+	return len(r.Metadata.Truncations) > 0
+}
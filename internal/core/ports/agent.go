@@ -0,0 +1,54 @@
+// internal/core/ports/agent.go
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"aethonx/internal/core/domain"
+)
+
+// ErrAgentUnavailable indica que el agente no respondió (red, TLS, caído o
+// sin capacidad). El coordinador ejecuta la source en local.
+var ErrAgentUnavailable = errors.New("agent unavailable")
+
+// Agent es un worker remoto de AethonX que ejecuta sources desde su propio
+// host (otra IP de salida o región) y devuelve los artifacts al coordinador.
+type Agent interface {
+	// Endpoint identifica al agente (e.g., su URL)
+	Endpoint() string
+
+	// Info consulta las capacidades y la carga actuales del agente
+	Info(ctx context.Context) (AgentInfo, error)
+
+	// RunSource ejecuta una source en el agente. onArtifact (opcional) recibe
+	// cada artifact a medida que llega; el resultado los incluye todos.
+	RunSource(ctx context.Context, req AgentRunRequest, onArtifact func(*domain.Artifact)) (*domain.ScanResult, error)
+}
+
+// AgentInfo describe un agente: sources que puede ejecutar y carga.
+type AgentInfo struct {
+	ID            string   `json:"id"`
+	Region        string   `json:"region,omitempty"`
+	Version       string   `json:"version,omitempty"`
+	Sources       []string `json:"sources"`        // Sources habilitadas y construidas en el agente
+	MaxConcurrent int      `json:"max_concurrent"` // Sources simultáneas (0 = sin límite)
+	Running       int      `json:"running"`        // Sources en ejecución
+}
+
+// Supports indica si el agente puede ejecutar source.
+func (i AgentInfo) Supports(source string) bool {
+	for _, s := range i.Sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// AgentRunRequest es la ejecución de una source asignada a un agente.
+type AgentRunRequest struct {
+	Source string             `json:"source"`
+	Target domain.Target      `json:"target"`
+	Input  *domain.ScanResult `json:"input,omitempty"` // Artifacts de stages previos ya filtrados
+}
@@ -0,0 +1,18 @@
+// internal/core/ports/lifecycle.go
+package ports
+
+import (
+	"context"
+
+	"aethonx/internal/core/domain"
+)
+
+// LifecycleStore persiste el estado first_seen/last_seen de los artifacts de
+// un target entre escaneos (modo monitor).
+type LifecycleStore interface {
+	// LoadLifecycle recupera el estado de target; retorna un estado vacío si no existe
+	LoadLifecycle(ctx context.Context, target string) (*domain.LifecycleState, error)
+
+	// SaveLifecycle guarda el estado actualizado
+	SaveLifecycle(ctx context.Context, state *domain.LifecycleState) error
+}
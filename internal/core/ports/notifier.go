@@ -35,6 +35,20 @@ type FilteredNotifier interface {
 	SetFilter(filter EventFilter)
 }
 
+// EventSpool persiste eventos que un observer no llegó a recibir (cola llena,
+// error o timeout) para reentregarlos más tarde, p. ej. a un webhook caído.
+// Los eventos de cada observer se conservan en orden de llegada.
+type EventSpool interface {
+	// Save persiste un evento no entregado al observer indicado
+	Save(observer string, event Event) error
+
+	// Pending retorna los eventos pendientes del observer en orden
+	Pending(observer string) ([]Event, error)
+
+	// Ack descarta los n primeros eventos pendientes del observer
+	Ack(observer string, n int) error
+}
+
 // Event representa un evento del sistema.
 type Event struct {
 	// Type tipo de evento
@@ -46,6 +60,9 @@ type Event struct {
 	// Source fuente que generó el evento
 	Source string
 
+	// ScanID escaneo al que pertenece el evento (lo rellena el orquestador)
+	ScanID string
+
 	// Target objetivo relacionado (opcional)
 	Target string
 
@@ -78,6 +95,15 @@ const (
 	// Artifact events
 	EventTypeArtifactDiscovered EventType = "artifact.discovered"
 	EventTypeArtifactValidated  EventType = "artifact.validated"
+	EventTypeArtifactStale      EventType = "artifact.stale"
+	EventTypeArtifactRemoved    EventType = "artifact.removed"
+	EventTypeArtifactReappeared EventType = "artifact.reappeared"
+
+	// Certificate events (modo monitor)
+	EventTypeCertificateExpiring EventType = "certificate.expiring"
+
+	// Page events (modo monitor)
+	EventTypePageChanged EventType = "page.changed"
 
 	// System events
 	EventTypeSystemError   EventType = "system.error"
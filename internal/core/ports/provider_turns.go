@@ -0,0 +1,35 @@
+// internal/core/ports/provider_turns.go
+package ports
+
+import "context"
+
+// ProviderTurns reparte los turnos de petición de los proveedores que
+// comparten varias sources de un stage: sus peticiones se intercalan en el
+// límite compartido del proveedor en lugar de competir por él (429).
+type ProviderTurns interface {
+	// Wait espera el turno de una petición a provider y retorna la función
+	// que lo libera. Los proveedores no compartidos no esperan.
+	Wait(ctx context.Context, provider string) (release func(), err error)
+}
+
+// providerTurnsKey es la clave de contexto de los turnos por proveedor.
+type providerTurnsKey struct{}
+
+// WithProviderTurns retorna un contexto cuyas peticiones a upstreams
+// compartidos esperan turno en turns.
+func WithProviderTurns(ctx context.Context, turns ProviderTurns) context.Context {
+	if turns == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, providerTurnsKey{}, turns)
+}
+
+// WaitProviderTurn espera el turno de una petición a provider según los
+// turnos de ctx; sin turnos en ctx retorna enseguida.
+func WaitProviderTurn(ctx context.Context, provider string) (func(), error) {
+	turns, _ := ctx.Value(providerTurnsKey{}).(ProviderTurns)
+	if turns == nil {
+		return func() {}, nil
+	}
+	return turns.Wait(ctx, provider)
+}
@@ -0,0 +1,37 @@
+// internal/core/ports/replay.go
+package ports
+
+import (
+	"context"
+
+	"aethonx/internal/core/domain"
+)
+
+// ReplayableSource es una fuente capaz de archivar su salida cruda durante un
+// escaneo (--capture-raw) y de reconstruir después su resultado a partir de
+// ella, sin consultar nada ("aethonx replay"). Sirve para recuperar escaneos
+// pasados cuando se corrige un parser.
+type ReplayableSource interface {
+	Source
+
+	// Replay parsea la captura cruda escrita en dir por una ejecución anterior.
+	Replay(ctx context.Context, target domain.Target, dir string) (*domain.ScanResult, error)
+}
+
+// captureKey es la clave de contexto del directorio de captura.
+type captureKey struct{}
+
+// WithCaptureDir retorna un contexto que pide a la source archivar su salida
+// cruda en dir (un directorio propio de la source en el escaneo en curso).
+func WithCaptureDir(ctx context.Context, dir string) context.Context {
+	if dir == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, captureKey{}, dir)
+}
+
+// CaptureDir retorna el directorio de captura pedido en ctx ("" = sin captura).
+func CaptureDir(ctx context.Context) string {
+	dir, _ := ctx.Value(captureKey{}).(string)
+	return dir
+}
@@ -0,0 +1,23 @@
+// internal/core/ports/sample.go
+package ports
+
+import "context"
+
+// sampleKey es la clave de contexto del tamaño de muestra (--sample).
+type sampleKey struct{}
+
+// WithSampleSize retorna un contexto que pide a la source detenerse tras n
+// artifacts. El orchestrator recorta igualmente lo que exceda la muestra;
+// las sources que lo respetan solo evitan el trabajo sobrante.
+func WithSampleSize(ctx context.Context, n int) context.Context {
+	if n <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, sampleKey{}, n)
+}
+
+// SampleSize retorna el tamaño de muestra pedido en ctx (0 = sin muestreo).
+func SampleSize(ctx context.Context) int {
+	n, _ := ctx.Value(sampleKey{}).(int)
+	return n
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/logx"
 )
 
 // Source es el port primario para todas las fuentes de datos en AethonX.
@@ -42,21 +43,51 @@ type AdvancedSource interface {
 	HealthCheck(ctx context.Context) error
 }
 
+// DiagnosticSource es implementado por sources que reportan detalles de su
+// estado para "aethonx doctor" (versión del binario, cuota de la API).
+type DiagnosticSource interface {
+	Source
+
+	// Diagnostics retorna los detalles disponibles (best-effort)
+	Diagnostics(ctx context.Context) (SourceDiagnostics, error)
+}
+
+// SourceDiagnostics son los detalles reportados por una DiagnosticSource.
+type SourceDiagnostics struct {
+	Version string // Versión del binario o la API ("" = desconocida)
+	Quota   string // Estado de cuota/créditos de la API ("" = no aplica)
+}
+
 // ProgressUpdate representa una actualización de progreso durante la ejecución de un source.
+// ArtifactCount es acumulado: perder actualizaciones intermedias no pierde
+// información, la siguiente trae el total.
 type ProgressUpdate struct {
 	ArtifactCount int    // Número actual de artifacts descubiertos
 	Message       string // Mensaje opcional de estado
 }
 
+// ProgressSource expone el progreso de una source mientras se ejecuta.
+//
+// Contrato del canal:
+//   - Lo crea la source con buffer (10 suele bastar) y lo cierra en Close.
+//   - Los envíos no bloquean nunca (select con default): con el buffer lleno
+//     la actualización se descarta. Una UI lenta no frena a la source.
+//   - El orquestador lo drena mientras la source corre y muestra la última
+//     actualización cada 100ms. Lo que quede en el buffer de una ejecución
+//     anterior se descarta antes de la siguiente.
+type ProgressSource interface {
+	Source
+
+	// ProgressChannel retorna un canal para emitir actualizaciones de progreso
+	ProgressChannel() <-chan ProgressUpdate
+}
+
 // StreamingSource permite a las fuentes emitir artefactos en tiempo real.
 type StreamingSource interface {
-	Source
+	ProgressSource
 
 	// Stream ejecuta la fuente y emite artefactos a medida que los descubre
 	Stream(ctx context.Context, target domain.Target) (<-chan *domain.Artifact, <-chan error)
-
-	// ProgressChannel retorna un canal para emitir actualizaciones de progreso
-	ProgressChannel() <-chan ProgressUpdate
 }
 
 // RateLimitedSource indica que la fuente implementa rate limiting.
@@ -80,6 +111,74 @@ type InputConsumer interface {
 	RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error)
 }
 
+// ScanScopedSource es implementado por sources que mantienen estado durante un
+// escaneo (e.g. el ledger de sondeos de httpx). El orquestador llama a
+// BeginScan al inicio de cada escaneo, antes de ejecutar ningún stage.
+type ScanScopedSource interface {
+	Source
+
+	// BeginScan descarta el estado del escaneo anterior
+	BeginScan(scanID string)
+}
+
+// LogScopedSource es implementado por sources cuyo logger puede sustituirse
+// en cada ejecución. El orquestador llama a SetLogger antes de ejecutar la
+// source con un logger que ya incluye scan_id, stage y source, de modo que
+// todas las líneas de un escaneo sean correlacionables.
+type LogScopedSource interface {
+	Source
+
+	// SetLogger sustituye el logger usado en las siguientes ejecuciones
+	SetLogger(logger logx.Logger)
+}
+
+// ProviderSource es implementado por sources cuyos proveedores dependen de
+// la configuración (e.g. reversewhois con WhoisXML o SecurityTrails). Sus
+// Providers sustituyen a los de SourceMetadata al agrupar las sources de un
+// stage; nil o vacío = los de SourceMetadata.
+type ProviderSource interface {
+	Source
+
+	// Providers retorna los upstreams que consulta con la configuración actual
+	Providers() []string
+}
+
+// SourceState estado no terminal de una source en ejecución.
+type SourceState string
+
+const (
+	// SourceStateRunning la source se está ejecutando (o vuelve a hacerlo tras un reintento)
+	SourceStateRunning SourceState = "running"
+
+	// SourceStateRetrying la ejecución falló y se reintentará tras un backoff
+	SourceStateRetrying SourceState = "retrying"
+
+	// SourceStateCircuitOpen el circuit breaker está abierto: no se ejecuta
+	SourceStateCircuitOpen SourceState = "circuit_open"
+)
+
+// StateReportingSource es implementado por sources (o wrappers como el de
+// retry) que informan de cambios de estado durante una ejecución. El
+// orquestador instala el handler antes de ejecutarla y lo retira después.
+type StateReportingSource interface {
+	Source
+
+	// SetStateHandler instala fn (nil = ninguno); detail es opcional
+	SetStateHandler(fn func(state SourceState, detail string))
+}
+
+// PartialResultSource es implementado por sources que entregan resultados
+// parciales durante una ejecución larga (e.g. httpx por chunks), para que el
+// orquestador los vuelque al streaming writer sin esperar al final.
+type PartialResultSource interface {
+	Source
+
+	// SetPartialHandler registra fn (llamada de forma secuencial) para cada
+	// resultado parcial. Los artifacts aceptados (error nil) no se repiten en
+	// el resultado final. nil desactiva el volcado.
+	SetPartialHandler(fn func(partial *domain.ScanResult) error)
+}
+
 // SourceConfig contiene la configuración específica de una fuente.
 type SourceConfig struct {
 	// Enabled indica si la fuente está habilitada
@@ -97,6 +196,19 @@ type SourceConfig struct {
 	// Priority prioridad de ejecución (mayor = más prioritario)
 	Priority int
 
+	// CacheTTL tiempo durante el que se reutiliza el resultado de la fuente
+	// para el mismo target, configuración e input (0 = sin caché)
+	CacheTTL time.Duration
+
+	// NegativeCacheTTL tiempo durante el que se recuerda que la fuente no
+	// encontró nada o que el upstream respondió "no encontrado" (0 = no se
+	// recuerda); suele ser más corto que CacheTTL
+	NegativeCacheTTL time.Duration
+
+	// Critical aborta el pipeline si la fuente falla, en lugar de continuar
+	// con el resto de stages (fail-fast)
+	Critical bool
+
 	// Custom configuración específica de la fuente (API keys, paths, etc.)
 	Custom map[string]interface{}
 }
@@ -132,4 +244,118 @@ type SourceMetadata struct {
 	OutputArtifacts []domain.ArtifactType // Artifact types produced by this source
 	Priority        int                    // Prioridad de ejecución (mayor = más prioritario)
 	StageHint       int                    // Hint manual de stage (0 = auto-detect, >0 = forzar stage específico)
+
+	// ConfigSchema declara las opciones aceptadas en SourceConfig.Custom: las
+	// factories las decodifican con registry.DecodeConfig, "aethonx config
+	// validate" detecta claves y tipos erróneos y --help-sources las lista.
+	ConfigSchema []ConfigField
+
+	// Dependency declara el binario externo que ejecuta la source (sources CLI
+	// o modo use_cli); "aethonx deps" deriva de aquí qué instalar (nil = ninguno).
+	Dependency *ToolDependency
+
+	// Pivot indica que la source descubre activos fuera del target (p.ej.
+	// otros dominios del mismo registrante); --no-pivot la deshabilita.
+	Pivot bool
+
+	// Providers upstreams de API que consulta la source, con los nombres del
+	// presupuesto compartido de rate.Shared() (p.ej. "crt.sh",
+	// "api.shodan.io"). Las peticiones de las sources de un stage con un
+	// proveedor en común se turnan (ProviderTurns), para no agotar juntas su
+	// límite (429).
+	Providers []string
+
+	// Phase fase del escaneo a la que pertenece la source (PhaseDiscovery,
+	// PhaseVerification, PhaseEnrichment); --skip-stages omite las sources
+	// de las fases indicadas. Vacío = se deduce con StagePhase.
+	Phase string
+}
+
+// Fases del escaneo declaradas en SourceMetadata.Phase.
+const (
+	PhaseDiscovery    = "discovery"    // Enumeración de activos (sin inputs o imports)
+	PhaseVerification = "verification" // Sondeo activo que confirma activos (resolución, puertos, HTTP)
+	PhaseEnrichment   = "enrichment"   // Contexto sobre activos ya conocidos (registro, TLS, crawling)
+)
+
+// Phases lista las fases válidas en orden de ejecución habitual.
+var Phases = []string{PhaseDiscovery, PhaseVerification, PhaseEnrichment}
+
+// StagePhase retorna la fase de la source: la declarada o, si no declara
+// ninguna, discovery para las que no consumen inputs, verification para las
+// activas y enrichment para el resto.
+func (m SourceMetadata) StagePhase() string {
+	switch {
+	case m.Phase != "":
+		return m.Phase
+	case len(m.InputArtifacts) == 0:
+		return PhaseDiscovery
+	case m.Mode == domain.SourceModeActive:
+		return PhaseVerification
+	default:
+		return PhaseEnrichment
+	}
+}
+
+// ConfigType es el tipo esperado de un valor de SourceConfig.Custom.
+type ConfigType string
+
+const (
+	ConfigTypeString     ConfigType = "string"
+	ConfigTypeInt        ConfigType = "int"
+	ConfigTypeFloat      ConfigType = "float"
+	ConfigTypeBool       ConfigType = "bool"
+	ConfigTypeDuration   ConfigType = "duration" // "30s", "5m" o nanosegundos
+	ConfigTypeStringList ConfigType = "[]string"
+)
+
+// ConfigField describe una opción de SourceConfig.Custom.
+type ConfigField struct {
+	Name        string
+	Type        ConfigType
+	Required    bool        // Debe tener valor (no vacío) para construir la source
+	Default     interface{} // Valor si no se configura, del tipo Go de Type (nil = cero)
+	Description string
+	Credential  bool // API key/token: si RequiresAuth, al menos una debe tener valor
+}
+
+// ToolDependency describe cómo instalar y comprobar el binario de una source.
+type ToolDependency struct {
+	Binary        string            // Nombre del ejecutable
+	Repo          string            // Repositorio GitHub owner/repo con releases
+	AssetPatterns map[string]string // "<os>_<arch>" → glob del asset de la release
+	PipPackage    string            // Paquete Python, si no se distribuye por GitHub
+	VersionArgs   []string          // Args que imprimen la versión (default: -version)
+	VersionMarker string            // Texto esperado en la salida de VersionArgs
+	MinVersion    string
+	Notes         string // Pasos manuales tras instalar (e.g., configurar API key)
+
+	// ChecksumAsset es el glob del asset de la release con los SHA256 de los
+	// demás (formato sha256sum). Si se declara, la instalación falla si el
+	// asset descargado no coincide; "" = la release no publica checksums.
+	ChecksumAsset string
+
+	// Cosign verifica además la firma del ChecksumAsset (opcional)
+	Cosign *CosignSignature
+
+	// FeatureVersions exige versiones mayores según la configuración
+	FeatureVersions []FeatureVersion
+}
+
+// CosignSignature describe la firma keyless (sigstore) del fichero de
+// checksums de una release.
+type CosignSignature struct {
+	SignatureAsset   string // Glob del asset .sig
+	CertificateAsset string // Glob del asset .pem
+	Identity         string // Regexp de la identidad del certificado (workflow de release)
+	Issuer           string // Emisor OIDC (e.g., https://token.actions.githubusercontent.com)
+}
+
+// FeatureVersion es la versión mínima del binario que necesita una opción de
+// Custom con cierto valor (e.g., httpx profile=headless usa -screenshot).
+type FeatureVersion struct {
+	Option     string // Clave de Custom
+	Value      string // Valor que activa el requisito ("" = cualquier valor no vacío)
+	MinVersion string
+	Reason     string
 }
@@ -0,0 +1,28 @@
+// internal/core/ports/tagging.go
+package ports
+
+import "aethonx/internal/core/domain"
+
+// TagRule es una regla de etiquetado definida por el usuario que se aplica
+// durante la consolidación. Un artifact recibe Tag si cumple todas las
+// condiciones definidas (las vacías no restringen).
+type TagRule struct {
+	// Name identifica la regla en logs (opcional)
+	Name string `yaml:"name" json:"name,omitempty"`
+
+	// Tag a añadir cuando la regla coincide (requerido)
+	Tag string `yaml:"tag" json:"tag"`
+
+	// Types restringe los tipos de artifact (vacío = todos)
+	Types []domain.ArtifactType `yaml:"types" json:"types,omitempty"`
+
+	// Value es un patrón glob sobre el valor (e.g., "*.internal.*")
+	Value string `yaml:"value" json:"value,omitempty"`
+
+	// ValueRegex es una expresión regular sobre el valor
+	ValueRegex string `yaml:"value_regex" json:"value_regex,omitempty"`
+
+	// Metadata mapea campos de metadata (claves de ToMap, e.g. "http_status")
+	// a expresiones regulares que deben coincidir
+	Metadata map[string]string `yaml:"metadata" json:"metadata,omitempty"`
+}
@@ -0,0 +1,135 @@
+// internal/core/usecases/agent_scheduler.go
+package usecases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+)
+
+// AgentScheduler reparte las sources de cada stage entre agentes remotos según
+// capacidad (qué sources tiene construidas cada agente) y carga (sources en
+// ejecución frente a su máximo). Las sources que ningún agente puede ejecutar
+// corren en local.
+type AgentScheduler struct {
+	agents []ports.Agent
+	logger logx.Logger
+
+	mu       sync.Mutex
+	info     map[string]ports.AgentInfo // Último Info por endpoint (solo agentes disponibles)
+	assigned map[string]int             // Asignaciones en curso por endpoint desde el último Refresh
+}
+
+// NewAgentScheduler crea un scheduler sobre agents. Llamar a Refresh antes de asignar.
+func NewAgentScheduler(agents []ports.Agent, logger logx.Logger) *AgentScheduler {
+	if logger == nil {
+		logger = logx.New()
+	}
+	return &AgentScheduler{
+		agents:   agents,
+		logger:   logger.With("component", "agent_scheduler"),
+		info:     make(map[string]ports.AgentInfo),
+		assigned: make(map[string]int),
+	}
+}
+
+// Refresh consulta capacidades y carga de todos los agentes en paralelo.
+// Los que no responden quedan fuera hasta el siguiente Refresh. Retorna el
+// número de agentes disponibles.
+func (s *AgentScheduler) Refresh(ctx context.Context) int {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	type reply struct {
+		endpoint string
+		info     ports.AgentInfo
+		err      error
+	}
+	replies := make(chan reply, len(s.agents))
+	for _, agent := range s.agents {
+		go func(a ports.Agent) {
+			info, err := a.Info(ctx)
+			replies <- reply{endpoint: a.Endpoint(), info: info, err: err}
+		}(agent)
+	}
+
+	info := make(map[string]ports.AgentInfo, len(s.agents))
+	for range s.agents {
+		r := <-replies
+		if r.err != nil {
+			s.logger.Warn("agent unavailable", "agent", r.endpoint, "error", r.err.Error())
+			continue
+		}
+		info[r.endpoint] = r.info
+		s.logger.Debug("agent available",
+			"agent", r.endpoint,
+			"id", r.info.ID,
+			"region", r.info.Region,
+			"sources", len(r.info.Sources),
+			"running", r.info.Running,
+		)
+	}
+
+	s.mu.Lock()
+	s.info = info
+	s.assigned = make(map[string]int)
+	s.mu.Unlock()
+
+	return len(info)
+}
+
+// Acquire asigna source al agente disponible con menor carga relativa que la
+// tenga. Los agentes saturados solo se eligen si todos lo están (el agente
+// encola). ok=false si ningún agente puede ejecutarla. Liberar con Release.
+func (s *AgentScheduler) Acquire(source string) (ports.Agent, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var best ports.Agent
+	bestLoad := 0.0
+	for _, agent := range s.agents {
+		info, ok := s.info[agent.Endpoint()]
+		if !ok || !info.Supports(source) {
+			continue
+		}
+		load := agentLoad(info, s.assigned[agent.Endpoint()])
+		if best == nil || load < bestLoad {
+			best, bestLoad = agent, load
+		}
+	}
+	if best == nil {
+		return nil, false
+	}
+
+	s.assigned[best.Endpoint()]++
+	return best, true
+}
+
+// Release libera una asignación de Acquire.
+func (s *AgentScheduler) Release(agent ports.Agent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.assigned[agent.Endpoint()] > 0 {
+		s.assigned[agent.Endpoint()]--
+	}
+}
+
+// MarkDown excluye un agente que falló hasta el siguiente Refresh.
+func (s *AgentScheduler) MarkDown(agent ports.Agent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.info, agent.Endpoint())
+}
+
+// agentLoad es la ocupación del agente contando las asignaciones en curso.
+// Sin máximo declarado se usa el número de sources en ejecución.
+func agentLoad(info ports.AgentInfo, assigned int) float64 {
+	running := float64(info.Running + assigned)
+	if info.MaxConcurrent <= 0 {
+		return running
+	}
+	return running / float64(info.MaxConcurrent)
+}
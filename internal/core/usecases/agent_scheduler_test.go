@@ -0,0 +1,164 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+)
+
+// fakeAgent simula un agente remoto que ejecuta sources con run.
+type fakeAgent struct {
+	endpoint string
+	info     ports.AgentInfo
+	infoErr  error
+	runErr   error
+
+	mu   sync.Mutex
+	runs []ports.AgentRunRequest
+}
+
+func (f *fakeAgent) Endpoint() string { return f.endpoint }
+
+func (f *fakeAgent) Info(ctx context.Context) (ports.AgentInfo, error) {
+	return f.info, f.infoErr
+}
+
+func (f *fakeAgent) RunSource(ctx context.Context, req ports.AgentRunRequest, onArtifact func(*domain.Artifact)) (*domain.ScanResult, error) {
+	f.mu.Lock()
+	f.runs = append(f.runs, req)
+	f.mu.Unlock()
+
+	if f.runErr != nil {
+		return nil, f.runErr
+	}
+	result := domain.NewScanResult(req.Target)
+	a := domain.NewArtifact(domain.ArtifactTypeSubdomain, "remote."+req.Target.Root, req.Source)
+	result.AddArtifact(a)
+	if onArtifact != nil {
+		onArtifact(a)
+	}
+	return result, nil
+}
+
+func (f *fakeAgent) runCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.runs)
+}
+
+func TestAgentScheduler_Acquire(t *testing.T) {
+	busy := &fakeAgent{endpoint: "https://busy", info: ports.AgentInfo{Sources: []string{"crtsh", "httpx"}, MaxConcurrent: 4, Running: 3}}
+	idle := &fakeAgent{endpoint: "https://idle", info: ports.AgentInfo{Sources: []string{"httpx"}, MaxConcurrent: 4}}
+	down := &fakeAgent{endpoint: "https://down", infoErr: fmt.Errorf("connection refused")}
+
+	scheduler := NewAgentScheduler([]ports.Agent{busy, idle, down}, logx.NewSilent())
+	testutil.AssertEqual(t, scheduler.Refresh(context.Background()), 2, "available agents")
+
+	t.Run("capability", func(t *testing.T) {
+		agent, ok := scheduler.Acquire("crtsh")
+		testutil.AssertTrue(t, ok, "crtsh should be assigned")
+		testutil.AssertEqual(t, agent.Endpoint(), "https://busy", "only busy runs crtsh")
+		scheduler.Release(agent)
+
+		_, ok = scheduler.Acquire("amass")
+		testutil.AssertFalse(t, ok, "no agent runs amass")
+	})
+
+	t.Run("load", func(t *testing.T) {
+		// idle: 0/4, busy: 3/4 -> idle hasta igualar la carga, luego se alternan
+		var got []string
+		for i := 0; i < 5; i++ {
+			agent, ok := scheduler.Acquire("httpx")
+			testutil.AssertTrue(t, ok, "httpx should be assigned")
+			got = append(got, agent.Endpoint())
+		}
+		testutil.AssertEqual(t, fmt.Sprint(got),
+			"[https://idle https://idle https://idle https://busy https://idle]", "assignments")
+	})
+
+	t.Run("mark down", func(t *testing.T) {
+		scheduler.MarkDown(busy)
+		_, ok := scheduler.Acquire("crtsh")
+		testutil.AssertFalse(t, ok, "agent marked down must not be assigned")
+	})
+}
+
+func TestPipelineOrchestrator_RunsSourcesOnAgents(t *testing.T) {
+	remoteSource := newMockSource("crtsh-mock", domain.SourceModePassive, domain.SourceTypeAPI)
+	localSource := &MockPassiveSource{name: "rdap-mock"}
+	consumer := &MockActiveSource{name: "httpx-mock"}
+
+	sourceMetadata := map[string]ports.SourceMetadata{
+		"crtsh-mock": {Name: "crtsh-mock", OutputArtifacts: []domain.ArtifactType{domain.ArtifactTypeSubdomain}},
+		"rdap-mock":  {Name: "rdap-mock", OutputArtifacts: []domain.ArtifactType{domain.ArtifactTypeDomain}},
+		"httpx-mock": {
+			Name:            "httpx-mock",
+			InputArtifacts:  []domain.ArtifactType{domain.ArtifactTypeSubdomain},
+			OutputArtifacts: []domain.ArtifactType{domain.ArtifactTypeURL},
+		},
+	}
+
+	agent := &fakeAgent{
+		endpoint: "https://agent-eu",
+		info:     ports.AgentInfo{ID: "eu", Sources: []string{"crtsh-mock", "httpx-mock"}, MaxConcurrent: 2},
+	}
+
+	orch := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources:        []ports.Source{remoteSource, localSource, consumer},
+		SourceMetadata: sourceMetadata,
+		Logger:         logx.NewSilent(),
+		MaxWorkers:     2,
+		Scheduler:      NewAgentScheduler([]ports.Agent{agent}, logx.NewSilent()),
+	})
+
+	result, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModeHybrid))
+	testutil.AssertNoError(t, err, "pipeline should succeed")
+	testutil.AssertEqual(t, agent.runCount(), 2, "crtsh-mock and httpx-mock run on the agent")
+
+	values := make(map[string]bool)
+	for _, a := range result.Artifacts {
+		values[a.Value] = true
+	}
+	testutil.AssertTrue(t, values["remote.example.com"], "remote artifacts are consolidated")
+	testutil.AssertTrue(t, values["example.com"], "rdap-mock runs locally")
+	testutil.AssertEqual(t, remoteSource.runCallCount, 0, "crtsh-mock must not also run locally")
+
+	// El consumer recibe en el agente los artifacts filtrados del stage previo
+	for _, run := range agent.runs {
+		if run.Source == "httpx-mock" {
+			testutil.AssertNotNil(t, run.Input, "consumer input is sent to the agent")
+			for _, a := range run.Input.Artifacts {
+				testutil.AssertEqual(t, a.Type, domain.ArtifactTypeSubdomain, "input filtered by InputArtifacts")
+			}
+		}
+	}
+}
+
+func TestPipelineOrchestrator_AgentUnavailableFallsBackToLocal(t *testing.T) {
+	source := newMockSource("crtsh-mock", domain.SourceModePassive, domain.SourceTypeAPI)
+	agent := &fakeAgent{
+		endpoint: "https://agent-down",
+		info:     ports.AgentInfo{Sources: []string{"crtsh-mock"}},
+		runErr:   fmt.Errorf("%w: connection reset", ports.ErrAgentUnavailable),
+	}
+
+	orch := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources: []ports.Source{source},
+		SourceMetadata: map[string]ports.SourceMetadata{
+			"crtsh-mock": {Name: "crtsh-mock", OutputArtifacts: []domain.ArtifactType{domain.ArtifactTypeSubdomain}},
+		},
+		Logger:    logx.NewSilent(),
+		Scheduler: NewAgentScheduler([]ports.Agent{agent}, logx.NewSilent()),
+	})
+
+	_, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModePassive))
+	testutil.AssertNoError(t, err, "pipeline should succeed")
+	testutil.AssertEqual(t, agent.runCount(), 1, "agent was tried")
+	testutil.AssertEqual(t, source.runCallCount, 1, "source ran locally after the agent failed")
+}
@@ -3,25 +3,39 @@ package usecases
 
 import (
 	"sort"
+	"sync"
 
 	"aethonx/internal/core/domain"
 )
 
 // DedupeService maneja la deduplicación y normalización de artifacts.
-type DedupeService struct{}
+// Recuerda los IDs reemplazados entre pasadas (deduplicación incremental por
+// stage) para reescribir relaciones que apunten a un ID obsoleto.
+type DedupeService struct {
+	mu    sync.Mutex
+	remap map[string]string // ID antiguo -> ID canónico
+}
 
 // NewDedupeService crea una nueva instancia del servicio.
 func NewDedupeService() *DedupeService {
-	return &DedupeService{}
+	return &DedupeService{remap: make(map[string]string)}
 }
 
 // Deduplicate normaliza y elimina duplicados de una lista de artifacts.
 // Si un mismo artifact aparece múltiples veces, combina sus fuentes y metadata.
+// Cada artifact superviviente recibe el ID canónico de su type:value y las
+// relaciones que apuntaban a un ID sustituido se redirigen a él.
 func (d *DedupeService) Deduplicate(artifacts []*domain.Artifact) []*domain.Artifact {
 	if len(artifacts) == 0 {
 		return artifacts
 	}
 
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.remap == nil {
+		d.remap = make(map[string]string)
+	}
+
 	// Mapa para tracking: key -> artifact
 	seen := make(map[string]*domain.Artifact)
 
@@ -33,6 +47,14 @@ func (d *DedupeService) Deduplicate(artifacts []*domain.Artifact) []*domain.Arti
 		// Normalizar artifact
 		a.Normalize()
 
+		// La normalización puede cambiar type:value; el ID debe seguirlo
+		if canonical := a.GenerateID(); a.ID != canonical {
+			if a.ID != "" {
+				d.remap[a.ID] = canonical
+			}
+			a.ID = canonical
+		}
+
 		// Generar key única
 		key := a.Key()
 
@@ -51,6 +73,7 @@ func (d *DedupeService) Deduplicate(artifacts []*domain.Artifact) []*domain.Arti
 	// Convertir mapa a slice
 	result := make([]*domain.Artifact, 0, len(seen))
 	for _, a := range seen {
+		d.rewriteRelations(a)
 		result = append(result, a)
 	}
 
@@ -60,6 +83,62 @@ func (d *DedupeService) Deduplicate(artifacts []*domain.Artifact) []*domain.Arti
 	return result
 }
 
+// IDMapping retorna una copia del mapeo ID antiguo -> ID canónico acumulado
+// en todas las pasadas de Deduplicate.
+func (d *DedupeService) IDMapping() map[string]string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	mapping := make(map[string]string, len(d.remap))
+	for old := range d.remap {
+		mapping[old] = d.resolve(old)
+	}
+	return mapping
+}
+
+// resolve sigue la cadena de reemplazos de un ID hasta el canónico.
+// El límite de saltos protege de ciclos en IDs cargados de disco.
+func (d *DedupeService) resolve(id string) string {
+	for i := 0; i <= len(d.remap); i++ {
+		next, ok := d.remap[id]
+		if !ok || next == id {
+			return id
+		}
+		id = next
+	}
+	return id
+}
+
+// rewriteRelations redirige las relaciones de a hacia IDs canónicos y
+// descarta las que quedan duplicadas o apuntan al propio artifact tras
+// la fusión.
+func (d *DedupeService) rewriteRelations(a *domain.Artifact) {
+	if len(d.remap) == 0 || len(a.Relations) == 0 {
+		return
+	}
+
+	type relKey struct {
+		target string
+		typ    domain.RelationType
+	}
+	kept := make(map[relKey]bool, len(a.Relations))
+	relations := a.Relations[:0]
+	for _, rel := range a.Relations {
+		target := d.resolve(rel.TargetID)
+		if target != rel.TargetID && target == a.ID {
+			continue
+		}
+		k := relKey{target: target, typ: rel.Type}
+		if kept[k] {
+			continue
+		}
+		kept[k] = true
+		rel.TargetID = target
+		relations = append(relations, rel)
+	}
+	a.Relations = relations
+}
+
 // sortArtifacts ordena artifacts por tipo y luego por valor.
 func (d *DedupeService) sortArtifacts(artifacts []*domain.Artifact) {
 	sort.Slice(artifacts, func(i, j int) bool {
@@ -89,6 +89,72 @@ func TestDedupeService_Deduplicate_MergesSources(t *testing.T) {
 	testutil.AssertContains(t, result[0].Sources, "dnsx", "sources")
 }
 
+func TestDedupeService_Deduplicate_RewritesRelationsToCanonicalID(t *testing.T) {
+	svc := NewDedupeService()
+
+	// Mismo subdominio: uno cargado de un scan previo con un ID obsoleto
+	legacy := domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "amass")
+	legacy.ID = "legacy-api"
+	legacy.AddRelation("legacy-api", domain.RelationSubdomainOf, domain.ConfidenceHigh, "amass")
+	fresh := domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "crtsh")
+
+	url := domain.NewArtifact(domain.ArtifactTypeURL, "https://api.example.com/", "httpx")
+	url.AddRelation("legacy-api", domain.RelationHostedOn, domain.ConfidenceHigh, "httpx")
+	url.AddRelation(fresh.ID, domain.RelationHostedOn, domain.ConfidenceHigh, "katana")
+	ip := domain.NewArtifact(domain.ArtifactTypeIP, "192.0.2.10", "dns")
+	ip.AddRelation("legacy-api", domain.RelationReverseResolves, domain.ConfidenceHigh, "dns")
+
+	result := svc.Deduplicate([]*domain.Artifact{legacy, url, fresh, ip})
+	testutil.AssertEqual(t, len(result), 3, "subdomain duplicates merged")
+
+	mapping := svc.IDMapping()
+	testutil.AssertEqual(t, mapping["legacy-api"], fresh.ID, "legacy ID mapped to canonical")
+
+	for _, a := range result {
+		switch a.Type {
+		case domain.ArtifactTypeSubdomain:
+			testutil.AssertEqual(t, a.ID, fresh.ID, "merged artifact keeps canonical ID")
+			testutil.AssertEqual(t, len(a.Relations), 0, "self relation created by the merge is dropped")
+		case domain.ArtifactTypeURL:
+			testutil.AssertEqual(t, len(a.Relations), 1, "relations collapsed after rewrite")
+			testutil.AssertEqual(t, a.Relations[0].TargetID, fresh.ID, "url relation rewritten")
+		case domain.ArtifactTypeIP:
+			testutil.AssertEqual(t, a.Relations[0].TargetID, fresh.ID, "ip relation rewritten")
+		}
+	}
+}
+
+func TestDedupeService_Deduplicate_ResolvesChainsAcrossPasses(t *testing.T) {
+	svc := NewDedupeService()
+
+	// Pasada 1 (stage 1): ID obsoleto sustituido por el canónico del subdominio
+	stale := domain.NewArtifact(domain.ArtifactTypeSubdomain, "example.com", "crtsh")
+	stale.ID = "v1-example"
+	svc.Deduplicate([]*domain.Artifact{stale})
+	subdomainID := stale.ID
+	testutil.AssertTrue(t, subdomainID != "v1-example", "stale ID replaced")
+
+	// Pasada 2 (stage 2): otra fuente lo reclasifica como dominio raíz
+	stale.Type = domain.ArtifactTypeDomain
+	rdap := domain.NewArtifact(domain.ArtifactTypeDomain, "example.com", "rdap")
+	svc.Deduplicate([]*domain.Artifact{stale, rdap})
+
+	// Pasada final: una relación volcada a disco antes de ambas pasadas
+	ip := domain.NewArtifact(domain.ArtifactTypeIP, "192.0.2.10", "dns")
+	ip.AddRelation("v1-example", domain.RelationReverseResolves, domain.ConfidenceHigh, "dns")
+	ip.AddRelation(subdomainID, domain.RelationReverseResolves, domain.ConfidenceHigh, "pdns")
+	result := svc.Deduplicate([]*domain.Artifact{ip, rdap})
+
+	testutil.AssertEqual(t, len(result), 2, "artifacts")
+	testutil.AssertEqual(t, svc.IDMapping()["v1-example"], rdap.ID, "chain resolved to final canonical ID")
+	for _, a := range result {
+		if a.Type == domain.ArtifactTypeIP {
+			testutil.AssertEqual(t, len(a.Relations), 1, "chained relations collapsed")
+			testutil.AssertEqual(t, a.Relations[0].TargetID, rdap.ID, "relation follows the chain")
+		}
+	}
+}
+
 func TestDedupeService_Deduplicate_SortsOutput(t *testing.T) {
 	svc := NewDedupeService()
 
@@ -122,7 +188,7 @@ func TestDedupeService_Deduplicate_SortsOutput(t *testing.T) {
 	if len(subdomains) >= 2 {
 		// api.example.com should come before www.example.com
 		testutil.AssertEqual(t, subdomains[0].Value, "api.example.com", "first subdomain")
-		testutil.AssertEqual(t, subdomains[1].Value, "example.com", "second subdomain (www removed)")
+		testutil.AssertEqual(t, subdomains[1].Value, "www.example.com", "second subdomain (www kept)")
 	}
 }
 
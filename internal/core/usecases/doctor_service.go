@@ -0,0 +1,133 @@
+// internal/core/usecases/doctor_service.go
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+)
+
+// Estados de un CheckResult.
+const (
+	CheckOK      = "ok"
+	CheckFailed  = "failed"
+	CheckSkipped = "skipped"
+)
+
+// CheckResult es el resultado de una comprobación de "aethonx doctor".
+type CheckResult struct {
+	Status   string        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// SourceHealth es el diagnóstico de una source.
+type SourceHealth struct {
+	Source        string      `json:"source"`
+	Initialize    CheckResult `json:"initialize"`
+	Validate      CheckResult `json:"validate"`
+	Health        CheckResult `json:"health"`
+	Live          CheckResult `json:"live"`
+	LiveArtifacts int         `json:"live_artifacts,omitempty"`
+	Version       string      `json:"version,omitempty"`
+	Quota         string      `json:"quota,omitempty"`
+}
+
+// Ready indica si ninguna comprobación falló.
+func (h SourceHealth) Ready() bool {
+	for _, c := range []CheckResult{h.Initialize, h.Validate, h.Health, h.Live} {
+		if c.Status == CheckFailed {
+			return false
+		}
+	}
+	return true
+}
+
+// DiagnoseOptions configura DiagnoseSource.
+type DiagnoseOptions struct {
+	Config      ports.SourceConfig // Se pasa a AdvancedSource.Initialize
+	LiveTarget  *domain.Target     // Target de la llamada real (nil = omitirla)
+	LiveTimeout time.Duration      // Timeout de la llamada real (0 = 30s)
+}
+
+// Las sources implementan Initialize sin argumentos (BaseCLISource) o con
+// contexto y configuración (ports.AdvancedSource); se aceptan ambas formas.
+type (
+	initializer     interface{ Initialize() error }
+	configValidator interface{ Validate() error }
+	healthChecker   interface {
+		HealthCheck(ctx context.Context) error
+	}
+)
+
+// DiagnoseSource ejecuta sobre src las comprobaciones opcionales que
+// implemente (Initialize, Validate, HealthCheck, Diagnostics) y, si hay
+// LiveTarget, una ejecución real contra él. Health y Live se omiten si
+// Initialize falla (e.g., binario ausente).
+func DiagnoseSource(ctx context.Context, src ports.Source, opts DiagnoseOptions) SourceHealth {
+	h := SourceHealth{Source: src.Name()}
+
+	switch s := src.(type) {
+	case ports.AdvancedSource:
+		h.Initialize = runCheck(func() error { return s.Initialize(ctx, opts.Config) })
+	case initializer:
+		h.Initialize = runCheck(s.Initialize)
+	default:
+		h.Initialize = CheckResult{Status: CheckSkipped}
+	}
+
+	h.Validate = CheckResult{Status: CheckSkipped}
+	if v, ok := src.(configValidator); ok {
+		h.Validate = runCheck(v.Validate)
+	}
+
+	h.Health = CheckResult{Status: CheckSkipped}
+	h.Live = CheckResult{Status: CheckSkipped}
+	if h.Initialize.Status == CheckFailed {
+		return h
+	}
+
+	if hc, ok := src.(healthChecker); ok {
+		h.Health = runCheck(func() error { return hc.HealthCheck(ctx) })
+	}
+
+	if d, ok := src.(ports.DiagnosticSource); ok {
+		// Best-effort: un fallo aquí no afecta a la disponibilidad
+		if diag, err := d.Diagnostics(ctx); err == nil {
+			h.Version = diag.Version
+			h.Quota = diag.Quota
+		}
+	}
+
+	if opts.LiveTarget != nil {
+		timeout := opts.LiveTimeout
+		if timeout <= 0 {
+			timeout = 30 * time.Second
+		}
+		liveCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		h.Live = runCheck(func() error {
+			result, err := src.Run(liveCtx, *opts.LiveTarget)
+			if result != nil {
+				h.LiveArtifacts = len(result.Artifacts)
+			}
+			return err
+		})
+	}
+
+	return h
+}
+
+func runCheck(check func() error) CheckResult {
+	start := time.Now()
+	err := check()
+	result := CheckResult{Status: CheckOK, Duration: time.Since(start)}
+	if err != nil {
+		result.Status = CheckFailed
+		result.Error = err.Error()
+	}
+	return result
+}
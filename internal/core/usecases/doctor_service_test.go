@@ -0,0 +1,84 @@
+// internal/core/usecases/doctor_service_test.go
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/testutil"
+)
+
+// checkedSource implementa las comprobaciones opcionales de DiagnoseSource
+type checkedSource struct {
+	*mockSource
+	initErr   error
+	healthErr error
+	checks    []string
+}
+
+func (c *checkedSource) Initialize() error {
+	c.checks = append(c.checks, "initialize")
+	return c.initErr
+}
+
+func (c *checkedSource) Validate() error {
+	c.checks = append(c.checks, "validate")
+	return nil
+}
+
+func (c *checkedSource) HealthCheck(ctx context.Context) error {
+	c.checks = append(c.checks, "health")
+	return c.healthErr
+}
+
+func (c *checkedSource) Diagnostics(ctx context.Context) (ports.SourceDiagnostics, error) {
+	return ports.SourceDiagnostics{Version: "v1.2.3", Quota: "plan dev"}, nil
+}
+
+func TestDiagnoseSource_AllChecks(t *testing.T) {
+	src := &checkedSource{mockSource: mockSourceWithArtifacts("tool", []*domain.Artifact{
+		domain.NewArtifact(domain.ArtifactTypeSubdomain, "www.example.com", "tool"),
+	})}
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+
+	h := DiagnoseSource(context.Background(), src, DiagnoseOptions{LiveTarget: target})
+
+	testutil.AssertTrue(t, h.Ready(), "all checks pass")
+	testutil.AssertEqual(t, h.Initialize.Status, CheckOK, "initialize")
+	testutil.AssertEqual(t, h.Validate.Status, CheckOK, "validate")
+	testutil.AssertEqual(t, h.Health.Status, CheckOK, "health")
+	testutil.AssertEqual(t, h.Live.Status, CheckOK, "live")
+	testutil.AssertEqual(t, h.LiveArtifacts, 1, "live artifacts")
+	testutil.AssertEqual(t, h.Version, "v1.2.3", "version")
+	testutil.AssertEqual(t, h.Quota, "plan dev", "quota")
+}
+
+func TestDiagnoseSource_InitializeFailureSkipsLiveChecks(t *testing.T) {
+	src := &checkedSource{mockSource: newMockSource("tool", domain.SourceModePassive, domain.SourceTypeCLI), initErr: errors.New("not found")}
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+
+	h := DiagnoseSource(context.Background(), src, DiagnoseOptions{LiveTarget: target})
+
+	testutil.AssertFalse(t, h.Ready(), "initialize failed")
+	testutil.AssertEqual(t, h.Initialize.Error, "not found", "error kept")
+	testutil.AssertEqual(t, h.Validate.Status, CheckOK, "validate still runs")
+	testutil.AssertEqual(t, h.Health.Status, CheckSkipped, "health skipped")
+	testutil.AssertEqual(t, h.Live.Status, CheckSkipped, "live skipped")
+	testutil.AssertEqual(t, src.runCallCount, 0, "source not run")
+}
+
+func TestDiagnoseSource_PlainSource(t *testing.T) {
+	src := mockSourceWithError("plain", errors.New("upstream down"))
+
+	h := DiagnoseSource(context.Background(), src, DiagnoseOptions{})
+	testutil.AssertTrue(t, h.Ready(), "no checks implemented")
+	testutil.AssertEqual(t, h.Initialize.Status, CheckSkipped, "initialize")
+	testutil.AssertEqual(t, h.Live.Status, CheckSkipped, "no live target")
+
+	h = DiagnoseSource(context.Background(), src, DiagnoseOptions{LiveTarget: &domain.Target{Root: "example.com"}})
+	testutil.AssertFalse(t, h.Ready(), "live call failed")
+	testutil.AssertEqual(t, h.Live.Error, "upstream down", "live error")
+}
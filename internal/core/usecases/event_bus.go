@@ -0,0 +1,313 @@
+// internal/core/usecases/event_bus.go
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+)
+
+const (
+	// defaultEventQueueSize eventos en cola por observer
+	defaultEventQueueSize = 256
+
+	// defaultNotifyTimeout tiempo máximo de un Notify
+	defaultNotifyTimeout = 5 * time.Second
+
+	// defaultDrainTimeout tiempo máximo para vaciar las colas en Close
+	defaultDrainTimeout = 10 * time.Second
+)
+
+// EventBusOptions configura un EventBus.
+type EventBusOptions struct {
+	// QueueSize eventos en cola por observer (0 = defaultEventQueueSize)
+	QueueSize int
+
+	// NotifyTimeout tiempo máximo de cada Notify (0 = defaultNotifyTimeout)
+	NotifyTimeout time.Duration
+
+	// DrainTimeout tiempo máximo de Close para entregar lo encolado
+	// (0 = defaultDrainTimeout)
+	DrainTimeout time.Duration
+
+	// Spool persiste los eventos no entregados (opcional)
+	Spool ports.EventSpool
+
+	Logger logx.Logger
+}
+
+// EventBusStats contadores de entrega de un observer.
+type EventBusStats struct {
+	Observer  string
+	Delivered int64
+	Failed    int64 // Notify devolvió error o excedió el timeout
+	Dropped   int64 // Cola llena al publicar
+	Spooled   int64 // Persistidos en el spool para reintento
+}
+
+// EventBus entrega eventos a los observers a través de una cola acotada por
+// observer consumida por una única goroutine, de modo que cada observer
+// recibe los eventos en el orden en que se publicaron. Publish nunca
+// bloquea: con la cola llena el evento se descarta (y se persiste en el
+// spool si hay uno). Los eventos que fallan o no llegan a entregarse antes
+// de Close también van al spool.
+type EventBus struct {
+	queues        []*observerQueue
+	spool         ports.EventSpool
+	notifyTimeout time.Duration
+	drainTimeout  time.Duration
+	logger        logx.Logger
+
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+	abandoned atomic.Bool // Drain timeout vencido: el resto va al spool
+}
+
+// observerQueue cola y contadores de un observer.
+type observerQueue struct {
+	name     string
+	notifier ports.Notifier
+	ch       chan ports.Event
+
+	// mu serializa Publish con el cierre del canal
+	mu     sync.RWMutex
+	closed bool
+
+	delivered atomic.Int64
+	failed    atomic.Int64
+	dropped   atomic.Int64
+	spooled   atomic.Int64
+}
+
+// NewEventBus crea el bus y arranca un consumidor por observer.
+func NewEventBus(observers []ports.Notifier, opts EventBusOptions) *EventBus {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = defaultEventQueueSize
+	}
+	if opts.NotifyTimeout <= 0 {
+		opts.NotifyTimeout = defaultNotifyTimeout
+	}
+	if opts.DrainTimeout <= 0 {
+		opts.DrainTimeout = defaultDrainTimeout
+	}
+	if opts.Logger == nil {
+		opts.Logger = logx.NewSilent()
+	}
+
+	b := &EventBus{
+		spool:         opts.Spool,
+		notifyTimeout: opts.NotifyTimeout,
+		drainTimeout:  opts.DrainTimeout,
+		logger:        opts.Logger.With("component", "event-bus"),
+	}
+	for _, notifier := range observers {
+		q := &observerQueue{
+			name:     ObserverName(notifier),
+			notifier: notifier,
+			ch:       make(chan ports.Event, opts.QueueSize),
+		}
+		b.queues = append(b.queues, q)
+		b.wg.Add(1)
+		go b.consume(q)
+	}
+	return b
+}
+
+// ObserverName identifica a un observer en el spool y en las métricas: su
+// Name() si lo implementa o, si no, su tipo concreto.
+func ObserverName(notifier ports.Notifier) string {
+	if named, ok := notifier.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("%T", notifier)
+}
+
+// Publish encola el evento para cada observer sin bloquear. Es seguro
+// llamarlo sobre un bus nil o ya cerrado (el evento se ignora).
+func (b *EventBus) Publish(event ports.Event) {
+	if b == nil {
+		return
+	}
+	for _, q := range b.queues {
+		q.mu.RLock()
+		if q.closed {
+			q.mu.RUnlock()
+			continue
+		}
+		select {
+		case q.ch <- event:
+			q.mu.RUnlock()
+		default:
+			q.mu.RUnlock()
+			q.dropped.Add(1)
+			b.logger.Warn("event queue full, event dropped",
+				"observer", q.name,
+				"event_type", event.Type,
+			)
+			b.persist(q, event)
+		}
+	}
+}
+
+// consume entrega en orden los eventos de la cola de un observer.
+func (b *EventBus) consume(q *observerQueue) {
+	defer b.wg.Done()
+	for event := range q.ch {
+		if b.abandoned.Load() {
+			b.persist(q, event)
+			continue
+		}
+		if err := b.deliver(q.notifier, event); err != nil {
+			q.failed.Add(1)
+			b.logger.Warn("notification failed",
+				"observer", q.name,
+				"event_type", event.Type,
+				"error", err.Error(),
+			)
+			b.persist(q, event)
+			continue
+		}
+		q.delivered.Add(1)
+	}
+}
+
+// deliver llama a Notify acotado por notifyTimeout. Un Notify que ignora
+// el contexto no bloquea la cola más allá del timeout.
+func (b *EventBus) deliver(notifier ports.Notifier, event ports.Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.notifyTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- notifier.Notify(ctx, event)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("notification timeout exceeded (%s)", b.notifyTimeout)
+	}
+}
+
+// persist guarda el evento en el spool si hay uno configurado.
+func (b *EventBus) persist(q *observerQueue, event ports.Event) {
+	if b.spool == nil {
+		return
+	}
+	if err := b.spool.Save(q.name, event); err != nil {
+		b.logger.Warn("failed to spool event",
+			"observer", q.name,
+			"event_type", event.Type,
+			"error", err.Error(),
+		)
+		return
+	}
+	q.spooled.Add(1)
+}
+
+// Close deja de aceptar eventos y espera a que se entregue lo encolado
+// durante drainTimeout como máximo; lo que quede después va al spool.
+// Retorna las métricas finales por observer.
+func (b *EventBus) Close() []EventBusStats {
+	if b == nil {
+		return nil
+	}
+	b.closeOnce.Do(func() {
+		for _, q := range b.queues {
+			q.mu.Lock()
+			q.closed = true
+			close(q.ch)
+			q.mu.Unlock()
+		}
+
+		done := make(chan struct{})
+		go func() {
+			b.wg.Wait()
+			close(done)
+		}()
+
+		timer := time.NewTimer(b.drainTimeout)
+		defer timer.Stop()
+		select {
+		case <-done:
+		case <-timer.C:
+			b.logger.Warn("event drain timeout exceeded, spooling remaining events",
+				"timeout", b.drainTimeout,
+			)
+			b.abandoned.Store(true)
+			<-done
+		}
+	})
+	return b.Stats()
+}
+
+// Stats retorna los contadores de entrega por observer.
+func (b *EventBus) Stats() []EventBusStats {
+	if b == nil {
+		return nil
+	}
+	stats := make([]EventBusStats, 0, len(b.queues))
+	for _, q := range b.queues {
+		stats = append(stats, EventBusStats{
+			Observer:  q.name,
+			Delivered: q.delivered.Load(),
+			Failed:    q.failed.Load(),
+			Dropped:   q.dropped.Load(),
+			Spooled:   q.spooled.Load(),
+		})
+	}
+	return stats
+}
+
+// logEventStats registra las métricas de entrega de los observers con
+// pérdidas o fallos.
+func logEventStats(logger logx.Logger, stats []EventBusStats) {
+	for _, s := range stats {
+		if s.Failed == 0 && s.Dropped == 0 {
+			continue
+		}
+		logger.Warn("events not delivered",
+			"observer", s.Observer,
+			"delivered", s.Delivered,
+			"failed", s.Failed,
+			"dropped", s.Dropped,
+			"spooled", s.Spooled,
+		)
+	}
+}
+
+// ReplaySpooledEvents reentrega en orden los eventos pendientes de un
+// observer y descarta del spool los entregados. Se detiene en el primer
+// fallo para no alterar el orden. Retorna cuántos eventos se entregaron.
+func ReplaySpooledEvents(ctx context.Context, spool ports.EventSpool, notifier ports.Notifier) (int, error) {
+	name := ObserverName(notifier)
+	pending, err := spool.Pending(name)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load spooled events for %s: %w", name, err)
+	}
+
+	delivered := 0
+	var notifyErr error
+	for _, event := range pending {
+		if notifyErr = notifier.Notify(ctx, event); notifyErr != nil {
+			break
+		}
+		delivered++
+	}
+
+	if delivered > 0 {
+		if err := spool.Ack(name, delivered); err != nil {
+			return delivered, fmt.Errorf("failed to ack spooled events for %s: %w", name, err)
+		}
+	}
+	if notifyErr != nil {
+		return delivered, fmt.Errorf("replay to %s stopped after %d events: %w", name, delivered, notifyErr)
+	}
+	return delivered, nil
+}
@@ -0,0 +1,185 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/ports"
+	"aethonx/internal/testutil"
+)
+
+// memSpool implementa ports.EventSpool en memoria.
+type memSpool struct {
+	mu     sync.Mutex
+	events map[string][]ports.Event
+}
+
+func newMemSpool() *memSpool {
+	return &memSpool{events: make(map[string][]ports.Event)}
+}
+
+func (s *memSpool) Save(observer string, event ports.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events[observer] = append(s.events[observer], event)
+	return nil
+}
+
+func (s *memSpool) Pending(observer string) ([]ports.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ports.Event(nil), s.events[observer]...), nil
+}
+
+func (s *memSpool) Ack(observer string, n int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events[observer] = s.events[observer][n:]
+	return nil
+}
+
+func numberedEvent(i int) ports.Event {
+	event := ports.NewEvent(ports.EventTypeSourceCompleted, "test", i)
+	event.Metadata["seq"] = fmt.Sprint(i)
+	return event
+}
+
+func TestEventBus_PreservesOrder(t *testing.T) {
+	slow := newMockNotifier()
+	slow.notifyFunc = func(ctx context.Context, event ports.Event) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	}
+	fast := newMockNotifier()
+
+	bus := NewEventBus([]ports.Notifier{slow, fast}, EventBusOptions{QueueSize: 100})
+	for i := 0; i < 50; i++ {
+		bus.Publish(numberedEvent(i))
+	}
+	stats := bus.Close()
+
+	for _, notifier := range []*mockNotifier{slow, fast} {
+		testutil.AssertEqual(t, len(notifier.events), 50, "all events delivered")
+		for i, event := range notifier.events {
+			testutil.AssertEqual(t, event.Data, i, "delivery order")
+		}
+	}
+	testutil.AssertEqual(t, stats[0].Delivered, int64(50), "delivered counter")
+	testutil.AssertEqual(t, stats[0].Dropped, int64(0), "nothing dropped")
+}
+
+func TestEventBus_DropsWhenQueueFullAndSpools(t *testing.T) {
+	release := make(chan struct{})
+	blocked := newMockNotifier()
+	blocked.notifyFunc = func(ctx context.Context, event ports.Event) error {
+		<-release
+		return nil
+	}
+	spool := newMemSpool()
+
+	bus := NewEventBus([]ports.Notifier{blocked}, EventBusOptions{QueueSize: 2, Spool: spool})
+	bus.Publish(numberedEvent(0))
+	// Esperar a que el consumidor tome el primero y quede bloqueado
+	deadline := time.Now().Add(time.Second)
+	for blocked.getNotifyCallCount() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	for i := 1; i <= 4; i++ {
+		bus.Publish(numberedEvent(i))
+	}
+	close(release)
+	stats := bus.Close()
+
+	testutil.AssertEqual(t, stats[0].Delivered, int64(3), "first event plus a full queue")
+	testutil.AssertEqual(t, stats[0].Dropped, int64(2), "overflow counted")
+	testutil.AssertEqual(t, stats[0].Spooled, int64(2), "overflow spooled")
+
+	pending, _ := spool.Pending(ObserverName(blocked))
+	testutil.AssertEqual(t, len(pending), 2, "dropped events persisted")
+	testutil.AssertEqual(t, pending[0].Data, 3, "oldest dropped first")
+}
+
+func TestEventBus_FailedDeliverySpooledAndReplayed(t *testing.T) {
+	down := true
+	webhook := newMockNotifier()
+	webhook.notifyFunc = func(ctx context.Context, event ports.Event) error {
+		if down {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	spool := newMemSpool()
+
+	bus := NewEventBus([]ports.Notifier{webhook}, EventBusOptions{Spool: spool})
+	for i := 0; i < 3; i++ {
+		bus.Publish(numberedEvent(i))
+	}
+	stats := bus.Close()
+	testutil.AssertEqual(t, stats[0].Failed, int64(3), "failures counted")
+	testutil.AssertEqual(t, stats[0].Spooled, int64(3), "failures spooled")
+
+	// Publicar tras Close no entrega ni bloquea
+	bus.Publish(numberedEvent(99))
+
+	down = false
+	replayed, err := ReplaySpooledEvents(context.Background(), spool, webhook)
+	testutil.AssertNoError(t, err, "replay")
+	testutil.AssertEqual(t, replayed, 3, "replayed events")
+
+	pending, _ := spool.Pending(ObserverName(webhook))
+	testutil.AssertEqual(t, len(pending), 0, "spool drained")
+}
+
+func TestReplaySpooledEvents_StopsAtFirstFailure(t *testing.T) {
+	webhook := newMockNotifier()
+	spool := newMemSpool()
+	for i := 0; i < 3; i++ {
+		spool.Save(ObserverName(webhook), numberedEvent(i))
+	}
+	webhook.notifyFunc = func(ctx context.Context, event ports.Event) error {
+		if event.Data == 1 {
+			return errors.New("503")
+		}
+		return nil
+	}
+
+	replayed, err := ReplaySpooledEvents(context.Background(), spool, webhook)
+	testutil.AssertError(t, err, "replay interrupted")
+	testutil.AssertEqual(t, replayed, 1, "delivered before the failure")
+
+	pending, _ := spool.Pending(ObserverName(webhook))
+	testutil.AssertEqual(t, len(pending), 2, "failed and later events kept")
+	testutil.AssertEqual(t, pending[0].Data, 1, "order kept")
+}
+
+func TestEventBus_DrainTimeoutSpoolsRemaining(t *testing.T) {
+	hung := newMockNotifier()
+	hung.notifyFunc = func(ctx context.Context, event ports.Event) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	spool := newMemSpool()
+
+	bus := NewEventBus([]ports.Notifier{hung}, EventBusOptions{
+		NotifyTimeout: 50 * time.Millisecond,
+		DrainTimeout:  20 * time.Millisecond,
+		Spool:         spool,
+	})
+	for i := 0; i < 5; i++ {
+		bus.Publish(numberedEvent(i))
+	}
+	stats := bus.Close()
+
+	testutil.AssertEqual(t, stats[0].Delivered, int64(0), "nothing delivered")
+	testutil.AssertEqual(t, stats[0].Spooled, int64(5), "every event spooled")
+}
+
+func TestEventBus_NilSafe(t *testing.T) {
+	var bus *EventBus
+	bus.Publish(numberedEvent(0))
+	testutil.AssertEqual(t, len(bus.Close()), 0, "nil bus has no stats")
+}
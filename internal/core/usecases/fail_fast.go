@@ -0,0 +1,37 @@
+// internal/core/usecases/fail_fast.go
+package usecases
+
+import (
+	"fmt"
+	"strings"
+
+	"aethonx/internal/core/domain"
+)
+
+// isCritical indica si el fallo de source en stage debe abortar el pipeline:
+// la source es crítica o lo es todo su stage.
+func (p *PipelineOrchestrator) isCritical(stage Stage, source string) bool {
+	return p.criticalSources[source] || p.criticalStages[stage.ID]
+}
+
+// criticalFailure construye el error con el que Run aborta el pipeline tras
+// el fallo de una source crítica.
+func criticalFailure(stage Stage, source string, err error) error {
+	return fmt.Errorf("%w: %s in stage %d (%s): %v", domain.ErrCriticalSourceFailed, source, stage.ID, stage.Name, err)
+}
+
+// abortStages registra en result los stages que no llegan a ejecutarse
+// porque una source crítica abortó el pipeline.
+func (p *PipelineOrchestrator) abortStages(stages []Stage, result *domain.ScanResult, cause error) {
+	names := make([]string, 0, len(stages))
+	for _, stage := range stages {
+		names = append(names, stage.Name)
+	}
+
+	p.logger.Warn("pipeline aborted by a critical source", "error", cause.Error(), "skipped_stages", names)
+	message := "pipeline aborted: " + cause.Error()
+	if len(names) > 0 {
+		message += "; stages skipped: " + strings.Join(names, ", ")
+	}
+	result.AddWarning("pipeline", message)
+}
@@ -0,0 +1,89 @@
+// internal/core/usecases/fail_fast_test.go
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/ui"
+	"aethonx/internal/testutil"
+)
+
+// failFastPipeline retorna un pipeline de dos stages: "broken" falla, "slow"
+// aporta un artifact y espera a la cancelación, y "consumer" (stage 1)
+// registra si llegó a ejecutarse.
+func failFastPipeline(opts PipelineOrchestratorOptions) (*PipelineOrchestrator, *bool) {
+	broken := newMockSource("broken", domain.SourceModePassive, domain.SourceTypeAPI)
+	broken.runFunc = func(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+		return nil, errors.New("upstream unavailable")
+	}
+	slow := newMockSource("slow", domain.SourceModePassive, domain.SourceTypeAPI)
+	slow.runFunc = func(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+		result := domain.NewScanResult(target)
+		result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "a."+target.Root, "slow"))
+		<-ctx.Done()
+		return result, ctx.Err()
+	}
+	consumerRan := false
+	consumer := &mockInputConsumerSource{
+		name: "consumer",
+		onRunWithInput: func(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+			consumerRan = true
+			return domain.NewScanResult(target), nil
+		},
+	}
+
+	subdomains := []domain.ArtifactType{domain.ArtifactTypeSubdomain}
+	opts.Sources = []ports.Source{broken, slow, consumer}
+	opts.SourceMetadata = map[string]ports.SourceMetadata{
+		"broken":   {Name: "broken", OutputArtifacts: subdomains},
+		"slow":     {Name: "slow", OutputArtifacts: subdomains},
+		"consumer": {Name: "consumer", InputArtifacts: subdomains},
+	}
+	opts.Logger = logx.NewSilent()
+	opts.Presenter = ui.NewNopPresenter()
+	opts.MaxWorkers = 4
+	return NewPipelineOrchestrator(opts), &consumerRan
+}
+
+func TestPipelineOrchestrator_CriticalSourceAborts(t *testing.T) {
+	tests := map[string]PipelineOrchestratorOptions{
+		"critical source": {CriticalSources: []string{"broken"}},
+		"critical stage":  {CriticalStages: []int{0}},
+	}
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			orch, consumerRan := failFastPipeline(opts)
+			result, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModeHybrid))
+
+			testutil.AssertTrue(t, errors.Is(err, domain.ErrCriticalSourceFailed), "run fails with the critical error")
+			testutil.AssertContains(t, err.Error(), "broken in stage 0", "error names source and stage")
+			testutil.AssertTrue(t, !*consumerRan, "later stage not started")
+			testutil.AssertNotNil(t, result, "partial result kept")
+			testutil.AssertTrue(t, result.HasFatalErrors(), "failure recorded as fatal")
+			testutil.AssertEqual(t, len(result.Errors), 1, "cancelled sibling is not a failure")
+			testutil.AssertTrue(t, len(result.Artifacts) > 0, "artifacts of the cancelled source kept")
+			testutil.AssertContains(t, fmt.Sprint(result.Warnings), "pipeline aborted", "abort warning added")
+		})
+	}
+}
+
+func TestPipelineOrchestrator_NonCriticalFailureContinues(t *testing.T) {
+	orch, consumerRan := failFastPipeline(PipelineOrchestratorOptions{
+		CriticalSources: []string{"consumer"},
+		CriticalStages:  []int{1},
+		Limits:          ScanLimits{StageMaxDuration: map[int]time.Duration{0: 30 * time.Millisecond}},
+	})
+	result, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModeHybrid))
+
+	testutil.AssertNoError(t, err, "fail-soft by default")
+	testutil.AssertTrue(t, *consumerRan, "next stage runs")
+	testutil.AssertTrue(t, !result.HasFatalErrors(), "failure is not fatal")
+	testutil.AssertEqual(t, len(result.Errors), 1, "failure still recorded")
+}
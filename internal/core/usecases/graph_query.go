@@ -0,0 +1,306 @@
+// internal/core/usecases/graph_query.go
+package usecases
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"aethonx/internal/core/domain"
+)
+
+// GraphQuery es una consulta de caminos sobre el grafo de relaciones.
+//
+// Sintaxis: nodo ( arista nodo )*
+//
+//	nodo   := tipo | tipo:valor | * | *:valor   (valor admite globs * y ?)
+//	arista := -[rel]->   relación saliente
+//	          <-[rel]-   relación entrante
+//	          (rel = * para cualquier tipo de relación)
+//
+// Ejemplos:
+//
+//	domain:example.com -[resolves_to]-> ip
+//	subdomain:*.dev.example.com -[*]-> *
+//	ip <-[resolves_to]- subdomain
+type GraphQuery struct {
+	Nodes []QueryNode
+	Edges []QueryEdge
+}
+
+// QueryNode selecciona artifacts por tipo y/o patrón de valor.
+type QueryNode struct {
+	Type  domain.ArtifactType // "" = cualquier tipo
+	Value string              // patrón original ("" = cualquier valor)
+	value *regexp.Regexp
+}
+
+// QueryEdge selecciona relaciones por tipo y dirección.
+type QueryEdge struct {
+	Relation domain.RelationType // "" = cualquier relación
+	Reverse  bool                // true = relación entrante (<-[rel]-)
+}
+
+// GraphMatch es un camino que satisface la consulta: Artifacts[i] está
+// conectado con Artifacts[i+1] mediante Edges[i] (con la relación concreta).
+type GraphMatch struct {
+	Artifacts []*domain.Artifact
+	Edges     []QueryEdge
+}
+
+var edgePattern = regexp.MustCompile(`^(<)?-\[([a-z_*]+)\]-(>)?$`)
+
+// ParseGraphQuery parsea una consulta. Los tokens se separan por espacios.
+func ParseGraphQuery(query string) (*GraphQuery, error) {
+	tokens := strings.Fields(query)
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty graph query")
+	}
+	if len(tokens)%2 == 0 {
+		return nil, fmt.Errorf("graph query must end with a node: %q", query)
+	}
+
+	q := &GraphQuery{}
+	for i, tok := range tokens {
+		if i%2 == 0 {
+			node, err := ParseQueryNode(tok)
+			if err != nil {
+				return nil, err
+			}
+			q.Nodes = append(q.Nodes, node)
+			continue
+		}
+
+		edge, err := parseQueryEdge(tok)
+		if err != nil {
+			return nil, err
+		}
+		q.Edges = append(q.Edges, edge)
+	}
+
+	return q, nil
+}
+
+// ParseQueryNode parsea un selector de nodo ("tipo[:valor]").
+func ParseQueryNode(tok string) (QueryNode, error) {
+	if edgePattern.MatchString(tok) {
+		return QueryNode{}, fmt.Errorf("expected node, got edge %q", tok)
+	}
+
+	typ, value, _ := strings.Cut(tok, ":")
+	node := QueryNode{Value: value}
+
+	if typ != "*" && typ != "" {
+		node.Type = domain.ArtifactType(strings.ToLower(typ))
+		if !node.Type.IsValid() {
+			return node, fmt.Errorf("unknown artifact type %q", typ)
+		}
+	}
+	if value != "" && value != "*" {
+		node.value = regexp.MustCompile(globToRegex(value))
+	}
+
+	return node, nil
+}
+
+func parseQueryEdge(tok string) (QueryEdge, error) {
+	m := edgePattern.FindStringSubmatch(tok)
+	if m == nil {
+		return QueryEdge{}, fmt.Errorf("invalid edge %q (use -[rel]-> or <-[rel]-)", tok)
+	}
+
+	reverse, forward := m[1] == "<", m[3] == ">"
+	if reverse == forward {
+		return QueryEdge{}, fmt.Errorf("edge %q must point in exactly one direction", tok)
+	}
+
+	edge := QueryEdge{Reverse: reverse}
+	if m[2] != "*" {
+		edge.Relation = domain.RelationType(m[2])
+	}
+	return edge, nil
+}
+
+// Matches indica si un artifact satisface el selector.
+func (n QueryNode) Matches(a *domain.Artifact) bool {
+	if a == nil {
+		return false
+	}
+	if n.Type != "" && a.Type != n.Type {
+		return false
+	}
+	return n.value == nil || n.value.MatchString(a.Value)
+}
+
+// Query ejecuta la consulta y retorna los caminos encontrados, ordenados por
+// valor del primer artifact. limit <= 0 = sin límite. Un artifact no se
+// repite dentro de un mismo camino (evita ciclos).
+func (g *GraphService) Query(q *GraphQuery, limit int) []GraphMatch {
+	var matches []GraphMatch
+
+	for _, start := range g.FindMatching(q.Nodes[0]) {
+		g.expandQuery(q, 0, GraphMatch{Artifacts: []*domain.Artifact{start}}, &matches, limit)
+		if limit > 0 && len(matches) >= limit {
+			break
+		}
+	}
+
+	return matches
+}
+
+// FindMatching retorna los artifacts que satisfacen un selector, ordenados por
+// tipo y valor para una salida estable.
+func (g *GraphService) FindMatching(node QueryNode) []*domain.Artifact {
+	var results []*domain.Artifact
+	for _, a := range g.artifacts {
+		if node.Matches(a) {
+			results = append(results, a)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Type != results[j].Type {
+			return results[i].Type < results[j].Type
+		}
+		return results[i].Value < results[j].Value
+	})
+	return results
+}
+
+// expandQuery extiende el camino parcial con la arista step (DFS).
+func (g *GraphService) expandQuery(q *GraphQuery, step int, path GraphMatch, out *[]GraphMatch, limit int) {
+	if limit > 0 && len(*out) >= limit {
+		return
+	}
+	if step == len(q.Edges) {
+		*out = append(*out, path)
+		return
+	}
+
+	current := path.Artifacts[len(path.Artifacts)-1]
+	edge := q.Edges[step]
+	next := q.Nodes[step+1]
+
+	for _, hop := range g.neighbors(current.ID, edge) {
+		if !next.Matches(hop.artifact) || containsArtifact(path.Artifacts, hop.artifact.ID) {
+			continue
+		}
+
+		extended := GraphMatch{
+			Artifacts: append(append([]*domain.Artifact{}, path.Artifacts...), hop.artifact),
+			Edges:     append(append([]QueryEdge{}, path.Edges...), QueryEdge{Relation: hop.relation, Reverse: edge.Reverse}),
+		}
+		g.expandQuery(q, step+1, extended, out, limit)
+	}
+}
+
+// queryHop es un vecino alcanzado por una relación concreta.
+type queryHop struct {
+	artifact *domain.Artifact
+	relation domain.RelationType
+}
+
+// neighbors retorna los vecinos de un artifact a través de una arista de la consulta.
+func (g *GraphService) neighbors(artifactID string, edge QueryEdge) []queryHop {
+	var hops []queryHop
+
+	if !edge.Reverse {
+		current := g.artifacts[artifactID]
+		if current == nil {
+			return nil
+		}
+		for _, rel := range current.Relations {
+			if edge.Relation != "" && rel.Type != edge.Relation {
+				continue
+			}
+			if target := g.artifacts[rel.TargetID]; target != nil {
+				hops = append(hops, queryHop{artifact: target, relation: rel.Type})
+			}
+		}
+		return hops
+	}
+
+	for relType, index := range g.reverseIndex {
+		if edge.Relation != "" && relType != edge.Relation {
+			continue
+		}
+		for _, sourceID := range index[artifactID] {
+			if source := g.artifacts[sourceID]; source != nil {
+				hops = append(hops, queryHop{artifact: source, relation: relType})
+			}
+		}
+	}
+
+	// reverseIndex es un map: ordenar para una salida estable
+	sort.Slice(hops, func(i, j int) bool {
+		if hops[i].relation != hops[j].relation {
+			return hops[i].relation < hops[j].relation
+		}
+		return hops[i].artifact.Value < hops[j].artifact.Value
+	})
+	return hops
+}
+
+func containsArtifact(artifacts []*domain.Artifact, id string) bool {
+	for _, a := range artifacts {
+		if a.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// String representa el camino con la misma sintaxis de la consulta.
+func (m GraphMatch) String() string {
+	var b strings.Builder
+	for i, a := range m.Artifacts {
+		if i > 0 {
+			b.WriteString(" " + m.Edges[i-1].String() + " ")
+		}
+		fmt.Fprintf(&b, "%s:%s", a.Type, a.Value)
+	}
+	return b.String()
+}
+
+// String representa la arista con la sintaxis de la consulta.
+func (e QueryEdge) String() string {
+	rel := string(e.Relation)
+	if rel == "" {
+		rel = "*"
+	}
+	if e.Reverse {
+		return "<-[" + rel + "]-"
+	}
+	return "-[" + rel + "]->"
+}
+
+// ShortestPath retorna el camino más corto (FindPath) entre algún artifact que
+// satisface from y alguno que satisface to. ok=false si no existe camino.
+func (g *GraphService) ShortestPath(from, to QueryNode) (GraphMatch, bool) {
+	var best []domain.ArtifactRelation
+	var bestFrom *domain.Artifact
+
+	targets := g.FindMatching(to)
+	for _, src := range g.FindMatching(from) {
+		for _, dst := range targets {
+			path := g.FindPath(src.ID, dst.ID)
+			if path == nil {
+				continue
+			}
+			if best == nil || len(path) < len(best) {
+				best, bestFrom = path, src
+			}
+		}
+	}
+
+	if best == nil {
+		return GraphMatch{}, false
+	}
+
+	match := GraphMatch{Artifacts: []*domain.Artifact{bestFrom}}
+	for _, rel := range best {
+		match.Artifacts = append(match.Artifacts, g.artifacts[rel.TargetID])
+		match.Edges = append(match.Edges, QueryEdge{Relation: rel.Type})
+	}
+	return match, true
+}
@@ -0,0 +1,69 @@
+// internal/core/usecases/graph_query_test.go
+package usecases
+
+import (
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+)
+
+func TestParseGraphQuery(t *testing.T) {
+	q, err := ParseGraphQuery("domain:example.com -[resolves_to]-> ip <-[*]- *")
+	testutil.AssertNoError(t, err, "query should parse")
+	testutil.AssertEqual(t, len(q.Nodes), 3, "three nodes")
+	testutil.AssertEqual(t, q.Nodes[0].Type, domain.ArtifactTypeDomain, "first node type")
+	testutil.AssertEqual(t, q.Edges[0].Relation, domain.RelationResolvesTo, "first edge relation")
+	testutil.AssertFalse(t, q.Edges[0].Reverse, "first edge forward")
+	testutil.AssertTrue(t, q.Edges[1].Reverse, "second edge reverse")
+	testutil.AssertEqual(t, q.Edges[1].Relation, domain.RelationType(""), "wildcard relation")
+
+	invalid := []string{
+		"",
+		"domain -[resolves_to]->",
+		"domain resolves_to ip",
+		"nosuchtype:x",
+		"domain <-[resolves_to]-> ip",
+	}
+	for _, s := range invalid {
+		_, err := ParseGraphQuery(s)
+		testutil.AssertError(t, err, "should reject "+s)
+	}
+}
+
+func TestGraphService_Query(t *testing.T) {
+	graph := NewGraphService(createTestArtifacts(), logx.New())
+
+	q, err := ParseGraphQuery("subdomain:*.example.com -[resolves_to]-> ip -[owned_by]-> asn")
+	testutil.AssertNoError(t, err, "query should parse")
+
+	matches := graph.Query(q, 0)
+	testutil.AssertEqual(t, len(matches), 1, "one path")
+	testutil.AssertEqual(t, matches[0].String(),
+		"subdomain:test.example.com -[resolves_to]-> ip:1.2.3.4 -[owned_by]-> asn:AS15169", "path rendering")
+
+	// Dirección inversa: ¿qué artifacts usan el certificado?
+	q, _ = ParseGraphQuery("certificate <-[uses_cert]- *")
+	matches = graph.Query(q, 0)
+	testutil.AssertEqual(t, len(matches), 2, "domain and subdomain use the cert")
+	testutil.AssertEqual(t, len(graph.Query(q, 1)), 1, "limit respected")
+
+	// Un solo nodo devuelve los artifacts que lo satisfacen
+	q, _ = ParseGraphQuery("email")
+	testutil.AssertEqual(t, len(graph.Query(q, 0)), 1, "single node query")
+}
+
+func TestGraphService_ShortestPath(t *testing.T) {
+	graph := NewGraphService(createTestArtifacts(), logx.New())
+
+	from, _ := ParseQueryNode("subdomain:test.example.com")
+	to, _ := ParseQueryNode("asn")
+
+	match, ok := graph.ShortestPath(from, to)
+	testutil.AssertTrue(t, ok, "path should exist")
+	testutil.AssertEqual(t, len(match.Edges), 2, "two hops to the ASN")
+
+	_, ok = graph.ShortestPath(to, from)
+	testutil.AssertFalse(t, ok, "relations are directed")
+}
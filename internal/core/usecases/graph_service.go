@@ -74,6 +74,62 @@ func (g *GraphService) buildIndexes(artifacts []*domain.Artifact) {
 	)
 }
 
+// PruneStats resume las relaciones eliminadas por PruneDangling.
+type PruneStats struct {
+	Dangling      int // destino inexistente (deduplicado o suprimido)
+	LowConfidence int // confianza inferior al umbral
+}
+
+// Total retorna el número total de relaciones eliminadas.
+func (s PruneStats) Total() int {
+	return s.Dangling + s.LowConfidence
+}
+
+// PruneDangling elimina las relaciones cuyo destino ya no existe en el grafo
+// (artifact fusionado por dedupe o descartado como ruido) y las de confianza
+// inferior a minConfidence (0 = sin umbral). Modifica los artifacts en sitio
+// y reconstruye los índices. Debe llamarse antes de exportar.
+func (g *GraphService) PruneDangling(minConfidence float64) PruneStats {
+	var stats PruneStats
+
+	for _, artifact := range g.artifacts {
+		if len(artifact.Relations) == 0 {
+			continue
+		}
+
+		kept := artifact.Relations[:0]
+		for _, rel := range artifact.Relations {
+			switch {
+			case g.artifacts[rel.TargetID] == nil:
+				stats.Dangling++
+			case rel.Confidence < minConfidence:
+				stats.LowConfidence++
+			default:
+				kept = append(kept, rel)
+			}
+		}
+		artifact.Relations = kept
+	}
+
+	if stats.Total() > 0 {
+		artifacts := make([]*domain.Artifact, 0, len(g.artifacts))
+		for _, a := range g.artifacts {
+			artifacts = append(artifacts, a)
+		}
+		g.relationIndex = make(map[domain.RelationType]map[string][]string)
+		g.reverseIndex = make(map[domain.RelationType]map[string][]string)
+		g.buildIndexes(artifacts)
+
+		g.logger.Info("relations pruned",
+			"dangling", stats.Dangling,
+			"low_confidence", stats.LowConfidence,
+			"min_confidence", minConfidence,
+		)
+	}
+
+	return stats
+}
+
 // GetArtifact retorna un artifact por su ID.
 func (g *GraphService) GetArtifact(artifactID string) *domain.Artifact {
 	return g.artifacts[artifactID]
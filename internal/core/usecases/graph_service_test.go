@@ -469,3 +469,35 @@ func TestGraphService_BuildIndexes_WithMetadata(t *testing.T) {
 	testutil.AssertEqual(t, relations[0].Metadata["issuer"], "Let's Encrypt", "metadata should be preserved")
 	testutil.AssertEqual(t, relations[0].Metadata["valid"], "true", "metadata should be preserved")
 }
+
+func TestGraphService_PruneDangling(t *testing.T) {
+	domain1 := domain.NewArtifact(domain.ArtifactTypeDomain, "example.com", "rdap")
+	sub := domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "crtsh")
+	ip := domain.NewIPArtifact("93.184.216.34", "dns")
+	gone := domain.NewArtifact(domain.ArtifactTypeSubdomain, "old.example.com", "crtsh")
+
+	sub.AddRelation(domain1.ID, domain.RelationSubdomainOf, 1.0, "crtsh")
+	sub.AddRelation(ip.ID, domain.RelationResolvesTo, 0.3, "amass")
+	sub.AddRelation(gone.ID, domain.RelationHasCNAME, 1.0, "amass")
+
+	// gone no forma parte del grafo (fusionado por dedupe)
+	graph := NewGraphService([]*domain.Artifact{domain1, sub, ip}, logx.New())
+	stats := graph.PruneDangling(0.5)
+
+	testutil.AssertEqual(t, stats.Dangling, 1, "relation to missing artifact pruned")
+	testutil.AssertEqual(t, stats.LowConfidence, 1, "low-confidence relation pruned")
+	testutil.AssertEqual(t, len(sub.Relations), 1, "only subdomain_of survives")
+	testutil.AssertEqual(t, len(graph.GetRelated(sub.ID, domain.RelationResolvesTo)), 0, "index rebuilt")
+	testutil.AssertEqual(t, len(graph.GetRelated(sub.ID, domain.RelationSubdomainOf)), 1, "kept relation indexed")
+	testutil.AssertEqual(t, graph.GetStats().TotalRelations, 1, "stats reflect pruning")
+}
+
+func TestGraphService_PruneDangling_ZeroThresholdKeepsValid(t *testing.T) {
+	graph := NewGraphService(createTestArtifacts(), logx.New())
+	before := graph.GetStats().TotalRelations
+
+	stats := graph.PruneDangling(0)
+
+	testutil.AssertEqual(t, stats.Total(), 0, "no relations pruned")
+	testutil.AssertEqual(t, graph.GetStats().TotalRelations, before, "relations untouched")
+}
@@ -0,0 +1,330 @@
+// internal/core/usecases/lifecycle_service.go
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+)
+
+// LifecycleOptions configura el seguimiento de frescura entre escaneos.
+type LifecycleOptions struct {
+	StaleAfter     int // Escaneos consecutivos ausente para marcar stale (mínimo 1)
+	RemoveAfter    int // Escaneos consecutivos ausente para marcar removed (>= StaleAfter)
+	MaxTrendPoints int // Puntos de la serie temporal a conservar (0 = DefaultMaxTrendPoints)
+
+	// CertAlertDays son los umbrales (días hasta la caducidad) que disparan
+	// un aviso por certificado (vacío = DefaultCertAlertDays)
+	CertAlertDays []int
+
+	Observers []ports.Notifier
+	Logger    logx.Logger
+}
+
+// DefaultMaxTrendPoints conserva aproximadamente un año de escaneos diarios.
+const DefaultMaxTrendPoints = 365
+
+// DefaultCertAlertDays son los umbrales de aviso de caducidad por defecto.
+var DefaultCertAlertDays = []int{30, 14, 7, 1}
+
+// LifecycleService mantiene first_seen/last_seen por artifact en el store y
+// detecta artifacts nuevos, reaparecidos, stale y retirados.
+type LifecycleService struct {
+	store          ports.LifecycleStore
+	staleAfter     int
+	removeAfter    int
+	maxTrendPoints int
+	certAlertDays  []int // De mayor a menor
+	observers      []ports.Notifier
+	logger         logx.Logger
+}
+
+// NewLifecycleService crea el servicio sobre store.
+func NewLifecycleService(store ports.LifecycleStore, opts LifecycleOptions) *LifecycleService {
+	if opts.StaleAfter < 1 {
+		opts.StaleAfter = 1
+	}
+	if opts.RemoveAfter < opts.StaleAfter {
+		opts.RemoveAfter = opts.StaleAfter
+	}
+	if opts.MaxTrendPoints <= 0 {
+		opts.MaxTrendPoints = DefaultMaxTrendPoints
+	}
+	logger := opts.Logger
+	if logger == nil {
+		logger = logx.New()
+	}
+	return &LifecycleService{
+		store:          store,
+		staleAfter:     opts.StaleAfter,
+		removeAfter:    opts.RemoveAfter,
+		maxTrendPoints: opts.MaxTrendPoints,
+		certAlertDays:  normalizeAlertDays(opts.CertAlertDays),
+		observers:      opts.Observers,
+		logger:         logger.With("component", "lifecycle"),
+	}
+}
+
+// Track carga el estado del target, lo actualiza con result, lo persiste y
+// adjunta el informe de cambios a result.Lifecycle y la serie temporal a
+// result.Trends.
+func (s *LifecycleService) Track(ctx context.Context, result *domain.ScanResult) (*domain.LifecycleReport, error) {
+	state, err := s.store.LoadLifecycle(ctx, result.Target.Root)
+	if err != nil {
+		return nil, fmt.Errorf("load lifecycle state: %w", err)
+	}
+
+	now := result.Metadata.EndTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+	report := s.Update(state, result, now)
+
+	if err := s.store.SaveLifecycle(ctx, state); err != nil {
+		return nil, fmt.Errorf("save lifecycle state: %w", err)
+	}
+
+	result.Lifecycle = report
+	result.Trends = append([]domain.TrendPoint(nil), state.Trends...)
+	s.logger.Info("lifecycle updated",
+		"target", result.Target.Root,
+		"scan", state.ScanCount,
+		"new", len(report.New),
+		"reappeared", len(report.Reappeared),
+		"stale", len(report.Stale),
+		"removed", len(report.Removed),
+		"cert_alerts", len(report.CertAlerts),
+		"page_changes", len(report.PageChanges),
+	)
+	s.notify(ctx, result.ID, result.Target.Root, report)
+
+	return report, nil
+}
+
+// Update aplica un escaneo sobre state y retorna los cambios de estado.
+// No accede al store (útil en tests y para recalcular a partir de históricos).
+func (s *LifecycleService) Update(state *domain.LifecycleState, result *domain.ScanResult, now time.Time) *domain.LifecycleReport {
+	report := &domain.LifecycleReport{}
+	if state.Artifacts == nil {
+		state.Artifacts = make(map[string]*domain.ArtifactLifecycle)
+	}
+
+	seen := make(map[string]bool, len(result.Artifacts))
+	for _, a := range result.Artifacts {
+		// Reinyectado de un escaneo anterior y sin confirmar en este: no
+		// cuenta como visto (ni nuevo) para no mantener vivo un activo perdido
+		if a == nil || a.IsHistoricalOnly() {
+			continue
+		}
+		key := a.Key()
+		seen[key] = true
+
+		entry, ok := state.Artifacts[key]
+		if !ok {
+			entry = &domain.ArtifactLifecycle{
+				Key:       key,
+				Type:      a.Type,
+				Value:     a.Value,
+				FirstSeen: now,
+				LastSeen:  now,
+				Status:    domain.LifecycleNew,
+			}
+			state.Artifacts[key] = entry
+			report.New = append(report.New, *entry)
+			continue
+		}
+
+		reappeared := entry.Status == domain.LifecycleStale || entry.Status == domain.LifecycleRemoved
+		entry.LastSeen = now
+		entry.MissedScans = 0
+		entry.Status = domain.LifecycleActive
+		if reappeared {
+			report.Reappeared = append(report.Reappeared, *entry)
+		}
+	}
+
+	for key, entry := range state.Artifacts {
+		if seen[key] {
+			continue
+		}
+		entry.MissedScans++
+
+		switch {
+		case entry.MissedScans >= s.removeAfter && entry.Status != domain.LifecycleRemoved:
+			entry.Status = domain.LifecycleRemoved
+			report.Removed = append(report.Removed, *entry)
+		case entry.MissedScans >= s.staleAfter && (entry.Status == domain.LifecycleNew || entry.Status == domain.LifecycleActive):
+			entry.Status = domain.LifecycleStale
+			report.Stale = append(report.Stale, *entry)
+		}
+	}
+
+	report.CertAlerts = s.watchCertificates(state, result, now)
+	report.PageChanges = watchPages(state, result)
+
+	state.ScanCount++
+	state.LastScan = now
+	state.AppendTrend(domain.NewTrendPoint(result, now), s.maxTrendPoints)
+
+	// Orden determinista para diffs estables
+	for _, list := range [][]domain.ArtifactLifecycle{report.New, report.Reappeared, report.Stale, report.Removed} {
+		sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	}
+
+	return report
+}
+
+// watchCertificates retorna los certificados que han cruzado un umbral de
+// aviso desde el último escaneo y actualiza los avisos enviados en state.
+// Cada umbral se avisa una vez por certificado; uno renovado vuelve a empezar.
+func (s *LifecycleService) watchCertificates(state *domain.LifecycleState, result *domain.ScanResult, now time.Time) []domain.CertExpiry {
+	var alerts []domain.CertExpiry
+	for _, cert := range domain.CertExpiries(result, now) {
+		watch := state.Certificates[cert.Host]
+		if watch != nil && watch.Serial != cert.Serial {
+			delete(state.Certificates, cert.Host)
+			watch = nil
+		}
+
+		threshold, crossed := s.certThreshold(cert.DaysRemaining)
+		if !crossed || (watch != nil && threshold >= watch.Threshold) {
+			continue
+		}
+		if state.Certificates == nil {
+			state.Certificates = make(map[string]*domain.CertWatch)
+		}
+		state.Certificates[cert.Host] = &domain.CertWatch{
+			Serial:     cert.Serial,
+			ValidUntil: cert.ValidUntil,
+			Threshold:  threshold,
+		}
+		alerts = append(alerts, cert)
+	}
+	return alerts
+}
+
+// watchPages compara cada URL sondeada con su última instantánea, marca las
+// que cambiaron (page-changed) y guarda la nueva instantánea en state. Las
+// URLs nuevas solo se guardan; las que no se sondearon conservan la anterior.
+func watchPages(state *domain.LifecycleState, result *domain.ScanResult) []domain.PageChange {
+	pages := domain.PageSnapshots(result)
+	if len(pages) == 0 {
+		return nil
+	}
+	if state.Pages == nil {
+		state.Pages = make(map[string]*domain.PageSnapshot)
+	}
+
+	changed := make(map[string]bool)
+	var changes []domain.PageChange
+	for url, snap := range pages {
+		if prev, ok := state.Pages[url]; ok {
+			if fields := domain.ComparePages(*prev, snap); len(fields) > 0 {
+				changes = append(changes, domain.NewPageChange(url, *prev, snap, fields))
+				changed[url] = true
+			}
+			snap = prev.Carry(snap)
+		}
+		state.Pages[url] = &snap
+	}
+
+	for _, a := range result.Artifacts {
+		if a != nil && a.Type == domain.ArtifactTypeURL && changed[a.Value] {
+			a.AddTag(domain.TagPageChanged)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].URL < changes[j].URL })
+	return changes
+}
+
+// certThreshold retorna el menor umbral que alcanza days (-1 si ya caducó).
+func (s *LifecycleService) certThreshold(days int) (int, bool) {
+	if days < 0 {
+		return -1, true
+	}
+	threshold, crossed := 0, false
+	for _, t := range s.certAlertDays {
+		if days <= t {
+			threshold, crossed = t, true
+		}
+	}
+	return threshold, crossed
+}
+
+// normalizeAlertDays descarta umbrales no positivos y duplicados y los ordena
+// de mayor a menor.
+func normalizeAlertDays(days []int) []int {
+	if len(days) == 0 {
+		days = DefaultCertAlertDays
+	}
+	var out []int
+	for _, d := range days {
+		if d > 0 && !slices.Contains(out, d) {
+			out = append(out, d)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(out)))
+	return out
+}
+
+// notify emite un evento por cada cambio relevante (stale, removed,
+// reappeared), por cada aviso de caducidad de certificado y por cada página
+// que cambió.
+// Es síncrono: en modo CLI el proceso termina justo después.
+func (s *LifecycleService) notify(ctx context.Context, scanID, target string, report *domain.LifecycleReport) {
+	if len(s.observers) == 0 {
+		return
+	}
+
+	send := func(event ports.Event) {
+		event.Target = target
+		event.ScanID = scanID
+		for _, observer := range s.observers {
+			notifyCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			if err := observer.Notify(notifyCtx, event); err != nil {
+				s.logger.Warn("notification failed", "error", err.Error())
+			}
+			cancel()
+		}
+	}
+	emit := func(eventType ports.EventType, entries []domain.ArtifactLifecycle) {
+		for _, entry := range entries {
+			send(ports.NewEvent(eventType, "lifecycle", entry))
+		}
+	}
+
+	emit(ports.EventTypeArtifactStale, report.Stale)
+	emit(ports.EventTypeArtifactRemoved, report.Removed)
+	emit(ports.EventTypeArtifactReappeared, report.Reappeared)
+
+	for _, cert := range report.CertAlerts {
+		event := ports.NewEvent(ports.EventTypeCertificateExpiring, "lifecycle", cert)
+		event.Severity = ports.EventSeverityWarning
+		if cert.Expired() {
+			event.Severity = ports.EventSeverityCritical
+		}
+		event.Metadata["host"] = cert.Host
+		event.Metadata["days_remaining"] = strconv.Itoa(cert.DaysRemaining)
+		send(event)
+	}
+
+	for _, change := range report.PageChanges {
+		event := ports.NewEvent(ports.EventTypePageChanged, "lifecycle", change)
+		if change.LoginAppeared() {
+			event.Severity = ports.EventSeverityWarning
+		}
+		event.Metadata["url"] = change.URL
+		event.Metadata["host"] = change.Host
+		event.Metadata["fields"] = strings.Join(change.Fields, ",")
+		event.Metadata["summary"] = change.Summary
+		send(event)
+	}
+}
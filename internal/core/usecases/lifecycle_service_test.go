@@ -0,0 +1,231 @@
+// internal/core/usecases/lifecycle_service_test.go
+package usecases
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+)
+
+// memoryLifecycleStore es un LifecycleStore en memoria para tests.
+type memoryLifecycleStore struct {
+	states map[string]*domain.LifecycleState
+}
+
+func (m *memoryLifecycleStore) LoadLifecycle(ctx context.Context, target string) (*domain.LifecycleState, error) {
+	if s, ok := m.states[target]; ok {
+		return s, nil
+	}
+	return domain.NewLifecycleState(target), nil
+}
+
+func (m *memoryLifecycleStore) SaveLifecycle(ctx context.Context, state *domain.LifecycleState) error {
+	m.states[state.Target] = state
+	return nil
+}
+
+// recordingNotifier guarda los eventos recibidos.
+type recordingNotifier struct {
+	events []ports.Event
+}
+
+func (r *recordingNotifier) Notify(ctx context.Context, event ports.Event) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *recordingNotifier) Close() error { return nil }
+
+func scanWith(values ...string) *domain.ScanResult {
+	result := domain.NewScanResult(domain.Target{Root: "example.com"})
+	for _, v := range values {
+		result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, v, "crtsh"))
+	}
+	return result
+}
+
+func TestLifecycleService_Update(t *testing.T) {
+	svc := NewLifecycleService(nil, LifecycleOptions{StaleAfter: 1, RemoveAfter: 2, Logger: logx.New()})
+	state := domain.NewLifecycleState("example.com")
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	report := svc.Update(state, scanWith("a.example.com", "b.example.com"), t0)
+	testutil.AssertEqual(t, len(report.New), 2, "first scan: all new")
+
+	report = svc.Update(state, scanWith("a.example.com"), t0.Add(time.Hour))
+	testutil.AssertEqual(t, len(report.New), 0, "second scan: nothing new")
+	testutil.AssertEqual(t, len(report.Stale), 1, "b missed once -> stale")
+
+	entryA := state.Artifacts["subdomain:a.example.com"]
+	testutil.AssertEqual(t, entryA.FirstSeen, t0, "first_seen kept")
+	testutil.AssertEqual(t, entryA.LastSeen, t0.Add(time.Hour), "last_seen updated")
+	testutil.AssertEqual(t, entryA.Status, domain.LifecycleActive, "a active")
+
+	report = svc.Update(state, scanWith("a.example.com"), t0.Add(2*time.Hour))
+	testutil.AssertEqual(t, len(report.Removed), 1, "b missed twice -> removed")
+	testutil.AssertEqual(t, len(report.Stale), 0, "not reported stale again")
+
+	report = svc.Update(state, scanWith("a.example.com", "b.example.com"), t0.Add(3*time.Hour))
+	testutil.AssertEqual(t, len(report.Reappeared), 1, "b reappeared")
+	testutil.AssertEqual(t, state.Artifacts["subdomain:b.example.com"].MissedScans, 0, "missed reset")
+	testutil.AssertEqual(t, state.ScanCount, 4, "scan count")
+	testutil.AssertEqual(t, len(state.Trends), 4, "one trend point per scan")
+	testutil.AssertEqual(t, state.Trends[3].Subdomains, 2, "trend subdomains")
+	testutil.AssertEqual(t, state.Trends[3].Time, t0.Add(3*time.Hour), "trend time")
+}
+
+func TestLifecycleService_UpdateIgnoresHistoricalOnly(t *testing.T) {
+	svc := NewLifecycleService(nil, LifecycleOptions{StaleAfter: 1, RemoveAfter: 2, Logger: logx.New()})
+	state := domain.NewLifecycleState("example.com")
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc.Update(state, scanWith("a.example.com", "b.example.com"), t0)
+
+	// b y c solo llegan reinyectados de un escaneo anterior; a lo confirma crtsh
+	result := scanWith("a.example.com")
+	for _, v := range []string{"a.example.com", "b.example.com", "c.example.com"} {
+		historical := domain.NewArtifact(domain.ArtifactTypeSubdomain, v, "previousscan")
+		historical.AddTag(domain.TagHistorical)
+		result.AddArtifact(historical)
+	}
+
+	report := svc.Update(state, result, t0.Add(time.Hour))
+	testutil.AssertEqual(t, len(report.New), 0, "historical artifacts are not new")
+	testutil.AssertEqual(t, len(report.Stale), 1, "b not confirmed -> stale")
+	testutil.AssertEqual(t, state.Artifacts["subdomain:a.example.com"].Status, domain.LifecycleActive, "a confirmed")
+}
+
+func TestLifecycleService_TrackPersistsAndNotifies(t *testing.T) {
+	store := &memoryLifecycleStore{states: map[string]*domain.LifecycleState{}}
+	notifier := &recordingNotifier{}
+	svc := NewLifecycleService(store, LifecycleOptions{
+		StaleAfter:  1,
+		RemoveAfter: 3,
+		Observers:   []ports.Notifier{notifier},
+		Logger:      logx.New(),
+	})
+	ctx := context.Background()
+
+	_, err := svc.Track(ctx, scanWith("a.example.com"))
+	testutil.AssertNoError(t, err, "first track")
+
+	result := scanWith()
+	report, err := svc.Track(ctx, result)
+	testutil.AssertNoError(t, err, "second track")
+	testutil.AssertEqual(t, len(report.Stale), 1, "stale reported")
+	testutil.AssertTrue(t, result.Lifecycle == report, "report attached to result")
+	testutil.AssertEqual(t, len(result.Trends), 2, "trend series attached to result")
+	testutil.AssertEqual(t, len(notifier.events), 1, "one notification")
+	testutil.AssertEqual(t, notifier.events[0].Type, ports.EventTypeArtifactStale, "stale event")
+}
+
+func TestLifecycleService_CertificateAlerts(t *testing.T) {
+	notifier := &recordingNotifier{}
+	svc := NewLifecycleService(nil, LifecycleOptions{
+		CertAlertDays: []int{7, 30, 0},
+		Observers:     []ports.Notifier{notifier},
+		Logger:        logx.New(),
+	})
+	state := domain.NewLifecycleState("example.com")
+	expiry := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	scanWithCert := func(serial string, until time.Time) *domain.ScanResult {
+		result := scanWith()
+		result.AddArtifact(domain.NewArtifactWithMetadata(domain.ArtifactTypeCertificate, serial, "crtsh", &metadata.CertificateMetadata{
+			SerialNumber: serial,
+			SubjectCN:    "www.example.com",
+			ValidUntil:   until.Format(time.RFC3339),
+		}))
+		return result
+	}
+
+	report := svc.Update(state, scanWithCert("0a01", expiry), expiry.Add(-60*day))
+	testutil.AssertEqual(t, len(report.CertAlerts), 0, "60 days left: no threshold crossed")
+
+	report = svc.Update(state, scanWithCert("0a01", expiry), expiry.Add(-20*day))
+	testutil.AssertEqual(t, len(report.CertAlerts), 1, "crossed 30 days")
+	testutil.AssertEqual(t, report.CertAlerts[0].DaysRemaining, 20, "days remaining")
+
+	report = svc.Update(state, scanWithCert("0a01", expiry), expiry.Add(-10*day))
+	testutil.AssertEqual(t, len(report.CertAlerts), 0, "30 days threshold alerted once")
+
+	report = svc.Update(state, scanWithCert("0a01", expiry), expiry.Add(-5*day))
+	testutil.AssertEqual(t, len(report.CertAlerts), 1, "crossed 7 days")
+
+	report = svc.Update(state, scanWithCert("0a01", expiry), expiry.Add(day))
+	testutil.AssertEqual(t, len(report.CertAlerts), 1, "expired")
+	testutil.AssertEqual(t, state.Certificates["www.example.com"].Threshold, -1, "expired recorded")
+
+	report = svc.Update(state, scanWithCert("0a02", expiry.Add(90*day)), expiry.Add(2*day))
+	testutil.AssertEqual(t, len(report.CertAlerts), 0, "renewed certificate")
+	testutil.AssertTrue(t, state.Certificates["www.example.com"] == nil, "watch reset on renewal")
+
+	svc.notify(context.Background(), "scan", "example.com", &domain.LifecycleReport{
+		CertAlerts: []domain.CertExpiry{{Host: "www.example.com", DaysRemaining: -1}},
+	})
+	testutil.AssertEqual(t, len(notifier.events), 1, "one notification")
+	testutil.AssertEqual(t, notifier.events[0].Type, ports.EventTypeCertificateExpiring, "certificate event")
+	testutil.AssertEqual(t, notifier.events[0].Severity, ports.EventSeverityCritical, "expired is critical")
+	testutil.AssertEqual(t, notifier.events[0].Metadata["host"], "www.example.com", "host metadata")
+}
+
+func TestLifecycleService_PageChanges(t *testing.T) {
+	notifier := &recordingNotifier{}
+	svc := NewLifecycleService(nil, LifecycleOptions{
+		Observers: []ports.Notifier{notifier},
+		Logger:    logx.New(),
+	})
+	state := domain.NewLifecycleState("example.com")
+	now := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	scanWithPage := func(status int, title, hash string, tech ...string) *domain.ScanResult {
+		result := scanWith()
+		page := domain.NewArtifactWithMetadata(domain.ArtifactTypeURL, "https://dev.example.com", "httpx", &metadata.ServiceMetadata{
+			StatusCode: status,
+			Title:      title,
+			BodySHA256: hash,
+			Banner:     "nginx",
+		})
+		result.AddArtifact(page)
+		for _, name := range tech {
+			a := domain.NewArtifact(domain.ArtifactTypeTechnology, name, "httpx")
+			a.AddRelation(page.ID, domain.RelationUsesTech, 0.9, "httpx")
+			result.AddArtifact(a)
+		}
+		return result
+	}
+
+	report := svc.Update(state, scanWithPage(404, "Not Found", "aa", "Nginx"), now)
+	testutil.AssertEqual(t, len(report.PageChanges), 0, "first snapshot is not a change")
+
+	report = svc.Update(state, scanWithPage(404, "Not Found", "aa", "Nginx"), now.Add(time.Hour))
+	testutil.AssertEqual(t, len(report.PageChanges), 0, "unchanged page")
+
+	// Un escaneo sin hashes ni tecnologías no cuenta como cambio
+	report = svc.Update(state, scanWithPage(404, "Not Found", ""), now.Add(2*time.Hour))
+	testutil.AssertEqual(t, len(report.PageChanges), 0, "unknown fields are not compared")
+
+	result := scanWithPage(200, "Sign in - Dev Portal", "bb", "Nginx", "Keycloak")
+	report = svc.Update(state, result, now.Add(3*time.Hour))
+	testutil.AssertEqual(t, len(report.PageChanges), 1, "page changed")
+	change := report.PageChanges[0]
+	testutil.AssertEqual(t, len(change.Fields), 4, "content, title, status and tech changed")
+	testutil.AssertTrue(t, change.LoginAppeared(), "login page detected")
+	testutil.AssertEqual(t, change.Summary, "login page appeared on dev.example.com (https://dev.example.com)", "summary")
+	testutil.AssertTrue(t, slices.Contains(result.Artifacts[0].Tags, domain.TagPageChanged), "url tagged")
+
+	testutil.AssertEqual(t, state.Pages["https://dev.example.com"].Hash, "bb", "snapshot updated")
+
+	svc.notify(context.Background(), "scan", "example.com", &domain.LifecycleReport{PageChanges: report.PageChanges})
+	testutil.AssertEqual(t, len(notifier.events), 1, "one notification")
+	testutil.AssertEqual(t, notifier.events[0].Type, ports.EventTypePageChanged, "page event")
+	testutil.AssertEqual(t, notifier.events[0].Severity, ports.EventSeverityWarning, "login page is a warning")
+	testutil.AssertEqual(t, notifier.events[0].Metadata["fields"], "content,title,status,tech", "fields metadata")
+}
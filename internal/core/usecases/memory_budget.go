@@ -0,0 +1,63 @@
+// internal/core/usecases/memory_budget.go
+package usecases
+
+import (
+	"sync/atomic"
+)
+
+// memoryBudget lleva la cuenta aproximada de bytes de artifacts retenidos en
+// memoria (acumulador + resultados del stage en curso). Cuando un resultado no
+// cabe en el presupuesto el orchestrator lo escribe a disco (WritePartial),
+// igual que al superar el threshold de artifacts.
+type memoryBudget struct {
+	limit  int64 // 0 = sin presupuesto
+	held   atomic.Int64
+	spills atomic.Int64
+}
+
+// newMemoryBudget crea un presupuesto de limit bytes (0 = ilimitado).
+func newMemoryBudget(limit int64) *memoryBudget {
+	if limit < 0 {
+		limit = 0
+	}
+	return &memoryBudget{limit: limit}
+}
+
+// Reserve suma size a los bytes retenidos si caben en el presupuesto.
+// Retorna false (sin reservar) si se excedería: el resultado debe ir a disco.
+func (b *memoryBudget) Reserve(size int64) bool {
+	for {
+		held := b.held.Load()
+		if b.limit > 0 && held+size > b.limit {
+			return false
+		}
+		if b.held.CompareAndSwap(held, held+size) {
+			return true
+		}
+	}
+}
+
+// Add suma size a los bytes retenidos sin comprobar el presupuesto.
+func (b *memoryBudget) Add(size int64) {
+	b.held.Add(size)
+}
+
+// Set fija los bytes retenidos (p.ej. tras deduplicar el acumulador).
+func (b *memoryBudget) Set(size int64) {
+	b.held.Store(size)
+}
+
+// Exceeded indica si los bytes retenidos superan el presupuesto.
+func (b *memoryBudget) Exceeded() bool {
+	return b.limit > 0 && b.held.Load() > b.limit
+}
+
+// Spilled registra una escritura a disco provocada por el presupuesto.
+func (b *memoryBudget) Spilled() {
+	b.spills.Add(1)
+}
+
+// Stats retorna los bytes retenidos, el presupuesto y las escrituras a disco.
+func (b *memoryBudget) Stats() (held, limit, spills int64) {
+	return b.held.Load(), b.limit, b.spills.Load()
+}
@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+)
+
+// recordingStreamingWriter registra las escrituras parciales sin tocar disco
+type recordingStreamingWriter struct {
+	mu       sync.Mutex
+	partials []string
+}
+
+func (w *recordingStreamingWriter) WritePartial(sourceName string, result *domain.ScanResult) (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.partials = append(w.partials, sourceName)
+	return sourceName + ".json", nil
+}
+
+func (w *recordingStreamingWriter) GetPattern() string       { return "partial-*.json" }
+func (w *recordingStreamingWriter) GetFinalFilename() string { return "final.json" }
+
+func TestMemoryBudget_Reserve(t *testing.T) {
+	b := newMemoryBudget(100)
+
+	testutil.AssertTrue(t, b.Reserve(60), "fits in the budget")
+	testutil.AssertFalse(t, b.Reserve(50), "60+50 exceeds the budget")
+	testutil.AssertTrue(t, b.Reserve(40), "60+40 is exactly the budget")
+	testutil.AssertFalse(t, b.Exceeded(), "held == budget is not exceeded")
+
+	b.Add(10)
+	testutil.AssertTrue(t, b.Exceeded(), "Add ignores the budget")
+
+	b.Set(0)
+	held, limit, _ := b.Stats()
+	testutil.AssertEqual(t, held, int64(0), "Set resets held bytes")
+	testutil.AssertEqual(t, limit, int64(100), "limit")
+
+	unlimited := newMemoryBudget(0)
+	testutil.AssertTrue(t, unlimited.Reserve(1<<40), "no budget: always fits")
+	testutil.AssertFalse(t, unlimited.Exceeded(), "no budget: never exceeded")
+}
+
+func TestPipelineOrchestrator_SpillsWhenMemoryBudgetExceeded(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+
+	// Pocas URLs pero muy pesadas: no llegan al threshold de artifacts
+	var urls []*domain.Artifact
+	for i := 0; i < 10; i++ {
+		value := fmt.Sprintf("https://example.com/%s?page=%d", strings.Repeat("a", 500), i)
+		urls = append(urls, domain.NewArtifact(domain.ArtifactTypeURL, value, "wayback-mock"))
+	}
+	subdomains := []*domain.Artifact{
+		domain.NewArtifact(domain.ArtifactTypeSubdomain, "www.example.com", "crtsh-mock"),
+		domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "crtsh-mock"),
+	}
+
+	writer := &recordingStreamingWriter{}
+	orch := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources: []ports.Source{
+			mockSourceWithArtifacts("wayback-mock", urls),
+			mockSourceWithArtifacts("crtsh-mock", subdomains),
+		},
+		SourceMetadata: map[string]ports.SourceMetadata{
+			"wayback-mock": {Name: "wayback-mock", OutputArtifacts: []domain.ArtifactType{domain.ArtifactTypeURL}},
+			"crtsh-mock":   {Name: "crtsh-mock", OutputArtifacts: []domain.ArtifactType{domain.ArtifactTypeSubdomain}},
+		},
+		Logger:          logx.NewSilent(),
+		StreamingWriter: writer,
+		StreamingConfig: StreamingConfig{
+			ArtifactThreshold: 1000,
+			OutputDir:         t.TempDir(),
+			MemoryBudgetBytes: 4096,
+		},
+	})
+
+	result, err := orch.Run(context.Background(), *target)
+	testutil.AssertNoError(t, err, "pipeline should succeed")
+	testutil.AssertEqual(t, strings.Join(writer.partials, ","), "wayback-mock", "only the heavy result exceeds the budget")
+	testutil.AssertEqual(t, len(result.Artifacts), 2, "small result stays in memory")
+
+	_, _, spills := orch.memory.Stats()
+	testutil.AssertEqual(t, spills, int64(1), "spill counted")
+}
+
+func TestArtifactApproxSize_GrowsWithContent(t *testing.T) {
+	small := domain.NewArtifact(domain.ArtifactTypeSubdomain, "www.example.com", "crtsh")
+	large := domain.NewArtifact(domain.ArtifactTypeURL, "https://example.com/"+strings.Repeat("a", 2000), "waybackurls")
+
+	testutil.AssertTrue(t, large.ApproxSize() > small.ApproxSize()+2000, "size follows the value length")
+
+	withRelation := domain.NewArtifact(domain.ArtifactTypeSubdomain, "www.example.com", "crtsh")
+	withRelation.AddRelation(large.ID, domain.RelationType("resolves_to"), 1, "dnsx")
+	testutil.AssertTrue(t, withRelation.ApproxSize() > small.ApproxSize(), "relations add to the size")
+}
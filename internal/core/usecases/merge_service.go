@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/compress"
 	"aethonx/internal/platform/logx"
 )
 
@@ -136,7 +137,8 @@ func (m *MergeService) loadPartialFile(filepath string) (PartialScanResult, erro
 		return PartialScanResult{}, fmt.Errorf("filepath cannot be empty")
 	}
 
-	f, err := os.Open(filepath)
+	// Parciales .json.gz/.json.zst se descomprimen de forma transparente
+	f, err := compress.Open(filepath)
 	if err != nil {
 		return PartialScanResult{}, fmt.Errorf("failed to open file: %w", err)
 	}
@@ -2,6 +2,8 @@
 package usecases
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -67,6 +69,38 @@ func TestMergeService_LoadPartialResults(t *testing.T) {
 	testutil.AssertEqual(t, totalArtifacts, 3, "should have 3 total artifacts")
 }
 
+func TestMergeService_LoadPartialResults_Compressed(t *testing.T) {
+	tmpDir := t.TempDir()
+	domainDir := filepath.Join(tmpDir, "example_com")
+	testutil.AssertNoError(t, os.MkdirAll(domainDir, 0o755), "create domain dir")
+
+	plain := PartialScanResult{
+		Source:    "crtsh",
+		Target:    "example.com",
+		Artifacts: []*domain.Artifact{domain.NewArtifact(domain.ArtifactTypeSubdomain, "www.example.com", "crtsh")},
+	}
+	writePartialFile(t, domainDir, "aethonx_example.com_20250119_partial_crtsh.json", plain)
+
+	// Parcial comprimido con gzip junto a uno sin comprimir
+	data, err := json.Marshal(PartialScanResult{
+		Source:    "waybackurls",
+		Target:    "example.com",
+		Artifacts: []*domain.Artifact{domain.NewArtifact(domain.ArtifactTypeURL, "https://example.com/login", "waybackurls")},
+	})
+	testutil.AssertNoError(t, err, "marshal partial")
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(data)
+	zw.Close()
+	err = os.WriteFile(filepath.Join(domainDir, "aethonx_example.com_20250119_partial_waybackurls.json.gz"), buf.Bytes(), 0o644)
+	testutil.AssertNoError(t, err, "write compressed partial")
+
+	merger := NewMergeService(logx.NewSilent())
+	results, err := merger.LoadPartialResults(tmpDir, "aethonx_example.com_20250119_partial_*.json*")
+	testutil.AssertNoError(t, err, "compressed partials load transparently")
+	testutil.AssertEqual(t, len(results), 2, "plain and compressed partials")
+}
+
 func TestMergeService_LoadPartialResults_NoFiles(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -0,0 +1,223 @@
+// internal/core/usecases/noise_service.go
+package usecases
+
+import (
+	"net/url"
+	"strings"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/validator"
+)
+
+// NoiseOptions configura la supresión de ruido de terceros.
+type NoiseOptions struct {
+	// Suppress descarta hosts con eTLD+1 distinto al del target
+	Suppress bool
+
+	// ExcludeApex descarta el artifact del dominio raíz, dejando solo subdominios
+	ExcludeApex bool
+}
+
+// NoiseService descarta artifacts de terceros: hosts cuyo eTLD+1 no coincide
+// con el del target (URLs históricas de CDNs, SANs de certificados compartidos...),
+// salvo que estén ligados al target vía CNAME o SAN de certificado o que
+// vengan de pivotar sobre el registrante (related-org). También
+// aplica las exclusiones del scope del target (Scope.ExcludeDomains).
+type NoiseService struct {
+	opts   NoiseOptions
+	logger logx.Logger
+}
+
+// NewNoiseService crea el servicio de supresión de ruido.
+func NewNoiseService(opts NoiseOptions, logger logx.Logger) *NoiseService {
+	if logger == nil {
+		logger = logx.New()
+	}
+	return &NoiseService{
+		opts:   opts,
+		logger: logger.With("component", "noise"),
+	}
+}
+
+// hostTypes son los tipos cuyo valor es (o contiene) un hostname filtrable.
+var hostTypes = map[domain.ArtifactType]bool{
+	domain.ArtifactTypeDomain:        true,
+	domain.ArtifactTypeSubdomain:     true,
+	domain.ArtifactTypeURL:           true,
+	domain.ArtifactTypeAPI:           true,
+	domain.ArtifactTypeJavaScript:    true,
+	domain.ArtifactTypeSensitiveFile: true,
+	domain.ArtifactTypeBackupFile:    true,
+}
+
+// Apply retorna los artifacts sin ruido y cuántos fueron suprimidos.
+// Un servicio nil no filtra nada.
+func (s *NoiseService) Apply(target domain.Target, artifacts []*domain.Artifact) ([]*domain.Artifact, int) {
+	if s == nil || len(artifacts) == 0 {
+		return artifacts, 0
+	}
+
+	root := target.QueryName()
+	rootETLD1 := validator.RegistrableDomain(root)
+	if rootETLD1 == "" {
+		return artifacts, 0
+	}
+
+	// Primera pasada: artifacts propios del target (anclas)
+	inScope := make(map[string]bool, len(artifacts))
+	for _, a := range artifacts {
+		if a == nil {
+			continue
+		}
+		host, ok := artifactHost(a)
+		if !ok || validator.RegistrableDomain(host) == rootETLD1 {
+			inScope[a.ID] = true
+		}
+	}
+
+	// Certificados del target y destinos de CNAME de hosts del target
+	anchors := collectNoiseAnchors(artifacts, inScope, rootETLD1)
+
+	kept := make([]*domain.Artifact, 0, len(artifacts))
+	suppressed := 0
+	bySource := make(map[string]int)
+
+	for _, a := range artifacts {
+		if a == nil {
+			continue
+		}
+
+		if s.opts.ExcludeApex && a.Type == domain.ArtifactTypeDomain && a.Value == root {
+			suppressed++
+			continue
+		}
+
+		if host, ok := artifactHost(a); ok && isExcluded(target, host) {
+			suppressed++
+			continue
+		}
+
+		if !s.opts.Suppress || inScope[a.ID] || a.IsRelatedOrg() || anchors.covers(a, inScope) {
+			kept = append(kept, a)
+			continue
+		}
+
+		suppressed++
+		for _, src := range a.Sources {
+			bySource[src]++
+		}
+	}
+
+	if suppressed > 0 {
+		s.logger.Info("third-party noise suppressed",
+			"target", root,
+			"suppressed", suppressed,
+			"kept", len(kept),
+			"by_source", bySource,
+		)
+	}
+
+	return kept, suppressed
+}
+
+// noiseAnchors son los artifacts que justifican conservar un host de terceros.
+type noiseAnchors struct {
+	certs  map[string]bool // certificados con algún SAN del target
+	cnames map[string]bool // destinos de CNAME desde hosts del target
+}
+
+// collectNoiseAnchors identifica certificados del target (por SAN o por relación
+// uses_cert desde un host propio) y destinos de CNAME de hosts propios.
+func collectNoiseAnchors(artifacts []*domain.Artifact, inScope map[string]bool, rootETLD1 string) noiseAnchors {
+	anchors := noiseAnchors{
+		certs:  make(map[string]bool),
+		cnames: make(map[string]bool),
+	}
+
+	for _, a := range artifacts {
+		if a == nil {
+			continue
+		}
+
+		if a.Type == domain.ArtifactTypeCertificate {
+			if meta, ok := a.TypedMetadata.(*metadata.CertificateMetadata); ok {
+				for _, san := range meta.SANDomains {
+					if validator.RegistrableDomain(strings.TrimPrefix(san, "*.")) == rootETLD1 {
+						anchors.certs[a.ID] = true
+						break
+					}
+				}
+			}
+			continue
+		}
+
+		if !inScope[a.ID] {
+			continue
+		}
+		for _, rel := range a.Relations {
+			switch rel.Type {
+			case domain.RelationUsesCert:
+				anchors.certs[rel.TargetID] = true
+			case domain.RelationHasCNAME:
+				anchors.cnames[rel.TargetID] = true
+			}
+		}
+	}
+
+	return anchors
+}
+
+// covers indica si un artifact de terceros está ligado al target por CNAME o
+// por certificado compartido, en cualquier dirección.
+func (n noiseAnchors) covers(a *domain.Artifact, inScope map[string]bool) bool {
+	if n.certs[a.ID] || n.cnames[a.ID] {
+		return true
+	}
+	for _, rel := range a.Relations {
+		switch rel.Type {
+		case domain.RelationUsesCert:
+			if n.certs[rel.TargetID] {
+				return true
+			}
+		case domain.RelationHasCNAME:
+			if inScope[rel.TargetID] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isExcluded indica si el host cae en alguna exclusión del scope del target.
+func isExcluded(target domain.Target, host string) bool {
+	for _, excluded := range target.Scope.ExcludeDomains {
+		if host == excluded || strings.HasSuffix(host, "."+excluded) {
+			return true
+		}
+	}
+	return false
+}
+
+// artifactHost extrae el hostname de un artifact filtrable. ok=false si el tipo
+// no es filtrable o el valor no contiene host (endpoints relativos, IPs).
+func artifactHost(a *domain.Artifact) (string, bool) {
+	if !hostTypes[a.Type] {
+		return "", false
+	}
+
+	host := a.Value
+	if a.Type != domain.ArtifactTypeDomain && a.Type != domain.ArtifactTypeSubdomain {
+		u, err := url.Parse(a.Value)
+		if err != nil || u.Hostname() == "" {
+			return "", false
+		}
+		host = u.Hostname()
+	}
+
+	if validator.IsIP(host) {
+		return "", false
+	}
+	return host, true
+}
@@ -0,0 +1,151 @@
+// internal/core/usecases/noise_service_test.go
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+)
+
+func artifactValues(artifacts []*domain.Artifact) map[string]bool {
+	values := make(map[string]bool, len(artifacts))
+	for _, a := range artifacts {
+		values[a.Value] = true
+	}
+	return values
+}
+
+func TestNoiseService_SuppressesThirdParty(t *testing.T) {
+	svc := NewNoiseService(NoiseOptions{Suppress: true}, logx.New())
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+
+	apex := domain.NewArtifact(domain.ArtifactTypeDomain, "example.com", "rdap")
+	sub := domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "crtsh")
+	ownURL := domain.NewArtifact(domain.ArtifactTypeURL, "https://www.example.com/login", "waybackurls")
+	cdnURL := domain.NewArtifact(domain.ArtifactTypeURL, "https://cdn.thirdparty.net/lib.js", "waybackurls")
+	lookalike := domain.NewArtifact(domain.ArtifactTypeSubdomain, "example.com.evil.net", "crtsh")
+	ip := domain.NewIPArtifact("93.184.216.34", "dns")
+
+	// CNAME desde un host propio hacia un proveedor externo
+	cnameTarget := domain.NewArtifact(domain.ArtifactTypeSubdomain, "example.herokudns.com", "amass")
+	sub.AddRelation(cnameTarget.ID, domain.RelationHasCNAME, 1.0, "amass")
+
+	// Certificado compartido: un SAN de terceros junto a nombres del target
+	certMeta := &metadata.CertificateMetadata{SANDomains: []string{"example.com", "partner.io"}}
+	cert := domain.NewArtifactWithMetadata(domain.ArtifactTypeCertificate, "0a1b2c", "crtsh", certMeta)
+	partner := domain.NewArtifact(domain.ArtifactTypeSubdomain, "partner.io", "crtsh")
+	partner.AddRelation(cert.ID, domain.RelationUsesCert, 0.95, "crtsh")
+
+	kept, suppressed := svc.Apply(target, []*domain.Artifact{
+		apex, sub, ownURL, cdnURL, lookalike, ip, cnameTarget, cert, partner,
+	})
+	values := artifactValues(kept)
+
+	testutil.AssertEqual(t, suppressed, 2, "cdn URL and lookalike should be suppressed")
+	testutil.AssertFalse(t, values["https://cdn.thirdparty.net/lib.js"], "third-party URL dropped")
+	testutil.AssertFalse(t, values["example.com.evil.net"], "suffix lookalike dropped")
+	testutil.AssertTrue(t, values["example.com"], "apex kept")
+	testutil.AssertTrue(t, values["93.184.216.34"], "IPs are not filtered")
+	testutil.AssertTrue(t, values["example.herokudns.com"], "CNAME target kept")
+	testutil.AssertTrue(t, values["partner.io"], "cert SAN sibling kept")
+}
+
+func TestNoiseService_KeepsRelatedOrg(t *testing.T) {
+	svc := NewNoiseService(NoiseOptions{Suppress: true}, logx.New())
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+
+	related := domain.NewArtifact(domain.ArtifactTypeDomain, "example-holdings.net", "reversewhois")
+	related.AddTag(domain.TagRelatedOrg)
+	other := domain.NewArtifact(domain.ArtifactTypeDomain, "unrelated.net", "crtsh")
+
+	kept, suppressed := svc.Apply(target, []*domain.Artifact{related, other})
+	values := artifactValues(kept)
+
+	testutil.AssertEqual(t, suppressed, 1, "only the untagged third party is suppressed")
+	testutil.AssertTrue(t, values["example-holdings.net"], "related-org domain kept")
+}
+
+// Los dominios de otra organización llegan a las sources pasivas pero nunca a
+// las activas, y sobreviven a la supresión de ruido.
+func TestPipelineOrchestrator_RelatedOrgNotProbed(t *testing.T) {
+	pivot := newMockSource("reversewhois", domain.SourceModePassive, domain.SourceTypeAPI)
+	pivot.runFunc = func(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+		result := domain.NewScanResult(target)
+		related := domain.NewArtifact(domain.ArtifactTypeDomain, "example-holdings.net", "reversewhois")
+		related.AddTag(domain.TagRelatedOrg)
+		result.AddArtifacts(domain.NewArtifact(domain.ArtifactTypeDomain, "example.com", "reversewhois"), related)
+		return result, nil
+	}
+	var probed []string
+	prober := &mockInputConsumerSource{
+		name: "httpx",
+		onRunWithInput: func(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+			for _, a := range input.Artifacts {
+				probed = append(probed, a.Value)
+			}
+			return domain.NewScanResult(target), nil
+		},
+	}
+
+	domains := []domain.ArtifactType{domain.ArtifactTypeDomain}
+	orch := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources: []ports.Source{pivot, prober},
+		SourceMetadata: map[string]ports.SourceMetadata{
+			"reversewhois": {Name: "reversewhois", OutputArtifacts: domains},
+			"httpx":        {Name: "httpx", Mode: domain.SourceModeActive, InputArtifacts: domains},
+		},
+		Logger: logx.NewSilent(),
+		Noise:  NewNoiseService(NoiseOptions{Suppress: true}, logx.NewSilent()),
+	})
+	result, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModeHybrid))
+	testutil.AssertNoError(t, err, "run")
+
+	testutil.AssertEqual(t, len(probed), 1, "only the target reaches the active source")
+	testutil.AssertEqual(t, probed[0], "example.com", "target probed")
+	testutil.AssertTrue(t, artifactValues(result.Artifacts)["example-holdings.net"], "related-org domain in output")
+	testutil.AssertEqual(t, result.Metadata.NoiseSuppressed, 0, "nothing suppressed")
+}
+
+func TestNoiseService_ExcludeApex(t *testing.T) {
+	svc := NewNoiseService(NoiseOptions{ExcludeApex: true}, logx.New())
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+
+	apex := domain.NewArtifact(domain.ArtifactTypeDomain, "example.com", "rdap")
+	sub := domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "crtsh")
+	other := domain.NewArtifact(domain.ArtifactTypeSubdomain, "cdn.thirdparty.net", "crtsh")
+
+	kept, suppressed := svc.Apply(target, []*domain.Artifact{apex, sub, other})
+
+	testutil.AssertEqual(t, suppressed, 1, "only the apex is dropped")
+	testutil.AssertEqual(t, len(kept), 2, "subdomain and third-party kept without Suppress")
+}
+
+func TestNoiseService_NilIsNoop(t *testing.T) {
+	var svc *NoiseService
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+	in := []*domain.Artifact{domain.NewArtifact(domain.ArtifactTypeSubdomain, "cdn.thirdparty.net", "crtsh")}
+
+	kept, suppressed := svc.Apply(target, in)
+	testutil.AssertEqual(t, suppressed, 0, "nil service suppresses nothing")
+	testutil.AssertEqual(t, len(kept), 1, "artifacts untouched")
+}
+
+func TestNoiseService_ScopeExclusions(t *testing.T) {
+	svc := NewNoiseService(NoiseOptions{}, logx.New())
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+	target.AddExclusion("legacy.example.com")
+
+	kept, suppressed := svc.Apply(target, []*domain.Artifact{
+		domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "crtsh"),
+		domain.NewArtifact(domain.ArtifactTypeSubdomain, "old.legacy.example.com", "crtsh"),
+		domain.NewArtifact(domain.ArtifactTypeURL, "https://legacy.example.com/login", "waybackurls"),
+	})
+
+	testutil.AssertEqual(t, suppressed, 2, "excluded host and its URL dropped")
+	testutil.AssertEqual(t, kept[0].Value, "api.example.com", "in-scope host kept")
+}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	"sync"
-	"time"
 
 	"aethonx/internal/core/domain"
 	"aethonx/internal/core/ports"
@@ -19,16 +18,21 @@ type Orchestrator struct {
 	logger    logx.Logger
 	observers []ports.Notifier
 
+	// baseLogger logger raíz del que derivan los loggers por escaneo
+	baseLogger logx.Logger
+
+	// scanID escaneo en curso; se añade a logs y eventos
+	scanID string
+
 	// Configuración
 	maxWorkers      int
 	failFast        bool
 	streamingWriter StreamingWriter
 	streamingConfig StreamingConfig
 
-	// Control de goroutines
-	notifyWg   sync.WaitGroup
-	notifySem  chan struct{} // Semáforo para limitar notificaciones concurrentes
-	maxNotifiers int
+	// Entrega de eventos: un bus por escaneo con cola ordenada por observer
+	events     *EventBus
+	eventSpool ports.EventSpool
 }
 
 // OrchestratorOptions configura el orchestrator.
@@ -40,6 +44,9 @@ type OrchestratorOptions struct {
 	FailFast        bool
 	StreamingWriter StreamingWriter
 	StreamingConfig StreamingConfig
+
+	// EventSpool persiste eventos no entregados a los observers (opcional)
+	EventSpool ports.EventSpool
 }
 
 // StreamingWriter es la interfaz para escribir resultados parciales.
@@ -53,6 +60,10 @@ type StreamingWriter interface {
 type StreamingConfig struct {
 	ArtifactThreshold int
 	OutputDir         string
+
+	// MemoryBudgetBytes escribe a disco cuando los artifacts retenidos superan
+	// este tamaño aproximado en bytes (0 = solo ArtifactThreshold)
+	MemoryBudgetBytes int64
 }
 
 // NewOrchestrator crea una nueva instancia del orchestrator.
@@ -67,23 +78,17 @@ func NewOrchestrator(opts OrchestratorOptions) *Orchestrator {
 		opts.StreamingConfig.ArtifactThreshold = 1000 // default
 	}
 
-	// Limitar notificaciones concurrentes (2x observers o mínimo 4)
-	maxNotifiers := len(opts.Observers) * 2
-	if maxNotifiers < 4 {
-		maxNotifiers = 4
-	}
-
 	return &Orchestrator{
 		sources:         opts.Sources,
 		dedupe:          NewDedupeService(),
 		logger:          opts.Logger.With("component", "orchestrator"),
+		baseLogger:      opts.Logger,
 		observers:       opts.Observers,
 		maxWorkers:      opts.MaxWorkers,
 		failFast:        opts.FailFast,
 		streamingWriter: opts.StreamingWriter,
 		streamingConfig: opts.StreamingConfig,
-		notifySem:       make(chan struct{}, maxNotifiers),
-		maxNotifiers:    maxNotifiers,
+		eventSpool:      opts.EventSpool,
 	}
 }
 
@@ -94,8 +99,10 @@ func (o *Orchestrator) Run(ctx context.Context, target domain.Target) (*domain.S
 		return nil, err
 	}
 
-	// Crear resultado
+	// Crear resultado; su ID correlaciona logs y eventos
 	result := domain.NewScanResult(target)
+	o.scanID = result.ID
+	o.logger = o.baseLogger.With("component", "orchestrator", "scan_id", result.ID)
 
 	// Filtrar fuentes compatibles con el modo de escaneo
 	sources := o.filterCompatibleSources(target.Mode)
@@ -104,6 +111,12 @@ func (o *Orchestrator) Run(ctx context.Context, target domain.Target) (*domain.S
 	}
 
 	result.Metadata.TotalSources = len(sources)
+	beginScan(sources, result.ID)
+
+	// Bus de eventos del escaneo; Close entrega lo pendiente antes de retornar
+	o.events = NewEventBus(o.observers, EventBusOptions{Spool: o.eventSpool, Logger: o.logger})
+	defer func() { logEventStats(o.logger, o.events.Close()) }()
+
 	o.logger.Info("starting scan",
 		"target", target.Root,
 		"mode", target.Mode,
@@ -112,7 +125,7 @@ func (o *Orchestrator) Run(ctx context.Context, target domain.Target) (*domain.S
 	)
 
 	// Notificar inicio
-	o.notify(ctx, ports.NewEvent(
+	o.notify(ports.NewEvent(
 		ports.EventTypeScanStarted,
 		"orchestrator",
 		ports.ScanStartedEvent{
@@ -121,11 +134,8 @@ func (o *Orchestrator) Run(ctx context.Context, target domain.Target) (*domain.S
 		},
 	))
 
-	// Ejecutar fuentes en paralelo
-	sourceResults := o.executeSources(ctx, sources, target)
-
-	// Consolidar resultados en memoria
-	o.consolidateResults(result, sourceResults)
+	// Ejecutar fuentes en paralelo consolidando en memoria según terminan
+	o.executeSources(ctx, sources, target, domain.NewArtifactStore(result))
 
 	// Si hay streaming writer, cargar archivos parciales
 	if o.streamingWriter != nil {
@@ -184,7 +194,7 @@ func (o *Orchestrator) Run(ctx context.Context, target domain.Target) (*domain.S
 	}
 
 	// Notificar finalización
-	o.notify(ctx, ports.NewEvent(
+	o.notify(ports.NewEvent(
 		ports.EventTypeScanCompleted,
 		"orchestrator",
 		ports.ScanCompletedEvent{
@@ -195,11 +205,6 @@ func (o *Orchestrator) Run(ctx context.Context, target domain.Target) (*domain.S
 		},
 	))
 
-	// Esperar a que todas las notificaciones terminen antes de retornar
-	o.logger.Debug("waiting for all notifications to complete")
-	o.notifyWg.Wait()
-	o.logger.Debug("all notifications completed")
-
 	return result, nil
 }
 
@@ -215,11 +220,13 @@ func (o *Orchestrator) filterCompatibleSources(mode domain.ScanMode) []ports.Sou
 }
 
 // executeSources ejecuta las fuentes con worker pool y scheduling inteligente.
+// Cada fuente consolida su resultado en store al terminar.
 func (o *Orchestrator) executeSources(
 	ctx context.Context,
 	sources []ports.Source,
 	target domain.Target,
-) []sourceResult {
+	store *domain.ArtifactStore,
+) {
 	// Crear tasks desde sources
 	tasks := make([]SourceTaskExecutor, 0, len(sources))
 	for _, source := range sources {
@@ -243,8 +250,6 @@ func (o *Orchestrator) executeSources(
 	// En futuro: usar workerpool.WorkerPool cuando se implemente completamente
 	sem := make(chan struct{}, o.maxWorkers)
 	var wg sync.WaitGroup
-	results := make([]sourceResult, 0, len(sources))
-	resultsMu := sync.Mutex{}
 
 	// Ordenar tasks por prioridad y peso antes de ejecutar
 	sortedTasks := sortTasksByPriority(tasks)
@@ -261,15 +266,19 @@ func (o *Orchestrator) executeSources(
 			// Ejecutar fuente
 			res := o.executeSource(ctx, t.GetSource(), target)
 
-			// Guardar resultado
-			resultsMu.Lock()
-			results = append(results, res)
-			resultsMu.Unlock()
+			// Consolidar resultado
+			store.Update(func(r *domain.ScanResult) {
+				r.Metadata.SourcesUsed = append(r.Metadata.SourcesUsed, res.source)
+			})
+			if res.err != nil {
+				store.AddErrorWithSeverity(res.source, res.err.Error(), domain.ErrorCritical, true)
+				return
+			}
+			store.Merge(res.result)
 		}(task)
 	}
 
 	wg.Wait()
-	return results
 }
 
 // SourceTaskExecutor interface mínima para compatibilidad.
@@ -332,8 +341,13 @@ func (o *Orchestrator) executeSource(
 	sourceName := source.Name()
 	o.logger.Debug("executing source", "source", sourceName)
 
+	// Logs de la source correlacionables con el escaneo
+	if scoped, ok := source.(ports.LogScopedSource); ok {
+		scoped.SetLogger(o.baseLogger.With("scan_id", o.scanID, "source", sourceName))
+	}
+
 	// Notificar inicio de fuente
-	o.notify(ctx, ports.NewEvent(
+	o.notify(ports.NewEvent(
 		ports.EventTypeSourceStarted,
 		sourceName,
 		nil,
@@ -344,7 +358,7 @@ func (o *Orchestrator) executeSource(
 
 	if err != nil {
 		o.logger.Warn("source failed", "source", sourceName, "error", err.Error())
-		o.notify(ctx, ports.NewEvent(
+		o.notify(ports.NewEvent(
 			ports.EventTypeSourceFailed,
 			sourceName,
 			err,
@@ -388,7 +402,7 @@ func (o *Orchestrator) executeSource(
 	}
 
 	// Notificar finalización de fuente
-	o.notify(ctx, ports.NewEvent(
+	o.notify(ports.NewEvent(
 		ports.EventTypeSourceCompleted,
 		sourceName,
 		artifactCount,
@@ -400,143 +414,13 @@ func (o *Orchestrator) executeSource(
 	}
 }
 
-// consolidateResults consolida resultados de todas las fuentes usando fan-out/fan-in pattern.
-// Cada goroutine escribe en su buffer local (sin contención), luego se hace merge secuencial.
-func (o *Orchestrator) consolidateResults(
-	result *domain.ScanResult,
-	sourceResults []sourceResult,
-) {
-	if len(sourceResults) == 0 {
-		return
-	}
-
-	// Si hay pocos resultados, consolidar secuencialmente (más eficiente)
-	if len(sourceResults) <= 2 {
-		o.consolidateResultsSequential(result, sourceResults)
-		return
-	}
-
-	// Buffer local por goroutine (elimina contención de mutex)
-	type resultBuffer struct {
-		sourceName string
-		artifacts  []*domain.Artifact
-		warnings   []domain.Warning
-		errors     []domain.Error
-	}
-
-	buffers := make([]resultBuffer, len(sourceResults))
-	var wg sync.WaitGroup
-
-	// Fan-out: cada goroutine procesa su resultado en buffer dedicado
-	for i, sr := range sourceResults {
-		wg.Add(1)
-		go func(idx int, sourceRes sourceResult) {
-			defer wg.Done()
-
-			buf := resultBuffer{sourceName: sourceRes.source}
-
-			if sourceRes.err != nil {
-				// Error de source: crear Error entry
-				buf.errors = []domain.Error{{
-					Source:   sourceRes.source,
-					Message:  sourceRes.err.Error(),
-					Severity: domain.ErrorCritical,
-				}}
-			} else if sourceRes.result != nil {
-				// Source exitosa: copiar datos
-				buf.artifacts = sourceRes.result.Artifacts
-				buf.warnings = sourceRes.result.Warnings
-				buf.errors = sourceRes.result.Errors
-			}
-
-			buffers[idx] = buf
-		}(i, sr)
-	}
-
-	wg.Wait()
-
-	// Fan-in: merge secuencial sin concurrencia (thread-safe)
-	for _, buf := range buffers {
-		result.Metadata.SourcesUsed = append(result.Metadata.SourcesUsed, buf.sourceName)
-		result.Artifacts = append(result.Artifacts, buf.artifacts...)
-		result.Warnings = append(result.Warnings, buf.warnings...)
-		result.Errors = append(result.Errors, buf.errors...)
-	}
-}
-
-// consolidateResultsSequential consolidación secuencial (fallback).
-func (o *Orchestrator) consolidateResultsSequential(
-	result *domain.ScanResult,
-	sourceResults []sourceResult,
-) {
-	for _, sr := range sourceResults {
-		result.Metadata.SourcesUsed = append(result.Metadata.SourcesUsed, sr.source)
-
-		if sr.err != nil {
-			result.AddError(sr.source, sr.err.Error(), false)
-			continue
-		}
-
-		if sr.result != nil {
-			// Añadir artifacts
-			result.Artifacts = append(result.Artifacts, sr.result.Artifacts...)
-
-			// Añadir warnings
-			result.Warnings = append(result.Warnings, sr.result.Warnings...)
-
-			// Añadir errores
-			result.Errors = append(result.Errors, sr.result.Errors...)
-		}
-	}
-}
-
-// notify envía una notificación a todos los observers con pool limitado.
-// Usa goroutines con WaitGroup, semáforo y timeout para evitar leaks y bloqueos.
-func (o *Orchestrator) notify(ctx context.Context, event ports.Event) {
-	const notificationTimeout = 5 * time.Second
-
-	for _, observer := range o.observers {
-		o.notifyWg.Add(1)
-		go func(notifier ports.Notifier) {
-			defer o.notifyWg.Done()
-
-			// Adquirir semáforo para limitar goroutines concurrentes
-			select {
-			case o.notifySem <- struct{}{}:
-				defer func() { <-o.notifySem }()
-			case <-ctx.Done():
-				o.logger.Warn("notification skipped, context cancelled")
-				return
-			}
-
-			// Crear contexto con timeout para esta notificación
-			notifyCtx, cancel := context.WithTimeout(ctx, notificationTimeout)
-			defer cancel()
-
-			// Canal para capturar el resultado
-			done := make(chan error, 1)
-
-			// Ejecutar notificación en goroutine separada
-			go func() {
-				done <- notifier.Notify(notifyCtx, event)
-			}()
-
-			// Esperar resultado o timeout
-			select {
-			case err := <-done:
-				if err != nil {
-					o.logger.Warn("notification failed", "error", err.Error())
-				}
-			case <-notifyCtx.Done():
-				if notifyCtx.Err() == context.DeadlineExceeded {
-					o.logger.Warn("notification timeout exceeded",
-						"timeout", notificationTimeout,
-						"event_type", event.Type,
-					)
-				}
-			}
-		}(observer)
+// notify publica el evento en el bus del escaneo; cada observer lo recibe
+// en orden de publicación.
+func (o *Orchestrator) notify(event ports.Event) {
+	if event.ScanID == "" {
+		event.ScanID = o.scanID
 	}
+	o.events.Publish(event)
 }
 
 // sourceResult encapsula el resultado de ejecución de una fuente.
@@ -2,12 +2,14 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"aethonx/internal/core/domain"
 	"aethonx/internal/core/ports"
 	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil/sourcetest"
 )
 
 // MockPassiveSource simula una source de Stage 0 (sin inputs)
@@ -365,3 +367,49 @@ func (m *mockInputConsumerSource) RunWithInput(ctx context.Context, target domai
 	}
 	return domain.NewScanResult(target), nil
 }
+
+// TestPipelineOrchestrator_MockSources prueba un pipeline con sources
+// simuladas de testutil/sourcetest: una falla y la siguiente etapa recibe
+// los artifacts de la que sí terminó
+func TestPipelineOrchestrator_MockSources(t *testing.T) {
+	discovery := sourcetest.NewMockSource("discovery",
+		sourcetest.Artifacts(domain.ArtifactTypeSubdomain, "discovery", "api.example.com", "www.example.com")...)
+	discovery.Delay = 5 * time.Millisecond
+	broken := sourcetest.NewMockSource("broken",
+		sourcetest.Artifacts(domain.ArtifactTypeSubdomain, "broken", "dev.example.com")...)
+	broken.Err = errors.New("upstream unavailable")
+	probe := sourcetest.NewMockSource("probe",
+		sourcetest.Artifacts(domain.ArtifactTypeURL, "probe", "https://api.example.com")...)
+	probe.SourceMode = domain.SourceModeActive
+
+	orchestrator := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources: []ports.Source{discovery, broken, probe},
+		SourceMetadata: map[string]ports.SourceMetadata{
+			"discovery": {Name: "discovery", OutputArtifacts: []domain.ArtifactType{domain.ArtifactTypeSubdomain}},
+			"broken":    {Name: "broken", OutputArtifacts: []domain.ArtifactType{domain.ArtifactTypeSubdomain}},
+			"probe": {
+				Name:            "probe",
+				InputArtifacts:  []domain.ArtifactType{domain.ArtifactTypeSubdomain},
+				OutputArtifacts: []domain.ArtifactType{domain.ArtifactTypeURL},
+			},
+		},
+		Logger:     logx.NewSilent(),
+		MaxWorkers: 2,
+	})
+
+	result, err := orchestrator.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModeHybrid))
+	if err != nil {
+		t.Fatalf("a failing source should not fail the pipeline: %v", err)
+	}
+
+	for _, source := range []*sourcetest.MockSource{discovery, broken, probe} {
+		if source.Runs() != 1 {
+			t.Errorf("%s: expected 1 run, got %d", source.Name(), source.Runs())
+		}
+	}
+	if input := probe.Input(); input == nil || len(input.Artifacts) < 2 {
+		t.Errorf("probe should receive the Stage 0 subdomains, got %v", input)
+	}
+	sourcetest.AssertArtifacts(t, result.Artifacts,
+		"subdomain api.example.com", "subdomain www.example.com", "url https://api.example.com")
+}
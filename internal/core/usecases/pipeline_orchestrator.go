@@ -3,10 +3,14 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"runtime"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"aethonx/internal/core/domain"
@@ -32,15 +36,48 @@ type PipelineOrchestrator struct {
 	dedupeService  *DedupeService
 	mergeService   *MergeService
 	graphService   *GraphService
+	taggingService *TaggingService
+	noiseService   *NoiseService
 	logger         logx.Logger
 
+	// baseLogger logger raíz (sin campos) del que derivan los loggers por
+	// escaneo y los de las sources
+	baseLogger logx.Logger
+
+	// scanID escaneo en curso; se añade a logs y eventos
+	scanID string
+
+	// scheduler reparte sources entre agentes remotos (nil = todo en local)
+	scheduler *AgentScheduler
+
+	// minRelationConfidence umbral de poda de relaciones antes de exportar
+	minRelationConfidence float64
+
 	// Configuración de ejecución
 	maxWorkers      int
 	streamingWriter StreamingWriter
 	streamingConfig StreamingConfig
 
-	// Observers para eventos
-	observers []ports.Notifier
+	// providerConcurrency peticiones en vuelo a un proveedor compartido por
+	// varias sources de un stage
+	providerConcurrency int
+
+	// skippedPhases fases del escaneo omitidas (SourceMetadata.StagePhase)
+	skippedPhases map[string]bool
+
+	// criticalSources y criticalStages abortan el pipeline si una de sus
+	// sources falla (fail-fast); por defecto se continúa (fail-soft)
+	criticalSources map[string]bool
+	criticalStages  map[int]bool
+
+	// memory cuenta los bytes aproximados de artifacts retenidos frente a
+	// StreamingConfig.MemoryBudgetBytes
+	memory *memoryBudget
+
+	// Observers para eventos; events es el bus del escaneo en curso
+	observers  []ports.Notifier
+	events     *EventBus
+	eventSpool ports.EventSpool
 
 	// UI Presenter para visualización del progreso
 	presenter ui.Presenter
@@ -48,6 +85,31 @@ type PipelineOrchestrator struct {
 
 	// stageResults almacena resultados de todos los stages para estadísticas
 	stageResults []StageResult
+
+	// limits topes del escaneo y de las sources; budget cuenta los artifacts
+	// admitidos en el escaneo en curso
+	limits ScanLimits
+	budget *artifactBudget
+
+	// truncations sources y stages detenidos por un tope en el escaneo en curso
+	truncMu     sync.Mutex
+	truncations []domain.Truncation
+
+	// cacheStats aciertos y fallos de la caché de sources en el escaneo en curso
+	cacheMu    sync.Mutex
+	cacheStats domain.SourceCacheStats
+
+	// activeWindow franja en la que pueden ejecutarse los stages activos
+	// (nil = siempre); deferrals registra los aplazados en el escaneo en curso
+	activeWindow *domain.TimeWindow
+	deferrals    []domain.StageDeferral
+
+	// captureDir directorio donde las sources archivan su salida cruda
+	// ("" = sin captura)
+	captureDir string
+
+	// now reloj del orchestrator (time.Now; sustituible en tests)
+	now func() time.Time
 }
 
 // PipelineOrchestratorOptions configura el pipeline orchestrator.
@@ -61,6 +123,46 @@ type PipelineOrchestratorOptions struct {
 	StreamingConfig StreamingConfig
 	Presenter       ui.Presenter
 	UIConfig        UIConfig
+
+	// ProviderConcurrency peticiones en vuelo a un proveedor
+	// (SourceMetadata.Providers) compartido por varias sources de un stage
+	// (0 = DefaultProviderConcurrency)
+	ProviderConcurrency int
+
+	// SkipStages fases cuyas sources no se ejecutan (ports.PhaseVerification,
+	// ports.PhaseEnrichment...); los stages que quedan vacíos desaparecen
+	SkipStages []string
+
+	// CriticalSources sources cuyo fallo aborta el pipeline; CriticalStages
+	// stages (Stage.ID) en los que el fallo de cualquier source lo aborta
+	CriticalSources []string
+	CriticalStages  []int
+
+	// Tagger aplica reglas de etiquetado del usuario en la consolidación (opcional)
+	Tagger *TaggingService
+
+	// Noise descarta artifacts de terceros en la consolidación (opcional)
+	Noise *NoiseService
+
+	// MinRelationConfidence descarta relaciones por debajo de este umbral (0 = todas)
+	MinRelationConfidence float64
+
+	// Scheduler ejecuta sources en agentes remotos (opcional)
+	Scheduler *AgentScheduler
+
+	// EventSpool persiste eventos no entregados a los observers (opcional)
+	EventSpool ports.EventSpool
+
+	// Limits topes de artifacts y duración del escaneo y por source (opcional)
+	Limits ScanLimits
+
+	// ActiveWindow restringe los stages activos a una franja horaria; fuera
+	// de ella se aplazan hasta su apertura (opcional, nil = siempre)
+	ActiveWindow *domain.TimeWindow
+
+	// CaptureDir directorio de capturas crudas para "aethonx replay": cada
+	// source recibe <CaptureDir>/<source> vía ports.WithCaptureDir (opcional)
+	CaptureDir string
 }
 
 // UIConfig contiene configuración de UI
@@ -85,19 +187,46 @@ func NewPipelineOrchestrator(opts PipelineOrchestratorOptions) *PipelineOrchestr
 	if opts.Presenter == nil {
 		opts.Presenter = ui.NewRawPresenter(ui.LogFormatText)
 	}
+	skipStages := make(map[string]bool, len(opts.SkipStages))
+	for _, phase := range opts.SkipStages {
+		skipStages[phase] = true
+	}
+	criticalSources := make(map[string]bool, len(opts.CriticalSources))
+	for _, name := range opts.CriticalSources {
+		criticalSources[name] = true
+	}
+	criticalStages := make(map[int]bool, len(opts.CriticalStages))
+	for _, id := range opts.CriticalStages {
+		criticalStages[id] = true
+	}
 
 	return &PipelineOrchestrator{
-		sources:         opts.Sources,
-		sourceMetadata:  opts.SourceMetadata,
-		dedupeService:   NewDedupeService(),
-		mergeService:    NewMergeService(opts.Logger),
-		logger:          opts.Logger.With("component", "pipeline_orchestrator"),
-		observers:       opts.Observers,
-		maxWorkers:      opts.MaxWorkers,
-		streamingWriter: opts.StreamingWriter,
-		streamingConfig: opts.StreamingConfig,
-		presenter:       opts.Presenter,
-		uiConfig:        opts.UIConfig,
+		sources:               opts.Sources,
+		sourceMetadata:        opts.SourceMetadata,
+		dedupeService:         NewDedupeService(),
+		mergeService:          NewMergeService(opts.Logger),
+		taggingService:        opts.Tagger,
+		noiseService:          opts.Noise,
+		scheduler:             opts.Scheduler,
+		minRelationConfidence: opts.MinRelationConfidence,
+		logger:                opts.Logger.With("component", "orchestrator"),
+		baseLogger:            opts.Logger,
+		observers:             opts.Observers,
+		eventSpool:            opts.EventSpool,
+		maxWorkers:            opts.MaxWorkers,
+		providerConcurrency:   opts.ProviderConcurrency,
+		skippedPhases:         skipStages,
+		criticalSources:       criticalSources,
+		criticalStages:        criticalStages,
+		streamingWriter:       opts.StreamingWriter,
+		streamingConfig:       opts.StreamingConfig,
+		memory:                newMemoryBudget(opts.StreamingConfig.MemoryBudgetBytes),
+		presenter:             opts.Presenter,
+		uiConfig:              opts.UIConfig,
+		limits:                opts.Limits,
+		activeWindow:          opts.ActiveWindow,
+		captureDir:            opts.CaptureDir,
+		now:                   time.Now,
 	}
 }
 
@@ -144,14 +273,26 @@ func (p *PipelineOrchestrator) Run(ctx context.Context, target domain.Target) (*
 		return nil, fmt.Errorf("invalid target: %w", err)
 	}
 
-	// Filtrar sources compatibles con el scan mode
+	// Filtrar sources compatibles con el scan mode y sin las fases omitidas
 	compatibleSources := p.filterCompatibleSources(p.sources, target.Mode)
+	compatibleSources, skipped := p.skipPhases(compatibleSources)
 	if len(compatibleSources) == 0 {
 		return nil, domain.ErrNoSourcesAvailable
 	}
 
-	// Resetear stageResults para esta ejecución
+	// Inicializar resultado acumulador; su ID correlaciona logs y eventos
+	result := domain.NewScanResult(target)
+	result.Metadata.TotalSources = len(compatibleSources)
+	p.scanID = result.ID
+	p.logger = p.baseLogger.With("component", "orchestrator", "scan_id", result.ID)
+
+	// Resetear stageResults, cuenta de memoria y topes para esta ejecución
 	p.stageResults = nil
+	p.memory = newMemoryBudget(p.streamingConfig.MemoryBudgetBytes)
+	p.budget = newArtifactBudget(p.limits.MaxArtifacts)
+	p.truncations = nil
+	p.cacheStats = domain.SourceCacheStats{}
+	p.deferrals = nil
 
 	p.logger.Info("starting pipeline execution",
 		"target", target.Root,
@@ -159,6 +300,10 @@ func (p *PipelineOrchestrator) Run(ctx context.Context, target domain.Target) (*
 		"sources", len(compatibleSources),
 		"workers", p.maxWorkers,
 	)
+	for _, phase := range p.skippedStages() {
+		p.logger.Info("stage skipped", "phase", phase, "sources", skipped[phase])
+	}
+	result.Metadata.SkippedStages = p.skippedStages()
 
 	// Construir stages
 	stages, err := p.BuildStages(compatibleSources)
@@ -173,6 +318,7 @@ func (p *PipelineOrchestrator) Run(ctx context.Context, target domain.Target) (*
 		Workers:        p.maxWorkers,
 		TimeoutSeconds: p.uiConfig.TimeoutS,
 		StreamingOn:    p.streamingWriter != nil,
+		MemoryBudget:   p.streamingConfig.MemoryBudgetBytes,
 		TotalStages:    len(stages),
 		UIMode:         p.uiConfig.Mode,
 		ShowMetrics:    p.uiConfig.ShowMetrics,
@@ -180,12 +326,20 @@ func (p *PipelineOrchestrator) Run(ctx context.Context, target domain.Target) (*
 	})
 	defer p.presenter.Close()
 
-	// Inicializar resultado acumulador
-	result := domain.NewScanResult(target)
-	result.Metadata.TotalSources = len(compatibleSources)
+	// Métricas de memoria en vivo
+	if p.uiConfig.ShowMetrics {
+		stopMemoryStats := p.reportMemoryStats()
+		defer stopMemoryStats()
+	}
+
+	beginScan(compatibleSources, result.ID)
+
+	// Bus de eventos del escaneo; Close entrega lo pendiente antes de retornar
+	p.events = NewEventBus(p.observers, EventBusOptions{Spool: p.eventSpool, Logger: p.logger})
+	defer func() { logEventStats(p.logger, p.events.Close()) }()
 
 	// Notificar inicio
-	p.notifyEvent(ctx, ports.NewEvent(
+	p.notifyEvent(ports.NewEvent(
 		ports.EventTypeScanStarted,
 		"pipeline_orchestrator",
 		ports.ScanStartedEvent{
@@ -194,13 +348,29 @@ func (p *PipelineOrchestrator) Run(ctx context.Context, target domain.Target) (*
 		},
 	))
 
-	// Ejecutar stages secuencialmente
+	// Ejecutar stages secuencialmente; abortErr detiene el pipeline tras el
+	// fallo de una source crítica
+	var abortErr error
 	for i, stage := range stages {
+		// Con un tope del escaneo alcanzado no se arrancan más stages
+		if cause := p.scanLimitCause(startTime); cause != nil {
+			p.skipStages(stages[i:], cause)
+			break
+		}
+
+		// Los stages activos esperan a la franja permitida (--active-window)
+		if !p.awaitActiveWindow(ctx, stage, target.Mode, startTime) {
+			deferral := p.deferrals[len(p.deferrals)-1]
+			result.AddWarning("pipeline", "active stage deferred: "+deferral.String())
+			continue
+		}
+
 		stageStartTime := time.Now()
 		p.logger.Info("executing stage",
 			"stage_id", stage.ID,
 			"stage_name", stage.Name,
 			"sources", stage.SourceCount(),
+			"workers", p.stageWorkers(stage),
 		)
 
 		// Notificar inicio de stage al presenter
@@ -209,7 +379,7 @@ func (p *PipelineOrchestrator) Run(ctx context.Context, target domain.Target) (*
 			sourceNames = append(sourceNames, src.Name())
 		}
 		p.presenter.StartStage(ui.StageInfo{
-			Number:      i + 1,
+			Number:      stage.Number(),
 			TotalStages: len(stages),
 			Name:        stage.Name,
 			Sources:     sourceNames,
@@ -222,28 +392,39 @@ func (p *PipelineOrchestrator) Run(ctx context.Context, target domain.Target) (*
 		var stageCancel context.CancelFunc
 
 		// Verificar si el contexto padre está cancelado
+		stageTimeout := time.Duration(p.uiConfig.TimeoutS) * time.Second
 		if ctx.Err() != nil {
 			p.logger.Warn("parent context cancelled, creating fresh context for stage",
 				"stage_id", stage.ID,
 				"stage_name", stage.Name,
 			)
 			// Contexto padre cancelado, crear uno completamente nuevo
-			if p.uiConfig.TimeoutS > 0 {
-				stageCtx, stageCancel = context.WithTimeout(context.Background(), time.Duration(p.uiConfig.TimeoutS)*time.Second)
-			} else {
-				stageCtx, stageCancel = context.WithCancel(context.Background())
-			}
+			stageCtx, stageCancel = p.stageContext(context.Background(), stage, stageTimeout)
 		} else {
 			// Contexto padre activo, crear hijo con timeout
-			if p.uiConfig.TimeoutS > 0 {
-				stageCtx, stageCancel = context.WithTimeout(ctx, time.Duration(p.uiConfig.TimeoutS)*time.Second)
-			} else {
-				stageCtx, stageCancel = context.WithCancel(ctx)
+			stageCtx, stageCancel = p.stageContext(ctx, stage, stageTimeout)
+		}
+
+		// --max-duration del escaneo cancela también los stages recreados
+		if p.limits.MaxDuration > 0 {
+			var cancelDeadline context.CancelFunc
+			stageCtx, cancelDeadline = context.WithDeadlineCause(stageCtx, startTime.Add(p.limits.MaxDuration), errScanMaxDuration)
+			cancelStage := stageCancel
+			stageCancel = func() {
+				cancelDeadline()
+				cancelStage()
 			}
 		}
 
 		// Ejecutar stage con artifacts acumulados como input
 		stageResult, err := p.executeStage(stageCtx, stage, result)
+		if errors.Is(context.Cause(stageCtx), errStageMaxDuration) {
+			p.logger.Warn("stage duration limit reached",
+				"stage_id", stage.ID,
+				"stage_name", stage.Name,
+				"limit", p.limits.StageMaxDuration[stage.ID].String(),
+			)
+		}
 		stageCancel() // Limpiar contexto del stage
 
 		if err != nil {
@@ -271,7 +452,7 @@ func (p *PipelineOrchestrator) Run(ctx context.Context, target domain.Target) (*
 		p.stageResults = append(p.stageResults, *stageResult)
 
 		// Notificar finalización de stage al presenter
-		p.presenter.FinishStage(i+1, stageDuration)
+		p.presenter.FinishStage(stage.Number(), stageDuration)
 
 		// Merge stage results con acumulador
 		if stageResult.ConsolidatedResult != nil {
@@ -283,11 +464,18 @@ func (p *PipelineOrchestrator) Run(ctx context.Context, target domain.Target) (*
 		// Deduplicar incrementalmente para reducir memory footprint
 		result.Artifacts = p.dedupeService.Deduplicate(result.Artifacts)
 
-		// Stream a disco si threshold excedido
-		if p.streamingWriter != nil && len(result.Artifacts) >= p.streamingConfig.ArtifactThreshold {
+		// El acumulador deduplicado sustituye a las reservas del stage
+		p.memory.Set(result.ApproxSize())
+
+		// Stream a disco si threshold o presupuesto de memoria excedido
+		overBudget := p.memory.Exceeded()
+		if p.streamingWriter != nil && (len(result.Artifacts) >= p.streamingConfig.ArtifactThreshold || overBudget) {
+			held, budget, _ := p.memory.Stats()
 			p.logger.Info("streaming accumulated results to disk",
 				"artifacts", len(result.Artifacts),
 				"threshold", p.streamingConfig.ArtifactThreshold,
+				"held_bytes", held,
+				"budget_bytes", budget,
 			)
 
 			filepath, writeErr := p.streamingWriter.WritePartial(fmt.Sprintf("stage_%d", stage.ID), result)
@@ -296,8 +484,19 @@ func (p *PipelineOrchestrator) Run(ctx context.Context, target domain.Target) (*
 			} else {
 				p.logger.Info("results streamed to disk", "file", filepath)
 				result.Artifacts = nil // Free memory
+				p.memory.Set(0)
+				if overBudget {
+					p.memory.Spilled()
+				}
 			}
 		}
+
+		// Fail-fast: se conserva lo obtenido, pero no se arrancan más stages
+		if stageResult.Abort != nil {
+			abortErr = stageResult.Abort
+			p.abortStages(stages[i+1:], result, abortErr)
+			break
+		}
 	}
 
 	// Consolidación final: cargar partial results si existen
@@ -320,13 +519,26 @@ func (p *PipelineOrchestrator) Run(ctx context.Context, target domain.Target) (*
 	// Deduplicación final
 	result.Artifacts = p.dedupeService.Deduplicate(result.Artifacts)
 
+	// Supresión de ruido de terceros (eTLD+1 distinto al target)
+	result.Artifacts, result.Metadata.NoiseSuppressed = p.noiseService.Apply(target, result.Artifacts)
+
+	// Reglas de etiquetado definidas por el usuario
+	p.taggingService.Apply(result.Artifacts)
+
 	// Construir grafo de relaciones
 	p.graphService = NewGraphService(result.Artifacts, p.logger)
+	p.graphService.PruneDangling(p.minRelationConfidence)
 	graphStats := p.graphService.GetStats()
 	result.Metadata.TotalRelations = graphStats.TotalRelations
 	result.Metadata.RelationsByType = graphStats.RelationsByType
 
 	// Finalizar resultado
+	result.Metadata.Truncations = p.truncations
+	result.Metadata.Deferrals = p.deferrals
+	if !p.cacheStats.IsZero() {
+		stats := p.cacheStats
+		result.Metadata.Cache = &stats
+	}
 	result.Finalize()
 
 	totalDuration := time.Since(startTime)
@@ -348,7 +560,7 @@ func (p *PipelineOrchestrator) Run(ctx context.Context, target domain.Target) (*
 	}
 
 	// Notificar finalización
-	p.notifyEvent(ctx, ports.NewEvent(
+	p.notifyEvent(ports.NewEvent(
 		ports.EventTypeScanCompleted,
 		"pipeline_orchestrator",
 		ports.ScanCompletedEvent{
@@ -388,7 +600,7 @@ func (p *PipelineOrchestrator) Run(ctx context.Context, target domain.Target) (*
 		RelationshipsBuilt: graphStats.TotalRelations,
 	})
 
-	return result, nil
+	return result, abortErr
 }
 
 // filterCompatibleSources filtra sources compatibles con el scan mode.
@@ -402,6 +614,15 @@ func (p *PipelineOrchestrator) filterCompatibleSources(sources []ports.Source, m
 	return compatible
 }
 
+// beginScan notifica el inicio del escaneo a las sources con estado por escaneo.
+func beginScan(sources []ports.Source, scanID string) {
+	for _, s := range sources {
+		if scoped, ok := s.(ports.ScanScopedSource); ok {
+			scoped.BeginScan(scanID)
+		}
+	}
+}
+
 // executeStage ejecuta un stage completo con concurrencia limitada.
 func (p *PipelineOrchestrator) executeStage(ctx context.Context, stage Stage, inputArtifacts *domain.ScanResult) (*StageResult, error) {
 	stageResult := &StageResult{
@@ -413,10 +634,40 @@ func (p *PipelineOrchestrator) executeStage(ctx context.Context, stage Stage, in
 		Warnings:           make([]string, 0),
 	}
 
-	// Ejecutar sources concurrentemente con worker pool pattern
-	sem := make(chan struct{}, p.maxWorkers)
+	// Capacidades y carga de los agentes al inicio de cada stage
+	if p.scheduler != nil {
+		available := p.scheduler.Refresh(ctx)
+		p.logger.Debug("agents refreshed", "stage_id", stage.ID, "available", available)
+	}
+
+	// Ejecutar sources concurrentemente con worker pool pattern; cada
+	// goroutine consolida su resultado en el store del stage
+	store := domain.NewArtifactStore(stageResult.ConsolidatedResult)
+	sem := make(chan struct{}, p.stageWorkers(stage))
+
+	// El fallo de una source crítica cancela el resto del stage (fail-fast)
+	ctx, abort := context.WithCancelCause(ctx)
+	defer abort(nil)
+	var abortOnce sync.Once
 	results := make(chan SourceExecutionResult, len(stage.Sources))
 
+	// Las sources con un proveedor en común corren a la vez, pero sus
+	// peticiones a ese proveedor se turnan (ports.ProviderTurns) y se
+	// intercalan en su limitador compartido
+	providers := make(map[string][]string, len(stage.Sources))
+	for _, source := range stage.Sources {
+		providers[source.Name()] = p.sourceProviders(source)
+	}
+	gate := newProviderGate(providers, p.providerConcurrency)
+	for name, shared := range providers {
+		if shared := gate.shared(shared); len(shared) > 0 {
+			p.logger.Debug("source shares providers in stage", "stage_id", stage.ID, "source", name, "providers", shared)
+		}
+	}
+	if len(gate.slots) > 0 {
+		ctx = ports.WithProviderTurns(ctx, gate)
+	}
+
 	for _, source := range stage.Sources {
 		go func(src ports.Source) {
 			// Adquirir semáforo
@@ -424,7 +675,30 @@ func (p *PipelineOrchestrator) executeStage(ctx context.Context, stage Stage, in
 			defer func() { <-sem }()
 
 			// Ejecutar source
-			execResult := p.executeSourceInStage(ctx, src, inputArtifacts)
+			execResult := p.executeSourceInStage(ctx, src, stage.Number(), inputArtifacts)
+			if execResult.Error == nil {
+				store.Merge(execResult.Result)
+			} else if errors.Is(context.Cause(ctx), domain.ErrCriticalSourceFailed) {
+				// Cancelada por el abort de otra source: no es un fallo
+				// propio, se conserva lo obtenido hasta entonces
+				if execResult.Result != nil {
+					store.Merge(execResult.Result)
+				}
+				store.AddWarning(execResult.SourceName, "cancelled: pipeline aborted by a critical source")
+			} else {
+				// Como en Orchestrator: el fallo de la source queda en el
+				// resultado (exit code, lifecycle); el primero de una source
+				// crítica es fatal y aborta el pipeline
+				severity := domain.ErrorCritical
+				if p.isCritical(stage, execResult.SourceName) {
+					abortOnce.Do(func() {
+						severity = domain.ErrorFatal
+						stageResult.Abort = criticalFailure(stage, execResult.SourceName, execResult.Error)
+						abort(stageResult.Abort)
+					})
+				}
+				store.AddErrorWithSeverity(execResult.SourceName, execResult.Error.Error(), severity, severity != domain.ErrorFatal)
+			}
 			results <- execResult
 		}(source)
 	}
@@ -433,46 +707,47 @@ func (p *PipelineOrchestrator) executeStage(ctx context.Context, stage Stage, in
 	for i := 0; i < len(stage.Sources); i++ {
 		execResult := <-results
 		stageResult.SourceResults = append(stageResult.SourceResults, execResult)
-
-		// Consolidar resultado si exitoso
-		if execResult.Error == nil && execResult.Result != nil {
-			// Merge artifacts
-			stageResult.ConsolidatedResult.Artifacts = append(
-				stageResult.ConsolidatedResult.Artifacts,
-				execResult.Result.Artifacts...,
-			)
-
-			// Merge warnings y errors
-			stageResult.ConsolidatedResult.Warnings = append(
-				stageResult.ConsolidatedResult.Warnings,
-				execResult.Result.Warnings...,
-			)
-			stageResult.ConsolidatedResult.Errors = append(
-				stageResult.ConsolidatedResult.Errors,
-				execResult.Result.Errors...,
-			)
-		} else if execResult.Error != nil {
+		if execResult.Error != nil {
 			stageResult.Errors = append(stageResult.Errors, execResult.Error)
 		}
 	}
 
 	close(results)
+	stageResult.ConsolidatedResult = store.Result()
 
 	return stageResult, nil
 }
 
 // executeSourceInStage ejecuta una source individual con manejo de inputs.
-func (p *PipelineOrchestrator) executeSourceInStage(ctx context.Context, source ports.Source, inputArtifacts *domain.ScanResult) SourceExecutionResult {
+// stageNum es el número visible del stage (Stage.Number).
+func (p *PipelineOrchestrator) executeSourceInStage(ctx context.Context, source ports.Source, stageNum int, inputArtifacts *domain.ScanResult) SourceExecutionResult {
 	startTime := time.Now()
 	sourceName := source.Name()
 
+	// Logs de la source correlacionables con el escaneo y el stage
+	if scoped, ok := source.(ports.LogScopedSource); ok {
+		scoped.SetLogger(p.baseLogger.With("scan_id", p.scanID, "stage", stageNum, "source", sourceName))
+	}
+
 	p.logger.Debug("executing source", "source", sourceName)
 
 	// Notificar inicio al presenter
-	p.presenter.StartSource(0, sourceName) // stageNum no es necesario aquí
+	p.presenter.StartSource(stageNum, sourceName)
+
+	// Reintentos y circuit breaker visibles en el presenter mientras corre
+	circuitOpen := false
+	if reporting, ok := source.(ports.StateReportingSource); ok {
+		reporting.SetStateHandler(func(state ports.SourceState, detail string) {
+			if state == ports.SourceStateCircuitOpen {
+				circuitOpen = true
+			}
+			p.presenter.UpdateSourceStatus(sourceName, sourceStatus(state), detail)
+		})
+		defer reporting.SetStateHandler(nil)
+	}
 
 	// Notificar inicio
-	p.notifyEvent(ctx, ports.NewEvent(
+	p.notifyEvent(ports.NewEvent(
 		ports.EventTypeSourceStarted,
 		sourceName,
 		nil,
@@ -481,30 +756,110 @@ func (p *PipelineOrchestrator) executeSourceInStage(ctx context.Context, source
 	var result *domain.ScanResult
 	var err error
 
-	// Verificar si la source implementa StreamingSource para escuchar progreso
-	var progressDone chan struct{}
-	if streamingSource, ok := source.(ports.StreamingSource); ok {
+	// Topes de la source y del escaneo: se cancela con causa para distinguir
+	// una truncación de un fallo
+	ctx, cancelSource := context.WithCancelCause(ctx)
+	defer cancelSource(nil)
+	if d := p.limits.sourceMaxDuration(sourceName); d > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeoutCause(ctx, d, errSourceMaxDuration)
+		defer cancelTimeout()
+	}
+	limiter := &sourceLimiter{
+		max:    p.limits.sourceMaxArtifacts(sourceName),
+		budget: p.budget,
+		cancel: cancelSource,
+	}
+	if p.limits.Sample > 0 {
+		ctx = ports.WithSampleSize(ctx, limiter.max)
+	}
+	if p.captureDir != "" {
+		ctx = ports.WithCaptureDir(ctx, filepath.Join(p.captureDir, sourceName))
+	}
+
+	// Escuchar el progreso de la source (si lo expone) mientras se ejecuta
+	var progressDone, progressStopped chan struct{}
+	if progressSource, ok := source.(ports.ProgressSource); ok {
 		progressDone = make(chan struct{})
-		go p.listenToProgress(ctx, streamingSource, sourceName, progressDone)
+		progressStopped = make(chan struct{})
+		latestProgress(progressSource.ProgressChannel(), ports.ProgressUpdate{}) // Restos de una ejecución anterior
+		go func() {
+			defer close(progressStopped)
+			p.listenToProgress(ctx, progressSource, sourceName, startTime, progressDone, limiter.observe)
+		}()
+	}
+
+	// Filtrar artifacts según InputArtifacts declarados
+	_, isConsumer := source.(ports.InputConsumer)
+	var filteredInput *domain.ScanResult
+	if isConsumer {
+		filteredInput = p.filterInputArtifacts(source, inputArtifacts)
 	}
 
-	// Verificar si la source implementa InputConsumer
-	if consumer, ok := source.(ports.InputConsumer); ok {
-		// Filtrar artifacts según InputArtifacts declarados
-		filteredInput := p.filterInputArtifacts(source, inputArtifacts)
-		result, err = consumer.RunWithInput(ctx, inputArtifacts.Target, filteredInput)
-	} else {
-		// Fallback: ejecutar sin inputs (source legacy)
-		result, err = source.Run(ctx, inputArtifacts.Target)
+	// Volcar a disco los resultados parciales de sources largas
+	flushed := 0
+	if partialSource, ok := source.(ports.PartialResultSource); ok && p.streamingWriter != nil {
+		part := 0
+		partialSource.SetPartialHandler(func(partial *domain.ScanResult) error {
+			partial.Artifacts = limiter.admit(partial.Artifacts)
+			if len(partial.Artifacts) == 0 {
+				return nil
+			}
+			part++
+			filepath, err := p.streamingWriter.WritePartial(fmt.Sprintf("%s_part%03d", sourceName, part), partial)
+			if err != nil {
+				return err
+			}
+			flushed += len(partial.Artifacts)
+			p.logger.Debug("partial source result streamed", "source", sourceName, "file", filepath, "artifacts", len(partial.Artifacts))
+			return nil
+		})
+		defer partialSource.SetPartialHandler(nil)
+	}
+
+	// Con el tope del escaneo ya agotado, o el stage ya vencido, la source
+	// no llega a ejecutarse
+	skipped := p.budget.exhausted() || errors.Is(context.Cause(ctx), errStageMaxDuration)
+	if skipped {
+		cancelSource(errScanMaxArtifacts)
+		result = domain.NewScanResult(inputArtifacts.Target)
+	}
+
+	remote := false
+	if !skipped && p.scheduler != nil {
+		result, remote, err = p.runOnAgent(ctx, sourceName, inputArtifacts.Target, filteredInput)
+	}
+
+	if !skipped && !remote {
+		if isConsumer {
+			result, err = source.(ports.InputConsumer).RunWithInput(ctx, inputArtifacts.Target, filteredInput)
+		} else {
+			// Fallback: ejecutar sin inputs (source legacy)
+			result, err = source.Run(ctx, inputArtifacts.Target)
+		}
 	}
 
-	// Detener goroutine de progreso si existe
+	// Detener goroutine de progreso antes de FinishSource para que ninguna
+	// actualización tardía llegue al presenter después del resultado final
 	if progressDone != nil {
 		close(progressDone)
+		<-progressStopped
 	}
 
 	duration := time.Since(startTime)
 
+	// Un tope alcanzado no es un fallo: se conserva lo obtenido hasta entonces
+	if err != nil && limiter.cause(ctx) != nil {
+		p.logger.Debug("source stopped by limit", "source", sourceName, "error", err.Error())
+		err = nil
+		if result == nil {
+			result = domain.NewScanResult(inputArtifacts.Target)
+		}
+	}
+	if err == nil {
+		result.Artifacts = limiter.admit(result.Artifacts)
+	}
+
 	execResult := SourceExecutionResult{
 		SourceName: sourceName,
 		Result:     result,
@@ -513,8 +868,13 @@ func (p *PipelineOrchestrator) executeSourceInStage(ctx context.Context, source
 	}
 
 	if err != nil {
+		// Un "no encontrado" repetido desde la caché negativa también cuenta
+		p.cacheMu.Lock()
+		p.cacheStats.Record(sourceName, result)
+		p.cacheMu.Unlock()
+
 		p.logger.Warn("source failed", "source", sourceName, "error", err.Error())
-		p.notifyEvent(ctx, ports.NewEvent(
+		p.notifyEvent(ports.NewEvent(
 			ports.EventTypeSourceFailed,
 			sourceName,
 			err,
@@ -524,12 +884,30 @@ func (p *PipelineOrchestrator) executeSourceInStage(ctx context.Context, source
 		execResult.Summary = summary
 
 		// Notificar error al presenter
-		p.presenter.FinishSource(sourceName, ui.StatusError, duration, 0, summary)
+		status := ui.StatusError
+		if circuitOpen {
+			status = ui.StatusCircuitOpen
+		}
+		p.presenter.FinishSource(sourceName, status, duration, 0, summary)
 		return execResult
 	}
 
-	artifactCount := len(result.Artifacts)
+	artifactCount := len(result.Artifacts) + flushed
 	execResult.ArtifactCount = artifactCount
+	if flushed > 0 {
+		execResult.StreamedToDisk = true
+	}
+
+	cause := limiter.cause(ctx)
+	if cause == nil && p.limits.sampleCompleted(sourceName, artifactCount) {
+		cause = errSourceMaxArtifacts
+	}
+	truncation, truncated := p.limits.truncation(sourceName, cause)
+	if truncated {
+		truncation.Artifacts = artifactCount
+		p.addTruncation(truncation)
+		result.AddWarning(sourceName, "truncated: "+truncation.String())
+	}
 
 	p.logger.Debug("source completed",
 		"source", sourceName,
@@ -537,25 +915,37 @@ func (p *PipelineOrchestrator) executeSourceInStage(ctx context.Context, source
 		"duration_ms", duration.Milliseconds(),
 	)
 
-	// Stream si supera threshold
-	if p.streamingWriter != nil && artifactCount >= p.streamingConfig.ArtifactThreshold {
-		p.logger.Info("streaming source result to disk",
-			"source", sourceName,
-			"artifacts", artifactCount,
-		)
+	// Stream si supera threshold o no cabe en el presupuesto de memoria
+	if p.streamingWriter != nil {
+		size := result.ApproxSize()
+		overThreshold := len(result.Artifacts) >= p.streamingConfig.ArtifactThreshold
+		overBudget := !overThreshold && !p.memory.Reserve(size)
+
+		if overThreshold || overBudget {
+			p.logger.Info("streaming source result to disk",
+				"source", sourceName,
+				"artifacts", artifactCount,
+				"bytes", size,
+				"over_budget", overBudget,
+			)
 
-		filepath, writeErr := p.streamingWriter.WritePartial(sourceName, result)
-		if writeErr != nil {
-			p.logger.Warn("failed to stream source result", "source", sourceName, "error", writeErr.Error())
-		} else {
-			p.logger.Info("source result streamed", "source", sourceName, "file", filepath)
-			result.Artifacts = nil // Free memory
-			execResult.StreamedToDisk = true
+			filepath, writeErr := p.streamingWriter.WritePartial(sourceName, result)
+			if writeErr != nil {
+				p.logger.Warn("failed to stream source result", "source", sourceName, "error", writeErr.Error())
+				p.memory.Add(size) // Sigue en memoria aunque exceda el presupuesto
+			} else {
+				p.logger.Info("source result streamed", "source", sourceName, "file", filepath)
+				result.Artifacts = nil // Free memory
+				execResult.StreamedToDisk = true
+				if overBudget {
+					p.memory.Spilled()
+				}
+			}
 		}
 	}
 
 	// Notificar finalización
-	p.notifyEvent(ctx, ports.NewEvent(
+	p.notifyEvent(ports.NewEvent(
 		ports.EventTypeSourceCompleted,
 		sourceName,
 		artifactCount,
@@ -563,6 +953,15 @@ func (p *PipelineOrchestrator) executeSourceInStage(ctx context.Context, source
 
 	// Generar summary para resultado exitoso
 	summary := p.buildSourceSummary(sourceName, result, nil, artifactCount)
+	if truncated && summary != nil {
+		summary.Summary += fmt.Sprintf(" • truncated (%s)", truncation.Reason)
+	}
+	p.cacheMu.Lock()
+	p.cacheStats.Record(sourceName, result)
+	p.cacheMu.Unlock()
+	if result.Metadata.Environment[domain.EnvSourceCache] == domain.SourceCacheHit && summary != nil {
+		summary.Summary += " • cached"
+	}
 	execResult.Summary = summary
 
 	// Notificar éxito al presenter
@@ -575,6 +974,61 @@ func (p *PipelineOrchestrator) executeSourceInStage(ctx context.Context, source
 	return execResult
 }
 
+// sourceStatus traduce el estado reportado por una source al del presenter.
+func sourceStatus(state ports.SourceState) ui.Status {
+	switch state {
+	case ports.SourceStateRetrying:
+		return ui.StatusRetrying
+	case ports.SourceStateCircuitOpen:
+		return ui.StatusCircuitOpen
+	default:
+		return ui.StatusRunning
+	}
+}
+
+// runOnAgent ejecuta la source en el agente que asigne el scheduler.
+// remote=false si ningún agente puede ejecutarla o el asignado no responde:
+// en ese caso la source corre en local.
+func (p *PipelineOrchestrator) runOnAgent(ctx context.Context, sourceName string, target domain.Target, input *domain.ScanResult) (result *domain.ScanResult, remote bool, err error) {
+	agent, ok := p.scheduler.Acquire(sourceName)
+	if !ok {
+		return nil, false, nil
+	}
+	defer p.scheduler.Release(agent)
+
+	p.logger.Debug("source assigned to agent", "source", sourceName, "agent", agent.Endpoint())
+
+	streamed := 0
+	result, err = agent.RunSource(ctx, ports.AgentRunRequest{
+		Source: sourceName,
+		Target: target,
+		Input:  input,
+	}, func(*domain.Artifact) {
+		streamed++
+		if streamed%100 == 0 {
+			p.presenter.UpdateSource(sourceName, ui.ProgressMetrics{
+				Current:    streamed,
+				Percentage: -1,
+				Phase:      "agent " + agent.Endpoint(),
+			})
+		}
+	})
+
+	if errors.Is(err, ports.ErrAgentUnavailable) {
+		p.logger.Warn("agent unavailable, running source locally",
+			"source", sourceName,
+			"agent", agent.Endpoint(),
+			"error", err.Error(),
+		)
+		p.scheduler.MarkDown(agent)
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, true, fmt.Errorf("agent %s: %w", agent.Endpoint(), err)
+	}
+	return result, true, nil
+}
+
 // filterInputArtifacts filtra artifacts del input según InputArtifacts declarados por la source.
 func (p *PipelineOrchestrator) filterInputArtifacts(source ports.Source, input *domain.ScanResult) *domain.ScanResult {
 	sourceName := source.Name()
@@ -590,10 +1044,12 @@ func (p *PipelineOrchestrator) filterInputArtifacts(source ports.Source, input *
 		requiredTypes[artifactType] = true
 	}
 
-	// Filtrar artifacts
+	// Filtrar artifacts; los de otras organizaciones (pivoting) nunca llegan
+	// a una source activa: están fuera del target
+	active := source.Mode() == domain.SourceModeActive
 	filtered := domain.NewScanResult(input.Target)
 	for _, artifact := range input.Artifacts {
-		if requiredTypes[artifact.Type] {
+		if requiredTypes[artifact.Type] && !(active && artifact.IsRelatedOrg()) {
 			filtered.Artifacts = append(filtered.Artifacts, artifact)
 		}
 	}
@@ -607,53 +1063,95 @@ func (p *PipelineOrchestrator) filterInputArtifacts(source ports.Source, input *
 	return filtered
 }
 
-// listenToProgress escucha el canal de progreso de un StreamingSource y actualiza el presenter.
-func (p *PipelineOrchestrator) listenToProgress(ctx context.Context, source ports.StreamingSource, sourceName string, done chan struct{}) {
+// reportMemoryStats envía al presenter el heap del proceso y los bytes de
+// artifacts retenidos cada 2s. Retorna la función que detiene el reporte.
+func (p *PipelineOrchestrator) reportMemoryStats() func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	report := func() {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		held, budget, spills := p.memory.Stats()
+		p.presenter.UpdateMemory(ui.MemoryStats{
+			HeapMB:      float64(m.HeapAlloc) / 1024 / 1024,
+			HeldBytes:   held,
+			BudgetBytes: budget,
+			Spills:      int(spills),
+		})
+	}
+
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(2 * time.Second)
+		defer ticker.Stop()
+
+		report()
+		for {
+			select {
+			case <-ticker.C:
+				report()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// listenToProgress drena el canal de progreso de una source y envía al
+// presenter la última actualización cada 100ms (debouncing). Nunca bloquea a
+// la source: ella envía sin esperar y aquí solo se conserva la más reciente.
+// onCount recibe cada contador sin debouncing (topes de artifacts).
+func (p *PipelineOrchestrator) listenToProgress(ctx context.Context, source ports.ProgressSource, sourceName string, startTime time.Time, done chan struct{}, onCount func(count int)) {
 	progressCh := source.ProgressChannel()
-	ticker := time.NewTicker(100 * time.Millisecond) // Debouncing: actualizar cada 100ms
+	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
 
 	p.logger.Debug("progress listener started", "source", sourceName)
 
-	var lastUpdate ports.ProgressUpdate
-	var lastEmitted int
+	var lastUpdate, lastEmitted ports.ProgressUpdate
+
+	// Hay algo nuevo que mostrar: más artifacts o una nueva fase (e.g. chunk)
+	pending := func() bool {
+		return lastUpdate != lastEmitted && (lastUpdate.ArtifactCount > 0 || lastUpdate.Message != "")
+	}
+
+	emit := func() {
+		if !pending() {
+			return
+		}
+		p.presenter.UpdateSource(sourceName, progressMetrics(lastUpdate, time.Since(startTime)))
+		p.logger.Debug("progress update",
+			"source", sourceName,
+			"artifacts", lastUpdate.ArtifactCount,
+			"message", lastUpdate.Message,
+		)
+		lastEmitted = lastUpdate
+	}
 
 	for {
 		select {
 		case update, ok := <-progressCh:
 			if !ok {
 				// Canal cerrado, salir
+				emit()
 				return
 			}
 			lastUpdate = update
+			onCount(update.ArtifactCount)
 
 		case <-ticker.C:
-			// Emitir actualización con debouncing solo si hay cambios
-			if lastUpdate.ArtifactCount > 0 && lastUpdate.ArtifactCount != lastEmitted {
-				p.presenter.UpdateSource(sourceName, ui.ProgressMetrics{
-					Current:    lastUpdate.ArtifactCount,
-					Total:      0, // Indeterminado
-					Percentage: -1,
-					Phase:      lastUpdate.Message,
-				})
-				p.logger.Debug("progress update",
-					"source", sourceName,
-					"artifacts", lastUpdate.ArtifactCount,
-					"message", lastUpdate.Message,
-				)
-				lastEmitted = lastUpdate.ArtifactCount
-			}
+			emit()
 
 		case <-done:
-			// Source terminó, emitir última actualización si hay
-			if lastUpdate.ArtifactCount > 0 && lastUpdate.ArtifactCount != lastEmitted {
-				p.presenter.UpdateSource(sourceName, ui.ProgressMetrics{
-					Current:    lastUpdate.ArtifactCount,
-					Total:      0,
-					Percentage: -1,
-					Phase:      lastUpdate.Message,
-				})
-			}
+			// Source terminó: tomar lo que quede en el buffer y emitir la última
+			lastUpdate = latestProgress(progressCh, lastUpdate)
+			emit()
 			return
 
 		case <-ctx.Done():
@@ -663,6 +1161,37 @@ func (p *PipelineOrchestrator) listenToProgress(ctx context.Context, source port
 	}
 }
 
+// progressMetrics convierte una actualización de progreso en métricas del
+// presenter. El total es indeterminado; el ritmo se calcula sobre elapsed.
+func progressMetrics(update ports.ProgressUpdate, elapsed time.Duration) ui.ProgressMetrics {
+	metrics := ui.ProgressMetrics{
+		Current:    update.ArtifactCount,
+		Total:      0, // Indeterminado
+		Percentage: -1,
+		Phase:      update.Message,
+	}
+	if seconds := elapsed.Seconds(); seconds > 0 {
+		metrics.Rate = float64(update.ArtifactCount) / seconds
+	}
+	return metrics
+}
+
+// latestProgress vacía el canal sin bloquear y retorna la última
+// actualización leída (last si no había ninguna).
+func latestProgress(ch <-chan ports.ProgressUpdate, last ports.ProgressUpdate) ports.ProgressUpdate {
+	for {
+		select {
+		case update, ok := <-ch:
+			if !ok {
+				return last
+			}
+			last = update
+		default:
+			return last
+		}
+	}
+}
+
 // buildSourceSummary genera un resumen informativo del resultado de un source
 func (p *PipelineOrchestrator) buildSourceSummary(
 	sourceName string,
@@ -696,6 +1225,8 @@ func (p *PipelineOrchestrator) buildSourceSummary(
 		return p.summarizeWaybackurls(result)
 	case "httpx":
 		return p.summarizeHTTPX(result)
+	case "katana":
+		return p.summarizeKatana(result)
 	case "subfinder":
 		return p.summarizeSubfinder(result)
 	case "rdap":
@@ -785,11 +1316,18 @@ func (p *PipelineOrchestrator) summarizeWaybackurls(result *domain.ScanResult) *
 		}
 	}
 
-	// Si hay estadísticas de filtrado, mostrarlas
+	// Si hay estadísticas de filtrado, mostrarlas (con lo colapsado por los pre-filtros)
 	if inputURLs > 0 && outputURLs > 0 && inputURLs != outputURLs {
-		return &ui.SourceSummary{
-			Summary: fmt.Sprintf("raw: %d → filtered: %d", inputURLs, outputURLs),
+		summary := fmt.Sprintf("raw: %d → filtered: %d", inputURLs, outputURLs)
+		collapsed := 0
+		for _, key := range []string{"waybackurls_filter_static_assets", "waybackurls_filter_signature_collapsed", "waybackurls_filter_values_collapsed"} {
+			n, _ := strconv.Atoi(result.Metadata.Environment[key])
+			collapsed += n
 		}
+		if collapsed > 0 {
+			summary += fmt.Sprintf(" (prefiltered: %d)", collapsed)
+		}
+		return &ui.SourceSummary{Summary: summary}
 	}
 
 	// Si solo hay outputURLs (sin diferencia), mostrar solo el total
@@ -843,6 +1381,25 @@ func (p *PipelineOrchestrator) summarizeHTTPX(result *domain.ScanResult) *ui.Sou
 	}
 }
 
+// summarizeKatana resume resultados de katana
+// Formato esperado: "seeds: 12 → crawled: 340 URLs (collapsed: 25)"
+func (p *PipelineOrchestrator) summarizeKatana(result *domain.ScanResult) *ui.SourceSummary {
+	env := result.Metadata.Environment
+	seeds, _ := strconv.Atoi(env["katana_seeds"])
+	crawled, _ := strconv.Atoi(env["katana_crawled"])
+	collapsed, _ := strconv.Atoi(env["katana_pattern_collapsed"])
+
+	if crawled == 0 {
+		return p.summarizeGeneric(result)
+	}
+
+	summary := fmt.Sprintf("seeds: %d → crawled: %d URLs", seeds, crawled)
+	if collapsed > 0 {
+		summary += fmt.Sprintf(" (collapsed: %d)", collapsed)
+	}
+	return &ui.SourceSummary{Summary: summary}
+}
+
 // summarizeSubfinder resume resultados de subfinder
 func (p *PipelineOrchestrator) summarizeSubfinder(result *domain.ScanResult) *ui.SourceSummary {
 	subdomains := 0
@@ -912,6 +1469,7 @@ func (p *PipelineOrchestrator) summarizeCRTSH(result *domain.ScanResult) *ui.Sou
 func (p *PipelineOrchestrator) summarizeAmass(result *domain.ScanResult) *ui.SourceSummary {
 	subdomains := 0
 	ips := 0
+	ipv6 := 0
 	asns := 0
 
 	for _, a := range result.Artifacts {
@@ -920,6 +1478,8 @@ func (p *PipelineOrchestrator) summarizeAmass(result *domain.ScanResult) *ui.Sou
 			subdomains++
 		case domain.ArtifactTypeIP:
 			ips++
+		case domain.ArtifactTypeIPv6:
+			ipv6++
 		case domain.ArtifactTypeASN:
 			asns++
 		}
@@ -932,6 +1492,9 @@ func (p *PipelineOrchestrator) summarizeAmass(result *domain.ScanResult) *ui.Sou
 	if ips > 0 {
 		parts = append(parts, fmt.Sprintf("ips: %d", ips))
 	}
+	if ipv6 > 0 {
+		parts = append(parts, fmt.Sprintf("ipv6: %d", ipv6))
+	}
 	if asns > 0 {
 		parts = append(parts, fmt.Sprintf("asn: %d", asns))
 	}
@@ -945,16 +1508,12 @@ func (p *PipelineOrchestrator) summarizeAmass(result *domain.ScanResult) *ui.Sou
 	}
 }
 
-// notifyEvent envía una notificación a todos los observers de forma asíncrona.
-func (p *PipelineOrchestrator) notifyEvent(ctx context.Context, event ports.Event) {
-	for _, observer := range p.observers {
-		go func(notifier ports.Notifier) {
-			notifyCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-			defer cancel()
-
-			if err := notifier.Notify(notifyCtx, event); err != nil {
-				p.logger.Warn("notification failed", "error", err.Error())
-			}
-		}(observer)
+// notifyEvent publica el evento en el bus del escaneo; cada observer lo
+// recibe en orden de publicación. Los eventos sin ScanID reciben el del
+// escaneo en curso.
+func (p *PipelineOrchestrator) notifyEvent(event ports.Event) {
+	if event.ScanID == "" {
+		event.ScanID = p.scanID
 	}
+	p.events.Publish(event)
 }
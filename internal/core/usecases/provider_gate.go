@@ -0,0 +1,95 @@
+// internal/core/usecases/provider_gate.go
+package usecases
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"aethonx/internal/core/ports"
+)
+
+// DefaultProviderConcurrency es cuántas peticiones a un proveedor compartido
+// por varias sources de un stage están en vuelo a la vez.
+const DefaultProviderConcurrency = 1
+
+// providerGate reparte los turnos de petición de los proveedores que
+// comparten varias sources de un stage. Las sources corren a la vez y cada
+// petición espera su turno (por orden de llegada) antes del limitador
+// compartido del upstream, así sus peticiones se intercalan en lugar de
+// lanzarse todas contra el mismo límite (y recibir 429). Implementa
+// ports.ProviderTurns.
+type providerGate struct {
+	slots map[string]chan struct{} // Proveedor -> huecos de petición libres
+}
+
+// newProviderGate crea los huecos de los proveedores compartidos por más de
+// una source del stage; los demás no se limitan.
+func newProviderGate(providers map[string][]string, concurrency int) *providerGate {
+	if concurrency <= 0 {
+		concurrency = DefaultProviderConcurrency
+	}
+	count := make(map[string]int)
+	for _, names := range providers {
+		for _, name := range names {
+			count[name]++
+		}
+	}
+
+	g := &providerGate{slots: make(map[string]chan struct{})}
+	for name, n := range count {
+		if n > 1 {
+			g.slots[name] = make(chan struct{}, concurrency)
+		}
+	}
+	return g
+}
+
+// shared retorna los proveedores limitados de providers, ordenados.
+func (g *providerGate) shared(providers []string) []string {
+	var shared []string
+	for _, name := range providers {
+		if _, ok := g.slots[name]; ok {
+			shared = append(shared, name)
+		}
+	}
+	sort.Strings(shared)
+	return shared
+}
+
+// Wait espera un hueco de petición en provider y retorna la función que lo
+// libera. Los envíos bloqueados en un canal se atienden en orden de llegada,
+// así que las peticiones de las sources se alternan. Con ctx cancelado
+// retorna su error.
+func (g *providerGate) Wait(ctx context.Context, provider string) (func(), error) {
+	slot, ok := g.slots[strings.ToLower(strings.TrimSpace(provider))]
+	if !ok {
+		return func() {}, nil
+	}
+	select {
+	case slot <- struct{}{}:
+		return func() { <-slot }, nil
+	case <-ctx.Done():
+		return func() {}, ctx.Err()
+	}
+}
+
+// sourceProviders retorna los proveedores de src: los de su configuración
+// actual (ports.ProviderSource) o los declarados en su metadata.
+func (p *PipelineOrchestrator) sourceProviders(src ports.Source) []string {
+	var providers []string
+	if ps, ok := src.(ports.ProviderSource); ok {
+		providers = ps.Providers()
+	}
+	if len(providers) == 0 {
+		providers = p.sourceMetadata[src.Name()].Providers
+	}
+
+	normalized := make([]string, 0, len(providers))
+	for _, name := range providers {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized
+}
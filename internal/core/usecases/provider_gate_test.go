@@ -0,0 +1,165 @@
+// internal/core/usecases/provider_gate_test.go
+package usecases
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+)
+
+// requestTracker registra las peticiones de cada proveedor: cuántas están
+// en vuelo a la vez, cuántas sources corren a la vez y en qué orden llegan.
+type requestTracker struct {
+	mu       sync.Mutex
+	inFlight map[string]int
+	peak     map[string]int
+	running  map[string]int
+	overlap  map[string]int
+	order    map[string][]string
+}
+
+func newRequestTracker() *requestTracker {
+	return &requestTracker{
+		inFlight: map[string]int{},
+		peak:     map[string]int{},
+		running:  map[string]int{},
+		overlap:  map[string]int{},
+		order:    map[string][]string{},
+	}
+}
+
+// source crea una source que hace requests peticiones a provider, cada una
+// con su turno (ports.WaitProviderTurn), como hace httpclient.
+func (r *requestTracker) source(name, provider string, requests int) *mockSource {
+	src := newMockSource(name, domain.SourceModePassive, domain.SourceTypeAPI)
+	src.runFunc = func(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+		r.mu.Lock()
+		r.running[provider]++
+		r.overlap[provider] = max(r.overlap[provider], r.running[provider])
+		r.mu.Unlock()
+
+		for i := 0; i < requests; i++ {
+			release, err := ports.WaitProviderTurn(ctx, provider)
+			if err != nil {
+				return nil, err
+			}
+			r.mu.Lock()
+			r.inFlight[provider]++
+			r.peak[provider] = max(r.peak[provider], r.inFlight[provider])
+			r.order[provider] = append(r.order[provider], name)
+			r.mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			r.mu.Lock()
+			r.inFlight[provider]--
+			r.mu.Unlock()
+			release()
+		}
+
+		r.mu.Lock()
+		r.running[provider]--
+		r.mu.Unlock()
+		return domain.NewScanResult(target), nil
+	}
+	return src
+}
+
+// providerSource es un mockSource que declara sus proveedores en runtime.
+type providerSource struct {
+	*mockSource
+	providers []string
+}
+
+func (p *providerSource) Providers() []string { return p.providers }
+
+func TestProviderGate_OnlyLimitsSharedProviders(t *testing.T) {
+	gate := newProviderGate(map[string][]string{
+		"a": {"api.shodan.io"},
+		"b": {"api.shodan.io", "crt.sh"},
+		"c": {"stat.ripe.net"},
+	}, 1)
+
+	testutil.AssertEqual(t, len(gate.slots), 1, "only the shared provider gets slots")
+	testutil.AssertEqual(t, len(gate.shared([]string{"stat.ripe.net", "crt.sh"})), 0, "unshared providers are free")
+
+	release, err := gate.Wait(context.Background(), "api.shodan.io")
+	testutil.AssertNoError(t, err, "first turn")
+
+	free, err := gate.Wait(context.Background(), "crt.sh")
+	testutil.AssertNoError(t, err, "unshared provider never waits")
+	free()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = gate.Wait(ctx, "API.Shodan.io")
+	testutil.AssertError(t, err, "second turn should wait until the context expires")
+
+	release()
+	release, err = gate.Wait(context.Background(), "api.shodan.io")
+	testutil.AssertNoError(t, err, "turn after release")
+	release()
+}
+
+func TestPipelineOrchestrator_InterleavesSharedProvider(t *testing.T) {
+	tracker := newRequestTracker()
+	sources := []ports.Source{
+		tracker.source("shodan-a", "api.shodan.io", 3),
+		tracker.source("shodan-b", "api.shodan.io", 3),
+		tracker.source("crtsh", "crt.sh", 2),
+		tracker.source("otx", "otx.alienvault.com", 1),
+	}
+	metadata := map[string]ports.SourceMetadata{
+		"shodan-a": {Name: "shodan-a", Providers: []string{"api.shodan.io"}},
+		"shodan-b": {Name: "shodan-b", Providers: []string{"API.Shodan.io "}},
+		"crtsh":    {Name: "crtsh", Providers: []string{"crt.sh"}},
+		"otx":      {Name: "otx"},
+	}
+
+	orch := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources:        sources,
+		SourceMetadata: metadata,
+		Logger:         logx.New(),
+		MaxWorkers:     4,
+	})
+
+	_, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModePassive))
+	testutil.AssertNoError(t, err, "run should succeed")
+
+	testutil.AssertEqual(t, tracker.overlap["api.shodan.io"], 2, "same-provider sources run at once")
+	testutil.AssertEqual(t, tracker.peak["api.shodan.io"], 1, "one request in flight per shared provider")
+	order := tracker.order["api.shodan.io"]
+	testutil.AssertEqual(t, len(order), 6, "every request sent")
+	for i := 1; i < len(order); i++ {
+		testutil.AssertTrue(t, order[i] != order[i-1], "requests should interleave, got order "+strings.Join(order, ","))
+	}
+	for _, src := range sources {
+		testutil.AssertEqual(t, src.(*mockSource).runCallCount, 1, src.Name()+" should run once")
+	}
+}
+
+func TestPipelineOrchestrator_ProviderSourceOverridesMetadata(t *testing.T) {
+	orch := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		SourceMetadata: map[string]ports.SourceMetadata{
+			"pdns": {Name: "pdns", Providers: []string{"api.mnemonic.no", "circl.lu"}},
+		},
+		Logger: logx.New(),
+	})
+
+	src := &providerSource{
+		mockSource: newMockSource("pdns", domain.SourceModePassive, domain.SourceTypeAPI),
+		providers:  []string{"circl.lu"},
+	}
+	testutil.AssertEqual(t, len(orch.sourceProviders(src)), 1, "runtime providers win")
+	testutil.AssertEqual(t, orch.sourceProviders(src)[0], "circl.lu", "runtime provider")
+
+	src.providers = nil
+	testutil.AssertEqual(t, len(orch.sourceProviders(src)), 2, "falls back to metadata")
+}
@@ -0,0 +1,295 @@
+// internal/core/usecases/scan_limits.go
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"aethonx/internal/core/domain"
+)
+
+// ScanLimits topes duros del escaneo y de cada source (0 = sin tope). Al
+// alcanzar uno, la source (o el escaneo) se cancela, se conserva lo obtenido
+// hasta ese momento y el resultado queda marcado como truncado.
+type ScanLimits struct {
+	MaxArtifacts int
+	MaxDuration  time.Duration
+
+	// Sample tamaño de la muestra (--sample): tope de artifacts de cada source
+	// que prevalece sobre topes por source mayores (0 = sin muestreo)
+	Sample int
+
+	// Topes por source; la clave "*" aplica a las sources sin tope propio
+	SourceMaxArtifacts map[string]int
+	SourceMaxDuration  map[string]time.Duration
+
+	// Topes por stage, por Stage.ID: StageMaxDuration sustituye al timeout
+	// por stage (-T) y StageMaxWorkers a MaxWorkers en ese stage
+	StageMaxDuration map[int]time.Duration
+	StageMaxWorkers  map[int]int
+}
+
+// AnySource clave de ScanLimits que aplica a todas las sources.
+const AnySource = "*"
+
+// Causas de cancelación de los topes (context.Cause)
+var (
+	errSourceMaxArtifacts = errors.New("source artifact limit reached")
+	errSourceMaxDuration  = errors.New("source duration limit reached")
+	errScanMaxArtifacts   = errors.New("scan artifact limit reached")
+	errScanMaxDuration    = errors.New("scan duration limit reached")
+	errStageMaxDuration   = errors.New("stage duration limit reached")
+)
+
+// stageDeadline causa de cancelación de un stage que alcanzó su
+// StageMaxDuration; recuerda el tope para la truncación de sus sources.
+type stageDeadline struct {
+	limit time.Duration
+}
+
+func (e stageDeadline) Error() string { return errStageMaxDuration.Error() }
+func (e stageDeadline) Unwrap() error { return errStageMaxDuration }
+
+// sourceMaxArtifacts retorna el tope de artifacts de la source.
+func (l ScanLimits) sourceMaxArtifacts(name string) int {
+	n, ok := l.SourceMaxArtifacts[name]
+	if !ok {
+		n = l.SourceMaxArtifacts[AnySource]
+	}
+	if l.sampled(n) {
+		return l.Sample
+	}
+	return n
+}
+
+// sampled indica si la muestra es el tope efectivo frente al tope propio n.
+func (l ScanLimits) sampled(n int) bool {
+	return l.Sample > 0 && (n <= 0 || l.Sample < n)
+}
+
+// sourceMaxDuration retorna el tope de duración de la source.
+func (l ScanLimits) sourceMaxDuration(name string) time.Duration {
+	if d, ok := l.SourceMaxDuration[name]; ok {
+		return d
+	}
+	return l.SourceMaxDuration[AnySource]
+}
+
+// sampleCompleted indica si la source completó su muestra: las que respetan
+// ports.SampleSize se detienen solas sin que el limitador recorte nada.
+func (l ScanLimits) sampleCompleted(source string, count int) bool {
+	n := l.sourceMaxArtifacts(source)
+	return l.Sample > 0 && n == l.Sample && count >= n
+}
+
+// truncation describe la truncación producida por una causa de cancelación;
+// ok es false si la causa no es un tope (fallo o cancelación externa).
+func (l ScanLimits) truncation(source string, cause error) (domain.Truncation, bool) {
+	t := domain.Truncation{Source: source}
+	switch {
+	case errors.Is(cause, errSourceMaxArtifacts):
+		t.Scope, t.Reason, t.Limit = "source", domain.TruncatedMaxArtifacts, strconv.Itoa(l.sourceMaxArtifacts(source))
+		if l.Sample > 0 && l.sourceMaxArtifacts(source) == l.Sample {
+			t.Reason = domain.TruncatedSample
+		}
+	case errors.Is(cause, errSourceMaxDuration):
+		t.Scope, t.Reason, t.Limit = "source", domain.TruncatedMaxDuration, l.sourceMaxDuration(source).String()
+	case errors.Is(cause, errStageMaxDuration):
+		var deadline stageDeadline
+		errors.As(cause, &deadline)
+		t.Scope, t.Reason, t.Limit = "stage", domain.TruncatedMaxDuration, deadline.limit.String()
+	case errors.Is(cause, errScanMaxArtifacts):
+		t.Scope, t.Reason, t.Limit = "scan", domain.TruncatedMaxArtifacts, strconv.Itoa(l.MaxArtifacts)
+	case errors.Is(cause, errScanMaxDuration):
+		t.Scope, t.Reason, t.Limit = "scan", domain.TruncatedMaxDuration, l.MaxDuration.String()
+	default:
+		return t, false
+	}
+	return t, true
+}
+
+// artifactBudget cuenta los artifacts admitidos en el escaneo frente a
+// ScanLimits.MaxArtifacts. Se cuentan antes de deduplicar: el tope acota lo
+// que emiten las sources, no el tamaño final del resultado.
+type artifactBudget struct {
+	mu   sync.Mutex
+	max  int // 0 = sin tope
+	used int
+}
+
+func newArtifactBudget(max int) *artifactBudget {
+	return &artifactBudget{max: max}
+}
+
+// take admite hasta n artifacts y retorna cuántos caben.
+func (b *artifactBudget) take(n int) int {
+	if b == nil || b.max <= 0 {
+		return n
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if free := b.max - b.used; n > free {
+		n = free
+	}
+	b.used += n
+	return n
+}
+
+// remaining retorna los artifacts que aún caben (-1 = sin tope).
+func (b *artifactBudget) remaining() int {
+	if b == nil || b.max <= 0 {
+		return -1
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.max - b.used
+}
+
+// exhausted indica si ya no cabe ningún artifact más.
+func (b *artifactBudget) exhausted() bool {
+	return b.remaining() == 0
+}
+
+// sourceLimiter aplica los topes de artifacts a una ejecución de source:
+// cancela su contexto al alcanzarlos y recorta lo que los excede.
+type sourceLimiter struct {
+	max    int // Tope de la source (0 = sin tope)
+	budget *artifactBudget
+	cancel context.CancelCauseFunc
+
+	mu   sync.Mutex
+	kept int   // Artifacts ya admitidos de la source
+	cut  error // Causa del primer recorte
+}
+
+// observe recibe el contador acumulado de la source mientras corre y la
+// cancela en cuanto alcanza su tope o el del escaneo.
+func (l *sourceLimiter) observe(count int) {
+	if l.max > 0 && count >= l.max {
+		l.cancel(errSourceMaxArtifacts)
+		return
+	}
+
+	// Lo ya admitido (resultados parciales) ya descontó del presupuesto
+	l.mu.Lock()
+	pending := count - l.kept
+	l.mu.Unlock()
+	if remaining := l.budget.remaining(); remaining >= 0 && pending >= remaining {
+		l.cancel(errScanMaxArtifacts)
+	}
+}
+
+// admit recorta artifacts a lo que permiten los topes; si sobra algo,
+// cancela la source y recuerda la causa.
+func (l *sourceLimiter) admit(artifacts []*domain.Artifact) []*domain.Artifact {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := len(artifacts)
+	var cause error
+	if l.max > 0 && l.kept+n > l.max {
+		n = l.max - l.kept
+		cause = errSourceMaxArtifacts
+	}
+	if granted := l.budget.take(n); granted < n {
+		n = granted
+		if cause == nil {
+			cause = errScanMaxArtifacts
+		}
+	}
+	l.kept += n
+
+	if cause != nil {
+		if l.cut == nil {
+			l.cut = cause
+		}
+		l.cancel(cause)
+	}
+	return artifacts[:n]
+}
+
+// cause retorna el tope que detuvo la source: el del primer recorte o el
+// que canceló ctx. nil si ningún tope intervino.
+func (l *sourceLimiter) cause(ctx context.Context) error {
+	l.mu.Lock()
+	cut := l.cut
+	l.mu.Unlock()
+	if cut != nil {
+		return cut
+	}
+	if cause := context.Cause(ctx); isLimitCause(cause) {
+		return cause
+	}
+	return nil
+}
+
+// isLimitCause indica si err es la causa de cancelación de un tope.
+func isLimitCause(err error) bool {
+	return errors.Is(err, errSourceMaxArtifacts) || errors.Is(err, errSourceMaxDuration) ||
+		errors.Is(err, errScanMaxArtifacts) || errors.Is(err, errScanMaxDuration) ||
+		errors.Is(err, errStageMaxDuration)
+}
+
+// stageWorkers retorna los workers del stage: su StageMaxWorkers o maxWorkers.
+func (p *PipelineOrchestrator) stageWorkers(stage Stage) int {
+	if n := p.limits.StageMaxWorkers[stage.ID]; n > 0 {
+		return n
+	}
+	return p.maxWorkers
+}
+
+// stageContext deriva el contexto de un stage de parent: con StageMaxDuration
+// el stage se cancela con causa stageDeadline al vencer (y sustituye al
+// timeout por stage); si no, aplica timeout (0 = sin timeout).
+func (p *PipelineOrchestrator) stageContext(parent context.Context, stage Stage, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if d := p.limits.StageMaxDuration[stage.ID]; d > 0 {
+		return context.WithTimeoutCause(parent, d, stageDeadline{limit: d})
+	}
+	if timeout > 0 {
+		return context.WithTimeout(parent, timeout)
+	}
+	return context.WithCancel(parent)
+}
+
+// scanLimitCause retorna el tope del escaneo ya alcanzado (nil si ninguno).
+func (p *PipelineOrchestrator) scanLimitCause(startTime time.Time) error {
+	if p.budget.exhausted() {
+		return errScanMaxArtifacts
+	}
+	if p.limits.MaxDuration > 0 && time.Since(startTime) >= p.limits.MaxDuration {
+		return errScanMaxDuration
+	}
+	return nil
+}
+
+// skipStages registra los stages que no llegan a ejecutarse por un tope
+// del escaneo.
+func (p *PipelineOrchestrator) skipStages(stages []Stage, cause error) {
+	names := make([]string, 0, len(stages))
+	for _, stage := range stages {
+		names = append(names, stage.Name)
+	}
+	truncation, _ := p.limits.truncation("", cause)
+	truncation.Message = "stages skipped: " + strings.Join(names, ", ")
+	p.addTruncation(truncation)
+}
+
+// addTruncation registra una truncación del escaneo en curso.
+func (p *PipelineOrchestrator) addTruncation(truncation domain.Truncation) {
+	p.logger.Warn("scan truncated",
+		"source", truncation.Source,
+		"scope", truncation.Scope,
+		"reason", truncation.Reason,
+		"limit", truncation.Limit,
+		"artifacts", truncation.Artifacts,
+		"message", truncation.Message,
+	)
+
+	p.truncMu.Lock()
+	defer p.truncMu.Unlock()
+	p.truncations = append(p.truncations, truncation)
+}
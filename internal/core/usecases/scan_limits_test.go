@@ -0,0 +1,226 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/ui"
+	"aethonx/internal/testutil"
+)
+
+// runLimited ejecuta un pipeline de un stage con los topes indicados.
+func runLimited(t *testing.T, limits ScanLimits, sources ...ports.Source) *domain.ScanResult {
+	t.Helper()
+	metadata := make(map[string]ports.SourceMetadata)
+	for _, src := range sources {
+		metadata[src.Name()] = ports.SourceMetadata{Name: src.Name()}
+	}
+	orch := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources:        sources,
+		SourceMetadata: metadata,
+		Logger:         logx.NewSilent(),
+		Presenter:      ui.NewNopPresenter(),
+		MaxWorkers:     1,
+		Limits:         limits,
+	})
+	result, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModePassive))
+	testutil.AssertNoError(t, err, "run")
+	return result
+}
+
+// countFrom cuenta los artifacts aportados por una source.
+func countFrom(result *domain.ScanResult, source string) int {
+	n := 0
+	for _, a := range result.Artifacts {
+		for _, s := range a.Sources {
+			if s == source {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
+
+func TestPipelineOrchestrator_SourceMaxArtifacts(t *testing.T) {
+	result := runLimited(t, ScanLimits{SourceMaxArtifacts: map[string]int{"big": 10, AnySource: 100}},
+		hostsSource("big", 50), hostsSource("small", 5))
+
+	testutil.AssertEqual(t, countFrom(result, "big"), 10, "big capped")
+	testutil.AssertEqual(t, countFrom(result, "small"), 5, "small untouched")
+	testutil.AssertEqual(t, len(result.Errors), 0, "a cap is not a failure")
+	testutil.AssertTrue(t, result.IsTruncated(), "result marked truncated")
+
+	truncation := result.Metadata.Truncations[0]
+	testutil.AssertEqual(t, truncation.Source, "big", "truncated source")
+	testutil.AssertEqual(t, truncation.Scope, "source", "source scope")
+	testutil.AssertEqual(t, truncation.Reason, domain.TruncatedMaxArtifacts, "reason")
+	testutil.AssertEqual(t, truncation.Limit, "10", "limit")
+	testutil.AssertEqual(t, truncation.Artifacts, 10, "kept artifacts")
+}
+
+func TestPipelineOrchestrator_Sample(t *testing.T) {
+	result := runLimited(t, ScanLimits{Sample: 3, SourceMaxArtifacts: map[string]int{"tight": 2}},
+		hostsSource("big", 50), hostsSource("tight", 50), hostsSource("small", 2))
+
+	testutil.AssertEqual(t, countFrom(result, "big"), 3, "big sampled")
+	testutil.AssertEqual(t, countFrom(result, "tight"), 2, "a smaller cap wins over the sample")
+	testutil.AssertEqual(t, countFrom(result, "small"), 2, "small untouched")
+	testutil.AssertEqual(t, len(result.Errors), 0, "sampling is not a failure")
+
+	reasons := make(map[string]domain.TruncationReason)
+	for _, truncation := range result.Metadata.Truncations {
+		reasons[truncation.Source] = truncation.Reason
+	}
+	testutil.AssertEqual(t, reasons["big"], domain.TruncatedSample, "big reason")
+	testutil.AssertEqual(t, reasons["tight"], domain.TruncatedMaxArtifacts, "tight reason")
+	_, cut := reasons["small"]
+	testutil.AssertFalse(t, cut, "small not truncated")
+}
+
+func TestPipelineOrchestrator_ScanMaxArtifacts(t *testing.T) {
+	result := runLimited(t, ScanLimits{MaxArtifacts: 15},
+		hostsSource("first", 10), hostsSource("second", 10), hostsSource("third", 10))
+
+	testutil.AssertEqual(t, len(result.Artifacts), 15, "scan capped")
+	testutil.AssertEqual(t, len(result.Metadata.Truncations), 2, "second cut, third skipped")
+	for _, truncation := range result.Metadata.Truncations {
+		testutil.AssertEqual(t, truncation.Scope, "scan", "scan scope")
+		testutil.AssertEqual(t, truncation.Limit, "15", "scan limit")
+	}
+}
+
+func TestPipelineOrchestrator_SourceMaxDurationKeepsPartialResults(t *testing.T) {
+	slow := newMockSource("slow", domain.SourceModePassive, domain.SourceTypeAPI)
+	slow.runFunc = func(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+		result := domain.NewScanResult(target)
+		result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "early."+target.Root, "slow"))
+		<-ctx.Done()
+		return result, ctx.Err()
+	}
+
+	result := runLimited(t, ScanLimits{SourceMaxDuration: map[string]time.Duration{"slow": 20 * time.Millisecond}}, slow)
+
+	testutil.AssertEqual(t, len(result.Errors), 0, "stopped by its cap, not failed")
+	testutil.AssertEqual(t, countFrom(result, "slow"), 1, "partial results kept")
+	testutil.AssertEqual(t, result.Metadata.Truncations[0].Reason, domain.TruncatedMaxDuration, "reason")
+	testutil.AssertEqual(t, result.Metadata.Truncations[0].Limit, "20ms", "limit")
+}
+
+func TestPipelineOrchestrator_ScanMaxDurationSkipsStages(t *testing.T) {
+	slow := newMockSource("slow", domain.SourceModePassive, domain.SourceTypeAPI)
+	slow.runFunc = func(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+		result := domain.NewScanResult(target)
+		result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "a."+target.Root, "slow"))
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	consumerRan := false
+	consumer := &mockInputConsumerSource{
+		name: "consumer",
+		onRunWithInput: func(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+			consumerRan = true
+			return domain.NewScanResult(target), nil
+		},
+	}
+
+	orch := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources: []ports.Source{slow, consumer},
+		SourceMetadata: map[string]ports.SourceMetadata{
+			"slow":     {Name: "slow", OutputArtifacts: []domain.ArtifactType{domain.ArtifactTypeSubdomain}},
+			"consumer": {Name: "consumer", InputArtifacts: []domain.ArtifactType{domain.ArtifactTypeSubdomain}},
+		},
+		Logger:    logx.NewSilent(),
+		Presenter: ui.NewNopPresenter(),
+		Limits:    ScanLimits{MaxDuration: 30 * time.Millisecond},
+	})
+	result, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModeHybrid))
+	testutil.AssertNoError(t, err, "run")
+
+	testutil.AssertTrue(t, !consumerRan, "later stage skipped")
+	testutil.AssertEqual(t, len(result.Errors), 0, "no source failure")
+	testutil.AssertEqual(t, len(result.Metadata.Truncations), 2, "source and scan truncations")
+
+	scan := result.Metadata.Truncations[1]
+	testutil.AssertEqual(t, scan.Source, "", "scan-wide truncation")
+	testutil.AssertEqual(t, scan.Reason, domain.TruncatedMaxDuration, "reason")
+	testutil.AssertContains(t, scan.Message, "stages skipped", "skipped stages noted")
+	testutil.AssertContains(t, fmt.Sprint(result.Warnings), "truncated", "warning added")
+}
+
+func TestPipelineOrchestrator_StageMaxDurationContinues(t *testing.T) {
+	started := 0
+	blocking := func(name string) *mockSource {
+		src := newMockSource(name, domain.SourceModePassive, domain.SourceTypeAPI)
+		src.runFunc = func(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+			started++
+			result := domain.NewScanResult(target)
+			result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, name+"."+target.Root, name))
+			<-ctx.Done()
+			return result, ctx.Err()
+		}
+		return src
+	}
+	consumerRan := false
+	consumer := &mockInputConsumerSource{
+		name: "consumer",
+		onRunWithInput: func(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+			consumerRan = ctx.Err() == nil
+			return domain.NewScanResult(target), nil
+		},
+	}
+
+	subdomains := []domain.ArtifactType{domain.ArtifactTypeSubdomain}
+	orch := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources: []ports.Source{blocking("first"), blocking("second"), consumer},
+		SourceMetadata: map[string]ports.SourceMetadata{
+			"first":    {Name: "first", OutputArtifacts: subdomains},
+			"second":   {Name: "second", OutputArtifacts: subdomains},
+			"consumer": {Name: "consumer", InputArtifacts: subdomains},
+		},
+		Logger:     logx.NewSilent(),
+		Presenter:  ui.NewNopPresenter(),
+		MaxWorkers: 4,
+		Limits: ScanLimits{
+			StageMaxDuration: map[int]time.Duration{0: 30 * time.Millisecond},
+			StageMaxWorkers:  map[int]int{0: 1},
+		},
+	})
+	result, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModeHybrid))
+	testutil.AssertNoError(t, err, "run")
+
+	testutil.AssertEqual(t, started, 1, "one worker in stage 0: the queued source never starts")
+	testutil.AssertTrue(t, consumerRan, "next stage runs after the deadline")
+	testutil.AssertEqual(t, len(result.Errors), 0, "stopped by the stage cap, not failed")
+	testutil.AssertEqual(t, len(result.Metadata.Truncations), 2, "both stage 0 sources truncated")
+	for _, truncation := range result.Metadata.Truncations {
+		testutil.AssertEqual(t, truncation.Scope, "stage", "stage scope")
+		testutil.AssertEqual(t, truncation.Limit, "30ms", "stage limit")
+	}
+	testutil.AssertEqual(t, countFrom(result, "first")+countFrom(result, "second"), 1, "partial results of the running source kept")
+}
+
+func TestSourceLimiter_AdmitAcrossPartials(t *testing.T) {
+	_, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	limiter := &sourceLimiter{max: 5, budget: newArtifactBudget(0), cancel: cancel}
+
+	artifacts := func(n int) []*domain.Artifact {
+		out := make([]*domain.Artifact, n)
+		for i := range out {
+			out[i] = domain.NewArtifact(domain.ArtifactTypeSubdomain, fmt.Sprintf("h%d.example.com", i), "test")
+		}
+		return out
+	}
+
+	testutil.AssertEqual(t, len(limiter.admit(artifacts(3))), 3, "first partial fits")
+	testutil.AssertEqual(t, len(limiter.admit(artifacts(3))), 2, "second partial cut")
+	testutil.AssertEqual(t, len(limiter.admit(artifacts(3))), 0, "nothing after the cap")
+	testutil.AssertTrue(t, strings.Contains(limiter.cause(context.Background()).Error(), "source artifact limit"), "cause recorded")
+}
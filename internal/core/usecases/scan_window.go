@@ -0,0 +1,95 @@
+// internal/core/usecases/scan_window.go
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"aethonx/internal/core/domain"
+)
+
+// isActiveStage indica si el stage envía tráfico al target: tiene alguna
+// source activa, o una "both" en un escaneo que no es pasivo.
+func isActiveStage(stage Stage, mode domain.ScanMode) bool {
+	for _, src := range stage.Sources {
+		switch src.Mode() {
+		case domain.SourceModeActive:
+			return true
+		case domain.SourceModeBoth:
+			if mode != domain.ScanModePassive {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// awaitActiveWindow aplaza un stage activo hasta que abra la franja
+// permitida (--active-window) y retorna si debe ejecutarse. Si el escaneo
+// termina antes de la apertura (deadline del contexto o --max-duration) o
+// se cancela durante la espera, el stage se omite; los stages pasivos
+// posteriores se ejecutan igualmente. Un stage ya arrancado nunca se
+// interrumpe al cerrarse la franja.
+func (p *PipelineOrchestrator) awaitActiveWindow(ctx context.Context, stage Stage, mode domain.ScanMode, startTime time.Time) bool {
+	if p.activeWindow == nil || !isActiveStage(stage, mode) {
+		return true
+	}
+	now := p.now()
+	if p.activeWindow.Contains(now) {
+		return true
+	}
+
+	deferral := domain.StageDeferral{
+		Stage:      stage.Name,
+		Window:     p.activeWindow.String(),
+		DeferredAt: now,
+		OpensAt:    p.activeWindow.NextOpen(now),
+	}
+	defer func() { p.deferrals = append(p.deferrals, deferral) }()
+
+	// Tiempo que le queda al escaneo (-1 = sin límite)
+	left := time.Duration(-1)
+	if deadline, ok := ctx.Deadline(); ok {
+		left = time.Until(deadline)
+	}
+	if p.limits.MaxDuration > 0 {
+		if d := p.limits.MaxDuration - time.Since(startTime); left < 0 || d < left {
+			left = d
+		}
+	}
+	switch {
+	case ctx.Err() != nil:
+		deferral.Skipped, deferral.Reason = true, "scan cancelled"
+	case left >= 0 && now.Add(left).Before(deferral.OpensAt):
+		deferral.Skipped, deferral.Reason = true, "scan ends before the window opens"
+	}
+	if deferral.Skipped {
+		p.logger.Warn("active stage skipped outside window",
+			"stage_name", stage.Name,
+			"window", deferral.Window,
+			"opens_at", deferral.OpensAt,
+			"reason", deferral.Reason,
+		)
+		return false
+	}
+
+	p.logger.Info("active stage deferred until window opens",
+		"stage_name", stage.Name,
+		"window", deferral.Window,
+		"opens_at", deferral.OpensAt,
+	)
+	timer := time.NewTimer(deferral.OpensAt.Sub(now))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		deferral.Skipped, deferral.Reason = true, "scan cancelled"
+		p.logger.Warn("active stage skipped: scan cancelled while deferred", "stage_name", stage.Name)
+		return false
+	}
+
+	resumed := p.now()
+	deferral.ResumedAt = &resumed
+	p.logger.Info("active stage resumed in window", "stage_name", stage.Name, "waited", resumed.Sub(now).String())
+	return true
+}
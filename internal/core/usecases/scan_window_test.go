@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/ui"
+	"aethonx/internal/testutil"
+)
+
+// windowedPipeline crea un pipeline con un stage pasivo y otro activo
+// restringido a la franja nocturna 22:00-06:00 UTC.
+func windowedPipeline(t *testing.T, activeRan *bool) *PipelineOrchestrator {
+	t.Helper()
+	active := newMockSource("prober", domain.SourceModeActive, domain.SourceTypeAPI)
+	active.runFunc = func(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+		*activeRan = true
+		return domain.NewScanResult(target), nil
+	}
+	window, err := domain.ParseTimeWindow("22:00-06:00", "UTC")
+	testutil.AssertNoError(t, err, "window")
+
+	return NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources: []ports.Source{hostsSource("passive", 3), active},
+		SourceMetadata: map[string]ports.SourceMetadata{
+			"passive": {Name: "passive", OutputArtifacts: []domain.ArtifactType{domain.ArtifactTypeSubdomain}},
+			"prober":  {Name: "prober", InputArtifacts: []domain.ArtifactType{domain.ArtifactTypeSubdomain}},
+		},
+		Logger:       logx.NewSilent(),
+		Presenter:    ui.NewNopPresenter(),
+		ActiveWindow: &window,
+	})
+}
+
+// clockAt retorna un reloj que arranca en start y avanza en tiempo real.
+func clockAt(start time.Time) func() time.Time {
+	real := time.Now()
+	return func() time.Time { return start.Add(time.Since(real)) }
+}
+
+func TestPipelineOrchestrator_ActiveWindowSkipsWhenScanCannotWait(t *testing.T) {
+	activeRan := false
+	orch := windowedPipeline(t, &activeRan)
+	orch.now = clockAt(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	result, err := orch.Run(ctx, *domain.NewTarget("example.com", domain.ScanModeHybrid))
+	testutil.AssertNoError(t, err, "run")
+
+	testutil.AssertFalse(t, activeRan, "active stage not run outside the window")
+	testutil.AssertEqual(t, len(result.Artifacts), 3, "passive stage ran anyway")
+	testutil.AssertEqual(t, len(result.Metadata.Deferrals), 1, "deferral recorded")
+
+	deferral := result.Metadata.Deferrals[0]
+	testutil.AssertTrue(t, deferral.Skipped, "skipped: the scan ends before 22:00")
+	testutil.AssertTrue(t, deferral.ResumedAt == nil, "never resumed")
+	testutil.AssertEqual(t, deferral.OpensAt, time.Date(2024, 6, 1, 22, 0, 0, 0, time.UTC), "next opening")
+	testutil.AssertContains(t, fmt.Sprint(result.Warnings), "active stage deferred", "warning added")
+}
+
+func TestPipelineOrchestrator_ActiveWindowDefersAndResumes(t *testing.T) {
+	activeRan := false
+	orch := windowedPipeline(t, &activeRan)
+	orch.now = clockAt(time.Date(2024, 6, 1, 21, 59, 59, 970_000_000, time.UTC))
+
+	result, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModeHybrid))
+	testutil.AssertNoError(t, err, "run")
+
+	testutil.AssertTrue(t, activeRan, "active stage ran once the window opened")
+	testutil.AssertEqual(t, len(result.Metadata.Deferrals), 1, "deferral recorded")
+
+	deferral := result.Metadata.Deferrals[0]
+	testutil.AssertFalse(t, deferral.Skipped, "not skipped")
+	testutil.AssertTrue(t, deferral.ResumedAt != nil && !deferral.ResumedAt.Before(deferral.OpensAt), "resumed in window")
+}
+
+func TestPipelineOrchestrator_ActiveWindowIgnoresPassiveStages(t *testing.T) {
+	window, _ := domain.ParseTimeWindow("22:00-06:00", "UTC")
+	orch := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources:        []ports.Source{hostsSource("passive", 2)},
+		SourceMetadata: map[string]ports.SourceMetadata{"passive": {Name: "passive"}},
+		Logger:         logx.NewSilent(),
+		Presenter:      ui.NewNopPresenter(),
+		ActiveWindow:   &window,
+	})
+	orch.now = clockAt(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	result, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModeHybrid))
+	testutil.AssertNoError(t, err, "run")
+	testutil.AssertEqual(t, len(result.Artifacts), 2, "passive stage runs anytime")
+	testutil.AssertEqual(t, len(result.Metadata.Deferrals), 0, "nothing deferred")
+}
@@ -53,6 +53,10 @@ type StageResult struct {
 
 	// StreamedToDisk indica si los resultados fueron escritos a disco
 	StreamedToDisk bool
+
+	// Abort fallo de una source crítica que detiene el pipeline (nil = se
+	// continúa con el siguiente stage)
+	Abort error
 }
 
 // SourceExecutionResult resultado de ejecución de una source individual.
@@ -153,6 +157,12 @@ func (s *Stage) SourceCount() int {
 	return len(s.Sources)
 }
 
+// Number retorna el número del stage que ven el usuario, el presenter y los
+// logs (1 = primer stage).
+func (s *Stage) Number() int {
+	return s.ID + 1
+}
+
 // HasErrors retorna true si el StageResult contiene errores críticos.
 func (sr *StageResult) HasErrors() bool {
 	return len(sr.Errors) > 0
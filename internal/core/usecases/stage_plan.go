@@ -0,0 +1,68 @@
+// internal/core/usecases/stage_plan.go
+package usecases
+
+import (
+	"sort"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+)
+
+// StagePlan stages que ejecutaría un escaneo, sin ejecutarlos (--plan).
+type StagePlan struct {
+	// Stages a ejecutar, en orden
+	Stages []Stage
+
+	// Skipped sources omitidas por --skip-stages, agrupadas por fase
+	Skipped map[string][]string
+}
+
+// Plan construye los stages de un escaneo en modo mode tal como los
+// ejecutaría Run, incluidas las fases omitidas, sin ejecutar ninguna source.
+func (p *PipelineOrchestrator) Plan(mode domain.ScanMode) (*StagePlan, error) {
+	compatible := p.filterCompatibleSources(p.sources, mode)
+	kept, skipped := p.skipPhases(compatible)
+
+	plan := &StagePlan{Skipped: skipped}
+	if len(kept) == 0 {
+		return plan, nil
+	}
+	stages, err := p.BuildStages(kept)
+	if err != nil {
+		return nil, err
+	}
+	plan.Stages = stages
+	return plan, nil
+}
+
+// skipPhases separa las sources de las fases de SkipStages; skipped agrupa
+// por fase los nombres de las omitidas.
+func (p *PipelineOrchestrator) skipPhases(sources []ports.Source) (kept []ports.Source, skipped map[string][]string) {
+	skipped = make(map[string][]string)
+	if len(p.skippedPhases) == 0 {
+		return sources, skipped
+	}
+
+	for _, src := range sources {
+		phase := p.sourceMetadata[src.Name()].StagePhase()
+		if p.skippedPhases[phase] {
+			skipped[phase] = append(skipped[phase], src.Name())
+			continue
+		}
+		kept = append(kept, src)
+	}
+	for _, names := range skipped {
+		sort.Strings(names)
+	}
+	return kept, skipped
+}
+
+// skippedStages retorna las fases de SkipStages ordenadas.
+func (p *PipelineOrchestrator) skippedStages() []string {
+	phases := make([]string, 0, len(p.skippedPhases))
+	for phase := range p.skippedPhases {
+		phases = append(phases, phase)
+	}
+	sort.Strings(phases)
+	return phases
+}
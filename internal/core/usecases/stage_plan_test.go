@@ -0,0 +1,81 @@
+// internal/core/usecases/stage_plan_test.go
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/ui"
+	"aethonx/internal/testutil"
+)
+
+// phasedPipeline retorna una source de cada fase: crtsh (discovery, sin
+// inputs), httpx (verification, activa con inputs) y rdap (enrichment
+// declarada aunque no consume inputs).
+func phasedPipeline(skip ...string) (*PipelineOrchestrator, map[string]*mockSource) {
+	sources := map[string]*mockSource{
+		"crtsh": newMockSource("crtsh", domain.SourceModePassive, domain.SourceTypeAPI),
+		"httpx": newMockSource("httpx", domain.SourceModeActive, domain.SourceTypeCLI),
+		"rdap":  newMockSource("rdap", domain.SourceModePassive, domain.SourceTypeAPI),
+	}
+	subdomains := []domain.ArtifactType{domain.ArtifactTypeSubdomain}
+	orch := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources: []ports.Source{sources["crtsh"], sources["httpx"], sources["rdap"]},
+		SourceMetadata: map[string]ports.SourceMetadata{
+			"crtsh": {Name: "crtsh", Mode: domain.SourceModePassive, OutputArtifacts: subdomains},
+			"httpx": {Name: "httpx", Mode: domain.SourceModeActive, InputArtifacts: subdomains},
+			"rdap":  {Name: "rdap", Mode: domain.SourceModePassive, Phase: ports.PhaseEnrichment},
+		},
+		Logger:     logx.NewSilent(),
+		Presenter:  ui.NewNopPresenter(),
+		SkipStages: skip,
+	})
+	return orch, sources
+}
+
+func TestSourceMetadata_StagePhase(t *testing.T) {
+	testutil.AssertEqual(t, ports.SourceMetadata{}.StagePhase(), ports.PhaseDiscovery, "no inputs")
+	testutil.AssertEqual(t, ports.SourceMetadata{
+		Mode:           domain.SourceModeActive,
+		InputArtifacts: []domain.ArtifactType{domain.ArtifactTypeURL},
+	}.StagePhase(), ports.PhaseVerification, "active with inputs")
+	testutil.AssertEqual(t, ports.SourceMetadata{
+		Mode:           domain.SourceModePassive,
+		InputArtifacts: []domain.ArtifactType{domain.ArtifactTypeASN},
+	}.StagePhase(), ports.PhaseEnrichment, "passive with inputs")
+	testutil.AssertEqual(t, ports.SourceMetadata{Phase: ports.PhaseEnrichment}.StagePhase(), ports.PhaseEnrichment, "declared")
+}
+
+func TestPipelineOrchestrator_PlanSkipsStages(t *testing.T) {
+	orch, _ := phasedPipeline()
+	plan, err := orch.Plan(domain.ScanModeHybrid)
+	testutil.AssertNoError(t, err, "plan")
+	testutil.AssertEqual(t, len(plan.Stages), 2, "discovery and verification stages")
+	testutil.AssertEqual(t, len(plan.Skipped), 0, "nothing skipped")
+
+	orch, _ = phasedPipeline(ports.PhaseVerification, ports.PhaseEnrichment)
+	plan, err = orch.Plan(domain.ScanModeHybrid)
+	testutil.AssertNoError(t, err, "plan")
+	testutil.AssertEqual(t, len(plan.Stages), 1, "only discovery left")
+	testutil.AssertEqual(t, plan.Stages[0].Sources[0].Name(), "crtsh", "discovery source")
+	testutil.AssertEqual(t, plan.Skipped[ports.PhaseVerification][0], "httpx", "verification skipped")
+	testutil.AssertEqual(t, plan.Skipped[ports.PhaseEnrichment][0], "rdap", "enrichment skipped")
+}
+
+func TestPipelineOrchestrator_RunSkipsStages(t *testing.T) {
+	orch, sources := phasedPipeline(ports.PhaseVerification, ports.PhaseEnrichment)
+	result, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModeHybrid))
+	testutil.AssertNoError(t, err, "run")
+
+	testutil.AssertEqual(t, sources["crtsh"].runCallCount, 1, "discovery runs")
+	testutil.AssertEqual(t, sources["httpx"].runCallCount, 0, "verification skipped")
+	testutil.AssertEqual(t, sources["rdap"].runCallCount, 0, "enrichment skipped")
+	testutil.AssertEqual(t, len(result.Metadata.SkippedStages), 2, "skipped phases recorded")
+
+	orch, _ = phasedPipeline(ports.PhaseDiscovery, ports.PhaseVerification, ports.PhaseEnrichment)
+	_, err = orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModeHybrid))
+	testutil.AssertError(t, err, "nothing left to run")
+}
@@ -0,0 +1,332 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/ui"
+	"aethonx/internal/testutil"
+)
+
+// hostsSource genera count subdominios propios (sin colisiones entre sources).
+func hostsSource(name string, count int) *mockSource {
+	mock := newMockSource(name, domain.SourceModePassive, domain.SourceTypeAPI)
+	mock.runFunc = func(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+		result := domain.NewScanResult(target)
+		for i := 0; i < count; i++ {
+			result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, fmt.Sprintf("%s-%d.%s", name, i, target.Root), name))
+		}
+		result.AddWarning(name, "done")
+		return result, nil
+	}
+	return mock
+}
+
+// Pensado para ejecutarse con -race (make test).
+func TestPipelineOrchestrator_ExecuteStage_ConcurrentSources(t *testing.T) {
+	const sources, perSource = 16, 50
+
+	stageSources := make([]ports.Source, 0, sources+1)
+	metadata := make(map[string]ports.SourceMetadata)
+	for i := 0; i < sources; i++ {
+		name := fmt.Sprintf("src%02d", i)
+		stageSources = append(stageSources, hostsSource(name, perSource))
+		metadata[name] = ports.SourceMetadata{Name: name}
+	}
+	stageSources = append(stageSources, mockSourceWithError("broken", errors.New("boom")))
+	metadata["broken"] = ports.SourceMetadata{Name: "broken"}
+
+	orch := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources:        stageSources,
+		SourceMetadata: metadata,
+		Logger:         logx.NewSilent(),
+		MaxWorkers:     8,
+	})
+
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	stageResult, err := orch.executeStage(context.Background(), *NewStage(0, stageSources), domain.NewScanResult(*target))
+	testutil.AssertNoError(t, err, "execute stage")
+
+	testutil.AssertEqual(t, len(stageResult.SourceResults), sources+1, "every source reported")
+	testutil.AssertEqual(t, len(stageResult.Errors), 1, "failing source")
+	testutil.AssertEqual(t, stageResult.TotalArtifacts(), sources*perSource, "consolidated artifacts")
+	testutil.AssertEqual(t, len(stageResult.ConsolidatedResult.Warnings), sources, "consolidated warnings")
+}
+
+func TestOrchestrator_Run_ConcurrentSources(t *testing.T) {
+	const sources, perSource = 12, 40
+
+	list := make([]ports.Source, 0, sources+1)
+	for i := 0; i < sources; i++ {
+		list = append(list, hostsSource(fmt.Sprintf("src%02d", i), perSource))
+	}
+	list = append(list, mockSourceWithError("broken", errors.New("boom")))
+
+	orch := NewOrchestrator(OrchestratorOptions{
+		Sources:    list,
+		Logger:     logx.NewSilent(),
+		MaxWorkers: 6,
+	})
+
+	result, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModePassive))
+	testutil.AssertNoError(t, err, "run")
+
+	testutil.AssertEqual(t, len(result.Artifacts), sources*perSource, "artifacts")
+	testutil.AssertEqual(t, len(result.Metadata.SourcesUsed), sources+1, "sources used")
+	testutil.AssertEqual(t, len(result.Errors), 1, "source error recorded")
+	testutil.AssertEqual(t, result.Errors[0].Severity, domain.ErrorCritical, "error severity")
+}
+
+// scopedSource registra el logger recibido y escribe una línea con él.
+type scopedSource struct {
+	*mockSource
+	logger logx.Logger
+}
+
+func (s *scopedSource) SetLogger(logger logx.Logger) { s.logger = logger }
+
+func (s *scopedSource) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	s.logger.Info("source running")
+	return s.mockSource.Run(ctx, target)
+}
+
+// waitForEvent espera a que el notifier reciba un evento del tipo dado
+// (las notificaciones se envían en goroutines).
+func waitForEvent(t *testing.T, notifier *mockNotifier, eventType ports.EventType) ports.Event {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if events := notifier.getEventsByType(eventType); len(events) > 0 {
+			return events[0]
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("no %s event received", eventType)
+	return ports.Event{}
+}
+
+func TestPipelineOrchestrator_Run_ScopesLoggerAndEvents(t *testing.T) {
+	var buf bytes.Buffer
+	source := &scopedSource{mockSource: hostsSource("scoped", 1)}
+	notifier := newMockNotifier()
+
+	orch := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources:        []ports.Source{source},
+		SourceMetadata: map[string]ports.SourceMetadata{"scoped": {Name: "scoped"}},
+		Logger:         logx.NewJSON(&buf, logx.LevelInfo),
+		Observers:      []ports.Notifier{notifier},
+		MaxWorkers:     1,
+	})
+
+	result, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModePassive))
+	testutil.AssertNoError(t, err, "run")
+
+	var line map[string]any
+	for _, raw := range strings.Split(buf.String(), "\n") {
+		if strings.Contains(raw, "source running") {
+			testutil.AssertNoError(t, json.Unmarshal([]byte(raw), &line), "json log line")
+		}
+	}
+	testutil.AssertNotNil(t, line, "source logged through the scoped logger")
+	testutil.AssertEqual(t, line["scan_id"], result.ID, "scan_id field")
+	testutil.AssertEqual(t, line["source"], "scoped", "source field")
+	testutil.AssertEqual(t, line["stage"], float64(1), "stage field (1-based, as in the presenter)")
+
+	event := waitForEvent(t, notifier, ports.EventTypeScanCompleted)
+	testutil.AssertEqual(t, event.ScanID, result.ID, "event scan_id")
+}
+
+func TestOrchestrator_Run_EventsCarryScanID(t *testing.T) {
+	source := &scopedSource{mockSource: hostsSource("scoped", 1)}
+	notifier := newMockNotifier()
+
+	orch := NewOrchestrator(OrchestratorOptions{
+		Sources:   []ports.Source{source},
+		Logger:    logx.NewSilent(),
+		Observers: []ports.Notifier{notifier},
+	})
+
+	result, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModePassive))
+	testutil.AssertNoError(t, err, "run")
+	testutil.AssertNotNil(t, source.logger, "logger injected")
+
+	event := waitForEvent(t, notifier, ports.EventTypeSourceCompleted)
+	testutil.AssertEqual(t, event.ScanID, result.ID, "event scan_id")
+}
+
+// progressSource emite progreso por un canal con buffer mínimo, como las
+// sources reales (envíos sin bloquear).
+type progressSource struct {
+	*mockSource
+	progressCh chan ports.ProgressUpdate
+}
+
+func (s *progressSource) ProgressChannel() <-chan ports.ProgressUpdate { return s.progressCh }
+
+func (s *progressSource) emit(count int) {
+	select {
+	case s.progressCh <- ports.ProgressUpdate{ArtifactCount: count, Message: "working"}:
+	default:
+	}
+}
+
+// progressPresenter registra UpdateSource/FinishSource en orden.
+type progressPresenter struct {
+	ui.Presenter
+	mu      sync.Mutex
+	calls   []string
+	updated chan int
+}
+
+func (p *progressPresenter) UpdateSource(sourceName string, metrics ui.ProgressMetrics) {
+	p.mu.Lock()
+	p.calls = append(p.calls, fmt.Sprintf("update:%d", metrics.Current))
+	p.mu.Unlock()
+	select {
+	case p.updated <- metrics.Current:
+	default:
+	}
+}
+
+func (p *progressPresenter) FinishSource(sourceName string, status ui.Status, duration time.Duration, artifactCount int, summary *ui.SourceSummary) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = append(p.calls, "finish")
+}
+
+func TestPipelineOrchestrator_Run_ForwardsSourceProgress(t *testing.T) {
+	presenter := &progressPresenter{Presenter: ui.NewNopPresenter(), updated: make(chan int, 1)}
+	source := &progressSource{mockSource: hostsSource("ticking", 3), progressCh: make(chan ports.ProgressUpdate, 1)}
+	source.progressCh <- ports.ProgressUpdate{ArtifactCount: 99} // Resto de una ejecución anterior
+
+	run := source.runFunc
+	source.runFunc = func(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+		source.emit(1)
+		// El presenter recibe el progreso mientras la source sigue corriendo
+		select {
+		case count := <-presenter.updated:
+			testutil.AssertEqual(t, count, 1, "live update")
+		case <-time.After(2 * time.Second):
+			t.Error("progress never reached the presenter")
+		}
+		// Con el buffer lleno los envíos se descartan sin bloquear
+		for i := 2; i <= 1000; i++ {
+			source.emit(i)
+		}
+		return run(ctx, target)
+	}
+
+	orch := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources:        []ports.Source{source},
+		SourceMetadata: map[string]ports.SourceMetadata{"ticking": {Name: "ticking"}},
+		Logger:         logx.NewSilent(),
+		Presenter:      presenter,
+	})
+	_, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModePassive))
+	testutil.AssertNoError(t, err, "run")
+
+	presenter.mu.Lock()
+	defer presenter.mu.Unlock()
+	testutil.AssertEqual(t, presenter.calls[0], "update:1", "stale update discarded")
+	testutil.AssertEqual(t, presenter.calls[len(presenter.calls)-1], "finish", "no update after FinishSource")
+}
+
+// flakySource falla la primera ejecución y reporta el reintento como lo
+// hace resilience.RetryableSource.
+type flakySource struct {
+	*mockSource
+	circuitOpen bool
+	onState     func(state ports.SourceState, detail string)
+}
+
+func (s *flakySource) SetStateHandler(fn func(state ports.SourceState, detail string)) {
+	s.onState = fn
+}
+
+func (s *flakySource) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	if s.circuitOpen {
+		s.onState(ports.SourceStateCircuitOpen, "circuit breaker open")
+		return nil, errors.New("circuit breaker open")
+	}
+	s.onState(ports.SourceStateRetrying, "attempt 2/2 in 0s: boom")
+	s.onState(ports.SourceStateRunning, "")
+	return s.mockSource.Run(ctx, target)
+}
+
+// statusPresenter registra inicio, cambios de estado y fin de cada source.
+type statusPresenter struct {
+	ui.Presenter
+	mu     sync.Mutex
+	calls  map[string][]string
+	stages []int
+}
+
+func (p *statusPresenter) record(source, call string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls[source] = append(p.calls[source], call)
+}
+
+func (p *statusPresenter) StartStage(stage ui.StageInfo) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stages = append(p.stages, stage.Number)
+}
+
+func (p *statusPresenter) StartSource(stageNum int, sourceName string) {
+	p.record(sourceName, fmt.Sprintf("start:%d", stageNum))
+}
+
+func (p *statusPresenter) UpdateSourceStatus(sourceName string, status ui.Status, detail string) {
+	p.record(sourceName, status.String())
+}
+
+func (p *statusPresenter) FinishSource(sourceName string, status ui.Status, duration time.Duration, artifactCount int, summary *ui.SourceSummary) {
+	p.record(sourceName, "finish:"+status.String())
+}
+
+func TestPipelineOrchestrator_Run_SourceStatesAndStageNumbers(t *testing.T) {
+	presenter := &statusPresenter{Presenter: ui.NewNopPresenter(), calls: make(map[string][]string)}
+	flaky := &flakySource{mockSource: hostsSource("flaky", 1)}
+	open := &flakySource{mockSource: hostsSource("open", 1), circuitOpen: true}
+	second := &mockInputConsumerSource{
+		name: "second",
+		onRunWithInput: func(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+			return domain.NewScanResult(target), nil
+		},
+	}
+
+	orch := NewPipelineOrchestrator(PipelineOrchestratorOptions{
+		Sources: []ports.Source{flaky, open, second},
+		SourceMetadata: map[string]ports.SourceMetadata{
+			"flaky":  {Name: "flaky", OutputArtifacts: []domain.ArtifactType{domain.ArtifactTypeSubdomain}},
+			"open":   {Name: "open"},
+			"second": {Name: "second", InputArtifacts: []domain.ArtifactType{domain.ArtifactTypeSubdomain}},
+		},
+		Logger:    logx.NewSilent(),
+		Presenter: presenter,
+	})
+	result, err := orch.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModeHybrid))
+	testutil.AssertNoError(t, err, "run")
+	testutil.AssertEqual(t, len(result.Errors), 1, "failed source recorded")
+	testutil.AssertEqual(t, result.Errors[0].Source, "open", "failed source name")
+	testutil.AssertEqual(t, result.Errors[0].Severity, domain.ErrorCritical, "failed source severity")
+
+	presenter.mu.Lock()
+	defer presenter.mu.Unlock()
+	testutil.AssertEqual(t, fmt.Sprint(presenter.stages), "[1 2]", "stage numbers")
+	testutil.AssertEqual(t, strings.Join(presenter.calls["flaky"], ","), "start:1,retrying,running,finish:warning", "retry states")
+	testutil.AssertEqual(t, strings.Join(presenter.calls["open"], ","), "start:1,circuit_open,finish:circuit_open", "circuit open is terminal")
+	testutil.AssertEqual(t, presenter.calls["second"][0], "start:2", "second stage number")
+	testutil.AssertTrue(t, flaky.onState == nil, "state handler removed after the run")
+}
@@ -0,0 +1,195 @@
+// internal/core/usecases/tagging_service.go
+package usecases
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+)
+
+// TaggingService aplica reglas de etiquetado definidas por el usuario
+// sobre los artifacts consolidados.
+type TaggingService struct {
+	rules  []compiledTagRule
+	logger logx.Logger
+}
+
+// compiledTagRule es una TagRule con sus expresiones ya compiladas.
+type compiledTagRule struct {
+	name     string
+	tag      string
+	types    map[domain.ArtifactType]bool
+	value    *regexp.Regexp
+	metadata map[string]*regexp.Regexp
+}
+
+// NewTaggingService compila las reglas. Retorna error si alguna regla no tiene
+// tag o contiene un patrón inválido.
+func NewTaggingService(rules []ports.TagRule, logger logx.Logger) (*TaggingService, error) {
+	if logger == nil {
+		logger = logx.New()
+	}
+
+	compiled := make([]compiledTagRule, 0, len(rules))
+	for i, r := range rules {
+		name := r.Name
+		if name == "" {
+			name = fmt.Sprintf("rule[%d]", i)
+		}
+
+		c, err := compileTagRule(name, r)
+		if err != nil {
+			return nil, fmt.Errorf("tag rule %s: %w", name, err)
+		}
+		compiled = append(compiled, c)
+	}
+
+	return &TaggingService{
+		rules:  compiled,
+		logger: logger.With("component", "tagging"),
+	}, nil
+}
+
+func compileTagRule(name string, r ports.TagRule) (compiledTagRule, error) {
+	c := compiledTagRule{
+		name: name,
+		tag:  strings.TrimSpace(r.Tag),
+	}
+	if c.tag == "" {
+		return c, fmt.Errorf("missing tag")
+	}
+
+	if len(r.Types) > 0 {
+		c.types = make(map[domain.ArtifactType]bool, len(r.Types))
+		for _, t := range r.Types {
+			c.types[t] = true
+		}
+	}
+
+	var valueExprs []string
+	if r.Value != "" {
+		valueExprs = append(valueExprs, globToRegex(r.Value))
+	}
+	if r.ValueRegex != "" {
+		valueExprs = append(valueExprs, "(?:"+r.ValueRegex+")")
+	}
+	switch len(valueExprs) {
+	case 1:
+		re, err := regexp.Compile(valueExprs[0])
+		if err != nil {
+			return c, fmt.Errorf("invalid value pattern: %w", err)
+		}
+		c.value = re
+	case 2:
+		return c, fmt.Errorf("value and value_regex are mutually exclusive")
+	}
+
+	if len(r.Metadata) > 0 {
+		c.metadata = make(map[string]*regexp.Regexp, len(r.Metadata))
+		for field, expr := range r.Metadata {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return c, fmt.Errorf("invalid metadata pattern for %q: %w", field, err)
+			}
+			c.metadata[field] = re
+		}
+	}
+
+	return c, nil
+}
+
+// globToRegex convierte un glob simple (* y ?) en una regex anclada e insensible a mayúsculas.
+// A diferencia de path.Match, '*' también cubre '/' para poder aplicarse a URLs.
+func globToRegex(glob string) string {
+	var b strings.Builder
+	b.WriteString("(?i)^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// Len retorna el número de reglas cargadas.
+func (t *TaggingService) Len() int {
+	if t == nil {
+		return 0
+	}
+	return len(t.rules)
+}
+
+// Apply evalúa todas las reglas sobre los artifacts y añade los tags
+// correspondientes. Retorna el número de tags añadidos.
+func (t *TaggingService) Apply(artifacts []*domain.Artifact) int {
+	if t == nil || len(t.rules) == 0 {
+		return 0
+	}
+
+	applied := 0
+	perRule := make(map[string]int, len(t.rules))
+
+	for _, a := range artifacts {
+		if a == nil {
+			continue
+		}
+
+		var meta map[string]string
+		for _, r := range t.rules {
+			if r.metadata != nil && meta == nil {
+				meta = artifactMetadataMap(a)
+			}
+			if !r.matches(a, meta) {
+				continue
+			}
+			before := len(a.Tags)
+			a.AddTag(r.tag)
+			if len(a.Tags) > before {
+				applied++
+				perRule[r.name]++
+			}
+		}
+	}
+
+	for name, n := range perRule {
+		t.logger.Debug("tag rule applied", "rule", name, "artifacts", n)
+	}
+	t.logger.Info("tagging rules applied", "rules", len(t.rules), "tags_added", applied)
+
+	return applied
+}
+
+// matches evalúa la regla contra un artifact (todas las condiciones con AND).
+func (r compiledTagRule) matches(a *domain.Artifact, meta map[string]string) bool {
+	if r.types != nil && !r.types[a.Type] {
+		return false
+	}
+	if r.value != nil && !r.value.MatchString(a.Value) {
+		return false
+	}
+	for field, re := range r.metadata {
+		v, ok := meta[field]
+		if !ok || !re.MatchString(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// artifactMetadataMap retorna el metadata tipado como mapa (vacío si no hay).
+func artifactMetadataMap(a *domain.Artifact) map[string]string {
+	if a.TypedMetadata == nil {
+		return map[string]string{}
+	}
+	return a.TypedMetadata.ToMap()
+}
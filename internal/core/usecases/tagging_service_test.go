@@ -0,0 +1,105 @@
+// internal/core/usecases/tagging_service_test.go
+package usecases
+
+import (
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+)
+
+func hasTag(a *domain.Artifact, tag string) bool {
+	for _, t := range a.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTaggingService_ValueGlob(t *testing.T) {
+	svc, err := NewTaggingService([]ports.TagRule{
+		{Name: "internal", Tag: "internal", Value: "*.internal.*"},
+	}, logx.New())
+	testutil.AssertNoError(t, err, "rules should compile")
+
+	internal := domain.NewArtifact(domain.ArtifactTypeSubdomain, "db.internal.example.com", "crtsh")
+	public := domain.NewArtifact(domain.ArtifactTypeSubdomain, "www.example.com", "crtsh")
+	url := domain.NewArtifact(domain.ArtifactTypeURL, "https://app.internal.example.com/login", "httpx")
+
+	added := svc.Apply([]*domain.Artifact{internal, public, url})
+
+	testutil.AssertEqual(t, added, 2, "two artifacts should be tagged")
+	testutil.AssertTrue(t, hasTag(internal, "internal"), "internal subdomain should be tagged")
+	testutil.AssertFalse(t, hasTag(public, "internal"), "public subdomain should not be tagged")
+	testutil.AssertTrue(t, hasTag(url, "internal"), "glob * should cross '/' in URLs")
+}
+
+func TestTaggingService_TypesAndMetadata(t *testing.T) {
+	svc, err := NewTaggingService([]ports.TagRule{
+		{
+			Tag:      "review",
+			Types:    []domain.ArtifactType{domain.ArtifactTypeSubdomain},
+			Metadata: map[string]string{"http_status": "^403$"},
+		},
+	}, logx.New())
+	testutil.AssertNoError(t, err, "rules should compile")
+
+	forbidden := domain.NewArtifact(domain.ArtifactTypeSubdomain, "admin.example.com", "httpx")
+	meta := metadata.NewDomainMetadata()
+	meta.HTTPStatus = 403
+	forbidden.TypedMetadata = meta
+
+	ok := domain.NewArtifact(domain.ArtifactTypeSubdomain, "www.example.com", "httpx")
+	okMeta := metadata.NewDomainMetadata()
+	okMeta.HTTPStatus = 200
+	ok.TypedMetadata = okMeta
+
+	noMeta := domain.NewArtifact(domain.ArtifactTypeSubdomain, "mail.example.com", "crtsh")
+
+	ip := domain.NewArtifact(domain.ArtifactTypeIP, "10.0.0.1", "httpx")
+	ipMeta := metadata.NewDomainMetadata()
+	ipMeta.HTTPStatus = 403
+	ip.TypedMetadata = ipMeta
+
+	svc.Apply([]*domain.Artifact{forbidden, ok, noMeta, ip})
+
+	testutil.AssertTrue(t, hasTag(forbidden, "review"), "403 subdomain should be tagged")
+	testutil.AssertFalse(t, hasTag(ok, "review"), "200 subdomain should not be tagged")
+	testutil.AssertFalse(t, hasTag(noMeta, "review"), "missing metadata field should not match")
+	testutil.AssertFalse(t, hasTag(ip, "review"), "type filter should exclude IPs")
+}
+
+func TestTaggingService_Idempotent(t *testing.T) {
+	svc, err := NewTaggingService([]ports.TagRule{
+		{Tag: "api", ValueRegex: `^api\.`},
+	}, logx.New())
+	testutil.AssertNoError(t, err, "rules should compile")
+
+	a := domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "crtsh")
+	testutil.AssertEqual(t, svc.Apply([]*domain.Artifact{a}), 1, "first apply adds tag")
+	testutil.AssertEqual(t, svc.Apply([]*domain.Artifact{a}), 0, "second apply adds nothing")
+	testutil.AssertLen(t, a.Tags, 1, "tag should not be duplicated")
+}
+
+func TestNewTaggingService_InvalidRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule ports.TagRule
+	}{
+		{"missing tag", ports.TagRule{Value: "*.example.com"}},
+		{"invalid regex", ports.TagRule{Tag: "x", ValueRegex: "("}},
+		{"invalid metadata regex", ports.TagRule{Tag: "x", Metadata: map[string]string{"http_status": "["}}},
+		{"value and value_regex", ports.TagRule{Tag: "x", Value: "*", ValueRegex: ".*"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTaggingService([]ports.TagRule{tt.rule}, logx.New())
+			testutil.AssertError(t, err, "invalid rule should fail")
+		})
+	}
+}
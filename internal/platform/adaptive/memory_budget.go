@@ -0,0 +1,86 @@
+// internal/platform/adaptive/memory_budget.go
+package adaptive
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// meminfoPath es el fichero del que se lee la RAM total (variable para tests).
+var meminfoPath = "/proc/meminfo"
+
+// SystemMemoryBytes retorna la RAM total del sistema según /proc/meminfo.
+// En sistemas sin /proc retorna error: usar un presupuesto absoluto.
+func SystemMemoryBytes() (int64, error) {
+	f, err := os.Open(meminfoPath)
+	if err != nil {
+		return 0, fmt.Errorf("cannot detect system memory (use an absolute budget like 512MB): %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "MemTotal:" {
+			continue
+		}
+		kb, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || kb <= 0 {
+			return 0, fmt.Errorf("invalid MemTotal in %s: %q", meminfoPath, fields[1])
+		}
+		return kb * 1024, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("MemTotal not found in %s", meminfoPath)
+}
+
+// byteUnits son los sufijos aceptados por ParseMemoryBudget (base 1024).
+var byteUnits = []struct {
+	suffix string
+	factor int64
+}{
+	{"gib", 1 << 30}, {"gb", 1 << 30}, {"g", 1 << 30},
+	{"mib", 1 << 20}, {"mb", 1 << 20}, {"m", 1 << 20},
+	{"kib", 1 << 10}, {"kb", 1 << 10}, {"k", 1 << 10},
+	{"b", 1},
+}
+
+// ParseMemoryBudget convierte un presupuesto de memoria a bytes: un tamaño
+// absoluto ("512MB", "2GiB", "1048576") o un porcentaje de la RAM del sistema
+// ("25%"). Cadena vacía = sin presupuesto (0).
+func ParseMemoryBudget(v string) (int64, error) {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if v == "" {
+		return 0, nil
+	}
+
+	if pct, ok := strings.CutSuffix(v, "%"); ok {
+		p, err := strconv.ParseFloat(strings.TrimSpace(pct), 64)
+		if err != nil || p <= 0 || p > 100 {
+			return 0, fmt.Errorf("invalid memory budget %q: percentage must be in (0, 100]", v)
+		}
+		total, err := SystemMemoryBytes()
+		if err != nil {
+			return 0, err
+		}
+		return int64(float64(total) * p / 100), nil
+	}
+
+	number, factor := v, int64(1)
+	for _, unit := range byteUnits {
+		if n, ok := strings.CutSuffix(v, unit.suffix); ok {
+			number, factor = n, unit.factor
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid memory budget %q (use e.g. 512MB, 2GB or 25%%)", v)
+	}
+	return int64(n * float64(factor)), nil
+}
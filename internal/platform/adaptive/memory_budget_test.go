@@ -0,0 +1,41 @@
+package adaptive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aethonx/internal/testutil"
+)
+
+func TestParseMemoryBudget(t *testing.T) {
+	meminfo := filepath.Join(t.TempDir(), "meminfo")
+	err := os.WriteFile(meminfo, []byte("MemTotal:        8388608 kB\nMemFree:         1024 kB\n"), 0o644)
+	testutil.AssertNoError(t, err, "write meminfo")
+	defer func(path string) { meminfoPath = path }(meminfoPath)
+	meminfoPath = meminfo
+
+	cases := map[string]int64{
+		"":        0,
+		"512MB":   512 << 20,
+		"2GiB":    2 << 30,
+		"1.5g":    3 << 29,
+		"64 kb":   64 << 10,
+		"1048576": 1 << 20,
+		"25%":     2 << 30,
+	}
+	for in, want := range cases {
+		got, err := ParseMemoryBudget(in)
+		testutil.AssertNoError(t, err, "valid budget "+in)
+		testutil.AssertEqual(t, got, want, "bytes for "+in)
+	}
+
+	for _, invalid := range []string{"lots", "-1MB", "0", "150%", "0%"} {
+		_, err := ParseMemoryBudget(invalid)
+		testutil.AssertError(t, err, "invalid budget "+invalid)
+	}
+
+	meminfoPath = filepath.Join(t.TempDir(), "missing")
+	_, err = ParseMemoryBudget("25%")
+	testutil.AssertError(t, err, "percentage without /proc/meminfo")
+}
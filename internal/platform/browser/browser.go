@@ -0,0 +1,266 @@
+// Package browser is the headless Chrome shared by every source that needs
+// one (screenshots, JS rendering, SPA crawling). A Pool starts one browser
+// lazily on first use and opens a tab per page, limiting the pages open at
+// once across the process (--browser-tabs) and bounding each page
+// (--browser-timeout).
+//
+// Sources that drive Chrome themselves (httpx -ss launches its own) reserve
+// a slot with Acquire so they count against the same limit. Like
+// politeness.Shared(), the pool is process-wide.
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Defaults of Config.
+const (
+	DefaultMaxTabs     = 4
+	DefaultPageTimeout = 30 * time.Second
+)
+
+// ErrNoChrome is returned when no Chrome/Chromium binary is available.
+var ErrNoChrome = errors.New("headless browser unavailable: Chrome/Chromium not found (install it or set --chrome-path)")
+
+// ErrClosed is returned by a pool that was closed.
+var ErrClosed = errors.New("headless browser pool is closed")
+
+// chromeNames are the binaries looked up in PATH, in order.
+var chromeNames = []string{
+	"chromium", "chromium-browser", "google-chrome", "google-chrome-stable", "chrome", "headless-shell",
+}
+
+// Config configures a Pool. Zero values take the defaults.
+type Config struct {
+	ExecPath    string        // Chrome/Chromium binary ("" = look up in PATH)
+	MaxTabs     int           // Pages open at once across the process
+	PageTimeout time.Duration // Max time per page (navigation and actions)
+	UserAgent   string        // "" = Chrome's own
+	ProxyURL    string        // "" = direct
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxTabs <= 0 {
+		c.MaxTabs = DefaultMaxTabs
+	}
+	if c.PageTimeout <= 0 {
+		c.PageTimeout = DefaultPageTimeout
+	}
+	return c
+}
+
+// FindChrome returns the path of the Chrome binary for execPath ("" = the
+// first of the usual names found in PATH).
+func FindChrome(execPath string) (string, error) {
+	if execPath != "" {
+		path, err := exec.LookPath(execPath)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", ErrNoChrome, execPath)
+		}
+		return path, nil
+	}
+	for _, name := range chromeNames {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	return "", ErrNoChrome
+}
+
+// Pool shares one headless browser between sources.
+type Pool struct {
+	mu     sync.Mutex
+	cfg    Config
+	slots  chan struct{}
+	closed bool
+
+	// Running browser (nil until the first page)
+	browserCtx    context.Context
+	cancelBrowser context.CancelFunc
+}
+
+// NewPool creates a pool; the browser starts on the first Page.
+func NewPool(cfg Config) *Pool {
+	cfg = cfg.withDefaults()
+	return &Pool{cfg: cfg, slots: make(chan struct{}, cfg.MaxTabs)}
+}
+
+var shared = NewPool(Config{})
+
+// Shared returns the process-wide pool.
+func Shared() *Pool {
+	return shared
+}
+
+// Configure replaces the configuration. A running browser is stopped and the
+// next page starts one with the new settings; pages in flight keep their slots.
+func (p *Pool) Configure(cfg Config) {
+	cfg = cfg.withDefaults()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stopLocked()
+	p.cfg = cfg
+	p.slots = make(chan struct{}, cfg.MaxTabs)
+	p.closed = false
+}
+
+// Config returns the current configuration.
+func (p *Pool) Config() Config {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cfg
+}
+
+// Available reports whether a Chrome binary was found.
+func (p *Pool) Available() bool {
+	_, err := FindChrome(p.Config().ExecPath)
+	return err == nil
+}
+
+// Acquire reserves a page slot until release is called, waiting while
+// MaxTabs pages are open. Sources that launch their own Chrome use it to
+// share the limit; Page acquires its slot itself.
+func (p *Pool) Acquire(ctx context.Context) (release func(), err error) {
+	p.mu.Lock()
+	slots, closed := p.slots, p.closed
+	p.mu.Unlock()
+	if closed {
+		return nil, ErrClosed
+	}
+
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	var once sync.Once
+	return func() { once.Do(func() { <-slots }) }, nil
+}
+
+// Page runs fn in a new tab of the shared browser. The tab context passed to
+// fn is cancelled after PageTimeout or when ctx is done, and the tab is
+// closed when fn returns.
+func (p *Pool) Page(ctx context.Context, fn func(tab context.Context) error) error {
+	release, err := p.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	browserCtx, err := p.browser()
+	if err != nil {
+		return err
+	}
+
+	tab, cancelTab := chromedp.NewContext(browserCtx)
+	defer cancelTab()
+	stop := context.AfterFunc(ctx, cancelTab)
+	defer stop()
+	tab, cancelTimeout := context.WithTimeout(tab, p.Config().PageTimeout)
+	defer cancelTimeout()
+
+	if err := fn(tab); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+	return nil
+}
+
+// Screenshot navigates to url and returns a PNG of the viewport.
+func (p *Pool) Screenshot(ctx context.Context, url string) ([]byte, error) {
+	var png []byte
+	err := p.Page(ctx, func(tab context.Context) error {
+		return chromedp.Run(tab,
+			chromedp.Navigate(url),
+			chromedp.CaptureScreenshot(&png),
+		)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("screenshot %s: %w", url, err)
+	}
+	return png, nil
+}
+
+// Render navigates to url and returns the DOM after the page scripts ran.
+func (p *Pool) Render(ctx context.Context, url string) (string, error) {
+	var html string
+	err := p.Page(ctx, func(tab context.Context) error {
+		return chromedp.Run(tab,
+			chromedp.Navigate(url),
+			chromedp.OuterHTML("html", &html, chromedp.ByQuery),
+		)
+	})
+	if err != nil {
+		return "", fmt.Errorf("render %s: %w", url, err)
+	}
+	return html, nil
+}
+
+// browser returns the running browser, starting it if needed (or again if
+// it crashed).
+func (p *Pool) browser() (context.Context, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil, ErrClosed
+	}
+	if p.browserCtx != nil && p.browserCtx.Err() == nil {
+		return p.browserCtx, nil
+	}
+	p.stopLocked()
+
+	path, err := FindChrome(p.cfg.ExecPath)
+	if err != nil {
+		return nil, err
+	}
+	opts := append(chromedp.DefaultExecAllocatorOptions[:], chromedp.ExecPath(path))
+	if p.cfg.UserAgent != "" {
+		opts = append(opts, chromedp.UserAgent(p.cfg.UserAgent))
+	}
+	if p.cfg.ProxyURL != "" {
+		opts = append(opts, chromedp.ProxyServer(p.cfg.ProxyURL))
+	}
+
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(context.Background(), opts...)
+	browserCtx, cancelBrowser := chromedp.NewContext(allocCtx)
+	// The first Run starts the browser
+	if err := chromedp.Run(browserCtx); err != nil {
+		cancelBrowser()
+		cancelAlloc()
+		return nil, fmt.Errorf("failed to start headless browser %s: %w", path, err)
+	}
+
+	p.browserCtx = browserCtx
+	p.cancelBrowser = func() {
+		cancelBrowser()
+		cancelAlloc()
+	}
+	return browserCtx, nil
+}
+
+// stopLocked stops the running browser, if any.
+func (p *Pool) stopLocked() {
+	if p.cancelBrowser != nil {
+		p.cancelBrowser()
+	}
+	p.browserCtx, p.cancelBrowser = nil, nil
+}
+
+// Close stops the browser. Later pages fail with ErrClosed until Configure.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stopLocked()
+	p.closed = true
+	return nil
+}
@@ -0,0 +1,72 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"aethonx/internal/testutil"
+)
+
+func TestPool_AcquireLimitsTabs(t *testing.T) {
+	p := NewPool(Config{MaxTabs: 2})
+	defer p.Close()
+
+	first, err := p.Acquire(context.Background())
+	testutil.AssertNoError(t, err, "first slot")
+	_, err = p.Acquire(context.Background())
+	testutil.AssertNoError(t, err, "second slot")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = p.Acquire(ctx)
+	testutil.AssertTrue(t, errors.Is(err, context.DeadlineExceeded), "third slot waits for a release")
+
+	first()
+	first() // Releasing twice frees one slot only
+	_, err = p.Acquire(context.Background())
+	testutil.AssertNoError(t, err, "slot freed by the release")
+
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = p.Acquire(ctx)
+	testutil.AssertError(t, err, "double release must not free a second slot")
+}
+
+func TestPool_Defaults(t *testing.T) {
+	cfg := NewPool(Config{}).Config()
+	testutil.AssertEqual(t, cfg.MaxTabs, DefaultMaxTabs, "default tabs")
+	testutil.AssertEqual(t, cfg.PageTimeout, DefaultPageTimeout, "default page timeout")
+}
+
+func TestPool_NoChrome(t *testing.T) {
+	p := NewPool(Config{ExecPath: "/nonexistent/chromium"})
+	defer p.Close()
+
+	testutil.AssertTrue(t, !p.Available(), "missing binary is not available")
+	err := p.Page(context.Background(), func(context.Context) error {
+		t.Error("fn must not run without a browser")
+		return nil
+	})
+	testutil.AssertTrue(t, errors.Is(err, ErrNoChrome), "Page reports ErrNoChrome")
+
+	// The slot taken by the failed page was released
+	release, err := p.Acquire(context.Background())
+	testutil.AssertNoError(t, err, "slot after failed page")
+	release()
+}
+
+func TestPool_CloseAndConfigure(t *testing.T) {
+	p := NewPool(Config{})
+	p.Close()
+
+	_, err := p.Acquire(context.Background())
+	testutil.AssertTrue(t, errors.Is(err, ErrClosed), "closed pool refuses slots")
+
+	p.Configure(Config{MaxTabs: 1, PageTimeout: time.Second})
+	release, err := p.Acquire(context.Background())
+	testutil.AssertNoError(t, err, "configure reopens the pool")
+	release()
+	testutil.AssertEqual(t, p.Config().MaxTabs, 1, "new tab limit")
+}
@@ -0,0 +1,257 @@
+// Package budget enforces global caps on the traffic a scan sends towards the
+// target: HTTP requests, DNS queries and bytes downloaded (--budget).
+//
+// Enforcement is cooperative: sources reserve what they are about to send
+// (a request per probed URL, two queries per resolved host) and stop once the
+// manager refuses. Like rate.Shared(), the budget is process-wide, so every
+// source of the scan (and every concurrent scan of a dashboard or agent)
+// draws from the same counters.
+package budget
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"aethonx/internal/platform/adaptive"
+)
+
+// Kind is a budgeted resource.
+type Kind string
+
+const (
+	Requests   Kind = "requests" // HTTP requests sent by active sources
+	DNSQueries Kind = "dns"      // DNS queries sent by the dns source
+	Bytes      Kind = "bytes"    // Response bytes downloaded by active sources
+)
+
+// Kinds lists the budgeted resources in display order.
+var Kinds = []Kind{Requests, DNSQueries, Bytes}
+
+// ErrExhausted is matched (errors.Is) by every ExhaustedError.
+var ErrExhausted = errors.New("budget exhausted")
+
+// ExhaustedError reports the budget a source ran out of.
+type ExhaustedError struct {
+	Kind  Kind
+	Limit int64
+}
+
+func (e *ExhaustedError) Error() string {
+	return fmt.Sprintf("%s budget exhausted (limit %d)", e.Kind, e.Limit)
+}
+
+// Is makes errors.Is(err, ErrExhausted) true.
+func (e *ExhaustedError) Is(target error) bool {
+	return target == ErrExhausted
+}
+
+// Usage is the consumption of one budget.
+type Usage struct {
+	Kind  Kind
+	Used  int64
+	Limit int64 // 0 = no limit
+}
+
+// Manager tracks the consumption of each budget against its limit.
+type Manager struct {
+	mu     sync.Mutex
+	limits map[Kind]int64
+	used   map[Kind]int64
+}
+
+// NewManager creates a manager without limits.
+func NewManager() *Manager {
+	return &Manager{
+		limits: make(map[Kind]int64),
+		used:   make(map[Kind]int64),
+	}
+}
+
+var shared = NewManager()
+
+// Shared returns the process-wide manager.
+func Shared() *Manager {
+	return shared
+}
+
+// Configure sets the limits (0 or missing = no limit). Consumption so far
+// is kept.
+func (m *Manager) Configure(limits map[Kind]int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.limits = make(map[Kind]int64, len(limits))
+	for kind, limit := range limits {
+		if limit > 0 {
+			m.limits[kind] = limit
+		}
+	}
+}
+
+// Reset clears the consumption counters (limits are kept).
+func (m *Manager) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.used = make(map[Kind]int64)
+}
+
+// Reserve grants up to n units of kind and returns how many were granted.
+// Sources probing a batch use it to trim the batch to what is left.
+func (m *Manager) Reserve(kind Kind, n int64) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if n <= 0 || m.exhaustedErr(kind) != nil {
+		return 0
+	}
+	if limit, ok := m.limits[kind]; ok {
+		if free := limit - m.used[kind]; n > free {
+			n = free
+		}
+	}
+	m.used[kind] += n
+	return n
+}
+
+// Take consumes n units of kind, or none if they do not all fit.
+func (m *Manager) Take(kind Kind, n int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.exhaustedErr(kind); err != nil {
+		return err
+	}
+	if limit, ok := m.limits[kind]; ok && m.used[kind]+n > limit {
+		return &ExhaustedError{Kind: kind, Limit: limit}
+	}
+	m.used[kind] += n
+	return nil
+}
+
+// Record consumes n units already spent (e.g. bytes downloaded) and returns
+// an ExhaustedError once the limit is exceeded.
+func (m *Manager) Record(kind Kind, n int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.used[kind] += n
+	if limit, ok := m.limits[kind]; ok && m.used[kind] > limit {
+		return &ExhaustedError{Kind: kind, Limit: limit}
+	}
+	return nil
+}
+
+// Exhausted reports whether nothing more of kind can be granted.
+func (m *Manager) Exhausted(kind Kind) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.exhaustedErr(kind) != nil
+}
+
+// Limited reports whether any budget has a limit.
+func (m *Manager) Limited() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.limits) > 0
+}
+
+// Usage returns the consumption of every budget in Kinds order.
+func (m *Manager) Usage() []Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	usage := make([]Usage, 0, len(Kinds))
+	for _, kind := range Kinds {
+		usage = append(usage, Usage{Kind: kind, Used: m.used[kind], Limit: m.limits[kind]})
+	}
+	return usage
+}
+
+// Reader counts the bytes read from r against the Bytes budget. Reads fail
+// with an ExhaustedError once the budget is exceeded.
+func (m *Manager) Reader(r io.ReadCloser) io.ReadCloser {
+	return &countingReader{ReadCloser: r, manager: m}
+}
+
+type countingReader struct {
+	io.ReadCloser
+	manager *Manager
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		if budgetErr := r.manager.Record(Bytes, int64(n)); budgetErr != nil {
+			return n, budgetErr
+		}
+	}
+	return n, err
+}
+
+// exhaustedErr returns the budget that keeps kind from being granted (nil if
+// it can be). HTTP requests are refused as well once the byte budget is
+// spent: every request downloads a response. Must be called with mu held.
+func (m *Manager) exhaustedErr(kind Kind) error {
+	if limit, ok := m.limits[kind]; ok && m.used[kind] >= limit {
+		return &ExhaustedError{Kind: kind, Limit: limit}
+	}
+	if kind == Requests {
+		if limit, ok := m.limits[Bytes]; ok && m.used[Bytes] >= limit {
+			return &ExhaustedError{Kind: Bytes, Limit: limit}
+		}
+	}
+	return nil
+}
+
+// ParseLimits parses "<kind>=<limit>" entries, e.g. "requests=5000",
+// "dns=20000" or "bytes=500MB" (sizes as in --memory-budget, without %).
+func ParseLimits(entries []string) (map[Kind]int64, error) {
+	limits := make(map[Kind]int64, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		kind := Kind(strings.ToLower(strings.TrimSpace(name)))
+		value = strings.TrimSpace(value)
+		if !ok || !validKind(kind) {
+			return nil, fmt.Errorf("invalid budget %q (use <kind>=<limit>, kind one of %s)", entry, kindNames())
+		}
+
+		var limit int64
+		var err error
+		if kind == Bytes {
+			if strings.HasSuffix(value, "%") {
+				return nil, fmt.Errorf("invalid budget %q: bytes takes a size such as 500MB", entry)
+			}
+			limit, err = adaptive.ParseMemoryBudget(value)
+		} else {
+			limit, err = strconv.ParseInt(value, 10, 64)
+		}
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("invalid budget %q: limit must be positive", entry)
+		}
+		limits[kind] = limit
+	}
+	return limits, nil
+}
+
+func validKind(kind Kind) bool {
+	for _, k := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func kindNames() string {
+	names := make([]string, 0, len(Kinds))
+	for _, k := range Kinds {
+		names = append(names, string(k))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,69 @@
+package budget
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"aethonx/internal/testutil"
+)
+
+func TestManager_ReserveAndTake(t *testing.T) {
+	m := NewManager()
+	testutil.AssertEqual(t, m.Reserve(Requests, 100), int64(100), "no limit grants everything")
+
+	m.Configure(map[Kind]int64{Requests: 150, DNSQueries: 10})
+	testutil.AssertEqual(t, m.Reserve(Requests, 40), int64(40), "fits")
+	testutil.AssertEqual(t, m.Reserve(Requests, 40), int64(10), "trimmed to what is left")
+	testutil.AssertEqual(t, m.Reserve(Requests, 1), int64(0), "nothing left")
+	testutil.AssertTrue(t, m.Exhausted(Requests), "requests exhausted")
+
+	testutil.AssertNoError(t, m.Take(DNSQueries, 8), "take within limit")
+	err := m.Take(DNSQueries, 3)
+	testutil.AssertTrue(t, errors.Is(err, ErrExhausted), "take is all-or-nothing")
+	testutil.AssertNoError(t, m.Take(DNSQueries, 2), "the failed take consumed nothing")
+
+	m.Reset()
+	testutil.AssertFalse(t, m.Exhausted(Requests), "reset clears consumption")
+}
+
+func TestManager_BytesBlockRequests(t *testing.T) {
+	m := NewManager()
+	m.Configure(map[Kind]int64{Bytes: 10})
+
+	body := m.Reader(io.NopCloser(strings.NewReader(strings.Repeat("x", 64))))
+	_, err := io.ReadAll(body)
+	var exhausted *ExhaustedError
+	testutil.AssertTrue(t, errors.As(err, &exhausted), "reading past the byte budget fails")
+	testutil.AssertEqual(t, exhausted.Kind, Bytes, "bytes budget")
+
+	err = m.Take(Requests, 1)
+	testutil.AssertTrue(t, errors.Is(err, ErrExhausted), "no more requests once bytes are spent")
+	testutil.AssertEqual(t, m.Reserve(Requests, 5), int64(0), "reserve refused as well")
+}
+
+func TestManager_Usage(t *testing.T) {
+	m := NewManager()
+	m.Configure(map[Kind]int64{Requests: 5})
+	m.Reserve(Requests, 3)
+	_ = m.Record(Bytes, 2048)
+
+	usage := m.Usage()
+	testutil.AssertEqual(t, len(usage), 3, "one entry per kind")
+	testutil.AssertEqual(t, usage[0], Usage{Kind: Requests, Used: 3, Limit: 5}, "requests")
+	testutil.AssertEqual(t, usage[2], Usage{Kind: Bytes, Used: 2048}, "bytes without limit")
+}
+
+func TestParseLimits(t *testing.T) {
+	limits, err := ParseLimits([]string{"requests=5000", " DNS = 20000", "bytes=1MB"})
+	testutil.AssertNoError(t, err, "valid limits")
+	testutil.AssertEqual(t, limits[Requests], int64(5000), "requests")
+	testutil.AssertEqual(t, limits[DNSQueries], int64(20000), "dns")
+	testutil.AssertTrue(t, limits[Bytes] >= 1000*1000, "bytes parsed as a size")
+
+	for _, bad := range []string{"requests", "packets=10", "requests=0", "dns=lots", "bytes=25%"} {
+		_, err := ParseLimits([]string{bad})
+		testutil.AssertError(t, err, bad)
+	}
+}
@@ -0,0 +1,200 @@
+// Package compress wraps output files in gzip or zstd.
+//
+// gzip uses the standard library. zstd is delegated to the zstd binary (as
+// encryption is delegated to age/gpg), so files stay readable with the
+// standard tools: zstd -d results.json.zst, zcat results.json.gz.
+// Readers detect the format from the magic bytes, so callers can open
+// compressed and plain files alike.
+package compress
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Codec is a compression format.
+type Codec string
+
+const (
+	None Codec = ""
+	Gzip Codec = "gzip"
+	Zstd Codec = "zstd"
+)
+
+// Codecs lists the valid values for --compress.
+var Codecs = []string{"none", string(Gzip), string(Zstd)}
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// Parse parses a codec name: none (or ""), gzip/gz, zstd/zst. zstd requires
+// the zstd binary in PATH.
+func Parse(name string) (Codec, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "none":
+		return None, nil
+	case "gzip", "gz":
+		return Gzip, nil
+	case "zstd", "zst":
+		if _, err := exec.LookPath("zstd"); err != nil {
+			return None, fmt.Errorf("zstd compression requires the zstd binary in PATH: %w", err)
+		}
+		return Zstd, nil
+	}
+	return None, fmt.Errorf("invalid compression %q (valid: %s)", name, strings.Join(Codecs, ", "))
+}
+
+// Extension returns the file extension of the codec (".gz", ".zst", "").
+func (c Codec) Extension() string {
+	switch c {
+	case Gzip:
+		return ".gz"
+	case Zstd:
+		return ".zst"
+	}
+	return ""
+}
+
+// NewWriter compresses everything written to the returned writer into w.
+// Close flushes the compressed stream; it does not close w.
+func NewWriter(w io.Writer, c Codec) (io.WriteCloser, error) {
+	switch c {
+	case None:
+		return nopWriteCloser{w}, nil
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		return startZstd(w, nil, "-q", "-c", "-T0")
+	}
+	return nil, fmt.Errorf("unknown compression %q", c)
+}
+
+// NewReader returns a reader of the decompressed content of r, detecting
+// gzip and zstd from their magic bytes. Plain content is returned as is.
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(len(zstdMagic))
+
+	switch {
+	case bytes.HasPrefix(head, gzipMagic):
+		return gzip.NewReader(br)
+	case bytes.HasPrefix(head, zstdMagic):
+		pr, pw := io.Pipe()
+		cmd, err := startZstd(pw, br, "-q", "-d", "-c")
+		if err != nil {
+			return nil, err
+		}
+		go func() {
+			pw.CloseWithError(cmd.Close())
+		}()
+		return pr, nil
+	}
+	return io.NopCloser(br), nil
+}
+
+// Open opens path for reading, decompressing it if needed.
+func Open(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return readCloser{Reader: r, closers: []io.Closer{r, f}}, nil
+}
+
+// ReadFile reads path, decompressing it if needed.
+func ReadFile(path string) ([]byte, error) {
+	r, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// TrimExtension removes a compression extension (".gz", ".zst") from name.
+func TrimExtension(name string) string {
+	for _, c := range []Codec{Gzip, Zstd} {
+		if trimmed, ok := strings.CutSuffix(name, c.Extension()); ok {
+			return trimmed
+		}
+	}
+	return name
+}
+
+// zstdProcess runs the zstd binary between stdin and stdout. Writes go to
+// its stdin; Close waits for it to finish.
+type zstdProcess struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stderr bytes.Buffer
+}
+
+func startZstd(stdout io.Writer, stdin io.Reader, args ...string) (*zstdProcess, error) {
+	bin, err := exec.LookPath("zstd")
+	if err != nil {
+		return nil, fmt.Errorf("zstd compression requires the zstd binary in PATH: %w", err)
+	}
+
+	p := &zstdProcess{cmd: exec.Command(bin, args...)}
+	p.cmd.Stdout = stdout
+	p.cmd.Stderr = &p.stderr
+	if stdin != nil {
+		p.cmd.Stdin = stdin
+	} else if p.stdin, err = p.cmd.StdinPipe(); err != nil {
+		return nil, err
+	}
+
+	if err := p.cmd.Start(); err != nil {
+		return nil, fmt.Errorf("zstd: %w", err)
+	}
+	return p, nil
+}
+
+func (p *zstdProcess) Write(b []byte) (int, error) {
+	return p.stdin.Write(b)
+}
+
+func (p *zstdProcess) Close() error {
+	if p.stdin != nil {
+		p.stdin.Close()
+	}
+	if err := p.cmd.Wait(); err != nil {
+		if msg := strings.TrimSpace(p.stderr.String()); msg != "" {
+			return fmt.Errorf("zstd failed: %s", msg)
+		}
+		return fmt.Errorf("zstd failed: %w", err)
+	}
+	return nil
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+type readCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r readCloser) Close() error {
+	var first error
+	for _, c := range r.closers {
+		if err := c.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
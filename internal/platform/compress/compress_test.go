@@ -0,0 +1,70 @@
+package compress
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"aethonx/internal/testutil"
+)
+
+func TestRoundTrip(t *testing.T) {
+	content := []byte(strings.Repeat(`{"type":"url","value":"https://example.com/a"}`+"\n", 1000))
+
+	codecs := []Codec{None, Gzip}
+	if _, err := exec.LookPath("zstd"); err == nil {
+		codecs = append(codecs, Zstd)
+	}
+
+	for _, c := range codecs {
+		name := string(c)
+		if c == None {
+			name = "none"
+		}
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, c)
+			testutil.AssertNoError(t, err, "new writer")
+			_, err = w.Write(content)
+			testutil.AssertNoError(t, err, "write")
+			testutil.AssertNoError(t, w.Close(), "close")
+
+			if c != None {
+				testutil.AssertTrue(t, buf.Len() < len(content)/4, "content is compressed")
+			}
+
+			path := filepath.Join(t.TempDir(), "results.json"+c.Extension())
+			testutil.AssertNoError(t, os.WriteFile(path, buf.Bytes(), 0o644), "write file")
+
+			got, err := ReadFile(path)
+			testutil.AssertNoError(t, err, "read file")
+			testutil.AssertTrue(t, bytes.Equal(got, content), "decompressed content matches")
+		})
+	}
+}
+
+func TestNewReader_PlainContent(t *testing.T) {
+	r, err := NewReader(strings.NewReader("{}"))
+	testutil.AssertNoError(t, err, "plain reader")
+	data, _ := io.ReadAll(r)
+	testutil.AssertEqual(t, string(data), "{}", "plain content is returned as is")
+}
+
+func TestParse(t *testing.T) {
+	for in, want := range map[string]Codec{"": None, "none": None, "GZIP": Gzip, "gz": Gzip} {
+		got, err := Parse(in)
+		testutil.AssertNoError(t, err, "valid codec "+in)
+		testutil.AssertEqual(t, got, want, "codec for "+in)
+	}
+
+	_, err := Parse("brotli")
+	testutil.AssertError(t, err, "unknown codec")
+
+	testutil.AssertEqual(t, TrimExtension("scan.json.zst"), "scan.json", "trim .zst")
+	testutil.AssertEqual(t, TrimExtension("scan.json.gz"), "scan.json", "trim .gz")
+	testutil.AssertEqual(t, TrimExtension("scan.json"), "scan.json", "no extension")
+}
@@ -5,11 +5,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"aethonx/internal/core/domain"
 	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/adaptive"
+	"aethonx/internal/platform/browser"
+	"aethonx/internal/platform/budget"
+	"aethonx/internal/platform/compress"
+	"aethonx/internal/platform/oob"
+	"aethonx/internal/platform/politeness"
+	"aethonx/internal/platform/rate"
+	"aethonx/internal/platform/registry"
+	"aethonx/internal/platform/validator"
 
 	"github.com/spf13/pflag"
 )
@@ -22,6 +33,14 @@ type Config struct {
 	Streaming  StreamingConfig
 	Resilience ResilienceConfig
 	Network    NetworkConfig
+	Browser    BrowserConfig
+	OOB        OOBConfig
+	Tagging    TaggingConfig
+	Lifecycle  LifecycleConfig
+	Noise      NoiseConfig
+	Notify     NotifyConfig
+	Agents     AgentsConfig
+	Export     ExportConfig
 }
 
 // CoreConfig contains fundamental scan parameters.
@@ -30,6 +49,76 @@ type CoreConfig struct {
 	Active   bool   // Enable active reconnaissance mode
 	Workers  int    // Number of concurrent workers
 	TimeoutS int    // Global timeout in seconds (0 = no timeout)
+
+	// ProviderConcurrency caps the requests in flight to an upstream provider
+	// shared by several sources of a stage (e.g. two sources both backed by
+	// api.shodan.io); their requests take turns instead of racing.
+	ProviderConcurrency int
+
+	// Normalization is the domain normalization policy: strict (default) keeps
+	// www.example.com as its own subdomain, aggressive collapses it into example.com.
+	Normalization string
+
+	// Workspace is the active workspace name ("" = none). See internal/platform/workspace.
+	Workspace string
+
+	// ConfigFile is the YAML config file with per-source settings ("" = none).
+	ConfigFile string
+
+	// ExcludeDomains are out-of-scope domains (and their subdomains), e.g. from
+	// the workspace scope file.
+	ExcludeDomains []string
+
+	// NoPivot disables pivot sources, which discover assets outside the target
+	// (e.g. other domains of the same registrant).
+	NoPivot bool
+
+	// SkipStages lists scan phases whose sources never run (verification,
+	// enrichment), e.g. for a fast discovery-only run with the same config.
+	SkipStages []string
+
+	// Plan prints the stages the scan would run (and the skipped ones) and
+	// exits without scanning.
+	Plan bool
+
+	// CriticalStages are stage IDs (0 = first stage) in which any source
+	// failure aborts the pipeline instead of continuing (fail-fast). Single
+	// sources are marked with SourceConfig.Critical.
+	CriticalStages []int
+
+	// Imports are output files of other tools fused into the scan without
+	// rescanning (nmap XML). Each file is routed to its import source.
+	Imports []string
+
+	// PreviousScan is a prior scan of the target ("latest" = newest complete
+	// scan in the output directory) whose assets are re-injected as
+	// historical so verification stages re-check them.
+	PreviousScan string
+
+	// FailOn lists the scan outcomes that produce a non-zero exit code:
+	// source-error, timeout, empty, new-risk[=<severity>] or none.
+	FailOn []string
+
+	// MaxArtifacts and MaxDuration are hard caps after which a source (or the
+	// whole scan) is stopped and the result marked truncated. Each entry is
+	// either a bare value for the whole scan or "<source>=<value>" ("*" for
+	// every source). See ScanLimits.
+	MaxArtifacts []string
+	MaxDuration  []string
+
+	// StageTimeout and StageWorkers override the per-stage timeout (-T) and
+	// the worker count for a single stage. Entries are "<stage>=<value>" with
+	// the stage ID as logged (0 = first stage).
+	StageTimeout []string
+	StageWorkers []string
+
+	// Sample stops every source after this many artifacts (0 = off) to preview
+	// what a scan would find. It caps like a per-source --max-artifacts.
+	Sample int
+
+	// Profile is the scan depth preset (quick, standard, deep) applied on top
+	// of the defaults; the config file, ENV and flags override it. See Profiles.
+	Profile string
 }
 
 // SourceConfig contains source-specific configurations.
@@ -47,11 +136,105 @@ type OutputConfig struct {
 	LogFormat   string // Log format for raw mode: text (default), json
 	ShowMetrics bool   // Show system metrics (CPU, memory, etc.)
 	ShowPhases  bool   // Show execution phases for each source
+
+	// Verbosity controls console logging: Quiet (-q) only errors and no visual UI,
+	// Verbosity 1 (-v) info logs, 2 (-vv) debug logs. Per-component levels come
+	// from AETHONX_LOG_LEVEL (e.g., "warn,crtsh=debug").
+	Quiet     bool
+	Verbosity int
+
+	// ProgressFormat emits machine-readable progress events on stderr: "" (off), json
+	ProgressFormat string
+
+	// File logging (independent of console/UI mode)
+	LogFile       string        // Log file path ("" = disabled)
+	LogFileFormat string        // Log file format: json (default), text
+	LogFileLevel  string        // Log file level: debug (default), info, warn, error
+	LogMaxSizeMB  int           // Rotate when file exceeds this size in MB (0 = no limit)
+	LogMaxAge     time.Duration // Rotate when file is older than this (0 = no limit)
+	LogMaxBackups int           // Rotated files to keep (0 = keep all)
+
+	// Output filters: restrict what is written to exports and the table.
+	// The consolidated JSON always keeps the full dataset.
+	OnlyTypes     []string // Artifact types to export (empty = all)
+	OnlyAlive     bool     // Export only artifacts that answered a probe
+	MinConfidence float64  // Minimum confidence to export (0 = no filter)
+	Tags          []string // Export artifacts carrying any of these tags
+
+	// IncludeProvenance records per-source provenance (raw value,
+	// timestamp, query) during the scan and keeps it in the JSON outputs.
+	IncludeProvenance bool
+
+	// MinRelationConfidence drops relations below this confidence before export
+	// (0 = keep all). Relations to deduped/suppressed artifacts are always pruned.
+	MinRelationConfidence float64
+
+	// SigningKey is an ed25519 private key (PEM or base64) used to sign the
+	// consolidated JSON (<file>.sig). Empty = unsigned.
+	SigningKey string
+
+	// Encrypt writes JSON outputs encrypted with "age" or "gpg" ("" = plaintext)
+	// for EncryptTo recipients (keys or recipient files).
+	Encrypt   string
+	EncryptTo []string
+
+	// Compress writes the JSON outputs and streaming partials compressed with
+	// "gzip" or "zstd" (.json.gz / .json.zst). "" or "none" = plain JSON.
+	Compress string
+
+	// FilenameTemplate names the output files (without extension) inside the
+	// target directory. Variables: {target}, {date}, {scan_id}, {format}.
+	// Without {format} each output keeps its suffix (_filtered, _report, ...).
+	// "" = "aethonx_{target}_{date}".
+	FilenameTemplate string
+
+	// Stdout also writes the consolidated JSON to stdout (one line, for
+	// piping). It implies Quiet so stdout carries nothing else.
+	Stdout bool
+
+	// Shard writes the consolidated JSON as a directory with one file per
+	// artifact type plus an index.json manifest (counts, files, SHA-256), so
+	// consumers only read the types they need.
+	Shard bool
+
+	// PDFReport also writes a client-ready PDF report (cover, charts, top risks,
+	// appendix tables) next to the JSON.
+	PDFReport bool
+
+	// Parquet also writes the exported artifacts as Apache Parquet (typed
+	// columns, flattened metadata) for DuckDB/Athena/Spark pipelines.
+	Parquet bool
+
+	// Burp also writes the target scope as Burp Suite project options
+	// (<file>_burp_scope.json) and ZAP as an OWASP ZAP context
+	// (<file>_zap.context). Either one writes the alive URLs (<file>_urls.txt)
+	// for importing into the proxy site map.
+	Burp bool
+	ZAP  bool
+
+	// Aquatone also writes the alive hosts (<file>_hosts.txt) and EyeWitness
+	// the alive URLs (<file>_urls.txt), the inputs of these screenshot tools.
+	Aquatone   bool
+	EyeWitness bool
+
+	// CaptureRaw archives the raw source outputs (httpx JSONL, amass database,
+	// crt.sh records) in <dir>/raw/<scan>/ so "aethonx replay" can rebuild the
+	// scan without querying anything again.
+	CaptureRaw bool
+
+	// Redact selects redaction profiles: "<profile>" for every output format or
+	// "<format>=<profile>" for one (see RedactionFormats). Default: full.
+	Redact []string
 }
 
 // StreamingConfig contains memory management settings.
 type StreamingConfig struct {
 	ArtifactThreshold int // Artifact count threshold for partial disk writes
+
+	// MemoryBudget also triggers partial disk writes when the artifacts held in
+	// memory exceed an approximate size: "512MB", "2GB" or "25%" of system RAM.
+	// Empty = count threshold only.
+	MemoryBudget string
 }
 
 // ResilienceConfig contains fault tolerance settings.
@@ -71,35 +254,180 @@ type ResilienceConfig struct {
 // NetworkConfig contains network-related settings.
 type NetworkConfig struct {
 	ProxyURL string // HTTP(S) proxy URL for outbound requests
+
+	// UpstreamRates override the shared per-upstream budgets every source and
+	// concurrent scan draw from: "<upstream>=<rps>[/<burst>]", e.g. "crt.sh=1".
+	UpstreamRates []string
+
+	// Budget caps the traffic active sources send to the target:
+	// "<kind>=<limit>" with kind requests, dns or bytes, e.g. "requests=5000".
+	Budget []string
+
+	// HostConcurrency and HostDelay limit active probing per destination host:
+	// a bare value for every host or "<domain>=<value>" for a scope entry
+	// (the domain and its subdomains), e.g. "4", "legacy.example.com=1", "1s".
+	HostConcurrency []string
+	HostDelay       []string
+}
+
+// BrowserConfig contains the headless browser shared by screenshot and
+// rendering sources.
+type BrowserConfig struct {
+	ExecPath    string        // Chrome/Chromium binary ("" = look up in PATH)
+	MaxTabs     int           // Pages open at once across all sources
+	PageTimeout time.Duration // Max time per page
+}
+
+// OOBConfig contains the interactsh server of out-of-band callbacks used by
+// active checks (takeover validation, SSRF canaries).
+type OOBConfig struct {
+	Server string        // interactsh server URL ("" = disabled)
+	Token  string        // Authorization token of a self-hosted server
+	Wait   time.Duration // Grace period for late callbacks after the scan
+}
+
+// LifecycleConfig contains first_seen/last_seen tracking across scans (monitor mode).
+type LifecycleConfig struct {
+	Enabled     bool // Persist per-artifact lifecycle state in the output directory
+	StaleAfter  int  // Consecutive missed scans before an artifact is stale
+	RemoveAfter int  // Consecutive missed scans before an artifact is removed
+
+	// CertAlertDays are the days-until-expiry thresholds that fire a
+	// certificate.expiring notification, once per threshold and certificate
+	CertAlertDays []int
+
+	// ActiveWindow restricts active stages to a daily window ("22:00-06:00")
+	// in ActiveWindowTZ (IANA name, "" = local time); passive stages run anytime
+	ActiveWindow   string
+	ActiveWindowTZ string
+}
+
+// NoiseConfig contains third-party noise suppression settings.
+type NoiseConfig struct {
+	Suppress    bool // Drop hosts whose eTLD+1 differs from the target unless linked via CNAME/cert SAN
+	ExcludeApex bool // Drop the apex domain artifact itself, keeping only its subdomains
+}
+
+// NotifyConfig contains the observers that receive scan events and the spool
+// that keeps the events they did not get.
+type NotifyConfig struct {
+	Webhooks []string // URLs that receive every event as a JSON POST
+
+	// SpoolDir keeps undelivered events ("" = dropped) and replays them to
+	// their webhook at the start of the next scan.
+	SpoolDir string
+}
+
+// AgentsConfig contains the remote worker agents of a distributed scan.
+// Sources run on the agents that have them (see "aethonx agent"); the rest,
+// and any source whose agent is unreachable, run locally.
+type AgentsConfig struct {
+	URLs     []string // Agent endpoints (https://host:port); empty = local scan
+	CAFile   string   // CA that signed the agent certificates ("" = system roots)
+	CertFile string   // Client certificate for mutual TLS (optional)
+	KeyFile  string   // Client certificate key
+	Token    string   // Bearer token shared with the agents (optional)
+}
+
+// ExportConfig contains the vulnerability-management platforms the findings
+// of each scan are imported into after the outputs are written. The product,
+// engagement and workspace mapping usually lives in the workspace config.env.
+type ExportConfig struct {
+	MinSeverity string // Lowest severity exported: low (default), medium, high, critical
+
+	DefectDojoURL         string // DefectDojo base URL ("" = disabled)
+	DefectDojoToken       string // API v2 key (AETHONX_DEFECTDOJO_TOKEN)
+	DefectDojoProduct     string // Product the findings are imported into
+	DefectDojoProductType string // Product type, needed to create a missing product
+	DefectDojoEngagement  string // Engagement ("" = "AethonX recon"), created if missing
+
+	FaradayURL       string // Faraday base URL ("" = disabled)
+	FaradayToken     string // API token (AETHONX_FARADAY_TOKEN)
+	FaradayWorkspace string // Existing Faraday workspace
+
+	// Jira tickets for new findings (requires lifecycle tracking)
+	JiraURL         string   // Jira base URL ("" = disabled)
+	JiraUser        string   // Account email for Jira Cloud ("" = token is a PAT)
+	JiraToken       string   // API token or PAT (AETHONX_JIRA_TOKEN)
+	JiraProject     string   // Project key the tickets are opened in
+	JiraIssueType   string   // Issue type (default: Task)
+	JiraLabels      []string // Extra labels for every ticket
+	JiraMinSeverity string   // Lowest severity that opens a ticket (default: high)
+
+	ReportURL string // Base URL where the output directory is published (evidence links)
 }
 
 // DefaultConfig returns a default configuration organized by categories.
 func DefaultConfig() Config {
 	return Config{
 		Core: CoreConfig{
-			Target:   "",
-			Active:   false,
-			Workers:  16,
-			TimeoutS: 30,
+			Target:        "",
+			Active:        false,
+			Workers:       16,
+			TimeoutS:      30,
+			Normalization: "strict",
+			FailOn:        []string{"source-error", "timeout", "empty"},
+			Profile:       DefaultProfile,
+
+			ProviderConcurrency: 1,
 		},
 
 		Source: SourceConfig{
 			Sources: map[string]ports.SourceConfig{
 				"crtsh": {
-					Enabled:   true,
-					Timeout:   30 * time.Second,
-					Retries:   2,
-					RateLimit: 0,
-					Priority:  10,
-					Custom:    make(map[string]interface{}),
+					Enabled:          true,
+					Timeout:          30 * time.Second,
+					Retries:          2,
+					RateLimit:        0,
+					Priority:         10,
+					NegativeCacheTTL: time.Hour, // Dominios sin certificados
+					Custom:           make(map[string]interface{}),
 				},
 				"rdap": {
+					Enabled:          true,
+					Timeout:          30 * time.Second,
+					Retries:          2,
+					RateLimit:        0,
+					Priority:         8,
+					NegativeCacheTTL: time.Hour, // Dominios sin RDAP (404)
+					Custom:           make(map[string]interface{}),
+				},
+				"dns": {
 					Enabled:   true,
-					Timeout:   30 * time.Second,
+					Timeout:   60 * time.Second,
 					Retries:   2,
 					RateLimit: 0,
-					Priority:  8,
-					Custom:    make(map[string]interface{}),
+					Priority:  12,
+					Custom: map[string]interface{}{
+						"workers":      20,
+						"resolver":     "", // host:port; vacío = resolver del sistema
+						"zone_checks":  true,
+						"mail_records": true,
+					},
+				},
+				"asnexpand": {
+					Enabled:   false, // Disabled by default (expands every announced prefix)
+					Timeout:   120 * time.Second,
+					Retries:   1,
+					RateLimit: 0,
+					Priority:  9,
+					Custom: map[string]interface{}{
+						"provider":             "ripestat",
+						"max_asns":             10,
+						"max_prefixes_per_asn": 500,
+					},
+				},
+				"pdns": {
+					Enabled:   false, // Disabled by default (public APIs with daily quota)
+					Timeout:   180 * time.Second,
+					Retries:   1,
+					RateLimit: 0,
+					Priority:  11,
+					Custom: map[string]interface{}{
+						"providers":  []string{"mnemonic", "circl"}, // circl only with credentials
+						"rate_limit": 1.0,
+						"max_hosts":  100,
+					},
 				},
 				"subfinder": {
 					Enabled:   true,
@@ -153,6 +481,94 @@ func DefaultConfig() Config {
 						"exec_path":  "waybackurls",
 					},
 				},
+				"katana": {
+					Enabled:   false, // Disabled by default (active crawling)
+					Timeout:   300 * time.Second,
+					Retries:   1,
+					RateLimit: 0,
+					Priority:  20, // After httpx confirms alive URLs
+					Custom: map[string]interface{}{
+						"depth":      3,
+						"rate_limit": 150,
+						"exec_path":  "katana",
+					},
+				},
+				"masscan": {
+					Enabled:   false, // Opt-in: needs --active, root/CAP_NET_RAW and allowed_ranges
+					Timeout:   30 * time.Minute,
+					Retries:   0,
+					RateLimit: 0,
+					Priority:  14, // After asnexpand emits the CIDRs, before httpx
+					Custom: map[string]interface{}{
+						"exec_path":      "masscan",
+						"ports":          "80,443,8000,8080,8443,8888,9443",
+						"rate":           300,
+						"allowed_ranges": []string{}, // Only CIDRs inside these ranges are scanned
+						"exclude_ranges": []string{},
+						"max_addresses":  65536,
+						"wait":           5,
+					},
+				},
+				"reversewhois": {
+					Enabled:   false, // Opt-in pivot source (requires API key, paid queries)
+					Timeout:   120 * time.Second,
+					Retries:   0,
+					RateLimit: 0,
+					Priority:  6, // After rdap extracts the registrant
+					Custom: map[string]interface{}{
+						"provider":  "whoisxml",
+						"api_key":   "", // Must be set via env or config
+						"max_terms": 5,
+					},
+				},
+				"axfr": {
+					Enabled:   true, // Active only: runs with --active
+					Timeout:   60 * time.Second,
+					Retries:   0,
+					RateLimit: 0,
+					Priority:  9, // After rdap extracts the nameservers
+					Custom: map[string]interface{}{
+						"port":    53,
+						"timeout": "10s",
+					},
+				},
+				"robots": {
+					Enabled:   false, // Disabled by default (active requests to the target)
+					Timeout:   120 * time.Second,
+					Retries:   1,
+					RateLimit: 0,
+					Priority:  18, // After httpx confirms alive hosts
+					Custom: map[string]interface{}{
+						"workers":    10,
+						"rate_limit": 10.0,
+					},
+				},
+				"tlsgrade": {
+					Enabled:   true, // Active only: runs with --active
+					Timeout:   300 * time.Second,
+					Retries:   0,
+					RateLimit: 0,
+					Priority:  19, // After httpx confirms alive hosts
+					Custom: map[string]interface{}{
+						"workers":      10,
+						"max_hosts":    100,
+						"timeout":      "5s",
+						"weak_ciphers": true,
+					},
+				},
+				"disclosure": {
+					Enabled:   true, // Passive: one public file per alive host and CAA lookups
+					Timeout:   120 * time.Second,
+					Retries:   1,
+					RateLimit: 0,
+					Priority:  19, // After httpx confirms alive hosts
+					Custom: map[string]interface{}{
+						"workers":      5,
+						"max_hosts":    50,
+						"caa":          true,
+						"security_txt": true,
+					},
+				},
 				"shodan": {
 					Enabled:   false, // Disabled by default (requires API key)
 					Timeout:   60 * time.Second,
@@ -165,6 +581,38 @@ func DefaultConfig() Config {
 						"rate_limit": 1.0,   // Requests per second
 					},
 				},
+				"nmapimport": {
+					Enabled:   false, // Enabled by --import with nmap XML files
+					Timeout:   120 * time.Second,
+					Retries:   0,
+					RateLimit: 0,
+					Priority:  12,
+					Custom: map[string]interface{}{
+						"files":     []string{},
+						"open_only": true,
+					},
+				},
+				"screenshotimport": {
+					Enabled:   false, // Enabled by --import with Aquatone/EyeWitness results
+					Timeout:   60 * time.Second,
+					Retries:   0,
+					RateLimit: 0,
+					Priority:  12,
+					Custom: map[string]interface{}{
+						"files": []string{},
+					},
+				},
+				"previousscan": {
+					Enabled:   false, // Enabled by --previous-scan or --import with an AethonX scan
+					Timeout:   60 * time.Second,
+					Retries:   0,
+					RateLimit: 0,
+					Priority:  11,
+					Custom: map[string]interface{}{
+						"files":      []string{},
+						"confidence": 0.5,
+					},
+				},
 			},
 		},
 
@@ -174,6 +622,13 @@ func DefaultConfig() Config {
 			LogFormat:   "text",
 			ShowMetrics: false,
 			ShowPhases:  false,
+
+			LogFile:       "",
+			LogFileFormat: "json",
+			LogFileLevel:  "debug",
+			LogMaxSizeMB:  50,
+			LogMaxAge:     24 * time.Hour,
+			LogMaxBackups: 5,
 		},
 
 		Streaming: StreamingConfig{
@@ -193,6 +648,32 @@ func DefaultConfig() Config {
 		Network: NetworkConfig{
 			ProxyURL: "",
 		},
+
+		Browser: BrowserConfig{
+			MaxTabs:     browser.DefaultMaxTabs,
+			PageTimeout: browser.DefaultPageTimeout,
+		},
+
+		OOB: OOBConfig{
+			Wait: oob.DefaultWait,
+		},
+
+		Lifecycle: LifecycleConfig{
+			Enabled:     false,
+			StaleAfter:    1,
+			RemoveAfter:   3,
+			CertAlertDays: []int{30, 14, 7, 1},
+		},
+
+		Noise: NoiseConfig{
+			Suppress:    true,
+			ExcludeApex: false,
+		},
+
+		Export: ExportConfig{
+			JiraIssueType:   "Task",
+			JiraMinSeverity: "high",
+		},
 	}
 }
 
@@ -200,18 +681,68 @@ func DefaultConfig() Config {
 func Load(version, commit, date string) (Config, error) {
 	cfg := DefaultConfig()
 
+	// Workspace config.env sits between defaults and ENV
+	ws, err := loadActiveWorkspace()
+	if err != nil {
+		return cfg, err
+	}
+
+	// The profile preset is the base every other layer overrides
+	if err := applyProfile(&cfg, profileName(os.Args[1:])); err != nil {
+		return cfg, err
+	}
+
+	// YAML config file sits between defaults/workspace and ENV
+	if path := configFileName(os.Args[1:]); path != "" {
+		if err := applyConfigFile(&cfg, path); err != nil {
+			return cfg, err
+		}
+	}
+
 	// Load from ENV
 	loadFromEnv(&cfg)
 
 	// Parse flags (overrides ENV)
 	loadFromFlags(&cfg, version, commit, date)
 
+	if ws != nil {
+		if err := applyWorkspace(&cfg, ws); err != nil {
+			return cfg, err
+		}
+	}
+
 	// Normalize
 	normalize(&cfg)
 
+	if err := loadTagRules(&cfg); err != nil {
+		return cfg, err
+	}
+
 	return cfg, nil
 }
 
+// FromEnv returns defaults overridden by ENV only (no flag parsing).
+// Used by subcommands that define their own flag set (e.g., "aethonx serve").
+func FromEnv() Config {
+	cfg := DefaultConfig()
+	loadFromEnv(&cfg)
+	normalize(&cfg)
+	return cfg
+}
+
+// loadTagRules loads tagging rules when a rules file is configured.
+func loadTagRules(cfg *Config) error {
+	if cfg.Tagging.RulesFile == "" {
+		return nil
+	}
+	rules, err := LoadTagRules(cfg.Tagging.RulesFile)
+	if err != nil {
+		return err
+	}
+	cfg.Tagging.Rules = rules
+	return nil
+}
+
 // loadFromEnv loads configuration from environment variables.
 func loadFromEnv(cfg *Config) {
 	// === CORE CONFIG ===
@@ -224,9 +755,48 @@ func loadFromEnv(cfg *Config) {
 	if v := getenv("AETHONX_WORKERS", ""); v != "" {
 		cfg.Core.Workers = parseInt(v, cfg.Core.Workers)
 	}
+	if v := getenv("AETHONX_PROVIDER_CONCURRENCY", ""); v != "" {
+		cfg.Core.ProviderConcurrency = parseInt(v, cfg.Core.ProviderConcurrency)
+	}
 	if v := getenv("AETHONX_TIMEOUT", ""); v != "" {
 		cfg.Core.TimeoutS = parseInt(v, cfg.Core.TimeoutS)
 	}
+	if v := getenv("AETHONX_NORMALIZATION", ""); v != "" {
+		cfg.Core.Normalization = v
+	}
+	if v := getenv("AETHONX_NO_PIVOT", ""); v != "" {
+		cfg.Core.NoPivot = parseBool(v)
+	}
+	if v := getenv("AETHONX_SKIP_STAGES", ""); v != "" {
+		cfg.Core.SkipStages = parseCSV(v)
+	}
+	if v := getenv("AETHONX_CRITICAL_STAGES", ""); v != "" {
+		cfg.Core.CriticalStages = parseIntList(v)
+	}
+	if v := getenv("AETHONX_IMPORT", ""); v != "" {
+		cfg.Core.Imports = parseCSV(v)
+	}
+	if v := getenv("AETHONX_PREVIOUS_SCAN", ""); v != "" {
+		cfg.Core.PreviousScan = v
+	}
+	if v := getenv("AETHONX_FAIL_ON", ""); v != "" {
+		cfg.Core.FailOn = parseCSV(v)
+	}
+	if v := getenv("AETHONX_MAX_ARTIFACTS", ""); v != "" {
+		cfg.Core.MaxArtifacts = parseCSV(v)
+	}
+	if v := getenv("AETHONX_MAX_DURATION", ""); v != "" {
+		cfg.Core.MaxDuration = parseCSV(v)
+	}
+	if v := getenv("AETHONX_STAGE_TIMEOUT", ""); v != "" {
+		cfg.Core.StageTimeout = parseCSV(v)
+	}
+	if v := getenv("AETHONX_STAGE_WORKERS", ""); v != "" {
+		cfg.Core.StageWorkers = parseCSV(v)
+	}
+	if v := getenv("AETHONX_SAMPLE", ""); v != "" {
+		cfg.Core.Sample = parseInt(v, cfg.Core.Sample)
+	}
 
 	// === OUTPUT CONFIG ===
 	if v := getenv("AETHONX_OUTPUT_DIR", ""); v != "" {
@@ -244,16 +814,272 @@ func loadFromEnv(cfg *Config) {
 	if v := getenv("AETHONX_SHOW_PHASES", ""); v != "" {
 		cfg.Output.ShowPhases = parseBool(v)
 	}
+	if v := getenv("AETHONX_QUIET", ""); v != "" {
+		cfg.Output.Quiet = parseBool(v)
+	}
+	if v := getenv("AETHONX_VERBOSITY", ""); v != "" {
+		cfg.Output.Verbosity = parseInt(v, cfg.Output.Verbosity)
+	}
+	if v := getenv("AETHONX_PROGRESS_FORMAT", ""); v != "" {
+		cfg.Output.ProgressFormat = v
+	}
+	if v := getenv("AETHONX_LOG_FILE", ""); v != "" {
+		cfg.Output.LogFile = v
+	}
+	if v := getenv("AETHONX_LOG_FILE_FORMAT", ""); v != "" {
+		cfg.Output.LogFileFormat = v
+	}
+	if v := getenv("AETHONX_LOG_FILE_LEVEL", ""); v != "" {
+		cfg.Output.LogFileLevel = v
+	}
+	if v := getenv("AETHONX_LOG_MAX_SIZE_MB", ""); v != "" {
+		cfg.Output.LogMaxSizeMB = parseInt(v, cfg.Output.LogMaxSizeMB)
+	}
+	if v := getenv("AETHONX_LOG_MAX_AGE", ""); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Output.LogMaxAge = d
+		}
+	}
+	if v := getenv("AETHONX_LOG_MAX_BACKUPS", ""); v != "" {
+		cfg.Output.LogMaxBackups = parseInt(v, cfg.Output.LogMaxBackups)
+	}
+	if v := getenv("AETHONX_ONLY_TYPES", ""); v != "" {
+		cfg.Output.OnlyTypes = parseCSV(v)
+	}
+	if v := getenv("AETHONX_ONLY_ALIVE", ""); v != "" {
+		cfg.Output.OnlyAlive = parseBool(v)
+	}
+	if v := getenv("AETHONX_MIN_CONFIDENCE", ""); v != "" {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			cfg.Output.MinConfidence = f
+		}
+	}
+	if v := getenv("AETHONX_MIN_RELATION_CONFIDENCE", ""); v != "" {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			cfg.Output.MinRelationConfidence = f
+		}
+	}
+	if v := getenv("AETHONX_TAGS", ""); v != "" {
+		cfg.Output.Tags = parseCSV(v)
+	}
+	if v := getenv("AETHONX_INCLUDE_PROVENANCE", ""); v != "" {
+		cfg.Output.IncludeProvenance = parseBool(v)
+	}
+	if v := getenv("AETHONX_SIGNING_KEY", ""); v != "" {
+		cfg.Output.SigningKey = v
+	}
+	if v := getenv("AETHONX_ENCRYPT", ""); v != "" {
+		cfg.Output.Encrypt = v
+	}
+	if v := getenv("AETHONX_ENCRYPT_TO", ""); v != "" {
+		cfg.Output.EncryptTo = parseCSV(v)
+	}
+	if v := getenv("AETHONX_COMPRESS", ""); v != "" {
+		cfg.Output.Compress = v
+	}
+	if v := getenv("AETHONX_FILENAME_TEMPLATE", ""); v != "" {
+		cfg.Output.FilenameTemplate = v
+	}
+	if v := getenv("AETHONX_STDOUT", ""); v != "" {
+		cfg.Output.Stdout = parseBool(v)
+	}
+	if v := getenv("AETHONX_SHARD", ""); v != "" {
+		cfg.Output.Shard = parseBool(v)
+	}
+	if v := getenv("AETHONX_PDF_REPORT", ""); v != "" {
+		cfg.Output.PDFReport = parseBool(v)
+	}
+	if v := getenv("AETHONX_PARQUET", ""); v != "" {
+		cfg.Output.Parquet = parseBool(v)
+	}
+	if v := getenv("AETHONX_BURP", ""); v != "" {
+		cfg.Output.Burp = parseBool(v)
+	}
+	if v := getenv("AETHONX_ZAP", ""); v != "" {
+		cfg.Output.ZAP = parseBool(v)
+	}
+	if v := getenv("AETHONX_AQUATONE", ""); v != "" {
+		cfg.Output.Aquatone = parseBool(v)
+	}
+	if v := getenv("AETHONX_EYEWITNESS", ""); v != "" {
+		cfg.Output.EyeWitness = parseBool(v)
+	}
+	if v := getenv("AETHONX_CAPTURE_RAW", ""); v != "" {
+		cfg.Output.CaptureRaw = parseBool(v)
+	}
+	if v := getenv("AETHONX_REDACT", ""); v != "" {
+		cfg.Output.Redact = parseCSV(v)
+	}
 
 	// === NETWORK CONFIG ===
 	if v := getenv("AETHONX_PROXY_URL", ""); v != "" {
 		cfg.Network.ProxyURL = v
 	}
+	if v := getenv("AETHONX_UPSTREAM_RATES", ""); v != "" {
+		cfg.Network.UpstreamRates = parseCSV(v)
+	}
+	if v := getenv("AETHONX_BUDGET", ""); v != "" {
+		cfg.Network.Budget = parseCSV(v)
+	}
+	if v := getenv("AETHONX_HOST_CONCURRENCY", ""); v != "" {
+		cfg.Network.HostConcurrency = parseCSV(v)
+	}
+	if v := getenv("AETHONX_HOST_DELAY", ""); v != "" {
+		cfg.Network.HostDelay = parseCSV(v)
+	}
+
+	// === BROWSER CONFIG ===
+	if v := getenv("AETHONX_CHROME_PATH", ""); v != "" {
+		cfg.Browser.ExecPath = v
+	}
+	if v := getenv("AETHONX_BROWSER_TABS", ""); v != "" {
+		cfg.Browser.MaxTabs = parseInt(v, cfg.Browser.MaxTabs)
+	}
+	if v := getenv("AETHONX_BROWSER_TIMEOUT", ""); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Browser.PageTimeout = d
+		}
+	}
+
+	// === OOB CONFIG ===
+	if v := getenv("AETHONX_OOB_SERVER", ""); v != "" {
+		cfg.OOB.Server = v
+	}
+	if v := getenv("AETHONX_OOB_TOKEN", ""); v != "" {
+		cfg.OOB.Token = v
+	}
+	if v := getenv("AETHONX_OOB_WAIT", ""); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.OOB.Wait = d
+		}
+	}
+
+	// === TAGGING CONFIG ===
+	if v := getenv("AETHONX_TAG_RULES", ""); v != "" {
+		cfg.Tagging.RulesFile = v
+	}
+
+	// === LIFECYCLE CONFIG ===
+	if v := getenv("AETHONX_TRACK_LIFECYCLE", ""); v != "" {
+		cfg.Lifecycle.Enabled = parseBool(v)
+	}
+	if v := getenv("AETHONX_STALE_AFTER", ""); v != "" {
+		cfg.Lifecycle.StaleAfter = parseInt(v, cfg.Lifecycle.StaleAfter)
+	}
+	if v := getenv("AETHONX_REMOVE_AFTER", ""); v != "" {
+		cfg.Lifecycle.RemoveAfter = parseInt(v, cfg.Lifecycle.RemoveAfter)
+	}
+	if v := getenv("AETHONX_CERT_ALERT_DAYS", ""); v != "" {
+		var days []int
+		for _, d := range parseCSV(v) {
+			if n := parseInt(d, 0); n > 0 {
+				days = append(days, n)
+			}
+		}
+		if len(days) > 0 {
+			cfg.Lifecycle.CertAlertDays = days
+		}
+	}
+	if v := getenv("AETHONX_ACTIVE_WINDOW", ""); v != "" {
+		cfg.Lifecycle.ActiveWindow = v
+	}
+	if v := getenv("AETHONX_ACTIVE_WINDOW_TZ", ""); v != "" {
+		cfg.Lifecycle.ActiveWindowTZ = v
+	}
+
+	// === NOISE CONFIG ===
+	if v := getenv("AETHONX_SUPPRESS_NOISE", ""); v != "" {
+		cfg.Noise.Suppress = parseBool(v)
+	}
+	if v := getenv("AETHONX_EXCLUDE_APEX", ""); v != "" {
+		cfg.Noise.ExcludeApex = parseBool(v)
+	}
+
+	// === NOTIFY CONFIG ===
+	if v := getenv("AETHONX_WEBHOOKS", ""); v != "" {
+		cfg.Notify.Webhooks = parseCSV(v)
+	}
+	if v := getenv("AETHONX_EVENT_SPOOL", ""); v != "" {
+		cfg.Notify.SpoolDir = v
+	}
+
+	// === EXPORT CONFIG ===
+	if v := getenv("AETHONX_EXPORT_MIN_SEVERITY", ""); v != "" {
+		cfg.Export.MinSeverity = v
+	}
+	if v := getenv("AETHONX_DEFECTDOJO_URL", ""); v != "" {
+		cfg.Export.DefectDojoURL = v
+	}
+	if v := getenv("AETHONX_DEFECTDOJO_TOKEN", ""); v != "" {
+		cfg.Export.DefectDojoToken = v
+	}
+	if v := getenv("AETHONX_DEFECTDOJO_PRODUCT", ""); v != "" {
+		cfg.Export.DefectDojoProduct = v
+	}
+	if v := getenv("AETHONX_DEFECTDOJO_PRODUCT_TYPE", ""); v != "" {
+		cfg.Export.DefectDojoProductType = v
+	}
+	if v := getenv("AETHONX_DEFECTDOJO_ENGAGEMENT", ""); v != "" {
+		cfg.Export.DefectDojoEngagement = v
+	}
+	if v := getenv("AETHONX_FARADAY_URL", ""); v != "" {
+		cfg.Export.FaradayURL = v
+	}
+	if v := getenv("AETHONX_FARADAY_TOKEN", ""); v != "" {
+		cfg.Export.FaradayToken = v
+	}
+	if v := getenv("AETHONX_FARADAY_WORKSPACE", ""); v != "" {
+		cfg.Export.FaradayWorkspace = v
+	}
+	if v := getenv("AETHONX_JIRA_URL", ""); v != "" {
+		cfg.Export.JiraURL = v
+	}
+	if v := getenv("AETHONX_JIRA_USER", ""); v != "" {
+		cfg.Export.JiraUser = v
+	}
+	if v := getenv("AETHONX_JIRA_TOKEN", ""); v != "" {
+		cfg.Export.JiraToken = v
+	}
+	if v := getenv("AETHONX_JIRA_PROJECT", ""); v != "" {
+		cfg.Export.JiraProject = v
+	}
+	if v := getenv("AETHONX_JIRA_ISSUE_TYPE", ""); v != "" {
+		cfg.Export.JiraIssueType = v
+	}
+	if v := getenv("AETHONX_JIRA_LABELS", ""); v != "" {
+		cfg.Export.JiraLabels = parseCSV(v)
+	}
+	if v := getenv("AETHONX_JIRA_MIN_SEVERITY", ""); v != "" {
+		cfg.Export.JiraMinSeverity = v
+	}
+	if v := getenv("AETHONX_REPORT_URL", ""); v != "" {
+		cfg.Export.ReportURL = v
+	}
+
+	// === AGENTS CONFIG ===
+	if v := getenv("AETHONX_AGENTS", ""); v != "" {
+		cfg.Agents.URLs = parseCSV(v)
+	}
+	if v := getenv("AETHONX_AGENT_CA", ""); v != "" {
+		cfg.Agents.CAFile = v
+	}
+	if v := getenv("AETHONX_AGENT_CERT", ""); v != "" {
+		cfg.Agents.CertFile = v
+	}
+	if v := getenv("AETHONX_AGENT_KEY", ""); v != "" {
+		cfg.Agents.KeyFile = v
+	}
+	if v := getenv("AETHONX_AGENT_TOKEN", ""); v != "" {
+		cfg.Agents.Token = v
+	}
 
 	// === SOURCE CONFIG ===
 	// Format: AETHONX_SOURCES_CRTSH_ENABLED=true
 	//         AETHONX_SOURCES_CRTSH_PRIORITY=10
 	//         AETHONX_SOURCES_CRTSH_TIMEOUT=60
+	//         AETHONX_SOURCES_CRTSH_CACHE_TTL=6h
+	//         AETHONX_SOURCES_CRTSH_NEGATIVE_CACHE_TTL=1h
+	//         AETHONX_SOURCES_CRTSH_CRITICAL=true
 	for name := range cfg.Source.Sources {
 		prefix := fmt.Sprintf("AETHONX_SOURCES_%s_", strings.ToUpper(name))
 
@@ -274,6 +1100,19 @@ func loadFromEnv(cfg *Config) {
 		if v := getenv(prefix+"RATELIMIT", ""); v != "" {
 			sourceCfg.RateLimit = parseInt(v, sourceCfg.RateLimit)
 		}
+		if v := getenv(prefix+"CRITICAL", ""); v != "" {
+			sourceCfg.Critical = parseBool(v)
+		}
+		if v := getenv(prefix+"CACHE_TTL", ""); v != "" {
+			if d, err := time.ParseDuration(v); err == nil {
+				sourceCfg.CacheTTL = d
+			}
+		}
+		if v := getenv(prefix+"NEGATIVE_CACHE_TTL", ""); v != "" {
+			if d, err := time.ParseDuration(v); err == nil {
+				sourceCfg.NegativeCacheTTL = d
+			}
+		}
 
 		// HTTPx-specific custom config
 		if name == "httpx" {
@@ -323,6 +1162,52 @@ func loadFromEnv(cfg *Config) {
 			}
 		}
 
+		// Reverse WHOIS provider and key
+		if name == "reversewhois" {
+			if v := getenv(prefix+"PROVIDER", ""); v != "" {
+				sourceCfg.Custom["provider"] = v
+			}
+			if v := getenv(prefix+"API_KEY", ""); v != "" {
+				sourceCfg.Custom["api_key"] = v
+			}
+		}
+
+		// Passive DNS credentials
+		if name == "pdns" {
+			if v := getenv(prefix+"MNEMONIC_API_KEY", ""); v != "" {
+				sourceCfg.Custom["mnemonic_api_key"] = v
+			}
+			if v := getenv(prefix+"CIRCL_USER", ""); v != "" {
+				sourceCfg.Custom["circl_user"] = v
+			}
+			if v := getenv(prefix+"CIRCL_PASSWORD", ""); v != "" {
+				sourceCfg.Custom["circl_password"] = v
+			}
+		}
+
+		// Masscan scope gate and packet rate
+		if name == "masscan" {
+			if v := getenv(prefix+"ALLOWED_RANGES", ""); v != "" {
+				sourceCfg.Custom["allowed_ranges"] = normalizeList(parseCSV(v), false)
+			}
+			if v := getenv(prefix+"EXCLUDE_RANGES", ""); v != "" {
+				sourceCfg.Custom["exclude_ranges"] = normalizeList(parseCSV(v), false)
+			}
+			if v := getenv(prefix+"RATE", ""); v != "" {
+				sourceCfg.Custom["rate"] = parseInt(v, 300)
+			}
+		}
+
+		// RDAP server selection (air-gapped hosts use a local bootstrap file)
+		if name == "rdap" {
+			if v := getenv(prefix+"BOOTSTRAP_FILE", ""); v != "" {
+				sourceCfg.Custom["bootstrap_file"] = v
+			}
+			if v := getenv(prefix+"BASE_URL", ""); v != "" {
+				sourceCfg.Custom["base_url"] = v
+			}
+		}
+
 		cfg.Source.Sources[name] = sourceCfg
 	}
 
@@ -330,6 +1215,9 @@ func loadFromEnv(cfg *Config) {
 	if v := getenv("AETHONX_STREAMING_THRESHOLD", ""); v != "" {
 		cfg.Streaming.ArtifactThreshold = parseInt(v, cfg.Streaming.ArtifactThreshold)
 	}
+	if v := getenv("AETHONX_MEMORY_BUDGET", ""); v != "" {
+		cfg.Streaming.MemoryBudget = v
+	}
 
 	// === RESILIENCE CONFIG ===
 	if v := getenv("AETHONX_RESILIENCE_MAX_RETRIES", ""); v != "" {
@@ -350,13 +1238,48 @@ func loadFromEnv(cfg *Config) {
 func loadFromFlags(cfg *Config, version, commit, date string) {
 	// Custom help flag handling
 	showHelp := pflag.BoolP("help", "h", false, "Show help message")
-	showVersion := pflag.BoolP("version", "v", false, "Print version information")
+	showVersion := pflag.BoolP("version", "V", false, "Print version information")
+	showSourcesHelp := pflag.Bool("help-sources", false, "List sources and their options")
 
 	// === CORE FLAGS ===
 	pflag.StringVarP(&cfg.Core.Target, "target", "t", cfg.Core.Target, "Target domain (required)")
 	pflag.BoolVarP(&cfg.Core.Active, "active", "a", cfg.Core.Active, "Enable active reconnaissance")
 	pflag.IntVarP(&cfg.Core.Workers, "workers", "w", cfg.Core.Workers, "Concurrent workers")
+	pflag.IntVar(&cfg.Core.ProviderConcurrency, "provider-concurrency", cfg.Core.ProviderConcurrency,
+		"Max requests in flight to an upstream provider shared by sources of a stage")
 	pflag.IntVarP(&cfg.Core.TimeoutS, "timeout", "T", cfg.Core.TimeoutS, "Global timeout in seconds (0=none)")
+	pflag.StringVar(&cfg.Core.Normalization, "normalization", cfg.Core.Normalization,
+		"Domain normalization policy: strict (keep www.), aggressive (strip www.)")
+	pflag.StringVar(&cfg.Core.Workspace, "workspace", cfg.Core.Workspace,
+		"Workspace name (config, scope and scan history under ~/.aethonx/workspaces)")
+	pflag.StringVar(&cfg.Core.ConfigFile, "config", cfg.Core.ConfigFile,
+		"YAML config file with per-source settings")
+	pflag.BoolVar(&cfg.Core.NoPivot, "no-pivot", cfg.Core.NoPivot,
+		"Never run pivot sources that discover assets outside the target (reverse WHOIS)")
+	pflag.StringSliceVar(&cfg.Core.SkipStages, "skip-stages", cfg.Core.SkipStages,
+		"Scan phases to skip: discovery, verification, enrichment (e.g. verification,enrichment)")
+	pflag.BoolVar(&cfg.Core.Plan, "plan", cfg.Core.Plan,
+		"Print the stages the scan would run, including skipped ones, and exit")
+	pflag.IntSliceVar(&cfg.Core.CriticalStages, "critical-stages", cfg.Core.CriticalStages,
+		"Stage IDs (0 = first stage) where any source failure aborts the scan")
+	pflag.StringSliceVar(&cfg.Core.Imports, "import", cfg.Core.Imports,
+		"Fuse the output of another tool into the scan (nmap XML, AethonX JSON, Aquatone session, EyeWitness Requests.csv; repeatable)")
+	pflag.StringVar(&cfg.Core.PreviousScan, "previous-scan", cfg.Core.PreviousScan,
+		"Re-verify the assets of a prior scan of the target: scan JSON or \"latest\"")
+	pflag.StringSliceVar(&cfg.Core.FailOn, "fail-on", cfg.Core.FailOn,
+		"Outcomes that exit non-zero: source-error, timeout, empty, new-risk[=<severity>], none")
+	pflag.StringSliceVar(&cfg.Core.MaxArtifacts, "max-artifacts", cfg.Core.MaxArtifacts,
+		"Artifact cap for the scan (<n>) or per source (<source>=<n>, *=<n>); results are kept and marked truncated")
+	pflag.StringSliceVar(&cfg.Core.MaxDuration, "max-duration", cfg.Core.MaxDuration,
+		"Duration cap for the scan (<dur>) or per source (<source>=<dur>, *=<dur>); results are kept and marked truncated")
+	pflag.StringSliceVar(&cfg.Core.StageTimeout, "stage-timeout", cfg.Core.StageTimeout,
+		"Duration cap for one stage (<stage>=<dur>, 0 = first stage); replaces -T for it, results are kept and marked truncated")
+	pflag.StringSliceVar(&cfg.Core.StageWorkers, "stage-workers", cfg.Core.StageWorkers,
+		"Concurrent workers for one stage (<stage>=<n>, 0 = first stage)")
+	pflag.IntVar(&cfg.Core.Sample, "sample", cfg.Core.Sample,
+		"Preview mode: stop every source after <n> artifacts")
+	pflag.StringVar(&cfg.Core.Profile, "profile", cfg.Core.Profile,
+		"Scan depth preset: quick, standard (default), deep; explicit flags override it")
 
 	// === SOURCE FLAGS ===
 	for name := range cfg.Source.Sources {
@@ -378,10 +1301,72 @@ func loadFromFlags(cfg *Config, version, commit, date string) {
 		"Show system metrics (CPU, memory, goroutines)")
 	pflag.BoolVar(&cfg.Output.ShowPhases, "show-phases", cfg.Output.ShowPhases,
 		"Show execution phases for each source")
+	pflag.BoolVarP(&cfg.Output.Quiet, "quiet", "q", cfg.Output.Quiet,
+		"Quiet: errors only, no visual UI (JSON output still written)")
+	pflag.CountVarP(&cfg.Output.Verbosity, "verbose", "v",
+		"Verbose logs on stderr (-v info, -vv debug)")
+	pflag.StringVar(&cfg.Output.ProgressFormat, "progress-format", cfg.Output.ProgressFormat,
+		"Emit progress events on stderr: json (JSON lines)")
+	pflag.StringVar(&cfg.Output.LogFile, "log-file", cfg.Output.LogFile,
+		"Also write logs to this file (rotated)")
+	pflag.StringVar(&cfg.Output.LogFileFormat, "log-file-format", cfg.Output.LogFileFormat,
+		"Log file format: json (default), text")
+	pflag.StringVar(&cfg.Output.LogFileLevel, "log-file-level", cfg.Output.LogFileLevel,
+		"Log file level: debug (default), info, warn, error")
+	pflag.IntVar(&cfg.Output.LogMaxSizeMB, "log-max-size", cfg.Output.LogMaxSizeMB,
+		"Rotate log file after N megabytes (0=no limit)")
+	pflag.DurationVar(&cfg.Output.LogMaxAge, "log-max-age", cfg.Output.LogMaxAge,
+		"Rotate log file after this age, e.g. 24h (0=no limit)")
+	pflag.IntVar(&cfg.Output.LogMaxBackups, "log-max-backups", cfg.Output.LogMaxBackups,
+		"Rotated log files to keep (0=keep all)")
+	pflag.StringSliceVar(&cfg.Output.OnlyTypes, "only-types", cfg.Output.OnlyTypes,
+		"Export only these artifact types (e.g. subdomain,url)")
+	pflag.BoolVar(&cfg.Output.OnlyAlive, "only-alive", cfg.Output.OnlyAlive,
+		"Export only artifacts that answered a probe")
+	pflag.Float64Var(&cfg.Output.MinConfidence, "min-confidence", cfg.Output.MinConfidence,
+		"Export only artifacts with at least this confidence (0-1)")
+	pflag.Float64Var(&cfg.Output.MinRelationConfidence, "min-relation-confidence", cfg.Output.MinRelationConfidence,
+		"Drop relations with confidence below this value (0-1)")
+	pflag.StringSliceVar(&cfg.Output.Tags, "tag", cfg.Output.Tags,
+		"Export only artifacts with any of these tags (repeatable)")
+	pflag.BoolVar(&cfg.Output.IncludeProvenance, "include-provenance", cfg.Output.IncludeProvenance,
+		"Include per-source provenance (raw value, time, query) in JSON output")
+	pflag.StringVar(&cfg.Output.SigningKey, "sign-key", cfg.Output.SigningKey,
+		"Sign the consolidated JSON with this ed25519 private key")
+	pflag.StringVar(&cfg.Output.Encrypt, "encrypt", cfg.Output.Encrypt,
+		"Encrypt JSON outputs: age, gpg")
+	pflag.StringSliceVar(&cfg.Output.EncryptTo, "encrypt-to", cfg.Output.EncryptTo,
+		"Encryption recipient: key or recipients file (repeatable)")
+	pflag.StringVar(&cfg.Output.Compress, "compress", cfg.Output.Compress,
+		"Compress JSON outputs and streaming partials: gzip, zstd")
+	pflag.StringVar(&cfg.Output.FilenameTemplate, "filename-template", cfg.Output.FilenameTemplate,
+		"Output file name template: {target}, {date}, {scan_id}, {format} (default aethonx_{target}_{date})")
+	pflag.BoolVar(&cfg.Output.Stdout, "stdout", cfg.Output.Stdout,
+		"Also write the consolidated JSON to stdout for piping (implies --quiet)")
+	pflag.BoolVar(&cfg.Output.Shard, "shard", cfg.Output.Shard,
+		"Write the consolidated JSON as a directory sharded by artifact type (index.json manifest)")
+	pflag.BoolVar(&cfg.Output.PDFReport, "pdf", cfg.Output.PDFReport,
+		"Also write a PDF report (cover, charts, top risks, appendix)")
+	pflag.BoolVar(&cfg.Output.Parquet, "parquet", cfg.Output.Parquet,
+		"Also write the artifacts as Apache Parquet (<file>_artifacts.parquet)")
+	pflag.BoolVar(&cfg.Output.Burp, "burp", cfg.Output.Burp,
+		"Also write the target scope for Burp Suite (<file>_burp_scope.json) and the alive URLs (<file>_urls.txt)")
+	pflag.BoolVar(&cfg.Output.ZAP, "zap", cfg.Output.ZAP,
+		"Also write the target scope as an OWASP ZAP context (<file>_zap.context) and the alive URLs (<file>_urls.txt)")
+	pflag.BoolVar(&cfg.Output.Aquatone, "aquatone", cfg.Output.Aquatone,
+		"Also write the alive hosts (<file>_hosts.txt) and URLs (<file>_urls.txt) for Aquatone")
+	pflag.BoolVar(&cfg.Output.EyeWitness, "eyewitness", cfg.Output.EyeWitness,
+		"Also write the alive URLs (<file>_urls.txt) for EyeWitness -f")
+	pflag.BoolVar(&cfg.Output.CaptureRaw, "capture-raw", cfg.Output.CaptureRaw,
+		"Archive raw source outputs in <out>/raw/<scan>/ for aethonx replay")
+	pflag.StringSliceVar(&cfg.Output.Redact, "redact", cfg.Output.Redact,
+		"Redaction profile (full, client-safe), optionally per format: json=full,table=client-safe")
 
 	// === STREAMING FLAGS ===
 	pflag.IntVarP(&cfg.Streaming.ArtifactThreshold, "streaming", "s", cfg.Streaming.ArtifactThreshold,
 		"Artifact threshold for streaming")
+	pflag.StringVar(&cfg.Streaming.MemoryBudget, "memory-budget", cfg.Streaming.MemoryBudget,
+		"Stream to disk when held artifacts exceed this size (e.g. 512MB, 25%)")
 
 	// === RESILIENCE FLAGS ===
 	pflag.IntVarP(&cfg.Resilience.MaxRetries, "retries", "r", cfg.Resilience.MaxRetries,
@@ -391,6 +1376,101 @@ func loadFromFlags(cfg *Config, version, commit, date string) {
 
 	// === NETWORK FLAGS ===
 	pflag.StringVarP(&cfg.Network.ProxyURL, "proxy", "p", cfg.Network.ProxyURL, "HTTP(S) proxy URL")
+	pflag.StringSliceVar(&cfg.Network.UpstreamRates, "upstream-rate", cfg.Network.UpstreamRates,
+		"Shared budget per upstream for all sources: <upstream>=<rps>[/<burst>] (repeatable)")
+	pflag.StringSliceVar(&cfg.Network.Budget, "budget", cfg.Network.Budget,
+		"Global traffic budget of active sources: requests=<n>, dns=<n>, bytes=<size> (repeatable)")
+	pflag.StringSliceVar(&cfg.Network.HostConcurrency, "host-concurrency", cfg.Network.HostConcurrency,
+		"Max requests in flight per destination host: <n> or <domain>=<n> per scope entry (repeatable)")
+	pflag.StringSliceVar(&cfg.Network.HostDelay, "host-delay", cfg.Network.HostDelay,
+		"Min delay between requests to a host: <dur> or <domain>=<dur> per scope entry (repeatable)")
+
+	// === BROWSER FLAGS ===
+	pflag.StringVar(&cfg.Browser.ExecPath, "chrome-path", cfg.Browser.ExecPath,
+		"Chrome/Chromium binary for the shared headless browser (default: look up in PATH)")
+	pflag.IntVar(&cfg.Browser.MaxTabs, "browser-tabs", cfg.Browser.MaxTabs,
+		"Max headless browser pages open at once across all sources")
+	pflag.DurationVar(&cfg.Browser.PageTimeout, "browser-timeout", cfg.Browser.PageTimeout,
+		"Max time per headless browser page")
+
+	// === OOB FLAGS ===
+	pflag.StringVar(&cfg.OOB.Server, "oob-server", cfg.OOB.Server,
+		"interactsh server for out-of-band callbacks of active checks (default: disabled)")
+	pflag.StringVar(&cfg.OOB.Token, "oob-token", cfg.OOB.Token,
+		"Authorization token of a self-hosted interactsh server")
+	pflag.DurationVar(&cfg.OOB.Wait, "oob-wait", cfg.OOB.Wait,
+		"Grace period for late out-of-band callbacks after the scan")
+
+	// === TAGGING FLAGS ===
+	pflag.StringVar(&cfg.Tagging.RulesFile, "tag-rules", cfg.Tagging.RulesFile,
+		"YAML file with artifact tagging rules")
+
+	// === LIFECYCLE FLAGS ===
+	pflag.BoolVar(&cfg.Lifecycle.Enabled, "track-lifecycle", cfg.Lifecycle.Enabled,
+		"Track first/last seen per artifact across scans of the same target")
+	pflag.IntVar(&cfg.Lifecycle.StaleAfter, "stale-after", cfg.Lifecycle.StaleAfter,
+		"Missed scans before an artifact is marked stale")
+	pflag.IntVar(&cfg.Lifecycle.RemoveAfter, "remove-after", cfg.Lifecycle.RemoveAfter,
+		"Missed scans before an artifact is marked removed")
+	pflag.IntSliceVar(&cfg.Lifecycle.CertAlertDays, "cert-alert-days", cfg.Lifecycle.CertAlertDays,
+		"Days-until-expiry thresholds that fire certificate alerts, e.g. 30,14,7,1")
+	pflag.StringVar(&cfg.Lifecycle.ActiveWindow, "active-window", cfg.Lifecycle.ActiveWindow,
+		"Run active stages only within this daily window, e.g. 22:00-06:00 (deferred until it opens)")
+	pflag.StringVar(&cfg.Lifecycle.ActiveWindowTZ, "active-window-tz", cfg.Lifecycle.ActiveWindowTZ,
+		"Time zone of --active-window, e.g. Europe/Madrid (default: local time)")
+
+	// === NOISE FLAGS ===
+	pflag.BoolVar(&cfg.Noise.Suppress, "suppress-noise", cfg.Noise.Suppress,
+		"Drop third-party hosts (different eTLD+1) unless linked via CNAME/cert SAN")
+	pflag.BoolVar(&cfg.Noise.ExcludeApex, "exclude-apex", cfg.Noise.ExcludeApex,
+		"Drop the apex domain itself from results, keeping its subdomains")
+
+	// === NOTIFY FLAGS ===
+	pflag.StringSliceVar(&cfg.Notify.Webhooks, "webhook", cfg.Notify.Webhooks,
+		"POST every scan event as JSON to this URL (repeatable)")
+	pflag.StringVar(&cfg.Notify.SpoolDir, "event-spool", cfg.Notify.SpoolDir,
+		"Keep events a webhook did not get in this directory and replay them on the next scan")
+
+	// === AGENT FLAGS ===
+	pflag.StringSliceVar(&cfg.Agents.URLs, "agent", cfg.Agents.URLs,
+		"Remote agent URL https://host:port (repeatable); sources run on agents that have them")
+	pflag.StringVar(&cfg.Agents.CAFile, "agent-ca", cfg.Agents.CAFile,
+		"CA file that signed the agent certificates")
+	pflag.StringVar(&cfg.Agents.CertFile, "agent-cert", cfg.Agents.CertFile,
+		"Client certificate for mutual TLS with the agents")
+	pflag.StringVar(&cfg.Agents.KeyFile, "agent-key", cfg.Agents.KeyFile,
+		"Client certificate key for mutual TLS with the agents")
+
+	// === EXPORT FLAGS ===
+	// Vulnerability-management export (tokens only from ENV / workspace config.env)
+	pflag.StringVar(&cfg.Export.MinSeverity, "export-min-severity", cfg.Export.MinSeverity,
+		"Lowest finding severity exported to DefectDojo/Faraday: low, medium, high, critical")
+	pflag.StringVar(&cfg.Export.DefectDojoURL, "defectdojo-url", cfg.Export.DefectDojoURL,
+		"Import findings into this DefectDojo instance after the scan")
+	pflag.StringVar(&cfg.Export.DefectDojoProduct, "defectdojo-product", cfg.Export.DefectDojoProduct,
+		"DefectDojo product for the findings")
+	pflag.StringVar(&cfg.Export.DefectDojoProductType, "defectdojo-product-type", cfg.Export.DefectDojoProductType,
+		"DefectDojo product type (needed to create a missing product)")
+	pflag.StringVar(&cfg.Export.DefectDojoEngagement, "defectdojo-engagement", cfg.Export.DefectDojoEngagement,
+		"DefectDojo engagement (default: AethonX recon)")
+	pflag.StringVar(&cfg.Export.FaradayURL, "faraday-url", cfg.Export.FaradayURL,
+		"Import findings into this Faraday instance after the scan")
+	pflag.StringVar(&cfg.Export.FaradayWorkspace, "faraday-workspace", cfg.Export.FaradayWorkspace,
+		"Faraday workspace for the findings")
+	pflag.StringVar(&cfg.Export.JiraURL, "jira-url", cfg.Export.JiraURL,
+		"Open Jira tickets for new findings (requires --track-lifecycle)")
+	pflag.StringVar(&cfg.Export.JiraUser, "jira-user", cfg.Export.JiraUser,
+		"Jira Cloud account email (omit to use the token as a PAT)")
+	pflag.StringVar(&cfg.Export.JiraProject, "jira-project", cfg.Export.JiraProject,
+		"Jira project key for the tickets")
+	pflag.StringVar(&cfg.Export.JiraIssueType, "jira-issue-type", cfg.Export.JiraIssueType,
+		"Jira issue type of the tickets")
+	pflag.StringSliceVar(&cfg.Export.JiraLabels, "jira-labels", cfg.Export.JiraLabels,
+		"Extra labels for the Jira tickets (comma-separated)")
+	pflag.StringVar(&cfg.Export.JiraMinSeverity, "jira-min-severity", cfg.Export.JiraMinSeverity,
+		"Lowest severity of a new finding that opens a ticket: low, medium, high, critical")
+	pflag.StringVar(&cfg.Export.ReportURL, "report-url", cfg.Export.ReportURL,
+		"Base URL where the output directory is published (evidence links in tickets)")
 
 	// Parse flags
 	pflag.Parse()
@@ -404,6 +1484,10 @@ func loadFromFlags(cfg *Config, version, commit, date string) {
 		PrintVersion(version, commit, date)
 	}
 
+	if *showSourcesHelp {
+		PrintSourcesHelp(registry.Global().GetAllMetadata())
+	}
+
 	// Detect common mistake: user typed "-target" instead of "--target" or "-t"
 	// This happens because "-target" is interpreted as "-t -a -r -g -e -t"
 	detectCommonFlagMistakes(cfg)
@@ -451,15 +1535,91 @@ func normalize(c *Config) {
 	if c.Core.Workers < 1 {
 		c.Core.Workers = 1
 	}
+	if c.Core.ProviderConcurrency < 1 {
+		c.Core.ProviderConcurrency = 1
+	}
 	if c.Core.TimeoutS < 0 {
 		c.Core.TimeoutS = 0
 	}
+	c.Core.Normalization = strings.ToLower(strings.TrimSpace(c.Core.Normalization))
+	c.Core.Profile = strings.ToLower(strings.TrimSpace(c.Core.Profile))
+	c.Core.FailOn = normalizeList(c.Core.FailOn, true)
+	c.Core.Imports = normalizeList(c.Core.Imports, false)
+	c.Core.PreviousScan = strings.TrimSpace(c.Core.PreviousScan)
+	c.Core.MaxArtifacts = normalizeList(c.Core.MaxArtifacts, true)
+	c.Core.MaxDuration = normalizeList(c.Core.MaxDuration, true)
+	c.Core.StageTimeout = normalizeList(c.Core.StageTimeout, true)
+	c.Core.StageWorkers = normalizeList(c.Core.StageWorkers, true)
+	c.Core.SkipStages = normalizeList(c.Core.SkipStages, true)
 
 	// Output normalization
 	if c.Output.Dir == "" {
 		c.Output.Dir = "aethonx_out"
 	}
 
+	c.Output.ProgressFormat = strings.ToLower(strings.TrimSpace(c.Output.ProgressFormat))
+	if c.Output.ProgressFormat != "json" {
+		c.Output.ProgressFormat = ""
+	}
+	if c.Output.LogFileFormat != "text" {
+		c.Output.LogFileFormat = "json"
+	}
+	if c.Output.LogMaxSizeMB < 0 {
+		c.Output.LogMaxSizeMB = 0
+	}
+	if c.Output.LogMaxBackups < 0 {
+		c.Output.LogMaxBackups = 0
+	}
+	c.Output.OnlyTypes = normalizeList(c.Output.OnlyTypes, true)
+	c.Output.Tags = normalizeList(c.Output.Tags, false)
+	if c.Output.MinConfidence < 0 {
+		c.Output.MinConfidence = 0
+	}
+	if c.Output.MinConfidence > 1 {
+		c.Output.MinConfidence = 1
+	}
+	if c.Output.MinRelationConfidence < 0 {
+		c.Output.MinRelationConfidence = 0
+	}
+	if c.Output.MinRelationConfidence > 1 {
+		c.Output.MinRelationConfidence = 1
+	}
+	c.Output.Encrypt = strings.ToLower(strings.TrimSpace(c.Output.Encrypt))
+	c.Output.EncryptTo = normalizeList(c.Output.EncryptTo, false)
+	c.Output.Redact = normalizeList(c.Output.Redact, true)
+	c.Export.MinSeverity = strings.ToLower(strings.TrimSpace(c.Export.MinSeverity))
+	c.Export.JiraMinSeverity = strings.ToLower(strings.TrimSpace(c.Export.JiraMinSeverity))
+	c.Export.JiraLabels = normalizeList(c.Export.JiraLabels, false)
+	c.Export.ReportURL = strings.TrimRight(strings.TrimSpace(c.Export.ReportURL), "/")
+	c.Output.FilenameTemplate = strings.TrimSpace(c.Output.FilenameTemplate)
+	if c.Output.Stdout {
+		// stdout carries only the JSON: no visual UI, table or info logs
+		c.Output.Quiet = true
+	}
+
+	// Browser normalization
+	c.Browser.ExecPath = strings.TrimSpace(c.Browser.ExecPath)
+	if c.Browser.MaxTabs < 1 {
+		c.Browser.MaxTabs = browser.DefaultMaxTabs
+	}
+	if c.Browser.PageTimeout <= 0 {
+		c.Browser.PageTimeout = browser.DefaultPageTimeout
+	}
+
+	// OOB normalization
+	c.OOB.Server = strings.TrimSpace(c.OOB.Server)
+	if c.OOB.Wait <= 0 {
+		c.OOB.Wait = oob.DefaultWait
+	}
+
+	// Lifecycle normalization
+	if c.Lifecycle.StaleAfter < 1 {
+		c.Lifecycle.StaleAfter = 1
+	}
+	if c.Lifecycle.RemoveAfter < c.Lifecycle.StaleAfter {
+		c.Lifecycle.RemoveAfter = c.Lifecycle.StaleAfter
+	}
+
 	// Resilience normalization
 	if c.Resilience.BackoffBase < 0 {
 		c.Resilience.BackoffBase = 1 * time.Second
@@ -486,12 +1646,286 @@ func (c Config) Timeout() time.Duration {
 	return time.Duration(c.Core.TimeoutS) * time.Second
 }
 
+// NormalizationPolicy returns the configured domain normalization policy.
+// Returns an error if the policy name is unknown.
+func (c Config) NormalizationPolicy() (validator.NormalizationPolicy, error) {
+	return validator.ParseNormalizationPolicy(c.Core.Normalization)
+}
+
+// UpstreamRates parses the per-upstream budget overrides (--upstream-rate).
+func (c Config) UpstreamRates() (map[string]rate.UpstreamRate, error) {
+	return rate.ParseUpstreamRates(c.Network.UpstreamRates)
+}
+
+// ScanLimits are the parsed --max-artifacts/--max-duration caps (0 = no cap).
+// Per-source maps use "*" for every source without its own cap.
+type ScanLimits struct {
+	MaxArtifacts int
+	MaxDuration  time.Duration
+
+	// Sample is the --sample size: a per-source artifact cap that wins over
+	// larger --max-artifacts caps
+	Sample int
+
+	SourceMaxArtifacts map[string]int
+	SourceMaxDuration  map[string]time.Duration
+
+	// Per-stage overrides keyed by stage ID (--stage-timeout, --stage-workers)
+	StageMaxDuration map[int]time.Duration
+	StageMaxWorkers  map[int]int
+}
+
+// ScanLimits parses --max-artifacts, --max-duration, --sample, --stage-timeout
+// and --stage-workers. Returns an error for malformed or non-positive values.
+func (c Config) ScanLimits() (ScanLimits, error) {
+	limits := ScanLimits{
+		Sample:             c.Core.Sample,
+		SourceMaxArtifacts: make(map[string]int),
+		SourceMaxDuration:  make(map[string]time.Duration),
+		StageMaxDuration:   make(map[int]time.Duration),
+		StageMaxWorkers:    make(map[int]int),
+	}
+	if c.Core.Sample < 0 {
+		return limits, fmt.Errorf("invalid --sample %d: want a positive number of artifacts", c.Core.Sample)
+	}
+
+	for _, entry := range c.Core.MaxArtifacts {
+		source, value, perSource := cutLimit(entry)
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return limits, fmt.Errorf("invalid --max-artifacts %q: want a positive integer", entry)
+		}
+		if perSource {
+			limits.SourceMaxArtifacts[source] = n
+		} else {
+			limits.MaxArtifacts = n
+		}
+	}
+
+	for _, entry := range c.Core.MaxDuration {
+		source, value, perSource := cutLimit(entry)
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return limits, fmt.Errorf("invalid --max-duration %q: want a positive duration (e.g. 30m, 2h)", entry)
+		}
+		if perSource {
+			limits.SourceMaxDuration[source] = d
+		} else {
+			limits.MaxDuration = d
+		}
+	}
+
+	for _, entry := range c.Core.StageTimeout {
+		stage, value, ok := cutStageLimit(entry)
+		d, err := time.ParseDuration(value)
+		if !ok || err != nil || d <= 0 {
+			return limits, fmt.Errorf("invalid --stage-timeout %q: want <stage>=<duration> (e.g. 0=5m)", entry)
+		}
+		limits.StageMaxDuration[stage] = d
+	}
+
+	for _, entry := range c.Core.StageWorkers {
+		stage, value, ok := cutStageLimit(entry)
+		n, err := strconv.Atoi(value)
+		if !ok || err != nil || n <= 0 {
+			return limits, fmt.Errorf("invalid --stage-workers %q: want <stage>=<n> (e.g. 1=4)", entry)
+		}
+		limits.StageMaxWorkers[stage] = n
+	}
+
+	return limits, nil
+}
+
+// CriticalSources returns the enabled sources marked critical: true, sorted.
+func (c Config) CriticalSources() []string {
+	var names []string
+	for name, sc := range c.Source.Sources {
+		if sc.Enabled && sc.Critical {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// StagesToSkip returns the --skip-stages phases. Returns an error for an
+// unknown phase name.
+func (c Config) StagesToSkip() ([]string, error) {
+	for _, phase := range c.Core.SkipStages {
+		if !slices.Contains(ports.Phases, phase) {
+			return nil, fmt.Errorf("invalid --skip-stages %q: want one of %s", phase, strings.Join(ports.Phases, ", "))
+		}
+	}
+	return c.Core.SkipStages, nil
+}
+
+// cutStageLimit splits a "<stage>=<value>" entry; ok is false unless the
+// stage is a non-negative stage ID.
+func cutStageLimit(entry string) (stage int, value string, ok bool) {
+	key, value, perStage := cutLimit(entry)
+	if !perStage {
+		return 0, value, false
+	}
+	stage, err := strconv.Atoi(key)
+	return stage, value, err == nil && stage >= 0
+}
+
+// cutLimit splits a "<source>=<value>" limit entry; perSource is false for a
+// bare value that applies to the whole scan.
+func cutLimit(entry string) (source, value string, perSource bool) {
+	source, value, perSource = strings.Cut(entry, "=")
+	if !perSource {
+		return "", strings.TrimSpace(entry), false
+	}
+	return strings.TrimSpace(source), strings.TrimSpace(value), source != ""
+}
+
+// Budgets parses the global traffic budgets (--budget).
+func (c Config) Budgets() (map[budget.Kind]int64, error) {
+	return budget.ParseLimits(c.Network.Budget)
+}
+
+// Politeness parses the per-host probing limits (--host-concurrency,
+// --host-delay).
+func (c Config) Politeness() (politeness.Policy, error) {
+	return politeness.ParsePolicy(c.Network.HostConcurrency, c.Network.HostDelay)
+}
+
+// BrowserPool returns the settings of the shared headless browser
+// (--chrome-path, --browser-tabs, --browser-timeout). The browser goes
+// through --proxy like every other request.
+func (c Config) BrowserPool() browser.Config {
+	return browser.Config{
+		ExecPath:    c.Browser.ExecPath,
+		MaxTabs:     c.Browser.MaxTabs,
+		PageTimeout: c.Browser.PageTimeout,
+		ProxyURL:    c.Network.ProxyURL,
+	}
+}
+
+// OOBClient returns the settings of the shared out-of-band callback client
+// (--oob-server, --oob-token, --oob-wait).
+func (c Config) OOBClient() oob.Config {
+	return oob.Config{
+		Server: c.OOB.Server,
+		Token:  c.OOB.Token,
+		Wait:   c.OOB.Wait,
+	}
+}
+
+// ActiveWindow parses the window active stages are restricted to
+// (--active-window, --active-window-tz); nil means no restriction.
+func (c Config) ActiveWindow() (*domain.TimeWindow, error) {
+	if strings.TrimSpace(c.Lifecycle.ActiveWindow) == "" {
+		return nil, nil
+	}
+	window, err := domain.ParseTimeWindow(c.Lifecycle.ActiveWindow, c.Lifecycle.ActiveWindowTZ)
+	if err != nil {
+		return nil, err
+	}
+	return &window, nil
+}
+
+// Compression parses the output compression (--compress).
+func (c Config) Compression() (compress.Codec, error) {
+	return compress.Parse(c.Output.Compress)
+}
+
+// MemoryBudgetBytes resolves the streaming memory budget (--memory-budget)
+// to bytes; 0 means no budget.
+func (c Config) MemoryBudgetBytes() (int64, error) {
+	return adaptive.ParseMemoryBudget(c.Streaming.MemoryBudget)
+}
+
+// OutputFilter builds the artifact filter for exports from the output settings.
+// Returns an error if an unknown artifact type was requested.
+func (c Config) OutputFilter() (domain.ArtifactFilter, error) {
+	filter := domain.ArtifactFilter{
+		Tags:          c.Output.Tags,
+		OnlyAlive:     c.Output.OnlyAlive,
+		MinConfidence: c.Output.MinConfidence,
+	}
+	for _, name := range c.Output.OnlyTypes {
+		t := domain.ArtifactType(name)
+		if !t.IsValid() {
+			return filter, fmt.Errorf("unknown artifact type in --only-types: %q", name)
+		}
+		filter.Types = append(filter.Types, t)
+	}
+	return filter, nil
+}
+
+// RedactionFormats are the outputs a redaction profile can be set for:
+// the consolidated JSON, the filtered JSON export, the terminal table, the
+// PDF report, the Parquet export, the hand-off files for Burp, ZAP, Aquatone
+// and EyeWitness and the findings sent to vulnerability management platforms.
+var RedactionFormats = []string{"json", "filtered", "table", "pdf", "parquet", "proxy", "export"}
+
+// RedactionProfiles resolves --redact into a profile per output format.
+// A bare profile applies to every format; "<format>=<profile>" overrides one.
+// Returns an error for unknown formats or profiles.
+func (c Config) RedactionProfiles() (map[string]domain.RedactionProfile, error) {
+	profiles := make(map[string]domain.RedactionProfile, len(RedactionFormats))
+	for _, format := range RedactionFormats {
+		profiles[format] = domain.RedactionFull
+	}
+
+	// Bare profiles first so per-format entries win regardless of order
+	var perFormat []string
+	for _, entry := range c.Output.Redact {
+		if strings.Contains(entry, "=") {
+			perFormat = append(perFormat, entry)
+			continue
+		}
+		profile, err := domain.ParseRedactionProfile(entry)
+		if err != nil {
+			return nil, err
+		}
+		for _, format := range RedactionFormats {
+			profiles[format] = profile
+		}
+	}
+
+	for _, entry := range perFormat {
+		format, name, _ := strings.Cut(entry, "=")
+		format = strings.TrimSpace(format)
+		if _, ok := profiles[format]; !ok {
+			return nil, fmt.Errorf("unknown output format in --redact: %q (valid: %s)", format, strings.Join(RedactionFormats, ", "))
+		}
+		profile, err := domain.ParseRedactionProfile(name)
+		if err != nil {
+			return nil, err
+		}
+		profiles[format] = profile
+	}
+
+	return profiles, nil
+}
+
+// CloneSources returns a copy of the source config map with independent Custom maps.
+// Needed when several scans share a base Config (e.g., dashboard-launched scans).
+func CloneSources(src map[string]ports.SourceConfig) map[string]ports.SourceConfig {
+	out := make(map[string]ports.SourceConfig, len(src))
+	for name, sc := range src {
+		custom := make(map[string]interface{}, len(sc.Custom))
+		for k, v := range sc.Custom {
+			custom[k] = v
+		}
+		sc.Custom = custom
+		out[name] = sc
+	}
+	return out
+}
+
 // Helpers
 
 func getenv(k, def string) string {
 	if v, ok := os.LookupEnv(k); ok {
 		return v
 	}
+	if v, ok := envOverlay[k]; ok {
+		return v
+	}
 	return def
 }
 
@@ -504,6 +1938,38 @@ func parseBool(v string) bool {
 	}
 }
 
+// parseCSV splits a comma-separated list.
+func parseCSV(v string) []string {
+	return strings.Split(v, ",")
+}
+
+// normalizeList trims entries, drops empty ones and optionally lowercases them.
+func normalizeList(in []string, lower bool) []string {
+	var out []string
+	for _, v := range in {
+		v = strings.TrimSpace(v)
+		if lower {
+			v = strings.ToLower(v)
+		}
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// parseIntList parses a comma-separated list of integers, skipping invalid
+// entries.
+func parseIntList(v string) []int {
+	var out []int
+	for _, item := range parseCSV(v) {
+		if i, err := strconv.Atoi(strings.TrimSpace(item)); err == nil {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
 func parseInt(v string, def int) int {
 	i, err := strconv.Atoi(strings.TrimSpace(v))
 	if err != nil {
@@ -2,9 +2,18 @@
 package config
 
 import (
+	"bytes"
 	"flag"
 	"os"
+	"strings"
 	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/browser"
+	"aethonx/internal/platform/budget"
+	"aethonx/internal/platform/oob"
 
 	"github.com/spf13/pflag"
 )
@@ -399,6 +408,8 @@ func TestLoad_FromEnv(t *testing.T) {
 	os.Setenv("AETHONX_SOURCES_RDAP_ENABLED", "true")
 	os.Setenv("AETHONX_OUTPUTS_TABLE_DISABLED", "false")
 	os.Setenv("AETHONX_PROXY_URL", "http://proxy.example.com:8080")
+	os.Setenv("AETHONX_WEBHOOKS", "https://hooks.example.com/a,https://hooks.example.com/b")
+	os.Setenv("AETHONX_EVENT_SPOOL", "spool")
 
 	defer func() {
 		os.Unsetenv("AETHONX_TARGET")
@@ -410,6 +421,8 @@ func TestLoad_FromEnv(t *testing.T) {
 		os.Unsetenv("AETHONX_SOURCES_RDAP_ENABLED")
 		os.Unsetenv("AETHONX_OUTPUTS_TABLE_DISABLED")
 		os.Unsetenv("AETHONX_PROXY_URL")
+		os.Unsetenv("AETHONX_WEBHOOKS")
+		os.Unsetenv("AETHONX_EVENT_SPOOL")
 	}()
 
 	// Simulate no CLI arguments (only ENV)
@@ -448,6 +461,9 @@ func TestLoad_FromEnv(t *testing.T) {
 	if cfg.Network.ProxyURL != "http://proxy.example.com:8080" {
 		t.Errorf("ProxyURL: expected %q, got %q", "http://proxy.example.com:8080", cfg.Network.ProxyURL)
 	}
+	if len(cfg.Notify.Webhooks) != 2 || cfg.Notify.SpoolDir != "spool" {
+		t.Errorf("Notify: expected 2 webhooks and spool dir, got %+v", cfg.Notify)
+	}
 }
 
 func TestLoad_Defaults(t *testing.T) {
@@ -513,4 +529,300 @@ func TestLoad_Defaults(t *testing.T) {
 	if cfg.Network.ProxyURL != "" {
 		t.Errorf("ProxyURL: expected empty, got %q", cfg.Network.ProxyURL)
 	}
+	if strings.Join(cfg.Core.FailOn, ",") != "source-error,timeout,empty" {
+		t.Errorf("FailOn: expected source-error,timeout,empty, got %v", cfg.Core.FailOn)
+	}
+}
+
+func TestConfig_OutputFilter(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Output.OnlyTypes = []string{" URL ", "subdomain", ""}
+	cfg.Output.Tags = []string{"internal", " "}
+	cfg.Output.MinConfidence = 1.5
+	cfg.Output.OnlyAlive = true
+	normalize(&cfg)
+
+	filter, err := cfg.OutputFilter()
+	if err != nil {
+		t.Fatalf("OutputFilter() failed: %v", err)
+	}
+	if len(filter.Types) != 2 || filter.Types[0] != "url" {
+		t.Errorf("Types: expected [url subdomain], got %v", filter.Types)
+	}
+	if len(filter.Tags) != 1 || filter.Tags[0] != "internal" {
+		t.Errorf("Tags: expected [internal], got %v", filter.Tags)
+	}
+	if filter.MinConfidence != 1 {
+		t.Errorf("MinConfidence: expected clamp to 1, got %v", filter.MinConfidence)
+	}
+	if !filter.OnlyAlive {
+		t.Error("OnlyAlive: expected true")
+	}
+
+	cfg.Output.OnlyTypes = []string{"bogus"}
+	if _, err := cfg.OutputFilter(); err == nil {
+		t.Error("expected error for unknown artifact type")
+	}
+}
+
+func TestConfig_RedactionProfiles(t *testing.T) {
+	cfg := DefaultConfig()
+	profiles, err := cfg.RedactionProfiles()
+	if err != nil {
+		t.Fatalf("RedactionProfiles() failed: %v", err)
+	}
+	if profiles["json"] != "full" || profiles["table"] != "full" {
+		t.Errorf("default should be full everywhere, got %v", profiles)
+	}
+
+	cfg.Output.Redact = []string{"json=full", " Client-Safe "}
+	normalize(&cfg)
+	profiles, err = cfg.RedactionProfiles()
+	if err != nil {
+		t.Fatalf("RedactionProfiles() failed: %v", err)
+	}
+	if profiles["json"] != "full" || profiles["filtered"] != "client-safe" || profiles["table"] != "client-safe" {
+		t.Errorf("per-format entry should override the bare profile, got %v", profiles)
+	}
+
+	for _, bad := range []string{"html=full", "public", "table=public"} {
+		cfg.Output.Redact = []string{bad}
+		if _, err := cfg.RedactionProfiles(); err == nil {
+			t.Errorf("expected error for --redact %q", bad)
+		}
+	}
+}
+
+func TestConfig_ScanLimits(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Core.MaxArtifacts = []string{"100000", "Waybackurls=50000", "*=20000"}
+	cfg.Core.MaxDuration = []string{"2h", "amass=30m"}
+	normalize(&cfg)
+
+	limits, err := cfg.ScanLimits()
+	if err != nil {
+		t.Fatalf("ScanLimits() failed: %v", err)
+	}
+	if limits.MaxArtifacts != 100000 || limits.MaxDuration != 2*time.Hour {
+		t.Errorf("scan caps: got %d / %s", limits.MaxArtifacts, limits.MaxDuration)
+	}
+	if limits.SourceMaxArtifacts["waybackurls"] != 50000 || limits.SourceMaxArtifacts["*"] != 20000 {
+		t.Errorf("per-source artifact caps: got %v", limits.SourceMaxArtifacts)
+	}
+	if limits.SourceMaxDuration["amass"] != 30*time.Minute {
+		t.Errorf("per-source duration caps: got %v", limits.SourceMaxDuration)
+	}
+
+	for _, bad := range [][2][]string{
+		{{"0"}, nil},
+		{{"amass=lots"}, nil},
+		{nil, {"forever"}},
+		{nil, {"amass=-1m"}},
+	} {
+		cfg.Core.MaxArtifacts, cfg.Core.MaxDuration = bad[0], bad[1]
+		if _, err := cfg.ScanLimits(); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+}
+
+func TestConfig_ScanLimitsStages(t *testing.T) {
+	t.Setenv("AETHONX_STAGE_TIMEOUT", "0=5m, 1=2h")
+	cfg := DefaultConfig()
+	loadFromEnv(&cfg)
+	cfg.Core.StageWorkers = []string{"1=4"}
+	normalize(&cfg)
+
+	limits, err := cfg.ScanLimits()
+	if err != nil {
+		t.Fatalf("ScanLimits() failed: %v", err)
+	}
+	if limits.StageMaxDuration[0] != 5*time.Minute || limits.StageMaxDuration[1] != 2*time.Hour {
+		t.Errorf("stage timeouts: got %v", limits.StageMaxDuration)
+	}
+	if limits.StageMaxWorkers[1] != 4 || len(limits.StageMaxWorkers) != 1 {
+		t.Errorf("stage workers: got %v", limits.StageMaxWorkers)
+	}
+
+	for _, bad := range [][2][]string{
+		{{"5m"}, nil},
+		{{"first=5m"}, nil},
+		{{"-1=5m"}, nil},
+		{nil, {"1=0"}},
+		{nil, {"1=many"}},
+	} {
+		cfg.Core.StageTimeout, cfg.Core.StageWorkers = bad[0], bad[1]
+		if _, err := cfg.ScanLimits(); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+}
+
+func TestConfig_ScanLimitsSample(t *testing.T) {
+	t.Setenv("AETHONX_SAMPLE", "25")
+	cfg := DefaultConfig()
+	loadFromEnv(&cfg)
+
+	limits, err := cfg.ScanLimits()
+	if err != nil {
+		t.Fatalf("ScanLimits() failed: %v", err)
+	}
+	if limits.Sample != 25 {
+		t.Errorf("sample: got %d, want 25", limits.Sample)
+	}
+
+	cfg.Core.Sample = -1
+	if _, err := cfg.ScanLimits(); err == nil {
+		t.Error("expected error for a negative sample")
+	}
+}
+
+func TestConfig_Budgets(t *testing.T) {
+	cfg := DefaultConfig()
+	limits, err := cfg.Budgets()
+	if err != nil || len(limits) != 0 {
+		t.Errorf("default should have no budget, got %v (%v)", limits, err)
+	}
+
+	cfg.Network.Budget = []string{"requests=5000", "dns=20000"}
+	limits, err = cfg.Budgets()
+	if err != nil {
+		t.Fatalf("Budgets() failed: %v", err)
+	}
+	if limits[budget.Requests] != 5000 || limits[budget.DNSQueries] != 20000 {
+		t.Errorf("unexpected budgets: %v", limits)
+	}
+
+	cfg.Network.Budget = []string{"packets=10"}
+	if _, err := cfg.Budgets(); err == nil {
+		t.Error("expected error for unknown budget kind")
+	}
+}
+
+func TestConfig_Politeness(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Network.HostConcurrency = []string{"4", "legacy.example.com=1"}
+	cfg.Network.HostDelay = []string{"250ms"}
+
+	policy, err := cfg.Politeness()
+	if err != nil {
+		t.Fatalf("Politeness() failed: %v", err)
+	}
+	if l := policy.For("app.legacy.example.com"); l.Concurrency != 1 || l.Delay != 250*time.Millisecond {
+		t.Errorf("unexpected limits for a scope entry subdomain: %+v", l)
+	}
+
+	cfg.Network.HostDelay = []string{"legacy.example.com=fast"}
+	if _, err := cfg.Politeness(); err == nil {
+		t.Error("expected error for invalid --host-delay")
+	}
+}
+
+func TestConfig_BrowserPool(t *testing.T) {
+	t.Setenv("AETHONX_CHROME_PATH", "/opt/chromium/chrome")
+	t.Setenv("AETHONX_BROWSER_TABS", "2")
+	t.Setenv("AETHONX_BROWSER_TIMEOUT", "45s")
+	cfg := DefaultConfig()
+	loadFromEnv(&cfg)
+	cfg.Network.ProxyURL = "http://127.0.0.1:8080"
+
+	pool := cfg.BrowserPool()
+	if pool.ExecPath != "/opt/chromium/chrome" || pool.MaxTabs != 2 || pool.PageTimeout != 45*time.Second {
+		t.Errorf("unexpected browser settings: %+v", pool)
+	}
+	if pool.ProxyURL != cfg.Network.ProxyURL {
+		t.Errorf("browser should use --proxy, got %q", pool.ProxyURL)
+	}
+
+	cfg.Browser.MaxTabs = 0
+	normalize(&cfg)
+	if cfg.Browser.MaxTabs != browser.DefaultMaxTabs {
+		t.Errorf("invalid tab limit should fall back to the default, got %d", cfg.Browser.MaxTabs)
+	}
+}
+
+func TestConfig_OOBClient(t *testing.T) {
+	t.Setenv("AETHONX_OOB_SERVER", " https://interact.example.net ")
+	t.Setenv("AETHONX_OOB_TOKEN", "s3cret")
+	t.Setenv("AETHONX_OOB_WAIT", "10s")
+	cfg := DefaultConfig()
+	loadFromEnv(&cfg)
+	normalize(&cfg)
+
+	client := cfg.OOBClient()
+	if client.Server != "https://interact.example.net" || client.Token != "s3cret" || client.Wait != 10*time.Second {
+		t.Errorf("unexpected oob settings: %+v", client)
+	}
+
+	cfg.OOB.Wait = 0
+	normalize(&cfg)
+	if cfg.OOB.Wait != oob.DefaultWait {
+		t.Errorf("invalid wait should fall back to the default, got %s", cfg.OOB.Wait)
+	}
+}
+
+func TestConfig_ActiveWindow(t *testing.T) {
+	cfg := DefaultConfig()
+	if w, err := cfg.ActiveWindow(); err != nil || w != nil {
+		t.Errorf("default: expected no window, got %v (err=%v)", w, err)
+	}
+
+	cfg.Lifecycle.ActiveWindow = "22:00-06:00"
+	cfg.Lifecycle.ActiveWindowTZ = "Europe/Madrid"
+	w, err := cfg.ActiveWindow()
+	if err != nil {
+		t.Fatalf("ActiveWindow() failed: %v", err)
+	}
+	if w.String() != "22:00-06:00 Europe/Madrid" {
+		t.Errorf("unexpected window: %s", w)
+	}
+
+	cfg.Lifecycle.ActiveWindow = "late"
+	if _, err := cfg.ActiveWindow(); err == nil {
+		t.Error("expected error for invalid --active-window")
+	}
+}
+
+func TestConfig_NormalizationPolicy(t *testing.T) {
+	cfg := DefaultConfig()
+	if p, err := cfg.NormalizationPolicy(); err != nil || p != "strict" {
+		t.Errorf("default policy: expected strict, got %q (err=%v)", p, err)
+	}
+
+	cfg.Core.Normalization = " Aggressive "
+	normalize(&cfg)
+	if p, err := cfg.NormalizationPolicy(); err != nil || p != "aggressive" {
+		t.Errorf("expected aggressive, got %q (err=%v)", p, err)
+	}
+
+	cfg.Core.Normalization = "loose"
+	if _, err := cfg.NormalizationPolicy(); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+}
+
+func TestWriteSourcesHelp(t *testing.T) {
+	var buf bytes.Buffer
+	WriteSourcesHelp(&buf, map[string]ports.SourceMetadata{
+		"tool": {
+			Mode:        domain.SourceModePassive,
+			Type:        domain.SourceTypeCLI,
+			Description: "Example tool",
+			ConfigSchema: []ports.ConfigField{
+				{Name: "threads", Type: ports.ConfigTypeInt, Default: 10, Description: "Concurrent workers"},
+				{Name: "token", Type: ports.ConfigTypeString, Required: true},
+			},
+		},
+		"plain": {Mode: domain.SourceModePassive, Type: domain.SourceTypeAPI},
+	})
+
+	out := buf.String()
+	for _, want := range []string{"tool (passive, cli)", "threads", "Concurrent workers", "required", "No options"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("sources help missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "plain") > strings.Index(out, "tool") {
+		t.Error("sources must be sorted by name")
+	}
 }
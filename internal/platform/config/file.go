@@ -0,0 +1,162 @@
+// internal/platform/config/file.go
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"aethonx/internal/core/ports"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configFile is the layout of the YAML config file (--config / AETHONX_CONFIG).
+// It holds per-source settings and the skipped and critical stages;
+// precedence is defaults < file < ENV < flags.
+//
+//	skip_stages: [verification, enrichment]
+//	critical_stages: [0]
+//	sources:
+//	  httpx:
+//	    enabled: true
+//	    timeout: 2m
+//	    custom:
+//	      threads: 50
+type configFile struct {
+	// SkipStages and CriticalStages are the config file equivalents of
+	// --skip-stages and --critical-stages
+	SkipStages     []string `yaml:"skip_stages"`
+	CriticalStages []int    `yaml:"critical_stages"`
+
+	Sources map[string]configFileSource `yaml:"sources"`
+}
+
+// configFileSource mirrors ports.SourceConfig; unset fields keep their defaults.
+type configFileSource struct {
+	Enabled   *bool                  `yaml:"enabled"`
+	Priority  *int                   `yaml:"priority"`
+	Timeout   string                 `yaml:"timeout"` // Go duration, e.g. "90s"
+	Retries   *int                   `yaml:"retries"`
+	RateLimit *int                   `yaml:"rate_limit"`
+	CacheTTL  string                 `yaml:"cache_ttl"` // Go duration; reuse results this long ("0" = no cache)
+	Custom    map[string]interface{} `yaml:"custom"`
+
+	// NegativeCacheTTL remembers empty or "not found" results this long
+	NegativeCacheTTL string `yaml:"negative_cache_ttl"`
+
+	// Critical aborts the scan when the source fails (fail-fast)
+	Critical *bool `yaml:"critical"`
+}
+
+// configFileName returns the config file requested via --config (args) or
+// AETHONX_CONFIG. Like the workspace, it must be known before ENV is loaded.
+func configFileName(args []string) string {
+	if v := argValue(args, "config"); v != "" {
+		return v
+	}
+	return getenv("AETHONX_CONFIG", "")
+}
+
+// applyConfigFile merges the YAML config file into cfg. Unknown top-level or
+// per-source fields are rejected; Custom keys are checked later against each
+// source's ConfigSchema ("aethonx config validate").
+func applyConfigFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var file configFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	for name, fs := range file.Sources {
+		sc, ok := cfg.Source.Sources[name]
+		if !ok {
+			sc = ports.DefaultSourceConfig()
+		}
+		if sc.Custom == nil {
+			sc.Custom = make(map[string]interface{})
+		}
+
+		if fs.Enabled != nil {
+			sc.Enabled = *fs.Enabled
+		}
+		if fs.Priority != nil {
+			sc.Priority = *fs.Priority
+		}
+		if fs.Timeout != "" {
+			d, err := time.ParseDuration(fs.Timeout)
+			if err != nil {
+				return fmt.Errorf("config file %s: sources.%s.timeout: %w", path, name, err)
+			}
+			sc.Timeout = d
+		}
+		if fs.Retries != nil {
+			sc.Retries = *fs.Retries
+		}
+		if fs.RateLimit != nil {
+			sc.RateLimit = *fs.RateLimit
+		}
+		if fs.CacheTTL != "" {
+			d, err := time.ParseDuration(fs.CacheTTL)
+			if err != nil {
+				return fmt.Errorf("config file %s: sources.%s.cache_ttl: %w", path, name, err)
+			}
+			sc.CacheTTL = d
+		}
+		if fs.NegativeCacheTTL != "" {
+			d, err := time.ParseDuration(fs.NegativeCacheTTL)
+			if err != nil {
+				return fmt.Errorf("config file %s: sources.%s.negative_cache_ttl: %w", path, name, err)
+			}
+			sc.NegativeCacheTTL = d
+		}
+		if fs.Critical != nil {
+			sc.Critical = *fs.Critical
+		}
+		for k, v := range fs.Custom {
+			sc.Custom[k] = v
+		}
+
+		cfg.Source.Sources[name] = sc
+	}
+
+	if file.SkipStages != nil {
+		cfg.Core.SkipStages = file.SkipStages
+	}
+	if file.CriticalStages != nil {
+		cfg.Core.CriticalStages = file.CriticalStages
+	}
+
+	cfg.Core.ConfigFile = path
+	return nil
+}
+
+// FromFile returns defaults overridden by the YAML config file at path
+// ("" = AETHONX_CONFIG, if set) and ENV, without flag parsing.
+// Used by subcommands that inspect the configuration (e.g., "aethonx config").
+func FromFile(path string) (Config, error) {
+	cfg := DefaultConfig()
+	if err := applyProfile(&cfg, getenv("AETHONX_PROFILE", "")); err != nil {
+		return cfg, err
+	}
+	if path == "" {
+		path = getenv("AETHONX_CONFIG", "")
+	}
+	if path != "" {
+		if err := applyConfigFile(&cfg, path); err != nil {
+			return cfg, err
+		}
+	}
+	loadFromEnv(&cfg)
+	normalize(&cfg)
+	return cfg, nil
+}
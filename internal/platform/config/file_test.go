@@ -0,0 +1,177 @@
+// internal/platform/config/file_test.go
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "aethonx.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFromFile(t *testing.T) {
+	t.Setenv("AETHONX_CONFIG", "")
+	t.Setenv("AETHONX_SOURCES_HTTPX_THREADS", "")
+	path := writeConfigFile(t, `
+sources:
+  httpx:
+    timeout: 90s
+    custom:
+      threads: 50
+  shodan:
+    enabled: true
+    cache_ttl: 6h
+    custom:
+      api_key: abc
+  crtsh:
+    negative_cache_ttl: 0
+  custom_src:
+    priority: 3
+`)
+
+	cfg, err := FromFile(path)
+	if err != nil {
+		t.Fatalf("FromFile() failed: %v", err)
+	}
+
+	httpx := cfg.Source.Sources["httpx"]
+	if httpx.Timeout != 90*time.Second {
+		t.Errorf("httpx timeout = %v, want 90s", httpx.Timeout)
+	}
+	if httpx.Custom["threads"] != 50 {
+		t.Errorf("httpx threads = %v, want 50", httpx.Custom["threads"])
+	}
+	if httpx.Custom["profile"] != "full" {
+		t.Errorf("defaults not kept for unset keys: profile = %v", httpx.Custom["profile"])
+	}
+	if shodan := cfg.Source.Sources["shodan"]; !shodan.Enabled || shodan.Custom["api_key"] != "abc" {
+		t.Errorf("shodan not configured from file: %+v", shodan)
+	}
+	if ttl := cfg.Source.Sources["shodan"].CacheTTL; ttl != 6*time.Hour {
+		t.Errorf("shodan cache_ttl = %v, want 6h", ttl)
+	}
+	if ttl := cfg.Source.Sources["crtsh"].NegativeCacheTTL; ttl != 0 {
+		t.Errorf("crtsh negative_cache_ttl = %v, want 0 (disabled)", ttl)
+	}
+	if ttl := cfg.Source.Sources["rdap"].NegativeCacheTTL; ttl != time.Hour {
+		t.Errorf("rdap negative_cache_ttl = %v, want the 1h default", ttl)
+	}
+	if _, ok := cfg.Source.Sources["custom_src"]; !ok {
+		t.Error("sources not in defaults must be kept for validation")
+	}
+	if cfg.Core.ConfigFile != path {
+		t.Errorf("ConfigFile = %q, want %q", cfg.Core.ConfigFile, path)
+	}
+
+	// ENV overrides the file
+	t.Setenv("AETHONX_SOURCES_HTTPX_THREADS", "20")
+	cfg, _ = FromFile(path)
+	if cfg.Source.Sources["httpx"].Custom["threads"] != 20 {
+		t.Errorf("ENV must override the file, got %v", cfg.Source.Sources["httpx"].Custom["threads"])
+	}
+}
+
+func TestFromFile_SkipStages(t *testing.T) {
+	t.Setenv("AETHONX_CONFIG", "")
+	t.Setenv("AETHONX_SKIP_STAGES", "")
+	path := writeConfigFile(t, "skip_stages: [Verification, enrichment]\n")
+
+	cfg, err := FromFile(path)
+	if err != nil {
+		t.Fatalf("FromFile() failed: %v", err)
+	}
+	skip, err := cfg.StagesToSkip()
+	if err != nil || strings.Join(skip, ",") != "verification,enrichment" {
+		t.Errorf("StagesToSkip() = %v, %v", skip, err)
+	}
+
+	// ENV overrides the file
+	t.Setenv("AETHONX_SKIP_STAGES", "enrichment")
+	cfg, _ = FromFile(path)
+	if strings.Join(cfg.Core.SkipStages, ",") != "enrichment" {
+		t.Errorf("ENV must override the file, got %v", cfg.Core.SkipStages)
+	}
+
+	cfg.Core.SkipStages = []string{"verify"}
+	if _, err := cfg.StagesToSkip(); err == nil {
+		t.Error("expected error for an unknown phase")
+	}
+}
+
+func TestFromFile_Critical(t *testing.T) {
+	t.Setenv("AETHONX_CONFIG", "")
+	t.Setenv("AETHONX_CRITICAL_STAGES", "")
+	path := writeConfigFile(t, `
+critical_stages: [0]
+sources:
+  crtsh:
+    enabled: true
+    critical: true
+  rdap:
+    enabled: false
+    critical: true
+`)
+
+	cfg, err := FromFile(path)
+	if err != nil {
+		t.Fatalf("FromFile() failed: %v", err)
+	}
+	if got := cfg.CriticalSources(); strings.Join(got, ",") != "crtsh" {
+		t.Errorf("CriticalSources() = %v, want only the enabled crtsh", got)
+	}
+	if len(cfg.Core.CriticalStages) != 1 || cfg.Core.CriticalStages[0] != 0 {
+		t.Errorf("CriticalStages = %v, want [0]", cfg.Core.CriticalStages)
+	}
+
+	// ENV overrides the file
+	t.Setenv("AETHONX_CRITICAL_STAGES", "1, 2")
+	t.Setenv("AETHONX_SOURCES_CRTSH_CRITICAL", "false")
+	cfg, _ = FromFile(path)
+	if len(cfg.Core.CriticalStages) != 2 || cfg.Core.CriticalStages[1] != 2 {
+		t.Errorf("ENV must override the file, got %v", cfg.Core.CriticalStages)
+	}
+	if got := cfg.CriticalSources(); len(got) != 0 {
+		t.Errorf("CriticalSources() = %v, want none", got)
+	}
+}
+
+func TestFromFile_Errors(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":    "sources:\n  httpx:\n    enabld: true\n",
+		"invalid duration": "sources:\n  httpx:\n    timeout: soon\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := FromFile(writeConfigFile(t, content)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	_, err := FromFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err == nil || !strings.Contains(err.Error(), "failed to read config file") {
+		t.Errorf("expected read error, got %v", err)
+	}
+}
+
+func TestConfigFileName(t *testing.T) {
+	t.Setenv("AETHONX_CONFIG", "env.yaml")
+	if got := configFileName([]string{"--config", "a.yaml"}); got != "a.yaml" {
+		t.Errorf("expected a.yaml, got %q", got)
+	}
+	if got := configFileName([]string{"--config=b.yaml"}); got != "b.yaml" {
+		t.Errorf("expected b.yaml, got %q", got)
+	}
+	if got := configFileName(nil); got != "env.yaml" {
+		t.Errorf("expected env fallback, got %q", got)
+	}
+}
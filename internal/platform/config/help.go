@@ -3,24 +3,61 @@ package config
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"aethonx/internal/core/ports"
 )
 
 const helpText = `AethonX - Modular Reconnaissance Engine
 
 USAGE
   aethonx -t <domain> [options]
+  aethonx <command> [options]
 
   Note: Use double dash (--) for long flags, single dash (-) for short flags
         Example: --target or -t (not -target)
 
+COMMANDS
+  serve                    Web dashboard + REST API (--addr, -o, --read-only)
+  graph                    Path queries over a saved scan (--scan, --query | --from/--to)
+  artifacts                List a saved scan's artifacts (--scan, --type, --tag, --sort, --format, --redact)
+  workspace                Manage workspaces (list, create <name>, clean <name>)
+  verify                   Check a signed scan and show its metadata (--scan, --pubkey)
+  config validate          Check config, source options, binaries and API keys (--config)
+  sources                  List sources: mode, stage, inputs/outputs, auth, install status (--format)
+  doctor                   Health-check enabled sources: init, validate, live call, versions, API quota
+  deps                     Source binaries: check, install, update, bundle (--all, --dir, --force, --upgrade)
+  agent                    Remote worker for distributed scans (--listen, --cert, --key, --client-ca)
+
 CORE OPTIONS
   -t, --target <domain>    Target domain (required)
   -a, --active             Active reconnaissance mode (default: passive)
   -w, --workers <int>      Concurrent workers (default: 16)
+      --provider-concurrency <int>
+                           Requests in flight to an upstream provider (crt.sh, Shodan...)
+                           shared by sources of a stage (default: 1)
   -o, --out <path>         Output directory (default: aethonx_out)
-  -q, --quiet              JSON only, no visual UI
+      --filename-template <tmpl>
+                           Output file names inside <out>/<target>/ (without extension).
+                           Variables: {target}, {date}, {scan_id}, {format}; without
+                           {format} each output keeps its suffix (_filtered, _report, ...).
+                           Default: aethonx_{target}_{date}
+      --stdout             Also write the consolidated JSON to stdout as one line for
+                           piping (aethonx -t example.com --stdout | jq ...); implies -q
+      --profile <name>     Scan depth preset (default: standard):
+                             quick     crt.sh, RDAP, DNS, subfinder; basic httpx probe,
+                                       no archived URL verification, 60s timeout
+                             standard  default sources and settings
+                             deep      + ASN expansion, passive DNS, katana, robots,
+                                       amass brute force, body hashes, long timeouts
+                           Config file, ENV and explicit flags override the preset
+  -q, --quiet              JSON only, no visual UI (errors only on stderr)
+  -v, --verbose            Verbose logs on stderr (-v info, -vv debug)
 
 SOURCES
   --src.crtsh              Certificate Transparency logs (default: enabled)
@@ -28,36 +65,268 @@ SOURCES
   --src.subfinder          Multi-source subdomain discovery (default: enabled)
   --src.amass              OWASP Amass enumeration (default: enabled)
   --src.httpx              HTTP probing (default: enabled)
+  --src.dns                A/AAAA resolution of discovered hosts (default: enabled)
 
   Disable with: --src.<name>=false
+  Per-source options: aethonx --help-sources
 
 ADVANCED
   -T, --timeout <sec>      Global timeout in seconds (default: 30, 0=none)
+      --config <file>      YAML config with per-source settings (sources.<name>.enabled,
+                           priority, timeout, retries, rate_limit, custom)
+      --workspace <name>   Use a workspace: its config.env, scope.txt and tags.yaml apply
+                           and scans are stored in its scans/ directory
+      --normalization <p>  Domain normalization: strict (default, keeps www.),
+                           aggressive (collapses www.example.com into example.com)
+      --no-pivot           Never run pivot sources that look beyond the target
+                           (reversewhois: other domains of the same registrant)
+      --skip-stages <list> Scan phases whose sources are skipped: discovery,
+                           verification, enrichment (e.g. verification,enrichment
+                           for a fast discovery-only run)
+      --plan               Print the stages the scan would run and the skipped
+                           ones, then exit without scanning
+      --critical-stages <ids>
+                           Stage IDs (0 = first stage) where any source failure
+                           aborts the scan (fail-fast) instead of going on; single
+                           sources use critical: true in --config
+      --import <file>      Fuse existing tool output into the scan without rescanning
+                           (nmap -oX XML, AethonX scan JSON, aquatone_session.json,
+                           EyeWitness Requests.csv; repeatable)
+      --previous-scan <f>  Re-inject the assets of a prior scan of the target (JSON or
+                           "latest") as historical, so httpx and DNS re-check them
+      --fail-on <list>     Outcomes that exit non-zero (default: source-error,timeout,empty):
+                           source-error, timeout, empty, new-risk[=<severity>] (new or
+                           reappeared findings >= severity, default medium; needs
+                           --track-lifecycle) or none
+      --max-artifacts <n>  Hard artifact cap for the whole scan (<n>) or per source
+                           (<source>=<n>, *=<n> for every source); repeatable
+      --max-duration <d>   Hard duration cap for the whole scan (<d>) or per source
+                           (<source>=<d>, *=<d>). A capped source is cancelled, its
+                           results are kept and the report marks the scan truncated
+      --stage-timeout <stage>=<d>
+                           Duration cap for one stage, by stage ID (0 = first stage);
+                           replaces -T for that stage. Its remaining sources are
+                           cancelled at the deadline and the scan goes on
+      --stage-workers <stage>=<n>
+                           Concurrent workers for one stage (overrides -w for it)
+      --sample <n>         Preview mode: stop every source after <n> artifacts to check
+                           the configuration and what a deep scan would find
   -s, --streaming <int>    Memory threshold for disk writes (default: 1000)
+      --memory-budget <size>
+                           Also write to disk when held artifacts exceed an approximate
+                           size: 512MB, 2GB or a percentage of system RAM (25%)
   -r, --retries <int>      Max retries per source (default: 3)
   -p, --proxy <url>        HTTP/S proxy URL
+      --upstream-rate <u>  Shared budget per upstream for every source and concurrent scan:
+                           <upstream>=<rps>[/<burst>], e.g. crt.sh=1, rdap.org=5/2 (repeatable)
+      --budget <b>         Global traffic budget of active sources, e.g. requests=5000,
+                           dns=20000, bytes=500MB. Sources stop probing once it is spent
+                           and the scan reports what was used
+      --host-concurrency <n>
+                           Max requests in flight per destination host; <domain>=<n>
+                           overrides it for a scope entry and its subdomains
+      --host-delay <d>     Min delay between requests to a host (e.g. 500ms), or
+                           <domain>=<d> per scope entry. httpx and katana get the
+                           strictest limits of their targets as flags
+      --chrome-path <path> Chrome/Chromium binary of the headless browser shared by
+                           screenshot and rendering sources (default: look up in PATH)
+      --browser-tabs <n>   Max headless browser pages open at once (default: 4)
+      --browser-timeout <d>
+                           Max time per headless browser page (default: 30s)
+      --oob-server <url>   interactsh server for out-of-band callbacks of active checks
+                           (takeover validation, SSRF canaries), e.g. https://oast.fun
+      --oob-token <t>      Authorization token of a self-hosted interactsh server
+      --oob-wait <d>       Grace period for late callbacks after the scan (default: 5s)
       --no-ui              Disable visual UI, use plain logs
       --circuit-breaker    Enable circuit breaker (default: true)
 
 UI OPTIONS
       --ui-mode <mode>     UI mode: pretty (default), raw
+      --progress-format    json: progress events as JSON lines on stderr
+
+LOGGING
+      --log-file <path>    Also write logs to file (works with pretty UI)
+      --log-file-format    json (default), text
+      --log-file-level     debug (default), info, warn, error
+      --log-max-size <MB>  Rotate after N megabytes (default: 50, 0=none)
+      --log-max-age <dur>  Rotate after duration (default: 24h, 0=none)
+      --log-max-backups    Rotated files to keep (default: 5, 0=all)
+
+PROVENANCE
+      --include-provenance Add per-source raw value, timestamp and query/endpoint
+                           to each artifact in the JSON output (for auditing)
+
+SIGNING
+      --sign-key <file>    Sign the consolidated JSON with an ed25519 private key
+                           (PEM from 'openssl genpkey -algorithm ed25519' or base64 seed);
+                           writes <file>.json.sig next to it. Check with 'aethonx verify'
+
+REPORTS
+      --pdf                Also write <file>_report.pdf: cover page, charts of artifacts
+                           by type and source, top risks and appendix tables (honours
+                           output filters, --redact pdf=..., --encrypt and --sign-key)
+      --parquet            Also write <file>_artifacts.parquet: one row per artifact with
+                           typed columns and meta_<key> columns for the metadata, ready
+                           for DuckDB/Athena (honours output filters and --redact parquet=...)
+      --burp               Also write <file>_burp_scope.json (Burp project options with the
+                           target scope: Target > Scope > Load options) and <file>_urls.txt
+                           with the alive URLs for the site map
+      --zap                Also write <file>_zap.context (File > Import Context) and
+                           <file>_urls.txt ("Import a File Containing URLs")
+      --aquatone           Also write <file>_hosts.txt and <file>_urls.txt with the alive
+                           hosts and URLs (cat <file>_hosts.txt | aquatone)
+      --eyewitness         Also write <file>_urls.txt with the alive URLs (EyeWitness -f)
+      --capture-raw        Archive raw source outputs (httpx JSONL, amass database, crt.sh
+                           records) in <out>/raw/<scan>/; "aethonx replay" rebuilds the
+                           scan from them with the current parsers
+
+REDACTION
+      --redact <profile>   full (default) or client-safe: masks emails, contact names,
+                           phones, addresses and secret values in exported outputs.
+                           Per format: --redact json=full,filtered=client-safe,table=client-safe
+                           (formats: json, filtered, table, pdf, parquet, proxy, export)
+
+COMPRESSION
+      --compress <codec>   Write JSON outputs and streaming partials compressed with gzip
+                           or zstd (zstd binary in PATH): <file>.json.gz / .json.zst.
+                           aethonx commands read them transparently
+      --shard              Write the consolidated JSON as a directory <file>/ with
+                           artifacts/<type>.json per artifact type and an index.json
+                           manifest (counts, SHA-256); --sign-key signs index.json and
+                           aethonx artifacts only reads the shards of the --type filter
+
+ENCRYPTION
+      --encrypt <tool>     Write JSON outputs encrypted with age or gpg (binary in PATH);
+                           plaintext never touches disk and streaming partials are removed
+      --encrypt-to <r>     Recipient: age/ssh public key, gpg key ID/email, or a
+                           recipients/public key file (repeatable)
+
+OUTPUT FILTERS (consolidated JSON always keeps everything)
+      --only-types <list>  Export only these types (e.g. subdomain,url)
+      --only-alive         Export only artifacts that answered a probe
+      --min-confidence <f> Export only artifacts with confidence >= f
+      --tag <tag>          Export only artifacts with this tag (repeatable)
+
+TAGGING
+      --tag-rules <file>   YAML tagging rules applied during consolidation
+                           (match on types, value glob/value_regex, metadata)
+
+LIFECYCLE (monitor mode)
+      --track-lifecycle    Keep first/last seen per artifact in <out>/<target>/lifecycle.json
+                           and report new, reappeared, stale and removed artifacts.
+                           Also records per-scan trends (subdomains, alive hosts, open
+                           ports, expiring certs) charted in --pdf and the dashboard
+      --stale-after <n>    Missed scans before marking stale (default: 1)
+      --remove-after <n>   Missed scans before marking removed (default: 3)
+      --cert-alert-days <d,...>
+                           Fire a certificate.expiring event when a host's certificate
+                           crosses one of these days-until-expiry thresholds, and
+                           again once expired (default: 30,14,7,1)
+      --active-window <HH:MM-HH:MM>
+                           Run active stages only within this daily window (e.g.
+                           22:00-06:00); passive stages run anytime. Active stages
+                           are deferred until it opens, or skipped if the scan
+                           cannot wait (-T, --max-duration). Recorded in
+                           Metadata.Deferrals
+      --active-window-tz <zone>
+                           Time zone of the window, e.g. Europe/Madrid (default: local)
+
+NOISE
+      --suppress-noise     Drop third-party hosts whose eTLD+1 differs from the target,
+                           unless linked via CNAME or certificate SAN (default: true)
+      --exclude-apex       Drop the apex domain itself, keeping only its subdomains
+      --min-relation-confidence <f>
+                           Drop relations with confidence < f (default: 0). Relations
+                           to deduped or suppressed artifacts are always pruned
+
+NOTIFICATIONS
+      --webhook <url>      POST every scan event as JSON to this URL (repeatable)
+      --event-spool <dir>  Keep the events a webhook did not get (down, slow, queue full)
+                           and replay them, in order, at the start of the next scan
+
+VULNERABILITY MANAGEMENT
+      --defectdojo-url <url>
+                           Reimport the findings into DefectDojo after the scan
+                           (token: AETHONX_DEFECTDOJO_TOKEN)
+      --defectdojo-product <name>
+                           Product; --defectdojo-product-type creates it if missing
+      --defectdojo-engagement <name>
+                           Engagement (default: AethonX recon), created if missing
+      --faraday-url <url>  Import the findings into Faraday after the scan
+                           (token: AETHONX_FARADAY_TOKEN)
+      --faraday-workspace <name>
+                           Existing Faraday workspace
+      --export-min-severity <sev>
+                           Lowest severity exported: low (default), medium, high, critical.
+                           Keep the mapping per engagement in the workspace config.env
+      --jira-url <url>     Open a Jira ticket per new finding (needs --track-lifecycle).
+                           Token: AETHONX_JIRA_TOKEN; repeated scans do not duplicate tickets
+      --jira-user <email>  Jira Cloud account (without it the token is a PAT)
+      --jira-project <key> Project key of the tickets
+      --jira-issue-type <name>
+                           Issue type (default: Task)
+      --jira-labels <list> Extra labels for the tickets (comma-separated)
+      --jira-min-severity <sev>
+                           Lowest severity that opens a ticket (default: high)
+      --report-url <url>   Base URL where the output directory is published;
+                           tickets link the report and JSON under it
+
+DISTRIBUTED SCANS
+      --agent <url>        Remote agent (https://host:port, repeatable). Each stage assigns
+                           sources to the least loaded agent that has them; the rest, or
+                           sources whose agent is down, run locally
+      --agent-ca <file>    CA that signed the agent certificates (default: system roots)
+      --agent-cert <file>  Client certificate for mutual TLS (with --agent-key)
+                           Token: AETHONX_AGENT_TOKEN
+
+EXIT CODES
+  0  Scan completed (no --fail-on outcome matched)
+  1  Scan or output failure
+  2  Invalid configuration or setup failure
+  3  Completed with source failures             (--fail-on source-error)
+  4  Partial results: timeout or interrupted    (--fail-on timeout)
+  5  No artifacts found                         (--fail-on empty)
+  6  New risky findings since the last scan     (--fail-on new-risk)
+  When several match, the first of 6, 4, 3, 5 is returned.
 
 INFO
   -h, --help               Show this help
-  -v, --version            Version information
+  -V, --version            Version information
 
 EXAMPLES
   aethonx -t example.com                        # Passive scan (pretty UI)
   aethonx -t example.com -a -w 8                # Active scan, 8 workers
+  aethonx -t example.com --profile quick        # Fast first look
+  aethonx -t example.com -a --profile deep --src.katana=false  # Deep, minus crawling
   aethonx -t example.com -q                     # Quiet mode (CI/CD)
   aethonx -t example.com --src.httpx=false      # Disable httpx source
   aethonx -t example.com --src.amass=false      # Disable amass source
   aethonx -t example.com --src.subfinder=false  # Disable subfinder
   aethonx -t example.com --ui-mode=raw          # Raw logs (for debugging)
+  aethonx -t example.com --progress-format=json 2>events.jsonl
+  aethonx -t example.com --only-types url --only-alive  # Focused export
+  aethonx serve --addr 127.0.0.1:8080           # Dashboard over aethonx_out
+  aethonx graph --scan scan.json --query "domain:example.com -[resolves_to]-> ip"
+  aethonx workspace create acme && aethonx --workspace acme -t acme.com
+  aethonx artifacts --scan scan.json --type subdomain --tag alive --sort -confidence --format csv
+  aethonx verify --scan scan.json --pubkey client.pub   # Integrity check of a signed scan
+  aethonx config validate --config aethonx.yaml         # Find problems before scanning
+  aethonx sources --format json                        # Discover sources and their capabilities
+  aethonx doctor --source shodan --live=false          # Troubleshoot a source before scanning
+  aethonx -t example.com --encrypt age --encrypt-to age1...  # Encrypted results
+  aethonx -t example.com --pdf --redact pdf=client-safe      # Shareable PDF report
+  aethonx -t example.com --compress zstd                     # Compressed results (.json.zst)
+  aethonx -t example.com --track-lifecycle --fail-on new-risk=high  # Fail CI on new high risks
+  aethonx -t example.com --max-artifacts waybackurls=50000 --max-duration 2h  # Guardrails for huge programs
+  aethonx -t example.com --profile deep --sample 20       # Preview a deep scan in minutes
 
 ENVIRONMENT VARIABLES
   All flags support AETHONX_ prefix: AETHONX_TARGET, AETHONX_ACTIVE, etc.
   CLI flags override environment variables.
+
+  AETHONX_LOG_LEVEL accepts per-component overrides matched against the
+  "component"/"source" log fields ("sources" matches every source):
+    AETHONX_LOG_LEVEL=warn,orchestrator=debug,crtsh=debug,httpclient=error
 `
 
 // PrintHelp prints the custom help message and exits.
@@ -66,6 +335,61 @@ func PrintHelp() {
 	os.Exit(0)
 }
 
+// PrintSourcesHelp prints the registered sources and their options, then exits.
+func PrintSourcesHelp(sources map[string]ports.SourceMetadata) {
+	WriteSourcesHelp(os.Stdout, sources)
+	os.Exit(0)
+}
+
+// WriteSourcesHelp writes each source's description and ConfigSchema options.
+func WriteSourcesHelp(w io.Writer, sources map[string]ports.SourceMetadata) {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "SOURCE OPTIONS")
+	fmt.Fprintln(w, "  Set in the --config file under sources.<name>.custom.<option>")
+	for _, name := range names {
+		meta := sources[name]
+		fmt.Fprintf(w, "\n%s (%s, %s)", name, meta.Mode, meta.Type)
+		if meta.RequiresAuth {
+			fmt.Fprint(w, " [requires API key]")
+		}
+		fmt.Fprintf(w, "\n  %s\n", meta.Description)
+
+		if len(meta.ConfigSchema) == 0 {
+			fmt.Fprintln(w, "  No options")
+			continue
+		}
+
+		tw := tabwriter.NewWriter(w, 2, 4, 2, ' ', 0)
+		for _, f := range meta.ConfigSchema {
+			def := "-"
+			if f.Required {
+				def = "required"
+			} else if f.Default != nil {
+				def = formatOptionDefault(f.Default)
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", f.Name, f.Type, def, f.Description)
+		}
+		tw.Flush()
+	}
+}
+
+// formatOptionDefault renders a default value, shortening long lists.
+func formatOptionDefault(v interface{}) string {
+	list, ok := v.([]string)
+	if !ok {
+		return fmt.Sprintf("%v", v)
+	}
+	if len(list) > 3 {
+		return fmt.Sprintf("%s,... (%d)", strings.Join(list[:3], ","), len(list))
+	}
+	return strings.Join(list, ",")
+}
+
 // PrintVersion prints version information and exits.
 func PrintVersion(version, commit, date string) {
 	fmt.Printf("AethonX %s\n", version)
@@ -0,0 +1,140 @@
+// internal/platform/config/profile.go
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// scanProfile is a preset of scan depth selectable with --profile. It is
+// applied on top of the defaults, so the config file, ENV and explicit flags
+// still override any of its settings.
+type scanProfile struct {
+	TimeoutS int // Global timeout in seconds (0 = keep the default)
+
+	// Per-source overrides; sources not listed keep their defaults
+	Enabled  map[string]bool
+	Timeouts map[string]time.Duration
+	Custom   map[string]map[string]interface{}
+}
+
+// Profiles names the presets in order of depth.
+var Profiles = []string{"quick", "standard", "deep"}
+
+// DefaultProfile is the preset matching DefaultConfig.
+const DefaultProfile = "standard"
+
+var scanProfiles = map[string]scanProfile{
+	// Fast sweep: crt.sh, RDAP, DNS and subfinder (default sources only);
+	// basic httpx probe without archived URL verification
+	"quick": {
+		TimeoutS: 60,
+		Enabled: map[string]bool{
+			"amass":       false,
+			"waybackurls": false,
+		},
+		Timeouts: map[string]time.Duration{
+			"subfinder": 60 * time.Second,
+			"httpx":     60 * time.Second,
+		},
+		Custom: map[string]map[string]interface{}{
+			"subfinder": {"all_sources": false},
+			"httpx":     {"profile": "basic", "verify_urls": false},
+		},
+	},
+
+	// DefaultConfig as is
+	"standard": {},
+
+	// Adds ASN expansion, passive DNS, crawling and robots, amass brute
+	// force and alterations, body hashes and long timeouts
+	"deep": {
+		TimeoutS: 3600,
+		Enabled: map[string]bool{
+			"asnexpand": true,
+			"pdns":      true,
+			"katana":    true,
+			"robots":    true,
+		},
+		Timeouts: map[string]time.Duration{
+			"subfinder": 600 * time.Second,
+			"amass":     1800 * time.Second,
+			"httpx":     600 * time.Second,
+			"katana":    900 * time.Second,
+		},
+		Custom: map[string]map[string]interface{}{
+			"amass": {"brute": true, "alts": true},
+			"httpx": {"profile": "full", "hash_body": true},
+		},
+	},
+}
+
+// profileName returns the profile requested via --profile (args) or
+// AETHONX_PROFILE. It must be known before the config file, ENV and flags
+// are loaded, since all of them override it.
+func profileName(args []string) string {
+	if v := argValue(args, "profile"); v != "" {
+		return v
+	}
+	return getenv("AETHONX_PROFILE", "")
+}
+
+// applyProfile applies the named preset to cfg ("" = none).
+func applyProfile(cfg *Config, name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return nil
+	}
+	profile, ok := scanProfiles[name]
+	if !ok {
+		return fmt.Errorf("unknown profile %q (valid: %s)", name, strings.Join(Profiles, ", "))
+	}
+
+	if profile.TimeoutS > 0 {
+		cfg.Core.TimeoutS = profile.TimeoutS
+	}
+	for _, source := range profileSources(profile) {
+		sc, ok := cfg.Source.Sources[source]
+		if !ok {
+			continue
+		}
+		if enabled, ok := profile.Enabled[source]; ok {
+			sc.Enabled = enabled
+		}
+		if timeout, ok := profile.Timeouts[source]; ok {
+			sc.Timeout = timeout
+		}
+		for k, v := range profile.Custom[source] {
+			if sc.Custom == nil {
+				sc.Custom = make(map[string]interface{})
+			}
+			sc.Custom[k] = v
+		}
+		cfg.Source.Sources[source] = sc
+	}
+
+	cfg.Core.Profile = name
+	return nil
+}
+
+// profileSources lists the sources a profile touches, sorted.
+func profileSources(profile scanProfile) []string {
+	seen := make(map[string]bool)
+	for name := range profile.Enabled {
+		seen[name] = true
+	}
+	for name := range profile.Timeouts {
+		seen[name] = true
+	}
+	for name := range profile.Custom {
+		seen[name] = true
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,83 @@
+// internal/platform/config/profile_test.go
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestApplyProfile(t *testing.T) {
+	cfg := DefaultConfig()
+	if err := applyProfile(&cfg, " Quick "); err != nil {
+		t.Fatalf("applyProfile(quick) failed: %v", err)
+	}
+	if cfg.Core.Profile != "quick" || cfg.Core.TimeoutS != 60 {
+		t.Errorf("quick core settings not applied: profile=%q timeout=%d", cfg.Core.Profile, cfg.Core.TimeoutS)
+	}
+	if cfg.Source.Sources["amass"].Enabled || cfg.Source.Sources["waybackurls"].Enabled {
+		t.Error("quick must disable amass and waybackurls")
+	}
+	httpx := cfg.Source.Sources["httpx"]
+	if httpx.Custom["profile"] != "basic" || httpx.Custom["verify_urls"] != false {
+		t.Errorf("quick httpx settings not applied: %v", httpx.Custom)
+	}
+	if httpx.Custom["threads"] != 75 {
+		t.Errorf("keys outside the profile must keep their defaults, threads = %v", httpx.Custom["threads"])
+	}
+
+	cfg = DefaultConfig()
+	if err := applyProfile(&cfg, "deep"); err != nil {
+		t.Fatalf("applyProfile(deep) failed: %v", err)
+	}
+	if !cfg.Source.Sources["pdns"].Enabled || !cfg.Source.Sources["katana"].Enabled {
+		t.Error("deep must enable pdns and katana")
+	}
+	amass := cfg.Source.Sources["amass"]
+	if amass.Custom["brute"] != true || amass.Timeout != 30*time.Minute {
+		t.Errorf("deep amass settings not applied: brute=%v timeout=%v", amass.Custom["brute"], amass.Timeout)
+	}
+
+	cfg = DefaultConfig()
+	if err := applyProfile(&cfg, "standard"); err != nil {
+		t.Fatalf("applyProfile(standard) failed: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, DefaultConfig()) {
+		t.Error("standard must match the defaults")
+	}
+
+	if err := applyProfile(&cfg, "thorough"); err == nil {
+		t.Error("expected error for an unknown profile")
+	}
+}
+
+func TestProfileName(t *testing.T) {
+	t.Setenv("AETHONX_PROFILE", "quick")
+	if got := profileName([]string{"-t", "example.com", "--profile=deep"}); got != "deep" {
+		t.Errorf("flag must win over ENV, got %q", got)
+	}
+	if got := profileName([]string{"-t", "example.com"}); got != "quick" {
+		t.Errorf("ENV fallback, got %q", got)
+	}
+}
+
+func TestFromFile_ProfileOverridden(t *testing.T) {
+	t.Setenv("AETHONX_CONFIG", "")
+	t.Setenv("AETHONX_PROFILE", "quick")
+	path := writeConfigFile(t, `
+sources:
+  amass:
+    enabled: true
+`)
+
+	cfg, err := FromFile(path)
+	if err != nil {
+		t.Fatalf("FromFile() failed: %v", err)
+	}
+	if !cfg.Source.Sources["amass"].Enabled {
+		t.Error("the config file must override the profile")
+	}
+	if cfg.Source.Sources["waybackurls"].Enabled {
+		t.Error("settings the file leaves unset come from the profile")
+	}
+}
@@ -0,0 +1,99 @@
+// internal/platform/config/setup.go
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetupAnswers are the choices collected by "aethonx init".
+type SetupAnswers struct {
+	// Credentials holds the API keys entered per source (source -> option -> value)
+	Credentials map[string]map[string]string
+
+	Active  bool // Active scanning permitted
+	PDF     bool // Also write the PDF report
+	Parquet bool // Also write Parquet
+}
+
+// CredentialFields returns the credential options of every source in
+// metadata, keyed by source name. Sources without credentials are omitted.
+func CredentialFields(metadata map[string]ports.SourceMetadata) map[string][]ports.ConfigField {
+	fields := make(map[string][]ports.ConfigField)
+	for name, meta := range metadata {
+		for _, f := range meta.ConfigSchema {
+			if f.Credential {
+				fields[name] = append(fields[name], f)
+			}
+		}
+	}
+	return fields
+}
+
+// SetupFile renders the YAML config file for the answers. Sources that get
+// credentials are enabled with them; sources that require a key nobody
+// provided, and active-only sources when active scanning is not permitted,
+// are disabled. Every other source keeps its defaults and is not listed.
+func SetupFile(metadata map[string]ports.SourceMetadata, answers SetupAnswers) ([]byte, error) {
+	names := make([]string, 0, len(metadata))
+	for name := range metadata {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sources := make(map[string]map[string]interface{})
+	for _, name := range names {
+		meta := metadata[name]
+
+		custom := make(map[string]interface{})
+		for key, value := range answers.Credentials[name] {
+			if value = strings.TrimSpace(value); value != "" {
+				custom[key] = value
+			}
+		}
+
+		switch {
+		case meta.Mode == domain.SourceModeActive && !answers.Active:
+			sources[name] = map[string]interface{}{"enabled": false}
+		case len(custom) > 0:
+			sources[name] = map[string]interface{}{"enabled": true, "custom": custom}
+		case meta.RequiresAuth:
+			sources[name] = map[string]interface{}{"enabled": false}
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("# Generated by aethonx init. Precedence: defaults < this file < AETHONX_* env < flags.\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(map[string]interface{}{"sources": sources}); err != nil {
+		return nil, fmt.Errorf("failed to render config file: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to render config file: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// SetupEnv renders the workspace config.env settings for the answers: the
+// config file to load and the global options the YAML file cannot hold.
+func SetupEnv(answers SetupAnswers, configPath string) string {
+	var b strings.Builder
+	b.WriteString("# aethonx init\n")
+	fmt.Fprintf(&b, "AETHONX_CONFIG=%s\n", configPath)
+	fmt.Fprintf(&b, "AETHONX_ACTIVE=%t\n", answers.Active)
+	if answers.PDF {
+		b.WriteString("AETHONX_PDF_REPORT=true\n")
+	}
+	if answers.Parquet {
+		b.WriteString("AETHONX_PARQUET=true\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,72 @@
+// internal/platform/config/setup_test.go
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+)
+
+func TestSetupFile(t *testing.T) {
+	t.Setenv("AETHONX_CONFIG", "")
+	t.Setenv("AETHONX_PROFILE", "")
+	t.Setenv("AETHONX_SOURCES_SHODAN_API_KEY", "")
+
+	apiKey := ports.ConfigField{Name: "api_key", Type: ports.ConfigTypeString, Credential: true}
+	metadata := map[string]ports.SourceMetadata{
+		"shodan":       {Name: "shodan", Mode: domain.SourceModePassive, RequiresAuth: true, ConfigSchema: []ports.ConfigField{apiKey}},
+		"reversewhois": {Name: "reversewhois", Mode: domain.SourceModePassive, RequiresAuth: true, ConfigSchema: []ports.ConfigField{apiKey}},
+		"httpx":        {Name: "httpx", Mode: domain.SourceModeActive},
+		"crtsh":        {Name: "crtsh", Mode: domain.SourceModePassive},
+	}
+	if fields := CredentialFields(metadata); len(fields) != 2 || fields["shodan"][0].Name != "api_key" {
+		t.Errorf("CredentialFields() = %v, want shodan and reversewhois api_key", fields)
+	}
+
+	data, err := SetupFile(metadata, SetupAnswers{
+		Credentials: map[string]map[string]string{"shodan": {"api_key": " abc "}},
+	})
+	if err != nil {
+		t.Fatalf("SetupFile() failed: %v", err)
+	}
+	if strings.Contains(string(data), "crtsh") {
+		t.Errorf("sources without answers must not be listed:\n%s", data)
+	}
+
+	cfg, err := FromFile(writeConfigFile(t, string(data)))
+	if err != nil {
+		t.Fatalf("generated config does not load: %v\n%s", err, data)
+	}
+	shodan := cfg.Source.Sources["shodan"]
+	if !shodan.Enabled || shodan.Custom["api_key"] != "abc" {
+		t.Errorf("shodan = enabled %v, api_key %v; want enabled with abc", shodan.Enabled, shodan.Custom["api_key"])
+	}
+	if cfg.Source.Sources["reversewhois"].Enabled {
+		t.Error("a source requiring a key nobody provided must be disabled")
+	}
+	if cfg.Source.Sources["httpx"].Enabled {
+		t.Error("active sources must be disabled when active scanning is not permitted")
+	}
+
+	data, err = SetupFile(metadata, SetupAnswers{Active: true})
+	if err != nil {
+		t.Fatalf("SetupFile() failed: %v", err)
+	}
+	if strings.Contains(string(data), "httpx") {
+		t.Errorf("httpx must keep its defaults when active scanning is permitted:\n%s", data)
+	}
+}
+
+func TestSetupEnv(t *testing.T) {
+	env := SetupEnv(SetupAnswers{Active: true, Parquet: true}, "/ws/aethonx.yaml")
+	for _, want := range []string{"AETHONX_CONFIG=/ws/aethonx.yaml\n", "AETHONX_ACTIVE=true\n", "AETHONX_PARQUET=true\n"} {
+		if !strings.Contains(env, want) {
+			t.Errorf("SetupEnv() missing %q:\n%s", want, env)
+		}
+	}
+	if strings.Contains(env, "AETHONX_PDF_REPORT") {
+		t.Errorf("SetupEnv() must not enable the PDF report:\n%s", env)
+	}
+}
@@ -0,0 +1,47 @@
+// internal/platform/config/tagging.go
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"aethonx/internal/core/ports"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TaggingConfig contains user-defined artifact tagging rules.
+type TaggingConfig struct {
+	RulesFile string          // YAML file with tagging rules ("" = disabled)
+	Rules     []ports.TagRule // Rules loaded from RulesFile
+}
+
+// tagRulesFile is the on-disk format of the tagging rules file:
+//
+//	rules:
+//	  - name: internal-hosts
+//	    tag: internal
+//	    value: "*.internal.*"
+//	  - name: forbidden
+//	    tag: review
+//	    types: [subdomain, url]
+//	    metadata:
+//	      http_status: "^403$"
+type tagRulesFile struct {
+	Rules []ports.TagRule `yaml:"rules"`
+}
+
+// LoadTagRules reads tagging rules from a YAML file.
+func LoadTagRules(path string) ([]ports.TagRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tag rules: %w", err)
+	}
+
+	var file tagRulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse tag rules %s: %w", path, err)
+	}
+
+	return file.Rules, nil
+}
@@ -0,0 +1,58 @@
+// internal/platform/config/tagging_test.go
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aethonx/internal/core/domain"
+)
+
+func TestLoadTagRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tags.yaml")
+	content := `rules:
+  - name: internal-hosts
+    tag: internal
+    value: "*.internal.*"
+  - name: forbidden
+    tag: review
+    types: [subdomain, url]
+    metadata:
+      http_status: "^403$"
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write rules: %v", err)
+	}
+
+	rules, err := LoadTagRules(path)
+	if err != nil {
+		t.Fatalf("LoadTagRules() failed: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+	if rules[0].Tag != "internal" || rules[0].Value != "*.internal.*" {
+		t.Errorf("unexpected first rule: %+v", rules[0])
+	}
+	if len(rules[1].Types) != 2 || rules[1].Types[1] != domain.ArtifactTypeURL {
+		t.Errorf("unexpected types: %v", rules[1].Types)
+	}
+	if rules[1].Metadata["http_status"] != "^403$" {
+		t.Errorf("unexpected metadata: %v", rules[1].Metadata)
+	}
+}
+
+func TestLoadTagRules_Errors(t *testing.T) {
+	if _, err := LoadTagRules(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	path := filepath.Join(t.TempDir(), "bad.yaml")
+	if err := os.WriteFile(path, []byte("rules: [\n"), 0o644); err != nil {
+		t.Fatalf("failed to write rules: %v", err)
+	}
+	if _, err := LoadTagRules(path); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+}
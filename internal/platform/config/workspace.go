@@ -0,0 +1,113 @@
+// internal/platform/config/workspace.go
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"aethonx/internal/platform/workspace"
+
+	"github.com/spf13/pflag"
+)
+
+// envOverlay holds AETHONX_* values from the active workspace's config.env.
+// getenv consults it only when the variable is not set in the process
+// environment, so precedence is: defaults < workspace < ENV < flags.
+var envOverlay map[string]string
+
+// workspaceName returns the workspace requested via --workspace (args) or
+// AETHONX_WORKSPACE. It must be known before ENV is loaded, so the args are
+// scanned ahead of pflag parsing.
+func workspaceName(args []string) string {
+	if v := argValue(args, "workspace"); v != "" {
+		return v
+	}
+	return os.Getenv("AETHONX_WORKSPACE")
+}
+
+// argValue returns the value of --<name> in args ("" if absent).
+func argValue(args []string, name string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if v, ok := strings.CutPrefix(arg, "--"+name+"="); ok {
+			return v
+		}
+		if arg == "--"+name && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// OpenWorkspace opens the named workspace under the default base directory.
+func OpenWorkspace(name string) (*workspace.Workspace, error) {
+	base, err := workspace.BaseDir()
+	if err != nil {
+		return nil, err
+	}
+	return workspace.Open(base, name)
+}
+
+// loadWorkspaceEnv installs the workspace config.env as the ENV overlay.
+func loadWorkspaceEnv(ws *workspace.Workspace) error {
+	env, err := ws.LoadEnv()
+	if err != nil {
+		return fmt.Errorf("workspace %s: %w", ws.Name, err)
+	}
+	envOverlay = env
+	return nil
+}
+
+// applyWorkspace points outputs, tagging rules and scope at the workspace.
+// Explicit -o/--out, --tag-rules and -t still win.
+func applyWorkspace(cfg *Config, ws *workspace.Workspace) error {
+	cfg.Core.Workspace = ws.Name
+
+	if !flagChanged("out") && getenv("AETHONX_OUTPUT_DIR", "") == "" {
+		cfg.Output.Dir = ws.ScansDir()
+	}
+
+	if cfg.Tagging.RulesFile == "" {
+		cfg.Tagging.RulesFile = ws.TagRulesPath()
+	}
+
+	scope, err := ws.LoadScope()
+	if err != nil {
+		return fmt.Errorf("workspace %s: %w", ws.Name, err)
+	}
+	if cfg.Core.Target == "" && len(scope.Include) > 0 {
+		cfg.Core.Target = scope.Include[0]
+	}
+	cfg.Core.ExcludeDomains = append(cfg.Core.ExcludeDomains, scope.Exclude...)
+
+	return nil
+}
+
+// flagChanged reports whether a global flag was set on the command line.
+func flagChanged(name string) bool {
+	f := pflag.CommandLine.Lookup(name)
+	return f != nil && f.Changed
+}
+
+// loadActiveWorkspace opens the requested workspace (if any) and installs its
+// config.env overlay.
+func loadActiveWorkspace() (*workspace.Workspace, error) {
+	envOverlay = nil
+
+	name := workspaceName(os.Args[1:])
+	if name == "" {
+		return nil, nil
+	}
+
+	ws, err := OpenWorkspace(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadWorkspaceEnv(ws); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
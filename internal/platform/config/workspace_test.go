@@ -0,0 +1,86 @@
+// internal/platform/config/workspace_test.go
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aethonx/internal/platform/workspace"
+
+	"github.com/spf13/pflag"
+)
+
+func TestWorkspaceName(t *testing.T) {
+	t.Setenv("AETHONX_WORKSPACE", "")
+
+	if got := workspaceName([]string{"-t", "x.com", "--workspace", "acme"}); got != "acme" {
+		t.Errorf("expected acme, got %q", got)
+	}
+	if got := workspaceName([]string{"--workspace=beta"}); got != "beta" {
+		t.Errorf("expected beta, got %q", got)
+	}
+	if got := workspaceName([]string{"--", "--workspace=beta"}); got != "" {
+		t.Errorf("args after -- must be ignored, got %q", got)
+	}
+
+	t.Setenv("AETHONX_WORKSPACE", "fromenv")
+	if got := workspaceName(nil); got != "fromenv" {
+		t.Errorf("expected env fallback, got %q", got)
+	}
+}
+
+func TestLoad_Workspace(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	defer func() { envOverlay = nil }()
+
+	pflag.CommandLine = pflag.NewFlagSet(os.Args[0], pflag.ContinueOnError)
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	base := t.TempDir()
+	t.Setenv("AETHONX_WORKSPACES_DIR", base)
+	t.Setenv("AETHONX_WORKSPACE", "")
+	t.Setenv("AETHONX_TIMEOUT", "90") // process ENV beats workspace config.env
+
+	ws, err := workspace.Create(base, "acme")
+	if err != nil {
+		t.Fatalf("create workspace: %v", err)
+	}
+	writeFile(t, filepath.Join(ws.Path, workspace.ConfigFile), "AETHONX_WORKERS=3\nAETHONX_TIMEOUT=10\n")
+	writeFile(t, filepath.Join(ws.Path, workspace.ScopeFile), "acme.com\n!legacy.acme.com\n")
+
+	os.Args = []string{"cmd", "--workspace", "acme"}
+
+	cfg, err := Load("1.0.0", "test", "2024-01-01")
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if cfg.Core.Workspace != "acme" {
+		t.Errorf("Workspace: expected acme, got %q", cfg.Core.Workspace)
+	}
+	if cfg.Core.Workers != 3 {
+		t.Errorf("Workers: expected workspace override 3, got %d", cfg.Core.Workers)
+	}
+	if cfg.Core.TimeoutS != 90 {
+		t.Errorf("TimeoutS: expected ENV to beat workspace, got %d", cfg.Core.TimeoutS)
+	}
+	if cfg.Core.Target != "acme.com" {
+		t.Errorf("Target: expected default from scope, got %q", cfg.Core.Target)
+	}
+	if len(cfg.Core.ExcludeDomains) != 1 || cfg.Core.ExcludeDomains[0] != "legacy.acme.com" {
+		t.Errorf("ExcludeDomains: got %v", cfg.Core.ExcludeDomains)
+	}
+	if cfg.Output.Dir != ws.ScansDir() {
+		t.Errorf("Output.Dir: expected %s, got %s", ws.ScansDir(), cfg.Output.Dir)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
@@ -7,10 +7,14 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"sync"
 	"time"
 
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/budget"
 	"aethonx/internal/platform/errors"
 	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/politeness"
 	"aethonx/internal/platform/rate"
 )
 
@@ -53,6 +57,21 @@ type Config struct {
 	// RateLimitBurst is the burst size for rate limiting.
 	// Default: 1
 	RateLimitBurst int
+
+	// Upstream shares the rate limit with every client of the same upstream
+	// (e.g., "crt.sh") through rate.Shared(), across sources and concurrent
+	// scans. "" = the client has its own limiter.
+	Upstream string
+
+	// Budgeted counts every request (retries included) and the bytes of its
+	// response against budget.Shared() (--budget). Set by active sources that
+	// probe the target; once a budget is spent, requests fail without being sent.
+	Budgeted bool
+
+	// Polite waits on politeness.Shared() before each request so the
+	// destination host never sees more than its --host-concurrency requests
+	// in flight nor two requests closer than its --host-delay.
+	Polite bool
 }
 
 // DefaultConfig returns the default configuration.
@@ -93,13 +112,17 @@ func New(config Config, logger logx.Logger) *Client {
 
 	var rateLimiter *rate.Limiter
 	if config.RateLimit > 0 {
-		rateLimiter = rate.New(config.RateLimit, config.RateLimitBurst)
+		if config.Upstream != "" {
+			rateLimiter = rate.Shared().Limiter(config.Upstream, config.RateLimit, config.RateLimitBurst)
+		} else {
+			rateLimiter = rate.New(config.RateLimit, config.RateLimitBurst)
+		}
 	}
 
 	return &Client{
 		httpClient:  httpClient,
 		rateLimiter: rateLimiter,
-		logger:      logger.With("component", "httpx"),
+		logger:      logger.With("component", "httpclient"),
 		config:      config,
 	}
 }
@@ -109,9 +132,28 @@ func (c *Client) Request(ctx context.Context, method, url string, body io.Reader
 	var lastErr error
 
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		// Global request budget (--budget)
+		if c.config.Budgeted {
+			if err := budget.Shared().Take(budget.Requests, 1); err != nil {
+				return nil, errors.Wrapf(err, "request to %s not sent", url)
+			}
+		}
+
+		// Provider turn: sources of a stage sharing this upstream take turns
+		// before its limiter, so their requests interleave. The turn is held
+		// until the body is closed
+		turn := func() {}
+		if c.config.Upstream != "" {
+			var err error
+			if turn, err = ports.WaitProviderTurn(ctx, c.config.Upstream); err != nil {
+				return nil, errors.Wrap(err, "provider turn wait failed")
+			}
+		}
+
 		// Rate limiting
 		if c.rateLimiter != nil {
 			if err := c.rateLimiter.Wait(ctx); err != nil {
+				turn()
 				return nil, errors.Wrap(err, "rate limit wait failed")
 			}
 		}
@@ -119,6 +161,7 @@ func (c *Client) Request(ctx context.Context, method, url string, body io.Reader
 		// Create request
 		req, err := http.NewRequestWithContext(ctx, method, url, body)
 		if err != nil {
+			turn()
 			return nil, errors.Wrapf(err, "failed to create request for %s %s", method, url)
 		}
 
@@ -136,10 +179,29 @@ func (c *Client) Request(ctx context.Context, method, url string, body io.Reader
 			"max_retries", c.config.MaxRetries+1,
 		)
 
+		// Per-host politeness: the slot is held until the body is closed
+		release := turn
+		if c.config.Polite {
+			polite, err := politeness.Shared().Acquire(ctx, req.URL.Hostname())
+			if err != nil {
+				turn()
+				return nil, errors.Wrap(err, "politeness wait failed")
+			}
+			release = func() {
+				polite()
+				turn()
+			}
+		}
+
 		// Perform request
 		start := time.Now()
 		resp, err := c.httpClient.Do(req)
 		duration := time.Since(start)
+		if err != nil {
+			release()
+		} else {
+			resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+		}
 
 		// Log response
 		if err != nil {
@@ -177,6 +239,9 @@ func (c *Client) Request(ctx context.Context, method, url string, body io.Reader
 
 		// If not a retryable status, return the response
 		if !isRetryableStatus {
+			if c.config.Budgeted {
+				resp.Body = budget.Shared().Reader(resp.Body)
+			}
 			return resp, nil
 		}
 
@@ -208,6 +273,20 @@ func (c *Client) Request(ctx context.Context, method, url string, body io.Reader
 	return nil, errors.Wrapf(lastErr, "request failed after %d attempts", c.config.MaxRetries+1)
 }
 
+// releasingBody releases the politeness slot and provider turn of its
+// request on the first Close.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
 // Get performs a GET request.
 func (c *Client) Get(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
 	return c.Request(ctx, http.MethodGet, url, nil, headers)
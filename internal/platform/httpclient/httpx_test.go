@@ -11,8 +11,11 @@ import (
 	"testing"
 	"time"
 
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/budget"
 	"aethonx/internal/platform/errors"
 	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/politeness"
 	"aethonx/internal/testutil"
 )
 
@@ -56,6 +59,20 @@ func TestNew(t *testing.T) {
 
 		testutil.AssertTrue(t, client.rateLimiter == nil, "rate limiter should not be created")
 	})
+
+	t.Run("shares the limiter of the same upstream", func(t *testing.T) {
+		config := Config{
+			RateLimit:      10,
+			RateLimitBurst: 5,
+			Upstream:       "shared-test.example",
+		}
+		a := New(config, logger)
+		b := New(config, logger)
+		private := New(Config{RateLimit: 10, RateLimitBurst: 5}, logger)
+
+		testutil.AssertTrue(t, a.rateLimiter == b.rateLimiter, "clients of one upstream should share the limiter")
+		testutil.AssertTrue(t, a.rateLimiter != private.rateLimiter, "clients without upstream keep their own limiter")
+	})
 }
 
 func TestDefaultConfig(t *testing.T) {
@@ -566,3 +583,113 @@ func ExampleClient_FetchJSON() {
 
 	fmt.Println("Body:", string(body))
 }
+
+func TestClient_Budgeted(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Write([]byte("0123456789"))
+	}))
+	defer server.Close()
+
+	budget.Shared().Configure(map[budget.Kind]int64{budget.Requests: 2})
+	budget.Shared().Reset()
+	defer func() {
+		budget.Shared().Configure(nil)
+		budget.Shared().Reset()
+	}()
+
+	config := DefaultConfig()
+	config.Budgeted = true
+	client := New(config, logx.NewSilent())
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(context.Background(), server.URL, nil)
+		testutil.AssertNoError(t, err, "request within budget")
+		_, err = ReadBody(resp)
+		testutil.AssertNoError(t, err, "body within budget")
+	}
+
+	_, err := client.Get(context.Background(), server.URL, nil)
+	testutil.AssertTrue(t, errors.Is(err, budget.ErrExhausted), "third request refused")
+	testutil.AssertEqual(t, atomic.LoadInt32(&hits), int32(2), "refused request never sent")
+	testutil.AssertEqual(t, budget.Shared().Usage()[2].Used, int64(20), "response bytes counted")
+}
+
+func TestClient_Polite(t *testing.T) {
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	politeness.Shared().Configure(politeness.Policy{Concurrency: map[string]int{"127.0.0.1": 1}})
+	defer politeness.Shared().Configure(politeness.Policy{})
+
+	config := DefaultConfig()
+	config.Polite = true
+	client := New(config, logx.NewSilent())
+
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			resp, err := client.Get(context.Background(), server.URL, nil)
+			if err == nil {
+				ReadBody(resp)
+			}
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		<-done
+	}
+
+	testutil.AssertEqual(t, atomic.LoadInt32(&peak), int32(1), "one request in flight per host")
+}
+
+// countingTurns cuenta los turnos pedidos y liberados por proveedor.
+type countingTurns struct {
+	waited, released map[string]int
+}
+
+func (c *countingTurns) Wait(ctx context.Context, provider string) (func(), error) {
+	c.waited[provider]++
+	return func() { c.released[provider]++ }, nil
+}
+
+func TestClient_ProviderTurn(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	turns := &countingTurns{waited: map[string]int{}, released: map[string]int{}}
+	ctx := ports.WithProviderTurns(context.Background(), turns)
+
+	config := DefaultConfig()
+	config.RateLimit = 100
+	config.Upstream = "turns.example"
+	client := New(config, logx.NewSilent())
+
+	resp, err := client.Get(ctx, server.URL, nil)
+	testutil.AssertNoError(t, err, "request")
+	testutil.AssertEqual(t, turns.released["turns.example"], 0, "turn held until the body is closed")
+	_, err = ReadBody(resp)
+	testutil.AssertNoError(t, err, "read body")
+	resp.Body.Close()
+	testutil.AssertEqual(t, turns.waited["turns.example"], 1, "turn taken for the upstream")
+	testutil.AssertEqual(t, turns.released["turns.example"], 1, "turn released once")
+
+	_, err = New(DefaultConfig(), logx.NewSilent()).Get(ctx, server.URL, nil)
+	testutil.AssertNoError(t, err, "request without upstream")
+	testutil.AssertEqual(t, len(turns.waited), 1, "clients without upstream take no turn")
+}
@@ -0,0 +1,457 @@
+package installer
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"aethonx/internal/platform/installer/providers"
+)
+
+// Layout of a bundle (a .tar.gz):
+//
+//	manifest.json                     BundleManifest
+//	assets/<tool>/<os_arch>/<asset>   Verified release assets
+//	pip/<tool>/*.whl|*.tar.gz         pip packages and their dependencies
+//	gomodcache/                       Go module cache of the aethonx sources (optional)
+const (
+	bundleManifestName = "manifest.json"
+	bundleGoModCache   = "gomodcache"
+)
+
+// BundleManifest describes the contents of an offline bundle.
+type BundleManifest struct {
+	CreatedAt time.Time    `json:"created_at"`
+	Tools     []BundleTool `json:"tools"`
+	GoModules bool         `json:"go_modules"` // gomodcache/ is included
+}
+
+// BundleTool is a tool packed in a bundle.
+type BundleTool struct {
+	Name         string       `json:"name"`
+	Version      string       `json:"version"`
+	Type         string       `json:"type"`
+	Package      string       `json:"package,omitempty"` // pip package
+	Verification Verification `json:"verification"`      // How the assets were verified at download
+	Files        []BundleFile `json:"files"`
+}
+
+// BundleFile is a file of a bundled tool.
+type BundleFile struct {
+	Platform string `json:"platform,omitempty"` // "<os>_<arch>" of release assets ("" for pip files)
+	Path     string `json:"path"`               // Relative to the bundle root
+	SHA256   string `json:"sha256"`
+}
+
+// BundleOptions configures CreateBundle.
+type BundleOptions struct {
+	Platforms []string         // "<os>_<arch>" of the target machines (default: this one)
+	GoModDir  string           // Module whose dependencies are bundled ("" = none)
+	Progress  ProgressCallback // Optional
+}
+
+// CreateBundle downloads and verifies every tool for the target platforms
+// and packs them into a .tar.gz at out, for installation on machines without
+// internet access ("aethonx deps bundle install"). pip packages are
+// downloaded for this machine's platform and Python version only.
+func CreateBundle(ctx context.Context, tools []ExternalTool, out string, opts BundleOptions) (*BundleManifest, error) {
+	platforms := opts.Platforms
+	if len(platforms) == 0 {
+		sys, err := DetectSystem(ctx, DefaultInstallDirectory)
+		if err != nil {
+			return nil, err
+		}
+		platforms = []string{sys.OS + "_" + sys.Arch}
+	}
+
+	staging, err := os.MkdirTemp("", "aethonx-bundle-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	manifest := &BundleManifest{CreatedAt: time.Now().UTC()}
+	for _, tool := range tools {
+		inst := NewExternalToolInstaller(tool)
+		inst.SetProgressCallback(opts.Progress)
+
+		var bt BundleTool
+		if tool.Type == string(DependencyTypePython) {
+			bt, err = inst.bundlePip(ctx, staging)
+		} else {
+			bt, err = inst.bundleRelease(ctx, staging, platforms)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", tool.Name, err)
+		}
+		manifest.Tools = append(manifest.Tools, bt)
+	}
+
+	if opts.GoModDir != "" {
+		if err := downloadGoModules(ctx, opts.GoModDir, filepath.Join(staging, bundleGoModCache)); err != nil {
+			return nil, err
+		}
+		manifest.GoModules = true
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(staging, bundleManifestName), data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	// Write next to out and rename, so a failed run leaves no partial bundle
+	tmp := out + ".tmp"
+	if err := writeTarGz(staging, tmp); err != nil {
+		os.Remove(tmp)
+		return nil, err
+	}
+	if err := os.Rename(tmp, out); err != nil {
+		os.Remove(tmp)
+		return nil, fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return manifest, nil
+}
+
+// bundleRelease downloads and verifies the latest release asset of each
+// platform. Platforms without a release asset are skipped.
+func (e *ExternalToolInstaller) bundleRelease(ctx context.Context, staging string, platforms []string) (BundleTool, error) {
+	e.reportProgress(PhaseDownloading, "Fetching latest release info...")
+	release, err := e.provider.GetLatestRelease(ctx, e.tool.Install.Github.Repo)
+	if err != nil {
+		return BundleTool{}, fmt.Errorf("failed to get latest release: %w", err)
+	}
+
+	bt := BundleTool{
+		Name:    e.tool.Name,
+		Version: release.GetVersion(),
+		Type:    e.tool.Type,
+	}
+	for _, platform := range platforms {
+		if _, ok := e.tool.Install.Github.AssetPatterns[platform]; !ok {
+			e.reportProgress(PhaseDownloading, fmt.Sprintf("No release asset for %s, skipped", platform))
+			continue
+		}
+
+		file, verification, err := e.bundleAsset(ctx, release, staging, platform)
+		if err != nil {
+			return BundleTool{}, err
+		}
+		bt.Files = append(bt.Files, file)
+		bt.Verification = verification
+	}
+	if len(bt.Files) == 0 {
+		return BundleTool{}, fmt.Errorf("no release asset for platforms %s", strings.Join(platforms, ", "))
+	}
+	return bt, nil
+}
+
+// bundleAsset fetches one platform's asset into the staging tree.
+func (e *ExternalToolInstaller) bundleAsset(ctx context.Context, release *providers.GitHubRelease, staging, platform string) (BundleFile, Verification, error) {
+	scratch, err := os.MkdirTemp("", "aethonx-asset-*")
+	if err != nil {
+		return BundleFile{}, "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(scratch)
+
+	path, name, verification, err := e.fetchAsset(ctx, release, platform, scratch)
+	if err != nil {
+		return BundleFile{}, "", err
+	}
+
+	rel := filepath.Join("assets", e.tool.Name, platform, name)
+	file, err := stageFile(path, staging, rel)
+	if err != nil {
+		return BundleFile{}, "", err
+	}
+	file.Platform = platform
+	return file, verification, nil
+}
+
+// bundlePip downloads a pip package with its dependencies.
+func (e *ExternalToolInstaller) bundlePip(ctx context.Context, staging string) (BundleTool, error) {
+	pkg := e.tool.Install.PythonPip.Package
+	rel := filepath.Join("pip", e.tool.Name)
+	dir := filepath.Join(staging, rel)
+
+	e.reportProgress(PhaseDownloading, fmt.Sprintf("Downloading %s with pip...", pkg))
+	cmd := exec.CommandContext(ctx, "python3", "-m", "pip", "download", "--dest", dir, pkg)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return BundleTool{}, fmt.Errorf("pip download %s failed: %w: %s", pkg, err, strings.TrimSpace(string(output)))
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return BundleTool{}, fmt.Errorf("failed to list pip downloads: %w", err)
+	}
+	bt := BundleTool{
+		Name:         e.tool.Name,
+		Type:         e.tool.Type,
+		Package:      pkg,
+		Verification: VerificationUnverified,
+	}
+	for _, entry := range entries {
+		sum, err := fileSHA256(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return BundleTool{}, err
+		}
+		bt.Files = append(bt.Files, BundleFile{Path: filepath.ToSlash(filepath.Join(rel, entry.Name())), SHA256: sum})
+		if bt.Version == "" {
+			bt.Version = distributionVersion(entry.Name(), pkg)
+		}
+	}
+	return bt, nil
+}
+
+// distributionVersion extracts the version from a wheel or sdist file name
+// of pkg ("shodan-1.31.0-py3-none-any.whl" → "1.31.0"); "" for other packages.
+func distributionVersion(file, pkg string) string {
+	normalized := strings.ReplaceAll(strings.ToLower(pkg), "-", "_")
+	name := strings.ReplaceAll(strings.ToLower(file), "-", "_")
+	if !strings.HasPrefix(name, normalized+"_") {
+		return ""
+	}
+	rest := file[len(normalized)+1:]
+	rest = strings.TrimSuffix(strings.TrimSuffix(rest, ".tar.gz"), ".zip")
+	return strings.SplitN(rest, "-", 2)[0]
+}
+
+// downloadGoModules fills cacheDir with the dependencies of the module at dir.
+func downloadGoModules(ctx context.Context, dir, cacheDir string) error {
+	cmd := exec.CommandContext(ctx, "go", "mod", "download", "all")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOMODCACHE="+cacheDir, "GOFLAGS=-modcacherw")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("go mod download failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// stageFile moves src to rel inside staging and hashes it.
+func stageFile(src, staging, rel string) (BundleFile, error) {
+	dst := filepath.Join(staging, rel)
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return BundleFile{}, fmt.Errorf("failed to create bundle directory: %w", err)
+	}
+	if err := copyFile(src, dst); err != nil {
+		return BundleFile{}, fmt.Errorf("failed to stage %s: %w", rel, err)
+	}
+	sum, err := fileSHA256(dst)
+	if err != nil {
+		return BundleFile{}, err
+	}
+	return BundleFile{Path: filepath.ToSlash(rel), SHA256: sum}, nil
+}
+
+// Bundle is an extracted offline bundle.
+type Bundle struct {
+	Manifest BundleManifest
+	dir      string
+}
+
+// OpenBundle extracts the bundle at path into a temporary directory; Close
+// removes it.
+func OpenBundle(path string) (*Bundle, error) {
+	dir, err := os.MkdirTemp("", "aethonx-bundle-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	if err := providers.NewGitHubProvider().ExtractTarGz(path, dir); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed to extract bundle: %w", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, bundleManifestName))
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("not an aethonx bundle (missing %s): %w", bundleManifestName, err)
+	}
+	b := &Bundle{dir: dir}
+	if err := json.Unmarshal(data, &b.Manifest); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("invalid bundle manifest: %w", err)
+	}
+	return b, nil
+}
+
+// Close removes the extracted files.
+func (b *Bundle) Close() error {
+	return os.RemoveAll(b.dir)
+}
+
+// Tool returns the bundled tool with the given name.
+func (b *Bundle) Tool(name string) (BundleTool, bool) {
+	for _, t := range b.Manifest.Tools {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return BundleTool{}, false
+}
+
+// file returns the path of a bundled file after checking its SHA256 against
+// the manifest.
+func (b *Bundle) file(f BundleFile) (string, error) {
+	path := filepath.Join(b.dir, filepath.FromSlash(f.Path))
+	got, err := fileSHA256(path)
+	if err != nil {
+		return "", fmt.Errorf("bundle file %s: %w", f.Path, err)
+	}
+	if got != f.SHA256 {
+		return "", fmt.Errorf("checksum mismatch for bundle file %s: expected %s, got %s", f.Path, f.SHA256, got)
+	}
+	return path, nil
+}
+
+// InstallGoModules copies the bundled Go module cache into cacheDir.
+func (b *Bundle) InstallGoModules(cacheDir string) error {
+	if !b.Manifest.GoModules {
+		return fmt.Errorf("bundle has no Go modules (create it with --go-modules)")
+	}
+	return copyTree(filepath.Join(b.dir, bundleGoModCache), cacheDir)
+}
+
+// installFromBundle installs the tool from the bundle set with SetBundle.
+func (e *ExternalToolInstaller) installFromBundle(ctx context.Context, sys SystemInfo) error {
+	bt, ok := e.bundle.Tool(e.tool.Name)
+	if !ok {
+		return fmt.Errorf("%s is not in the bundle", e.tool.Name)
+	}
+
+	if bt.Type == string(DependencyTypePython) {
+		for _, f := range bt.Files {
+			if _, err := e.bundle.file(f); err != nil {
+				return err
+			}
+		}
+		dir := filepath.Join(e.bundle.dir, "pip", bt.Name)
+		e.reportProgress(PhaseInstalling, fmt.Sprintf("Installing %s with pip from the bundle...", bt.Package))
+		cmd := exec.CommandContext(ctx, "python3", "-m", "pip", "install", "--user", "--no-index", "--find-links", dir, bt.Package)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("pip install %s failed: %w: %s", bt.Package, err, strings.TrimSpace(string(output)))
+		}
+		e.verification = bt.Verification
+		return nil
+	}
+
+	platform := sys.OS + "_" + sys.Arch
+	for _, f := range bt.Files {
+		if f.Platform != platform {
+			continue
+		}
+		e.reportProgress(PhaseVerifying, "Verifying bundled asset...")
+		path, err := e.bundle.file(f)
+		if err != nil {
+			return err
+		}
+
+		tempDir, err := os.MkdirTemp("", "aethonx-install-*")
+		if err != nil {
+			return fmt.Errorf("failed to create temp directory: %w", err)
+		}
+		defer os.RemoveAll(tempDir)
+
+		if err := e.installAsset(sys, tempDir, path, filepath.Base(path)); err != nil {
+			return err
+		}
+		e.verification = bt.Verification
+		return nil
+	}
+	return fmt.Errorf("bundle has no %s asset for platform %s", e.tool.Name, platform)
+}
+
+// writeTarGz packs the contents of dir into a .tar.gz at out.
+func writeTarGz(dir, out string) error {
+	f, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || path == dir {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil // Symlinks and special files are never bundled
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return f.Close()
+}
+
+// copyTree copies the regular files under src into dst, keeping existing
+// files (module cache entries are immutable).
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		if _, err := os.Stat(target); err == nil {
+			return nil
+		}
+		if err := copyFile(path, target); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", rel, err)
+		}
+		return os.Chmod(target, 0o444)
+	})
+}
@@ -0,0 +1,189 @@
+package installer
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeBundle packs files plus a manifest into a bundle and returns its path.
+func writeBundle(t *testing.T, manifest BundleManifest, files map[string]string) string {
+	t.Helper()
+	staging := t.TempDir()
+	for rel, content := range files {
+		path := filepath.Join(staging, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(staging, bundleManifestName), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := writeTarGz(staging, out); err != nil {
+		t.Fatalf("writeTarGz() error = %v", err)
+	}
+	return out
+}
+
+func TestBundle_InstallFromBundle(t *testing.T) {
+	const binary = "#!/bin/sh\necho tool v1.2.3\n"
+	manifest := BundleManifest{
+		Tools: []BundleTool{{
+			Name:         "tool",
+			Version:      "1.2.3",
+			Type:         string(DependencyTypeBinary),
+			Verification: VerificationSHA256,
+			Files: []BundleFile{
+				{Platform: "linux_amd64", Path: "assets/tool/linux_amd64/tool-linux-amd64", SHA256: sha256Hex(binary)},
+			},
+		}},
+	}
+	path := writeBundle(t, manifest, map[string]string{"assets/tool/linux_amd64/tool-linux-amd64": binary})
+
+	bundle, err := OpenBundle(path)
+	if err != nil {
+		t.Fatalf("OpenBundle() error = %v", err)
+	}
+	defer bundle.Close()
+
+	if _, ok := bundle.Tool("tool"); !ok {
+		t.Fatal("expected tool in the bundle")
+	}
+
+	tool := ExternalTool{Name: "tool", Type: string(DependencyTypeBinary)}
+	tool.Install.Github.BinaryName = "tool"
+	inst := NewExternalToolInstaller(tool)
+	inst.SetBundle(bundle)
+
+	t.Run("installs the platform asset", func(t *testing.T) {
+		sys := SystemInfo{OS: "linux", Arch: "amd64", InstallDir: t.TempDir()}
+		if err := inst.Install(context.Background(), sys); err != nil {
+			t.Fatalf("Install() error = %v", err)
+		}
+		got, err := os.ReadFile(filepath.Join(sys.InstallDir, "tool"))
+		if err != nil || string(got) != binary {
+			t.Errorf("installed binary = %q, %v", got, err)
+		}
+		if inst.Verification() != VerificationSHA256 {
+			t.Errorf("Verification() = %q, want %q", inst.Verification(), VerificationSHA256)
+		}
+	})
+
+	t.Run("missing platform", func(t *testing.T) {
+		sys := SystemInfo{OS: "darwin", Arch: "arm64", InstallDir: t.TempDir()}
+		err := inst.Install(context.Background(), sys)
+		if err == nil || !strings.Contains(err.Error(), "darwin_arm64") {
+			t.Errorf("expected missing platform error, got %v", err)
+		}
+	})
+
+	t.Run("needs update compares with the bundled version", func(t *testing.T) {
+		needs, latest, err := inst.NeedsUpdate(context.Background(), "1.0.0")
+		if err != nil || !needs || latest != "1.2.3" {
+			t.Errorf("NeedsUpdate() = (%v, %q, %v)", needs, latest, err)
+		}
+	})
+
+	t.Run("tampered file fails closed", func(t *testing.T) {
+		asset := filepath.Join(bundle.dir, "assets", "tool", "linux_amd64", "tool-linux-amd64")
+		if err := os.WriteFile(asset, []byte("tampered"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		sys := SystemInfo{OS: "linux", Arch: "amd64", InstallDir: t.TempDir()}
+		err := inst.Install(context.Background(), sys)
+		if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+			t.Errorf("expected checksum mismatch, got %v", err)
+		}
+		if _, statErr := os.Stat(filepath.Join(sys.InstallDir, "tool")); statErr == nil {
+			t.Error("tampered binary must not be installed")
+		}
+	})
+}
+
+func TestOpenBundle_Invalid(t *testing.T) {
+	t.Run("no manifest", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "empty.tar.gz")
+		if err := writeTarGz(t.TempDir(), out); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := OpenBundle(out); err == nil {
+			t.Error("expected an error for a tarball without manifest")
+		}
+	})
+
+	t.Run("path traversal", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "evil.tar.gz")
+		f, err := os.Create(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gz := gzip.NewWriter(f)
+		tw := tar.NewWriter(gz)
+		content := []byte("x")
+		tw.WriteHeader(&tar.Header{Name: "../escaped", Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		tw.Write(content)
+		tw.Close()
+		gz.Close()
+		f.Close()
+
+		if _, err := OpenBundle(out); err == nil || !strings.Contains(err.Error(), "illegal path") {
+			t.Errorf("expected illegal path error, got %v", err)
+		}
+	})
+}
+
+func TestBundle_InstallGoModules(t *testing.T) {
+	path := writeBundle(t, BundleManifest{GoModules: true}, map[string]string{
+		"gomodcache/cache/download/example.com/m/@v/v1.0.0.mod": "module example.com/m\n",
+	})
+	bundle, err := OpenBundle(path)
+	if err != nil {
+		t.Fatalf("OpenBundle() error = %v", err)
+	}
+	defer bundle.Close()
+
+	cache := t.TempDir()
+	if err := bundle.InstallGoModules(cache); err != nil {
+		t.Fatalf("InstallGoModules() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cache, "cache", "download", "example.com", "m", "@v", "v1.0.0.mod")); err != nil {
+		t.Errorf("module file not copied: %v", err)
+	}
+	// Read-only files: make the temp dir removable
+	filepath.Walk(cache, func(p string, info os.FileInfo, err error) error {
+		if err == nil {
+			os.Chmod(p, 0o755)
+		}
+		return nil
+	})
+}
+
+func TestDistributionVersion(t *testing.T) {
+	tests := []struct {
+		file, pkg, want string
+	}{
+		{"shodan-1.31.0-py3-none-any.whl", "shodan", "1.31.0"},
+		{"shodan-1.31.0.tar.gz", "shodan", "1.31.0"},
+		{"click_plugins-1.1.1-py2.py3-none-any.whl", "click-plugins", "1.1.1"},
+		{"requests-2.32.0-py3-none-any.whl", "shodan", ""},
+	}
+	for _, tt := range tests {
+		if got := distributionVersion(tt.file, tt.pkg); got != tt.want {
+			t.Errorf("distributionVersion(%q, %q) = %q, want %q", tt.file, tt.pkg, got, tt.want)
+		}
+	}
+}
@@ -56,11 +56,26 @@ func AnalyzeError(toolName string, phase string, err error, docsURL string) *Err
 
 	// Analyze error type and provide solutions
 	switch {
+	case strings.Contains(errMsg, "checksum") || strings.Contains(errMsg, "signature verification"):
+		ctx.Reason = "Downloaded release asset failed integrity verification; nothing was installed"
+		ctx.Solutions = []string{
+			"Retry: the download may have been corrupted or truncated",
+			"If it fails again, do not install the asset manually: it may have been tampered with",
+			fmt.Sprintf("Report it upstream if the release checksums are wrong: %s", docsURL),
+		}
+
+	case strings.Contains(errMsg, "bundle"):
+		ctx.Reason = "The offline bundle does not provide this tool for this machine"
+		ctx.Solutions = []string{
+			"Recreate the bundle including this tool: aethonx deps bundle create --all",
+			"Add this platform when creating it: --platform <os>_<arch>",
+		}
+
 	case strings.Contains(errMsg, "rate limit") || strings.Contains(errMsg, "403"):
 		ctx.Reason = "GitHub API rate limit exceeded (60 requests/hour for unauthenticated requests)"
 		ctx.Solutions = []string{
 			"Wait 1 hour and try again",
-			"Set GITHUB_TOKEN environment variable for higher rate limit (5000/hour):\n       export GITHUB_TOKEN=ghp_your_token_here\n       Then run: ./install-deps",
+			"Set GITHUB_TOKEN environment variable for higher rate limit (5000/hour):\n       export GITHUB_TOKEN=ghp_your_token_here\n       Then run: aethonx deps install",
 			"Create a GitHub token at: https://github.com/settings/tokens (no scopes needed)",
 			fmt.Sprintf("Install manually: Check installation docs at %s", docsURL),
 		}
@@ -69,7 +84,7 @@ func AnalyzeError(toolName string, phase string, err error, docsURL string) *Err
 		ctx.Reason = "Network request timeout - slow or unstable connection"
 		ctx.Solutions = []string{
 			"Check your internet connection and retry",
-			"Try again with increased timeout: ./install-deps (will retry automatically)",
+			"Try again with increased timeout: aethonx deps install (will retry automatically)",
 			"Use a VPN if GitHub is blocked in your region",
 			"Install manually if network issues persist",
 		}
@@ -103,15 +118,15 @@ func AnalyzeError(toolName string, phase string, err error, docsURL string) *Err
 		ctx.Reason = "Downloaded archive doesn't contain expected binary"
 		ctx.Solutions = []string{
 			"The release archive structure may have changed",
-			"Try forcing reinstall: ./install-deps --force",
+			"Try forcing reinstall: aethonx deps install --force",
 			"Install manually from the GitHub releases page",
 		}
 
 	case strings.Contains(errMsg, "permission denied"):
 		ctx.Reason = "Insufficient permissions to write to installation directory"
 		ctx.Solutions = []string{
-			"Run with sudo: sudo ./install-deps",
-			"Or install to a user-writable directory: ./install-deps --dir ~/bin",
+			"Run with sudo: sudo aethonx deps install",
+			"Or install to a user-writable directory: aethonx deps install --dir ~/bin",
 			"Check directory permissions: ls -la ~/go/bin",
 		}
 
@@ -126,7 +141,7 @@ func AnalyzeError(toolName string, phase string, err error, docsURL string) *Err
 		ctx.Reason = "Installation succeeded but tool validation failed"
 		ctx.Solutions = []string{
 			"The tool may be installed but not working correctly",
-			"Try reinstalling: ./install-deps --force",
+			"Try reinstalling: aethonx deps install --force",
 			"Check if dependencies are missing: ldd $(which " + toolName + ")",
 			"Verify PATH is set correctly",
 		}
@@ -134,7 +149,7 @@ func AnalyzeError(toolName string, phase string, err error, docsURL string) *Err
 	case strings.Contains(errMsg, "already exists"):
 		ctx.Reason = "Tool appears to be already installed"
 		ctx.Solutions = []string{
-			"Use --force to reinstall: ./install-deps --force",
+			"Use --force to reinstall: aethonx deps install --force",
 			"Or check installation: which " + toolName,
 		}
 
@@ -142,9 +157,9 @@ func AnalyzeError(toolName string, phase string, err error, docsURL string) *Err
 		// Generic error
 		ctx.Reason = "An unexpected error occurred during installation"
 		ctx.Solutions = []string{
-			"Try running with verbose mode: ./install-deps --verbose",
+			"Try again and check the output: aethonx deps install",
 			"Check the installation logs for details",
-			"Try forcing reinstall: ./install-deps --force",
+			"Try forcing reinstall: aethonx deps install --force",
 			"Install manually if the issue persists",
 		}
 	}
@@ -155,11 +170,12 @@ func AnalyzeError(toolName string, phase string, err error, docsURL string) *Err
 // GetDocumentationURL returns the documentation URL for a tool.
 func GetDocumentationURL(toolName string) string {
 	urls := map[string]string{
-		"subfinder":    "https://github.com/projectdiscovery/subfinder",
-		"httpx":        "https://github.com/projectdiscovery/httpx",
-		"amass":        "https://github.com/owasp-amass/amass",
-		"waybackurls":  "https://github.com/tomnomnom/waybackurls",
-		"go-modules":   "https://golang.org/doc/install",
+		"subfinder":   "https://github.com/projectdiscovery/subfinder",
+		"httpx":       "https://github.com/projectdiscovery/httpx",
+		"katana":      "https://github.com/projectdiscovery/katana",
+		"amass":       "https://github.com/owasp-amass/amass",
+		"waybackurls": "https://github.com/tomnomnom/waybackurls",
+		"go-modules":  "https://golang.org/doc/install",
 	}
 
 	if url, ok := urls[strings.ToLower(toolName)]; ok {
@@ -4,10 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 
-	"aethonx/cmd/install-deps/providers"
+	"aethonx/internal/platform/installer/providers"
 )
 
 // ExternalToolInstaller handles installation of external binary tools from GitHub.
@@ -15,6 +16,8 @@ type ExternalToolInstaller struct {
 	tool             ExternalTool
 	provider         *providers.GitHubProvider
 	progressCallback ProgressCallback
+	verification     Verification // Set by Install
+	bundle           *Bundle      // Install from an offline bundle instead of downloading
 }
 
 // NewExternalToolInstaller creates a new external tool installer.
@@ -30,6 +33,27 @@ func (e *ExternalToolInstaller) Name() string {
 	return e.tool.Name
 }
 
+// MinVersion returns the minimum version required by the sources.
+func (e *ExternalToolInstaller) MinVersion() string {
+	return e.tool.MinVersion
+}
+
+// BelowMinVersion reports whether version is known and older than MinVersion.
+func (e *ExternalToolInstaller) BelowMinVersion(version string) bool {
+	return e.tool.MinVersion != "" && isValidVersion(cleanVersion(version)) &&
+		CompareVersions(version, e.tool.MinVersion) < 0
+}
+
+// SetBundle makes Install and NeedsUpdate use an offline bundle.
+func (e *ExternalToolInstaller) SetBundle(b *Bundle) {
+	e.bundle = b
+}
+
+// Verification returns how the last Install verified the downloaded asset.
+func (e *ExternalToolInstaller) Verification() Verification {
+	return e.verification
+}
+
 // SetProgressCallback sets the progress callback function.
 func (e *ExternalToolInstaller) SetProgressCallback(callback ProgressCallback) {
 	e.progressCallback = callback
@@ -61,8 +85,21 @@ func (e *ExternalToolInstaller) Check(ctx context.Context, sys SystemInfo) (bool
 	return true, version, nil
 }
 
-// NeedsUpdate checks if the installed version is older than the latest available.
+// NeedsUpdate checks if the installed version is older than the latest
+// available (or the bundled one).
 func (e *ExternalToolInstaller) NeedsUpdate(ctx context.Context, currentVersion string) (bool, string, error) {
+	if e.bundle != nil {
+		bt, ok := e.bundle.Tool(e.tool.Name)
+		if !ok || bt.Version == "" {
+			return false, "", fmt.Errorf("bundle has no version of %s", e.tool.Name)
+		}
+		return CompareVersions(currentVersion, bt.Version) < 0, bt.Version, nil
+	}
+
+	if e.tool.Install.Github.Repo == "" {
+		return false, "", fmt.Errorf("updates are only checked for GitHub releases")
+	}
+
 	// Fetch latest release
 	release, err := e.provider.GetLatestRelease(ctx, e.tool.Install.Github.Repo)
 	if err != nil {
@@ -79,17 +116,19 @@ func (e *ExternalToolInstaller) NeedsUpdate(ctx context.Context, currentVersion
 	return false, latestVersion, nil
 }
 
-// Install downloads and installs the external tool.
+// Install downloads and installs the external tool (from the bundle, if set).
 func (e *ExternalToolInstaller) Install(ctx context.Context, sys SystemInfo) error {
+	e.verification = ""
+	if e.bundle != nil {
+		return e.installFromBundle(ctx, sys)
+	}
+	if e.tool.Type == string(DependencyTypePython) {
+		return e.installPip(ctx)
+	}
+
 	// Get platform key
 	platformKey := fmt.Sprintf("%s_%s", sys.OS, sys.Arch)
 
-	// Get asset pattern for this platform
-	assetPattern, ok := e.tool.Install.Github.AssetPatterns[platformKey]
-	if !ok {
-		return fmt.Errorf("no asset pattern for platform %s", platformKey)
-	}
-
 	// Fetch latest release
 	e.reportProgress(PhaseDownloading, "Fetching latest release info...")
 	release, err := e.provider.GetLatestRelease(ctx, e.tool.Install.Github.Repo)
@@ -100,12 +139,6 @@ func (e *ExternalToolInstaller) Install(ctx context.Context, sys SystemInfo) err
 	version := release.GetVersion()
 	e.reportProgress(PhaseDownloading, fmt.Sprintf("Found version %s", version))
 
-	// Find matching asset
-	assetName, assetURL, err := e.provider.FindMatchingAsset(release, assetPattern)
-	if err != nil {
-		return fmt.Errorf("failed to find matching asset: %w", err)
-	}
-
 	// Create temp directory for download
 	tempDir, err := os.MkdirTemp("", "aethonx-install-*")
 	if err != nil {
@@ -113,14 +146,50 @@ func (e *ExternalToolInstaller) Install(ctx context.Context, sys SystemInfo) err
 	}
 	defer os.RemoveAll(tempDir)
 
+	downloadPath, assetName, verification, err := e.fetchAsset(ctx, release, platformKey, tempDir)
+	if err != nil {
+		return err
+	}
+	e.verification = verification
+
+	return e.installAsset(sys, tempDir, downloadPath, assetName)
+}
+
+// fetchAsset downloads the release asset for platformKey ("<os>_<arch>") into
+// dir and verifies it; nothing is returned if verification fails.
+func (e *ExternalToolInstaller) fetchAsset(ctx context.Context, release *providers.GitHubRelease, platformKey, dir string) (path, name string, verification Verification, err error) {
+	// Get asset pattern for this platform
+	assetPattern, ok := e.tool.Install.Github.AssetPatterns[platformKey]
+	if !ok {
+		return "", "", "", fmt.Errorf("no asset pattern for platform %s", platformKey)
+	}
+
+	// Find matching asset
+	assetName, assetURL, err := e.provider.FindMatchingAsset(release, assetPattern)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to find matching asset: %w", err)
+	}
+
 	// Download asset
 	e.reportProgress(PhaseDownloading, fmt.Sprintf("Downloading %s...", assetName))
-	downloadPath := filepath.Join(tempDir, assetName)
+	downloadPath := filepath.Join(dir, assetName)
 	if err := e.provider.DownloadAsset(ctx, assetURL, downloadPath); err != nil {
-		return fmt.Errorf("failed to download asset: %w", err)
+		return "", "", "", fmt.Errorf("failed to download asset: %w", err)
 	}
 	e.reportProgress(PhaseDownloading, "Download complete")
 
+	// Integrity: fail closed before anything is extracted or installed
+	verification, err = e.verifyAsset(ctx, release, dir, downloadPath, assetName)
+	if err != nil {
+		os.Remove(downloadPath)
+		return "", "", "", err
+	}
+	return downloadPath, assetName, verification, nil
+}
+
+// installAsset extracts the binary from a verified asset (archive or bare
+// binary) and copies it to the install directory; tempDir holds the extraction.
+func (e *ExternalToolInstaller) installAsset(sys SystemInfo, tempDir, assetPath, assetName string) error {
 	var binaryPath string
 	binaryName := e.tool.Install.Github.BinaryName
 	if sys.OS == "windows" {
@@ -143,16 +212,17 @@ func (e *ExternalToolInstaller) Install(ctx context.Context, sys SystemInfo) err
 
 		// Extract based on file extension
 		if strings.HasSuffix(lowerAssetName, ".zip") {
-			if err := e.provider.ExtractZip(downloadPath, extractDir); err != nil {
+			if err := e.provider.ExtractZip(assetPath, extractDir); err != nil {
 				return fmt.Errorf("failed to extract zip: %w", err)
 			}
 		} else if strings.HasSuffix(lowerAssetName, ".tar.gz") || strings.HasSuffix(lowerAssetName, ".tgz") {
-			if err := e.provider.ExtractTarGz(downloadPath, extractDir); err != nil {
+			if err := e.provider.ExtractTarGz(assetPath, extractDir); err != nil {
 				return fmt.Errorf("failed to extract tar.gz: %w", err)
 			}
 		}
 
 		// Find binary in extracted files (may be in subdirectory)
+		var err error
 		binaryPath, err = findBinaryInDir(extractDir, binaryName)
 		if err != nil {
 			return fmt.Errorf("binary %s not found in archive: %w", binaryName, err)
@@ -160,7 +230,7 @@ func (e *ExternalToolInstaller) Install(ctx context.Context, sys SystemInfo) err
 		e.reportProgress(PhaseExtracting, "Extraction complete")
 	} else {
 		// Direct binary download - use downloaded file directly
-		binaryPath = downloadPath
+		binaryPath = assetPath
 	}
 
 	// Ensure install directory exists
@@ -187,6 +257,81 @@ func (e *ExternalToolInstaller) Install(ctx context.Context, sys SystemInfo) err
 	return nil
 }
 
+// verifyAsset checks the downloaded asset against the release checksum file
+// and, if declared and cosign is installed, the file's signature.
+func (e *ExternalToolInstaller) verifyAsset(ctx context.Context, release *providers.GitHubRelease, tempDir, assetPath, assetName string) (Verification, error) {
+	gh := e.tool.Install.Github
+	if gh.ChecksumAsset == "" {
+		e.reportProgress(PhaseVerifying, "Release publishes no checksums, integrity not verified")
+		return VerificationUnverified, nil
+	}
+
+	e.reportProgress(PhaseVerifying, "Verifying SHA256 checksum...")
+	sumsPath, err := e.downloadReleaseFile(ctx, release, tempDir, gh.ChecksumAsset)
+	if err != nil {
+		return "", fmt.Errorf("checksum file: %w", err)
+	}
+	data, err := os.ReadFile(sumsPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read checksum file: %w", err)
+	}
+	if err := VerifyChecksum(assetPath, assetName, ParseChecksums(data)); err != nil {
+		return "", err
+	}
+
+	verification := VerificationSHA256
+	if gh.Cosign.SignatureAsset != "" {
+		if !cosignAvailable() {
+			e.reportProgress(PhaseVerifying, "cosign not installed, signature not verified")
+		} else {
+			e.reportProgress(PhaseVerifying, "Verifying checksum file signature...")
+			sigPath, err := e.downloadReleaseFile(ctx, release, tempDir, gh.Cosign.SignatureAsset)
+			if err != nil {
+				return "", fmt.Errorf("signature file: %w", err)
+			}
+			certPath, err := e.downloadReleaseFile(ctx, release, tempDir, gh.Cosign.CertificateAsset)
+			if err != nil {
+				return "", fmt.Errorf("certificate file: %w", err)
+			}
+			if err := verifyBlobSignature(ctx, sumsPath, sigPath, certPath, gh.Cosign.Identity, gh.Cosign.Issuer); err != nil {
+				return "", err
+			}
+			verification = VerificationCosign
+		}
+	}
+
+	e.reportProgress(PhaseVerifying, fmt.Sprintf("Verified (%s)", verification))
+	return verification, nil
+}
+
+// downloadReleaseFile downloads the release asset matching pattern into dir.
+func (e *ExternalToolInstaller) downloadReleaseFile(ctx context.Context, release *providers.GitHubRelease, dir, pattern string) (string, error) {
+	name, url, err := e.provider.FindMatchingAsset(release, pattern)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	if err := e.provider.DownloadAsset(ctx, url, path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// installPip installs a Python package into the user site with pip.
+func (e *ExternalToolInstaller) installPip(ctx context.Context) error {
+	pkg := e.tool.Install.PythonPip.Package
+	e.reportProgress(PhaseInstalling, fmt.Sprintf("Installing %s with pip...", pkg))
+
+	cmd := exec.CommandContext(ctx, "python3", "-m", "pip", "install", "--user", "--upgrade", pkg)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("pip install %s failed: %w: %s", pkg, err, strings.TrimSpace(string(output)))
+	}
+
+	e.verification = VerificationUnverified
+	e.reportProgress(PhaseInstalling, fmt.Sprintf("Installed %s", pkg))
+	return nil
+}
+
 // Validate runs health check on the installed tool.
 func (e *ExternalToolInstaller) Validate(ctx context.Context) error {
 	version, err := GetCommandVersion(ctx, e.tool.HealthCheck.Command, e.tool.HealthCheck.Args)
@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"os"
 	"time"
-
-	"gopkg.in/yaml.v3"
 )
 
 // Orchestrator coordinates the installation of all dependencies.
@@ -15,24 +13,81 @@ type Orchestrator struct {
 	systemInfo       SystemInfo
 	installers       []Installer
 	progressCallback ProgressCallback
+	checkUpdates     bool
+	upgrade          bool
+	records          ToolRecords
+	bundle           *Bundle
 }
 
-// NewOrchestrator creates a new installation orchestrator.
-func NewOrchestrator(configPath string, installDir string) (*Orchestrator, error) {
-	// Load configuration
-	config, err := loadConfig(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load config: %w", err)
-	}
-
-	// Override install directory if provided
+// NewOrchestrator creates a new installation orchestrator for config.
+// installDir overrides config.InstallDirectory when not empty.
+func NewOrchestrator(config Config, installDir string) *Orchestrator {
 	if installDir != "" {
 		config.InstallDirectory = installDir
 	}
+	if config.InstallDirectory == "" {
+		config.InstallDirectory = DefaultInstallDirectory
+	}
 
 	return &Orchestrator{
 		config: config,
-	}, nil
+	}
+}
+
+// SetCheckUpdates makes Install upgrade installed tools that are older than
+// their latest release ("aethonx deps update").
+func (o *Orchestrator) SetCheckUpdates(enabled bool) {
+	o.checkUpdates = enabled
+}
+
+// SetUpgrade makes Install upgrade installed tools that are older than the
+// version the sources require ("aethonx deps install --upgrade"). Without it
+// they are reported as outdated and left untouched.
+func (o *Orchestrator) SetUpgrade(enabled bool) {
+	o.upgrade = enabled
+}
+
+// SetBundle installs tools from an offline bundle instead of downloading
+// them. Must be called before Initialize.
+func (o *Orchestrator) SetBundle(b *Bundle) {
+	o.bundle = b
+}
+
+// SetRecords provides the tool records of previous runs, from which Check
+// reports how installed binaries were verified.
+func (o *Orchestrator) SetRecords(records ToolRecords) {
+	o.records = records
+}
+
+// recordedVerification returns the verification recorded when the installed
+// binary was installed ("" if it was installed some other way or changed).
+func (o *Orchestrator) recordedVerification(inst Installer) Verification {
+	ext, ok := inst.(*ExternalToolInstaller)
+	if !ok {
+		return ""
+	}
+	rec, ok := o.records[ext.Name()]
+	if !ok {
+		return ""
+	}
+	path, err := lookPath(ext.tool.HealthCheck.Command)
+	if err != nil || !rec.Describes(path) {
+		return ""
+	}
+	return rec.Verification
+}
+
+// minVersion returns the required version of installers that declare one.
+func minVersion(inst Installer) string {
+	if ext, ok := inst.(*ExternalToolInstaller); ok {
+		return ext.MinVersion()
+	}
+	return ""
+}
+
+func belowMinVersion(inst Installer, version string) bool {
+	ext, ok := inst.(*ExternalToolInstaller)
+	return ok && ext.BelowMinVersion(version)
 }
 
 // SetProgressCallback sets the progress callback for real-time updates.
@@ -67,6 +122,9 @@ func (o *Orchestrator) Initialize(ctx context.Context) error {
 	for _, tool := range o.config.ExternalTools {
 		if tool.Required {
 			inst := NewExternalToolInstaller(tool)
+			if o.bundle != nil {
+				inst.SetBundle(o.bundle)
+			}
 			// Set progress callback if available
 			if o.progressCallback != nil {
 				inst.SetProgressCallback(o.progressCallback)
@@ -89,16 +147,23 @@ func (o *Orchestrator) Check(ctx context.Context) ([]InstallationResult, error)
 
 		result := InstallationResult{
 			Dependency: Dependency{
-				Name: inst.Name(),
+				Name:       inst.Name(),
+				MinVersion: minVersion(inst),
 			},
 			Duration: time.Since(startTime),
 			Version:  version,
 		}
+		if installed {
+			result.Verification = o.recordedVerification(inst)
+		}
 
 		if err != nil {
 			result.Status = StatusFailed
 			result.Error = err
 			result.Message = fmt.Sprintf("Check failed: %v", err)
+		} else if installed && belowMinVersion(inst, version) {
+			result.Status = StatusOutdated
+			result.Message = fmt.Sprintf("Outdated (version: %s, requires >= %s)", version, result.Dependency.MinVersion)
 		} else if installed {
 			result.Status = StatusAlreadyInstalled
 			result.Message = fmt.Sprintf("Already installed (version: %s)", version)
@@ -122,17 +187,32 @@ func (o *Orchestrator) Install(ctx context.Context, force bool) ([]InstallationR
 
 		result := InstallationResult{
 			Dependency: Dependency{
-				Name: inst.Name(),
+				Name:       inst.Name(),
+				MinVersion: minVersion(inst),
 			},
 		}
 
 		// Check if already installed
 		installed, currentVersion, _ := inst.Check(ctx, o.systemInfo)
+		if installed {
+			result.Verification = o.recordedVerification(inst)
+		}
+
+		// Below the version the sources require: upgrade only if asked to
+		outdated := installed && belowMinVersion(inst, currentVersion)
+		if outdated && !force && !o.upgrade {
+			result.Status = StatusOutdated
+			result.Version = currentVersion
+			result.Message = fmt.Sprintf("Outdated (version: %s, requires >= %s; use --upgrade)", currentVersion, result.Dependency.MinVersion)
+			result.Duration = time.Since(startTime)
+			results = append(results, result)
+			continue
+		}
 
 		// If installed and not forcing, check if update is needed
-		if installed && !force {
+		if installed && !force && !outdated {
 			// Check if installer supports version updates (external tools)
-			if extInst, ok := inst.(*ExternalToolInstaller); ok {
+			if extInst, ok := inst.(*ExternalToolInstaller); ok && o.checkUpdates {
 				needsUpdate, latestVersion, err := extInst.NeedsUpdate(ctx, currentVersion)
 				if err != nil {
 					// If we can't check for updates, assume current version is fine
@@ -156,7 +236,7 @@ func (o *Orchestrator) Install(ctx context.Context, force bool) ([]InstallationR
 				// Update needed - install newer version
 				result.Message = fmt.Sprintf("Updating from %s to %s", currentVersion, latestVersion)
 			} else {
-				// For Go installer, just report already installed
+				// Go installer or update checks disabled: report already installed
 				result.Status = StatusAlreadyInstalled
 				result.Version = currentVersion
 				result.Message = fmt.Sprintf("Already installed (version: %s)", currentVersion)
@@ -192,9 +272,22 @@ func (o *Orchestrator) Install(ctx context.Context, force bool) ([]InstallationR
 
 		// Success
 		_, newVersion, _ := inst.Check(ctx, o.systemInfo)
+		if belowMinVersion(inst, newVersion) {
+			result.Status = StatusFailed
+			result.Version = newVersion
+			result.Phase = PhaseFailed
+			result.Error = fmt.Errorf("latest release %s is older than required %s", newVersion, result.Dependency.MinVersion)
+			result.Message = result.Error.Error()
+			result.Duration = time.Since(startTime)
+			results = append(results, result)
+			continue
+		}
 		result.Status = StatusSuccess
 		result.Version = newVersion
 		result.Phase = PhaseCompleted
+		if ext, ok := inst.(*ExternalToolInstaller); ok {
+			result.Verification = ext.Verification()
+		}
 
 		// Determine install path
 		if extInst, ok := inst.(*ExternalToolInstaller); ok {
@@ -246,19 +339,3 @@ Or add it permanently to your shell profile (%s):
 Note: ~/go/bin is the standard Go binary location. Most Go developers already have this in PATH.
 `, o.systemInfo.InstallDir, o.systemInfo.InstallDir, shellProfile, o.systemInfo.InstallDir, shellProfile)
 }
-
-// loadConfig loads the deps.yaml configuration file.
-func loadConfig(path string) (Config, error) {
-	var config Config
-
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return config, fmt.Errorf("failed to read config file: %w", err)
-	}
-
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return config, fmt.Errorf("failed to parse config file: %w", err)
-	}
-
-	return config, nil
-}
@@ -213,8 +213,11 @@ func (g *GitHubProvider) ExtractTarGz(tarGzPath, destDir string) error {
 			return fmt.Errorf("failed to read tar header: %w", err)
 		}
 
-		// Construct target path
+		// Construct target path, refusing entries that escape destDir
 		target := filepath.Join(destDir, header.Name)
+		if target != filepath.Clean(destDir) && !strings.HasPrefix(target, filepath.Clean(destDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("illegal path in archive: %s", header.Name)
+		}
 
 		switch header.Typeflag {
 		case tar.TypeDir:
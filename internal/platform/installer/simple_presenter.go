@@ -98,6 +98,7 @@ func (s *SimplePresenter) ShowCheckResults(results []InstallationResult) {
 
 	installed := 0
 	missing := 0
+	outdated := 0
 
 	for _, result := range results {
 		// Clean version (truncate long/multiline output)
@@ -111,8 +112,11 @@ func (s *SimplePresenter) ShowCheckResults(results []InstallationResult) {
 
 		switch result.Status {
 		case StatusAlreadyInstalled:
-			fmt.Printf("  ✓ %-15s v%-10s (installed)\n", result.Dependency.Name, version)
+			fmt.Printf("  ✓ %-15s v%-10s (installed, %s)\n", result.Dependency.Name, version, verificationLabel(result.Verification))
 			installed++
+		case StatusOutdated:
+			fmt.Printf("  ⚠ %-15s v%-10s (requires >= %s)\n", result.Dependency.Name, version, result.Dependency.MinVersion)
+			outdated++
 		case StatusPending:
 			fmt.Printf("  ✗ %-15s %-10s   (missing)\n", result.Dependency.Name, "-")
 			missing++
@@ -124,8 +128,12 @@ func (s *SimplePresenter) ShowCheckResults(results []InstallationResult) {
 
 	fmt.Println()
 	if missing > 0 {
-		fmt.Printf("To install %d missing dependencies, run: ./install-deps\n", missing)
-	} else {
+		fmt.Printf("To install %d missing dependencies, run: aethonx deps install\n", missing)
+	}
+	if outdated > 0 {
+		fmt.Printf("To upgrade %d outdated dependencies, run: aethonx deps install --upgrade\n", outdated)
+	}
+	if missing == 0 && outdated == 0 {
 		fmt.Println("All dependencies are installed ✓")
 	}
 	fmt.Println()
@@ -174,7 +182,7 @@ func (s *SimplePresenter) ShowResult(result InstallationResult) {
 		if s.quiet {
 			fmt.Printf("✓ %s v%s\n", result.Dependency.Name, version)
 		} else {
-			fmt.Printf("  ✓ %-15s v%-10s (%.1fs)\n", result.Dependency.Name, version, result.Duration.Seconds())
+			fmt.Printf("  ✓ %-15s v%-10s (%.1fs, %s)\n", result.Dependency.Name, version, result.Duration.Seconds(), verificationLabel(result.Verification))
 		}
 
 	case StatusAlreadyInstalled:
@@ -182,6 +190,9 @@ func (s *SimplePresenter) ShowResult(result InstallationResult) {
 			fmt.Printf("  ✓ %-15s v%-10s (already installed)\n", result.Dependency.Name, version)
 		}
 
+	case StatusOutdated:
+		fmt.Printf("  ⚠ %-15s v%-10s (outdated, requires >= %s; use --upgrade)\n", result.Dependency.Name, version, result.Dependency.MinVersion)
+
 	case StatusFailed:
 		fmt.Printf("  ✗ %-15s FAILED\n", result.Dependency.Name)
 		if !s.quiet && result.ErrorContext != nil {
@@ -324,6 +335,18 @@ func (s *SimplePresenter) ShowSummary(results []InstallationResult, duration tim
 	fmt.Println()
 }
 
+// verificationLabel describes how a binary's integrity was checked.
+func verificationLabel(v Verification) string {
+	switch v {
+	case VerificationSHA256, VerificationCosign:
+		return string(v) + " verified"
+	case VerificationUnverified:
+		return "integrity not verified"
+	default:
+		return "provenance unknown"
+	}
+}
+
 // wrapText wraps text at the specified width.
 func wrapText(text string, width int) string {
 	if len(text) <= width {
@@ -0,0 +1,109 @@
+package installer
+
+import (
+	"sort"
+
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/registry"
+)
+
+// DefaultInstallDirectory is the standard Go binary location.
+const DefaultInstallDirectory = "$HOME/go/bin"
+
+// ToolsFor derives the external tools to install from source metadata: every
+// source that declares a Dependency and runs a binary with its configuration
+// (CLI sources, or API sources with use_cli). Disabled sources are skipped
+// unless includeDisabled is set, and so are sources with runtime: docker. Tools are sorted by name and deduplicated;
+// MinVersion includes the requirements of the configured features.
+func ToolsFor(metadata map[string]ports.SourceMetadata, configs map[string]ports.SourceConfig, includeDisabled bool) []ExternalTool {
+	names := make([]string, 0, len(metadata))
+	for name := range metadata {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	seen := make(map[string]int) // Tool name → index in tools
+	tools := make([]ExternalTool, 0)
+	for _, name := range names {
+		meta := metadata[name]
+		if meta.Dependency == nil {
+			continue
+		}
+
+		cfg, ok := configs[name]
+		if !ok {
+			cfg = ports.DefaultSourceConfig()
+			cfg.Enabled = false
+		}
+		if !cfg.Enabled && !includeDisabled {
+			continue
+		}
+
+		// Containerized sources need docker, not the tool
+		if registry.UsesContainer(cfg) {
+			continue
+		}
+
+		// exec_path may point at a custom location; checks use it as is
+		bin, _ := registry.BinaryStatus(name, meta, cfg)
+		if bin == "" && !includeDisabled {
+			continue // e.g., shodan in API mode
+		}
+		if bin == "" {
+			bin = meta.Dependency.Binary
+		}
+
+		tool := ToolFromDependency(name, *meta.Dependency, bin)
+		tool.MinVersion, _ = RequiredVersion(*meta.Dependency, cfg.Custom)
+		if i, ok := seen[tool.Name]; ok {
+			// Shared binary: keep the highest requirement
+			if CompareVersions(tool.MinVersion, tools[i].MinVersion) > 0 {
+				tools[i].MinVersion = tool.MinVersion
+			}
+			continue
+		}
+		seen[tool.Name] = len(tools)
+		tools = append(tools, tool)
+	}
+
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+	return tools
+}
+
+// ToolFromDependency converts a source's declared dependency into an
+// ExternalTool; command is the binary used for health checks.
+func ToolFromDependency(source string, dep ports.ToolDependency, command string) ExternalTool {
+	tool := ExternalTool{
+		Name:        dep.Binary,
+		Description: "Required by the " + source + " source",
+		Required:    true,
+		Type:        string(DependencyTypeBinary),
+		MinVersion:  dep.MinVersion,
+		Notes:       dep.Notes,
+	}
+
+	if dep.PipPackage != "" {
+		tool.Type = string(DependencyTypePython)
+		tool.Install.PythonPip.Package = dep.PipPackage
+	} else {
+		tool.Install.Github.Repo = dep.Repo
+		tool.Install.Github.AssetPatterns = dep.AssetPatterns
+		tool.Install.Github.BinaryName = dep.Binary
+		tool.Install.Github.ChecksumAsset = dep.ChecksumAsset
+		if dep.Cosign != nil {
+			tool.Install.Github.Cosign.SignatureAsset = dep.Cosign.SignatureAsset
+			tool.Install.Github.Cosign.CertificateAsset = dep.Cosign.CertificateAsset
+			tool.Install.Github.Cosign.Identity = dep.Cosign.Identity
+			tool.Install.Github.Cosign.Issuer = dep.Cosign.Issuer
+		}
+	}
+
+	tool.HealthCheck.Command = command
+	tool.HealthCheck.Args = dep.VersionArgs
+	if len(tool.HealthCheck.Args) == 0 {
+		tool.HealthCheck.Args = []string{"-version"}
+	}
+	tool.HealthCheck.ExpectedContains = dep.VersionMarker
+
+	return tool
+}
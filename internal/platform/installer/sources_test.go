@@ -0,0 +1,67 @@
+package installer
+
+import (
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+)
+
+func TestToolsFor(t *testing.T) {
+	metadata := map[string]ports.SourceMetadata{
+		"cli": {
+			Type: domain.SourceTypeCLI,
+			Dependency: &ports.ToolDependency{
+				Binary:        "cli",
+				Repo:          "owner/cli",
+				AssetPatterns: map[string]string{"linux_amd64": "cli_*_linux_amd64.zip"},
+				VersionMarker: "Current Version",
+			},
+		},
+		"api": {
+			Type:       domain.SourceTypeAPI,
+			Dependency: &ports.ToolDependency{Binary: "apicli", PipPackage: "apicli", VersionArgs: []string{"version"}},
+		},
+		"builtin": {Type: domain.SourceTypeBuiltin},
+		"off": {
+			Type:       domain.SourceTypeCLI,
+			Dependency: &ports.ToolDependency{Binary: "off", Repo: "owner/off"},
+		},
+	}
+	configs := map[string]ports.SourceConfig{
+		"cli":     {Enabled: true, Custom: map[string]interface{}{"exec_path": "/opt/cli/cli"}},
+		"api":     {Enabled: true},
+		"builtin": {Enabled: true},
+		"off":     {Enabled: false},
+	}
+
+	tools := ToolsFor(metadata, configs, false)
+	if len(tools) != 1 {
+		t.Fatalf("expected only the enabled CLI source, got %+v", tools)
+	}
+	cli := tools[0]
+	if cli.Name != "cli" || cli.Install.Github.Repo != "owner/cli" || cli.Install.Github.BinaryName != "cli" {
+		t.Errorf("unexpected tool: %+v", cli)
+	}
+	if cli.HealthCheck.Command != "/opt/cli/cli" {
+		t.Errorf("health check should use exec_path, got %q", cli.HealthCheck.Command)
+	}
+	if len(cli.HealthCheck.Args) != 1 || cli.HealthCheck.Args[0] != "-version" {
+		t.Errorf("expected default -version args, got %v", cli.HealthCheck.Args)
+	}
+
+	// use_cli makes an API source need its binary
+	configs["api"] = ports.SourceConfig{Enabled: true, Custom: map[string]interface{}{"use_cli": true}}
+	tools = ToolsFor(metadata, configs, false)
+	if len(tools) != 2 || tools[0].Name != "apicli" {
+		t.Fatalf("expected apicli and cli, got %+v", tools)
+	}
+	if tools[0].Type != string(DependencyTypePython) || tools[0].Install.PythonPip.Package != "apicli" {
+		t.Errorf("expected pip install for apicli, got %+v", tools[0])
+	}
+
+	tools = ToolsFor(metadata, configs, true)
+	if len(tools) != 3 {
+		t.Errorf("includeDisabled should add off, got %d tools", len(tools))
+	}
+}
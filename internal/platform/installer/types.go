@@ -13,17 +13,19 @@ const (
 	DependencyTypeGo     DependencyType = "go"
 	DependencyTypeBinary DependencyType = "binary"
 	DependencyTypeSystem DependencyType = "system"
+	DependencyTypePython DependencyType = "python_package"
 )
 
 // Status represents the installation status of a dependency.
 type Status string
 
 const (
-	StatusSuccess           Status = "success"
-	StatusFailed            Status = "failed"
-	StatusSkipped           Status = "skipped"
-	StatusAlreadyInstalled  Status = "already_installed"
-	StatusPending           Status = "pending"
+	StatusSuccess          Status = "success"
+	StatusFailed           Status = "failed"
+	StatusSkipped          Status = "skipped"
+	StatusAlreadyInstalled Status = "already_installed"
+	StatusPending          Status = "pending"
+	StatusOutdated         Status = "outdated" // Installed below the required version
 )
 
 // InstallationPhase represents the current phase of installation.
@@ -32,6 +34,7 @@ type InstallationPhase string
 const (
 	PhaseChecking    InstallationPhase = "checking"
 	PhaseDownloading InstallationPhase = "downloading"
+	PhaseVerifying   InstallationPhase = "verifying"
 	PhaseExtracting  InstallationPhase = "extracting"
 	PhaseInstalling  InstallationPhase = "installing"
 	PhaseValidating  InstallationPhase = "validating"
@@ -41,11 +44,11 @@ const (
 
 // SystemInfo contains system detection information.
 type SystemInfo struct {
-	OS           string // linux, darwin, windows
-	Arch         string // amd64, arm64
-	GoVersion    string
-	InstallDir   string
-	PathEntries  []string
+	OS          string // linux, darwin, windows
+	Arch        string // amd64, arm64
+	GoVersion   string
+	InstallDir  string
+	PathEntries []string
 }
 
 // Dependency represents a single dependency requirement.
@@ -69,19 +72,20 @@ type InstallationResult struct {
 	Duration      time.Duration
 	Message       string
 	Phase         InstallationPhase
-	InstallPath   string // Where the tool was installed
-	AlreadyLatest bool   // True if already had latest version
+	InstallPath   string       // Where the tool was installed
+	AlreadyLatest bool         // True if already had latest version
+	Verification  Verification // Integrity check of the installed binary ("" = unknown)
 }
 
-// Config represents the parsed deps.yaml configuration.
+// Config lists the dependencies to install (see ToolsFor).
 type Config struct {
 	Go struct {
 		MinVersion      string `yaml:"min_version"`
 		ModulesRequired bool   `yaml:"modules_required"`
 	} `yaml:"go"`
-	ExternalTools []ExternalTool `yaml:"external_tools"`
-	InstallDirectory string      `yaml:"install_directory"`
-	AddToPath        bool        `yaml:"add_to_path"`
+	ExternalTools    []ExternalTool `yaml:"external_tools"`
+	InstallDirectory string         `yaml:"install_directory"`
+	AddToPath        bool           `yaml:"add_to_path"`
 }
 
 // ExternalTool represents an external tool dependency configuration.
@@ -95,14 +99,25 @@ type ExternalTool struct {
 			Repo          string            `yaml:"repo"`
 			AssetPatterns map[string]string `yaml:"asset_patterns"`
 			BinaryName    string            `yaml:"binary_name"`
+			ChecksumAsset string            `yaml:"checksum_asset"`
+			Cosign        struct {
+				SignatureAsset   string `yaml:"signature_asset"`
+				CertificateAsset string `yaml:"certificate_asset"`
+				Identity         string `yaml:"identity"`
+				Issuer           string `yaml:"issuer"`
+			} `yaml:"cosign"`
 		} `yaml:"github"`
+		PythonPip struct {
+			Package string `yaml:"package"`
+		} `yaml:"python_pip"`
 	} `yaml:"install"`
 	HealthCheck struct {
-		Command         string   `yaml:"command"`
-		Args            []string `yaml:"args"`
-		ExpectedContains string  `yaml:"expected_contains"`
+		Command          string   `yaml:"command"`
+		Args             []string `yaml:"args"`
+		ExpectedContains string   `yaml:"expected_contains"`
 	} `yaml:"health_check"`
 	MinVersion string `yaml:"min_version"`
+	Notes      string `yaml:"notes"`
 }
 
 // ProgressCallback is called during installation to report progress.
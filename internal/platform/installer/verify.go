@@ -0,0 +1,92 @@
+package installer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Verification describes how the integrity of an installed binary was checked.
+type Verification string
+
+const (
+	VerificationSHA256     Verification = "sha256"        // Matched the release checksum file
+	VerificationCosign     Verification = "sha256+cosign" // Checksum file signature also verified
+	VerificationUnverified Verification = "unverified"    // Release publishes no checksums (or pip)
+)
+
+// ParseChecksums parses a sha256sum-style file ("<hex>  <name>" per line,
+// "*<name>" for binary mode) into asset name → lowercase hex digest.
+func ParseChecksums(data []byte) map[string]string {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || len(fields[0]) != sha256.Size*2 {
+			continue
+		}
+		name := strings.TrimPrefix(fields[1], "*")
+		sums[filepath.Base(name)] = strings.ToLower(fields[0])
+	}
+	return sums
+}
+
+// VerifyChecksum checks the file at path against the digest listed for
+// assetName. A missing entry is an error: verification fails closed.
+func VerifyChecksum(path, assetName string, sums map[string]string) error {
+	want, ok := sums[assetName]
+	if !ok {
+		return fmt.Errorf("checksum for %s not listed in the release checksum file", assetName)
+	}
+
+	got, err := fileSHA256(path)
+	if err != nil {
+		return fmt.Errorf("failed to hash %s: %w", assetName, err)
+	}
+	if got != want {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", assetName, want, got)
+	}
+	return nil
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// cosignAvailable reports whether the cosign CLI is installed (replaceable in tests).
+var cosignAvailable = func() bool {
+	_, err := exec.LookPath("cosign")
+	return err == nil
+}
+
+// verifyBlobSignature runs "cosign verify-blob" with keyless verification.
+func verifyBlobSignature(ctx context.Context, blob, signature, certificate, identity, issuer string) error {
+	cmd := exec.CommandContext(ctx, "cosign", "verify-blob",
+		"--signature", signature,
+		"--certificate", certificate,
+		"--certificate-identity-regexp", identity,
+		"--certificate-oidc-issuer", issuer,
+		blob)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("signature verification failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package installer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"aethonx/internal/platform/installer/providers"
+)
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestParseChecksums(t *testing.T) {
+	data := strings.Join([]string{
+		sha256Hex("a") + "  tool_1.0.0_linux_amd64.zip",
+		strings.ToUpper(sha256Hex("b")) + " *tool_1.0.0_macOS_arm64.zip",
+		"not a checksum line",
+		"",
+	}, "\n")
+
+	sums := ParseChecksums([]byte(data))
+	if len(sums) != 2 {
+		t.Fatalf("expected 2 entries, got %v", sums)
+	}
+	if sums["tool_1.0.0_linux_amd64.zip"] != sha256Hex("a") {
+		t.Errorf("unexpected linux digest %q", sums["tool_1.0.0_linux_amd64.zip"])
+	}
+	if sums["tool_1.0.0_macOS_arm64.zip"] != sha256Hex("b") {
+		t.Errorf("binary-mode entry should be parsed and lowercased, got %q", sums["tool_1.0.0_macOS_arm64.zip"])
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "asset.zip")
+	if err := os.WriteFile(path, []byte("payload"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifyChecksum(path, "asset.zip", map[string]string{"asset.zip": sha256Hex("payload")}); err != nil {
+		t.Errorf("expected match, got %v", err)
+	}
+
+	err := VerifyChecksum(path, "asset.zip", map[string]string{"asset.zip": sha256Hex("tampered")})
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("expected checksum mismatch, got %v", err)
+	}
+
+	if err := VerifyChecksum(path, "asset.zip", map[string]string{}); err == nil {
+		t.Error("expected an error when the asset is not listed")
+	}
+}
+
+// releaseServer serves files as release assets and returns the release.
+func releaseServer(t *testing.T, files map[string]string) *providers.GitHubRelease {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	release := &providers.GitHubRelease{TagName: "v1.0.0"}
+	for name := range files {
+		release.Assets = append(release.Assets, struct {
+			Name               string `json:"name"`
+			BrowserDownloadURL string `json:"browser_download_url"`
+		}{Name: name, BrowserDownloadURL: srv.URL + "/" + name})
+	}
+	return release
+}
+
+func TestExternalToolInstaller_VerifyAsset(t *testing.T) {
+	const assetName = "tool_1.0.0_linux_amd64.zip"
+
+	newInstaller := func(checksumAsset string) *ExternalToolInstaller {
+		tool := ExternalTool{Name: "tool"}
+		tool.Install.Github.ChecksumAsset = checksumAsset
+		return NewExternalToolInstaller(tool)
+	}
+	writeAsset := func(t *testing.T, dir, content string) string {
+		path := filepath.Join(dir, assetName)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("matching checksum", func(t *testing.T) {
+		dir := t.TempDir()
+		release := releaseServer(t, map[string]string{
+			"tool_1.0.0_checksums.txt": sha256Hex("binary") + "  " + assetName + "\n",
+		})
+		got, err := newInstaller("tool_*checksums.txt").verifyAsset(context.Background(), release, dir, writeAsset(t, dir, "binary"), assetName)
+		if err != nil || got != VerificationSHA256 {
+			t.Errorf("verifyAsset() = (%q, %v), want (%q, nil)", got, err, VerificationSHA256)
+		}
+	})
+
+	t.Run("mismatch fails closed", func(t *testing.T) {
+		dir := t.TempDir()
+		release := releaseServer(t, map[string]string{
+			"tool_1.0.0_checksums.txt": sha256Hex("original") + "  " + assetName + "\n",
+		})
+		if _, err := newInstaller("tool_*checksums.txt").verifyAsset(context.Background(), release, dir, writeAsset(t, dir, "tampered"), assetName); err == nil {
+			t.Error("expected a checksum mismatch error")
+		}
+	})
+
+	t.Run("declared checksum file missing fails closed", func(t *testing.T) {
+		dir := t.TempDir()
+		release := releaseServer(t, map[string]string{})
+		if _, err := newInstaller("tool_*checksums.txt").verifyAsset(context.Background(), release, dir, writeAsset(t, dir, "binary"), assetName); err == nil {
+			t.Error("expected an error when the checksum file is absent")
+		}
+	})
+
+	t.Run("no checksums published", func(t *testing.T) {
+		dir := t.TempDir()
+		got, err := newInstaller("").verifyAsset(context.Background(), &providers.GitHubRelease{}, dir, writeAsset(t, dir, "binary"), assetName)
+		if err != nil || got != VerificationUnverified {
+			t.Errorf("verifyAsset() = (%q, %v), want (%q, nil)", got, err, VerificationUnverified)
+		}
+	})
+}
@@ -0,0 +1,207 @@
+package installer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/registry"
+)
+
+// ToolRecord is the version of a tool recorded by "aethonx deps".
+type ToolRecord struct {
+	Version      string       `json:"version"`
+	Path         string       `json:"path"`
+	RecordedAt   time.Time    `json:"recorded_at"`
+	Verification Verification `json:"verification,omitempty"`
+}
+
+// Describes reports whether the record still describes the binary at path:
+// same location and not modified since it was recorded.
+func (rec ToolRecord) Describes(path string) bool {
+	if rec.Path == "" || rec.Path != path {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && !info.ModTime().After(rec.RecordedAt)
+}
+
+// ToolRecords maps binary names to their recorded installation.
+type ToolRecords map[string]ToolRecord
+
+// DefaultRecordPath returns ~/.aethonx/tools.json.
+func DefaultRecordPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".aethonx", "tools.json"), nil
+}
+
+// LoadRecords reads the records at path (empty if the file does not exist).
+func LoadRecords(path string) (ToolRecords, error) {
+	records := make(ToolRecords)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool records: %w", err)
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse tool records %s: %w", path, err)
+	}
+	return records, nil
+}
+
+// Save writes the records to path, creating its directory.
+func (r ToolRecords) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create records directory: %w", err)
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write tool records: %w", err)
+	}
+	return nil
+}
+
+// Record stores the versions found by a check or installation.
+func (r ToolRecords) Record(tools []ExternalTool, results []InstallationResult, now time.Time) {
+	commands := make(map[string]string, len(tools))
+	for _, t := range tools {
+		commands[t.Name] = t.HealthCheck.Command
+	}
+
+	for _, res := range results {
+		if res.Version == "" || (res.Status != StatusSuccess && res.Status != StatusAlreadyInstalled && res.Status != StatusOutdated) {
+			continue
+		}
+		path, _ := lookPath(commands[res.Dependency.Name])
+		r[res.Dependency.Name] = ToolRecord{
+			Version:      res.Version,
+			Path:         path,
+			RecordedAt:   now,
+			Verification: res.Verification,
+		}
+	}
+}
+
+// lookPath resolves binaries (replaceable in tests).
+var lookPath = exec.LookPath
+
+// RequiredVersion returns the minimum version of dep that a source needs with
+// the given Custom options, and why (the feature that raises it, if any).
+func RequiredVersion(dep ports.ToolDependency, custom map[string]interface{}) (version, reason string) {
+	version = dep.MinVersion
+	for _, f := range dep.FeatureVersions {
+		value := registry.GetStringConfig(custom, f.Option, "")
+		if value == "" || (f.Value != "" && value != f.Value) {
+			continue
+		}
+		if version == "" || CompareVersions(f.MinVersion, version) > 0 {
+			version, reason = f.MinVersion, f.Reason
+		}
+	}
+	return version, reason
+}
+
+// VersionIssue is a tool installed with a version older than a source needs.
+type VersionIssue struct {
+	Source    string
+	Binary    string
+	Installed string
+	Required  string
+	Reason    string
+}
+
+func (i VersionIssue) String() string {
+	msg := fmt.Sprintf("%s: %s %s is older than required %s", i.Source, i.Binary, i.Installed, i.Required)
+	if i.Reason != "" {
+		msg += " (" + i.Reason + ")"
+	}
+	return msg
+}
+
+// CheckVersions compares the installed tools of the enabled sources with the
+// versions their configuration requires. Installed versions come from records
+// when the recorded binary is still the one on PATH and unchanged since, or
+// else from running it. Missing binaries and unknown versions are not
+// reported ("aethonx config validate" and "aethonx doctor" cover those).
+func CheckVersions(ctx context.Context, metadata map[string]ports.SourceMetadata, configs map[string]ports.SourceConfig, records ToolRecords) []VersionIssue {
+	var issues []VersionIssue
+	for name, meta := range metadata {
+		cfg, ok := configs[name]
+		if !ok || !cfg.Enabled || meta.Dependency == nil || registry.UsesContainer(cfg) {
+			continue // Containers pin their version with the image
+		}
+
+		required, reason := RequiredVersion(*meta.Dependency, cfg.Custom)
+		if required == "" {
+			continue
+		}
+
+		bin, path := registry.BinaryStatus(name, meta, cfg)
+		if path == "" {
+			continue
+		}
+
+		installed := recordedVersion(records[meta.Dependency.Binary], path)
+		if installed == "" {
+			args := meta.Dependency.VersionArgs
+			if len(args) == 0 {
+				args = []string{"-version"}
+			}
+			installed = DetectVersion(ctx, path, args)
+		}
+		if installed == "" || CompareVersions(installed, required) >= 0 {
+			continue
+		}
+
+		issues = append(issues, VersionIssue{
+			Source:    name,
+			Binary:    bin,
+			Installed: installed,
+			Required:  required,
+			Reason:    reason,
+		})
+	}
+
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Source < issues[j].Source })
+	return issues
+}
+
+// recordedVersion returns the recorded version if it still describes the
+// binary at path.
+func recordedVersion(rec ToolRecord, path string) string {
+	if !rec.Describes(path) {
+		return ""
+	}
+	return rec.Version
+}
+
+// DetectVersion runs a tool and extracts its version ("" if unknown).
+func DetectVersion(ctx context.Context, command string, args []string) string {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	output, err := GetCommandVersion(ctx, command, args)
+	if err != nil {
+		return ""
+	}
+	version := ExtractVersion(output)
+	if !isValidVersion(version) {
+		return ""
+	}
+	return version
+}
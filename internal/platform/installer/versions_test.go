@@ -0,0 +1,151 @@
+package installer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+)
+
+func TestRequiredVersion(t *testing.T) {
+	dep := ports.ToolDependency{
+		Binary:     "httpx",
+		MinVersion: "1.3.0",
+		FeatureVersions: []ports.FeatureVersion{
+			{Option: "profile", Value: "headless", MinVersion: "1.6.1", Reason: "headless profile uses -screenshot-idle"},
+			{Option: "legacy", MinVersion: "1.0.0", Reason: "lower than the base minimum"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		custom      map[string]interface{}
+		wantVersion string
+		wantReason  string
+	}{
+		{"no options", nil, "1.3.0", ""},
+		{"other profile", map[string]interface{}{"profile": "full"}, "1.3.0", ""},
+		{"feature raises minimum", map[string]interface{}{"profile": "headless"}, "1.6.1", "headless profile uses -screenshot-idle"},
+		{"feature below minimum", map[string]interface{}{"legacy": "yes"}, "1.3.0", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, reason := RequiredVersion(dep, tt.custom)
+			if version != tt.wantVersion || reason != tt.wantReason {
+				t.Errorf("RequiredVersion() = (%q, %q), want (%q, %q)", version, reason, tt.wantVersion, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestToolRecords_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "tools.json")
+
+	records, err := LoadRecords(path)
+	if err != nil {
+		t.Fatalf("LoadRecords() on missing file error = %v", err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("expected empty records, got %+v", records)
+	}
+
+	orig := lookPath
+	lookPath = func(bin string) (string, error) { return "/usr/local/bin/" + bin, nil }
+	t.Cleanup(func() { lookPath = orig })
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tool := ExternalTool{Name: "httpx"}
+	tool.HealthCheck.Command = "httpx"
+	tools := []ExternalTool{tool}
+	results := []InstallationResult{
+		{Dependency: Dependency{Name: "httpx"}, Status: StatusAlreadyInstalled, Version: "1.6.9"},
+		{Dependency: Dependency{Name: "amass"}, Status: StatusFailed, Version: "4.2.0"},
+	}
+	records.Record(tools, results, now)
+
+	if err := records.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadRecords(path)
+	if err != nil {
+		t.Fatalf("LoadRecords() error = %v", err)
+	}
+	if len(loaded) != 1 {
+		t.Fatalf("expected only the installed tool to be recorded, got %+v", loaded)
+	}
+	rec := loaded["httpx"]
+	if rec.Version != "1.6.9" || rec.Path != "/usr/local/bin/httpx" || !rec.RecordedAt.Equal(now) {
+		t.Errorf("unexpected record %+v", rec)
+	}
+}
+
+func TestCheckVersions(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "fakehttpx")
+	script := "#!/bin/sh\necho 'Current Version: v1.5.0'\n"
+	if err := os.WriteFile(bin, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := map[string]ports.SourceMetadata{
+		"httpx": {
+			Type: domain.SourceTypeCLI,
+			Dependency: &ports.ToolDependency{
+				Binary: "httpx",
+				FeatureVersions: []ports.FeatureVersion{
+					{Option: "profile", Value: "headless", MinVersion: "1.6.1", Reason: "headless profile uses -screenshot-idle"},
+				},
+			},
+		},
+	}
+	headless := map[string]ports.SourceConfig{
+		"httpx": {Enabled: true, Custom: map[string]interface{}{"exec_path": bin, "profile": "headless"}},
+	}
+
+	t.Run("detected version below requirement", func(t *testing.T) {
+		issues := CheckVersions(context.Background(), metadata, headless, nil)
+		if len(issues) != 1 {
+			t.Fatalf("expected 1 issue, got %+v", issues)
+		}
+		got := issues[0]
+		if got.Source != "httpx" || got.Installed != "1.5.0" || got.Required != "1.6.1" {
+			t.Errorf("unexpected issue %+v", got)
+		}
+	})
+
+	t.Run("recorded version is trusted while the binary is unchanged", func(t *testing.T) {
+		records := ToolRecords{"httpx": {Version: "1.7.0", Path: bin, RecordedAt: time.Now().Add(time.Minute)}}
+		if issues := CheckVersions(context.Background(), metadata, headless, records); len(issues) != 0 {
+			t.Errorf("expected no issues, got %+v", issues)
+		}
+	})
+
+	t.Run("stale record is ignored", func(t *testing.T) {
+		records := ToolRecords{"httpx": {Version: "1.7.0", Path: bin, RecordedAt: time.Now().Add(-time.Hour)}}
+		if issues := CheckVersions(context.Background(), metadata, headless, records); len(issues) != 1 {
+			t.Errorf("expected the detected version to be used, got %+v", issues)
+		}
+	})
+
+	t.Run("no feature requirement", func(t *testing.T) {
+		configs := map[string]ports.SourceConfig{
+			"httpx": {Enabled: true, Custom: map[string]interface{}{"exec_path": bin}},
+		}
+		if issues := CheckVersions(context.Background(), metadata, configs, nil); len(issues) != 0 {
+			t.Errorf("expected no issues, got %+v", issues)
+		}
+	})
+
+	t.Run("missing binary is not reported", func(t *testing.T) {
+		configs := map[string]ports.SourceConfig{
+			"httpx": {Enabled: true, Custom: map[string]interface{}{"exec_path": filepath.Join(dir, "absent"), "profile": "headless"}},
+		}
+		if issues := CheckVersions(context.Background(), metadata, configs, nil); len(issues) != 0 {
+			t.Errorf("expected no issues, got %+v", issues)
+		}
+	})
+}
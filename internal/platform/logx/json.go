@@ -0,0 +1,100 @@
+// internal/platform/logx/json.go
+package logx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// jsonLogger escribe una línea JSON por evento (útil para ficheros y post-proceso).
+type jsonLogger struct {
+	mu    *sync.Mutex // compartido entre clones: todos escriben al mismo writer
+	lvl   Level
+	scope []any // pares key/value fijos
+	w     io.Writer
+}
+
+// NewJSON crea un logger que emite JSON lines en w.
+func NewJSON(w io.Writer, lvl Level) Logger {
+	return &jsonLogger{
+		mu:  &sync.Mutex{},
+		lvl: lvl,
+		w:   w,
+	}
+}
+
+func (j *jsonLogger) With(kv ...any) Logger {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return &jsonLogger{
+		mu:    j.mu,
+		lvl:   j.lvl,
+		scope: append(append([]any{}, j.scope...), kv...),
+		w:     j.w,
+	}
+}
+
+func (j *jsonLogger) SetLevel(lvl Level) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.lvl = lvl
+}
+
+func (j *jsonLogger) Debug(msg string, kv ...any) { j.log(LevelDebug, "debug", msg, kv...) }
+func (j *jsonLogger) Info(msg string, kv ...any)  { j.log(LevelInfo, "info", msg, kv...) }
+func (j *jsonLogger) Warn(msg string, kv ...any)  { j.log(LevelWarn, "warn", msg, kv...) }
+func (j *jsonLogger) Err(err error, kv ...any) {
+	if err == nil {
+		return
+	}
+	kv = append([]any{"error", err.Error()}, kv...)
+	j.log(LevelError, "error", "", kv...)
+}
+
+func (j *jsonLogger) log(l Level, level, msg string, kv ...any) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if l < j.lvl {
+		return
+	}
+
+	entry := make(map[string]any, 3+(len(j.scope)+len(kv))/2)
+	addFields(entry, j.scope)
+	addFields(entry, kv)
+	entry["ts"] = time.Now().Format(time.RFC3339Nano)
+	entry["level"] = level
+	if msg != "" {
+		entry["msg"] = msg
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		data = []byte(fmt.Sprintf(`{"level":"error","msg":"log marshal failed","error":%q}`, err.Error()))
+	}
+	_, _ = j.w.Write(append(data, '\n'))
+}
+
+// addFields vuelca pares key/value en entry (valores no serializables se formatean).
+func addFields(entry map[string]any, kv []any) {
+	for i := 0; i < len(kv); i += 2 {
+		key := fmt.Sprintf("%v", kv[i])
+		var v any = "(missing)"
+		if i+1 < len(kv) {
+			v = kv[i+1]
+		}
+		switch val := v.(type) {
+		case error:
+			v = val.Error()
+		case fmt.Stringer:
+			v = val.String()
+		}
+		if _, err := json.Marshal(v); err != nil {
+			v = fmt.Sprintf("%v", v)
+		}
+		entry[key] = v
+	}
+}
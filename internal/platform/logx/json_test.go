@@ -0,0 +1,59 @@
+// internal/platform/logx/json_test.go
+package logx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJSONLogger_WritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewJSON(&buf, LevelDebug).With("component", "test")
+
+	logger.Info("hello", "count", 3)
+	logger.Err(errors.New("boom"), "phase", "run")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("invalid JSON line: %v", err)
+	}
+	if entry["msg"] != "hello" || entry["level"] != "info" || entry["component"] != "test" {
+		t.Errorf("unexpected entry: %v", entry)
+	}
+	if entry["count"] != float64(3) {
+		t.Errorf("count = %v, want 3", entry["count"])
+	}
+
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatalf("invalid JSON line: %v", err)
+	}
+	if entry["error"] != "boom" || entry["level"] != "error" {
+		t.Errorf("unexpected error entry: %v", entry)
+	}
+}
+
+func TestTee_IndependentLevels(t *testing.T) {
+	var console, file bytes.Buffer
+	logger := Tee(NewText(&console, LevelError), NewJSON(&file, LevelDebug))
+
+	logger.Debug("debug only to file")
+	logger.With("source", "crtsh").Warn("warn only to file")
+
+	if console.Len() != 0 {
+		t.Errorf("console should be silent, got %q", console.String())
+	}
+	if got := strings.Count(file.String(), "\n"); got != 2 {
+		t.Errorf("file lines = %d, want 2", got)
+	}
+	if !strings.Contains(file.String(), `"source":"crtsh"`) {
+		t.Errorf("scoped fields missing in file output: %q", file.String())
+	}
+}
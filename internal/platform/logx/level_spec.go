@@ -0,0 +1,83 @@
+// internal/platform/logx/level_spec.go
+package logx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LevelSpec describe el nivel por defecto y overrides por componente.
+//
+// Formato (AETHONX_LOG_LEVEL): lista separada por comas donde una entrada sin
+// "=" fija el nivel por defecto y "nombre=nivel" fija un override:
+//
+//	warn,orchestrator=debug,crtsh=debug,httpclient=error
+//
+// Un nombre coincide con el valor de los campos "component" o "source" que se
+// añaden con With(). El nombre especial "sources" aplica a cualquier logger
+// con campo "source" (un override por nombre concreto tiene prioridad).
+type LevelSpec struct {
+	Default    Level
+	Components map[string]Level
+}
+
+// sourcesGroup nombre que agrupa todos los loggers de sources
+const sourcesGroup = "sources"
+
+// ParseLevelSpec parsea la especificación; entradas inválidas se ignoran.
+func ParseLevelSpec(s string) LevelSpec {
+	spec := LevelSpec{
+		Default:    LevelInfo,
+		Components: make(map[string]Level),
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, lvl, found := strings.Cut(part, "=")
+		if !found {
+			spec.Default = parseLevel(name)
+			continue
+		}
+
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		spec.Components[name] = parseLevel(lvl)
+	}
+
+	return spec
+}
+
+// resolve retorna el nivel para un logger derivado con los campos kv.
+// Si ningún campo coincide con un override se conserva current.
+func (s *LevelSpec) resolve(current Level, kv []any) Level {
+	if s == nil || len(s.Components) == 0 {
+		return current
+	}
+
+	lvl := current
+	for i := 0; i+1 < len(kv); i += 2 {
+		key := fmt.Sprintf("%v", kv[i])
+		if key != "component" && key != "source" {
+			continue
+		}
+
+		if key == "source" {
+			if groupLvl, ok := s.Components[sourcesGroup]; ok {
+				lvl = groupLvl
+			}
+		}
+
+		name := strings.ToLower(fmt.Sprintf("%v", kv[i+1]))
+		if override, ok := s.Components[name]; ok {
+			return override
+		}
+	}
+
+	return lvl
+}
@@ -0,0 +1,57 @@
+// internal/platform/logx/level_spec_test.go
+package logx
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestParseLevelSpec(t *testing.T) {
+	spec := ParseLevelSpec(" warn, orchestrator=debug ,CRTSH=debug,httpclient=error,=info,bogus")
+
+	// "bogus" sin "=" redefine el default (nivel inválido -> info)
+	if spec.Default != LevelInfo {
+		t.Errorf("Default = %v, want %v", spec.Default, LevelInfo)
+	}
+
+	want := map[string]Level{
+		"orchestrator": LevelDebug,
+		"crtsh":        LevelDebug,
+		"httpclient":   LevelError,
+	}
+	if len(spec.Components) != len(want) {
+		t.Fatalf("Components = %v, want %v", spec.Components, want)
+	}
+	for name, lvl := range want {
+		if spec.Components[name] != lvl {
+			t.Errorf("Components[%q] = %v, want %v", name, spec.Components[name], lvl)
+		}
+	}
+}
+
+func TestLevelSpec_ComponentOverrides(t *testing.T) {
+	var buf bytes.Buffer
+	spec := ParseLevelSpec("warn,sources=info,crtsh=debug,httpclient=error")
+	root := &simpleLogger{lvl: spec.Default, lg: log.New(&buf, "", 0), spec: &spec}
+
+	root.Info("root info hidden")
+	root.With("component", "httpclient").Warn("httpclient warn hidden")
+	root.With("source", "rdap").Info("rdap info shown")
+	root.With("source", "rdap").Debug("rdap debug hidden")
+	root.With("source", "crtsh").With("phase", "query").Debug("crtsh debug shown")
+	root.With("component", "orchestrator").Warn("orchestrator warn shown")
+
+	out := buf.String()
+	for _, hidden := range []string{"root info hidden", "httpclient warn hidden", "rdap debug hidden"} {
+		if strings.Contains(out, hidden) {
+			t.Errorf("output should not contain %q", hidden)
+		}
+	}
+	for _, shown := range []string{"rdap info shown", "crtsh debug shown", "orchestrator warn shown"} {
+		if !strings.Contains(out, shown) {
+			t.Errorf("output should contain %q", shown)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package logx
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
@@ -33,14 +34,22 @@ type simpleLogger struct {
 	lvl   Level
 	scope []string // pares key=value fijos
 	lg    *log.Logger
+	spec  *LevelSpec // overrides por componente (nil = sin overrides)
 }
 
+// New creates a stderr logger configured from AETHONX_LOG_LEVEL
+// (e.g., "info" or "warn,orchestrator=debug,crtsh=debug").
 func New() Logger {
-	l := &simpleLogger{
-		lvl: parseLevel(os.Getenv("AETHONX_LOG_LEVEL")),
-		lg:  log.New(os.Stderr, "", 0),
+	return NewWithSpec(ParseLevelSpec(os.Getenv("AETHONX_LOG_LEVEL")))
+}
+
+// NewWithSpec creates a stderr logger with per-component level overrides
+func NewWithSpec(spec LevelSpec) Logger {
+	return &simpleLogger{
+		lvl:  spec.Default,
+		lg:   log.New(os.Stderr, "", 0),
+		spec: &spec,
 	}
-	return l
 }
 
 // NewWithLevel creates a logger with a specific log level
@@ -52,15 +61,28 @@ func NewWithLevel(lvl Level) Logger {
 	return l
 }
 
+// NewText creates a text logger (same format as console) writing to w
+func NewText(w io.Writer, lvl Level) Logger {
+	return &simpleLogger{
+		lvl: lvl,
+		lg:  log.New(w, "", 0),
+	}
+}
+
 // NewSilent creates a logger that only outputs errors (silent mode for UI)
 func NewSilent() Logger {
 	return NewWithLevel(LevelError)
 }
 
 func (s *simpleLogger) With(kv ...any) Logger {
-	clone := *s
-	clone.scope = append(append([]string{}, s.scope...), kvPairs(kv...)...)
-	return &clone
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &simpleLogger{
+		lvl:   s.spec.resolve(s.lvl, kv),
+		scope: append(append([]string{}, s.scope...), kvPairs(kv...)...),
+		lg:    s.lg,
+		spec:  s.spec,
+	}
 }
 
 func (s *simpleLogger) SetLevel(lvl Level) {
@@ -115,6 +137,12 @@ func kvPairs(kv ...any) []string {
 	return out
 }
 
+// ParseLevel converts a level name (debug, info, warn, error) to Level.
+// Unknown values default to LevelInfo.
+func ParseLevel(s string) Level {
+	return parseLevel(s)
+}
+
 func parseLevel(s string) Level {
 	switch strings.ToLower(strings.TrimSpace(s)) {
 	case "debug", "dbg":
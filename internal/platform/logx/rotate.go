@@ -0,0 +1,161 @@
+// internal/platform/logx/rotate.go
+package logx
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotateOptions configura la rotación del fichero de log.
+type RotateOptions struct {
+	MaxSizeBytes int64         // Rotar al superar este tamaño (0 = sin límite)
+	MaxAge       time.Duration // Rotar cuando el fichero actual supera esta edad (0 = sin límite)
+	MaxBackups   int           // Ficheros rotados a conservar (0 = todos)
+}
+
+// RotatingFile es un io.WriteCloser que rota por tamaño y/o antigüedad.
+// Los ficheros rotados se renombran a <name>.<timestamp><ext>.
+type RotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	opts     RotateOptions
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// NewRotatingFile abre (o crea) path en modo append.
+func NewRotatingFile(path string, opts RotateOptions) (*RotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	r := &RotatingFile{path: path, opts: opts}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write escribe p, rotando antes si el límite de tamaño o edad se alcanzó.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if r.shouldRotate(int64(len(p))) {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Rotate fuerza una rotación inmediata.
+func (r *RotatingFile) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rotate()
+}
+
+// Close cierra el fichero actual.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+func (r *RotatingFile) shouldRotate(incoming int64) bool {
+	if r.size == 0 {
+		return false
+	}
+	if r.opts.MaxSizeBytes > 0 && r.size+incoming > r.opts.MaxSizeBytes {
+		return true
+	}
+	if r.opts.MaxAge > 0 && time.Since(r.openedAt) > r.opts.MaxAge {
+		return true
+	}
+	return false
+}
+
+func (r *RotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	r.file = f
+	r.size = info.Size()
+	r.openedAt = time.Now()
+	return nil
+}
+
+func (r *RotatingFile) rotate() error {
+	if r.file != nil {
+		if err := r.file.Close(); err != nil {
+			return fmt.Errorf("failed to close log file: %w", err)
+		}
+		r.file = nil
+	}
+
+	ext := filepath.Ext(r.path)
+	base := strings.TrimSuffix(r.path, ext)
+	backup := fmt.Sprintf("%s.%s%s", base, time.Now().Format("20060102_150405.000000"), ext)
+	if err := os.Rename(r.path, backup); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	r.pruneBackups(base, ext)
+	return r.open()
+}
+
+// pruneBackups elimina los backups más antiguos por encima de MaxBackups.
+func (r *RotatingFile) pruneBackups(base, ext string) {
+	if r.opts.MaxBackups <= 0 {
+		return
+	}
+
+	matches, err := filepath.Glob(base + ".*" + ext)
+	if err != nil {
+		return
+	}
+
+	backups := matches[:0]
+	for _, m := range matches {
+		if m != r.path {
+			backups = append(backups, m)
+		}
+	}
+	if len(backups) <= r.opts.MaxBackups {
+		return
+	}
+
+	// El timestamp en el nombre ordena cronológicamente
+	sort.Strings(backups)
+	for _, old := range backups[:len(backups)-r.opts.MaxBackups] {
+		_ = os.Remove(old)
+	}
+}
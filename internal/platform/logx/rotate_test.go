@@ -0,0 +1,84 @@
+// internal/platform/logx/rotate_test.go
+package logx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFile_RotatesBySize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aethonx.log")
+
+	rf, err := NewRotatingFile(path, RotateOptions{MaxSizeBytes: 32})
+	if err != nil {
+		t.Fatalf("NewRotatingFile() failed: %v", err)
+	}
+	defer rf.Close()
+
+	line := []byte(strings.Repeat("x", 20) + "\n")
+	for i := 0; i < 3; i++ {
+		if _, err := rf.Write(line); err != nil {
+			t.Fatalf("Write() failed: %v", err)
+		}
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "aethonx.*.log"))
+	if len(matches) != 2 {
+		t.Errorf("expected 2 rotated files, got %d", len(matches))
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("current log file missing: %v", err)
+	}
+	if info.Size() != int64(len(line)) {
+		t.Errorf("current file size = %d, want %d", info.Size(), len(line))
+	}
+}
+
+func TestRotatingFile_RotatesByAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aethonx.log")
+
+	rf, err := NewRotatingFile(path, RotateOptions{MaxAge: time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewRotatingFile() failed: %v", err)
+	}
+	defer rf.Close()
+
+	rf.Write([]byte("first\n"))
+	time.Sleep(5 * time.Millisecond)
+	rf.Write([]byte("second\n"))
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "aethonx.*.log"))
+	if len(matches) != 1 {
+		t.Errorf("expected 1 rotated file, got %d", len(matches))
+	}
+}
+
+func TestRotatingFile_PrunesBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "aethonx.log")
+
+	rf, err := NewRotatingFile(path, RotateOptions{MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("NewRotatingFile() failed: %v", err)
+	}
+	defer rf.Close()
+
+	for i := 0; i < 5; i++ {
+		rf.Write([]byte("line\n"))
+		if err := rf.Rotate(); err != nil {
+			t.Fatalf("Rotate() failed: %v", err)
+		}
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "aethonx.*.log"))
+	if len(matches) != 2 {
+		t.Errorf("expected 2 backups after pruning, got %d", len(matches))
+	}
+}
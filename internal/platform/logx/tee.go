@@ -0,0 +1,61 @@
+// internal/platform/logx/tee.go
+package logx
+
+// teeLogger reenvía cada evento a varios loggers, cada uno con su propio nivel.
+// Permite, por ejemplo, consola silenciosa (UI pretty) + fichero en debug.
+type teeLogger struct {
+	loggers []Logger
+}
+
+// Tee combina varios loggers en uno. Los nil se ignoran.
+func Tee(loggers ...Logger) Logger {
+	out := make([]Logger, 0, len(loggers))
+	for _, l := range loggers {
+		if l != nil {
+			out = append(out, l)
+		}
+	}
+	if len(out) == 1 {
+		return out[0]
+	}
+	return &teeLogger{loggers: out}
+}
+
+func (t *teeLogger) Debug(msg string, kv ...any) {
+	for _, l := range t.loggers {
+		l.Debug(msg, kv...)
+	}
+}
+
+func (t *teeLogger) Info(msg string, kv ...any) {
+	for _, l := range t.loggers {
+		l.Info(msg, kv...)
+	}
+}
+
+func (t *teeLogger) Warn(msg string, kv ...any) {
+	for _, l := range t.loggers {
+		l.Warn(msg, kv...)
+	}
+}
+
+func (t *teeLogger) Err(err error, kv ...any) {
+	for _, l := range t.loggers {
+		l.Err(err, kv...)
+	}
+}
+
+func (t *teeLogger) With(kv ...any) Logger {
+	out := make([]Logger, len(t.loggers))
+	for i, l := range t.loggers {
+		out[i] = l.With(kv...)
+	}
+	return &teeLogger{loggers: out}
+}
+
+// SetLevel aplica el nivel a todos los loggers combinados.
+func (t *teeLogger) SetLevel(lvl Level) {
+	for _, l := range t.loggers {
+		l.SetLevel(lvl)
+	}
+}
@@ -0,0 +1,232 @@
+package oob
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Lengths of the interactsh IDs: a callback ID is the session correlation
+// ID followed by a per-callback nonce.
+const (
+	correlationIDLength = 20
+	nonceLength         = 13
+	rsaKeyBits          = 2048
+)
+
+// idAlphabet are the characters of correlation IDs and nonces (DNS labels).
+const idAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// session is a registration with an interactsh server. The server encrypts
+// the interactions with an AES key sent wrapped with the session RSA key.
+type session struct {
+	server        string
+	domain        string // Callback domain (the server host)
+	correlationID string
+	secret        string
+	key           *rsa.PrivateKey
+}
+
+// register opens a session: it generates the RSA key and IDs and sends the
+// public key to the server.
+func register(ctx context.Context, client *http.Client, cfg Config) (*session, error) {
+	u, err := url.Parse(cfg.Server)
+	if err != nil || u.Hostname() == "" {
+		return nil, fmt.Errorf("oob: invalid server %q", cfg.Server)
+	}
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, fmt.Errorf("oob: generate key: %w", err)
+	}
+	public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("oob: encode key: %w", err)
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: public})
+
+	s := &session{
+		server:        cfg.Server,
+		domain:        strings.ToLower(u.Hostname()),
+		correlationID: randomID(correlationIDLength),
+		secret:        randomSecret(),
+		key:           key,
+	}
+	body := map[string]string{
+		"public-key":     base64.StdEncoding.EncodeToString(publicPEM),
+		"secret-key":     s.secret,
+		"correlation-id": s.correlationID,
+	}
+	if err := s.post(ctx, client, cfg, "/register", body); err != nil {
+		return nil, fmt.Errorf("oob: register with %s: %w", cfg.Server, err)
+	}
+	return s, nil
+}
+
+// newID returns a new callback ID of the session.
+func (s *session) newID() string {
+	return s.correlationID + randomID(nonceLength)
+}
+
+// pollResponse is the body of GET /poll. Data holds the encrypted
+// interactions; Extra and TLDData plaintext ones.
+type pollResponse struct {
+	Data    []string `json:"data"`
+	Extra   []string `json:"extra"`
+	TLDData []string `json:"tld_data"`
+	AESKey  string   `json:"aes_key"`
+}
+
+// poll fetches and decrypts the interactions received since the last poll.
+// Undecodable entries are skipped.
+func (s *session) poll(ctx context.Context, client *http.Client, cfg Config) ([]Interaction, error) {
+	query := url.Values{"id": {s.correlationID}, "secret": {s.secret}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.server+"/poll?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("oob: %w", err)
+	}
+	var resp pollResponse
+	if err := do(client, cfg, req, &resp); err != nil {
+		return nil, fmt.Errorf("oob: poll %s: %w", s.server, err)
+	}
+
+	var plain [][]byte
+	if len(resp.Data) > 0 {
+		aesKey, err := s.unwrapKey(resp.AESKey)
+		if err != nil {
+			return nil, fmt.Errorf("oob: poll %s: %w", s.server, err)
+		}
+		for _, data := range resp.Data {
+			if decrypted, err := decrypt(aesKey, data); err == nil {
+				plain = append(plain, decrypted)
+			}
+		}
+	}
+	for _, data := range append(resp.Extra, resp.TLDData...) {
+		plain = append(plain, []byte(data))
+	}
+
+	interactions := make([]Interaction, 0, len(plain))
+	for _, data := range plain {
+		var in Interaction
+		if err := json.Unmarshal(data, &in); err == nil {
+			interactions = append(interactions, in)
+		}
+	}
+	return interactions, nil
+}
+
+// deregister closes the session on the server.
+func (s *session) deregister(ctx context.Context, client *http.Client, cfg Config) error {
+	body := map[string]string{"correlation-id": s.correlationID, "secret-key": s.secret}
+	if err := s.post(ctx, client, cfg, "/deregister", body); err != nil {
+		return fmt.Errorf("deregister from %s: %w", s.server, err)
+	}
+	return nil
+}
+
+// unwrapKey decrypts the AES key of a poll with the session RSA key.
+func (s *session) unwrapKey(wrapped string) ([]byte, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("invalid aes_key: %w", err)
+	}
+	key, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, s.key, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt aes_key: %w", err)
+	}
+	return key, nil
+}
+
+// decrypt decodes an AES-CFB interaction: the IV followed by the ciphertext.
+func decrypt(key []byte, data string) ([]byte, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aes.BlockSize {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	iv, ciphertext := ciphertext[:aes.BlockSize], ciphertext[aes.BlockSize:]
+	plain := make([]byte, len(ciphertext))
+	cipher.NewCFBDecrypter(block, iv).XORKeyStream(plain, ciphertext)
+	return plain, nil
+}
+
+func (s *session) post(ctx context.Context, client *http.Client, cfg Config, path string, body map[string]string) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.server+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return do(client, cfg, req, nil)
+}
+
+// do sends req with the server token and decodes a JSON answer into out
+// (nil = discard it).
+func do(client *http.Client, cfg Config, req *http.Request, out interface{}) error {
+	if cfg.Token != "" {
+		req.Header.Set("Authorization", cfg.Token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// randomID returns n random characters of idAlphabet.
+func randomID(n int) string {
+	max := big.NewInt(int64(len(idAlphabet)))
+	id := make([]byte, n)
+	for i := range id {
+		v, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			panic(fmt.Sprintf("oob: crypto/rand failed: %v", err))
+		}
+		id[i] = idAlphabet[v.Int64()]
+	}
+	return string(id)
+}
+
+// randomSecret returns a random UUIDv4, the format of interactsh secrets.
+func randomSecret() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("oob: crypto/rand failed: %v", err))
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,226 @@
+// Package oob is the out-of-band callback infrastructure of active checks
+// (subdomain takeover validation, SSRF canaries in nuclei templates). A
+// Client opens one session with an interactsh server on first use, hands out
+// a unique callback host per check and polls the server for the DNS, HTTP
+// and SMTP interactions those hosts receive.
+//
+// Every callback is bound to the artifact it was planted for (Origin), so
+// each interaction is correlated back to that artifact. Like
+// browser.Shared(), the client is process-wide.
+package oob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of Config.
+const (
+	DefaultWait    = 5 * time.Second
+	DefaultTimeout = 15 * time.Second
+)
+
+// ErrDisabled is returned when no interactsh server is configured.
+var ErrDisabled = errors.New("out-of-band callbacks disabled (set --oob-server)")
+
+// Config configures a Client. Zero values take the defaults.
+type Config struct {
+	Server  string        // interactsh server, e.g. https://oast.fun ("" = disabled)
+	Token   string        // Authorization token of a self-hosted server
+	Wait    time.Duration // Grace period for late callbacks before the last poll
+	Timeout time.Duration // Max time per request to the server
+}
+
+func (c Config) withDefaults() Config {
+	c.Server = strings.TrimRight(strings.TrimSpace(c.Server), "/")
+	if c.Server != "" && !strings.Contains(c.Server, "://") {
+		c.Server = "https://" + c.Server
+	}
+	if c.Wait <= 0 {
+		c.Wait = DefaultWait
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultTimeout
+	}
+	return c
+}
+
+// Origin is what a callback was planted for.
+type Origin struct {
+	ArtifactKey string // Key of the artifact under test (domain.Artifact.Key)
+	Source      string // Source that planted the callback
+	Check       string // Check that planted it, e.g. "takeover", "ssrf"
+}
+
+// Callback is a unique out-of-band endpoint. Any DNS lookup of Host or
+// request to URL is reported by Poll with the callback's Origin.
+type Callback struct {
+	ID     string // Unique ID the server reports back
+	Host   string // <id>.<server domain>, for DNS canaries
+	URL    string // http://<host>, for HTTP canaries
+	Origin Origin
+}
+
+// Interaction is a DNS, HTTP or SMTP request received by a callback.
+type Interaction struct {
+	Protocol      string    `json:"protocol"`
+	UniqueID      string    `json:"unique-id"`
+	FullID        string    `json:"full-id"`
+	QType         string    `json:"q-type,omitempty"`
+	RawRequest    string    `json:"raw-request,omitempty"`
+	SMTPFrom      string    `json:"smtp-from,omitempty"`
+	RemoteAddress string    `json:"remote-address"`
+	Timestamp     time.Time `json:"timestamp"`
+
+	// Origin of the callback that received it (set by Poll)
+	Origin Origin `json:"-"`
+}
+
+// Client hands out callbacks and correlates their interactions.
+type Client struct {
+	mu           sync.Mutex
+	cfg          Config
+	http         *http.Client
+	session      *session          // nil until the first callback
+	origins      map[string]Origin // Callback ID -> origin
+	interactions []Interaction
+}
+
+// NewClient creates a client; the session opens on the first URL.
+func NewClient(cfg Config) *Client {
+	c := &Client{}
+	c.Configure(cfg)
+	return c
+}
+
+var shared = NewClient(Config{})
+
+// Shared returns the process-wide client.
+func Shared() *Client {
+	return shared
+}
+
+// Configure replaces the configuration and forgets the current session, its
+// callbacks and interactions. Call it before the first URL.
+func (c *Client) Configure(cfg Config) {
+	cfg = cfg.withDefaults()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cfg = cfg
+	c.http = &http.Client{Timeout: cfg.Timeout}
+	c.session = nil
+	c.origins = make(map[string]Origin)
+	c.interactions = nil
+}
+
+// Config returns the current configuration.
+func (c *Client) Config() Config {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cfg
+}
+
+// Enabled reports whether an interactsh server is configured.
+func (c *Client) Enabled() bool {
+	return c.Config().Server != ""
+}
+
+// URL returns a new callback bound to origin, registering the session with
+// the server on first use.
+func (c *Client) URL(ctx context.Context, origin Origin) (Callback, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cfg.Server == "" {
+		return Callback{}, ErrDisabled
+	}
+	if c.session == nil {
+		s, err := register(ctx, c.http, c.cfg)
+		if err != nil {
+			return Callback{}, err
+		}
+		c.session = s
+	}
+
+	id := c.session.newID()
+	host := id + "." + c.session.domain
+	c.origins[id] = origin
+	return Callback{ID: id, Host: host, URL: "http://" + host, Origin: origin}, nil
+}
+
+// Poll fetches the interactions received since the last poll and returns
+// those of known callbacks, with their Origin. Without callbacks it does
+// nothing.
+func (c *Client) Poll(ctx context.Context) ([]Interaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.session == nil {
+		return nil, nil
+	}
+
+	received, err := c.session.poll(ctx, c.http, c.cfg)
+	if err != nil {
+		return nil, err
+	}
+	var correlated []Interaction
+	for _, in := range received {
+		origin, ok := c.correlateLocked(in)
+		if !ok {
+			continue
+		}
+		in.Origin = origin
+		correlated = append(correlated, in)
+	}
+	c.interactions = append(c.interactions, correlated...)
+	return correlated, nil
+}
+
+// correlateLocked finds the callback an interaction belongs to: by its
+// unique ID or, for requests to a subdomain of the callback host, by the
+// full ID.
+func (c *Client) correlateLocked(in Interaction) (Origin, bool) {
+	if origin, ok := c.origins[strings.ToLower(in.UniqueID)]; ok {
+		return origin, true
+	}
+	for _, label := range strings.Split(strings.ToLower(in.FullID), ".") {
+		if origin, ok := c.origins[label]; ok {
+			return origin, true
+		}
+	}
+	return Origin{}, false
+}
+
+// Interactions returns every correlated interaction polled so far.
+func (c *Client) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// Callbacks reports how many callbacks were handed out.
+func (c *Client) Callbacks() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.origins)
+}
+
+// Close deregisters the session from the server. Interactions already
+// polled are kept; a later URL opens a new session.
+func (c *Client) Close(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.session == nil {
+		return nil
+	}
+	s := c.session
+	c.session = nil
+	if err := s.deregister(ctx, c.http, c.cfg); err != nil {
+		return fmt.Errorf("oob: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package oob
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeInteractsh implements the interactsh register/poll/deregister API and
+// encrypts the queued interactions with the registered public key.
+type fakeInteractsh struct {
+	mu           sync.Mutex
+	t            *testing.T
+	token        string
+	public       *rsa.PublicKey
+	secret       string
+	correlation  string
+	pending      []Interaction
+	extra        []string
+	deregistered bool
+}
+
+func (f *fakeInteractsh) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.Header.Get("Authorization") != f.token {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/register":
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		publicPEM, _ := base64.StdEncoding.DecodeString(body["public-key"])
+		block, _ := pem.Decode(publicPEM)
+		if block == nil {
+			http.Error(w, "bad key", http.StatusBadRequest)
+			return
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.public = key.(*rsa.PublicKey)
+		f.secret, f.correlation = body["secret-key"], body["correlation-id"]
+		_, _ = w.Write([]byte(`{"message":"registration successful"}`))
+	case "/poll":
+		if r.URL.Query().Get("id") != f.correlation || r.URL.Query().Get("secret") != f.secret {
+			http.Error(w, "invalid session", http.StatusBadRequest)
+			return
+		}
+		aesKey := make([]byte, 32)
+		_, _ = rand.Read(aesKey)
+		wrapped, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, f.public, aesKey, nil)
+		if err != nil {
+			f.t.Fatal(err)
+		}
+		resp := pollResponse{AESKey: base64.StdEncoding.EncodeToString(wrapped), Extra: f.extra}
+		for _, in := range f.pending {
+			plain, _ := json.Marshal(in)
+			resp.Data = append(resp.Data, encrypt(f.t, aesKey, plain))
+		}
+		f.pending, f.extra = nil, nil
+		_ = json.NewEncoder(w).Encode(resp)
+	case "/deregister":
+		f.deregistered = true
+		_, _ = w.Write([]byte(`{"message":"deregistration successful"}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeInteractsh) receive(in ...Interaction) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pending = append(f.pending, in...)
+}
+
+func encrypt(t *testing.T, key, plain []byte) string {
+	t.Helper()
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make([]byte, aes.BlockSize+len(plain))
+	iv := out[:aes.BlockSize]
+	_, _ = rand.Read(iv)
+	cipher.NewCFBEncrypter(block, iv).XORKeyStream(out[aes.BlockSize:], plain)
+	return base64.StdEncoding.EncodeToString(out)
+}
+
+func TestClient_CorrelatesInteractions(t *testing.T) {
+	fake := &fakeInteractsh{t: t, token: "s3cret"}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	client := NewClient(Config{Server: srv.URL, Token: "s3cret"})
+	ctx := context.Background()
+
+	takeover := Origin{ArtifactKey: "subdomain:old.example.com", Source: "takeover", Check: "takeover"}
+	cb1, err := client.URL(ctx, takeover)
+	if err != nil {
+		t.Fatalf("URL() failed: %v", err)
+	}
+	cb2, err := client.URL(ctx, Origin{ArtifactKey: "url:https://app.example.com/fetch", Source: "nuclei", Check: "ssrf"})
+	if err != nil {
+		t.Fatalf("URL() failed: %v", err)
+	}
+
+	if cb1.ID == cb2.ID || len(cb1.ID) != correlationIDLength+nonceLength {
+		t.Fatalf("expected distinct %d-char IDs, got %q and %q", correlationIDLength+nonceLength, cb1.ID, cb2.ID)
+	}
+	if !strings.HasPrefix(cb1.ID, fake.correlation) || cb1.Host != cb1.ID+".127.0.0.1" || cb1.URL != "http://"+cb1.Host {
+		t.Errorf("unexpected callback %+v", cb1)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	fake.receive(
+		Interaction{Protocol: "dns", UniqueID: cb1.ID, FullID: cb1.ID, QType: "A", RemoteAddress: "198.51.100.7", Timestamp: now},
+		Interaction{Protocol: "http", UniqueID: "unknownunknownunknownunknown12345", FullID: "unknown", RemoteAddress: "203.0.113.1", Timestamp: now},
+	)
+	// A plaintext interaction (extra) to a subdomain of the second callback
+	extra, _ := json.Marshal(Interaction{Protocol: "http", FullID: "internal." + strings.ToUpper(cb2.ID), RemoteAddress: "192.0.2.44", Timestamp: now})
+	fake.extra = []string{string(extra)}
+
+	got, err := client.Poll(ctx)
+	if err != nil {
+		t.Fatalf("Poll() failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 correlated interactions, got %d: %+v", len(got), got)
+	}
+	if got[0].Origin != takeover || got[0].QType != "A" || !got[0].Timestamp.Equal(now) {
+		t.Errorf("unexpected DNS interaction %+v", got[0])
+	}
+	if got[1].Origin.Check != "ssrf" || got[1].RemoteAddress != "192.0.2.44" {
+		t.Errorf("unexpected HTTP interaction %+v", got[1])
+	}
+
+	// Nothing new on the next poll; everything is kept
+	if again, err := client.Poll(ctx); err != nil || len(again) != 0 {
+		t.Errorf("expected no new interactions, got %d (%v)", len(again), err)
+	}
+	if len(client.Interactions()) != 2 || client.Callbacks() != 2 {
+		t.Errorf("expected 2 interactions of 2 callbacks, got %d of %d", len(client.Interactions()), client.Callbacks())
+	}
+
+	if err := client.Close(ctx); err != nil || !fake.deregistered {
+		t.Errorf("expected the session to be deregistered (%v)", err)
+	}
+}
+
+func TestClient_Disabled(t *testing.T) {
+	client := NewClient(Config{})
+	if client.Enabled() {
+		t.Error("expected a client without server to be disabled")
+	}
+	if _, err := client.URL(context.Background(), Origin{}); err != ErrDisabled {
+		t.Errorf("expected ErrDisabled, got %v", err)
+	}
+	if got, err := client.Poll(context.Background()); err != nil || got != nil {
+		t.Errorf("Poll() without session = %v, %v", got, err)
+	}
+	if err := client.Close(context.Background()); err != nil {
+		t.Errorf("Close() without session = %v", err)
+	}
+}
+
+func TestClient_RegisterRejected(t *testing.T) {
+	srv := httptest.NewServer(&fakeInteractsh{t: t, token: "expected"})
+	defer srv.Close()
+
+	client := NewClient(Config{Server: srv.URL, Token: "wrong"})
+	if _, err := client.URL(context.Background(), Origin{}); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected a registration error, got %v", err)
+	}
+}
+
+func TestConfig_Defaults(t *testing.T) {
+	cfg := Config{Server: " oast.fun/ "}.withDefaults()
+	if cfg.Server != "https://oast.fun" || cfg.Wait != DefaultWait || cfg.Timeout != DefaultTimeout {
+		t.Errorf("unexpected defaults %+v", cfg)
+	}
+}
@@ -0,0 +1,249 @@
+// Package politeness limits how hard active probing hits each destination
+// host: at most N requests in flight and a minimum delay between requests
+// (--host-concurrency, --host-delay).
+//
+// Limits are set for every host and overridden per scope entry: an entry for
+// example.com applies to example.com and all its subdomains, and the most
+// specific entry wins. Internal HTTP clients wait on Acquire; CLI tools get
+// the strictest limits of the hosts they will probe as flags (see Strictest).
+// Like rate.Shared(), the manager is process-wide.
+package politeness
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limits are the politeness limits of one host (0 = no limit).
+type Limits struct {
+	Concurrency int           // Max requests in flight to the host
+	Delay       time.Duration // Min delay between two requests to the host
+}
+
+// IsZero reports whether no limit applies.
+func (l Limits) IsZero() bool {
+	return l.Concurrency <= 0 && l.Delay <= 0
+}
+
+// Policy maps scope entries to limits. The "" key applies to every host.
+type Policy struct {
+	Concurrency map[string]int
+	Delay       map[string]time.Duration
+}
+
+// ParsePolicy parses --host-concurrency and --host-delay entries: a bare
+// value for every host or "<domain>=<value>" for a scope entry, e.g. "4",
+// "legacy.example.com=1", "500ms", "api.example.com=2s".
+func ParsePolicy(concurrency, delay []string) (Policy, error) {
+	policy := Policy{
+		Concurrency: make(map[string]int),
+		Delay:       make(map[string]time.Duration),
+	}
+
+	for _, entry := range concurrency {
+		scope, value := cutEntry(entry)
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return policy, fmt.Errorf("invalid --host-concurrency %q: want a positive integer", entry)
+		}
+		policy.Concurrency[scope] = n
+	}
+
+	for _, entry := range delay {
+		scope, value := cutEntry(entry)
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return policy, fmt.Errorf("invalid --host-delay %q: want a positive duration (e.g. 250ms, 1s)", entry)
+		}
+		policy.Delay[scope] = d
+	}
+
+	return policy, nil
+}
+
+// cutEntry splits "<domain>=<value>"; scope is "" for a bare value.
+func cutEntry(entry string) (scope, value string) {
+	scope, value, ok := strings.Cut(entry, "=")
+	if !ok {
+		return "", strings.TrimSpace(entry)
+	}
+	return normalizeHost(scope), strings.TrimSpace(value)
+}
+
+// For returns the limits of host.
+func (p Policy) For(host string) Limits {
+	host = normalizeHost(host)
+	return Limits{
+		Concurrency: lookup(p.Concurrency, host),
+		Delay:       lookup(p.Delay, host),
+	}
+}
+
+// lookup returns the value of the most specific scope entry covering host:
+// host itself, then each parent domain, then the "" default.
+func lookup[V any](entries map[string]V, host string) V {
+	for name := host; name != ""; {
+		if v, ok := entries[name]; ok {
+			return v
+		}
+		_, parent, found := strings.Cut(name, ".")
+		if !found {
+			break
+		}
+		name = parent
+	}
+	return entries[""]
+}
+
+// hostState tracks the requests in flight and the next allowed start of one host.
+type hostState struct {
+	slots chan struct{} // nil = no concurrency limit
+	mu    sync.Mutex
+	next  time.Time
+}
+
+// Manager enforces a Policy across every client of the process.
+type Manager struct {
+	mu     sync.Mutex
+	policy Policy
+	hosts  map[string]*hostState
+}
+
+// NewManager creates a manager without limits.
+func NewManager() *Manager {
+	return &Manager{hosts: make(map[string]*hostState)}
+}
+
+var shared = NewManager()
+
+// Shared returns the process-wide manager.
+func Shared() *Manager {
+	return shared
+}
+
+// Configure replaces the policy. Requests in flight keep their slots.
+func (m *Manager) Configure(policy Policy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.policy = policy
+	m.hosts = make(map[string]*hostState)
+}
+
+// For returns the limits of host.
+func (m *Manager) For(host string) Limits {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.policy.For(host)
+}
+
+// Strictest returns the tightest limits among hosts (lowest concurrency,
+// longest delay). A CLI tool probes all of them with one set of flags, so
+// this is what keeps every host within its policy.
+func (m *Manager) Strictest(hosts []string) Limits {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var strictest Limits
+	for _, host := range hosts {
+		l := m.policy.For(host)
+		if l.Concurrency > 0 && (strictest.Concurrency == 0 || l.Concurrency < strictest.Concurrency) {
+			strictest.Concurrency = l.Concurrency
+		}
+		if l.Delay > strictest.Delay {
+			strictest.Delay = l.Delay
+		}
+	}
+	return strictest
+}
+
+// Acquire waits until a request to host is allowed and returns the function
+// that releases its slot once the response is done. Returns ctx's error if
+// it is cancelled while waiting.
+func (m *Manager) Acquire(ctx context.Context, host string) (func(), error) {
+	host = normalizeHost(host)
+
+	m.mu.Lock()
+	limits := m.policy.For(host)
+	if limits.IsZero() {
+		m.mu.Unlock()
+		return func() {}, nil
+	}
+	state, ok := m.hosts[host]
+	if !ok {
+		state = &hostState{}
+		if limits.Concurrency > 0 {
+			state.slots = make(chan struct{}, limits.Concurrency)
+		}
+		m.hosts[host] = state
+	}
+	m.mu.Unlock()
+
+	release := func() {}
+	if state.slots != nil {
+		select {
+		case state.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		var once sync.Once
+		release = func() { once.Do(func() { <-state.slots }) }
+	}
+
+	if limits.Delay > 0 {
+		// Reserve the next start so concurrent waiters queue up behind it
+		state.mu.Lock()
+		now := time.Now()
+		start := state.next
+		if start.Before(now) {
+			start = now
+		}
+		state.next = start.Add(limits.Delay)
+		state.mu.Unlock()
+
+		if wait := time.Until(start); wait > 0 {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				release()
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	return release, nil
+}
+
+// Host extracts the destination host of a probe target: a URL
+// ("https://a.example.com:8443/x"), host:port or a bare host.
+func Host(target string) string {
+	if _, rest, ok := strings.Cut(target, "://"); ok {
+		target = rest
+	}
+	if i := strings.IndexAny(target, "/?#"); i >= 0 {
+		target = target[:i]
+	}
+	if h, _, err := net.SplitHostPort(target); err == nil {
+		target = h
+	}
+	return normalizeHost(strings.Trim(target, "[]"))
+}
+
+// Hosts applies Host to every target.
+func Hosts(targets []string) []string {
+	hosts := make([]string, 0, len(targets))
+	for _, target := range targets {
+		hosts = append(hosts, Host(target))
+	}
+	return hosts
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
@@ -0,0 +1,102 @@
+package politeness
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aethonx/internal/testutil"
+)
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy([]string{"4", "Legacy.Example.com=1"}, []string{"100ms", "api.example.com=2s"})
+	testutil.AssertNoError(t, err, "valid policy")
+
+	testutil.AssertEqual(t, policy.For("www.example.com"), Limits{Concurrency: 4, Delay: 100 * time.Millisecond}, "defaults")
+	testutil.AssertEqual(t, policy.For("legacy.example.com").Concurrency, 1, "scope entry itself")
+	testutil.AssertEqual(t, policy.For("old.legacy.example.com").Concurrency, 1, "subdomain of the scope entry")
+	testutil.AssertEqual(t, policy.For("v2.api.example.com").Delay, 2*time.Second, "delay override")
+	testutil.AssertEqual(t, policy.For("v2.api.example.com").Concurrency, 4, "fields resolve independently")
+
+	for _, bad := range [][2][]string{{{"0"}, nil}, {{"x.com=many"}, nil}, {nil, {"soon"}}, {nil, {"x.com=-1s"}}} {
+		_, err := ParsePolicy(bad[0], bad[1])
+		testutil.AssertError(t, err, "invalid entry")
+	}
+}
+
+func TestManager_Strictest(t *testing.T) {
+	m := NewManager()
+	policy, _ := ParsePolicy([]string{"8", "slow.example.com=2"}, []string{"legacy.example.com=1s"})
+	m.Configure(policy)
+
+	got := m.Strictest([]string{"www.example.com", "slow.example.com", "legacy.example.com"})
+	testutil.AssertEqual(t, got, Limits{Concurrency: 2, Delay: time.Second}, "tightest of each field")
+	testutil.AssertTrue(t, NewManager().Strictest([]string{"a.com"}).IsZero(), "no policy, no limits")
+}
+
+func TestManager_AcquireConcurrency(t *testing.T) {
+	m := NewManager()
+	m.Configure(Policy{Concurrency: map[string]int{"": 2}})
+
+	var inFlight, peak int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := m.Acquire(context.Background(), "example.com")
+			testutil.AssertNoError(t, err, "acquire")
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			release()
+		}()
+	}
+	wg.Wait()
+
+	testutil.AssertTrue(t, peak <= 2, "never more than 2 requests in flight")
+}
+
+func TestManager_AcquireDelay(t *testing.T) {
+	m := NewManager()
+	m.Configure(Policy{Delay: map[string]time.Duration{"example.com": 20 * time.Millisecond}})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		release, err := m.Acquire(context.Background(), "www.example.com")
+		testutil.AssertNoError(t, err, "acquire")
+		release()
+	}
+	testutil.AssertTrue(t, time.Since(start) >= 40*time.Millisecond, "requests spaced by the delay")
+
+	// Otro host no hereda la espera
+	other := time.Now()
+	release, _ := m.Acquire(context.Background(), "other.net")
+	release()
+	testutil.AssertTrue(t, time.Since(other) < 10*time.Millisecond, "unrelated host not delayed")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := m.Acquire(ctx, "www.example.com")
+	testutil.AssertError(t, err, "cancelled while waiting")
+}
+
+func TestHost(t *testing.T) {
+	for target, want := range map[string]string{
+		"https://API.example.com:8443/v1?x=1": "api.example.com",
+		"example.com:80":                      "example.com",
+		"www.example.com/login":               "www.example.com",
+		"example.com.":                        "example.com",
+		"http://[2001:db8::1]:8080/":          "2001:db8::1",
+	} {
+		testutil.AssertEqual(t, Host(target), want, target)
+	}
+}
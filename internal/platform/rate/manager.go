@@ -0,0 +1,141 @@
+package rate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Manager hands out one token bucket per upstream (e.g., "crt.sh", "rdap.org")
+// so every client of that upstream draws from the same budget: all sources and
+// all targets scanned concurrently by the process (dashboard scans, agent runs).
+//
+// The first client of an upstream creates its bucket; later clients with a
+// stricter rate lower it, so the most conservative source wins. Limits set with
+// Configure (user overrides) are fixed and ignore client defaults.
+type Manager struct {
+	mu         sync.Mutex
+	limiters   map[string]*Limiter
+	configured map[string]bool
+}
+
+// NewManager creates an empty manager.
+func NewManager() *Manager {
+	return &Manager{
+		limiters:   make(map[string]*Limiter),
+		configured: make(map[string]bool),
+	}
+}
+
+var shared = NewManager()
+
+// Shared returns the process-wide manager.
+func Shared() *Manager {
+	return shared
+}
+
+// Limiter returns the shared bucket of upstream, creating it with rps and
+// burst. An existing bucket is lowered to rps/burst if they are stricter,
+// unless the upstream was configured explicitly.
+func (m *Manager) Limiter(upstream string, rps float64, burst int) *Limiter {
+	upstream = normalizeUpstream(upstream)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l, ok := m.limiters[upstream]
+	if !ok {
+		l = New(rps, burst)
+		m.limiters[upstream] = l
+		return l
+	}
+
+	if !m.configured[upstream] {
+		if rps > 0 && rps < l.Rate() {
+			l.SetRate(rps)
+		}
+		if burst > 0 && burst < l.Burst() {
+			l.SetBurst(burst)
+		}
+	}
+	return l
+}
+
+// Configure fixes the budget of upstream (e.g., from --upstream-rate),
+// overriding the defaults of the clients that use it.
+func (m *Manager) Configure(upstream string, rps float64, burst int) {
+	upstream = normalizeUpstream(upstream)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if l, ok := m.limiters[upstream]; ok {
+		l.SetRate(rps)
+		l.SetBurst(burst)
+	} else {
+		m.limiters[upstream] = New(rps, burst)
+	}
+	m.configured[upstream] = true
+}
+
+// Upstreams returns the known upstreams and their rates (for diagnostics).
+func (m *Manager) Upstreams() map[string]float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rates := make(map[string]float64, len(m.limiters))
+	for name, l := range m.limiters {
+		rates[name] = l.Rate()
+	}
+	return rates
+}
+
+// ParseUpstreamRates parses "<upstream>=<rps>[/<burst>]" entries, e.g.
+// "crt.sh=1" or "rdap.org=5/2". Burst defaults to 1.
+func ParseUpstreamRates(entries []string) (map[string]UpstreamRate, error) {
+	rates := make(map[string]UpstreamRate, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid upstream rate %q (use <upstream>=<rps>[/<burst>])", entry)
+		}
+
+		r := UpstreamRate{Burst: 1}
+		rps, burst, hasBurst := strings.Cut(value, "/")
+		var err error
+		if r.RPS, err = strconv.ParseFloat(strings.TrimSpace(rps), 64); err != nil || r.RPS <= 0 {
+			return nil, fmt.Errorf("invalid upstream rate %q: rps must be a positive number", entry)
+		}
+		if hasBurst {
+			if r.Burst, err = strconv.Atoi(strings.TrimSpace(burst)); err != nil || r.Burst <= 0 {
+				return nil, fmt.Errorf("invalid upstream rate %q: burst must be a positive integer", entry)
+			}
+		}
+		rates[normalizeUpstream(name)] = r
+	}
+	return rates, nil
+}
+
+// UpstreamRate is a configured budget for an upstream.
+type UpstreamRate struct {
+	RPS   float64
+	Burst int
+}
+
+// ConfigureAll applies ParseUpstreamRates results in a stable order.
+func (m *Manager) ConfigureAll(rates map[string]UpstreamRate) {
+	names := make([]string, 0, len(rates))
+	for name := range rates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		m.Configure(name, rates[name].RPS, rates[name].Burst)
+	}
+}
+
+func normalizeUpstream(upstream string) string {
+	return strings.ToLower(strings.TrimSpace(upstream))
+}
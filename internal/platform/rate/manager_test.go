@@ -0,0 +1,55 @@
+package rate
+
+import (
+	"testing"
+
+	"aethonx/internal/testutil"
+)
+
+func TestManager_SharesLimiterPerUpstream(t *testing.T) {
+	m := NewManager()
+
+	a := m.Limiter("crt.sh", 2, 1)
+	b := m.Limiter("CRT.SH ", 5, 3)
+	testutil.AssertTrue(t, a == b, "same upstream should share the limiter")
+	testutil.AssertEqual(t, a.Rate(), 2.0, "a laxer client must not raise the rate")
+	testutil.AssertEqual(t, a.Burst(), 1, "a laxer client must not raise the burst")
+
+	m.Limiter("crt.sh", 0.5, 1)
+	testutil.AssertEqual(t, a.Rate(), 0.5, "a stricter client lowers the shared rate")
+
+	other := m.Limiter("rdap.org", 5, 2)
+	testutil.AssertTrue(t, other != a, "different upstreams have their own limiter")
+
+	// Todos los tokens se consumen del mismo bucket
+	testutil.AssertTrue(t, a.Allow(), "first token available")
+	testutil.AssertFalse(t, b.Allow(), "bucket shared: no token left for the second client")
+}
+
+func TestManager_Configure(t *testing.T) {
+	m := NewManager()
+
+	existing := m.Limiter("crt.sh", 2, 1)
+	m.Configure("crt.sh", 10, 4)
+	testutil.AssertEqual(t, existing.Rate(), 10.0, "configure updates an existing limiter")
+
+	m.Limiter("crt.sh", 1, 1)
+	testutil.AssertEqual(t, existing.Rate(), 10.0, "configured upstreams ignore client defaults")
+	testutil.AssertEqual(t, existing.Burst(), 4, "configured burst is kept")
+
+	m.Configure("rdap.org", 3, 1)
+	testutil.AssertEqual(t, m.Limiter("rdap.org", 5, 2).Rate(), 3.0, "configured before any client")
+	testutil.AssertEqual(t, len(m.Upstreams()), 2, "known upstreams")
+}
+
+func TestParseUpstreamRates(t *testing.T) {
+	rates, err := ParseUpstreamRates([]string{"crt.sh=1", " RDAP.org = 5/2 "})
+	testutil.AssertNoError(t, err, "valid entries")
+	testutil.AssertEqual(t, rates["crt.sh"], UpstreamRate{RPS: 1, Burst: 1}, "default burst")
+	testutil.AssertEqual(t, rates["rdap.org"], UpstreamRate{RPS: 5, Burst: 2}, "explicit burst")
+
+	for _, invalid := range []string{"crt.sh", "=1", "crt.sh=0", "crt.sh=fast", "crt.sh=1/0"} {
+		_, err := ParseUpstreamRates([]string{invalid})
+		testutil.AssertError(t, err, "invalid entry "+invalid)
+	}
+}
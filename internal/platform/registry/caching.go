@@ -0,0 +1,308 @@
+// internal/platform/registry/caching.go
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/cache"
+	"aethonx/internal/platform/errors"
+	"aethonx/internal/platform/logx"
+)
+
+// DefaultCacheCapacity es el número de resultados de sources que guarda la
+// caché del registry.
+const DefaultCacheCapacity = 256
+
+// CachingSource decora una source y reutiliza su resultado durante ttl para
+// el mismo target, configuración Custom e input. Se aplica desde Build a las
+// sources con CacheTTL o NegativeCacheTTL > 0, así ninguna source tiene que
+// implementar su propia caché.
+//
+// El resultado se guarda serializado: cada acierto retorna una copia nueva,
+// que el orquestador puede filtrar o vaciar sin afectar a la caché. Solo se
+// guardan ejecuciones sin errores. Los resultados negativos (sin artefactos,
+// o un error "no encontrado" del upstream) van a la caché negativa durante
+// negativeTTL, normalmente más corto.
+type CachingSource struct {
+	source      ports.Source
+	store       cache.Cache
+	ttl         time.Duration
+	negative    *NegativeCache
+	negativeTTL time.Duration
+	custom      map[string]interface{}
+	logger      logx.Logger
+
+	mu      sync.Mutex
+	partial []*domain.Artifact // Artefactos volcados por el partial handler en la ejecución actual
+}
+
+// NewCachingSource envuelve source con la caché store y la caché negativa
+// negative.
+func NewCachingSource(source ports.Source, store cache.Cache, negative *NegativeCache, cfg ports.SourceConfig, logger logx.Logger) *CachingSource {
+	return &CachingSource{
+		source:      source,
+		store:       store,
+		ttl:         cfg.CacheTTL,
+		negative:    negative,
+		negativeTTL: cfg.NegativeCacheTTL,
+		custom:      cfg.Custom,
+		logger:      logger.With("component", "source-cache", "source", source.Name()),
+	}
+}
+
+// Name retorna el nombre del source subyacente.
+func (c *CachingSource) Name() string {
+	return c.source.Name()
+}
+
+// Mode retorna el modo del source subyacente.
+func (c *CachingSource) Mode() domain.SourceMode {
+	return c.source.Mode()
+}
+
+// Type retorna el tipo del source subyacente.
+func (c *CachingSource) Type() domain.SourceType {
+	return c.source.Type()
+}
+
+// Run retorna el resultado cacheado o ejecuta el source.
+func (c *CachingSource) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	return c.cached(ctx, target, nil, func() (*domain.ScanResult, error) {
+		return c.source.Run(ctx, target)
+	})
+}
+
+// RunWithInput retorna el resultado cacheado o ejecuta el source con input.
+// Si el source no consume input se ejecuta Run. Implementa ports.InputConsumer.
+func (c *CachingSource) RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+	consumer, ok := c.source.(ports.InputConsumer)
+	if !ok {
+		return c.Run(ctx, target)
+	}
+	return c.cached(ctx, target, input, func() (*domain.ScanResult, error) {
+		return consumer.RunWithInput(ctx, target, input)
+	})
+}
+
+// cached busca el resultado en la caché y, si no está, lo obtiene con run y
+// lo guarda. Las ejecuciones con muestreo (--sample) no usan la caché, y las
+// cortadas por un tope o una cancelación (ctx terminado) no se guardan: su
+// resultado es parcial y no debe servirse a un escaneo completo.
+func (c *CachingSource) cached(ctx context.Context, target domain.Target, input *domain.ScanResult, run func() (*domain.ScanResult, error)) (*domain.ScanResult, error) {
+	if ports.SampleSize(ctx) > 0 {
+		c.logger.Debug("sampled run bypasses the source cache")
+		return run()
+	}
+
+	key, err := c.key(target, input)
+	if err != nil {
+		c.logger.Debug("result not cacheable", "error", err.Error())
+		return run()
+	}
+
+	if c.ttl > 0 {
+		if value, ok := c.store.Get(key); ok {
+			var result domain.ScanResult
+			if err := json.Unmarshal(value.([]byte), &result); err == nil {
+				c.logger.Debug("source cache hit", "artifacts", len(result.Artifacts))
+				markCache(&result, domain.SourceCacheHit)
+				return &result, nil
+			}
+			c.store.Delete(key)
+		}
+	}
+	if c.negativeTTL > 0 {
+		if entry, ok := c.negative.Get(key); ok {
+			c.logger.Debug("negative cache hit", "reason", entry.Reason, "expires", entry.Expires)
+			result := domain.NewScanResult(target)
+			markCache(result, domain.SourceCacheHit)
+			if entry.Reason != "" {
+				return result, &negativeError{reason: entry.Reason}
+			}
+			return result, nil
+		}
+	}
+
+	c.mu.Lock()
+	c.partial = nil
+	c.mu.Unlock()
+
+	result, err := run()
+	if result != nil {
+		markCache(result, domain.SourceCacheMiss)
+	}
+	if ctx.Err() != nil {
+		c.mu.Lock()
+		c.partial = nil
+		c.mu.Unlock()
+		c.logger.Debug("truncated run not cached", "cause", context.Cause(ctx).Error())
+		return result, err
+	}
+	if err != nil {
+		if errors.IsNotFound(err) {
+			c.storeNegative(key, target, err.Error())
+		}
+		return result, err
+	}
+	if result == nil || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	// Se guarda el resultado completo, incluidos los artefactos ya volcados
+	// a disco como parciales
+	c.mu.Lock()
+	entry := *result
+	entry.Artifacts = append(append([]*domain.Artifact(nil), c.partial...), result.Artifacts...)
+	c.partial = nil
+	c.mu.Unlock()
+
+	if len(entry.Artifacts) == 0 {
+		c.storeNegative(key, target, "")
+		return result, nil
+	}
+	if c.ttl <= 0 {
+		return result, nil
+	}
+
+	data, err := json.Marshal(&entry)
+	if err != nil {
+		c.logger.Debug("result not cacheable", "error", err.Error())
+		return result, nil
+	}
+	c.store.Set(key, data, c.ttl)
+	return result, nil
+}
+
+// storeNegative guarda un resultado negativo, si la source tiene
+// NegativeCacheTTL.
+func (c *CachingSource) storeNegative(key string, target domain.Target, reason string) {
+	if c.negativeTTL <= 0 {
+		return
+	}
+	entry := NegativeEntry{Source: c.source.Name(), Target: target.Root, Reason: reason}
+	if err := c.negative.Set(key, entry, c.negativeTTL); err != nil {
+		c.logger.Warn("failed to save negative cache", "error", err.Error())
+	}
+}
+
+// negativeError es el error "no encontrado" de un upstream, repetido desde la
+// caché negativa.
+type negativeError struct {
+	reason string
+}
+
+func (e *negativeError) Error() string {
+	return e.reason + " (cached)"
+}
+
+func (e *negativeError) Unwrap() error {
+	return errors.ErrNotFound
+}
+
+// key identifica una ejecución: source, target, configuración Custom y
+// claves de los artefactos de input.
+func (c *CachingSource) key(target domain.Target, input *domain.ScanResult) (string, error) {
+	var inputKeys []string
+	if input != nil {
+		inputKeys = make([]string, 0, len(input.Artifacts))
+		for _, a := range input.Artifacts {
+			if a != nil {
+				inputKeys = append(inputKeys, a.Key())
+			}
+		}
+		sort.Strings(inputKeys)
+	}
+
+	data, err := json.Marshal(struct {
+		Source string
+		Target domain.Target
+		Custom map[string]interface{}
+		Input  []string
+	}{c.source.Name(), target, c.custom, inputKeys})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return "source:" + c.source.Name() + ":" + hex.EncodeToString(sum[:]), nil
+}
+
+// markCache anota en el resultado si salió de la caché.
+func markCache(result *domain.ScanResult, status string) {
+	if result.Metadata.Environment == nil {
+		result.Metadata.Environment = make(map[string]string)
+	}
+	result.Metadata.Environment[domain.EnvSourceCache] = status
+}
+
+// BeginScan propaga el ID del escaneo al source subyacente.
+func (c *CachingSource) BeginScan(scanID string) {
+	if scoped, ok := c.source.(ports.ScanScopedSource); ok {
+		scoped.BeginScan(scanID)
+	}
+}
+
+// SetLogger propaga el logger del escaneo al source subyacente.
+func (c *CachingSource) SetLogger(logger logx.Logger) {
+	c.logger = logger.With("component", "source-cache", "source", c.source.Name())
+	if scoped, ok := c.source.(ports.LogScopedSource); ok {
+		scoped.SetLogger(logger)
+	}
+}
+
+// SetStateHandler propaga el handler de cambios de estado al source
+// subyacente.
+func (c *CachingSource) SetStateHandler(fn func(state ports.SourceState, detail string)) {
+	if reporting, ok := c.source.(ports.StateReportingSource); ok {
+		reporting.SetStateHandler(fn)
+	}
+}
+
+// SetPartialHandler propaga el handler de resultados parciales al source
+// subyacente, guardando los artefactos volcados para la entrada de caché.
+func (c *CachingSource) SetPartialHandler(fn func(partial *domain.ScanResult) error) {
+	partialSource, ok := c.source.(ports.PartialResultSource)
+	if !ok {
+		return
+	}
+	if fn == nil {
+		partialSource.SetPartialHandler(nil)
+		return
+	}
+	partialSource.SetPartialHandler(func(partial *domain.ScanResult) error {
+		c.mu.Lock()
+		c.partial = append(c.partial, partial.Artifacts...)
+		c.mu.Unlock()
+		return fn(partial)
+	})
+}
+
+// ProgressChannel retorna el canal de progreso del source subyacente (nil si
+// no informa de progreso). Implementa ports.ProgressSource.
+func (c *CachingSource) ProgressChannel() <-chan ports.ProgressUpdate {
+	if progress, ok := c.source.(ports.ProgressSource); ok {
+		return progress.ProgressChannel()
+	}
+	return nil
+}
+
+// Providers retorna los proveedores del source subyacente (nil si no los
+// expone). Implementa ports.ProviderSource.
+func (c *CachingSource) Providers() []string {
+	if ps, ok := c.source.(ports.ProviderSource); ok {
+		return ps.Providers()
+	}
+	return nil
+}
+
+// Close cierra el source subyacente.
+func (c *CachingSource) Close() error {
+	return c.source.Close()
+}
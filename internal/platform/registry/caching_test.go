@@ -0,0 +1,221 @@
+// internal/platform/registry/caching_test.go
+package registry
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/cache"
+	"aethonx/internal/platform/errors"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+)
+
+// countingSource cuenta sus ejecuciones y retorna un subdominio por input.
+type countingSource struct {
+	mockSource
+	runs int
+	fail bool
+}
+
+func (c *countingSource) RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+	c.runs++
+	result := domain.NewScanResult(target)
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "www."+target.Root, c.name))
+	if input != nil {
+		for _, a := range input.Artifacts {
+			result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "dev."+a.Value, c.name))
+		}
+	}
+	if c.fail {
+		result.AddError(c.name, "upstream returned 500", false)
+	}
+	return result, nil
+}
+
+func TestCachingSource(t *testing.T) {
+	registry := NewSourceRegistry(logx.New())
+	inner := &countingSource{mockSource: mockSource{name: "counting"}}
+	registry.Register("counting", func(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+		return inner, nil
+	}, ports.SourceMetadata{Name: "counting"})
+
+	build := func(custom map[string]interface{}) ports.InputConsumer {
+		sources, err := registry.Build(map[string]ports.SourceConfig{
+			"counting": {Enabled: true, CacheTTL: time.Hour, Custom: custom},
+		}, logx.New())
+		testutil.AssertNoError(t, err, "build should succeed")
+		consumer, ok := sources[0].(ports.InputConsumer)
+		testutil.AssertTrue(t, ok, "cached source must consume input")
+		return consumer
+	}
+
+	ctx := context.Background()
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+	input := domain.NewScanResult(target)
+	input.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "crtsh"))
+
+	source := build(map[string]interface{}{"depth": 1})
+	first, err := source.RunWithInput(ctx, target, input)
+	testutil.AssertNoError(t, err, "first run")
+	testutil.AssertEqual(t, first.Metadata.Environment[domain.EnvSourceCache], domain.SourceCacheMiss, "first run is a miss")
+	first.Artifacts = nil // El orquestador puede vaciar el resultado
+
+	// Un build posterior (otro escaneo) reutiliza la caché del registry
+	second, err := build(map[string]interface{}{"depth": 1}).RunWithInput(ctx, target, input)
+	testutil.AssertNoError(t, err, "second run")
+	testutil.AssertEqual(t, inner.runs, 1, "second run must come from the cache")
+	testutil.AssertEqual(t, second.Metadata.Environment[domain.EnvSourceCache], domain.SourceCacheHit, "second run is a hit")
+	testutil.AssertEqual(t, len(second.Artifacts), 2, "cached artifacts")
+
+	// Otro input u otra configuración Custom vuelven a ejecutar la source
+	other := domain.NewScanResult(target)
+	other.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "mail.example.com", "crtsh"))
+	_, _ = source.RunWithInput(ctx, target, other)
+	_, _ = build(map[string]interface{}{"depth": 2}).RunWithInput(ctx, target, input)
+	testutil.AssertEqual(t, inner.runs, 3, "a different key must run the source")
+
+	// Los resultados con errores no se guardan
+	inner.fail = true
+	failed := build(map[string]interface{}{"depth": 3})
+	_, _ = failed.RunWithInput(ctx, target, input)
+	_, _ = failed.RunWithInput(ctx, target, input)
+	testutil.AssertEqual(t, inner.runs, 5, "failed results must not be cached")
+}
+
+func TestSourceRegistry_Build_NoCacheByDefault(t *testing.T) {
+	registry := NewSourceRegistry(logx.New())
+	registry.Register("test", func(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+		return &mockSource{name: "test"}, nil
+	}, ports.SourceMetadata{Name: "test"})
+
+	sources, err := registry.Build(map[string]ports.SourceConfig{"test": {Enabled: true}}, logx.New())
+	testutil.AssertNoError(t, err, "build should succeed")
+	_, cached := sources[0].(*CachingSource)
+	testutil.AssertFalse(t, cached, "sources without CacheTTL must not be wrapped")
+}
+
+// deadEndSource no encuentra nada: sin artefactos o con un 404 del upstream.
+type deadEndSource struct {
+	mockSource
+	runs     int
+	notFound bool
+}
+
+func (d *deadEndSource) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	d.runs++
+	if d.notFound {
+		return domain.NewScanResult(target), errors.Wrapf(errors.ErrNotFound, "domain not found in RDAP: %s", target.Root)
+	}
+	return domain.NewScanResult(target), nil
+}
+
+func TestCachingSource_Negative(t *testing.T) {
+	path := filepath.Join(t.TempDir(), NegativeCacheFilename)
+	negative, err := LoadNegativeCache(path)
+	testutil.AssertNoError(t, err, "missing file is an empty cache")
+
+	inner := &deadEndSource{mockSource: mockSource{name: "rdap"}}
+	cfg := ports.SourceConfig{NegativeCacheTTL: time.Hour}
+	source := NewCachingSource(inner, cache.NewMemoryCache(10), negative, cfg, logx.New())
+	ctx := context.Background()
+	target := *domain.NewTarget("empty.example", domain.ScanModePassive)
+
+	// Resultado vacío: se recuerda
+	_, _ = source.Run(ctx, target)
+	result, err := source.Run(ctx, target)
+	testutil.AssertNoError(t, err, "cached empty result")
+	testutil.AssertEqual(t, inner.runs, 1, "empty result must be cached")
+	testutil.AssertEqual(t, result.Metadata.Environment[domain.EnvSourceCache], domain.SourceCacheHit, "negative hit")
+
+	// "No encontrado": se recuerda el error y se persiste para otro proceso
+	gone := *domain.NewTarget("gone.example", domain.ScanModePassive)
+	inner.notFound = true
+	_, first := source.Run(ctx, gone)
+	reloaded, err := LoadNegativeCache(path)
+	testutil.AssertNoError(t, err, "reload")
+	testutil.AssertEqual(t, reloaded.Len(), 2, "entries persisted")
+
+	source = NewCachingSource(inner, cache.NewMemoryCache(10), reloaded, cfg, logx.New())
+	_, second := source.Run(ctx, gone)
+	testutil.AssertEqual(t, inner.runs, 2, "not found must come from the persisted cache")
+	testutil.AssertTrue(t, errors.IsNotFound(second), "cached error keeps its kind")
+	testutil.AssertContains(t, second.Error(), first.Error(), "cached error keeps its message")
+
+	// Caducadas no cuentan
+	reloaded.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	_, _ = source.Run(ctx, gone)
+	testutil.AssertEqual(t, inner.runs, 3, "expired entries must run the source")
+}
+
+func TestCachingSource_NegativeOnlyDoesNotCachePositives(t *testing.T) {
+	inner := &countingSource{mockSource: mockSource{name: "crtsh"}}
+	source := NewCachingSource(inner, cache.NewMemoryCache(10), NewNegativeCache(), ports.SourceConfig{NegativeCacheTTL: time.Hour}, logx.New())
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+
+	_, _ = source.RunWithInput(context.Background(), target, nil)
+	_, _ = source.RunWithInput(context.Background(), target, nil)
+	testutil.AssertEqual(t, inner.runs, 2, "results with artifacts need CacheTTL")
+}
+
+func TestCachingSource_SampledRunBypassesCache(t *testing.T) {
+	inner := &deadEndSource{mockSource: mockSource{name: "rdap"}}
+	cfg := ports.SourceConfig{CacheTTL: time.Hour, NegativeCacheTTL: time.Hour}
+	negative := NewNegativeCache()
+	source := NewCachingSource(inner, cache.NewMemoryCache(10), negative, cfg, logx.New())
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+
+	// Una muestra (--sample) vacía no dice nada del escaneo completo
+	sampled := ports.WithSampleSize(context.Background(), 5)
+	_, _ = source.Run(sampled, target)
+	_, _ = source.Run(sampled, target)
+	testutil.AssertEqual(t, inner.runs, 2, "sampled runs must not read the cache")
+	testutil.AssertEqual(t, negative.Len(), 0, "sampled runs must not be negative-cached")
+
+	_, _ = source.Run(context.Background(), target)
+	testutil.AssertEqual(t, inner.runs, 3, "a full run after a sample must run the source")
+}
+
+// truncatedSource simula el corte del orquestador: cancela el ctx de la
+// ejecución (tope de artefactos o de duración) antes de retornar.
+type truncatedSource struct {
+	countingSource
+	cancel context.CancelCauseFunc
+}
+
+func (s *truncatedSource) RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+	result, err := s.countingSource.RunWithInput(ctx, target, input)
+	if s.cancel != nil {
+		s.cancel(context.DeadlineExceeded)
+	}
+	return result, err
+}
+
+func TestCachingSource_TruncatedRunNotCached(t *testing.T) {
+	inner := &truncatedSource{countingSource: countingSource{mockSource: mockSource{name: "crtsh"}}}
+	cfg := ports.SourceConfig{CacheTTL: time.Hour, NegativeCacheTTL: time.Hour}
+	negative := NewNegativeCache()
+	source := NewCachingSource(inner, cache.NewMemoryCache(10), negative, cfg, logx.New())
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	inner.cancel = cancel
+	result, err := source.RunWithInput(ctx, target, nil)
+	testutil.AssertNoError(t, err, "truncated run")
+	testutil.AssertEqual(t, len(result.Artifacts), 1, "partial artifacts are still returned")
+
+	// El escaneo completo siguiente no recibe el resultado cortado
+	inner.cancel = nil
+	result, err = source.RunWithInput(context.Background(), target, nil)
+	testutil.AssertNoError(t, err, "full run")
+	testutil.AssertEqual(t, inner.runs, 2, "truncated results must not be cached")
+	testutil.AssertEqual(t, result.Metadata.Environment[domain.EnvSourceCache], domain.SourceCacheMiss, "full run is a miss")
+	testutil.AssertEqual(t, negative.Len(), 0, "truncated runs must not be negative-cached")
+
+	_, _ = source.RunWithInput(context.Background(), target, nil)
+	testutil.AssertEqual(t, inner.runs, 2, "the full run is cached")
+}
@@ -0,0 +1,128 @@
+// internal/platform/registry/negative_cache.go
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// NegativeCacheFilename es el fichero del directorio de salida donde se
+// guardan los resultados negativos entre ejecuciones.
+const NegativeCacheFilename = "negative_cache.json"
+
+// NegativeEntry es un callejón sin salida conocido: una source que no
+// encontró nada (Reason vacío) o cuyo upstream respondió "no encontrado"
+// (Reason con el error, e.g. un 404 de RDAP).
+type NegativeEntry struct {
+	Source  string    `json:"source"`
+	Target  string    `json:"target"`
+	Reason  string    `json:"reason,omitempty"`
+	Expires time.Time `json:"expires"`
+}
+
+// NegativeCache guarda los resultados negativos de las sources con
+// NegativeCacheTTL. Con path se persiste en disco, para que los escaneos
+// periódicos (modo monitor) no vuelvan a consultar lo que se sabe vacío.
+type NegativeCache struct {
+	mu      sync.Mutex
+	path    string // "" = solo en memoria
+	entries map[string]NegativeEntry
+	now     func() time.Time
+}
+
+// NewNegativeCache crea una caché negativa en memoria.
+func NewNegativeCache() *NegativeCache {
+	return &NegativeCache{entries: make(map[string]NegativeEntry), now: time.Now}
+}
+
+// LoadNegativeCache abre la caché negativa guardada en path (vacía si el
+// fichero no existe). Las entradas caducadas se descartan.
+func LoadNegativeCache(path string) (*NegativeCache, error) {
+	n := NewNegativeCache()
+	n.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return n, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read negative cache: %w", err)
+	}
+	if err := json.Unmarshal(data, &n.entries); err != nil {
+		return nil, fmt.Errorf("failed to decode negative cache %s: %w", path, err)
+	}
+	if n.entries == nil {
+		n.entries = make(map[string]NegativeEntry)
+	}
+	n.pruneLocked()
+	return n, nil
+}
+
+// Get retorna la entrada vigente de key.
+func (n *NegativeCache) Get(key string) (NegativeEntry, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	entry, ok := n.entries[key]
+	if !ok || !n.now().Before(entry.Expires) {
+		return NegativeEntry{}, false
+	}
+	return entry, true
+}
+
+// Set guarda entry bajo key durante ttl y, si la caché tiene fichero, lo
+// reescribe.
+func (n *NegativeCache) Set(key string, entry NegativeEntry, ttl time.Duration) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	entry.Expires = n.now().Add(ttl).UTC()
+	n.entries[key] = entry
+	n.pruneLocked()
+	return n.saveLocked()
+}
+
+// Len retorna el número de entradas vigentes.
+func (n *NegativeCache) Len() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.pruneLocked()
+	return len(n.entries)
+}
+
+// pruneLocked elimina las entradas caducadas.
+func (n *NegativeCache) pruneLocked() {
+	now := n.now()
+	for key, entry := range n.entries {
+		if !now.Before(entry.Expires) {
+			delete(n.entries, key)
+		}
+	}
+}
+
+// saveLocked escribe el fichero de forma atómica (fichero temporal + rename).
+func (n *NegativeCache) saveLocked() error {
+	if n.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(n.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create negative cache directory: %w", err)
+	}
+	data, err := json.MarshalIndent(n.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode negative cache: %w", err)
+	}
+	tmp := n.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write negative cache: %w", err)
+	}
+	if err := os.Rename(tmp, n.path); err != nil {
+		return fmt.Errorf("failed to write negative cache: %w", err)
+	}
+	return nil
+}
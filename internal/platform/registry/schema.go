@@ -0,0 +1,210 @@
+// internal/platform/registry/schema.go
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"aethonx/internal/core/ports"
+)
+
+// SourceOptions son las opciones Custom de una source decodificadas contra su
+// ConfigSchema, con los defaults aplicados. Los valores tienen el tipo Go de
+// su ConfigType (string, int, float64, bool, time.Duration, []string).
+type SourceOptions struct {
+	values map[string]interface{}
+}
+
+// activeModeOption es la opción que el binario y el SDK inyectan en todas las
+// sources con el modo del escaneo; solo la decodifican las que la declaran.
+const activeModeOption = "active_mode"
+
+// DecodeConfig valida custom contra el schema y retorna las opciones tipadas.
+// Falla con todas las claves desconocidas (sugiriendo la más parecida), tipos
+// incorrectos y opciones requeridas sin valor. Un string o lista vacíos
+// cuentan como no configurados y toman el default.
+func DecodeConfig(schema []ports.ConfigField, custom map[string]interface{}) (SourceOptions, error) {
+	opts := SourceOptions{values: make(map[string]interface{}, len(schema))}
+	var errs []error
+
+	fields := make(map[string]bool, len(schema))
+	names := make([]string, 0, len(schema))
+	for _, f := range schema {
+		fields[f.Name] = true
+		names = append(names, f.Name)
+	}
+
+	unknown := make([]string, 0)
+	for key := range custom {
+		if !fields[key] && key != activeModeOption {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		errs = append(errs, fmt.Errorf("unknown option %q (%s)", key, suggestion(key, names, "not declared by the source")))
+	}
+
+	for _, f := range schema {
+		raw := custom[f.Name]
+		if isUnset(raw) {
+			if f.Required {
+				errs = append(errs, fmt.Errorf("option %q is required", f.Name))
+				continue
+			}
+			if f.Default != nil {
+				opts.values[f.Name], _ = decodeValue(f.Type, f.Default) // validado en Register
+			}
+			continue
+		}
+
+		value, err := decodeValue(f.Type, raw)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("option %q: %w", f.Name, err))
+			continue
+		}
+		opts.values[f.Name] = value
+	}
+
+	return opts, errors.Join(errs...)
+}
+
+// validateSchema comprueba que los defaults del schema sean del tipo declarado.
+func validateSchema(schema []ports.ConfigField) error {
+	for _, f := range schema {
+		if f.Default == nil {
+			continue
+		}
+		if _, err := decodeValue(f.Type, f.Default); err != nil {
+			return fmt.Errorf("invalid default for option %q: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// isUnset indica si un valor de Custom cuenta como no configurado: nil, un
+// string vacío o una lista vacía (los defaults de config declaran listas vacías).
+func isUnset(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []string:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// decodeValue convierte value al tipo Go de t. Acepta las representaciones
+// que producen ENV, YAML y JSON (e.g., enteros como float64, duraciones como
+// string o nanosegundos).
+func decodeValue(t ports.ConfigType, value interface{}) (interface{}, error) {
+	mismatch := fmt.Errorf("expected %s, got %T (%v)", t, value, value)
+
+	switch t {
+	case ports.ConfigTypeString:
+		if v, ok := value.(string); ok {
+			return v, nil
+		}
+	case ports.ConfigTypeInt:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case int64:
+			return int(v), nil
+		case float64:
+			if v == float64(int64(v)) {
+				return int(v), nil
+			}
+		}
+	case ports.ConfigTypeFloat:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		}
+	case ports.ConfigTypeBool:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	case ports.ConfigTypeDuration:
+		switch v := value.(type) {
+		case time.Duration:
+			return v, nil
+		case int:
+			return time.Duration(v), nil
+		case int64:
+			return time.Duration(v), nil
+		case float64:
+			return time.Duration(v), nil
+		case string:
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("expected duration like 30s or 5m, got %q", v)
+			}
+			return d, nil
+		}
+	case ports.ConfigTypeStringList:
+		switch v := value.(type) {
+		case []string:
+			return v, nil
+		case []interface{}:
+			out := make([]string, 0, len(v))
+			for _, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return nil, mismatch
+				}
+				out = append(out, s)
+			}
+			return out, nil
+		}
+	default:
+		return value, nil
+	}
+	return nil, mismatch
+}
+
+// String retorna una opción string ("" si no está declarada).
+func (o SourceOptions) String(name string) string {
+	v, _ := o.values[name].(string)
+	return v
+}
+
+// Int retorna una opción int.
+func (o SourceOptions) Int(name string) int {
+	v, _ := o.values[name].(int)
+	return v
+}
+
+// Float retorna una opción float.
+func (o SourceOptions) Float(name string) float64 {
+	v, _ := o.values[name].(float64)
+	return v
+}
+
+// Bool retorna una opción bool.
+func (o SourceOptions) Bool(name string) bool {
+	v, _ := o.values[name].(bool)
+	return v
+}
+
+// Duration retorna una opción duration.
+func (o SourceOptions) Duration(name string) time.Duration {
+	v, _ := o.values[name].(time.Duration)
+	return v
+}
+
+// Strings retorna una opción []string.
+func (o SourceOptions) Strings(name string) []string {
+	v, _ := o.values[name].([]string)
+	return v
+}
@@ -0,0 +1,95 @@
+// internal/platform/registry/schema_test.go
+package registry
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+)
+
+var testSchema = []ports.ConfigField{
+	{Name: "exec_path", Type: ports.ConfigTypeString, Default: "tool"},
+	{Name: "threads", Type: ports.ConfigTypeInt, Default: 10},
+	{Name: "rate_limit", Type: ports.ConfigTypeFloat, Default: 1.5},
+	{Name: "verbose", Type: ports.ConfigTypeBool},
+	{Name: "timeout", Type: ports.ConfigTypeDuration, Default: time.Minute},
+	{Name: "sources", Type: ports.ConfigTypeStringList},
+	{Name: "token", Type: ports.ConfigTypeString, Required: true},
+}
+
+func TestDecodeConfig(t *testing.T) {
+	opts, err := DecodeConfig(testSchema, map[string]interface{}{
+		"exec_path":  "", // vacío = default
+		"threads":    float64(25),
+		"rate_limit": 3,
+		"verbose":    true,
+		"timeout":    "30s",
+		"sources":    []interface{}{"a", "b"},
+		"token":      "t0k",
+	})
+	testutil.AssertNoError(t, err, "valid config")
+
+	testutil.AssertEqual(t, opts.String("exec_path"), "tool", "empty string takes default")
+	testutil.AssertEqual(t, opts.Int("threads"), 25, "float64 decoded as int")
+	testutil.AssertEqual(t, opts.Float("rate_limit"), 3.0, "int decoded as float")
+	testutil.AssertTrue(t, opts.Bool("verbose"), "bool")
+	testutil.AssertEqual(t, opts.Duration("timeout"), 30*time.Second, "duration string")
+	testutil.AssertEqual(t, strings.Join(opts.Strings("sources"), ","), "a,b", "string list")
+	testutil.AssertEqual(t, opts.String("token"), "t0k", "required value")
+}
+
+func TestDecodeConfig_Defaults(t *testing.T) {
+	opts, err := DecodeConfig(testSchema, map[string]interface{}{"token": "x"})
+	testutil.AssertNoError(t, err, "defaults only")
+	testutil.AssertEqual(t, opts.Int("threads"), 10, "int default")
+	testutil.AssertEqual(t, opts.Float("rate_limit"), 1.5, "float default")
+	testutil.AssertEqual(t, opts.Duration("timeout"), time.Minute, "duration default")
+	testutil.AssertFalse(t, opts.Bool("verbose"), "zero value without default")
+	testutil.AssertEqual(t, len(opts.Strings("sources")), 0, "nil list without default")
+
+	withList := []ports.ConfigField{{Name: "sources", Type: ports.ConfigTypeStringList, Default: []string{"a"}}}
+	opts, err = DecodeConfig(withList, map[string]interface{}{"sources": []string{}})
+	testutil.AssertNoError(t, err, "empty list")
+	testutil.AssertEqual(t, strings.Join(opts.Strings("sources"), ","), "a", "empty list takes default")
+}
+
+func TestDecodeConfig_Errors(t *testing.T) {
+	_, err := DecodeConfig(testSchema, map[string]interface{}{
+		"rate_limt": 10,
+		"threads":   "many",
+		"timeout":   "soon",
+	})
+	testutil.AssertError(t, err, "invalid config")
+
+	msg := err.Error()
+	for _, want := range []string{
+		`unknown option "rate_limt" (did you mean "rate_limit"?)`,
+		`option "threads": expected int`,
+		`option "timeout": expected duration`,
+		`option "token" is required`,
+	} {
+		testutil.AssertTrue(t, strings.Contains(msg, want), "error mentions: "+want)
+	}
+}
+
+func TestDecodeConfig_ActiveModeInjected(t *testing.T) {
+	// El binario inyecta active_mode en todas las sources
+	_, err := DecodeConfig(testSchema, map[string]interface{}{"token": "x", "active_mode": true})
+	testutil.AssertNoError(t, err, "active_mode is accepted without being declared")
+}
+
+func TestRegister_InvalidSchemaDefault(t *testing.T) {
+	r := NewSourceRegistry(logx.NewSilent())
+	factory := func(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+		return &mockSource{name: "bad"}, nil
+	}
+
+	err := r.Register("bad", factory, ports.SourceMetadata{
+		ConfigSchema: []ports.ConfigField{{Name: "threads", Type: ports.ConfigTypeInt, Default: "10"}},
+	})
+	testutil.AssertError(t, err, "default of the wrong type must be rejected")
+}
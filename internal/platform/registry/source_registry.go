@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/cache"
 	"aethonx/internal/platform/logx"
 )
 
@@ -19,6 +20,12 @@ type SourceRegistry struct {
 	factories map[string]SourceFactory
 	metadata  map[string]ports.SourceMetadata
 	logger    logx.Logger
+
+	// cache resultados de las sources con CacheTTL > 0; vive lo que el
+	// registry, así se reutilizan entre escaneos del mismo proceso.
+	// negative resultados negativos de las sources con NegativeCacheTTL > 0
+	cache    cache.Cache
+	negative *NegativeCache
 }
 
 // SourceFactory es una función que crea una instancia de Source.
@@ -42,6 +49,8 @@ func NewSourceRegistry(logger logx.Logger) *SourceRegistry {
 		factories: make(map[string]SourceFactory),
 		metadata:  make(map[string]ports.SourceMetadata),
 		logger:    logger.With("component", "source-registry"),
+		cache:     cache.NewMemoryCache(DefaultCacheCapacity),
+		negative:  NewNegativeCache(),
 	}
 }
 
@@ -63,6 +72,10 @@ func (r *SourceRegistry) Register(name string, factory SourceFactory, meta ports
 		return fmt.Errorf("source %s is already registered", name)
 	}
 
+	if err := validateSchema(meta.ConfigSchema); err != nil {
+		return fmt.Errorf("source %s: %w", name, err)
+	}
+
 	r.factories[name] = factory
 	r.metadata[name] = meta
 	r.logger.Debug("source registered", "name", name, "mode", meta.Mode, "type", meta.Type)
@@ -152,6 +165,10 @@ func (r *SourceRegistry) Build(configs map[string]ports.SourceConfig, logger log
 			}
 		}
 
+		if ps.config.CacheTTL > 0 || ps.config.NegativeCacheTTL > 0 {
+			source = NewCachingSource(source, r.cache, r.negative, ps.config, logger)
+		}
+
 		sources = append(sources, source)
 		r.logger.Debug("source built",
 			"name", ps.name,
@@ -176,6 +193,27 @@ func (r *SourceRegistry) Build(configs map[string]ports.SourceConfig, logger log
 	return sources, nil
 }
 
+// SetNegativeCache reemplaza la caché negativa de las sources que se
+// construyan después (e.g., una persistida con LoadNegativeCache).
+func (r *SourceRegistry) SetNegativeCache(negative *NegativeCache) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.negative = negative
+}
+
+// BuildSource construye una única source con cfg, sin comprobar Enabled ni
+// inicializarla (e.g., para diagnosticarla con "aethonx doctor").
+func (r *SourceRegistry) BuildSource(name string, cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+	r.mu.RLock()
+	factory, exists := r.factories[name]
+	r.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("source %s not registered in registry", name)
+	}
+	return factory(cfg, logger)
+}
+
 // List retorna los nombres de todas las sources registradas.
 func (r *SourceRegistry) List() []string {
 	r.mu.RLock()
@@ -138,6 +138,23 @@ func TestSourceRegistry_Build_Priority(t *testing.T) {
 	testutil.AssertEqual(t, sources[1].Name(), "source_b", "lower priority second")
 }
 
+func TestSourceRegistry_BuildSource(t *testing.T) {
+	registry := NewSourceRegistry(logx.New())
+
+	factory := func(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+		return &mockSource{name: "test"}, nil
+	}
+	registry.Register("test", factory, ports.SourceMetadata{Name: "test"})
+
+	// Disabled sources can still be built individually
+	source, err := registry.BuildSource("test", ports.SourceConfig{Enabled: false}, logx.New())
+	testutil.AssertNoError(t, err, "build should succeed")
+	testutil.AssertEqual(t, source.Name(), "test", "built source")
+
+	_, err = registry.BuildSource("missing", ports.SourceConfig{}, logx.New())
+	testutil.AssertError(t, err, "unregistered source should fail")
+}
+
 func TestSourceRegistry_List(t *testing.T) {
 	registry := NewSourceRegistry(logx.New())
 
@@ -0,0 +1,256 @@
+// internal/platform/registry/validate.go
+package registry
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+)
+
+// Severidades de ConfigProblem.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// ConfigProblem es un diagnóstico de la configuración de una source.
+type ConfigProblem struct {
+	Source   string
+	Key      string // Clave de Custom afectada ("" = la source en sí)
+	Severity string
+	Message  string
+	Hint     string // Acción sugerida para corregirlo
+}
+
+// lookPath resuelve binarios (sustituible en tests).
+var lookPath = exec.LookPath
+
+// ValidateConfigs comprueba las configuraciones de sources contra el registry:
+// sources desconocidas, claves de Custom no declaradas en ConfigSchema, tipos
+// incorrectos y, para las sources habilitadas, binarios CLI y API keys.
+// Los problemas se retornan ordenados por source y clave.
+func (r *SourceRegistry) ValidateConfigs(configs map[string]ports.SourceConfig) []ConfigProblem {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	known := make([]string, 0, len(r.metadata))
+	for name := range r.metadata {
+		known = append(known, name)
+	}
+	sort.Strings(known)
+
+	var problems []ConfigProblem
+	for name, cfg := range configs {
+		meta, ok := r.metadata[name]
+		if !ok {
+			problems = append(problems, ConfigProblem{
+				Source:   name,
+				Severity: SeverityError,
+				Message:  "unknown source",
+				Hint:     suggestion(name, known, "remove it from the config"),
+			})
+			continue
+		}
+
+		problems = append(problems, checkCustom(name, meta.ConfigSchema, cfg.Custom)...)
+		if cfg.Enabled {
+			problems = append(problems, checkRuntime(name, meta, cfg)...)
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Source != problems[j].Source {
+			return problems[i].Source < problems[j].Source
+		}
+		return problems[i].Key < problems[j].Key
+	})
+	return problems
+}
+
+// checkCustom valida las claves y tipos de Custom contra el schema declarado.
+func checkCustom(source string, schema []ports.ConfigField, custom map[string]interface{}) []ConfigProblem {
+	fields := make(map[string]ports.ConfigField, len(schema))
+	names := make([]string, 0, len(schema))
+	for _, f := range schema {
+		fields[f.Name] = f
+		names = append(names, f.Name)
+	}
+
+	var problems []ConfigProblem
+	for key, value := range custom {
+		field, ok := fields[key]
+		if !ok {
+			hint := "remove it"
+			if len(names) > 0 {
+				hint = "valid keys: " + strings.Join(sortedCopy(names), ", ")
+			}
+			problems = append(problems, ConfigProblem{
+				Source:   source,
+				Key:      key,
+				Severity: SeverityError,
+				Message:  "unknown option",
+				Hint:     suggestion(key, names, hint),
+			})
+			continue
+		}
+		if _, err := decodeValue(field.Type, value); err != nil {
+			problems = append(problems, ConfigProblem{
+				Source:   source,
+				Key:      key,
+				Severity: SeverityError,
+				Message:  err.Error(),
+				Hint:     fieldHint(field),
+			})
+		}
+	}
+	return problems
+}
+
+// checkRuntime comprueba lo que una source habilitada necesita para ejecutarse:
+// opciones requeridas, el binario de las sources CLI y las credenciales de las
+// que requieren auth.
+func checkRuntime(source string, meta ports.SourceMetadata, cfg ports.SourceConfig) []ConfigProblem {
+	var problems []ConfigProblem
+
+	// use_cli: el binario usa su propia configuración de credenciales
+	useCLI := GetBoolConfig(cfg.Custom, "use_cli", false)
+
+	if bin, path := BinaryStatus(source, meta, cfg); bin != "" && path == "" {
+		problems = append(problems, ConfigProblem{
+			Source:   source,
+			Key:      "exec_path",
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("binary %q not found in PATH", bin),
+			Hint:     binaryHint(source, cfg),
+		})
+	}
+
+	for _, f := range meta.ConfigSchema {
+		if f.Required && isUnset(cfg.Custom[f.Name]) {
+			problems = append(problems, ConfigProblem{
+				Source:   source,
+				Key:      f.Name,
+				Severity: SeverityError,
+				Message:  "required option not set",
+				Hint:     fmt.Sprintf("set sources.%s.custom.%s in the config file", source, f.Name),
+			})
+		}
+	}
+
+	if meta.RequiresAuth && !useCLI {
+		var credentials []string
+		configured := false
+		for _, f := range meta.ConfigSchema {
+			if !f.Credential {
+				continue
+			}
+			credentials = append(credentials, f.Name)
+			if GetStringConfig(cfg.Custom, f.Name, "") != "" {
+				configured = true
+			}
+		}
+		if len(credentials) > 0 && !configured {
+			env := fmt.Sprintf("AETHONX_SOURCES_%s_%s", strings.ToUpper(source), strings.ToUpper(credentials[0]))
+			problems = append(problems, ConfigProblem{
+				Source:   source,
+				Key:      credentials[0],
+				Severity: SeverityError,
+				Message:  "API key required but not configured",
+				Hint:     fmt.Sprintf("set %s or disable it (%s=false)", env, enabledEnv(source)),
+			})
+		}
+	}
+
+	return problems
+}
+
+// BinaryStatus retorna el binario que la source ejecuta con cfg ("" si no usa
+// ninguno) y su ruta resuelta en PATH ("" si no está instalado). Usan binario
+// las sources CLI y las API con modo CLI alternativo (use_cli, e.g., shodan);
+// con runtime: docker el binario es docker.
+func BinaryStatus(source string, meta ports.SourceMetadata, cfg ports.SourceConfig) (bin, path string) {
+	if meta.Type != domain.SourceTypeCLI && !GetBoolConfig(cfg.Custom, "use_cli", false) {
+		return "", ""
+	}
+	bin = GetStringConfig(cfg.Custom, "exec_path", source)
+	if UsesContainer(cfg) {
+		bin = "docker"
+	}
+	path, err := lookPath(bin)
+	if err != nil {
+		return bin, ""
+	}
+	return bin, path
+}
+
+func binaryHint(source string, cfg ports.SourceConfig) string {
+	if UsesContainer(cfg) {
+		return fmt.Sprintf("install docker, set runtime: host, or disable it (%s=false)", enabledEnv(source))
+	}
+	return fmt.Sprintf("run aethonx deps install, set exec_path, use runtime: docker, or disable it (%s=false)", enabledEnv(source))
+}
+
+// UsesContainer indica si la source ejecuta su herramienta en un contenedor
+// (runtime: docker) en lugar de un binario del host.
+func UsesContainer(cfg ports.SourceConfig) bool {
+	return GetStringConfig(cfg.Custom, "runtime", "") == "docker"
+}
+
+func enabledEnv(source string) string {
+	return fmt.Sprintf("AETHONX_SOURCES_%s_ENABLED", strings.ToUpper(source))
+}
+
+// fieldHint describe el valor esperado de una opción.
+func fieldHint(f ports.ConfigField) string {
+	hint := fmt.Sprintf("set %s to a value of type %s", f.Name, f.Type)
+	if f.Description != "" {
+		hint += " (" + f.Description + ")"
+	}
+	return hint
+}
+
+// suggestion retorna "did you mean X?" si hay un candidato cercano (errata),
+// o fallback en otro caso.
+func suggestion(name string, candidates []string, fallback string) string {
+	best, bestDist := "", 3 // Distancia máxima aceptada: 2 ediciones
+	for _, c := range candidates {
+		if d := editDistance(name, c); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	if best == "" {
+		return fallback
+	}
+	return fmt.Sprintf("did you mean %q?", best)
+}
+
+// editDistance calcula la distancia de Levenshtein entre a y b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func sortedCopy(values []string) []string {
+	out := append([]string(nil), values...)
+	sort.Strings(out)
+	return out
+}
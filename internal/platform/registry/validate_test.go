@@ -0,0 +1,139 @@
+// internal/platform/registry/validate_test.go
+package registry
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+)
+
+func validationRegistry(t *testing.T) *SourceRegistry {
+	t.Helper()
+
+	r := NewSourceRegistry(logx.NewSilent())
+	factory := func(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+		return &mockSource{name: "test"}, nil
+	}
+
+	testutil.AssertNoError(t, r.Register("clitool", factory, ports.SourceMetadata{
+		Name: "clitool",
+		Type: domain.SourceTypeCLI,
+		ConfigSchema: []ports.ConfigField{
+			{Name: "exec_path", Type: ports.ConfigTypeString},
+			{Name: "rate_limit", Type: ports.ConfigTypeInt},
+			{Name: "sources", Type: ports.ConfigTypeStringList},
+		},
+	}), "register clitool")
+	testutil.AssertNoError(t, r.Register("keyed", factory, ports.SourceMetadata{
+		Name:         "keyed",
+		Type:         domain.SourceTypeAPI,
+		RequiresAuth: true,
+		ConfigSchema: []ports.ConfigField{
+			{Name: "api_key", Type: ports.ConfigTypeString, Credential: true},
+			{Name: "use_cli", Type: ports.ConfigTypeBool},
+			{Name: "timeout", Type: ports.ConfigTypeDuration},
+		},
+	}), "register keyed")
+	return r
+}
+
+func withLookPath(t *testing.T, found ...string) {
+	t.Helper()
+	orig := lookPath
+	lookPath = func(bin string) (string, error) {
+		for _, f := range found {
+			if f == bin {
+				return "/usr/bin/" + bin, nil
+			}
+		}
+		return "", errors.New("not found")
+	}
+	t.Cleanup(func() { lookPath = orig })
+}
+
+func TestValidateConfigs_Valid(t *testing.T) {
+	withLookPath(t, "clitool")
+	r := validationRegistry(t)
+
+	problems := r.ValidateConfigs(map[string]ports.SourceConfig{
+		"clitool": {Enabled: true, Custom: map[string]interface{}{
+			"rate_limit": float64(10), // JSON/YAML numbers
+			"sources":    []interface{}{"a", "b"},
+		}},
+		"keyed": {Enabled: true, Custom: map[string]interface{}{"api_key": "secret", "timeout": "30s"}},
+	})
+	testutil.AssertEqual(t, len(problems), 0, "valid config has no problems")
+}
+
+func TestValidateConfigs_Problems(t *testing.T) {
+	withLookPath(t)
+	r := validationRegistry(t)
+
+	problems := r.ValidateConfigs(map[string]ports.SourceConfig{
+		"clitool": {Enabled: true, Custom: map[string]interface{}{
+			"rate_limt": 10,
+			"sources":   "a,b",
+		}},
+		"keyed":   {Enabled: true, Custom: map[string]interface{}{"timeout": "soon"}},
+		"clitoll": {Enabled: true},
+	})
+
+	byKey := make(map[string]ConfigProblem)
+	for _, p := range problems {
+		byKey[p.Source+"/"+p.Key] = p
+	}
+
+	testutil.AssertEqual(t, byKey["clitoll/"].Hint, `did you mean "clitool"?`, "unknown source suggestion")
+	testutil.AssertEqual(t, byKey["clitool/rate_limt"].Hint, `did you mean "rate_limit"?`, "typo suggestion")
+	testutil.AssertTrue(t, strings.Contains(byKey["clitool/sources"].Message, "expected []string"), "type mismatch")
+	testutil.AssertTrue(t, strings.Contains(byKey["clitool/exec_path"].Message, "not found"), "missing binary")
+	testutil.AssertTrue(t, strings.Contains(byKey["keyed/api_key"].Hint, "AETHONX_SOURCES_KEYED_API_KEY"), "missing API key")
+	testutil.AssertTrue(t, strings.Contains(byKey["keyed/timeout"].Message, "expected duration"), "invalid duration")
+	testutil.AssertEqual(t, len(problems), 6, "problem count")
+}
+
+func TestValidateConfigs_DisabledSkipsRuntimeChecks(t *testing.T) {
+	withLookPath(t)
+	r := validationRegistry(t)
+
+	problems := r.ValidateConfigs(map[string]ports.SourceConfig{
+		"clitool": {Enabled: false},
+		"keyed":   {Enabled: true, Custom: map[string]interface{}{"use_cli": true}},
+	})
+
+	// keyed en modo CLI: no requiere API key pero sí el binario
+	testutil.AssertEqual(t, len(problems), 1, "only the CLI binary of keyed")
+	testutil.AssertEqual(t, problems[0].Source, "keyed", "keyed binary")
+	testutil.AssertEqual(t, problems[0].Key, "exec_path", "binary problem")
+}
+
+func TestBinaryStatus(t *testing.T) {
+	withLookPath(t, "clitool", "shodan")
+
+	cli := ports.SourceMetadata{Type: domain.SourceTypeCLI}
+	api := ports.SourceMetadata{Type: domain.SourceTypeAPI}
+
+	bin, path := BinaryStatus("clitool", cli, ports.SourceConfig{})
+	testutil.AssertEqual(t, bin, "clitool", "CLI source defaults to its name")
+	testutil.AssertEqual(t, path, "/usr/bin/clitool", "resolved path")
+
+	bin, path = BinaryStatus("clitool", cli, ports.SourceConfig{Custom: map[string]interface{}{"exec_path": "other"}})
+	testutil.AssertEqual(t, bin, "other", "exec_path overrides the binary")
+	testutil.AssertEqual(t, path, "", "missing binary has no path")
+
+	bin, _ = BinaryStatus("shodan", api, ports.SourceConfig{})
+	testutil.AssertEqual(t, bin, "", "API source needs no binary")
+
+	bin, path = BinaryStatus("shodan", api, ports.SourceConfig{Custom: map[string]interface{}{"use_cli": true}})
+	testutil.AssertEqual(t, bin, "shodan", "use_cli requires the binary")
+	testutil.AssertEqual(t, path, "/usr/bin/shodan", "resolved path")
+
+	bin, path = BinaryStatus("clitool", cli, ports.SourceConfig{Custom: map[string]interface{}{"runtime": "docker"}})
+	testutil.AssertEqual(t, bin, "docker", "runtime docker requires docker")
+	testutil.AssertEqual(t, path, "", "docker not installed")
+}
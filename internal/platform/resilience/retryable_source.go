@@ -20,6 +20,9 @@ type RetryableSource struct {
 	backoffMultiplier float64
 	circuitBreaker  *CircuitBreaker
 	logger          logx.Logger
+
+	// stateHandler recibe los reintentos y la apertura del circuito (opcional)
+	stateHandler func(state ports.SourceState, detail string)
 }
 
 // NewRetryableSource crea un nuevo RetryableSource.
@@ -71,6 +74,7 @@ func (r *RetryableSource) Run(ctx context.Context, target domain.Target) (*domai
 	// Check circuit breaker
 	if r.circuitBreaker != nil && !r.circuitBreaker.Allow() {
 		r.logger.Warn("circuit breaker open, skipping source")
+		r.reportState(ports.SourceStateCircuitOpen, "circuit breaker open")
 		return nil, fmt.Errorf("circuit breaker open for source %s: %w", r.source.Name(), ErrCircuitOpen)
 	}
 
@@ -128,6 +132,7 @@ func (r *RetryableSource) Run(ctx context.Context, target domain.Target) (*domai
 		r.logger.Debug("backing off before retry",
 			"delay_ms", backoff.Milliseconds(),
 		)
+		r.reportState(ports.SourceStateRetrying, fmt.Sprintf("attempt %d/%d in %s: %v", attempt+2, r.maxRetries+1, backoff, err))
 
 		// Wait with context cancellation support
 		select {
@@ -142,6 +147,7 @@ func (r *RetryableSource) Run(ctx context.Context, target domain.Target) (*domai
 		}
 
 		attempt++
+		r.reportState(ports.SourceStateRunning, "")
 	}
 
 	// All retries exhausted
@@ -157,6 +163,52 @@ func (r *RetryableSource) Run(ctx context.Context, target domain.Target) (*domai
 	return nil, fmt.Errorf("source %s failed after %d attempts: %w", r.source.Name(), attempt+1, lastErr)
 }
 
+// BeginScan propaga el inicio de escaneo al source subyacente.
+func (r *RetryableSource) BeginScan(scanID string) {
+	if scoped, ok := r.source.(ports.ScanScopedSource); ok {
+		scoped.BeginScan(scanID)
+	}
+}
+
+// SetLogger propaga el logger del escaneo al source subyacente y lo usa
+// también para los logs de reintentos.
+func (r *RetryableSource) SetLogger(logger logx.Logger) {
+	r.logger = logger.With("component", "retryable-source")
+	if scoped, ok := r.source.(ports.LogScopedSource); ok {
+		scoped.SetLogger(logger)
+	}
+}
+
+// SetStateHandler instala el handler de cambios de estado (reintentos y
+// circuito abierto). Implementa ports.StateReportingSource.
+func (r *RetryableSource) SetStateHandler(fn func(state ports.SourceState, detail string)) {
+	r.stateHandler = fn
+}
+
+// reportState informa al handler, si hay uno instalado.
+func (r *RetryableSource) reportState(state ports.SourceState, detail string) {
+	if r.stateHandler != nil {
+		r.stateHandler(state, detail)
+	}
+}
+
+// SetPartialHandler propaga el handler de resultados parciales al source
+// subyacente.
+func (r *RetryableSource) SetPartialHandler(fn func(partial *domain.ScanResult) error) {
+	if partial, ok := r.source.(ports.PartialResultSource); ok {
+		partial.SetPartialHandler(fn)
+	}
+}
+
+// Providers retorna los proveedores del source subyacente (nil si no los
+// expone). Implementa ports.ProviderSource.
+func (r *RetryableSource) Providers() []string {
+	if ps, ok := r.source.(ports.ProviderSource); ok {
+		return ps.Providers()
+	}
+	return nil
+}
+
 // Close cierra el source subyacente.
 func (r *RetryableSource) Close() error {
 	return r.source.Close()
@@ -106,7 +106,7 @@ func (c *CustomPresenter) FinishStage(stageNum int, duration time.Duration) {
 
 	// Verificar errores
 	for _, src := range stage.Sources {
-		if src.Status == StatusError {
+		if src.Status == StatusError || src.Status == StatusCircuitOpen {
 			stage.Status = StatusWarning
 			break
 		}
@@ -144,7 +144,7 @@ func (c *CustomPresenter) FinishStage(stageNum int, duration time.Duration) {
 		// Añadir summary si existe
 		if result.summary != nil && result.summary.Summary != "" {
 			summaryColor := terminal.Gray
-			if result.status == StatusError {
+			if result.status == StatusError || result.status == StatusCircuitOpen {
 				summaryColor = terminal.BrightRed
 			}
 			line += fmt.Sprintf(" | %s", terminal.Colorize(result.summary.Summary, summaryColor))
@@ -168,12 +168,20 @@ func (c *CustomPresenter) FinishStage(stageNum int, duration time.Duration) {
 func (c *CustomPresenter) StartSource(stageNum int, sourceName string) {
 	c.mu.Lock()
 
-	// Buscar en qué stage está este source (ignorar stageNum que puede ser incorrecto)
-	var stage *StageProgress
-	for _, s := range c.stages {
-		if _, exists := s.Sources[sourceName]; exists {
-			stage = s
-			break
+	// Stage del source: el indicado por stageNum o, si no lo contiene, el
+	// primero que lo declare
+	stage := c.stages[stageNum]
+	if stage != nil {
+		if _, exists := stage.Sources[sourceName]; !exists {
+			stage = nil
+		}
+	}
+	if stage == nil {
+		for _, s := range c.stages {
+			if _, exists := s.Sources[sourceName]; exists {
+				stage = s
+				break
+			}
 		}
 	}
 
@@ -216,11 +224,26 @@ func (c *CustomPresenter) UpdateSource(sourceName string, metrics ProgressMetric
 
 	c.mu.Unlock()
 
-	// Actualizar contador de artifacts en GlobalProgress
+	// Actualizar contadores (por source y total) en GlobalProgress
+	c.globalProgress.UpdateSourceArtifacts(sourceName, metrics.Current)
 	c.globalProgress.UpdateArtifactCount(totalArtifacts)
 	// No llamar Render() aquí porque el spinner ya lo hace cada 200ms
 }
 
+// UpdateSourceStatus actualiza el estado de un source en curso
+// (running, retrying, circuit open) en el state y en el dashboard
+func (c *CustomPresenter) UpdateSourceStatus(sourceName string, status Status, detail string) {
+	c.mu.Lock()
+	for _, stage := range c.stages {
+		if srcProgress, exists := stage.Sources[sourceName]; exists {
+			srcProgress.Status = status
+		}
+	}
+	c.mu.Unlock()
+
+	c.globalProgress.UpdateSourceStatus(sourceName, status)
+}
+
 // UpdateSourcePhase actualiza solo la fase de un source
 func (c *CustomPresenter) UpdateSourcePhase(sourceName string, phase string) {
 	// No-op en custom presenter (simplificado)
@@ -263,6 +286,11 @@ func (c *CustomPresenter) UpdateDiscoveries(discoveries DiscoveryStats) {
 	// No-op en modo simple
 }
 
+// UpdateMemory muestra las métricas de memoria en la barra de progreso
+func (c *CustomPresenter) UpdateMemory(stats MemoryStats) {
+	c.globalProgress.UpdateMemory(stats)
+}
+
 // Info muestra un mensaje informativo
 func (c *CustomPresenter) Info(msg string) {
 	fmt.Printf("%s %s\n", terminal.Colorize(IconInfo, terminal.BrightCyan), msg)
@@ -364,6 +392,9 @@ func (c *CustomPresenter) renderHeader(info ScanInfo) {
 	streamingStatus := "OFF"
 	if info.StreamingOn {
 		streamingStatus = "ON"
+		if info.MemoryBudget > 0 {
+			streamingStatus += " (budget " + FormatMemory(float64(info.MemoryBudget)/1024/1024) + ")"
+		}
 	}
 	fmt.Printf("  ℹ STREAMING   %s\n", terminal.Colorize(streamingStatus, terminal.BrightCyan))
 	fmt.Printf("  %s UI MODE     %s\n", IconMode, terminal.Colorize(string(info.UIMode), terminal.BrightCyan))
@@ -0,0 +1,196 @@
+// internal/platform/ui/event_presenter.go
+package ui
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// ProgressFormat define el formato de eventos de progreso legibles por máquina
+type ProgressFormat string
+
+const (
+	ProgressFormatNone ProgressFormat = ""     // Sin eventos (default)
+	ProgressFormatJSON ProgressFormat = "json" // JSON lines (un evento por línea)
+)
+
+// ProgressEvent es un evento de progreso serializado como una línea JSON.
+// El campo Event identifica el tipo (scan_started, stage_started, source_finished...).
+type ProgressEvent struct {
+	Event     string                 `json:"event"`
+	Timestamp string                 `json:"ts"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+}
+
+// EventPresenter emite eventos de progreso como JSON lines en un writer
+// (normalmente stderr), pensado para scripts que envuelven AethonX mientras
+// la UI visual se renderiza en stdout.
+type EventPresenter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewEventPresenter crea un EventPresenter que escribe en w
+func NewEventPresenter(w io.Writer) *EventPresenter {
+	return &EventPresenter{
+		enc: json.NewEncoder(w),
+	}
+}
+
+// emit serializa y escribe un evento
+func (e *EventPresenter) emit(event string, data map[string]interface{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	_ = e.enc.Encode(ProgressEvent{
+		Event:     event,
+		Timestamp: time.Now().Format(time.RFC3339Nano),
+		Data:      data,
+	})
+}
+
+// Start emite scan_started
+func (e *EventPresenter) Start(info ScanInfo) {
+	e.emit("scan_started", map[string]interface{}{
+		"target":       info.Target,
+		"mode":         info.Mode,
+		"workers":      info.Workers,
+		"total_stages": info.TotalStages,
+	})
+}
+
+// StartStage emite stage_started
+func (e *EventPresenter) StartStage(stage StageInfo) {
+	e.emit("stage_started", map[string]interface{}{
+		"stage":        stage.Number,
+		"total_stages": stage.TotalStages,
+		"name":         stage.Name,
+		"sources":      stage.Sources,
+	})
+}
+
+// FinishStage emite stage_finished
+func (e *EventPresenter) FinishStage(stageNum int, duration time.Duration) {
+	e.emit("stage_finished", map[string]interface{}{
+		"stage":       stageNum,
+		"duration_ms": duration.Milliseconds(),
+	})
+}
+
+// StartSource emite source_started
+func (e *EventPresenter) StartSource(stageNum int, sourceName string) {
+	e.emit("source_started", map[string]interface{}{
+		"stage":  stageNum,
+		"source": sourceName,
+	})
+}
+
+// UpdateSourceStatus emite source_status
+func (e *EventPresenter) UpdateSourceStatus(sourceName string, status Status, detail string) {
+	data := map[string]interface{}{
+		"source": sourceName,
+		"status": status.String(),
+	}
+	if detail != "" {
+		data["detail"] = detail
+	}
+	e.emit("source_status", data)
+}
+
+// UpdateSource emite source_progress
+func (e *EventPresenter) UpdateSource(sourceName string, metrics ProgressMetrics) {
+	data := map[string]interface{}{
+		"source":    sourceName,
+		"artifacts": metrics.Current,
+		"rate":      metrics.Rate,
+	}
+	if metrics.Total > 0 {
+		data["total"] = metrics.Total
+		data["percentage"] = metrics.Percentage
+	}
+	if metrics.Phase != "" {
+		data["phase"] = metrics.Phase
+	}
+	e.emit("source_progress", data)
+}
+
+// UpdateSourcePhase emite source_phase
+func (e *EventPresenter) UpdateSourcePhase(sourceName string, phase string) {
+	e.emit("source_phase", map[string]interface{}{
+		"source": sourceName,
+		"phase":  phase,
+	})
+}
+
+// FinishSource emite source_finished
+func (e *EventPresenter) FinishSource(sourceName string, status Status, duration time.Duration, artifactCount int, summary *SourceSummary) {
+	data := map[string]interface{}{
+		"source":      sourceName,
+		"status":      status.String(),
+		"duration_ms": duration.Milliseconds(),
+		"artifacts":   artifactCount,
+	}
+	if summary != nil && summary.Summary != "" {
+		data["summary"] = summary.Summary
+	}
+	e.emit("source_finished", data)
+}
+
+// UpdateDiscoveries emite artifact_counts
+func (e *EventPresenter) UpdateDiscoveries(d DiscoveryStats) {
+	e.emit("artifact_counts", map[string]interface{}{
+		"subdomains": d.Subdomains,
+		"ips":        d.IPs,
+		"ipv6":       d.IPv6,
+		"urls":       d.URLs,
+		"emails":     d.Emails,
+		"ports":      d.Ports,
+		"total":      d.Total,
+		"unique":     d.Unique,
+	})
+}
+
+// UpdateMemory emite memory_stats
+func (e *EventPresenter) UpdateMemory(stats MemoryStats) {
+	e.emit("memory_stats", map[string]interface{}{
+		"heap_mb":      stats.HeapMB,
+		"held_bytes":   stats.HeldBytes,
+		"budget_bytes": stats.BudgetBytes,
+		"spills":       stats.Spills,
+	})
+}
+
+// Info emite message (level=info)
+func (e *EventPresenter) Info(msg string) {
+	e.emit("message", map[string]interface{}{"level": "info", "message": msg})
+}
+
+// Warning emite message (level=warn)
+func (e *EventPresenter) Warning(msg string) {
+	e.emit("message", map[string]interface{}{"level": "warn", "message": msg})
+}
+
+// Error emite message (level=error)
+func (e *EventPresenter) Error(msg string) {
+	e.emit("message", map[string]interface{}{"level": "error", "message": msg})
+}
+
+// Finish emite scan_finished con el desglose por tipo
+func (e *EventPresenter) Finish(stats ScanStats) {
+	e.emit("scan_finished", map[string]interface{}{
+		"duration_ms":       stats.TotalDuration.Milliseconds(),
+		"total":             stats.TotalArtifacts,
+		"unique":            stats.UniqueArtifacts,
+		"sources_ok":        stats.SourcesSucceeded,
+		"sources_failed":    stats.SourcesFailed,
+		"relationships":     stats.RelationshipsBuilt,
+		"artifacts_by_type": stats.ArtifactsByType,
+	})
+}
+
+// Close no libera recursos (el writer pertenece al llamador)
+func (e *EventPresenter) Close() error {
+	return nil
+}
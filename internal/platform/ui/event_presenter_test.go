@@ -0,0 +1,83 @@
+// internal/platform/ui/event_presenter_test.go
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventPresenter_EmitsJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewEventPresenter(&buf)
+
+	p.StartStage(StageInfo{Number: 1, TotalStages: 2, Name: "Stage 1", Sources: []string{"crtsh"}})
+	p.FinishSource("crtsh", StatusSuccess, 1500*time.Millisecond, 42, nil)
+	p.Finish(ScanStats{UniqueArtifacts: 42, ArtifactsByType: map[string]int{"subdomain": 42}})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(lines))
+	}
+
+	want := []string{"stage_started", "source_finished", "scan_finished"}
+	for i, line := range lines {
+		var ev ProgressEvent
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v", i, err)
+		}
+		if ev.Event != want[i] {
+			t.Errorf("event %d = %q, want %q", i, ev.Event, want[i])
+		}
+	}
+
+	var finished ProgressEvent
+	_ = json.Unmarshal([]byte(lines[1]), &finished)
+	if finished.Data["artifacts"] != float64(42) || finished.Data["duration_ms"] != float64(1500) {
+		t.Errorf("unexpected source_finished data: %v", finished.Data)
+	}
+}
+
+func TestMultiPresenter_FansOut(t *testing.T) {
+	var a, b bytes.Buffer
+	m := NewMultiPresenter(NewEventPresenter(&a), nil, NewEventPresenter(&b))
+
+	m.StartSource(1, "rdap")
+
+	for name, buf := range map[string]*bytes.Buffer{"first": &a, "second": &b} {
+		if !strings.Contains(buf.String(), `"event":"source_started"`) {
+			t.Errorf("%s presenter missed the event: %q", name, buf.String())
+		}
+	}
+	if err := m.Close(); err != nil {
+		t.Errorf("Close() = %v", err)
+	}
+}
+
+func TestEventPresenter_SourceStatus(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewEventPresenter(&buf)
+
+	p.UpdateSourceStatus("shodan", StatusRetrying, "attempt 2/3 in 1s: 503")
+	p.UpdateSourceStatus("shodan", StatusCircuitOpen, "")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(lines))
+	}
+
+	var retrying, open ProgressEvent
+	_ = json.Unmarshal([]byte(lines[0]), &retrying)
+	_ = json.Unmarshal([]byte(lines[1]), &open)
+	if retrying.Event != "source_status" || retrying.Data["status"] != "retrying" || retrying.Data["detail"] == nil {
+		t.Errorf("unexpected retrying event: %+v", retrying)
+	}
+	if open.Data["status"] != "circuit_open" {
+		t.Errorf("unexpected circuit event: %+v", open)
+	}
+	if _, ok := open.Data["detail"]; ok {
+		t.Errorf("empty detail should be omitted: %+v", open.Data)
+	}
+}
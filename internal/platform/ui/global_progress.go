@@ -40,8 +40,12 @@ type GlobalProgress struct {
 
 	// Status por source
 	sourceNames   []string           // Lista ordenada de nombres de sources
-	sourceStatus  map[string]Status  // Estado de cada source
-	sourceSpinner map[string]int     // Frame del spinner de cada source
+	sourceStatus    map[string]Status // Estado de cada source
+	sourceSpinner   map[string]int    // Frame del spinner de cada source
+	sourceArtifacts map[string]int    // Artifacts en vivo de cada source
+
+	// Métricas de memoria (solo si se reciben vía UpdateMemory)
+	memory *MemoryStats
 }
 
 // NewGlobalProgress crea una nueva instancia de GlobalProgress
@@ -59,9 +63,10 @@ func NewGlobalProgress() *GlobalProgress {
 		growingEdgeFrame:  0,
 
 		// Status tracking por source
-		sourceNames:   make([]string, 0),
-		sourceStatus:  make(map[string]Status),
-		sourceSpinner: make(map[string]int),
+		sourceNames:     make([]string, 0),
+		sourceStatus:    make(map[string]Status),
+		sourceSpinner:   make(map[string]int),
+		sourceArtifacts: make(map[string]int),
 	}
 }
 
@@ -94,6 +99,7 @@ func (g *GlobalProgress) InitializeSources(sourceNames []string) {
 	g.sourceNames = sourceNames
 	g.sourceStatus = make(map[string]Status)
 	g.sourceSpinner = make(map[string]int)
+	g.sourceArtifacts = make(map[string]int)
 
 	// Inicializar todos como pending
 	for _, name := range sourceNames {
@@ -130,6 +136,14 @@ func (g *GlobalProgress) UpdateSourceStatus(sourceName string, status Status) {
 	g.mu.Unlock()
 }
 
+// UpdateSourceArtifacts actualiza el contador en vivo de un source
+func (g *GlobalProgress) UpdateSourceArtifacts(sourceName string, count int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.sourceArtifacts[sourceName] = count
+}
+
 // UpdateArtifactCount actualiza el contador total de artifacts
 func (g *GlobalProgress) UpdateArtifactCount(count int) {
 	g.mu.Lock()
@@ -140,6 +154,14 @@ func (g *GlobalProgress) UpdateArtifactCount(count int) {
 	g.lastUpdateTime = time.Now()
 }
 
+// UpdateMemory actualiza las métricas de memoria mostradas en la línea de progreso
+func (g *GlobalProgress) UpdateMemory(stats MemoryStats) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.memory = &stats
+}
+
 // IncrementCompleted incrementa el contador de sources completados
 func (g *GlobalProgress) IncrementCompleted() {
 	g.mu.Lock()
@@ -175,7 +197,7 @@ func (g *GlobalProgress) startSpinner() {
 
 					// Avanzar frames de spinners por source (solo los que están running)
 					for name, status := range g.sourceStatus {
-						if status == StatusRunning {
+						if status == StatusRunning || status == StatusRetrying {
 							g.sourceSpinner[name] = (g.sourceSpinner[name] + 1) % len(g.spinnerFrames)
 						}
 					}
@@ -304,12 +326,18 @@ func (g *GlobalProgress) renderUnsafe() {
 		}
 	}
 
+	// Memoria: heap del proceso y artifacts retenidos frente al presupuesto
+	memoryText := ""
+	if g.memory != nil {
+		memoryText = terminal.Colorize(" • "+formatMemoryStats(*g.memory), terminal.Gray)
+	}
+
 	// Construir dashboard de sources (a la derecha)
 	sourceDashboard := g.buildSourceDashboard()
 
 	// Construir línea de progreso mejorada con dashboard de sources
 	// Formato: ⠋ [████████░░] 75% | (2/3)%s%s%s | 342ms | [httpx ⠋] [rdap ✓] [crtsh ✖]
-	line := fmt.Sprintf("  %s %s %3s | %s%s%s%s | %s%s",
+	line := fmt.Sprintf("  %s %s %3s | %s%s%s%s%s | %s%s",
 		terminal.Colorize(spinnerSymbol, spinnerColor),
 		terminal.Colorize("[", terminal.Gray)+terminal.Colorize(bar, barColor)+terminal.Colorize("]", terminal.Gray),
 		terminal.Colorize(fmt.Sprintf("%d%%", percentage), barColor),
@@ -317,6 +345,7 @@ func (g *GlobalProgress) renderUnsafe() {
 		slowIndicator,
 		etaText,
 		artifactText,
+		memoryText,
 		terminal.Colorize(formatDuration(elapsed), terminal.Gray),
 		sourceDashboard,
 	)
@@ -327,7 +356,7 @@ func (g *GlobalProgress) renderUnsafe() {
 }
 
 // buildSourceDashboard construye el mini-dashboard de sources
-// Formato: | [httpx ⠋] [rdap ✓] [crtsh ✖]
+// Formato: | [httpx ⠋ 42] [rdap ✓ 3] [crtsh ✖] [shodan ⊘]
 func (g *GlobalProgress) buildSourceDashboard() string {
 	if len(g.sourceNames) == 0 {
 		return ""
@@ -356,6 +385,17 @@ func (g *GlobalProgress) buildSourceDashboard() string {
 		case StatusWarning:
 			symbol = "⚠"
 			color = terminal.BrightYellow
+		case StatusRetrying:
+			// Spinner en amarillo mientras espera el siguiente intento
+			frame := g.sourceSpinner[name]
+			symbol = StatusRetrying.Symbol() + g.spinnerFrames[frame]
+			color = terminal.Yellow
+		case StatusCircuitOpen:
+			symbol = StatusCircuitOpen.Symbol()
+			color = terminal.BrightRed
+		case StatusSkipped:
+			symbol = StatusSkipped.Symbol()
+			color = terminal.Gray
 		case StatusPending:
 			symbol = "○" // Círculo vacío para pending
 			color = terminal.Gray
@@ -364,11 +404,18 @@ func (g *GlobalProgress) buildSourceDashboard() string {
 			color = terminal.Gray
 		}
 
-		// Construir parte: [name symbol]
+		// Construir parte: [name symbol] o [name symbol count] con artifacts
 		part := fmt.Sprintf("[%s %s]",
 			terminal.Colorize(name, terminal.White),
 			terminal.Colorize(symbol, color),
 		)
+		if count := g.sourceArtifacts[name]; count > 0 {
+			part = fmt.Sprintf("[%s %s %s]",
+				terminal.Colorize(name, terminal.White),
+				terminal.Colorize(symbol, color),
+				terminal.Colorize(fmt.Sprintf("%d", count), terminal.Gray),
+			)
+		}
 		parts = append(parts, part)
 	}
 
@@ -422,3 +469,19 @@ func (g *GlobalProgress) calculateVelocity() int {
 	velocity := float64(g.totalArtifacts) / elapsed.Seconds()
 	return int(velocity)
 }
+
+// formatMemoryStats formatea las métricas de memoria de la línea de progreso
+// Formato: mem 182.4 MB, held 40.2 MB/512.0 MB (2 spills)
+func formatMemoryStats(stats MemoryStats) string {
+	text := "mem " + FormatMemory(stats.HeapMB)
+	if stats.HeldBytes > 0 || stats.BudgetBytes > 0 {
+		text += ", held " + FormatMemory(float64(stats.HeldBytes)/1024/1024)
+		if stats.BudgetBytes > 0 {
+			text += "/" + FormatMemory(float64(stats.BudgetBytes)/1024/1024)
+		}
+	}
+	if stats.Spills > 0 {
+		text += fmt.Sprintf(" (%d spills)", stats.Spills)
+	}
+	return text
+}
@@ -2,9 +2,12 @@
 package ui
 
 import (
+	"strings"
 	"sync"
 	"testing"
 	"time"
+
+	"aethonx/internal/platform/ui/terminal"
 )
 
 func TestGlobalProgress_Start(t *testing.T) {
@@ -198,6 +201,36 @@ func TestGlobalProgress_ClearResetsLineRendered(t *testing.T) {
 	}
 }
 
+func TestGlobalProgress_SourceDashboard(t *testing.T) {
+	gp := NewGlobalProgress()
+	gp.InitializeSources([]string{"crtsh", "shodan", "httpx"})
+
+	gp.mu.Lock()
+	gp.sourceStatus["crtsh"] = StatusRunning
+	gp.sourceStatus["shodan"] = StatusCircuitOpen
+	gp.sourceStatus["httpx"] = StatusRetrying
+	gp.mu.Unlock()
+	gp.UpdateSourceArtifacts("crtsh", 42)
+
+	dashboard := terminal.StripANSI(gp.buildSourceDashboard())
+
+	if !strings.Contains(dashboard, "42]") {
+		t.Errorf("Expected live artifact count for crtsh, got %q", dashboard)
+	}
+	if !strings.Contains(dashboard, "[shodan "+StatusCircuitOpen.Symbol()+"]") {
+		t.Errorf("Expected circuit-open symbol for shodan, got %q", dashboard)
+	}
+	if !strings.Contains(dashboard, "[httpx "+StatusRetrying.Symbol()) {
+		t.Errorf("Expected retrying symbol for httpx, got %q", dashboard)
+	}
+
+	// InitializeSources (nuevo stage) reinicia los contadores
+	gp.InitializeSources([]string{"crtsh"})
+	if strings.Contains(terminal.StripANSI(gp.buildSourceDashboard()), "42") {
+		t.Error("Expected counters reset for a new stage")
+	}
+}
+
 func TestFormatDuration(t *testing.T) {
 	tests := []struct {
 		duration time.Duration
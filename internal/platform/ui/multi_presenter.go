@@ -0,0 +1,124 @@
+// internal/platform/ui/multi_presenter.go
+package ui
+
+import (
+	"errors"
+	"time"
+)
+
+// MultiPresenter reenvía cada llamada a varios presenters en orden
+// (e.g., UI visual en stdout + eventos JSON en stderr).
+type MultiPresenter struct {
+	presenters []Presenter
+}
+
+// NewMultiPresenter combina presenters; los nil se ignoran.
+func NewMultiPresenter(presenters ...Presenter) *MultiPresenter {
+	out := make([]Presenter, 0, len(presenters))
+	for _, p := range presenters {
+		if p != nil {
+			out = append(out, p)
+		}
+	}
+	return &MultiPresenter{presenters: out}
+}
+
+func (m *MultiPresenter) Start(info ScanInfo) {
+	for _, p := range m.presenters {
+		p.Start(info)
+	}
+}
+
+func (m *MultiPresenter) StartStage(stage StageInfo) {
+	for _, p := range m.presenters {
+		p.StartStage(stage)
+	}
+}
+
+func (m *MultiPresenter) FinishStage(stageNum int, duration time.Duration) {
+	for _, p := range m.presenters {
+		p.FinishStage(stageNum, duration)
+	}
+}
+
+func (m *MultiPresenter) StartSource(stageNum int, sourceName string) {
+	for _, p := range m.presenters {
+		p.StartSource(stageNum, sourceName)
+	}
+}
+
+func (m *MultiPresenter) UpdateSourceStatus(sourceName string, status Status, detail string) {
+	for _, p := range m.presenters {
+		p.UpdateSourceStatus(sourceName, status, detail)
+	}
+}
+
+func (m *MultiPresenter) UpdateSource(sourceName string, metrics ProgressMetrics) {
+	for _, p := range m.presenters {
+		p.UpdateSource(sourceName, metrics)
+	}
+}
+
+func (m *MultiPresenter) UpdateSourcePhase(sourceName string, phase string) {
+	for _, p := range m.presenters {
+		p.UpdateSourcePhase(sourceName, phase)
+	}
+}
+
+func (m *MultiPresenter) FinishSource(sourceName string, status Status, duration time.Duration, artifactCount int, summary *SourceSummary) {
+	for _, p := range m.presenters {
+		p.FinishSource(sourceName, status, duration, artifactCount, summary)
+	}
+}
+
+func (m *MultiPresenter) UpdateDiscoveries(discoveries DiscoveryStats) {
+	for _, p := range m.presenters {
+		p.UpdateDiscoveries(discoveries)
+	}
+}
+
+func (m *MultiPresenter) UpdateMemory(stats MemoryStats) {
+	for _, p := range m.presenters {
+		p.UpdateMemory(stats)
+	}
+}
+
+func (m *MultiPresenter) Info(msg string) {
+	for _, p := range m.presenters {
+		p.Info(msg)
+	}
+}
+
+func (m *MultiPresenter) Warning(msg string) {
+	for _, p := range m.presenters {
+		p.Warning(msg)
+	}
+}
+
+func (m *MultiPresenter) Error(msg string) {
+	for _, p := range m.presenters {
+		p.Error(msg)
+	}
+}
+
+func (m *MultiPresenter) Finish(stats ScanStats) {
+	for _, p := range m.presenters {
+		p.Finish(stats)
+	}
+}
+
+// Close cierra todos los presenters y agrega los errores
+func (m *MultiPresenter) Close() error {
+	var errs []error
+	for _, p := range m.presenters {
+		if err := p.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NewNopPresenter retorna un presenter que descarta todo (modo --quiet).
+func NewNopPresenter() Presenter {
+	return NewMultiPresenter()
+}
@@ -28,6 +28,10 @@ type Presenter interface {
 	// StartSource notifica el inicio de ejecución de un source
 	StartSource(stageNum int, sourceName string)
 
+	// UpdateSourceStatus cambia el estado no terminal de un source en curso
+	// (StatusRunning, StatusRetrying, StatusCircuitOpen); detail es opcional
+	UpdateSourceStatus(sourceName string, status Status, detail string)
+
 	// UpdateSource actualiza el progreso de un source con métricas completas
 	UpdateSource(sourceName string, metrics ProgressMetrics)
 
@@ -40,6 +44,9 @@ type Presenter interface {
 	// UpdateDiscoveries actualiza contadores de artifacts por tipo en tiempo real
 	UpdateDiscoveries(discoveries DiscoveryStats)
 
+	// UpdateMemory actualiza las métricas de memoria (solo con ShowMetrics)
+	UpdateMemory(stats MemoryStats)
+
 	// Info muestra un mensaje informativo
 	Info(msg string)
 
@@ -63,6 +70,7 @@ type ScanInfo struct {
 	Workers        int
 	TimeoutSeconds int
 	StreamingOn    bool
+	MemoryBudget   int64 // Presupuesto de memoria del streaming en bytes (0 = sin presupuesto)
 	TotalStages    int
 	UIMode         UIMode
 	ShowMetrics    bool
@@ -91,7 +99,8 @@ type ScanStats struct {
 // DiscoveryStats contiene estadísticas de descubrimiento en tiempo real
 type DiscoveryStats struct {
 	Subdomains int
-	IPs        int
+	IPs        int // IPv4 only
+	IPv6       int
 	URLs       int
 	Emails     int
 	Ports      int
@@ -99,6 +108,14 @@ type DiscoveryStats struct {
 	Unique     int
 }
 
+// MemoryStats contiene métricas de memoria en tiempo real
+type MemoryStats struct {
+	HeapMB      float64 // Heap en uso por el proceso (runtime)
+	HeldBytes   int64   // Bytes aproximados de artifacts retenidos en memoria
+	BudgetBytes int64   // Presupuesto de memoria del streaming (0 = sin presupuesto)
+	Spills      int     // Escrituras a disco provocadas por el presupuesto
+}
+
 // SourceProgress representa el progreso de un source específico
 type SourceProgress struct {
 	Name          string
@@ -133,6 +133,23 @@ func (r *RawPresenter) StartSource(stageNum int, sourceName string) {
 	})
 }
 
+// UpdateSourceStatus notifica un cambio de estado de un source en curso
+func (r *RawPresenter) UpdateSourceStatus(sourceName string, status Status, detail string) {
+	fields := map[string]interface{}{
+		"source": sourceName,
+		"status": status.String(),
+	}
+	if detail != "" {
+		fields["detail"] = detail
+	}
+
+	level := "INFO"
+	if status == StatusRetrying || status == StatusCircuitOpen {
+		level = "WARN"
+	}
+	r.log(level, "source_status", fields)
+}
+
 // UpdateSource actualiza el progreso de un source
 func (r *RawPresenter) UpdateSource(sourceName string, metrics ProgressMetrics) {
 	fields := map[string]interface{}{
@@ -186,6 +203,7 @@ func (r *RawPresenter) UpdateDiscoveries(discoveries DiscoveryStats) {
 	r.log("INFO", "discoveries_update", map[string]interface{}{
 		"subdomains": discoveries.Subdomains,
 		"ips":        discoveries.IPs,
+		"ipv6":       discoveries.IPv6,
 		"urls":       discoveries.URLs,
 		"emails":     discoveries.Emails,
 		"ports":      discoveries.Ports,
@@ -194,6 +212,16 @@ func (r *RawPresenter) UpdateDiscoveries(discoveries DiscoveryStats) {
 	})
 }
 
+// UpdateMemory registra las métricas de memoria
+func (r *RawPresenter) UpdateMemory(stats MemoryStats) {
+	r.log("INFO", "memory_update", map[string]interface{}{
+		"heap_mb":      fmt.Sprintf("%.1f", stats.HeapMB),
+		"held_bytes":   stats.HeldBytes,
+		"budget_bytes": stats.BudgetBytes,
+		"spills":       stats.Spills,
+	})
+}
+
 // Info muestra un mensaje informativo
 func (r *RawPresenter) Info(msg string) {
 	r.log("INFO", msg, nil)
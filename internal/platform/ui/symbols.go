@@ -11,6 +11,8 @@ const (
 	StatusWarning
 	StatusError
 	StatusSkipped
+	StatusRetrying    // Reintentando tras un fallo (no terminal)
+	StatusCircuitOpen // Circuit breaker abierto: la source no se ejecuta
 )
 
 // String convierte el status a string
@@ -28,6 +30,10 @@ func (s Status) String() string {
 		return "error"
 	case StatusSkipped:
 		return "skipped"
+	case StatusRetrying:
+		return "retrying"
+	case StatusCircuitOpen:
+		return "circuit_open"
 	default:
 		return "unknown"
 	}
@@ -48,6 +54,10 @@ func (s Status) Symbol() string {
 		return "✖" // Cruz de muerte
 	case StatusSkipped:
 		return "〰" // Río Aqueronte (omitido)
+	case StatusRetrying:
+		return "↻" // Vuelta al tormento (reintento)
+	case StatusCircuitOpen:
+		return "⊘" // Puerta sellada (circuit breaker)
 	default:
 		return "?"
 	}
@@ -68,6 +78,10 @@ func (s Status) Color() string {
 		return "\033[91m" // Bright Red
 	case StatusSkipped:
 		return "\033[90m" // Gray
+	case StatusRetrying:
+		return "\033[33m" // Yellow
+	case StatusCircuitOpen:
+		return "\033[31m" // Red
 	default:
 		return "\033[97m" // Bright White
 	}
@@ -82,6 +82,11 @@ type FilterConfig struct {
 	MaxURLs             int  // Hard limit on URLs to process (0 = no limit)
 	EnableVolumeControl bool // Apply volume limits
 
+	// Pre-filters (applied before normalization, see preFilter)
+	DropExtensions       []string // Static asset extensions to drop (".png", "css")
+	DedupeParamSignature bool     // One URL per host + path + parameter names
+	DedupeQueryValues    bool     // Collapse URLs differing only in dynamic values
+
 	// Normalization
 	NormStrategy NormalizationStrategy // Normalization strategy to use
 
@@ -115,6 +120,10 @@ func DefaultConfig() FilterConfig {
 		MaxURLs:             100000,
 		EnableVolumeControl: true,
 
+		// Pre-filters
+		DropExtensions:    DefaultDropExtensions,
+		DedupeQueryValues: true,
+
 		// Normalization
 		NormStrategy: NormAggressive,
 
@@ -218,6 +227,11 @@ type FilterStats struct {
 	OutOfScopeSkipped   int `json:"out_of_scope_skipped"`
 	InvalidURLsSkipped  int `json:"invalid_urls_skipped"`
 
+	// Pre-filters
+	StaticAssetsDropped  int `json:"static_assets_dropped"`
+	SignatureCollapsed   int `json:"signature_collapsed"`
+	QueryValuesCollapsed int `json:"query_values_collapsed"`
+
 	// Clustering
 	ClustersMerged int `json:"clusters_merged"`
 	PatternsFound  int `json:"patterns_found"`
@@ -253,10 +267,11 @@ func (s FilterStats) ThroughputURLsPerSecond() float64 {
 // String returns human-readable statistics.
 func (s FilterStats) String() string {
 	return fmt.Sprintf(
-		"FilterStats{input: %d, output: %d, reduction: %.1f%%, duplicates: %d, low_priority: %d, clusters: %d, patterns: %d, duration: %v, throughput: %.0f urls/s}",
+		"FilterStats{input: %d, output: %d, reduction: %.1f%%, prefiltered: %d, duplicates: %d, low_priority: %d, clusters: %d, patterns: %d, duration: %v, throughput: %.0f urls/s}",
 		s.InputURLs,
 		s.OutputURLs,
 		s.ReductionRatio(),
+		s.PreFilterCollapsed(),
 		s.DuplicatesSkipped,
 		s.LowPrioritySkipped,
 		s.ClustersMerged,
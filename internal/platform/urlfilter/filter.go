@@ -82,7 +82,16 @@ func (f *FilterEngine) Filter(ctx context.Context, urls []string) ([]ScoredURL,
 		stats.InputURLs = len(urls)
 	}
 
-	// Step 2: Normalize URLs and deduplicate using Bloom filter
+	// Step 2: Pre-filters (static assets, parameter signature, dynamic values)
+	urls = f.preFilter(ctx, urls, &stats)
+	f.logger.Debug("pre-filtered URLs",
+		"static_assets", stats.StaticAssetsDropped,
+		"signature_collapsed", stats.SignatureCollapsed,
+		"values_collapsed", stats.QueryValuesCollapsed,
+		"output", len(urls),
+	)
+
+	// Step 3: Normalize URLs and deduplicate using Bloom filter
 	normalized := f.normalizeAndDeduplicate(ctx, urls, &stats)
 	if len(normalized) == 0 {
 		return nil, stats, fmt.Errorf("no valid URLs after normalization")
@@ -94,11 +103,11 @@ func (f *FilterEngine) Filter(ctx context.Context, urls []string) ([]ScoredURL,
 		"duplicates", stats.DuplicatesSkipped,
 	)
 
-	// Step 3: Score URLs by priority
+	// Step 4: Score URLs by priority
 	scored := f.scoreURLs(ctx, normalized, &stats)
 	f.logger.Debug("scored URLs", "count", len(scored))
 
-	// Step 4: Apply clustering (if enabled)
+	// Step 5: Apply clustering (if enabled)
 	if f.config.EnableClustering {
 		scored = f.clusterURLs(ctx, scored, &stats)
 		f.logger.Debug("clustered URLs",
@@ -108,7 +117,7 @@ func (f *FilterEngine) Filter(ctx context.Context, urls []string) ([]ScoredURL,
 		)
 	}
 
-	// Step 5: Apply pattern-based filtering (if enabled)
+	// Step 6: Apply pattern-based filtering (if enabled)
 	if f.config.EnablePatternFilter && f.config.MaxPerPattern > 0 {
 		scored = f.filterByPattern(ctx, scored, &stats)
 		f.logger.Debug("filtered by pattern",
@@ -117,7 +126,7 @@ func (f *FilterEngine) Filter(ctx context.Context, urls []string) ([]ScoredURL,
 		)
 	}
 
-	// Step 6: Filter by minimum priority score
+	// Step 7: Filter by minimum priority score
 	scored = f.filterByScore(scored, &stats)
 	f.logger.Debug("filtered by score",
 		"min_score", f.config.MinPriorityScore,
@@ -0,0 +1,126 @@
+package urlfilter
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultDropExtensions are static asset extensions dropped before
+// verification: they never reveal endpoints. Scripts, documents and
+// archives are kept (JS analysis, sensitive/backup files).
+var DefaultDropExtensions = []string{
+	".jpg", ".jpeg", ".png", ".gif", ".svg", ".webp", ".ico", ".bmp", ".tif", ".tiff",
+	".css", ".scss", ".sass", ".less",
+	".woff", ".woff2", ".ttf", ".eot", ".otf",
+	".mp4", ".webm", ".ogg", ".avi", ".mov", ".wmv", ".flv", ".mp3", ".wav", ".flac",
+}
+
+// dynamicValuePattern matches query values that only identify an instance
+// (numeric IDs, UUIDs, hashes, timestamps), collapsed by DedupeQueryValues.
+var dynamicValuePattern = regexp.MustCompile(`^(\d+|[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{12}|[a-fA-F0-9]{32,64})$`)
+
+// preFilter applies the cheap pre-filters before normalization:
+//
+//  1. drop URLs whose path ends in one of DropExtensions
+//  2. DedupeParamSignature: keep one URL per host + path + sorted
+//     parameter names (values ignored)
+//  3. DedupeQueryValues: URLs differing only in dynamic query values
+//     (IDs, UUIDs, hashes) collapse into one
+//
+// Unparseable URLs pass through; normalization accounts for them.
+func (f *FilterEngine) preFilter(ctx context.Context, urls []string, stats *FilterStats) []string {
+	drop := make(map[string]bool, len(f.config.DropExtensions))
+	for _, ext := range f.config.DropExtensions {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext != "" && !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		drop[ext] = true
+	}
+	if len(drop) == 0 && !f.config.DedupeParamSignature && !f.config.DedupeQueryValues {
+		return urls
+	}
+
+	seen := make(map[string]bool, len(urls))
+	kept := make([]string, 0, len(urls))
+
+	for _, rawURL := range urls {
+		select {
+		case <-ctx.Done():
+			f.logger.Warn("pre-filtering cancelled", "processed", len(kept))
+			return kept
+		default:
+		}
+
+		parsed, err := url.Parse(strings.TrimSpace(rawURL))
+		if err != nil || parsed.Host == "" {
+			kept = append(kept, rawURL)
+			continue
+		}
+
+		if drop[strings.ToLower(path.Ext(parsed.Path))] {
+			stats.StaticAssetsDropped++
+			continue
+		}
+
+		if parsed.RawQuery == "" {
+			kept = append(kept, rawURL)
+			continue
+		}
+
+		if f.config.DedupeParamSignature {
+			key := querySignature(parsed, false)
+			if seen[key] {
+				stats.SignatureCollapsed++
+				continue
+			}
+			seen[key] = true
+		} else if f.config.DedupeQueryValues {
+			key := querySignature(parsed, true)
+			if seen[key] {
+				stats.QueryValuesCollapsed++
+				continue
+			}
+			seen[key] = true
+		}
+
+		kept = append(kept, rawURL)
+	}
+
+	return kept
+}
+
+// querySignature keys a URL by host, path and sorted parameter names. With
+// keepValues, parameter values are kept except dynamic ones, replaced by a
+// placeholder.
+func querySignature(parsed *url.URL, keepValues bool) string {
+	query := parsed.Query()
+	params := make([]string, 0, len(query))
+	for name, values := range query {
+		if !keepValues {
+			params = append(params, name)
+			continue
+		}
+		normalized := make([]string, len(values))
+		for i, v := range values {
+			if dynamicValuePattern.MatchString(v) {
+				v = "{dyn}"
+			}
+			normalized[i] = v
+		}
+		sort.Strings(normalized)
+		params = append(params, name+"="+strings.Join(normalized, ","))
+	}
+	sort.Strings(params)
+
+	return strings.ToLower(parsed.Host) + parsed.EscapedPath() + "?" + strings.Join(params, "&")
+}
+
+// PreFilterCollapsed returns how many URLs the pre-filters removed.
+func (s FilterStats) PreFilterCollapsed() int {
+	return s.StaticAssetsDropped + s.SignatureCollapsed + s.QueryValuesCollapsed
+}
@@ -0,0 +1,90 @@
+package urlfilter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"aethonx/internal/platform/logx"
+)
+
+func TestFilterEngine_PreFilter(t *testing.T) {
+	urls := []string{
+		"https://example.com/logo.PNG",
+		"https://example.com/fonts/a.woff2",
+		"https://example.com/app.js",
+		"https://example.com/item?id=1&lang=en",
+		"https://example.com/item?id=2&lang=en",
+		"https://example.com/item?lang=fr&id=3",
+		"https://example.com/item?id=550e8400-e29b-41d4-a716-446655440000&lang=en",
+		"https://example.com/search?q=admin",
+		"https://example.com/search?q=login",
+	}
+
+	tests := []struct {
+		name       string
+		configure  func(*FilterConfig)
+		expected   []string
+		static     int
+		signature  int
+		valuesColl int
+	}{
+		{
+			name:      "defaults drop assets and dynamic values",
+			configure: func(*FilterConfig) {},
+			expected: []string{
+				"https://example.com/app.js",
+				"https://example.com/item?id=1&lang=en",
+				"https://example.com/item?lang=fr&id=3",
+				"https://example.com/search?q=admin",
+				"https://example.com/search?q=login",
+			},
+			static:     2,
+			valuesColl: 2,
+		},
+		{
+			name: "parameter signature",
+			configure: func(c *FilterConfig) {
+				c.DropExtensions = []string{"png"}
+				c.DedupeParamSignature = true
+			},
+			expected: []string{
+				"https://example.com/fonts/a.woff2",
+				"https://example.com/app.js",
+				"https://example.com/item?id=1&lang=en",
+				"https://example.com/search?q=admin",
+			},
+			static:    1,
+			signature: 4,
+		},
+		{
+			name: "disabled",
+			configure: func(c *FilterConfig) {
+				c.DropExtensions = nil
+				c.DedupeQueryValues = false
+			},
+			expected: urls,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.configure(&cfg)
+			engine := NewFilterEngine(cfg, logx.New())
+
+			var stats FilterStats
+			got := engine.preFilter(context.Background(), urls, &stats)
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("preFilter() = %v, expected %v", got, tt.expected)
+			}
+			if stats.StaticAssetsDropped != tt.static || stats.SignatureCollapsed != tt.signature || stats.QueryValuesCollapsed != tt.valuesColl {
+				t.Errorf("unexpected stats: static=%d signature=%d values=%d", stats.StaticAssetsDropped, stats.SignatureCollapsed, stats.QueryValuesCollapsed)
+			}
+			if stats.PreFilterCollapsed() != len(urls)-len(got) {
+				t.Errorf("PreFilterCollapsed() = %d, expected %d", stats.PreFilterCollapsed(), len(urls)-len(got))
+			}
+		})
+	}
+}
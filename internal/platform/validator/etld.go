@@ -0,0 +1,34 @@
+// internal/platform/validator/etld.go
+package validator
+
+import (
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// RegistrableDomain retorna el eTLD+1 de un host según la Public Suffix List
+// (p.ej. "api.example.co.uk" -> "example.co.uk"). Si no puede calcularse
+// (host vacío, IP, sufijo público) retorna "".
+func RegistrableDomain(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+	if host == "" || IsIP(host) {
+		return ""
+	}
+	if IsIDN(host) {
+		if ascii, err := ToASCIIDomain(host); err == nil {
+			host = ascii
+		}
+	}
+	etld1, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return ""
+	}
+	return etld1
+}
+
+// SameRegistrableDomain indica si dos hosts comparten eTLD+1.
+func SameRegistrableDomain(a, b string) bool {
+	ra := RegistrableDomain(a)
+	return ra != "" && ra == RegistrableDomain(b)
+}
@@ -0,0 +1,30 @@
+// internal/platform/validator/etld_test.go
+package validator
+
+import (
+	"testing"
+
+	"aethonx/internal/testutil"
+)
+
+func TestRegistrableDomain(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"example.com", "example.com"},
+		{"API.Example.com.", "example.com"},
+		{"a.b.example.co.uk", "example.co.uk"},
+		{"shop.bücher.example", "xn--bcher-kva.example"},
+		{"co.uk", ""},
+		{"93.184.216.34", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		testutil.AssertEqual(t, RegistrableDomain(tt.host), tt.want, "eTLD+1 of "+tt.host)
+	}
+
+	testutil.AssertTrue(t, SameRegistrableDomain("cdn.example.com", "example.com"), "subdomain shares eTLD+1 with apex")
+	testutil.AssertFalse(t, SameRegistrableDomain("example.com.evil.net", "example.com"), "suffix trick must not match")
+}
@@ -0,0 +1,48 @@
+// internal/platform/validator/idn.go
+package validator
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// IsIDN indica si un dominio es internacionalizado: contiene caracteres no
+// ASCII (forma unicode) o alguna etiqueta punycode ("xn--").
+func IsIDN(domain string) bool {
+	if !isASCII(domain) {
+		return true
+	}
+	for _, label := range strings.Split(strings.ToLower(domain), ".") {
+		if strings.HasPrefix(label, "xn--") {
+			return true
+		}
+	}
+	return false
+}
+
+// ToASCIIDomain convierte un dominio (unicode o punycode) a su forma ASCII
+// (punycode) según IDNA2008. Es la forma canónica y la usada en consultas DNS/HTTP.
+func ToASCIIDomain(domain string) (string, error) {
+	return idna.Lookup.ToASCII(domain)
+}
+
+// ToUnicodeDomain convierte un dominio a su forma unicode legible.
+// Si la conversión falla retorna el dominio sin cambios.
+func ToUnicodeDomain(domain string) string {
+	u, err := idna.Display.ToUnicode(domain)
+	if err != nil {
+		return domain
+	}
+	return u
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,31 @@
+// internal/platform/validator/idn_test.go
+package validator
+
+import (
+	"testing"
+
+	"aethonx/internal/testutil"
+)
+
+func TestIsIDN(t *testing.T) {
+	testutil.AssertTrue(t, IsIDN("münchen.de"), "unicode domain")
+	testutil.AssertTrue(t, IsIDN("xn--mnchen-3ya.de"), "punycode domain")
+	testutil.AssertFalse(t, IsIDN("example.com"), "ascii domain")
+}
+
+func TestNormalizeDomain_IDN(t *testing.T) {
+	testutil.AssertEqual(t, NormalizeDomain("MÜNCHEN.de."), "xn--mnchen-3ya.de", "unicode to punycode")
+	testutil.AssertEqual(t, NormalizeDomain("xn--mnchen-3ya.de"), "xn--mnchen-3ya.de", "punycode unchanged")
+	testutil.AssertEqual(t, ToUnicodeDomain("xn--mnchen-3ya.de"), "münchen.de", "punycode to unicode")
+}
+
+func TestIsDomain_IDN(t *testing.T) {
+	testutil.AssertTrue(t, IsDomain("münchen.de"), "unicode domain is valid")
+	testutil.AssertTrue(t, IsDomain("xn--mnchen-3ya.de"), "punycode domain is valid")
+	testutil.AssertFalse(t, IsDomain("xn--a.de"), "malformed punycode label")
+}
+
+func TestNormalizeURL_IDNHost(t *testing.T) {
+	got := NormalizeURL("https://Bücher.example:8443/Path")
+	testutil.AssertEqual(t, got, "https://xn--bcher-kva.example:8443/Path", "host converted to punycode")
+}
@@ -2,6 +2,7 @@
 package validator
 
 import (
+	"fmt"
 	"net"
 	"net/url"
 	"regexp"
@@ -14,6 +15,15 @@ import (
 // IsDomain verifica si un string es un dominio válido.
 // Soporta dominios internacionales (IDN) y punycode.
 func IsDomain(domain string) bool {
+	// IDN: validar la forma punycode (también valida etiquetas xn-- mal formadas)
+	if IsIDN(domain) {
+		ascii, err := ToASCIIDomain(domain)
+		if err != nil {
+			return false
+		}
+		domain = ascii
+	}
+
 	if len(domain) == 0 || len(domain) > 253 {
 		return false
 	}
@@ -45,11 +55,50 @@ func IsSubdomain(subdomain, baseDomain string) bool {
 	return strings.HasSuffix(subdomain, "."+baseDomain)
 }
 
-// NormalizeDomain normaliza un dominio a su forma canónica.
+// NormalizationPolicy controla cuánto se reescribe un dominio al normalizarlo.
+type NormalizationPolicy string
+
+const (
+	// NormalizationStrict solo aplica cambios sin pérdida (minúsculas, punto final).
+	// www.example.com y example.com son activos distintos (default).
+	NormalizationStrict NormalizationPolicy = "strict"
+
+	// NormalizationAggressive además elimina el prefijo "www.", colapsando
+	// www.example.com en example.com.
+	NormalizationAggressive NormalizationPolicy = "aggressive"
+)
+
+// ParseNormalizationPolicy convierte un string en NormalizationPolicy.
+// Un string vacío equivale a NormalizationStrict.
+func ParseNormalizationPolicy(s string) (NormalizationPolicy, error) {
+	switch NormalizationPolicy(strings.ToLower(strings.TrimSpace(s))) {
+	case "", NormalizationStrict:
+		return NormalizationStrict, nil
+	case NormalizationAggressive:
+		return NormalizationAggressive, nil
+	default:
+		return NormalizationStrict, fmt.Errorf("unknown normalization policy %q (use strict or aggressive)", s)
+	}
+}
+
+// NormalizeDomain normaliza un dominio a su forma canónica (política strict).
 func NormalizeDomain(domain string) string {
+	return NormalizeDomainWithPolicy(domain, NormalizationStrict)
+}
+
+// NormalizeDomainWithPolicy normaliza un dominio aplicando la política indicada.
+func NormalizeDomainWithPolicy(domain string, policy NormalizationPolicy) string {
 	domain = strings.ToLower(strings.TrimSpace(domain))
 	domain = strings.TrimSuffix(domain, ".")
-	domain = strings.TrimPrefix(domain, "www.")
+	// IDN: la forma canónica es punycode (la unicode se deriva con ToUnicodeDomain)
+	if IsIDN(domain) {
+		if ascii, err := ToASCIIDomain(domain); err == nil {
+			domain = ascii
+		}
+	}
+	if policy == NormalizationAggressive {
+		domain = strings.TrimPrefix(domain, "www.")
+	}
 	return domain
 }
 
@@ -115,6 +164,39 @@ func NormalizeIP(ip string) string {
 	return parsed.String()
 }
 
+// IsASN verifica si un string es un ASN válido ("AS15133" o "15133", 32 bits).
+func IsASN(asn string) bool {
+	n, err := strconv.ParseUint(strings.TrimPrefix(NormalizeASN(asn), "AS"), 10, 32)
+	return err == nil && n > 0
+}
+
+// NormalizeASN normaliza un ASN a la forma "AS<number>".
+func NormalizeASN(asn string) string {
+	asn = strings.ToUpper(strings.TrimSpace(asn))
+	asn = strings.TrimPrefix(asn, "AS")
+	asn = strings.TrimSpace(asn)
+	if asn == "" {
+		return ""
+	}
+	return "AS" + asn
+}
+
+// IsCIDR verifica si un string es un rango de red válido (v4 o v6).
+func IsCIDR(cidr string) bool {
+	_, _, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	return err == nil
+}
+
+// NormalizeCIDR normaliza un CIDR a su dirección de red canónica
+// ("10.0.0.5/24" -> "10.0.0.0/24"). Si es inválido, retorna string vacío.
+func NormalizeCIDR(cidr string) string {
+	_, ipnet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	if err != nil {
+		return ""
+	}
+	return ipnet.String()
+}
+
 // URL validators
 
 // IsURL verifica si un string es una URL válida.
@@ -144,8 +226,13 @@ func NormalizeURL(urlStr string) string {
 	// Normalizar scheme (case-insensitive)
 	parsed.Scheme = strings.ToLower(parsed.Scheme)
 
-	// Normalizar host (case-insensitive)
+	// Normalizar host (case-insensitive, IDN a punycode)
 	parsed.Host = strings.ToLower(parsed.Host)
+	if host := parsed.Hostname(); IsIDN(host) {
+		if ascii, err := ToASCIIDomain(host); err == nil {
+			parsed.Host = strings.Replace(parsed.Host, host, ascii, 1)
+		}
+	}
 
 	// Remover puertos por defecto
 	if parsed.Scheme == "http" && strings.HasSuffix(parsed.Host, ":80") {
@@ -183,6 +270,15 @@ func NormalizeCertSerial(serial string) string {
 	return strings.ToLower(strings.TrimSpace(serial))
 }
 
+// Vulnerability validators
+
+var cveRegex = regexp.MustCompile(`^CVE-\d{4}-\d{4,}$`)
+
+// IsCVE verifica si un string es un identificador CVE válido (CVE-YYYY-NNNN+).
+func IsCVE(id string) bool {
+	return cveRegex.MatchString(strings.ToUpper(strings.TrimSpace(id)))
+}
+
 // Hash validators
 
 // IsHash verifica si un string es un hash válido (MD5, SHA1, SHA256, etc.).
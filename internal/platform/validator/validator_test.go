@@ -63,8 +63,8 @@ func TestNormalizeDomain(t *testing.T) {
 	}{
 		{"lowercase", "EXAMPLE.COM", "example.com"},
 		{"remove trailing dot", "example.com.", "example.com"},
-		{"remove www prefix", "www.example.com", "example.com"},
-		{"all together", "WWW.EXAMPLE.COM.", "example.com"},
+		{"keep www prefix", "www.example.com", "www.example.com"},
+		{"all together", "WWW.EXAMPLE.COM.", "www.example.com"},
 		{"trim spaces", "  example.com  ", "example.com"},
 	}
 
@@ -76,6 +76,27 @@ func TestNormalizeDomain(t *testing.T) {
 	}
 }
 
+func TestNormalizeDomainWithPolicy_Aggressive(t *testing.T) {
+	result := NormalizeDomainWithPolicy("WWW.EXAMPLE.COM.", NormalizationAggressive)
+	testutil.AssertEqual(t, result, "example.com", "aggressive strips www")
+
+	result = NormalizeDomainWithPolicy("www.example.com", NormalizationStrict)
+	testutil.AssertEqual(t, result, "www.example.com", "strict keeps www")
+}
+
+func TestParseNormalizationPolicy(t *testing.T) {
+	p, err := ParseNormalizationPolicy("")
+	testutil.AssertNoError(t, err, "empty policy")
+	testutil.AssertEqual(t, p, NormalizationStrict, "default policy")
+
+	p, err = ParseNormalizationPolicy("Aggressive")
+	testutil.AssertNoError(t, err, "aggressive policy")
+	testutil.AssertEqual(t, p, NormalizationAggressive, "aggressive")
+
+	_, err = ParseNormalizationPolicy("loose")
+	testutil.AssertError(t, err, "unknown policy")
+}
+
 func TestIsEmail(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -301,3 +322,46 @@ func TestMinLength(t *testing.T) {
 	testutil.AssertTrue(t, MinLength("test", 4), "should pass equal length")
 	testutil.AssertTrue(t, !MinLength("test", 5), "should fail min length")
 }
+
+func TestIsASN(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"prefixed", "AS15133", true},
+		{"lowercase prefix", "as15133", true},
+		{"bare number", "15133", true},
+		{"32-bit max", "AS4294967295", true},
+		{"zero", "AS0", false},
+		{"overflow", "AS4294967296", false},
+		{"not a number", "ASabc", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.AssertEqual(t, IsASN(tt.input), tt.expected, "asn validation")
+		})
+	}
+
+	testutil.AssertEqual(t, NormalizeASN(" as15133 "), "AS15133", "asn normalization")
+	testutil.AssertEqual(t, NormalizeASN("15133"), "AS15133", "bare asn normalization")
+}
+
+func TestIsCIDR(t *testing.T) {
+	testutil.AssertTrue(t, IsCIDR("10.0.0.0/8"), "ipv4 cidr")
+	testutil.AssertTrue(t, IsCIDR("2001:db8::/32"), "ipv6 cidr")
+	testutil.AssertFalse(t, IsCIDR("10.0.0.0"), "missing mask")
+	testutil.AssertFalse(t, IsCIDR("10.0.0.0/33"), "mask out of range")
+
+	testutil.AssertEqual(t, NormalizeCIDR("10.0.0.5/24"), "10.0.0.0/24", "cidr network address")
+	testutil.AssertEqual(t, NormalizeCIDR("bogus"), "", "invalid cidr")
+}
+
+func TestIsCVE(t *testing.T) {
+	testutil.AssertTrue(t, IsCVE("CVE-2021-44228"), "valid cve")
+	testutil.AssertTrue(t, IsCVE("cve-2021-1234567"), "lowercase, long sequence")
+	testutil.AssertFalse(t, IsCVE("CVE-21-44228"), "short year")
+	testutil.AssertFalse(t, IsCVE("GHSA-xxxx-yyyy-zzzz"), "not a cve")
+}
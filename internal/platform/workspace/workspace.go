@@ -0,0 +1,307 @@
+// internal/platform/workspace/workspace.go
+package workspace
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Layout de un workspace (~/.aethonx/workspaces/<name>/):
+//
+//	config.env   overrides AETHONX_* (KEY=VALUE), por debajo de env y flags
+//	scope.txt    dominios en scope, uno por línea; "!dominio" excluye
+//	tags.yaml    reglas de etiquetado (opcional, si no se pasa --tag-rules)
+//	scans/       directorio de salida (scans/<target>/aethonx_*.json, historial)
+//	cache/       caché persistente de sources
+//	wordlists/   wordlists propias del engagement
+const (
+	ConfigFile   = "config.env"
+	ScopeFile    = "scope.txt"
+	TagRulesFile = "tags.yaml"
+	ScansDir     = "scans"
+	CacheDir     = "cache"
+	WordlistsDir = "wordlists"
+)
+
+// signatureExt es la extensión de las firmas separadas (<scan>.json.sig),
+// que acompañan a su scan y no cuentan como scans propios.
+const signatureExt = ".sig"
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// Workspace es un proyecto con nombre que agrupa configuración, scope y scans.
+type Workspace struct {
+	Name string
+	Path string
+}
+
+// Info resume un workspace para "aethonx workspace list".
+type Info struct {
+	Name      string
+	Path      string
+	Scans     int
+	SizeBytes int64
+	Modified  time.Time
+}
+
+// BaseDir retorna el directorio raíz de workspaces: AETHONX_WORKSPACES_DIR o
+// ~/.aethonx/workspaces.
+func BaseDir() (string, error) {
+	if dir := os.Getenv("AETHONX_WORKSPACES_DIR"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".aethonx", "workspaces"), nil
+}
+
+// ValidateName verifica que el nombre sea usable como directorio.
+func ValidateName(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid workspace name %q (use letters, digits, '.', '_' or '-')", name)
+	}
+	return nil
+}
+
+// Create crea el workspace (idempotente) con su estructura y plantillas.
+func Create(base, name string) (*Workspace, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	ws := &Workspace{Name: name, Path: filepath.Join(base, name)}
+	for _, dir := range []string{ScansDir, CacheDir, WordlistsDir} {
+		if err := os.MkdirAll(filepath.Join(ws.Path, dir), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create workspace: %w", err)
+		}
+	}
+
+	templates := map[string]string{
+		ConfigFile: "# AETHONX_* overrides for this workspace (environment and flags take priority)\n" +
+			"# AETHONX_WORKERS=8\n# AETHONX_SOURCES_AMASS_ENABLED=false\n",
+		ScopeFile: "# In-scope domains, one per line. The first one is the default target.\n" +
+			"# Prefix with ! to exclude a domain and its subdomains.\n# example.com\n# !legacy.example.com\n",
+	}
+	for file, content := range templates {
+		path := filepath.Join(ws.Path, file)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+
+	return ws, nil
+}
+
+// Open abre un workspace existente.
+func Open(base, name string) (*Workspace, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	ws := &Workspace{Name: name, Path: filepath.Join(base, name)}
+	info, err := os.Stat(ws.Path)
+	if err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("workspace %q not found (create it with: aethonx workspace create %s)", name, name)
+	}
+	return ws, nil
+}
+
+// List retorna los workspaces bajo base, ordenados por nombre.
+// Un base inexistente no es un error (aún no hay workspaces).
+func List(base string) ([]Info, error) {
+	entries, err := os.ReadDir(base)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	var infos []Info
+	for _, e := range entries {
+		if !e.IsDir() || ValidateName(e.Name()) != nil {
+			continue
+		}
+		ws := &Workspace{Name: e.Name(), Path: filepath.Join(base, e.Name())}
+		info := Info{Name: ws.Name, Path: ws.Path}
+
+		scans, _ := ws.scanFiles()
+		info.Scans = len(scans)
+
+		_ = filepath.Walk(ws.Path, func(_ string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			info.SizeBytes += fi.Size()
+			if fi.ModTime().After(info.Modified) {
+				info.Modified = fi.ModTime()
+			}
+			return nil
+		})
+
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos, nil
+}
+
+// ScansDir retorna el directorio de salida de los scans del workspace.
+func (w *Workspace) ScansDir() string { return filepath.Join(w.Path, ScansDir) }
+
+// CacheDir retorna el directorio de caché del workspace.
+func (w *Workspace) CacheDir() string { return filepath.Join(w.Path, CacheDir) }
+
+// WordlistsDir retorna el directorio de wordlists del workspace.
+func (w *Workspace) WordlistsDir() string { return filepath.Join(w.Path, WordlistsDir) }
+
+// TagRulesPath retorna la ruta de tags.yaml si existe, o "".
+func (w *Workspace) TagRulesPath() string {
+	path := filepath.Join(w.Path, TagRulesFile)
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// LoadEnv lee config.env (KEY=VALUE, # comentarios). Un fichero inexistente
+// equivale a no tener overrides.
+func (w *Workspace) LoadEnv() (map[string]string, error) {
+	env := make(map[string]string)
+	err := w.readLines(ConfigFile, func(lineNo int, line string) error {
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(strings.TrimPrefix(key, "export "))
+		if !ok || key == "" {
+			return fmt.Errorf("%s:%d: expected KEY=VALUE", ConfigFile, lineNo)
+		}
+		env[key] = strings.Trim(strings.TrimSpace(value), `"'`)
+		return nil
+	})
+	return env, err
+}
+
+// Scope es el contenido de scope.txt.
+type Scope struct {
+	Include []string // dominios en scope (el primero es el target por defecto)
+	Exclude []string // dominios excluidos (con sus subdominios)
+}
+
+// LoadScope lee scope.txt. Un fichero inexistente equivale a un scope vacío.
+func (w *Workspace) LoadScope() (Scope, error) {
+	var scope Scope
+	err := w.readLines(ScopeFile, func(_ int, line string) error {
+		line = strings.ToLower(line)
+		if strings.HasPrefix(line, "!") {
+			if d := strings.TrimSpace(line[1:]); d != "" {
+				scope.Exclude = append(scope.Exclude, d)
+			}
+			return nil
+		}
+		scope.Include = append(scope.Include, line)
+		return nil
+	})
+	return scope, err
+}
+
+// CleanOptions controla qué elimina Clean.
+type CleanOptions struct {
+	KeepScans int  // scans más recientes a conservar (0 = borrar todos)
+	Cache     bool // vaciar también cache/
+}
+
+// Clean elimina los scans antiguos (y opcionalmente la caché). Retorna el
+// número de ficheros eliminados. Nunca toca config, scope ni wordlists.
+func (w *Workspace) Clean(opts CleanOptions) (int, error) {
+	scans, err := w.scanFiles()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	if opts.KeepScans < len(scans) {
+		for _, path := range scans[max(0, opts.KeepScans):] {
+			if err := os.Remove(path); err != nil {
+				return removed, fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+			removed++
+
+			// La firma separada no tiene sentido sin el scan
+			if err := os.Remove(path + signatureExt); err == nil {
+				removed++
+			}
+		}
+	}
+
+	if opts.Cache {
+		entries, err := os.ReadDir(w.CacheDir())
+		if err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		for _, e := range entries {
+			if err := os.RemoveAll(filepath.Join(w.CacheDir(), e.Name())); err != nil {
+				return removed, err
+			}
+			removed++
+		}
+	}
+
+	return removed, nil
+}
+
+// scanFiles retorna los resultados guardados (scans/<target>/aethonx_*.json),
+// más recientes primero.
+func (w *Workspace) scanFiles() ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(w.ScansDir(), "*", "aethonx_*"))
+	if err != nil {
+		return nil, err
+	}
+
+	mods := make(map[string]time.Time, len(matches))
+	files := matches[:0]
+	for _, path := range matches {
+		fi, err := os.Stat(path)
+		if err != nil || fi.IsDir() || strings.HasSuffix(path, signatureExt) {
+			continue
+		}
+		mods[path] = fi.ModTime()
+		files = append(files, path)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return mods[files[i]].After(mods[files[j]]) })
+	return files, nil
+}
+
+// readLines itera las líneas no vacías ni comentadas de un fichero del workspace.
+func (w *Workspace) readLines(name string, fn func(lineNo int, line string) error) error {
+	f, err := os.Open(filepath.Join(w.Path, name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := fn(lineNo, line); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
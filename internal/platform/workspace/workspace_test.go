@@ -0,0 +1,97 @@
+// internal/platform/workspace/workspace_test.go
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"aethonx/internal/testutil"
+)
+
+func TestCreateOpenList(t *testing.T) {
+	base := t.TempDir()
+
+	ws, err := Create(base, "acme-2026")
+	testutil.AssertNoError(t, err, "create should succeed")
+	for _, dir := range []string{ws.ScansDir(), ws.CacheDir(), ws.WordlistsDir()} {
+		info, err := os.Stat(dir)
+		testutil.AssertNoError(t, err, "layout dir should exist: "+dir)
+		testutil.AssertTrue(t, info.IsDir(), "layout entry should be a directory")
+	}
+
+	_, err = Create(base, "acme-2026")
+	testutil.AssertNoError(t, err, "create should be idempotent")
+
+	_, err = Create(base, "../escape")
+	testutil.AssertError(t, err, "path traversal names rejected")
+
+	_, err = Open(base, "missing")
+	testutil.AssertError(t, err, "opening a missing workspace fails")
+
+	infos, err := List(base)
+	testutil.AssertNoError(t, err, "list should succeed")
+	testutil.AssertEqual(t, len(infos), 1, "one workspace listed")
+	testutil.AssertEqual(t, infos[0].Name, "acme-2026", "workspace name")
+
+	infos, err = List(filepath.Join(base, "nope"))
+	testutil.AssertNoError(t, err, "missing base is not an error")
+	testutil.AssertEqual(t, len(infos), 0, "no workspaces")
+}
+
+func TestLoadEnvAndScope(t *testing.T) {
+	ws, err := Create(t.TempDir(), "acme")
+	testutil.AssertNoError(t, err, "create should succeed")
+
+	env := "# comment\nAETHONX_WORKERS=4\nexport AETHONX_SOURCES_AMASS_ENABLED=\"false\"\n"
+	testutil.AssertNoError(t, os.WriteFile(filepath.Join(ws.Path, ConfigFile), []byte(env), 0o644), "write env")
+	scope := "Acme.com\nacme.io\n!legacy.acme.com\n"
+	testutil.AssertNoError(t, os.WriteFile(filepath.Join(ws.Path, ScopeFile), []byte(scope), 0o644), "write scope")
+
+	vars, err := ws.LoadEnv()
+	testutil.AssertNoError(t, err, "env should parse")
+	testutil.AssertEqual(t, vars["AETHONX_WORKERS"], "4", "plain value")
+	testutil.AssertEqual(t, vars["AETHONX_SOURCES_AMASS_ENABLED"], "false", "export prefix and quotes stripped")
+
+	s, err := ws.LoadScope()
+	testutil.AssertNoError(t, err, "scope should parse")
+	testutil.AssertEqual(t, len(s.Include), 2, "two in-scope domains")
+	testutil.AssertEqual(t, s.Include[0], "acme.com", "lowercased")
+	testutil.AssertEqual(t, len(s.Exclude), 1, "one exclusion")
+	testutil.AssertEqual(t, s.Exclude[0], "legacy.acme.com", "exclusion without prefix")
+
+	testutil.AssertNoError(t, os.WriteFile(filepath.Join(ws.Path, ConfigFile), []byte("garbage\n"), 0o644), "write env")
+	_, err = ws.LoadEnv()
+	testutil.AssertError(t, err, "invalid line rejected")
+}
+
+func TestClean(t *testing.T) {
+	ws, err := Create(t.TempDir(), "acme")
+	testutil.AssertNoError(t, err, "create should succeed")
+
+	dir := filepath.Join(ws.ScansDir(), "acme.com")
+	testutil.AssertNoError(t, os.MkdirAll(dir, 0o755), "mkdir")
+	now := time.Now()
+	for i, name := range []string{"aethonx_acme.com_1.json", "aethonx_acme.com_2.json", "aethonx_acme.com_3.json"} {
+		path := filepath.Join(dir, name)
+		testutil.AssertNoError(t, os.WriteFile(path, []byte("{}"), 0o644), "write scan")
+		mod := now.Add(time.Duration(i) * time.Minute)
+		testutil.AssertNoError(t, os.Chtimes(path, mod, mod), "chtimes")
+	}
+	testutil.AssertNoError(t, os.WriteFile(filepath.Join(dir, "aethonx_acme.com_1.json.sig"), []byte("{}"), 0o644), "write signature")
+	testutil.AssertNoError(t, os.WriteFile(filepath.Join(dir, "lifecycle.json"), []byte("{}"), 0o644), "write state")
+	testutil.AssertNoError(t, os.WriteFile(filepath.Join(ws.CacheDir(), "entry"), []byte("x"), 0o644), "write cache")
+
+	removed, err := ws.Clean(CleanOptions{KeepScans: 1, Cache: true})
+	testutil.AssertNoError(t, err, "clean should succeed")
+	testutil.AssertEqual(t, removed, 4, "two old scans, one signature and one cache entry removed")
+
+	_, err = os.Stat(filepath.Join(dir, "aethonx_acme.com_1.json.sig"))
+	testutil.AssertTrue(t, os.IsNotExist(err), "signature removed with its scan")
+
+	_, err = os.Stat(filepath.Join(dir, "aethonx_acme.com_3.json"))
+	testutil.AssertNoError(t, err, "newest scan kept")
+	_, err = os.Stat(filepath.Join(dir, "lifecycle.json"))
+	testutil.AssertNoError(t, err, "lifecycle state untouched")
+}
@@ -8,8 +8,10 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -17,6 +19,7 @@ import (
 	"time"
 
 	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
 	"aethonx/internal/platform/logx"
 	"aethonx/internal/sources/common"
 
@@ -37,6 +40,13 @@ type AmassSource struct {
 	maxDNSQPS  int  // DNS queries per second (0 = unlimited)
 	brute      bool // Enable brute force
 	alts       bool // Enable alterations
+
+	// Installed version, detected once at Initialize (or the first Run)
+	versionMu      sync.Mutex
+	versionChecked bool
+	version        string
+	layout         resultLayout
+	versionErr     error
 }
 
 // AmassConfig contains configuration for AmassSource.
@@ -111,12 +121,17 @@ func (a *AmassSource) Run(ctx context.Context, target domain.Target) (*domain.Sc
 	result := domain.NewScanResult(target)
 	startTime := time.Now()
 
+	if err := a.detectVersion(ctx); err != nil {
+		return nil, err
+	}
+
 	a.GetLogger().Info("starting amass scan",
 		"target", target.Root,
 		"active", a.activeMode,
 		"brute", a.brute,
 		"alts", a.alts,
 		"max_dns_qps", a.maxDNSQPS,
+		"version", a.version,
 	)
 
 	// Create temporary directory for amass output
@@ -131,8 +146,9 @@ func (a *AmassSource) Run(ctx context.Context, target domain.Target) (*domain.Sc
 	// Build command arguments
 	args := a.buildCommandArgs(target, tempDir)
 
-	// Build command manually (amass needs special handling for database output)
-	cmd := exec.CommandContext(ctx, a.GetExecPath(), args...)
+	// Build command manually (amass needs special handling for database output).
+	// The temp dir is mounted when amass runs in a container.
+	cmd := a.Command(ctx, args, tempDir)
 
 	// Create stderr pipe to capture progress/warnings
 	stderr, err := cmd.StderrPipe()
@@ -189,36 +205,15 @@ func (a *AmassSource) Run(ctx context.Context, target domain.Target) (*domain.Sc
 		a.GetLogger().Debug("amass produced output", "lines", stderrCount)
 	}
 
-	// Read results from SQLite database
-	// Amass creates a subdirectory like: tempDir/db/amass.sqlite
-	// Try multiple possible paths
-	possibleDBPaths := []string{
-		fmt.Sprintf("%s/db/amass.sqlite", tempDir), // Amass v4 default
-		fmt.Sprintf("%s/amass.sqlite", tempDir),    // Direct path
-	}
-
-	var artifacts []*domain.Artifact
-	var dbErr error
-	dbFound := false
-
-	for _, dbPath := range possibleDBPaths {
-		a.GetLogger().Debug("trying database path", "path", dbPath)
-		artifacts, dbErr = a.readDatabaseResults(dbPath, target)
-		if dbErr == nil {
-			dbFound = true
-			a.GetLogger().Debug("successfully read database", "path", dbPath, "artifacts", len(artifacts))
-			break
-		}
-		a.GetLogger().Debug("database not found at path", "path", dbPath, "error", dbErr.Error())
+	artifacts, err := a.readResults(ctx, tempDir, target)
+	if err != nil {
+		return nil, err
 	}
 
-	if !dbFound {
-		// If database read fails, fall back to text file parsing
-		a.GetLogger().Warn("failed to read database from any path, trying text file", "last_error", dbErr.Error())
-		txtPath := fmt.Sprintf("%s/amass.txt", tempDir)
-		artifacts, dbErr = a.readTextResults(txtPath, target)
-		if dbErr != nil {
-			return nil, fmt.Errorf("failed to read amass results from database or text file: %w", dbErr)
+	// Archive the database or JSON output for "aethonx replay" (--capture-raw)
+	if dir := ports.CaptureDir(ctx); dir != "" {
+		if err := captureResults(tempDir, dir); err != nil {
+			a.GetLogger().Warn("amass results not captured", "error", err.Error())
 		}
 	}
 
@@ -247,6 +242,180 @@ func (a *AmassSource) Run(ctx context.Context, target domain.Target) (*domain.Sc
 	return result, nil
 }
 
+// capturedFiles are the amass outputs readResults can parse, relative to the
+// output directory.
+var capturedFiles = []string{"amass.sqlite", filepath.Join("db", "amass.sqlite"), "amass.json", "amass.txt"}
+
+// captureResults copies the outputs amass left in dir to the capture
+// directory, keeping their layout so readResults can parse them again.
+func captureResults(dir, captureDir string) error {
+	for _, name := range capturedFiles {
+		src, err := os.Open(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = copyFile(src, filepath.Join(captureDir, name))
+		src.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFile writes src to path, creating its directory.
+func copyFile(src io.Reader, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	dst, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// Replay rebuilds the result from the outputs captured by a previous run.
+// The layout follows the captured files, so the binary is not needed.
+// Implements ports.ReplayableSource.
+func (a *AmassSource) Replay(ctx context.Context, target domain.Target, dir string) (*domain.ScanResult, error) {
+	var artifacts []*domain.Artifact
+	var err error
+	if _, statErr := os.Stat(filepath.Join(dir, "amass.json")); statErr == nil {
+		artifacts, err = a.readJSONResults(filepath.Join(dir, "amass.json"), target)
+	} else {
+		artifacts, err = a.readResults(ctx, dir, target)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := domain.NewScanResult(target)
+	for _, artifact := range artifacts {
+		result.AddArtifact(artifact)
+	}
+	return result, nil
+}
+
+// readResults reads the results amass left in dir with the parser of the
+// detected version, falling back to the other formats it may have written.
+func (a *AmassSource) readResults(ctx context.Context, dir string, target domain.Target) ([]*domain.Artifact, error) {
+	if a.layout == layoutV3 {
+		artifacts, err := a.readJSONResults(filepath.Join(dir, "amass.json"), target)
+		if err == nil {
+			return artifacts, nil
+		}
+		a.GetLogger().Debug("amass json output not readable, trying text file", "error", err.Error())
+		artifacts, err = a.readTextResults(filepath.Join(dir, "amass.txt"), target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read amass v3 results from json or text file: %w", err)
+		}
+		return artifacts, nil
+	}
+
+	// Amass v4 creates the database in dir (4.2) or dir/db (earlier releases)
+	possibleDBPaths := []string{
+		filepath.Join(dir, "amass.sqlite"),
+		filepath.Join(dir, "db", "amass.sqlite"),
+	}
+
+	var dbErr error
+	for _, dbPath := range possibleDBPaths {
+		a.GetLogger().Debug("trying database path", "path", dbPath)
+		artifacts, err := a.readDatabaseResults(dbPath, target)
+		if err == nil {
+			a.GetLogger().Debug("successfully read database", "path", dbPath, "artifacts", len(artifacts))
+			return artifacts, nil
+		}
+		dbErr = err
+		a.GetLogger().Debug("database not found at path", "path", dbPath, "error", err.Error())
+	}
+
+	// Schema the reader does not know: let oam_subs (oam-tools) list the names
+	artifacts, err := a.readOAMSubs(ctx, dir, target)
+	if err == nil {
+		return artifacts, nil
+	}
+	a.GetLogger().Debug("oam_subs fallback unavailable", "error", err.Error())
+
+	a.GetLogger().Warn("failed to read database from any path, trying text file", "last_error", dbErr.Error())
+	artifacts, err = a.readTextResults(filepath.Join(dir, "amass.txt"), target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read amass results from database or text file: %w", err)
+	}
+	return artifacts, nil
+}
+
+// readJSONResults parses the JSONL output of amass v3 (-json).
+func (a *AmassSource) readJSONResults(jsonPath string, target domain.Target) ([]*domain.Artifact, error) {
+	file, err := os.Open(jsonPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open json file: %w", err)
+	}
+	defer file.Close()
+
+	parser := NewParser(a.GetLogger(), sourceName)
+	artifacts := make([]*domain.Artifact, 0, 100)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var resp AmassResponse
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			a.GetLogger().Warn("failed to parse amass json line", "error", err.Error())
+			continue
+		}
+		for _, artifact := range parser.ParseResponse(&resp, target) {
+			artifact.Confidence = a.confidence()
+			artifacts = append(artifacts, artifact)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading json file: %w", err)
+	}
+
+	return artifacts, nil
+}
+
+// readOAMSubs lists the discovered names with oam_subs when it is installed
+// (native runtime only).
+func (a *AmassSource) readOAMSubs(ctx context.Context, dir string, target domain.Target) ([]*domain.Artifact, error) {
+	if a.Runtime() == common.RuntimeDocker {
+		return nil, fmt.Errorf("oam_subs is not available in the docker runtime")
+	}
+	bin, err := exec.LookPath("oam_subs")
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := exec.CommandContext(ctx, bin, "-d", target.Root, "-dir", dir, "-names").Output()
+	if err != nil {
+		return nil, fmt.Errorf("oam_subs failed: %w", err)
+	}
+
+	txtPath := filepath.Join(dir, "oam_subs.txt")
+	if err := os.WriteFile(txtPath, output, 0o600); err != nil {
+		return nil, err
+	}
+	return a.readTextResults(txtPath, target)
+}
+
+// confidence is the confidence of amass artifacts: high when names were
+// validated with active DNS, medium for passive discovery.
+func (a *AmassSource) confidence() float64 {
+	if a.activeMode {
+		return domain.ConfidenceHigh
+	}
+	return domain.ConfidenceMedium
+}
+
 // readDatabaseResults reads and parses the SQLite database created by amass.
 func (a *AmassSource) readDatabaseResults(dbPath string, target domain.Target) ([]*domain.Artifact, error) {
 	// Check if database file exists
@@ -262,20 +431,22 @@ func (a *AmassSource) readDatabaseResults(dbPath string, target domain.Target) (
 	defer db.Close()
 
 	// Query all assets
-	rows, err := db.Query("SELECT type, content FROM assets ORDER BY created_at")
+	rows, err := db.Query("SELECT id, type, content FROM assets ORDER BY created_at")
 	if err != nil {
 		return nil, fmt.Errorf("failed to query assets: %w", err)
 	}
 	defer rows.Close()
 
 	artifacts := make([]*domain.Artifact, 0, 100)
-	seenFQDNs := make(map[string]bool) // Deduplicate FQDNs
+	seenFQDNs := make(map[string]*domain.Artifact) // Deduplicate FQDNs
+	byID := make(map[int64]*domain.Artifact)       // Asset id -> artifact (relation edges)
 
 	for rows.Next() {
+		var assetID int64
 		var assetType string
 		var contentJSON string
 
-		if err := rows.Scan(&assetType, &contentJSON); err != nil {
+		if err := rows.Scan(&assetID, &assetType, &contentJSON); err != nil {
 			a.GetLogger().Warn("failed to scan row", "error", err.Error())
 			continue
 		}
@@ -295,11 +466,11 @@ func (a *AmassSource) readDatabaseResults(dbPath string, target domain.Target) (
 				continue
 			}
 
-			// Skip duplicates
-			if seenFQDNs[fqdn] {
+			// Skip duplicates (their edges point to the first artifact)
+			if existing, ok := seenFQDNs[fqdn]; ok {
+				byID[assetID] = existing
 				continue
 			}
-			seenFQDNs[fqdn] = true
 
 			// Create subdomain artifact
 			artifact := domain.NewArtifact(
@@ -313,12 +484,14 @@ func (a *AmassSource) readDatabaseResults(dbPath string, target domain.Target) (
 			} else {
 				artifact.Confidence = domain.ConfidenceMedium // Passive discovery
 			}
+			seenFQDNs[fqdn] = artifact
+			byID[assetID] = artifact
 			artifacts = append(artifacts, artifact)
 
 		case "IPAddress":
 			if addr, ok := content["address"].(string); ok && addr != "" {
 				artifact := domain.NewArtifact(
-					domain.ArtifactTypeIP,
+					domain.IPArtifactType(addr),
 					addr,
 					sourceName,
 				)
@@ -327,6 +500,7 @@ func (a *AmassSource) readDatabaseResults(dbPath string, target domain.Target) (
 				} else {
 					artifact.Confidence = domain.ConfidenceMedium
 				}
+				byID[assetID] = artifact
 				artifacts = append(artifacts, artifact)
 			}
 
@@ -342,6 +516,7 @@ func (a *AmassSource) readDatabaseResults(dbPath string, target domain.Target) (
 				} else {
 					artifact.Confidence = domain.ConfidenceMedium
 				}
+				byID[assetID] = artifact
 				artifacts = append(artifacts, artifact)
 			}
 
@@ -358,6 +533,7 @@ func (a *AmassSource) readDatabaseResults(dbPath string, target domain.Target) (
 				} else {
 					artifact.Confidence = domain.ConfidenceMedium
 				}
+				byID[assetID] = artifact
 				artifacts = append(artifacts, artifact)
 			}
 		}
@@ -367,14 +543,24 @@ func (a *AmassSource) readDatabaseResults(dbPath string, target domain.Target) (
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
+	// Relation edges enrich the graph; databases without them still load
+	relations, err := a.importRelations(db, byID)
+	if err != nil {
+		a.GetLogger().Debug("amass relations not imported", "error", err.Error())
+	}
+
 	a.GetLogger().Debug("read database results",
 		"db_path", dbPath,
 		"artifacts", len(artifacts),
+		"relations", relations,
 	)
 
 	return artifacts, nil
 }
 
+// bareNameRegex matches a line holding only a hostname.
+var bareNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_*][-a-zA-Z0-9_.]*\.[a-zA-Z]{2,}$`)
+
 // readTextResults reads and parses the text file created by amass (fallback).
 func (a *AmassSource) readTextResults(txtPath string, target domain.Target) ([]*domain.Artifact, error) {
 	// Check if text file exists
@@ -398,8 +584,13 @@ func (a *AmassSource) readTextResults(txtPath string, target domain.Target) ([]*
 	for scanner.Scan() {
 		line := scanner.Text()
 
-		// Extract all FQDNs from the line
+		// Extract all FQDNs from the line; v3 -o and oam_subs print bare names
 		matches := fqdnRegex.FindAllStringSubmatch(line, -1)
+		if len(matches) == 0 {
+			if name := strings.TrimSpace(line); bareNameRegex.MatchString(name) {
+				matches = [][]string{{name, name}}
+			}
+		}
 		for _, match := range matches {
 			if len(match) < 2 {
 				continue
@@ -447,11 +638,16 @@ func (a *AmassSource) Stream(ctx context.Context, target domain.Target) (<-chan
 
 // Initialize verifies that amass is installed and accessible.
 // Implements ports.AdvancedSource.
+// The installed version selects the output parser; unsupported major
+// versions fail here with a clear error.
 func (a *AmassSource) Initialize() error {
-	return a.DefaultInitialize(
+	if err := a.DefaultInitialize(
 		"amass",
 		"https://github.com/owasp-amass/amass",
-	)
+	); err != nil {
+		return err
+	}
+	return a.detectVersion(context.Background())
 }
 
 // Validate checks if the source configuration is valid.
@@ -516,6 +712,14 @@ func (a *AmassSource) buildCommandArgs(target domain.Target, outputDir string) [
 	}
 	args = append(args, "-timeout", strconv.Itoa(timeoutMinutes))
 
+	// v3 keeps its graph in a Cayley db: ask for JSONL and the plain list
+	if a.layout == layoutV3 {
+		args = append(args,
+			"-json", filepath.Join(outputDir, "amass.json"),
+			"-o", filepath.Join(outputDir, "amass.txt"),
+		)
+	}
+
 	a.GetLogger().Debug("built amass command",
 		"args", args,
 		"timeout", a.GetTimeout().String(),
@@ -1,14 +1,18 @@
 package amass
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"aethonx/internal/core/domain"
 	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil/sourcetest"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -463,3 +467,184 @@ func TestAmassSource_ProgressChannel(t *testing.T) {
 		t.Error("expected non-nil progress channel")
 	}
 }
+
+func TestLayoutForVersion(t *testing.T) {
+	tests := []struct {
+		version     string
+		layout      resultLayout
+		unsupported bool
+	}{
+		{"v3.23.3", layoutV3, false},
+		{"4.2.0", layoutV4, false},
+		{"v5.0.1", layoutV4, true},
+		{"v2.9.0", layoutV4, true},
+	}
+
+	for _, tt := range tests {
+		layout, err := layoutForVersion(tt.version)
+		var unsupported *UnsupportedVersionError
+		if errors.As(err, &unsupported) != tt.unsupported {
+			t.Errorf("%s: unexpected error %v", tt.version, err)
+		}
+		if err == nil && layout != tt.layout {
+			t.Errorf("%s: expected layout %s, got %s", tt.version, tt.layout, layout)
+		}
+	}
+}
+
+func TestAmassSource_detectVersion(t *testing.T) {
+	fakeAmass := func(t *testing.T, version string) string {
+		path := filepath.Join(t.TempDir(), "amass")
+		script := "#!/bin/sh\necho \"" + version + "\" >&2\n"
+		if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+			t.Fatalf("failed to write fake amass: %v", err)
+		}
+		return path
+	}
+
+	t.Run("v3 selects json output", func(t *testing.T) {
+		source := NewWithConfig(logx.New(), AmassConfig{ExecPath: fakeAmass(t, "v3.23.3")})
+		if err := source.detectVersion(context.Background()); err != nil {
+			t.Fatalf("detectVersion failed: %v", err)
+		}
+		if source.layout != layoutV3 {
+			t.Errorf("expected v3 layout, got %s", source.layout)
+		}
+
+		args := source.buildCommandArgs(domain.Target{Root: "example.com"}, "/tmp/out")
+		if !strings.Contains(strings.Join(args, " "), "-json /tmp/out/amass.json") {
+			t.Errorf("v3 args should request json output: %v", args)
+		}
+	})
+
+	t.Run("unsupported major version", func(t *testing.T) {
+		source := NewWithConfig(logx.New(), AmassConfig{ExecPath: fakeAmass(t, "v5.0.0")})
+		err := source.detectVersion(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "not supported") {
+			t.Fatalf("expected unsupported version error, got %v", err)
+		}
+
+		// Run fails before starting amass
+		if _, err := source.Run(context.Background(), domain.Target{Root: "example.com"}); err == nil {
+			t.Error("Run should fail with an unsupported version")
+		}
+	})
+}
+
+func TestAmassSource_readResults_V3JSON(t *testing.T) {
+	source := New(logx.New())
+	source.layout = layoutV3
+	target := domain.Target{Root: "example.com"}
+
+	dir := t.TempDir()
+	lines := `{"name":"www.example.com","domain":"example.com","addresses":[{"ip":"93.184.216.34","cidr":"93.184.216.0/24","asn":15133,"desc":"EDGECAST"}],"tag":"dns","source":"DNS"}
+{"name":"api.example.com","domain":"example.com","addresses":[],"tag":"cert","source":"Crtsh"}
+`
+	if err := os.WriteFile(filepath.Join(dir, "amass.json"), []byte(lines), 0644); err != nil {
+		t.Fatalf("failed to write json: %v", err)
+	}
+
+	artifacts, err := source.readResults(context.Background(), dir, target)
+	if err != nil {
+		t.Fatalf("readResults failed: %v", err)
+	}
+
+	// 2 subdomains + IP + CIDR + ASN
+	if len(artifacts) != 5 {
+		t.Errorf("expected 5 artifacts, got %d", len(artifacts))
+	}
+}
+
+func TestAmassSource_readTextResults_BareNames(t *testing.T) {
+	source := New(logx.New())
+	txtPath := filepath.Join(t.TempDir(), "amass.txt")
+	if err := os.WriteFile(txtPath, []byte("www.example.com\napi.example.com\n\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	artifacts, err := source.readTextResults(txtPath, domain.Target{Root: "example.com"})
+	if err != nil {
+		t.Fatalf("readTextResults failed: %v", err)
+	}
+	if len(artifacts) != 2 {
+		t.Errorf("expected 2 artifacts from a v3 name list, got %d", len(artifacts))
+	}
+}
+
+func TestAmassSource_readDatabaseResults_Relations(t *testing.T) {
+	source := New(logx.New())
+	target := domain.Target{Root: "example.com"}
+
+	dbPath := filepath.Join(t.TempDir(), "amass.sqlite")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	schema := `
+	CREATE TABLE assets (id INTEGER PRIMARY KEY, created_at DATETIME DEFAULT CURRENT_TIMESTAMP, type TEXT, content TEXT);
+	CREATE TABLE relations (id INTEGER PRIMARY KEY, type TEXT, from_asset_id INTEGER, to_asset_id INTEGER);
+	INSERT INTO assets (id, type, content) VALUES
+		(1, 'FQDN', '{"name":"example.com"}'),
+		(2, 'FQDN', '{"name":"www.example.com"}'),
+		(3, 'IPAddress', '{"address":"192.0.2.1"}'),
+		(4, 'Netblock', '{"cidr":"192.0.2.0/24"}'),
+		(5, 'ASN', '{"number":64500}'),
+		(6, 'FQDN', '{"name":"cdn.example.net"}');
+	INSERT INTO relations (type, from_asset_id, to_asset_id) VALUES
+		('node', 1, 2),
+		('a_record', 2, 3),
+		('cname_record', 2, 6),
+		('announces', 5, 4),
+		('contains', 4, 3),
+		('unknown_edge', 1, 3);
+	`
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	artifacts, err := source.readDatabaseResults(dbPath, target)
+	if err != nil {
+		t.Fatalf("readDatabaseResults failed: %v", err)
+	}
+
+	byValue := make(map[string]*domain.Artifact)
+	for _, a := range artifacts {
+		byValue[a.Value] = a
+	}
+
+	expected := []struct {
+		from, to string
+		relType  domain.RelationType
+	}{
+		{"www.example.com", "example.com", domain.RelationSubdomainOf},
+		{"www.example.com", "192.0.2.1", domain.RelationResolvesTo},
+		{"www.example.com", "cdn.example.net", domain.RelationHasCNAME},
+		{"192.0.2.0/24", "AS64500", domain.RelationOwnedBy},
+		{"192.0.2.1", "AS64500", domain.RelationOwnedBy},
+	}
+	for _, e := range expected {
+		from, to := byValue[e.from], byValue[e.to]
+		if from == nil || to == nil {
+			t.Fatalf("missing artifacts for %s -> %s", e.from, e.to)
+		}
+		if !from.HasRelation(to.ID, e.relType) {
+			t.Errorf("expected %s %s %s", e.from, e.relType, e.to)
+		}
+	}
+
+	if n := len(byValue["example.com"].Relations); n != 0 {
+		t.Errorf("unknown edge types should be ignored, example.com has %d relations", n)
+	}
+}
+
+// TestAmassSource_ReplayGolden replays a recorded amass.json capture
+func TestAmassSource_ReplayGolden(t *testing.T) {
+	source := New(logx.NewSilent())
+	defer source.Close()
+
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	artifacts := sourcetest.Replay(t, source, *target, "testdata/capture")
+	sourcetest.Golden(t, "testdata/capture.golden", artifacts)
+}
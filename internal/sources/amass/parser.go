@@ -148,8 +148,9 @@ func (p *Parser) parseIP(addr AmassAddress) *domain.Artifact {
 	}
 
 	// Create artifact with metadata
+	ipMeta.IPVersion = domain.IPVersion(addr.IP)
 	artifact := domain.NewArtifactWithMetadata(
-		domain.ArtifactTypeIP,
+		domain.IPArtifactType(addr.IP),
 		addr.IP,
 		p.sourceName,
 		ipMeta,
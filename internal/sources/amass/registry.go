@@ -1,23 +1,52 @@
 package amass
 
 import (
+	"fmt"
+
 	"aethonx/internal/core/domain"
 	"aethonx/internal/core/ports"
 	"aethonx/internal/platform/logx"
 	"aethonx/internal/platform/registry"
+	"aethonx/internal/sources/common"
 )
 
+// dockerImage es la imagen fijada para runtime: docker.
+const dockerImage = "caffix/amass:v4.2.0"
+
+// configSchema declara las opciones Custom de amass.
+var configSchema = append([]ports.ConfigField{
+	{Name: "exec_path", Type: ports.ConfigTypeString, Default: "amass", Description: "Path to the amass binary"},
+	{Name: "max_dns_qps", Type: ports.ConfigTypeInt, Default: 0, Description: "Max DNS queries per second (0 = unlimited)"},
+	{Name: "brute", Type: ports.ConfigTypeBool, Default: false, Description: "Enable subdomain brute forcing (active mode)"},
+	{Name: "alts", Type: ports.ConfigTypeBool, Default: false, Description: "Enable name alterations (active mode)"},
+	{Name: "active_mode", Type: ports.ConfigTypeBool, Default: false, Description: "Run amass in active mode"},
+}, common.RuntimeFields(dockerImage)...)
+
+// dependency declares the amass binary for "aethonx deps".
+var dependency = &ports.ToolDependency{
+	Binary: "amass",
+	Repo:   "owasp-amass/amass",
+	AssetPatterns: map[string]string{
+		"linux_amd64":   "amass_linux_amd64.tar.gz",
+		"linux_arm64":   "amass_linux_arm64.tar.gz",
+		"darwin_amd64":  "amass_darwin_amd64.tar.gz",
+		"darwin_arm64":  "amass_darwin_arm64.tar.gz",
+		"windows_amd64": "amass_windows_amd64.tar.gz",
+	},
+	ChecksumAsset: "amass_*checksums.txt",
+	VersionMarker: "v",
+	MinVersion:    "4.0.0",
+}
+
 // Auto-registro de la source al importar el package
 func init() {
 	if err := registry.Global().Register(
 		"amass",
 		func(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
-			// Extract custom config using registry helpers
-			execPath := registry.GetStringConfig(cfg.Custom, "exec_path", "amass")
-			maxDNSQPS := registry.GetIntConfig(cfg.Custom, "max_dns_qps", 0)
-			brute := registry.GetBoolConfig(cfg.Custom, "brute", false)
-			alts := registry.GetBoolConfig(cfg.Custom, "alts", false)
-			activeMode := registry.GetBoolConfig(cfg.Custom, "active_mode", false)
+			opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+			if err != nil {
+				return nil, fmt.Errorf("amass config: %w", err)
+			}
 
 			// Use configured timeout or default
 			timeout := cfg.Timeout
@@ -26,15 +55,22 @@ func init() {
 			}
 
 			amassConfig := AmassConfig{
-				ExecPath:   execPath,
+				ExecPath:   opts.String("exec_path"),
 				Timeout:    timeout,
-				ActiveMode: activeMode,
-				MaxDNSQPS:  maxDNSQPS,
-				Brute:      brute,
-				Alts:       alts,
+				ActiveMode: opts.Bool("active_mode"),
+				MaxDNSQPS:  opts.Int("max_dns_qps"),
+				Brute:      opts.Bool("brute"),
+				Alts:       opts.Bool("alts"),
+			}
+
+			rt, container, err := common.RuntimeFromOptions(opts)
+			if err != nil {
+				return nil, fmt.Errorf("amass config: %w", err)
 			}
 
-			return NewWithConfig(logger, amassConfig), nil
+			source := NewWithConfig(logger, amassConfig)
+			source.SetRuntime(rt, container)
+			return source, nil
 		},
 		ports.SourceMetadata{
 			Name:         "amass",
@@ -51,11 +87,15 @@ func init() {
 			OutputArtifacts: []domain.ArtifactType{
 				domain.ArtifactTypeSubdomain,
 				domain.ArtifactTypeIP,
+				domain.ArtifactTypeIPv6,
 				domain.ArtifactTypeCIDR,
 				domain.ArtifactTypeASN,
 			},
 			Priority:  15, // Medium-high priority (runs after passive sources like waybackurls=5, rdap=8, crtsh=10, subfinder=10)
 			StageHint: 0,  // Stage 0 explicit
+
+			ConfigSchema: configSchema,
+			Dependency:   dependency,
 		},
 	); err != nil {
 		// Log error but don't panic - allow application to start
@@ -0,0 +1,96 @@
+package amass
+
+import (
+	"database/sql"
+	"fmt"
+
+	"aethonx/internal/core/domain"
+)
+
+// relationConfidence is the confidence of edges read from the amass graph.
+const relationConfidence = 0.9
+
+// edgeRelations maps amass asset-db relation types (from -> to) onto
+// AethonX relations with the same direction. "node" (parent -> child FQDN)
+// and the ASN/Netblock ownership edges are handled separately because
+// their direction is reversed.
+var edgeRelations = map[string]domain.RelationType{
+	"a_record":     domain.RelationResolvesTo,
+	"aaaa_record":  domain.RelationResolvesTo,
+	"cname_record": domain.RelationHasCNAME,
+	"ns_record":    domain.RelationHasNameserver,
+	"mx_record":    domain.RelationHasMX,
+}
+
+// amassEdge is a row of the relations table.
+type amassEdge struct {
+	kind     string
+	from, to int64
+}
+
+// importRelations reads the relations table of the amass database and adds
+// the edges between imported assets as artifact relations:
+//
+//	FQDN a/aaaa_record IP     -> fqdn resolves_to ip
+//	FQDN cname/ns/mx_record   -> has_cname / has_nameserver / has_mx
+//	FQDN node FQDN (child)    -> child subdomain_of parent
+//	ASN announces Netblock    -> netblock owned_by asn
+//	Netblock contains IP      -> ip owned_by asn (announcing the netblock)
+//
+// Returns the number of relations added.
+func (a *AmassSource) importRelations(db *sql.DB, byID map[int64]*domain.Artifact) (int, error) {
+	rows, err := db.Query("SELECT type, from_asset_id, to_asset_id FROM relations")
+	if err != nil {
+		return 0, fmt.Errorf("failed to query relations: %w", err)
+	}
+	defer rows.Close()
+
+	var edges []amassEdge
+	for rows.Next() {
+		var e amassEdge
+		if err := rows.Scan(&e.kind, &e.from, &e.to); err != nil {
+			a.GetLogger().Warn("failed to scan relation row", "error", err.Error())
+			continue
+		}
+		edges = append(edges, e)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating relations: %w", err)
+	}
+
+	// Netblock -> ASN announcing it, to attribute contained IPs
+	announcedBy := make(map[int64]*domain.Artifact)
+	for _, e := range edges {
+		if e.kind == "announces" && byID[e.from] != nil {
+			announcedBy[e.to] = byID[e.from]
+		}
+	}
+
+	added := 0
+	link := func(from, to *domain.Artifact, relType domain.RelationType) {
+		if from == nil || to == nil || from == to || from.HasRelation(to.ID, relType) {
+			return
+		}
+		from.AddRelation(to.ID, relType, relationConfidence, sourceName)
+		added++
+	}
+
+	for _, e := range edges {
+		from, to := byID[e.from], byID[e.to]
+
+		switch e.kind {
+		case "node":
+			link(to, from, domain.RelationSubdomainOf)
+		case "announces":
+			link(to, from, domain.RelationOwnedBy)
+		case "contains":
+			link(to, announcedBy[e.from], domain.RelationOwnedBy)
+		default:
+			if relType, ok := edgeRelations[e.kind]; ok {
+				link(from, to, relType)
+			}
+		}
+	}
+
+	return added, nil
+}
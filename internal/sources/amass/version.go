@@ -0,0 +1,92 @@
+package amass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// resultLayout is where a major amass version leaves its results.
+type resultLayout int
+
+const (
+	// layoutV4: asset-db SQLite in -dir; oam_subs or amass.txt as fallbacks.
+	layoutV4 resultLayout = iota
+	// layoutV3: JSONL written with -json; the -o text list as fallback.
+	layoutV3
+)
+
+func (l resultLayout) String() string {
+	if l == layoutV3 {
+		return "v3 (json/text)"
+	}
+	return "v4 (sqlite)"
+}
+
+// UnsupportedVersionError is returned for amass major versions whose output
+// layout the source cannot read.
+type UnsupportedVersionError struct {
+	Version string
+}
+
+func (e *UnsupportedVersionError) Error() string {
+	return fmt.Sprintf("amass %s is not supported (supported: v3.x, v4.x); install a supported release or disable the amass source", e.Version)
+}
+
+// layoutForVersion maps a version string ("v4.2.0", "3.23.3") to its layout.
+func layoutForVersion(version string) (resultLayout, error) {
+	major, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), ".")
+	n, err := strconv.Atoi(major)
+	if err != nil {
+		return layoutV4, fmt.Errorf("unrecognized amass version %q", version)
+	}
+
+	switch n {
+	case 3:
+		return layoutV3, nil
+	case 4:
+		return layoutV4, nil
+	}
+	return layoutV4, &UnsupportedVersionError{Version: version}
+}
+
+// detectVersion runs "amass -version" once and selects the result layout.
+// Unsupported major versions are an error; if the version cannot be read
+// the v4 layout (and its fallbacks) is assumed.
+func (a *AmassSource) detectVersion(ctx context.Context) error {
+	a.versionMu.Lock()
+	defer a.versionMu.Unlock()
+
+	if a.versionChecked {
+		return a.versionErr
+	}
+	a.versionChecked = true
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	diag, err := a.Diagnostics(ctx)
+	if err != nil || diag.Version == "" {
+		a.GetLogger().Warn("could not detect amass version, assuming v4 layout")
+		return nil
+	}
+
+	layout, err := layoutForVersion(diag.Version)
+	if err != nil {
+		var unsupported *UnsupportedVersionError
+		if errors.As(err, &unsupported) {
+			a.versionErr = err
+			return err
+		}
+		a.GetLogger().Warn("could not parse amass version, assuming v4 layout", "version", diag.Version)
+		return nil
+	}
+
+	a.version = diag.Version
+	a.layout = layout
+	a.GetLogger().Debug("detected amass version", "version", a.version, "layout", layout.String())
+	return nil
+}
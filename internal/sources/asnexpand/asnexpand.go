@@ -0,0 +1,328 @@
+// internal/sources/asnexpand/asnexpand.go
+package asnexpand
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/httpclient"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+	"aethonx/internal/platform/validator"
+)
+
+const (
+	sourceName               = "asnexpand"
+	defaultProvider          = "ripestat"
+	defaultMaxASNs           = 10
+	defaultMaxPrefixesPerASN = 500
+	defaultRateLimit         = 2.0
+	requestTimeout           = 30 * time.Second
+	tagASNPrefix             = "asn-prefix"
+)
+
+// defaultSkipASNs son ASNs de cloud/CDN compartidos: sus prefijos no
+// pertenecen al target y expandirlos inundaría el resultado.
+var defaultSkipASNs = []string{
+	"AS13335",  // Cloudflare
+	"AS16509",  // Amazon
+	"AS14618",  // Amazon
+	"AS15169",  // Google
+	"AS396982", // Google Cloud
+	"AS8075",   // Microsoft
+	"AS20940",  // Akamai
+	"AS16625",  // Akamai
+	"AS54113",  // Fastly
+}
+
+// configSchema declara las opciones Custom de asnexpand.
+var configSchema = []ports.ConfigField{
+	{Name: "provider", Type: ports.ConfigTypeString, Default: defaultProvider, Description: "Prefix data provider: ripestat, bgpview"},
+	{Name: "max_asns", Type: ports.ConfigTypeInt, Default: defaultMaxASNs, Description: "Max ASNs expanded (0 = all)"},
+	{Name: "max_prefixes_per_asn", Type: ports.ConfigTypeInt, Default: defaultMaxPrefixesPerASN, Description: "Max prefixes emitted per ASN (0 = no limit)"},
+	{Name: "include_ipv6", Type: ports.ConfigTypeBool, Default: true, Description: "Also emit IPv6 prefixes"},
+	{Name: "skip_asns", Type: ports.ConfigTypeStringList, Default: defaultSkipASNs, Description: "ASNs never expanded (shared cloud/CDN networks)"},
+	{Name: "rate_limit", Type: ports.ConfigTypeFloat, Default: defaultRateLimit, Description: "Max requests per second (0 = unlimited)"},
+}
+
+// Auto-registro de la source al importar el package
+func init() {
+	if err := registry.Global().Register(
+		sourceName,
+		factory,
+		ports.SourceMetadata{
+			Name:         sourceName,
+			Description:  "Announced prefixes of discovered ASNs (RIPEstat, BGPView)",
+			Version:      "1.0.0",
+			Author:       "AethonX",
+			Mode:         domain.SourceModePassive,
+			Type:         domain.SourceTypeAPI,
+			RequiresAuth: false,
+
+			// Consume los ASN de amass/shodan
+			InputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeASN,
+			},
+			OutputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeCIDR,
+				domain.ArtifactTypeASN,
+			},
+			Priority: 9,
+			Phase:    ports.PhaseDiscovery,
+
+			ConfigSchema: configSchema,
+			Providers:    []string{upstreams["ripestat"], upstreams["bgpview"]},
+		},
+	); err != nil {
+		logx.New().Warn("failed to register asnexpand source", "error", err.Error())
+	}
+}
+
+// factory crea la source desde SourceConfig (Custom según configSchema).
+func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("asnexpand config: %w", err)
+	}
+
+	maxASNs := opts.Int("max_asns")
+	maxPrefixes := opts.Int("max_prefixes_per_asn")
+	if maxASNs < 0 || maxPrefixes < 0 {
+		return nil, fmt.Errorf("asnexpand max_asns and max_prefixes_per_asn cannot be negative")
+	}
+	rateLimit := opts.Float("rate_limit")
+	if rateLimit < 0 {
+		return nil, fmt.Errorf("asnexpand rate_limit cannot be negative, got %v", rateLimit)
+	}
+
+	skip := make(map[string]bool)
+	for _, asn := range opts.Strings("skip_asns") {
+		if !validator.IsASN(asn) {
+			return nil, fmt.Errorf("asnexpand skip_asns: invalid ASN %q", asn)
+		}
+		skip[validator.NormalizeASN(asn)] = true
+	}
+
+	var p provider
+	switch name := strings.ToLower(opts.String("provider")); name {
+	case "ripestat":
+		p = &ripeStatProvider{client: newClient(logger, upstreams[name], rateLimit), urlTmpl: ripeStatURL}
+	case "bgpview":
+		p = &bgpViewProvider{client: newClient(logger, upstreams[name], rateLimit), urlTmpl: bgpViewURL}
+	default:
+		return nil, fmt.Errorf("asnexpand unknown provider %q (valid: ripestat, bgpview)", name)
+	}
+
+	source := New(logger, p)
+	source.maxASNs = maxASNs
+	source.maxPrefixes = maxPrefixes
+	source.includeIPv6 = opts.Bool("include_ipv6")
+	source.skip = skip
+	return source, nil
+}
+
+// upstreams es el presupuesto compartido (rate.Shared) de cada proveedor.
+var upstreams = map[string]string{
+	"ripestat": "stat.ripe.net",
+	"bgpview":  "api.bgpview.io",
+}
+
+// newClient crea el cliente HTTP con presupuesto compartido por upstream.
+func newClient(logger logx.Logger, upstream string, rateLimit float64) *httpclient.Client {
+	return httpclient.New(httpclient.Config{
+		Timeout:        requestTimeout,
+		MaxRetries:     2,
+		UserAgent:      "AethonX/1.0",
+		RateLimit:      rateLimit,
+		RateLimitBurst: 1,
+		Upstream:       upstream,
+	}, logger)
+}
+
+// Source expande los ASN descubiertos a todos los prefijos que anuncian y
+// emite artifacts cidr con relación owned_by hacia el ASN, de modo que
+// sources posteriores (p.ej. barridos PTR) puedan recorrer los rangos.
+type Source struct {
+	provider    provider
+	maxASNs     int
+	maxPrefixes int
+	includeIPv6 bool
+	skip        map[string]bool
+	logger      logx.Logger
+}
+
+// New crea la source asnexpand.
+func New(logger logx.Logger, p provider) *Source {
+	return &Source{
+		provider:    p,
+		maxASNs:     defaultMaxASNs,
+		maxPrefixes: defaultMaxPrefixesPerASN,
+		includeIPv6: true,
+		skip:        make(map[string]bool),
+		logger:      logger.With("source", sourceName),
+	}
+}
+
+// Name retorna el nombre de la fuente.
+func (s *Source) Name() string { return sourceName }
+
+// Providers retorna el upstream del proveedor configurado. Implementa
+// ports.ProviderSource.
+func (s *Source) Providers() []string {
+	if upstream, ok := upstreams[s.provider.Name()]; ok {
+		return []string{upstream}
+	}
+	return nil
+}
+
+// Mode retorna el modo de operación (pasivo: datos BGP públicos).
+func (s *Source) Mode() domain.SourceMode { return domain.SourceModePassive }
+
+// Type retorna el tipo de fuente (API).
+func (s *Source) Type() domain.SourceType { return domain.SourceTypeAPI }
+
+// Close no libera recursos.
+func (s *Source) Close() error { return nil }
+
+// SetLogger sustituye el logger por el del escaneo (scan_id, stage, source).
+// Implementa ports.LogScopedSource.
+func (s *Source) SetLogger(logger logx.Logger) { s.logger = logger }
+
+// Run no tiene ASNs que expandir sin input.
+func (s *Source) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	return s.RunWithInput(ctx, target, nil)
+}
+
+// RunWithInput expande los ASN de input. Implementa ports.InputConsumer.
+func (s *Source) RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+	result := domain.NewScanResult(target)
+	result.Metadata.SourcesUsed = []string{sourceName}
+
+	asns, skipped := s.selectASNs(input)
+	failures, truncated, prefixes := 0, 0, 0
+	for _, asn := range asns {
+		if ctx.Err() != nil {
+			break
+		}
+		number, _ := strconv.Atoi(strings.TrimPrefix(asn, "AS"))
+		found, err := s.provider.Prefixes(ctx, number)
+		if err != nil {
+			failures++
+			s.logger.Warn("asn prefix lookup failed", "asn", asn, "provider", s.provider.Name(), "error", err.Error())
+			continue
+		}
+		emitted, cut := s.addArtifacts(result, number, found)
+		prefixes += emitted
+		if cut {
+			truncated++
+		}
+	}
+
+	if failures > 0 {
+		result.AddWarning(sourceName, fmt.Sprintf("%d of %d ASN lookups failed", failures, len(asns)))
+	}
+	if truncated > 0 {
+		result.AddWarning(sourceName, fmt.Sprintf("%d ASNs announce more than %d prefixes, output truncated", truncated, s.maxPrefixes))
+	}
+
+	if result.Metadata.Environment == nil {
+		result.Metadata.Environment = make(map[string]string)
+	}
+	result.Metadata.Environment["asnexpand_asns"] = strconv.Itoa(len(asns))
+	result.Metadata.Environment["asnexpand_skipped"] = strconv.Itoa(skipped)
+	result.Metadata.Environment["asnexpand_prefixes"] = strconv.Itoa(prefixes)
+
+	s.logger.Info("asn expansion completed",
+		"provider", s.provider.Name(),
+		"asns", len(asns),
+		"skipped", skipped,
+		"prefixes", prefixes,
+		"failures", failures,
+	)
+
+	return result, ctx.Err()
+}
+
+// selectASNs extrae los ASN de input (normalizados, deduplicados y
+// ordenados), descarta los de skip y aplica maxASNs.
+func (s *Source) selectASNs(input *domain.ScanResult) (asns []string, skipped int) {
+	if input == nil {
+		return nil, 0
+	}
+	seen := make(map[string]bool)
+	for _, a := range input.Artifacts {
+		if a.Type != domain.ArtifactTypeASN || !validator.IsASN(a.Value) {
+			continue
+		}
+		asn := validator.NormalizeASN(a.Value)
+		if seen[asn] {
+			continue
+		}
+		seen[asn] = true
+		if s.skip[asn] {
+			skipped++
+			continue
+		}
+		asns = append(asns, asn)
+	}
+	sort.Strings(asns)
+	if s.maxASNs > 0 && len(asns) > s.maxASNs {
+		s.logger.Info("limiting expanded ASNs", "asns", len(asns), "max", s.maxASNs)
+		asns = asns[:s.maxASNs]
+	}
+	return asns, skipped
+}
+
+// addArtifacts emite el ASN (con el número de prefijos) y un cidr por
+// prefijo válido con relación owned_by. Devuelve los prefijos emitidos y si
+// se truncaron por maxPrefixes.
+func (s *Source) addArtifacts(result *domain.ScanResult, number int, found []prefix) (int, bool) {
+	asnArtifact := domain.NewASNArtifact(number, "", sourceName)
+	asnArtifact.Confidence = domain.ConfidenceHigh
+
+	seen := make(map[string]bool)
+	emitted, truncated := 0, false
+	for _, p := range found {
+		_, ipnet, err := net.ParseCIDR(strings.TrimSpace(p.network))
+		if err != nil || (ipnet.IP.To4() == nil && !s.includeIPv6) {
+			continue
+		}
+		if seen[ipnet.String()] {
+			continue
+		}
+		if s.maxPrefixes > 0 && emitted >= s.maxPrefixes {
+			truncated = true
+			break
+		}
+		seen[ipnet.String()] = true
+		emitted++
+
+		cidr := domain.NewCIDRArtifact(ipnet.String(), sourceName)
+		cidr.Confidence = domain.ConfidenceHigh // Anunciado en BGP
+		cidr.AddTag(tagASNPrefix)
+		if meta, ok := cidr.TypedMetadata.(*metadata.CIDRMetadata); ok {
+			meta.ASN = asnArtifact.Value
+			meta.NetName = p.name
+			meta.Org = p.description
+			meta.Country = p.country
+			meta.Source = s.provider.Name()
+		}
+		cidr.AddRelation(asnArtifact.ID, domain.RelationOwnedBy, domain.ConfidenceHigh, sourceName)
+		result.AddArtifact(cidr)
+	}
+
+	if meta, ok := asnArtifact.TypedMetadata.(*metadata.ASNMetadata); ok {
+		meta.PrefixCount = len(found)
+		meta.Source = s.provider.Name()
+	}
+	result.AddArtifact(asnArtifact)
+	return emitted, truncated
+}
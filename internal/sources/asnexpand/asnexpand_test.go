@@ -0,0 +1,167 @@
+package asnexpand
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/httpclient"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+)
+
+func newTestClient() *httpclient.Client {
+	return httpclient.New(httpclient.Config{MaxRetries: 0}, logx.New())
+}
+
+// newUpstream simula RIPEstat (/ripe/) y BGPView (/bgpview/).
+func newUpstream(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ripe/64500":
+			w.Write([]byte(`{"status":"ok","data":{"prefixes":[
+				{"prefix":"198.51.100.0/24"},
+				{"prefix":"198.51.100.0/24"},
+				{"prefix":"2001:db8::/32"},
+				{"prefix":"not-a-prefix"}
+			]}}`))
+		case "/ripe/64501":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/bgpview/64500":
+			w.Write([]byte(`{"status":"ok","data":{
+				"ipv4_prefixes":[{"prefix":"203.0.113.0/24","name":"EXAMPLE-NET","description":"Example Corp","country_code":"ES"}],
+				"ipv6_prefixes":[]
+			}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func newRunInput(asns ...string) (domain.Target, *domain.ScanResult) {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	input := domain.NewScanResult(*target)
+	for _, asn := range asns {
+		input.AddArtifact(domain.NewArtifact(domain.ArtifactTypeASN, asn, "amass"))
+	}
+	return *target, input
+}
+
+func TestSource_RunWithInput_RIPEstat(t *testing.T) {
+	var _ ports.InputConsumer = (*Source)(nil)
+
+	server := newUpstream(t)
+	defer server.Close()
+
+	source := New(logx.New(), &ripeStatProvider{client: newTestClient(), urlTmpl: server.URL + "/ripe/%d"})
+	source.skip["AS13335"] = true
+	target, input := newRunInput("AS64500", "as64501", "AS13335")
+
+	result, err := source.RunWithInput(context.Background(), target, input)
+	testutil.AssertNoError(t, err, "run")
+
+	asnID := domain.NewASNArtifact(64500, "", sourceName).ID
+	cidrs := make(map[string]*domain.Artifact)
+	for _, a := range result.Artifacts {
+		switch a.Type {
+		case domain.ArtifactTypeCIDR:
+			cidrs[a.Value] = a
+		case domain.ArtifactTypeASN:
+			testutil.AssertEqual(t, a.ID, asnID, "only AS64500 resolved")
+			meta := a.TypedMetadata.(*metadata.ASNMetadata)
+			testutil.AssertEqual(t, meta.PrefixCount, 4, "prefix count as announced")
+			testutil.AssertEqual(t, meta.Source, "ripestat", "asn source")
+		}
+	}
+	testutil.AssertEqual(t, len(cidrs), 2, "deduplicated valid prefixes")
+
+	cidr, ok := cidrs["198.51.100.0/24"]
+	testutil.AssertTrue(t, ok, "IPv4 prefix emitted")
+	testutil.AssertContains(t, cidr.Tags, tagASNPrefix, "prefix tag")
+	meta := cidr.TypedMetadata.(*metadata.CIDRMetadata)
+	testutil.AssertEqual(t, meta.ASN, "AS64500", "cidr asn")
+	testutil.AssertEqual(t, meta.Size, "256", "cidr size")
+	testutil.AssertEqual(t, meta.Source, "ripestat", "cidr provider")
+
+	rels := cidr.GetRelations(domain.RelationOwnedBy)
+	testutil.AssertEqual(t, len(rels), 1, "owned_by relation")
+	testutil.AssertEqual(t, rels[0].TargetID, asnID, "relation points to the ASN")
+
+	_, ok = cidrs["2001:db8::/32"]
+	testutil.AssertTrue(t, ok, "IPv6 prefix emitted")
+
+	testutil.AssertEqual(t, len(result.Warnings), 1, "failed lookup warning")
+	testutil.AssertEqual(t, result.Metadata.Environment["asnexpand_asns"], "2", "expanded asns")
+	testutil.AssertEqual(t, result.Metadata.Environment["asnexpand_skipped"], "1", "skipped asns")
+	testutil.AssertEqual(t, result.Metadata.Environment["asnexpand_prefixes"], "2", "emitted prefixes")
+}
+
+func TestSource_RunWithInput_BGPViewMetadata(t *testing.T) {
+	server := newUpstream(t)
+	defer server.Close()
+
+	source := New(logx.New(), &bgpViewProvider{client: newTestClient(), urlTmpl: server.URL + "/bgpview/%d"})
+	target, input := newRunInput("AS64500")
+
+	result, err := source.RunWithInput(context.Background(), target, input)
+	testutil.AssertNoError(t, err, "run")
+
+	for _, a := range result.Artifacts {
+		if a.Type != domain.ArtifactTypeCIDR {
+			continue
+		}
+		meta := a.TypedMetadata.(*metadata.CIDRMetadata)
+		testutil.AssertEqual(t, a.Value, "203.0.113.0/24", "cidr value")
+		testutil.AssertEqual(t, meta.NetName, "EXAMPLE-NET", "net name")
+		testutil.AssertEqual(t, meta.Org, "Example Corp", "org")
+		testutil.AssertEqual(t, meta.Country, "ES", "country")
+		return
+	}
+	t.Fatal("no cidr artifact emitted")
+}
+
+func TestSource_AddArtifacts_Limits(t *testing.T) {
+	source := New(logx.New(), &ripeStatProvider{})
+	source.maxPrefixes = 1
+	source.includeIPv6 = false
+
+	target, _ := newRunInput()
+	result := domain.NewScanResult(target)
+	emitted, truncated := source.addArtifacts(result, 64500, []prefix{
+		{network: "2001:db8::/32"},
+		{network: "198.51.100.0/24"},
+		{network: "203.0.113.0/24"},
+	})
+	testutil.AssertEqual(t, emitted, 1, "capped at max_prefixes")
+	testutil.AssertTrue(t, truncated, "truncation reported")
+}
+
+func TestSelectASNs_MaxASNs(t *testing.T) {
+	source := New(logx.New(), nil)
+	source.maxASNs = 2
+	_, input := newRunInput("AS3", "AS1", "AS2", "1")
+
+	asns, skipped := source.selectASNs(input)
+	testutil.AssertEqual(t, len(asns), 2, "capped at max_asns")
+	testutil.AssertEqual(t, asns[0], "AS1", "sorted and normalized")
+	testutil.AssertEqual(t, skipped, 0, "nothing skipped")
+}
+
+func TestFactory_Validation(t *testing.T) {
+	_, err := factory(ports.SourceConfig{Custom: map[string]interface{}{"provider": "unknown"}}, logx.New())
+	testutil.AssertError(t, err, "unknown provider")
+
+	_, err = factory(ports.SourceConfig{Custom: map[string]interface{}{"skip_asns": []string{"cloudflare"}}}, logx.New())
+	testutil.AssertError(t, err, "invalid skip ASN")
+
+	src, err := factory(ports.SourceConfig{Custom: map[string]interface{}{}}, logx.New())
+	testutil.AssertNoError(t, err, "defaults")
+	s := src.(*Source)
+	testutil.AssertTrue(t, s.skip["AS13335"], "shared CDN ASNs skipped by default")
+	testutil.AssertEqual(t, s.provider.Name(), "ripestat", "default provider")
+}
@@ -0,0 +1,112 @@
+// internal/sources/asnexpand/providers.go
+package asnexpand
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"aethonx/internal/platform/errors"
+	"aethonx/internal/platform/httpclient"
+)
+
+const (
+	ripeStatURL = "https://stat.ripe.net/data/announced-prefixes/data.json?resource=AS%d&sourceapp=aethonx"
+	bgpViewURL  = "https://api.bgpview.io/asn/%d/prefixes"
+)
+
+// prefix es un prefijo anunciado por un ASN.
+type prefix struct {
+	network     string
+	name        string // Nombre del bloque, si el proveedor lo da
+	description string
+	country     string
+}
+
+// provider lista los prefijos anunciados por un ASN.
+type provider interface {
+	Name() string
+	Prefixes(ctx context.Context, asn int) ([]prefix, error)
+}
+
+// ripeStatProvider usa RIPEstat announced-prefixes (gratuito, sin clave).
+type ripeStatProvider struct {
+	client  *httpclient.Client
+	urlTmpl string
+}
+
+type ripeStatResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Prefixes []struct {
+			Prefix string `json:"prefix"`
+		} `json:"prefixes"`
+	} `json:"data"`
+}
+
+func (r *ripeStatProvider) Name() string { return "ripestat" }
+
+func (r *ripeStatProvider) Prefixes(ctx context.Context, asn int) ([]prefix, error) {
+	body, err := r.client.FetchJSON(ctx, fmt.Sprintf(r.urlTmpl, asn))
+	if err != nil {
+		return nil, err
+	}
+	var resp ripeStatResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, errors.Wrap(err, "invalid ripestat response")
+	}
+	if resp.Status != "" && resp.Status != "ok" {
+		return nil, errors.Errorf("ripestat status %q", resp.Status)
+	}
+
+	prefixes := make([]prefix, 0, len(resp.Data.Prefixes))
+	for _, p := range resp.Data.Prefixes {
+		prefixes = append(prefixes, prefix{network: p.Prefix})
+	}
+	return prefixes, nil
+}
+
+// bgpViewProvider usa BGPView, que además da nombre, descripción y país
+// de cada prefijo.
+type bgpViewProvider struct {
+	client  *httpclient.Client
+	urlTmpl string
+}
+
+type bgpViewPrefix struct {
+	Prefix      string `json:"prefix"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CountryCode string `json:"country_code"`
+}
+
+type bgpViewResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		IPv4Prefixes []bgpViewPrefix `json:"ipv4_prefixes"`
+		IPv6Prefixes []bgpViewPrefix `json:"ipv6_prefixes"`
+	} `json:"data"`
+}
+
+func (b *bgpViewProvider) Name() string { return "bgpview" }
+
+func (b *bgpViewProvider) Prefixes(ctx context.Context, asn int) ([]prefix, error) {
+	body, err := b.client.FetchJSON(ctx, fmt.Sprintf(b.urlTmpl, asn))
+	if err != nil {
+		return nil, err
+	}
+	var resp bgpViewResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, errors.Wrap(err, "invalid bgpview response")
+	}
+	if resp.Status != "" && resp.Status != "ok" {
+		return nil, errors.Errorf("bgpview status %q", resp.Status)
+	}
+
+	all := append(resp.Data.IPv4Prefixes, resp.Data.IPv6Prefixes...)
+	prefixes := make([]prefix, 0, len(all))
+	for _, p := range all {
+		prefixes = append(prefixes, prefix{network: p.Prefix, name: p.Name, description: p.Description, country: p.CountryCode})
+	}
+	return prefixes, nil
+}
@@ -0,0 +1,403 @@
+// internal/sources/axfr/axfr.go
+package axfr
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/budget"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+	"aethonx/internal/sources/common"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	sourceName        = "axfr"
+	defaultPort       = 53
+	defaultTimeout    = 10 * time.Second
+	defaultMaxRecords = 50000
+
+	// findingID identifica el check en los artifacts vulnerability
+	findingID = "dns-zone-transfer"
+)
+
+// configSchema declara las opciones Custom de axfr.
+var configSchema = []ports.ConfigField{
+	{Name: "port", Type: ports.ConfigTypeInt, Default: defaultPort, Description: "DNS port of the nameservers"},
+	{Name: "timeout", Type: ports.ConfigTypeDuration, Default: defaultTimeout.String(), Description: "Dial and read timeout per nameserver"},
+	{Name: "max_records", Type: ports.ConfigTypeInt, Default: defaultMaxRecords, Description: "Max records kept per transfer (0 = no limit)"},
+}
+
+// Auto-registro de la source al importar el package
+func init() {
+	if err := registry.Global().Register(
+		sourceName,
+		factory,
+		ports.SourceMetadata{
+			Name:         sourceName,
+			Description:  "DNS zone transfer (AXFR) attempt against the target nameservers",
+			Version:      "1.0.0",
+			Author:       "AethonX",
+			Mode:         domain.SourceModeActive,
+			Type:         domain.SourceTypeBuiltin,
+			RequiresAuth: false,
+
+			// Consume los nameservers que extrae rdap
+			InputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeNameserver,
+			},
+			OutputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeDNSRecord,
+				domain.ArtifactTypeSubdomain,
+				domain.ArtifactTypeIP,
+				domain.ArtifactTypeIPv6,
+				domain.ArtifactTypeMXRecord,
+				domain.ArtifactTypeVulnerability,
+			},
+			Priority: 9,
+			Phase:    ports.PhaseDiscovery,
+
+			ConfigSchema: configSchema,
+		},
+	); err != nil {
+		logx.New().Warn("failed to register axfr source", "error", err.Error())
+	}
+}
+
+// factory crea la source desde SourceConfig (Custom según configSchema).
+func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("axfr config: %w", err)
+	}
+
+	port := opts.Int("port")
+	if port < 1 || port > 65535 {
+		return nil, fmt.Errorf("axfr port must be between 1 and 65535, got %d", port)
+	}
+	timeout := opts.Duration("timeout")
+	if timeout <= 0 {
+		return nil, fmt.Errorf("axfr timeout must be positive, got %s", timeout)
+	}
+	maxRecords := opts.Int("max_records")
+	if maxRecords < 0 {
+		return nil, fmt.Errorf("axfr max_records cannot be negative, got %d", maxRecords)
+	}
+
+	source := New(logger)
+	source.port = port
+	source.timeout = timeout
+	source.maxRecords = maxRecords
+	return source, nil
+}
+
+// nsResolver consulta los registros NS de la zona (net.Resolver lo cumple).
+type nsResolver interface {
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+}
+
+// Source intenta una transferencia de zona (AXFR) contra cada nameserver
+// del target. Si alguno la permite, emite todos los registros de la zona y
+// un artifact vulnerability de severidad alta por nameserver.
+type Source struct {
+	resolver   nsResolver
+	port       int
+	timeout    time.Duration
+	maxRecords int
+	logger     logx.Logger
+}
+
+// New crea la source axfr. Los nameservers se buscan con el resolver
+// inyectado (common.WithResolver) si resuelve NS; si no, con el del sistema.
+func New(logger logx.Logger, opts ...common.Option) *Source {
+	var resolver nsResolver = net.DefaultResolver
+	if r, ok := common.ApplyOptions(opts...).Resolver.(nsResolver); ok {
+		resolver = r
+	}
+	return &Source{
+		resolver:   resolver,
+		port:       defaultPort,
+		timeout:    defaultTimeout,
+		maxRecords: defaultMaxRecords,
+		logger:     logger.With("source", sourceName),
+	}
+}
+
+// Name retorna el nombre de la fuente.
+func (s *Source) Name() string { return sourceName }
+
+// Mode retorna el modo de operación (activo: conecta con los nameservers).
+func (s *Source) Mode() domain.SourceMode { return domain.SourceModeActive }
+
+// Type retorna el tipo de fuente (builtin).
+func (s *Source) Type() domain.SourceType { return domain.SourceTypeBuiltin }
+
+// Close no libera recursos.
+func (s *Source) Close() error { return nil }
+
+// SetLogger sustituye el logger por el del escaneo (scan_id, stage, source).
+// Implementa ports.LogScopedSource.
+func (s *Source) SetLogger(logger logx.Logger) { s.logger = logger }
+
+// Run prueba los nameservers de la zona según el resolver.
+func (s *Source) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	return s.transferAll(ctx, target, nil)
+}
+
+// RunWithInput prueba además los nameservers descubiertos por stages
+// previos (rdap). Implementa ports.InputConsumer.
+func (s *Source) RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+	var nameservers []string
+	if input != nil {
+		for _, a := range input.Artifacts {
+			if a.Type == domain.ArtifactTypeNameserver {
+				nameservers = append(nameservers, a.Value)
+			}
+		}
+	}
+	return s.transferAll(ctx, target, nameservers)
+}
+
+// transferAll intenta la transferencia contra cada nameserver (los conocidos
+// más los del resolver) en paralelo.
+func (s *Source) transferAll(ctx context.Context, target domain.Target, known []string) (*domain.ScanResult, error) {
+	result := domain.NewScanResult(target)
+	result.Metadata.SourcesUsed = []string{sourceName}
+	zone := target.QueryName()
+
+	nsRecords, err := s.resolver.LookupNS(ctx, zone)
+	if err != nil {
+		s.logger.Debug("NS lookup failed", "zone", zone, "error", err.Error())
+	}
+	for _, ns := range nsRecords {
+		known = append(known, ns.Host)
+	}
+	nameservers := normalizeHosts(known)
+	if len(nameservers) == 0 {
+		result.AddWarning(sourceName, "no nameservers found for "+zone)
+		return result, nil
+	}
+
+	// Presupuesto global de consultas (--budget): una por nameserver
+	if granted := int(budget.Shared().Reserve(budget.DNSQueries, int64(len(nameservers)))); granted < len(nameservers) {
+		skipped := len(nameservers) - granted
+		s.logger.Warn("dns query budget exhausted, nameservers not tried", "skipped", skipped)
+		result.AddWarning(sourceName, fmt.Sprintf("dns query budget exhausted: %d of %d nameservers not tried", skipped, len(nameservers)))
+		nameservers = nameservers[:granted]
+	}
+
+	transfers := make([]transfer, len(nameservers))
+	var wg sync.WaitGroup
+	for i, ns := range nameservers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			transfers[i] = s.transfer(ctx, zone, ns)
+		}()
+	}
+	wg.Wait()
+
+	emitted := make(map[string]bool)
+	allowed := 0
+	for _, t := range transfers {
+		if t.err != nil {
+			s.logger.Debug("zone transfer refused", "nameserver", t.nameserver, "error", t.err.Error())
+			continue
+		}
+		allowed++
+		s.logger.Warn("zone transfer allowed", "zone", zone, "nameserver", t.nameserver, "records", len(t.records))
+		if t.truncated {
+			result.AddWarning(sourceName, fmt.Sprintf("%s: zone truncated to %d records", t.nameserver, s.maxRecords))
+		}
+		s.addArtifacts(result, target, t, emitted)
+	}
+
+	s.logger.Info("axfr completed",
+		"zone", zone,
+		"nameservers", len(nameservers),
+		"allowed", allowed,
+		"artifacts", len(result.Artifacts),
+	)
+	return result, ctx.Err()
+}
+
+// transfer es el resultado de la transferencia de un nameserver.
+type transfer struct {
+	nameserver string
+	records    []dns.RR
+	truncated  bool
+	err        error
+}
+
+// transfer pide la zona completa a un nameserver por TCP. Un rechazo
+// (REFUSED, NOTAUTH, conexión cerrada) o una zona sin registros es error.
+func (s *Source) transfer(ctx context.Context, zone, nameserver string) transfer {
+	t := transfer{nameserver: nameserver}
+
+	msg := new(dns.Msg)
+	msg.SetAxfr(dns.Fqdn(zone))
+
+	conn, err := (&net.Dialer{Timeout: s.timeout}).DialContext(ctx, "tcp", net.JoinHostPort(nameserver, strconv.Itoa(s.port)))
+	if err != nil {
+		t.err = err
+		return t
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	tr := &dns.Transfer{Conn: &dns.Conn{Conn: conn}, ReadTimeout: s.timeout}
+	envelopes, err := tr.In(msg, "")
+	if err != nil {
+		conn.Close()
+		t.err = err
+		return t
+	}
+	for env := range envelopes {
+		if env.Error != nil {
+			t.err = env.Error
+			continue
+		}
+		for _, rr := range env.RR {
+			if s.maxRecords > 0 && len(t.records) >= s.maxRecords {
+				t.truncated = true
+				break
+			}
+			t.records = append(t.records, rr)
+		}
+	}
+	if t.err == nil && len(t.records) == 0 {
+		t.err = fmt.Errorf("empty transfer")
+	}
+	if t.err != nil {
+		t.records = nil
+	}
+	return t
+}
+
+// addArtifacts emite el hallazgo y los registros de una transferencia.
+// emitted deduplica los registros repetidos entre nameservers.
+func (s *Source) addArtifacts(result *domain.ScanResult, target domain.Target, t transfer, emitted map[string]bool) {
+	zone := target.QueryName()
+	rootArtifact := domain.NewDomainArtifact(zone, sourceName)
+
+	vuln := domain.NewArtifactWithMetadata(domain.ArtifactTypeVulnerability,
+		findingID+":"+zone+"@"+t.nameserver, sourceName, &metadata.VulnerabilityMetadata{
+			Title:    "DNS zone transfer (AXFR) allowed",
+			Severity: "high",
+			Description: fmt.Sprintf("Nameserver %s returned the full %s zone (%d records) to an unauthenticated AXFR request",
+				t.nameserver, zone, len(t.records)),
+			MatchedAt:     net.JoinHostPort(t.nameserver, strconv.Itoa(s.port)),
+			TemplateID:    findingID,
+			DiscoveryTool: sourceName,
+		})
+	vuln.Confidence = domain.ConfidenceHigh
+	nsArtifact := domain.NewArtifact(domain.ArtifactTypeNameserver, t.nameserver, sourceName)
+	nsArtifact.AddRelation(vuln.ID, domain.RelationHasVuln, 1.0, sourceName)
+	rootArtifact.AddRelation(vuln.ID, domain.RelationHasVuln, 1.0, sourceName)
+	result.AddArtifact(vuln)
+	result.AddArtifact(nsArtifact)
+
+	hosts := make(map[string]*domain.Artifact)
+	hostArtifact := func(name string) *domain.Artifact {
+		if name == zone {
+			return rootArtifact
+		}
+		if a, ok := hosts[name]; ok {
+			return a
+		}
+		if !strings.HasSuffix(name, "."+zone) {
+			return nil
+		}
+		a := domain.NewSubdomainArtifact(name, sourceName)
+		hosts[name] = a
+		return a
+	}
+
+	for _, rr := range t.records {
+		owner := normalizeName(rr.Header().Name)
+		value := recordValue(rr)
+		if emitted[value] {
+			continue
+		}
+		emitted[value] = true
+
+		record := domain.NewArtifact(domain.ArtifactTypeDNSRecord, value, sourceName)
+		record.AddTag(sourceName)
+		result.AddArtifact(record)
+
+		host := hostArtifact(owner)
+		if host == nil {
+			continue
+		}
+
+		switch r := rr.(type) {
+		case *dns.A:
+			s.addIP(result, host, r.A.String())
+		case *dns.AAAA:
+			s.addIP(result, host, r.AAAA.String())
+		case *dns.NS:
+			ns := domain.NewArtifact(domain.ArtifactTypeNameserver, normalizeName(r.Ns), sourceName)
+			host.AddRelation(ns.ID, domain.RelationHasNameserver, 1.0, sourceName)
+			result.AddArtifact(ns)
+		case *dns.MX:
+			mx := domain.NewArtifact(domain.ArtifactTypeMXRecord, normalizeName(r.Mx), sourceName)
+			host.AddRelation(mx.ID, domain.RelationHasMX, 1.0, sourceName)
+			result.AddArtifact(mx)
+		case *dns.CNAME:
+			host.AddRelation(record.ID, domain.RelationHasCNAME, 1.0, sourceName)
+		}
+	}
+
+	result.AddArtifact(rootArtifact)
+	for _, host := range hosts {
+		result.AddArtifact(host)
+	}
+}
+
+// addIP emite la IP de un registro A/AAAA relacionada con su host.
+func (s *Source) addIP(result *domain.ScanResult, host *domain.Artifact, ip string) {
+	ipArtifact := domain.NewIPArtifact(ip, sourceName)
+	host.AddRelation(ipArtifact.ID, domain.RelationResolvesTo, 1.0, sourceName)
+	ipArtifact.AddRelation(host.ID, domain.RelationReverseResolves, 1.0, sourceName)
+	result.AddArtifact(ipArtifact)
+}
+
+// recordValue representa un registro como "<nombre> <TIPO> <datos>", sin
+// TTL ni clase para que no cambie entre transferencias.
+func recordValue(rr dns.RR) string {
+	hdr := rr.Header()
+	rdata := strings.TrimSpace(strings.TrimPrefix(rr.String(), hdr.String()))
+	return normalizeName(hdr.Name) + " " + dns.TypeToString[hdr.Rrtype] + " " + rdata
+}
+
+// normalizeName pasa un nombre DNS a minúsculas y sin punto final.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// normalizeHosts normaliza, deduplica y ordena los nameservers.
+func normalizeHosts(hosts []string) []string {
+	seen := make(map[string]bool, len(hosts))
+	out := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		h = normalizeName(strings.TrimSpace(h))
+		if h == "" || seen[h] {
+			continue
+		}
+		seen[h] = true
+		out = append(out, h)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,136 @@
+package axfr
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/sources/common"
+	"aethonx/internal/testutil"
+	"aethonx/internal/testutil/sourcetest"
+
+	"github.com/miekg/dns"
+)
+
+// leakyZone es la zona que el servidor de prueba transfiere; el resto se rechaza.
+const leakyZone = "leaky.test."
+
+var leakyRecords = []string{
+	"leaky.test. 3600 IN SOA ns1.leaky.test. admin.leaky.test. 1 7200 3600 1209600 3600",
+	"leaky.test. 3600 IN NS ns1.leaky.test.",
+	"leaky.test. 3600 IN MX 10 mail.leaky.test.",
+	"leaky.test. 3600 IN A 192.0.2.1",
+	"internal.leaky.test. 300 IN A 10.0.0.5",
+	"vpn.leaky.test. 300 IN AAAA 2001:db8::5",
+	"www.leaky.test. 300 IN CNAME leaky.test.",
+	"leaky.test. 300 IN TXT \"v=spf1 -all\"",
+	"leaky.test. 3600 IN SOA ns1.leaky.test. admin.leaky.test. 1 7200 3600 1209600 3600",
+}
+
+// startServer arranca un servidor DNS TCP local que permite AXFR de leakyZone.
+func startServer(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	testutil.AssertNoError(t, err, "listen")
+
+	handler := dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+		if len(req.Question) == 0 || req.Question[0].Qtype != dns.TypeAXFR || req.Question[0].Name != leakyZone {
+			m := new(dns.Msg)
+			m.SetRcode(req, dns.RcodeRefused)
+			_ = w.WriteMsg(m)
+			return
+		}
+		var rrs []dns.RR
+		for _, s := range leakyRecords {
+			rr, err := dns.NewRR(s)
+			if err != nil {
+				t.Errorf("bad test record %q: %v", s, err)
+				return
+			}
+			rrs = append(rrs, rr)
+		}
+		ch := make(chan *dns.Envelope, 1)
+		ch <- &dns.Envelope{RR: rrs}
+		close(ch)
+		_ = new(dns.Transfer).Out(w, req, ch)
+		w.Hijack()
+		w.Close()
+	})
+
+	server := &dns.Server{Listener: ln, Handler: handler}
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+// fakeResolver devuelve 127.0.0.1 como nameserver de cualquier zona.
+type fakeResolver struct{}
+
+func (fakeResolver) LookupIP(context.Context, string, string) ([]net.IP, error) { return nil, nil }
+
+func (fakeResolver) LookupNS(context.Context, string) ([]*net.NS, error) {
+	return []*net.NS{{Host: "127.0.0.1."}}, nil
+}
+
+func newTestSource(t *testing.T) *Source {
+	s := New(logx.NewSilent(), common.WithResolver(fakeResolver{}))
+	s.port = startServer(t)
+	s.timeout = 2 * time.Second
+	return s
+}
+
+func TestAXFR_TransferAllowed(t *testing.T) {
+	s := newTestSource(t)
+	target := domain.NewTarget("leaky.test", domain.ScanModeActive)
+
+	result, err := s.Run(context.Background(), *target)
+	testutil.AssertNoError(t, err, "run")
+
+	sourcetest.AssertArtifacts(t, result.Artifacts,
+		"vulnerability dns-zone-transfer:leaky.test@127.0.0.1",
+		"subdomain internal.leaky.test",
+		"ip 10.0.0.5",
+		"ipv6 2001:db8::5",
+		"mx_record mail.leaky.test",
+		"nameserver ns1.leaky.test",
+		"dns_record www.leaky.test CNAME leaky.test.",
+		"dns_record leaky.test TXT \"v=spf1 -all\"",
+	)
+
+	for _, a := range result.Artifacts {
+		if a.Type != domain.ArtifactTypeVulnerability {
+			continue
+		}
+		meta, ok := a.TypedMetadata.(*metadata.VulnerabilityMetadata)
+		testutil.AssertTrue(t, ok, "finding has vulnerability metadata")
+		testutil.AssertEqual(t, meta.Severity, "high", "finding severity")
+		testutil.AssertEqual(t, meta.MatchedAt, "127.0.0.1:"+strconv.Itoa(s.port), "finding location")
+	}
+}
+
+func TestAXFR_TransferRefused(t *testing.T) {
+	s := newTestSource(t)
+	target := domain.NewTarget("secure.test", domain.ScanModeActive)
+
+	result, err := s.Run(context.Background(), *target)
+	testutil.AssertNoError(t, err, "run")
+	testutil.AssertEqual(t, len(result.Artifacts), 0, "refused transfer emits nothing")
+}
+
+func TestFactory_Validation(t *testing.T) {
+	for _, custom := range []map[string]interface{}{
+		{"port": 0},
+		{"timeout": "0s"},
+		{"max_records": -1},
+	} {
+		if _, err := factory(ports.SourceConfig{Custom: custom}, logx.NewSilent()); err == nil {
+			t.Errorf("expected error for %v", custom)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// internal/sources/common/capture.go
+package common
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"aethonx/internal/core/ports"
+)
+
+// RawOutputFile is the capture of a CLI tool's stdout (one line per record).
+const RawOutputFile = "stdout.txt"
+
+// CaptureFile opens name in the capture directory of ctx for appending
+// (--capture-raw; see ports.CaptureDir). It returns nil when the scan does
+// not capture raw outputs.
+func CaptureFile(ctx context.Context, name string) (*os.File, error) {
+	dir := ports.CaptureDir(ctx)
+	if dir == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create capture directory: %w", err)
+	}
+	f, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open capture file: %w", err)
+	}
+	return f, nil
+}
+
+// CaptureOutput tees stdout into the RawOutputFile capture when ctx asks for
+// one. The returned function closes the capture; capture failures only warn.
+func (b *BaseCLISource) CaptureOutput(ctx context.Context, stdout io.Reader) (io.Reader, func()) {
+	capture, err := CaptureFile(ctx, RawOutputFile)
+	if err != nil {
+		b.logger.Warn("raw output not captured", "error", err.Error())
+	}
+	if capture == nil {
+		return stdout, func() {}
+	}
+	return io.TeeReader(stdout, capture), func() {
+		if err := capture.Close(); err != nil {
+			b.logger.Warn("failed to close raw output capture", "error", err.Error())
+		}
+	}
+}
+
+// ReplayOutput feeds the stdout captured in dir to handler line by line, as
+// ExecuteCLI would while the tool runs, and finalizes it.
+func ReplayOutput(dir string, handler OutputHandler) error {
+	f, err := os.Open(filepath.Join(dir, RawOutputFile))
+	if err != nil {
+		return fmt.Errorf("failed to open raw capture: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		if err := handler.ProcessLine(scanner.Bytes()); err != nil {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read raw capture: %w", err)
+	}
+	return handler.Finalize()
+}
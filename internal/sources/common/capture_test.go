@@ -0,0 +1,45 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+)
+
+// TestBaseCLISource_CaptureAndReplay tests that captured stdout replays the same lines
+func TestBaseCLISource_CaptureAndReplay(t *testing.T) {
+	base := NewBaseCLISource(logx.NewSilent(), BaseCLIConfig{
+		SourceName: "test",
+		ExecPath:   "echo",
+		Timeout:    5 * time.Second,
+	})
+	defer base.Close()
+
+	dir := t.TempDir()
+	ctx := ports.WithCaptureDir(context.Background(), dir)
+	target := domain.Target{Root: "example.com"}
+	if _, _, err := base.ExecuteCLI(ctx, target, []string{"a.example.com\nb.example.com"}, &mockHandler{}); err != nil {
+		t.Fatalf("ExecuteCLI failed: %v", err)
+	}
+
+	replayed := &mockHandler{}
+	if err := ReplayOutput(dir, replayed); err != nil {
+		t.Fatalf("ReplayOutput failed: %v", err)
+	}
+	lines := replayed.getLines()
+	if len(lines) != 2 || lines[0] != "a.example.com" || lines[1] != "b.example.com" {
+		t.Errorf("expected the captured lines to replay, got %v", lines)
+	}
+}
+
+// TestBaseCLISource_NoCapture tests that nothing is written without a capture dir
+func TestBaseCLISource_NoCapture(t *testing.T) {
+	file, err := CaptureFile(context.Background(), RawOutputFile)
+	if err != nil || file != nil {
+		t.Errorf("expected no capture file, got %v, %v", file, err)
+	}
+}
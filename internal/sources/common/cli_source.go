@@ -8,6 +8,8 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"regexp"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,6 +30,14 @@ type OutputHandler interface {
 	Finalize() error
 }
 
+// ProgressCounter is an optional OutputHandler extension. ExecuteCLI reports
+// Progress() through the progress channel after each stdout line, so the
+// artifact count ticks live in the UI while the tool runs.
+type ProgressCounter interface {
+	// Progress returns the number of results collected so far.
+	Progress() int
+}
+
 // BaseCLISource provides common functionality for CLI-based reconnaissance sources.
 // It handles subprocess execution, I/O management, signal handling, and resource cleanup.
 //
@@ -41,7 +51,10 @@ type BaseCLISource struct {
 	execPath   string        // Path to CLI binary
 	timeout    time.Duration // Timeout for subprocess
 	progressCh chan ports.ProgressUpdate
-	chClosed   bool          // Track if progressCh is closed
+	chClosed   bool            // Track if progressCh is closed
+	progressMu sync.Mutex      // Guards progressCh sends against Close
+	runtime    Runtime         // Where the tool runs (default: host)
+	container  ContainerConfig // Used with RuntimeDocker
 
 	// Process management
 	mu  sync.Mutex
@@ -95,12 +108,20 @@ func (b *BaseCLISource) ExecuteCLI(
 
 	b.logger.Info("executing CLI command",
 		"exec_path", b.execPath,
+		"runtime", b.Runtime(),
 		"args", args,
 		"timeout", b.timeout.String(),
 	)
 
-	// Build command with context
-	cmd := exec.CommandContext(ctx, b.execPath, args...)
+	// With --sample the command is killed once the handler counts enough
+	// artifacts (see ports.SampleSize)
+	sample := ports.SampleSize(ctx)
+	ctx, stopSample := context.WithCancel(ctx)
+	defer stopSample()
+	sampled := false
+
+	// Build command with context (host binary or container)
+	cmd := b.Command(ctx, args)
 
 	// Create stdout pipe for streaming output
 	stdout, err := cmd.StdoutPipe()
@@ -143,13 +164,19 @@ func (b *BaseCLISource) ExecuteCLI(
 		stderrMu.Unlock()
 	}()
 
+	// Archive the raw output for "aethonx replay" (--capture-raw)
+	output, closeCapture := b.CaptureOutput(ctx, stdout)
+	defer closeCapture()
+
 	// Process stdout line by line
-	scanner := bufio.NewScanner(stdout)
+	scanner := bufio.NewScanner(output)
 
 	// Increase buffer size for large output lines
 	buf := make([]byte, 0, 64*1024)
 	scanner.Buffer(buf, 10*1024*1024) // 10MB max token size
 
+	counter, countsProgress := handler.(ProgressCounter)
+
 	for scanner.Scan() {
 		line := scanner.Bytes()
 
@@ -158,6 +185,15 @@ func (b *BaseCLISource) ExecuteCLI(
 			b.logger.Warn("handler error", "error", err.Error())
 			// Continue processing despite handler errors
 		}
+
+		if countsProgress {
+			b.EmitProgress(counter.Progress(), "")
+			if sample > 0 && counter.Progress() >= sample {
+				sampled = true
+				stopSample()
+				break
+			}
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -184,6 +220,11 @@ func (b *BaseCLISource) ExecuteCLI(
 		b.logger.Debug("subprocess stderr", "output", stderrOutput)
 	}
 
+	if sampled {
+		b.logger.Info("CLI command stopped after sample", "sample", sample)
+		return result, stderrOutput, nil
+	}
+
 	// Handle process exit errors
 	if waitErr != nil {
 		// Log execution time even on failure
@@ -205,8 +246,15 @@ func (b *BaseCLISource) ExecuteCLI(
 	return result, stderrOutput, nil
 }
 
-// EmitProgress sends a progress update (non-blocking).
+// EmitProgress sends a progress update (non-blocking). Safe to call from
+// worker goroutines concurrently with Close: updates after Close are dropped.
 func (b *BaseCLISource) EmitProgress(artifactCount int, message string) {
+	b.progressMu.Lock()
+	defer b.progressMu.Unlock()
+	if b.chClosed {
+		return
+	}
+
 	select {
 	case b.progressCh <- ports.ProgressUpdate{
 		ArtifactCount: artifactCount,
@@ -218,7 +266,7 @@ func (b *BaseCLISource) EmitProgress(artifactCount int, message string) {
 }
 
 // ProgressChannel returns the progress channel for streaming updates.
-// Implements ports.StreamingSource.
+// Implements ports.ProgressSource.
 func (b *BaseCLISource) ProgressChannel() <-chan ports.ProgressUpdate {
 	return b.progressCh
 }
@@ -265,10 +313,12 @@ func (b *BaseCLISource) Close() error {
 	b.logger.Debug("closing CLI source")
 
 	// Close progress channel to prevent goroutine leaks (only once)
+	b.progressMu.Lock()
 	if !b.chClosed {
 		close(b.progressCh)
 		b.chClosed = true
 	}
+	b.progressMu.Unlock()
 
 	// Kill process if still running
 	// Note: We hold the mutex during the entire operation to prevent races
@@ -298,9 +348,14 @@ func (b *BaseCLISource) Close() error {
 }
 
 // DefaultInitialize provides a default Initialize implementation for AdvancedSource.
-// Verifies that the CLI binary exists and is executable.
+// Verifies that the CLI binary exists and is executable (or, with
+// RuntimeDocker, that docker is available).
 func (b *BaseCLISource) DefaultInitialize(sourceName, installInstructions string) error {
-	b.logger.Debug("initializing CLI source", "exec_path", b.execPath)
+	b.logger.Debug("initializing CLI source", "exec_path", b.execPath, "runtime", b.Runtime())
+
+	if b.Runtime() == RuntimeDocker {
+		return b.initializeContainer(sourceName)
+	}
 
 	// Check if binary exists in PATH
 	execPath, err := exec.LookPath(b.execPath)
@@ -347,10 +402,10 @@ func (b *BaseCLISource) DefaultHealthCheck(ctx context.Context) error {
 	defer cancel()
 
 	// Try running with -version or -h flag
-	cmd := exec.CommandContext(ctx, b.execPath, "-version")
+	cmd := b.Command(ctx, []string{"-version"})
 	if err := cmd.Run(); err != nil {
 		// Try -h as fallback
-		cmd = exec.CommandContext(ctx, b.execPath, "-h")
+		cmd = b.Command(ctx, []string{"-h"})
 		if err := cmd.Run(); err != nil {
 			return fmt.Errorf("health check failed: %w", err)
 		}
@@ -359,11 +414,41 @@ func (b *BaseCLISource) DefaultHealthCheck(ctx context.Context) error {
 	return nil
 }
 
+// versionPattern matches version tokens such as "v2.6.3" or "1.0".
+var versionPattern = regexp.MustCompile(`v?\d+\.\d+(\.\d+)?`)
+
+// Diagnostics implements ports.DiagnosticSource by reporting the version
+// printed by "<binary> -version". Version detection is best-effort: tools
+// without a version flag report an empty version.
+func (b *BaseCLISource) Diagnostics(ctx context.Context) (ports.SourceDiagnostics, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Some tools print the version to stderr or exit non-zero after printing it
+	output, err := b.Command(ctx, []string{"-version"}).CombinedOutput()
+	if err != nil && len(output) == 0 {
+		return ports.SourceDiagnostics{}, fmt.Errorf("version check failed: %w", err)
+	}
+
+	return ports.SourceDiagnostics{Version: parseVersion(string(output))}, nil
+}
+
+// parseVersion extracts the first version token from a tool's output.
+func parseVersion(output string) string {
+	return versionPattern.FindString(output)
+}
+
 // GetExecPath returns the resolved executable path.
 func (b *BaseCLISource) GetExecPath() string {
 	return b.execPath
 }
 
+// CommandLine renders the executed command for provenance records.
+func (b *BaseCLISource) CommandLine(args []string) string {
+	name, argv := b.commandArgs("", args, nil)
+	return strings.TrimSpace(name + " " + strings.Join(argv, " "))
+}
+
 // GetTimeout returns the configured timeout.
 func (b *BaseCLISource) GetTimeout() time.Duration {
 	return b.timeout
@@ -379,6 +464,13 @@ func (b *BaseCLISource) GetLogger() logx.Logger {
 	return b.logger
 }
 
+// SetLogger replaces the logger with the scan-scoped one (scan_id, stage, source).
+// Embedding sources implement ports.LogScopedSource through it; handlers that
+// call GetLogger at run time pick the scoped logger up as well.
+func (b *BaseCLISource) SetLogger(logger logx.Logger) {
+	b.logger = logger
+}
+
 // ProcessOutput processes stdout using the given handler.
 // This is useful for sources that need direct control over subprocess execution
 // (e.g., RunWithInput using stdin).
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
 	"aethonx/internal/platform/logx"
 )
 
@@ -263,6 +264,71 @@ func TestBaseCLISource_EmitProgress(t *testing.T) {
 	}
 }
 
+// countingHandler is a mockHandler that implements ProgressCounter
+type countingHandler struct {
+	mockHandler
+}
+
+func (c *countingHandler) Progress() int {
+	return len(c.getLines())
+}
+
+// TestBaseCLISource_ExecuteCLI_ReportsProgress tests live progress from a ProgressCounter handler
+func TestBaseCLISource_ExecuteCLI_ReportsProgress(t *testing.T) {
+	base := NewBaseCLISource(logx.NewSilent(), BaseCLIConfig{
+		SourceName:     "test",
+		ExecPath:       "sh",
+		Timeout:        5 * time.Second,
+		ProgressBuffer: 10,
+	})
+	defer base.Close()
+
+	target := domain.Target{Root: "example.com", Mode: domain.ScanModePassive}
+	handler := &countingHandler{}
+	if _, _, err := base.ExecuteCLI(context.Background(), target, []string{"-c", "echo a; echo b; echo c"}, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var counts []int
+	for len(counts) < 3 {
+		select {
+		case update := <-base.ProgressChannel():
+			counts = append(counts, update.ArtifactCount)
+		default:
+			t.Fatalf("expected 3 progress updates, got %v", counts)
+		}
+	}
+	if counts[2] != 3 {
+		t.Errorf("expected running count to reach 3, got %v", counts)
+	}
+}
+
+// TestBaseCLISource_ExecuteCLI_StopsAfterSample tests that --sample kills the command early
+func TestBaseCLISource_ExecuteCLI_StopsAfterSample(t *testing.T) {
+	base := NewBaseCLISource(logx.NewSilent(), BaseCLIConfig{
+		SourceName:     "test",
+		ExecPath:       "sh",
+		Timeout:        5 * time.Second,
+		ProgressBuffer: 100,
+	})
+	defer base.Close()
+
+	target := domain.Target{Root: "example.com", Mode: domain.ScanModePassive}
+	handler := &countingHandler{}
+	ctx := ports.WithSampleSize(context.Background(), 2)
+	start := time.Now()
+	_, _, err := base.ExecuteCLI(ctx, target, []string{"-c", "while true; do echo line; done"}, handler)
+	if err != nil {
+		t.Fatalf("a sampled run is not a failure: %v", err)
+	}
+	if handler.Progress() != 2 {
+		t.Errorf("expected reading to stop at the sample size, got %d lines", handler.Progress())
+	}
+	if time.Since(start) > 4*time.Second {
+		t.Error("the command should be killed once the sample is complete")
+	}
+}
+
 // TestBaseCLISource_DefaultInitialize tests binary resolution
 func TestBaseCLISource_DefaultInitialize(t *testing.T) {
 	logger := logx.NewWithLevel(logx.LevelInfo)
@@ -423,6 +489,33 @@ func TestBaseCLISource_ConcurrentClose(t *testing.T) {
 	}
 }
 
+func TestBaseCLISource_EmitProgressDuringClose(t *testing.T) {
+	logger := logx.NewWithLevel(logx.LevelInfo)
+
+	base := NewBaseCLISource(logger, BaseCLIConfig{
+		SourceName: "test",
+		ExecPath:   "echo",
+		Timeout:    5 * time.Second,
+	})
+
+	// Worker goroutines keep emitting while the source is closed:
+	// must not panic with "send on closed channel"
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				base.EmitProgress(i*100+j, "chunk")
+			}
+		}(i)
+	}
+	base.Close()
+	wg.Wait()
+
+	base.EmitProgress(1, "after close")
+}
+
 // BenchmarkBaseCLISource_ExecuteCLI benchmarks ExecuteCLI performance
 func BenchmarkBaseCLISource_ExecuteCLI(b *testing.B) {
 	logger := logx.NewWithLevel(logx.LevelError) // Minimal logging
@@ -504,3 +597,50 @@ func TestLookPath(t *testing.T) {
 		t.Error("expected error for non-existent binary, got nil")
 	}
 }
+
+// TestParseVersion tests version extraction from tool output
+func TestParseVersion(t *testing.T) {
+	tests := map[string]string{
+		"[INF] Current httpx version v1.6.9 (latest)": "v1.6.9",
+		"v4.2.0\n":                "v4.2.0",
+		"shodan 1.31":             "1.31",
+		"Usage of waybackurls:\n": "",
+	}
+
+	for output, want := range tests {
+		if got := parseVersion(output); got != want {
+			t.Errorf("parseVersion(%q) = %q, want %q", output, got, want)
+		}
+	}
+}
+
+// TestBaseCLISource_Diagnostics tests best-effort version reporting
+func TestBaseCLISource_Diagnostics(t *testing.T) {
+	logger := logx.NewWithLevel(logx.LevelInfo)
+
+	base := NewBaseCLISource(logger, BaseCLIConfig{
+		SourceName: "test",
+		ExecPath:   "echo", // Prints "-version": runs, but reports no version
+		Timeout:    5 * time.Second,
+	})
+	defer base.Close()
+
+	diag, err := base.Diagnostics(context.Background())
+	if err != nil {
+		t.Fatalf("Diagnostics failed for echo: %v", err)
+	}
+	if diag.Version != "" {
+		t.Errorf("expected empty version, got %q", diag.Version)
+	}
+
+	missing := NewBaseCLISource(logger, BaseCLIConfig{
+		SourceName: "test",
+		ExecPath:   "nonexistent-binary-xyz",
+		Timeout:    5 * time.Second,
+	})
+	defer missing.Close()
+
+	if _, err := missing.Diagnostics(context.Background()); err == nil {
+		t.Error("expected error for missing binary, got nil")
+	}
+}
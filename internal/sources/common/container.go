@@ -0,0 +1,180 @@
+package common
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/registry"
+)
+
+// Runtime selects where a CLI source runs its tool.
+type Runtime string
+
+const (
+	RuntimeHost   Runtime = "host"   // Binary from PATH or exec_path
+	RuntimeDocker Runtime = "docker" // Pinned container image
+)
+
+// ContainerConfig configures RuntimeDocker.
+type ContainerConfig struct {
+	Image   string   // Pinned image whose entrypoint is the tool
+	Network string   // docker run --network (default: host)
+	Mounts  []string // Extra host paths bound at the same path (e.g., wordlists)
+}
+
+// RuntimeFields returns the Custom options that let a CLI source run in a
+// container; sources append them to their ConfigSchema. defaultImage is the
+// pinned image ("" = users must set docker_image).
+func RuntimeFields(defaultImage string) []ports.ConfigField {
+	return []ports.ConfigField{
+		{Name: "runtime", Type: ports.ConfigTypeString, Default: string(RuntimeHost), Description: "Where the tool runs: host (binary) or docker (pinned image)"},
+		{Name: "docker_image", Type: ports.ConfigTypeString, Default: defaultImage, Description: "Image for runtime docker (pin a tag or digest)"},
+		{Name: "docker_network", Type: ports.ConfigTypeString, Default: "host", Description: "Network for runtime docker"},
+		{Name: "docker_mounts", Type: ports.ConfigTypeStringList, Description: "Host paths mounted for runtime docker (e.g., files in custom_flags)"},
+	}
+}
+
+// RuntimeFromOptions reads the RuntimeFields of decoded options.
+func RuntimeFromOptions(opts registry.SourceOptions) (Runtime, ContainerConfig, error) {
+	rt := Runtime(opts.String("runtime"))
+	container := ContainerConfig{
+		Image:   opts.String("docker_image"),
+		Network: opts.String("docker_network"),
+		Mounts:  opts.Strings("docker_mounts"),
+	}
+
+	switch rt {
+	case RuntimeHost:
+	case RuntimeDocker:
+		if container.Image == "" {
+			return "", ContainerConfig{}, fmt.Errorf("runtime docker needs docker_image")
+		}
+	default:
+		return "", ContainerConfig{}, fmt.Errorf("invalid runtime %q (valid: host, docker)", rt)
+	}
+	return rt, container, nil
+}
+
+// SetRuntime selects where the tool runs. Call before Initialize.
+func (b *BaseCLISource) SetRuntime(rt Runtime, container ContainerConfig) {
+	b.runtime = rt
+	b.container = container
+}
+
+// Runtime returns where the tool runs.
+func (b *BaseCLISource) Runtime() Runtime {
+	if b.runtime == "" {
+		return RuntimeHost
+	}
+	return b.runtime
+}
+
+// Command builds the command that runs the tool with args. With
+// RuntimeDocker it runs the image with stdin attached (for tools fed through
+// stdin) and binds mounts (host directories the tool reads or writes, such
+// as temp dirs) at the same path, so args need no rewriting.
+//
+// Cancelling ctx only kills the docker CLI, which leaves the container
+// running, so docker commands get a unique --name and a Cancel that kills
+// that container first.
+func (b *BaseCLISource) Command(ctx context.Context, args []string, mounts ...string) *exec.Cmd {
+	if b.Runtime() != RuntimeDocker {
+		name, argv := b.commandArgs("", args, mounts)
+		return exec.CommandContext(ctx, name, argv...)
+	}
+
+	container := newContainerName()
+	name, argv := b.commandArgs(container, args, mounts)
+	cmd := exec.CommandContext(ctx, name, argv...)
+	cmd.Cancel = func() error {
+		b.killContainer(container)
+		return cmd.Process.Kill()
+	}
+	// Do not wait forever on output pipes if the container lingers
+	cmd.WaitDelay = containerKillTimeout
+	return cmd
+}
+
+// commandArgs returns the binary and arguments that run the tool. container
+// names the docker container ("" = unnamed, e.g. for CommandLine).
+func (b *BaseCLISource) commandArgs(container string, args []string, mounts []string) (string, []string) {
+	if b.Runtime() != RuntimeDocker {
+		return b.execPath, args
+	}
+
+	argv := []string{"run", "--rm", "-i"}
+	if container != "" {
+		argv = append(argv, "--name", container)
+	}
+	if b.container.Network != "" {
+		argv = append(argv, "--network", b.container.Network)
+	}
+	if runtime.GOOS == "linux" {
+		// Files written to mounts stay owned (and removable) by the user
+		argv = append(argv,
+			"--user", strconv.Itoa(os.Getuid())+":"+strconv.Itoa(os.Getgid()),
+			"-e", "HOME=/tmp")
+	}
+	for _, m := range append(append([]string(nil), b.container.Mounts...), mounts...) {
+		argv = append(argv, "-v", m+":"+m)
+	}
+	argv = append(argv, b.container.Image)
+	return dockerBinary, append(argv, args...)
+}
+
+// dockerBinary is the container CLI used by RuntimeDocker (a var for tests).
+var dockerBinary = "docker"
+
+// containerKillTimeout bounds docker kill and the wait for the output pipes
+// of a cancelled container.
+const containerKillTimeout = 10 * time.Second
+
+// newContainerName returns a unique name for a tool container.
+func newContainerName() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("aethonx-%d", time.Now().UnixNano())
+	}
+	return "aethonx-" + hex.EncodeToString(b)
+}
+
+// killContainer stops container after its run was cancelled. With --rm
+// docker removes it once killed.
+func (b *BaseCLISource) killContainer(container string) {
+	ctx, cancel := context.WithTimeout(context.Background(), containerKillTimeout)
+	defer cancel()
+	if out, err := exec.CommandContext(ctx, dockerBinary, "kill", container).CombinedOutput(); err != nil {
+		b.logger.Warn("failed to kill cancelled container",
+			"container", container,
+			"error", err.Error(),
+			"output", strings.TrimSpace(string(out)),
+		)
+	}
+}
+
+// initializeContainer checks that docker is available. A missing image is
+// not an error: docker pulls it on the first run.
+func (b *BaseCLISource) initializeContainer(sourceName string) error {
+	if _, err := exec.LookPath(dockerBinary); err != nil {
+		return fmt.Errorf("%s runs with runtime docker but docker is not in PATH: %w", sourceName, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := exec.CommandContext(ctx, dockerBinary, "image", "inspect", b.container.Image).Run(); err != nil {
+		b.logger.Info("container image not present locally, it will be pulled on first run", "image", b.container.Image)
+		return nil
+	}
+
+	b.logger.Info("CLI source initialized with runtime docker", "image", b.container.Image)
+	return nil
+}
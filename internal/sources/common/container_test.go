@@ -0,0 +1,145 @@
+package common
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+)
+
+func TestRuntimeFromOptions(t *testing.T) {
+	schema := RuntimeFields("tool/image:v1.0.0")
+
+	tests := []struct {
+		name      string
+		custom    map[string]interface{}
+		wantRT    Runtime
+		wantImage string
+		wantErr   bool
+	}{
+		{"defaults to host", nil, RuntimeHost, "tool/image:v1.0.0", false},
+		{"docker with default image", map[string]interface{}{"runtime": "docker"}, RuntimeDocker, "tool/image:v1.0.0", false},
+		{"docker with custom image", map[string]interface{}{"runtime": "docker", "docker_image": "mirror/tool@sha256:abc"}, RuntimeDocker, "mirror/tool@sha256:abc", false},
+		{"unknown runtime", map[string]interface{}{"runtime": "podman"}, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := registry.DecodeConfig(schema, tt.custom)
+			if err != nil {
+				t.Fatalf("DecodeConfig() error = %v", err)
+			}
+			rt, container, err := RuntimeFromOptions(opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RuntimeFromOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if rt != tt.wantRT || container.Image != tt.wantImage {
+				t.Errorf("RuntimeFromOptions() = (%q, %q), want (%q, %q)", rt, container.Image, tt.wantRT, tt.wantImage)
+			}
+		})
+	}
+
+	t.Run("docker without image", func(t *testing.T) {
+		opts, err := registry.DecodeConfig(RuntimeFields(""), map[string]interface{}{"runtime": "docker"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := RuntimeFromOptions(opts); err == nil {
+			t.Error("expected an error when no image is configured")
+		}
+	})
+}
+
+func TestBaseCLISource_Command(t *testing.T) {
+	base := NewBaseCLISource(logx.NewSilent(), BaseCLIConfig{
+		SourceName: "test",
+		ExecPath:   "tool",
+		Timeout:    time.Second,
+	})
+
+	cmd := base.Command(context.Background(), []string{"-json"}, "/tmp/out")
+	if got := strings.Join(cmd.Args, " "); got != "tool -json" {
+		t.Errorf("host command = %q, want %q", got, "tool -json")
+	}
+
+	base.SetRuntime(RuntimeDocker, ContainerConfig{
+		Image:   "tool/image:v1.0.0",
+		Network: "host",
+		Mounts:  []string{"/data/wordlists"},
+	})
+	cmd = base.Command(context.Background(), []string{"-json"}, "/tmp/out")
+	got := strings.Join(cmd.Args, " ")
+
+	for _, want := range []string{
+		"docker run --rm -i --name aethonx-",
+		"--network host",
+		"-v /data/wordlists:/data/wordlists -v /tmp/out:/tmp/out tool/image:v1.0.0 -json",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("docker command %q does not contain %q", got, want)
+		}
+	}
+	if runtime.GOOS == "linux" && !strings.Contains(got, "--user ") {
+		t.Errorf("docker command %q should run as the current user on linux", got)
+	}
+	if !strings.HasPrefix(base.CommandLine([]string{"-json"}), "docker run --rm -i") {
+		t.Errorf("CommandLine() should render the docker invocation, got %q", base.CommandLine([]string{"-json"}))
+	}
+	if strings.Contains(base.CommandLine([]string{"-json"}), "--name") {
+		t.Errorf("CommandLine() should not include the per-run container name, got %q", base.CommandLine([]string{"-json"}))
+	}
+}
+
+func TestBaseCLISource_Command_CancelKillsContainer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake docker is a shell script")
+	}
+
+	// Fake docker: "run" blocks like a long scan, "kill" records its args
+	dir := t.TempDir()
+	killed := filepath.Join(dir, "killed")
+	script := "#!/bin/sh\nif [ \"$1\" = kill ]; then echo \"$@\" > " + killed + "; exit 0; fi\nexec sleep 30\n"
+	fake := filepath.Join(dir, "docker")
+	if err := os.WriteFile(fake, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	prev := dockerBinary
+	dockerBinary = fake
+	t.Cleanup(func() { dockerBinary = prev })
+
+	base := NewBaseCLISource(logx.NewSilent(), BaseCLIConfig{SourceName: "test", ExecPath: "tool", Timeout: time.Minute})
+	base.SetRuntime(RuntimeDocker, ContainerConfig{Image: "tool/image:v1.0.0"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := base.Command(ctx, []string{"-json"})
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	start := time.Now()
+	cmd.Wait()
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("cancelled run did not stop")
+	}
+
+	var name string
+	for i, arg := range cmd.Args {
+		if arg == "--name" && i+1 < len(cmd.Args) {
+			name = cmd.Args[i+1]
+		}
+	}
+	got, err := os.ReadFile(killed)
+	if err != nil {
+		t.Fatalf("container was not killed on cancel: %v", err)
+	}
+	if strings.TrimSpace(string(got)) != "kill "+name {
+		t.Errorf("docker called with %q, want %q", strings.TrimSpace(string(got)), "kill "+name)
+	}
+}
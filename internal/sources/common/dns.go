@@ -0,0 +1,26 @@
+// internal/sources/common/dns.go
+package common
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/miekg/dns"
+)
+
+// resolvConf is the system resolver configuration read by SystemDNSServer.
+var resolvConf = "/etc/resolv.conf"
+
+// SystemDNSServer returns the first nameserver of the system resolver
+// configuration as host:port, for sources that send raw DNS queries (DNSSEC,
+// CAA) the net package cannot make.
+func SystemDNSServer() (string, error) {
+	conf, err := dns.ClientConfigFromFile(resolvConf)
+	if err != nil {
+		return "", err
+	}
+	if len(conf.Servers) == 0 {
+		return "", fmt.Errorf("no nameservers in %s", resolvConf)
+	}
+	return net.JoinHostPort(conf.Servers[0], conf.Port), nil
+}
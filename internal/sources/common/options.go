@@ -0,0 +1,54 @@
+// internal/sources/common/options.go
+package common
+
+import (
+	"context"
+	"net"
+
+	"aethonx/internal/platform/cache"
+	"aethonx/internal/platform/httpclient"
+)
+
+// Resolver is the DNS lookup used by sources; *net.Resolver implements it.
+type Resolver interface {
+	LookupIP(ctx context.Context, network, host string) ([]net.IP, error)
+}
+
+// Deps are the dependencies a source constructor can take from its caller
+// instead of building them. Nil fields mean "use the platform default".
+type Deps struct {
+	HTTPClient *httpclient.Client
+	Cache      cache.Cache
+	Resolver   Resolver
+}
+
+// Option injects a dependency into a source constructor.
+type Option func(*Deps)
+
+// WithHTTPClient makes the source send its requests through client (shared
+// rate limits, proxies, test servers).
+func WithHTTPClient(client *httpclient.Client) Option {
+	return func(d *Deps) { d.HTTPClient = client }
+}
+
+// WithCache makes the source cache its responses in c instead of a private
+// in-memory cache. The caller owns c and its cleanup.
+func WithCache(c cache.Cache) Option {
+	return func(d *Deps) { d.Cache = c }
+}
+
+// WithResolver makes the source resolve names through r.
+func WithResolver(r Resolver) Option {
+	return func(d *Deps) { d.Resolver = r }
+}
+
+// ApplyOptions collects the dependencies injected by opts.
+func ApplyOptions(opts ...Option) Deps {
+	var d Deps
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&d)
+		}
+	}
+	return d
+}
@@ -0,0 +1,65 @@
+// internal/sources/crtsh/capture.go
+package crtsh
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/sources/common"
+)
+
+// captureFile es la captura cruda de crtsh: una línea JSON por consulta.
+const captureFile = "records.jsonl"
+
+// capturedQuery es una consulta completada y sus registros tal como llegaron.
+type capturedQuery struct {
+	Query   string       `json:"query"`
+	Records []certRecord `json:"records"`
+}
+
+// capture archiva los registros de una consulta si el escaneo captura las
+// salidas crudas (--capture-raw). Un fallo solo se avisa.
+func (c *CRT) capture(ctx context.Context, query string, records []certRecord) {
+	f, err := common.CaptureFile(ctx, captureFile)
+	if err == nil && f != nil {
+		err = json.NewEncoder(f).Encode(capturedQuery{Query: query, Records: records})
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		c.logger.Warn("crtsh records not captured", "query", query, "error", err.Error())
+	}
+}
+
+// Replay reconstruye el resultado a partir de los registros capturados en
+// dir por una ejecución anterior. Implementa ports.ReplayableSource.
+func (c *CRT) Replay(ctx context.Context, target domain.Target, dir string) (*domain.ScanResult, error) {
+	f, err := os.Open(filepath.Join(dir, captureFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open raw capture: %w", err)
+	}
+	defer f.Close()
+
+	result := domain.NewScanResult(target)
+	result.Metadata.SourcesUsed = []string{c.Name()}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 256*1024*1024) // Una consulta completa puede ocupar cientos de MB
+	for scanner.Scan() {
+		var q capturedQuery
+		if err := json.Unmarshal(scanner.Bytes(), &q); err != nil {
+			return nil, fmt.Errorf("failed to parse raw capture: %w", err)
+		}
+		result.AddArtifacts(c.chunkArtifacts(ctx, q.Query, q.Records, target)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read raw capture: %w", err)
+	}
+	return result, nil
+}
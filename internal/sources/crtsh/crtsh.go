@@ -5,6 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -14,15 +18,42 @@ import (
 	"aethonx/internal/platform/httpclient"
 	"aethonx/internal/platform/logx"
 	"aethonx/internal/platform/registry"
+	"aethonx/internal/sources/common"
 )
 
+// Modos de chunking de las consultas a crt.sh.
+const (
+	ChunkingAuto   = "auto"   // Consulta completa; por chunks si falla
+	ChunkingAlways = "always" // Siempre por chunks (orgs con muchos certificados)
+	ChunkingNever  = "never"  // Solo la consulta completa
+
+	BackendHTTP     = "http"     // API JSON de crt.sh
+	BackendPostgres = "postgres" // Endpoint PostgreSQL público de crt.sh
+
+	defaultBaseURL   = "https://crt.sh/"
+	defaultCursorTTL = 24 * time.Hour
+)
+
+// chunkAlphabet son los primeros caracteres de etiqueta de los chunks por
+// identidad. "_" y "%" son comodines del LIKE de crt.sh y no sirven de prefijo.
+const chunkAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// configSchema declara las opciones Custom de crtsh.
+var configSchema = []ports.ConfigField{
+	{Name: "chunking", Type: ports.ConfigTypeString, Default: ChunkingAuto, Description: "Query in identity chunks: auto (when the full query fails), always, never"},
+	{Name: "cursor_dir", Type: ports.ConfigTypeString, Description: "Directory of resumable chunk cursors (default ~/.aethonx/cursors)"},
+	{Name: "cursor_ttl", Type: ports.ConfigTypeDuration, Default: defaultCursorTTL.String(), Description: "Discard cursors older than this and restart the fetch"},
+	{Name: "backend", Type: ports.ConfigTypeString, Default: BackendHTTP, Description: "Query crt.sh over http (JSON API) or postgres (public PostgreSQL endpoint)"},
+	{Name: "postgres_addr", Type: ports.ConfigTypeString, Default: defaultPostgresAddr, Description: "crt.sh PostgreSQL host:port (backend: postgres)"},
+	{Name: "pool_size", Type: ports.ConfigTypeInt, Default: defaultPoolSize, Description: "Max open PostgreSQL connections (backend: postgres)"},
+	{Name: "query_timeout", Type: ports.ConfigTypeDuration, Default: defaultQueryTimeout.String(), Description: "Per-query timeout, also set as statement_timeout (backend: postgres)"},
+}
+
 // Auto-registro de la source al importar el package
 func init() {
 	if err := registry.Global().Register(
 		"crtsh",
-		func(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
-			return New(logger), nil
-		},
+		factory,
 		ports.SourceMetadata{
 			Name:         "crtsh",
 			Description:  "Certificate Transparency log search via crt.sh",
@@ -41,6 +72,9 @@ func init() {
 			},
 			Priority:  10, // Alta prioridad (passive discovery)
 			StageHint: 0,  // Stage 0 explícito
+
+			ConfigSchema: configSchema,
+			Providers:    []string{"crt.sh"},
 		},
 	); err != nil {
 		// Log error but don't panic - allow application to start
@@ -49,16 +83,100 @@ func init() {
 	}
 }
 
+// factory crea la source desde SourceConfig (Custom según configSchema).
+func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("crtsh config: %w", err)
+	}
+
+	crtConfig := CRTConfig{
+		Chunking:     opts.String("chunking"),
+		CursorDir:    opts.String("cursor_dir"),
+		CursorTTL:    opts.Duration("cursor_ttl"),
+		Backend:      opts.String("backend"),
+		PostgresAddr: opts.String("postgres_addr"),
+		PoolSize:     opts.Int("pool_size"),
+		QueryTimeout: opts.Duration("query_timeout"),
+	}
+	switch crtConfig.Chunking {
+	case ChunkingAuto, ChunkingAlways, ChunkingNever:
+	default:
+		return nil, fmt.Errorf("crtsh chunking must be auto, always or never, got %q", crtConfig.Chunking)
+	}
+	switch crtConfig.Backend {
+	case BackendHTTP, BackendPostgres:
+	default:
+		return nil, fmt.Errorf("crtsh backend must be http or postgres, got %q", crtConfig.Backend)
+	}
+	if crtConfig.Backend == BackendPostgres {
+		if _, _, err := net.SplitHostPort(crtConfig.PostgresAddr); err != nil {
+			return nil, fmt.Errorf("crtsh postgres_addr must be host:port, got %q", crtConfig.PostgresAddr)
+		}
+		if crtConfig.PoolSize <= 0 || crtConfig.PoolSize > 16 {
+			return nil, fmt.Errorf("crtsh pool_size must be between 1 and 16, got %d", crtConfig.PoolSize)
+		}
+	}
+	if crtConfig.CursorDir == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			crtConfig.CursorDir = filepath.Join(home, ".aethonx", "cursors")
+		}
+	}
+
+	return NewWithConfig(logger, crtConfig), nil
+}
+
+// CRTConfig configura el fetch por chunks de crt.sh.
+type CRTConfig struct {
+	Chunking  string        // ChunkingAuto (default), ChunkingAlways o ChunkingNever
+	CursorDir string        // Directorio de los cursores ("" = sin persistencia)
+	CursorTTL time.Duration // Antigüedad máxima de un cursor para retomarlo
+	BaseURL   string        // Endpoint de crt.sh (default https://crt.sh/)
+
+	// Backend postgres: mismas consultas por el endpoint PostgreSQL de crt.sh
+	Backend      string        // BackendHTTP (default) o BackendPostgres
+	PostgresAddr string        // host:port (default crt.sh:5432)
+	PoolSize     int           // Conexiones abiertas como máximo (default 2)
+	QueryTimeout time.Duration // Timeout por consulta (default 2m)
+}
+
 // CRT implementa una fuente que consulta la base de datos crt.sh
 // para descubrir certificados SSL/TLS y subdominios asociados.
+//
+// Para orgs grandes la consulta %.root puede tardar más que el timeout; en
+// ese caso se divide por identidad (a%.root, b%.root, ... y el root exacto),
+// emitiendo los artifacts de cada chunk al completarlo. Los chunks que vuelven
+// a fallar se dividen una vez más por el segundo carácter. Cada chunk queda
+// registrado en un cursor en disco, así que un fetch interrumpido se retoma
+// en la siguiente ejecución en vez de empezar de cero.
 type CRT struct {
 	client     httpclient.Client
 	logger     logx.Logger
 	progressCh chan ports.ProgressUpdate
+	config     CRTConfig
+	postgres   *postgresBackend // nil = API HTTP
+
+	artifactCount int // Artifacts emitidos en el fetch en curso (progreso)
 }
 
 // New crea una nueva instancia de la fuente crt.sh con resilience completa.
-func New(logger logx.Logger) ports.Source {
+// opts inyectan el cliente HTTP (por defecto se construye uno propio).
+func New(logger logx.Logger, opts ...common.Option) ports.Source {
+	return NewWithConfig(logger, CRTConfig{}, opts...)
+}
+
+// NewWithConfig crea la fuente crt.sh con la configuración de chunking dada.
+func NewWithConfig(logger logx.Logger, cfg CRTConfig, opts ...common.Option) *CRT {
+	if cfg.Chunking == "" {
+		cfg.Chunking = ChunkingAuto
+	}
+	if cfg.CursorTTL <= 0 {
+		cfg.CursorTTL = defaultCursorTTL
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = defaultBaseURL
+	}
+
 	// Configuración específica para crt.sh
 	httpConfig := httpclient.Config{
 		Timeout:          30 * time.Second,
@@ -68,13 +186,24 @@ func New(logger logx.Logger) ports.Source {
 		UserAgent:        "AethonX/1.0 (RDAP-like reconnaissance tool; +https://github.com/yourusername/aethonx)",
 		RateLimit:        2.0, // 2 req/s - ser respetuoso con crt.sh
 		RateLimitBurst:   1,
+		Upstream:         "crt.sh", // Presupuesto compartido entre scans concurrentes
 	}
 
-	return &CRT{
-		client:     *httpclient.New(httpConfig, logger),
+	client := common.ApplyOptions(opts...).HTTPClient
+	if client == nil {
+		client = httpclient.New(httpConfig, logger)
+	}
+
+	c := &CRT{
+		client:     *client,
 		logger:     logger.With("source", "crtsh"),
 		progressCh: make(chan ports.ProgressUpdate, 10), // Buffered channel
+		config:     cfg,
+	}
+	if cfg.Backend == BackendPostgres {
+		c.postgres = newPostgresBackend(cfg.PostgresAddr, cfg.PoolSize, cfg.QueryTimeout)
 	}
+	return c
 }
 
 // Name retorna el nombre de la fuente.
@@ -99,49 +228,173 @@ func (c *CRT) Run(ctx context.Context, target domain.Target) (*domain.ScanResult
 	result := domain.NewScanResult(target)
 	result.Metadata.SourcesUsed = []string{c.Name()}
 
-	// Construir URL de la API
-	url := fmt.Sprintf("https://crt.sh/?q=%%25.%s&output=json", target.Root)
-
-	// Fetch JSON usando httpx.Client (con retry, rate limiting, etc.)
-	body, err := c.client.FetchJSON(ctx, url)
+	err := c.fetch(ctx, target, func(artifacts []*domain.Artifact) {
+		result.AddArtifacts(artifacts...)
+	})
 	if err != nil {
-		errMsg := fmt.Sprintf("HTTP request failed: %v", err)
-		result.AddError(c.Name(), errMsg, false) // No fatal - el scan puede continuar
+		// No fatal: los chunks completados se conservan y el scan puede continuar
+		result.AddError(c.Name(), err.Error(), false)
 		c.logger.Warn("crtsh request failed", "target", target.Root, "error", err.Error())
 		return result, err
 	}
 
-	// Parsear JSON
+	c.logger.Info("crtsh scan completed",
+		"target", target.Root,
+		"artifacts", len(result.Artifacts),
+	)
+
+	return result, nil
+}
+
+// fetch consulta crt.sh y pasa a emit los artifacts de cada consulta
+// completada: una sola si la consulta completa responde, una por chunk si no.
+func (c *CRT) fetch(ctx context.Context, target domain.Target, emit func([]*domain.Artifact)) error {
+	root := target.QueryName()
+	cur := loadCursor(c.config.CursorDir, root, c.config.CursorTTL)
+	c.artifactCount = 0
+
+	// Con un cursor pendiente se retoma directamente por chunks
+	if c.config.Chunking != ChunkingAlways && cur.empty() {
+		records, err := c.query(ctx, root, "%."+root)
+		if err == nil {
+			emit(c.chunkArtifacts(ctx, "%."+root, records, target))
+			return nil
+		}
+		if c.config.Chunking == ChunkingNever || ctx.Err() != nil {
+			return err
+		}
+		c.logger.Warn("full crtsh query failed, fetching in chunks", "target", root, "error", err.Error())
+	}
+
+	resumed := 0
+	cur.completed(func(query string, records []certRecord) {
+		emit(c.chunkArtifacts(ctx, query, records, target))
+		resumed++
+	})
+	if resumed > 0 {
+		c.logger.Info("resuming crtsh fetch from cursor", "target", root, "chunks_done", resumed)
+	}
+
+	pending := identityChunks(root)
+	var failed []string
+	for len(pending) > 0 {
+		query := pending[0]
+		pending = pending[1:]
+
+		if cur.isDone(query) {
+			continue
+		}
+		if cur.isSplit(query) {
+			pending = append(splitChunk(query, root), pending...)
+			continue
+		}
+
+		records, err := c.query(ctx, root, query)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("crtsh fetch interrupted with %d chunks pending (rerun to resume): %w", len(pending)+1, ctx.Err())
+			}
+			if sub := splitChunk(query, root); sub != nil {
+				c.logger.Debug("crtsh chunk failed, splitting", "query", query, "error", err.Error())
+				c.saveCursor(cur.markSplit(query))
+				pending = append(sub, pending...)
+				continue
+			}
+			c.logger.Warn("crtsh chunk failed", "query", query, "error", err.Error())
+			failed = append(failed, query)
+			continue
+		}
+
+		c.saveCursor(cur.markDone(query, records))
+		emit(c.chunkArtifacts(ctx, query, records, target))
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d crtsh chunks failed (rerun to resume): %s", len(failed), strings.Join(failed, ", "))
+	}
+	cur.remove()
+	return nil
+}
+
+// query ejecuta una consulta q de crt.sh y parsea los registros.
+func (c *CRT) query(ctx context.Context, root, q string) ([]certRecord, error) {
+	if c.postgres != nil {
+		records, err := c.postgres.records(ctx, root, q)
+		if err != nil {
+			return nil, err
+		}
+		c.logger.Debug("fetched crtsh records via postgres", "query", q, "count", len(records))
+		return records, nil
+	}
+
+	body, err := c.client.FetchJSON(ctx, c.queryURL(q))
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+
+	// Si falla el parsing, crt.sh suele haber devuelto una página HTML de error
 	var records []certRecord
 	if err := json.Unmarshal(body, &records); err != nil {
-		// Si falla el parsing, puede ser que crt.sh devolvió HTML/error
-		result.AddWarning(c.Name(), fmt.Sprintf("failed to parse JSON: %v", err))
-		return result, nil
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	c.logger.Debug("parsed crtsh records", "count", len(records))
+	c.logger.Debug("parsed crtsh records", "query", q, "count", len(records))
+	return records, nil
+}
 
-	// Procesar records y extraer subdominios CON PROGRESO INCREMENTAL
-	artifacts := c.processRecordsWithProgress(ctx, records, target)
+// queryURL es la procedencia de una consulta (URL HTTP o DSN PostgreSQL).
+func (c *CRT) queryURL(q string) string {
+	if c.postgres != nil {
+		return fmt.Sprintf("postgres://%s@%s/%s?q=%s", postgresUser, c.postgres.addr, postgresDatabase, url.QueryEscape(q))
+	}
+	return c.config.BaseURL + "?q=" + url.QueryEscape(q) + "&output=json"
+}
 
-	// Añadir artifacts al resultado
+// chunkArtifacts procesa los registros de una consulta y anota su procedencia.
+func (c *CRT) chunkArtifacts(ctx context.Context, query string, records []certRecord, target domain.Target) []*domain.Artifact {
+	c.capture(ctx, query, records)
+	artifacts := c.processRecordsWithProgress(ctx, records, target)
 	for _, a := range artifacts {
-		result.AddArtifact(a)
+		a.SetProvenanceQuery(c.Name(), c.queryURL(query))
 	}
+	return artifacts
+}
 
-	c.logger.Info("crtsh scan completed",
-		"target", target.Root,
-		"artifacts", len(artifacts),
-	)
+// saveCursor registra (sin abortar el fetch) un fallo al escribir el cursor.
+func (c *CRT) saveCursor(err error) {
+	if err != nil {
+		c.logger.Warn("failed to write crtsh cursor", "error", err.Error())
+	}
+}
 
-	return result, nil
+// identityChunks divide %.root por el primer carácter de la etiqueta más a la
+// izquierda, más la identidad exacta del root.
+func identityChunks(root string) []string {
+	chunks := []string{root}
+	for _, ch := range chunkAlphabet {
+		chunks = append(chunks, string(ch)+"%."+root)
+	}
+	return chunks
+}
+
+// splitChunk divide un chunk de un carácter por el segundo (más la etiqueta
+// de un solo carácter). Retorna nil si el chunk no se puede dividir más.
+func splitChunk(query, root string) []string {
+	prefix, ok := strings.CutSuffix(query, "%."+root)
+	if !ok || len(prefix) != 1 {
+		return nil
+	}
+	chunks := []string{prefix + "." + root}
+	for _, ch := range chunkAlphabet + "-" {
+		chunks = append(chunks, prefix+string(ch)+"%."+root)
+	}
+	return chunks
 }
 
 // processRecordsWithProgress procesa los registros de certificados y extrae artifacts
 // emitiendo actualizaciones de progreso en tiempo real.
 func (c *CRT) processRecordsWithProgress(ctx context.Context, records []certRecord, target domain.Target) []*domain.Artifact {
 	artifacts := make([]*domain.Artifact, 0)
-	artifactCount := 0
 
 	for _, record := range records {
 		// Verificar cancelación de contexto
@@ -212,12 +465,12 @@ func (c *CRT) processRecordsWithProgress(ctx context.Context, records []certReco
 
 			artifacts = append(artifacts, artifact)
 			artifacts = append(artifacts, certArtifact)
-			artifactCount += 2
+			c.artifactCount += 2
 
 			// Emitir progreso (non-blocking)
 			select {
 			case c.progressCh <- ports.ProgressUpdate{
-				ArtifactCount: artifactCount,
+				ArtifactCount: c.artifactCount,
 				Message:       fmt.Sprintf("Processing %s", host),
 			}:
 			default:
@@ -234,7 +487,8 @@ func (c *CRT) ProgressChannel() <-chan ports.ProgressUpdate {
 	return c.progressCh
 }
 
-// Stream implementa ports.StreamingSource (no usado actualmente pero requerido por interfaz)
+// Stream implementa ports.StreamingSource: en modo por chunks emite los
+// artifacts de cada chunk en cuanto se completa.
 func (c *CRT) Stream(ctx context.Context, target domain.Target) (<-chan *domain.Artifact, <-chan error) {
 	artifactCh := make(chan *domain.Artifact, 100)
 	errorCh := make(chan error, 1)
@@ -243,18 +497,17 @@ func (c *CRT) Stream(ctx context.Context, target domain.Target) (<-chan *domain.
 		defer close(artifactCh)
 		defer close(errorCh)
 
-		result, err := c.Run(ctx, target)
+		err := c.fetch(ctx, target, func(artifacts []*domain.Artifact) {
+			for _, artifact := range artifacts {
+				select {
+				case artifactCh <- artifact:
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
 		if err != nil {
 			errorCh <- err
-			return
-		}
-
-		for _, artifact := range result.Artifacts {
-			select {
-			case artifactCh <- artifact:
-			case <-ctx.Done():
-				return
-			}
 		}
 	}()
 
@@ -268,10 +521,17 @@ func (c *CRT) Close() error {
 	c.logger.Debug("closing crtsh source")
 	// Close progress channel to prevent goroutine leaks
 	close(c.progressCh)
+	if c.postgres != nil {
+		c.postgres.close()
+	}
 	// http.Client no requiere Close() explícito
 	return nil
 }
 
+// SetLogger sustituye el logger por el del escaneo (scan_id, stage, source).
+// Implementa ports.LogScopedSource.
+func (c *CRT) SetLogger(logger logx.Logger) { c.logger = logger }
+
 // certRecord representa un registro de certificado de crt.sh.
 type certRecord struct {
 	IssuerName   string `json:"issuer_name"`
@@ -3,11 +3,20 @@ package crtsh
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/httpclient"
 	"aethonx/internal/platform/logx"
+	"aethonx/internal/sources/common"
 	"aethonx/internal/testutil"
+	"aethonx/internal/testutil/sourcetest"
 )
 
 func TestNew(t *testing.T) {
@@ -86,7 +95,7 @@ func TestProcessRecords(t *testing.T) {
 				},
 			},
 			expectedCount:  4, // 2 subdomains + 2 certificates (uno por subdomain)
-			expectedValues: []string{"api.example.com", "example.com"}, // www. se normaliza a example.com
+			expectedValues: []string{"api.example.com", "www.example.com"}, // www. se conserva como subdominio propio
 		},
 		{
 			name: "wildcard certificate",
@@ -200,3 +209,122 @@ func TestProcessRecords_MetadataAndRelations(t *testing.T) {
 	testutil.AssertEqual(t, subdomainArtifact.Relations[0].Type, domain.RelationUsesCert, "relation type")
 	testutil.AssertEqual(t, subdomainArtifact.Relations[0].TargetID, certArtifact.ID, "relation target")
 }
+
+func TestRun_ChunkedFetchResumesFromCursor(t *testing.T) {
+	var mu sync.Mutex
+	failing := true
+	queried := make(map[string]int)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		mu.Lock()
+		queried[q]++
+		fail := q == "%.example.com" || q == "c%.example.com" || (failing && q == "cd%.example.com")
+		mu.Unlock()
+
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		records := []certRecord{}
+		switch q {
+		case "a%.example.com":
+			records = append(records, certRecord{NameValue: "api.example.com", SerialNumber: "01"})
+		case "cd%.example.com":
+			records = append(records, certRecord{NameValue: "cdn.example.com", SerialNumber: "02"})
+		}
+		json.NewEncoder(w).Encode(records)
+	}))
+	defer server.Close()
+
+	cursorDir := t.TempDir()
+	newSource := func() *CRT {
+		crt := NewWithConfig(logx.New(), CRTConfig{CursorDir: cursorDir, BaseURL: server.URL + "/"})
+		crt.client = *httpclient.New(httpclient.Config{MaxRetries: 0}, logx.New())
+		return crt
+	}
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+
+	// Primer intento: la consulta completa falla, cd% falla tras dividir c%
+	result, err := newSource().Run(context.Background(), target)
+	testutil.AssertError(t, err, "failed chunk should surface as error")
+	testutil.AssertTrue(t, hasSubdomain(result, "api.example.com"), "completed chunks are returned")
+	testutil.AssertFalse(t, hasSubdomain(result, "cdn.example.com"), "failed chunk has no artifacts")
+
+	cursorPath := filepath.Join(cursorDir, "crtsh_example.com.jsonl")
+	_, statErr := os.Stat(cursorPath)
+	testutil.AssertNoError(t, statErr, "cursor is persisted")
+
+	// Segundo intento: retoma solo lo pendiente
+	mu.Lock()
+	failing = false
+	queried = make(map[string]int)
+	mu.Unlock()
+
+	result, err = newSource().Run(context.Background(), target)
+	testutil.AssertNoError(t, err, "resumed fetch")
+	testutil.AssertTrue(t, hasSubdomain(result, "api.example.com"), "artifacts from cursor")
+	testutil.AssertTrue(t, hasSubdomain(result, "cdn.example.com"), "artifacts from resumed chunk")
+
+	testutil.AssertEqual(t, queried["%.example.com"], 0, "full query skipped when resuming")
+	testutil.AssertEqual(t, queried["a%.example.com"], 0, "completed chunk not fetched again")
+	testutil.AssertEqual(t, queried["c%.example.com"], 0, "split chunk not fetched again")
+	testutil.AssertEqual(t, queried["cd%.example.com"], 1, "pending chunk fetched")
+
+	_, statErr = os.Stat(cursorPath)
+	testutil.AssertTrue(t, os.IsNotExist(statErr), "cursor removed after a complete fetch")
+}
+
+func TestRun_ChunkingNever(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	crt := NewWithConfig(logx.New(), CRTConfig{Chunking: ChunkingNever, BaseURL: server.URL + "/"})
+	crt.client = *httpclient.New(httpclient.Config{MaxRetries: 0}, logx.New())
+
+	result, err := crt.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModePassive))
+	testutil.AssertError(t, err, "full query failure is returned")
+	testutil.AssertEqual(t, len(result.Artifacts), 0, "no artifacts")
+}
+
+func TestSplitChunk(t *testing.T) {
+	sub := splitChunk("a%.example.com", "example.com")
+	testutil.AssertEqual(t, len(sub), len(chunkAlphabet)+2, "second-character chunks plus the single-label name")
+	testutil.AssertEqual(t, sub[0], "a.example.com", "single-label name")
+	testutil.AssertTrue(t, splitChunk("ab%.example.com", "example.com") == nil, "two-character chunks are not split")
+	testutil.AssertTrue(t, splitChunk("example.com", "example.com") == nil, "root identity is not split")
+}
+
+func hasSubdomain(result *domain.ScanResult, value string) bool {
+	for _, a := range result.Artifacts {
+		if a.Type == domain.ArtifactTypeSubdomain && a.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRun_FakeUpstream(t *testing.T) {
+	server := sourcetest.NewCRTShServer(t,
+		sourcetest.CRTShRecord{NameValue: "api.example.com\nwww.example.com", SerialNumber: "01"},
+		sourcetest.CRTShRecord{NameValue: "cdn.example.com", SerialNumber: "02"},
+		sourcetest.CRTShRecord{NameValue: "unrelated.test", SerialNumber: "03"},
+	)
+	server.SetStatus(http.StatusServiceUnavailable)
+
+	crt := NewWithConfig(logx.New(), CRTConfig{Chunking: ChunkingNever, BaseURL: server.BaseURL()},
+		common.WithHTTPClient(httpclient.New(httpclient.Config{MaxRetries: 0}, logx.New())))
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+
+	_, err := crt.Run(context.Background(), target)
+	testutil.AssertError(t, err, "upstream failure is returned")
+
+	server.SetStatus(0)
+	result, err := crt.Run(context.Background(), target)
+	testutil.AssertNoError(t, err, "upstream recovered")
+	sourcetest.AssertArtifacts(t, result.Artifacts,
+		"subdomain api.example.com", "subdomain www.example.com", "subdomain cdn.example.com")
+	testutil.AssertFalse(t, hasSubdomain(result, "unrelated.test"), "records outside the pattern are not served")
+}
@@ -0,0 +1,156 @@
+// internal/sources/crtsh/cursor.go
+package crtsh
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cursor es el diario de una consulta por chunks: una línea JSON por chunk
+// completado (con sus registros) o dividido. Si el fetch falla a medias, la
+// siguiente ejecución contra el mismo target retoma desde aquí en vez de
+// volver a consultar los chunks ya descargados. Sin path vive solo en memoria.
+type cursor struct {
+	path  string
+	done  map[string][]certRecord
+	split map[string]bool
+	order []string // queries completadas, en orden de descarga
+}
+
+// cursorEntry es una línea del diario.
+type cursorEntry struct {
+	Query   string       `json:"query"`
+	Split   bool         `json:"split,omitempty"`
+	Records []certRecord `json:"records,omitempty"`
+}
+
+// loadCursor abre el diario de root en dir ("" = sin persistencia). Un diario
+// más antiguo que ttl se descarta: crt.sh habrá indexado certificados nuevos.
+func loadCursor(dir, root string, ttl time.Duration) *cursor {
+	c := &cursor{
+		done:  make(map[string][]certRecord),
+		split: make(map[string]bool),
+	}
+	if dir == "" {
+		return c
+	}
+	c.path = filepath.Join(dir, "crtsh_"+cursorName(root)+".jsonl")
+
+	info, err := os.Stat(c.path)
+	if err != nil {
+		return c
+	}
+	if ttl > 0 && time.Since(info.ModTime()) > ttl {
+		os.Remove(c.path)
+		return c
+	}
+
+	f, err := os.Open(c.path)
+	if err != nil {
+		return c
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 256*1024*1024)
+	for scanner.Scan() {
+		var entry cursorEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			break // Línea truncada por una escritura interrumpida
+		}
+		c.apply(entry)
+	}
+	return c
+}
+
+// empty indica que no hay chunks de un intento anterior.
+func (c *cursor) empty() bool {
+	return len(c.done) == 0 && len(c.split) == 0
+}
+
+// completed recorre los chunks ya descargados, en orden de descarga.
+func (c *cursor) completed(fn func(query string, records []certRecord)) {
+	for _, query := range c.order {
+		fn(query, c.done[query])
+	}
+}
+
+func (c *cursor) isDone(query string) bool {
+	_, ok := c.done[query]
+	return ok
+}
+
+func (c *cursor) isSplit(query string) bool {
+	return c.split[query]
+}
+
+// markDone registra un chunk descargado con sus registros.
+func (c *cursor) markDone(query string, records []certRecord) error {
+	if records == nil {
+		records = []certRecord{}
+	}
+	return c.append(cursorEntry{Query: query, Records: records})
+}
+
+// markSplit registra que un chunk se dividió en consultas más pequeñas.
+func (c *cursor) markSplit(query string) error {
+	return c.append(cursorEntry{Query: query, Split: true})
+}
+
+// remove borra el diario al completar el fetch.
+func (c *cursor) remove() {
+	if c.path != "" {
+		os.Remove(c.path)
+	}
+}
+
+func (c *cursor) apply(entry cursorEntry) {
+	if entry.Split {
+		c.split[entry.Query] = true
+		return
+	}
+	if _, ok := c.done[entry.Query]; !ok {
+		c.order = append(c.order, entry.Query)
+	}
+	c.done[entry.Query] = entry.Records
+}
+
+// append añade la entrada en memoria y, si hay path, al diario en disco.
+func (c *cursor) append(entry cursorEntry) error {
+	c.apply(entry)
+	if c.path == "" {
+		return nil
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(c.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// cursorName convierte el root en un nombre de fichero seguro.
+func cursorName(root string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		}
+		return '_'
+	}, root)
+}
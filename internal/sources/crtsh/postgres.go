@@ -0,0 +1,124 @@
+// internal/sources/crtsh/postgres.go
+package crtsh
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Endpoint público de crt.sh (usuario guest sin contraseña, base certwatch).
+const (
+	defaultPostgresAddr = "crt.sh:5432"
+	postgresUser        = "guest"
+	postgresDatabase    = "certwatch"
+	defaultPoolSize     = 2
+	defaultQueryTimeout = 2 * time.Minute
+)
+
+// certQuery selecciona los certificados con identidades que encajan con el
+// patrón LIKE (mismo formato que la consulta q= de la API HTTP) y devuelve
+// las columnas de certRecord con el formato del JSON de crt.sh, para que los
+// artifacts emitidos sean idénticos en ambos backends. $1 es el dominio raíz
+// y $2 el patrón; van como parámetros, nunca interpolados en el SQL.
+const certQuery = `SELECT COALESCE(ca.NAME, ''),
+  array_to_string(array_agg(DISTINCT cai.NAME_VALUE), chr(10)),
+  to_char(x509_notAfter(cai.CERTIFICATE), 'YYYY-MM-DD"T"HH24:MI:SS'),
+  to_char(x509_notBefore(cai.CERTIFICATE), 'YYYY-MM-DD"T"HH24:MI:SS'),
+  encode(x509_serialNumber(cai.CERTIFICATE), 'hex')
+FROM certificate_and_identities cai
+LEFT JOIN ca ON ca.ID = cai.ISSUER_CA_ID
+WHERE plainto_tsquery('certwatch', $1) @@ identities(cai.CERTIFICATE)
+  AND cai.NAME_VALUE ILIKE $2
+GROUP BY cai.CERTIFICATE, ca.NAME`
+
+// postgresBackend consulta crt.sh por su endpoint PostgreSQL, más fiable que
+// el JSON HTTP para dominios grandes. Usa un pool de pgx con conexiones
+// reutilizadas entre chunks y scans.
+type postgresBackend struct {
+	addr         string
+	queryTimeout time.Duration
+	pool         *pgxpool.Pool
+	err          error // Configuración inválida: se retorna en cada consulta
+}
+
+// newPostgresBackend prepara el pool; las conexiones se abren con la primera
+// consulta.
+func newPostgresBackend(addr string, poolSize int, queryTimeout time.Duration) *postgresBackend {
+	if addr == "" {
+		addr = defaultPostgresAddr
+	}
+	if poolSize <= 0 {
+		poolSize = defaultPoolSize
+	}
+	if queryTimeout <= 0 {
+		queryTimeout = defaultQueryTimeout
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.User(postgresUser),
+		Host:     addr,
+		Path:     "/" + postgresDatabase,
+		RawQuery: "sslmode=disable&application_name=aethonx",
+	}
+	b := &postgresBackend{addr: addr, queryTimeout: queryTimeout}
+	cfg, err := pgxpool.ParseConfig(dsn.String())
+	if err != nil {
+		b.err = fmt.Errorf("postgres config: %w", err)
+		return b
+	}
+	cfg.MaxConns = int32(poolSize)
+	// El servidor aborta las consultas que exceden queryTimeout
+	cfg.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(queryTimeout.Milliseconds(), 10)
+	// Sin sentencias preparadas: una sola consulta con parámetros por ida y vuelta
+	cfg.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeExec
+
+	if b.pool, err = pgxpool.NewWithConfig(context.Background(), cfg); err != nil {
+		b.err = fmt.Errorf("postgres pool: %w", err)
+	}
+	return b
+}
+
+// records ejecuta la consulta para el patrón q (e.g. "%.example.com").
+func (b *postgresBackend) records(ctx context.Context, root, q string) ([]certRecord, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	ctx, cancel := context.WithTimeout(ctx, b.queryTimeout)
+	defer cancel()
+
+	rows, err := b.pool.Query(ctx, certQuery, root, q)
+	if err != nil {
+		return nil, fmt.Errorf("postgres query: %w", b.wrap(ctx, err))
+	}
+	records, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (certRecord, error) {
+		var r certRecord
+		err := row.Scan(&r.IssuerName, &r.NameValue, &r.NotAfter, &r.NotBefore, &r.SerialNumber)
+		return r, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postgres query: %w", b.wrap(ctx, err))
+	}
+	return records, nil
+}
+
+// wrap retorna el error del contexto si la consulta falló por cancelación.
+func (b *postgresBackend) wrap(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// close cierra el pool; espera a que se devuelvan las conexiones en uso.
+func (b *postgresBackend) close() {
+	if b.pool != nil {
+		b.pool.Close()
+	}
+}
@@ -0,0 +1,181 @@
+// internal/sources/crtsh/postgres_test.go
+package crtsh
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgproto3"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+)
+
+// fakeCertwatch simula el endpoint PostgreSQL de crt.sh: acepta el startup
+// sin autenticación y responde a cada consulta (SQL y parámetros enlazados)
+// con las filas de rows.
+func fakeCertwatch(t *testing.T, rows func(sql string, params []string) ([][]string, string)) (string, *atomic.Int32) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	testutil.AssertNoError(t, err, "listen")
+	t.Cleanup(func() { ln.Close() })
+
+	var conns atomic.Int32
+	go func() {
+		for {
+			nc, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conns.Add(1)
+			go serveCertwatch(nc, rows)
+		}
+	}()
+	return ln.Addr().String(), &conns
+}
+
+// serveCertwatch atiende el protocolo extendido (Parse/Bind/Describe/Execute/
+// Sync) que usa pgx con QueryExecModeExec.
+func serveCertwatch(nc net.Conn, rows func(sql string, params []string) ([][]string, string)) {
+	defer nc.Close()
+	backend := pgproto3.NewBackend(nc, nc)
+	if _, err := backend.ReceiveStartupMessage(); err != nil {
+		return
+	}
+	backend.Send(&pgproto3.AuthenticationOk{})
+	backend.Send(&pgproto3.ReadyForQuery{TxStatus: 'I'})
+	if backend.Flush() != nil {
+		return
+	}
+
+	var sql string
+	var params []string
+	var failed bool
+	for {
+		msg, err := backend.Receive()
+		if err != nil {
+			return
+		}
+		switch msg := msg.(type) {
+		case *pgproto3.Parse:
+			sql, failed = msg.Query, false
+			backend.Send(&pgproto3.ParseComplete{})
+		case *pgproto3.Bind:
+			params = params[:0]
+			for _, p := range msg.Parameters {
+				params = append(params, string(p))
+			}
+			backend.Send(&pgproto3.BindComplete{})
+		case *pgproto3.Describe:
+			fields := make([]pgproto3.FieldDescription, 5)
+			for i := range fields {
+				fields[i] = pgproto3.FieldDescription{Name: []byte("col"), DataTypeOID: 25, DataTypeSize: -1, TypeModifier: -1}
+			}
+			backend.Send(&pgproto3.RowDescription{Fields: fields})
+		case *pgproto3.Execute:
+			if failed {
+				continue
+			}
+			result, errMsg := rows(sql, params)
+			if errMsg != "" {
+				failed = true
+				backend.Send(&pgproto3.ErrorResponse{Severity: "ERROR", Code: "57014", Message: errMsg})
+				continue
+			}
+			for _, row := range result {
+				values := make([][]byte, len(row))
+				for i, col := range row {
+					values[i] = []byte(col)
+				}
+				backend.Send(&pgproto3.DataRow{Values: values})
+			}
+			backend.Send(&pgproto3.CommandComplete{CommandTag: []byte("SELECT " + strconv.Itoa(len(result)))})
+		case *pgproto3.Sync:
+			backend.Send(&pgproto3.ReadyForQuery{TxStatus: 'I'})
+			if backend.Flush() != nil {
+				return
+			}
+		case *pgproto3.Terminate:
+			return
+		}
+	}
+}
+
+func TestRun_PostgresBackend(t *testing.T) {
+	addr, conns := fakeCertwatch(t, func(sql string, params []string) ([][]string, string) {
+		if !strings.Contains(sql, "ILIKE $2") || strings.Join(params, " ") != "example.com %.example.com" {
+			return nil, "unexpected query"
+		}
+		return [][]string{{"C=US, O=Let's Encrypt, CN=R3", "www.example.com\napi.example.com", "2025-12-31T23:59:59", "2025-01-01T00:00:00", "0abc"}}, ""
+	})
+
+	crt := NewWithConfig(logx.New(), CRTConfig{Backend: BackendPostgres, PostgresAddr: addr, QueryTimeout: 5 * time.Second})
+	defer crt.Close()
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+
+	result, err := crt.Run(context.Background(), target)
+	testutil.AssertNoError(t, err, "postgres run")
+
+	// Mismos artifacts que la API HTTP para los mismos registros
+	expected := crt.processRecordsWithProgress(context.Background(), []certRecord{{
+		IssuerName:   "C=US, O=Let's Encrypt, CN=R3",
+		NameValue:    "www.example.com\napi.example.com",
+		NotAfter:     "2025-12-31T23:59:59",
+		NotBefore:    "2025-01-01T00:00:00",
+		SerialNumber: "0abc",
+	}}, target)
+	testutil.AssertEqual(t, len(result.Artifacts), len(expected), "artifact count")
+	for i, a := range result.Artifacts {
+		testutil.AssertEqual(t, a.ID, expected[i].ID, "artifact "+a.Value)
+	}
+
+	// El pool reutiliza la conexión entre scans
+	_, err = crt.Run(context.Background(), target)
+	testutil.AssertNoError(t, err, "second run")
+	testutil.AssertEqual(t, conns.Load(), int32(1), "pooled connection reused")
+}
+
+func TestPostgresBackend_QueryError(t *testing.T) {
+	addr, conns := fakeCertwatch(t, func(string, []string) ([][]string, string) {
+		return nil, "canceling statement due to statement timeout"
+	})
+
+	backend := newPostgresBackend(addr, 1, 5*time.Second)
+	defer backend.close()
+
+	_, err := backend.records(context.Background(), "example.com", "%.example.com")
+	testutil.AssertError(t, err, "server error is returned")
+	testutil.AssertTrue(t, strings.Contains(err.Error(), "statement timeout"), "error message from server")
+
+	// La conexión sigue en el pool tras un error SQL
+	_, err = backend.records(context.Background(), "example.com", "%.example.com")
+	testutil.AssertError(t, err, "second query")
+	testutil.AssertEqual(t, conns.Load(), int32(1), "connection reused after SQL error")
+}
+
+func TestPostgresBackend_BindsParameters(t *testing.T) {
+	var gotSQL string
+	var gotParams []string
+	addr, _ := fakeCertwatch(t, func(sql string, params []string) ([][]string, string) {
+		gotSQL, gotParams = sql, append([]string(nil), params...)
+		return nil, ""
+	})
+
+	backend := newPostgresBackend(addr, 1, 5*time.Second)
+	defer backend.close()
+
+	// Las comillas del input llegan como datos, no como SQL
+	root := "example.com') OR 1=1 --"
+	_, err := backend.records(context.Background(), root, "%."+root)
+	testutil.AssertNoError(t, err, "query")
+	testutil.AssertFalse(t, strings.Contains(gotSQL, root), "input must not be interpolated into the SQL")
+	testutil.AssertEqual(t, len(gotParams), 2, "bound parameters")
+	testutil.AssertEqual(t, gotParams[0], root, "root parameter")
+	testutil.AssertEqual(t, gotParams[1], "%."+root, "pattern parameter")
+}
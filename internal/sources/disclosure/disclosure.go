@@ -0,0 +1,501 @@
+// internal/sources/disclosure/disclosure.go
+package disclosure
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/budget"
+	"aethonx/internal/platform/httpclient"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+	"aethonx/internal/platform/validator"
+	"aethonx/internal/sources/common"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	sourceName      = "disclosure"
+	defaultWorkers  = 5
+	defaultMaxHosts = 50
+	maxBodyBytes    = 32 << 10 // security.txt es un fichero pequeño
+	requestTimeout  = 10 * time.Second
+	queryTimeout    = 5 * time.Second
+
+	// Tags de los emails emitidos
+	tagSecurityContact = "security-contact"
+	tagCAAIodef        = "caa-iodef"
+)
+
+// securityTxtPaths son las ubicaciones de security.txt en orden (RFC 9116).
+var securityTxtPaths = []string{"/.well-known/security.txt", "/security.txt"}
+
+// configSchema declara las opciones Custom de disclosure.
+var configSchema = []ports.ConfigField{
+	{Name: "workers", Type: ports.ConfigTypeInt, Default: defaultWorkers, Description: "Hosts checked in parallel (1-50)"},
+	{Name: "max_hosts", Type: ports.ConfigTypeInt, Default: defaultMaxHosts, Description: "Max alive hosts checked (0 = all)"},
+	{Name: "caa", Type: ports.ConfigTypeBool, Default: true, Description: "Look up CAA records"},
+	{Name: "security_txt", Type: ports.ConfigTypeBool, Default: true, Description: "Fetch /.well-known/security.txt"},
+	{Name: "resolver", Type: ports.ConfigTypeString, Description: "Resolver host:port for CAA (empty = system resolver)"},
+}
+
+// Auto-registro de la source al importar el package
+func init() {
+	if err := registry.Global().Register(
+		sourceName,
+		factory,
+		ports.SourceMetadata{
+			Name:         sourceName,
+			Description:  "CAA issuers and security.txt contacts of alive hosts",
+			Version:      "1.0.0",
+			Author:       "AethonX",
+			Mode:         domain.SourceModePassive,
+			Type:         domain.SourceTypeBuiltin,
+			RequiresAuth: false,
+
+			// Consume las URLs vivas de httpx (un origen por host); sin ellas,
+			// el dominio raíz
+			InputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeURL,
+			},
+			// Emite emails de contacto, pero no se declaran: reversewhois
+			// consume emails y emite dominios que llegan a httpx, lo que
+			// crearía un ciclo de dependencias.
+			OutputArtifacts: []domain.ArtifactType{},
+			Priority:        19,
+
+			ConfigSchema: configSchema,
+		},
+	); err != nil {
+		logx.New().Warn("failed to register disclosure source", "error", err.Error())
+	}
+}
+
+// factory crea la source desde SourceConfig (Custom según configSchema).
+func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("disclosure config: %w", err)
+	}
+
+	workers := opts.Int("workers")
+	if workers <= 0 || workers > 50 {
+		return nil, fmt.Errorf("disclosure workers must be between 1 and 50, got %d", workers)
+	}
+	maxHosts := opts.Int("max_hosts")
+	if maxHosts < 0 {
+		return nil, fmt.Errorf("disclosure max_hosts cannot be negative, got %d", maxHosts)
+	}
+
+	client := httpclient.New(httpclient.Config{
+		Timeout:        requestTimeout,
+		MaxRetries:     1,
+		UserAgent:      "AethonX/1.0",
+		RateLimitBurst: workers,
+		Budgeted:       true,
+		Polite:         true,
+	}, logger)
+
+	source := New(logger, common.WithHTTPClient(client))
+	source.workers = workers
+	source.maxHosts = maxHosts
+	source.caa = opts.Bool("caa")
+	source.securityTxt = opts.Bool("security_txt")
+	source.dnsServer = opts.String("resolver")
+	return source, nil
+}
+
+// Source recoge los datos de divulgación responsable de los hosts vivos: las
+// CAs autorizadas por CAA y los contactos de security.txt. Los guarda en el
+// DomainMetadata del host y emite un email por contacto mailto:.
+type Source struct {
+	client      *httpclient.Client
+	dnsClient   *dns.Client
+	dnsServer   string // host:port ("" = resolver del sistema)
+	workers     int
+	maxHosts    int
+	caa         bool
+	securityTxt bool
+	logger      logx.Logger
+}
+
+// New crea la source disclosure. opts inyectan el cliente HTTP.
+func New(logger logx.Logger, opts ...common.Option) *Source {
+	client := common.ApplyOptions(opts...).HTTPClient
+	if client == nil {
+		client = httpclient.New(httpclient.Config{Timeout: requestTimeout, MaxRetries: 1}, logger)
+	}
+	return &Source{
+		client:      client,
+		dnsClient:   &dns.Client{Timeout: queryTimeout},
+		workers:     defaultWorkers,
+		maxHosts:    defaultMaxHosts,
+		caa:         true,
+		securityTxt: true,
+		logger:      logger.With("source", sourceName),
+	}
+}
+
+// Name retorna el nombre de la fuente.
+func (s *Source) Name() string { return sourceName }
+
+// Mode retorna el modo de operación (pasivo: un fichero público por host y
+// consultas DNS).
+func (s *Source) Mode() domain.SourceMode { return domain.SourceModePassive }
+
+// Type retorna el tipo de fuente (builtin).
+func (s *Source) Type() domain.SourceType { return domain.SourceTypeBuiltin }
+
+// Close no libera recursos.
+func (s *Source) Close() error { return nil }
+
+// SetLogger sustituye el logger por el del escaneo (scan_id, stage, source).
+// Implementa ports.LogScopedSource.
+func (s *Source) SetLogger(logger logx.Logger) { s.logger = logger }
+
+// Run revisa solo el dominio raíz del target (https).
+func (s *Source) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	return s.checkAll(ctx, target, []string{"https://" + target.QueryName()})
+}
+
+// RunWithInput revisa los orígenes de las URLs vivas de input; sin URLs
+// vivas, el dominio raíz. Implementa ports.InputConsumer.
+func (s *Source) RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+	origins := s.aliveOrigins(input, target)
+	if len(origins) == 0 {
+		return s.Run(ctx, target)
+	}
+	return s.checkAll(ctx, target, origins)
+}
+
+// aliveOrigins extrae scheme://host[:port] de las URLs vivas en scope, uno
+// por host (https primero), limitados a maxHosts.
+func (s *Source) aliveOrigins(input *domain.ScanResult, target domain.Target) []string {
+	if input == nil {
+		return nil
+	}
+	byHost := make(map[string]string)
+	for _, a := range input.Artifacts {
+		if a.Type != domain.ArtifactTypeURL || !a.IsAlive() {
+			continue
+		}
+		u, err := url.Parse(a.Value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+		host := strings.ToLower(u.Hostname())
+		root := target.QueryName()
+		if (host != root && !strings.HasSuffix(host, "."+root)) || !target.IsInScope(host) {
+			continue
+		}
+		if prev, ok := byHost[host]; !ok || (u.Scheme == "https" && !strings.HasPrefix(prev, "https:")) {
+			byHost[host] = u.Scheme + "://" + u.Host
+		}
+	}
+
+	origins := make([]string, 0, len(byHost))
+	for _, origin := range byHost {
+		origins = append(origins, origin)
+	}
+	sort.Strings(origins)
+	if s.maxHosts > 0 && len(origins) > s.maxHosts {
+		s.logger.Info("limiting checked hosts", "alive", len(origins), "max", s.maxHosts)
+		origins = origins[:s.maxHosts]
+	}
+	return origins
+}
+
+// hostResult son los datos de divulgación de un host.
+type hostResult struct {
+	origin string
+	host   string
+	caa    caaRecords
+	sectxt *securityTxt
+	errs   []string
+}
+
+// checkAll revisa los orígenes con un pool de workers.
+func (s *Source) checkAll(ctx context.Context, target domain.Target, origins []string) (*domain.ScanResult, error) {
+	result := domain.NewScanResult(target)
+	result.Metadata.SourcesUsed = []string{sourceName}
+
+	dnsServer := s.dnsServer
+	if s.caa && dnsServer == "" {
+		server, err := common.SystemDNSServer()
+		if err != nil {
+			result.AddWarning(sourceName, "CAA lookups skipped: "+err.Error())
+		}
+		dnsServer = server
+	}
+
+	jobs := make(chan string)
+	results := make(chan hostResult, len(origins))
+
+	var wg sync.WaitGroup
+	workers := min(s.workers, len(origins))
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for origin := range jobs {
+				results <- s.checkHost(ctx, origin, dnsServer)
+			}
+		}()
+	}
+
+feed:
+	for _, origin := range origins {
+		select {
+		case jobs <- origin:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	close(results)
+
+	hostResults := make([]hostResult, 0, len(origins))
+	for r := range results {
+		hostResults = append(hostResults, r)
+	}
+	sort.Slice(hostResults, func(i, j int) bool { return hostResults[i].origin < hostResults[j].origin })
+
+	failures, contacts := 0, 0
+	for _, r := range hostResults {
+		if len(r.errs) > 0 {
+			failures++
+			s.logger.Debug("disclosure check failed", "origin", r.origin, "errors", strings.Join(r.errs, "; "))
+		}
+		contacts += s.addArtifacts(result, target, r)
+	}
+
+	if failures > 0 {
+		result.AddWarning(sourceName, fmt.Sprintf("%d of %d hosts could not be fully checked", failures, len(origins)))
+	}
+
+	s.logger.Info("disclosure check completed",
+		"hosts", len(origins),
+		"contacts", contacts,
+		"artifacts", len(result.Artifacts),
+		"failures", failures,
+	)
+	return result, ctx.Err()
+}
+
+// checkHost busca los CAA del host y descarga su security.txt.
+func (s *Source) checkHost(ctx context.Context, origin, dnsServer string) hostResult {
+	r := hostResult{origin: origin}
+	if u, err := url.Parse(origin); err == nil {
+		r.host = strings.ToLower(u.Hostname())
+	}
+
+	if s.caa && dnsServer != "" && r.host != "" && !validator.IsIP(r.host) {
+		caa, err := s.lookupCAA(ctx, dnsServer, r.host)
+		if err != nil {
+			r.errs = append(r.errs, err.Error())
+		}
+		r.caa = caa
+	}
+
+	if s.securityTxt {
+		for _, path := range securityTxtPaths {
+			body, found, err := s.fetch(ctx, origin+path)
+			if err != nil {
+				r.errs = append(r.errs, err.Error())
+				break
+			}
+			if found {
+				r.sectxt = parseSecurityTxt(body)
+				break
+			}
+		}
+	}
+	return r
+}
+
+// fetch descarga rawURL. Un 4xx o una respuesta que no es texto plano (las
+// páginas de error que devuelven 200) no son error: found es false.
+func (s *Source) fetch(ctx context.Context, rawURL string) (body []byte, found bool, err error) {
+	resp, err := s.client.Get(ctx, rawURL, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return nil, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("%s: HTTP %d", rawURL, resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.HasPrefix(strings.ToLower(ct), "text/plain") {
+		return nil, false, nil
+	}
+
+	body, err = io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	if err != nil {
+		return nil, false, err
+	}
+	return body, true, nil
+}
+
+// addArtifacts añade el host con sus datos de divulgación y un email por
+// contacto. Retorna el número de contactos.
+func (s *Source) addArtifacts(result *domain.ScanResult, target domain.Target, r hostResult) int {
+	if r.host == "" || validator.IsIP(r.host) || (len(r.caa.issuers) == 0 && len(r.caa.iodef) == 0 && r.sectxt == nil) {
+		return 0
+	}
+
+	meta := metadata.NewDomainMetadata()
+	meta.CAAIssuers = r.caa.issuers
+	if r.sectxt != nil {
+		meta.SecurityContacts = r.sectxt.contacts
+		meta.SecurityPolicy = r.sectxt.policy
+		meta.SecurityTxtExpires = r.sectxt.expires
+	}
+	hostType := domain.ArtifactTypeSubdomain
+	if r.host == target.QueryName() {
+		hostType = domain.ArtifactTypeDomain
+	}
+	hostArtifact := domain.NewArtifactWithMetadata(hostType, r.host, sourceName, meta)
+
+	contacts := 0
+	addEmail := func(contact, tag string) {
+		addr, ok := strings.CutPrefix(strings.ToLower(contact), "mailto:")
+		if !ok {
+			return
+		}
+		if i := strings.IndexByte(addr, '?'); i >= 0 {
+			addr = addr[:i]
+		}
+		email := domain.NewArtifact(domain.ArtifactTypeEmail, addr, sourceName)
+		email.AddTag(tag)
+		hostArtifact.AddRelation(email.ID, domain.RelationHasContact, 1.0, sourceName)
+		result.AddArtifact(email)
+		contacts++
+	}
+	if r.sectxt != nil {
+		for _, contact := range r.sectxt.contacts {
+			addEmail(contact, tagSecurityContact)
+		}
+	}
+	for _, iodef := range r.caa.iodef {
+		addEmail(iodef, tagCAAIodef)
+	}
+
+	result.AddArtifact(hostArtifact)
+	return contacts
+}
+
+// caaRecords son los CAA que aplican a un host.
+type caaRecords struct {
+	issuers []string // Dominios de las CAs de issue/issuewild
+	iodef   []string // URLs de notificación (mailto:, https:)
+}
+
+// lookupCAA busca el conjunto CAA relevante del host: el del propio nombre
+// o, si no tiene, el del ancestro más cercano hasta el dominio registrable
+// (RFC 8659).
+func (s *Source) lookupCAA(ctx context.Context, server, host string) (caaRecords, error) {
+	var records caaRecords
+	stop := validator.RegistrableDomain(host)
+	for name := host; name != ""; {
+		if budget.Shared().Reserve(budget.DNSQueries, 1) == 0 {
+			return records, fmt.Errorf("dns query budget exhausted")
+		}
+		msg := new(dns.Msg)
+		msg.SetQuestion(dns.Fqdn(name), dns.TypeCAA)
+		resp, _, err := s.dnsClient.ExchangeContext(ctx, msg, server)
+		if err != nil {
+			return records, fmt.Errorf("CAA %s: %w", name, err)
+		}
+
+		found := false
+		for _, rr := range resp.Answer {
+			caa, ok := rr.(*dns.CAA)
+			if !ok {
+				continue
+			}
+			found = true
+			switch strings.ToLower(caa.Tag) {
+			case "issue", "issuewild":
+				// "ca.example; account=123" -> "ca.example"; ";" solo prohíbe emitir
+				if issuer := strings.TrimSpace(strings.SplitN(caa.Value, ";", 2)[0]); issuer != "" {
+					records.issuers = appendUnique(records.issuers, strings.ToLower(issuer))
+				}
+			case "iodef":
+				records.iodef = appendUnique(records.iodef, caa.Value)
+			}
+		}
+		if found || name == stop || stop == "" {
+			break
+		}
+		_, parent, ok := strings.Cut(name, ".")
+		if !ok {
+			break
+		}
+		name = parent
+	}
+	return records, nil
+}
+
+// securityTxt son los campos de security.txt que se conservan.
+type securityTxt struct {
+	contacts []string
+	policy   string
+	expires  string
+}
+
+// parseSecurityTxt extrae Contact, Policy y Expires de un security.txt,
+// firmado o no (la firma PGP no se verifica).
+func parseSecurityTxt(body []byte) *securityTxt {
+	txt := &securityTxt{}
+	for _, line := range strings.Split(string(body), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "-----BEGIN PGP SIGNATURE") {
+			break
+		}
+		field, value, ok := strings.Cut(line, ":")
+		if !ok || strings.HasPrefix(line, "#") {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(field)) {
+		case "contact":
+			txt.contacts = appendUnique(txt.contacts, value)
+		case "policy":
+			if txt.policy == "" {
+				txt.policy = value
+			}
+		case "expires":
+			if txt.expires == "" {
+				txt.expires = value
+			}
+		}
+	}
+	if len(txt.contacts) == 0 && txt.policy == "" {
+		return nil
+	}
+	return txt
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
@@ -0,0 +1,175 @@
+package disclosure
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/httpclient"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/sources/common"
+	"aethonx/internal/testutil"
+
+	"github.com/miekg/dns"
+)
+
+func newTestSource() *Source {
+	client := httpclient.New(httpclient.Config{MaxRetries: 0}, logx.New())
+	return New(logx.New(), common.WithHTTPClient(client))
+}
+
+// startCAA arranca un servidor DNS UDP que responde los CAA de records.
+func startCAA(t *testing.T, records ...string) string {
+	t.Helper()
+	answers := make(map[string][]dns.RR)
+	for _, s := range records {
+		rr, err := dns.NewRR(s)
+		testutil.AssertNoError(t, err, "test record "+s)
+		answers[rr.Header().Name] = append(answers[rr.Header().Name], rr)
+	}
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	testutil.AssertNoError(t, err, "listen")
+	server := &dns.Server{PacketConn: pc, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(req)
+		if req.Question[0].Qtype == dns.TypeCAA {
+			m.Answer = answers[req.Question[0].Name]
+		}
+		_ = w.WriteMsg(m)
+	})}
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+	return pc.LocalAddr().String()
+}
+
+func TestParseSecurityTxt(t *testing.T) {
+	body := []byte(`-----BEGIN PGP SIGNED MESSAGE-----
+Hash: SHA256
+
+# Our security policy
+Contact: mailto:security@example.com
+Contact: https://example.com/report
+Contact: mailto:security@example.com
+Expires: 2027-01-01T00:00:00.000Z
+Policy: https://example.com/security-policy
+-----BEGIN PGP SIGNATURE-----
+Contact: mailto:forged@example.com
+-----END PGP SIGNATURE-----
+`)
+
+	txt := parseSecurityTxt(body)
+	testutil.AssertNotNil(t, txt, "security.txt parsed")
+	testutil.AssertEqual(t, strings.Join(txt.contacts, ","), "mailto:security@example.com,https://example.com/report", "contacts deduplicated, signature ignored")
+	testutil.AssertEqual(t, txt.expires, "2027-01-01T00:00:00.000Z", "expires")
+	testutil.AssertEqual(t, txt.policy, "https://example.com/security-policy", "policy")
+
+	testutil.AssertTrue(t, parseSecurityTxt([]byte("<html>not found</html>")) == nil, "no fields, no security.txt")
+}
+
+func TestSource_LookupCAA(t *testing.T) {
+	server := startCAA(t,
+		`example.com. 300 IN CAA 0 issue "letsencrypt.org; validationmethods=dns-01"`,
+		`example.com. 300 IN CAA 0 issuewild "digicert.com"`,
+		`example.com. 300 IN CAA 0 issue ";"`,
+		`example.com. 300 IN CAA 0 iodef "mailto:caa@example.com"`,
+		`own.example.com. 300 IN CAA 0 issue "sectigo.com"`,
+	)
+	s := newTestSource()
+
+	// Sin CAA propio hereda el del dominio registrable
+	records, err := s.lookupCAA(context.Background(), server, "api.dev.example.com")
+	testutil.AssertNoError(t, err, "inherited CAA")
+	testutil.AssertEqual(t, strings.Join(records.issuers, ","), "letsencrypt.org,digicert.com", "issuers without parameters")
+	testutil.AssertEqual(t, strings.Join(records.iodef, ","), "mailto:caa@example.com", "iodef")
+
+	records, err = s.lookupCAA(context.Background(), server, "own.example.com")
+	testutil.AssertNoError(t, err, "own CAA")
+	testutil.AssertEqual(t, strings.Join(records.issuers, ","), "sectigo.com", "closest CAA set wins")
+
+	records, err = s.lookupCAA(context.Background(), server, "other.net")
+	testutil.AssertNoError(t, err, "no CAA")
+	testutil.AssertEqual(t, len(records.issuers), 0, "no issuers")
+}
+
+func TestSource_CheckHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/security.txt":
+			// Página de error servida con 200
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html>Contact: nobody</html>"))
+		case "/security.txt":
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Write([]byte("Contact: mailto:security@example.com\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	r := newTestSource().checkHost(context.Background(), server.URL, "")
+	testutil.AssertEqual(t, len(r.errs), 0, "no errors")
+	testutil.AssertNotNil(t, r.sectxt, "legacy location used after a non text/plain response")
+	testutil.AssertEqual(t, strings.Join(r.sectxt.contacts, ","), "mailto:security@example.com", "contact")
+}
+
+func TestSource_AddArtifacts(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+
+	contacts := newTestSource().addArtifacts(result, *target, hostResult{
+		origin: "https://www.example.com",
+		host:   "www.example.com",
+		caa:    caaRecords{issuers: []string{"letsencrypt.org"}, iodef: []string{"mailto:caa@example.com"}},
+		sectxt: &securityTxt{
+			contacts: []string{"mailto:Security@Example.com?subject=report", "https://example.com/report"},
+			policy:   "https://example.com/policy",
+		},
+	})
+	testutil.AssertEqual(t, contacts, 2, "security.txt and iodef mailto contacts")
+
+	var host *domain.Artifact
+	emails := make(map[string]*domain.Artifact)
+	for _, a := range result.Artifacts {
+		switch a.Type {
+		case domain.ArtifactTypeSubdomain:
+			host = a
+		case domain.ArtifactTypeEmail:
+			emails[a.Value] = a
+		}
+	}
+	testutil.AssertTrue(t, host != nil, "host artifact")
+	email := emails["security@example.com"]
+	testutil.AssertTrue(t, email != nil, "mailto address without query")
+	testutil.AssertEqual(t, email.Tags[0], tagSecurityContact, "security contact tag")
+	testutil.AssertEqual(t, emails["caa@example.com"].Tags[0], tagCAAIodef, "iodef tag")
+	testutil.AssertTrue(t, host.HasRelation(email.ID, domain.RelationHasContact), "host has_contact relation")
+
+	meta, ok := host.TypedMetadata.(*metadata.DomainMetadata)
+	testutil.AssertTrue(t, ok, "domain metadata")
+	testutil.AssertEqual(t, strings.Join(meta.CAAIssuers, ","), "letsencrypt.org", "issuers stored")
+	testutil.AssertEqual(t, meta.SecurityPolicy, "https://example.com/policy", "policy stored")
+	testutil.AssertEqual(t, len(meta.SecurityContacts), 2, "raw contacts stored")
+}
+
+func TestSource_AliveOrigins(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	input := domain.NewScanResult(*target)
+	for _, u := range []string{"http://www.example.com/a", "https://www.example.com/b", "https://api.example.com:8443/", "https://other.net/"} {
+		a := domain.NewArtifact(domain.ArtifactTypeURL, u, "httpx")
+		a.AddTag("alive")
+		input.AddArtifact(a)
+	}
+	input.AddArtifact(domain.NewArtifact(domain.ArtifactTypeURL, "https://dead.example.com/", "waybackurls"))
+
+	var _ ports.InputConsumer = (*Source)(nil)
+	origins := newTestSource().aliveOrigins(input, *target)
+	testutil.AssertEqual(t, strings.Join(origins, ","), "https://api.example.com:8443,https://www.example.com", "one https origin per alive in-scope host")
+}
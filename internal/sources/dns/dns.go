@@ -0,0 +1,352 @@
+// internal/sources/dns/dns.go
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/budget"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+	"aethonx/internal/sources/common"
+
+	mdns "github.com/miekg/dns"
+)
+
+const (
+	sourceName     = "dns"
+	defaultWorkers = 20
+	lookupTimeout  = 5 * time.Second
+)
+
+// configSchema declara las opciones Custom de dns.
+var configSchema = []ports.ConfigField{
+	{Name: "workers", Type: ports.ConfigTypeInt, Default: defaultWorkers, Description: "Concurrent lookups (1-1000)"},
+	{Name: "resolver", Type: ports.ConfigTypeString, Description: "Resolver host:port (empty = system resolver)"},
+	{Name: "zone_checks", Type: ports.ConfigTypeBool, Default: true, Description: "Validate the apex DNSSEC chain and check its nameservers for lame delegations"},
+	{Name: "mail_records", Type: ports.ConfigTypeBool, Default: true, Description: "Collect the apex MX, TXT and DMARC records and detect the mail provider"},
+}
+
+// Auto-registro de la source al importar el package
+func init() {
+	if err := registry.Global().Register(
+		sourceName,
+		factory,
+		ports.SourceMetadata{
+			Name:         sourceName,
+			Description:  "DNS resolution of discovered hosts (A + AAAA), DNSSEC and delegation checks and mail records of the apex",
+			Version:      "1.0.0",
+			Author:       "AethonX",
+			Mode:         domain.SourceModePassive,
+			Type:         domain.SourceTypeBuiltin,
+			RequiresAuth: false,
+
+			// Consume dominios/subdominios de stages previos
+			InputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeDomain,
+				domain.ArtifactTypeSubdomain,
+			},
+			// También emite los nameservers con hallazgos, pero no se declaran:
+			// axfr los consume y emite subdominios, lo que crearía un ciclo.
+			OutputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeIP,
+				domain.ArtifactTypeIPv6,
+				domain.ArtifactTypeVulnerability,
+				domain.ArtifactTypeMXRecord,
+				domain.ArtifactTypeTXTRecord,
+			},
+			Priority: 12,
+			Phase:    ports.PhaseVerification,
+
+			ConfigSchema: configSchema,
+		},
+	); err != nil {
+		logx.New().Warn("failed to register dns source", "error", err.Error())
+	}
+}
+
+// factory crea la source desde SourceConfig (Custom según configSchema).
+func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("dns config: %w", err)
+	}
+
+	workers := opts.Int("workers")
+	if workers <= 0 || workers > 1000 {
+		return nil, fmt.Errorf("dns workers must be between 1 and 1000, got %d", workers)
+	}
+
+	var resolverOpt common.Option
+	addr := opts.String("resolver")
+	if addr != "" {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return nil, fmt.Errorf("dns resolver must be host:port, got %q", addr)
+		}
+		resolverOpt = common.WithResolver(&net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, addr)
+			},
+		})
+	}
+
+	source := New(logger, workers, resolverOpt)
+	if opts.Bool("zone_checks") {
+		source.zone = &zoneChecker{
+			client: &mdns.Client{Timeout: lookupTimeout},
+			server: addr,
+			nsPort: "53",
+			now:    time.Now,
+		}
+	}
+	if opts.Bool("mail_records") {
+		source.mail = &mailChecker{
+			client: &mdns.Client{Timeout: lookupTimeout},
+			server: addr,
+		}
+	}
+	return source, nil
+}
+
+// Resolver abstrae net.Resolver para poder sustituirlo en tests o
+// inyectarlo al embeber la source (ver common.WithResolver).
+type Resolver = common.Resolver
+
+// Source resuelve registros A y AAAA de los hosts descubiertos y emite
+// artifacts ip/ipv6 con relación resolves_to desde el host.
+type Source struct {
+	resolver Resolver
+	workers  int
+	logger   logx.Logger
+
+	// zone valida DNSSEC y las delegaciones del apex (nil = desactivado;
+	// lo activa la opción zone_checks)
+	zone *zoneChecker
+
+	// mail recoge MX/TXT/DMARC del apex (nil = desactivado; opción mail_records)
+	mail *mailChecker
+}
+
+// New crea la source dns. opts inyectan el resolver (por defecto el del
+// sistema, net.DefaultResolver).
+func New(logger logx.Logger, workers int, opts ...common.Option) *Source {
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	resolver := common.ApplyOptions(opts...).Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &Source{
+		resolver: resolver,
+		workers:  workers,
+		logger:   logger.With("source", sourceName),
+	}
+}
+
+// Name retorna el nombre de la fuente.
+func (s *Source) Name() string { return sourceName }
+
+// Mode retorna el modo de operación (pasivo: solo consultas al resolver).
+func (s *Source) Mode() domain.SourceMode { return domain.SourceModePassive }
+
+// Type retorna el tipo de fuente (builtin).
+func (s *Source) Type() domain.SourceType { return domain.SourceTypeBuiltin }
+
+// Close no libera recursos.
+func (s *Source) Close() error { return nil }
+
+// SetLogger sustituye el logger por el del escaneo (scan_id, stage, source).
+// Implementa ports.LogScopedSource.
+func (s *Source) SetLogger(logger logx.Logger) { s.logger = logger }
+
+// Run resuelve solo el dominio raíz del target.
+func (s *Source) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	return s.resolveAll(ctx, target, []string{target.QueryName()})
+}
+
+// RunWithInput resuelve el dominio raíz y todos los dominios/subdominios de input.
+// Implementa ports.InputConsumer.
+func (s *Source) RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+	hosts := []string{target.QueryName()}
+	if input != nil {
+		for _, a := range input.Artifacts {
+			if a.Type == domain.ArtifactTypeDomain || a.Type == domain.ArtifactTypeSubdomain {
+				hosts = append(hosts, a.Value)
+			}
+		}
+	}
+	return s.resolveAll(ctx, target, hosts)
+}
+
+// hostResult es la resolución de un host (v4 y v6 por separado).
+type hostResult struct {
+	host string
+	ipv4 []string
+	ipv6 []string
+	err  error
+}
+
+// resolveAll resuelve hosts (deduplicados) con un pool de workers.
+func (s *Source) resolveAll(ctx context.Context, target domain.Target, hosts []string) (*domain.ScanResult, error) {
+	result := domain.NewScanResult(target)
+	result.Metadata.SourcesUsed = []string{sourceName}
+
+	unique := dedupeHosts(hosts)
+
+	// Presupuesto global de consultas (--budget): dos por host (A y AAAA)
+	if granted := int(budget.Shared().Reserve(budget.DNSQueries, int64(2*len(unique)))) / 2; granted < len(unique) {
+		skipped := len(unique) - granted
+		s.logger.Warn("dns query budget exhausted, hosts not resolved", "skipped", skipped)
+		result.AddWarning(sourceName, fmt.Sprintf("dns query budget exhausted: %d of %d hosts not resolved", skipped, len(unique)))
+		unique = unique[:granted]
+	}
+
+	jobs := make(chan string)
+	results := make(chan hostResult, len(unique))
+
+	var wg sync.WaitGroup
+	workers := s.workers
+	if workers > len(unique) {
+		workers = len(unique)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for host := range jobs {
+				results <- s.resolveHost(ctx, host)
+			}
+		}()
+	}
+
+feed:
+	for _, host := range unique {
+		select {
+		case jobs <- host:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	close(results)
+
+	failures := 0
+	for r := range results {
+		if r.err != nil {
+			failures++
+			s.logger.Debug("dns lookup failed", "host", r.host, "error", r.err.Error())
+			continue
+		}
+		s.addArtifacts(result, target, r)
+	}
+
+	if failures > 0 {
+		result.AddWarning(sourceName, fmt.Sprintf("%d of %d lookups failed", failures, len(unique)))
+	}
+
+	if s.zone != nil && ctx.Err() == nil {
+		s.checkZone(ctx, target, result)
+	}
+	if s.mail != nil && ctx.Err() == nil {
+		s.checkMail(ctx, target, result)
+	}
+
+	s.logger.Info("dns resolution completed",
+		"hosts", len(unique),
+		"artifacts", len(result.Artifacts),
+		"failures", failures,
+	)
+
+	return result, ctx.Err()
+}
+
+// resolveHost consulta A y AAAA por separado para no depender del orden ni
+// de la preferencia de familia del sistema. NXDOMAIN no se considera error.
+func (s *Source) resolveHost(ctx context.Context, host string) hostResult {
+	r := hostResult{host: host}
+
+	for _, network := range []string{"ip4", "ip6"} {
+		lookupCtx, cancel := context.WithTimeout(ctx, lookupTimeout)
+		ips, err := s.resolver.LookupIP(lookupCtx, network, host)
+		cancel()
+
+		if err != nil {
+			var dnsErr *net.DNSError
+			if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+				continue
+			}
+			r.err = err
+			continue
+		}
+
+		for _, ip := range ips {
+			if network == "ip4" && ip.To4() != nil {
+				r.ipv4 = append(r.ipv4, ip.String())
+			} else if network == "ip6" && ip.To4() == nil {
+				r.ipv6 = append(r.ipv6, ip.String())
+			}
+		}
+	}
+
+	// Un fallo parcial (p.ej. AAAA con timeout) no invalida lo resuelto
+	if len(r.ipv4)+len(r.ipv6) > 0 {
+		r.err = nil
+	}
+	return r
+}
+
+// addArtifacts crea el artifact del host (con ResolvedIPs) y uno por IP.
+func (s *Source) addArtifacts(result *domain.ScanResult, target domain.Target, r hostResult) {
+	ips := append(append([]string{}, r.ipv4...), r.ipv6...)
+	if len(ips) == 0 {
+		return
+	}
+
+	hostType := domain.ArtifactTypeSubdomain
+	if r.host == target.QueryName() {
+		hostType = domain.ArtifactTypeDomain
+	}
+
+	hostMeta := metadata.NewDomainMetadata()
+	hostMeta.ResolvedIPs = ips
+	hostArtifact := domain.NewArtifactWithMetadata(hostType, r.host, sourceName, hostMeta)
+
+	for _, ip := range ips {
+		ipArtifact := domain.NewIPArtifact(ip, sourceName)
+		if ipMeta, ok := ipArtifact.TypedMetadata.(*metadata.IPMetadata); ok {
+			ipMeta.ReverseDNS = r.host
+		}
+		hostArtifact.AddRelation(ipArtifact.ID, domain.RelationResolvesTo, 1.0, sourceName)
+		ipArtifact.AddRelation(hostArtifact.ID, domain.RelationReverseResolves, 1.0, sourceName)
+		result.AddArtifact(ipArtifact)
+	}
+
+	result.AddArtifact(hostArtifact)
+}
+
+// dedupeHosts elimina duplicados y vacíos manteniendo un orden estable.
+func dedupeHosts(hosts []string) []string {
+	seen := make(map[string]bool, len(hosts))
+	out := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		if h == "" || seen[h] {
+			continue
+		}
+		seen[h] = true
+		out = append(out, h)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,143 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/budget"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/sources/common"
+	"aethonx/internal/testutil"
+)
+
+// fakeResolver responde desde mapas por familia.
+type fakeResolver struct {
+	v4   map[string][]string
+	v6   map[string][]string
+	fail map[string]bool
+}
+
+func (f *fakeResolver) LookupIP(_ context.Context, network, host string) ([]net.IP, error) {
+	if f.fail[host] {
+		return nil, errors.New("i/o timeout")
+	}
+	records := f.v4
+	if network == "ip6" {
+		records = f.v6
+	}
+	values, ok := records[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	ips := make([]net.IP, 0, len(values))
+	for _, v := range values {
+		ips = append(ips, net.ParseIP(v))
+	}
+	return ips, nil
+}
+
+func TestNew(t *testing.T) {
+	source := New(logx.New(), 0, common.WithResolver(&fakeResolver{}))
+
+	testutil.AssertEqual(t, source.Name(), "dns", "name should be dns")
+	testutil.AssertEqual(t, source.Mode(), domain.SourceModePassive, "mode should be passive")
+	testutil.AssertEqual(t, source.Type(), domain.SourceTypeBuiltin, "type should be builtin")
+	testutil.AssertEqual(t, source.workers, defaultWorkers, "workers should default")
+}
+
+func TestSource_RunWithInput_AAndAAAA(t *testing.T) {
+	resolver := &fakeResolver{
+		v4: map[string][]string{
+			"example.com":     {"93.184.216.34"},
+			"www.example.com": {"93.184.216.34"},
+		},
+		v6: map[string][]string{
+			"example.com": {"2606:2800:220:1:248:1893:25c8:1946"},
+		},
+	}
+	source := New(logx.New(), 4, common.WithResolver(resolver))
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+
+	input := domain.NewScanResult(target)
+	input.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "www.example.com", "crtsh"))
+	input.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "missing.example.com", "crtsh"))
+
+	result, err := source.RunWithInput(context.Background(), target, input)
+	testutil.AssertNoError(t, err, "run should succeed")
+
+	var v4, v6 int
+	var root *domain.Artifact
+	for _, a := range result.Artifacts {
+		switch a.Type {
+		case domain.ArtifactTypeIP:
+			v4++
+			meta, ok := a.TypedMetadata.(*metadata.IPMetadata)
+			testutil.AssertTrue(t, ok, "ip should carry IPMetadata")
+			testutil.AssertEqual(t, meta.IPVersion, "4", "ip version should be 4")
+		case domain.ArtifactTypeIPv6:
+			v6++
+			meta, ok := a.TypedMetadata.(*metadata.IPMetadata)
+			testutil.AssertTrue(t, ok, "ipv6 should carry IPMetadata")
+			testutil.AssertEqual(t, meta.IPVersion, "6", "ip version should be 6")
+		case domain.ArtifactTypeDomain:
+			root = a
+		}
+	}
+
+	// La IPv4 compartida se emite dos veces (una por host) y se deduplica en el merge
+	testutil.AssertEqual(t, v4, 2, "should emit A records")
+	testutil.AssertEqual(t, v6, 1, "should emit AAAA records")
+	testutil.AssertNotNil(t, root, "root domain should be emitted")
+	testutil.AssertEqual(t, len(root.Relations), 2, "root should resolve to v4 and v6")
+	testutil.AssertEqual(t, len(result.Warnings), 0, "NXDOMAIN should not warn")
+}
+
+func TestSource_Run_LookupFailureWarns(t *testing.T) {
+	resolver := &fakeResolver{fail: map[string]bool{"example.com": true}}
+	source := New(logx.New(), 1, common.WithResolver(resolver))
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+
+	result, err := source.Run(context.Background(), target)
+	testutil.AssertNoError(t, err, "lookup failures should not abort the source")
+	testutil.AssertEqual(t, len(result.Artifacts), 0, "no artifacts on failure")
+	testutil.AssertEqual(t, len(result.Warnings), 1, "failure should be reported as warning")
+}
+
+func TestSource_RunWithInput_QueryBudget(t *testing.T) {
+	budget.Shared().Configure(map[budget.Kind]int64{budget.DNSQueries: 4})
+	budget.Shared().Reset()
+	defer func() {
+		budget.Shared().Configure(nil)
+		budget.Shared().Reset()
+	}()
+
+	resolver := &fakeResolver{v4: map[string][]string{
+		"example.com":   {"93.184.216.34"},
+		"a.example.com": {"93.184.216.35"},
+		"b.example.com": {"93.184.216.36"},
+	}}
+	source := New(logx.New(), 1, common.WithResolver(resolver))
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+	input := domain.NewScanResult(target)
+	input.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "a.example.com", "crtsh"))
+	input.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "b.example.com", "crtsh"))
+
+	result, err := source.RunWithInput(context.Background(), target, input)
+	testutil.AssertNoError(t, err, "a spent budget is not a failure")
+	testutil.AssertEqual(t, len(result.Warnings), 1, "budget warning")
+	testutil.AssertContains(t, result.Warnings[0].Message, "1 of 3 hosts not resolved", "one host over budget")
+	testutil.AssertTrue(t, budget.Shared().Exhausted(budget.DNSQueries), "budget spent")
+}
+
+func TestFactory_InvalidResolver(t *testing.T) {
+	_, err := factory(ports.SourceConfig{Custom: map[string]interface{}{"resolver": "8.8.8.8"}}, logx.New())
+	testutil.AssertError(t, err, "resolver without port should be rejected")
+
+	_, err = factory(ports.SourceConfig{Custom: map[string]interface{}{"resolver": "8.8.8.8:53"}}, logx.New())
+	testutil.AssertNoError(t, err, "host:port resolver should be accepted")
+}
@@ -0,0 +1,210 @@
+// internal/sources/dns/mail.go
+package dns
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/sources/common"
+
+	mdns "github.com/miekg/dns"
+)
+
+// mailChecker consulta los registros MX y TXT del apex y el DMARC de
+// _dmarc.<apex> a través de un resolver recursivo.
+type mailChecker struct {
+	client *mdns.Client
+	server string // Resolver recursivo host:port ("" = el del sistema)
+}
+
+// mailRecord es un registro MX o TXT de la infraestructura de correo.
+type mailRecord struct {
+	kind     string // metadata.MailKind*
+	name     string // Nombre consultado
+	host     string // Intercambiador (MX)
+	priority int
+	text     string // Texto (TXT)
+	provider string
+}
+
+// mxProviders identifican el proveedor de correo por el sufijo del MX.
+var mxProviders = []struct{ suffix, provider string }{
+	{"google.com", "Google Workspace"},
+	{"googlemail.com", "Google Workspace"},
+	{"outlook.com", "Microsoft 365"},
+	{"protonmail.ch", "Proton Mail"},
+	{"zoho.com", "Zoho Mail"},
+	{"zoho.eu", "Zoho Mail"},
+	{"pphosted.com", "Proofpoint"},
+	{"mimecast.com", "Mimecast"},
+	{"amazonaws.com", "Amazon SES"},
+}
+
+// txtProviders identifican el proveedor por los includes del SPF y los
+// tokens de verificación que publica en el TXT del dominio.
+var txtProviders = []struct{ marker, provider string }{
+	{"include:_spf.google.com", "Google Workspace"},
+	{"include:spf.protection.outlook.com", "Microsoft 365"},
+	{"ms=ms", "Microsoft 365"},
+	{"include:_spf.protonmail.ch", "Proton Mail"},
+	{"protonmail-verification=", "Proton Mail"},
+	{"include:zoho.", "Zoho Mail"},
+	{"zoho-verification=", "Zoho Mail"},
+	{"include:amazonses.com", "Amazon SES"},
+}
+
+// lookup retorna los registros de correo de zone: MX ordenados por
+// preferencia, TXT del apex y DMARC.
+func (c *mailChecker) lookup(ctx context.Context, zone string) ([]mailRecord, error) {
+	var records []mailRecord
+
+	resp, err := exchange(ctx, c.client, c.server, zone, mdns.TypeMX, true)
+	if err != nil {
+		return nil, err
+	}
+	var mx []mailRecord
+	for _, rr := range resp.Answer {
+		r, ok := rr.(*mdns.MX)
+		if !ok || r.Mx == "." { // "." es un null MX (RFC 7505): no acepta correo
+			continue
+		}
+		host := normalizeName(r.Mx)
+		mx = append(mx, mailRecord{
+			kind:     metadata.MailKindMX,
+			name:     zone,
+			host:     host,
+			priority: int(r.Preference),
+			provider: mxProvider(host),
+		})
+	}
+	sort.SliceStable(mx, func(i, j int) bool { return mx[i].priority < mx[j].priority })
+	records = append(records, mx...)
+
+	for _, name := range []string{zone, "_dmarc." + zone} {
+		resp, err := exchange(ctx, c.client, c.server, name, mdns.TypeTXT, true)
+		if err != nil {
+			return records, err
+		}
+		for _, rr := range resp.Answer {
+			r, ok := rr.(*mdns.TXT)
+			if !ok {
+				continue
+			}
+			text := strings.Join(r.Txt, "")
+			kind := txtKind(name, text)
+			if name != zone && kind != metadata.MailKindDMARC {
+				continue
+			}
+			records = append(records, mailRecord{
+				kind:     kind,
+				name:     name,
+				text:     text,
+				provider: txtProvider(text),
+			})
+		}
+	}
+	return records, nil
+}
+
+// txtKind clasifica un TXT publicado en name.
+func txtKind(name, text string) string {
+	lower := strings.ToLower(text)
+	switch {
+	case strings.HasPrefix(lower, "v=spf1"):
+		return metadata.MailKindSPF
+	case strings.HasPrefix(name, "_dmarc.") && strings.HasPrefix(lower, "v=dmarc1"):
+		return metadata.MailKindDMARC
+	case strings.Contains(lower, "-verification=") || strings.HasPrefix(lower, "ms=ms"):
+		return metadata.MailKindVerification
+	default:
+		return metadata.MailKindTXT
+	}
+}
+
+// mxProvider retorna el proveedor de correo de un intercambiador ("" si no
+// es uno conocido).
+func mxProvider(host string) string {
+	for _, p := range mxProviders {
+		if host == p.suffix || strings.HasSuffix(host, "."+p.suffix) {
+			return p.provider
+		}
+	}
+	return ""
+}
+
+// txtProvider retorna el primer proveedor de correo que delata un TXT.
+func txtProvider(text string) string {
+	lower := strings.ToLower(text)
+	for _, p := range txtProviders {
+		if strings.Contains(lower, p.marker) {
+			return p.provider
+		}
+	}
+	return ""
+}
+
+// checkMail añade a result el apex con un artifact mx_record por
+// intercambiador (relación has_mx) y uno txt_record por TXT (has_txt).
+func (s *Source) checkMail(ctx context.Context, target domain.Target, result *domain.ScanResult) {
+	checker := *s.mail
+	if checker.server == "" {
+		server, err := common.SystemDNSServer()
+		if err != nil {
+			result.AddWarning(sourceName, "mail records skipped: "+err.Error())
+			return
+		}
+		checker.server = server
+	}
+
+	zone := target.QueryName()
+	records, err := checker.lookup(ctx, zone)
+	if err != nil {
+		s.logger.Warn("mail records lookup failed", "zone", zone, "error", err.Error())
+		result.AddWarning(sourceName, "mail records lookup failed: "+err.Error())
+	}
+	if len(records) == 0 {
+		return
+	}
+
+	meta := metadata.NewDomainMetadata()
+	apex := domain.NewArtifactWithMetadata(domain.ArtifactTypeDomain, zone, sourceName, meta)
+	providers := make(map[string]bool)
+	for _, r := range records {
+		var a *domain.Artifact
+		relation := domain.RelationHasTXT
+		if r.kind == metadata.MailKindMX {
+			a = domain.NewMXRecordArtifact(r.name, r.host, r.priority, sourceName)
+			relation = domain.RelationHasMX
+		} else {
+			a = domain.NewTXTRecordArtifact(r.name, r.text, r.kind, sourceName)
+		}
+		if mail := a.GetMailInfraMetadata(); mail != nil {
+			mail.Provider = r.provider
+		}
+		if r.provider != "" {
+			providers[r.provider] = true
+		}
+
+		recordType := "TXT"
+		if r.kind == metadata.MailKindMX {
+			recordType = "MX"
+		}
+		if !slices.Contains(meta.DNSRecords, recordType) {
+			meta.DNSRecords = append(meta.DNSRecords, recordType)
+		}
+
+		apex.AddRelation(a.ID, relation, 1.0, sourceName)
+		result.AddArtifact(a)
+	}
+	result.AddArtifact(apex)
+
+	s.logger.Info("mail records collected",
+		"zone", zone,
+		"records", len(records),
+		"providers", len(providers),
+	)
+}
@@ -0,0 +1,98 @@
+package dns
+
+import (
+	"context"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/sources/common"
+	"aethonx/internal/testutil"
+	"aethonx/internal/testutil/sourcetest"
+)
+
+func TestMailChecker_Lookup(t *testing.T) {
+	zones := newZones(t, "mail.test.", "ns1.mail.test.")
+	zones.add(t,
+		"mail.test. 3600 IN MX 10 ALT1.ASPMX.L.GOOGLE.COM.",
+		"mail.test. 3600 IN MX 1 aspmx.l.google.com.",
+		"mail.test. 3600 IN MX 20 mx.self-hosted.test.",
+		`mail.test. 3600 IN TXT "v=spf1 include:_spf.google.com " "~all"`,
+		`mail.test. 3600 IN TXT "MS=ms12345678"`,
+		`mail.test. 3600 IN TXT "some-other=token"`,
+		`_dmarc.mail.test. 3600 IN TXT "v=DMARC1; p=reject"`,
+	)
+	zone := startZones(t, zones)
+	checker := &mailChecker{client: zone.client, server: zone.server}
+
+	records, err := checker.lookup(context.Background(), "mail.test")
+	testutil.AssertNoError(t, err, "lookup")
+	testutil.AssertEqual(t, len(records), 7, "3 MX + 3 TXT + DMARC")
+
+	testutil.AssertEqual(t, records[0].host, "aspmx.l.google.com", "MX sorted by preference")
+	testutil.AssertEqual(t, records[0].provider, "Google Workspace", "Google MX")
+	testutil.AssertEqual(t, records[1].host, "alt1.aspmx.l.google.com", "lowercased exchange")
+	testutil.AssertEqual(t, records[2].provider, "", "unknown exchange")
+
+	kinds := make(map[string]mailRecord)
+	for _, r := range records[3:] {
+		kinds[r.kind] = r
+	}
+	testutil.AssertEqual(t, kinds[metadata.MailKindSPF].text, "v=spf1 include:_spf.google.com ~all", "TXT chunks joined")
+	testutil.AssertEqual(t, kinds[metadata.MailKindSPF].provider, "Google Workspace", "SPF include")
+	testutil.AssertEqual(t, kinds[metadata.MailKindVerification].provider, "Microsoft 365", "O365 verification token")
+	testutil.AssertEqual(t, kinds[metadata.MailKindDMARC].name, "_dmarc.mail.test", "DMARC owner")
+	testutil.AssertEqual(t, kinds[metadata.MailKindTXT].text, "some-other=token", "other TXT kept")
+}
+
+func TestMailProviders(t *testing.T) {
+	tests := map[string]string{
+		"outlook.com.attacker.test":               "",
+		"example-com.mail.protection.outlook.com": "Microsoft 365",
+		"mail.protonmail.ch":                      "Proton Mail",
+		"mx.zoho.eu":                              "Zoho Mail",
+		"notgoogle.com":                           "",
+	}
+	for host, want := range tests {
+		testutil.AssertEqual(t, mxProvider(host), want, host)
+	}
+	testutil.AssertEqual(t, txtProvider("v=spf1 include:_spf.protonmail.ch mx ~all"), "Proton Mail", "Proton SPF")
+}
+
+func TestSource_MailArtifacts(t *testing.T) {
+	zones := newZones(t, "mail.test.", "ns1.mail.test.")
+	zones.add(t,
+		"mail.test. 3600 IN MX 10 mail.protonmail.ch.",
+		`mail.test. 3600 IN TXT "v=spf1 include:_spf.protonmail.ch ~all"`,
+	)
+	zone := startZones(t, zones)
+	source := New(logx.NewSilent(), 1, common.WithResolver(&fakeResolver{}))
+	source.mail = &mailChecker{client: zone.client, server: zone.server}
+
+	result, err := source.Run(context.Background(), *domain.NewTarget("mail.test", domain.ScanModePassive))
+	testutil.AssertNoError(t, err, "run")
+	sourcetest.AssertArtifacts(t, result.Artifacts,
+		"domain mail.test",
+		"mx_record mail.protonmail.ch",
+		"txt_record v=spf1 include:_spf.protonmail.ch ~all",
+	)
+
+	var apex, mx, txt *domain.Artifact
+	for _, a := range result.Artifacts {
+		switch a.Type {
+		case domain.ArtifactTypeDomain:
+			apex = a
+		case domain.ArtifactTypeMXRecord:
+			mx = a
+		case domain.ArtifactTypeTXTRecord:
+			txt = a
+		}
+	}
+	testutil.AssertTrue(t, apex.HasRelation(mx.ID, domain.RelationHasMX), "apex has_mx")
+	testutil.AssertTrue(t, apex.HasRelation(txt.ID, domain.RelationHasTXT), "apex has_txt")
+	meta := mx.GetMailInfraMetadata()
+	testutil.AssertEqual(t, meta.Provider, "Proton Mail", "provider")
+	testutil.AssertEqual(t, meta.Priority, 10, "priority")
+	testutil.AssertEqual(t, txt.GetMailInfraMetadata().Kind, metadata.MailKindSPF, "SPF kind")
+}
@@ -0,0 +1,394 @@
+// internal/sources/dns/zone.go
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/platform/budget"
+	"aethonx/internal/platform/validator"
+	"aethonx/internal/sources/common"
+
+	mdns "github.com/miekg/dns"
+)
+
+// Estados de DNSSEC del apex (DomainMetadata.DNSSECStatus).
+const (
+	dnssecValid    = "valid"
+	dnssecUnsigned = "unsigned"
+	dnssecInsecure = "insecure"
+	dnssecBroken   = "broken"
+)
+
+// errQueryBudget indica que --budget dns no permite más consultas.
+var errQueryBudget = errors.New("dns query budget exhausted")
+
+// zoneChecker valida la cadena DNSSEC del apex y busca delegaciones rotas
+// (lame delegations) consultando un resolver recursivo y, para comprobar si
+// cada nameserver es autoritativo, al propio nameserver.
+type zoneChecker struct {
+	client *mdns.Client
+	server string // Resolver recursivo host:port
+	nsPort string // Puerto DNS de los nameservers
+	now    func() time.Time
+}
+
+// zoneReport es el resultado de las comprobaciones del apex.
+type zoneReport struct {
+	status      string // dnssec*
+	reason      string // Por qué la cadena no valida (broken/insecure)
+	nameservers []string
+	findings    []zoneFinding
+}
+
+// zoneFinding es un hallazgo de configuración DNS.
+type zoneFinding struct {
+	id          string // ID del check (TemplateID)
+	title       string
+	severity    string
+	description string
+	nameserver  string // Nameserver afectado ("" = la zona)
+}
+
+// check comprueba DNSSEC y las delegaciones de zone.
+func (c *zoneChecker) check(ctx context.Context, zone string) (*zoneReport, error) {
+	report := &zoneReport{}
+
+	status, reason, err := c.dnssec(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	report.status, report.reason = status, reason
+
+	switch status {
+	case dnssecBroken:
+		report.findings = append(report.findings, zoneFinding{
+			id:          "dnssec-broken",
+			title:       "DNSSEC chain of trust broken",
+			severity:    "high",
+			description: fmt.Sprintf("DNSSEC validation of %s fails: %s. Validating resolvers answer SERVFAIL for the whole zone", zone, reason),
+		})
+	case dnssecInsecure:
+		report.findings = append(report.findings, zoneFinding{
+			id:          "dnssec-missing-ds",
+			title:       "DNSSEC not anchored in the parent zone",
+			severity:    "low",
+			description: fmt.Sprintf("%s is signed but %s, so resolvers treat it as unsigned", zone, reason),
+		})
+	case dnssecUnsigned:
+		report.findings = append(report.findings, zoneFinding{
+			id:          "dnssec-unsigned",
+			title:       "DNSSEC not enabled",
+			severity:    "low",
+			description: fmt.Sprintf("%s publishes no DS or DNSKEY records; its answers can be spoofed", zone),
+		})
+	}
+
+	nameservers, err := c.nameservers(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	report.nameservers = nameservers
+	for _, ns := range nameservers {
+		finding, err := c.delegation(ctx, zone, ns)
+		if err != nil {
+			return report, err
+		}
+		if finding != nil {
+			report.findings = append(report.findings, *finding)
+		}
+	}
+	return report, nil
+}
+
+// dnssec valida el último eslabón de la cadena: el DS del padre debe casar
+// con un DNSKEY que firme el RRset DNSKEY, y ese RRset debe firmar el SOA.
+// La cadena del padre hasta la raíz se delega en el resolver.
+func (c *zoneChecker) dnssec(ctx context.Context, zone string) (status, reason string, err error) {
+	dsResp, err := c.query(ctx, c.server, zone, mdns.TypeDS, true)
+	if err != nil {
+		return "", "", err
+	}
+	keyResp, err := c.query(ctx, c.server, zone, mdns.TypeDNSKEY, true)
+	if err != nil {
+		return "", "", err
+	}
+
+	var dsRecords []*mdns.DS
+	for _, rr := range dsResp.Answer {
+		if ds, ok := rr.(*mdns.DS); ok {
+			dsRecords = append(dsRecords, ds)
+		}
+	}
+	var keys []*mdns.DNSKEY
+	var keySet []mdns.RR
+	for _, rr := range keyResp.Answer {
+		if key, ok := rr.(*mdns.DNSKEY); ok {
+			keys = append(keys, key)
+			keySet = append(keySet, key)
+		}
+	}
+
+	switch {
+	case len(dsRecords) == 0 && len(keys) == 0:
+		return dnssecUnsigned, "", nil
+	case len(dsRecords) == 0:
+		return dnssecInsecure, "the parent zone has no DS record", nil
+	case len(keys) == 0:
+		return dnssecBroken, "the parent has DS records but the zone publishes no DNSKEY", nil
+	}
+
+	// Claves ancladas: las que casan con algún DS del padre
+	var anchored []*mdns.DNSKEY
+	for _, key := range keys {
+		for _, ds := range dsRecords {
+			if key.KeyTag() != ds.KeyTag || key.Algorithm != ds.Algorithm {
+				continue
+			}
+			if digest := key.ToDS(ds.DigestType); digest != nil && strings.EqualFold(digest.Digest, ds.Digest) {
+				anchored = append(anchored, key)
+				break
+			}
+		}
+	}
+	if len(anchored) == 0 {
+		return dnssecBroken, "no DNSKEY matches the DS records of the parent zone", nil
+	}
+
+	if reason := c.verify(keySet, signatures(keyResp.Answer, mdns.TypeDNSKEY), anchored); reason != "" {
+		return dnssecBroken, "DNSKEY RRset " + reason, nil
+	}
+
+	soaResp, err := c.query(ctx, c.server, zone, mdns.TypeSOA, true)
+	if err != nil {
+		return "", "", err
+	}
+	var soaSet []mdns.RR
+	for _, rr := range soaResp.Answer {
+		if rr.Header().Rrtype == mdns.TypeSOA {
+			soaSet = append(soaSet, rr)
+		}
+	}
+	if len(soaSet) > 0 {
+		if reason := c.verify(soaSet, signatures(soaResp.Answer, mdns.TypeSOA), keys); reason != "" {
+			return dnssecBroken, "SOA " + reason, nil
+		}
+	}
+	return dnssecValid, "", nil
+}
+
+// verify retorna "" si alguna firma de sigs sobre rrset, hecha con una de
+// keys, es válida y está vigente; si no, el motivo.
+func (c *zoneChecker) verify(rrset []mdns.RR, sigs []*mdns.RRSIG, keys []*mdns.DNSKEY) string {
+	if len(sigs) == 0 {
+		return "is not signed"
+	}
+	reason := "signature does not verify"
+	for _, sig := range sigs {
+		for _, key := range keys {
+			if key.KeyTag() != sig.KeyTag || key.Algorithm != sig.Algorithm {
+				continue
+			}
+			if err := sig.Verify(key, rrset); err != nil {
+				continue
+			}
+			if sig.ValidityPeriod(c.now()) {
+				return ""
+			}
+			reason = "signature expired or not yet valid"
+		}
+	}
+	return reason
+}
+
+// signatures retorna las RRSIG de rrs que cubren typ.
+func signatures(rrs []mdns.RR, typ uint16) []*mdns.RRSIG {
+	var sigs []*mdns.RRSIG
+	for _, rr := range rrs {
+		if sig, ok := rr.(*mdns.RRSIG); ok && sig.TypeCovered == typ {
+			sigs = append(sigs, sig)
+		}
+	}
+	return sigs
+}
+
+// nameservers retorna los NS de la zona según el resolver.
+func (c *zoneChecker) nameservers(ctx context.Context, zone string) ([]string, error) {
+	resp, err := c.query(ctx, c.server, zone, mdns.TypeNS, true)
+	if err != nil {
+		return nil, err
+	}
+	var hosts []string
+	for _, rr := range resp.Answer {
+		if ns, ok := rr.(*mdns.NS); ok {
+			hosts = append(hosts, normalizeName(ns.Ns))
+		}
+	}
+	return dedupeHosts(hosts), nil
+}
+
+// delegation comprueba un nameserver de la zona. Si su dominio registrable
+// no existe, cualquiera puede registrarlo y servir la zona (takeover); si
+// existe pero el nameserver no responde con autoridad, la delegación es lame.
+func (c *zoneChecker) delegation(ctx context.Context, zone, ns string) (*zoneFinding, error) {
+	if registrable := validator.RegistrableDomain(ns); registrable != "" && ns != zone && !strings.HasSuffix(ns, "."+zone) {
+		resp, err := c.query(ctx, c.server, registrable, mdns.TypeSOA, true)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Rcode == mdns.RcodeNameError {
+			return &zoneFinding{
+				id:       "dns-ns-unregistered",
+				title:    "Nameserver in an unregistered domain (takeover risk)",
+				severity: "high",
+				description: fmt.Sprintf("%s delegates to %s, but %s does not exist: registering it would let anyone serve the %s zone",
+					zone, ns, registrable, zone),
+				nameserver: ns,
+			}, nil
+		}
+	}
+
+	lame := func(why string) *zoneFinding {
+		return &zoneFinding{
+			id:          "dns-lame-delegation",
+			title:       "Lame delegation",
+			severity:    "medium",
+			description: fmt.Sprintf("%s is listed as nameserver of %s but %s", ns, zone, why),
+			nameserver:  ns,
+		}
+	}
+
+	addrResp, err := c.query(ctx, c.server, ns, mdns.TypeA, true)
+	if err != nil {
+		return nil, err
+	}
+	var addr string
+	for _, rr := range addrResp.Answer {
+		if a, ok := rr.(*mdns.A); ok {
+			addr = a.A.String()
+			break
+		}
+	}
+	if addr == "" {
+		return lame("its name does not resolve"), nil
+	}
+
+	resp, err := c.query(ctx, net.JoinHostPort(addr, c.nsPort), zone, mdns.TypeSOA, false)
+	if errors.Is(err, errQueryBudget) {
+		return nil, err
+	}
+	switch {
+	case err != nil:
+		return lame("it does not answer (" + err.Error() + ")"), nil
+	case resp.Rcode != mdns.RcodeSuccess:
+		return lame("it answers " + mdns.RcodeToString[resp.Rcode]), nil
+	case !resp.Authoritative:
+		return lame("its answers are not authoritative"), nil
+	}
+	return nil, nil
+}
+
+// query envía una consulta con EDNS0 y el bit DO, y la repite por TCP si la
+// respuesta llega truncada. recursive pide recursión y desactiva la
+// validación del resolver (CD) para recibir los datos aunque no validen.
+func (c *zoneChecker) query(ctx context.Context, server, name string, qtype uint16, recursive bool) (*mdns.Msg, error) {
+	return exchange(ctx, c.client, server, name, qtype, recursive)
+}
+
+// exchange implementa zoneChecker.query con un cliente cualquiera; también
+// la usan las consultas de correo (mailChecker).
+func exchange(ctx context.Context, client *mdns.Client, server, name string, qtype uint16, recursive bool) (*mdns.Msg, error) {
+	if budget.Shared().Reserve(budget.DNSQueries, 1) == 0 {
+		return nil, errQueryBudget
+	}
+
+	msg := new(mdns.Msg)
+	msg.SetQuestion(mdns.Fqdn(name), qtype)
+	msg.RecursionDesired = recursive
+	msg.CheckingDisabled = recursive
+	msg.SetEdns0(4096, true)
+
+	resp, _, err := client.ExchangeContext(ctx, msg, server)
+	if err == nil && resp.Truncated {
+		tcp := *client
+		tcp.Net = "tcp"
+		resp, _, err = tcp.ExchangeContext(ctx, msg, server)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s %s @%s: %w", mdns.TypeToString[qtype], name, server, err)
+	}
+	return resp, nil
+}
+
+// checkZone ejecuta las comprobaciones del apex y añade a result el dominio
+// con DNSSEC/nameservers en su DomainMetadata y un artifact vulnerability
+// por hallazgo.
+func (s *Source) checkZone(ctx context.Context, target domain.Target, result *domain.ScanResult) {
+	checker := *s.zone
+	if checker.server == "" {
+		server, err := common.SystemDNSServer()
+		if err != nil {
+			result.AddWarning(sourceName, "zone checks skipped: "+err.Error())
+			return
+		}
+		checker.server = server
+	}
+
+	zone := target.QueryName()
+	report, err := checker.check(ctx, zone)
+	if err != nil {
+		s.logger.Warn("zone checks failed", "zone", zone, "error", err.Error())
+		result.AddWarning(sourceName, "zone checks failed: "+err.Error())
+		if report == nil {
+			return
+		}
+	}
+
+	meta := metadata.NewDomainMetadata()
+	meta.DNSSECStatus = report.status
+	meta.DNSSEC = report.status == dnssecValid
+	meta.Nameservers = report.nameservers
+	apex := domain.NewArtifactWithMetadata(domain.ArtifactTypeDomain, zone, sourceName, meta)
+
+	for _, f := range report.findings {
+		value := f.id + ":" + zone
+		if f.nameserver != "" {
+			value += "@" + f.nameserver
+		}
+		vuln := domain.NewArtifactWithMetadata(domain.ArtifactTypeVulnerability, value, sourceName, &metadata.VulnerabilityMetadata{
+			Title:         f.title,
+			Severity:      f.severity,
+			Description:   f.description,
+			MatchedAt:     zone,
+			TemplateID:    f.id,
+			DiscoveryTool: sourceName,
+		})
+		apex.AddRelation(vuln.ID, domain.RelationHasVuln, 1.0, sourceName)
+		if f.nameserver != "" {
+			nsArtifact := domain.NewArtifact(domain.ArtifactTypeNameserver, f.nameserver, sourceName)
+			nsArtifact.AddRelation(vuln.ID, domain.RelationHasVuln, 1.0, sourceName)
+			apex.AddRelation(nsArtifact.ID, domain.RelationHasNameserver, 1.0, sourceName)
+			result.AddArtifact(nsArtifact)
+		}
+		result.AddArtifact(vuln)
+	}
+	result.AddArtifact(apex)
+
+	s.logger.Info("zone checks completed",
+		"zone", zone,
+		"dnssec", report.status,
+		"nameservers", len(report.nameservers),
+		"findings", len(report.findings),
+	)
+}
+
+// normalizeName pasa un nombre DNS a minúsculas y sin punto final.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
@@ -0,0 +1,229 @@
+package dns
+
+import (
+	"context"
+	"crypto"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/sources/common"
+	"aethonx/internal/testutil"
+	"aethonx/internal/testutil/sourcetest"
+
+	mdns "github.com/miekg/dns"
+)
+
+// testZones sirve como resolver y como nameserver autoritativo de las zonas
+// de prueba. Las consultas sin recursión a lameZone no son autoritativas.
+type testZones struct {
+	records map[string][]mdns.RR // "<nombre> <tipo>" -> respuesta
+}
+
+const lameZone = "lame.test."
+
+func (z *testZones) add(t *testing.T, rrs ...string) {
+	t.Helper()
+	for _, s := range rrs {
+		rr, err := mdns.NewRR(s)
+		testutil.AssertNoError(t, err, "test record "+s)
+		z.put(rr)
+	}
+}
+
+func (z *testZones) put(rrs ...mdns.RR) {
+	for _, rr := range rrs {
+		key := strings.ToLower(rr.Header().Name) + " " + mdns.TypeToString[rr.Header().Rrtype]
+		if sig, ok := rr.(*mdns.RRSIG); ok {
+			key = strings.ToLower(sig.Header().Name) + " " + mdns.TypeToString[sig.TypeCovered]
+		}
+		z.records[key] = append(z.records[key], rr)
+	}
+}
+
+func (z *testZones) ServeDNS(w mdns.ResponseWriter, req *mdns.Msg) {
+	q := req.Question[0]
+	m := new(mdns.Msg)
+	m.SetReply(req)
+	m.Authoritative = !(q.Name == lameZone && !req.RecursionDesired)
+
+	answer := z.records[strings.ToLower(q.Name)+" "+mdns.TypeToString[q.Qtype]]
+	known := false
+	for key := range z.records {
+		if strings.HasPrefix(key, strings.ToLower(q.Name)+" ") {
+			known = true
+			break
+		}
+	}
+	if !known {
+		m.Rcode = mdns.RcodeNameError
+	}
+	m.Answer = answer
+	_ = w.WriteMsg(m)
+}
+
+// startZones arranca el servidor UDP y retorna un checker que lo usa como
+// resolver y como puerto de los nameservers.
+func startZones(t *testing.T, zones *testZones) *zoneChecker {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	testutil.AssertNoError(t, err, "listen")
+	server := &mdns.Server{PacketConn: pc, Handler: zones}
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+
+	_, port, _ := net.SplitHostPort(pc.LocalAddr().String())
+	return &zoneChecker{
+		client: &mdns.Client{Timeout: time.Second},
+		server: pc.LocalAddr().String(),
+		nsPort: port,
+		now:    time.Now,
+	}
+}
+
+// signZone publica DNSKEY y firmas de zone con una clave nueva y el DS del
+// padre calculado con dsKey (nil = la misma clave).
+func signZone(t *testing.T, zones *testZones, zone string, dsKey *mdns.DNSKEY) *mdns.DNSKEY {
+	t.Helper()
+	key, signer := newKey(t, zone)
+	if dsKey == nil {
+		dsKey = key
+	}
+	zones.put(dsKey.ToDS(mdns.SHA256))
+	zones.put(key)
+
+	for _, typ := range []uint16{mdns.TypeDNSKEY, mdns.TypeSOA} {
+		rrset := zones.records[zone+" "+mdns.TypeToString[typ]]
+		sig := &mdns.RRSIG{
+			Hdr:        mdns.RR_Header{Name: zone, Rrtype: mdns.TypeRRSIG, Class: mdns.ClassINET, Ttl: 3600},
+			Algorithm:  key.Algorithm,
+			KeyTag:     key.KeyTag(),
+			SignerName: zone,
+			Inception:  uint32(time.Now().Add(-time.Hour).Unix()),
+			Expiration: uint32(time.Now().Add(24 * time.Hour).Unix()),
+		}
+		testutil.AssertNoError(t, sig.Sign(signer, rrset), "sign "+mdns.TypeToString[typ])
+		zones.put(sig)
+	}
+	return key
+}
+
+func newKey(t *testing.T, zone string) (*mdns.DNSKEY, crypto.Signer) {
+	t.Helper()
+	key := &mdns.DNSKEY{
+		Hdr:       mdns.RR_Header{Name: zone, Rrtype: mdns.TypeDNSKEY, Class: mdns.ClassINET, Ttl: 3600},
+		Flags:     257,
+		Protocol:  3,
+		Algorithm: mdns.ECDSAP256SHA256,
+	}
+	priv, err := key.Generate(256)
+	testutil.AssertNoError(t, err, "generate key")
+	return key, priv.(crypto.Signer)
+}
+
+func newZones(t *testing.T, zone string, ns ...string) *testZones {
+	zones := &testZones{records: make(map[string][]mdns.RR)}
+	zones.add(t, zone+" 3600 IN SOA "+ns[0]+" admin."+zone+" 1 7200 3600 1209600 3600")
+	for _, n := range ns {
+		zones.add(t, zone+" 3600 IN NS "+n)
+		if strings.HasSuffix(n, "."+zone) {
+			zones.add(t, n+" 3600 IN A 127.0.0.1")
+		}
+	}
+	return zones
+}
+
+func TestZoneChecker_DNSSECValid(t *testing.T) {
+	zones := newZones(t, "signed.test.", "ns1.signed.test.")
+	signZone(t, zones, "signed.test.", nil)
+	checker := startZones(t, zones)
+
+	report, err := checker.check(context.Background(), "signed.test")
+	testutil.AssertNoError(t, err, "check")
+	testutil.AssertEqual(t, report.status, dnssecValid, "signed zone validates")
+	testutil.AssertEqual(t, len(report.findings), 0, "no findings")
+	testutil.AssertEqual(t, strings.Join(report.nameservers, ","), "ns1.signed.test", "nameservers")
+
+	// Con el reloj tras la caducidad, las firmas ya no son vigentes
+	checker.now = func() time.Time { return time.Now().Add(48 * time.Hour) }
+	report, err = checker.check(context.Background(), "signed.test")
+	testutil.AssertNoError(t, err, "check")
+	testutil.AssertEqual(t, report.status, dnssecBroken, "expired signatures")
+	testutil.AssertTrue(t, strings.Contains(report.reason, "expired"), "reason mentions expiry: "+report.reason)
+}
+
+func TestZoneChecker_DNSSECBroken(t *testing.T) {
+	zones := newZones(t, "broken.test.", "ns1.broken.test.")
+	other, _ := newKey(t, "broken.test.")
+	signZone(t, zones, "broken.test.", other)
+	checker := startZones(t, zones)
+
+	report, err := checker.check(context.Background(), "broken.test")
+	testutil.AssertNoError(t, err, "check")
+	testutil.AssertEqual(t, report.status, dnssecBroken, "DS of another key")
+	testutil.AssertEqual(t, report.findings[0].id, "dnssec-broken", "finding")
+	testutil.AssertEqual(t, report.findings[0].severity, "high", "severity")
+}
+
+func TestZoneChecker_UnsignedAndDelegations(t *testing.T) {
+	zones := newZones(t, "plain.test.", "ns1.plain.test.", "ns1.dangling-provider.test.")
+	checker := startZones(t, zones)
+
+	report, err := checker.check(context.Background(), "plain.test")
+	testutil.AssertNoError(t, err, "check")
+	testutil.AssertEqual(t, report.status, dnssecUnsigned, "no DS nor DNSKEY")
+
+	ids := make(map[string]string)
+	for _, f := range report.findings {
+		ids[f.id] = f.nameserver
+	}
+	testutil.AssertEqual(t, len(ids), 2, "unsigned + unregistered nameserver")
+	testutil.AssertEqual(t, ids["dns-ns-unregistered"], "ns1.dangling-provider.test", "takeover vector")
+	_, unsigned := ids["dnssec-unsigned"]
+	testutil.AssertTrue(t, unsigned, "absent DNSSEC is flagged")
+}
+
+func TestZoneChecker_LameDelegation(t *testing.T) {
+	zones := newZones(t, lameZone, "ns1.lame.test.")
+	checker := startZones(t, zones)
+
+	report, err := checker.check(context.Background(), "lame.test")
+	testutil.AssertNoError(t, err, "check")
+	var lame *zoneFinding
+	for i := range report.findings {
+		if report.findings[i].id == "dns-lame-delegation" {
+			lame = &report.findings[i]
+		}
+	}
+	testutil.AssertTrue(t, lame != nil, "non-authoritative nameserver is lame")
+	testutil.AssertEqual(t, lame.nameserver, "ns1.lame.test", "lame nameserver")
+}
+
+func TestSource_ZoneChecksArtifacts(t *testing.T) {
+	zones := newZones(t, "plain.test.", "ns1.plain.test.", "ns1.dangling-provider.test.")
+	source := New(logx.NewSilent(), 1, common.WithResolver(&fakeResolver{}))
+	source.zone = startZones(t, zones)
+
+	result, err := source.Run(context.Background(), *domain.NewTarget("plain.test", domain.ScanModePassive))
+	testutil.AssertNoError(t, err, "run")
+	sourcetest.AssertArtifacts(t, result.Artifacts,
+		"domain plain.test",
+		"vulnerability dnssec-unsigned:plain.test",
+		"vulnerability dns-ns-unregistered:plain.test@ns1.dangling-provider.test",
+		"nameserver ns1.dangling-provider.test",
+	)
+
+	for _, a := range result.Artifacts {
+		if a.Type != domain.ArtifactTypeDomain {
+			continue
+		}
+		meta := a.TypedMetadata.(*metadata.DomainMetadata)
+		testutil.AssertEqual(t, meta.DNSSECStatus, dnssecUnsigned, "DNSSEC status in metadata")
+		testutil.AssertTrue(t, !meta.DNSSEC, "unsigned zone is not DNSSEC")
+		testutil.AssertEqual(t, len(meta.Nameservers), 2, "nameservers in metadata")
+	}
+}
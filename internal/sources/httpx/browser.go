@@ -0,0 +1,35 @@
+package httpx
+
+import (
+	"context"
+	"strconv"
+
+	"aethonx/internal/platform/browser"
+)
+
+// withBrowser reserves a slot of the shared headless browser pool for a
+// headless (-ss) run, so httpx counts against --browser-tabs together with
+// the other screenshot and rendering sources, and caps its threads (one
+// tab each) to the limit. Other profiles get a no-op release.
+func (h *HTTPXSource) withBrowser(ctx context.Context, args []string) ([]string, func(), error) {
+	if h.profile != ProfileHeadless {
+		return args, func() {}, nil
+	}
+
+	pool := browser.Shared()
+	release, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	maxTabs := pool.Config().MaxTabs
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == "-t" {
+			if threads, err := strconv.Atoi(args[i+1]); err != nil || threads > maxTabs {
+				args[i+1] = strconv.Itoa(maxTabs)
+			}
+			break
+		}
+	}
+	return args, release, nil
+}
@@ -0,0 +1,34 @@
+package httpx
+
+import (
+	"aethonx/internal/platform/budget"
+)
+
+// reserveProbes reserves one request per target against the global request
+// budget (--budget) and trims the groups, in order, to what was granted.
+// httpx may send a few more requests per target (redirects, fallback to
+// http), so the reservation is a lower bound. Returns the kept groups and
+// the number of targets dropped.
+func reserveProbes(groups ...[]string) ([][]string, int) {
+	total := 0
+	for _, group := range groups {
+		total += len(group)
+	}
+	granted := int(budget.Shared().Reserve(budget.Requests, int64(total)))
+
+	kept := make([][]string, len(groups))
+	for i, group := range groups {
+		n := min(len(group), granted)
+		kept[i] = group[:n]
+		granted -= n
+	}
+	return kept, total - sum(kept)
+}
+
+func sum(groups [][]string) int {
+	n := 0
+	for _, group := range groups {
+		n += len(group)
+	}
+	return n
+}
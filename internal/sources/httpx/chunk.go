@@ -0,0 +1,156 @@
+package httpx
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"aethonx/internal/core/domain"
+)
+
+const (
+	// defaultChunkSize targets per httpx process (0 = single process)
+	defaultChunkSize = 5000
+	// defaultChunkWorkers httpx processes running at once
+	defaultChunkWorkers = 1
+	// maxChunkWorkers caps parallel processes (each uses its own threads)
+	maxChunkWorkers = 16
+)
+
+// SetChunking splits large target lists into chunks of size targets, run
+// by up to workers httpx processes at once. size 0 runs a single process.
+func (h *HTTPXSource) SetChunking(size, workers int) {
+	if workers <= 0 {
+		workers = defaultChunkWorkers
+	}
+	h.chunkSize = size
+	h.chunkWorkers = workers
+}
+
+// SetPartialHandler registers fn to receive each finished chunk's result
+// when a run is split into several chunks. Artifacts accepted by fn (nil
+// error) are not repeated in the final result; on error they stay in it.
+// fn is called sequentially. nil disables partial flushing.
+// Implements ports.PartialResultSource.
+func (h *HTTPXSource) SetPartialHandler(fn func(partial *domain.ScanResult) error) {
+	h.partialMu.Lock()
+	defer h.partialMu.Unlock()
+	h.partial = fn
+}
+
+// runChunks probes targets chunk by chunk with the current profile settings.
+// Failed chunks become warnings; the run fails only if every chunk failed.
+func (h *HTTPXSource) runChunks(ctx context.Context, target domain.Target, targets []string, inputArtifacts []*domain.Artifact) (*domain.ScanResult, int, error) {
+	chunks := splitTargets(targets, h.chunkSize)
+	if len(chunks) == 1 {
+		result, err := h.probeChunk(ctx, target, targets, inputArtifacts)
+		if err != nil {
+			return nil, 0, err
+		}
+		h.ledger.record(targets)
+		return result, 0, nil
+	}
+
+	h.GetLogger().Info("running httpx in chunks",
+		"profile", h.profile,
+		"targets", len(targets),
+		"chunks", len(chunks),
+		"workers", h.chunkWorkers,
+	)
+
+	var (
+		mu        sync.Mutex
+		result    = domain.NewScanResult(target)
+		done      int
+		failed    int
+		artifacts int
+		flushed   int
+		firstErr  error
+	)
+
+	sem := make(chan struct{}, h.chunkWorkers)
+	var wg sync.WaitGroup
+
+launch:
+	for i, chunk := range chunks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break launch
+		}
+
+		wg.Add(1)
+		go func(i int, chunk []string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			chunkResult, err := h.probeChunk(ctx, target, chunk, inputArtifacts)
+
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+
+			if err != nil {
+				failed++
+				if firstErr == nil {
+					firstErr = err
+				}
+				h.GetLogger().Warn("httpx chunk failed", "chunk", i+1, "error", err.Error())
+				result.AddWarning("httpx", fmt.Sprintf("chunk %d/%d failed: %v", i+1, len(chunks), err))
+			} else {
+				h.ledger.record(chunk)
+				artifacts += len(chunkResult.Artifacts)
+				result.Warnings = append(result.Warnings, chunkResult.Warnings...)
+
+				if h.flushPartial(chunkResult) {
+					flushed += len(chunkResult.Artifacts)
+				} else {
+					for _, artifact := range chunkResult.Artifacts {
+						result.AddArtifact(artifact)
+					}
+				}
+			}
+
+			h.EmitProgress(artifacts, fmt.Sprintf("%s chunk %d/%d", h.profile, done, len(chunks)))
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	if failed == len(chunks) {
+		return nil, 0, fmt.Errorf("all %d chunks failed: %w", len(chunks), firstErr)
+	}
+	if done < len(chunks) {
+		result.AddWarning("httpx", fmt.Sprintf("cancelled after %d/%d chunks", done, len(chunks)))
+	}
+	return result, flushed, nil
+}
+
+// flushPartial hands a chunk's artifacts to the partial handler, if any.
+// Returns true when the handler took them.
+func (h *HTTPXSource) flushPartial(partial *domain.ScanResult) bool {
+	h.partialMu.Lock()
+	fn := h.partial
+	h.partialMu.Unlock()
+
+	if fn == nil || len(partial.Artifacts) == 0 {
+		return false
+	}
+	if err := fn(partial); err != nil {
+		h.GetLogger().Warn("failed to flush httpx chunk, keeping it in memory", "error", err.Error())
+		return false
+	}
+	return true
+}
+
+// splitTargets splits targets into chunks of at most size (size <= 0 = one chunk).
+func splitTargets(targets []string, size int) [][]string {
+	if size <= 0 || len(targets) <= size {
+		return [][]string{targets}
+	}
+	chunks := make([][]string, 0, (len(targets)+size-1)/size)
+	for start := 0; start < len(targets); start += size {
+		end := min(start+size, len(targets))
+		chunks = append(chunks, targets[start:end])
+	}
+	return chunks
+}
@@ -0,0 +1,82 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+)
+
+// fakeHTTPX writes a script that answers every stdin target with a 200.
+func fakeHTTPX(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "httpx")
+	script := "#!/bin/sh\nwhile read -r t; do\n" +
+		"  printf '{\"url\":\"https://%s\",\"input\":\"%s\",\"scheme\":\"https\",\"port\":\"443\",\"status_code\":200}\\n' \"$t\" \"$t\"\n" +
+		"done\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake httpx: %v", err)
+	}
+	return path
+}
+
+func TestSplitTargets(t *testing.T) {
+	targets := []string{"a", "b", "c", "d", "e"}
+
+	if got := splitTargets(targets, 2); !reflect.DeepEqual(got, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}) {
+		t.Errorf("splitTargets(2) = %v", got)
+	}
+	if got := splitTargets(targets, 0); len(got) != 1 || len(got[0]) != 5 {
+		t.Errorf("size 0 should yield a single chunk, got %v", got)
+	}
+}
+
+func TestHTTPXSource_RunWithInput_Chunks(t *testing.T) {
+	var _ ports.PartialResultSource = (*HTTPXSource)(nil)
+
+	source := NewWithConfig(logx.New(), fakeHTTPX(t), ProfileBasic, 10*time.Second, 5, 10)
+	source.SetHeaderCapture(false)
+	source.SetChunking(2, 2)
+
+	var partials []*domain.ScanResult
+	source.SetPartialHandler(func(partial *domain.ScanResult) error {
+		partials = append(partials, partial)
+		if len(partials) == 1 {
+			return errors.New("disk full") // Stays in the final result
+		}
+		return nil
+	})
+
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+	input := domain.NewScanResult(*target)
+	for _, host := range []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com", "e.example.com"} {
+		input.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, host, "crtsh"))
+	}
+
+	result, err := source.RunWithInput(context.Background(), *target, input)
+	if err != nil {
+		t.Fatalf("RunWithInput failed: %v", err)
+	}
+	if len(partials) != 3 {
+		t.Fatalf("expected 3 chunks flushed, got %d", len(partials))
+	}
+
+	if len(result.Artifacts) != len(partials[0].Artifacts) {
+		t.Errorf("final result should hold only the rejected chunk: %d artifacts, first chunk %d", len(result.Artifacts), len(partials[0].Artifacts))
+	}
+	if probed := result.Metadata.Environment["httpx_probed"]; probed != "5" {
+		t.Errorf("expected 5 probed targets, got %s", probed)
+	}
+
+	// Every chunk was recorded in the ledger
+	if fresh, _ := source.ledger.pending([]string{"a.example.com", "e.example.com"}); len(fresh[0]) != 0 {
+		t.Errorf("chunks should be recorded as probed, pending %v", fresh[0])
+	}
+}
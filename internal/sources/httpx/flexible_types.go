@@ -114,3 +114,29 @@ func (fb FlexibleBool) Bool() bool {
 func (fb FlexibleBool) String() string {
 	return strconv.FormatBool(fb.value)
 }
+
+// FlexibleStrings handles JSON fields that can be either string, []string, or null,
+// keeping each value separate (e.g. repeated Set-Cookie headers).
+type FlexibleStrings []string
+
+// UnmarshalJSON implements custom unmarshaling to handle multiple types.
+func (fs *FlexibleStrings) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*fs = nil
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*fs = FlexibleStrings{s}
+		return nil
+	}
+
+	var arr []string
+	if err := json.Unmarshal(data, &arr); err == nil {
+		*fs = arr
+		return nil
+	}
+
+	return fmt.Errorf("FlexibleStrings: cannot unmarshal %s", string(data))
+}
@@ -0,0 +1,120 @@
+package httpx
+
+import (
+	"sort"
+	"strings"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+)
+
+// securityHeader is a response header whose absence is reported.
+type securityHeader struct {
+	key       string // httpx header key
+	name      string // Short name used in tags and metadata
+	httpsOnly bool   // Only expected on HTTPS responses
+}
+
+// securityHeaders are checked in this order.
+var securityHeaders = []securityHeader{
+	{key: "strict_transport_security", name: "hsts", httpsOnly: true},
+	{key: "content_security_policy", name: "csp"},
+	{key: "x_frame_options", name: "x-frame-options"},
+	{key: "x_content_type_options", name: "x-content-type-options"},
+}
+
+// header returns the values of a response header. httpx normalizes names
+// to lowercase with "_" separators; other spellings are accepted as well.
+func (r *HTTPXResponse) header(key string) []string {
+	if values, ok := r.Header[key]; ok {
+		return values
+	}
+	for k, values := range r.Header {
+		if strings.ReplaceAll(strings.ToLower(k), "-", "_") == key {
+			return values
+		}
+	}
+	return nil
+}
+
+// applyHeaderAnalysis copies the security-relevant headers into the
+// ServiceMetadata, records missing security headers and cookies without
+// Secure/HttpOnly, and tags the URL artifact accordingly. Responses without
+// captured headers (no -irh) are left untouched.
+func applyHeaderAnalysis(resp *HTTPXResponse, meta *metadata.ServiceMetadata, artifact *domain.Artifact) {
+	if resp.Header == nil {
+		return
+	}
+	https := strings.EqualFold(resp.Scheme, "https")
+
+	meta.HeaderHSTS = strings.Join(resp.header("strict_transport_security"), "; ")
+	meta.HeaderCSP = strings.Join(resp.header("content_security_policy"), "; ")
+	meta.HeaderXFrameOptions = strings.Join(resp.header("x_frame_options"), "; ")
+	meta.HeaderXContentTypeOptions = strings.Join(resp.header("x_content_type_options"), "; ")
+	if meta.Banner == "" {
+		meta.Banner = strings.Join(resp.header("server"), "; ")
+	}
+
+	for _, h := range securityHeaders {
+		if h.httpsOnly && !https {
+			continue
+		}
+		if len(resp.header(h.key)) > 0 {
+			continue
+		}
+		// CSP frame-ancestors supersedes X-Frame-Options
+		if h.key == "x_frame_options" && strings.Contains(strings.ToLower(meta.HeaderCSP), "frame-ancestors") {
+			continue
+		}
+		meta.MissingSecurityHeaders = append(meta.MissingSecurityHeaders, h.name)
+		artifact.AddTag("missing-" + h.name)
+	}
+
+	meta.InsecureCookies = insecureCookies(resp.header("set_cookie"), https)
+
+	if len(meta.MissingSecurityHeaders) > 0 {
+		artifact.AddTag("missing-security-headers")
+		meta.RiskLevel = "low"
+	}
+	if len(meta.InsecureCookies) > 0 {
+		artifact.AddTag("insecure-cookie")
+		meta.RiskLevel = "medium"
+	}
+}
+
+// insecureCookies returns the names of cookies set without HttpOnly or,
+// over HTTPS, without Secure.
+func insecureCookies(setCookies []string, https bool) []string {
+	seen := make(map[string]bool)
+	for _, c := range setCookies {
+		parts := strings.Split(c, ";")
+		name, _, _ := strings.Cut(strings.TrimSpace(parts[0]), "=")
+		if name == "" {
+			continue
+		}
+
+		secure, httpOnly := false, false
+		for _, attr := range parts[1:] {
+			attr, _, _ = strings.Cut(strings.TrimSpace(attr), "=")
+			switch strings.ToLower(attr) {
+			case "secure":
+				secure = true
+			case "httponly":
+				httpOnly = true
+			}
+		}
+		if !httpOnly || (https && !secure) {
+			seen[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return nil
+	}
+	return names
+}
@@ -0,0 +1,93 @@
+package httpx
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/platform/logx"
+)
+
+func TestParser_ParseResponse_SecurityHeaders(t *testing.T) {
+	line := `{
+		"url": "https://example.com",
+		"input": "example.com",
+		"scheme": "https",
+		"port": "443",
+		"status_code": 200,
+		"header": {
+			"server": "nginx",
+			"content_security_policy": "default-src 'self'; frame-ancestors 'none'",
+			"set_cookie": ["sid=abc; Path=/; HttpOnly", "theme=dark; Secure; HttpOnly", "track=1"]
+		}
+	}`
+	var resp HTTPXResponse
+	if err := json.Unmarshal([]byte(line), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	parser := NewParser(logx.New(), "httpx")
+	artifacts := parser.ParseResponse(&resp, *domain.NewTarget("example.com", domain.ScanModeActive))
+	if len(artifacts) == 0 {
+		t.Fatal("expected artifacts")
+	}
+	url := artifacts[0]
+	meta := url.TypedMetadata.(*metadata.ServiceMetadata)
+
+	// frame-ancestors in the CSP covers X-Frame-Options
+	if want := []string{"hsts", "x-content-type-options"}; !reflect.DeepEqual(meta.MissingSecurityHeaders, want) {
+		t.Errorf("missing headers = %v, expected %v", meta.MissingSecurityHeaders, want)
+	}
+	if want := []string{"sid", "track"}; !reflect.DeepEqual(meta.InsecureCookies, want) {
+		t.Errorf("insecure cookies = %v, expected %v", meta.InsecureCookies, want)
+	}
+	if meta.Banner != "nginx" || meta.RiskLevel != "medium" {
+		t.Errorf("unexpected banner %q / risk level %q", meta.Banner, meta.RiskLevel)
+	}
+	for _, tag := range []string{"missing-security-headers", "missing-hsts", "insecure-cookie"} {
+		if !containsString(url.Tags, tag) {
+			t.Errorf("expected tag %q, got %v", tag, url.Tags)
+		}
+	}
+}
+
+func TestParser_ParseResponse_NoHeadersCaptured(t *testing.T) {
+	resp := &HTTPXResponse{URL: "http://example.com", Input: "example.com", Scheme: "http", Port: "80", StatusCode: 200}
+
+	parser := NewParser(logx.New(), "httpx")
+	artifacts := parser.ParseResponse(resp, *domain.NewTarget("example.com", domain.ScanModeActive))
+	meta := artifacts[0].TypedMetadata.(*metadata.ServiceMetadata)
+
+	if len(meta.MissingSecurityHeaders) != 0 || containsString(artifacts[0].Tags, "missing-security-headers") {
+		t.Errorf("headers not captured must not be reported missing, got %v", meta.MissingSecurityHeaders)
+	}
+}
+
+func TestHTTPXSource_HeaderCaptureFlags(t *testing.T) {
+	source := NewWithConfig(logx.New(), "httpx", ProfileBasic, 0, 25, 100)
+	if !containsString(source.buildCommandArgsWithStdin(), "-irh") {
+		t.Error("expected -irh by default")
+	}
+
+	source.SetProfile(ProfileVerification)
+	if containsString(source.buildCommandArgsWithStdin(), "-irh") {
+		t.Error("verification profile must not capture headers")
+	}
+
+	source.SetProfile(ProfileBasic)
+	source.SetHeaderCapture(false)
+	if containsString(source.buildCommandArgsWithStdin(), "-irh") {
+		t.Error("expected no -irh when header capture is disabled")
+	}
+}
+
+func containsString(args []string, arg string) bool {
+	for _, a := range args {
+		if a == arg {
+			return true
+		}
+	}
+	return false
+}
@@ -7,12 +7,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"os/exec"
 	"strconv"
 	"sync"
 	"time"
 
 	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/budget"
 	"aethonx/internal/platform/logx"
 	"aethonx/internal/sources/common"
 )
@@ -23,6 +23,9 @@ const (
 	defaultThreads   = 75
 	defaultRateLimit = 150
 
+	// Default cap for stored response bodies (store_body)
+	defaultBodyMaxBytes = 4096
+
 	// Verification profile optimizations (for waybackurls mass validation)
 	verificationThreads   = 150
 	verificationRateLimit = 300
@@ -39,6 +42,23 @@ type HTTPXSource struct {
 	rateLimit   int
 	customFlags []string
 	parser      *Parser
+
+	// Endpoints already probed in the current scan
+	ledger *probeLedger
+
+	// Chunked execution (see SetChunking and SetPartialHandler)
+	chunkSize    int
+	chunkWorkers int
+	partialMu    sync.Mutex
+	partial      func(partial *domain.ScanResult) error
+
+	// Response capture (see SetHeaderCapture and SetBodyCapture)
+	captureHeaders bool
+	hashBody       bool
+	bodyMaxBytes   int
+
+	// Probe archived URLs with the verification profile (see SetVerifyURLs)
+	verifyURLs bool
 }
 
 // New creates a new HTTPXSource with default configuration.
@@ -50,11 +70,16 @@ func New(logger logx.Logger) *HTTPXSource {
 			Timeout:        defaultTimeout,
 			ProgressBuffer: 10,
 		}),
-		profile:     ProfileFull,
-		threads:     defaultThreads,
-		rateLimit:   defaultRateLimit,
-		customFlags: []string{},
-		parser:      NewParser(logger, sourceName),
+		profile:        ProfileFull,
+		threads:        defaultThreads,
+		rateLimit:      defaultRateLimit,
+		customFlags:    []string{},
+		parser:         NewParser(logger, sourceName),
+		captureHeaders: true,
+		verifyURLs:     true,
+		ledger:         newProbeLedger(),
+		chunkSize:      defaultChunkSize,
+		chunkWorkers:   defaultChunkWorkers,
 	}
 }
 
@@ -67,11 +92,16 @@ func NewWithConfig(logger logx.Logger, execPath string, profile ScanProfile, tim
 			Timeout:        timeout,
 			ProgressBuffer: 10,
 		}),
-		profile:     profile,
-		threads:     threads,
-		rateLimit:   rateLimit,
-		customFlags: []string{},
-		parser:      NewParser(logger, sourceName),
+		profile:        profile,
+		threads:        threads,
+		rateLimit:      rateLimit,
+		customFlags:    []string{},
+		parser:         NewParser(logger, sourceName),
+		captureHeaders: true,
+		verifyURLs:     true,
+		ledger:         newProbeLedger(),
+		chunkSize:      defaultChunkSize,
+		chunkWorkers:   defaultChunkWorkers,
 	}
 }
 
@@ -101,8 +131,28 @@ func (h *HTTPXSource) Run(ctx context.Context, target domain.Target) (*domain.Sc
 		"rate_limit", h.rateLimit,
 	)
 
+	// Skip the root if another invocation already probed it in this scan
+	rootTargets := []string{target.QueryName()}
+	if fresh, _ := h.ledger.pending(rootTargets); len(fresh[0]) == 0 {
+		h.GetLogger().Debug("target already probed in this scan, skipping", "target", target.Root)
+		return domain.NewScanResult(target), nil
+	}
+
+	// Global request budget (--budget)
+	if _, dropped := reserveProbes(rootTargets); dropped > 0 {
+		h.GetLogger().Warn("request budget exhausted, target not probed", "target", target.Root)
+		result := domain.NewScanResult(target)
+		result.AddWarning("httpx", "request budget exhausted: target not probed")
+		return result, nil
+	}
+
 	// Build command arguments
-	args := h.buildCommandArgs(target)
+	args := h.withPoliteness(h.buildCommandArgs(target), rootTargets)
+	args, release, err := h.withBrowser(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
 	// Create handler for processing output
 	handler := &httpxHandler{
@@ -140,11 +190,14 @@ func (h *HTTPXSource) Run(ctx context.Context, target domain.Target) (*domain.Sc
 		}
 	}
 
+	h.ledger.record(rootTargets)
+
 	// Parse responses into artifacts (after ExecuteCLI completes)
 	artifacts := h.parser.ParseMultipleResponses(handler.responses, target)
 	for _, artifact := range artifacts {
 		result.AddArtifact(artifact)
 	}
+	result.SetProvenanceQuery(h.Name(), h.CommandLine(args))
 
 	duration := time.Since(startTime)
 	h.GetLogger().Info("httpx scan completed",
@@ -157,6 +210,28 @@ func (h *HTTPXSource) Run(ctx context.Context, target domain.Target) (*domain.Sc
 	return result, nil
 }
 
+// Replay rebuilds the result from the stdout captured by a previous run (the
+// root probe and every chunk). Without the input artifacts, confidences are
+// not upgraded. Implements ports.ReplayableSource.
+func (h *HTTPXSource) Replay(ctx context.Context, target domain.Target, dir string) (*domain.ScanResult, error) {
+	handler := &httpxHandler{
+		parser:    h.parser,
+		target:    target,
+		logger:    h.GetLogger(),
+		responses: make([]*HTTPXResponse, 0, 100),
+		replay:    true,
+	}
+	if err := common.ReplayOutput(dir, handler); err != nil {
+		return nil, err
+	}
+
+	result := domain.NewScanResult(target)
+	for _, artifact := range h.parser.ParseMultipleResponses(handler.responses, target) {
+		result.AddArtifact(artifact)
+	}
+	return result, nil
+}
+
 // httpxHandler implements common.OutputHandler for httpx JSON output processing.
 type httpxHandler struct {
 	parser    *Parser
@@ -164,6 +239,9 @@ type httpxHandler struct {
 	logger    logx.Logger
 	responses []*HTTPXResponse
 
+	// replay parses a capture: nothing is downloaded, so --budget is untouched
+	replay bool
+
 	// State
 	mu sync.Mutex
 }
@@ -181,6 +259,12 @@ func (h *httpxHandler) ProcessLine(line []byte) error {
 
 	h.responses = append(h.responses, &resp)
 
+	// Bytes downloaded count against --budget; once spent, later reservations
+	// of requests are refused
+	if !h.replay {
+		_ = budget.Shared().Record(budget.Bytes, int64(resp.ContentLength))
+	}
+
 	h.logger.Debug("parsed httpx response",
 		"url", resp.URL,
 		"status_code", resp.StatusCode,
@@ -190,6 +274,14 @@ func (h *httpxHandler) ProcessLine(line []byte) error {
 	return nil
 }
 
+// Progress returns the number of responses parsed so far.
+// Implements common.ProgressCounter.
+func (h *httpxHandler) Progress() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.responses)
+}
+
 // Finalize is called after all lines are processed.
 func (h *httpxHandler) Finalize() error {
 	h.mu.Lock()
@@ -253,14 +345,14 @@ func (h *HTTPXSource) buildCommandArgs(target domain.Target) []string {
 	profileCfg := GetProfile(h.profile)
 
 	args := []string{
-		"-u", target.Root, // Target URL/domain
+		"-u", target.QueryName(), // Target URL/domain (punycode for IDN)
 		"-json",           // JSON output
 		"-silent",         // No progress output
 		"-no-color",       // No ANSI colors
 	}
 
 	// Add profile-specific flags
-	args = append(args, profileCfg.Flags...)
+	args = append(args, h.profileFlags(profileCfg)...)
 
 	// Add performance flags
 	args = append(args,
@@ -294,6 +386,65 @@ func (h *HTTPXSource) SetCustomFlags(flags []string) {
 	h.customFlags = flags
 }
 
+// SetBodyCapture configures response content capture. With hashBody the
+// body is hashed (mmh3 and sha256); maxBytes > 0 also stores the body,
+// truncated to maxBytes, in the URL artifact's ServiceMetadata.
+func (h *HTTPXSource) SetBodyCapture(hashBody bool, maxBytes int) {
+	h.hashBody = hashBody || maxBytes > 0
+	h.bodyMaxBytes = maxBytes
+	h.parser.SetBodyLimit(maxBytes)
+}
+
+// profileFlags returns the profile flags plus the header and body capture
+// flags. Hashing replaces the profile's own -hash algorithm list. The
+// verification profile stays lean and captures nothing extra.
+func (h *HTTPXSource) profileFlags(profileCfg ProfileConfig) []string {
+	if h.profile == ProfileVerification || (!h.hashBody && !h.captureHeaders) {
+		return profileCfg.Flags
+	}
+
+	flags := make([]string, 0, len(profileCfg.Flags)+7)
+	for i := 0; i < len(profileCfg.Flags); i++ {
+		if h.hashBody && profileCfg.Flags[i] == "-hash" {
+			i++ // Skip the algorithm list
+			continue
+		}
+		flags = append(flags, profileCfg.Flags[i])
+	}
+
+	if h.captureHeaders {
+		flags = append(flags, "-irh") // Include response headers in JSON
+	}
+	if h.hashBody {
+		flags = append(flags, "-hash", "mmh3,sha256")
+	}
+	if h.bodyMaxBytes > 0 {
+		flags = append(flags,
+			"-irr",                                // Include response (body) in JSON
+			"-rsts", strconv.Itoa(h.bodyMaxBytes), // Max response size to save
+		)
+	}
+	return flags
+}
+
+// SetHeaderCapture enables capturing response headers (-irh) for the
+// security-header analysis.
+func (h *HTTPXSource) SetHeaderCapture(enabled bool) {
+	h.captureHeaders = enabled
+}
+
+// SetVerifyURLs enables probing archived URLs (waybackurls) with the
+// verification profile. Disabled, only hosts and high-confidence URLs are probed.
+func (h *HTTPXSource) SetVerifyURLs(enabled bool) {
+	h.verifyURLs = enabled
+}
+
+// BeginScan forgets the endpoints probed in the previous scan.
+// Implements ports.ScanScopedSource.
+func (h *HTTPXSource) BeginScan(scanID string) {
+	h.ledger.reset(scanID)
+}
+
 // SetProfile changes the scan profile.
 func (h *HTTPXSource) SetProfile(profile ScanProfile) {
 	h.profile = profile
@@ -313,43 +464,70 @@ func (h *HTTPXSource) RunWithInput(ctx context.Context, target domain.Target, in
 		return h.Run(ctx, target)
 	}
 
+	// Archived URLs are only liveness-checked when verify_urls is on
+	if !h.verifyURLs && len(waybackurlsTargets) > 0 {
+		h.GetLogger().Debug("skipping archived URL verification", "targets", len(waybackurlsTargets))
+		waybackurlsTargets = nil
+	}
+
+	// Drop endpoints already probed in this scan. The full profile claims
+	// shared endpoints first so they get the comprehensive probe.
+	fresh, skipped := h.ledger.pending(otherTargets, waybackurlsTargets)
+	otherTargets, waybackurlsTargets = fresh[0], fresh[1]
+
+	// Global request budget (--budget): high-confidence targets go first
+	budgeted, overBudget := reserveProbes(otherTargets, waybackurlsTargets)
+	otherTargets, waybackurlsTargets = budgeted[0], budgeted[1]
+	if overBudget > 0 {
+		h.GetLogger().Warn("request budget exhausted, targets not probed", "skipped", overBudget)
+		result.AddWarning("httpx", fmt.Sprintf("request budget exhausted: %d targets not probed", overBudget))
+	}
+
 	h.GetLogger().Info("starting httpx scan with smart profile selection",
 		"target", target.Root,
 		"waybackurls_targets", len(waybackurlsTargets),
 		"other_targets", len(otherTargets),
+		"already_probed", skipped,
 	)
 
+	// Artifacts already delivered through the partial handler
+	flushed := 0
+
 	// Execute verification profile for waybackurls (fast)
 	if len(waybackurlsTargets) > 0 {
-		verificationResults, err := h.runWithProfile(ctx, target, waybackurlsTargets, ProfileVerification, input.Artifacts)
+		verificationResults, n, err := h.runWithProfile(ctx, target, waybackurlsTargets, ProfileVerification, input.Artifacts)
 		if err != nil {
 			h.GetLogger().Warn("verification profile failed", "error", err.Error())
 			result.AddWarning("httpx", fmt.Sprintf("verification failed: %v", err))
 		} else {
 			// Merge results
+			flushed += n
 			for _, artifact := range verificationResults.Artifacts {
 				result.AddArtifact(artifact)
 			}
+			result.Warnings = append(result.Warnings, verificationResults.Warnings...)
 		}
 	}
 
 	// Execute full profile for other sources (comprehensive)
 	if len(otherTargets) > 0 {
-		fullResults, err := h.runWithProfile(ctx, target, otherTargets, h.profile, input.Artifacts)
+		fullResults, n, err := h.runWithProfile(ctx, target, otherTargets, h.profile, input.Artifacts)
 		if err != nil {
 			h.GetLogger().Warn("full profile failed", "error", err.Error())
 			result.AddWarning("httpx", fmt.Sprintf("full profile failed: %v", err))
 		} else {
 			// Merge results
+			flushed += n
 			for _, artifact := range fullResults.Artifacts {
 				result.AddArtifact(artifact)
 			}
+			result.Warnings = append(result.Warnings, fullResults.Warnings...)
 		}
 	}
 
 	duration := time.Since(startTime)
 	totalProbed := len(waybackurlsTargets) + len(otherTargets)
-	totalAlive := len(result.Artifacts)
+	totalAlive := len(result.Artifacts) + flushed
 
 	h.GetLogger().Info("httpx scan completed with smart profiles",
 		"target", target.Root,
@@ -366,6 +544,8 @@ func (h *HTTPXSource) RunWithInput(ctx context.Context, target domain.Target, in
 	}
 	result.Metadata.Environment["httpx_probed"] = fmt.Sprintf("%d", totalProbed)
 	result.Metadata.Environment["httpx_alive"] = fmt.Sprintf("%d", totalAlive)
+	result.Metadata.Environment["httpx_skipped"] = fmt.Sprintf("%d", skipped)
+	result.Metadata.Environment["httpx_over_budget"] = fmt.Sprintf("%d", overBudget)
 
 	return result, nil
 }
@@ -426,11 +606,11 @@ func (h *HTTPXSource) separateTargetsBySource(input *domain.ScanResult) (wayback
 	return waybackurls, others
 }
 
-// runWithProfile executes httpx with a specific profile for the given targets.
-func (h *HTTPXSource) runWithProfile(ctx context.Context, target domain.Target, targets []string, profile ScanProfile, inputArtifacts []*domain.Artifact) (*domain.ScanResult, error) {
-	result := domain.NewScanResult(target)
-	startTime := time.Now()
-
+// runWithProfile executes httpx with a specific profile for the given targets,
+// split into chunks (see SetChunking). Each successful chunk is recorded in
+// the probe ledger and, with a partial handler, flushed immediately; the
+// returned flushed count covers the artifacts not kept in the result.
+func (h *HTTPXSource) runWithProfile(ctx context.Context, target domain.Target, targets []string, profile ScanProfile, inputArtifacts []*domain.Artifact) (result *domain.ScanResult, flushed int, err error) {
 	// Temporarily switch profile
 	originalProfile := h.profile
 	originalThreads := h.threads
@@ -458,6 +638,16 @@ func (h *HTTPXSource) runWithProfile(ctx context.Context, target domain.Target,
 		h.SetTimeout(originalTimeout)
 	}()
 
+	return h.runChunks(ctx, target, targets, inputArtifacts)
+}
+
+// probeChunk runs one httpx process over targets (read from stdin) with the
+// current profile settings.
+func (h *HTTPXSource) probeChunk(ctx context.Context, target domain.Target, targets []string, inputArtifacts []*domain.Artifact) (*domain.ScanResult, error) {
+	result := domain.NewScanResult(target)
+	startTime := time.Now()
+	profile := h.profile
+
 	h.GetLogger().Info("running httpx with profile",
 		"profile", profile,
 		"targets", len(targets),
@@ -466,7 +656,12 @@ func (h *HTTPXSource) runWithProfile(ctx context.Context, target domain.Target,
 	)
 
 	// Build command arguments for stdin mode
-	args := h.buildCommandArgsWithStdin()
+	args := h.withPoliteness(h.buildCommandArgsWithStdin(), targets)
+	args, release, err := h.withBrowser(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
 	// Create handler for processing output
 	handler := &httpxHandler{
@@ -476,8 +671,8 @@ func (h *HTTPXSource) runWithProfile(ctx context.Context, target domain.Target,
 		responses: make([]*HTTPXResponse, 0, len(targets)),
 	}
 
-	// Build command with context
-	cmd := exec.CommandContext(ctx, h.GetExecPath(), args...)
+	// Build command with context (host binary or container, stdin attached)
+	cmd := h.Command(ctx, args)
 
 	// Create stdout pipe for streaming JSON
 	stdout, err := cmd.StdoutPipe()
@@ -512,8 +707,10 @@ func (h *HTTPXSource) runWithProfile(ctx context.Context, target domain.Target,
 		}
 	}()
 
-	// Process stdout using handler
-	if err := h.ProcessOutput(stdout, handler); err != nil {
+	// Process stdout using handler, archiving it for "aethonx replay"
+	output, closeCapture := h.CaptureOutput(ctx, stdout)
+	defer closeCapture()
+	if err := h.ProcessOutput(output, handler); err != nil {
 		h.GetLogger().Warn("output processing error", "error", err.Error())
 	}
 
@@ -570,7 +767,7 @@ func (h *HTTPXSource) buildCommandArgsWithStdin() []string {
 	}
 
 	// Add profile-specific flags
-	args = append(args, profileCfg.Flags...)
+	args = append(args, h.profileFlags(profileCfg)...)
 
 	// Add performance flags
 	args = append(args,
@@ -1,13 +1,19 @@
 package httpx
 
 import (
+	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
 	"aethonx/internal/core/domain"
 	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/platform/browser"
+	"aethonx/internal/platform/budget"
 	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/politeness"
+	"aethonx/internal/testutil/sourcetest"
 )
 
 func TestHTTPXSource_Name(t *testing.T) {
@@ -164,6 +170,26 @@ func TestParser_ParseResponse_Success(t *testing.T) {
 	if techCount != 2 {
 		t.Errorf("expected 2 technology artifacts, got %d", techCount)
 	}
+
+	// Check port artifact (ip:port) and its relations
+	var portArtifact *domain.Artifact
+	for _, a := range artifacts {
+		if a.Type == domain.ArtifactTypePort {
+			portArtifact = a
+		}
+	}
+	if portArtifact == nil {
+		t.Fatal("expected a port artifact")
+	}
+	if portArtifact.Value != "93.184.216.34:443" {
+		t.Errorf("expected port '93.184.216.34:443', got '%s'", portArtifact.Value)
+	}
+	if !ipArtifact.HasRelation(portArtifact.ID, domain.RelationListensOn) {
+		t.Error("expected IP -listens_on-> port relation")
+	}
+	if !portArtifact.HasRelation(urlArtifact.ID, domain.RelationServes) {
+		t.Error("expected port -serves-> URL relation")
+	}
 }
 
 func TestParser_ParseResponse_Failed(t *testing.T) {
@@ -835,3 +861,161 @@ func TestParser_ExtractHostname(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPXSource_BodyCaptureFlags(t *testing.T) {
+	source := NewWithConfig(logx.New(), "httpx", ProfileTech, 60*time.Second, 25, 100)
+	source.SetBodyCapture(false, 1024)
+
+	args := strings.Join(source.buildCommandArgsWithStdin(), " ")
+
+	if strings.Count(args, "-hash ") != 1 || !strings.Contains(args, "-hash mmh3,sha256") {
+		t.Errorf("expected a single '-hash mmh3,sha256', got %q", args)
+	}
+	if !strings.Contains(args, "-irr") || !strings.Contains(args, "-rsts 1024") {
+		t.Errorf("expected body storage flags, got %q", args)
+	}
+
+	source.SetBodyCapture(false, 0)
+	args = strings.Join(source.buildCommandArgsWithStdin(), " ")
+	if !strings.Contains(args, "-hash sha256") || strings.Contains(args, "-irr") {
+		t.Errorf("expected profile flags without body capture, got %q", args)
+	}
+}
+
+func TestParser_ParseResponse_BodyContent(t *testing.T) {
+	parser := NewParser(logx.New(), "httpx")
+	parser.SetBodyLimit(8)
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+
+	resp := &HTTPXResponse{
+		URL:           "https://example.com",
+		Input:         "example.com",
+		Scheme:        "https",
+		Port:          "443",
+		StatusCode:    404,
+		ContentLength: 11,
+		Words:         2,
+		Lines:         1,
+		Body:          "Not found ñ",
+		Hash:          &HashData{BodyMMH3: "-1234", BodySHA256: "abcd"},
+	}
+
+	artifacts := parser.ParseResponse(resp, *target)
+	if len(artifacts) == 0 {
+		t.Fatal("expected artifacts")
+	}
+	meta, ok := artifacts[0].TypedMetadata.(*metadata.ServiceMetadata)
+	if !ok {
+		t.Fatalf("expected ServiceMetadata, got %T", artifacts[0].TypedMetadata)
+	}
+
+	if meta.BodyMMH3 != "-1234" || meta.BodySHA256 != "abcd" {
+		t.Errorf("unexpected hashes: mmh3=%q sha256=%q", meta.BodyMMH3, meta.BodySHA256)
+	}
+	if meta.WordCount != 2 || meta.LineCount != 1 || meta.ContentLength != 11 {
+		t.Errorf("unexpected counts: words=%d lines=%d length=%d", meta.WordCount, meta.LineCount, meta.ContentLength)
+	}
+	if meta.Body != "Not foun" {
+		t.Errorf("expected body truncated to 8 bytes, got %q", meta.Body)
+	}
+
+	m := meta.ToMap()
+	if m["body_mmh3"] != "-1234" || m["word_count"] != "2" {
+		t.Errorf("unexpected map: %v", m)
+	}
+}
+
+func TestTruncateBody(t *testing.T) {
+	tests := []struct {
+		body     string
+		max      int
+		expected string
+	}{
+		{"short", 10, "short"},
+		{"abcdef", 3, "abc"},
+		{"añb", 2, "a"}, // Does not split the 2-byte rune
+	}
+
+	for _, tt := range tests {
+		if got := truncateBody(tt.body, tt.max); got != tt.expected {
+			t.Errorf("truncateBody(%q, %d) = %q, expected %q", tt.body, tt.max, got, tt.expected)
+		}
+	}
+}
+
+func TestHTTPXSource_WithPoliteness(t *testing.T) {
+	policy, _ := politeness.ParsePolicy([]string{"legacy.example.com=2"}, []string{"300ms"})
+	politeness.Shared().Configure(policy)
+	defer politeness.Shared().Configure(politeness.Policy{})
+
+	h := New(logx.NewSilent())
+	base := []string{"-json", "-t", "50", "-rl", "150"}
+
+	args := h.withPoliteness(append([]string{}, base...), []string{"https://www.example.com", "legacy.example.com:8080"})
+	if got := strings.Join(args, " "); got != "-json -t 2 -rl 150 -delay 300ms" {
+		t.Errorf("unexpected args: %s", got)
+	}
+
+	args = h.withPoliteness(append([]string{}, base...), []string{"https://www.example.com"})
+	if got := strings.Join(args, " "); got != "-json -t 50 -rl 150 -delay 300ms" {
+		t.Errorf("concurrency of another scope entry should not apply: %s", got)
+	}
+}
+
+func TestReserveProbes(t *testing.T) {
+	budget.Shared().Configure(map[budget.Kind]int64{budget.Requests: 3})
+	budget.Shared().Reset()
+	defer func() {
+		budget.Shared().Configure(nil)
+		budget.Shared().Reset()
+	}()
+
+	kept, dropped := reserveProbes([]string{"a", "b"}, []string{"c", "d"})
+	if dropped != 1 || len(kept[0]) != 2 || len(kept[1]) != 1 {
+		t.Errorf("earlier groups should be kept first: %v (dropped %d)", kept, dropped)
+	}
+}
+
+// TestParser_Golden runs the parser over recorded httpx JSON lines
+func TestParser_Golden(t *testing.T) {
+	parser := NewParser(logx.NewSilent(), "httpx")
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+
+	var responses []*HTTPXResponse
+	for _, line := range sourcetest.Lines(t, "testdata/responses.jsonl") {
+		var resp HTTPXResponse
+		if err := json.Unmarshal(line, &resp); err != nil {
+			t.Fatalf("invalid fixture line: %v", err)
+		}
+		responses = append(responses, &resp)
+	}
+
+	sourcetest.Golden(t, "testdata/responses.golden", parser.ParseMultipleResponses(responses, *target))
+}
+
+func TestHTTPXSource_WithBrowser(t *testing.T) {
+	browser.Shared().Configure(browser.Config{MaxTabs: 3})
+	defer browser.Shared().Configure(browser.Config{})
+
+	h := New(logx.NewSilent())
+	base := []string{"-json", "-t", "50", "-ss"}
+
+	args, release, err := h.withBrowser(context.Background(), append([]string{}, base...))
+	if err != nil {
+		t.Fatalf("withBrowser failed: %v", err)
+	}
+	release()
+	if got := strings.Join(args, " "); got != strings.Join(base, " ") {
+		t.Errorf("non-headless profile should not change args: %s", got)
+	}
+
+	h.profile = ProfileHeadless
+	args, release, err = h.withBrowser(context.Background(), append([]string{}, base...))
+	if err != nil {
+		t.Fatalf("withBrowser failed: %v", err)
+	}
+	defer release()
+	if got := strings.Join(args, " "); got != "-json -t 3 -ss" {
+		t.Errorf("threads should be capped to the browser tabs: %s", got)
+	}
+}
@@ -0,0 +1,98 @@
+package httpx
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// probeLedger records the endpoints already probed during the current scan
+// so httpx never probes the same endpoint twice in one run, whether it
+// appears in several profiles, in several invocations or with several
+// spellings (bare host vs. root URL, explicit default port, scheme).
+type probeLedger struct {
+	mu     sync.Mutex
+	scanID string
+	probed map[string]bool
+}
+
+func newProbeLedger() *probeLedger {
+	return &probeLedger{probed: make(map[string]bool)}
+}
+
+// reset starts a new scan, forgetting every recorded endpoint.
+func (l *probeLedger) reset(scanID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scanID = scanID
+	l.probed = make(map[string]bool)
+}
+
+// pending returns, for each group of targets, those whose endpoint has not
+// been probed yet. Duplicates are dropped across groups too, so an endpoint
+// in several groups stays only in the first one. Nothing is recorded until
+// record is called, so a failed (and retried) run probes its targets again.
+func (l *probeLedger) pending(groups ...[]string) (fresh [][]string, skipped int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	seen := make(map[string]bool)
+	fresh = make([][]string, len(groups))
+	for i, targets := range groups {
+		fresh[i] = make([]string, 0, len(targets))
+		for _, t := range targets {
+			key := endpointKey(t)
+			if l.probed[key] || seen[key] {
+				skipped++
+				continue
+			}
+			seen[key] = true
+			fresh[i] = append(fresh[i], t)
+		}
+	}
+	return fresh, skipped
+}
+
+// record marks the targets' endpoints as probed.
+func (l *probeLedger) record(targets []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, t := range targets {
+		l.probed[endpointKey(t)] = true
+	}
+}
+
+// endpointKey identifies what httpx probes for a target. A bare host and
+// its root URL on a default port are the same probe (httpx tries both
+// schemes); other URLs are keyed by host, non-default port, path and query.
+func endpointKey(target string) string {
+	target = strings.TrimSpace(target)
+	if !strings.Contains(target, "://") {
+		return strings.ToLower(strings.TrimSuffix(target, "/"))
+	}
+
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" {
+		return strings.ToLower(target)
+	}
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && !isDefaultPort(scheme, port) {
+		host = net.JoinHostPort(host, port)
+	}
+
+	path := u.EscapedPath()
+	if (path == "" || path == "/") && u.RawQuery == "" {
+		return host
+	}
+	key := scheme + "://" + host + path
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+	return key
+}
+
+func isDefaultPort(scheme, port string) bool {
+	return (scheme == "https" && port == "443") || (scheme == "http" && port == "80")
+}
@@ -0,0 +1,80 @@
+package httpx
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+)
+
+func TestEndpointKey(t *testing.T) {
+	tests := []struct {
+		target   string
+		expected string
+	}{
+		{"API.example.com", "api.example.com"},
+		{"https://api.example.com", "api.example.com"},
+		{"http://api.example.com:80/", "api.example.com"},
+		{"https://api.example.com:8443/", "api.example.com:8443"},
+		{"https://api.example.com/login?next=/", "https://api.example.com/login?next=/"},
+		{"HTTPS://API.example.com:443/login", "https://api.example.com/login"},
+	}
+
+	for _, tt := range tests {
+		if got := endpointKey(tt.target); got != tt.expected {
+			t.Errorf("endpointKey(%q) = %q, expected %q", tt.target, got, tt.expected)
+		}
+	}
+}
+
+func TestProbeLedger_PendingAndRecord(t *testing.T) {
+	ledger := newProbeLedger()
+
+	fresh, skipped := ledger.pending(
+		[]string{"api.example.com", "www.example.com", "api.example.com"},
+		[]string{"https://api.example.com/", "https://www.example.com/a"},
+	)
+	expected := [][]string{{"api.example.com", "www.example.com"}, {"https://www.example.com/a"}}
+	if !reflect.DeepEqual(fresh, expected) || skipped != 2 {
+		t.Errorf("pending = %v (skipped %d), expected %v (skipped 2)", fresh, skipped, expected)
+	}
+
+	// Nothing is recorded until the probe succeeds
+	if fresh, _ := ledger.pending([]string{"api.example.com"}); len(fresh[0]) != 1 {
+		t.Error("pending must not record endpoints")
+	}
+
+	ledger.record([]string{"api.example.com"})
+	if fresh, skipped := ledger.pending([]string{"http://api.example.com"}); len(fresh[0]) != 0 || skipped != 1 {
+		t.Errorf("recorded endpoint should be skipped, got %v", fresh)
+	}
+
+	ledger.reset("scan-2")
+	if fresh, _ := ledger.pending([]string{"api.example.com"}); len(fresh[0]) != 1 {
+		t.Error("reset should forget recorded endpoints")
+	}
+}
+
+func TestHTTPXSource_Run_SkipsProbedRoot(t *testing.T) {
+	var _ ports.ScanScopedSource = (*HTTPXSource)(nil)
+
+	source := NewWithConfig(logx.New(), "/nonexistent/httpx", ProfileBasic, 0, 25, 100)
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+	source.ledger.record([]string{"example.com"})
+
+	result, err := source.Run(context.Background(), *target)
+	if err != nil {
+		t.Fatalf("probed root should be skipped without running httpx, got %v", err)
+	}
+	if len(result.Artifacts) != 0 {
+		t.Errorf("expected empty result, got %d artifacts", len(result.Artifacts))
+	}
+
+	source.BeginScan("next-scan")
+	if _, err := source.Run(context.Background(), *target); err == nil {
+		t.Error("after BeginScan the root should be probed again")
+	}
+}
@@ -26,6 +26,10 @@ type HTTPXResponse struct {
 	StatusCode   int        `json:"status_code"`
 	ContentLength int       `json:"content_length,omitempty"`
 	Failed       bool       `json:"failed"`
+	Body         string     `json:"body,omitempty"` // With -irr (capped by -rsts)
+
+	// Response headers (with -irh), keyed by lowercase name with "_" separators
+	Header map[string]FlexibleStrings `json:"header,omitempty"`
 	TechDetect   []string   `json:"tech,omitempty"`
 
 	// TLS/Certificate fields
@@ -58,6 +62,7 @@ type HTTPXResponse struct {
 // HashData contains hash information for body and headers.
 type HashData struct {
 	BodyMD5       string `json:"body_md5,omitempty"`
+	BodyMMH3      string `json:"body_mmh3,omitempty"`
 	BodySHA256    string `json:"body_sha256,omitempty"`
 	BodySHA512    string `json:"body_sha512,omitempty"`
 	HeaderMD5     string `json:"header_md5,omitempty"`
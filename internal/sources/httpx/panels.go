@@ -0,0 +1,87 @@
+package httpx
+
+import (
+	"strings"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+)
+
+// panelSignature identifies an administration panel from the probe results.
+// Detection is passive: only the title and tech fingerprints httpx already
+// collected are inspected, no credentials are ever tried.
+type panelSignature struct {
+	product  string   // Product name stored in metadata
+	slug     string   // Short name used in the panel-<slug> tag
+	titles   []string // Lowercase substrings of the page title
+	techs    []string // Lowercase httpx tech names
+	severity string   // Risk level of an exposed instance
+}
+
+// panelSignatures are checked in this order; the first match wins.
+var panelSignatures = []panelSignature{
+	{product: "Jenkins", slug: "jenkins", titles: []string{"[jenkins]"}, techs: []string{"jenkins"}, severity: "high"},
+	{product: "phpMyAdmin", slug: "phpmyadmin", titles: []string{"phpmyadmin"}, techs: []string{"phpmyadmin"}, severity: "high"},
+	{product: "Kibana", slug: "kibana", titles: []string{"kibana"}, techs: []string{"kibana"}, severity: "high"},
+	{product: "Grafana", slug: "grafana", titles: []string{"grafana"}, techs: []string{"grafana"}, severity: "medium"},
+	{product: "Adminer", slug: "adminer", titles: []string{"adminer"}, techs: []string{"adminer"}, severity: "high"},
+	{product: "Portainer", slug: "portainer", titles: []string{"portainer"}, techs: []string{"portainer"}, severity: "high"},
+	{product: "RabbitMQ Management", slug: "rabbitmq", titles: []string{"rabbitmq management"}, techs: []string{"rabbitmq"}, severity: "medium"},
+	{product: "Tomcat Manager", slug: "tomcat-manager", titles: []string{"tomcat web application manager"}, severity: "high"},
+	{product: "SonarQube", slug: "sonarqube", titles: []string{"sonarqube"}, techs: []string{"sonarqube"}, severity: "medium"},
+	{product: "Webmin", slug: "webmin", titles: []string{"webmin"}, techs: []string{"webmin"}, severity: "high"},
+}
+
+// riskRank orders ServiceMetadata risk levels.
+var riskRank = map[string]int{"critical": 4, "high": 3, "medium": 2, "low": 1}
+
+// applyPanelDetection tags URLs serving a known administration panel with
+// "exposed-panel" and "panel-<product>", records the product in the
+// ServiceMetadata and raises its risk level. Only reachable responses
+// (2xx/3xx, or 401/403 login walls) are considered.
+func applyPanelDetection(resp *HTTPXResponse, meta *metadata.ServiceMetadata, artifact *domain.Artifact) {
+	if resp.StatusCode >= 400 && resp.StatusCode != 401 && resp.StatusCode != 403 {
+		return
+	}
+
+	sig, version, ok := matchPanel(resp.Title, resp.TechDetect)
+	if !ok {
+		return
+	}
+
+	meta.ExposedPanel = sig.product
+	meta.PanelVersion = version
+	if riskRank[sig.severity] > riskRank[meta.RiskLevel] {
+		meta.RiskLevel = sig.severity
+	}
+	artifact.AddTag("exposed-panel")
+	artifact.AddTag("panel-" + sig.slug)
+}
+
+// matchPanel returns the first signature matching the tech fingerprints or
+// the page title. Tech matches are preferred since they may carry a version.
+func matchPanel(title string, techs []string) (panelSignature, string, bool) {
+	for _, sig := range panelSignatures {
+		for _, tech := range techs {
+			name, version := parseTechNameAndVersion(tech)
+			for _, want := range sig.techs {
+				if strings.EqualFold(name, want) {
+					return sig, version, true
+				}
+			}
+		}
+	}
+
+	title = strings.ToLower(title)
+	if title == "" {
+		return panelSignature{}, "", false
+	}
+	for _, sig := range panelSignatures {
+		for _, want := range sig.titles {
+			if strings.Contains(title, want) {
+				return sig, "", true
+			}
+		}
+	}
+	return panelSignature{}, "", false
+}
@@ -0,0 +1,88 @@
+package httpx
+
+import (
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/platform/logx"
+)
+
+func TestParser_ParseResponse_ExposedPanel(t *testing.T) {
+	tests := []struct {
+		name     string
+		resp     HTTPXResponse
+		product  string
+		version  string
+		tag      string
+		risk     string
+		detected bool
+	}{
+		{
+			name:     "jenkins by title",
+			resp:     HTTPXResponse{Title: "Dashboard [Jenkins]", StatusCode: 200},
+			product:  "Jenkins",
+			tag:      "panel-jenkins",
+			risk:     "high",
+			detected: true,
+		},
+		{
+			name:     "grafana by tech with version",
+			resp:     HTTPXResponse{Title: "Home", TechDetect: []string{"Grafana:10.2.0"}, StatusCode: 302},
+			product:  "Grafana",
+			version:  "10.2.0",
+			tag:      "panel-grafana",
+			risk:     "medium",
+			detected: true,
+		},
+		{
+			name:     "phpmyadmin behind login wall",
+			resp:     HTTPXResponse{Title: "phpMyAdmin", StatusCode: 401},
+			product:  "phpMyAdmin",
+			tag:      "panel-phpmyadmin",
+			risk:     "high",
+			detected: true,
+		},
+		{
+			name: "not found page mentioning kibana",
+			resp: HTTPXResponse{Title: "Kibana - 404", StatusCode: 404},
+		},
+		{
+			name: "regular site",
+			resp: HTTPXResponse{Title: "Welcome", TechDetect: []string{"Nginx"}, StatusCode: 200},
+		},
+	}
+
+	parser := NewParser(logx.New(), "httpx")
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := tt.resp
+			resp.URL = "https://admin.example.com"
+			resp.Scheme = "https"
+			resp.Port = "443"
+
+			url := parser.ParseResponse(&resp, *target)[0]
+			meta := url.TypedMetadata.(*metadata.ServiceMetadata)
+
+			if got := containsString(url.Tags, "exposed-panel"); got != tt.detected {
+				t.Fatalf("exposed-panel tag = %v, expected %v (tags %v)", got, tt.detected, url.Tags)
+			}
+			if !tt.detected {
+				if meta.ExposedPanel != "" {
+					t.Errorf("unexpected panel %q", meta.ExposedPanel)
+				}
+				return
+			}
+			if meta.ExposedPanel != tt.product || meta.PanelVersion != tt.version {
+				t.Errorf("panel = %q %q, expected %q %q", meta.ExposedPanel, meta.PanelVersion, tt.product, tt.version)
+			}
+			if !containsString(url.Tags, tt.tag) {
+				t.Errorf("missing tag %q in %v", tt.tag, url.Tags)
+			}
+			if meta.RiskLevel != tt.risk {
+				t.Errorf("risk level = %q, expected %q", meta.RiskLevel, tt.risk)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"aethonx/internal/core/domain"
 	"aethonx/internal/core/domain/metadata"
@@ -16,6 +17,8 @@ import (
 type Parser struct {
 	logger     logx.Logger
 	sourceName string
+
+	bodyLimit int // Max stored body bytes (0 = body not stored)
 }
 
 // NewParser creates a new Parser instance.
@@ -26,6 +29,12 @@ func NewParser(logger logx.Logger, sourceName string) *Parser {
 	}
 }
 
+// SetBodyLimit sets how many bytes of the response body are stored in
+// ServiceMetadata (0 disables body storage).
+func (p *Parser) SetBodyLimit(maxBytes int) {
+	p.bodyLimit = maxBytes
+}
+
 // extractHostname extracts the hostname from HTTPXResponse.
 // Priority: Input > URL parsing > empty string
 // Note: resp.Host contains the resolved IP, not the hostname.
@@ -81,6 +90,14 @@ func (p *Parser) ParseResponse(resp *HTTPXResponse, target domain.Target) []*dom
 	if resp.Host != "" {
 		ipArtifact := p.createIPArtifact(resp, hostname)
 		artifacts = append(artifacts, ipArtifact)
+
+		// Port artifact: IP -listens_on-> ip:port -serves-> URL
+		if port := parsePort(resp.Port); port > 0 {
+			portArtifact := domain.NewPortArtifact(resp.Host, port, strings.ToLower(resp.Scheme), p.sourceName)
+			portArtifact.AddRelation(urlArtifact.ID, domain.RelationServes, 1.0, p.sourceName)
+			ipArtifact.AddRelation(portArtifact.ID, domain.RelationListensOn, 1.0, p.sourceName)
+			artifacts = append(artifacts, portArtifact)
+		}
 	}
 
 	// 4. Technology artifacts (from tech detection)
@@ -150,6 +167,25 @@ func (p *Parser) createURLArtifact(resp *HTTPXResponse, hostname string) *domain
 		Confidence:      1.0,
 		ScanTool:        "httpx",
 		ParentIP:        resp.Host, // resp.Host contains the resolved IP
+		StatusCode:      resp.StatusCode,
+		ContentLength:   resp.ContentLength,
+		WordCount:       resp.Words,
+		LineCount:       resp.Lines,
+		Title:           resp.Title,
+		FaviconMMH3:     resp.FaviconMMH3,
+	}
+	// Older httpx releases report the favicon hash as "favicon"
+	if serviceMeta.FaviconMMH3 == "" {
+		serviceMeta.FaviconMMH3 = resp.Favicon
+	}
+
+	// Content hashes (with -hash) for change detection and page clustering
+	if resp.Hash != nil {
+		serviceMeta.BodyMMH3 = resp.Hash.BodyMMH3
+		serviceMeta.BodySHA256 = resp.Hash.BodySHA256
+	}
+	if p.bodyLimit > 0 && resp.Body != "" {
+		serviceMeta.Body = truncateBody(resp.Body, p.bodyLimit)
 	}
 
 	// Add SSL info if HTTPS
@@ -158,6 +194,12 @@ func (p *Parser) createURLArtifact(resp *HTTPXResponse, hostname string) *domain
 		serviceMeta.SSLCert = resp.TLS.SubjectCN
 	}
 
+	// Security headers and cookie flags (with -irh)
+	applyHeaderAnalysis(resp, serviceMeta, artifact)
+
+	// Known administration panels (identification only)
+	applyPanelDetection(resp, serviceMeta, artifact)
+
 	artifact.TypedMetadata = serviceMeta
 	artifact.Confidence = 1.0
 
@@ -268,9 +310,11 @@ func (p *Parser) addStatusTags(artifact *domain.Artifact, statusCode int) {
 
 // createIPArtifact creates an IP artifact with network metadata.
 func (p *Parser) createIPArtifact(resp *HTTPXResponse, hostname string) *domain.Artifact {
-	artifact := domain.NewArtifact(domain.ArtifactTypeIP, resp.Host, p.sourceName) // resp.Host contains the resolved IP
+	// resp.Host contains the resolved IP (v4 or v6)
+	artifact := domain.NewArtifact(domain.IPArtifactType(resp.Host), resp.Host, p.sourceName)
 
 	ipMeta := metadata.NewIPMetadata()
+	ipMeta.IPVersion = domain.IPVersion(resp.Host)
 
 	// Add ASN data if available
 	if resp.ASN != nil {
@@ -380,6 +424,18 @@ func (p *Parser) createSubdomainArtifact(subdomain, sourceURL string) *domain.Ar
 	return artifact
 }
 
+// truncateBody caps body at maxBytes without splitting a UTF-8 sequence.
+func truncateBody(body string, maxBytes int) string {
+	if len(body) <= maxBytes {
+		return body
+	}
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return body[:cut]
+}
+
 // parsePort extracts port number from port string.
 func parsePort(portStr string) int {
 	if portStr == "" {
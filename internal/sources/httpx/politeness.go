@@ -0,0 +1,39 @@
+package httpx
+
+import (
+	"strconv"
+
+	"aethonx/internal/platform/politeness"
+)
+
+// withPoliteness applies the per-host limits (--host-concurrency,
+// --host-delay) of targets to httpx args. httpx has no per-host limits, so
+// the strictest ones cap the whole process: -t is lowered to the
+// concurrency and -delay spaces the requests of each thread (use a
+// concurrency of 1 for a strict per-host delay).
+func (h *HTTPXSource) withPoliteness(args []string, targets []string) []string {
+	limits := politeness.Shared().Strictest(politeness.Hosts(targets))
+	if limits.IsZero() {
+		return args
+	}
+
+	if limits.Concurrency > 0 {
+		for i := 0; i+1 < len(args); i++ {
+			if args[i] == "-t" {
+				if threads, err := strconv.Atoi(args[i+1]); err != nil || threads > limits.Concurrency {
+					args[i+1] = strconv.Itoa(limits.Concurrency)
+				}
+				break
+			}
+		}
+	}
+	if limits.Delay > 0 {
+		args = append(args, "-delay", limits.Delay.String())
+	}
+
+	h.GetLogger().Debug("applied per-host politeness",
+		"concurrency", limits.Concurrency,
+		"delay", limits.Delay.String(),
+	)
+	return args
+}
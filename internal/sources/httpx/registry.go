@@ -7,8 +7,47 @@ import (
 	"aethonx/internal/core/ports"
 	"aethonx/internal/platform/logx"
 	"aethonx/internal/platform/registry"
+	"aethonx/internal/sources/common"
 )
 
+// dockerImage is the pinned image used with runtime: docker.
+const dockerImage = "projectdiscovery/httpx:v1.6.9"
+
+// configSchema declares the httpx Custom options.
+var configSchema = append([]ports.ConfigField{
+	{Name: "exec_path", Type: ports.ConfigTypeString, Default: "httpx", Description: "Path to the httpx binary"},
+	{Name: "profile", Type: ports.ConfigTypeString, Default: string(ProfileFull), Description: "Probe profile: basic, tech, tls, full, headless"},
+	{Name: "threads", Type: ports.ConfigTypeInt, Default: defaultThreads, Description: "Concurrent probes (1-1000)"},
+	{Name: "rate_limit", Type: ports.ConfigTypeInt, Default: defaultRateLimit, Description: "Max requests per second (0 = unlimited)"},
+	{Name: "custom_flags", Type: ports.ConfigTypeStringList, Description: "Extra flags passed to httpx"},
+	{Name: "chunk_size", Type: ports.ConfigTypeInt, Default: defaultChunkSize, Description: "Targets per httpx process (0 = single process)"},
+	{Name: "chunk_workers", Type: ports.ConfigTypeInt, Default: defaultChunkWorkers, Description: "httpx processes running in parallel (1-16)"},
+	{Name: "capture_headers", Type: ports.ConfigTypeBool, Default: true, Description: "Capture response headers and flag missing security headers"},
+	{Name: "hash_body", Type: ports.ConfigTypeBool, Default: false, Description: "Store mmh3/sha256 hashes of response bodies"},
+	{Name: "store_body", Type: ports.ConfigTypeBool, Default: false, Description: "Store response bodies (implies hash_body)"},
+	{Name: "body_max_bytes", Type: ports.ConfigTypeInt, Default: defaultBodyMaxBytes, Description: "Max stored bytes per response body"},
+	{Name: "verify_urls", Type: ports.ConfigTypeBool, Default: true, Description: "Liveness-check archived URLs (waybackurls) with the verification profile"},
+}, common.RuntimeFields(dockerImage)...)
+
+// dependency declares the httpx binary for "aethonx deps".
+var dependency = &ports.ToolDependency{
+	Binary: "httpx",
+	Repo:   "projectdiscovery/httpx",
+	AssetPatterns: map[string]string{
+		"linux_amd64":   "httpx_*_linux_amd64.zip",
+		"linux_arm64":   "httpx_*_linux_arm64.zip",
+		"darwin_amd64":  "httpx_*_macOS_amd64.zip",
+		"darwin_arm64":  "httpx_*_macOS_arm64.zip",
+		"windows_amd64": "httpx_*_windows_amd64.zip",
+	},
+	ChecksumAsset: "httpx_*checksums.txt",
+	VersionMarker: "Current Version",
+	MinVersion:    "1.6.0",
+	FeatureVersions: []ports.FeatureVersion{
+		{Option: "profile", Value: string(ProfileHeadless), MinVersion: "1.6.1", Reason: "headless profile uses -screenshot-idle"},
+	},
+}
+
 // Auto-register httpx source on package import.
 func init() {
 	err := registry.Global().Register("httpx", factory, ports.SourceMetadata{
@@ -26,11 +65,15 @@ func init() {
 		},
 		OutputArtifacts: []domain.ArtifactType{
 			domain.ArtifactTypeURL,         // Probed URLs
-			domain.ArtifactTypeIP,          // Resolved IPs
+			domain.ArtifactTypeIP,          // Resolved IPv4
+			domain.ArtifactTypeIPv6,        // Resolved IPv6
+			domain.ArtifactTypePort,        // Probed ports (ip:port)
 			domain.ArtifactTypeTechnology,  // Detected technologies
 			domain.ArtifactTypeCertificate, // SSL certificates
 			domain.ArtifactTypeSubdomain,   // Subdomains from SANs
 		},
+		ConfigSchema: configSchema,
+		Dependency:   dependency,
 	})
 
 	if err != nil {
@@ -39,13 +82,16 @@ func init() {
 	}
 }
 
-// factory creates a new HTTPXSource from SourceConfig using registry helpers.
+// factory creates a new HTTPXSource from SourceConfig, decoding Custom against configSchema.
 func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
-	// Extract custom configuration using registry helpers
-	execPath := registry.GetStringConfig(cfg.Custom, "exec_path", "httpx")
-	profileStr := registry.GetStringConfig(cfg.Custom, "profile", string(ProfileFull))
-	threads := registry.GetIntConfig(cfg.Custom, "threads", defaultThreads)
-	rateLimit := registry.GetIntConfig(cfg.Custom, "rate_limit", defaultRateLimit)
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("httpx config: %w", err)
+	}
+	execPath := opts.String("exec_path")
+	profileStr := opts.String("profile")
+	threads := opts.Int("threads")
+	rateLimit := opts.Int("rate_limit")
 
 	// Parse profile
 	profile := ScanProfile(profileStr)
@@ -68,18 +114,52 @@ func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
 		return nil, fmt.Errorf("httpx rate_limit cannot be negative, got %d", rateLimit)
 	}
 
+	chunkSize := opts.Int("chunk_size")
+	if chunkSize < 0 {
+		return nil, fmt.Errorf("httpx chunk_size cannot be negative, got %d", chunkSize)
+	}
+	chunkWorkers := opts.Int("chunk_workers")
+	if chunkWorkers <= 0 || chunkWorkers > maxChunkWorkers {
+		return nil, fmt.Errorf("httpx chunk_workers must be between 1 and %d, got %d", maxChunkWorkers, chunkWorkers)
+	}
+
+	bodyMaxBytes := opts.Int("body_max_bytes")
+	if bodyMaxBytes <= 0 {
+		return nil, fmt.Errorf("httpx body_max_bytes must be positive, got %d", bodyMaxBytes)
+	}
+
+	rt, container, err := common.RuntimeFromOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("httpx config: %w", err)
+	}
+
 	// Create source
 	source := NewWithConfig(logger, execPath, profile, timeout, threads, rateLimit)
+	source.SetRuntime(rt, container)
+
+	// Response content capture
+	storeBody := opts.Bool("store_body")
+	if !storeBody {
+		bodyMaxBytes = 0
+	}
+	source.SetChunking(chunkSize, chunkWorkers)
+	source.SetHeaderCapture(opts.Bool("capture_headers"))
+	source.SetBodyCapture(opts.Bool("hash_body"), bodyMaxBytes)
+	source.SetVerifyURLs(opts.Bool("verify_urls"))
 
 	// Set custom flags if provided
-	if customFlags, ok := cfg.Custom["custom_flags"].([]string); ok {
+	if customFlags := opts.Strings("custom_flags"); len(customFlags) > 0 {
 		source.SetCustomFlags(customFlags)
 	}
 
 	logger.Debug("httpx source created via factory",
+		"runtime", rt,
 		"profile", profile,
 		"threads", threads,
 		"rate_limit", rateLimit,
+		"chunk_size", chunkSize,
+		"chunk_workers", chunkWorkers,
+		"store_body", storeBody,
 		"timeout", timeout.String(),
 	)
 
@@ -0,0 +1,78 @@
+// Package katana implements integration with projectdiscovery/katana CLI tool.
+// config.go holds the crawl settings and their defaults.
+package katana
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultTimeout       = 300 * time.Second // Crawling is slow on large sites
+	defaultDepth         = 3
+	defaultConcurrency   = 10
+	defaultParallelism   = 10
+	defaultRateLimit     = 150
+	defaultMaxSeeds      = 200
+	defaultMaxPerPattern = 10
+	maxDepth             = 10
+)
+
+// validFieldScopes are the katana -field-scope values.
+var validFieldScopes = map[string]bool{"rdn": true, "fqdn": true, "dn": true}
+
+// CrawlConfig contains the katana crawl settings.
+type CrawlConfig struct {
+	Depth         int           // -depth: maximum crawl depth
+	JSCrawl       bool          // -js-crawl: parse endpoints in JavaScript files
+	FieldScope    string        // -field-scope: rdn, fqdn or dn
+	Concurrency   int           // -concurrency: fetchers per seed
+	Parallelism   int           // -parallelism: seeds crawled in parallel
+	RateLimit     int           // -rate-limit: requests per second (0 = unlimited)
+	CrawlDuration time.Duration // -crawl-duration: max time per seed (0 = no limit)
+	MaxSeeds      int           // Alive URLs used as seeds (0 = all)
+	MaxPerPattern int           // URLs kept per URL pattern (0 = no limit)
+}
+
+// DefaultCrawlConfig returns the default crawl settings.
+func DefaultCrawlConfig() CrawlConfig {
+	return CrawlConfig{
+		Depth:         defaultDepth,
+		JSCrawl:       true,
+		FieldScope:    "rdn",
+		Concurrency:   defaultConcurrency,
+		Parallelism:   defaultParallelism,
+		RateLimit:     defaultRateLimit,
+		MaxSeeds:      defaultMaxSeeds,
+		MaxPerPattern: defaultMaxPerPattern,
+	}
+}
+
+// Validate checks the crawl settings.
+func (c CrawlConfig) Validate() error {
+	if c.Depth < 1 || c.Depth > maxDepth {
+		return fmt.Errorf("depth must be between 1 and %d, got %d", maxDepth, c.Depth)
+	}
+	if !validFieldScopes[c.FieldScope] {
+		return fmt.Errorf("invalid field_scope %q (valid: rdn, fqdn, dn)", c.FieldScope)
+	}
+	if c.Concurrency < 1 || c.Concurrency > 100 {
+		return fmt.Errorf("concurrency must be between 1 and 100, got %d", c.Concurrency)
+	}
+	if c.Parallelism < 1 || c.Parallelism > 100 {
+		return fmt.Errorf("parallelism must be between 1 and 100, got %d", c.Parallelism)
+	}
+	if c.RateLimit < 0 {
+		return fmt.Errorf("rate_limit cannot be negative, got %d", c.RateLimit)
+	}
+	if c.CrawlDuration < 0 {
+		return fmt.Errorf("crawl_duration cannot be negative, got %s", c.CrawlDuration)
+	}
+	if c.MaxSeeds < 0 {
+		return fmt.Errorf("max_seeds cannot be negative, got %d", c.MaxSeeds)
+	}
+	if c.MaxPerPattern < 0 {
+		return fmt.Errorf("max_per_pattern cannot be negative, got %d", c.MaxPerPattern)
+	}
+	return nil
+}
@@ -0,0 +1,367 @@
+// Package katana implements integration with projectdiscovery/katana CLI tool.
+// It crawls alive URLs found by earlier stages and turns the discovered links
+// into URL, endpoint, parameter and JavaScript artifacts.
+package katana
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/budget"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/urlfilter"
+	"aethonx/internal/sources/common"
+)
+
+const sourceName = "katana"
+
+// KatanaSource implements ports.Source, ports.AdvancedSource and ports.InputConsumer.
+// It wraps katana CLI tool for active crawling.
+type KatanaSource struct {
+	*common.BaseCLISource // Embedded base for subprocess management
+
+	crawl    CrawlConfig                 // Crawl settings
+	parser   *Parser                     // Output parser
+	patterns *urlfilter.PatternExtractor // Caps URLs per pattern
+}
+
+// New creates a new KatanaSource with default configuration.
+func New(logger logx.Logger) *KatanaSource {
+	return NewWithConfig(logger, "katana", defaultTimeout, DefaultCrawlConfig())
+}
+
+// NewWithConfig creates KatanaSource with custom configuration.
+func NewWithConfig(logger logx.Logger, execPath string, timeout time.Duration, crawl CrawlConfig) *KatanaSource {
+	return &KatanaSource{
+		BaseCLISource: common.NewBaseCLISource(logger, common.BaseCLIConfig{
+			SourceName:     sourceName,
+			ExecPath:       execPath,
+			Timeout:        timeout,
+			ProgressBuffer: 100,
+		}),
+		crawl:    crawl,
+		parser:   NewParser(logger, sourceName),
+		patterns: urlfilter.NewPatternExtractor(1, logger),
+	}
+}
+
+// Name returns the source name.
+func (k *KatanaSource) Name() string {
+	return sourceName
+}
+
+// Mode returns the source operation mode (active).
+func (k *KatanaSource) Mode() domain.SourceMode {
+	return domain.SourceModeActive
+}
+
+// Type returns the source type (CLI).
+func (k *KatanaSource) Type() domain.SourceType {
+	return domain.SourceTypeCLI
+}
+
+// Run crawls the target root when no alive URLs are available.
+func (k *KatanaSource) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	return k.crawlSeeds(ctx, target, []string{"https://" + target.Root})
+}
+
+// RunWithInput crawls the alive URLs from previous stages.
+// Implements ports.InputConsumer interface.
+func (k *KatanaSource) RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+	seeds := k.selectSeeds(input, target)
+	if len(seeds) == 0 {
+		k.GetLogger().Warn("no alive URLs in input, crawling root target", "target", target.Root)
+		return k.Run(ctx, target)
+	}
+	return k.crawlSeeds(ctx, target, seeds)
+}
+
+// selectSeeds returns the alive, in-scope URL artifacts of input, in input
+// order and capped at MaxSeeds.
+func (k *KatanaSource) selectSeeds(input *domain.ScanResult, target domain.Target) []string {
+	if input == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	seeds := make([]string, 0)
+	for _, artifact := range input.Artifacts {
+		if artifact.Type != domain.ArtifactTypeURL || !artifact.IsAlive() {
+			continue
+		}
+		if seen[artifact.Value] || !k.parser.InScope(artifact.Value, target) {
+			continue
+		}
+		seen[artifact.Value] = true
+		seeds = append(seeds, artifact.Value)
+
+		if k.crawl.MaxSeeds > 0 && len(seeds) >= k.crawl.MaxSeeds {
+			k.GetLogger().Info("reached max crawl seeds", "max", k.crawl.MaxSeeds)
+			break
+		}
+	}
+	return seeds
+}
+
+// crawlSeeds runs one katana process over seeds (read from stdin) and
+// converts the in-scope results into artifacts.
+func (k *KatanaSource) crawlSeeds(ctx context.Context, target domain.Target, seeds []string) (*domain.ScanResult, error) {
+	result := domain.NewScanResult(target)
+	startTime := time.Now()
+	args := k.withPoliteness(k.buildCommandArgs(target), append([]string{target.Root}, seeds...))
+
+	k.GetLogger().Info("starting katana crawl",
+		"target", target.Root,
+		"seeds", len(seeds),
+		"depth", k.crawl.Depth,
+		"rate_limit", k.crawl.RateLimit,
+	)
+
+	// Global request budget (--budget): every line katana prints is a request
+	if budget.Shared().Exhausted(budget.Requests) {
+		k.GetLogger().Warn("request budget exhausted, crawl skipped", "seeds", len(seeds))
+		result.AddWarning(sourceName, "request budget exhausted: crawl skipped")
+		return result, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	handler := &katanaHandler{
+		source: k,
+		target: target,
+		seen:   make(map[string]bool),
+		stop:   cancel,
+	}
+
+	// Build command with context (host binary or container, stdin attached)
+	cmd := k.Command(ctx, args)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdin pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start katana: %w", err)
+	}
+
+	// Write seeds to stdin and drain stderr in background
+	go func() {
+		defer stdin.Close()
+		for _, seed := range seeds {
+			fmt.Fprintln(stdin, seed)
+		}
+	}()
+	stderrCh := make(chan []byte, 1)
+	go func() {
+		data, _ := io.ReadAll(stderr)
+		stderrCh <- data
+	}()
+
+	if err := k.ProcessOutput(stdout, handler); err != nil {
+		k.GetLogger().Warn("output processing error", "error", err.Error())
+	}
+
+	if stderrBytes := <-stderrCh; len(stderrBytes) > 0 {
+		k.GetLogger().Debug("katana stderr", "output", string(stderrBytes))
+		result.AddWarning(sourceName, fmt.Sprintf("stderr output: %s", stderrBytes))
+	}
+
+	if handler.budgetErr != nil {
+		k.GetLogger().Warn("request budget exhausted, crawl stopped", "crawled", len(handler.urls))
+		result.AddWarning(sourceName, fmt.Sprintf("crawl stopped: %v", handler.budgetErr))
+	}
+
+	if err := cmd.Wait(); err != nil && handler.budgetErr == nil {
+		// Partial crawls are still useful
+		if len(handler.urls) == 0 {
+			return nil, fmt.Errorf("katana failed: %w", err)
+		}
+		k.GetLogger().Warn("katana exited with error but produced results", "error", err.Error())
+		result.AddWarning(sourceName, fmt.Sprintf("process exited with error: %v", err))
+	}
+
+	// Keep a bounded number of URLs per pattern (e.g. /item/{id})
+	kept := handler.urls
+	if k.crawl.MaxPerPattern > 0 && len(kept) > 0 {
+		kept = k.patterns.FilterByPattern(kept, k.crawl.MaxPerPattern)
+		sort.Strings(kept)
+	}
+	collapsed := len(handler.urls) - len(kept)
+
+	seenArtifacts := make(map[string]bool)
+	for _, rawURL := range kept {
+		for _, artifact := range k.parser.Analyze(handler.results[rawURL], target) {
+			key := string(artifact.Type) + ":" + artifact.Value
+			if !seenArtifacts[key] {
+				seenArtifacts[key] = true
+				result.AddArtifact(artifact)
+			}
+		}
+	}
+
+	result.SetProvenanceQuery(k.Name(), k.CommandLine(args))
+
+	if result.Metadata.Environment == nil {
+		result.Metadata.Environment = make(map[string]string)
+	}
+	result.Metadata.Environment["katana_seeds"] = strconv.Itoa(len(seeds))
+	result.Metadata.Environment["katana_crawled"] = strconv.Itoa(len(handler.urls))
+	result.Metadata.Environment["katana_out_of_scope"] = strconv.Itoa(handler.outOfScope)
+	result.Metadata.Environment["katana_pattern_collapsed"] = strconv.Itoa(collapsed)
+
+	k.GetLogger().Info("katana crawl completed",
+		"target", target.Root,
+		"duration", time.Since(startTime).String(),
+		"seeds", len(seeds),
+		"crawled", len(handler.urls),
+		"out_of_scope", handler.outOfScope,
+		"pattern_collapsed", collapsed,
+		"artifacts", len(result.Artifacts),
+	)
+
+	return result, nil
+}
+
+// katanaHandler implements common.OutputHandler for katana JSONL output.
+type katanaHandler struct {
+	source *KatanaSource
+	target domain.Target
+
+	// State
+	mu         sync.Mutex
+	seen       map[string]bool
+	urls       []string                 // In-scope URLs, in discovery order
+	results    map[string]*KatanaResult // First result per URL
+	outOfScope int
+
+	stop      func() // Stops the crawl once the request budget is spent
+	budgetErr error  // Set when the request budget stopped the crawl
+}
+
+// ProcessLine handles each line of katana stdout.
+func (h *katanaHandler) ProcessLine(line []byte) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := budget.Shared().Take(budget.Requests, 1); err != nil {
+		h.budgetErr = err
+		if h.stop != nil {
+			h.stop()
+		}
+		return err
+	}
+
+	res, err := h.source.parser.ParseLine(line)
+	if err != nil {
+		h.source.GetLogger().Debug("skipping katana line", "error", err.Error())
+		return nil
+	}
+
+	rawURL := res.Request.Endpoint
+	if h.seen[rawURL] {
+		return nil
+	}
+	h.seen[rawURL] = true
+
+	if !h.source.parser.InScope(rawURL, h.target) {
+		h.outOfScope++
+		return nil
+	}
+
+	if h.results == nil {
+		h.results = make(map[string]*KatanaResult)
+	}
+	h.results[rawURL] = res
+	h.urls = append(h.urls, rawURL)
+
+	if len(h.urls)%100 == 0 {
+		h.source.EmitProgress(len(h.urls), fmt.Sprintf("crawled %d URLs", len(h.urls)))
+	}
+	return nil
+}
+
+// Finalize is called after all lines are processed.
+func (h *katanaHandler) Finalize() error {
+	return nil
+}
+
+// Stream implements ports.StreamingSource.
+func (k *KatanaSource) Stream(ctx context.Context, target domain.Target) (<-chan *domain.Artifact, <-chan error) {
+	return k.DefaultStream(ctx, target, k.Run)
+}
+
+// Initialize verifies that katana is installed and accessible.
+// Implements ports.AdvancedSource.
+func (k *KatanaSource) Initialize() error {
+	return k.DefaultInitialize(
+		"katana",
+		"go install github.com/projectdiscovery/katana/cmd/katana@latest",
+	)
+}
+
+// Validate checks if the source configuration is valid.
+// Implements ports.AdvancedSource.
+func (k *KatanaSource) Validate() error {
+	if err := k.DefaultValidate(); err != nil {
+		return err
+	}
+	return k.crawl.Validate()
+}
+
+// HealthCheck verifies that katana is responsive.
+// Implements ports.AdvancedSource.
+func (k *KatanaSource) HealthCheck(ctx context.Context) error {
+	return k.DefaultHealthCheck(ctx)
+}
+
+// buildCommandArgs constructs the katana command arguments. Seeds are read
+// from stdin; the crawl scope is pinned to the target root on top of
+// -field-scope so redirects to third-party hosts are not followed.
+func (k *KatanaSource) buildCommandArgs(target domain.Target) []string {
+	args := []string{
+		"-jsonl",     // JSONL output
+		"-silent",    // No banner/progress
+		"-no-color",  // No ANSI colors
+		"-omit-raw",  // No raw request/response in output
+		"-omit-body", // No response body in output
+		"-disable-update-check",
+		"-depth", strconv.Itoa(k.crawl.Depth),
+		"-field-scope", k.crawl.FieldScope,
+		"-crawl-scope", scopeRegex(target.Root),
+		"-concurrency", strconv.Itoa(k.crawl.Concurrency),
+		"-parallelism", strconv.Itoa(k.crawl.Parallelism),
+	}
+
+	if k.crawl.JSCrawl {
+		args = append(args, "-js-crawl")
+	}
+	if k.crawl.RateLimit > 0 {
+		args = append(args, "-rate-limit", strconv.Itoa(k.crawl.RateLimit))
+	}
+	if k.crawl.CrawlDuration > 0 {
+		args = append(args, "-crawl-duration", fmt.Sprintf("%ds", int(k.crawl.CrawlDuration.Seconds())))
+	}
+
+	return args
+}
+
+// scopeRegex matches http(s) URLs on root or any of its subdomains.
+func scopeRegex(root string) string {
+	return `^https?://([a-z0-9-]+\.)*` + regexp.QuoteMeta(root) + `(:\d+)?(/|$)`
+}
@@ -0,0 +1,237 @@
+package katana
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/budget"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/politeness"
+)
+
+// fakeKatana writes a script that ignores its seeds and prints a fixed crawl.
+func fakeKatana(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "katana")
+	script := "#!/bin/sh\ncat > /dev/null\ncat <<'EOF'\n" + strings.Join(lines, "\n") + "\nEOF\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake katana: %v", err)
+	}
+	return path
+}
+
+func crawlLine(endpoint, source, tag string) string {
+	return `{"request":{"method":"GET","endpoint":"` + endpoint + `","tag":"` + tag + `","attribute":"href","source":"` + source + `"},"response":{"status_code":200}}`
+}
+
+func TestParser_InScope(t *testing.T) {
+	parser := NewParser(logx.New(), sourceName)
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+	target.AddExclusion("internal.example.com")
+
+	tests := map[string]bool{
+		"https://example.com/login":        true,
+		"http://api.example.com:8080/v1":   true,
+		"https://notexample.com/":          false,
+		"https://cdn.other.net/app.js":     false,
+		"https://db.internal.example.com/": false,
+		"mailto:admin@example.com":         false,
+		"javascript:void(0)//example.com":  false,
+	}
+	for rawURL, expected := range tests {
+		if got := parser.InScope(rawURL, *target); got != expected {
+			t.Errorf("InScope(%q) = %v, expected %v", rawURL, got, expected)
+		}
+	}
+}
+
+func TestParser_Analyze(t *testing.T) {
+	parser := NewParser(logx.New(), sourceName)
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+
+	res, err := parser.ParseLine([]byte(crawlLine("https://example.com/static/app.js?v=2", "https://example.com/", "script")))
+	if err != nil {
+		t.Fatalf("ParseLine failed: %v", err)
+	}
+
+	counts := make(map[domain.ArtifactType]int)
+	for _, a := range parser.Analyze(res, *target) {
+		counts[a.Type]++
+	}
+	expected := map[domain.ArtifactType]int{
+		domain.ArtifactTypeURL:        1,
+		domain.ArtifactTypeEndpoint:   1,
+		domain.ArtifactTypeParameter:  1,
+		domain.ArtifactTypeJavaScript: 1,
+	}
+	for artifactType, n := range expected {
+		if counts[artifactType] != n {
+			t.Errorf("expected %d %s artifacts, got %d", n, artifactType, counts[artifactType])
+		}
+	}
+
+	if _, err := parser.ParseLine([]byte(`{"request":{}}`)); err == nil {
+		t.Error("expected error for result without endpoint")
+	}
+}
+
+func TestCrawlConfig_Validate(t *testing.T) {
+	if err := DefaultCrawlConfig().Validate(); err != nil {
+		t.Fatalf("default config should be valid: %v", err)
+	}
+
+	invalid := []func(*CrawlConfig){
+		func(c *CrawlConfig) { c.Depth = 0 },
+		func(c *CrawlConfig) { c.Depth = maxDepth + 1 },
+		func(c *CrawlConfig) { c.FieldScope = "any" },
+		func(c *CrawlConfig) { c.Concurrency = 0 },
+		func(c *CrawlConfig) { c.RateLimit = -1 },
+		func(c *CrawlConfig) { c.MaxSeeds = -1 },
+	}
+	for i, mutate := range invalid {
+		cfg := DefaultCrawlConfig()
+		mutate(&cfg)
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("case %d: expected validation error", i)
+		}
+	}
+}
+
+func TestKatanaSource_BuildCommandArgs(t *testing.T) {
+	crawl := DefaultCrawlConfig()
+	crawl.CrawlDuration = 2 * time.Minute
+	source := NewWithConfig(logx.New(), "katana", defaultTimeout, crawl)
+
+	args := strings.Join(source.buildCommandArgs(*domain.NewTarget("example.com", domain.ScanModeActive)), " ")
+	for _, want := range []string{"-jsonl", "-depth 3", "-field-scope rdn", "-js-crawl", "-rate-limit 150", "-crawl-duration 120s", `-crawl-scope ^https?://([a-z0-9-]+\.)*example\.com(:\d+)?(/|$)`} {
+		if !strings.Contains(args, want) {
+			t.Errorf("args %q missing %q", args, want)
+		}
+	}
+}
+
+func TestKatanaSource_SelectSeeds(t *testing.T) {
+	crawl := DefaultCrawlConfig()
+	crawl.MaxSeeds = 2
+	source := NewWithConfig(logx.New(), "katana", defaultTimeout, crawl)
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+
+	alive := func(value string) *domain.Artifact {
+		a := domain.NewArtifact(domain.ArtifactTypeURL, value, "httpx")
+		a.AddTag("alive")
+		return a
+	}
+	input := domain.NewScanResult(*target)
+	input.AddArtifact(domain.NewArtifact(domain.ArtifactTypeURL, "https://old.example.com/", "waybackurls"))
+	input.AddArtifact(alive("https://other.net/"))
+	input.AddArtifact(alive("https://example.com/"))
+	input.AddArtifact(alive("https://api.example.com/"))
+	input.AddArtifact(alive("https://www.example.com/"))
+
+	seeds := source.selectSeeds(input, *target)
+	if len(seeds) != 2 || seeds[0] != "https://example.com" || seeds[1] != "https://api.example.com" {
+		t.Errorf("unexpected seeds: %v", seeds)
+	}
+}
+
+func TestKatanaSource_RunWithInput(t *testing.T) {
+	var _ ports.InputConsumer = (*KatanaSource)(nil)
+
+	crawl := DefaultCrawlConfig()
+	crawl.MaxPerPattern = 2
+	execPath := fakeKatana(t,
+		crawlLine("https://example.com/login", "https://example.com/", "a"),
+		crawlLine("https://example.com/item/1", "https://example.com/", "a"),
+		crawlLine("https://example.com/item/2", "https://example.com/", "a"),
+		crawlLine("https://example.com/item/3", "https://example.com/", "a"),
+		crawlLine("https://example.com/login", "https://example.com/about", "a"),
+		crawlLine("https://cdn.other.net/lib.js", "https://example.com/", "script"),
+		"not json",
+	)
+	source := NewWithConfig(logx.New(), execPath, defaultTimeout, crawl)
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+
+	input := domain.NewScanResult(*target)
+	seed := domain.NewArtifact(domain.ArtifactTypeURL, "https://example.com/", "httpx")
+	seed.AddTag("alive")
+	input.AddArtifact(seed)
+
+	result, err := source.RunWithInput(context.Background(), *target, input)
+	if err != nil {
+		t.Fatalf("RunWithInput failed: %v", err)
+	}
+
+	env := result.Metadata.Environment
+	if env["katana_seeds"] != "1" || env["katana_crawled"] != "4" || env["katana_out_of_scope"] != "1" || env["katana_pattern_collapsed"] != "1" {
+		t.Errorf("unexpected crawl stats: %v", env)
+	}
+
+	urls := 0
+	for _, a := range result.Artifacts {
+		if a.Type == domain.ArtifactTypeURL {
+			urls++
+			if !strings.HasPrefix(a.Value, "https://example.com/") {
+				t.Errorf("out of scope URL emitted: %s", a.Value)
+			}
+		}
+	}
+	if urls != 3 {
+		t.Errorf("expected 3 URL artifacts (login + 2 items), got %d", urls)
+	}
+}
+
+func TestKatanaSource_RequestBudget(t *testing.T) {
+	budget.Shared().Configure(map[budget.Kind]int64{budget.Requests: 2})
+	budget.Shared().Reset()
+	defer func() {
+		budget.Shared().Configure(nil)
+		budget.Shared().Reset()
+	}()
+
+	execPath := fakeKatana(t,
+		crawlLine("https://example.com/login", "https://example.com/", "a"),
+		crawlLine("https://example.com/admin", "https://example.com/", "a"),
+		crawlLine("https://example.com/api", "https://example.com/", "a"),
+	)
+	source := NewWithConfig(logx.New(), execPath, defaultTimeout, DefaultCrawlConfig())
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+
+	result, err := source.crawlSeeds(context.Background(), *target, []string{"https://example.com/"})
+	if err != nil {
+		t.Fatalf("a spent budget should stop the crawl, not fail it: %v", err)
+	}
+	if got := result.Metadata.Environment["katana_crawled"]; got != "2" {
+		t.Errorf("expected 2 crawled URLs within budget, got %s", got)
+	}
+	if len(result.Warnings) == 0 || !strings.Contains(result.Warnings[len(result.Warnings)-1].Message, "budget exhausted") {
+		t.Errorf("expected a budget warning, got %v", result.Warnings)
+	}
+
+	result, err = source.crawlSeeds(context.Background(), *target, []string{"https://example.com/"})
+	if err != nil || result.Metadata.Environment["katana_crawled"] != "" {
+		t.Errorf("crawl should be skipped once the budget is spent: %v %v", err, result.Metadata.Environment)
+	}
+}
+
+func TestKatanaSource_WithPoliteness(t *testing.T) {
+	policy, _ := politeness.ParsePolicy([]string{"4"}, []string{"example.com=1500ms"})
+	politeness.Shared().Configure(policy)
+	defer politeness.Shared().Configure(politeness.Policy{})
+
+	source := NewWithConfig(logx.New(), "katana", defaultTimeout, DefaultCrawlConfig())
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+
+	args := source.withPoliteness(source.buildCommandArgs(*target), []string{"example.com", "https://www.example.com/"})
+	got := strings.Join(args, " ")
+	for _, want := range []string{"-concurrency 4", "-parallelism 1", "-delay 2"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("args should contain %q: %s", want, got)
+		}
+	}
+}
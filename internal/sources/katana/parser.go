@@ -0,0 +1,124 @@
+// Package katana implements integration with projectdiscovery/katana CLI tool.
+// parser.go handles parsing of katana JSONL output.
+package katana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/platform/logx"
+)
+
+// KatanaResult represents one line of katana -jsonl output.
+type KatanaResult struct {
+	Timestamp string         `json:"timestamp"`
+	Request   KatanaRequest  `json:"request"`
+	Response  KatanaResponse `json:"response"`
+}
+
+// KatanaRequest describes how a URL was discovered.
+type KatanaRequest struct {
+	Method    string `json:"method"`
+	Endpoint  string `json:"endpoint"`  // Discovered URL
+	Tag       string `json:"tag"`       // HTML tag or "js"/"file" for non-HTML sources
+	Attribute string `json:"attribute"` // Attribute holding the link (href, src, action...)
+	Source    string `json:"source"`    // Page the URL was found on
+}
+
+// KatanaResponse is the (optional) response to the crawled URL.
+type KatanaResponse struct {
+	StatusCode int `json:"status_code"`
+}
+
+// Parser converts katana results into artifacts.
+type Parser struct {
+	logger     logx.Logger
+	sourceName string
+}
+
+// NewParser creates a new Parser.
+func NewParser(logger logx.Logger, sourceName string) *Parser {
+	return &Parser{
+		logger:     logger.With("component", "parser"),
+		sourceName: sourceName,
+	}
+}
+
+// ParseLine decodes a single JSONL line.
+func (p *Parser) ParseLine(line []byte) (*KatanaResult, error) {
+	var res KatanaResult
+	if err := json.Unmarshal(line, &res); err != nil {
+		return nil, fmt.Errorf("invalid katana output: %w", err)
+	}
+	if res.Request.Endpoint == "" {
+		return nil, fmt.Errorf("katana output without endpoint")
+	}
+	return &res, nil
+}
+
+// InScope reports whether rawURL belongs to the target: the root domain or
+// one of its subdomains, not excluded by the target scope.
+func (p *Parser) InScope(rawURL string, target domain.Target) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if host != target.Root && !strings.HasSuffix(host, "."+target.Root) {
+		return false
+	}
+	return target.IsInScope(host)
+}
+
+// Analyze creates the artifacts for a crawled URL: the URL itself, its
+// endpoint path, query parameters and, for .js files, a JavaScript artifact.
+func (p *Parser) Analyze(res *KatanaResult, target domain.Target) []*domain.Artifact {
+	rawURL := res.Request.Endpoint
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		p.logger.Debug("failed to parse URL", "url", rawURL, "error", err.Error())
+		return nil
+	}
+
+	artifacts := make([]*domain.Artifact, 0, 4)
+
+	urlArtifact := domain.NewArtifact(domain.ArtifactTypeURL, rawURL, p.sourceName)
+	urlArtifact.Confidence = domain.ConfidenceHigh // Linked from a live page
+	urlArtifact.AddTag("crawled")
+	switch {
+	case res.Request.Tag == "form":
+		urlArtifact.AddTag("form")
+	case strings.HasSuffix(strings.ToLower(res.Request.Source), ".js"):
+		urlArtifact.AddTag("js-endpoint")
+	}
+	hostArtifact := domain.NewArtifact(domain.ArtifactTypeDomain, u.Hostname(), p.sourceName)
+	urlArtifact.AddRelation(hostArtifact.ID, domain.RelationHostedOn, domain.ConfidenceHigh, p.sourceName)
+	artifacts = append(artifacts, urlArtifact)
+
+	if u.Path != "" && u.Path != "/" && len(u.Path) <= 500 {
+		endpoint := domain.NewArtifact(domain.ArtifactTypeEndpoint, u.Path, p.sourceName)
+		endpoint.Confidence = domain.ConfidenceHigh
+		artifacts = append(artifacts, endpoint)
+	}
+
+	for name := range u.Query() {
+		if name == "" {
+			continue
+		}
+		param := domain.NewArtifact(domain.ArtifactTypeParameter, name, p.sourceName)
+		param.Confidence = domain.ConfidenceHigh
+		artifacts = append(artifacts, param)
+	}
+
+	if strings.ToLower(path.Ext(u.Path)) == ".js" {
+		js := domain.NewArtifact(domain.ArtifactTypeJavaScript, rawURL, p.sourceName)
+		js.Confidence = domain.ConfidenceHigh
+		artifacts = append(artifacts, js)
+	}
+
+	return artifacts
+}
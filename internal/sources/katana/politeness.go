@@ -0,0 +1,47 @@
+package katana
+
+import (
+	"strconv"
+	"time"
+
+	"aethonx/internal/platform/politeness"
+)
+
+// withPoliteness applies the per-host limits (--host-concurrency,
+// --host-delay) to katana args. The crawl can reach any host in scope, so
+// the strictest limits among the seeds and the target root cap the whole
+// process: -concurrency x -parallelism stays within the concurrency and
+// -delay (whole seconds, rounded up) spaces the requests of each fetcher.
+func (k *KatanaSource) withPoliteness(args []string, hosts []string) []string {
+	limits := politeness.Shared().Strictest(politeness.Hosts(hosts))
+	if limits.IsZero() {
+		return args
+	}
+
+	if limits.Concurrency > 0 {
+		concurrency := min(k.crawl.Concurrency, limits.Concurrency)
+		parallelism := max(1, min(k.crawl.Parallelism, limits.Concurrency/concurrency))
+		setFlag(args, "-concurrency", strconv.Itoa(concurrency))
+		setFlag(args, "-parallelism", strconv.Itoa(parallelism))
+	}
+	if limits.Delay > 0 {
+		seconds := int((limits.Delay + time.Second - 1) / time.Second)
+		args = append(args, "-delay", strconv.Itoa(seconds))
+	}
+
+	k.GetLogger().Debug("applied per-host politeness",
+		"concurrency", limits.Concurrency,
+		"delay", limits.Delay.String(),
+	)
+	return args
+}
+
+// setFlag replaces the value of flag in args, if present.
+func setFlag(args []string, flag, value string) {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == flag {
+			args[i+1] = value
+			return
+		}
+	}
+}
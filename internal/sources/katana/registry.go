@@ -0,0 +1,124 @@
+package katana
+
+import (
+	"fmt"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+	"aethonx/internal/sources/common"
+)
+
+// dockerImage is the pinned image used with runtime: docker.
+const dockerImage = "projectdiscovery/katana:v1.1.0"
+
+// configSchema declares the katana Custom options.
+var configSchema = append([]ports.ConfigField{
+	{Name: "exec_path", Type: ports.ConfigTypeString, Default: "katana", Description: "Path to the katana binary"},
+	{Name: "depth", Type: ports.ConfigTypeInt, Default: defaultDepth, Description: "Maximum crawl depth (1-10)"},
+	{Name: "js_crawl", Type: ports.ConfigTypeBool, Default: true, Description: "Parse endpoints out of JavaScript files"},
+	{Name: "field_scope", Type: ports.ConfigTypeString, Default: "rdn", Description: "Crawl scope: rdn (root domain), fqdn (seed host only), dn (domain keyword)"},
+	{Name: "concurrency", Type: ports.ConfigTypeInt, Default: defaultConcurrency, Description: "Concurrent fetchers per seed (1-100)"},
+	{Name: "parallelism", Type: ports.ConfigTypeInt, Default: defaultParallelism, Description: "Seeds crawled in parallel (1-100)"},
+	{Name: "rate_limit", Type: ports.ConfigTypeInt, Default: defaultRateLimit, Description: "Max requests per second (0 = unlimited)"},
+	{Name: "crawl_duration", Type: ports.ConfigTypeDuration, Default: "0s", Description: "Max crawl time per seed (0 = no limit)"},
+	{Name: "max_seeds", Type: ports.ConfigTypeInt, Default: defaultMaxSeeds, Description: "Max alive URLs used as crawl seeds (0 = all)"},
+	{Name: "max_per_pattern", Type: ports.ConfigTypeInt, Default: defaultMaxPerPattern, Description: "Max URLs kept per URL pattern (0 = no limit)"},
+}, common.RuntimeFields(dockerImage)...)
+
+// dependency declares the katana binary for "aethonx deps".
+var dependency = &ports.ToolDependency{
+	Binary: "katana",
+	Repo:   "projectdiscovery/katana",
+	AssetPatterns: map[string]string{
+		"linux_amd64":   "katana_*_linux_amd64.zip",
+		"linux_arm64":   "katana_*_linux_arm64.zip",
+		"darwin_amd64":  "katana_*_macOS_amd64.zip",
+		"darwin_arm64":  "katana_*_macOS_arm64.zip",
+		"windows_amd64": "katana_*_windows_amd64.zip",
+	},
+	ChecksumAsset: "katana_*checksums.txt",
+	VersionMarker: "Current Version",
+	MinVersion:    "1.0.4",
+}
+
+// Auto-register katana source on package import.
+func init() {
+	err := registry.Global().Register("katana", factory, ports.SourceMetadata{
+		Name:        "katana",
+		Description: "Project Discovery katana - active web crawling of alive URLs",
+		Author:      "Project Discovery",
+		Version:     "1.1.0",
+		Mode:        domain.SourceModeActive,
+		Type:        domain.SourceTypeCLI,
+		Priority:    20, // Runs after httpx has confirmed alive URLs
+		Phase:       ports.PhaseEnrichment,
+		InputArtifacts: []domain.ArtifactType{
+			domain.ArtifactTypeURL, // Alive URLs from httpx
+		},
+		// Crawled URLs are emitted as well, but ArtifactTypeURL is not
+		// declared: httpx consumes URLs, so declaring it would create a
+		// dependency cycle between both sources.
+		OutputArtifacts: []domain.ArtifactType{
+			domain.ArtifactTypeEndpoint,
+			domain.ArtifactTypeParameter,
+			domain.ArtifactTypeJavaScript,
+		},
+		ConfigSchema: configSchema,
+		Dependency:   dependency,
+	})
+
+	if err != nil {
+		// Log warning but don't panic - allows application to continue
+		logx.New().Warn("failed to register katana source", "error", err.Error())
+	}
+}
+
+// factory creates a new KatanaSource from SourceConfig, decoding Custom against configSchema.
+func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("katana config: %w", err)
+	}
+
+	crawl := CrawlConfig{
+		Depth:         opts.Int("depth"),
+		JSCrawl:       opts.Bool("js_crawl"),
+		FieldScope:    opts.String("field_scope"),
+		Concurrency:   opts.Int("concurrency"),
+		Parallelism:   opts.Int("parallelism"),
+		RateLimit:     opts.Int("rate_limit"),
+		CrawlDuration: opts.Duration("crawl_duration"),
+		MaxSeeds:      opts.Int("max_seeds"),
+		MaxPerPattern: opts.Int("max_per_pattern"),
+	}
+	if err := crawl.Validate(); err != nil {
+		return nil, fmt.Errorf("katana config: %w", err)
+	}
+
+	// Use configured timeout or default
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	rt, container, err := common.RuntimeFromOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("katana config: %w", err)
+	}
+
+	source := NewWithConfig(logger, opts.String("exec_path"), timeout, crawl)
+	source.SetRuntime(rt, container)
+
+	logger.Debug("katana source created via factory",
+		"runtime", rt,
+		"depth", crawl.Depth,
+		"field_scope", crawl.FieldScope,
+		"rate_limit", crawl.RateLimit,
+		"max_seeds", crawl.MaxSeeds,
+		"timeout", timeout.String(),
+	)
+
+	return source, nil
+}
@@ -0,0 +1,392 @@
+// Package masscan implements integration with the masscan port scanner.
+// It scans the CIDR artifacts of earlier stages that fall inside the
+// authorized ranges and hands every open port to httpx as a URL.
+package masscan
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/sources/common"
+)
+
+const (
+	sourceName = "masscan"
+
+	defaultTimeout      = 30 * time.Minute
+	defaultPorts        = "80,443,8000,8080,8443,8888,9443"
+	defaultRate         = 300
+	defaultMaxAddresses = 65536
+	defaultWait         = 5
+	maxRate             = 100000
+)
+
+// tlsPorts are probed as https:// by httpx; every other port as http://.
+var tlsPorts = map[int]bool{443: true, 4443: true, 8443: true, 9443: true}
+
+// ScanConfig controls which ranges are scanned and how fast.
+type ScanConfig struct {
+	Ports        string       // masscan -p syntax ("80,443,8000-8100")
+	Rate         int          // Packets per second
+	Allowed      []*net.IPNet // Authorized ranges: the scope gate
+	Exclude      []string     // Ranges never scanned (--exclude)
+	MaxAddresses int          // Cap on IPv4 addresses per run
+	Wait         int          // Seconds to wait for late responses
+}
+
+// Validate checks the scan settings.
+func (c ScanConfig) Validate() error {
+	if strings.TrimSpace(c.Ports) == "" {
+		return fmt.Errorf("ports cannot be empty")
+	}
+	if c.Rate < 1 || c.Rate > maxRate {
+		return fmt.Errorf("rate must be between 1 and %d, got %d", maxRate, c.Rate)
+	}
+	if c.MaxAddresses < 1 {
+		return fmt.Errorf("max_addresses must be positive, got %d", c.MaxAddresses)
+	}
+	if c.Wait < 0 {
+		return fmt.Errorf("wait cannot be negative, got %d", c.Wait)
+	}
+	for _, r := range c.Exclude {
+		if net.ParseIP(r) == nil {
+			if _, _, err := net.ParseCIDR(r); err != nil {
+				return fmt.Errorf("exclude_ranges: invalid range %q", r)
+			}
+		}
+	}
+	return nil
+}
+
+// MasscanSource implements ports.Source, ports.AdvancedSource and ports.InputConsumer.
+// It wraps the masscan CLI for port discovery over large address ranges.
+type MasscanSource struct {
+	*common.BaseCLISource // Embedded base for subprocess management
+
+	scan ScanConfig
+}
+
+// New creates a new MasscanSource with default configuration and no
+// authorized ranges (nothing is scanned until some are configured).
+func New(logger logx.Logger) *MasscanSource {
+	return NewWithConfig(logger, "masscan", defaultTimeout, ScanConfig{
+		Ports:        defaultPorts,
+		Rate:         defaultRate,
+		MaxAddresses: defaultMaxAddresses,
+		Wait:         defaultWait,
+	})
+}
+
+// NewWithConfig creates MasscanSource with custom configuration.
+func NewWithConfig(logger logx.Logger, execPath string, timeout time.Duration, scan ScanConfig) *MasscanSource {
+	return &MasscanSource{
+		BaseCLISource: common.NewBaseCLISource(logger, common.BaseCLIConfig{
+			SourceName:     sourceName,
+			ExecPath:       execPath,
+			Timeout:        timeout,
+			ProgressBuffer: 100,
+		}),
+		scan: scan,
+	}
+}
+
+// Name returns the source name.
+func (m *MasscanSource) Name() string {
+	return sourceName
+}
+
+// Mode returns the source operation mode (active).
+func (m *MasscanSource) Mode() domain.SourceMode {
+	return domain.SourceModeActive
+}
+
+// Type returns the source type (CLI).
+func (m *MasscanSource) Type() domain.SourceType {
+	return domain.SourceTypeCLI
+}
+
+// Run has nothing to scan without CIDR artifacts: masscan never scans the
+// authorized ranges on its own, only discovered prefixes inside them.
+func (m *MasscanSource) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	result := domain.NewScanResult(target)
+	result.AddWarning(sourceName, "no CIDR artifacts in input: nothing to scan")
+	return result, nil
+}
+
+// RunWithInput scans the authorized part of the CIDR artifacts from
+// previous stages. Implements ports.InputConsumer interface.
+func (m *MasscanSource) RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+	ranges, skipped := m.selectRanges(input)
+	if len(ranges) == 0 {
+		result := domain.NewScanResult(target)
+		if len(m.scan.Allowed) == 0 {
+			result.AddWarning(sourceName, "no allowed_ranges configured: CIDRs are only scanned inside authorized ranges")
+		} else {
+			result.AddWarning(sourceName, fmt.Sprintf("no CIDR inside the allowed ranges (%d skipped)", skipped))
+		}
+		return result, nil
+	}
+	return m.scanRanges(ctx, target, ranges, skipped)
+}
+
+// selectRanges returns the IPv4 ranges to scan: each CIDR artifact clipped
+// to the allowed range that contains it (or to every allowed range it
+// contains), without duplicates and within MaxAddresses. skipped counts the
+// CIDRs and ranges left out.
+func (m *MasscanSource) selectRanges(input *domain.ScanResult) (ranges []string, skipped int) {
+	if input == nil {
+		return nil, 0
+	}
+
+	seen := make(map[string]bool)
+	addresses := 0
+	for _, artifact := range input.Artifacts {
+		if artifact == nil || artifact.Type != domain.ArtifactTypeCIDR {
+			continue
+		}
+		_, network, err := net.ParseCIDR(artifact.Value)
+		if err != nil || network.IP.To4() == nil {
+			skipped++
+			continue
+		}
+		clipped := clip(network, m.scan.Allowed)
+		if len(clipped) == 0 {
+			skipped++
+			continue
+		}
+		for _, c := range clipped {
+			value := c.String()
+			if seen[value] {
+				continue
+			}
+			size := rangeSize(c)
+			if addresses+size > m.scan.MaxAddresses {
+				m.GetLogger().Warn("CIDR skipped: max_addresses reached", "cidr", value, "max_addresses", m.scan.MaxAddresses)
+				skipped++
+				continue
+			}
+			seen[value] = true
+			addresses += size
+			ranges = append(ranges, value)
+		}
+	}
+	sort.Strings(ranges)
+	return ranges, skipped
+}
+
+// clip returns the parts of network inside the allowed ranges: network itself
+// when an allowed range contains it, otherwise every allowed range it
+// contains (nested allowed ranges are reported once, by the outermost).
+// Two CIDRs either nest or do not overlap, so there is no partial case.
+func clip(network *net.IPNet, allowed []*net.IPNet) []*net.IPNet {
+	networkOnes, _ := network.Mask.Size()
+	var inside []*net.IPNet
+	for _, a := range allowed {
+		allowedOnes, _ := a.Mask.Size()
+		switch {
+		case a.Contains(network.IP) && networkOnes >= allowedOnes:
+			return []*net.IPNet{network}
+		case network.Contains(a.IP) && allowedOnes >= networkOnes:
+			inside = append(inside, a)
+		}
+	}
+
+	var clipped []*net.IPNet
+	for _, a := range inside {
+		if !nestedIn(a, inside) {
+			clipped = append(clipped, a)
+		}
+	}
+	return clipped
+}
+
+// nestedIn reports whether network lies inside a larger range of others.
+func nestedIn(network *net.IPNet, others []*net.IPNet) bool {
+	ones, _ := network.Mask.Size()
+	for _, o := range others {
+		otherOnes, _ := o.Mask.Size()
+		if otherOnes < ones && o.Contains(network.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// rangeSize returns the number of IPv4 addresses in network.
+func rangeSize(network *net.IPNet) int {
+	ones, bits := network.Mask.Size()
+	return 1 << (bits - ones)
+}
+
+// scanRanges runs one masscan process over ranges.
+func (m *MasscanSource) scanRanges(ctx context.Context, target domain.Target, ranges []string, skipped int) (*domain.ScanResult, error) {
+	startTime := time.Now()
+	args := m.buildCommandArgs(ranges)
+
+	m.GetLogger().Info("starting masscan",
+		"ranges", len(ranges),
+		"skipped", skipped,
+		"ports", m.scan.Ports,
+		"rate", m.scan.Rate,
+	)
+
+	handler := &masscanHandler{
+		source:  m,
+		results: make(map[string]*domain.Artifact),
+	}
+	result, stderr, err := m.ExecuteCLI(ctx, target, args, handler)
+	if err != nil {
+		// masscan exits non-zero without root/CAP_NET_RAW: no partial results
+		if handler.open == 0 {
+			return nil, fmt.Errorf("masscan failed: %w (%s)", err, strings.TrimSpace(stderr))
+		}
+		m.GetLogger().Warn("masscan exited with error but produced results", "error", err.Error())
+		result.AddWarning(sourceName, fmt.Sprintf("process exited with error: %v", err))
+	}
+
+	result.AddArtifacts(handler.artifacts...)
+	result.SetProvenanceQuery(m.Name(), m.CommandLine(args))
+
+	if result.Metadata.Environment == nil {
+		result.Metadata.Environment = make(map[string]string)
+	}
+	result.Metadata.Environment["masscan_ranges"] = strconv.Itoa(len(ranges))
+	result.Metadata.Environment["masscan_skipped_ranges"] = strconv.Itoa(skipped)
+	result.Metadata.Environment["masscan_open_ports"] = strconv.Itoa(handler.open)
+
+	m.GetLogger().Info("masscan completed",
+		"duration", time.Since(startTime).String(),
+		"ranges", len(ranges),
+		"open_ports", handler.open,
+		"artifacts", len(result.Artifacts),
+	)
+	return result, nil
+}
+
+// buildCommandArgs constructs the masscan command arguments: list output on
+// stdout (-oL -) and the scan rate pinned to the configured value.
+func (m *MasscanSource) buildCommandArgs(ranges []string) []string {
+	args := []string{
+		"-p", m.scan.Ports,
+		"--rate", strconv.Itoa(m.scan.Rate),
+		"--wait", strconv.Itoa(m.scan.Wait),
+		"-oL", "-",
+	}
+	for _, excluded := range m.scan.Exclude {
+		args = append(args, "--exclude", excluded)
+	}
+	return append(args, ranges...)
+}
+
+// masscanHandler implements common.OutputHandler for masscan list output
+// ("open tcp 443 192.0.2.10 1700000000").
+type masscanHandler struct {
+	source *MasscanSource
+
+	mu        sync.Mutex
+	results   map[string]*domain.Artifact // Emitted artifacts by key
+	artifacts []*domain.Artifact          // In emission order
+	open      int
+}
+
+// ProcessLine handles each line of masscan stdout.
+func (h *masscanHandler) ProcessLine(line []byte) error {
+	fields := strings.Fields(string(line))
+	if len(fields) < 4 || fields[0] != "open" || fields[1] != "tcp" {
+		return nil // Comments (#masscan, # end) and UDP/banner lines
+	}
+	port, err := strconv.Atoi(fields[2])
+	ip := net.ParseIP(fields[3])
+	if err != nil || port < 1 || port > 65535 || ip == nil {
+		return fmt.Errorf("invalid masscan line: %q", line)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.open++
+
+	ipArtifact := h.add(domain.NewIPArtifact(ip.String(), sourceName))
+	portArtifact := domain.NewPortArtifact(ip.String(), port, "", sourceName)
+	if meta, ok := portArtifact.TypedMetadata.(*metadata.ServiceMetadata); ok {
+		meta.Protocol = "tcp"
+		meta.State = "open"
+		meta.DetectionMethod = "syn"
+		meta.ScanTool = sourceName
+	}
+	portArtifact = h.add(portArtifact)
+	ipArtifact.AddRelation(portArtifact.ID, domain.RelationListensOn, 1.0, sourceName)
+
+	urlArtifact := h.add(domain.NewArtifact(domain.ArtifactTypeURL, webURL(ip, port), sourceName))
+	portArtifact.AddRelation(urlArtifact.ID, domain.RelationServes, 1.0, sourceName)
+	return nil
+}
+
+// Progress implements common.ProgressCounter.
+func (h *masscanHandler) Progress() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.open
+}
+
+// Finalize is called after all lines are processed.
+func (h *masscanHandler) Finalize() error {
+	return nil
+}
+
+// add records a without duplicates and returns the emitted artifact.
+func (h *masscanHandler) add(a *domain.Artifact) *domain.Artifact {
+	if existing, ok := h.results[a.Key()]; ok {
+		return existing
+	}
+	h.results[a.Key()] = a
+	h.artifacts = append(h.artifacts, a)
+	return a
+}
+
+// webURL returns the URL httpx probes for an open port.
+func webURL(ip net.IP, port int) string {
+	scheme := "http"
+	if tlsPorts[port] {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(ip.String(), strconv.Itoa(port)))
+}
+
+// Initialize verifies that masscan is installed and accessible.
+// Implements ports.AdvancedSource.
+func (m *MasscanSource) Initialize() error {
+	return m.DefaultInitialize(
+		sourceName,
+		"apt install masscan (or build from github.com/robertdavidgraham/masscan); needs root or CAP_NET_RAW",
+	)
+}
+
+// Validate checks if the source configuration is valid.
+// Implements ports.AdvancedSource.
+func (m *MasscanSource) Validate() error {
+	if err := m.DefaultValidate(); err != nil {
+		return err
+	}
+	return m.scan.Validate()
+}
+
+// HealthCheck verifies that masscan is responsive (masscan only knows
+// --version, not -version).
+// Implements ports.AdvancedSource.
+func (m *MasscanSource) HealthCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := m.Command(ctx, []string{"--version"}).Run(); err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package masscan
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+)
+
+// fakeMasscan writes a script that records its arguments and prints a
+// fixed list output.
+func fakeMasscan(t *testing.T, lines ...string) (execPath, argsFile string) {
+	t.Helper()
+	dir := t.TempDir()
+	execPath = filepath.Join(dir, "masscan")
+	argsFile = filepath.Join(dir, "args")
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\ncat <<'EOF'\n" + strings.Join(lines, "\n") + "\nEOF\n"
+	if err := os.WriteFile(execPath, []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake masscan: %v", err)
+	}
+	return execPath, argsFile
+}
+
+func mustCIDR(t *testing.T, s string) *net.IPNet {
+	t.Helper()
+	_, network, err := net.ParseCIDR(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return network
+}
+
+func cidrInput(target *domain.Target, cidrs ...string) *domain.ScanResult {
+	input := domain.NewScanResult(*target)
+	for _, c := range cidrs {
+		input.AddArtifact(domain.NewCIDRArtifact(c, "asnexpand"))
+	}
+	return input
+}
+
+func TestMasscanSource_SelectRanges(t *testing.T) {
+	scan := ScanConfig{Ports: defaultPorts, Rate: defaultRate, MaxAddresses: 512, Allowed: []*net.IPNet{mustCIDR(t, "192.0.2.0/24"), mustCIDR(t, "198.51.100.128/25")}}
+	source := NewWithConfig(logx.NewSilent(), "masscan", defaultTimeout, scan)
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+
+	ranges, skipped := source.selectRanges(cidrInput(target,
+		"192.0.2.64/26",   // Inside an allowed range
+		"198.51.100.0/24", // Contains an allowed range: clipped to it
+		"203.0.113.0/24",  // Not authorized
+		"192.0.2.0/24",    // Equal to an allowed range
+		"2001:db8::/48",   // IPv6 is never scanned
+		"192.0.2.64/26",   // Duplicate
+	))
+	want := []string{"192.0.2.0/24", "192.0.2.64/26", "198.51.100.128/25"}
+	if strings.Join(ranges, " ") != strings.Join(want, " ") {
+		t.Errorf("selectRanges() = %v, want %v", ranges, want)
+	}
+	if skipped != 2 {
+		t.Errorf("expected 2 skipped CIDRs, got %d", skipped)
+	}
+
+	source.scan.MaxAddresses = 100
+	ranges, _ = source.selectRanges(cidrInput(target, "192.0.2.0/24", "192.0.2.64/26"))
+	if strings.Join(ranges, " ") != "192.0.2.64/26" {
+		t.Errorf("expected only the range within max_addresses, got %v", ranges)
+	}
+}
+
+func TestMasscanSource_SelectRanges_SeveralAllowedInside(t *testing.T) {
+	scan := ScanConfig{Ports: defaultPorts, Rate: defaultRate, MaxAddresses: defaultMaxAddresses, Allowed: []*net.IPNet{
+		mustCIDR(t, "10.0.1.0/24"),
+		mustCIDR(t, "10.0.3.128/25"),
+		mustCIDR(t, "10.0.3.192/26"), // Nested in the /25: not scanned twice
+		mustCIDR(t, "192.0.2.0/24"),
+	}}
+	source := NewWithConfig(logx.NewSilent(), "masscan", defaultTimeout, scan)
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+
+	ranges, skipped := source.selectRanges(cidrInput(target, "10.0.0.0/16"))
+	want := []string{"10.0.1.0/24", "10.0.3.128/25"}
+	if strings.Join(ranges, " ") != strings.Join(want, " ") {
+		t.Errorf("selectRanges() = %v, want %v", ranges, want)
+	}
+	if skipped != 0 {
+		t.Errorf("expected no skipped CIDRs, got %d", skipped)
+	}
+}
+
+func TestMasscanSource_RunWithInput(t *testing.T) {
+	var _ ports.InputConsumer = (*MasscanSource)(nil)
+
+	execPath, argsFile := fakeMasscan(t,
+		"#masscan",
+		"open tcp 443 192.0.2.10 1700000000",
+		"open tcp 8080 192.0.2.10 1700000001",
+		"open tcp 80 192.0.2.11 1700000002",
+		"open udp 53 192.0.2.12 1700000003",
+		"# end",
+	)
+	scan := ScanConfig{Ports: defaultPorts, Rate: 100, MaxAddresses: defaultMaxAddresses, Wait: 1, Allowed: []*net.IPNet{mustCIDR(t, "192.0.2.0/24")}, Exclude: []string{"192.0.2.1"}}
+	source := NewWithConfig(logx.NewSilent(), execPath, defaultTimeout, scan)
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+
+	result, err := source.RunWithInput(context.Background(), *target, cidrInput(target, "192.0.2.0/24", "203.0.113.0/24"))
+	if err != nil {
+		t.Fatalf("RunWithInput failed: %v", err)
+	}
+
+	args, _ := os.ReadFile(argsFile)
+	for _, want := range []string{"-p " + defaultPorts, "--rate 100", "-oL -", "--exclude 192.0.2.1", "192.0.2.0/24"} {
+		if !strings.Contains(string(args), want) {
+			t.Errorf("expected %q in args %q", want, args)
+		}
+	}
+	if strings.Contains(string(args), "203.0.113.0/24") {
+		t.Errorf("unauthorized range passed to masscan: %q", args)
+	}
+
+	counts := make(map[domain.ArtifactType]int)
+	urls := make(map[string]bool)
+	for _, a := range result.Artifacts {
+		counts[a.Type]++
+		if a.Type == domain.ArtifactTypeURL {
+			urls[a.Value] = true
+		}
+	}
+	if counts[domain.ArtifactTypeIP] != 2 || counts[domain.ArtifactTypePort] != 3 || counts[domain.ArtifactTypeURL] != 3 {
+		t.Errorf("unexpected artifact counts: %v", counts)
+	}
+	if !urls["https://192.0.2.10"] && !urls["https://192.0.2.10:443"] {
+		t.Errorf("expected an https URL for port 443, got %v", urls)
+	}
+	if result.Metadata.Environment["masscan_open_ports"] != "3" || result.Metadata.Environment["masscan_skipped_ranges"] != "1" {
+		t.Errorf("unexpected stats: %v", result.Metadata.Environment)
+	}
+}
+
+func TestMasscanSource_RequiresAllowedRanges(t *testing.T) {
+	execPath, argsFile := fakeMasscan(t, "open tcp 80 203.0.113.5 1700000000")
+	source := NewWithConfig(logx.NewSilent(), execPath, defaultTimeout, ScanConfig{Ports: defaultPorts, Rate: defaultRate, MaxAddresses: defaultMaxAddresses})
+	target := domain.NewTarget("example.com", domain.ScanModeActive)
+
+	result, err := source.RunWithInput(context.Background(), *target, cidrInput(target, "203.0.113.0/24"))
+	if err != nil {
+		t.Fatalf("RunWithInput failed: %v", err)
+	}
+	if len(result.Artifacts) != 0 || len(result.Warnings) != 1 {
+		t.Errorf("expected no scan and a warning, got %d artifacts and %v", len(result.Artifacts), result.Warnings)
+	}
+	if _, err := os.Stat(argsFile); err == nil {
+		t.Error("masscan must not run without allowed ranges")
+	}
+}
+
+func TestFactory_Validation(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		"invalid allowed range": {"allowed_ranges": []string{"not-a-cidr"}},
+		"rate too high":         {"rate": maxRate + 1},
+		"invalid exclude":       {"exclude_ranges": []string{"nope"}},
+		"empty ports":           {"ports": " "},
+	}
+	for name, custom := range cases {
+		if _, err := factory(ports.SourceConfig{Custom: custom}, logx.NewSilent()); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := factory(ports.SourceConfig{Custom: map[string]interface{}{"allowed_ranges": []string{"192.0.2.0/24"}}}, logx.NewSilent()); err != nil {
+		t.Errorf("factory() failed: %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package masscan
+
+import (
+	"fmt"
+	"net"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+)
+
+// configSchema declares the masscan Custom options.
+var configSchema = []ports.ConfigField{
+	{Name: "exec_path", Type: ports.ConfigTypeString, Default: "masscan", Description: "Path to the masscan binary"},
+	{Name: "ports", Type: ports.ConfigTypeString, Default: defaultPorts, Description: "Ports to scan (masscan -p syntax); every open port is handed to httpx as a URL"},
+	{Name: "rate", Type: ports.ConfigTypeInt, Default: defaultRate, Description: "Packets per second (1-100000); keep it low on shared networks"},
+	{Name: "allowed_ranges", Type: ports.ConfigTypeStringList, Description: "CIDRs authorized for scanning; discovered CIDRs outside them are never scanned (required)"},
+	{Name: "exclude_ranges", Type: ports.ConfigTypeStringList, Description: "CIDRs or IPs never scanned (masscan --exclude)"},
+	{Name: "max_addresses", Type: ports.ConfigTypeInt, Default: defaultMaxAddresses, Description: "Max IPv4 addresses scanned per run; larger ranges are skipped"},
+	{Name: "wait", Type: ports.ConfigTypeInt, Default: defaultWait, Description: "Seconds to wait for late responses after sending"},
+}
+
+// Auto-register masscan source on package import.
+func init() {
+	err := registry.Global().Register(sourceName, factory, ports.SourceMetadata{
+		Name:        sourceName,
+		Description: "masscan - rate-limited port discovery over authorized CIDR ranges",
+		Author:      "Robert Graham",
+		Version:     "1.3.2",
+		Mode:        domain.SourceModeActive,
+		Type:        domain.SourceTypeCLI,
+		Priority:    14, // Runs before httpx verifies the open ports
+		InputArtifacts: []domain.ArtifactType{
+			domain.ArtifactTypeCIDR, // Prefixes from amass/asnexpand
+		},
+		OutputArtifacts: []domain.ArtifactType{
+			domain.ArtifactTypeIP,
+			domain.ArtifactTypePort,
+			domain.ArtifactTypeURL, // Open ports, verified by httpx
+		},
+		ConfigSchema: configSchema,
+	})
+
+	if err != nil {
+		// Log warning but don't panic - allows application to continue
+		logx.New().Warn("failed to register masscan source", "error", err.Error())
+	}
+}
+
+// factory creates a new MasscanSource from SourceConfig, decoding Custom against configSchema.
+func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("masscan config: %w", err)
+	}
+
+	scan := ScanConfig{
+		Ports:        opts.String("ports"),
+		Rate:         opts.Int("rate"),
+		MaxAddresses: opts.Int("max_addresses"),
+		Wait:         opts.Int("wait"),
+		Exclude:      opts.Strings("exclude_ranges"),
+	}
+	for _, cidr := range opts.Strings("allowed_ranges") {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("masscan allowed_ranges: %w", err)
+		}
+		scan.Allowed = append(scan.Allowed, network)
+	}
+	if err := scan.Validate(); err != nil {
+		return nil, fmt.Errorf("masscan config: %w", err)
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	source := NewWithConfig(logger, opts.String("exec_path"), timeout, scan)
+	logger.Debug("masscan source created via factory",
+		"ports", scan.Ports,
+		"rate", scan.Rate,
+		"allowed_ranges", len(scan.Allowed),
+		"timeout", timeout.String(),
+	)
+	return source, nil
+}
@@ -0,0 +1,150 @@
+// internal/sources/nmapimport/nmapimport.go
+package nmapimport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+)
+
+const (
+	sourceName = "nmapimport"
+
+	// importTag marca los artifacts que vienen de un escaneo nmap previo
+	importTag = "nmap-import"
+)
+
+// configSchema declara las opciones Custom de nmapimport.
+var configSchema = []ports.ConfigField{
+	{Name: "files", Type: ports.ConfigTypeStringList, Description: "nmap XML files to import (-oX / -oA); set by --import"},
+	{Name: "open_only", Type: ports.ConfigTypeBool, Default: true, Description: "Import only open ports (skip filtered/closed)"},
+}
+
+// Auto-registro de la source al importar el package
+func init() {
+	if err := registry.Global().Register(
+		sourceName,
+		factory,
+		ports.SourceMetadata{
+			Name:         sourceName,
+			Description:  "Import of existing nmap XML output (hosts, ports, services, NSE scripts)",
+			Version:      "1.0.0",
+			Author:       "AethonX",
+			Mode:         domain.SourceModePassive,
+			Type:         domain.SourceTypeFile,
+			RequiresAuth: false,
+
+			// Sin inputs: corre en el primer stage y sus hosts y URLs alimentan
+			// la verificación (httpx) de los stages siguientes
+			OutputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeIP,
+				domain.ArtifactTypeIPv6,
+				domain.ArtifactTypeSubdomain,
+				domain.ArtifactTypePort,
+				domain.ArtifactTypeService,
+				domain.ArtifactTypeURL,
+				domain.ArtifactTypeVulnerability,
+			},
+			Priority: 12,
+
+			ConfigSchema: configSchema,
+		},
+	); err != nil {
+		logx.New().Warn("failed to register nmapimport source", "error", err.Error())
+	}
+}
+
+// factory crea la source desde SourceConfig (Custom según configSchema).
+func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("nmapimport config: %w", err)
+	}
+	files := opts.Strings("files")
+	if len(files) == 0 {
+		return nil, fmt.Errorf("nmapimport needs at least one file (--import scan.xml)")
+	}
+
+	source := New(logger, files)
+	source.openOnly = opts.Bool("open_only")
+	return source, nil
+}
+
+// Source convierte la salida XML de nmap en artifacts: IPs, hostnames en
+// scope, puertos, servicios (producto, versión, CPE, scripts NSE), URLs de
+// los servicios HTTP y las vulnerabilidades que reportan los scripts vuln.
+// No envía tráfico: fusiona escaneos previos sin volver a escanear.
+type Source struct {
+	files    []string
+	openOnly bool
+	logger   logx.Logger
+}
+
+// New crea la source nmapimport para los ficheros dados.
+func New(logger logx.Logger, files []string) *Source {
+	return &Source{
+		files:    files,
+		openOnly: true,
+		logger:   logger.With("source", sourceName),
+	}
+}
+
+// Name retorna el nombre de la fuente.
+func (s *Source) Name() string { return sourceName }
+
+// Mode retorna el modo de operación (pasivo: solo lee ficheros).
+func (s *Source) Mode() domain.SourceMode { return domain.SourceModePassive }
+
+// Type retorna el tipo de fuente (file).
+func (s *Source) Type() domain.SourceType { return domain.SourceTypeFile }
+
+// Close no libera recursos.
+func (s *Source) Close() error { return nil }
+
+// SetLogger sustituye el logger por el del escaneo (scan_id, stage, source).
+// Implementa ports.LogScopedSource.
+func (s *Source) SetLogger(logger logx.Logger) { s.logger = logger }
+
+// Run importa cada fichero. Un fichero ilegible o que no es XML de nmap se
+// registra como error y no impide importar el resto.
+func (s *Source) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	result := domain.NewScanResult(target)
+	result.Metadata.SourcesUsed = []string{sourceName}
+
+	p := newParser(target, s.openOnly)
+	for _, file := range s.files {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			result.AddError(sourceName, fmt.Sprintf("open %s: %v", file, err), false)
+			continue
+		}
+		hosts, err := p.parse(f, result)
+		f.Close()
+		if err != nil {
+			result.AddError(sourceName, fmt.Sprintf("%s: %v", file, err), false)
+			continue
+		}
+		s.logger.Info("nmap file imported", "file", file, "hosts", hosts)
+	}
+
+	s.logger.Info("nmapimport completed",
+		"files", len(s.files),
+		"artifacts", len(result.Artifacts),
+	)
+	return result, nil
+}
+
+// IsNmapXML indica si head (el inicio de un fichero) es salida XML de nmap.
+// --import lo usa para enviar cada fichero a su source.
+func IsNmapXML(head []byte) bool {
+	return bytes.Contains(head, []byte("<nmaprun"))
+}
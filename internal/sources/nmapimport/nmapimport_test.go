@@ -0,0 +1,86 @@
+package nmapimport
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+	"aethonx/internal/testutil/sourcetest"
+)
+
+func TestNmapImport_Golden(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result, err := New(logx.NewSilent(), []string{"testdata/scan.xml"}).Run(context.Background(), *target)
+	testutil.AssertNoError(t, err, "run")
+	testutil.AssertEqual(t, len(result.Errors), 0, "import errors")
+
+	sourcetest.Golden(t, "testdata/scan.golden", result.Artifacts)
+}
+
+func TestNmapImport_ServiceAndVulnMetadata(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result, err := New(logx.NewSilent(), []string{"testdata/scan.xml"}).Run(context.Background(), *target)
+	testutil.AssertNoError(t, err, "run")
+
+	for _, a := range result.Artifacts {
+		switch {
+		case a.Type == domain.ArtifactTypeService && a.Value == "192.0.2.10:443":
+			meta, ok := a.TypedMetadata.(*metadata.ServiceMetadata)
+			testutil.AssertTrue(t, ok, "service metadata")
+			testutil.AssertEqual(t, meta.Product, "nginx", "product")
+			testutil.AssertEqual(t, meta.CPE, "cpe:/a:igor_sysoev:nginx:1.18.0", "cpe")
+			testutil.AssertTrue(t, meta.SSLEnabled, "ssl tunnel")
+			testutil.AssertEqual(t, meta.ScriptResults["http-title"], "Example Portal", "script output")
+			testutil.AssertEqual(t, len(meta.CVEList), 1, "vulners CVEs")
+		case a.Type == domain.ArtifactTypeVulnerability:
+			meta, ok := a.TypedMetadata.(*metadata.VulnerabilityMetadata)
+			testutil.AssertTrue(t, ok, "vulnerability metadata")
+			testutil.AssertEqual(t, meta.CVE, "CVE-2017-0143", "cve")
+			testutil.AssertEqual(t, meta.MatchedAt, "192.0.2.11", "location")
+			testutil.AssertEqual(t, meta.TemplateID, "smb-vuln-ms17-010", "script")
+		case a.Type == domain.ArtifactTypeIP && a.Value == "192.0.2.11":
+			meta, ok := a.TypedMetadata.(*metadata.IPMetadata)
+			testutil.AssertTrue(t, ok, "ip metadata")
+			testutil.AssertEqual(t, meta.PTRRecord, "mail.other.org", "ptr kept on the ip")
+		}
+	}
+}
+
+func TestNmapImport_AllPortStates(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	s := New(logx.NewSilent(), []string{"testdata/scan.xml"})
+	s.openOnly = false
+
+	result, err := s.Run(context.Background(), *target)
+	testutil.AssertNoError(t, err, "run")
+	sourcetest.AssertArtifacts(t, result.Artifacts, "port 192.0.2.10:8080", "url http://www.example.com:8080")
+}
+
+func TestNmapImport_InvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	notNmap := filepath.Join(dir, "other.xml")
+	testutil.AssertNoError(t, os.WriteFile(notNmap, []byte("<report><host/></report>"), 0o644), "write")
+
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result, err := New(logx.NewSilent(), []string{notNmap, filepath.Join(dir, "missing.xml"), "testdata/scan.xml"}).Run(context.Background(), *target)
+	testutil.AssertNoError(t, err, "run")
+	testutil.AssertEqual(t, len(result.Errors), 2, "one error per bad file")
+	sourcetest.AssertArtifacts(t, result.Artifacts, "ip 192.0.2.10")
+}
+
+func TestIsNmapXML(t *testing.T) {
+	testutil.AssertTrue(t, IsNmapXML(sourcetest.Fixture(t, "testdata/scan.xml")), "nmap output detected")
+	testutil.AssertFalse(t, IsNmapXML([]byte(`{"Artifacts": []}`)), "json is not nmap")
+}
+
+func TestFactory_RequiresFiles(t *testing.T) {
+	if _, err := factory(ports.SourceConfig{Custom: map[string]interface{}{}}, logx.NewSilent()); err == nil {
+		t.Error("expected an error without files")
+	}
+}
@@ -0,0 +1,378 @@
+// internal/sources/nmapimport/parser.go
+package nmapimport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+)
+
+// Modelo del XML de nmap (-oX): solo los elementos que se importan.
+type nmapHost struct {
+	Status    nmapStatus     `xml:"status"`
+	Addresses []nmapAddress  `xml:"address"`
+	Hostnames []nmapHostname `xml:"hostnames>hostname"`
+	Ports     []nmapPort     `xml:"ports>port"`
+	Scripts   []nmapScript   `xml:"hostscript>script"`
+}
+
+type nmapStatus struct {
+	State string `xml:"state,attr"`
+}
+
+type nmapAddress struct {
+	Addr     string `xml:"addr,attr"`
+	AddrType string `xml:"addrtype,attr"` // ipv4, ipv6, mac
+}
+
+type nmapHostname struct {
+	Name string `xml:"name,attr"`
+	Type string `xml:"type,attr"` // user, PTR
+}
+
+type nmapPort struct {
+	Protocol string       `xml:"protocol,attr"`
+	PortID   int          `xml:"portid,attr"`
+	State    nmapStatus   `xml:"state"`
+	Service  nmapService  `xml:"service"`
+	Scripts  []nmapScript `xml:"script"`
+}
+
+type nmapService struct {
+	Name      string   `xml:"name,attr"`
+	Product   string   `xml:"product,attr"`
+	Version   string   `xml:"version,attr"`
+	ExtraInfo string   `xml:"extrainfo,attr"`
+	Tunnel    string   `xml:"tunnel,attr"` // "ssl"
+	Method    string   `xml:"method,attr"` // probed, table
+	Conf      int      `xml:"conf,attr"`   // 0-10
+	CPEs      []string `xml:"cpe"`
+}
+
+type nmapScript struct {
+	ID     string      `xml:"id,attr"`
+	Output string      `xml:"output,attr"`
+	Tables []nmapTable `xml:"table"`
+}
+
+type nmapTable struct {
+	Key    string      `xml:"key,attr"`
+	Elems  []nmapElem  `xml:"elem"`
+	Tables []nmapTable `xml:"table"`
+}
+
+type nmapElem struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// cvePattern extrae CVEs de la salida de scripts como vulners.
+var cvePattern = regexp.MustCompile(`CVE-\d{4}-\d{4,}`)
+
+// parser convierte hosts de nmap en artifacts deduplicados entre ficheros.
+type parser struct {
+	target   domain.Target
+	openOnly bool
+	seen     map[string]*domain.Artifact
+}
+
+func newParser(target domain.Target, openOnly bool) *parser {
+	return &parser{target: target, openOnly: openOnly, seen: make(map[string]*domain.Artifact)}
+}
+
+// parse lee r elemento a elemento (los XML de rangos grandes no se cargan
+// enteros) y añade a result los artifacts de cada host activo. Retorna el
+// número de hosts importados.
+func (p *parser) parse(r io.Reader, result *domain.ScanResult) (int, error) {
+	dec := xml.NewDecoder(r)
+	sawRun, hosts := false, 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return hosts, fmt.Errorf("invalid nmap XML: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "nmaprun":
+			sawRun = true
+		case "host":
+			var h nmapHost
+			if err := dec.DecodeElement(&h, &start); err != nil {
+				return hosts, fmt.Errorf("invalid nmap host: %w", err)
+			}
+			if p.addHost(result, h) {
+				hosts++
+			}
+		}
+	}
+	if !sawRun {
+		return 0, fmt.Errorf("not an nmap XML file (no <nmaprun>)")
+	}
+	return hosts, nil
+}
+
+// addHost emite IP -listens_on-> port -serves-> service/url, los hostnames
+// en scope (-resolves_to-> IP) y las vulnerabilidades de los scripts.
+func (p *parser) addHost(result *domain.ScanResult, h nmapHost) bool {
+	if h.Status.State != "" && h.Status.State != "up" {
+		return false
+	}
+	ipAddr := hostIP(h)
+	if ipAddr == "" {
+		return false
+	}
+
+	ip := p.add(result, domain.NewIPArtifact(ipAddr, sourceName))
+	ipMeta, _ := ip.TypedMetadata.(*metadata.IPMetadata)
+
+	// Los hostnames en scope son subdominios del target; el resto (PTR de
+	// proveedores) queda como reverse DNS de la IP
+	var names []string
+	for _, hn := range h.Hostnames {
+		name := strings.ToLower(strings.TrimSuffix(hn.Name, "."))
+		if name == "" {
+			continue
+		}
+		if hn.Type == "PTR" && ipMeta != nil && ipMeta.PTRRecord == "" {
+			ipMeta.PTRRecord = name
+		}
+		if !p.target.IsInScope(name) {
+			continue
+		}
+		sub := p.add(result, domain.NewSubdomainArtifact(name, sourceName))
+		sub.AddRelation(ip.ID, domain.RelationResolvesTo, 1.0, sourceName)
+		names = append(names, name)
+	}
+
+	for _, script := range h.Scripts {
+		p.addVulns(result, ip, script, ipAddr)
+	}
+
+	for _, port := range h.Ports {
+		if p.openOnly && port.State.State != "open" {
+			continue
+		}
+		p.addPort(result, ip, ipMeta, ipAddr, names, port)
+	}
+	return true
+}
+
+// addPort emite el puerto, su servicio y, si es HTTP, la URL que verificará httpx.
+func (p *parser) addPort(result *domain.ScanResult, ip *domain.Artifact, ipMeta *metadata.IPMetadata, ipAddr string, names []string, port nmapPort) {
+	svc := port.Service
+	portArtifact := p.add(result, domain.NewPortArtifact(ipAddr, port.PortID, svc.Name, sourceName))
+	ip.AddRelation(portArtifact.ID, domain.RelationListensOn, 1.0, sourceName)
+	if ipMeta != nil {
+		ipMeta.OpenPorts = appendUniqueInt(ipMeta.OpenPorts, port.PortID)
+	}
+
+	name := svc.Name
+	if name == "" {
+		name = "unknown"
+	}
+	meta := metadata.NewServiceMetadata(name, port.PortID)
+	meta.Product = svc.Product
+	meta.Version = svc.Version
+	meta.ExtraInfo = svc.ExtraInfo
+	meta.Protocol = port.Protocol
+	meta.State = port.State.State
+	meta.ParentIP = ipAddr
+	meta.ScanTool = "nmap"
+	meta.SSLEnabled = svc.Tunnel == "ssl"
+	if len(svc.CPEs) > 0 {
+		meta.CPE = svc.CPEs[0]
+	}
+	switch svc.Method {
+	case "probed":
+		meta.DetectionMethod = "probe"
+	case "table":
+		meta.DetectionMethod = "inference"
+	}
+	if svc.Conf > 0 {
+		meta.Confidence = float64(svc.Conf) / 10
+	}
+	if len(port.Scripts) > 0 {
+		meta.ScriptResults = make(map[string]string, len(port.Scripts))
+		for _, script := range port.Scripts {
+			meta.ScriptResults[script.ID] = strings.TrimSpace(script.Output)
+			meta.CVEList = appendUnique(meta.CVEList, cvePattern.FindAllString(script.Output, -1)...)
+		}
+		sort.Strings(meta.CVEList)
+	}
+	meta.HasVulns = len(meta.CVEList) > 0
+
+	service := p.add(result, domain.NewArtifactWithMetadata(domain.ArtifactTypeService, domain.PortValue(ipAddr, port.PortID), sourceName, meta))
+	portArtifact.AddRelation(service.ID, domain.RelationServes, 1.0, sourceName)
+
+	location := domain.PortValue(ipAddr, port.PortID)
+	for _, script := range port.Scripts {
+		p.addVulns(result, service, script, location)
+	}
+
+	// Una URL por hostname en scope (vhosts) o, sin ellos, por la IP
+	if scheme := webScheme(svc); scheme != "" && port.Protocol != "udp" {
+		hosts := names
+		if len(hosts) == 0 {
+			hosts = []string{ipAddr}
+		}
+		for _, host := range hosts {
+			u := p.add(result, domain.NewArtifact(domain.ArtifactTypeURL, webURL(scheme, host, port.PortID), sourceName))
+			portArtifact.AddRelation(u.ID, domain.RelationServes, 1.0, sourceName)
+		}
+	}
+}
+
+// addVulns emite las vulnerabilidades que un script NSE de la categoría vuln
+// marca como VULNERABLE (owner -has_vuln-> vulnerability).
+func (p *parser) addVulns(result *domain.ScanResult, owner *domain.Artifact, script nmapScript, location string) {
+	found := false
+	for _, t := range script.Tables {
+		state := strings.ToUpper(elem(t, "state"))
+		if !strings.HasPrefix(state, "VULNERABLE") && !strings.HasPrefix(state, "LIKELY VULNERABLE") {
+			continue
+		}
+		found = true
+
+		id := script.ID
+		for _, ids := range t.Tables {
+			if ids.Key != "ids" {
+				continue
+			}
+			for _, e := range ids.Elems {
+				if cve := strings.TrimPrefix(e.Value, "CVE:"); strings.HasPrefix(cve, "CVE-") {
+					id = cve
+					break
+				}
+			}
+		}
+		vuln := domain.NewVulnerabilityArtifact(id, "", sourceName)
+		if m, ok := vuln.TypedMetadata.(*metadata.VulnerabilityMetadata); ok {
+			m.Title = elem(t, "title")
+			m.MatchedAt = location
+			m.TemplateID = script.ID
+			m.DiscoveryTool = "nmap"
+			if score, err := strconv.ParseFloat(elem(t, "cvss"), 64); err == nil {
+				m.CVSSScore = score
+			}
+			m.Description = strings.TrimSpace(elem(t, "description"))
+			for _, refs := range t.Tables {
+				if refs.Key == "refs" {
+					for _, e := range refs.Elems {
+						m.References = append(m.References, strings.TrimSpace(e.Value))
+					}
+				}
+			}
+		}
+		vuln = p.add(result, vuln)
+		owner.AddRelation(vuln.ID, domain.RelationHasVuln, 1.0, sourceName)
+	}
+
+	// Scripts sin salida estructurada: solo el texto "VULNERABLE"
+	if !found && strings.Contains(script.Output, "State: VULNERABLE") {
+		vuln := domain.NewVulnerabilityArtifact(script.ID, "", sourceName)
+		if m, ok := vuln.TypedMetadata.(*metadata.VulnerabilityMetadata); ok {
+			m.MatchedAt = location
+			m.DiscoveryTool = "nmap"
+			m.Description = strings.TrimSpace(script.Output)
+		}
+		vuln = p.add(result, vuln)
+		owner.AddRelation(vuln.ID, domain.RelationHasVuln, 1.0, sourceName)
+	}
+}
+
+// add añade el artifact (etiquetado como importado) salvo que ya se haya
+// emitido, y retorna el que queda en result.
+func (p *parser) add(result *domain.ScanResult, a *domain.Artifact) *domain.Artifact {
+	key := a.Key()
+	if existing, ok := p.seen[key]; ok {
+		return existing
+	}
+	a.AddTag(importTag)
+	p.seen[key] = a
+	result.AddArtifact(a)
+	return a
+}
+
+// hostIP retorna la dirección IPv4/IPv6 del host ("" si solo tiene MAC).
+func hostIP(h nmapHost) string {
+	for _, a := range h.Addresses {
+		if a.AddrType == "ipv4" || a.AddrType == "ipv6" {
+			if ip := net.ParseIP(a.Addr); ip != nil {
+				return ip.String()
+			}
+		}
+	}
+	return ""
+}
+
+// webScheme retorna "http"/"https" si el servicio es web ("" si no).
+func webScheme(svc nmapService) string {
+	name := strings.ToLower(svc.Name)
+	switch {
+	case name == "https" || name == "https-alt" || (strings.HasPrefix(name, "http") && svc.Tunnel == "ssl"):
+		return "https"
+	case strings.HasPrefix(name, "http"):
+		return "http"
+	}
+	return ""
+}
+
+// webURL compone la URL omitiendo el puerto por defecto del esquema.
+func webURL(scheme, host string, port int) string {
+	if (scheme == "http" && port == 80) || (scheme == "https" && port == 443) {
+		if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+		return scheme + "://" + host
+	}
+	return scheme + "://" + net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// elem retorna el valor del elem con la clave dada.
+func elem(t nmapTable, key string) string {
+	for _, e := range t.Elems {
+		if e.Key == key {
+			return e.Value
+		}
+	}
+	return ""
+}
+
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		dup := false
+		for _, existing := range list {
+			if existing == v {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+func appendUniqueInt(list []int, v int) []int {
+	for _, existing := range list {
+		if existing == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
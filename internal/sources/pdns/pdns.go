@@ -0,0 +1,447 @@
+// internal/sources/pdns/pdns.go
+package pdns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/httpclient"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+)
+
+const (
+	sourceName           = "pdns"
+	defaultWorkers       = 4
+	defaultRateLimit     = 1.0
+	defaultMaxHosts      = 100
+	defaultCurrentWindow = 30 * 24 * time.Hour
+	requestTimeout       = 30 * time.Second
+	tagHistorical        = "pdns-historical"
+)
+
+// defaultProviders son los proveedores consultados por defecto; circl solo
+// se usa si hay credenciales.
+var defaultProviders = []string{"mnemonic", "circl"}
+
+// upstreams es el presupuesto compartido (rate.Shared) de cada proveedor.
+var upstreams = map[string]string{
+	"mnemonic": "api.mnemonic.no",
+	"circl":    "circl.lu",
+}
+
+// configSchema declara las opciones Custom de pdns.
+var configSchema = []ports.ConfigField{
+	{Name: "providers", Type: ports.ConfigTypeStringList, Default: defaultProviders, Description: "Passive DNS providers to query (mnemonic, circl)"},
+	{Name: "mnemonic_api_key", Type: ports.ConfigTypeString, Credential: true, Description: "Mnemonic API key (optional, raises the daily quota)"},
+	{Name: "circl_user", Type: ports.ConfigTypeString, Description: "CIRCL Passive DNS username"},
+	{Name: "circl_password", Type: ports.ConfigTypeString, Credential: true, Description: "CIRCL Passive DNS password"},
+	{Name: "workers", Type: ports.ConfigTypeInt, Default: defaultWorkers, Description: "Hosts queried in parallel (1-50)"},
+	{Name: "rate_limit", Type: ports.ConfigTypeFloat, Default: defaultRateLimit, Description: "Max requests per second per provider (0 = unlimited)"},
+	{Name: "max_hosts", Type: ports.ConfigTypeInt, Default: defaultMaxHosts, Description: "Max hosts queried (0 = all)"},
+	{Name: "current_window", Type: ports.ConfigTypeDuration, Default: defaultCurrentWindow.String(), Description: "Resolutions last seen longer ago are marked historical"},
+}
+
+// Auto-registro de la source al importar el package
+func init() {
+	if err := registry.Global().Register(
+		sourceName,
+		factory,
+		ports.SourceMetadata{
+			Name:         sourceName,
+			Description:  "Historical resolutions from passive DNS (Mnemonic, CIRCL)",
+			Version:      "1.0.0",
+			Author:       "AethonX",
+			Mode:         domain.SourceModePassive,
+			Type:         domain.SourceTypeAPI,
+			RequiresAuth: false, // Mnemonic admite consultas anónimas
+
+			// Consume dominios/subdominios de stages previos
+			InputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeDomain,
+				domain.ArtifactTypeSubdomain,
+			},
+			OutputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeIP,
+				domain.ArtifactTypeIPv6,
+			},
+			Priority: 11,
+			Phase:    ports.PhaseDiscovery,
+
+			ConfigSchema: configSchema,
+			Providers:    []string{upstreams["mnemonic"], upstreams["circl"]},
+		},
+	); err != nil {
+		logx.New().Warn("failed to register pdns source", "error", err.Error())
+	}
+}
+
+// factory crea la source desde SourceConfig (Custom según configSchema).
+func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("pdns config: %w", err)
+	}
+
+	workers := opts.Int("workers")
+	if workers <= 0 || workers > 50 {
+		return nil, fmt.Errorf("pdns workers must be between 1 and 50, got %d", workers)
+	}
+	rateLimit := opts.Float("rate_limit")
+	if rateLimit < 0 {
+		return nil, fmt.Errorf("pdns rate_limit cannot be negative, got %v", rateLimit)
+	}
+	maxHosts := opts.Int("max_hosts")
+	if maxHosts < 0 {
+		return nil, fmt.Errorf("pdns max_hosts cannot be negative, got %d", maxHosts)
+	}
+	window := opts.Duration("current_window")
+	if window <= 0 {
+		return nil, fmt.Errorf("pdns current_window must be positive, got %s", window)
+	}
+
+	newClient := func(upstream string) *httpclient.Client {
+		return httpclient.New(httpclient.Config{
+			Timeout:        requestTimeout,
+			MaxRetries:     2,
+			UserAgent:      "AethonX/1.0",
+			RateLimit:      rateLimit,
+			RateLimitBurst: 1,
+			Upstream:       upstream, // Cuota compartida entre scans concurrentes
+		}, logger)
+	}
+
+	providers := make([]provider, 0, 2)
+	for _, name := range opts.Strings("providers") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "mnemonic":
+			providers = append(providers, &mnemonicProvider{
+				client:  newClient(upstreams["mnemonic"]),
+				baseURL: mnemonicBaseURL,
+				apiKey:  opts.String("mnemonic_api_key"),
+			})
+		case "circl":
+			user, password := opts.String("circl_user"), opts.String("circl_password")
+			if user == "" || password == "" {
+				logger.Debug("skipping circl provider: no credentials configured")
+				continue
+			}
+			providers = append(providers, &circlProvider{
+				client:   newClient(upstreams["circl"]),
+				baseURL:  circlBaseURL,
+				user:     user,
+				password: password,
+			})
+		default:
+			return nil, fmt.Errorf("pdns unknown provider %q (valid: mnemonic, circl)", name)
+		}
+	}
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("pdns has no usable provider (circl requires circl_user and circl_password)")
+	}
+
+	source := New(logger, providers, workers)
+	source.maxHosts = maxHosts
+	source.currentWindow = window
+	return source, nil
+}
+
+// Source consulta bases de DNS pasivo por el dominio raíz y sus subdominios
+// y emite artifacts ip/ipv6 con relaciones resolves_to históricas: cada
+// relación lleva first_seen/last_seen en su metadata, de modo que la
+// infraestructura rotada (IPs que ya no resuelven) queda identificada.
+type Source struct {
+	providers     []provider
+	workers       int
+	maxHosts      int
+	currentWindow time.Duration
+	now           func() time.Time
+	logger        logx.Logger
+}
+
+// New crea la source pdns.
+func New(logger logx.Logger, providers []provider, workers int) *Source {
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	return &Source{
+		providers:     providers,
+		workers:       workers,
+		maxHosts:      defaultMaxHosts,
+		currentWindow: defaultCurrentWindow,
+		now:           time.Now,
+		logger:        logger.With("source", sourceName),
+	}
+}
+
+// Name retorna el nombre de la fuente.
+func (s *Source) Name() string { return sourceName }
+
+// Providers retorna los upstreams de los proveedores configurados.
+// Implementa ports.ProviderSource.
+func (s *Source) Providers() []string {
+	providers := make([]string, 0, len(s.providers))
+	for _, p := range s.providers {
+		if upstream, ok := upstreams[p.Name()]; ok {
+			providers = append(providers, upstream)
+		}
+	}
+	return providers
+}
+
+// Mode retorna el modo de operación (pasivo: solo APIs de terceros).
+func (s *Source) Mode() domain.SourceMode { return domain.SourceModePassive }
+
+// Type retorna el tipo de fuente (API).
+func (s *Source) Type() domain.SourceType { return domain.SourceTypeAPI }
+
+// Close no libera recursos.
+func (s *Source) Close() error { return nil }
+
+// SetLogger sustituye el logger por el del escaneo (scan_id, stage, source).
+// Implementa ports.LogScopedSource.
+func (s *Source) SetLogger(logger logx.Logger) { s.logger = logger }
+
+// Run consulta solo el dominio raíz del target.
+func (s *Source) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	return s.queryAll(ctx, target, []string{target.QueryName()})
+}
+
+// RunWithInput consulta el dominio raíz y los dominios/subdominios en scope
+// de input. Implementa ports.InputConsumer.
+func (s *Source) RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+	hosts := []string{target.QueryName()}
+	if input != nil {
+		for _, a := range input.Artifacts {
+			if a.Type != domain.ArtifactTypeDomain && a.Type != domain.ArtifactTypeSubdomain {
+				continue
+			}
+			if target.IsInScope(a.Value) {
+				hosts = append(hosts, a.Value)
+			}
+		}
+	}
+	return s.queryAll(ctx, target, hosts)
+}
+
+// resolution agrega las observaciones de host → value de todos los proveedores.
+type resolution struct {
+	host      string
+	value     string
+	firstSeen time.Time
+	lastSeen  time.Time
+	providers []string
+}
+
+// hostResult son las resoluciones de un host.
+type hostResult struct {
+	host        string
+	resolutions []*resolution
+	failed      []string // Proveedores que fallaron
+}
+
+// queryAll consulta hosts (deduplicados, el raíz primero) con un pool de workers.
+func (s *Source) queryAll(ctx context.Context, target domain.Target, hosts []string) (*domain.ScanResult, error) {
+	result := domain.NewScanResult(target)
+	result.Metadata.SourcesUsed = []string{sourceName}
+
+	unique := s.selectHosts(target, hosts)
+	jobs := make(chan string)
+	results := make(chan hostResult, len(unique))
+
+	var wg sync.WaitGroup
+	workers := s.workers
+	if workers > len(unique) {
+		workers = len(unique)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for host := range jobs {
+				results <- s.queryHost(ctx, host)
+			}
+		}()
+	}
+
+feed:
+	for _, host := range unique {
+		select {
+		case jobs <- host:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	close(results)
+
+	hostResults := make([]hostResult, 0, len(unique))
+	for r := range results {
+		hostResults = append(hostResults, r)
+	}
+	sort.Slice(hostResults, func(i, j int) bool { return hostResults[i].host < hostResults[j].host })
+
+	failures, historical := 0, 0
+	for _, r := range hostResults {
+		if len(r.failed) > 0 {
+			failures++
+		}
+		historical += s.addArtifacts(result, target, r)
+	}
+
+	if failures > 0 {
+		result.AddWarning(sourceName, fmt.Sprintf("%d of %d hosts had failed pDNS lookups", failures, len(unique)))
+	}
+
+	if result.Metadata.Environment == nil {
+		result.Metadata.Environment = make(map[string]string)
+	}
+	result.Metadata.Environment["pdns_hosts"] = fmt.Sprintf("%d", len(unique))
+	result.Metadata.Environment["pdns_historical"] = fmt.Sprintf("%d", historical)
+
+	s.logger.Info("passive dns lookup completed",
+		"hosts", len(unique),
+		"artifacts", len(result.Artifacts),
+		"historical", historical,
+		"failures", failures,
+	)
+
+	return result, ctx.Err()
+}
+
+// selectHosts deduplica hosts (en minúsculas) y aplica maxHosts manteniendo
+// siempre el dominio raíz.
+func (s *Source) selectHosts(target domain.Target, hosts []string) []string {
+	root := target.QueryName()
+	seen := map[string]bool{root: true}
+	others := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		h = strings.ToLower(strings.TrimSuffix(h, "."))
+		if h == "" || seen[h] {
+			continue
+		}
+		seen[h] = true
+		others = append(others, h)
+	}
+	sort.Strings(others)
+
+	unique := append([]string{root}, others...)
+	if s.maxHosts > 0 && len(unique) > s.maxHosts {
+		s.logger.Info("limiting pDNS hosts", "hosts", len(unique), "max", s.maxHosts)
+		unique = unique[:s.maxHosts]
+	}
+	return unique
+}
+
+// queryHost consulta todos los proveedores y agrega sus registros A/AAAA
+// por valor: first_seen es el mínimo y last_seen el máximo observados.
+func (s *Source) queryHost(ctx context.Context, host string) hostResult {
+	r := hostResult{host: host}
+	byValue := make(map[string]*resolution)
+
+	for _, p := range s.providers {
+		records, err := p.Lookup(ctx, host)
+		if err != nil {
+			s.logger.Debug("pdns lookup failed", "provider", p.Name(), "host", host, "error", err.Error())
+			r.failed = append(r.failed, p.Name())
+			continue
+		}
+
+		for _, rec := range records {
+			if (rec.rrtype != "a" && rec.rrtype != "aaaa") || net.ParseIP(rec.value) == nil {
+				continue
+			}
+			if !strings.EqualFold(strings.TrimSuffix(rec.host, "."), host) {
+				continue
+			}
+			res, ok := byValue[rec.value]
+			if !ok {
+				res = &resolution{host: host, value: rec.value, firstSeen: rec.firstSeen, lastSeen: rec.lastSeen}
+				byValue[rec.value] = res
+				r.resolutions = append(r.resolutions, res)
+			}
+			if rec.firstSeen.Before(res.firstSeen) {
+				res.firstSeen = rec.firstSeen
+			}
+			if rec.lastSeen.After(res.lastSeen) {
+				res.lastSeen = rec.lastSeen
+			}
+			if !containsString(res.providers, rec.provider) {
+				res.providers = append(res.providers, rec.provider)
+			}
+		}
+	}
+
+	sort.Slice(r.resolutions, func(i, j int) bool { return r.resolutions[i].value < r.resolutions[j].value })
+	return r
+}
+
+// addArtifacts crea el artifact del host y uno por IP observada, con la
+// relación resolves_to (y reverse_resolves) anotada con las fechas. Las IPs
+// no vistas dentro de currentWindow se etiquetan como históricas y la
+// relación baja a confianza baja. Devuelve el número de resoluciones históricas.
+func (s *Source) addArtifacts(result *domain.ScanResult, target domain.Target, r hostResult) int {
+	if len(r.resolutions) == 0 {
+		return 0
+	}
+
+	hostType := domain.ArtifactTypeSubdomain
+	if r.host == target.QueryName() {
+		hostType = domain.ArtifactTypeDomain
+	}
+	hostArtifact := domain.NewArtifact(hostType, r.host, sourceName)
+	hostArtifact.Confidence = domain.ConfidenceMedium
+
+	cutoff := s.now().Add(-s.currentWindow)
+	historical := 0
+	for _, res := range r.resolutions {
+		ipArtifact := domain.NewIPArtifact(res.value, sourceName)
+
+		confidence := domain.ConfidenceMedium
+		isHistorical := res.lastSeen.Before(cutoff)
+		if isHistorical {
+			historical++
+			confidence = domain.ConfidenceLow
+			ipArtifact.AddTag(tagHistorical)
+		}
+		ipArtifact.Confidence = confidence
+
+		relMeta := map[string]string{
+			"first_seen": res.firstSeen.Format(time.RFC3339),
+			"last_seen":  res.lastSeen.Format(time.RFC3339),
+			"providers":  strings.Join(res.providers, ","),
+			"historical": fmt.Sprintf("%t", isHistorical),
+		}
+		hostArtifact.AddRelationWithMetadata(ipArtifact.ID, domain.RelationResolvesTo, confidence, sourceName, relMeta)
+		ipArtifact.AddRelationWithMetadata(hostArtifact.ID, domain.RelationReverseResolves, confidence, sourceName, copyMap(relMeta))
+		result.AddArtifact(ipArtifact)
+	}
+
+	result.AddArtifact(hostArtifact)
+	return historical
+}
+
+func copyMap(m map[string]string) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
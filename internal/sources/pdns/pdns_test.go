@@ -0,0 +1,130 @@
+package pdns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/httpclient"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+)
+
+var testNow = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestClient() *httpclient.Client {
+	return httpclient.New(httpclient.Config{MaxRetries: 0}, logx.New())
+}
+
+// newUpstream simula las APIs de Mnemonic (/mnemonic/) y CIRCL (/circl/).
+func newUpstream(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mnemonic/example.com":
+			fmt.Fprintf(w, `{"responseCode":200,"data":[
+				{"query":"example.com","answer":"93.184.216.34","rrtype":"a","firstSeenTimestamp":%d,"lastSeenTimestamp":%d},
+				{"query":"example.com","answer":"ns1.example.net","rrtype":"ns","firstSeenTimestamp":0,"lastSeenTimestamp":0}
+			]}`, testNow.AddDate(-1, 0, 0).UnixMilli(), testNow.AddDate(0, 0, -1).UnixMilli())
+		case "/mnemonic/old.example.com":
+			fmt.Fprintf(w, `{"responseCode":200,"data":[
+				{"query":"old.example.com","answer":"2001:db8::1","rrtype":"aaaa","firstSeenTimestamp":%d,"lastSeenTimestamp":%d}
+			]}`, testNow.AddDate(-3, 0, 0).UnixMilli(), testNow.AddDate(-2, 0, 0).UnixMilli())
+		case "/circl/example.com":
+			if user, pass, ok := r.BasicAuth(); !ok || user != "u" || pass != "p" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprintf(w, "{\"rrname\":\"example.com.\",\"rrtype\":\"A\",\"rdata\":\"93.184.216.34\",\"time_first\":%d,\"time_last\":%d}\nnot json\n",
+				testNow.AddDate(-5, 0, 0).Unix(), testNow.AddDate(0, -2, 0).Unix())
+		case "/circl/old.example.com":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func newTestSource(server *httptest.Server) *Source {
+	providers := []provider{
+		&mnemonicProvider{client: newTestClient(), baseURL: server.URL + "/mnemonic/"},
+		&circlProvider{client: newTestClient(), baseURL: server.URL + "/circl/", user: "u", password: "p"},
+	}
+	source := New(logx.New(), providers, 2)
+	source.now = func() time.Time { return testNow }
+	return source
+}
+
+func TestSource_RunWithInput_MergesProviders(t *testing.T) {
+	var _ ports.InputConsumer = (*Source)(nil)
+
+	server := newUpstream(t)
+	defer server.Close()
+
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	input := domain.NewScanResult(*target)
+	input.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "old.example.com", "crtsh"))
+	input.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "unknown.example.com", "crtsh"))
+
+	result, err := newTestSource(server).RunWithInput(context.Background(), *target, input)
+	testutil.AssertNoError(t, err, "run")
+
+	relations := make(map[string]domain.ArtifactRelation)
+	ips := make(map[string]*domain.Artifact)
+	for _, a := range result.Artifacts {
+		switch a.Type {
+		case domain.ArtifactTypeIP, domain.ArtifactTypeIPv6:
+			ips[a.Value] = a
+		case domain.ArtifactTypeDomain, domain.ArtifactTypeSubdomain:
+			for _, rel := range a.GetRelations(domain.RelationResolvesTo) {
+				relations[a.Value] = rel
+			}
+		}
+	}
+
+	testutil.AssertEqual(t, len(ips), 2, "one IP per A/AAAA resolution, NS ignored")
+
+	current := relations["example.com"]
+	testutil.AssertEqual(t, current.Metadata["first_seen"], testNow.AddDate(-5, 0, 0).Format(time.RFC3339), "earliest first_seen across providers")
+	testutil.AssertEqual(t, current.Metadata["last_seen"], testNow.AddDate(0, 0, -1).Format(time.RFC3339), "latest last_seen across providers")
+	testutil.AssertEqual(t, current.Metadata["providers"], "mnemonic,circl", "both providers recorded")
+	testutil.AssertEqual(t, current.Metadata["historical"], "false", "recent resolution is current")
+	testutil.AssertFalse(t, containsString(ips["93.184.216.34"].Tags, tagHistorical), "current IP not tagged")
+
+	old := relations["old.example.com"]
+	testutil.AssertEqual(t, old.Metadata["historical"], "true", "stale resolution is historical")
+	testutil.AssertEqual(t, old.Confidence, domain.ConfidenceLow, "historical relations have low confidence")
+	testutil.AssertEqual(t, ips["2001:db8::1"].Type, domain.ArtifactTypeIPv6, "AAAA answers are IPv6 artifacts")
+	testutil.AssertTrue(t, containsString(ips["2001:db8::1"].Tags, tagHistorical), "rotated IP tagged")
+
+	testutil.AssertEqual(t, result.Metadata.Environment["pdns_hosts"], "3", "queried hosts")
+	testutil.AssertEqual(t, result.Metadata.Environment["pdns_historical"], "1", "historical resolutions")
+	testutil.AssertTrue(t, len(result.Warnings) == 1 && strings.Contains(result.Warnings[0].Message, "1 of 3"), "failed lookup reported")
+}
+
+func TestSource_SelectHosts(t *testing.T) {
+	source := New(logx.New(), nil, 1)
+	source.maxHosts = 2
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+
+	hosts := source.selectHosts(*target, []string{"www.example.com", "API.example.com.", "example.com", "www.example.com"})
+	testutil.AssertEqual(t, strings.Join(hosts, ","), "example.com,api.example.com", "root first, deduped, lowercased and capped")
+}
+
+func TestFactory(t *testing.T) {
+	_, err := factory(ports.SourceConfig{Custom: map[string]interface{}{"providers": []string{"circl"}}}, logx.New())
+	testutil.AssertError(t, err, "circl without credentials leaves no provider")
+
+	_, err = factory(ports.SourceConfig{Custom: map[string]interface{}{"providers": []string{"virustotal"}}}, logx.New())
+	testutil.AssertError(t, err, "unknown provider")
+
+	source, err := factory(ports.SourceConfig{}, logx.New())
+	testutil.AssertNoError(t, err, "defaults")
+	testutil.AssertEqual(t, len(source.(*Source).providers), 1, "only mnemonic without circl credentials")
+}
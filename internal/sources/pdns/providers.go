@@ -0,0 +1,159 @@
+// internal/sources/pdns/providers.go
+package pdns
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"aethonx/internal/platform/errors"
+	"aethonx/internal/platform/httpclient"
+)
+
+const (
+	mnemonicBaseURL = "https://api.mnemonic.no/pdns/v3/"
+	circlBaseURL    = "https://www.circl.lu/pdns/query/"
+	mnemonicLimit   = 1000
+)
+
+// record es una resolución histórica observada por un proveedor pDNS.
+type record struct {
+	host      string
+	rrtype    string // "a" o "aaaa"
+	value     string
+	firstSeen time.Time
+	lastSeen  time.Time
+	provider  string
+}
+
+// provider consulta una base de datos de DNS pasivo.
+type provider interface {
+	Name() string
+	Lookup(ctx context.Context, host string) ([]record, error)
+}
+
+// mnemonicProvider consulta la API pública de Mnemonic (sin clave con
+// límite diario; con clave en Argus-API-Key).
+type mnemonicProvider struct {
+	client  *httpclient.Client
+	baseURL string
+	apiKey  string
+}
+
+// mnemonicResponse es la respuesta de /pdns/v3/{query}.
+type mnemonicResponse struct {
+	ResponseCode int `json:"responseCode"`
+	Data         []struct {
+		Query              string `json:"query"`
+		Answer             string `json:"answer"`
+		RRType             string `json:"rrtype"`
+		FirstSeenTimestamp int64  `json:"firstSeenTimestamp"` // Milisegundos
+		LastSeenTimestamp  int64  `json:"lastSeenTimestamp"`
+	} `json:"data"`
+}
+
+func (m *mnemonicProvider) Name() string { return "mnemonic" }
+
+// Lookup devuelve los registros A/AAAA de host. 404 equivale a sin datos.
+func (m *mnemonicProvider) Lookup(ctx context.Context, host string) ([]record, error) {
+	endpoint := fmt.Sprintf("%s%s?limit=%d", m.baseURL, url.PathEscape(host), mnemonicLimit)
+	headers := map[string]string{"Accept": "application/json"}
+	if m.apiKey != "" {
+		headers["Argus-API-Key"] = m.apiKey
+	}
+
+	body, err := fetch(ctx, m.client, endpoint, headers)
+	if err != nil || body == nil {
+		return nil, err
+	}
+
+	var resp mnemonicResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, errors.Wrap(err, "invalid mnemonic response")
+	}
+
+	records := make([]record, 0, len(resp.Data))
+	for _, d := range resp.Data {
+		records = append(records, record{
+			host:      d.Query,
+			rrtype:    strings.ToLower(d.RRType),
+			value:     d.Answer,
+			firstSeen: time.UnixMilli(d.FirstSeenTimestamp).UTC(),
+			lastSeen:  time.UnixMilli(d.LastSeenTimestamp).UTC(),
+			provider:  m.Name(),
+		})
+	}
+	return records, nil
+}
+
+// circlProvider consulta CIRCL Passive DNS (requiere usuario y contraseña).
+type circlProvider struct {
+	client   *httpclient.Client
+	baseURL  string
+	user     string
+	password string
+}
+
+// circlRecord es una línea de la respuesta NDJSON (Passive DNS Common
+// Output Format).
+type circlRecord struct {
+	RRName    string `json:"rrname"`
+	RRType    string `json:"rrtype"`
+	RData     string `json:"rdata"`
+	TimeFirst int64  `json:"time_first"` // Segundos
+	TimeLast  int64  `json:"time_last"`
+}
+
+func (c *circlProvider) Name() string { return "circl" }
+
+// Lookup devuelve los registros de host; las líneas inválidas se ignoran.
+func (c *circlProvider) Lookup(ctx context.Context, host string) ([]record, error) {
+	auth := base64.StdEncoding.EncodeToString([]byte(c.user + ":" + c.password))
+	headers := map[string]string{"Authorization": "Basic " + auth}
+
+	body, err := fetch(ctx, c.client, c.baseURL+url.PathEscape(host), headers)
+	if err != nil || body == nil {
+		return nil, err
+	}
+
+	records := make([]record, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r circlRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+		records = append(records, record{
+			host:      strings.TrimSuffix(r.RRName, "."),
+			rrtype:    strings.ToLower(r.RRType),
+			value:     r.RData,
+			firstSeen: time.Unix(r.TimeFirst, 0).UTC(),
+			lastSeen:  time.Unix(r.TimeLast, 0).UTC(),
+			provider:  c.Name(),
+		})
+	}
+	return records, scanner.Err()
+}
+
+// fetch descarga endpoint. Un 404 devuelve body nil sin error.
+func fetch(ctx context.Context, client *httpclient.Client, endpoint string, headers map[string]string) ([]byte, error) {
+	resp, err := client.Get(ctx, endpoint, headers)
+	if err != nil {
+		return nil, err
+	}
+	if err := httpclient.CheckStatus(resp); err != nil {
+		resp.Body.Close()
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return httpclient.ReadBody(resp)
+}
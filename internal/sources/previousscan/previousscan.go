@@ -0,0 +1,217 @@
+// internal/sources/previousscan/previousscan.go
+package previousscan
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync/atomic"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+)
+
+const (
+	sourceName = "previousscan"
+
+	// defaultConfidence es la confianza de un activo que solo consta en un
+	// escaneo anterior (no confirmado todavía en este)
+	defaultConfidence = 0.5
+)
+
+// reinjectedTypes son los tipos que se reinyectan: los activos que los stages
+// de verificación (httpx, DNS, puertos) vuelven a comprobar. Metadata y
+// relaciones no se copian: describen el estado del escaneo anterior.
+var reinjectedTypes = []domain.ArtifactType{
+	domain.ArtifactTypeDomain,
+	domain.ArtifactTypeSubdomain,
+	domain.ArtifactTypeIP,
+	domain.ArtifactTypeIPv6,
+	domain.ArtifactTypeURL,
+}
+
+// configSchema declara las opciones Custom de previousscan.
+var configSchema = []ports.ConfigField{
+	{Name: "files", Type: ports.ConfigTypeStringList, Description: "Previous AethonX scans (consolidated JSON, compressed or sharded); set by --previous-scan or --import"},
+	{Name: "confidence", Type: ports.ConfigTypeFloat, Default: defaultConfidence, Description: "Confidence of re-injected artifacts until a source confirms them"},
+}
+
+// Loader carga el escaneo guardado en path con solo los artifacts de types.
+// La source no depende del adaptador de salida: el binario fija el lector.
+type Loader func(path string, types []domain.ArtifactType) (*domain.ScanResult, error)
+
+// loader es el lector activo. Se fija una vez al arrancar.
+var loader atomic.Value
+
+// SetLoader fija el lector de escaneos guardados.
+func SetLoader(l Loader) {
+	loader.Store(l)
+}
+
+// Auto-registro de la source al importar el package
+func init() {
+	if err := registry.Global().Register(
+		sourceName,
+		factory,
+		ports.SourceMetadata{
+			Name:         sourceName,
+			Description:  "Re-injects the assets of a previous AethonX scan of the same target for re-verification",
+			Version:      "1.0.0",
+			Author:       "AethonX",
+			Mode:         domain.SourceModePassive,
+			Type:         domain.SourceTypeFile,
+			RequiresAuth: false,
+
+			// Sin inputs: corre en el primer stage y sus activos alimentan los
+			// stages de verificación aunque las sources pasivas no los vean
+			OutputArtifacts: reinjectedTypes,
+			Priority:        11,
+
+			ConfigSchema: configSchema,
+		},
+	); err != nil {
+		logx.New().Warn("failed to register previousscan source", "error", err.Error())
+	}
+}
+
+// factory crea la source desde SourceConfig (Custom según configSchema).
+func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("previousscan config: %w", err)
+	}
+	files := opts.Strings("files")
+	if len(files) == 0 {
+		return nil, fmt.Errorf("previousscan needs at least one file (--previous-scan latest|scan.json)")
+	}
+	load, _ := loader.Load().(Loader)
+	if load == nil {
+		return nil, fmt.Errorf("previousscan: no scan loader configured")
+	}
+	confidence := opts.Float("confidence")
+	if confidence <= 0 || confidence > 1 {
+		return nil, fmt.Errorf("previousscan confidence must be in (0, 1], got %v", confidence)
+	}
+
+	source := New(logger, files, load)
+	source.confidence = confidence
+	return source, nil
+}
+
+// Source reinyecta los activos de escaneos anteriores del mismo target con
+// el tag "historical" y confianza reducida. Cuando otra source los vuelve a
+// encontrar se fusionan con ella; los que nadie confirma siguen llegando a
+// la verificación pero no cuentan como vistos para el lifecycle.
+type Source struct {
+	files      []string
+	load       Loader
+	confidence float64
+	logger     logx.Logger
+}
+
+// New crea la source previousscan para los ficheros dados.
+func New(logger logx.Logger, files []string, load Loader) *Source {
+	return &Source{
+		files:      files,
+		load:       load,
+		confidence: defaultConfidence,
+		logger:     logger.With("source", sourceName),
+	}
+}
+
+// Name retorna el nombre de la fuente.
+func (s *Source) Name() string { return sourceName }
+
+// Mode retorna el modo de operación (pasivo: solo lee ficheros).
+func (s *Source) Mode() domain.SourceMode { return domain.SourceModePassive }
+
+// Type retorna el tipo de fuente (file).
+func (s *Source) Type() domain.SourceType { return domain.SourceTypeFile }
+
+// Close no libera recursos.
+func (s *Source) Close() error { return nil }
+
+// SetLogger sustituye el logger por el del escaneo (scan_id, stage, source).
+// Implementa ports.LogScopedSource.
+func (s *Source) SetLogger(logger logx.Logger) { s.logger = logger }
+
+// Run carga cada escaneo y reinyecta sus activos. Un fichero ilegible o de
+// otro target se registra como error y no impide cargar el resto.
+func (s *Source) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	result := domain.NewScanResult(target)
+	result.Metadata.SourcesUsed = []string{sourceName}
+
+	seen := make(map[string]bool)
+	for _, file := range s.files {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		previous, err := s.load(file, reinjectedTypes)
+		if err != nil {
+			result.AddError(sourceName, fmt.Sprintf("%s: %v", file, err), false)
+			continue
+		}
+		if !strings.EqualFold(previous.Target.Root, target.Root) {
+			result.AddError(sourceName, fmt.Sprintf("%s is a scan of %s, not %s", file, previous.Target.Root, target.Root), false)
+			continue
+		}
+
+		added := 0
+		for _, a := range previous.Artifacts {
+			if a == nil || !s.reinject(a, &target) {
+				continue
+			}
+			artifact := domain.NewArtifact(a.Type, a.Value, sourceName)
+			artifact.Confidence = s.confidence
+			artifact.AddTag(domain.TagHistorical)
+			if seen[artifact.Key()] {
+				continue
+			}
+			seen[artifact.Key()] = true
+			result.AddArtifact(artifact)
+			added++
+		}
+		s.logger.Info("previous scan loaded", "file", file, "scan_id", previous.ID, "artifacts", added)
+	}
+
+	s.logger.Info("previousscan completed",
+		"files", len(s.files),
+		"artifacts", len(result.Artifacts),
+	)
+	return result, nil
+}
+
+// reinject decide si un artifact del escaneo anterior vuelve a entrar. Los
+// que ya eran solo históricos allí no se arrastran otra vez (un activo se
+// reinyecta durante un escaneo tras su última confirmación) y los dominios
+// se comprueban contra el scope actual, que puede haber cambiado.
+func (s *Source) reinject(a *domain.Artifact, target *domain.Target) bool {
+	if a.IsHistoricalOnly() {
+		return false
+	}
+	switch a.Type {
+	case domain.ArtifactTypeDomain, domain.ArtifactTypeSubdomain:
+		return target.IsInScope(a.Value)
+	case domain.ArtifactTypeURL:
+		u, err := url.Parse(a.Value)
+		if err != nil || u.Hostname() == "" {
+			return false
+		}
+		return net.ParseIP(u.Hostname()) != nil || target.IsInScope(u.Hostname())
+	case domain.ArtifactTypeIP, domain.ArtifactTypeIPv6:
+		return true
+	}
+	return false
+}
+
+// IsScanJSON indica si head (el inicio de un fichero) es un JSON consolidado
+// de AethonX. --import lo usa para enviar cada fichero a su source.
+func IsScanJSON(head []byte) bool {
+	head = bytes.TrimSpace(head)
+	return bytes.HasPrefix(head, []byte("{")) && bytes.Contains(head, []byte(`"schema_version"`))
+}
@@ -0,0 +1,109 @@
+package previousscan
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+	"aethonx/internal/testutil/sourcetest"
+)
+
+// previousScan es un escaneo anterior de example.com con un activo que ya
+// solo era histórico y otro que hoy queda fuera de scope.
+func previousScan() *domain.ScanResult {
+	result := domain.NewScanResult(*domain.NewTarget("example.com", domain.ScanModePassive))
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "crtsh"))
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "legacy.example.com", "crtsh"))
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeURL, "https://api.example.com/login", "httpx"))
+	result.AddArtifact(domain.NewArtifact(domain.ArtifactTypeIP, "192.0.2.10", "dnsx"))
+
+	ghost := domain.NewArtifact(domain.ArtifactTypeSubdomain, "old.example.com", sourceName)
+	ghost.AddTag(domain.TagHistorical)
+	result.AddArtifact(ghost)
+	return result
+}
+
+// fakeLoader sirve los escaneos por ruta.
+func fakeLoader(scans map[string]*domain.ScanResult) Loader {
+	return func(path string, types []domain.ArtifactType) (*domain.ScanResult, error) {
+		if scan, ok := scans[path]; ok {
+			return scan, nil
+		}
+		return nil, fmt.Errorf("open %s: no such file", path)
+	}
+}
+
+func TestPreviousScan_ReinjectsAsHistorical(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	target.Scope.ExcludeDomains = []string{"legacy.example.com"}
+
+	s := New(logx.NewSilent(), []string{"prev.json"}, fakeLoader(map[string]*domain.ScanResult{"prev.json": previousScan()}))
+	result, err := s.Run(context.Background(), *target)
+	testutil.AssertNoError(t, err, "run")
+	testutil.AssertEqual(t, len(result.Errors), 0, "load errors")
+
+	sourcetest.AssertArtifacts(t, result.Artifacts,
+		"subdomain api.example.com",
+		"url https://api.example.com/login",
+		"ip 192.0.2.10",
+	)
+	testutil.AssertEqual(t, len(result.Artifacts), 3, "excluded and historical-only artifacts dropped")
+	for _, a := range result.Artifacts {
+		testutil.AssertTrue(t, a.IsHistoricalOnly(), "tagged historical: "+a.Key())
+		testutil.AssertEqual(t, a.Confidence, defaultConfidence, "reduced confidence")
+		testutil.AssertEqual(t, len(a.Relations), 0, "relations not copied")
+	}
+}
+
+func TestPreviousScan_ConfirmedBySource(t *testing.T) {
+	s := New(logx.NewSilent(), []string{"prev.json"}, fakeLoader(map[string]*domain.ScanResult{"prev.json": previousScan()}))
+	result, err := s.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModePassive))
+	testutil.AssertNoError(t, err, "run")
+
+	// Otra source vuelve a encontrar api.example.com y la deduplicación los fusiona
+	for _, a := range result.Artifacts {
+		if a.Value == "api.example.com" {
+			testutil.AssertNoError(t, a.Merge(domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "crtsh")), "merge")
+			testutil.AssertFalse(t, a.IsHistoricalOnly(), "confirmed artifact is no longer historical-only")
+			testutil.AssertEqual(t, a.Confidence, 1.0, "confirmed confidence")
+		}
+	}
+}
+
+func TestPreviousScan_WrongTargetAndMissingFile(t *testing.T) {
+	other := domain.NewScanResult(*domain.NewTarget("other.org", domain.ScanModePassive))
+	other.AddArtifact(domain.NewArtifact(domain.ArtifactTypeSubdomain, "www.other.org", "crtsh"))
+
+	s := New(logx.NewSilent(), []string{"other.json", "missing.json", "prev.json"}, fakeLoader(map[string]*domain.ScanResult{
+		"other.json": other,
+		"prev.json":  previousScan(),
+	}))
+	result, err := s.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModePassive))
+	testutil.AssertNoError(t, err, "run")
+	testutil.AssertEqual(t, len(result.Errors), 2, "one error per bad file")
+	sourcetest.AssertArtifacts(t, result.Artifacts, "subdomain api.example.com")
+}
+
+func TestFactory_Validation(t *testing.T) {
+	SetLoader(fakeLoader(nil))
+
+	if _, err := factory(ports.SourceConfig{Custom: map[string]interface{}{}}, logx.NewSilent()); err == nil {
+		t.Error("expected an error without files")
+	}
+	if _, err := factory(ports.SourceConfig{Custom: map[string]interface{}{"files": []string{"a.json"}, "confidence": 1.5}}, logx.NewSilent()); err == nil {
+		t.Error("expected an error for confidence > 1")
+	}
+	if _, err := factory(ports.SourceConfig{Custom: map[string]interface{}{"files": []string{"a.json"}}}, logx.NewSilent()); err != nil {
+		t.Errorf("factory() failed: %v", err)
+	}
+}
+
+func TestIsScanJSON(t *testing.T) {
+	testutil.AssertTrue(t, IsScanJSON([]byte("{\n  \"schema_version\": \"1.0\",\n  \"ID\": \"scan\"")), "scan JSON detected")
+	testutil.AssertFalse(t, IsScanJSON([]byte(`<?xml version="1.0"?><nmaprun>`)), "nmap XML is not a scan")
+	testutil.AssertFalse(t, IsScanJSON([]byte(`{"version": 1, "scan_id": "x"}`)), "a manifest is not a scan")
+}
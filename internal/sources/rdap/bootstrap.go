@@ -0,0 +1,304 @@
+package rdap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"aethonx/internal/platform/errors"
+	"aethonx/internal/platform/resilience"
+)
+
+const (
+	// IANA bootstrap registry of the RDAP servers of each TLD (RFC 9224)
+	ianaBootstrapURL = "https://data.iana.org/rdap/dns.json"
+
+	// The bootstrap file changes rarely; it is fetched once per day
+	bootstrapTTL = 24 * time.Hour
+
+	// Per-server circuit breaker: after breakerThreshold consecutive
+	// failures the server is skipped for breakerTimeout
+	breakerThreshold = 3
+	breakerTimeout   = 5 * time.Minute
+)
+
+// bootstrapRegistry maps TLDs (and multi-label suffixes) to the RDAP base
+// URLs of their registries, as published by IANA in dns.json.
+type bootstrapRegistry struct {
+	Publication string
+	services    map[string][]string // lowercase TLD -> base URLs (ending in "/")
+}
+
+// parseBootstrap parses an IANA DNS bootstrap file:
+// {"services": [[["com", "net"], ["https://rdap.verisign.com/com/v1/"]], ...]}
+func parseBootstrap(data []byte) (*bootstrapRegistry, error) {
+	var file struct {
+		Publication string       `json:"publication"`
+		Services    [][][]string `json:"services"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid RDAP bootstrap file: %w", err)
+	}
+
+	b := &bootstrapRegistry{Publication: file.Publication, services: make(map[string][]string)}
+	for _, service := range file.Services {
+		if len(service) < 2 {
+			continue
+		}
+		servers := make([]string, 0, len(service[1]))
+		for _, server := range service[1] {
+			if server = strings.TrimSpace(server); server != "" {
+				servers = append(servers, withSlash(server))
+			}
+		}
+		// HTTPS first (RFC 9224 §3: clients should prefer it)
+		sort.SliceStable(servers, func(i, j int) bool {
+			return strings.HasPrefix(servers[i], "https://") && !strings.HasPrefix(servers[j], "https://")
+		})
+		for _, tld := range service[0] {
+			tld = strings.Trim(strings.ToLower(tld), ".")
+			if tld != "" && len(servers) > 0 {
+				b.services[tld] = servers
+			}
+		}
+	}
+	if len(b.services) == 0 {
+		return nil, fmt.Errorf("RDAP bootstrap file has no services")
+	}
+	return b, nil
+}
+
+// lookup returns the registry servers of domainName, matching the longest
+// suffix in the bootstrap file (e.g. "co.uk" before "uk").
+func (b *bootstrapRegistry) lookup(domainName string) []string {
+	labels := strings.Split(strings.Trim(strings.ToLower(domainName), "."), ".")
+	for i := 1; i < len(labels); i++ {
+		if servers, ok := b.services[strings.Join(labels[i:], ".")]; ok {
+			return servers
+		}
+	}
+	if len(labels) == 1 {
+		return b.services[labels[0]]
+	}
+	return nil
+}
+
+// loadBootstrap returns the bootstrap registry: the configured local file or
+// the IANA one, cached for bootstrapTTL. nil means it is unavailable and only
+// the fallback service is queried.
+func (r *RDAP) loadBootstrap(ctx context.Context) *bootstrapRegistry {
+	if !r.bootstrap {
+		return nil
+	}
+	source := r.bootstrapFile
+	if source == "" {
+		source = r.bootstrapURL
+	}
+	cacheKey := "rdap:bootstrap:" + source
+	if cached, found := r.cache.Get(cacheKey); found {
+		if b, ok := cached.(*bootstrapRegistry); ok {
+			return b
+		}
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if r.bootstrapFile != "" {
+		data, err = os.ReadFile(r.bootstrapFile)
+	} else {
+		data, err = r.client.FetchJSON(ctx, r.bootstrapURL)
+	}
+	var b *bootstrapRegistry
+	if err == nil {
+		b, err = parseBootstrap(data)
+	}
+	if err != nil {
+		r.logger.Warn("RDAP bootstrap unavailable, using the fallback service only",
+			"bootstrap", source,
+			"fallback", r.baseURL,
+			"error", err.Error(),
+		)
+		return nil
+	}
+
+	r.cache.Set(cacheKey, b, bootstrapTTL)
+	r.logger.Debug("RDAP bootstrap loaded", "bootstrap", source, "tlds", len(b.services), "publication", b.Publication)
+	return b
+}
+
+// servers returns the fallback chain for domainName: the registry servers
+// from the bootstrap file, then the fallback service (rdap.org).
+func (r *RDAP) servers(ctx context.Context, domainName string) []string {
+	var servers []string
+	if b := r.loadBootstrap(ctx); b != nil {
+		servers = append(servers, b.lookup(domainName)...)
+	}
+	if r.baseURL != "" && !containsServer(servers, r.baseURL) {
+		servers = append(servers, r.baseURL)
+	}
+	return servers
+}
+
+// query walks the fallback chain until a server answers. A "not found" is
+// authoritative and ends the chain; rate limits, server errors and servers
+// with an open circuit move on to the next one. It returns the server that
+// answered.
+func (r *RDAP) query(ctx context.Context, domainName string) (*rdapResponse, string, error) {
+	servers := r.servers(ctx, domainName)
+	if len(servers) == 0 {
+		return nil, "", errors.Wrapf(errors.ErrNotFound, "no RDAP server known for %s", domainName)
+	}
+
+	var lastErr error
+	for _, server := range servers {
+		breaker := serverBreaker(server)
+		if !breaker.Allow() {
+			r.logger.Debug("RDAP server circuit open, skipping", "server", server)
+			lastErr = fmt.Errorf("circuit breaker open for %s: %w", server, resilience.ErrCircuitOpen)
+			continue
+		}
+
+		data, err := r.queryRDAP(ctx, server, domainName)
+		if err == nil || errors.IsNotFound(err) {
+			breaker.RecordSuccess()
+			return data, server, err
+		}
+		breaker.RecordFailure()
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+		r.logger.Debug("RDAP server failed, trying the next one", "server", server, "error", err.Error())
+	}
+	return nil, "", lastErr
+}
+
+// followRegistrar queries the registrar's RDAP server linked from a thin
+// registry answer (e.g. .com) and merges its contacts. Failures keep the
+// registry answer.
+func (r *RDAP) followRegistrar(ctx context.Context, data *rdapResponse, registryServer string) *rdapResponse {
+	if !r.registrar {
+		return data
+	}
+	href := registrarLink(data, registryServer)
+	if href == "" {
+		return data
+	}
+
+	server := href[:strings.Index(href, "/domain/")+1]
+	breaker := serverBreaker(server)
+	if !breaker.Allow() {
+		return data
+	}
+	registrarData, err := r.fetch(ctx, href)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			breaker.RecordFailure()
+		}
+		r.logger.Debug("registrar RDAP query failed", "url", href, "error", err.Error())
+		return data
+	}
+	breaker.RecordSuccess()
+	r.logger.Debug("registrar RDAP data merged", "url", href)
+	return mergeRegistrar(data, registrarData)
+}
+
+// registrarLink returns the registrar RDAP URL of a registry answer: a
+// "related" link to a domain object on another server.
+func registrarLink(data *rdapResponse, registryServer string) string {
+	registryHost := hostOf(registryServer)
+	for _, link := range data.Links {
+		if !strings.EqualFold(link.Rel, "related") || !strings.Contains(link.Href, "/domain/") {
+			continue
+		}
+		if link.Type != "" && !strings.Contains(strings.ToLower(link.Type), "rdap") {
+			continue
+		}
+		if host := hostOf(link.Href); host != "" && host != registryHost {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+// mergeRegistrar adds to the registry answer the registrar's entities of
+// roles the registry doesn't have (registrant, admin, tech, abuse...), and
+// its events and nameservers if the registry has none.
+func mergeRegistrar(registry, registrar *rdapResponse) *rdapResponse {
+	merged := *registry
+	merged.Entities = append([]rdapEntity(nil), registry.Entities...)
+
+	roles := make(map[string]bool)
+	for _, entity := range registry.Entities {
+		for _, role := range entity.Roles {
+			roles[strings.ToLower(role)] = true
+		}
+	}
+	for _, entity := range registrar.Entities {
+		for _, role := range entity.Roles {
+			if !roles[strings.ToLower(role)] {
+				merged.Entities = append(merged.Entities, entity)
+				break
+			}
+		}
+	}
+
+	if len(merged.Events) == 0 {
+		merged.Events = registrar.Events
+	}
+	if len(merged.Nameservers) == 0 {
+		merged.Nameservers = registrar.Nameservers
+	}
+	return &merged
+}
+
+var (
+	breakersMu sync.Mutex
+	breakers   = make(map[string]*resilience.CircuitBreaker)
+)
+
+// serverBreaker returns the process-wide circuit breaker of an RDAP server,
+// shared by every scan so a failing registry is skipped by all of them.
+func serverBreaker(server string) *resilience.CircuitBreaker {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	cb, ok := breakers[server]
+	if !ok {
+		cb = resilience.NewCircuitBreaker(breakerThreshold, breakerTimeout, 1)
+		breakers[server] = cb
+	}
+	return cb
+}
+
+func containsServer(servers []string, server string) bool {
+	for _, s := range servers {
+		if strings.EqualFold(s, server) {
+			return true
+		}
+	}
+	return false
+}
+
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Host)
+}
+
+func withSlash(server string) string {
+	if !strings.HasSuffix(server, "/") {
+		return server + "/"
+	}
+	return server
+}
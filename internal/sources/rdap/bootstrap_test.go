@@ -0,0 +1,166 @@
+package rdap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/httpclient"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+	"aethonx/internal/sources/common"
+	"aethonx/internal/testutil"
+	"aethonx/internal/testutil/sourcetest"
+)
+
+func TestParseBootstrap(t *testing.T) {
+	b, err := parseBootstrap([]byte(`{
+  "publication": "2024-01-01T00:00:00Z",
+  "services": [
+    [["com", "net"], ["http://rdap.verisign.test/com/v1", "https://rdap.verisign.test/com/v1/"]],
+    [["uk"], ["https://rdap.nominet.test/uk/"]],
+    [["co.uk"], ["https://rdap.co-uk.test/"]],
+    [["empty"], []]
+  ]
+}`))
+	testutil.AssertNoError(t, err, "parse bootstrap")
+
+	tests := map[string]string{
+		"example.com":    "https://rdap.verisign.test/com/v1/,http://rdap.verisign.test/com/v1/",
+		"Example.NET.":   "https://rdap.verisign.test/com/v1/,http://rdap.verisign.test/com/v1/",
+		"example.co.uk":  "https://rdap.co-uk.test/",
+		"example.org.uk": "https://rdap.nominet.test/uk/",
+		"example.org":    "",
+		"example.empty":  "",
+	}
+	for name, want := range tests {
+		testutil.AssertEqual(t, strings.Join(b.lookup(name), ","), want, "servers of "+name)
+	}
+
+	_, err = parseBootstrap([]byte(`{"services": []}`))
+	testutil.AssertError(t, err, "a bootstrap file without services")
+}
+
+// writeBootstrap writes a bootstrap file mapping "com" to servers.
+func writeBootstrap(t *testing.T, servers ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "dns.json")
+	content := fmt.Sprintf(`{"services": [[["com"], ["%s"]]]}`, strings.Join(servers, `", "`))
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestRDAP_BootstrapFallbackChain queries the registry server from the
+// bootstrap file and falls back to the next server when it fails; after
+// breakerThreshold failures its circuit opens and it is skipped.
+func TestRDAP_BootstrapFallbackChain(t *testing.T) {
+	var registryHits int32
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&registryHits, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	fallback := sourcetest.NewRDAPServer(t)
+	fallback.Set("example.com", sourcetest.Fixture(t, "testdata/example.com.json"))
+
+	options := Options{
+		BaseURL:       fallback.BaseURL(),
+		Bootstrap:     true,
+		BootstrapFile: writeBootstrap(t, failing.URL+"/"),
+	}
+	client := httpclient.New(httpclient.Config{MaxRetries: 0}, logx.NewSilent())
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+
+	for i := 0; i < breakerThreshold+1; i++ {
+		source := NewWithOptions(logx.NewSilent(), options, common.WithHTTPClient(client))
+		result, err := source.Run(context.Background(), target)
+		testutil.AssertNoError(t, err, "fallback answers")
+		sourcetest.AssertArtifacts(t, result.Artifacts, "domain example.com")
+		testutil.AssertEqual(t, result.Metadata.Environment["rdap_server"], fallback.BaseURL(), "answered by the fallback")
+		source.Close()
+	}
+	testutil.AssertEqual(t, int(atomic.LoadInt32(&registryHits)), breakerThreshold, "failing registry skipped once its circuit opens")
+}
+
+// TestRDAP_RegistryNotFoundIsAuthoritative doesn't ask the fallback for a
+// domain the registry doesn't know.
+func TestRDAP_RegistryNotFoundIsAuthoritative(t *testing.T) {
+	registryServer := sourcetest.NewRDAPServer(t)
+	fallback := sourcetest.NewRDAPServer(t)
+	fallback.Set("gone.com", sourcetest.Fixture(t, "testdata/example.com.json"))
+
+	source := NewWithOptions(logx.NewSilent(), Options{
+		BaseURL:       fallback.BaseURL(),
+		Bootstrap:     true,
+		BootstrapFile: writeBootstrap(t, registryServer.BaseURL()),
+	}, common.WithHTTPClient(httpclient.New(httpclient.Config{MaxRetries: 0}, logx.NewSilent())))
+	defer source.Close()
+
+	_, err := source.Run(context.Background(), *domain.NewTarget("gone.com", domain.ScanModePassive))
+	testutil.AssertError(t, err, "registry 404")
+	testutil.AssertContains(t, err.Error(), "not found", "not found error")
+	testutil.AssertEqual(t, len(fallback.Queries()), 0, "fallback not queried")
+}
+
+// TestRDAP_FollowRegistrar merges the registrant contact published by the
+// registrar server linked from a thin registry answer.
+func TestRDAP_FollowRegistrar(t *testing.T) {
+	registrar := sourcetest.NewRDAPServer(t)
+	registrar.Set("example.com", []byte(`{
+  "objectClassName": "domain",
+  "ldhName": "example.com",
+  "entities": [
+    {"roles": ["registrar"], "vcardArray": ["vcard", [["fn", {}, "text", "Registrar Inc"]]]},
+    {"roles": ["registrant"], "vcardArray": ["vcard", [["fn", {}, "text", "Example Org"], ["email", {}, "text", "owner@example.com"]]]}
+  ]
+}`))
+
+	registryServer := sourcetest.NewRDAPServer(t)
+	registryServer.Set("example.com", []byte(`{
+  "objectClassName": "domain",
+  "ldhName": "EXAMPLE.COM",
+  "entities": [{"roles": ["registrar"], "vcardArray": ["vcard", [["fn", {}, "text", "Registrar Inc"]]]}],
+  "nameservers": [{"ldhName": "ns1.example.com"}],
+  "links": [
+    {"rel": "self", "href": "`+registryServer.BaseURL()+`domain/example.com", "type": "application/rdap+json"},
+    {"rel": "related", "href": "`+registrar.BaseURL()+`domain/example.com", "type": "application/rdap+json"}
+  ]
+}`))
+
+	source := NewWithOptions(logx.NewSilent(), Options{
+		Bootstrap:       true,
+		BootstrapFile:   writeBootstrap(t, registryServer.BaseURL()),
+		FollowRegistrar: true,
+	}, common.WithHTTPClient(httpclient.New(httpclient.Config{MaxRetries: 0}, logx.NewSilent())))
+	defer source.Close()
+
+	result, err := source.Run(context.Background(), *domain.NewTarget("example.com", domain.ScanModePassive))
+	testutil.AssertNoError(t, err, "registry answer")
+	sourcetest.AssertArtifacts(t, result.Artifacts, "domain example.com", "nameserver ns1.example.com", "email owner@example.com")
+	testutil.AssertEqual(t, strings.Join(registrar.Queries(), ","), "example.com", "registrar queried")
+}
+
+func TestFactory_ServerOptions(t *testing.T) {
+	src, err := registry.Global().BuildSource("rdap", ports.SourceConfig{Custom: map[string]interface{}{
+		"base_url":       "none",
+		"bootstrap_file": "/opt/iana/dns.json",
+	}}, logx.NewSilent())
+	testutil.AssertNoError(t, err, "build rdap")
+	defer src.Close()
+
+	r := src.(*RDAP)
+	testutil.AssertEqual(t, r.baseURL, "", "base_url none disables the fallback")
+	testutil.AssertEqual(t, r.bootstrapFile, "/opt/iana/dns.json", "bootstrap file")
+	testutil.AssertTrue(t, r.bootstrap && r.registrar, "bootstrap and registrar links on by default")
+}
@@ -20,14 +20,36 @@ import (
 	"aethonx/internal/platform/httpclient"
 	"aethonx/internal/platform/logx"
 	"aethonx/internal/platform/registry"
+	"aethonx/internal/sources/common"
 )
 
+// configSchema declares the RDAP Custom options.
+var configSchema = []ports.ConfigField{
+	{Name: "bootstrap", Type: ports.ConfigTypeBool, Default: true, Description: "Query the registry RDAP servers listed in the IANA bootstrap file before the fallback service"},
+	{Name: "bootstrap_file", Type: ports.ConfigTypeString, Description: "Local copy of the IANA dns.json bootstrap file (default: fetched from IANA once per day)"},
+	{Name: "base_url", Type: ports.ConfigTypeString, Default: defaultBaseURL, Description: "Fallback RDAP service, queried when the registry servers fail or are unknown (\"none\" = no fallback)"},
+	{Name: "follow_registrar", Type: ports.ConfigTypeBool, Default: true, Description: "Follow the registry link to the registrar RDAP server for contacts (thin registries such as .com)"},
+}
+
 // Auto-registro de la source al importar el package
 func init() {
 	if err := registry.Global().Register(
 		"rdap",
 		func(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
-			return New(logger), nil
+			opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+			if err != nil {
+				return nil, fmt.Errorf("rdap config: %w", err)
+			}
+			baseURL := opts.String("base_url")
+			if strings.EqualFold(baseURL, "none") {
+				baseURL = ""
+			}
+			return NewWithOptions(logger, Options{
+				BaseURL:         baseURL,
+				Bootstrap:       opts.Bool("bootstrap"),
+				BootstrapFile:   opts.String("bootstrap_file"),
+				FollowRegistrar: opts.Bool("follow_registrar"),
+			}), nil
 		},
 		ports.SourceMetadata{
 			Name:         "rdap",
@@ -47,8 +69,11 @@ func init() {
 				domain.ArtifactTypeNameserver,
 				domain.ArtifactTypeWhoisContact,
 			},
-			Priority:  8, // Alta prioridad (passive discovery)
-			StageHint: 0, // Stage 0 explícito
+			Priority:     8, // Alta prioridad (passive discovery)
+			StageHint:    0, // Stage 0 explícito
+			Phase:        ports.PhaseEnrichment,
+			ConfigSchema: configSchema,
+			Providers:    []string{"rdap.org"}, // Upstream budget of every RDAP server
 		},
 	); err != nil {
 		// Log error but don't panic - allow application to start
@@ -59,7 +84,7 @@ func init() {
 
 const (
 	// RDAP bootstrap service for automatic server discovery
-	rdapBootstrapURL = "https://rdap.org/domain/%s"
+	defaultBaseURL = "https://rdap.org/"
 
 	// Cache TTL for RDAP responses (24 hours)
 	cacheTTL = 24 * time.Hour
@@ -70,11 +95,16 @@ const (
 
 // RDAP implements the ports.Source interface for RDAP queries
 type RDAP struct {
-	client      httpclient.Client
-	cache       cache.Cache
-	logger      logx.Logger
-	stopCleanup func() // Función para detener el cache cleanup worker
-	progressCh  chan ports.ProgressUpdate
+	client        httpclient.Client
+	baseURL       string // Fallback RDAP service queried as <baseURL>domain/<name> ("" = none)
+	bootstrap     bool   // Query the registry servers of the IANA bootstrap file first
+	bootstrapFile string // Local bootstrap file ("" = bootstrapURL)
+	bootstrapURL  string
+	registrar     bool // Follow the link to the registrar RDAP server
+	cache         cache.Cache
+	logger        logx.Logger
+	stopCleanup   func() // Función para detener el cache cleanup worker
+	progressCh    chan ports.ProgressUpdate
 }
 
 // rdapResponse representa la respuesta de RDAP (simplificada)
@@ -141,37 +171,98 @@ type rdapLink struct {
 	Type  string `json:"type"`
 }
 
-// New creates a new RDAP source
-func New(logger logx.Logger) ports.Source {
-	// Create HTTP client with retry and rate limiting
-	httpConfig := httpclient.Config{
-		Timeout:         30 * time.Second,
-		MaxRetries:      3,
-		RetryBackoff:    1 * time.Second,
-		MaxRetryBackoff: 10 * time.Second,
-		UserAgent:       "AethonX/1.0 RDAP Client",
-		RateLimit:       5, // 5 requests per second
-		RateLimitBurst:  2,
+// Options selects the RDAP servers queried for a domain.
+type Options struct {
+	// BaseURL is the fallback RDAP service ("" = none)
+	BaseURL string
+
+	// Bootstrap queries the registry servers of the IANA bootstrap file
+	// (BootstrapFile, or BootstrapURL fetched once per day) before BaseURL
+	Bootstrap     bool
+	BootstrapFile string
+	BootstrapURL  string // Default: the IANA dns.json
+
+	// FollowRegistrar merges the contacts of the registrar RDAP server
+	FollowRegistrar bool
+}
+
+// DefaultOptions queries the registry servers from the IANA bootstrap file,
+// falls back to rdap.org and follows registrar links.
+func DefaultOptions() Options {
+	return Options{
+		BaseURL:         defaultBaseURL,
+		Bootstrap:       true,
+		BootstrapURL:    ianaBootstrapURL,
+		FollowRegistrar: true,
 	}
+}
+
+// New creates a new RDAP source with DefaultOptions. opts inject the HTTP
+// client and cache; by default it builds its own.
+func New(logger logx.Logger, opts ...common.Option) ports.Source {
+	return NewWithOptions(logger, DefaultOptions(), opts...)
+}
 
-	// Create cache
-	rdapCache := cache.NewMemoryCache(1000) // Cache up to 1000 domains
+// NewWithBaseURL creates an RDAP source that only queries baseURL, without
+// bootstrap nor registrar links (e.g., a local RDAP server or a test fake).
+func NewWithBaseURL(logger logx.Logger, baseURL string, opts ...common.Option) ports.Source {
+	return NewWithOptions(logger, Options{BaseURL: baseURL}, opts...)
+}
+
+// NewWithOptions creates an RDAP source with the given server options.
+func NewWithOptions(logger logx.Logger, options Options, opts ...common.Option) ports.Source {
+	if options.BaseURL != "" {
+		options.BaseURL = withSlash(options.BaseURL)
+	}
+	if options.BootstrapURL == "" {
+		options.BootstrapURL = ianaBootstrapURL
+	}
+	deps := common.ApplyOptions(opts...)
 
 	// Create RDAP instance
 	r := &RDAP{
-		client:     *httpclient.New(httpConfig, logger),
-		cache:      rdapCache,
-		logger:     logger.With("source", sourceName),
-		progressCh: make(chan ports.ProgressUpdate, 10), // Buffered channel
+		baseURL:       options.BaseURL,
+		bootstrap:     options.Bootstrap,
+		bootstrapFile: options.BootstrapFile,
+		bootstrapURL:  options.BootstrapURL,
+		registrar:     options.FollowRegistrar,
+		cache:         deps.Cache,
+		logger:        logger.With("source", sourceName),
+		progressCh:    make(chan ports.ProgressUpdate, 10), // Buffered channel
 	}
 
-	// Iniciar cleanup worker (limpieza cada 1 hora)
-	r.stopCleanup = rdapCache.StartCleanupWorker(1 * time.Hour)
-	r.logger.Debug("cache cleanup worker started", "interval", "1h")
+	// Create HTTP client with retry and rate limiting
+	if deps.HTTPClient != nil {
+		r.client = *deps.HTTPClient
+	} else {
+		r.client = *httpclient.New(defaultHTTPConfig, logger)
+	}
+
+	// Private cache (an injected one is cleaned by its owner)
+	if r.cache == nil {
+		rdapCache := cache.NewMemoryCache(1000) // Cache up to 1000 domains
+		r.cache = rdapCache
+
+		// Iniciar cleanup worker (limpieza cada 1 hora)
+		r.stopCleanup = rdapCache.StartCleanupWorker(1 * time.Hour)
+		r.logger.Debug("cache cleanup worker started", "interval", "1h")
+	}
 
 	return r
 }
 
+// defaultHTTPConfig is the HTTP client built when none is injected.
+var defaultHTTPConfig = httpclient.Config{
+	Timeout:         30 * time.Second,
+	MaxRetries:      3,
+	RetryBackoff:    1 * time.Second,
+	MaxRetryBackoff: 10 * time.Second,
+	UserAgent:       "AethonX/1.0 RDAP Client",
+	RateLimit:       5, // 5 requests per second
+	RateLimitBurst:  2,
+	Upstream:        "rdap.org", // Presupuesto compartido entre scans concurrentes
+}
+
 // Name implements ports.Source
 func (r *RDAP) Name() string {
 	return sourceName
@@ -197,7 +288,7 @@ func (r *RDAP) Run(ctx context.Context, target domain.Target) (*domain.ScanResul
 	)
 
 	// Extract base domain from target
-	domainName := r.extractBaseDomain(target.Root)
+	domainName := r.extractBaseDomain(target.QueryName())
 	if domainName == "" {
 		return result, errors.New("invalid target: could not extract domain name")
 	}
@@ -212,8 +303,8 @@ func (r *RDAP) Run(ctx context.Context, target domain.Target) (*domain.ScanResul
 		}
 	}
 
-	// Query RDAP server
-	rdapData, err := r.queryRDAP(ctx, domainName)
+	// Query the registry servers, then the fallback service
+	rdapData, server, err := r.query(ctx, domainName)
 	if err != nil {
 		r.logger.Warn("RDAP query failed",
 			"domain", domainName,
@@ -221,9 +312,12 @@ func (r *RDAP) Run(ctx context.Context, target domain.Target) (*domain.ScanResul
 		)
 		return result, errors.Wrapf(err, "RDAP query failed for %s", domainName)
 	}
+	rdapData = r.followRegistrar(ctx, rdapData, server)
 
 	// Extract artifacts from RDAP response
 	r.extractArtifacts(result, rdapData, domainName)
+	result.SetProvenanceQuery(r.Name(), server+"domain/"+domainName)
+	result.Metadata.Environment["rdap_server"] = server
 
 	// Cache result
 	r.cache.Set(cacheKey, result, cacheTTL)
@@ -236,21 +330,32 @@ func (r *RDAP) Run(ctx context.Context, target domain.Target) (*domain.ScanResul
 	return result, nil
 }
 
-// queryRDAP performs the RDAP query
-func (r *RDAP) queryRDAP(ctx context.Context, domain string) (*rdapResponse, error) {
-	// Use rdap.org bootstrap service for automatic server discovery
-	url := fmt.Sprintf(rdapBootstrapURL, domain)
+// queryRDAP queries the RDAP server for domain
+func (r *RDAP) queryRDAP(ctx context.Context, server, domain string) (*rdapResponse, error) {
+	url := server + "domain/" + domain
 
 	r.logger.Debug("Querying RDAP server",
 		"domain", domain,
 		"url", url,
 	)
 
+	rdapData, err := r.fetch(ctx, url)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "domain not found in RDAP: %s", domain)
+		}
+		return nil, err
+	}
+	return rdapData, nil
+}
+
+// fetch retrieves and parses an RDAP domain object
+func (r *RDAP) fetch(ctx context.Context, url string) (*rdapResponse, error) {
 	// Fetch JSON response
 	body, err := r.client.FetchJSON(ctx, url)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			return nil, errors.Wrapf(err, "domain not found in RDAP: %s", domain)
+			return nil, err
 		}
 		if errors.IsRateLimit(err) {
 			return nil, errors.Wrap(err, "RDAP rate limit exceeded")
@@ -261,7 +366,7 @@ func (r *RDAP) queryRDAP(ctx context.Context, domain string) (*rdapResponse, err
 	// Parse response
 	var rdapData rdapResponse
 	if err := json.Unmarshal(body, &rdapData); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse RDAP response for %s", domain)
+		return nil, errors.Wrapf(err, "failed to parse RDAP response from %s", url)
 	}
 
 	return &rdapData, nil
@@ -625,6 +730,10 @@ func (r *RDAP) Close() error {
 	return nil
 }
 
+// SetLogger sustituye el logger por el del escaneo (scan_id, stage, source).
+// Implementa ports.LogScopedSource.
+func (r *RDAP) SetLogger(logger logx.Logger) { r.logger = logger }
+
 // extractBaseDomain extracts the base domain (eTLD+1) from a target value.
 // Handles complex TLDs like .co.uk, .com.br using the Public Suffix List.
 //
@@ -5,12 +5,17 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"aethonx/internal/core/domain"
 	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/platform/cache"
+	"aethonx/internal/platform/httpclient"
 	"aethonx/internal/platform/logx"
+	"aethonx/internal/sources/common"
 	"aethonx/internal/testutil"
+	"aethonx/internal/testutil/sourcetest"
 )
 
 func TestNew(t *testing.T) {
@@ -615,3 +620,55 @@ func TestExtractBaseDomain(t *testing.T) {
 		})
 	}
 }
+
+// TestExtractArtifacts_Golden runs the extraction over a recorded RDAP payload
+func TestExtractArtifacts_Golden(t *testing.T) {
+	source := New(logx.NewSilent()).(*RDAP)
+	defer source.Close()
+
+	var payload rdapResponse
+	if err := json.Unmarshal(sourcetest.Fixture(t, "testdata/example.com.json"), &payload); err != nil {
+		t.Fatalf("invalid fixture: %v", err)
+	}
+
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result := domain.NewScanResult(*target)
+	source.extractArtifacts(result, &payload, "example.com")
+
+	sourcetest.Golden(t, "testdata/example.com.golden", result.Artifacts)
+}
+
+// TestRDAP_RunAgainstFakeUpstream runs the source against a fake RDAP service
+func TestRDAP_RunAgainstFakeUpstream(t *testing.T) {
+	server := sourcetest.NewRDAPServer(t)
+	server.Set("example.com", sourcetest.Fixture(t, "testdata/example.com.json"))
+
+	source := NewWithBaseURL(logx.NewSilent(), server.BaseURL())
+	defer source.Close()
+
+	result, err := source.Run(context.Background(), *domain.NewTarget("www.example.com", domain.ScanModePassive))
+	testutil.AssertNoError(t, err, "fake upstream query")
+	sourcetest.AssertArtifacts(t, result.Artifacts, "domain example.com", "email hostmaster@example.com")
+	testutil.AssertEqual(t, strings.Join(server.Queries(), ","), "example.com", "base domain queried once")
+}
+
+// TestRDAP_InjectedDependencies shares an injected cache and HTTP client
+// between two sources: the second one answers from the cache
+func TestRDAP_InjectedDependencies(t *testing.T) {
+	server := sourcetest.NewRDAPServer(t)
+	server.Set("example.com", sourcetest.Fixture(t, "testdata/example.com.json"))
+
+	shared := cache.NewMemoryCache(10)
+	client := httpclient.New(httpclient.Config{MaxRetries: 0}, logx.NewSilent())
+	target := *domain.NewTarget("example.com", domain.ScanModePassive)
+
+	for i := 0; i < 2; i++ {
+		source := NewWithBaseURL(logx.NewSilent(), server.BaseURL(),
+			common.WithCache(shared), common.WithHTTPClient(client))
+		result, err := source.Run(context.Background(), target)
+		testutil.AssertNoError(t, err, "run with injected dependencies")
+		sourcetest.AssertArtifacts(t, result.Artifacts, "domain example.com")
+		source.Close()
+	}
+	testutil.AssertEqual(t, len(server.Queries()), 1, "second source answered from the shared cache")
+}
@@ -0,0 +1,144 @@
+// internal/sources/reversewhois/providers.go
+package reversewhois
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"aethonx/internal/platform/errors"
+	"aethonx/internal/platform/httpclient"
+)
+
+const (
+	whoisXMLURL       = "https://reverse-whois.whoisxmlapi.com/api/v2"
+	securityTrailsURL = "https://api.securitytrails.com/v1/domains/list"
+)
+
+// upstreams es el presupuesto compartido (rate.Shared) de cada proveedor.
+var upstreams = map[string]string{
+	"whoisxml":       "reverse-whois.whoisxmlapi.com",
+	"securitytrails": "api.securitytrails.com",
+}
+
+// Tipos de término de búsqueda.
+const (
+	termOrganization = "organization"
+	termEmail        = "email"
+)
+
+// searchTerm es un dato del registrante por el que se pivota.
+type searchTerm struct {
+	kind  string // termOrganization o termEmail
+	value string
+}
+
+// provider busca los dominios registrados con un término del registrante.
+type provider interface {
+	Name() string
+	Search(ctx context.Context, term searchTerm) ([]string, error)
+}
+
+// whoisXMLProvider usa la Reverse WHOIS API v2 de WhoisXML (registros actuales).
+type whoisXMLProvider struct {
+	client *httpclient.Client
+	url    string
+	apiKey string
+}
+
+type whoisXMLRequest struct {
+	APIKey           string `json:"apiKey"`
+	SearchType       string `json:"searchType"`
+	Mode             string `json:"mode"`
+	Punycode         bool   `json:"punycode"`
+	BasicSearchTerms struct {
+		Include []string `json:"include"`
+	} `json:"basicSearchTerms"`
+}
+
+type whoisXMLResponse struct {
+	DomainsCount int      `json:"domainsCount"`
+	DomainsList  []string `json:"domainsList"`
+	Messages     string   `json:"messages"` // Presente solo en errores
+}
+
+func (w *whoisXMLProvider) Name() string { return "whoisxml" }
+
+// Search busca el término en organización, email y demás campos WHOIS.
+func (w *whoisXMLProvider) Search(ctx context.Context, term searchTerm) ([]string, error) {
+	req := whoisXMLRequest{APIKey: w.apiKey, SearchType: "current", Mode: "purchase", Punycode: true}
+	req.BasicSearchTerms.Include = []string{term.value}
+
+	var resp whoisXMLResponse
+	if err := postJSON(ctx, w.client, w.url, nil, req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Messages != "" && len(resp.DomainsList) == 0 {
+		return nil, errors.Errorf("whoisxml: %s", resp.Messages)
+	}
+	return resp.DomainsList, nil
+}
+
+// securityTrailsProvider usa el listado de dominios de SecurityTrails filtrado
+// por whois_organization o whois_email.
+type securityTrailsProvider struct {
+	client *httpclient.Client
+	url    string
+	apiKey string
+}
+
+type securityTrailsResponse struct {
+	Records []struct {
+		Hostname string `json:"hostname"`
+	} `json:"records"`
+}
+
+func (s *securityTrailsProvider) Name() string { return "securitytrails" }
+
+// Search consulta la primera página de resultados.
+func (s *securityTrailsProvider) Search(ctx context.Context, term searchTerm) ([]string, error) {
+	field := "whois_organization"
+	if term.kind == termEmail {
+		field = "whois_email"
+	}
+	body := map[string]map[string]string{"filter": {field: term.value}}
+
+	var resp securityTrailsResponse
+	if err := postJSON(ctx, s.client, s.url+"?include_ips=false", map[string]string{"APIKEY": s.apiKey}, body, &resp); err != nil {
+		return nil, err
+	}
+	domains := make([]string, 0, len(resp.Records))
+	for _, r := range resp.Records {
+		domains = append(domains, r.Hostname)
+	}
+	return domains, nil
+}
+
+// postJSON envía body como JSON y decodifica la respuesta 2xx en out.
+func postJSON(ctx context.Context, client *httpclient.Client, url string, headers map[string]string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	h := map[string]string{"Content-Type": "application/json", "Accept": "application/json"}
+	for k, v := range headers {
+		h[k] = v
+	}
+
+	resp, err := client.Post(ctx, url, bytes.NewReader(payload), h)
+	if err != nil {
+		return err
+	}
+	if err := httpclient.CheckStatus(resp); err != nil {
+		resp.Body.Close()
+		return errors.Wrapf(err, "request to %s failed", url)
+	}
+	data, err := httpclient.ReadBody(resp)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return errors.Wrap(err, "invalid reverse WHOIS response")
+	}
+	return nil
+}
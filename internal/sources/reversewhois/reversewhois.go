@@ -0,0 +1,325 @@
+// internal/sources/reversewhois/reversewhois.go
+package reversewhois
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/httpclient"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+)
+
+const (
+	sourceName        = "reversewhois"
+	defaultProvider   = "whoisxml"
+	defaultMaxTerms   = 5
+	defaultMaxDomains = 500
+	defaultRateLimit  = 1.0
+	requestTimeout    = 60 * time.Second
+)
+
+// configSchema declara las opciones Custom de reversewhois.
+var configSchema = []ports.ConfigField{
+	{Name: "provider", Type: ports.ConfigTypeString, Default: defaultProvider, Description: "Reverse WHOIS provider: whoisxml, securitytrails"},
+	{Name: "api_key", Type: ports.ConfigTypeString, Credential: true, Description: "API key of the provider"},
+	{Name: "max_terms", Type: ports.ConfigTypeInt, Default: defaultMaxTerms, Description: "Max registrant terms searched (each one is a paid query)"},
+	{Name: "max_domains", Type: ports.ConfigTypeInt, Default: defaultMaxDomains, Description: "Max related domains emitted (0 = no limit)"},
+	{Name: "search_emails", Type: ports.ConfigTypeBool, Default: true, Description: "Also pivot on registrant emails, not only the organization"},
+	{Name: "rate_limit", Type: ports.ConfigTypeFloat, Default: defaultRateLimit, Description: "Max requests per second (0 = unlimited)"},
+}
+
+// privacyMarkers identifican organizaciones/emails de servicios de privacidad
+// WHOIS: pivotar sobre ellos devolvería dominios de miles de clientes ajenos.
+var privacyMarkers = []string{
+	"redacted", "privacy", "proxy", "whoisguard", "withheld", "not disclosed",
+	"data protected", "gdpr", "anonymous", "domains by proxy", "contact privacy",
+	"perfect privacy", "identity protect", "private registration",
+}
+
+// Auto-registro de la source al importar el package
+func init() {
+	if err := registry.Global().Register(
+		sourceName,
+		factory,
+		ports.SourceMetadata{
+			Name:         sourceName,
+			Description:  "Other domains of the same registrant via reverse WHOIS (WhoisXML, SecurityTrails)",
+			Version:      "1.0.0",
+			Author:       "AethonX",
+			Mode:         domain.SourceModePassive,
+			Type:         domain.SourceTypeAPI,
+			RequiresAuth: true,
+
+			// Consume la organización/emails del registrante extraídos por rdap
+			InputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeDomain,
+				domain.ArtifactTypeEmail,
+			},
+			OutputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeDomain,
+			},
+			Priority: 6,
+			Phase:    ports.PhaseDiscovery,
+
+			ConfigSchema: configSchema,
+			Providers:    []string{upstreams["whoisxml"], upstreams["securitytrails"]},
+
+			// Sale del target: --no-pivot la deshabilita
+			Pivot: true,
+		},
+	); err != nil {
+		logx.New().Warn("failed to register reversewhois source", "error", err.Error())
+	}
+}
+
+// factory crea la source desde SourceConfig (Custom según configSchema).
+func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("reversewhois config: %w", err)
+	}
+
+	apiKey := opts.String("api_key")
+	if apiKey == "" {
+		return nil, fmt.Errorf("reversewhois requires api_key")
+	}
+	maxTerms := opts.Int("max_terms")
+	if maxTerms <= 0 {
+		return nil, fmt.Errorf("reversewhois max_terms must be positive, got %d", maxTerms)
+	}
+	maxDomains := opts.Int("max_domains")
+	if maxDomains < 0 {
+		return nil, fmt.Errorf("reversewhois max_domains cannot be negative, got %d", maxDomains)
+	}
+	rateLimit := opts.Float("rate_limit")
+	if rateLimit < 0 {
+		return nil, fmt.Errorf("reversewhois rate_limit cannot be negative, got %v", rateLimit)
+	}
+
+	// Sin reintentos: las búsquedas son POST (el cuerpo no se reenvía) y de
+	// pago. El presupuesto es el del upstream, compartido con otras sources
+	name := strings.ToLower(opts.String("provider"))
+	client := httpclient.New(httpclient.Config{
+		Timeout:    requestTimeout,
+		MaxRetries: 0,
+		UserAgent:  "AethonX/1.0",
+		RateLimit:  rateLimit,
+		Upstream:   upstreams[name],
+	}, logger)
+
+	var p provider
+	switch name {
+	case "whoisxml":
+		p = &whoisXMLProvider{client: client, url: whoisXMLURL, apiKey: apiKey}
+	case "securitytrails":
+		p = &securityTrailsProvider{client: client, url: securityTrailsURL, apiKey: apiKey}
+	default:
+		return nil, fmt.Errorf("reversewhois unknown provider %q (valid: whoisxml, securitytrails)", name)
+	}
+
+	source := New(logger, p)
+	source.maxTerms = maxTerms
+	source.maxDomains = maxDomains
+	source.searchEmails = opts.Bool("search_emails")
+	return source, nil
+}
+
+// Source busca, a partir de la organización y los emails del registrante del
+// target (extraídos por rdap), otros dominios registrados por la misma
+// entidad. Los emite como artifacts domain etiquetados "related-org" y
+// enlazados al dominio raíz con same_registrant. Es una source de pivoting:
+// los dominios encontrados quedan fuera del target y --no-pivot la omite.
+type Source struct {
+	provider     provider
+	maxTerms     int
+	maxDomains   int
+	searchEmails bool
+	logger       logx.Logger
+}
+
+// New crea la source reversewhois.
+func New(logger logx.Logger, p provider) *Source {
+	return &Source{
+		provider:     p,
+		maxTerms:     defaultMaxTerms,
+		maxDomains:   defaultMaxDomains,
+		searchEmails: true,
+		logger:       logger.With("source", sourceName),
+	}
+}
+
+// Name retorna el nombre de la fuente.
+func (s *Source) Name() string { return sourceName }
+
+// Providers retorna el upstream del proveedor configurado. Implementa
+// ports.ProviderSource.
+func (s *Source) Providers() []string {
+	if upstream, ok := upstreams[s.provider.Name()]; ok {
+		return []string{upstream}
+	}
+	return nil
+}
+
+// Mode retorna el modo de operación (pasivo: solo APIs de terceros).
+func (s *Source) Mode() domain.SourceMode { return domain.SourceModePassive }
+
+// Type retorna el tipo de fuente (API).
+func (s *Source) Type() domain.SourceType { return domain.SourceTypeAPI }
+
+// Close no libera recursos.
+func (s *Source) Close() error { return nil }
+
+// SetLogger sustituye el logger por el del escaneo (scan_id, stage, source).
+// Implementa ports.LogScopedSource.
+func (s *Source) SetLogger(logger logx.Logger) { s.logger = logger }
+
+// Run no tiene datos del registrante sobre los que pivotar.
+func (s *Source) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	return s.RunWithInput(ctx, target, nil)
+}
+
+// RunWithInput pivota sobre los datos del registrante presentes en input.
+// Implementa ports.InputConsumer.
+func (s *Source) RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+	result := domain.NewScanResult(target)
+	result.Metadata.SourcesUsed = []string{sourceName}
+
+	terms := s.collectTerms(input)
+	if len(terms) == 0 {
+		result.AddWarning(sourceName, "no public registrant organization or email to pivot on (run rdap first; privacy-protected WHOIS is skipped)")
+		return result, nil
+	}
+
+	// Dominio relacionado → términos que lo devolvieron
+	related := make(map[string][]searchTerm)
+	failures := 0
+	for _, term := range terms {
+		if ctx.Err() != nil {
+			break
+		}
+		domains, err := s.provider.Search(ctx, term)
+		if err != nil {
+			failures++
+			s.logger.Warn("reverse whois search failed", "provider", s.provider.Name(), "term", term.value, "error", err.Error())
+			continue
+		}
+		for _, d := range domains {
+			d = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(d), "."))
+			if d == "" || d == target.Root || strings.HasSuffix(d, "."+target.Root) {
+				continue
+			}
+			related[d] = append(related[d], term)
+		}
+	}
+	if failures > 0 {
+		result.AddWarning(sourceName, fmt.Sprintf("%d of %d reverse WHOIS searches failed", failures, len(terms)))
+	}
+
+	names := make([]string, 0, len(related))
+	for d := range related {
+		names = append(names, d)
+	}
+	sort.Strings(names)
+	if s.maxDomains > 0 && len(names) > s.maxDomains {
+		s.logger.Info("limiting related domains", "found", len(names), "max", s.maxDomains)
+		names = names[:s.maxDomains]
+	}
+
+	rootID := domain.NewArtifact(domain.ArtifactTypeDomain, target.Root, sourceName).ID
+	for _, d := range names {
+		artifact := domain.NewArtifact(domain.ArtifactTypeDomain, d, sourceName)
+		artifact.Confidence = domain.ConfidenceLow // Misma entidad inferida, no verificada
+		artifact.AddTag(domain.TagRelatedOrg)
+
+		matched := related[d]
+		values, kinds := make([]string, 0, len(matched)), make([]string, 0, len(matched))
+		for _, t := range matched {
+			values = append(values, t.value)
+			kinds = append(kinds, t.kind)
+		}
+		artifact.AddRelationWithMetadata(rootID, domain.RelationSameRegistrant, domain.ConfidenceLow, sourceName, map[string]string{
+			"pivot_terms": strings.Join(values, "; "),
+			"term_types":  strings.Join(kinds, ","),
+			"provider":    s.provider.Name(),
+		})
+		result.AddArtifact(artifact)
+	}
+
+	if result.Metadata.Environment == nil {
+		result.Metadata.Environment = make(map[string]string)
+	}
+	result.Metadata.Environment["reversewhois_terms"] = fmt.Sprintf("%d", len(terms))
+	result.Metadata.Environment["reversewhois_related"] = fmt.Sprintf("%d", len(names))
+
+	s.logger.Info("reverse whois completed",
+		"provider", s.provider.Name(),
+		"terms", len(terms),
+		"related_domains", len(names),
+		"failures", failures,
+	)
+
+	return result, ctx.Err()
+}
+
+// collectTerms extrae la organización del registrante (RegistrarMetadata de
+// los dominios y ContactMetadata de los emails) y, si está habilitado, los
+// emails de contactos registrant. Descarta datos redactados o de servicios
+// de privacidad, deduplica sin distinguir mayúsculas y limita a maxTerms
+// (organizaciones primero).
+func (s *Source) collectTerms(input *domain.ScanResult) []searchTerm {
+	if input == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var orgs, emails []searchTerm
+	add := func(list *[]searchTerm, kind, value string) {
+		value = strings.TrimSpace(value)
+		key := kind + ":" + strings.ToLower(value)
+		if value == "" || seen[key] || isPrivacyProtected(value) {
+			return
+		}
+		seen[key] = true
+		*list = append(*list, searchTerm{kind: kind, value: value})
+	}
+
+	for _, a := range input.Artifacts {
+		switch m := a.TypedMetadata.(type) {
+		case *metadata.RegistrarMetadata:
+			add(&orgs, termOrganization, m.Organization)
+		case *metadata.ContactMetadata:
+			if m.Redacted || !strings.EqualFold(m.ContactType, "registrant") {
+				continue
+			}
+			add(&orgs, termOrganization, m.Organization)
+			if s.searchEmails && a.Type == domain.ArtifactTypeEmail {
+				add(&emails, termEmail, a.Value)
+			}
+		}
+	}
+
+	terms := append(orgs, emails...)
+	if len(terms) > s.maxTerms {
+		s.logger.Info("limiting reverse whois terms", "terms", len(terms), "max", s.maxTerms)
+		terms = terms[:s.maxTerms]
+	}
+	return terms
+}
+
+// isPrivacyProtected detecta valores de servicios de privacidad WHOIS.
+func isPrivacyProtected(value string) bool {
+	lower := strings.ToLower(value)
+	for _, marker := range privacyMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package reversewhois
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/httpclient"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+	"aethonx/internal/testutil"
+)
+
+// rdapInput reproduce lo que rdap emite: dominio con RegistrarMetadata y
+// emails con ContactMetadata.
+func rdapInput(target *domain.Target) *domain.ScanResult {
+	input := domain.NewScanResult(*target)
+
+	reg := metadata.NewRegistrarMetadata()
+	reg.Organization = "Acme Corp"
+	input.AddArtifact(domain.NewArtifactWithMetadata(domain.ArtifactTypeDomain, "example.com", "rdap", reg))
+
+	registrant := metadata.NewContactMetadata("registrant")
+	registrant.Organization = "ACME CORP"
+	input.AddArtifact(domain.NewArtifactWithMetadata(domain.ArtifactTypeEmail, "hostmaster@example.com", "rdap", registrant))
+
+	tech := metadata.NewContactMetadata("tech")
+	tech.Organization = "Hosting Ltd"
+	input.AddArtifact(domain.NewArtifactWithMetadata(domain.ArtifactTypeEmail, "noc@hosting.example", "rdap", tech))
+
+	privacy := metadata.NewContactMetadata("registrant")
+	privacy.Organization = "Domains By Proxy, LLC"
+	input.AddArtifact(domain.NewArtifactWithMetadata(domain.ArtifactTypeEmail, "example.com@domainsbyproxy.com", "rdap", privacy))
+
+	return input
+}
+
+func TestSource_CollectTerms(t *testing.T) {
+	source := New(logx.New(), nil)
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+
+	terms := source.collectTerms(rdapInput(target))
+	expected := []searchTerm{
+		{kind: termOrganization, value: "Acme Corp"},
+		{kind: termEmail, value: "hostmaster@example.com"},
+	}
+	testutil.AssertEqual(t, len(terms), len(expected), "registrant org (deduped) and email, privacy and tech contacts skipped")
+	for i := range expected {
+		testutil.AssertEqual(t, terms[i], expected[i], "term order: organizations first")
+	}
+
+	source.searchEmails = false
+	source.maxTerms = 1
+	testutil.AssertEqual(t, len(source.collectTerms(rdapInput(target))), 1, "emails disabled")
+}
+
+func TestSource_RunWithInput_WhoisXML(t *testing.T) {
+	var _ ports.InputConsumer = (*Source)(nil)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req whoisXMLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.APIKey != "key" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch req.BasicSearchTerms.Include[0] {
+		case "Acme Corp":
+			w.Write([]byte(`{"domainsCount":3,"domainsList":["acme-shop.net","example.com","www.example.com"]}`))
+		case "hostmaster@example.com":
+			w.Write([]byte(`{"domainsCount":2,"domainsList":["acme-shop.net","ACME.io."]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := httpclient.New(httpclient.Config{}, logx.New())
+	source := New(logx.New(), &whoisXMLProvider{client: client, url: server.URL, apiKey: "key"})
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+
+	result, err := source.RunWithInput(context.Background(), *target, rdapInput(target))
+	testutil.AssertNoError(t, err, "run")
+	testutil.AssertEqual(t, len(result.Artifacts), 2, "target and its subdomains are not related domains")
+
+	byValue := make(map[string]*domain.Artifact)
+	for _, a := range result.Artifacts {
+		byValue[a.Value] = a
+		testutil.AssertEqual(t, a.Type, domain.ArtifactTypeDomain, "related domains are domain artifacts")
+		testutil.AssertContains(t, a.Tags, domain.TagRelatedOrg, "tagged related-org")
+	}
+
+	shop, ok := byValue["acme-shop.net"]
+	if !ok {
+		t.Fatal("acme-shop.net not emitted")
+	}
+	rels := shop.GetRelations(domain.RelationSameRegistrant)
+	testutil.AssertEqual(t, len(rels), 1, "linked to the target root")
+	testutil.AssertEqual(t, rels[0].Metadata["pivot_terms"], "Acme Corp; hostmaster@example.com", "both terms recorded")
+	_, ok = byValue["acme.io"]
+	testutil.AssertTrue(t, ok, "domains normalized")
+	testutil.AssertEqual(t, result.Metadata.Environment["reversewhois_related"], "2", "related count")
+}
+
+func TestSource_RunWithInput_NoTerms(t *testing.T) {
+	source := New(logx.New(), nil)
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+
+	result, err := source.Run(context.Background(), *target)
+	testutil.AssertNoError(t, err, "run without input")
+	testutil.AssertEqual(t, len(result.Artifacts), 0, "nothing to pivot on")
+	testutil.AssertEqual(t, len(result.Warnings), 1, "warning explains why")
+}
+
+func TestFactory(t *testing.T) {
+	_, err := factory(ports.SourceConfig{}, logx.New())
+	testutil.AssertError(t, err, "api_key required")
+
+	_, err = factory(ports.SourceConfig{Custom: map[string]interface{}{"api_key": "k", "provider": "domaintools"}}, logx.New())
+	testutil.AssertError(t, err, "unknown provider")
+
+	src, err := factory(ports.SourceConfig{Custom: map[string]interface{}{"api_key": "k", "provider": "securitytrails"}}, logx.New())
+	testutil.AssertNoError(t, err, "securitytrails")
+	testutil.AssertEqual(t, src.(*Source).provider.Name(), "securitytrails", "provider selected")
+
+	meta, ok := registry.Global().GetMetadata(sourceName)
+	testutil.AssertTrue(t, ok && meta.Pivot, "registered as a pivot source")
+}
@@ -0,0 +1,538 @@
+// internal/sources/robots/robots.go
+package robots
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/budget"
+	"aethonx/internal/platform/httpclient"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+)
+
+const (
+	sourceName            = "robots"
+	defaultWorkers        = 10
+	defaultRateLimit      = 10.0
+	defaultMaxHosts       = 100
+	defaultMaxURLsPerHost = 1000
+	maxSitemapsPerHost    = 10
+	maxBodyBytes          = 10 << 20 // robots.txt/sitemaps mayores se truncan
+	requestTimeout        = 15 * time.Second
+)
+
+// configSchema declara las opciones Custom de robots.
+var configSchema = []ports.ConfigField{
+	{Name: "workers", Type: ports.ConfigTypeInt, Default: defaultWorkers, Description: "Hosts fetched in parallel (1-100)"},
+	{Name: "rate_limit", Type: ports.ConfigTypeFloat, Default: defaultRateLimit, Description: "Max requests per second (0 = unlimited)"},
+	{Name: "max_hosts", Type: ports.ConfigTypeInt, Default: defaultMaxHosts, Description: "Max alive hosts harvested (0 = all)"},
+	{Name: "max_urls_per_host", Type: ports.ConfigTypeInt, Default: defaultMaxURLsPerHost, Description: "Max URLs emitted per host (0 = no limit)"},
+	{Name: "sitemaps", Type: ports.ConfigTypeBool, Default: true, Description: "Fetch sitemap.xml and the sitemaps listed in robots.txt"},
+}
+
+// Auto-registro de la source al importar el package
+func init() {
+	if err := registry.Global().Register(
+		sourceName,
+		factory,
+		ports.SourceMetadata{
+			Name:         sourceName,
+			Description:  "robots.txt and sitemap.xml harvesting from alive hosts",
+			Version:      "1.0.0",
+			Author:       "AethonX",
+			Mode:         domain.SourceModeActive,
+			Type:         domain.SourceTypeBuiltin,
+			RequiresAuth: false,
+
+			// Consume las URLs vivas de httpx (un origen por host)
+			InputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeURL,
+			},
+			// También emite URLs, pero no se declaran: httpx consume URLs y
+			// declararlas crearía un ciclo de dependencias entre ambas sources.
+			OutputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeEndpoint,
+			},
+			Priority: 18,
+			Phase:    ports.PhaseEnrichment,
+
+			ConfigSchema: configSchema,
+		},
+	); err != nil {
+		logx.New().Warn("failed to register robots source", "error", err.Error())
+	}
+}
+
+// factory crea la source desde SourceConfig (Custom según configSchema).
+func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("robots config: %w", err)
+	}
+
+	workers := opts.Int("workers")
+	if workers <= 0 || workers > 100 {
+		return nil, fmt.Errorf("robots workers must be between 1 and 100, got %d", workers)
+	}
+	rateLimit := opts.Float("rate_limit")
+	if rateLimit < 0 {
+		return nil, fmt.Errorf("robots rate_limit cannot be negative, got %v", rateLimit)
+	}
+	maxHosts := opts.Int("max_hosts")
+	maxURLs := opts.Int("max_urls_per_host")
+	if maxHosts < 0 || maxURLs < 0 {
+		return nil, fmt.Errorf("robots max_hosts and max_urls_per_host cannot be negative")
+	}
+
+	client := httpclient.New(httpclient.Config{
+		Timeout:        requestTimeout,
+		MaxRetries:     1,
+		UserAgent:      "AethonX/1.0",
+		RateLimit:      rateLimit,
+		RateLimitBurst: workers,
+		Budgeted:       true,
+		Polite:         true,
+	}, logger)
+
+	source := New(logger, client, workers)
+	source.maxHosts = maxHosts
+	source.maxURLsPerHost = maxURLs
+	source.sitemaps = opts.Bool("sitemaps")
+	return source, nil
+}
+
+// Source descarga robots.txt y sitemap.xml de los hosts vivos y emite como
+// artifacts url las rutas que listan (las Disallow suelen ser interesantes),
+// etiquetadas con el fichero de origen.
+type Source struct {
+	client         *httpclient.Client
+	workers        int
+	maxHosts       int
+	maxURLsPerHost int
+	sitemaps       bool
+	logger         logx.Logger
+}
+
+// New crea la source robots.
+func New(logger logx.Logger, client *httpclient.Client, workers int) *Source {
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	return &Source{
+		client:         client,
+		workers:        workers,
+		maxHosts:       defaultMaxHosts,
+		maxURLsPerHost: defaultMaxURLsPerHost,
+		sitemaps:       true,
+		logger:         logger.With("source", sourceName),
+	}
+}
+
+// Name retorna el nombre de la fuente.
+func (s *Source) Name() string { return sourceName }
+
+// Mode retorna el modo de operación (activo: peticiones HTTP al target).
+func (s *Source) Mode() domain.SourceMode { return domain.SourceModeActive }
+
+// Type retorna el tipo de fuente (builtin).
+func (s *Source) Type() domain.SourceType { return domain.SourceTypeBuiltin }
+
+// Close no libera recursos.
+func (s *Source) Close() error { return nil }
+
+// SetLogger sustituye el logger por el del escaneo (scan_id, stage, source).
+// Implementa ports.LogScopedSource.
+func (s *Source) SetLogger(logger logx.Logger) { s.logger = logger }
+
+// Run cosecha solo el dominio raíz del target (https).
+func (s *Source) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	return s.harvestAll(ctx, target, []string{"https://" + target.QueryName()})
+}
+
+// RunWithInput cosecha los orígenes de las URLs vivas de input; sin URLs
+// vivas, el dominio raíz. Implementa ports.InputConsumer.
+func (s *Source) RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+	origins := s.aliveOrigins(input, target)
+	if len(origins) == 0 {
+		return s.Run(ctx, target)
+	}
+	return s.harvestAll(ctx, target, origins)
+}
+
+// aliveOrigins extrae scheme://host[:port] de las URLs vivas en scope,
+// deduplicados y limitados a maxHosts.
+func (s *Source) aliveOrigins(input *domain.ScanResult, target domain.Target) []string {
+	if input == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	origins := make([]string, 0)
+	for _, a := range input.Artifacts {
+		if a.Type != domain.ArtifactTypeURL || !a.IsAlive() {
+			continue
+		}
+		u, err := url.Parse(a.Value)
+		if err != nil || !inScope(u, target) {
+			continue
+		}
+		origin := u.Scheme + "://" + u.Host
+		if seen[origin] {
+			continue
+		}
+		seen[origin] = true
+		origins = append(origins, origin)
+	}
+	sort.Strings(origins)
+	if s.maxHosts > 0 && len(origins) > s.maxHosts {
+		s.logger.Info("limiting harvested hosts", "alive", len(origins), "max", s.maxHosts)
+		origins = origins[:s.maxHosts]
+	}
+	return origins
+}
+
+// harvested es una URL encontrada y el fichero del que procede.
+type harvested struct {
+	url string
+	tag string // robots-disallow, robots-allow o sitemap
+}
+
+// hostResult es la cosecha de un origen.
+type hostResult struct {
+	origin     string
+	urls       []harvested
+	outOfScope int
+	err        error
+}
+
+// harvestAll procesa los orígenes con un pool de workers.
+func (s *Source) harvestAll(ctx context.Context, target domain.Target, origins []string) (*domain.ScanResult, error) {
+	result := domain.NewScanResult(target)
+	result.Metadata.SourcesUsed = []string{sourceName}
+
+	jobs := make(chan string)
+	results := make(chan hostResult, len(origins))
+
+	var wg sync.WaitGroup
+	workers := s.workers
+	if workers > len(origins) {
+		workers = len(origins)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for origin := range jobs {
+				results <- s.harvestHost(ctx, target, origin)
+			}
+		}()
+	}
+
+	// Con el presupuesto de peticiones agotado (--budget) no se lanzan más hosts
+	skipped := 0
+feed:
+	for i, origin := range origins {
+		if budget.Shared().Exhausted(budget.Requests) {
+			skipped = len(origins) - i
+			break
+		}
+		select {
+		case jobs <- origin:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	close(results)
+
+	if skipped > 0 {
+		s.logger.Warn("request budget exhausted, hosts skipped", "skipped", skipped)
+		result.AddWarning(sourceName, fmt.Sprintf("request budget exhausted: %d of %d hosts skipped", skipped, len(origins)))
+	}
+
+	hostResults := make([]hostResult, 0, len(origins))
+	for r := range results {
+		hostResults = append(hostResults, r)
+	}
+	sort.Slice(hostResults, func(i, j int) bool { return hostResults[i].origin < hostResults[j].origin })
+
+	failures, outOfScope := 0, 0
+	for _, r := range hostResults {
+		outOfScope += r.outOfScope
+		if r.err != nil {
+			failures++
+			s.logger.Debug("robots harvest failed", "origin", r.origin, "error", r.err.Error())
+		}
+		s.addArtifacts(result, r)
+	}
+
+	if failures > 0 {
+		result.AddWarning(sourceName, fmt.Sprintf("%d of %d hosts could not be harvested", failures, len(origins)))
+	}
+
+	if result.Metadata.Environment == nil {
+		result.Metadata.Environment = make(map[string]string)
+	}
+	result.Metadata.Environment["robots_hosts"] = fmt.Sprintf("%d", len(origins))
+	result.Metadata.Environment["robots_out_of_scope"] = fmt.Sprintf("%d", outOfScope)
+
+	s.logger.Info("robots harvest completed",
+		"hosts", len(origins),
+		"artifacts", len(result.Artifacts),
+		"out_of_scope", outOfScope,
+		"failures", failures,
+	)
+
+	return result, ctx.Err()
+}
+
+// harvestHost descarga robots.txt del origen y, si está habilitado, sus
+// sitemaps (los listados en robots.txt o /sitemap.xml). Un 404 no es error.
+func (s *Source) harvestHost(ctx context.Context, target domain.Target, origin string) hostResult {
+	r := hostResult{origin: origin}
+	seen := make(map[string]bool)
+
+	add := func(rawURL, tag string) bool {
+		if s.maxURLsPerHost > 0 && len(r.urls) >= s.maxURLsPerHost {
+			return false
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil || u.Host == "" {
+			return true
+		}
+		if !inScope(u, target) {
+			r.outOfScope++
+			return true
+		}
+		if !seen[rawURL] {
+			seen[rawURL] = true
+			r.urls = append(r.urls, harvested{url: rawURL, tag: tag})
+		}
+		return true
+	}
+
+	body, found, err := s.fetch(ctx, origin+"/robots.txt")
+	if err != nil {
+		r.err = err
+		return r
+	}
+
+	var sitemaps []string
+	if found {
+		rules, listed := parseRobots(body)
+		for _, rule := range rules {
+			add(origin+rule.path, rule.tag)
+		}
+		sitemaps = listed
+	}
+
+	if !s.sitemaps {
+		return r
+	}
+	if len(sitemaps) == 0 {
+		sitemaps = []string{origin + "/sitemap.xml"}
+	}
+
+	// Los sitemap index se expanden en anchura hasta maxSitemapsPerHost
+	fetched := make(map[string]bool)
+	for len(sitemaps) > 0 && len(fetched) < maxSitemapsPerHost {
+		sitemapURL := sitemaps[0]
+		sitemaps = sitemaps[1:]
+		if fetched[sitemapURL] {
+			continue
+		}
+		fetched[sitemapURL] = true
+
+		u, err := url.Parse(sitemapURL)
+		if err != nil || !inScope(u, target) {
+			r.outOfScope++
+			continue
+		}
+
+		body, found, err := s.fetch(ctx, sitemapURL)
+		if err != nil || !found {
+			continue
+		}
+		locs, nested, err := parseSitemap(body, strings.HasSuffix(u.Path, ".gz"))
+		if err != nil {
+			s.logger.Debug("invalid sitemap", "url", sitemapURL, "error", err.Error())
+			continue
+		}
+		for _, loc := range locs {
+			if !add(loc, tagSitemap) {
+				break
+			}
+		}
+		sitemaps = append(sitemaps, nested...)
+	}
+
+	return r
+}
+
+// fetch descarga rawURL. found es false con 404/410 y otros 4xx.
+func (s *Source) fetch(ctx context.Context, rawURL string) (body []byte, found bool, err error) {
+	resp, err := s.client.Get(ctx, rawURL, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return nil, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("%s: HTTP %d", rawURL, resp.StatusCode)
+	}
+
+	body, err = io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	if err != nil {
+		return nil, false, err
+	}
+	return body, true, nil
+}
+
+// addArtifacts crea un artifact url por URL cosechada y un endpoint por
+// ruta Disallow.
+func (s *Source) addArtifacts(result *domain.ScanResult, r hostResult) {
+	for _, h := range r.urls {
+		urlArtifact := domain.NewArtifact(domain.ArtifactTypeURL, h.url, sourceName)
+		urlArtifact.Confidence = domain.ConfidenceMedium // Listada, no verificada
+		urlArtifact.AddTag(h.tag)
+		result.AddArtifact(urlArtifact)
+
+		if h.tag == tagDisallow {
+			if u, err := url.Parse(h.url); err == nil && u.Path != "" && u.Path != "/" {
+				endpoint := domain.NewArtifact(domain.ArtifactTypeEndpoint, u.Path, sourceName)
+				endpoint.Confidence = domain.ConfidenceMedium
+				endpoint.AddTag(tagDisallow)
+				result.AddArtifact(endpoint)
+			}
+		}
+	}
+}
+
+// Tags con el fichero (y directiva) de origen de cada URL.
+const (
+	tagDisallow = "robots-disallow"
+	tagAllow    = "robots-allow"
+	tagSitemap  = "sitemap"
+)
+
+// robotsRule es una ruta Allow/Disallow de robots.txt.
+type robotsRule struct {
+	path string
+	tag  string
+}
+
+// parseRobots extrae las rutas Allow/Disallow (de todos los user-agents) y
+// las directivas Sitemap. Los comodines (*, $) recortan la ruta al prefijo
+// literal; rutas vacías o "/" se descartan.
+func parseRobots(body []byte) ([]robotsRule, []string) {
+	var rules []robotsRule
+	var sitemaps []string
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "sitemap":
+			if value != "" {
+				sitemaps = append(sitemaps, value)
+			}
+		case "disallow", "allow":
+			if i := strings.IndexAny(value, "*$"); i >= 0 {
+				value = value[:i]
+			}
+			if value == "" || value == "/" || !strings.HasPrefix(value, "/") || seen[value] {
+				continue
+			}
+			seen[value] = true
+			tag := tagDisallow
+			if key == "allow" {
+				tag = tagAllow
+			}
+			rules = append(rules, robotsRule{path: value, tag: tag})
+		}
+	}
+	return rules, sitemaps
+}
+
+// sitemapDoc cubre urlset y sitemapindex: ambos listan <loc>.
+type sitemapDoc struct {
+	XMLName  xml.Name
+	URLs     []sitemapLoc `xml:"url"`
+	Sitemaps []sitemapLoc `xml:"sitemap"`
+}
+
+type sitemapLoc struct {
+	Loc string `xml:"loc"`
+}
+
+// parseSitemap retorna las URLs de un urlset y los sitemaps anidados de un
+// sitemapindex. gzipped descomprime el cuerpo (sitemap.xml.gz).
+func parseSitemap(body []byte, gzipped bool) (locs []string, nested []string, err error) {
+	if gzipped {
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, nil, err
+		}
+		if body, err = io.ReadAll(io.LimitReader(zr, maxBodyBytes)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var doc sitemapDoc
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, nil, err
+	}
+	for _, u := range doc.URLs {
+		if loc := strings.TrimSpace(u.Loc); loc != "" {
+			locs = append(locs, loc)
+		}
+	}
+	for _, sm := range doc.Sitemaps {
+		if loc := strings.TrimSpace(sm.Loc); loc != "" {
+			nested = append(nested, loc)
+		}
+	}
+	return locs, nested, nil
+}
+
+// inScope indica si u es http(s) y su host es el dominio raíz o un
+// subdominio no excluido por el scope del target.
+func inScope(u *url.URL, target domain.Target) bool {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	root := target.QueryName()
+	if host != root && !strings.HasSuffix(host, "."+root) {
+		return false
+	}
+	return target.IsInScope(host)
+}
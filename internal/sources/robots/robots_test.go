@@ -0,0 +1,126 @@
+package robots
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/httpclient"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+)
+
+func newTestSource() *Source {
+	return New(logx.New(), httpclient.New(httpclient.Config{MaxRetries: 0}, logx.New()), 2)
+}
+
+func TestParseRobots(t *testing.T) {
+	body := []byte(`User-agent: *
+Disallow: /admin/   # panel
+Disallow: /private/*.pdf$
+Disallow: /
+Allow: /public
+Disallow:
+Disallow: /admin/
+Sitemap: https://example.com/sitemap_index.xml
+`)
+
+	rules, sitemaps := parseRobots(body)
+
+	expected := []robotsRule{
+		{path: "/admin/", tag: tagDisallow},
+		{path: "/private/", tag: tagDisallow},
+		{path: "/public", tag: tagAllow},
+	}
+	testutil.AssertTrue(t, reflect.DeepEqual(rules, expected), "rules should drop wildcards, / and duplicates")
+	testutil.AssertTrue(t, reflect.DeepEqual(sitemaps, []string{"https://example.com/sitemap_index.xml"}), "sitemap directive")
+}
+
+func TestParseSitemap(t *testing.T) {
+	index := []byte(`<?xml version="1.0"?><sitemapindex><sitemap><loc> https://example.com/a.xml </loc></sitemap></sitemapindex>`)
+	locs, nested, err := parseSitemap(index, false)
+	testutil.AssertNoError(t, err, "sitemap index")
+	testutil.AssertEqual(t, len(locs), 0, "index has no URLs")
+	testutil.AssertTrue(t, reflect.DeepEqual(nested, []string{"https://example.com/a.xml"}), "nested sitemap")
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte(`<urlset><url><loc>https://example.com/page</loc></url></urlset>`))
+	zw.Close()
+	locs, _, err = parseSitemap(gz.Bytes(), true)
+	testutil.AssertNoError(t, err, "gzipped urlset")
+	testutil.AssertTrue(t, reflect.DeepEqual(locs, []string{"https://example.com/page"}), "urlset locs")
+
+	_, _, err = parseSitemap([]byte("not xml"), false)
+	testutil.AssertError(t, err, "invalid sitemap")
+}
+
+func TestSource_RunWithInput(t *testing.T) {
+	var _ ports.InputConsumer = (*Source)(nil)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			w.Write([]byte("User-agent: *\nDisallow: /backup/\nSitemap: " + server.URL + "/index.xml\n"))
+		case "/index.xml":
+			w.Write([]byte(`<sitemapindex><sitemap><loc>` + server.URL + `/pages.xml</loc></sitemap></sitemapindex>`))
+		case "/pages.xml":
+			w.Write([]byte(`<urlset><url><loc>` + server.URL + `/about</loc></url><url><loc>https://other.net/x</loc></url></urlset>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	target := domain.NewTarget("127.0.0.1", domain.ScanModeActive)
+	input := domain.NewScanResult(*target)
+	alive := domain.NewArtifact(domain.ArtifactTypeURL, server.URL+"/login", "httpx")
+	alive.AddTag("alive")
+	input.AddArtifact(alive)
+	input.AddArtifact(domain.NewArtifact(domain.ArtifactTypeURL, "https://127.0.0.1:1/old", "waybackurls"))
+
+	result, err := newTestSource().RunWithInput(context.Background(), *target, input)
+	testutil.AssertNoError(t, err, "run")
+
+	tags := make(map[string]string)
+	endpoints := 0
+	for _, a := range result.Artifacts {
+		switch a.Type {
+		case domain.ArtifactTypeURL:
+			tags[a.Value] = a.Tags[0]
+		case domain.ArtifactTypeEndpoint:
+			endpoints++
+		}
+	}
+	testutil.AssertEqual(t, tags[server.URL+"/backup/"], tagDisallow, "disallowed path tagged")
+	testutil.AssertEqual(t, tags[server.URL+"/about"], tagSitemap, "sitemap URL tagged")
+	testutil.AssertEqual(t, len(tags), 2, "only the alive host is harvested, out of scope URLs dropped")
+	testutil.AssertEqual(t, endpoints, 1, "disallowed path endpoint")
+	testutil.AssertEqual(t, result.Metadata.Environment["robots_out_of_scope"], "1", "out of scope count")
+}
+
+func TestSource_MaxURLsPerHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			w.Write([]byte("Disallow: /a\nDisallow: /b\nDisallow: /c\n"))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	source := newTestSource()
+	source.maxURLsPerHost = 2
+	target := domain.NewTarget("127.0.0.1", domain.ScanModeActive)
+
+	r := source.harvestHost(context.Background(), *target, server.URL)
+	testutil.AssertNoError(t, r.err, "harvest")
+	testutil.AssertEqual(t, len(r.urls), 2, "URLs capped per host")
+}
@@ -0,0 +1,64 @@
+// internal/sources/screenshotimport/aquatone.go
+package screenshotimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// aquatoneSession es aquatone_session.json (solo los campos que se importan).
+type aquatoneSession struct {
+	Version string                   `json:"version"`
+	Pages   map[string]*aquatonePage `json:"pages"`
+}
+
+// aquatonePage es una página visitada por Aquatone.
+type aquatonePage struct {
+	URL            string   `json:"url"`
+	Hostname       string   `json:"hostname"`
+	Addrs          []string `json:"addrs"`
+	Status         string   `json:"status"` // "200 OK"
+	PageTitle      string   `json:"pageTitle"`
+	ScreenshotPath string   `json:"screenshotPath"` // Relativa al directorio de la sesión
+	HasScreenshot  bool     `json:"hasScreenshot"`
+}
+
+// aquatone importa una sesión de Aquatone. Las rutas de las capturas son
+// relativas a base, el directorio de la sesión.
+func (imp *importer) aquatone(r io.Reader, base string) (int, error) {
+	var session aquatoneSession
+	if err := json.NewDecoder(r).Decode(&session); err != nil {
+		return 0, fmt.Errorf("invalid Aquatone session: %w", err)
+	}
+
+	// Orden determinista: el mapa de páginas va por UUID
+	ids := make([]string, 0, len(session.Pages))
+	for id := range session.Pages {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	pages := 0
+	for _, id := range ids {
+		p := session.Pages[id]
+		if p == nil {
+			continue
+		}
+		imported := page{
+			url:      p.URL,
+			hostname: p.Hostname,
+			addrs:    p.Addrs,
+			status:   statusCode(p.Status),
+			title:    p.PageTitle,
+		}
+		if p.HasScreenshot {
+			imported.screenshot = p.ScreenshotPath
+		}
+		if imp.addPage(imported, base) {
+			pages++
+		}
+	}
+	return pages, nil
+}
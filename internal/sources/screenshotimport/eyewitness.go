@@ -0,0 +1,70 @@
+// internal/sources/screenshotimport/eyewitness.go
+package screenshotimport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Columnas de Requests.csv de EyeWitness que se importan.
+const (
+	ewProtocol   = "protocol"
+	ewPort       = "port"
+	ewDomain     = "domain"
+	ewStatus     = "request status"
+	ewScreenshot = "screenshot path"
+)
+
+// eyewitness importa el Requests.csv de un informe de EyeWitness. Solo las
+// peticiones con estado "Successful" (o sin estado) son páginas vivas.
+func (imp *importer) eyewitness(r io.Reader, base string) (int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, fmt.Errorf("invalid EyeWitness CSV: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{ewProtocol, ewPort, ewDomain} {
+		if _, ok := columns[required]; !ok {
+			return 0, fmt.Errorf("invalid EyeWitness CSV: missing column %q", required)
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	pages := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return pages, fmt.Errorf("invalid EyeWitness CSV: %w", err)
+		}
+		if status := field(record, ewStatus); status != "" && !strings.EqualFold(status, "successful") {
+			continue
+		}
+
+		// Domain suele ser el host; algunas versiones escriben la URL completa
+		rawURL := field(record, ewDomain)
+		if !strings.Contains(rawURL, "://") {
+			rawURL = fmt.Sprintf("%s://%s:%s", strings.ToLower(field(record, ewProtocol)), rawURL, field(record, ewPort))
+		}
+		if imp.addPage(page{url: rawURL, screenshot: field(record, ewScreenshot)}, base) {
+			pages++
+		}
+	}
+	return pages, nil
+}
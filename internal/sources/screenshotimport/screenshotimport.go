@@ -0,0 +1,284 @@
+// internal/sources/screenshotimport/screenshotimport.go
+package screenshotimport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+)
+
+const (
+	sourceName = "screenshotimport"
+
+	// importTag marca los artifacts que vienen de Aquatone o EyeWitness
+	importTag = "screenshot-import"
+
+	// sniffBytes es lo que se lee de cada fichero para detectar su formato
+	sniffBytes = 4096
+)
+
+// configSchema declara las opciones Custom de screenshotimport.
+var configSchema = []ports.ConfigField{
+	{Name: "files", Type: ports.ConfigTypeStringList, Description: "Aquatone sessions (aquatone_session.json) or EyeWitness reports (Requests.csv); set by --import"},
+}
+
+// Auto-registro de la source al importar el package
+func init() {
+	if err := registry.Global().Register(
+		sourceName,
+		factory,
+		ports.SourceMetadata{
+			Name:         sourceName,
+			Description:  "Import of Aquatone and EyeWitness results (alive URLs, titles, screenshots)",
+			Version:      "1.0.0",
+			Author:       "AethonX",
+			Mode:         domain.SourceModePassive,
+			Type:         domain.SourceTypeFile,
+			RequiresAuth: false,
+
+			// Sin inputs: corre en el primer stage
+			OutputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeURL,
+				domain.ArtifactTypeDomain,
+				domain.ArtifactTypeSubdomain,
+				domain.ArtifactTypeIP,
+				domain.ArtifactTypeIPv6,
+			},
+			Priority: 12,
+
+			ConfigSchema: configSchema,
+		},
+	); err != nil {
+		logx.New().Warn("failed to register screenshotimport source", "error", err.Error())
+	}
+}
+
+// factory crea la source desde SourceConfig (Custom según configSchema).
+func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("screenshotimport config: %w", err)
+	}
+	files := opts.Strings("files")
+	if len(files) == 0 {
+		return nil, fmt.Errorf("screenshotimport needs at least one file (--import aquatone_session.json)")
+	}
+	return New(logger, files), nil
+}
+
+// Source importa los resultados de Aquatone y EyeWitness: cada página
+// capturada es una URL viva con su título y la ruta de la captura, y sus
+// hosts en scope e IPs se añaden al grafo. No envía tráfico.
+type Source struct {
+	files  []string
+	logger logx.Logger
+}
+
+// New crea la source screenshotimport para los ficheros dados.
+func New(logger logx.Logger, files []string) *Source {
+	return &Source{
+		files:  files,
+		logger: logger.With("source", sourceName),
+	}
+}
+
+// Name retorna el nombre de la fuente.
+func (s *Source) Name() string { return sourceName }
+
+// Mode retorna el modo de operación (pasivo: solo lee ficheros).
+func (s *Source) Mode() domain.SourceMode { return domain.SourceModePassive }
+
+// Type retorna el tipo de fuente (file).
+func (s *Source) Type() domain.SourceType { return domain.SourceTypeFile }
+
+// Close no libera recursos.
+func (s *Source) Close() error { return nil }
+
+// SetLogger sustituye el logger por el del escaneo (scan_id, stage, source).
+// Implementa ports.LogScopedSource.
+func (s *Source) SetLogger(logger logx.Logger) { s.logger = logger }
+
+// Run importa cada fichero según su formato. Un fichero ilegible o de otro
+// formato se registra como error y no impide importar el resto.
+func (s *Source) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	result := domain.NewScanResult(target)
+	result.Metadata.SourcesUsed = []string{sourceName}
+
+	imp := &importer{target: target, result: result, seen: make(map[string]*domain.Artifact)}
+	for _, file := range s.files {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		pages, err := s.importFile(imp, file)
+		if err != nil {
+			result.AddError(sourceName, fmt.Sprintf("%s: %v", file, err), false)
+			continue
+		}
+		s.logger.Info("screenshot results imported", "file", file, "pages", pages)
+	}
+
+	s.logger.Info("screenshotimport completed",
+		"files", len(s.files),
+		"artifacts", len(result.Artifacts),
+		"out_of_scope", imp.outOfScope,
+	)
+	return result, nil
+}
+
+// importFile detecta el formato de file y lo importa.
+func (s *Source) importFile(imp *importer, file string) (int, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return 0, err
+	}
+	head := data[:min(len(data), sniffBytes)]
+	base := filepath.Dir(file)
+	switch {
+	case IsAquatoneSession(head):
+		return imp.aquatone(bytes.NewReader(data), base)
+	case IsEyeWitnessCSV(head):
+		return imp.eyewitness(bytes.NewReader(data), base)
+	}
+	return 0, fmt.Errorf("not an Aquatone session or EyeWitness Requests.csv")
+}
+
+// IsAquatoneSession indica si head es el inicio de aquatone_session.json.
+func IsAquatoneSession(head []byte) bool {
+	head = bytes.TrimSpace(head)
+	return bytes.HasPrefix(head, []byte("{")) && bytes.Contains(head, []byte(`"pages"`)) && bytes.Contains(head, []byte(`"stats"`))
+}
+
+// IsEyeWitnessCSV indica si head es el inicio del Requests.csv de EyeWitness.
+func IsEyeWitnessCSV(head []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(head), []byte("Protocol,Port,Domain"))
+}
+
+// page es una página capturada, común a ambos formatos.
+type page struct {
+	url        string
+	hostname   string // "" = el host de url
+	addrs      []string
+	status     int // 0 = desconocido
+	title      string
+	screenshot string // Ruta absoluta o relativa al fichero importado
+}
+
+// importer acumula los artifacts de todos los ficheros sin duplicados.
+type importer struct {
+	target     domain.Target
+	result     *domain.ScanResult
+	seen       map[string]*domain.Artifact
+	outOfScope int
+}
+
+// addPage emite la URL viva con su metadata y, si está en scope, su host
+// (url -hosted_on-> host) y las IPs a las que resuelve.
+func (imp *importer) addPage(p page, base string) bool {
+	u, err := url.Parse(strings.TrimSpace(p.url))
+	if err != nil || u.Hostname() == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	host := strings.ToLower(p.hostname)
+	if host == "" {
+		host = strings.ToLower(u.Hostname())
+	}
+	isIP := net.ParseIP(host) != nil
+	if !isIP && !imp.target.IsInScope(host) {
+		imp.outOfScope++
+		return false
+	}
+
+	meta := &metadata.ServiceMetadata{
+		Name:            "http",
+		Port:            urlPort(u),
+		Protocol:        u.Scheme,
+		State:           "open",
+		Title:           strings.TrimSpace(p.title),
+		SSLEnabled:      u.Scheme == "https",
+		DetectionMethod: "screenshot",
+		Confidence:      1.0,
+	}
+	if p.screenshot != "" {
+		meta.Screenshot = p.screenshot
+		if !filepath.IsAbs(meta.Screenshot) {
+			meta.Screenshot = filepath.Join(base, filepath.FromSlash(meta.Screenshot))
+		}
+	}
+	if len(p.addrs) > 0 {
+		meta.ParentIP = p.addrs[0]
+	}
+	pageArtifact := domain.NewArtifact(domain.ArtifactTypeURL, u.String(), sourceName)
+	pageArtifact.TypedMetadata = meta
+	pageArtifact.AddTag("alive")
+	if p.status > 0 {
+		pageArtifact.AddTag(fmt.Sprintf("http-%d", p.status))
+	}
+	pageArtifact = imp.add(pageArtifact)
+
+	var hostArtifact *domain.Artifact
+	switch {
+	case isIP:
+		hostArtifact = imp.add(domain.NewIPArtifact(host, sourceName))
+	case host == imp.target.Root:
+		hostArtifact = imp.add(domain.NewDomainArtifact(host, sourceName))
+	default:
+		hostArtifact = imp.add(domain.NewSubdomainArtifact(host, sourceName))
+	}
+	pageArtifact.AddRelation(hostArtifact.ID, domain.RelationHostedOn, 1.0, sourceName)
+
+	if !isIP {
+		for _, addr := range p.addrs {
+			if ip := net.ParseIP(addr); ip != nil {
+				ipArtifact := imp.add(domain.NewIPArtifact(ip.String(), sourceName))
+				hostArtifact.AddRelation(ipArtifact.ID, domain.RelationResolvesTo, 1.0, sourceName)
+			}
+		}
+	}
+	return true
+}
+
+// add añade el artifact (etiquetado como importado) salvo que ya se haya
+// emitido, y retorna el que queda en result.
+func (imp *importer) add(a *domain.Artifact) *domain.Artifact {
+	key := a.Key()
+	if existing, ok := imp.seen[key]; ok {
+		return existing
+	}
+	a.AddTag(importTag)
+	imp.seen[key] = a
+	imp.result.AddArtifact(a)
+	return a
+}
+
+// urlPort retorna el puerto de u, o el del scheme si no lo indica.
+func urlPort(u *url.URL) int {
+	if p, err := strconv.Atoi(u.Port()); err == nil {
+		return p
+	}
+	if u.Scheme == "https" {
+		return 443
+	}
+	return 80
+}
+
+// statusCode extrae el código de un estado HTTP ("200 OK" -> 200).
+func statusCode(status string) int {
+	fields := strings.Fields(status)
+	if len(fields) == 0 {
+		return 0
+	}
+	code, _ := strconv.Atoi(fields[0])
+	return code
+}
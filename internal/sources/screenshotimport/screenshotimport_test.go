@@ -0,0 +1,77 @@
+package screenshotimport
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+	"aethonx/internal/testutil/sourcetest"
+)
+
+func TestScreenshotImport_Golden(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result, err := New(logx.NewSilent(), []string{"testdata/aquatone_session.json", "testdata/Requests.csv"}).Run(context.Background(), *target)
+	testutil.AssertNoError(t, err, "run")
+	testutil.AssertEqual(t, len(result.Errors), 0, "import errors")
+
+	sourcetest.Golden(t, "testdata/import.golden", result.Artifacts)
+}
+
+func TestScreenshotImport_PageMetadata(t *testing.T) {
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result, err := New(logx.NewSilent(), []string{"testdata/aquatone_session.json", "testdata/Requests.csv"}).Run(context.Background(), *target)
+	testutil.AssertNoError(t, err, "run")
+
+	screenshots := map[string]string{
+		"https://www.example.com":     filepath.Join("testdata", "screenshots", "https__www_example_com__42.png"),
+		"https://api.example.com":     "/tmp/ew/screens/https.api.example.com.png",
+		"http://dev.example.com:8000": filepath.Join("testdata", "screens", "http.dev.example.com.8000.png"),
+		"http://192.0.2.20:8080":      "",
+	}
+	for _, a := range result.Artifacts {
+		if a.Type != domain.ArtifactTypeURL {
+			continue
+		}
+		want, ok := screenshots[a.Value]
+		testutil.AssertTrue(t, ok, "unexpected url "+a.Value)
+		testutil.AssertTrue(t, a.IsAlive(), "imported page is alive")
+		meta, ok := a.TypedMetadata.(*metadata.ServiceMetadata)
+		testutil.AssertTrue(t, ok, "service metadata")
+		testutil.AssertEqual(t, meta.Screenshot, want, "screenshot of "+a.Value)
+		if a.Value == "https://www.example.com" {
+			testutil.AssertEqual(t, meta.Title, "Example Portal", "title")
+			testutil.AssertEqual(t, meta.ParentIP, "192.0.2.10", "address")
+		}
+	}
+}
+
+func TestScreenshotImport_InvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	other := filepath.Join(dir, "other.json")
+	testutil.AssertNoError(t, os.WriteFile(other, []byte(`{"Artifacts": []}`), 0o644), "write")
+
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	result, err := New(logx.NewSilent(), []string{other, filepath.Join(dir, "missing.csv"), "testdata/Requests.csv"}).Run(context.Background(), *target)
+	testutil.AssertNoError(t, err, "run")
+	testutil.AssertEqual(t, len(result.Errors), 2, "one error per bad file")
+	sourcetest.AssertArtifacts(t, result.Artifacts, "url https://api.example.com")
+}
+
+func TestDetectors(t *testing.T) {
+	testutil.AssertTrue(t, IsAquatoneSession(sourcetest.Fixture(t, "testdata/aquatone_session.json")), "aquatone session detected")
+	testutil.AssertTrue(t, IsEyeWitnessCSV(sourcetest.Fixture(t, "testdata/Requests.csv")), "eyewitness csv detected")
+	testutil.AssertFalse(t, IsAquatoneSession([]byte(`{"schema_version": "1.0", "Artifacts": []}`)), "aethonx scan is not aquatone")
+	testutil.AssertFalse(t, IsEyeWitnessCSV([]byte("type,value\nsubdomain,a.example.com")), "other csv")
+}
+
+func TestFactory_RequiresFiles(t *testing.T) {
+	if _, err := factory(ports.SourceConfig{Custom: map[string]interface{}{}}, logx.NewSilent()); err == nil {
+		t.Error("expected an error without files")
+	}
+}
@@ -42,6 +42,7 @@ func NewAPIClient(apiKey string, logger logx.Logger) *ShodanAPIClient {
 		UserAgent:       "AethonX/1.0 (Reconnaissance tool; +https://github.com/yourusername/aethonx)",
 		RateLimit:       1.0, // 1 req/s (free tier default)
 		RateLimitBurst:  1,
+		Upstream:        "api.shodan.io",
 	}
 
 	return &ShodanAPIClient{
@@ -62,6 +63,7 @@ func NewAPIClientWithConfig(apiKey string, logger logx.Logger, rateLimit float64
 		UserAgent:       "AethonX/1.0",
 		RateLimit:       rateLimit,
 		RateLimitBurst:  1,
+		Upstream:        "api.shodan.io",
 	}
 
 	return &ShodanAPIClient{
@@ -8,113 +8,23 @@ import (
 	"aethonx/internal/core/domain/metadata"
 )
 
-// VulnerabilityMetadata contains detailed information about a security vulnerability.
-type VulnerabilityMetadata struct {
-	CVE           string   // CVE identifier (e.g., "CVE-2021-44228")
-	Severity      string   // critical, high, medium, low
-	CVSSScore     float64  // CVSS score (0.0-10.0)
-	Description   string   // Vulnerability description
-	References    []string // URLs to advisories
-	AffectedPorts []int    // Ports where vulnerability was detected
-	DiscoveryTool string   // Tool that discovered the vuln (e.g., "shodan")
-}
-
-// ToMap converts VulnerabilityMetadata to map[string]string.
-func (v *VulnerabilityMetadata) ToMap() map[string]string {
-	m := make(map[string]string)
-	metadata.SetIfNotEmpty(m, "cve", v.CVE)
-	metadata.SetIfNotEmpty(m, "severity", v.Severity)
-	if v.CVSSScore > 0 {
-		metadata.SetFloat(m, "cvss_score", v.CVSSScore)
-	}
-	metadata.SetIfNotEmpty(m, "description", v.Description)
-	if len(v.References) > 0 {
-		m["references"] = metadata.StringSliceToCSV(v.References)
-	}
-	if len(v.AffectedPorts) > 0 {
-		m["affected_ports"] = metadata.IntSliceToCSV(v.AffectedPorts)
-	}
-	metadata.SetIfNotEmpty(m, "discovery_tool", v.DiscoveryTool)
-	return m
-}
-
-// FromMap loads VulnerabilityMetadata from map[string]string.
-func (v *VulnerabilityMetadata) FromMap(m map[string]string) error {
-	v.CVE = metadata.GetString(m, "cve", "")
-	v.Severity = metadata.GetString(m, "severity", "")
-	v.CVSSScore = metadata.GetFloat(m, "cvss_score", 0.0)
-	v.Description = metadata.GetString(m, "description", "")
-	v.References = metadata.CSVToStringSlice(metadata.GetString(m, "references", ""))
-	v.AffectedPorts = metadata.CSVToIntSlice(metadata.GetString(m, "affected_ports", ""))
-	v.DiscoveryTool = metadata.GetString(m, "discovery_tool", "")
-	return nil
-}
-
-// IsValid verifies if the metadata has valid minimum data.
-func (v *VulnerabilityMetadata) IsValid() bool {
-	return v.CVE != ""
-}
-
-// Type returns the metadata type.
-func (v *VulnerabilityMetadata) Type() string {
-	return "vulnerability"
-}
+// VulnerabilityMetadata is the core vulnerability metadata, registered in the
+// MetadataEnvelope so Shodan findings survive JSON round-trips.
+type VulnerabilityMetadata = metadata.VulnerabilityMetadata
 
-// NewVulnerabilityMetadata creates a new VulnerabilityMetadata instance.
+// NewVulnerabilityMetadata creates a VulnerabilityMetadata attributed to Shodan.
 func NewVulnerabilityMetadata(cve string) *VulnerabilityMetadata {
-	return &VulnerabilityMetadata{
-		CVE:           cve,
-		References:    []string{},
-		AffectedPorts: []int{},
-		DiscoveryTool: "shodan",
-	}
-}
-
-// CloudMetadata contains information about cloud infrastructure.
-type CloudMetadata struct {
-	Provider string   // aws, azure, gcp, digitalocean, etc.
-	Service  string   // ec2, s3, compute-engine, etc.
-	Region   string   // us-east-1, westeurope, etc.
-	Tags     []string // Additional tags
-}
-
-// ToMap converts CloudMetadata to map[string]string.
-func (c *CloudMetadata) ToMap() map[string]string {
-	m := make(map[string]string)
-	metadata.SetIfNotEmpty(m, "provider", c.Provider)
-	metadata.SetIfNotEmpty(m, "service", c.Service)
-	metadata.SetIfNotEmpty(m, "region", c.Region)
-	if len(c.Tags) > 0 {
-		m["tags"] = metadata.StringSliceToCSV(c.Tags)
-	}
-	return m
+	v := metadata.NewVulnerabilityMetadata(cve)
+	v.DiscoveryTool = "shodan"
+	return v
 }
 
-// FromMap loads CloudMetadata from map[string]string.
-func (c *CloudMetadata) FromMap(m map[string]string) error {
-	c.Provider = metadata.GetString(m, "provider", "")
-	c.Service = metadata.GetString(m, "service", "")
-	c.Region = metadata.GetString(m, "region", "")
-	c.Tags = metadata.CSVToStringSlice(metadata.GetString(m, "tags", ""))
-	return nil
-}
-
-// IsValid verifies if the metadata has valid minimum data.
-func (c *CloudMetadata) IsValid() bool {
-	return c.Provider != ""
-}
-
-// Type returns the metadata type.
-func (c *CloudMetadata) Type() string {
-	return "cloud"
-}
+// CloudMetadata is the core cloud resource metadata.
+type CloudMetadata = metadata.CloudResourceMetadata
 
 // NewCloudMetadata creates a new CloudMetadata instance.
 func NewCloudMetadata(provider string) *CloudMetadata {
-	return &CloudMetadata{
-		Provider: provider,
-		Tags:     []string{},
-	}
+	return metadata.NewCloudResourceMetadata(provider)
 }
 
 // InferSeverityFromCVE attempts to extract severity from CVE identifier.
@@ -28,7 +28,7 @@ func NewParser(logger logx.Logger, sourceName string) *Parser {
 // A single host response can generate:
 // - IP artifact (with IPMetadata)
 // - Subdomain artifacts (from hostnames)
-// - Port artifact
+// - Port artifact (ip:port, IP -listens_on-> Port -serves-> Service)
 // - Service artifact (with ServiceMetadata)
 // - Vulnerability artifacts (from vulns list)
 // - Certificate artifact (if SSL present)
@@ -38,8 +38,9 @@ func (p *Parser) ParseHostResponse(resp *ShodanHostResponse, target domain.Targe
 	artifacts := make([]*domain.Artifact, 0, 10)
 
 	// 1. Create IP artifact with rich metadata
+	var ipArtifact *domain.Artifact
 	if resp.IPStr != "" {
-		ipArtifact := p.createIPArtifact(resp, target)
+		ipArtifact = p.createIPArtifact(resp, target)
 		if ipArtifact != nil {
 			artifacts = append(artifacts, ipArtifact)
 		}
@@ -69,20 +70,21 @@ func (p *Parser) ParseHostResponse(resp *ShodanHostResponse, target domain.Targe
 		}
 	}
 
-	// 4. Create port artifact
-	if resp.Port > 0 {
-		portValue := fmt.Sprintf("%s:%d", resp.IPStr, resp.Port)
-		portArtifact := domain.NewArtifact(
-			domain.ArtifactTypePort,
-			portValue,
-			p.sourceName,
-		)
-		artifacts = append(artifacts, portArtifact)
-	}
+	// 4. Create port artifact (ip:port) and service artifact:
+	// IP -listens_on-> Port -serves-> Service
+	if resp.Port > 0 && resp.IPStr != "" {
+		portArtifact := domain.NewPortArtifact(resp.IPStr, resp.Port, "", p.sourceName)
+		if ipArtifact != nil {
+			ipArtifact.AddRelation(portArtifact.ID, domain.RelationListensOn, 1.0, p.sourceName)
+		}
 
-	// 5. Create service artifact with detailed metadata
-	if resp.Port > 0 {
+		// 5. Create service artifact with detailed metadata
 		serviceArtifact := p.createServiceArtifact(resp, target)
+		if serviceArtifact != nil {
+			portArtifact.AddRelation(serviceArtifact.ID, domain.RelationServes, 1.0, p.sourceName)
+		}
+
+		artifacts = append(artifacts, portArtifact)
 		if serviceArtifact != nil {
 			artifacts = append(artifacts, serviceArtifact)
 		}
@@ -205,8 +207,9 @@ func (p *Parser) createIPArtifact(resp *ShodanHostResponse, target domain.Target
 		ipMeta.ServicesSummary = []metadata.ServiceSummary{serviceSummary}
 	}
 
+	ipMeta.IPVersion = domain.IPVersion(resp.IPStr)
 	return domain.NewArtifactWithMetadata(
-		domain.ArtifactTypeIP,
+		domain.IPArtifactType(resp.IPStr),
 		resp.IPStr,
 		p.sourceName,
 		ipMeta,
@@ -348,6 +351,7 @@ func (p *Parser) createCloudArtifact(resp *ShodanHostResponse, target domain.Tar
 	cloudMeta := NewCloudMetadata(resp.Cloud.Provider)
 	cloudMeta.Service = resp.Cloud.Service
 	cloudMeta.Region = resp.Cloud.Region
+	cloudMeta.PublicIP = resp.IPStr
 	cloudMeta.Tags = resp.Tags
 
 	value := fmt.Sprintf("%s:%s", resp.Cloud.Provider, resp.IPStr)
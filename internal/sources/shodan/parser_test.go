@@ -132,6 +132,36 @@ func TestParser_ParseHostResponse(t *testing.T) {
 	}
 }
 
+func TestParser_ParseHostResponse_PortRelations(t *testing.T) {
+	parser := NewParser(logx.New(), "shodan")
+	target := domain.Target{Root: "example.com"}
+
+	artifacts := parser.ParseHostResponse(&ShodanHostResponse{
+		IPStr:   "93.184.216.34",
+		Port:    22,
+		Product: "OpenSSH",
+	}, target)
+
+	byType := make(map[domain.ArtifactType]*domain.Artifact)
+	for _, a := range artifacts {
+		byType[a.Type] = a
+	}
+
+	ip, port, service := byType[domain.ArtifactTypeIP], byType[domain.ArtifactTypePort], byType[domain.ArtifactTypeService]
+	if ip == nil || port == nil || service == nil {
+		t.Fatalf("expected IP, port and service artifacts, got %v", byType)
+	}
+	if port.Value != "93.184.216.34:22" || !port.IsValid() {
+		t.Errorf("expected valid port '93.184.216.34:22', got %q", port.Value)
+	}
+	if !ip.HasRelation(port.ID, domain.RelationListensOn) {
+		t.Error("expected IP -listens_on-> port relation")
+	}
+	if !port.HasRelation(service.ID, domain.RelationServes) {
+		t.Error("expected port -serves-> service relation")
+	}
+}
+
 func TestParser_ParseDomainResponse(t *testing.T) {
 	logger := logx.New()
 	parser := NewParser(logger, "shodan")
@@ -2,7 +2,7 @@
 package shodan
 
 import (
-	"time"
+	"fmt"
 
 	"aethonx/internal/core/domain"
 	"aethonx/internal/core/ports"
@@ -10,6 +10,23 @@ import (
 	"aethonx/internal/platform/registry"
 )
 
+// configSchema declares the Shodan Custom options.
+var configSchema = []ports.ConfigField{
+	{Name: "api_key", Type: ports.ConfigTypeString, Credential: true, Description: "Shodan API key (API mode)"},
+	{Name: "use_cli", Type: ports.ConfigTypeBool, Default: false, Description: "Use the shodan CLI (its own key) instead of the API"},
+	{Name: "timeout", Type: ports.ConfigTypeDuration, Default: defaultTimeout, Description: "Per-request timeout"},
+	{Name: "rate_limit", Type: ports.ConfigTypeFloat, Default: defaultRateLimit, Description: "Max queries per second"},
+}
+
+// dependency declares the shodan CLI, needed only with use_cli.
+var dependency = &ports.ToolDependency{
+	Binary:      "shodan",
+	PipPackage:  "shodan",
+	VersionArgs: []string{"version"},
+	MinVersion:  "1.30.0",
+	Notes:       "Requires Python 3 and pip. Initialize with: shodan init YOUR_API_KEY",
+}
+
 // Auto-registration: This init() function is called when the package is imported.
 // It registers the Shodan source with the global registry.
 func init() {
@@ -32,6 +49,7 @@ func init() {
 			InputArtifacts: []domain.ArtifactType{}, // Stage 0: No input dependencies
 			OutputArtifacts: []domain.ArtifactType{
 				domain.ArtifactTypeIP,
+				domain.ArtifactTypeIPv6,
 				domain.ArtifactTypeSubdomain,
 				domain.ArtifactTypePort,
 				domain.ArtifactTypeService,
@@ -45,6 +63,11 @@ func init() {
 			// Priority: After crtsh (10), before subfinder (20)
 			Priority:  12,
 			StageHint: 0, // Stage 0: Early passive reconnaissance
+			Phase:     ports.PhaseEnrichment,
+
+			ConfigSchema: configSchema,
+			Dependency:   dependency,
+			Providers:    []string{"api.shodan.io"},
 		},
 	); err != nil {
 		// Log error but don't panic - allow application to start
@@ -53,13 +76,16 @@ func init() {
 }
 
 // factory creates a new Shodan source instance from configuration.
-// It uses registry helpers for type-safe config extraction.
+// Custom is decoded and validated against configSchema.
 func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
-	// Extract configuration using type-safe registry helpers
-	apiKey := registry.GetStringConfig(cfg.Custom, "api_key", "")
-	useCLI := registry.GetBoolConfig(cfg.Custom, "use_cli", false)
-	timeout := registry.GetDurationConfig(cfg.Custom, "timeout", 60*time.Second)
-	rateLimit := registry.GetFloat64Config(cfg.Custom, "rate_limit", 1.0)
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("shodan config: %w", err)
+	}
+	apiKey := opts.String("api_key")
+	useCLI := opts.Bool("use_cli")
+	timeout := opts.Duration("timeout")
+	rateLimit := opts.Float("rate_limit")
 
 	logger.Debug("creating shodan source",
 		"use_cli", useCLI,
@@ -5,9 +5,11 @@ package shodan
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
 	"aethonx/internal/platform/logx"
 )
 
@@ -229,6 +231,10 @@ func (s *ShodanSource) Close() error {
 	return nil
 }
 
+// SetLogger replaces the logger with the scan-scoped one (scan_id, stage, source).
+// Implements ports.LogScopedSource.
+func (s *ShodanSource) SetLogger(logger logx.Logger) { s.logger = logger }
+
 // Initialize verifies that the source is properly configured.
 // Implements ports.AdvancedSource.
 func (s *ShodanSource) Initialize() error {
@@ -286,6 +292,40 @@ func (s *ShodanSource) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
+// Diagnostics implements ports.DiagnosticSource: the CLI version in CLI mode,
+// or the plan and remaining credits of the API key in API mode.
+func (s *ShodanSource) Diagnostics(ctx context.Context) (ports.SourceDiagnostics, error) {
+	if s.useCLI {
+		return s.cliExec.Diagnostics(ctx)
+	}
+
+	if s.apiClient == nil {
+		return ports.SourceDiagnostics{}, fmt.Errorf("API client not initialized")
+	}
+
+	info, err := s.apiClient.GetAPIInfo(ctx)
+	if err != nil {
+		return ports.SourceDiagnostics{}, err
+	}
+
+	return ports.SourceDiagnostics{Quota: formatAPIQuota(info)}, nil
+}
+
+// formatAPIQuota summarizes the /api-info response, e.g.
+// "plan dev, query_credits=100, scan_credits=100".
+func formatAPIQuota(info map[string]interface{}) string {
+	parts := make([]string, 0, 3)
+	if plan, ok := info["plan"].(string); ok && plan != "" {
+		parts = append(parts, "plan "+plan)
+	}
+	for _, key := range []string{"query_credits", "scan_credits"} {
+		if v, ok := info[key].(float64); ok {
+			parts = append(parts, fmt.Sprintf("%s=%d", key, int(v)))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 // Stream implements ports.StreamingSource for real-time artifact emission.
 // This delegates to the default stream implementation.
 func (s *ShodanSource) Stream(ctx context.Context, target domain.Target) (<-chan *domain.Artifact, <-chan error) {
@@ -0,0 +1,22 @@
+// internal/sources/shodan/shodan_test.go
+package shodan
+
+import "testing"
+
+func TestFormatAPIQuota(t *testing.T) {
+	info := map[string]interface{}{
+		"plan":          "dev",
+		"query_credits": float64(100),
+		"scan_credits":  float64(5),
+		"unlocked":      true,
+	}
+
+	want := "plan dev, query_credits=100, scan_credits=5"
+	if got := formatAPIQuota(info); got != want {
+		t.Errorf("formatAPIQuota() = %q, want %q", got, want)
+	}
+
+	if got := formatAPIQuota(map[string]interface{}{}); got != "" {
+		t.Errorf("formatAPIQuota(empty) = %q, want empty", got)
+	}
+}
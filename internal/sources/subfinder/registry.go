@@ -1,12 +1,46 @@
 package subfinder
 
 import (
+	"fmt"
+
 	"aethonx/internal/core/domain"
 	"aethonx/internal/core/ports"
 	"aethonx/internal/platform/logx"
 	"aethonx/internal/platform/registry"
+	"aethonx/internal/sources/common"
 )
 
+// defaultSources are the free subfinder sources used when none are configured.
+var defaultSources = []string{"alienvault", "anubis", "commoncrawl", "crtsh", "digitorus", "dnsdumpster", "hackertarget", "rapiddns", "sitedossier", "waybackarchive"}
+
+// dockerImage is the pinned image used with runtime: docker.
+const dockerImage = "projectdiscovery/subfinder:v2.6.6"
+
+// configSchema declares the subfinder Custom options.
+var configSchema = append([]ports.ConfigField{
+	{Name: "exec_path", Type: ports.ConfigTypeString, Default: "subfinder", Description: "Path to the subfinder binary"},
+	{Name: "all_sources", Type: ports.ConfigTypeBool, Default: true, Description: "Use all subfinder sources"},
+	{Name: "sources", Type: ports.ConfigTypeStringList, Default: defaultSources, Description: "Subfinder sources to query"},
+	{Name: "threads", Type: ports.ConfigTypeInt, Default: defaultThreads, Description: "Concurrent goroutines"},
+	{Name: "rate_limit", Type: ports.ConfigTypeInt, Default: 0, Description: "Max requests per second (0 = unlimited)"},
+}, common.RuntimeFields(dockerImage)...)
+
+// dependency declares the subfinder binary for "aethonx deps".
+var dependency = &ports.ToolDependency{
+	Binary: "subfinder",
+	Repo:   "projectdiscovery/subfinder",
+	AssetPatterns: map[string]string{
+		"linux_amd64":   "subfinder_*_linux_amd64.zip",
+		"linux_arm64":   "subfinder_*_linux_arm64.zip",
+		"darwin_amd64":  "subfinder_*_macOS_amd64.zip",
+		"darwin_arm64":  "subfinder_*_macOS_arm64.zip",
+		"windows_amd64": "subfinder_*_windows_amd64.zip",
+	},
+	ChecksumAsset: "subfinder_*checksums.txt",
+	VersionMarker: "Current Version",
+	MinVersion:    "2.6.0",
+}
+
 // Auto-registration on package import using registry helpers
 func init() {
 	if err := registry.Global().Register(
@@ -29,6 +63,9 @@ func init() {
 			},
 			Priority:  10, // High priority (passive discovery, same as crtsh)
 			StageHint: 0,  // Stage 0 explicit
+
+			ConfigSchema: configSchema,
+			Dependency:   dependency,
 		},
 	); err != nil {
 		// Log error but don't panic - allow application to start
@@ -36,13 +73,16 @@ func init() {
 	}
 }
 
-// factory creates a new SubfinderSource from SourceConfig using registry helpers
+// factory creates a new SubfinderSource from SourceConfig, decoding Custom against configSchema
 func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
-	// Extract custom config using registry helpers (type-safe, no manual nil checks)
-	execPath := registry.GetStringConfig(cfg.Custom, "exec_path", "subfinder")
-	threads := registry.GetIntConfig(cfg.Custom, "threads", defaultThreads)
-	rateLimit := registry.GetIntConfig(cfg.Custom, "rate_limit", 0)
-	sources := registry.GetSliceConfig(cfg.Custom, "sources", []string{"alienvault", "anubis", "commoncrawl", "crtsh", "digitorus", "dnsdumpster", "hackertarget", "rapiddns", "sitedossier", "waybackarchive"})
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("subfinder config: %w", err)
+	}
+	execPath := opts.String("exec_path")
+	threads := opts.Int("threads")
+	rateLimit := opts.Int("rate_limit")
+	sources := opts.Strings("sources")
 
 	// Use configured timeout or default
 	timeout := cfg.Timeout
@@ -50,5 +90,12 @@ func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
 		timeout = defaultTimeout
 	}
 
-	return NewWithConfig(logger, execPath, timeout, threads, rateLimit, sources), nil
+	rt, container, err := common.RuntimeFromOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("subfinder config: %w", err)
+	}
+
+	source := NewWithConfig(logger, execPath, timeout, threads, rateLimit, sources)
+	source.SetRuntime(rt, container)
+	return source, nil
 }
@@ -135,6 +135,7 @@ func (s *SubfinderSource) Run(ctx context.Context, target domain.Target) (*domai
 	for _, artifact := range artifacts {
 		result.AddArtifact(artifact)
 	}
+	result.SetProvenanceQuery(s.Name(), s.CommandLine(args))
 
 	// Log warning if responses were found but no artifacts created (filtered out)
 	if len(handler.responses) > 0 && len(result.Artifacts) == 0 {
@@ -166,6 +167,26 @@ func (s *SubfinderSource) Run(ctx context.Context, target domain.Target) (*domai
 	return result, nil
 }
 
+// Replay rebuilds the result from the stdout captured by a previous run.
+// Implements ports.ReplayableSource.
+func (s *SubfinderSource) Replay(ctx context.Context, target domain.Target, dir string) (*domain.ScanResult, error) {
+	handler := &subfinderHandler{
+		parser:    s.parser,
+		target:    target,
+		logger:    s.GetLogger(),
+		responses: make([]*SubfinderResponse, 0, 100),
+	}
+	if err := common.ReplayOutput(dir, handler); err != nil {
+		return nil, err
+	}
+
+	result := domain.NewScanResult(target)
+	for _, artifact := range s.parser.ParseMultipleResponses(handler.responses, target) {
+		result.AddArtifact(artifact)
+	}
+	return result, nil
+}
+
 // subfinderHandler implements common.OutputHandler for subfinder JSON output processing.
 type subfinderHandler struct {
 	parser    *Parser
@@ -198,6 +219,14 @@ func (h *subfinderHandler) ProcessLine(line []byte) error {
 	return nil
 }
 
+// Progress returns the number of subdomains parsed so far.
+// Implements common.ProgressCounter.
+func (h *subfinderHandler) Progress() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.responses)
+}
+
 // Finalize is called after all lines are processed.
 func (h *subfinderHandler) Finalize() error {
 	h.mu.Lock()
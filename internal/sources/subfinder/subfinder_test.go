@@ -6,6 +6,7 @@ import (
 
 	"aethonx/internal/core/domain"
 	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil/sourcetest"
 )
 
 func TestSubfinder_Name(t *testing.T) {
@@ -195,3 +196,13 @@ func TestJoinSources(t *testing.T) {
 		})
 	}
 }
+
+// TestSubfinder_ReplayGolden replays a recorded stdout capture
+func TestSubfinder_ReplayGolden(t *testing.T) {
+	source := New(logx.NewSilent())
+	defer source.Close()
+
+	target := domain.NewTarget("example.com", domain.ScanModePassive)
+	artifacts := sourcetest.Replay(t, source, *target, "testdata/capture")
+	sourcetest.Golden(t, "testdata/capture.golden", artifacts)
+}
@@ -0,0 +1,272 @@
+// internal/sources/tlsgrade/grade.go
+package tlsgrade
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"slices"
+	"time"
+
+	"aethonx/internal/platform/budget"
+	"aethonx/internal/platform/politeness"
+)
+
+// Problemas de la cadena de certificados (CertificateMetadata.ChainIssues)
+const (
+	issueExpired          = "expired"
+	issueNotYetValid      = "not-yet-valid"
+	issueSelfSigned       = "self-signed"
+	issueUntrusted        = "untrusted"
+	issueHostnameMismatch = "hostname-mismatch"
+	issueWeakKey          = "weak-key"
+	issueWeakSignature    = "weak-signature"
+)
+
+// Notas, de mejor a peor
+const (
+	gradeA = "A"
+	gradeB = "B"
+	gradeC = "C"
+	gradeF = "F"
+)
+
+// errProbeBudget indica que --budget requests no permite más handshakes.
+var errProbeBudget = errors.New("request budget exhausted")
+
+// probedVersions son las versiones que se prueban, de la más antigua a la
+// más reciente (SSLv3 no lo soporta crypto/tls).
+var probedVersions = []uint16{tls.VersionTLS10, tls.VersionTLS11, tls.VersionTLS12, tls.VersionTLS13}
+
+// weakSuites son las suites que se consideran débiles: las que crypto/tls
+// marca como inseguras (RC4, 3DES, CBC con SHA-256, RSA sin ECDHE+SHA-256).
+func weakSuites() []uint16 {
+	var ids []uint16
+	for _, suite := range tls.InsecureCipherSuites() {
+		ids = append(ids, suite.ID)
+	}
+	return ids
+}
+
+// hostReport es el resultado del análisis TLS de un host:puerto.
+type hostReport struct {
+	versions    []string // Versiones aceptadas, de la más antigua a la más reciente
+	weakCiphers []string
+	chain       []*x509.Certificate // Cadena presentada (hoja primero)
+	issues      []string
+	grade       string
+}
+
+// prober hace los handshakes de análisis contra un servidor.
+type prober struct {
+	dialer  net.Dialer
+	timeout time.Duration
+	roots   *x509.CertPool // nil = raíces del sistema
+	now     func() time.Time
+	ciphers bool // Probar suites débiles
+}
+
+// analyze prueba las versiones y suites que acepta addr (host:port), valida
+// la cadena para serverName y calcula la nota.
+func (p *prober) analyze(ctx context.Context, addr, serverName string) (*hostReport, error) {
+	report := &hostReport{}
+
+	var state *tls.ConnectionState
+	for _, version := range probedVersions {
+		cs, err := p.handshake(ctx, addr, &tls.Config{
+			ServerName: serverName,
+			MinVersion: version,
+			MaxVersion: version,
+		})
+		if errors.Is(err, errProbeBudget) || ctx.Err() != nil {
+			return nil, errors.Join(err, ctx.Err())
+		}
+		if err != nil {
+			continue
+		}
+		report.versions = append(report.versions, tls.VersionName(version))
+		state = cs
+	}
+	if state == nil {
+		return nil, errors.New("no TLS version accepted")
+	}
+	report.chain = state.PeerCertificates
+
+	// Las suites configurables son las de TLS 1.0-1.2
+	if p.ciphers && slices.ContainsFunc(report.versions, func(v string) bool { return v != tls.VersionName(tls.VersionTLS13) }) {
+		weak, err := p.weakCiphers(ctx, addr, serverName)
+		if err != nil {
+			return nil, err
+		}
+		report.weakCiphers = weak
+	}
+
+	report.issues = p.chainIssues(report.chain, serverName)
+	report.grade = grade(report)
+	return report, nil
+}
+
+// weakCiphers ofrece solo suites débiles (TLS <= 1.2) y va retirando la que
+// negocia el servidor hasta que rechaza el resto.
+func (p *prober) weakCiphers(ctx context.Context, addr, serverName string) ([]string, error) {
+	offered := weakSuites()
+	var accepted []string
+	for len(offered) > 0 {
+		cs, err := p.handshake(ctx, addr, &tls.Config{
+			ServerName:   serverName,
+			MinVersion:   tls.VersionTLS10,
+			MaxVersion:   tls.VersionTLS12,
+			CipherSuites: offered,
+		})
+		if errors.Is(err, errProbeBudget) || ctx.Err() != nil {
+			return nil, errors.Join(err, ctx.Err())
+		}
+		if err != nil {
+			break
+		}
+		accepted = append(accepted, tls.CipherSuiteName(cs.CipherSuite))
+		offered = slices.DeleteFunc(offered, func(id uint16) bool { return id == cs.CipherSuite })
+	}
+	return accepted, nil
+}
+
+// handshake conecta con addr y completa un handshake con cfg, sin verificar
+// la cadena (se valida aparte). Cuenta en --budget requests y respeta
+// --polite.
+func (p *prober) handshake(ctx context.Context, addr string, cfg *tls.Config) (*tls.ConnectionState, error) {
+	if budget.Shared().Reserve(budget.Requests, 1) == 0 {
+		return nil, errProbeBudget
+	}
+	release, err := politeness.Shared().Acquire(ctx, cfg.ServerName)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	conn, err := p.dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	cfg.InsecureSkipVerify = true
+	client := tls.Client(conn, cfg)
+	if err := client.HandshakeContext(ctx); err != nil {
+		return nil, err
+	}
+	state := client.ConnectionState()
+	return &state, nil
+}
+
+// chainIssues valida la cadena presentada contra las raíces y serverName, y
+// revisa la clave y la firma de la hoja.
+func (p *prober) chainIssues(chain []*x509.Certificate, serverName string) []string {
+	if len(chain) == 0 {
+		return []string{issueUntrusted}
+	}
+	leaf := chain[0]
+	now := p.now()
+
+	var issues []string
+	switch {
+	case now.After(leaf.NotAfter):
+		issues = append(issues, issueExpired)
+	case now.Before(leaf.NotBefore):
+		issues = append(issues, issueNotYetValid)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	// La caducidad ya se ha comprobado: se verifica en una fecha válida para
+	// no confundirla con una cadena no confiable
+	verifyAt := now
+	if now.After(leaf.NotAfter) || now.Before(leaf.NotBefore) {
+		verifyAt = leaf.NotBefore.Add(leaf.NotAfter.Sub(leaf.NotBefore) / 2)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         p.roots,
+		Intermediates: intermediates,
+		CurrentTime:   verifyAt,
+	}); err != nil {
+		if isSelfSigned(leaf) {
+			issues = append(issues, issueSelfSigned)
+		} else {
+			issues = append(issues, issueUntrusted)
+		}
+	}
+	if net.ParseIP(serverName) == nil && leaf.VerifyHostname(serverName) != nil {
+		issues = append(issues, issueHostnameMismatch)
+	}
+
+	if weakKey(leaf) {
+		issues = append(issues, issueWeakKey)
+	}
+	switch leaf.SignatureAlgorithm {
+	case x509.MD5WithRSA, x509.SHA1WithRSA, x509.ECDSAWithSHA1, x509.DSAWithSHA1:
+		issues = append(issues, issueWeakSignature)
+	}
+	return issues
+}
+
+// grade calcula la nota, inspirada en la de SSL Labs:
+//   - F: cadena no válida (caducada, no confiable, autofirmada o de otro host)
+//   - C: suites débiles o ninguna versión moderna (TLS 1.2+)
+//   - B: TLS 1.0/1.1 aceptados, o clave o firma débiles
+//   - A: el resto
+func grade(r *hostReport) string {
+	for _, issue := range r.issues {
+		switch issue {
+		case issueExpired, issueNotYetValid, issueSelfSigned, issueUntrusted, issueHostnameMismatch:
+			return gradeF
+		}
+	}
+	modern := slices.ContainsFunc(r.versions, func(v string) bool { return !isLegacyVersion(v) })
+	if len(r.weakCiphers) > 0 || !modern {
+		return gradeC
+	}
+	if slices.ContainsFunc(r.versions, isLegacyVersion) || len(r.issues) > 0 {
+		return gradeB
+	}
+	return gradeA
+}
+
+// isLegacyVersion indica si version es TLS 1.0 o 1.1.
+func isLegacyVersion(version string) bool {
+	return version == tls.VersionName(tls.VersionTLS10) || version == tls.VersionName(tls.VersionTLS11)
+}
+
+// isSelfSigned indica si cert se firma a sí mismo (solo es un problema si no
+// está entre las raíces de confianza).
+func isSelfSigned(cert *x509.Certificate) bool {
+	return cert.Subject.String() == cert.Issuer.String() && cert.CheckSignatureFrom(cert) == nil
+}
+
+// weakKey indica si la clave pública es RSA < 2048 bits o ECDSA < 256.
+func weakKey(cert *x509.Certificate) bool {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return key.N.BitLen() < 2048
+	case *ecdsa.PublicKey:
+		return key.Curve.Params().BitSize < 256
+	}
+	return false
+}
+
+// keySize retorna el tamaño en bits de la clave pública (0 si se desconoce).
+func keySize(cert *x509.Certificate) int {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return key.N.BitLen()
+	case *ecdsa.PublicKey:
+		return key.Curve.Params().BitSize
+	}
+	return 0
+}
@@ -0,0 +1,363 @@
+// internal/sources/tlsgrade/tlsgrade.go
+package tlsgrade
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+)
+
+const (
+	sourceName      = "tlsgrade"
+	defaultWorkers  = 10
+	defaultMaxHosts = 100
+	defaultTimeout  = 5 * time.Second
+)
+
+// configSchema declara las opciones Custom de tlsgrade.
+var configSchema = []ports.ConfigField{
+	{Name: "workers", Type: ports.ConfigTypeInt, Default: defaultWorkers, Description: "Hosts analyzed in parallel (1-100)"},
+	{Name: "max_hosts", Type: ports.ConfigTypeInt, Default: defaultMaxHosts, Description: "Max HTTPS hosts analyzed (0 = all)"},
+	{Name: "timeout", Type: ports.ConfigTypeDuration, Default: defaultTimeout.String(), Description: "Dial and handshake timeout"},
+	{Name: "weak_ciphers", Type: ports.ConfigTypeBool, Default: true, Description: "Probe weak cipher suites on TLS 1.0-1.2"},
+}
+
+// Auto-registro de la source al importar el package
+func init() {
+	if err := registry.Global().Register(
+		sourceName,
+		factory,
+		ports.SourceMetadata{
+			Name:         sourceName,
+			Description:  "TLS configuration grading (protocol versions, weak ciphers, certificate chain) of alive HTTPS hosts",
+			Version:      "1.0.0",
+			Author:       "AethonX",
+			Mode:         domain.SourceModeActive,
+			Type:         domain.SourceTypeBuiltin,
+			RequiresAuth: false,
+
+			// Consume las URLs https vivas de httpx (una por host:puerto)
+			InputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeURL,
+			},
+			// También reemite las URLs analizadas con la nota en su
+			// ServiceMetadata; no se declaran para que las demás sources que
+			// consumen URLs no esperen a este análisis.
+			OutputArtifacts: []domain.ArtifactType{
+				domain.ArtifactTypeCertificate,
+			},
+			Priority: 19,
+			Phase:    ports.PhaseEnrichment,
+
+			ConfigSchema: configSchema,
+		},
+	); err != nil {
+		logx.New().Warn("failed to register tlsgrade source", "error", err.Error())
+	}
+}
+
+// factory crea la source desde SourceConfig (Custom según configSchema).
+func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("tlsgrade config: %w", err)
+	}
+
+	workers := opts.Int("workers")
+	if workers <= 0 || workers > 100 {
+		return nil, fmt.Errorf("tlsgrade workers must be between 1 and 100, got %d", workers)
+	}
+	maxHosts := opts.Int("max_hosts")
+	if maxHosts < 0 {
+		return nil, fmt.Errorf("tlsgrade max_hosts cannot be negative, got %d", maxHosts)
+	}
+	timeout := opts.Duration("timeout")
+	if timeout <= 0 {
+		return nil, fmt.Errorf("tlsgrade timeout must be positive, got %s", timeout)
+	}
+
+	source := New(logger)
+	source.workers = workers
+	source.maxHosts = maxHosts
+	source.prober.timeout = timeout
+	source.prober.ciphers = opts.Bool("weak_ciphers")
+	return source, nil
+}
+
+// Source analiza la configuración TLS de los hosts HTTPS vivos con
+// crypto/tls: versiones aceptadas, suites débiles y validez de la cadena.
+// La nota (A, B, C, F) queda en el ServiceMetadata de la URL y el detalle
+// del certificado en un artifact certificate.
+type Source struct {
+	prober   *prober
+	workers  int
+	maxHosts int
+	logger   logx.Logger
+}
+
+// New crea la source tlsgrade.
+func New(logger logx.Logger) *Source {
+	return &Source{
+		prober: &prober{
+			timeout: defaultTimeout,
+			now:     time.Now,
+			ciphers: true,
+		},
+		workers:  defaultWorkers,
+		maxHosts: defaultMaxHosts,
+		logger:   logger.With("source", sourceName),
+	}
+}
+
+// Name retorna el nombre de la fuente.
+func (s *Source) Name() string { return sourceName }
+
+// Mode retorna el modo de operación (activo: handshakes contra el target).
+func (s *Source) Mode() domain.SourceMode { return domain.SourceModeActive }
+
+// Type retorna el tipo de fuente (builtin).
+func (s *Source) Type() domain.SourceType { return domain.SourceTypeBuiltin }
+
+// Close no libera recursos.
+func (s *Source) Close() error { return nil }
+
+// SetLogger sustituye el logger por el del escaneo (scan_id, stage, source).
+// Implementa ports.LogScopedSource.
+func (s *Source) SetLogger(logger logx.Logger) { s.logger = logger }
+
+// Run analiza solo el dominio raíz del target (https en el 443).
+func (s *Source) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	return s.analyzeAll(ctx, target, []string{"https://" + target.QueryName()})
+}
+
+// RunWithInput analiza un endpoint por host:puerto de las URLs https vivas
+// de input; sin ellas, el dominio raíz. Implementa ports.InputConsumer.
+func (s *Source) RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+	endpoints := s.httpsEndpoints(input, target)
+	if len(endpoints) == 0 {
+		return s.Run(ctx, target)
+	}
+	return s.analyzeAll(ctx, target, endpoints)
+}
+
+// httpsEndpoints retorna la primera URL https viva en scope de cada
+// host:puerto, limitadas a maxHosts.
+func (s *Source) httpsEndpoints(input *domain.ScanResult, target domain.Target) []string {
+	if input == nil {
+		return nil
+	}
+	byAddr := make(map[string]string)
+	for _, a := range input.Artifacts {
+		if a.Type != domain.ArtifactTypeURL || !a.IsAlive() {
+			continue
+		}
+		u, err := url.Parse(a.Value)
+		if err != nil || u.Scheme != "https" || !target.IsInScope(u.Hostname()) {
+			continue
+		}
+		addr := hostPort(u)
+		if prev, ok := byAddr[addr]; !ok || a.Value < prev {
+			byAddr[addr] = a.Value
+		}
+	}
+
+	endpoints := make([]string, 0, len(byAddr))
+	for _, rawURL := range byAddr {
+		endpoints = append(endpoints, rawURL)
+	}
+	sort.Strings(endpoints)
+	if s.maxHosts > 0 && len(endpoints) > s.maxHosts {
+		s.logger.Info("limiting analyzed hosts", "https", len(endpoints), "max", s.maxHosts)
+		endpoints = endpoints[:s.maxHosts]
+	}
+	return endpoints
+}
+
+// analysis es el resultado del análisis de una URL.
+type analysis struct {
+	url    *url.URL
+	raw    string
+	report *hostReport
+	err    error
+}
+
+// analyzeAll analiza las URLs con un pool de workers.
+func (s *Source) analyzeAll(ctx context.Context, target domain.Target, endpoints []string) (*domain.ScanResult, error) {
+	result := domain.NewScanResult(target)
+	result.Metadata.SourcesUsed = []string{sourceName}
+
+	jobs := make(chan string)
+	results := make(chan analysis, len(endpoints))
+
+	var wg sync.WaitGroup
+	workers := min(s.workers, len(endpoints))
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for raw := range jobs {
+				results <- s.analyze(ctx, raw)
+			}
+		}()
+	}
+
+feed:
+	for _, raw := range endpoints {
+		select {
+		case jobs <- raw:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	close(results)
+
+	analyses := make([]analysis, 0, len(endpoints))
+	for r := range results {
+		analyses = append(analyses, r)
+	}
+	sort.Slice(analyses, func(i, j int) bool { return analyses[i].raw < analyses[j].raw })
+
+	grades := make(map[string]int)
+	failures := 0
+	for _, r := range analyses {
+		if r.err != nil {
+			failures++
+			if errors.Is(r.err, errProbeBudget) {
+				result.AddWarning(sourceName, "request budget exhausted: "+r.raw+" not fully analyzed")
+			}
+			s.logger.Debug("TLS analysis failed", "url", r.raw, "error", r.err.Error())
+			continue
+		}
+		grades[r.report.grade]++
+		s.addArtifacts(result, r)
+	}
+	if failures > 0 {
+		result.AddWarning(sourceName, fmt.Sprintf("%d of %d HTTPS hosts could not be analyzed", failures, len(endpoints)))
+	}
+
+	s.logger.Info("TLS grading completed",
+		"hosts", len(endpoints),
+		"grade_a", grades[gradeA],
+		"grade_b", grades[gradeB],
+		"grade_c", grades[gradeC],
+		"grade_f", grades[gradeF],
+		"failures", failures,
+	)
+	return result, ctx.Err()
+}
+
+// analyze analiza el host:puerto de raw.
+func (s *Source) analyze(ctx context.Context, raw string) analysis {
+	r := analysis{raw: raw}
+	u, err := url.Parse(raw)
+	if err != nil {
+		r.err = err
+		return r
+	}
+	r.url = u
+	r.report, r.err = s.prober.analyze(ctx, hostPort(u), strings.ToLower(u.Hostname()))
+	return r
+}
+
+// addArtifacts añade la URL con la nota y el certificado de la hoja.
+func (s *Source) addArtifacts(result *domain.ScanResult, r analysis) {
+	port, _ := strconv.Atoi(r.url.Port())
+	if port == 0 {
+		port = 443
+	}
+	service := &metadata.ServiceMetadata{
+		Name:            "https",
+		Port:            port,
+		Protocol:        "tcp",
+		SSLEnabled:      true,
+		TLSGrade:        r.report.grade,
+		TLSVersions:     r.report.versions,
+		WeakCiphers:     r.report.weakCiphers,
+		DetectionMethod: "probe",
+		Confidence:      1.0,
+		ScanTool:        sourceName,
+	}
+	urlArtifact := domain.NewArtifactWithMetadata(domain.ArtifactTypeURL, r.raw, sourceName, service)
+
+	if len(r.report.chain) > 0 {
+		leaf := r.report.chain[0]
+		service.SSLCert = leaf.Subject.String()
+		cert := certificateArtifact(leaf, r.report.issues, s.prober.now())
+		urlArtifact.AddRelation(cert.ID, domain.RelationUsesCert, 1.0, sourceName)
+		result.AddArtifact(cert)
+	}
+	result.AddArtifact(urlArtifact)
+}
+
+// certificateArtifact crea el artifact del certificado de la hoja. El valor
+// es el serial en hexadecimal, como el de crtsh, para que se fusionen.
+func certificateArtifact(leaf *x509.Certificate, issues []string, now time.Time) *domain.Artifact {
+	serial := hex.EncodeToString(leaf.SerialNumber.Bytes())
+	sha256Sum := sha256.Sum256(leaf.Raw)
+	sha1Sum := sha1.Sum(leaf.Raw)
+
+	meta := &metadata.CertificateMetadata{
+		SerialNumber:       serial,
+		FingerprintSHA256:  hex.EncodeToString(sha256Sum[:]),
+		FingerprintSHA1:    hex.EncodeToString(sha1Sum[:]),
+		IssuerCN:           leaf.Issuer.CommonName,
+		IssuerFull:         leaf.Issuer.String(),
+		SubjectCN:          leaf.Subject.CommonName,
+		SubjectFull:        leaf.Subject.String(),
+		ValidFrom:          leaf.NotBefore.UTC().Format(time.RFC3339),
+		ValidUntil:         leaf.NotAfter.UTC().Format(time.RFC3339),
+		DaysRemaining:      int(leaf.NotAfter.Sub(now).Hours() / 24),
+		CertValid:          len(issues) == 0,
+		CertExpired:        now.After(leaf.NotAfter),
+		SANDomains:         leaf.DNSNames,
+		SANCount:           len(leaf.DNSNames),
+		SignatureAlgorithm: leaf.SignatureAlgorithm.String(),
+		PublicKeyAlgorithm: leaf.PublicKeyAlgorithm.String(),
+		KeySize:            keySize(leaf),
+		ChainIssues:        issues,
+	}
+	for _, issue := range issues {
+		switch issue {
+		case issueSelfSigned:
+			meta.IsSelfSigned = true
+		case issueWeakKey:
+			meta.WeakKey = true
+		case issueWeakSignature:
+			meta.WeakSignature = true
+		}
+	}
+	for _, name := range leaf.DNSNames {
+		if strings.HasPrefix(name, "*.") {
+			meta.WildcardCert = true
+		}
+	}
+	return domain.NewArtifactWithMetadata(domain.ArtifactTypeCertificate, serial, sourceName, meta)
+}
+
+// hostPort retorna host:puerto de u (443 si no lo indica).
+func hostPort(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+	return net.JoinHostPort(strings.ToLower(u.Hostname()), port)
+}
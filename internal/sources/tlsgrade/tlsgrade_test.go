@@ -0,0 +1,129 @@
+package tlsgrade
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/domain/metadata"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/testutil"
+)
+
+// startTLS arranca un servidor HTTPS con la configuración TLS indicada y
+// retorna también un pool que confía en su certificado.
+func startTLS(t *testing.T, cfg *tls.Config) (*httptest.Server, *x509.CertPool) {
+	t.Helper()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = cfg
+	server.Config.ErrorLog = log.New(io.Discard, "", 0) // Handshakes rechazados a propósito
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	return server, roots
+}
+
+func TestGrade(t *testing.T) {
+	tls10, tls12, tls13 := tls.VersionName(tls.VersionTLS10), tls.VersionName(tls.VersionTLS12), tls.VersionName(tls.VersionTLS13)
+
+	tests := []struct {
+		name   string
+		report hostReport
+		want   string
+	}{
+		{"modern", hostReport{versions: []string{tls12, tls13}}, gradeA},
+		{"legacy versions", hostReport{versions: []string{tls10, tls12}}, gradeB},
+		{"weak key", hostReport{versions: []string{tls13}, issues: []string{issueWeakKey}}, gradeB},
+		{"weak ciphers", hostReport{versions: []string{tls12}, weakCiphers: []string{"TLS_RSA_WITH_3DES_EDE_CBC_SHA"}}, gradeC},
+		{"legacy only", hostReport{versions: []string{tls10}}, gradeC},
+		{"expired", hostReport{versions: []string{tls13}, issues: []string{issueExpired}}, gradeF},
+		{"hostname mismatch", hostReport{versions: []string{tls13}, issues: []string{issueHostnameMismatch}}, gradeF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.AssertEqual(t, grade(&tt.report), tt.want, "grade")
+		})
+	}
+}
+
+func TestProber_Analyze(t *testing.T) {
+	// crypto/tls ya no sirve TLS 1.0/1.1 ni 3DES: la suite débil es CBC-SHA256
+	weak := tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256
+	server, roots := startTLS(t, &tls.Config{
+		CipherSuites: []uint16{weak, tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+	})
+	addr := server.Listener.Addr().String()
+
+	s := New(logx.New())
+	s.prober.roots = roots
+	report, err := s.prober.analyze(context.Background(), addr, "127.0.0.1")
+	testutil.AssertNoError(t, err, "analyze weak server")
+	testutil.AssertEqual(t, strings.Join(report.versions, ","), "TLS 1.2,TLS 1.3", "accepted versions")
+	testutil.AssertEqual(t, strings.Join(report.weakCiphers, ","), tls.CipherSuiteName(weak), "weak cipher found")
+	testutil.AssertEqual(t, len(report.issues), 0, "trusted chain")
+	testutil.AssertEqual(t, report.grade, gradeC, "weak ciphers grade")
+
+	// Sin la raíz del servidor la cadena es autofirmada
+	s.prober.roots = x509.NewCertPool()
+	report, err = s.prober.analyze(context.Background(), addr, "127.0.0.1")
+	testutil.AssertNoError(t, err, "analyze untrusted server")
+	testutil.AssertEqual(t, strings.Join(report.issues, ","), issueSelfSigned, "self-signed chain")
+	testutil.AssertEqual(t, report.grade, gradeF, "invalid chain grade")
+
+	report, err = s.prober.analyze(context.Background(), addr, "www.example.net")
+	testutil.AssertNoError(t, err, "analyze with another name")
+	testutil.AssertTrue(t, strings.Contains(strings.Join(report.issues, ","), issueHostnameMismatch), "hostname mismatch")
+}
+
+func TestSource_RunWithInput(t *testing.T) {
+	var _ ports.InputConsumer = (*Source)(nil)
+
+	server, roots := startTLS(t, &tls.Config{MinVersion: tls.VersionTLS12})
+
+	target := domain.NewTarget("127.0.0.1", domain.ScanModeActive)
+	input := domain.NewScanResult(*target)
+	for _, u := range []string{server.URL + "/login", server.URL, "http://127.0.0.1:1/"} {
+		a := domain.NewArtifact(domain.ArtifactTypeURL, u, "httpx")
+		a.AddTag("alive")
+		input.AddArtifact(a)
+	}
+
+	s := New(logx.New())
+	s.prober.roots = roots
+	result, err := s.RunWithInput(context.Background(), *target, input)
+	testutil.AssertNoError(t, err, "run")
+
+	var urlArtifact, cert *domain.Artifact
+	for _, a := range result.Artifacts {
+		switch a.Type {
+		case domain.ArtifactTypeURL:
+			urlArtifact = a
+		case domain.ArtifactTypeCertificate:
+			cert = a
+		}
+	}
+	testutil.AssertTrue(t, urlArtifact != nil, "graded URL")
+	testutil.AssertTrue(t, cert != nil, "certificate")
+	testutil.AssertEqual(t, urlArtifact.Value, server.URL, "one URL per host:port")
+	testutil.AssertTrue(t, urlArtifact.HasRelation(cert.ID, domain.RelationUsesCert), "URL uses certificate")
+
+	service, ok := urlArtifact.TypedMetadata.(*metadata.ServiceMetadata)
+	testutil.AssertTrue(t, ok, "service metadata")
+	testutil.AssertEqual(t, service.TLSGrade, gradeA, "modern server grade")
+	testutil.AssertEqual(t, len(service.WeakCiphers), 0, "no weak ciphers")
+
+	certMeta, ok := cert.TypedMetadata.(*metadata.CertificateMetadata)
+	testutil.AssertTrue(t, ok, "certificate metadata")
+	testutil.AssertTrue(t, certMeta.CertValid, "valid certificate")
+	testutil.AssertTrue(t, certMeta.FingerprintSHA256 != "", "fingerprint")
+}
@@ -1,13 +1,47 @@
 package waybackurls
 
 import (
+	"fmt"
+
 	"aethonx/internal/core/domain"
 	"aethonx/internal/core/ports"
 	"aethonx/internal/platform/logx"
 	"aethonx/internal/platform/registry"
 	"aethonx/internal/platform/urlfilter"
+	"aethonx/internal/sources/common"
 )
 
+// dockerImage is empty: there is no official image, so runtime: docker
+// needs docker_image.
+const dockerImage = ""
+
+// configSchema declares the waybackurls Custom options.
+var configSchema = append([]ports.ConfigField{
+	{Name: "exec_path", Type: ports.ConfigTypeString, Default: "waybackurls", Description: "Path to the waybackurls binary"},
+	{Name: "with_dates", Type: ports.ConfigTypeBool, Default: false, Description: "Request capture dates"},
+	{Name: "no_subs", Type: ports.ConfigTypeBool, Default: false, Description: "Exclude subdomains of the target"},
+	{Name: "drop_extensions", Type: ports.ConfigTypeStringList, Default: urlfilter.DefaultDropExtensions, Description: "Static asset extensions dropped before verification ([] keeps all)"},
+	{Name: "dedupe_signature", Type: ports.ConfigTypeBool, Default: false, Description: "Keep one URL per host, path and parameter names"},
+	{Name: "dedupe_query_values", Type: ports.ConfigTypeBool, Default: true, Description: "Collapse URLs differing only in IDs/UUIDs/hashes in query values"},
+}, common.RuntimeFields(dockerImage)...)
+
+// dependency declares the waybackurls binary for "aethonx deps".
+var dependency = &ports.ToolDependency{
+	Binary: "waybackurls",
+	Repo:   "tomnomnom/waybackurls",
+	AssetPatterns: map[string]string{
+		"linux_amd64":   "waybackurls-linux-amd64-*",
+		"linux_arm64":   "waybackurls-linux-arm64-*",
+		"darwin_amd64":  "waybackurls-darwin-amd64-*",
+		"darwin_arm64":  "waybackurls-darwin-arm64-*",
+		"windows_amd64": "waybackurls-windows-amd64-*.exe",
+	},
+	VersionArgs:   []string{"-h"}, // No version flag
+	VersionMarker: "Usage",
+	MinVersion:    "0.1.0",
+	// No ChecksumAsset: the releases publish no checksums
+}
+
 // Auto-registration on package import using registry helpers
 func init() {
 	if err := registry.Global().Register(
@@ -39,6 +73,9 @@ func init() {
 			},
 			Priority:  5, // High priority (passive discovery, early execution)
 			StageHint: 0, // Stage 0 explicit
+
+			ConfigSchema: configSchema,
+			Dependency:   dependency,
 		},
 	); err != nil {
 		// Log error but don't panic - allow application to start
@@ -46,12 +83,15 @@ func init() {
 	}
 }
 
-// factory creates a new WaybackurlsSource from SourceConfig using registry helpers
+// factory creates a new WaybackurlsSource from SourceConfig, decoding Custom against configSchema
 func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
-	// Extract custom config using registry helpers (type-safe, no manual nil checks)
-	execPath := registry.GetStringConfig(cfg.Custom, "exec_path", "waybackurls")
-	withDates := registry.GetBoolConfig(cfg.Custom, "with_dates", false)
-	noSubs := registry.GetBoolConfig(cfg.Custom, "no_subs", false)
+	opts, err := registry.DecodeConfig(configSchema, cfg.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("waybackurls config: %w", err)
+	}
+	execPath := opts.String("exec_path")
+	withDates := opts.Bool("with_dates")
+	noSubs := opts.Bool("no_subs")
 
 	// Use configured timeout or default
 	timeout := cfg.Timeout
@@ -59,8 +99,18 @@ func factory(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
 		timeout = defaultTimeout
 	}
 
-	// Use default filter config (can be customized via Custom map in the future)
+	// Default filter config with the configurable pre-filters
 	filterCfg := urlfilter.DefaultConfig()
+	filterCfg.DropExtensions = opts.Strings("drop_extensions")
+	filterCfg.DedupeParamSignature = opts.Bool("dedupe_signature")
+	filterCfg.DedupeQueryValues = opts.Bool("dedupe_query_values")
+
+	rt, container, err := common.RuntimeFromOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("waybackurls config: %w", err)
+	}
 
-	return NewWithConfig(logger, execPath, timeout, withDates, noSubs, filterCfg), nil
+	source := NewWithConfig(logger, execPath, timeout, withDates, noSubs, filterCfg)
+	source.SetRuntime(rt, container)
+	return source, nil
 }
@@ -147,6 +147,8 @@ func (w *WaybackurlsSource) Run(ctx context.Context, target domain.Target) (*dom
 		result.AddWarning("waybackurls", "scan completed but no URLs were found - target may not be archived in Wayback Machine")
 	}
 
+	result.SetProvenanceQuery(w.Name(), w.CommandLine(args))
+
 	// Log final statistics
 	duration := time.Since(startTime)
 	w.GetLogger().Info("waybackurls scan completed",
@@ -166,6 +168,25 @@ func (w *WaybackurlsSource) Run(ctx context.Context, target domain.Target) (*dom
 	return result, nil
 }
 
+// Replay rebuilds the result from the stdout captured by a previous run,
+// applying the current filters. Implements ports.ReplayableSource.
+func (w *WaybackurlsSource) Replay(ctx context.Context, target domain.Target, dir string) (*domain.ScanResult, error) {
+	result := domain.NewScanResult(target)
+	handler := &waybackurlsHandler{
+		parser:    w.parser,
+		filter:    w.filter,
+		filterCfg: w.filterCfg,
+		target:    target,
+		logger:    w.GetLogger(),
+		result:    result,
+		rawURLs:   make([]string, 0, 10000),
+	}
+	if err := common.ReplayOutput(dir, handler); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // waybackurlsHandler implements common.OutputHandler for waybackurls output processing.
 type waybackurlsHandler struct {
 	parser    *Parser
@@ -209,6 +230,14 @@ func (h *waybackurlsHandler) ProcessLine(line []byte) error {
 	return nil
 }
 
+// Progress returns the number of raw URLs collected so far (before filtering).
+// Implements common.ProgressCounter.
+func (h *waybackurlsHandler) Progress() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.rawURLs)
+}
+
 // Finalize is called after all lines are processed.
 func (h *waybackurlsHandler) Finalize() error {
 	h.mu.Lock()
@@ -238,6 +267,7 @@ func (h *waybackurlsHandler) Finalize() error {
 				"input", stats.InputURLs,
 				"output", stats.OutputURLs,
 				"reduction", fmt.Sprintf("%.1f%%", stats.ReductionRatio()),
+				"prefiltered", stats.PreFilterCollapsed(),
 				"duplicates", stats.DuplicatesSkipped,
 				"low_priority", stats.LowPrioritySkipped,
 				"clusters", stats.ClustersMerged,
@@ -251,6 +281,9 @@ func (h *waybackurlsHandler) Finalize() error {
 			h.result.Metadata.Environment["waybackurls_filter_input_urls"] = fmt.Sprintf("%d", stats.InputURLs)
 			h.result.Metadata.Environment["waybackurls_filter_output_urls"] = fmt.Sprintf("%d", stats.OutputURLs)
 			h.result.Metadata.Environment["waybackurls_filter_reduction_ratio"] = fmt.Sprintf("%.1f%%", stats.ReductionRatio())
+			h.result.Metadata.Environment["waybackurls_filter_static_assets"] = fmt.Sprintf("%d", stats.StaticAssetsDropped)
+			h.result.Metadata.Environment["waybackurls_filter_signature_collapsed"] = fmt.Sprintf("%d", stats.SignatureCollapsed)
+			h.result.Metadata.Environment["waybackurls_filter_values_collapsed"] = fmt.Sprintf("%d", stats.QueryValuesCollapsed)
 			h.result.Metadata.Environment["waybackurls_filter_duplicates"] = fmt.Sprintf("%d", stats.DuplicatesSkipped)
 			h.result.Metadata.Environment["waybackurls_filter_low_priority"] = fmt.Sprintf("%d", stats.LowPrioritySkipped)
 			h.result.Metadata.Environment["waybackurls_filter_clusters"] = fmt.Sprintf("%d", stats.ClustersMerged)
@@ -0,0 +1,178 @@
+// internal/testutil/sourcetest/mock_source.go
+package sourcetest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+)
+
+// MockSource es un ports.Source configurable para tests del orchestrator y
+// del pipeline sin red ni binarios. Emite sus artifacts de uno en uno (con
+// Delay entre ellos y progreso por ProgressChannel) y puede fallar.
+//
+// Implementa ports.ProgressSource e ports.InputConsumer; el input recibido
+// queda disponible en Input.
+type MockSource struct {
+	SourceName string
+	SourceMode domain.SourceMode
+	SourceType domain.SourceType
+
+	// Artifacts se emiten en cada ejecución (se clonan: cada Run es independiente)
+	Artifacts []*domain.Artifact
+
+	// Delay antes de emitir cada artifact; respeta la cancelación del contexto
+	Delay time.Duration
+
+	// Err se retorna tras emitir los artifacts (resultado parcial). Con
+	// FailTimes > 0 solo las primeras FailTimes ejecuciones fallan.
+	Err       error
+	FailTimes int
+
+	// RunFunc sustituye todo el comportamiento anterior si no es nil
+	RunFunc func(ctx context.Context, target domain.Target) (*domain.ScanResult, error)
+
+	mu         sync.Mutex
+	runs       int
+	closed     bool
+	input      *domain.ScanResult
+	progressCh chan ports.ProgressUpdate
+}
+
+// NewMockSource crea una source pasiva de tipo API que emite artifacts.
+func NewMockSource(name string, artifacts ...*domain.Artifact) *MockSource {
+	return &MockSource{
+		SourceName: name,
+		SourceMode: domain.SourceModePassive,
+		SourceType: domain.SourceTypeAPI,
+		Artifacts:  artifacts,
+	}
+}
+
+// Name implementa ports.Source.
+func (m *MockSource) Name() string { return m.SourceName }
+
+// Mode implementa ports.Source.
+func (m *MockSource) Mode() domain.SourceMode { return m.SourceMode }
+
+// Type implementa ports.Source.
+func (m *MockSource) Type() domain.SourceType { return m.SourceType }
+
+// Run implementa ports.Source.
+func (m *MockSource) Run(ctx context.Context, target domain.Target) (*domain.ScanResult, error) {
+	m.mu.Lock()
+	m.runs++
+	run := m.runs
+	m.mu.Unlock()
+
+	if m.RunFunc != nil {
+		return m.RunFunc(ctx, target)
+	}
+
+	result := domain.NewScanResult(target)
+	for i, artifact := range m.Artifacts {
+		if m.Delay > 0 {
+			select {
+			case <-ctx.Done():
+				return result, ctx.Err()
+			case <-time.After(m.Delay):
+			}
+		} else if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		result.AddArtifact(copyArtifact(artifact))
+		m.emit(ports.ProgressUpdate{ArtifactCount: i + 1, Message: artifact.Value})
+	}
+
+	if m.Err != nil && (m.FailTimes <= 0 || run <= m.FailTimes) {
+		return result, m.Err
+	}
+	return result, nil
+}
+
+// RunWithInput implementa ports.InputConsumer: guarda input y ejecuta Run.
+func (m *MockSource) RunWithInput(ctx context.Context, target domain.Target, input *domain.ScanResult) (*domain.ScanResult, error) {
+	m.mu.Lock()
+	m.input = input
+	m.mu.Unlock()
+	return m.Run(ctx, target)
+}
+
+// ProgressChannel implementa ports.ProgressSource.
+func (m *MockSource) ProgressChannel() <-chan ports.ProgressUpdate {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.progressCh == nil {
+		m.progressCh = make(chan ports.ProgressUpdate, 100)
+	}
+	return m.progressCh
+}
+
+// emit envía un progreso sin bloquear si nadie escucha.
+func (m *MockSource) emit(update ports.ProgressUpdate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.progressCh == nil || m.closed {
+		return
+	}
+	select {
+	case m.progressCh <- update:
+	default:
+	}
+}
+
+// Close implementa ports.Source.
+func (m *MockSource) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.closed && m.progressCh != nil {
+		close(m.progressCh)
+	}
+	m.closed = true
+	return nil
+}
+
+// Runs retorna cuántas veces se ejecutó la source.
+func (m *MockSource) Runs() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.runs
+}
+
+// Closed indica si se llamó a Close.
+func (m *MockSource) Closed() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.closed
+}
+
+// Input retorna el input de la última ejecución con RunWithInput (o nil).
+func (m *MockSource) Input() *domain.ScanResult {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.input
+}
+
+// copyArtifact copia artifact para que las fusiones del pipeline no alteren
+// el original entre ejecuciones.
+func copyArtifact(artifact *domain.Artifact) *domain.Artifact {
+	clone := *artifact
+	clone.Sources = append([]string(nil), artifact.Sources...)
+	clone.Tags = append([]string(nil), artifact.Tags...)
+	clone.Relations = append([]domain.ArtifactRelation(nil), artifact.Relations...)
+	clone.Provenance = append([]domain.Provenance(nil), artifact.Provenance...)
+	return &clone
+}
+
+// Artifacts construye artifacts de un tipo descubiertos por source.
+func Artifacts(typ domain.ArtifactType, source string, values ...string) []*domain.Artifact {
+	artifacts := make([]*domain.Artifact, 0, len(values))
+	for _, value := range values {
+		artifacts = append(artifacts, domain.NewArtifact(typ, value, source))
+	}
+	return artifacts
+}
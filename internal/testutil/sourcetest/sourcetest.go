@@ -0,0 +1,193 @@
+// internal/testutil/sourcetest/sourcetest.go
+
+// Package sourcetest es el arnés común de regresión de parsers: ejecuta el
+// parser de cualquier source sobre fixtures grabados (líneas JSON de httpx,
+// payloads de rdap, capturas de amass) y compara el conjunto de artifacts
+// resultante con un golden file en testdata/.
+//
+// Uso típico en el _test.go de una source:
+//
+//	func TestParser_Golden(t *testing.T) {
+//		for _, line := range sourcetest.Lines(t, "testdata/httpx.jsonl") {
+//			...
+//		}
+//		sourcetest.Golden(t, "testdata/httpx.golden", artifacts)
+//	}
+//
+// Los golden files se regeneran con: go test ./internal/sources/<source>/ -update
+package sourcetest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+)
+
+// update reescribe los golden files con la salida actual en vez de compararla.
+var update = flag.Bool("update", false, "rewrite sourcetest golden files")
+
+// Fixture lee un fixture grabado; falla el test si no existe.
+func Fixture(t testing.TB, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("fixture %s: %v", path, err)
+	}
+	return data
+}
+
+// Lines retorna las líneas no vacías de un fixture (JSONL, stdout de una
+// herramienta CLI), en orden.
+func Lines(t testing.TB, path string) [][]byte {
+	t.Helper()
+	var lines [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(Fixture(t, path)))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			lines = append(lines, append([]byte(nil), line...))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("fixture %s: %v", path, err)
+	}
+	return lines
+}
+
+// Replay ejecuta el replay de una source sobre un directorio de captura
+// grabado (el formato de --capture-raw) y retorna sus artifacts.
+func Replay(t testing.TB, source ports.ReplayableSource, target domain.Target, dir string) []*domain.Artifact {
+	t.Helper()
+	result, err := source.Replay(context.Background(), target, dir)
+	if err != nil {
+		t.Fatalf("%s replay of %s: %v", source.Name(), dir, err)
+	}
+	return result.Artifacts
+}
+
+// Golden compara el conjunto de artifacts con el golden file en path (ver
+// Format). Con -update lo reescribe.
+func Golden(t testing.TB, path string, artifacts []*domain.Artifact) {
+	t.Helper()
+	got := Format(artifacts)
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("golden %s: %v", path, err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("golden %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("golden %s: %v (run with -update to create it)", path, err)
+	}
+	if got != string(want) {
+		t.Errorf("artifacts differ from golden %s (run with -update to accept):\n%s", path, diff(string(want), got))
+	}
+}
+
+// AssertArtifacts verifica que artifacts contenga cada "tipo valor" de want.
+func AssertArtifacts(t testing.TB, artifacts []*domain.Artifact, want ...string) {
+	t.Helper()
+	have := make(map[string]bool, len(artifacts))
+	for _, a := range artifacts {
+		have[key(a)] = true
+	}
+	for _, w := range want {
+		if !have[w] {
+			t.Errorf("missing artifact %q", w)
+		}
+	}
+}
+
+// Format retorna la forma canónica de un conjunto de artifacts: una línea
+// "tipo valor [tags]" por artifact, ordenadas, seguida de sus relaciones
+// "  -> relación tipo valor". Es independiente del orden de salida del
+// parser, de timestamps y de duplicados.
+func Format(artifacts []*domain.Artifact) string {
+	byID := make(map[string]*domain.Artifact, len(artifacts))
+	for _, a := range artifacts {
+		if a != nil {
+			byID[a.ID] = a
+		}
+	}
+
+	blocks := make(map[string]bool, len(byID))
+	for _, a := range byID {
+		var b strings.Builder
+		b.WriteString(key(a))
+		if len(a.Tags) > 0 {
+			tags := append([]string(nil), a.Tags...)
+			sort.Strings(tags)
+			fmt.Fprintf(&b, " [%s]", strings.Join(tags, ","))
+		}
+		b.WriteByte('\n')
+
+		relations := make([]string, 0, len(a.Relations))
+		for _, rel := range a.Relations {
+			to := rel.TargetID
+			if target, ok := byID[rel.TargetID]; ok {
+				to = key(target)
+			}
+			relations = append(relations, fmt.Sprintf("  -> %s %s\n", rel.Type, to))
+		}
+		sort.Strings(relations)
+		for i, rel := range relations {
+			if i == 0 || rel != relations[i-1] {
+				b.WriteString(rel)
+			}
+		}
+		blocks[b.String()] = true
+	}
+
+	sorted := make([]string, 0, len(blocks))
+	for block := range blocks {
+		sorted = append(sorted, block)
+	}
+	sort.Strings(sorted)
+	return strings.Join(sorted, "")
+}
+
+func key(a *domain.Artifact) string {
+	return string(a.Type) + " " + a.Value
+}
+
+// diff lista las líneas que sobran (+) y faltan (-) respecto del golden.
+func diff(want, got string) string {
+	count := func(s string) map[string]int {
+		m := make(map[string]int)
+		for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+			m[line]++
+		}
+		return m
+	}
+	wantLines, gotLines := count(want), count(got)
+
+	var out []string
+	for line, n := range wantLines {
+		for i := gotLines[line]; i < n; i++ {
+			out = append(out, "- "+line)
+		}
+	}
+	for line, n := range gotLines {
+		for i := wantLines[line]; i < n; i++ {
+			out = append(out, "+ "+line)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i][2:] < out[j][2:] })
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,182 @@
+// internal/testutil/sourcetest/upstream.go
+package sourcetest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// CRTShRecord es un registro de la API JSON de crt.sh (NameValue admite
+// varios nombres separados por saltos de línea, como la API real).
+type CRTShRecord struct {
+	IssuerName   string `json:"issuer_name"`
+	NameValue    string `json:"name_value"`
+	NotAfter     string `json:"not_after"`
+	NotBefore    string `json:"not_before"`
+	SerialNumber string `json:"serial_number"`
+}
+
+// CRTShServer es un crt.sh falso: responde ?q=<patrón>&output=json con los
+// registros cuyo name_value encaja en el patrón LIKE (% = comodín), de modo
+// que la consulta completa y las consultas por chunks se comportan como en
+// crt.sh. Se cierra al terminar el test.
+type CRTShServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	records []CRTShRecord
+	status  int
+	queries []string
+}
+
+// NewCRTShServer arranca un crt.sh falso con records.
+func NewCRTShServer(t testing.TB, records ...CRTShRecord) *CRTShServer {
+	t.Helper()
+	s := &CRTShServer{records: records}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// BaseURL retorna el endpoint para crtsh.CRTConfig.BaseURL.
+func (s *CRTShServer) BaseURL() string {
+	return s.URL + "/"
+}
+
+// Add añade registros.
+func (s *CRTShServer) Add(records ...CRTShRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, records...)
+}
+
+// SetStatus hace que todas las consultas respondan status (p.ej. 503);
+// 0 o 200 restaura las respuestas normales.
+func (s *CRTShServer) SetStatus(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+// Queries retorna los patrones consultados, en orden.
+func (s *CRTShServer) Queries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.queries...)
+}
+
+func (s *CRTShServer) serve(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+
+	s.mu.Lock()
+	s.queries = append(s.queries, query)
+	status := s.status
+	matched := make([]CRTShRecord, 0)
+	for _, record := range s.records {
+		for _, name := range strings.Split(record.NameValue, "\n") {
+			if likeMatch(strings.ToLower(query), strings.ToLower(strings.TrimSpace(name))) {
+				matched = append(matched, record)
+				break
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	if status != 0 && status != http.StatusOK {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(matched)
+}
+
+// likeMatch evalúa un patrón LIKE de SQL en el que solo % es comodín.
+func likeMatch(pattern, value string) bool {
+	parts := strings.Split(pattern, "%")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+// RDAPServer es un servicio RDAP falso: responde /domain/<dominio> con el
+// payload registrado para el dominio o 404. Se cierra al terminar el test.
+type RDAPServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	payloads map[string][]byte
+	status   int
+	queries  []string
+}
+
+// NewRDAPServer arranca un servicio RDAP falso sin dominios.
+func NewRDAPServer(t testing.TB) *RDAPServer {
+	t.Helper()
+	s := &RDAPServer{payloads: make(map[string][]byte)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// BaseURL retorna el endpoint para rdap.NewWithBaseURL.
+func (s *RDAPServer) BaseURL() string {
+	return s.URL + "/"
+}
+
+// Set registra el payload RDAP (JSON) de domain, p.ej. un fixture grabado.
+func (s *RDAPServer) Set(domain string, payload []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.payloads[strings.ToLower(domain)] = payload
+}
+
+// SetStatus hace que todas las consultas respondan status (p.ej. 429);
+// 0 o 200 restaura las respuestas normales.
+func (s *RDAPServer) SetStatus(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+// Queries retorna los dominios consultados, en orden.
+func (s *RDAPServer) Queries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.queries...)
+}
+
+func (s *RDAPServer) serve(w http.ResponseWriter, r *http.Request) {
+	domain := strings.ToLower(strings.TrimPrefix(r.URL.Path, "/domain/"))
+
+	s.mu.Lock()
+	s.queries = append(s.queries, domain)
+	status := s.status
+	payload, ok := s.payloads[domain]
+	s.mu.Unlock()
+
+	switch {
+	case status != 0 && status != http.StatusOK:
+		w.WriteHeader(status)
+	case !ok:
+		http.NotFound(w, r)
+	default:
+		w.Header().Set("Content-Type", "application/rdap+json")
+		w.Write(payload)
+	}
+}
@@ -0,0 +1,27 @@
+// Package aethonx embeds the AethonX reconnaissance pipeline in other Go
+// programs without importing internal/.
+//
+//	scanner, err := aethonx.NewScanner(
+//		aethonx.WithSources("crtsh", "rdap"),
+//		aethonx.WithTimeout(5*time.Minute),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	defer scanner.Close()
+//
+//	result, err := scanner.Scan(ctx, "example.com")
+//	if err != nil {
+//		return err
+//	}
+//	for _, sub := range result.ByType(aethonx.TypeSubdomain) {
+//		fmt.Println(sub.Value)
+//	}
+//
+// Compatibility: this package follows semantic versioning. Within a major
+// version the exported identifiers of this package (Scanner, Option and the
+// With* constructors, Result, Artifact, Relation, Issue and ArtifactType) keep
+// their names, signatures and meaning; fields and options may be added.
+// Source names and artifact type values are data, not API: sources may be
+// added, and the set reported by Sources depends on the build.
+package aethonx
@@ -0,0 +1,129 @@
+// pkg/aethonx/options.go
+package aethonx
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Option configures a Scanner.
+type Option func(*settings) error
+
+// settings is the configuration collected from the options.
+type settings struct {
+	configFile    string
+	sources       []string
+	sourceOptions map[string]map[string]interface{}
+	active        bool
+	workers       int
+	timeout       time.Duration
+	exclusions    []string
+	logOutput     io.Writer
+	noResilience  bool
+	minConfidence float64
+}
+
+// WithSources runs only the named sources (see Sources). Without it the
+// sources enabled by default, or by the config file, run.
+func WithSources(names ...string) Option {
+	return func(s *settings) error {
+		if len(names) == 0 {
+			return fmt.Errorf("WithSources: no sources given")
+		}
+		s.sources = append(s.sources, names...)
+		return nil
+	}
+}
+
+// WithSourceOption sets a source-specific option, as the "custom" section of
+// the config file does (e.g. an API key).
+func WithSourceOption(source, key string, value interface{}) Option {
+	return func(s *settings) error {
+		if s.sourceOptions == nil {
+			s.sourceOptions = make(map[string]map[string]interface{})
+		}
+		if s.sourceOptions[source] == nil {
+			s.sourceOptions[source] = make(map[string]interface{})
+		}
+		s.sourceOptions[source][key] = value
+		return nil
+	}
+}
+
+// WithConfigFile loads the sources from an AethonX YAML config file, plus the
+// AETHONX_* environment, as "aethonx --config" does. Other options override it.
+func WithConfigFile(path string) Option {
+	return func(s *settings) error {
+		s.configFile = path
+		return nil
+	}
+}
+
+// WithActive enables active reconnaissance (HTTP probing, crawling, port
+// scanning). Only use it against targets you are authorized to test.
+func WithActive() Option {
+	return func(s *settings) error {
+		s.active = true
+		return nil
+	}
+}
+
+// WithWorkers sets how many sources run concurrently within a stage.
+func WithWorkers(n int) Option {
+	return func(s *settings) error {
+		if n < 1 {
+			return fmt.Errorf("WithWorkers: must be at least 1, got %d", n)
+		}
+		s.workers = n
+		return nil
+	}
+}
+
+// WithTimeout bounds every Scan; the partial result is returned on expiry.
+func WithTimeout(d time.Duration) Option {
+	return func(s *settings) error {
+		if d <= 0 {
+			return fmt.Errorf("WithTimeout: must be positive, got %s", d)
+		}
+		s.timeout = d
+		return nil
+	}
+}
+
+// WithExclusions keeps the given domains and their subdomains out of scope.
+func WithExclusions(domains ...string) Option {
+	return func(s *settings) error {
+		s.exclusions = append(s.exclusions, domains...)
+		return nil
+	}
+}
+
+// WithMinConfidence drops relations below the given confidence (0.0-1.0).
+func WithMinConfidence(c float64) Option {
+	return func(s *settings) error {
+		if c < 0 || c > 1 {
+			return fmt.Errorf("WithMinConfidence: must be between 0 and 1, got %g", c)
+		}
+		s.minConfidence = c
+		return nil
+	}
+}
+
+// WithLogOutput writes the pipeline logs as text to w. Scans are silent by
+// default.
+func WithLogOutput(w io.Writer) Option {
+	return func(s *settings) error {
+		s.logOutput = w
+		return nil
+	}
+}
+
+// WithoutRetries disables the retry and circuit breaker wrappers, so every
+// source runs exactly once per scan.
+func WithoutRetries() Option {
+	return func(s *settings) error {
+		s.noResilience = true
+		return nil
+	}
+}
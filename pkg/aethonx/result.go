@@ -0,0 +1,221 @@
+// pkg/aethonx/result.go
+package aethonx
+
+import (
+	"time"
+
+	"aethonx/internal/core/domain"
+)
+
+// ArtifactType classifies an artifact. The values are those of the "type"
+// field of the aethonx JSON output.
+type ArtifactType string
+
+// Common artifact types. Scans may report others (see the aethonx JSON
+// documentation); compare against ArtifactType values, not only these.
+const (
+	TypeDomain       ArtifactType = ArtifactType(domain.ArtifactTypeDomain)
+	TypeSubdomain    ArtifactType = ArtifactType(domain.ArtifactTypeSubdomain)
+	TypeIP           ArtifactType = ArtifactType(domain.ArtifactTypeIP)
+	TypeIPv6         ArtifactType = ArtifactType(domain.ArtifactTypeIPv6)
+	TypeCIDR         ArtifactType = ArtifactType(domain.ArtifactTypeCIDR)
+	TypeASN          ArtifactType = ArtifactType(domain.ArtifactTypeASN)
+	TypePort         ArtifactType = ArtifactType(domain.ArtifactTypePort)
+	TypeService      ArtifactType = ArtifactType(domain.ArtifactTypeService)
+	TypeNameserver   ArtifactType = ArtifactType(domain.ArtifactTypeNameserver)
+	TypeMXRecord     ArtifactType = ArtifactType(domain.ArtifactTypeMXRecord)
+	TypeTXTRecord    ArtifactType = ArtifactType(domain.ArtifactTypeTXTRecord)
+	TypeURL          ArtifactType = ArtifactType(domain.ArtifactTypeURL)
+	TypeEndpoint     ArtifactType = ArtifactType(domain.ArtifactTypeEndpoint)
+	TypeTechnology   ArtifactType = ArtifactType(domain.ArtifactTypeTechnology)
+	TypeCertificate  ArtifactType = ArtifactType(domain.ArtifactTypeCertificate)
+	TypeEmail        ArtifactType = ArtifactType(domain.ArtifactTypeEmail)
+	TypeWhoisContact ArtifactType = ArtifactType(domain.ArtifactTypeWhoisContact)
+)
+
+// Result is the consolidated outcome of a scan.
+type Result struct {
+	ID        string
+	Target    string
+	Active    bool
+	StartTime time.Time
+	EndTime   time.Time
+
+	// Sources lists the sources that ran
+	Sources []string
+
+	// Artifacts are deduplicated across sources
+	Artifacts []Artifact
+
+	// Warnings and Errors are per-source problems that did not stop the scan
+	Warnings []Issue
+	Errors   []Issue
+
+	byID map[string]int
+}
+
+// Artifact is a discovered asset.
+type Artifact struct {
+	ID           string
+	Type         ArtifactType
+	Value        string
+	Sources      []string
+	Tags         []string
+	Confidence   float64
+	DiscoveredAt time.Time
+	Relations    []Relation
+}
+
+// Relation is a directed link from an artifact to another one (TargetID).
+type Relation struct {
+	Type       string
+	TargetID   string
+	Confidence float64
+	Source     string
+}
+
+// Issue is a warning or error reported by a source.
+type Issue struct {
+	Source  string
+	Message string
+}
+
+// Duration is the wall time of the scan.
+func (r *Result) Duration() time.Duration {
+	return r.EndTime.Sub(r.StartTime)
+}
+
+// Each calls fn for every artifact in order until fn returns false.
+func (r *Result) Each(fn func(Artifact) bool) {
+	for _, a := range r.Artifacts {
+		if !fn(a) {
+			return
+		}
+	}
+}
+
+// ByType returns the artifacts of the given types, in order.
+func (r *Result) ByType(types ...ArtifactType) []Artifact {
+	want := make(map[ArtifactType]bool, len(types))
+	for _, t := range types {
+		want[t] = true
+	}
+	var out []Artifact
+	for _, a := range r.Artifacts {
+		if want[a.Type] {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// Values returns the values of the artifacts of type t, in order.
+func (r *Result) Values(t ArtifactType) []string {
+	var out []string
+	for _, a := range r.Artifacts {
+		if a.Type == t {
+			out = append(out, a.Value)
+		}
+	}
+	return out
+}
+
+// Find returns the artifact of type t with the given value.
+func (r *Result) Find(t ArtifactType, value string) (Artifact, bool) {
+	for _, a := range r.Artifacts {
+		if a.Type == t && a.Value == value {
+			return a, true
+		}
+	}
+	return Artifact{}, false
+}
+
+// Get returns the artifact with the given ID.
+func (r *Result) Get(id string) (Artifact, bool) {
+	if r.byID == nil {
+		for _, a := range r.Artifacts {
+			if a.ID == id {
+				return a, true
+			}
+		}
+		return Artifact{}, false
+	}
+	if i, ok := r.byID[id]; ok {
+		return r.Artifacts[i], true
+	}
+	return Artifact{}, false
+}
+
+// Related returns the artifacts a links to, optionally only through the
+// given relation types ("resolves_to", "uses_cert", ...). Relations to
+// artifacts outside the result are skipped.
+func (r *Result) Related(a Artifact, relationTypes ...string) []Artifact {
+	want := make(map[string]bool, len(relationTypes))
+	for _, t := range relationTypes {
+		want[t] = true
+	}
+	var out []Artifact
+	for _, rel := range a.Relations {
+		if len(want) > 0 && !want[rel.Type] {
+			continue
+		}
+		if target, ok := r.Get(rel.TargetID); ok {
+			out = append(out, target)
+		}
+	}
+	return out
+}
+
+// Counts returns the number of artifacts per type.
+func (r *Result) Counts() map[ArtifactType]int {
+	counts := make(map[ArtifactType]int)
+	for _, a := range r.Artifacts {
+		counts[a.Type]++
+	}
+	return counts
+}
+
+// newResult converts the internal scan result.
+func newResult(sr *domain.ScanResult) *Result {
+	r := &Result{
+		ID:        sr.ID,
+		Target:    sr.Target.Root,
+		Active:    sr.Target.Mode != domain.ScanModePassive,
+		StartTime: sr.Metadata.StartTime,
+		EndTime:   sr.Metadata.EndTime,
+		Sources:   append([]string(nil), sr.Metadata.SourcesUsed...),
+		Artifacts: make([]Artifact, 0, len(sr.Artifacts)),
+		byID:      make(map[string]int, len(sr.Artifacts)),
+	}
+	for _, a := range sr.Artifacts {
+		if a == nil {
+			continue
+		}
+		artifact := Artifact{
+			ID:           a.ID,
+			Type:         ArtifactType(a.Type),
+			Value:        a.Value,
+			Sources:      append([]string(nil), a.Sources...),
+			Tags:         append([]string(nil), a.Tags...),
+			Confidence:   a.Confidence,
+			DiscoveredAt: a.DiscoveredAt,
+		}
+		for _, rel := range a.Relations {
+			artifact.Relations = append(artifact.Relations, Relation{
+				Type:       string(rel.Type),
+				TargetID:   rel.TargetID,
+				Confidence: rel.Confidence,
+				Source:     rel.Source,
+			})
+		}
+		r.byID[a.ID] = len(r.Artifacts)
+		r.Artifacts = append(r.Artifacts, artifact)
+	}
+	for _, w := range sr.Warnings {
+		r.Warnings = append(r.Warnings, Issue{Source: w.Source, Message: w.Message})
+	}
+	for _, e := range sr.Errors {
+		r.Errors = append(r.Errors, Issue{Source: e.Source, Message: e.Message})
+	}
+	return r
+}
@@ -0,0 +1,274 @@
+// pkg/aethonx/scanner.go
+package aethonx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"aethonx/internal/adapters/output"
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/core/usecases"
+	"aethonx/internal/platform/config"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+	"aethonx/internal/platform/resilience"
+	"aethonx/internal/platform/ui"
+	"aethonx/internal/sources/previousscan"
+
+	// Sources register themselves on import, as in the aethonx binary
+	_ "aethonx/internal/sources/amass"
+	_ "aethonx/internal/sources/asnexpand"
+	_ "aethonx/internal/sources/axfr"
+	_ "aethonx/internal/sources/crtsh"
+	_ "aethonx/internal/sources/disclosure"
+	_ "aethonx/internal/sources/dns"
+	_ "aethonx/internal/sources/httpx"
+	_ "aethonx/internal/sources/katana"
+	_ "aethonx/internal/sources/masscan"
+	_ "aethonx/internal/sources/nmapimport"
+	_ "aethonx/internal/sources/pdns"
+	_ "aethonx/internal/sources/rdap"
+	_ "aethonx/internal/sources/reversewhois"
+	_ "aethonx/internal/sources/robots"
+	_ "aethonx/internal/sources/screenshotimport"
+	_ "aethonx/internal/sources/shodan"
+	_ "aethonx/internal/sources/subfinder"
+	_ "aethonx/internal/sources/tlsgrade"
+	_ "aethonx/internal/sources/waybackurls"
+)
+
+func init() {
+	// previousscan reads saved scans through the output adapter, as in the
+	// aethonx binary
+	previousscan.SetLoader(output.ReadScanTypes)
+}
+
+// ErrClosed is returned by Scan after Close.
+var ErrClosed = errors.New("aethonx: scanner is closed")
+
+// Scanner runs the AethonX pipeline. Build it with NewScanner and release it
+// with Close. Scan calls on one Scanner are serialized; use one Scanner per
+// concurrent scan.
+type Scanner struct {
+	settings settings
+	cfg      config.Config
+	logger   logx.Logger
+	sources  []ports.Source
+	metadata map[string]ports.SourceMetadata
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Sources returns the names of the sources available in this build.
+func Sources() []string {
+	names := registry.Global().List()
+	sort.Strings(names)
+	return names
+}
+
+// NewScanner builds a Scanner from the options. It fails on invalid options
+// and unknown source names; sources that cannot run (missing binaries or API
+// keys) are reported in Result.Errors by Scan.
+func NewScanner(opts ...Option) (*Scanner, error) {
+	var s settings
+	for _, opt := range opts {
+		if err := opt(&s); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg := config.DefaultConfig()
+	if s.configFile != "" {
+		loaded, err := config.FromFile(s.configFile)
+		if err != nil {
+			return nil, fmt.Errorf("aethonx: %w", err)
+		}
+		cfg = loaded
+	}
+
+	metadata := registry.Global().GetAllMetadata()
+	if err := applySources(&cfg, s, metadata); err != nil {
+		return nil, err
+	}
+	cfg.Core.Active = cfg.Core.Active || s.active
+	if s.workers > 0 {
+		cfg.Core.Workers = s.workers
+	}
+	for name, sc := range cfg.Source.Sources {
+		custom := make(map[string]interface{}, len(sc.Custom)+1)
+		for k, v := range sc.Custom {
+			custom[k] = v
+		}
+		custom["active_mode"] = cfg.Core.Active
+		sc.Custom = custom
+		cfg.Source.Sources[name] = sc
+	}
+
+	logger := logx.NewSilent()
+	if s.logOutput != nil {
+		logger = logx.NewText(s.logOutput, logx.LevelInfo)
+	}
+
+	sources, err := registry.Global().Build(cfg.Source.Sources, logger)
+	if err != nil {
+		return nil, fmt.Errorf("aethonx: failed to build sources: %w", err)
+	}
+	if len(sources) == 0 {
+		return nil, errors.New("aethonx: no sources enabled")
+	}
+	if cfg.Resilience.CircuitBreakerEnabled && !s.noResilience {
+		for i, src := range sources {
+			cb := resilience.NewCircuitBreaker(
+				cfg.Resilience.CircuitBreakerThreshold,
+				cfg.Resilience.CircuitBreakerTimeout,
+				cfg.Resilience.CircuitBreakerHalfOpenMax,
+			)
+			sources[i] = resilience.NewRetryableSource(src, cfg.Resilience.MaxRetries,
+				cfg.Resilience.BackoffBase, cfg.Resilience.BackoffMultiplier, cb, logger)
+		}
+	}
+
+	return &Scanner{
+		settings: s,
+		cfg:      cfg,
+		logger:   logger,
+		sources:  sources,
+		metadata: metadata,
+	}, nil
+}
+
+// applySources restricts cfg to the sources of WithSources and merges the
+// WithSourceOption values.
+func applySources(cfg *config.Config, s settings, metadata map[string]ports.SourceMetadata) error {
+	if cfg.Source.Sources == nil {
+		cfg.Source.Sources = make(map[string]ports.SourceConfig)
+	}
+	check := func(name string) error {
+		if _, ok := metadata[name]; !ok {
+			return fmt.Errorf("aethonx: unknown source %q (available: %v)", name, Sources())
+		}
+		return nil
+	}
+	sourceConfig := func(name string) ports.SourceConfig {
+		if sc, ok := cfg.Source.Sources[name]; ok {
+			return sc
+		}
+		return ports.DefaultSourceConfig()
+	}
+
+	if len(s.sources) > 0 {
+		for name, sc := range cfg.Source.Sources {
+			sc.Enabled = false
+			cfg.Source.Sources[name] = sc
+		}
+		for _, name := range s.sources {
+			if err := check(name); err != nil {
+				return err
+			}
+			sc := sourceConfig(name)
+			sc.Enabled = true
+			cfg.Source.Sources[name] = sc
+		}
+	}
+
+	for name, values := range s.sourceOptions {
+		if err := check(name); err != nil {
+			return err
+		}
+		sc := sourceConfig(name)
+		custom := make(map[string]interface{}, len(sc.Custom)+len(values))
+		for k, v := range sc.Custom {
+			custom[k] = v
+		}
+		for k, v := range values {
+			custom[k] = v
+		}
+		sc.Custom = custom
+		cfg.Source.Sources[name] = sc
+	}
+	return nil
+}
+
+// Scan runs the pipeline against target (a domain). On cancellation or
+// timeout it returns the partial result together with the error.
+func (s *Scanner) Scan(ctx context.Context, target string) (*Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, ErrClosed
+	}
+
+	mode := domain.ScanModePassive
+	if s.cfg.Core.Active {
+		mode = domain.ScanModeActive
+	}
+	t := domain.NewTarget(target, mode)
+	for _, excluded := range append(append([]string(nil), s.cfg.Core.ExcludeDomains...), s.settings.exclusions...) {
+		t.AddExclusion(excluded)
+	}
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("aethonx: %w", err)
+	}
+
+	if s.settings.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.settings.timeout)
+		defer cancel()
+	}
+
+	tagger, err := usecases.NewTaggingService(s.cfg.Tagging.Rules, s.logger)
+	if err != nil {
+		return nil, fmt.Errorf("aethonx: %w", err)
+	}
+	minConfidence := s.cfg.Output.MinRelationConfidence
+	if s.settings.minConfidence > 0 {
+		minConfidence = s.settings.minConfidence
+	}
+
+	orch := usecases.NewPipelineOrchestrator(usecases.PipelineOrchestratorOptions{
+		Sources:             s.sources,
+		SourceMetadata:      s.metadata,
+		Logger:              s.logger,
+		MaxWorkers:          max(1, s.cfg.Core.Workers),
+		ProviderConcurrency: s.cfg.Core.ProviderConcurrency,
+		SkipStages:          s.cfg.Core.SkipStages,
+		CriticalSources:     s.cfg.CriticalSources(),
+		CriticalStages:      s.cfg.Core.CriticalStages,
+		Presenter:           ui.NewNopPresenter(),
+		Tagger:              tagger,
+		Noise: usecases.NewNoiseService(usecases.NoiseOptions{
+			Suppress:    s.cfg.Noise.Suppress,
+			ExcludeApex: s.cfg.Noise.ExcludeApex,
+		}, s.logger),
+		MinRelationConfidence: minConfidence,
+	})
+
+	result, runErr := orch.Run(ctx, *t)
+	if result == nil {
+		return nil, runErr
+	}
+	return newResult(result), runErr
+}
+
+// Close releases the sources. Scan fails with ErrClosed afterwards.
+func (s *Scanner) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	var errs []error
+	for _, src := range s.sources {
+		if err := src.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", src.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,129 @@
+package aethonx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"aethonx/internal/core/domain"
+	"aethonx/internal/core/ports"
+	"aethonx/internal/platform/logx"
+	"aethonx/internal/platform/registry"
+	"aethonx/internal/testutil/sourcetest"
+)
+
+// registerMock registers a mock source once for the whole test binary.
+func registerMock(t *testing.T, name string, build func() *sourcetest.MockSource) {
+	t.Helper()
+	if _, ok := registry.Global().GetAllMetadata()[name]; ok {
+		return
+	}
+	err := registry.Global().Register(name, func(cfg ports.SourceConfig, logger logx.Logger) (ports.Source, error) {
+		return build(), nil
+	}, ports.SourceMetadata{
+		Name:            name,
+		Mode:            domain.SourceModePassive,
+		Type:            domain.SourceTypeAPI,
+		OutputArtifacts: []domain.ArtifactType{domain.ArtifactTypeSubdomain, domain.ArtifactTypeIP},
+	})
+	if err != nil {
+		t.Fatalf("register %s: %v", name, err)
+	}
+}
+
+func TestScanner_Scan(t *testing.T) {
+	registerMock(t, "sdk-mock", func() *sourcetest.MockSource {
+		www := domain.NewArtifact(domain.ArtifactTypeSubdomain, "www.example.com", "sdk-mock")
+		ip := domain.NewArtifact(domain.ArtifactTypeIP, "93.184.216.34", "sdk-mock")
+		www.AddRelation(ip.ID, domain.RelationType("resolves_to"), 1.0, "sdk-mock")
+		return sourcetest.NewMockSource("sdk-mock", www, ip,
+			domain.NewArtifact(domain.ArtifactTypeSubdomain, "api.example.com", "sdk-mock"))
+	})
+
+	scanner, err := NewScanner(WithSources("sdk-mock"), WithoutRetries())
+	if err != nil {
+		t.Fatalf("NewScanner: %v", err)
+	}
+	defer scanner.Close()
+
+	result, err := scanner.Scan(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	if result.Target != "example.com" || result.Active {
+		t.Errorf("unexpected target %q (active %t)", result.Target, result.Active)
+	}
+	if got := len(result.ByType(TypeSubdomain)); got != 2 {
+		t.Errorf("expected 2 subdomains, got %d", got)
+	}
+	if result.Counts()[TypeIP] != 1 {
+		t.Errorf("expected 1 ip, got %v", result.Counts())
+	}
+
+	www, ok := result.Find(TypeSubdomain, "www.example.com")
+	if !ok {
+		t.Fatal("www.example.com not found")
+	}
+	related := result.Related(www, "resolves_to")
+	if len(related) != 1 || related[0].Value != "93.184.216.34" {
+		t.Errorf("expected www to resolve to the ip, got %v", related)
+	}
+
+	seen := 0
+	result.Each(func(Artifact) bool {
+		seen++
+		return false
+	})
+	if seen != 1 {
+		t.Errorf("Each should stop when fn returns false, visited %d", seen)
+	}
+
+	if err := scanner.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := scanner.Scan(context.Background(), "example.com"); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed after Close, got %v", err)
+	}
+}
+
+func TestNewScanner_InvalidOptions(t *testing.T) {
+	cases := map[string]Option{
+		"unknown source":        WithSources("no-such-source"),
+		"unknown source option": WithSourceOption("no-such-source", "api_key", "x"),
+		"zero workers":          WithWorkers(0),
+		"negative timeout":      WithTimeout(-1),
+		"confidence above one":  WithMinConfidence(1.5),
+	}
+	for name, opt := range cases {
+		if _, err := NewScanner(opt); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestNewScanner_PreviousScan(t *testing.T) {
+	scanner, err := NewScanner(
+		WithSources("previousscan"),
+		WithSourceOption("previousscan", "files", []string{"scan.json"}),
+	)
+	if err != nil {
+		t.Fatalf("previousscan must be available as in the aethonx binary: %v", err)
+	}
+	scanner.Close()
+}
+
+func TestScanner_InvalidTarget(t *testing.T) {
+	registerMock(t, "sdk-mock-empty", func() *sourcetest.MockSource {
+		return sourcetest.NewMockSource("sdk-mock-empty")
+	})
+	scanner, err := NewScanner(WithSources("sdk-mock-empty"))
+	if err != nil {
+		t.Fatalf("NewScanner: %v", err)
+	}
+	defer scanner.Close()
+
+	if _, err := scanner.Scan(context.Background(), "not a domain"); err == nil {
+		t.Error("expected an invalid target error")
+	}
+}